@@ -1,18 +1,23 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"os/signal"
-	"path/filepath"
-	"sort"
 	"syscall"
 	"time"
 
+	"keerja-backend/database/migrator"
 	"keerja-backend/internal/cache"
 	"keerja-backend/internal/config"
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/domain/job"
 	"keerja-backend/internal/handler/http/admin"
 	applicationhandler "keerja-backend/internal/handler/http/application"
 	authhandler "keerja-backend/internal/handler/http/auth"
@@ -23,8 +28,10 @@ import (
 	userhandler "keerja-backend/internal/handler/http/jobseeker"
 	"keerja-backend/internal/handler/http/master"
 	notificationhandler "keerja-backend/internal/handler/http/notification"
+	uploadhandler "keerja-backend/internal/handler/http/upload"
 	"keerja-backend/internal/handler/websocket"
 	"keerja-backend/internal/jobs"
+	"keerja-backend/internal/metrics"
 	"keerja-backend/internal/middleware"
 	"keerja-backend/internal/repository/postgres"
 	"keerja-backend/internal/routes"
@@ -36,7 +43,8 @@ import (
 	_ "github.com/lib/pq"
 )
 
-// runMigrations executes all .up.sql files in database/migrations directory
+// runMigrations applies pending .up.sql files in database/migrations,
+// skipping versions already recorded in keerja_migrations
 func runMigrations() {
 	log.Println("Running database migrations...")
 
@@ -54,37 +62,20 @@ func runMigrations() {
 		log.Fatalf("Failed to ping database: %v", err)
 	}
 
-	entries, err := os.ReadDir(migrationsPath)
+	m, err := migrator.New(db, migrationsPath)
 	if err != nil {
-		log.Fatalf("Failed to read migrations directory: %v", err)
+		log.Fatalf("Failed to load migrations: %v", err)
 	}
 
-	var targets []string
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-		name := entry.Name()
-		if len(name) > 7 && name[len(name)-7:] == ".up.sql" {
-			targets = append(targets, filepath.Join(migrationsPath, name))
-		}
+	applied, err := m.Up(context.Background(), 0)
+	for _, mig := range applied {
+		log.Printf("Applied migration: %06d_%s", mig.Version, mig.Name)
 	}
-
-	sort.Strings(targets)
-
-	for _, target := range targets {
-		log.Printf("Applying migration: %s", filepath.Base(target))
-		content, err := os.ReadFile(target)
-		if err != nil {
-			log.Fatalf("Failed to read migration file %s: %v", target, err)
-		}
-
-		if _, err := db.Exec(string(content)); err != nil {
-			log.Fatalf("Failed to execute migration %s: %v", target, err)
-		}
+	if err != nil {
+		log.Fatalf("Failed to apply migrations: %v", err)
 	}
 
-	log.Printf("Successfully applied %d migrations", len(targets))
+	log.Printf("Successfully applied %d migrations", len(applied))
 }
 
 func main() {
@@ -137,10 +128,11 @@ func main() {
 	db := config.GetDB()
 	appLogger.Info(" Database connected successfully")
 
-	// Initialize Redis
+	// Initialize Redis. Unless REDIS_REQUIRED, a Redis outage only degrades the API:
+	// OAuth states and idempotency keys are kept in memory until Redis is back.
 	appLogger.Info("Initializing Redis connection...")
 	redisClient, err := config.InitRedis(cfg)
-	if err != nil {
+	if err != nil && cfg.RedisRequired {
 		appLogger.WithError(err).Fatal("Failed to initialize Redis")
 	}
 	defer func() {
@@ -149,6 +141,32 @@ func main() {
 		}
 	}()
 
+	redisCtx, stopRedisReconnect := context.WithCancel(context.Background())
+	defer stopRedisReconnect()
+
+	var kvStore cache.KeyValueStore
+	if cfg.RedisRequired {
+		kvStore = cache.NewRedisKVStore(redisClient)
+	} else {
+		var primary cache.KeyValueStore
+		if redisClient == nil {
+			appLogger.WithError(err).Warn("Redis unavailable, starting with the in-memory fallback")
+			// The client connects lazily, so the reconnect loop can pick Redis up later
+			if lazyClient, cerr := config.NewRedisClient(cfg); cerr == nil {
+				defer lazyClient.Close()
+				primary = cache.NewRedisKVStore(lazyClient)
+			}
+		} else {
+			primary = cache.NewRedisKVStore(redisClient)
+		}
+
+		fallback := cache.NewInMemoryKVStore(time.Minute)
+		defer fallback.Stop()
+		failover := cache.NewFailoverKVStore(primary, fallback, redisClient != nil)
+		go failover.Run(redisCtx, cfg.RedisReconnectInterval)
+		kvStore = failover
+	}
+
 	// Initialize validator
 	utils.InitValidator()
 
@@ -156,20 +174,43 @@ func main() {
 	appLogger.Info("Initializing repositories...")
 	userRepo := postgres.NewUserRepository(db)
 	emailRepo := postgres.NewEmailRepository(db)
+	emailQueueRepo := postgres.NewEmailQueueRepository(db)
+	jobRunRepo := postgres.NewJobRunRepository(db)
 	companyRepo := postgres.NewCompanyRepository(db)
+	companyQuotaRepo := postgres.NewCompanyQuotaRepository(db)
+	companyDigestRepo := postgres.NewCompanyDigestRepository(db)
+	companyDomainRepo := postgres.NewCompanyDomainRepository(db)
 	jobRepo := postgres.NewJobRepository(db)
+	matchScoreRepo := postgres.NewMatchScoreRepository(db)
 	applicationRepo := postgres.NewApplicationRepository(db)
 	skillsMasterRepo := postgres.NewSkillsMasterRepository(db)
 	oauthRepo := postgres.NewOAuthRepository(db)
 	otpCodeRepo := postgres.NewOTPCodeRepository(db)
 	refreshTokenRepo := postgres.NewRefreshTokenRepository(db)
+	accountPrivacyRepo := postgres.NewAccountPrivacyRepository(db)
 
 	// Admin repositories
 	adminUserRepo := postgres.NewAdminUserRepository(db)
+	adminCompanyRepo := postgres.NewAdminCompanyRepository(db)
 	adminRoleRepo := postgres.NewAdminRoleRepository(db)
+	adminAnalyticsRepo := postgres.NewAdminAnalyticsRepository(db)
+	impersonationRepo := postgres.NewImpersonationRepository(db)
 
 	// FCM Notification repository
 	deviceTokenRepo := postgres.NewDeviceTokenRepository(db)
+	pushCampaignRepo := postgres.NewPushCampaignRepository(db)
+	deferredPushRepo := postgres.NewDeferredPushRepository(db)
+	whatsAppDeliveryRepo := postgres.NewWhatsAppDeliveryRepository(db)
+
+	// In-app notification repository
+	notificationRepo := postgres.NewNotificationRepository(db)
+
+	// Audit log repository
+	auditLogRepo := postgres.NewAuditLogRepository(db)
+	apiKeyRepo := postgres.NewAPIKeyRepository(db)
+	webhookRepo := postgres.NewWebhookRepository(db)
+	companyTemplateRepo := postgres.NewCompanyTemplateRepository(db)
+	talentPoolRepo := postgres.NewTalentPoolRepository(db)
 
 	// Chat repositories
 	appLogger.Info("Initializing chat repositories...")
@@ -193,8 +234,14 @@ func main() {
 	appLogger.Info("Initializing services...")
 	tokenStore := service.NewInMemoryTokenStore()
 
-	// Initialize email service with config
-	emailService := service.NewEmailService(emailRepo, cfg)
+	// Initialize email service with config. Notification emails are queued and sent by the
+	// email queue job through the SMTP transport, which retries failed sends with backoff.
+	emailTransport := service.NewEmailService(emailRepo, cfg)
+	emailQueueService := service.NewEmailQueueService(emailQueueRepo, emailTransport, service.EmailQueueConfig{
+		MaxAttempts: cfg.EmailQueueMaxAttempts,
+		BatchSize:   cfg.EmailQueueBatchSize,
+	})
+	emailService := service.NewQueuedEmailService(emailTransport, emailQueueService)
 
 	// Initialize FCM service (Firebase Cloud Messaging)
 	appLogger.Info("Initializing Firebase Cloud Messaging (FCM)...")
@@ -204,18 +251,45 @@ func main() {
 	} else {
 		appLogger.Warn("FCM service disabled (set FCM_ENABLED=true to enable)")
 	}
+	pushTopicService := service.NewPushTopicService(deviceTokenRepo, userRepo, fcmService)
+	pushCampaignService := service.NewPushCampaignService(pushCampaignRepo, deviceTokenRepo, fcmService)
+
+	// WhatsApp provider (interview and offer notifications to verified phones; disabled when unset)
+	whatsAppProvider, err := service.NewWhatsAppProvider(service.WhatsAppConfig{
+		Provider:      cfg.WhatsAppProvider,
+		BaseURL:       cfg.WhatsAppAPIBaseURL,
+		Token:         cfg.WhatsAppToken,
+		PhoneNumberID: cfg.WhatsAppPhoneNumberID,
+		Language:      cfg.WhatsAppLanguage,
+		Templates:     cfg.WhatsAppTemplates,
+		Timeout:       time.Duration(cfg.WhatsAppTimeout) * time.Second,
+	})
+	if err != nil {
+		appLogger.WithError(err).Warn("WhatsApp provider unavailable, notifications go out by email and push only")
+	}
+	whatsAppDeliveryService := service.NewWhatsAppDeliveryService(whatsAppDeliveryRepo)
+
+	// Every notification email, push and WhatsApp message goes through the dispatcher, which
+	// applies the recipient's per-event channels and holds pushes back during their quiet hours
+	notificationDispatcher := service.NewNotificationDispatcher(userRepo, fcmService, deferredPushRepo, whatsAppProvider, whatsAppDeliveryRepo)
+
+	// In-app notifications are saved to the feed and also pushed through FCM
+	notificationService := service.NewNotificationService(notificationRepo, notificationDispatcher, emailService)
 
 	// Initialize upload service
-	uploadConfig := service.UploadServiceConfig{
-		StorageProvider: "local",
-		UploadPath:      "./uploads",
-		BaseURL:         "http://localhost:8080", // TODO: Get from config
+	uploadService, err := service.NewUploadServiceFromConfig(cfg)
+	if err != nil {
+		appLogger.WithError(err).Fatal("Failed to initialize S3 storage")
+	}
+	if cfg.StorageProvider == "s3" {
+		appLogger.Info(fmt.Sprintf("Upload storage: s3 (bucket: %s)", cfg.AWSBucket))
 	}
-	uploadService := service.NewUploadService(uploadConfig)
 
 	authServiceConfig := service.AuthServiceConfig{
 		JWTSecret:   cfg.JWTSecret,
 		JWTDuration: time.Duration(cfg.JWTExpirationHours) * time.Hour,
+
+		VerificationGracePeriod: time.Duration(cfg.EmailVerificationGraceDays) * 24 * time.Hour,
 	}
 
 	// Initialize cache
@@ -231,16 +305,13 @@ func main() {
 		appLogger.Info("Cache disabled")
 	}
 
-	// Create auth service with email service
-	authService := service.NewAuthService(userRepo, emailService, tokenStore, authServiceConfig)
-
 	// Create OAuth service
 	googleConfig := service.OAuthConfig{
 		ClientID:     cfg.GoogleClientID,
 		ClientSecret: cfg.GoogleClientSecret,
 		RedirectURI:  cfg.GoogleRedirectURI,
 	}
-	stateStore := service.NewRedisOAuthStateStore(redisClient)
+	stateStore := service.NewKVOAuthStateStore(kvStore)
 	oauthService := service.NewOAuthService(
 		oauthRepo,
 		userRepo,
@@ -251,28 +322,54 @@ func main() {
 		cfg.AllowedMobileRedirectURIs,
 	)
 
-	// Create registration service (for OTP-based registration)
-	registrationService := service.NewRegistrationService(
-		userRepo,
-		otpCodeRepo,
-		emailService,
-		cfg.JWTSecret,
-		time.Duration(cfg.JWTExpirationHours)*time.Hour,
-	)
-
 	// Create refresh token service (for remember me)
 	refreshTokenService := service.NewRefreshTokenService(
 		refreshTokenRepo,
+		userRepo,
 		cfg.JWTSecret,
 		time.Duration(cfg.JWTExpirationHours)*time.Hour,
 	)
 
-	userService := service.NewUserService(userRepo, uploadService, skillsMasterRepo)
+	userService := service.NewUserService(userRepo, uploadService, skillsMasterRepo, pushTopicService, cacheService, matchScoreRepo)
+
+	// Audit service (writes entries in the background so audited actions never wait on it)
+	auditService := service.NewAuditService(auditLogRepo, cfg.AuditBufferSize)
+	defer auditService.Close()
+
+	// Account deletion & personal data export (job seekers)
+	accountPrivacyService := service.NewAccountPrivacyService(
+		accountPrivacyRepo,
+		userRepo,
+		applicationRepo,
+		companyRepo,
+		uploadService,
+		emailService,
+		auditService,
+		service.AccountPrivacyConfig{
+			DeletionGracePeriod: time.Duration(cfg.AccountDeletionGraceDays) * 24 * time.Hour,
+			ExportTTL:           time.Duration(cfg.DataExportLinkHours) * time.Hour,
+		},
+	)
+
+	// Company API keys (ATS integrations)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, auditService)
+
+	// Company webhooks (events pushed to ATS integrations)
+	webhookService := service.NewWebhookService(webhookRepo, companyRepo, userRepo, emailService, auditService, service.WebhookConfig{
+		Timeout:          time.Duration(cfg.WebhookTimeout) * time.Second,
+		AllowPrivateURLs: cfg.WebhookAllowPrivateURLs,
+	})
+
+	// Company versions of candidate emails
+	companyTemplateService := service.NewCompanyTemplateService(companyTemplateRepo, companyRepo)
 
 	// Admin services
 	appLogger.Info("Initializing admin services...")
 	adminAuthService := service.NewAdminAuthService(adminUserRepo, adminRoleRepo, cfg)
-	adminCompanyService := service.NewAdminCompanyService(companyRepo, jobRepo, emailService, cacheService)
+	adminCompanyService := service.NewAdminCompanyService(companyRepo, adminCompanyRepo, jobRepo, emailService, cacheService, auditService)
+	adminAnalyticsService := service.NewAdminAnalyticsService(adminAnalyticsRepo, cacheService)
+	adminPermissionService := service.NewAdminPermissionService(adminUserRepo, adminRoleRepo, auditService, cacheService)
+	impersonationService := service.NewImpersonationService(impersonationRepo, userRepo, auditService, cfg.JWTSecret, cfg.ImpersonationTTL)
 	appLogger.Info("✓ Admin services initialized")
 
 	// Master data services
@@ -288,6 +385,25 @@ func main() {
 	jobOptionsService := service.NewJobOptionsService(jobOptionsRepo, cacheService)
 	appLogger.Info("✓ Master data services initialized")
 
+	// Geocoder (fills company address coordinates; disabled unless GEOCODER_PROVIDER is set)
+	geocoder, err := service.NewGeocoder(service.GeocoderConfig{
+		Provider: cfg.GeocoderProvider,
+		BaseURL:  cfg.GeocoderBaseURL,
+		APIKey:   cfg.GoogleMapsAPIKey,
+		Timeout:  time.Duration(cfg.GeocoderTimeout) * time.Second,
+	})
+	if err != nil {
+		appLogger.WithError(err).Warn("Geocoder disabled")
+		geocoder = service.NewNoopGeocoder()
+	}
+
+	// SMS provider (sends phone verification codes; "log" only logs them)
+	smsProvider, err := service.NewSMSProvider(service.SMSConfig{Provider: cfg.SMSProvider})
+	if err != nil {
+		appLogger.WithError(err).Warn("SMS provider unavailable, logging messages instead")
+		smsProvider = service.NewLogSMSProvider()
+	}
+
 	// Company service
 	companyService := service.NewCompanyService(
 		companyRepo,
@@ -298,24 +414,83 @@ func main() {
 		companySizeService,
 		districtService,
 		jobRepo,
+		applicationRepo,
 		userService,
 		userRepo,
+		emailService,
+		auditService,
+		geocoder,
+		notificationService,
+		notificationDispatcher,
+	)
+	// Domain claims are checked by email code or by a TXT record looked up on the system resolver
+	companyDomainService := service.NewCompanyDomainService(companyRepo, companyDomainRepo, userRepo, emailService, net.DefaultResolver, cacheService)
+
+	// Create auth and registration services; signups with an invitation token join the
+	// inviting company through the company service
+	authService := service.NewAuthService(userRepo, emailService, tokenStore, authServiceConfig, companyService)
+	registrationService := service.NewRegistrationService(
+		userRepo,
+		otpCodeRepo,
+		emailService,
+		cfg.JWTSecret,
+		time.Duration(cfg.JWTExpirationHours)*time.Hour,
+		companyService,
+		smsProvider,
+		cacheService,
 	)
 
+	followerNotifier := service.NewFollowerNotifier(jobRepo, companyRepo, userRepo, emailService, notificationDispatcher)
+	verificationExpiryService := service.NewVerificationExpiryService(companyRepo, userRepo, emailService, notificationDispatcher, time.Duration(cfg.VerificationGraceDays)*24*time.Hour)
+
+	jobExpiryPolicy := job.ExpiryPolicy{
+		DefaultPeriod:     time.Duration(cfg.JobDefaultExpiryDays) * 24 * time.Hour,
+		MaxAutoExtensions: cfg.JobMaxAutoExtensions,
+	}
+
+	// Published job limits per company plan; admins can override the configured limits
+	jobQuotaService := service.NewCompanyQuotaService(companyQuotaRepo, companyRepo, company.QuotaLimits{
+		company.PlanFree:     cfg.JobQuotaFree,
+		company.PlanVerified: cfg.JobQuotaVerified,
+		company.PlanPremium:  cfg.JobQuotaPremium,
+	}, auditService)
+
+	// Anonymous job viewers are deduplicated by a keyed hash of their IP
+	jobViewHashSecret := cfg.JobViewHashSecret
+	if jobViewHashSecret == "" {
+		jobViewHashSecret = cfg.JWTSecret
+	}
 	jobService := service.NewJobService(
 		jobRepo,
 		companyRepo,
 		userRepo,
 		jobOptionsRepo,
 		jobTitleRepo,
+		skillsMasterRepo,
 		industryService,
 		districtService,
+		followerNotifier,
+		emailService,
+		notificationService,
+		webhookService,
+		jobExpiryPolicy,
+		matchScoreRepo,
+		cacheService,
+		jobQuotaService,
+		jobViewHashSecret,
 	)
 
 	// Admin job service (orchestrates admin operations on jobs)
-	adminJobService := service.NewAdminJobService(jobRepo)
+	adminJobService := service.NewAdminJobService(jobRepo, companyRepo, userRepo, emailService, notificationDispatcher, notificationService, followerNotifier, webhookService, auditService, jobExpiryPolicy, cacheService, jobQuotaService)
+
+	reapplyPolicy := application.ReapplyPolicy{
+		WithdrawnCooldown: time.Duration(cfg.ReapplyAfterWithdrawDays) * 24 * time.Hour,
+		RejectedCooldown:  time.Duration(cfg.ReapplyAfterRejectDays) * 24 * time.Hour,
+	}
+	applicationService := service.NewApplicationService(applicationRepo, jobRepo, userRepo, companyRepo, emailService, notificationService, webhookService, reapplyPolicy, uploadService, cfg.ApplicationDocumentURLs, notificationDispatcher, companyTemplateService)
 
-	applicationService := service.NewApplicationService(applicationRepo, jobRepo, userRepo, companyRepo, emailService, nil) // notificationService disabled temporarily
+	// Talent pools of candidates for future roles, matched against jobs with the job scorer
+	talentPoolService := service.NewTalentPoolService(talentPoolRepo, applicationRepo, jobRepo, jobService, userRepo)
 	skillsMasterService := service.NewSkillsMasterService(skillsMasterRepo)
 
 	// Initialize WebSocket hub
@@ -333,14 +508,22 @@ func main() {
 	appLogger.Info("Initializing handlers...")
 	authHandler := authhandler.NewAuthHandler(authService, oauthService, registrationService, refreshTokenService, userRepo, companyRepo)
 
+	// Private documents are handed out as signed, expiring download links
+	downloadSecret := cfg.DownloadURLSecret
+	if downloadSecret == "" {
+		downloadSecret = cfg.JWTSecret
+	}
+	downloadSigner := service.NewDownloadSigner(downloadSecret, cfg.DownloadURLTTL)
+
 	// Initialize user handlers (split by domain)
 	appLogger.Info("Initializing user handlers...")
-	userProfileHandler := userhandler.NewUserProfileHandler(userService)
+	userProfileHandler := userhandler.NewUserProfileHandler(userService, authService.VerificationPolicy())
 	userEducationHandler := userhandler.NewUserEducationHandler(userService)
 	userExperienceHandler := userhandler.NewUserExperienceHandler(userService)
 	userSkillHandler := userhandler.NewUserSkillHandler(userService)
-	userDocumentHandler := userhandler.NewUserDocumentHandler(userService)
+	userDocumentHandler := userhandler.NewUserDocumentHandler(userService, downloadSigner)
 	userMiscHandler := userhandler.NewUserMiscHandler(userService)
+	accountPrivacyHandler := userhandler.NewAccountPrivacyHandler(accountPrivacyService)
 
 	// Initialize company handlers (split by domain)
 	appLogger.Info("Initializing company handlers...")
@@ -370,18 +553,44 @@ func main() {
 	companyProfileHandler := companyhandler.NewCompanyProfileHandler(companyService)
 	companyReviewHandler := companyhandler.NewCompanyReviewHandler(companyService)
 	companyStatsHandler := companyhandler.NewCompanyStatsHandler(companyService)
-	companyInviteHandler := companyhandler.NewCompanyInviteHandler(companyService, emailService, userService)
+	companyInviteHandler := companyhandler.NewCompanyInviteHandler(companyService, companyDomainService)
+	companyDomainHandler := companyhandler.NewCompanyDomainHandler(companyDomainService)
+	companyEmployeeHandler := companyhandler.NewCompanyEmployeeHandler(companyService)
+	companyAPIKeyHandler := companyhandler.NewCompanyAPIKeyHandler(apiKeyService)
+	companyWebhookHandler := companyhandler.NewCompanyWebhookHandler(webhookService)
+	companyEmailTemplateHandler := companyhandler.NewCompanyEmailTemplateHandler(companyTemplateService)
+	companyTalentPoolHandler := companyhandler.NewCompanyTalentPoolHandler(talentPoolService)
+
+	companyDocumentHandler := companyhandler.NewCompanyDocumentHandler(companyService, downloadSigner)
+	downloadHandler := uploadhandler.NewDownloadHandler(downloadSigner, uploadService)
 
 	// Initialize job & application handlers
 	appLogger.Info("Initializing job & application handlers...")
-	jobHandler := jobhandler.NewJobHandler(jobService, companyService, jobOptionsService, skillsMasterService)
-	applicationHandler := applicationhandler.NewApplicationHandler(applicationService)
+	jobHandler := jobhandler.NewJobHandler(jobService, companyService, jobOptionsService, skillsMasterService, jobQuotaService)
+	jobFeedHandler := jobhandler.NewJobFeedHandler(service.NewJobFeedService(jobRepo, companyRepo, cacheService, cfg.FrontendURL))
+	jobCompareHandler := jobhandler.NewJobCompareHandler(service.NewJobComparisonService(jobService, companyService, userService, applicationRepo, geocoder))
+	applicationHandler := applicationhandler.NewApplicationHandler(applicationService, downloadSigner)
 
 	// Initialize admin handlers
 	appLogger.Info("Initializing admin handlers...")
 	adminJobHandler := admin.NewAdminJobHandler(adminJobService)
+	adminJobQuotaHandler := admin.NewAdminJobQuotaHandler(jobQuotaService)
 	adminAuthHandler := admin.NewAdminAuthHandler(adminAuthService)
-	adminCompanyHandler := admin.NewCompanyHandler(adminCompanyService)
+	adminCompanyHandler := admin.NewCompanyHandler(adminCompanyService, downloadSigner)
+	adminReviewHandler := admin.NewAdminReviewHandler(companyService)
+	adminChangeRequestHandler := admin.NewAdminChangeRequestHandler(companyService)
+	adminPushHandler := admin.NewAdminPushHandler(pushCampaignService)
+	adminAuditHandler := admin.NewAdminAuditHandler(auditService)
+	adminEmailQueueHandler := admin.NewAdminEmailQueueHandler(emailQueueService)
+	adminWhatsAppHandler := admin.NewAdminWhatsAppHandler(whatsAppDeliveryService)
+	adminAnalyticsHandler := admin.NewAdminAnalyticsHandler(adminAnalyticsService)
+
+	// The scheduler is created here so admins can manage it; jobs are registered after the routes
+	scheduler := jobs.NewScheduler(jobRunRepo)
+	adminSchedulerHandler := admin.NewAdminSchedulerHandler(scheduler)
+	adminRoleHandler := admin.NewAdminRoleHandler(adminPermissionService)
+	adminImpersonationHandler := admin.NewAdminImpersonationHandler(impersonationService)
+	adminWebhookHandler := admin.NewAdminWebhookHandler(webhookService)
 
 	// Initialize admin master data services
 	appLogger.Info("Initializing admin master data services...")
@@ -391,6 +600,7 @@ func main() {
 	adminCityService := service.NewAdminCityService(cityService, cityRepo, db, cacheService)
 	adminDistrictService := service.NewAdminDistrictService(districtService, districtRepo, db, cacheService)
 	adminJobTypeService := service.NewAdminJobTypeService(jobOptionsService, jobOptionsRepo, db, cacheService)
+	adminMasterDataTransferService := service.NewAdminMasterDataTransferService(db, cacheService)
 	appLogger.Info("✓ Admin master data services initialized")
 
 	// Initialize admin master data handler
@@ -401,6 +611,9 @@ func main() {
 		adminIndustryService,
 		adminCompanySizeService,
 		adminJobTypeService,
+		skillsMasterService,
+		adminMasterDataTransferService,
+		auditService,
 	)
 
 	// Initialize master data handlers
@@ -424,8 +637,9 @@ func main() {
 
 	// Initialize FCM notification handlers
 	appLogger.Info("Initializing FCM notification handlers...")
-	deviceTokenHandler := notificationhandler.NewDeviceTokenHandler(deviceTokenRepo, fcmService, appLogger)
+	deviceTokenHandler := notificationhandler.NewDeviceTokenHandler(deviceTokenRepo, fcmService, pushTopicService, appLogger)
 	pushNotificationHandler := notificationhandler.NewPushNotificationHandler(fcmService, appLogger)
+	notificationHandler := notificationhandler.NewNotificationHandler(notificationService)
 	appLogger.Info("FCM handlers initialized successfully")
 
 	// Initialize chat handlers
@@ -436,16 +650,39 @@ func main() {
 
 	// Initialize health check handler
 	appLogger.Info("Initializing health check handler...")
-	healthHandler := health.NewHealthHandler(db, redisClient, cfg.AppVersion)
+	healthHandler := health.NewHealthHandler(db, kvStore, cfg.AppVersion, cfg.GitSHA)
+	healthHandler.AddCheck("fcm", health.FCMCheck(cfg.FCMEnabled))
+	healthHandler.AddCheck("upload_storage", health.StorageCheck(uploadService))
 	appLogger.Info("✓ Health check handler initialized")
 
+	// Initialize metrics. The cache, scheduler and push service record to the default
+	// metrics, which stay a no-op when METRICS_ENABLED is false.
+	var appMetrics *metrics.Metrics
+	if cfg.MetricsEnabled {
+		appMetrics = metrics.New()
+		metrics.SetDefault(appMetrics)
+		if sqlDB, err := db.DB(); err != nil {
+			appLogger.WithError(err).Warn("Database pool metrics unavailable")
+		} else if err := appMetrics.RegisterDB(sqlDB, cfg.DBName); err != nil {
+			appLogger.WithError(err).Warn("Failed to register database pool metrics")
+		}
+		if err := appMetrics.RegisterEmailQueueDepth(func(ctx context.Context) (int64, error) {
+			_, pending, err := emailQueueRepo.ListByStatus(ctx, email.QueueStatusPending, 1, 1)
+			return pending, err
+		}); err != nil {
+			appLogger.WithError(err).Warn("Failed to register email queue metrics")
+		}
+		appLogger.Info("✓ Metrics initialized")
+	}
+
 	// Setup Fiber app
 	app := fiber.New(fiber.Config{
 		AppName:               cfg.AppName,
 		ServerHeader:          "Keerja",
 		StrictRouting:         false,
 		CaseSensitive:         false,
-		ErrorHandler:          nil, // Will be set by middleware
+		ErrorHandler:          nil,                                         // Will be set by middleware
+		BodyLimit:             (cfg.UploadMaxDocumentMB + 5) * 1024 * 1024, // Room for document uploads plus their form fields
 		DisableStartupMessage: false,
 	})
 
@@ -455,6 +692,16 @@ func main() {
 	// 1. Panic recovery (must be first)
 	app.Use(middleware.RecoverPanic(cfg.AppEnv == "development"))
 
+	// 1a. Request metrics, then the metrics endpoint itself when it shares the API port.
+	// It is registered before logging, rate limiting and auth so scrapes skip them.
+	app.Use(middleware.Metrics(appMetrics))
+	if appMetrics != nil && cfg.MetricsPort == "" {
+		app.Get(metrics.Path, appMetrics.Handler())
+	}
+
+	// 1b. Request ID, carried by the request's logs and SQL logs through the user context
+	app.Use(middleware.RequestID())
+
 	// 2. Request logging
 	if cfg.AppEnv == "development" {
 		app.Use(middleware.DetailedLogger())
@@ -469,12 +716,18 @@ func main() {
 	app.Use(middleware.SecurityHeaders())
 
 	// 5. Rate limiting
-	app.Use(middleware.RateLimiter(cfg))
+	app.Use(middleware.RateLimiter(cfg, kvStore))
 
 	// 6. Response compression (gzip for >5KB responses)
 	app.Use(middleware.ResponseCompression())
 
-	// 7. Error handler
+	// 7. Localization (Accept-Language)
+	app.Use(middleware.Localization())
+
+	// 8. Request deadline, cancels the context passed to services
+	app.Use(middleware.RequestTimeout(cfg.RequestTimeout))
+
+	// 9. Error handler
 	app.Use(middleware.ErrorHandler(cfg.AppEnv == "development"))
 
 	// Setup routes
@@ -482,8 +735,11 @@ func main() {
 
 	// Setup health check routes (before auth middleware)
 	routes.SetupHealthRoutes(app, healthHandler)
+	if cfg.StorageProvider == config.StorageLocal {
+		routes.SetupUploadRoutes(app, uploadhandler.NewFileHandler(cfg.UploadPath, uploadhandler.DefaultCacheMaxAge))
+	}
 
-	adminAuthMw := middleware.NewAdminAuthMiddleware(cfg, adminUserRepo)
+	adminAuthMw := middleware.NewAdminAuthMiddleware(cfg, adminUserRepo, adminPermissionService)
 	deps := &routes.Dependencies{
 		Config:      cfg,
 		AuthHandler: authHandler,
@@ -495,28 +751,53 @@ func main() {
 		UserSkillHandler:      userSkillHandler,
 		UserDocumentHandler:   userDocumentHandler,
 		UserMiscHandler:       userMiscHandler,
+		AccountPrivacyHandler: accountPrivacyHandler,
 
 		// Admin handlers
-		AdminAuthHandler:    adminAuthHandler,
-		AdminCompanyHandler: adminCompanyHandler,
-		AdminAuthMiddleware: adminAuthMw,
+		AdminAuthHandler:          adminAuthHandler,
+		AdminCompanyHandler:       adminCompanyHandler,
+		AdminReviewHandler:        adminReviewHandler,
+		AdminChangeRequestHandler: adminChangeRequestHandler,
+		AdminPushHandler:          adminPushHandler,
+		AdminAuditHandler:         adminAuditHandler,
+		AdminEmailQueueHandler:    adminEmailQueueHandler,
+		AdminWhatsAppHandler:      adminWhatsAppHandler,
+		AdminAnalyticsHandler:     adminAnalyticsHandler,
+		AdminSchedulerHandler:     adminSchedulerHandler,
+		AdminRoleHandler:          adminRoleHandler,
+		AdminWebhookHandler:       adminWebhookHandler,
+		AdminImpersonationHandler: adminImpersonationHandler,
+		AdminJobQuotaHandler:      adminJobQuotaHandler,
+		AdminAuthMiddleware:       adminAuthMw,
 
 		// Job & Application handlers
 		JobHandler:             jobHandler,
+		JobFeedHandler:         jobFeedHandler,
+		JobCompareHandler:      jobCompareHandler,
 		ApplicationHandler:     applicationHandler,
 		AdminJobHandler:        adminJobHandler,
 		AdminMasterDataHandler: adminMasterDataHandler,
 
 		// Company handlers (split by domain)
-		CompanyBasicHandler:        companyBasicHandler,
-		CompanyImageHandler:        companyImageHandler,
-		CompanyAddressHandler:      companyAddressHandler,
-		CompanyEmployerHandler:     companyEmployerHandler,
-		CompanyVerificationHandler: companyVerificationHandler,
-		CompanyProfileHandler:      companyProfileHandler,
-		CompanyReviewHandler:       companyReviewHandler,
-		CompanyStatsHandler:        companyStatsHandler,
-		CompanyInviteHandler:       companyInviteHandler,
+		CompanyBasicHandler:         companyBasicHandler,
+		CompanyImageHandler:         companyImageHandler,
+		CompanyAddressHandler:       companyAddressHandler,
+		CompanyEmployerHandler:      companyEmployerHandler,
+		CompanyVerificationHandler:  companyVerificationHandler,
+		CompanyProfileHandler:       companyProfileHandler,
+		CompanyReviewHandler:        companyReviewHandler,
+		CompanyStatsHandler:         companyStatsHandler,
+		CompanyInviteHandler:        companyInviteHandler,
+		CompanyDomainHandler:        companyDomainHandler,
+		CompanyEmployeeHandler:      companyEmployeeHandler,
+		CompanyAPIKeyHandler:        companyAPIKeyHandler,
+		CompanyWebhookHandler:       companyWebhookHandler,
+		CompanyEmailTemplateHandler: companyEmailTemplateHandler,
+		CompanyTalentPoolHandler:    companyTalentPoolHandler,
+		CompanyDocumentHandler:      companyDocumentHandler,
+
+		// Signed downloads of private documents
+		DownloadHandler: downloadHandler,
 
 		// Master data handlers
 		SkillsMasterHandler: skillsMasterHandler,
@@ -526,6 +807,7 @@ func main() {
 		// FCM Notification handlers
 		DeviceTokenHandler:      deviceTokenHandler,
 		PushNotificationHandler: pushNotificationHandler,
+		NotificationHandler:     notificationHandler,
 
 		// Chat handlers
 		ChatHandler:      chatHandler,
@@ -533,7 +815,11 @@ func main() {
 		WebSocketHandler: wsHandler,
 
 		// Services (for middlewares)
-		CompanyService: companyService,
+		CompanyService:       companyService,
+		APIKeyService:        apiKeyService,
+		SessionValidator:     refreshTokenService,
+		ImpersonationTracker: impersonationService,
+		IdempotencyStore:     middleware.NewKVIdempotencyStore(kvStore),
 	}
 	routes.SetupRoutes(app, deps)
 
@@ -545,18 +831,98 @@ func main() {
 	// ==========================================
 	appLogger.Info("Setting up background jobs...")
 
-	// Initialize scheduler
-	scheduler := jobs.NewScheduler()
-
 	// Register jobs
 	invitationExpiryJob := jobs.NewInvitationExpiryJob(companyService)
 	if err := scheduler.Register(invitationExpiryJob); err != nil {
 		appLogger.WithError(err).Fatal("Failed to register invitation expiry job")
 	}
 
+	stageReminderJob := jobs.NewStageReminderJob(applicationRepo, companyRepo, userRepo, emailService, appLogger, jobs.StageReminderConfig{})
+	if err := scheduler.Register(stageReminderJob); err != nil {
+		appLogger.WithError(err).Fatal("Failed to register stage reminder job")
+	}
+
+	weeklyDigestJob := jobs.NewWeeklyDigestJob(companyDigestRepo, companyRepo, userRepo, emailService, appLogger)
+	if err := scheduler.Register(weeklyDigestJob); err != nil {
+		appLogger.WithError(err).Fatal("Failed to register weekly digest job")
+	}
+
+	pushCampaignJob := jobs.NewPushCampaignJob(pushCampaignService, appLogger)
+	if err := scheduler.Register(pushCampaignJob); err != nil {
+		appLogger.WithError(err).Fatal("Failed to register push campaign job")
+	}
+
+	deferredPushJob := jobs.NewDeferredPushJob(notificationDispatcher, appLogger)
+	if err := scheduler.Register(deferredPushJob); err != nil {
+		appLogger.WithError(err).Fatal("Failed to register deferred push job")
+	}
+
+	emailQueueJob := jobs.NewEmailQueueJob(emailQueueService, appLogger)
+	if err := scheduler.Register(emailQueueJob); err != nil {
+		appLogger.WithError(err).Fatal("Failed to register email queue job")
+	}
+
+	webhookDeliveryJob := jobs.NewWebhookDeliveryJob(webhookService, appLogger)
+	if err := scheduler.Register(webhookDeliveryJob); err != nil {
+		appLogger.WithError(err).Fatal("Failed to register webhook delivery job")
+	}
+
+	verificationExpiryJob := jobs.NewVerificationExpiryJob(verificationExpiryService, appLogger)
+	if err := scheduler.Register(verificationExpiryJob); err != nil {
+		appLogger.WithError(err).Fatal("Failed to register verification expiry job")
+	}
+
+	jobExpiryJob := jobs.NewJobExpiryJob(jobService, appLogger)
+	if err := scheduler.Register(jobExpiryJob); err != nil {
+		appLogger.WithError(err).Fatal("Failed to register job expiry job")
+	}
+
+	ratingSummaryJob := jobs.NewRatingSummaryJob(companyService, appLogger)
+	if err := scheduler.Register(ratingSummaryJob); err != nil {
+		appLogger.WithError(err).Fatal("Failed to register rating summary job")
+	}
+
+	deviceTokenCleanupJob := jobs.NewDeviceTokenCleanupJob(deviceTokenRepo, appLogger, jobs.CleanupConfig{InactiveDays: cfg.DeviceTokenInactiveDays})
+	if err := scheduler.Register(deviceTokenCleanupJob); err != nil {
+		appLogger.WithError(err).Fatal("Failed to register device token cleanup job")
+	}
+
+	accountDeletionJob := jobs.NewAccountDeletionJob(accountPrivacyService, appLogger)
+	if err := scheduler.Register(accountDeletionJob); err != nil {
+		appLogger.WithError(err).Fatal("Failed to register account deletion job")
+	}
+
+	dataExportJob := jobs.NewDataExportJob(accountPrivacyService, appLogger)
+	if err := scheduler.Register(dataExportJob); err != nil {
+		appLogger.WithError(err).Fatal("Failed to register data export job")
+	}
+
+	matchScoreRefreshJob := jobs.NewMatchScoreRefreshJob(jobService, appLogger)
+	if err := scheduler.Register(matchScoreRefreshJob); err != nil {
+		appLogger.WithError(err).Fatal("Failed to register match score refresh job")
+	}
+
+	applicationBulkActionJob := jobs.NewApplicationBulkActionJob(applicationService, appLogger)
+	if err := scheduler.Register(applicationBulkActionJob); err != nil {
+		appLogger.WithError(err).Fatal("Failed to register application bulk action job")
+	}
+
+	jobViewCleanupJob := jobs.NewJobViewCleanupJob(jobRepo, appLogger, cfg.JobViewRetentionDays)
+	if err := scheduler.Register(jobViewCleanupJob); err != nil {
+		appLogger.WithError(err).Fatal("Failed to register job view cleanup job")
+	}
+
+	jobRunCleanupJob := jobs.NewJobRunCleanupJob(jobRunRepo, appLogger, jobs.DefaultJobRunRetentionDays)
+	if err := scheduler.Register(jobRunCleanupJob); err != nil {
+		appLogger.WithError(err).Fatal("Failed to register job run cleanup job")
+	}
+
 	// Start scheduler
 	scheduler.Start()
 
+	// The push campaign job ticks every minute, so a few minutes of silence means the scheduler is stuck
+	healthHandler.AddCheck("scheduler", health.SchedulerCheck(scheduler, 5*time.Minute))
+
 	// Ensure scheduler stops on shutdown
 	defer scheduler.Stop()
 
@@ -575,6 +941,20 @@ func main() {
 		}
 	}()
 
+	// Serve metrics on their own port, which production keeps off the public network
+	var metricsApp *fiber.App
+	if appMetrics != nil && cfg.MetricsPort != "" {
+		metricsApp = fiber.New(fiber.Config{DisableStartupMessage: true})
+		metricsApp.Get(metrics.Path, appMetrics.Handler())
+		metricsAddr := fmt.Sprintf("%s:%s", cfg.ServerHost, cfg.MetricsPort)
+		go func() {
+			appLogger.Info(fmt.Sprintf("Metrics listening on %s%s", metricsAddr, metrics.Path))
+			if err := metricsApp.Listen(metricsAddr); err != nil {
+				appLogger.WithError(err).Error("Failed to start metrics server")
+			}
+		}()
+	}
+
 	// Wait for interrupt signal for graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -590,6 +970,11 @@ func main() {
 	if err := app.ShutdownWithTimeout(10 * time.Second); err != nil {
 		appLogger.WithError(err).Error("Server forced to shutdown")
 	}
+	if metricsApp != nil {
+		if err := metricsApp.ShutdownWithTimeout(5 * time.Second); err != nil {
+			appLogger.WithError(err).Warn("Metrics server forced to shutdown")
+		}
+	}
 
 	appLogger.Info("Server stopped successfully")
 }
@@ -1,25 +1,33 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"flag"
 	"fmt"
-	"io/ioutil"
 	"log"
-	"path/filepath"
-	"sort"
 
+	"keerja-backend/database/migrator"
 	"keerja-backend/internal/config"
 
 	_ "github.com/lib/pq"
 )
 
-// simple migrator: runs all .up.sql files (or .down.sql) in database/migrations
+// migrator CLI: applies .up.sql / .down.sql files in database/migrations and
+// records applied versions in the keerja_migrations table
 func main() {
 	direction := flag.String("dir", "up", "migration direction: up or down")
 	migrationsPath := flag.String("path", "database/migrations", "path to migrations directory")
+	steps := flag.Int("steps", 0, "number of migrations to apply or roll back (0 = all for up, 1 for down)")
+	status := flag.Bool("status", false, "print applied and pending migrations and exit")
+	baseline := flag.Bool("baseline", false, "record migrations as applied without executing them")
+	to := flag.Int64("to", -1, "migrate up or down to this version (0 rolls back everything)")
 	flag.Parse()
 
+	if flag.Arg(0) == "status" {
+		*status = true
+	}
+
 	cfg := config.LoadConfig()
 
 	// open DB using standard library (pq)
@@ -33,182 +41,94 @@ func main() {
 		log.Fatalf("failed to ping db: %v", err)
 	}
 
-	files, err := ioutil.ReadDir(*migrationsPath)
+	m, err := migrator.New(db, *migrationsPath)
 	if err != nil {
-		log.Fatalf("failed to read migrations dir: %v", err)
+		log.Fatalf("failed to load migrations: %v", err)
 	}
 
-	var targets []string
-	for _, f := range files {
-		if f.IsDir() {
-			continue
-		}
-		name := f.Name()
-		// Accept files that end with .up.sql or .down.sql depending on direction
-		if *direction == "up" && len(name) > 7 && name[len(name)-7:] == ".up.sql" {
-			targets = append(targets, filepath.Join(*migrationsPath, name))
-		}
-		if *direction == "down" && len(name) > 9 && name[len(name)-9:] == ".down.sql" {
-			targets = append(targets, filepath.Join(*migrationsPath, name))
-		}
-	}
-
-	if len(targets) == 0 {
-		log.Println("no migration files found for direction", *direction)
-		return
-	}
+	ctx := context.Background()
 
-	// sort files for up (ascending) and reverse for down
-	sort.Strings(targets)
-	if *direction == "down" {
-		// reverse order
-		for i := 0; i < len(targets)/2; i++ {
-			j := len(targets) - 1 - i
-			targets[i], targets[j] = targets[j], targets[i]
+	switch {
+	case *status:
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			log.Fatalf("failed to read migration status: %v", err)
 		}
-	}
+		printStatus(statuses)
 
-	for _, file := range targets {
-		log.Printf("applying %s\n", file)
-		content, err := ioutil.ReadFile(file)
+	case *baseline:
+		done, err := m.Baseline(ctx, *steps)
 		if err != nil {
-			log.Fatalf("failed to read file %s: %v", file, err)
+			log.Fatalf("baseline failed: %v", err)
 		}
-
-		// Execute individual statements so we can ignore "already exists" errors for idempotency
-		stmts := splitSQLStatements(string(content))
-		for _, s := range stmts {
-			if s == "" {
-				continue
-			}
-			if _, err := db.Exec(s); err != nil {
-				// If error indicates object already exists, log and continue
-				msg := err.Error()
-				if containsAlreadyExists(msg) {
-					log.Printf("warning: statement skipped (already exists): %v", msg)
-					continue
-				}
-				log.Fatalf("failed to execute statement in %s: %v\nstatement: %s", file, err, s)
-			}
+		for _, mig := range done {
+			log.Printf("baselined %06d_%s\n", mig.Version, mig.Name)
 		}
-	}
-
-	fmt.Printf("migrations %s completed (%d files)\n", *direction, len(targets))
-}
+		fmt.Printf("baseline completed (%d migrations)\n", len(done))
 
-// splitSQLStatements splits SQL by semicolon but respects single quotes, double quotes and dollar-quoted strings
-func splitSQLStatements(sqlText string) []string {
-	var stmts []string
-	var cur []rune
-	inSingle := false
-	inDouble := false
-	var dollarTag string
-
-	runes := []rune(sqlText)
-	for i := 0; i < len(runes); i++ {
-		r := runes[i]
-		// detect start of dollar-quote
-		if r == '$' && !inSingle && !inDouble {
-			// try to read tag
-			j := i + 1
-			for j < len(runes) && ((runes[j] >= 'a' && runes[j] <= 'z') || (runes[j] >= 'A' && runes[j] <= 'Z') || (runes[j] >= '0' && runes[j] <= '9') || runes[j] == '_') {
-				j++
-			}
-			if j < len(runes) && runes[j] == '$' {
-				// we have a dollar-tag start
-				tag := string(runes[i : j+1]) // includes both $
-				if dollarTag == "" {
-					dollarTag = tag
-					cur = append(cur, runes[i])
-					continue
-				} else if dollarTag == tag {
-					// closing tag
-					dollarTag = ""
-					cur = append(cur, runes[i])
-					continue
-				}
-			}
+	case *to >= 0:
+		rolledBack, applied, err := m.To(ctx, *to)
+		for _, mig := range rolledBack {
+			log.Printf("rolled back %06d_%s\n", mig.Version, mig.Name)
 		}
-
-		// if we're inside a dollar-quote, just append
-		if dollarTag != "" {
-			cur = append(cur, r)
-			continue
+		for _, mig := range applied {
+			log.Printf("applied %06d_%s\n", mig.Version, mig.Name)
+		}
+		if err != nil {
+			log.Fatalf("migration to %d failed: %v", *to, err)
 		}
+		fmt.Printf("migrated to version %d (%d rolled back, %d applied)\n", *to, len(rolledBack), len(applied))
 
-		if r == '\'' && !inDouble {
-			inSingle = !inSingle
-			cur = append(cur, r)
-			continue
+	case *direction == "up":
+		done, err := m.Up(ctx, *steps)
+		for _, mig := range done {
+			log.Printf("applied %06d_%s\n", mig.Version, mig.Name)
 		}
-		if r == '"' && !inSingle {
-			inDouble = !inDouble
-			cur = append(cur, r)
-			continue
+		if err != nil {
+			log.Fatalf("migration failed: %v", err)
 		}
-
-		if r == ';' && !inSingle && !inDouble && dollarTag == "" {
-			// statement terminator
-			s := string(cur)
-			if trim(s) != "" {
-				stmts = append(stmts, s)
-			}
-			cur = []rune{}
-			continue
+		if len(done) == 0 {
+			log.Println("no pending migrations")
+			return
 		}
+		fmt.Printf("migrations up completed (%d files)\n", len(done))
 
-		cur = append(cur, r)
-	}
-
-	// trailing
-	if trim(string(cur)) != "" {
-		stmts = append(stmts, string(cur))
-	}
-	return stmts
-}
-
-func trim(s string) string {
-	// simple whitespace trim
-	i := 0
-	j := len(s) - 1
-	for i <= j {
-		if s[i] == ' ' || s[i] == '\n' || s[i] == '\r' || s[i] == '\t' {
-			i++
-			continue
+	case *direction == "down":
+		// Rolling back everything must be explicit; default to one step
+		n := *steps
+		if n == 0 {
+			n = 1
 		}
-		break
-	}
-	for j >= i {
-		if s[j] == ' ' || s[j] == '\n' || s[j] == '\r' || s[j] == '\t' {
-			j--
-			continue
+		done, err := m.Down(ctx, n)
+		for _, mig := range done {
+			log.Printf("rolled back %06d_%s\n", mig.Version, mig.Name)
 		}
-		break
-	}
-	if i > j {
-		return ""
-	}
-	return s[i : j+1]
-}
+		if err != nil {
+			log.Fatalf("rollback failed: %v", err)
+		}
+		if len(done) == 0 {
+			log.Println("no applied migrations to roll back")
+			return
+		}
+		fmt.Printf("migrations down completed (%d files)\n", len(done))
 
-func containsAlreadyExists(msg string) bool {
-	if msg == "" {
-		return false
-	}
-	if indexOf(msg, "already exists") >= 0 {
-		return true
-	}
-	if indexOf(msg, "duplicate key value") >= 0 {
-		return true
+	default:
+		log.Fatalf("unknown direction %q (expected up or down)", *direction)
 	}
-	return false
 }
 
-func indexOf(s, sub string) int {
-	for i := 0; i+len(sub) <= len(s); i++ {
-		if s[i:i+len(sub)] == sub {
-			return i
+func printStatus(statuses []migrator.Status) {
+	applied := 0
+	fmt.Printf("%-8s %-40s %-10s %s\n", "VERSION", "NAME", "STATUS", "APPLIED AT")
+	for _, s := range statuses {
+		state := "pending"
+		appliedAt := "-"
+		if s.Applied {
+			applied++
+			state = "applied"
+			appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
 		}
+		fmt.Printf("%06d   %-40s %-10s %s\n", s.Version, s.Name, state, appliedAt)
 	}
-	return -1
+	fmt.Printf("\n%d applied, %d pending\n", applied, len(statuses)-applied)
 }
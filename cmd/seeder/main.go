@@ -1,28 +1,90 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
-	"os"
+	"strings"
 
 	"keerja-backend/database/seeders"
 	"keerja-backend/internal/config"
 )
 
+// seeder CLI: runs the named seeders in database/seeders and records the versions
+// that ran in the seed_runs table
 func main() {
-	// Load configuration
 	cfg := config.LoadConfig()
 
-	// Initialize database
+	only := flag.String("only", "", "comma-separated seeders to run, globs allowed (e.g. skills,industries)")
+	exclude := flag.String("exclude", "", "comma-separated seeders to skip, globs allowed (e.g. demo_*)")
+	env := flag.String("env", cfg.AppEnv, "environment; production runs only production-safe seeders")
+	dryRun := flag.Bool("dry-run", false, "report what would change and roll everything back")
+	force := flag.Bool("force", false, "run seeders even if their current version already ran")
+	list := flag.Bool("list", false, "list the registered seeders and exit")
+	flag.Parse()
+
+	if *list {
+		printSeeders(seeders.Registered())
+		return
+	}
+
 	db, err := config.InitDB(cfg)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+
+	opts := seeders.Options{
+		Only:    splitList(*only),
+		Exclude: splitList(*exclude),
+		Env:     *env,
+		DryRun:  *dryRun,
+		Force:   *force,
+	}
+
+	results, err := seeders.Run(db, opts)
+	printResults(results, opts.DryRun)
+	if err != nil {
+		log.Fatalf("seeding failed: %v", err)
+	}
+	if len(results) == 0 {
+		log.Println("no seeders selected")
+		return
 	}
 
-	// Run seeders
-	if err := seeders.RunSeeders(db); err != nil {
-		log.Fatalf("Failed to run seeders: %v", err)
-		os.Exit(1)
+	if opts.DryRun {
+		fmt.Println("dry run: no changes were saved")
+		return
 	}
+	fmt.Printf("seeding completed (%d seeders)\n", len(results))
+}
 
-	log.Println("Seeders completed successfully!")
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func printSeeders(registered []seeders.Seeder) {
+	fmt.Printf("%-20s %-8s %s\n", "NAME", "VERSION", "PRODUCTION")
+	for _, s := range registered {
+		fmt.Printf("%-20s %-8d %t\n", s.Name, s.Version, s.Production)
+	}
+}
+
+func printResults(results []seeders.Result, dryRun bool) {
+	changed := "changed"
+	if dryRun {
+		changed = "would change"
+	}
+	for _, r := range results {
+		if r.Skipped {
+			log.Printf("skipped %s v%d (already ran)\n", r.Name, r.Version)
+			continue
+		}
+		log.Printf("%s v%d: %d rows %s\n", r.Name, r.Version, r.Rows, changed)
+	}
 }
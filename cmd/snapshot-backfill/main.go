@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"keerja-backend/internal/config"
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/service"
+)
+
+// snapshot-backfill CLI: copies the library resumes and documents that applications submitted
+// before apply-time snapshots still reference into the applications' own storage. Files no
+// longer in the applicant's library or in the storage are left as referenced. Safe to run again.
+func main() {
+	batch := flag.Int("batch", 100, "applications and documents loaded at a time")
+	flag.Parse()
+
+	cfg := config.LoadConfig()
+	db, err := config.InitDB(cfg)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+
+	uploads, err := service.NewUploadServiceFromConfig(cfg)
+	if err != nil {
+		log.Fatalf("failed to initialize upload storage: %v", err)
+	}
+
+	userRepo := postgres.NewUserRepository(db)
+	appService := service.NewApplicationService(
+		postgres.NewApplicationRepository(db),
+		postgres.NewJobRepository(db),
+		userRepo,
+		postgres.NewCompanyRepository(db),
+		nil, nil, nil,
+		application.DefaultReapplyPolicy,
+		uploads,
+		cfg.ApplicationDocumentURLs,
+		nil, nil,
+	)
+
+	result, err := appService.BackfillFileSnapshots(context.Background(), *batch)
+	if result != nil {
+		fmt.Printf("resumes snapshotted: %d\ndocuments snapshotted: %d\nskipped: %d\nfailed: %d\n",
+			result.Resumes, result.Documents, result.Skipped, result.Failed)
+	}
+	if err != nil {
+		log.Fatalf("snapshot backfill failed: %v", err)
+	}
+}
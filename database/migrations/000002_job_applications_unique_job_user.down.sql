@@ -0,0 +1,5 @@
+-- Migration: Enforce one application per user per job
+-- Direction: down
+-- The constraint is part of the baseline schema (000001), so it is intentionally kept.
+
+SELECT 1;
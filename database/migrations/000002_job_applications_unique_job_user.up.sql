@@ -0,0 +1,18 @@
+-- Migration: Enforce one application per user per job
+-- Description: Databases restored from older dumps may lack the (job_id, user_id)
+-- unique constraint that ApplyForJob relies on to reject concurrent duplicates.
+-- If duplicate rows exist this migration fails and must be resolved manually.
+-- Direction: up
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint
+        WHERE conname = 'job_applications_job_id_user_id_key'
+          AND conrelid = 'public.job_applications'::regclass
+    ) THEN
+        ALTER TABLE public.job_applications
+            ADD CONSTRAINT job_applications_job_id_user_id_key UNIQUE (job_id, user_id);
+    END IF;
+END
+$$;
@@ -0,0 +1,8 @@
+-- Migration: Job review records
+-- Direction: down
+
+DROP TABLE IF EXISTS public.job_reviews;
+
+DROP INDEX IF EXISTS public.idx_jobs_submitted_at;
+
+ALTER TABLE public.jobs DROP COLUMN IF EXISTS submitted_at;
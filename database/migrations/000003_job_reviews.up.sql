@@ -0,0 +1,24 @@
+-- Migration: Job review records
+-- Description: Stores admin approve/reject decisions for jobs submitted for review
+-- and tracks when a job entered pending_review.
+-- Direction: up
+
+ALTER TABLE public.jobs ADD COLUMN IF NOT EXISTS submitted_at timestamp without time zone;
+
+UPDATE public.jobs
+SET submitted_at = updated_at
+WHERE status = 'pending_review' AND submitted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_submitted_at ON public.jobs USING btree (submitted_at);
+
+CREATE TABLE IF NOT EXISTS public.job_reviews (
+    id bigserial PRIMARY KEY,
+    job_id bigint NOT NULL REFERENCES public.jobs(id) ON DELETE CASCADE,
+    admin_id bigint REFERENCES public.admin_users(id) ON DELETE SET NULL,
+    action character varying(20) NOT NULL,
+    reason text,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    CONSTRAINT job_reviews_action_check CHECK (((action)::text = ANY (ARRAY['approved'::text, 'rejected'::text])))
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_reviews_job_id_created_at ON public.job_reviews USING btree (job_id, created_at DESC);
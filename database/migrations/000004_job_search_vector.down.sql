@@ -0,0 +1,15 @@
+-- Migration: Job full-text search
+-- Direction: down
+
+DROP INDEX IF EXISTS public.idx_jobs_search_vector;
+
+ALTER TABLE public.jobs DROP COLUMN IF EXISTS search_vector;
+
+DROP TRIGGER IF EXISTS trigger_skills_master_refresh_skill_names ON public.skills_master;
+DROP TRIGGER IF EXISTS trigger_job_skills_refresh_skill_names ON public.job_skills;
+
+DROP FUNCTION IF EXISTS public.skills_master_refresh_skill_names();
+DROP FUNCTION IF EXISTS public.job_skills_refresh_skill_names();
+DROP FUNCTION IF EXISTS public.refresh_job_skill_names(bigint);
+
+ALTER TABLE public.jobs DROP COLUMN IF EXISTS skill_names;
@@ -0,0 +1,71 @@
+-- Migration: Job full-text search
+-- Description: Adds a weighted tsvector over job title, skill names and description
+-- with a GIN index. Generated columns cannot read other tables, so skill names are
+-- denormalized into jobs.skill_names and kept in sync by triggers on job_skills
+-- and skills_master.
+-- Direction: up
+
+ALTER TABLE public.jobs ADD COLUMN IF NOT EXISTS skill_names text DEFAULT ''::text NOT NULL;
+
+CREATE OR REPLACE FUNCTION public.refresh_job_skill_names(p_job_id bigint) RETURNS void
+    LANGUAGE sql
+    AS $$
+    UPDATE public.jobs
+    SET skill_names = COALESCE((
+        SELECT string_agg(sm.name, ' ' ORDER BY sm.name)
+        FROM public.job_skills js
+        JOIN public.skills_master sm ON sm.id = js.skill_id
+        WHERE js.job_id = p_job_id
+    ), '')
+    WHERE id = p_job_id;
+$$;
+
+CREATE OR REPLACE FUNCTION public.job_skills_refresh_skill_names() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        PERFORM public.refresh_job_skill_names(OLD.job_id);
+    END IF;
+    IF TG_OP = 'INSERT' OR (TG_OP = 'UPDATE' AND NEW.job_id <> OLD.job_id) THEN
+        PERFORM public.refresh_job_skill_names(NEW.job_id);
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION public.skills_master_refresh_skill_names() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    PERFORM public.refresh_job_skill_names(js.job_id)
+    FROM (SELECT DISTINCT job_id FROM public.job_skills WHERE skill_id = NEW.id) js;
+    RETURN NULL;
+END;
+$$;
+
+DROP TRIGGER IF EXISTS trigger_job_skills_refresh_skill_names ON public.job_skills;
+CREATE TRIGGER trigger_job_skills_refresh_skill_names AFTER INSERT OR UPDATE OR DELETE ON public.job_skills FOR EACH ROW EXECUTE FUNCTION public.job_skills_refresh_skill_names();
+
+DROP TRIGGER IF EXISTS trigger_skills_master_refresh_skill_names ON public.skills_master;
+CREATE TRIGGER trigger_skills_master_refresh_skill_names AFTER UPDATE OF name ON public.skills_master FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name) EXECUTE FUNCTION public.skills_master_refresh_skill_names();
+
+-- Backfill skill names for existing jobs
+UPDATE public.jobs j
+SET skill_names = s.names
+FROM (
+    SELECT js.job_id, string_agg(sm.name, ' ' ORDER BY sm.name) AS names
+    FROM public.job_skills js
+    JOIN public.skills_master sm ON sm.id = js.skill_id
+    GROUP BY js.job_id
+) s
+WHERE s.job_id = j.id;
+
+-- Title matches rank above skill matches, which rank above description matches
+ALTER TABLE public.jobs ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english'::regconfig, COALESCE(title, '')::text), 'A') ||
+    setweight(to_tsvector('english'::regconfig, COALESCE(skill_names, '')), 'B') ||
+    setweight(to_tsvector('english'::regconfig, COALESCE(description, '')), 'C')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_search_vector ON public.jobs USING gin (search_vector);
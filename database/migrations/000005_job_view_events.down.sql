@@ -0,0 +1,4 @@
+-- Migration: Job view events
+-- Direction: down
+
+DROP TABLE IF EXISTS public.job_view_events;
@@ -0,0 +1,16 @@
+-- Migration: Job view events
+-- Description: Records individual job views so repeat views from the same user or IP
+-- within the dedup window are not counted, and so daily view analytics can be built.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.job_view_events (
+    id bigserial PRIMARY KEY,
+    job_id bigint NOT NULL REFERENCES public.jobs(id) ON DELETE CASCADE,
+    user_id bigint REFERENCES public.users(id) ON DELETE SET NULL,
+    ip_hash character varying(64),
+    viewed_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_view_events_job_viewed_at ON public.job_view_events USING btree (job_id, viewed_at);
+CREATE INDEX IF NOT EXISTS idx_job_view_events_job_user ON public.job_view_events USING btree (job_id, user_id, viewed_at) WHERE user_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_job_view_events_job_ip ON public.job_view_events USING btree (job_id, ip_hash, viewed_at) WHERE ip_hash IS NOT NULL;
@@ -0,0 +1,7 @@
+-- Migration: Refresh token rotation families
+-- Direction: down
+
+DROP INDEX IF EXISTS public.idx_refresh_tokens_family_id;
+
+ALTER TABLE public.refresh_tokens DROP COLUMN IF EXISTS replaced_by;
+ALTER TABLE public.refresh_tokens DROP COLUMN IF EXISTS family_id;
@@ -0,0 +1,18 @@
+-- Migration: Refresh token rotation families
+-- Description: Groups rotated refresh tokens into a family so that reuse of an already
+-- rotated token can revoke every token descended from the same login.
+-- Direction: up
+
+ALTER TABLE public.refresh_tokens ADD COLUMN IF NOT EXISTS family_id uuid;
+ALTER TABLE public.refresh_tokens ADD COLUMN IF NOT EXISTS replaced_by bigint REFERENCES public.refresh_tokens(id) ON DELETE SET NULL;
+
+-- Existing tokens each start their own family
+UPDATE public.refresh_tokens SET family_id = gen_random_uuid() WHERE family_id IS NULL;
+
+ALTER TABLE public.refresh_tokens ALTER COLUMN family_id SET DEFAULT gen_random_uuid();
+ALTER TABLE public.refresh_tokens ALTER COLUMN family_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON public.refresh_tokens USING btree (family_id);
+
+COMMENT ON COLUMN public.refresh_tokens.family_id IS 'Login session this token belongs to; shared by all tokens produced by rotation';
+COMMENT ON COLUMN public.refresh_tokens.replaced_by IS 'Token issued when this token was rotated; presenting a rotated token revokes the family';
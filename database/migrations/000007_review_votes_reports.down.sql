@@ -0,0 +1,8 @@
+-- Migration: Company review votes and abuse reports
+-- Direction: down
+
+DROP TABLE IF EXISTS public.review_reports;
+DROP TABLE IF EXISTS public.review_votes;
+
+ALTER TABLE public.company_reviews DROP COLUMN IF EXISTS not_helpful_count;
+ALTER TABLE public.company_reviews DROP COLUMN IF EXISTS helpful_count;
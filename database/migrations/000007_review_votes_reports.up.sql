@@ -0,0 +1,37 @@
+-- Migration: Company review votes and abuse reports
+-- Description: Lets users mark reviews as helpful or not helpful and report abusive
+-- reviews. Vote counts are denormalized onto company_reviews for listing.
+-- Direction: up
+
+ALTER TABLE public.company_reviews ADD COLUMN IF NOT EXISTS helpful_count integer NOT NULL DEFAULT 0;
+ALTER TABLE public.company_reviews ADD COLUMN IF NOT EXISTS not_helpful_count integer NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS public.review_votes (
+    id bigserial PRIMARY KEY,
+    review_id bigint NOT NULL REFERENCES public.company_reviews(id) ON DELETE CASCADE,
+    user_id bigint NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    vote character varying(20) NOT NULL,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone DEFAULT now(),
+    CONSTRAINT review_votes_vote_check CHECK (((vote)::text = ANY (ARRAY['helpful'::text, 'not_helpful'::text]))),
+    CONSTRAINT review_votes_review_id_user_id_key UNIQUE (review_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_votes_user_id ON public.review_votes USING btree (user_id);
+
+CREATE TABLE IF NOT EXISTS public.review_reports (
+    id bigserial PRIMARY KEY,
+    review_id bigint NOT NULL REFERENCES public.company_reviews(id) ON DELETE CASCADE,
+    user_id bigint NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    reason text NOT NULL,
+    status character varying(20) NOT NULL DEFAULT 'open',
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone DEFAULT now(),
+    CONSTRAINT review_reports_status_check CHECK (((status)::text = ANY (ARRAY['open'::text, 'resolved'::text, 'dismissed'::text]))),
+    CONSTRAINT review_reports_review_id_user_id_key UNIQUE (review_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_reports_review_id_status ON public.review_reports USING btree (review_id, status);
+
+COMMENT ON TABLE public.review_votes IS 'One helpful/not_helpful vote per user per company review';
+COMMENT ON TABLE public.review_reports IS 'Abuse reports on company reviews; enough open reports hide the review';
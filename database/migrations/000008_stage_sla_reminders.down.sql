@@ -0,0 +1,8 @@
+-- Migration: Application stage SLA reminders
+-- Direction: down
+
+DROP INDEX IF EXISTS public.idx_job_application_stages_open;
+
+ALTER TABLE public.job_application_stages DROP COLUMN IF EXISTS sla_reminded_at;
+
+DROP TABLE IF EXISTS public.company_settings;
@@ -0,0 +1,20 @@
+-- Migration: Application stage SLA reminders
+-- Description: Adds per-company settings for stage reminder emails and tracks when each
+-- application stage was last included in a reminder so it is not re-sent within 24h.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.company_settings (
+    company_id bigint PRIMARY KEY REFERENCES public.companies(id) ON DELETE CASCADE,
+    stage_reminder_enabled boolean NOT NULL DEFAULT true,
+    stage_reminder_days integer NOT NULL DEFAULT 7,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone DEFAULT now(),
+    CONSTRAINT company_settings_stage_reminder_days_check CHECK (((stage_reminder_days >= 1) AND (stage_reminder_days <= 90)))
+);
+
+ALTER TABLE public.job_application_stages ADD COLUMN IF NOT EXISTS sla_reminded_at timestamp without time zone;
+
+CREATE INDEX IF NOT EXISTS idx_job_application_stages_open ON public.job_application_stages USING btree (started_at) WHERE (completed_at IS NULL);
+
+COMMENT ON TABLE public.company_settings IS 'Per-company preferences; a missing row means defaults apply';
+COMMENT ON COLUMN public.job_application_stages.sla_reminded_at IS 'Last time this open stage was included in a recruiter SLA reminder';
@@ -0,0 +1,5 @@
+-- Migration: Interview calendar invites
+-- Direction: down
+
+ALTER TABLE public.interviews DROP COLUMN IF EXISTS calendar_sequence;
+ALTER TABLE public.interviews DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,10 @@
+-- Migration: Interview calendar invites
+-- Description: Stores the timezone an interview was scheduled in and the iCalendar
+-- SEQUENCE so rescheduled and cancelled invites replace the earlier calendar entry.
+-- Direction: up
+
+ALTER TABLE public.interviews ADD COLUMN IF NOT EXISTS timezone character varying(64) NOT NULL DEFAULT 'UTC';
+ALTER TABLE public.interviews ADD COLUMN IF NOT EXISTS calendar_sequence integer NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN public.interviews.timezone IS 'IANA timezone the interview was scheduled in; scheduled_at is stored in UTC';
+COMMENT ON COLUMN public.interviews.calendar_sequence IS 'iCalendar SEQUENCE, incremented on every reschedule or cancellation';
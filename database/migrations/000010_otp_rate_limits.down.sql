@@ -0,0 +1,6 @@
+-- Migration: OTP rate limits
+-- Direction: down
+
+DROP INDEX IF EXISTS public.idx_otp_codes_user_type_created;
+DROP INDEX IF EXISTS public.idx_otp_codes_ip_created;
+ALTER TABLE public.otp_codes DROP COLUMN IF EXISTS ip_address;
@@ -0,0 +1,11 @@
+-- Migration: OTP rate limits
+-- Description: Records the requesting IP on OTP codes so issuance can be limited per IP
+-- as well as per email, and indexes the lookups used by the limiter.
+-- Direction: up
+
+ALTER TABLE public.otp_codes ADD COLUMN IF NOT EXISTS ip_address character varying(45);
+
+COMMENT ON COLUMN public.otp_codes.ip_address IS 'IP address that requested the OTP, used for per-IP issuance limits';
+
+CREATE INDEX IF NOT EXISTS idx_otp_codes_ip_created ON public.otp_codes USING btree (ip_address, created_at) WHERE ip_address IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_otp_codes_user_type_created ON public.otp_codes USING btree (user_id, type, created_at DESC);
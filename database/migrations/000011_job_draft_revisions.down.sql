@@ -0,0 +1,5 @@
+-- Migration: Job draft revisions
+-- Direction: down
+
+DROP INDEX IF EXISTS public.idx_job_draft_revisions_job_id;
+DROP TABLE IF EXISTS public.job_draft_revisions;
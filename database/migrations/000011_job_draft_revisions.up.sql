@@ -0,0 +1,15 @@
+-- Migration: Job draft revisions
+-- Description: Keeps the last few payload snapshots of each job draft so employers can
+-- restore an earlier autosave. Revisions are removed together with their job.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.job_draft_revisions (
+    id bigserial PRIMARY KEY,
+    job_id bigint NOT NULL REFERENCES public.jobs(id) ON DELETE CASCADE,
+    payload jsonb NOT NULL,
+    created_at timestamp without time zone DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_draft_revisions_job_id ON public.job_draft_revisions USING btree (job_id, created_at DESC);
+
+COMMENT ON TABLE public.job_draft_revisions IS 'Payload snapshots of job drafts; only the newest 5 per job are kept';
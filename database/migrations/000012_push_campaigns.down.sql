@@ -0,0 +1,6 @@
+-- Migration: Push campaigns and topic subscriptions
+-- Direction: down
+
+DROP INDEX IF EXISTS public.idx_push_campaigns_due;
+DROP TABLE IF EXISTS public.push_campaigns;
+DROP TABLE IF EXISTS public.device_token_topics;
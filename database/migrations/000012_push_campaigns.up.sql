@@ -0,0 +1,43 @@
+-- Migration: Push campaigns and topic subscriptions
+-- Description: Records the FCM topics each device token is subscribed to, so role and
+-- province topics can be kept in sync, and stores admin push campaigns with their
+-- delivery stats. A campaign targets either a topic or a user filter, never both.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.device_token_topics (
+    device_token_id bigint NOT NULL REFERENCES public.device_tokens(id) ON DELETE CASCADE,
+    topic character varying(900) NOT NULL,
+    created_at timestamp without time zone DEFAULT now(),
+    PRIMARY KEY (device_token_id, topic)
+);
+
+CREATE TABLE IF NOT EXISTS public.push_campaigns (
+    id bigserial PRIMARY KEY,
+    title character varying(255) NOT NULL,
+    body text NOT NULL,
+    data jsonb DEFAULT '{}'::jsonb,
+    topic character varying(900),
+    role character varying(20),
+    province_id bigint,
+    followed_company_id bigint,
+    status character varying(20) DEFAULT 'scheduled'::character varying NOT NULL,
+    scheduled_at timestamp without time zone NOT NULL,
+    started_at timestamp without time zone,
+    completed_at timestamp without time zone,
+    sent_count integer DEFAULT 0 NOT NULL,
+    failed_count integer DEFAULT 0 NOT NULL,
+    invalid_tokens_pruned integer DEFAULT 0 NOT NULL,
+    error_message text,
+    created_by bigint REFERENCES public.admin_users(id) ON DELETE SET NULL,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone DEFAULT now(),
+    CONSTRAINT push_campaigns_status_check CHECK (status IN ('scheduled', 'sending', 'completed', 'failed')),
+    CONSTRAINT push_campaigns_target_check CHECK (
+        (topic IS NOT NULL) <> (role IS NOT NULL OR province_id IS NOT NULL OR followed_company_id IS NOT NULL)
+    )
+);
+
+CREATE INDEX IF NOT EXISTS idx_push_campaigns_due ON public.push_campaigns USING btree (status, scheduled_at);
+
+COMMENT ON TABLE public.device_token_topics IS 'FCM topics each device token is subscribed to';
+COMMENT ON TABLE public.push_campaigns IS 'Admin push campaigns sent by the scheduler, with delivery stats';
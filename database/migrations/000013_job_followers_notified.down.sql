@@ -0,0 +1,4 @@
+-- Migration: Job follower notification marker
+-- Direction: down
+
+ALTER TABLE public.jobs DROP COLUMN IF EXISTS followers_notified_at;
@@ -0,0 +1,8 @@
+-- Migration: Job follower notification marker
+-- Description: Records when the followers of a job's company were last notified about the
+-- job, so unpublishing and republishing within a day does not notify them twice.
+-- Direction: up
+
+ALTER TABLE public.jobs ADD COLUMN IF NOT EXISTS followers_notified_at timestamp without time zone;
+
+COMMENT ON COLUMN public.jobs.followers_notified_at IS 'Last time company followers were notified that this job was published';
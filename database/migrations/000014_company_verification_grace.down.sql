@@ -0,0 +1,7 @@
+-- Migration: Company verification expiry warnings and grace period
+-- Direction: down
+
+DROP INDEX IF EXISTS public.idx_company_verifications_status_expiry;
+
+ALTER TABLE public.company_verifications DROP COLUMN IF EXISTS expiry_warning_days;
+ALTER TABLE public.company_verifications DROP COLUMN IF EXISTS grace_until;
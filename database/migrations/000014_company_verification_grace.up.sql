@@ -0,0 +1,13 @@
+-- Migration: Company verification expiry warnings and grace period
+-- Description: Tracks which days-before-expiry warning was last sent for a verification, so
+-- each warning is sent once, and when the grace period after an expired verification ends.
+-- Direction: up
+
+ALTER TABLE public.company_verifications ADD COLUMN IF NOT EXISTS grace_until timestamp without time zone;
+ALTER TABLE public.company_verifications ADD COLUMN IF NOT EXISTS expiry_warning_days smallint;
+
+COMMENT ON COLUMN public.company_verifications.grace_until IS 'End of the grace period after verification_expiry; the company stays verified until then';
+COMMENT ON COLUMN public.company_verifications.expiry_warning_days IS 'Smallest days-before-expiry threshold (30/7/1) already warned about; reset on renewal';
+
+CREATE INDEX IF NOT EXISTS idx_company_verifications_status_expiry
+    ON public.company_verifications (status, verification_expiry);
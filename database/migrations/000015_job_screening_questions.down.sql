@@ -0,0 +1,6 @@
+-- Migration: Job screening questions
+-- Direction: down
+
+DROP TABLE IF EXISTS public.application_answers;
+
+DROP TABLE IF EXISTS public.job_questions;
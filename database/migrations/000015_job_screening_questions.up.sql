@@ -0,0 +1,33 @@
+-- Migration: Job screening questions
+-- Description: Per-job screening questions and the answers applicants give when applying.
+-- Knockout questions reject an application automatically when the answer does not match.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.job_questions (
+    id bigserial PRIMARY KEY,
+    job_id bigint NOT NULL REFERENCES public.jobs(id) ON DELETE CASCADE,
+    question_text text NOT NULL,
+    question_type character varying(20) NOT NULL,
+    options text[],
+    is_required boolean DEFAULT false NOT NULL,
+    is_knockout boolean DEFAULT false NOT NULL,
+    knockout_value text,
+    sort_order integer DEFAULT 0 NOT NULL,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL,
+    CONSTRAINT job_questions_question_type_check CHECK (((question_type)::text = ANY (ARRAY['text'::text, 'boolean'::text, 'number'::text, 'select'::text])))
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_questions_job_id ON public.job_questions USING btree (job_id, sort_order);
+
+CREATE TABLE IF NOT EXISTS public.application_answers (
+    id bigserial PRIMARY KEY,
+    application_id bigint NOT NULL REFERENCES public.job_applications(id) ON DELETE CASCADE,
+    question_id bigint NOT NULL REFERENCES public.job_questions(id) ON DELETE CASCADE,
+    answer_value text NOT NULL,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    CONSTRAINT application_answers_application_question_key UNIQUE (application_id, question_id)
+);
+
+-- Supports filtering a job's applicants by answer value
+CREATE INDEX IF NOT EXISTS idx_application_answers_question_value ON public.application_answers USING btree (question_id, lower(answer_value));
@@ -0,0 +1,4 @@
+-- Migration: Audit logs
+-- Direction: down
+
+DROP TABLE IF EXISTS public.audit_logs;
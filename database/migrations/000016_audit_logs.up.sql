@@ -0,0 +1,23 @@
+-- Migration: Audit logs
+-- Description: Trail of sensitive admin, employer and system actions such as verification
+-- decisions, review moderation, employer role changes and company deletion.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.audit_logs (
+    id bigserial PRIMARY KEY,
+    actor_id bigint,
+    actor_type character varying(20) NOT NULL,
+    action character varying(50) NOT NULL,
+    entity_type character varying(50) NOT NULL,
+    entity_id bigint NOT NULL,
+    metadata jsonb DEFAULT '{}'::jsonb NOT NULL,
+    ip character varying(45),
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    CONSTRAINT audit_logs_actor_type_check CHECK (((actor_type)::text = ANY (ARRAY['admin'::text, 'employer'::text, 'system'::text])))
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON public.audit_logs USING btree (created_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor ON public.audit_logs USING btree (actor_type, actor_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON public.audit_logs USING btree (entity_type, entity_id, created_at DESC);
@@ -0,0 +1,6 @@
+-- Migration: Company address geocoding
+-- Direction: down
+
+ALTER TABLE public.company_addresses
+    DROP COLUMN IF EXISTS geocode_confidence,
+    DROP COLUMN IF EXISTS geocode_provider;
@@ -0,0 +1,8 @@
+-- Migration: Company address geocoding
+-- Description: Records which provider geocoded a company address and how confident the
+-- match was.
+-- Direction: up
+
+ALTER TABLE public.company_addresses
+    ADD COLUMN IF NOT EXISTS geocode_provider VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS geocode_confidence NUMERIC(4,3);
@@ -0,0 +1,4 @@
+-- Migration: Company API keys
+-- Direction: down
+
+DROP TABLE IF EXISTS public.company_api_keys;
@@ -0,0 +1,22 @@
+-- Migration: Company API keys
+-- Description: Read-only API keys companies use to pull their jobs and applications into
+-- an external ATS. Only the SHA-256 hash of each key is stored.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.company_api_keys (
+    id bigserial PRIMARY KEY,
+    company_id bigint NOT NULL REFERENCES public.companies(id) ON DELETE CASCADE,
+    name character varying(100) NOT NULL,
+    key_prefix character varying(20) NOT NULL,
+    key_hash character varying(64) NOT NULL,
+    scopes text[] NOT NULL,
+    created_by bigint REFERENCES public.users(id) ON DELETE SET NULL,
+    last_used_at timestamp without time zone,
+    revoked_at timestamp without time zone,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL,
+    CONSTRAINT company_api_keys_key_hash_key UNIQUE (key_hash),
+    CONSTRAINT company_api_keys_scopes_check CHECK (cardinality(scopes) > 0 AND scopes <@ ARRAY['jobs:read'::text, 'applications:read'::text])
+);
+
+CREATE INDEX IF NOT EXISTS idx_company_api_keys_company ON public.company_api_keys USING btree (company_id, created_at DESC);
@@ -0,0 +1,4 @@
+-- Migration: Email queue
+-- Direction: down
+
+DROP TABLE IF EXISTS public.email_queue;
@@ -0,0 +1,27 @@
+-- Migration: Email queue
+-- Description: Outbox of notification emails sent by the email queue worker, with
+-- exponential backoff between failed attempts. Emails that exhaust their attempts are
+-- kept with status dead so admins can inspect and retry them.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.email_queue (
+    id bigserial PRIMARY KEY,
+    recipient character varying(255) NOT NULL,
+    template character varying(50) NOT NULL,
+    payload jsonb NOT NULL,
+    dedupe_key character varying(64) NOT NULL,
+    status character varying(20) DEFAULT 'pending'::character varying NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    next_attempt_at timestamp without time zone DEFAULT now() NOT NULL,
+    last_error text,
+    sent_at timestamp without time zone,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL,
+    CONSTRAINT email_queue_status_check CHECK (status IN ('pending', 'sent', 'dead'))
+);
+
+-- Queuing the same email again while it is pending is a no-op
+CREATE UNIQUE INDEX IF NOT EXISTS uq_email_queue_pending_dedupe ON public.email_queue USING btree (dedupe_key) WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_email_queue_due ON public.email_queue USING btree (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_email_queue_status ON public.email_queue USING btree (status, updated_at DESC);
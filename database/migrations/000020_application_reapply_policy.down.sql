@@ -0,0 +1,20 @@
+-- Migration: Application reapply policy
+-- Direction: down
+-- Restoring the (job_id, user_id) constraint requires dropping older applications
+-- that were followed by a reapplication, keeping the most recent one per pair.
+
+DROP INDEX IF EXISTS public.idx_job_applications_job_user_applied;
+DROP INDEX IF EXISTS public.uq_job_applications_open_job_user;
+
+DELETE FROM public.job_applications a
+USING public.job_applications b
+WHERE a.job_id = b.job_id
+  AND a.user_id = b.user_id
+  AND (a.applied_at, a.id) < (b.applied_at, b.id);
+
+ALTER TABLE public.job_applications
+    ADD CONSTRAINT job_applications_job_id_user_id_key UNIQUE (job_id, user_id);
+
+ALTER TABLE public.job_applications
+    DROP COLUMN IF EXISTS closed_at,
+    DROP COLUMN IF EXISTS do_not_reapply;
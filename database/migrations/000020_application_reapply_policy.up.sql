@@ -0,0 +1,25 @@
+-- Migration: Application reapply policy
+-- Description: Lets applicants apply to the same job again after withdrawing or being
+-- rejected. The one-row-per-user-per-job constraint becomes a unique index over open
+-- applications only, closed_at records when an application was withdrawn or rejected
+-- (the start of the reapply cooldown), and do_not_reapply lets the employer bar a
+-- rejected applicant from applying again.
+-- Direction: up
+
+ALTER TABLE public.job_applications
+    ADD COLUMN IF NOT EXISTS do_not_reapply boolean NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS closed_at timestamp with time zone;
+
+UPDATE public.job_applications
+SET closed_at = updated_at
+WHERE status IN ('withdrawn', 'rejected') AND closed_at IS NULL;
+
+ALTER TABLE public.job_applications
+    DROP CONSTRAINT IF EXISTS job_applications_job_id_user_id_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_job_applications_open_job_user
+    ON public.job_applications (job_id, user_id)
+    WHERE status NOT IN ('withdrawn', 'rejected');
+
+CREATE INDEX IF NOT EXISTS idx_job_applications_job_user_applied
+    ON public.job_applications (job_id, user_id, applied_at DESC);
@@ -0,0 +1,7 @@
+-- Migration: Device token lifecycle
+-- Direction: down
+
+DROP INDEX IF EXISTS public.uq_device_tokens_token;
+
+ALTER TABLE public.device_tokens
+    ADD CONSTRAINT unique_user_token UNIQUE (user_id, token);
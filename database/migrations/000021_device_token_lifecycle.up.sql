@@ -0,0 +1,17 @@
+-- Migration: Device token lifecycle
+-- Description: A device token now belongs to exactly one user. Registering a token that
+-- another user registered before reassigns the existing row instead of adding a second
+-- one, so the (user_id, token) constraint is replaced by a unique index on token.
+-- Duplicate rows left by earlier logins are collapsed to the most recently updated one.
+-- Direction: up
+
+DELETE FROM public.device_tokens a
+USING public.device_tokens b
+WHERE a.token = b.token
+  AND (a.updated_at, a.id) < (b.updated_at, b.id);
+
+ALTER TABLE public.device_tokens
+    DROP CONSTRAINT IF EXISTS unique_user_token;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_device_tokens_token
+    ON public.device_tokens (token);
@@ -0,0 +1,5 @@
+-- Migration: Email queue locale
+-- Direction: down
+
+ALTER TABLE public.email_queue
+    DROP COLUMN IF EXISTS locale;
@@ -0,0 +1,7 @@
+-- Migration: Email queue locale
+-- Description: Store the language each queued email is rendered in, so emails sent by the
+-- queue worker use the recipient's language preference or the request's Accept-Language.
+-- Direction: up
+
+ALTER TABLE public.email_queue
+    ADD COLUMN IF NOT EXISTS locale character varying(10) DEFAULT 'id'::character varying NOT NULL;
@@ -0,0 +1,7 @@
+-- Migration: Admin analytics indexes
+-- Direction: down
+
+DROP INDEX IF EXISTS public.idx_job_application_stages_hired;
+DROP INDEX IF EXISTS public.idx_job_applications_applied_at;
+DROP INDEX IF EXISTS public.idx_jobs_published_at;
+DROP INDEX IF EXISTS public.idx_companies_created_at;
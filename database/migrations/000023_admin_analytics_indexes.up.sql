@@ -0,0 +1,9 @@
+-- Migration: Admin analytics indexes
+-- Description: Index the timestamps the admin dashboard summary buckets by, so each
+-- metric's period is a range scan instead of a full table scan.
+-- Direction: up
+
+CREATE INDEX IF NOT EXISTS idx_companies_created_at ON public.companies USING btree (created_at);
+CREATE INDEX IF NOT EXISTS idx_jobs_published_at ON public.jobs USING btree (published_at) WHERE (published_at IS NOT NULL);
+CREATE INDEX IF NOT EXISTS idx_job_applications_applied_at ON public.job_applications USING btree (applied_at);
+CREATE INDEX IF NOT EXISTS idx_job_application_stages_hired ON public.job_application_stages USING btree (started_at) WHERE ((stage_name)::text = 'hired'::text);
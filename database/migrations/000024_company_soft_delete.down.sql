@@ -0,0 +1,10 @@
+-- Migration: Company soft delete
+-- Direction: down
+
+ALTER TABLE public.employer_users
+    DROP COLUMN IF EXISTS company_deleted_at;
+
+DROP INDEX IF EXISTS public.idx_companies_deleted_at;
+
+ALTER TABLE public.companies
+    DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,13 @@
+-- Migration: Company soft delete
+-- Description: Deleting a company now only stamps deleted_at so an admin can restore it.
+-- Employer memberships deactivated by the deletion record when it happened, so a restore
+-- reactivates exactly those and leaves memberships that were already inactive alone.
+-- Direction: up
+
+ALTER TABLE public.companies
+    ADD COLUMN IF NOT EXISTS deleted_at timestamp without time zone;
+
+CREATE INDEX IF NOT EXISTS idx_companies_deleted_at ON public.companies USING btree (deleted_at);
+
+ALTER TABLE public.employer_users
+    ADD COLUMN IF NOT EXISTS company_deleted_at timestamp without time zone;
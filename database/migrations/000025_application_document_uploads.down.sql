@@ -0,0 +1,5 @@
+-- Migration: Application document uploads
+-- Direction: down
+
+ALTER TABLE public.application_documents
+    DROP COLUMN IF EXISTS uploaded;
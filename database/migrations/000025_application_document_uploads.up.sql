@@ -0,0 +1,8 @@
+-- Migration: Application document uploads
+-- Description: Application documents are now uploaded through the API. Flag the ones whose
+-- file we stored so deleting the document or application also deletes the file, while
+-- pre-uploaded file URLs from clients are left alone.
+-- Direction: up
+
+ALTER TABLE public.application_documents
+    ADD COLUMN IF NOT EXISTS uploaded boolean DEFAULT false NOT NULL;
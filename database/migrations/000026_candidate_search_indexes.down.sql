@@ -0,0 +1,7 @@
+-- Migration: Candidate search indexes
+-- Direction: down
+
+DROP INDEX IF EXISTS public.idx_job_applications_company_applied;
+DROP INDEX IF EXISTS public.idx_user_experiences_user_dates;
+DROP INDEX IF EXISTS public.idx_user_educations_user_degree;
+DROP INDEX IF EXISTS public.idx_user_skills_user_normalized_name;
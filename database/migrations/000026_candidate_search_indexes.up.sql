@@ -0,0 +1,10 @@
+-- Migration: Candidate search indexes
+-- Description: Index the profile tables the employer application search filters on, so
+-- each skill, education, experience and location condition is an index lookup per
+-- applicant. Skills are matched on their normalized name like the job match score does.
+-- Direction: up
+
+CREATE INDEX IF NOT EXISTS idx_user_skills_user_normalized_name ON public.user_skills USING btree (user_id, lower(regexp_replace(btrim((skill_name)::text), '\s+'::text, ' '::text, 'g'::text)));
+CREATE INDEX IF NOT EXISTS idx_user_educations_user_degree ON public.user_educations USING btree (user_id, degree_level);
+CREATE INDEX IF NOT EXISTS idx_user_experiences_user_dates ON public.user_experiences USING btree (user_id, start_date, end_date);
+CREATE INDEX IF NOT EXISTS idx_job_applications_company_applied ON public.job_applications USING btree (company_id, applied_at DESC);
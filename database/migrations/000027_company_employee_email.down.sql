@@ -0,0 +1,5 @@
+-- Migration: Company employee email
+-- Direction: down
+
+DROP INDEX IF EXISTS public.idx_company_employees_company_email;
+ALTER TABLE public.company_employees DROP COLUMN IF EXISTS email;
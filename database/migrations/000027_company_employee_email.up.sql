@@ -0,0 +1,8 @@
+-- Migration: Company employee email
+-- Description: Store an optional email on company employees so roster imports can detect
+-- employees that were already added. Emails are compared case-insensitively per company.
+-- Direction: up
+
+ALTER TABLE public.company_employees ADD COLUMN IF NOT EXISTS email character varying(150);
+
+CREATE INDEX IF NOT EXISTS idx_company_employees_company_email ON public.company_employees USING btree (company_id, lower((email)::text));
@@ -0,0 +1,7 @@
+-- Migration: Job expiry reminders and auto-extend
+-- Direction: down
+
+DROP INDEX IF EXISTS public.idx_jobs_published_expired_at;
+ALTER TABLE public.jobs DROP COLUMN IF EXISTS expiry_reminder_days;
+ALTER TABLE public.jobs DROP COLUMN IF EXISTS auto_extend_count;
+ALTER TABLE public.jobs DROP COLUMN IF EXISTS auto_extend;
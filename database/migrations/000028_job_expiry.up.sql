@@ -0,0 +1,11 @@
+-- Migration: Job expiry reminders and auto-extend
+-- Description: Let employers opt a job into being extended by the default expiry period
+-- instead of expiring (up to a maximum number of times), and record which expiry
+-- reminder was last sent so reminders are not repeated.
+-- Direction: up
+
+ALTER TABLE public.jobs ADD COLUMN IF NOT EXISTS auto_extend boolean DEFAULT false NOT NULL;
+ALTER TABLE public.jobs ADD COLUMN IF NOT EXISTS auto_extend_count integer DEFAULT 0 NOT NULL;
+ALTER TABLE public.jobs ADD COLUMN IF NOT EXISTS expiry_reminder_days integer;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_published_expired_at ON public.jobs USING btree (expired_at) WHERE ((status)::text = 'published'::text);
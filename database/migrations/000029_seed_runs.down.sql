@@ -0,0 +1,12 @@
+-- Migration: Seed runs
+-- Direction: down
+
+DROP INDEX IF EXISTS public.uq_company_sizes_label;
+
+DROP INDEX IF EXISTS public.uq_districts_code;
+CREATE INDEX IF NOT EXISTS idx_districts_code ON public.districts USING btree (code) WHERE (code IS NOT NULL);
+
+DROP INDEX IF EXISTS public.uq_cities_code;
+CREATE INDEX IF NOT EXISTS idx_cities_code ON public.cities USING btree (code) WHERE (code IS NOT NULL);
+
+DROP TABLE IF EXISTS public.seed_runs;
@@ -0,0 +1,23 @@
+-- Migration: Seed runs
+-- Description: Record which version of each named seeder has run, and give the
+-- location and company size seed tables unique natural keys so seeders can upsert
+-- on them instead of duplicating rows when re-run.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.seed_runs (
+    id bigserial PRIMARY KEY,
+    seeder_name character varying(100) NOT NULL,
+    version integer NOT NULL,
+    rows_affected bigint DEFAULT 0 NOT NULL,
+    ran_at timestamp without time zone DEFAULT now() NOT NULL,
+    CONSTRAINT seed_runs_seeder_name_version_key UNIQUE (seeder_name, version)
+);
+
+-- The unique indexes replace the plain code lookups; NULL codes stay allowed
+DROP INDEX IF EXISTS public.idx_cities_code;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_cities_code ON public.cities USING btree (code);
+
+DROP INDEX IF EXISTS public.idx_districts_code;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_districts_code ON public.districts USING btree (code);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_company_sizes_label ON public.company_sizes USING btree (label);
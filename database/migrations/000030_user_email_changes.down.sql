@@ -0,0 +1,4 @@
+-- Migration: User email changes
+-- Direction: down
+
+DROP TABLE IF EXISTS public.user_email_changes;
@@ -0,0 +1,15 @@
+-- Migration: User email changes
+-- Description: Keep the previous email address each time a user changes their account
+-- email, so support can trace an account back to addresses it used before.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.user_email_changes (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    old_email character varying(150) NOT NULL,
+    new_email character varying(150) NOT NULL,
+    changed_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_email_changes_user ON public.user_email_changes USING btree (user_id, changed_at DESC);
+CREATE INDEX IF NOT EXISTS idx_user_email_changes_old_email ON public.user_email_changes USING btree (old_email);
@@ -0,0 +1,6 @@
+-- Migration: Company rating summaries
+-- Direction: down
+
+DROP INDEX IF EXISTS public.idx_company_reviews_updated_at;
+DROP INDEX IF EXISTS public.idx_company_reviews_company_status;
+DROP TABLE IF EXISTS public.company_rating_summaries;
@@ -0,0 +1,35 @@
+-- Migration: Company rating summaries
+-- Description: Precompute each company's average ratings over its approved reviews so
+-- rating reads and the top-rated list no longer aggregate company_reviews per request.
+-- The service refreshes a company's row whenever its reviews change and a scheduled
+-- job catches up on rows that went stale. Existing reviews are backfilled here.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.company_rating_summaries (
+    company_id bigint PRIMARY KEY REFERENCES public.companies(id) ON DELETE CASCADE,
+    overall numeric(3,2) DEFAULT 0 NOT NULL,
+    culture numeric(3,2) DEFAULT 0 NOT NULL,
+    work_life numeric(3,2) DEFAULT 0 NOT NULL,
+    salary numeric(3,2) DEFAULT 0 NOT NULL,
+    management numeric(3,2) DEFAULT 0 NOT NULL,
+    total_reviews bigint DEFAULT 0 NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_company_rating_summaries_top ON public.company_rating_summaries USING btree (overall DESC, total_reviews DESC);
+CREATE INDEX IF NOT EXISTS idx_company_reviews_company_status ON public.company_reviews USING btree (company_id, status);
+CREATE INDEX IF NOT EXISTS idx_company_reviews_updated_at ON public.company_reviews USING btree (updated_at);
+
+INSERT INTO public.company_rating_summaries (company_id, overall, culture, work_life, salary, management, total_reviews, updated_at)
+SELECT c.id,
+    COALESCE(AVG(r.rating_overall), 0),
+    COALESCE(AVG(r.rating_culture), 0),
+    COALESCE(AVG(r.rating_worklife), 0),
+    COALESCE(AVG(r.rating_salary), 0),
+    COALESCE(AVG(r.rating_management), 0),
+    COUNT(r.id),
+    now()
+FROM public.companies c
+JOIN public.company_reviews r ON r.company_id = c.id AND r.status = 'approved'
+GROUP BY c.id
+ON CONFLICT (company_id) DO NOTHING;
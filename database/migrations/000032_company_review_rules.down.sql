@@ -0,0 +1,11 @@
+-- Migration: Company review rules
+-- Direction: down
+-- Deleted duplicate reviews are not restored.
+
+DROP INDEX IF EXISTS public.idx_company_reviews_user_created;
+
+ALTER TABLE public.company_reviews
+    DROP COLUMN IF EXISTS is_verified_reviewer;
+
+ALTER TABLE public.company_reviews
+    DROP CONSTRAINT IF EXISTS company_reviews_company_id_user_id_key;
@@ -0,0 +1,24 @@
+-- Migration: Company review rules
+-- Description: Allow one review per user per company and flag reviews written by verified
+-- employees or hired applicants.
+--
+-- Existing duplicates: for each (company_id, user_id) pair only the latest review (by
+-- created_at, then id) is kept and older ones are deleted together with their votes and
+-- reports (ON DELETE CASCADE) before the unique constraint is added. Reviews without a
+-- user are not affected. Rating summaries of the affected companies no longer match their
+-- review counts afterwards and are recomputed by the rating summary job on its next run.
+-- Direction: up
+
+DELETE FROM public.company_reviews older
+USING public.company_reviews newer
+WHERE older.company_id = newer.company_id
+    AND older.user_id = newer.user_id
+    AND (older.created_at, older.id) < (newer.created_at, newer.id);
+
+ALTER TABLE public.company_reviews
+    ADD CONSTRAINT company_reviews_company_id_user_id_key UNIQUE (company_id, user_id);
+
+ALTER TABLE public.company_reviews
+    ADD COLUMN IF NOT EXISTS is_verified_reviewer boolean DEFAULT false NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_company_reviews_user_created ON public.company_reviews USING btree (user_id, created_at);
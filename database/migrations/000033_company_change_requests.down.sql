@@ -0,0 +1,4 @@
+-- Migration: Company change requests
+-- Direction: down
+
+DROP TABLE IF EXISTS public.company_change_requests;
@@ -0,0 +1,27 @@
+-- Migration: Company change requests
+-- Description: Let employers ask an admin to change their company's industry, size or
+-- district, which they cannot edit themselves. Approved requests are applied to the
+-- company; at most one request per company may be pending at a time.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.company_change_requests (
+    id bigserial PRIMARY KEY,
+    company_id bigint NOT NULL REFERENCES public.companies(id) ON DELETE CASCADE,
+    requested_by bigint NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    industry_id bigint REFERENCES public.industries(id) ON DELETE RESTRICT,
+    company_size_id bigint REFERENCES public.company_sizes(id) ON DELETE RESTRICT,
+    district_id bigint REFERENCES public.districts(id) ON DELETE RESTRICT,
+    reason text NOT NULL,
+    status character varying(20) DEFAULT 'pending'::character varying NOT NULL,
+    decided_by bigint REFERENCES public.admin_users(id) ON DELETE SET NULL,
+    decided_at timestamp without time zone,
+    decision_note text,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL,
+    CONSTRAINT company_change_requests_status_check CHECK (status IN ('pending', 'approved', 'rejected')),
+    CONSTRAINT company_change_requests_not_empty CHECK (industry_id IS NOT NULL OR company_size_id IS NOT NULL OR district_id IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS idx_company_change_requests_company ON public.company_change_requests USING btree (company_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_company_change_requests_status ON public.company_change_requests USING btree (status, created_at);
+CREATE UNIQUE INDEX IF NOT EXISTS company_change_requests_one_pending ON public.company_change_requests USING btree (company_id) WHERE status = 'pending';
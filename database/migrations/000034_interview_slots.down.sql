@@ -0,0 +1,5 @@
+-- Migration: Interview slots and self-scheduling links
+-- Direction: down
+
+DROP TABLE IF EXISTS public.interview_booking_links;
+DROP TABLE IF EXISTS public.interview_slots;
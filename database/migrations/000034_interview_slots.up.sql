@@ -0,0 +1,45 @@
+-- Migration: Interview slots and self-scheduling links
+-- Description: Recruiters publish the times an interviewer is available as interview slots
+-- and send candidates a tokenized link to book one of them. A slot is booked by at most one
+-- application, and a booking link books at most one slot.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.interview_slots (
+    id bigserial PRIMARY KEY,
+    company_id bigint NOT NULL REFERENCES public.companies(id) ON DELETE CASCADE,
+    interviewer_id bigint NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    starts_at timestamp without time zone NOT NULL,
+    ends_at timestamp without time zone NOT NULL,
+    timezone character varying(64) DEFAULT 'UTC'::character varying NOT NULL,
+    interview_type character varying(20) DEFAULT 'online'::character varying NOT NULL,
+    meeting_link text,
+    location text,
+    application_id bigint REFERENCES public.job_applications(id) ON DELETE SET NULL,
+    interview_id bigint REFERENCES public.interviews(id) ON DELETE SET NULL,
+    booked_at timestamp without time zone,
+    created_by bigint NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL,
+    CONSTRAINT interview_slots_time_check CHECK (ends_at > starts_at),
+    CONSTRAINT interview_slots_type_check CHECK (interview_type IN ('online', 'onsite', 'hybrid'))
+);
+
+-- An interviewer has one slot per start time, so overlapping batches do not duplicate slots
+CREATE UNIQUE INDEX IF NOT EXISTS interview_slots_interviewer_start ON public.interview_slots USING btree (interviewer_id, starts_at);
+CREATE INDEX IF NOT EXISTS idx_interview_slots_company_start ON public.interview_slots USING btree (company_id, starts_at);
+CREATE INDEX IF NOT EXISTS idx_interview_slots_interview ON public.interview_slots USING btree (interview_id) WHERE interview_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS public.interview_booking_links (
+    id bigserial PRIMARY KEY,
+    application_id bigint NOT NULL REFERENCES public.job_applications(id) ON DELETE CASCADE,
+    company_id bigint NOT NULL REFERENCES public.companies(id) ON DELETE CASCADE,
+    token_hash character varying(64) NOT NULL,
+    expires_at timestamp without time zone NOT NULL,
+    created_by bigint NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    slot_id bigint REFERENCES public.interview_slots(id) ON DELETE SET NULL,
+    used_at timestamp without time zone,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    CONSTRAINT interview_booking_links_token_hash_key UNIQUE (token_hash)
+);
+
+CREATE INDEX IF NOT EXISTS idx_interview_booking_links_application ON public.interview_booking_links USING btree (application_id);
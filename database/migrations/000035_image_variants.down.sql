@@ -0,0 +1,8 @@
+-- Migration: Image variants
+-- Direction: down
+
+ALTER TABLE public.user_profiles DROP COLUMN IF EXISTS cover_variants;
+ALTER TABLE public.user_profiles DROP COLUMN IF EXISTS avatar_variants;
+
+ALTER TABLE public.companies DROP COLUMN IF EXISTS banner_variants;
+ALTER TABLE public.companies DROP COLUMN IF EXISTS logo_variants;
@@ -0,0 +1,12 @@
+-- Migration: Image variants
+-- Description: Record the resized WebP and JPEG variants rendered for company logos and
+-- banners and for profile avatars and covers, as a map of variant key (e.g. 200_webp) to
+-- URL. The existing URL columns keep pointing at the original image. Images uploaded
+-- before this migration have no variants.
+-- Direction: up
+
+ALTER TABLE public.companies ADD COLUMN IF NOT EXISTS logo_variants jsonb DEFAULT '{}'::jsonb NOT NULL;
+ALTER TABLE public.companies ADD COLUMN IF NOT EXISTS banner_variants jsonb DEFAULT '{}'::jsonb NOT NULL;
+
+ALTER TABLE public.user_profiles ADD COLUMN IF NOT EXISTS avatar_variants jsonb DEFAULT '{}'::jsonb NOT NULL;
+ALTER TABLE public.user_profiles ADD COLUMN IF NOT EXISTS cover_variants jsonb DEFAULT '{}'::jsonb NOT NULL;
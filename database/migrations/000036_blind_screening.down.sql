@@ -0,0 +1,4 @@
+-- Migration: Blind screening
+-- Direction: down
+
+ALTER TABLE public.company_settings DROP COLUMN IF EXISTS blind_screening_enabled;
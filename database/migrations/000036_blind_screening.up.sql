@@ -0,0 +1,9 @@
+-- Migration: Blind screening
+-- Description: Adds a company setting that hides applicant identity (name, contact details
+-- and photo) from recruiters and viewers while applications are in the applied and
+-- screening stages. Company admins and owners always see full applicant data.
+-- Direction: up
+
+ALTER TABLE public.company_settings ADD COLUMN IF NOT EXISTS blind_screening_enabled boolean DEFAULT false NOT NULL;
+
+COMMENT ON COLUMN public.company_settings.blind_screening_enabled IS 'Hide applicant identity from recruiters until an application is shortlisted';
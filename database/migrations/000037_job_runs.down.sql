@@ -0,0 +1,4 @@
+-- Migration: Job runs
+-- Direction: down
+
+DROP TABLE IF EXISTS public.job_runs;
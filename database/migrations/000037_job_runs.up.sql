@@ -0,0 +1,20 @@
+-- Migration: Job runs
+-- Description: History of background job runs recorded by the scheduler, with how each
+-- run was triggered, when it started and finished, whether it succeeded and how many items
+-- it processed. Runs older than the retention period are removed by the job_run_cleanup job.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.job_runs (
+    id bigserial PRIMARY KEY,
+    job_name character varying(100) NOT NULL,
+    triggered_by character varying(20) NOT NULL,
+    started_at timestamp without time zone NOT NULL,
+    finished_at timestamp without time zone,
+    success boolean DEFAULT false NOT NULL,
+    error text,
+    items_processed integer DEFAULT 0 NOT NULL,
+    CONSTRAINT job_runs_triggered_by_check CHECK (triggered_by IN ('schedule', 'manual'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_runs_job_started ON public.job_runs USING btree (job_name, started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_job_runs_started ON public.job_runs USING btree (started_at);
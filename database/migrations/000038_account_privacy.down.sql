@@ -0,0 +1,9 @@
+-- Migration: Account privacy
+-- Direction: down
+
+UPDATE public.audit_logs SET actor_type = 'system' WHERE actor_type = 'user';
+ALTER TABLE public.audit_logs DROP CONSTRAINT IF EXISTS audit_logs_actor_type_check;
+ALTER TABLE public.audit_logs ADD CONSTRAINT audit_logs_actor_type_check CHECK (((actor_type)::text = ANY (ARRAY['admin'::text, 'employer'::text, 'system'::text])));
+
+DROP TABLE IF EXISTS public.account_data_exports;
+DROP TABLE IF EXISTS public.account_deletion_requests;
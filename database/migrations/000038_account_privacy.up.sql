@@ -0,0 +1,44 @@
+-- Migration: Account privacy
+-- Description: Job seeker account deletion and personal data export. A deletion request
+-- is carried out by the account_deletion job once its grace period has ended and can be
+-- canceled until then; at most one request per user may be pending. Data exports are
+-- generated by the data_export job and downloaded with an emailed token until they expire.
+-- Deleted accounts are anonymized rather than removed, and audit entries may now be
+-- attributed to job seekers.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.account_deletion_requests (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    status character varying(20) DEFAULT 'pending'::character varying NOT NULL,
+    scheduled_for timestamp with time zone NOT NULL,
+    canceled_at timestamp with time zone,
+    completed_at timestamp with time zone,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL,
+    CONSTRAINT account_deletion_requests_status_check CHECK (status IN ('pending', 'canceled', 'completed'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS account_deletion_requests_one_pending ON public.account_deletion_requests USING btree (user_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_account_deletion_requests_due ON public.account_deletion_requests USING btree (scheduled_for) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS public.account_data_exports (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    status character varying(20) DEFAULT 'pending'::character varying NOT NULL,
+    token_hash character varying(64),
+    data jsonb,
+    error text,
+    completed_at timestamp with time zone,
+    expires_at timestamp with time zone,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL,
+    CONSTRAINT account_data_exports_status_check CHECK (status IN ('pending', 'ready', 'failed', 'expired'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS account_data_exports_token_hash ON public.account_data_exports USING btree (token_hash) WHERE token_hash IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_account_data_exports_user ON public.account_data_exports USING btree (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_account_data_exports_status ON public.account_data_exports USING btree (status, created_at) WHERE status IN ('pending', 'ready');
+
+ALTER TABLE public.audit_logs DROP CONSTRAINT IF EXISTS audit_logs_actor_type_check;
+ALTER TABLE public.audit_logs ADD CONSTRAINT audit_logs_actor_type_check CHECK (((actor_type)::text = ANY (ARRAY['admin'::text, 'employer'::text, 'user'::text, 'system'::text])));
@@ -0,0 +1,28 @@
+-- Migration: Job soft delete
+-- Direction: down
+-- Applications of deleted jobs get their previous status back, and jobs still deleted are
+-- removed for good, as deleting a job did before this migration.
+
+UPDATE public.job_applications
+SET status = COALESCE(status_before_job_withdrawn, 'withdrawn')
+WHERE status = 'job_withdrawn';
+
+DELETE FROM public.job_application_stages WHERE stage_name = 'job_withdrawn';
+
+DELETE FROM public.jobs WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE public.job_application_stages DROP CONSTRAINT IF EXISTS job_application_stages_stage_name_check;
+ALTER TABLE public.job_application_stages ADD CONSTRAINT job_application_stages_stage_name_check
+    CHECK (stage_name IN ('applied', 'screening', 'shortlisted', 'interview', 'offered', 'hired', 'rejected', 'withdrawn'));
+
+ALTER TABLE public.job_applications DROP CONSTRAINT IF EXISTS job_applications_status_check;
+ALTER TABLE public.job_applications ADD CONSTRAINT job_applications_status_check
+    CHECK (status IN ('applied', 'screening', 'shortlisted', 'interview', 'offered', 'hired', 'rejected', 'withdrawn'));
+
+ALTER TABLE public.job_applications
+    DROP COLUMN IF EXISTS status_before_job_withdrawn;
+
+DROP INDEX IF EXISTS public.idx_jobs_deleted_at;
+
+ALTER TABLE public.jobs
+    DROP COLUMN IF EXISTS deleted_at;
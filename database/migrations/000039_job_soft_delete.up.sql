@@ -0,0 +1,24 @@
+-- Migration: Job soft delete
+-- Description: Deleting a job now only stamps deleted_at so the employer can restore it
+-- within 30 days; skills, benefits, requirements and locations are kept for the restore.
+-- Open applications of a deleted job move to the new job_withdrawn status, remembering the
+-- status they had so a restore can put them back.
+-- Direction: up
+
+ALTER TABLE public.jobs
+    ADD COLUMN IF NOT EXISTS deleted_at timestamp without time zone;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_deleted_at ON public.jobs USING btree (deleted_at);
+
+ALTER TABLE public.job_applications
+    ADD COLUMN IF NOT EXISTS status_before_job_withdrawn character varying(30);
+
+ALTER TABLE public.job_applications DROP CONSTRAINT IF EXISTS job_applications_status_check;
+ALTER TABLE public.job_applications ADD CONSTRAINT job_applications_status_check
+    CHECK (status IN ('applied', 'screening', 'shortlisted', 'interview', 'offered', 'hired', 'rejected', 'withdrawn', 'job_withdrawn'));
+
+ALTER TABLE public.job_application_stages DROP CONSTRAINT IF EXISTS job_application_stages_stage_name_check;
+ALTER TABLE public.job_application_stages ADD CONSTRAINT job_application_stages_stage_name_check
+    CHECK (stage_name IN ('applied', 'screening', 'shortlisted', 'interview', 'offered', 'hired', 'rejected', 'withdrawn', 'job_withdrawn'));
+
+COMMENT ON COLUMN public.job_applications.status_before_job_withdrawn IS 'Status to restore when the deleted job of a job_withdrawn application is restored';
@@ -0,0 +1,4 @@
+-- Migration: Admin role permissions
+-- Direction: down
+
+ALTER TABLE public.admin_roles DROP COLUMN IF EXISTS permissions;
@@ -0,0 +1,20 @@
+-- Migration: Admin role permissions
+-- Description: Admin roles carry a set of permissions that admin routes check, replacing
+-- the single "is an admin" check. Existing roles are granted permissions by the access
+-- level tiers of AdminRole (super admin 9+, admin 7+, moderator 5+), matching the default
+-- roles the seeder creates, so current admins keep their access.
+-- Direction: up
+
+ALTER TABLE public.admin_roles
+    ADD COLUMN permissions text[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN public.admin_roles.permissions IS 'Permissions granted to admins with this role, e.g. companies.verify';
+
+UPDATE public.admin_roles
+SET permissions = CASE
+    WHEN access_level >= 9 THEN ARRAY['companies.verify', 'reviews.moderate', 'jobs.review', 'master_data.write', 'analytics.read', 'admins.manage']
+    WHEN access_level >= 7 THEN ARRAY['companies.verify', 'reviews.moderate', 'jobs.review', 'master_data.write', 'analytics.read']
+    WHEN access_level >= 5 THEN ARRAY['reviews.moderate', 'jobs.review']
+    WHEN role_name = 'Analyst' THEN ARRAY['analytics.read']
+    ELSE '{}'::text[]
+END;
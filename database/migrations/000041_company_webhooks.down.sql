@@ -0,0 +1,5 @@
+-- Migration: Company webhooks
+-- Direction: down
+
+DROP TABLE IF EXISTS public.webhook_deliveries;
+DROP TABLE IF EXISTS public.company_webhooks;
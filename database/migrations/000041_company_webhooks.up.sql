@@ -0,0 +1,48 @@
+-- Migration: Company webhooks
+-- Description: Endpoints companies register to be sent application, interview and job
+-- events, and the deliveries sent to them. Deliveries are retried with exponential backoff;
+-- endpoints that keep failing are disabled by the circuit breaker.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.company_webhooks (
+    id bigserial PRIMARY KEY,
+    company_id bigint NOT NULL REFERENCES public.companies(id) ON DELETE CASCADE,
+    url text NOT NULL,
+    secret character varying(100) NOT NULL,
+    events text[] NOT NULL,
+    active boolean DEFAULT true NOT NULL,
+    failure_count integer DEFAULT 0 NOT NULL,
+    failing_since timestamp without time zone,
+    disabled_at timestamp without time zone,
+    created_by bigint REFERENCES public.users(id) ON DELETE SET NULL,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL,
+    CONSTRAINT company_webhooks_events_check CHECK (cardinality(events) > 0 AND events <@ ARRAY[
+        'application.created'::text, 'application.status_changed'::text, 'interview.scheduled'::text,
+        'job.published'::text, 'job.expired'::text
+    ])
+);
+
+CREATE INDEX IF NOT EXISTS idx_company_webhooks_company ON public.company_webhooks USING btree (company_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS public.webhook_deliveries (
+    id bigserial PRIMARY KEY,
+    webhook_id bigint NOT NULL REFERENCES public.company_webhooks(id) ON DELETE CASCADE,
+    company_id bigint NOT NULL REFERENCES public.companies(id) ON DELETE CASCADE,
+    event_id uuid NOT NULL,
+    event character varying(50) NOT NULL,
+    payload jsonb NOT NULL,
+    status character varying(20) DEFAULT 'pending'::character varying NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    next_attempt_at timestamp without time zone DEFAULT now() NOT NULL,
+    response_code integer,
+    last_error text,
+    delivered_at timestamp without time zone,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL,
+    CONSTRAINT webhook_deliveries_status_check CHECK (status IN ('pending', 'delivered', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON public.webhook_deliveries USING btree (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook ON public.webhook_deliveries USING btree (webhook_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_company ON public.webhook_deliveries USING btree (company_id, created_at DESC);
@@ -0,0 +1,4 @@
+-- Migration: User job match scores
+-- Direction: down
+
+DROP TABLE IF EXISTS public.user_job_match_scores;
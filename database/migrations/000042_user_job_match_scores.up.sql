@@ -0,0 +1,26 @@
+-- Migration: User job match scores
+-- Description: Precomputed match scores of published jobs for active job seekers, refreshed
+-- nightly so job matching and recommendations read scores instead of computing them per
+-- request. Rows are deleted when the user's skills, experience or education or the job's
+-- requirements change, and recomputed on demand until the next nightly run.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.user_job_match_scores (
+    user_id bigint NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    job_id bigint NOT NULL REFERENCES public.jobs(id) ON DELETE CASCADE,
+    overall double precision NOT NULL,
+    skill double precision NOT NULL,
+    experience double precision NOT NULL,
+    education double precision NOT NULL,
+    location double precision NOT NULL,
+    computed_at timestamp without time zone DEFAULT now() NOT NULL,
+    PRIMARY KEY (user_id, job_id)
+);
+
+-- Top N jobs by score for a user
+CREATE INDEX IF NOT EXISTS idx_user_job_match_scores_user_top ON public.user_job_match_scores USING btree (user_id, overall DESC, job_id);
+
+-- Invalidation when a job's requirements change
+CREATE INDEX IF NOT EXISTS idx_user_job_match_scores_job ON public.user_job_match_scores USING btree (job_id);
+
+COMMENT ON TABLE public.user_job_match_scores IS 'Precomputed job match scores (0-1) per job seeker and published job';
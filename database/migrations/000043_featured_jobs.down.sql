@@ -0,0 +1,12 @@
+-- Migration: Featured jobs
+-- Direction: down
+
+UPDATE public.admin_roles SET permissions = array_remove(permissions, 'jobs.feature');
+
+DROP INDEX IF EXISTS public.idx_job_view_events_viewed_at;
+DROP INDEX IF EXISTS public.idx_jobs_featured;
+
+ALTER TABLE public.jobs
+    DROP COLUMN IF EXISTS featured_until,
+    DROP COLUMN IF EXISTS featured_from,
+    DROP COLUMN IF EXISTS is_featured;
@@ -0,0 +1,22 @@
+-- Migration: Featured jobs
+-- Description: Jobs can be featured by admins for a scheduled window; featured jobs lead the
+-- homepage list, which is filled up by recent job views. Recent views are ranked from
+-- job_view_events, so an index on viewed_at alone serves the window scan. Admin roles at
+-- access level 7+ (admin and above) are granted the new jobs.feature permission.
+-- Direction: up
+
+ALTER TABLE public.jobs
+    ADD COLUMN IF NOT EXISTS is_featured boolean DEFAULT false NOT NULL,
+    ADD COLUMN IF NOT EXISTS featured_from timestamp without time zone,
+    ADD COLUMN IF NOT EXISTS featured_until timestamp without time zone;
+
+COMMENT ON COLUMN public.jobs.featured_from IS 'Start of the featured window; NULL features the job immediately';
+COMMENT ON COLUMN public.jobs.featured_until IS 'End of the featured window (exclusive); NULL features the job until unfeatured';
+
+CREATE INDEX IF NOT EXISTS idx_jobs_featured ON public.jobs USING btree (featured_from, featured_until) WHERE is_featured AND deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_job_view_events_viewed_at ON public.job_view_events USING btree (viewed_at);
+
+UPDATE public.admin_roles
+SET permissions = array_append(permissions, 'jobs.feature')
+WHERE access_level >= 7 AND NOT ('jobs.feature' = ANY (permissions));
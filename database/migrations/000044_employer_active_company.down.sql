@@ -0,0 +1,7 @@
+-- Migration: Employer active company
+-- Direction: down
+
+DROP INDEX IF EXISTS public.idx_employer_users_user_activated;
+
+ALTER TABLE public.employer_users
+    DROP COLUMN IF EXISTS activated_at;
@@ -0,0 +1,12 @@
+-- Migration: Employer active company
+-- Description: Employers who belong to several companies choose the company they act for.
+-- The membership with the latest activated_at is the user's active company; requests can
+-- still pick another one with the X-Company-ID header.
+-- Direction: up
+
+ALTER TABLE public.employer_users
+    ADD COLUMN IF NOT EXISTS activated_at timestamp without time zone;
+
+COMMENT ON COLUMN public.employer_users.activated_at IS 'When the user last chose this company as their active company; NULL if never chosen';
+
+CREATE INDEX IF NOT EXISTS idx_employer_users_user_activated ON public.employer_users USING btree (user_id, activated_at DESC NULLS LAST);
@@ -0,0 +1,12 @@
+-- Migration: Notification settings and deferred pushes
+-- Direction: down
+
+DROP INDEX IF EXISTS public.idx_deferred_pushes_deliver_at;
+DROP INDEX IF EXISTS public.idx_deferred_pushes_user_id;
+DROP TABLE IF EXISTS public.deferred_pushes;
+
+ALTER TABLE public.user_preferences
+    DROP COLUMN IF EXISTS quiet_hours_timezone,
+    DROP COLUMN IF EXISTS quiet_hours_end,
+    DROP COLUMN IF EXISTS quiet_hours_start,
+    DROP COLUMN IF EXISTS notification_matrix;
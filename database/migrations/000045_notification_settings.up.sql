@@ -0,0 +1,35 @@
+-- Migration: Notification settings and deferred pushes
+-- Description: Job seekers choose per event (application status, interviews, messages, job
+-- alerts, followed companies, marketing) whether they are emailed and pushed, and may set
+-- quiet hours in their timezone. Pushes that fall in quiet hours are stored until the
+-- quiet hours end and sent by the deferred_push job.
+-- Direction: up
+
+ALTER TABLE public.user_preferences
+    ADD COLUMN IF NOT EXISTS notification_matrix jsonb DEFAULT '{}'::jsonb NOT NULL,
+    ADD COLUMN IF NOT EXISTS quiet_hours_start character varying(5),
+    ADD COLUMN IF NOT EXISTS quiet_hours_end character varying(5),
+    ADD COLUMN IF NOT EXISTS quiet_hours_timezone character varying(64) DEFAULT 'Asia/Jakarta'::character varying NOT NULL;
+
+COMMENT ON COLUMN public.user_preferences.notification_matrix IS 'Email and push choice per notification event; events not listed use the defaults';
+COMMENT ON COLUMN public.user_preferences.quiet_hours_start IS 'Start of quiet hours as HH:MM in quiet_hours_timezone; NULL when quiet hours are off';
+COMMENT ON COLUMN public.user_preferences.quiet_hours_end IS 'End of quiet hours as HH:MM in quiet_hours_timezone; before the start when quiet hours pass midnight';
+
+CREATE TABLE IF NOT EXISTS public.deferred_pushes (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    event character varying(30) NOT NULL,
+    title character varying(255) NOT NULL,
+    body text NOT NULL,
+    image_url character varying(500),
+    data jsonb DEFAULT '{}'::jsonb,
+    priority character varying(20),
+    click_action character varying(500),
+    deliver_at timestamp without time zone NOT NULL,
+    created_at timestamp without time zone DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_deferred_pushes_user_id ON public.deferred_pushes USING btree (user_id);
+CREATE INDEX IF NOT EXISTS idx_deferred_pushes_deliver_at ON public.deferred_pushes USING btree (deliver_at);
+
+COMMENT ON TABLE public.deferred_pushes IS 'Pushes held back by the recipient''s quiet hours until deliver_at';
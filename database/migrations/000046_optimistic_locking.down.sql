@@ -0,0 +1,7 @@
+-- Migration: Optimistic locking of company and job edits
+-- Direction: down
+
+ALTER TABLE public.jobs DROP COLUMN IF EXISTS version;
+ALTER TABLE public.employer_users DROP COLUMN IF EXISTS version;
+ALTER TABLE public.company_profiles DROP COLUMN IF EXISTS version;
+ALTER TABLE public.companies DROP COLUMN IF EXISTS version;
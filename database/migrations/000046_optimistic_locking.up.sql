@@ -0,0 +1,22 @@
+-- Migration: Optimistic locking of company and job edits
+-- Description: Companies, company profiles, employer memberships and jobs get a version that
+-- every edit increments. Updates only apply when the row still has the version the editor
+-- loaded, so concurrent edits are reported as conflicts instead of overwriting each other.
+-- Direction: up
+
+ALTER TABLE public.companies
+    ADD COLUMN IF NOT EXISTS version bigint DEFAULT 1 NOT NULL;
+
+ALTER TABLE public.company_profiles
+    ADD COLUMN IF NOT EXISTS version bigint DEFAULT 1 NOT NULL;
+
+ALTER TABLE public.employer_users
+    ADD COLUMN IF NOT EXISTS version bigint DEFAULT 1 NOT NULL;
+
+ALTER TABLE public.jobs
+    ADD COLUMN IF NOT EXISTS version bigint DEFAULT 1 NOT NULL;
+
+COMMENT ON COLUMN public.companies.version IS 'Incremented by every edit; updates require the version the editor loaded';
+COMMENT ON COLUMN public.company_profiles.version IS 'Incremented by every edit; updates require the version the editor loaded';
+COMMENT ON COLUMN public.employer_users.version IS 'Incremented by every edit; updates require the version the editor loaded';
+COMMENT ON COLUMN public.jobs.version IS 'Incremented by every edit and status change; updates require the version the editor loaded';
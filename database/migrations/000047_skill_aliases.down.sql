@@ -0,0 +1,10 @@
+-- Migration: Skill aliases and skill autocomplete
+-- Direction: down
+-- pg_trgm is left installed, other objects may depend on it
+
+DROP INDEX IF EXISTS public.idx_skills_master_name_trgm;
+DROP INDEX IF EXISTS public.idx_skill_aliases_normalized_alias_trgm;
+DROP INDEX IF EXISTS public.idx_skill_aliases_merged_skill_id;
+DROP INDEX IF EXISTS public.idx_skill_aliases_skill_id;
+DROP INDEX IF EXISTS public.idx_skill_aliases_normalized_alias;
+DROP TABLE IF EXISTS public.skill_aliases;
@@ -0,0 +1,46 @@
+-- Migration: Skill aliases and skill autocomplete
+-- Description: Alternate skill names ("Golang", "go-lang") map to one canonical
+-- skills_master entry. Merging a duplicate skill into its canonical skill records the
+-- duplicate's names here together with its ID, so old IDs and names keep resolving. Skill
+-- autocomplete matches canonical names and aliases by prefix and by trigram similarity.
+-- Direction: up
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE TABLE IF NOT EXISTS public.skill_aliases (
+    id bigserial PRIMARY KEY,
+    skill_id bigint NOT NULL REFERENCES public.skills_master(id) ON DELETE CASCADE,
+    alias character varying(150) NOT NULL,
+    normalized_alias character varying(150) NOT NULL,
+    merged_skill_id bigint,
+    created_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE public.skill_aliases IS 'Alternate names of skills_master entries';
+COMMENT ON COLUMN public.skill_aliases.normalized_alias IS 'Alias lowercased with whitespace collapsed, as skill names are compared';
+COMMENT ON COLUMN public.skill_aliases.merged_skill_id IS 'ID of the skill merged into skill_id that this alias was recorded for';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_skill_aliases_normalized_alias ON public.skill_aliases USING btree (normalized_alias);
+CREATE INDEX IF NOT EXISTS idx_skill_aliases_skill_id ON public.skill_aliases USING btree (skill_id);
+CREATE INDEX IF NOT EXISTS idx_skill_aliases_merged_skill_id ON public.skill_aliases USING btree (merged_skill_id) WHERE merged_skill_id IS NOT NULL;
+
+-- Trigram indexes serve both the prefix (LIKE 'go%') and the similarity (%) matches of autocomplete
+CREATE INDEX IF NOT EXISTS idx_skill_aliases_normalized_alias_trgm ON public.skill_aliases USING gin (normalized_alias gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_skills_master_name_trgm ON public.skills_master USING gin (lower((name)::text) gin_trgm_ops);
+
+-- Existing aliases that are not some skill's own name become alias rows; an alias shared
+-- by several skills goes to the lowest ID
+INSERT INTO public.skill_aliases (skill_id, alias, normalized_alias)
+SELECT DISTINCT ON (a.normalized_alias) a.skill_id, a.alias, a.normalized_alias
+FROM (
+    SELECT sm.id AS skill_id, btrim(alias) AS alias,
+           lower(regexp_replace(btrim(alias), '\s+', ' ', 'g')) AS normalized_alias
+    FROM public.skills_master sm, unnest(sm.aliases) AS alias
+) a
+WHERE a.normalized_alias <> ''
+  AND NOT EXISTS (
+      SELECT 1 FROM public.skills_master s
+      WHERE lower(regexp_replace(btrim(s.name), '\s+', ' ', 'g')) = a.normalized_alias
+  )
+ORDER BY a.normalized_alias, a.skill_id
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,4 @@
+-- Migration: Company email templates
+-- Direction: down
+
+DROP TABLE IF EXISTS public.company_email_templates;
@@ -0,0 +1,24 @@
+-- Migration: Company email templates
+-- Description: A company's own subject and body for the candidate emails of rejections,
+-- shortlists, interview invitations and offers. Templates use {{variable}} placeholders from
+-- a fixed list per event; emails without a company template use the platform default.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.company_email_templates (
+    id bigserial PRIMARY KEY,
+    company_id bigint NOT NULL REFERENCES public.companies(id) ON DELETE CASCADE,
+    event_type character varying(50) NOT NULL,
+    subject character varying(200) NOT NULL,
+    body text NOT NULL,
+    updated_by bigint REFERENCES public.users(id) ON DELETE SET NULL,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL,
+    CONSTRAINT company_email_templates_event_type_check CHECK (event_type IN (
+        'application_rejected', 'application_shortlisted', 'interview_scheduled', 'offer_made'
+    ))
+);
+
+COMMENT ON TABLE public.company_email_templates IS 'Company versions of candidate emails, sent instead of the platform default';
+COMMENT ON COLUMN public.company_email_templates.body IS 'HTML with {{variable}} placeholders; variable values are HTML-escaped when rendered';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_company_email_templates_company_event ON public.company_email_templates USING btree (company_id, event_type);
@@ -0,0 +1,7 @@
+-- Migration: Talent pools
+-- Direction: down
+
+DROP TABLE IF EXISTS public.talent_pool_members;
+DROP TABLE IF EXISTS public.company_talent_pools;
+
+ALTER TABLE public.user_preferences DROP COLUMN IF EXISTS allow_talent_pools;
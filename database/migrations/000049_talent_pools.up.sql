@@ -0,0 +1,39 @@
+-- Migration: Talent pools
+-- Description: Named lists of candidates a company keeps to reach out to for future roles,
+-- usually strong applicants turned down because the role was filled. Candidates can opt out
+-- through user_preferences.allow_talent_pools, which hides them from every pool.
+-- Direction: up
+
+ALTER TABLE public.user_preferences ADD COLUMN IF NOT EXISTS allow_talent_pools boolean DEFAULT true NOT NULL;
+
+COMMENT ON COLUMN public.user_preferences.allow_talent_pools IS 'False hides the user from company talent pools and keeps them from being added';
+
+CREATE TABLE IF NOT EXISTS public.company_talent_pools (
+    id bigserial PRIMARY KEY,
+    company_id bigint NOT NULL REFERENCES public.companies(id) ON DELETE CASCADE,
+    name character varying(100) NOT NULL,
+    description text,
+    created_by bigint REFERENCES public.users(id) ON DELETE SET NULL,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_company_talent_pools_company_name ON public.company_talent_pools USING btree (company_id, lower(name));
+
+CREATE TABLE IF NOT EXISTS public.talent_pool_members (
+    id bigserial PRIMARY KEY,
+    pool_id bigint NOT NULL REFERENCES public.company_talent_pools(id) ON DELETE CASCADE,
+    user_id bigint NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    source_application_id bigint REFERENCES public.job_applications(id) ON DELETE SET NULL,
+    tags text[] DEFAULT '{}'::text[] NOT NULL,
+    notes text,
+    added_by bigint REFERENCES public.users(id) ON DELETE SET NULL,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON COLUMN public.talent_pool_members.tags IS 'Lowercased recruiter tags, filtered with @>';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_talent_pool_members_pool_user ON public.talent_pool_members USING btree (pool_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_talent_pool_members_user ON public.talent_pool_members USING btree (user_id);
+CREATE INDEX IF NOT EXISTS idx_talent_pool_members_tags ON public.talent_pool_members USING gin (tags);
@@ -0,0 +1,16 @@
+-- Migration: Status constraints
+-- Direction: down
+-- Suspended verifications become rejected, the closest status the old constraint allows.
+-- The NOT NULL constraints are dropped; the CHECK constraints stay as they were before.
+
+UPDATE public.company_verifications SET status = 'rejected' WHERE status = 'suspended';
+
+ALTER TABLE public.company_verifications DROP CONSTRAINT IF EXISTS company_verifications_status_check;
+ALTER TABLE public.company_verifications ADD CONSTRAINT company_verifications_status_check
+    CHECK (status IN ('pending', 'under_review', 'verified', 'rejected', 'blacklisted', 'expired'));
+
+ALTER TABLE public.employer_users ALTER COLUMN role DROP NOT NULL;
+ALTER TABLE public.company_verifications ALTER COLUMN status DROP NOT NULL;
+ALTER TABLE public.company_reviews ALTER COLUMN status DROP NOT NULL;
+ALTER TABLE public.job_applications ALTER COLUMN status DROP NOT NULL;
+ALTER TABLE public.jobs ALTER COLUMN status DROP NOT NULL;
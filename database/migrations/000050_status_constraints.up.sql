@@ -0,0 +1,49 @@
+-- Migration: Status constraints
+-- Description: The status and role columns the Go status constants describe get CHECK
+-- constraints listing exactly those values, and NOT NULL after NULLs are backfilled with
+-- the column default. Company verifications may now be suspended, which admins set when
+-- taking a badge away. Constraints are added NOT VALID and validated separately so existing
+-- rows are checked without blocking writes.
+-- Direction: up
+
+UPDATE public.jobs SET status = 'draft' WHERE status IS NULL;
+UPDATE public.job_applications SET status = 'applied' WHERE status IS NULL;
+UPDATE public.company_reviews SET status = 'pending' WHERE status IS NULL;
+UPDATE public.company_verifications SET status = 'pending' WHERE status IS NULL;
+UPDATE public.employer_users SET role = 'recruiter' WHERE role IS NULL;
+
+ALTER TABLE public.jobs ALTER COLUMN status SET NOT NULL;
+ALTER TABLE public.job_applications ALTER COLUMN status SET NOT NULL;
+ALTER TABLE public.company_reviews ALTER COLUMN status SET NOT NULL;
+ALTER TABLE public.company_verifications ALTER COLUMN status SET NOT NULL;
+ALTER TABLE public.employer_users ALTER COLUMN role SET NOT NULL;
+
+ALTER TABLE public.jobs DROP CONSTRAINT IF EXISTS jobs_status_check;
+ALTER TABLE public.jobs ADD CONSTRAINT jobs_status_check
+    CHECK (status IN ('in_review', 'draft', 'pending_review', 'published', 'inactive', 'closed', 'expired', 'suspended', 'rejected')) NOT VALID;
+ALTER TABLE public.jobs VALIDATE CONSTRAINT jobs_status_check;
+
+ALTER TABLE public.job_applications DROP CONSTRAINT IF EXISTS job_applications_status_check;
+ALTER TABLE public.job_applications ADD CONSTRAINT job_applications_status_check
+    CHECK (status IN ('applied', 'screening', 'shortlisted', 'interview', 'offered', 'hired', 'rejected', 'withdrawn', 'job_withdrawn')) NOT VALID;
+ALTER TABLE public.job_applications VALIDATE CONSTRAINT job_applications_status_check;
+
+ALTER TABLE public.company_reviews DROP CONSTRAINT IF EXISTS company_reviews_status_check;
+ALTER TABLE public.company_reviews ADD CONSTRAINT company_reviews_status_check
+    CHECK (status IN ('pending', 'approved', 'rejected', 'hidden')) NOT VALID;
+ALTER TABLE public.company_reviews VALIDATE CONSTRAINT company_reviews_status_check;
+
+ALTER TABLE public.company_verifications DROP CONSTRAINT IF EXISTS company_verifications_status_check;
+ALTER TABLE public.company_verifications ADD CONSTRAINT company_verifications_status_check
+    CHECK (status IN ('pending', 'under_review', 'verified', 'rejected', 'suspended', 'blacklisted', 'expired')) NOT VALID;
+ALTER TABLE public.company_verifications VALIDATE CONSTRAINT company_verifications_status_check;
+
+ALTER TABLE public.company_invitations DROP CONSTRAINT IF EXISTS company_invitations_status_check;
+ALTER TABLE public.company_invitations ADD CONSTRAINT company_invitations_status_check
+    CHECK (status IN ('pending', 'accepted', 'rejected', 'expired')) NOT VALID;
+ALTER TABLE public.company_invitations VALIDATE CONSTRAINT company_invitations_status_check;
+
+ALTER TABLE public.employer_users DROP CONSTRAINT IF EXISTS employer_users_role_check;
+ALTER TABLE public.employer_users ADD CONSTRAINT employer_users_role_check
+    CHECK (role IN ('owner', 'admin', 'recruiter', 'viewer')) NOT VALID;
+ALTER TABLE public.employer_users VALIDATE CONSTRAINT employer_users_role_check;
@@ -0,0 +1,6 @@
+-- Migration: Admin impersonation
+-- Direction: down
+
+UPDATE public.admin_roles SET permissions = array_remove(permissions, 'users.impersonate');
+
+DROP TABLE IF EXISTS public.admin_impersonation_sessions;
@@ -0,0 +1,25 @@
+-- Migration: Admin impersonation
+-- Description: Admins handling a support request can view the API as a user through a
+-- short-lived, read-only token. Each token belongs to an impersonation session, which the
+-- admin can end early; the auth middleware rejects tokens of ended or expired sessions.
+-- Admin roles at access level 9+ (super admin) are granted the new users.impersonate permission.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.admin_impersonation_sessions (
+    id bigserial PRIMARY KEY,
+    admin_id bigint NOT NULL REFERENCES public.admin_users(id) ON DELETE CASCADE,
+    user_id bigint NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    reason text NOT NULL,
+    expires_at timestamp without time zone NOT NULL,
+    ended_at timestamp without time zone,
+    created_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON COLUMN public.admin_impersonation_sessions.ended_at IS 'Set when the admin ends the session before expires_at';
+
+CREATE INDEX IF NOT EXISTS idx_admin_impersonation_sessions_admin ON public.admin_impersonation_sessions USING btree (admin_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_admin_impersonation_sessions_user ON public.admin_impersonation_sessions USING btree (user_id, created_at DESC);
+
+UPDATE public.admin_roles
+SET permissions = array_append(permissions, 'users.impersonate')
+WHERE access_level >= 9 AND NOT ('users.impersonate' = ANY (permissions));
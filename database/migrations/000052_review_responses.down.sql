@@ -0,0 +1,4 @@
+-- Migration: Review responses
+-- Direction: down
+
+DROP TABLE IF EXISTS public.review_responses;
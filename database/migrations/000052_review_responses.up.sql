@@ -0,0 +1,20 @@
+-- Migration: Review responses
+-- Description: Company owners and admins can post one public response to each approved review.
+-- The response is shown with the review; edited_at is set whenever the response is edited.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.review_responses (
+    id bigserial PRIMARY KEY,
+    review_id bigint NOT NULL REFERENCES public.company_reviews(id) ON DELETE CASCADE,
+    company_id bigint NOT NULL REFERENCES public.companies(id) ON DELETE CASCADE,
+    responder_employer_user_id bigint REFERENCES public.employer_users(id) ON DELETE SET NULL,
+    body text NOT NULL,
+    edited_at timestamp without time zone,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL,
+    CONSTRAINT review_responses_review_id_key UNIQUE (review_id)
+);
+
+COMMENT ON COLUMN public.review_responses.edited_at IS 'Set when the response is edited after it was posted';
+
+CREATE INDEX IF NOT EXISTS idx_review_responses_company ON public.review_responses USING btree (company_id);
@@ -0,0 +1,14 @@
+-- Migration: Application source attribution
+-- Direction: down
+
+ALTER TABLE public.job_view_events
+    DROP COLUMN IF EXISTS referrer,
+    DROP COLUMN IF EXISTS utm_campaign,
+    DROP COLUMN IF EXISTS utm_medium,
+    DROP COLUMN IF EXISTS utm_source;
+
+ALTER TABLE public.job_applications
+    DROP COLUMN IF EXISTS referrer,
+    DROP COLUMN IF EXISTS utm_campaign,
+    DROP COLUMN IF EXISTS utm_medium,
+    DROP COLUMN IF EXISTS utm_source;
@@ -0,0 +1,17 @@
+-- Migration: Application source attribution
+-- Description: Applications and job view events record the utm_source, utm_medium and
+-- utm_campaign of the job link the applicant or viewer followed, and the referring page.
+-- Source analytics group them by utm_source, with missing sources counted as "direct".
+-- Direction: up
+
+ALTER TABLE public.job_applications
+    ADD COLUMN IF NOT EXISTS utm_source character varying(100),
+    ADD COLUMN IF NOT EXISTS utm_medium character varying(100),
+    ADD COLUMN IF NOT EXISTS utm_campaign character varying(100),
+    ADD COLUMN IF NOT EXISTS referrer character varying(500);
+
+ALTER TABLE public.job_view_events
+    ADD COLUMN IF NOT EXISTS utm_source character varying(100),
+    ADD COLUMN IF NOT EXISTS utm_medium character varying(100),
+    ADD COLUMN IF NOT EXISTS utm_campaign character varying(100),
+    ADD COLUMN IF NOT EXISTS referrer character varying(500);
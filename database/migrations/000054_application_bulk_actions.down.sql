@@ -0,0 +1,5 @@
+-- Migration: Application bulk actions
+-- Direction: down
+
+DROP TABLE IF EXISTS public.application_bulk_action_items;
+DROP TABLE IF EXISTS public.application_bulk_actions;
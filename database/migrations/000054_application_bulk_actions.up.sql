@@ -0,0 +1,42 @@
+-- Migration: Application bulk actions
+-- Description: Recruiters queue moving up to 2000 applications to a status; a background job
+-- processes the items in batches and records each result, so progress can be polled and an
+-- interrupted action resumes with its pending items.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.application_bulk_actions (
+    id bigserial PRIMARY KEY,
+    company_id bigint NOT NULL REFERENCES public.companies(id) ON DELETE CASCADE,
+    created_by bigint NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    target_status character varying(20) NOT NULL,
+    notes text,
+    status character varying(20) DEFAULT 'queued'::character varying NOT NULL,
+    total_count integer DEFAULT 0 NOT NULL,
+    processed_count integer DEFAULT 0 NOT NULL,
+    succeeded_count integer DEFAULT 0 NOT NULL,
+    failed_count integer DEFAULT 0 NOT NULL,
+    skipped_count integer DEFAULT 0 NOT NULL,
+    started_at timestamp without time zone,
+    completed_at timestamp without time zone,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL,
+    CONSTRAINT application_bulk_actions_status_check CHECK (status IN ('queued', 'running', 'done'))
+);
+
+COMMENT ON COLUMN public.application_bulk_actions.skipped_count IS 'Requested application IDs that are not the company''s applications';
+
+CREATE INDEX IF NOT EXISTS idx_application_bulk_actions_company ON public.application_bulk_actions USING btree (company_id);
+CREATE INDEX IF NOT EXISTS idx_application_bulk_actions_unfinished ON public.application_bulk_actions USING btree (id) WHERE status <> 'done';
+
+CREATE TABLE IF NOT EXISTS public.application_bulk_action_items (
+    id bigserial PRIMARY KEY,
+    bulk_action_id bigint NOT NULL REFERENCES public.application_bulk_actions(id) ON DELETE CASCADE,
+    application_id bigint NOT NULL REFERENCES public.job_applications(id) ON DELETE CASCADE,
+    status character varying(20) DEFAULT 'pending'::character varying NOT NULL,
+    error text,
+    processed_at timestamp without time zone,
+    CONSTRAINT application_bulk_action_items_status_check CHECK (status IN ('pending', 'succeeded', 'failed')),
+    CONSTRAINT application_bulk_action_items_action_application_key UNIQUE (bulk_action_id, application_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_application_bulk_action_items_status ON public.application_bulk_action_items USING btree (bulk_action_id, status, id);
@@ -0,0 +1,5 @@
+-- Migration: User email verification timestamp
+-- Direction: down
+
+ALTER TABLE public.users
+    DROP COLUMN IF EXISTS email_verified_at;
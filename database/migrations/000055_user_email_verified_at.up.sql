@@ -0,0 +1,12 @@
+-- Migration: User email verification timestamp
+-- Description: users.email_verified_at records when the current email was verified. It drives
+-- the email verification grace period at sign in; is_verified is kept in step for existing
+-- readers. Accounts already flagged as verified are backfilled with the best timestamp available.
+-- Direction: up
+
+ALTER TABLE public.users
+    ADD COLUMN IF NOT EXISTS email_verified_at timestamp without time zone;
+
+UPDATE public.users
+SET email_verified_at = COALESCE(updated_at, created_at, now())
+WHERE is_verified = true AND email_verified_at IS NULL;
@@ -0,0 +1,8 @@
+-- Migration: Application file snapshots
+-- Direction: down
+
+ALTER TABLE public.application_documents
+    DROP COLUMN IF EXISTS source_url;
+
+ALTER TABLE public.job_applications
+    DROP COLUMN IF EXISTS resume_source_url;
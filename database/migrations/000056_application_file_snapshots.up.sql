@@ -0,0 +1,13 @@
+-- Migration: Application file snapshots
+-- Description: Resumes and documents attached from the applicant's document library are copied
+-- into the application's own storage at apply time, so the application keeps what was
+-- submitted when the library document is replaced or deleted. The *_source_url columns keep the
+-- library document each snapshot was copied from; NULL means the application still references
+-- the original file.
+-- Direction: up
+
+ALTER TABLE public.job_applications
+    ADD COLUMN IF NOT EXISTS resume_source_url text;
+
+ALTER TABLE public.application_documents
+    ADD COLUMN IF NOT EXISTS source_url text;
@@ -0,0 +1,11 @@
+-- Migration: Company plans and published job quotas
+-- Direction: down
+
+DROP INDEX IF EXISTS public.idx_jobs_company_published;
+
+DROP TABLE IF EXISTS public.company_plan_quotas;
+
+ALTER TABLE public.companies DROP CONSTRAINT IF EXISTS companies_plan_check;
+
+ALTER TABLE public.companies
+    DROP COLUMN IF EXISTS plan;
@@ -0,0 +1,24 @@
+-- Migration: Company plans and published job quotas
+-- Description: Companies are on a plan (free, verified or premium) that limits how many jobs
+-- they may have published at once. companies.plan is NULL for companies whose plan follows
+-- their verification: verified companies are on the verified plan, others on the free plan.
+-- The limits come from configuration; rows in company_plan_quotas override them per plan and
+-- are read on every check, so admins can change a limit without a restart.
+-- Direction: up
+
+ALTER TABLE public.companies
+    ADD COLUMN IF NOT EXISTS plan character varying(20);
+
+ALTER TABLE public.companies DROP CONSTRAINT IF EXISTS companies_plan_check;
+ALTER TABLE public.companies ADD CONSTRAINT companies_plan_check
+    CHECK (plan IS NULL OR plan IN ('free', 'verified', 'premium'));
+
+CREATE TABLE IF NOT EXISTS public.company_plan_quotas (
+    plan character varying(20) PRIMARY KEY CHECK (plan IN ('free', 'verified', 'premium')),
+    max_published_jobs integer NOT NULL CHECK (max_published_jobs >= 1),
+    updated_by bigint REFERENCES public.admin_users(id) ON DELETE SET NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
+-- Published job counts per company are taken on every publish
+CREATE INDEX IF NOT EXISTS idx_jobs_company_published ON public.jobs USING btree (company_id) WHERE status = 'published' AND deleted_at IS NULL;
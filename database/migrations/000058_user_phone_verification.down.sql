@@ -0,0 +1,8 @@
+-- Migration: User phone normalization and verification
+-- Description: Normalized phone numbers are kept in E.164 form.
+-- Direction: down
+
+DROP INDEX IF EXISTS public.idx_users_phone_verified;
+
+ALTER TABLE public.users
+    DROP COLUMN IF EXISTS phone_verified_at;
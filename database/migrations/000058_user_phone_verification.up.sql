@@ -0,0 +1,29 @@
+-- Migration: User phone normalization and verification
+-- Description: users.phone is stored in E.164 form (+6281234567890) so the same number is no
+-- longer stored as 0812..., 62 812-... and +62812.... Existing numbers in the common Indonesian
+-- formats are normalized here; numbers that don't look like one are left as they are and are
+-- normalized the next time the user saves their profile. users.phone_verified_at records when
+-- the user confirmed the number with an SMS code, and a verified number belongs to one account.
+-- Direction: up
+
+ALTER TABLE public.users
+    ADD COLUMN IF NOT EXISTS phone_verified_at timestamp without time zone;
+
+WITH digits AS (
+    SELECT id, regexp_replace(phone, '[\s().\-/]', '', 'g') AS phone
+    FROM public.users
+    WHERE phone IS NOT NULL
+)
+UPDATE public.users u
+SET phone = CASE
+        WHEN d.phone = '' THEN NULL
+        WHEN d.phone ~ '^0[2-9][0-9]{7,11}$' THEN '+62' || substring(d.phone FROM 2)
+        WHEN d.phone ~ '^62[2-9][0-9]{7,11}$' THEN '+' || d.phone
+        WHEN d.phone ~ '^\+62[2-9][0-9]{7,11}$' THEN d.phone
+        WHEN d.phone ~ '^8[0-9]{8,11}$' THEN '+62' || d.phone
+        ELSE u.phone
+    END
+FROM digits d
+WHERE u.id = d.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_phone_verified ON public.users USING btree (phone) WHERE phone_verified_at IS NOT NULL;
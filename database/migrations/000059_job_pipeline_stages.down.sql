@@ -0,0 +1,15 @@
+-- Migration: Per-job hiring pipeline stages
+-- Description: Removes job pipelines. Stage records of custom stages are renamed to their
+-- canonical status so the stage_name check can be restored.
+-- Direction: down
+
+UPDATE public.job_application_stages SET stage_name = status WHERE stage_name <> status;
+
+ALTER TABLE public.job_application_stages DROP CONSTRAINT IF EXISTS job_application_stages_status_check;
+ALTER TABLE public.job_application_stages DROP CONSTRAINT IF EXISTS job_application_stages_stage_name_check;
+ALTER TABLE public.job_application_stages ADD CONSTRAINT job_application_stages_stage_name_check
+    CHECK (stage_name IN ('applied', 'screening', 'shortlisted', 'interview', 'offered', 'hired', 'rejected', 'withdrawn', 'job_withdrawn'));
+
+ALTER TABLE public.job_application_stages DROP COLUMN IF EXISTS status;
+
+DROP TABLE IF EXISTS public.job_pipeline_stages;
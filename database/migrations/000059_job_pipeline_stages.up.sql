@@ -0,0 +1,49 @@
+-- Migration: Per-job hiring pipeline stages
+-- Description: Each job has a pipeline of stages. The canonical stages are named after the
+-- application statuses (applied, screening, shortlisted, interview, offered, hired); a job may
+-- add custom stages such as a technical test between them while it is draft or pending review.
+-- A custom stage maps to the status applications have while in it, so job_applications.status
+-- and the reports built on it stay canonical. job_application_stages.stage_name now records
+-- the pipeline stage, custom or not, and the new status column its canonical status.
+-- Existing jobs get the default pipeline of canonical stages.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.job_pipeline_stages (
+    id bigserial PRIMARY KEY,
+    job_id bigint NOT NULL REFERENCES public.jobs(id) ON DELETE CASCADE,
+    name character varying(50) NOT NULL,
+    stage_order integer NOT NULL,
+    maps_to_status character varying(30) NOT NULL
+        CHECK (maps_to_status IN ('applied', 'screening', 'shortlisted', 'interview', 'offered', 'hired')),
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    CONSTRAINT job_pipeline_stages_job_order_key UNIQUE (job_id, stage_order)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_job_pipeline_stages_job_name ON public.job_pipeline_stages USING btree (job_id, lower(name));
+
+INSERT INTO public.job_pipeline_stages (job_id, name, stage_order, maps_to_status)
+SELECT j.id, s.name, s.stage_order, s.name
+FROM public.jobs j
+CROSS JOIN (VALUES
+    ('applied', 1),
+    ('screening', 2),
+    ('shortlisted', 3),
+    ('interview', 4),
+    ('offered', 5),
+    ('hired', 6)
+) AS s(name, stage_order)
+WHERE NOT EXISTS (SELECT 1 FROM public.job_pipeline_stages p WHERE p.job_id = j.id);
+
+ALTER TABLE public.job_application_stages
+    ADD COLUMN IF NOT EXISTS status character varying(30);
+
+UPDATE public.job_application_stages SET status = stage_name WHERE status IS NULL;
+
+ALTER TABLE public.job_application_stages ALTER COLUMN status SET NOT NULL;
+
+ALTER TABLE public.job_application_stages DROP CONSTRAINT IF EXISTS job_application_stages_stage_name_check;
+ALTER TABLE public.job_application_stages DROP CONSTRAINT IF EXISTS job_application_stages_status_check;
+ALTER TABLE public.job_application_stages ADD CONSTRAINT job_application_stages_status_check
+    CHECK (status IN ('applied', 'screening', 'shortlisted', 'interview', 'offered', 'hired', 'rejected', 'withdrawn', 'job_withdrawn'));
+
+COMMENT ON COLUMN public.job_application_stages.status IS 'Canonical application status of the stage; differs from stage_name for custom pipeline stages';
@@ -0,0 +1,15 @@
+-- Migration: WhatsApp notifications
+-- Direction: down
+
+DROP INDEX IF EXISTS public.idx_whatsapp_deliveries_status;
+DROP INDEX IF EXISTS public.idx_whatsapp_deliveries_user_id;
+DROP TABLE IF EXISTS public.whatsapp_deliveries;
+
+UPDATE public.user_preferences
+SET notification_matrix = (
+    SELECT jsonb_object_agg(key, value - 'whatsapp')
+    FROM jsonb_each(notification_matrix)
+)
+WHERE notification_matrix <> '{}'::jsonb;
+
+COMMENT ON COLUMN public.user_preferences.notification_matrix IS 'Email and push choice per notification event; events not listed use the defaults';
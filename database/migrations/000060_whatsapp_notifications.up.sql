@@ -0,0 +1,41 @@
+-- Migration: WhatsApp notifications
+-- Description: Interview invitations, reschedules and cancellations and job offers are also
+-- sent by WhatsApp to candidates with a verified phone number. The notification matrix gains
+-- a whatsapp channel for the application status and interview events, on by default, so
+-- matrices saved before it existed get it turned on. whatsapp_deliveries records every
+-- WhatsApp message sent and whether the provider accepted it, for support.
+-- Direction: up
+
+UPDATE public.user_preferences
+SET notification_matrix = (
+    SELECT jsonb_object_agg(
+        key,
+        CASE
+            WHEN key IN ('application_status', 'interview') AND NOT value ? 'whatsapp'
+                THEN value || '{"whatsapp": true}'::jsonb
+            ELSE value
+        END
+    )
+    FROM jsonb_each(notification_matrix)
+)
+WHERE notification_matrix ?| ARRAY['application_status', 'interview'];
+
+COMMENT ON COLUMN public.user_preferences.notification_matrix IS 'Email, push and WhatsApp choice per notification event; events not listed use the defaults';
+
+CREATE TABLE IF NOT EXISTS public.whatsapp_deliveries (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    event character varying(30) NOT NULL,
+    template character varying(100) NOT NULL,
+    phone character varying(20) NOT NULL,
+    status character varying(20) NOT NULL,
+    provider_message_id character varying(255),
+    error text,
+    created_at timestamp without time zone DEFAULT now(),
+    CONSTRAINT whatsapp_deliveries_status_check CHECK (status IN ('sent', 'failed', 'template_rejected'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_whatsapp_deliveries_user_id ON public.whatsapp_deliveries USING btree (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_whatsapp_deliveries_status ON public.whatsapp_deliveries USING btree (status, created_at DESC);
+
+COMMENT ON TABLE public.whatsapp_deliveries IS 'WhatsApp template messages sent to users and how the provider took them';
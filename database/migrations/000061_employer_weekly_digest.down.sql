@@ -0,0 +1,5 @@
+-- Migration: Employer weekly digest
+-- Direction: down
+
+ALTER TABLE public.company_settings DROP COLUMN IF EXISTS weekly_digest_sent_at;
+ALTER TABLE public.company_settings DROP COLUMN IF EXISTS weekly_digest_enabled;
@@ -0,0 +1,11 @@
+-- Migration: Employer weekly digest
+-- Description: Adds a company setting to opt out of the weekly digest email sent to
+-- recruiters and records when each company was last sent one, so a rerun of the digest
+-- job does not send it twice.
+-- Direction: up
+
+ALTER TABLE public.company_settings ADD COLUMN IF NOT EXISTS weekly_digest_enabled boolean DEFAULT true NOT NULL;
+ALTER TABLE public.company_settings ADD COLUMN IF NOT EXISTS weekly_digest_sent_at timestamp without time zone;
+
+COMMENT ON COLUMN public.company_settings.weekly_digest_enabled IS 'Send recruiters the weekly digest of jobs and pipeline activity';
+COMMENT ON COLUMN public.company_settings.weekly_digest_sent_at IS 'When the company was last sent the weekly digest';
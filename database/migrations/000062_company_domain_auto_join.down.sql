@@ -0,0 +1,14 @@
+-- Migration: Company domain auto-join
+-- Direction: down
+
+-- Pending join requests never became memberships
+DELETE FROM public.employer_users WHERE join_requested_at IS NOT NULL;
+
+DROP INDEX IF EXISTS public.idx_employer_users_join_requests;
+ALTER TABLE public.employer_users DROP COLUMN IF EXISTS join_requested_at;
+
+ALTER TABLE public.company_settings DROP CONSTRAINT IF EXISTS company_settings_auto_join_role_check;
+ALTER TABLE public.company_settings DROP COLUMN IF EXISTS auto_join_role;
+ALTER TABLE public.company_settings DROP COLUMN IF EXISTS auto_join_enabled;
+
+DROP TABLE IF EXISTS public.company_domain_verifications;
@@ -0,0 +1,37 @@
+-- Migration: Company domain auto-join
+-- Description: Companies prove they own their email domain with a code sent to
+-- postmaster@domain or a DNS TXT record. Once verified and with auto-join enabled, users with
+-- a verified email on the domain can ask to join; the membership stays inactive with
+-- join_requested_at set until an owner or admin approves it.
+-- Direction: up
+
+CREATE TABLE IF NOT EXISTS public.company_domain_verifications (
+    company_id bigint PRIMARY KEY REFERENCES public.companies(id) ON DELETE CASCADE,
+    domain character varying(100) NOT NULL,
+    method character varying(10) NOT NULL,
+    token character varying(128) NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    expires_at timestamp without time zone,
+    verified_at timestamp without time zone,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL,
+    CONSTRAINT company_domain_verifications_method_check CHECK (((method)::text = ANY (ARRAY[('email'::character varying)::text, ('dns'::character varying)::text])))
+);
+
+COMMENT ON COLUMN public.company_domain_verifications.token IS 'SHA-256 of the emailed code, or the TXT record token for DNS verification';
+
+CREATE INDEX IF NOT EXISTS idx_company_domain_verifications_domain ON public.company_domain_verifications USING btree (domain) WHERE verified_at IS NOT NULL;
+
+ALTER TABLE public.company_settings ADD COLUMN IF NOT EXISTS auto_join_enabled boolean DEFAULT false NOT NULL;
+ALTER TABLE public.company_settings ADD COLUMN IF NOT EXISTS auto_join_role character varying(30) DEFAULT 'viewer' NOT NULL;
+ALTER TABLE public.company_settings DROP CONSTRAINT IF EXISTS company_settings_auto_join_role_check;
+ALTER TABLE public.company_settings ADD CONSTRAINT company_settings_auto_join_role_check CHECK (((auto_join_role)::text = ANY (ARRAY[('admin'::character varying)::text, ('recruiter'::character varying)::text, ('viewer'::character varying)::text])));
+
+COMMENT ON COLUMN public.company_settings.auto_join_enabled IS 'Let users with a verified email on the verified company domain ask to join';
+COMMENT ON COLUMN public.company_settings.auto_join_role IS 'Role given to users joining through the company domain';
+
+ALTER TABLE public.employer_users ADD COLUMN IF NOT EXISTS join_requested_at timestamp without time zone;
+
+COMMENT ON COLUMN public.employer_users.join_requested_at IS 'Set while a domain join request waits for an owner or admin to approve it';
+
+CREATE INDEX IF NOT EXISTS idx_employer_users_join_requests ON public.employer_users USING btree (company_id, join_requested_at) WHERE join_requested_at IS NOT NULL;
@@ -0,0 +1,6 @@
+-- Migration: Job view retention
+-- Direction: down
+
+COMMENT ON COLUMN public.job_view_events.ip_hash IS NULL;
+
+DROP INDEX IF EXISTS public.idx_job_view_events_viewed_at;
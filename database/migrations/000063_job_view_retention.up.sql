@@ -0,0 +1,11 @@
+-- Migration: Job view retention
+-- Description: View events older than the retention period are deleted daily, which needs an
+-- index on viewed_at. IP hashes are now keyed with a server secret; the unkeyed hashes stored
+-- so far could be reversed by hashing every IPv4 address, so they are dropped.
+-- Direction: up
+
+CREATE INDEX IF NOT EXISTS idx_job_view_events_viewed_at ON public.job_view_events USING btree (viewed_at);
+
+UPDATE public.job_view_events SET ip_hash = NULL WHERE ip_hash IS NOT NULL;
+
+COMMENT ON COLUMN public.job_view_events.ip_hash IS 'HMAC-SHA256 of the anonymous viewer''s IP, keyed with a server secret';
@@ -0,0 +1,6 @@
+-- Migration: Bulk action employer user
+-- Direction: down
+
+ALTER TABLE public.application_bulk_actions
+    DROP COLUMN IF EXISTS employer_role,
+    DROP COLUMN IF EXISTS employer_user_id;
@@ -0,0 +1,14 @@
+-- Migration: Bulk action employer user
+-- Description: Bulk actions store the employer user that queued them and its role, so items
+-- are moved as that membership of the action's company rather than looked up again from the
+-- creator's user ID. Existing actions are backfilled from the creator's membership.
+-- Direction: up
+
+ALTER TABLE public.application_bulk_actions
+    ADD COLUMN IF NOT EXISTS employer_user_id bigint REFERENCES public.employer_users(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS employer_role character varying(30);
+
+UPDATE public.application_bulk_actions a
+SET employer_user_id = eu.id, employer_role = eu.role
+FROM public.employer_users eu
+WHERE eu.user_id = a.created_by AND eu.company_id = a.company_id AND a.employer_user_id IS NULL;
@@ -0,0 +1,452 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TableName is the bookkeeping table that records applied migration versions.
+// It deliberately differs from golang-migrate's schema_migrations, which has a
+// single (version, dirty) row and is imported once by importLegacyVersion.
+const TableName = "keerja_migrations"
+
+// legacyTableName is the table golang-migrate used before this migrator existed
+const legacyTableName = "schema_migrations"
+
+const (
+	createTableSQL = `CREATE TABLE IF NOT EXISTS public.` + TableName + ` (
+	version bigint PRIMARY KEY,
+	name character varying(255) NOT NULL,
+	applied_at timestamp without time zone NOT NULL DEFAULT now()
+)`
+	insertVersionSQL = "INSERT INTO public." + TableName + " (version, name) VALUES ($1, $2)"
+	deleteVersionSQL = "DELETE FROM public." + TableName + " WHERE version = $1"
+)
+
+// Migration represents a versioned pair of up/down SQL files
+type Migration struct {
+	Version  int64
+	Name     string
+	UpPath   string
+	DownPath string
+}
+
+// Status describes whether a migration has been applied
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// Load reads the migrations directory and returns migrations sorted by version.
+// Files must be named <version>_<name>.up.sql / <version>_<name>.down.sql.
+func Load(dir string) ([]Migration, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		name := entry.Name()
+		var base string
+		var isUp bool
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			base, isUp = strings.TrimSuffix(name, ".up.sql"), true
+		case strings.HasSuffix(name, ".down.sql"):
+			base = strings.TrimSuffix(name, ".down.sql")
+		default:
+			continue
+		}
+
+		parts := strings.SplitN(base, "_", 2)
+		version, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", name, err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version}
+			if len(parts) == 2 {
+				m.Name = parts[1]
+			}
+			byVersion[version] = m
+		}
+
+		path := filepath.Join(dir, name)
+		if isUp {
+			if m.UpPath != "" {
+				return nil, fmt.Errorf("duplicate up migration for version %d", version)
+			}
+			m.UpPath = path
+		} else {
+			if m.DownPath != "" {
+				return nil, fmt.Errorf("duplicate down migration for version %d", version)
+			}
+			m.DownPath = path
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.UpPath == "" {
+			return nil, fmt.Errorf("migration %d has no up file", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// PlanUp returns the pending migrations in ascending order.
+// steps <= 0 means all pending migrations.
+func PlanUp(all []Migration, applied map[int64]time.Time, steps int) []Migration {
+	var pending []Migration
+	for _, m := range all {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+		pending = append(pending, m)
+		if steps > 0 && len(pending) == steps {
+			break
+		}
+	}
+	return pending
+}
+
+// PlanDown returns the applied migrations to roll back, newest first.
+// steps <= 0 means all applied migrations.
+func PlanDown(all []Migration, applied map[int64]time.Time, steps int) []Migration {
+	var rollback []Migration
+	for i := len(all) - 1; i >= 0; i-- {
+		if _, ok := applied[all[i].Version]; !ok {
+			continue
+		}
+		rollback = append(rollback, all[i])
+		if steps > 0 && len(rollback) == steps {
+			break
+		}
+	}
+	return rollback
+}
+
+// PlanTo returns the migrations to roll back (newest first) and then apply
+// (oldest first) so that exactly the migrations up to version are applied.
+// version 0 rolls back everything.
+func PlanTo(all []Migration, applied map[int64]time.Time, version int64) (down, up []Migration) {
+	for i := len(all) - 1; i >= 0; i-- {
+		if _, ok := applied[all[i].Version]; ok && all[i].Version > version {
+			down = append(down, all[i])
+		}
+	}
+	for _, m := range all {
+		if _, ok := applied[m.Version]; !ok && m.Version <= version {
+			up = append(up, m)
+		}
+	}
+	return down, up
+}
+
+// Migrator applies migrations and records them in keerja_migrations
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New creates a migrator for the given directory
+func New(db *sql.DB, dir string) (*Migrator, error) {
+	migrations, err := Load(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Migrations returns all migrations known to the migrator
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Applied returns the applied versions and when they were applied
+func (m *Migrator) Applied(ctx context.Context) (map[int64]time.Time, error) {
+	if _, err := m.db.ExecContext(ctx, createTableSQL); err != nil {
+		return nil, fmt.Errorf("failed to create %s table: %w", TableName, err)
+	}
+
+	applied, err := m.readApplied(ctx)
+	if err != nil || len(applied) > 0 {
+		return applied, err
+	}
+
+	imported, err := m.importLegacyVersion(ctx)
+	if err != nil || !imported {
+		return applied, err
+	}
+	return m.readApplied(ctx)
+}
+
+func (m *Migrator) readApplied(ctx context.Context) (map[int64]time.Time, error) {
+	rows, err := m.db.QueryContext(ctx, "SELECT version, applied_at FROM public."+TableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", TableName, err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// importLegacyVersion records every known migration up to the version stored in
+// golang-migrate's schema_migrations table, so databases migrated with the
+// migrate CLI keep their history. It reports whether anything was imported.
+func (m *Migrator) importLegacyVersion(ctx context.Context) (bool, error) {
+	var isLegacy bool
+	err := m.db.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = 'public' AND table_name = $1 AND column_name = 'dirty')",
+		legacyTableName,
+	).Scan(&isLegacy)
+	if err != nil {
+		return false, fmt.Errorf("failed to inspect database: %w", err)
+	}
+	if !isLegacy {
+		return false, nil
+	}
+
+	var version int64
+	var dirty bool
+	err = m.db.QueryRowContext(ctx, "SELECT version, dirty FROM public."+legacyTableName+" LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", legacyTableName, err)
+	}
+	if dirty {
+		return false, fmt.Errorf("%s marks version %d as dirty; fix the database and clear the flag before migrating", legacyTableName, version)
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	imported := false
+	for _, mig := range m.migrations {
+		if mig.Version > version {
+			break
+		}
+		if _, err := tx.ExecContext(ctx, insertVersionSQL, mig.Version, mig.Name); err != nil {
+			return false, fmt.Errorf("failed to import migration %d: %w", mig.Version, err)
+		}
+		imported = true
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return imported, nil
+}
+
+// Status returns every known migration with its applied state
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		s := Status{Migration: mig}
+		if at, ok := applied[mig.Version]; ok {
+			at := at
+			s.Applied = true
+			s.AppliedAt = &at
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses, nil
+}
+
+// Up applies pending migrations (steps <= 0 applies all) and returns the ones applied
+func (m *Migrator) Up(ctx context.Context, steps int) ([]Migration, error) {
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(applied) == 0 {
+		if err := m.ensureFreshDatabase(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return m.apply(ctx, PlanUp(m.migrations, applied, steps))
+}
+
+// Down rolls back applied migrations (steps <= 0 rolls back all) and returns the ones rolled back
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.rollback(ctx, PlanDown(m.migrations, applied, steps))
+}
+
+// To migrates up or down until exactly the migrations up to version are applied,
+// returning the ones rolled back and the ones applied. version 0 rolls back everything.
+func (m *Migrator) To(ctx context.Context, version int64) (rolledBack, applied []Migration, err error) {
+	if version != 0 && !m.has(version) {
+		return nil, nil, fmt.Errorf("unknown migration version %d", version)
+	}
+
+	current, err := m.Applied(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(current) == 0 && version > 0 {
+		if err := m.ensureFreshDatabase(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	down, up := PlanTo(m.migrations, current, version)
+	if rolledBack, err = m.rollback(ctx, down); err != nil {
+		return rolledBack, nil, err
+	}
+	applied, err = m.apply(ctx, up)
+	return rolledBack, applied, err
+}
+
+func (m *Migrator) has(version int64) bool {
+	for _, mig := range m.migrations {
+		if mig.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// apply runs the up files of the given migrations in order
+func (m *Migrator) apply(ctx context.Context, migrations []Migration) ([]Migration, error) {
+	var done []Migration
+	for _, mig := range migrations {
+		if err := m.run(ctx, mig.UpPath, func(tx *sql.Tx) error {
+			// The table is (re)created inside the transaction because a migration
+			// may reset the schema (see 000001_initial_schema.up.sql).
+			if _, err := tx.ExecContext(ctx, createTableSQL); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, insertVersionSQL, mig.Version, mig.Name)
+			return err
+		}); err != nil {
+			return done, fmt.Errorf("migration %d (%s) failed: %w", mig.Version, mig.Name, err)
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+// rollback runs the down files of the given migrations in order
+func (m *Migrator) rollback(ctx context.Context, migrations []Migration) ([]Migration, error) {
+	var done []Migration
+	for _, mig := range migrations {
+		if mig.DownPath == "" {
+			return done, fmt.Errorf("migration %d (%s) has no down file", mig.Version, mig.Name)
+		}
+		if err := m.run(ctx, mig.DownPath, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, createTableSQL); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, deleteVersionSQL, mig.Version)
+			return err
+		}); err != nil {
+			return done, fmt.Errorf("rollback %d (%s) failed: %w", mig.Version, mig.Name, err)
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+// Baseline records migrations as applied without executing them.
+// Used once on databases that were migrated before version tracking existed.
+func (m *Migrator) Baseline(ctx context.Context, steps int) ([]Migration, error) {
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, mig := range PlanUp(m.migrations, applied, steps) {
+		if _, err := m.db.ExecContext(ctx, insertVersionSQL, mig.Version, mig.Name); err != nil {
+			return done, fmt.Errorf("failed to baseline migration %d: %w", mig.Version, err)
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+// run executes a migration file and the bookkeeping statement in a single transaction
+func (m *Migrator) run(ctx context.Context, path string, record func(tx *sql.Tx) error) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, string(content)); err != nil {
+		return err
+	}
+	if err := record(tx); err != nil {
+		return fmt.Errorf("failed to record migration: %w", err)
+	}
+	return tx.Commit()
+}
+
+// ensureFreshDatabase guards against re-running the initial schema on a database
+// that was migrated before version tracking existed.
+func (m *Migrator) ensureFreshDatabase(ctx context.Context) error {
+	var exists bool
+	err := m.db.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_schema = 'public' AND table_name = 'users')",
+	).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to inspect database: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("database already has tables but %s is empty; run with -baseline to record existing migrations first", TableName)
+	}
+	return nil
+}
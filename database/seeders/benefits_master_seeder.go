@@ -2,16 +2,12 @@ package seeders
 
 import (
 	"keerja-backend/internal/domain/master"
-	"log"
 
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
 // BenefitsMasterSeeder seeds the benefits_master table
-func BenefitsMasterSeeder(db *gorm.DB) error {
-	log.Println("Seeding benefits_master table...")
-
+func BenefitsMasterSeeder(db *gorm.DB) (int64, error) {
 	benefits := []master.BenefitsMaster{
 		// Financial Benefits
 		{Code: "COMPETITIVE_SALARY", Name: "Competitive Salary", Category: "financial", Description: "Above-market salary package", Icon: "💰", PopularityScore: 95, IsActive: true},
@@ -75,17 +71,5 @@ func BenefitsMasterSeeder(db *gorm.DB) error {
 		{Code: "REFERRAL_BONUS", Name: "Referral Bonus", Category: "other", Description: "Bonus for successful referrals", Icon: "👥", PopularityScore: 70, IsActive: true},
 	}
 
-	// Use OnConflict to update existing benefits or create new ones
-	result := db.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "code"}},
-		DoUpdates: clause.AssignmentColumns([]string{"name", "category", "description", "icon", "popularity_score", "is_active", "updated_at"}),
-	}).Create(&benefits)
-
-	if result.Error != nil {
-		log.Printf("Failed to seed benefits: %v", result.Error)
-		return result.Error
-	}
-
-	log.Printf("Successfully seeded %d benefits", len(benefits))
-	return nil
+	return upsert(db, &benefits, []string{"code"}, []string{"name", "category", "description", "icon", "popularity_score", "is_active", "updated_at"})
 }
@@ -2,21 +2,23 @@ package seeders
 
 import (
 	"keerja-backend/internal/domain/company"
-	"log"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
 // CompaniesSeeder seeds sample companies for development/testing
-func CompaniesSeeder(db *gorm.DB) error {
-	log.Println("Seeding sample companies...")
+func CompaniesSeeder(db *gorm.DB) (int64, error) {
+	companies := demoCompanies()
+	return upsert(db, &companies, []string{"slug"}, []string{"company_name", "industry", "company_type", "size_category", "website_url", "city", "province", "about", "is_active", "updated_at"})
+}
 
+// demoCompanies returns the sample companies, which are identified by slug
+func demoCompanies() []company.Company {
 	// Helper function to create string pointer
 	strPtr := func(s string) *string { return &s }
 
-	companies := []company.Company{
+	return []company.Company{
 		{
 			UUID:         uuid.New(),
 			CompanyName:  "Gojek Indonesia",
@@ -274,18 +276,4 @@ func CompaniesSeeder(db *gorm.DB) error {
 			IsActive:     true,
 		},
 	}
-
-	// Use OnConflict to update existing companies or create new ones
-	result := db.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "slug"}},
-		DoUpdates: clause.AssignmentColumns([]string{"company_name", "industry", "company_type", "size_category", "website_url", "city", "province", "about", "is_active", "updated_at"}),
-	}).Create(&companies)
-
-	if result.Error != nil {
-		log.Printf("Failed to seed companies: %v", result.Error)
-		return result.Error
-	}
-
-	log.Printf("Successfully seeded %d companies", len(companies))
-	return nil
 }
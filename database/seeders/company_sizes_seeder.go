@@ -1,8 +1,6 @@
 package seeders
 
 import (
-	"log"
-
 	"gorm.io/gorm"
 )
 
@@ -20,9 +18,7 @@ func (CompanySize) TableName() string {
 }
 
 // CompanySizesSeeder seeds the company_sizes table
-func CompanySizesSeeder(db *gorm.DB) error {
-	log.Println("Seeding company_sizes table...")
-
+func CompanySizesSeeder(db *gorm.DB) (int64, error) {
 	// Helper for max employees pointer
 	maxPtr := func(i int) *int { return &i }
 
@@ -35,23 +31,5 @@ func CompanySizesSeeder(db *gorm.DB) error {
 		{Label: "1000+ karyawan", MinEmployees: 1001, MaxEmployees: nil, DisplayOrder: 6},
 	}
 
-	// Insert one by one, skip if already exists
-	insertedCount := 0
-	for _, size := range companySizes {
-		// Check if already exists
-		var existing CompanySize
-		if err := db.Where("label = ?", size.Label).First(&existing).Error; err == nil {
-			// Already exists, skip
-			continue
-		}
-
-		if err := db.Create(&size).Error; err != nil {
-			log.Printf("Failed to seed company size %s: %v", size.Label, err)
-			continue
-		}
-		insertedCount++
-	}
-
-	log.Printf("Successfully seeded %d company sizes", insertedCount)
-	return nil
+	return upsert(db, &companySizes, []string{"label"}, []string{"min_employees", "max_employees", "display_order"})
 }
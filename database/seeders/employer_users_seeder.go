@@ -1,42 +1,43 @@
 package seeders
 
 import (
+	"fmt"
 	"keerja-backend/internal/domain/company"
 	"log"
 	"time"
 
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
-// EmployerUsersSeeder seeds employer users for companies
-func EmployerUsersSeeder(db *gorm.DB) error {
-	log.Println("Seeding employer_users table...")
+// EmployerUsersSeeder makes existing users employers of the demo companies. Only the
+// companies CompaniesSeeder creates are touched, never real ones.
+func EmployerUsersSeeder(db *gorm.DB) (int64, error) {
+	var slugs []string
+	for _, c := range demoCompanies() {
+		slugs = append(slugs, c.Slug)
+	}
 
-	// Get all companies
 	var companies []company.Company
-	if err := db.Find(&companies).Error; err != nil {
-		log.Printf("Failed to fetch companies: %v", err)
-		return err
+	if err := db.Where("slug IN ?", slugs).Order("id").Find(&companies).Error; err != nil {
+		return 0, fmt.Errorf("failed to fetch demo companies: %w", err)
 	}
 
 	if len(companies) == 0 {
-		log.Println("No companies found, skipping employer_users seeding.")
-		return nil
+		log.Println("No demo companies found, skipping employer_users seeding")
+		return 0, nil
 	}
 
 	// Get available users from users table
 	var users []struct {
 		ID int64
 	}
-	if err := db.Table("users").Select("id").Find(&users).Error; err != nil {
-		log.Printf("Failed to fetch users: %v", err)
-		return err
+	if err := db.Table("users").Select("id").Order("id").Find(&users).Error; err != nil {
+		return 0, fmt.Errorf("failed to fetch users: %w", err)
 	}
 
 	if len(users) == 0 {
-		log.Println("No users found, skipping employer_users seeding.")
-		return nil
+		log.Println("No users found, skipping employer_users seeding")
+		return 0, nil
 	}
 
 	now := time.Now()
@@ -60,21 +61,9 @@ func EmployerUsersSeeder(db *gorm.DB) error {
 	}
 
 	if len(employerUsers) == 0 {
-		log.Println("No employer users to seed.")
-		return nil
-	}
-
-	// Use OnConflict to handle duplicates gracefully — target unique (user_id, company_id)
-	result := db.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "user_id"}, {Name: "company_id"}},
-		DoUpdates: clause.AssignmentColumns([]string{"role", "position_title", "department", "email_company", "phone_company", "is_verified", "verified_at", "verified_by", "is_active", "last_login", "updated_at"}),
-	}).Create(&employerUsers)
-
-	if result.Error != nil {
-		log.Printf("Failed to seed employer_users: %v", result.Error)
-		return result.Error
+		log.Println("No employer users to seed")
+		return 0, nil
 	}
 
-	log.Printf("Successfully seeded %d employer users", len(employerUsers))
-	return nil
+	return upsert(db, &employerUsers, []string{"user_id", "company_id"}, []string{"role", "is_active", "updated_at"})
 }
@@ -1,10 +1,7 @@
 package seeders
 
 import (
-	"log"
-
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
 // Industry represents an industry entity
@@ -21,9 +18,7 @@ func (Industry) TableName() string {
 }
 
 // IndustriesSeeder seeds the industries table
-func IndustriesSeeder(db *gorm.DB) error {
-	log.Println("Seeding industries table...")
-
+func IndustriesSeeder(db *gorm.DB) (int64, error) {
 	industries := []Industry{
 		{Name: "Technology", Slug: "technology", Description: "Information technology, software development, and IT services", DisplayOrder: 1},
 		{Name: "Healthcare", Slug: "healthcare", Description: "Healthcare services, medical facilities, and pharmaceuticals", DisplayOrder: 2},
@@ -63,16 +58,5 @@ func IndustriesSeeder(db *gorm.DB) error {
 		{Name: "Other", Slug: "other", Description: "Other industries not listed above", DisplayOrder: 99},
 	}
 
-	result := db.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "name"}},
-		DoUpdates: clause.AssignmentColumns([]string{"slug", "description", "display_order"}),
-	}).Create(&industries)
-
-	if result.Error != nil {
-		log.Printf("Failed to seed industries: %v", result.Error)
-		return result.Error
-	}
-
-	log.Printf("Successfully seeded %d industries", len(industries))
-	return nil
+	return upsert(db, &industries, []string{"name"}, []string{"slug", "description", "display_order"})
 }
@@ -2,16 +2,12 @@ package seeders
 
 import (
 	"keerja-backend/internal/domain/job"
-	"log"
 
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
 // JobCategoriesSeeder seeds the job_categories table
-func JobCategoriesSeeder(db *gorm.DB) error {
-	log.Println("Seeding job_categories table...")
-
+func JobCategoriesSeeder(db *gorm.DB) (int64, error) {
 	categories := []job.JobCategory{
 		// Technology & IT
 		{Code: "TECH", Name: "Technology & IT", Description: "Information Technology, Software Development, and related fields", IsActive: true},
@@ -112,17 +108,5 @@ func JobCategoriesSeeder(db *gorm.DB) error {
 		{Code: "FREELANCE", Name: "Freelance & Contract", Description: "Freelance and contract work", IsActive: true},
 	}
 
-	// Use OnConflict to update existing categories or create new ones
-	result := db.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "code"}},
-		DoUpdates: clause.AssignmentColumns([]string{"name", "description", "is_active", "updated_at"}),
-	}).Create(&categories)
-
-	if result.Error != nil {
-		log.Printf("Failed to seed job categories: %v", result.Error)
-		return result.Error
-	}
-
-	log.Printf("Successfully seeded %d job categories", len(categories))
-	return nil
+	return upsert(db, &categories, []string{"code"}, []string{"name", "description", "is_active", "updated_at"})
 }
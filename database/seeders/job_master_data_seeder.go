@@ -2,39 +2,32 @@ package seeders
 
 import (
 	"keerja-backend/internal/domain/master"
-	"log"
 
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
-// JobMasterDataSeeder seeds all job-related master data tables
-func JobMasterDataSeeder(db *gorm.DB) error {
-	if err := SeedJobTypes(db); err != nil {
-		return err
-	}
-	if err := SeedWorkPolicies(db); err != nil {
-		return err
-	}
-	if err := SeedEducationLevels(db); err != nil {
-		return err
-	}
-	if err := SeedExperienceLevels(db); err != nil {
-		return err
-	}
-	if err := SeedGenderPreferences(db); err != nil {
-		return err
-	}
-	if err := SeedJobTitles(db); err != nil {
-		return err
-	}
-	return nil
+// JobOptionsSeeder seeds the option tables a job posting picks from: job types, work
+// policies, education levels, experience levels and gender preferences
+func JobOptionsSeeder(db *gorm.DB) (int64, error) {
+	var total int64
+	for _, seed := range []func(*gorm.DB) (int64, error){
+		SeedJobTypes,
+		SeedWorkPolicies,
+		SeedEducationLevels,
+		SeedExperienceLevels,
+		SeedGenderPreferences,
+	} {
+		rows, err := seed(db)
+		if err != nil {
+			return total, err
+		}
+		total += rows
+	}
+	return total, nil
 }
 
 // SeedJobTypes seeds the job_types table
-func SeedJobTypes(db *gorm.DB) error {
-	log.Println("Seeding job_types table...")
-
+func SeedJobTypes(db *gorm.DB) (int64, error) {
 	jobTypes := []master.JobType{
 		{Name: "Full-Time", Code: "full_time", Order: 1},
 		{Name: "Part-Time", Code: "part_time", Order: 2},
@@ -43,79 +36,37 @@ func SeedJobTypes(db *gorm.DB) error {
 		{Name: "Contract", Code: "contract", Order: 5},
 	}
 
-	result := db.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "code"}},
-		DoUpdates: clause.AssignmentColumns([]string{"name", "order"}),
-	}).Create(&jobTypes)
-
-	if result.Error != nil {
-		log.Printf("Error seeding job_types: %v", result.Error)
-		return result.Error
-	}
-
-	log.Printf("Successfully seeded %d job types", len(jobTypes))
-	return nil
+	return upsert(db, &jobTypes, []string{"code"}, []string{"name", "order"})
 }
 
 // SeedWorkPolicies seeds the work_policies table
-func SeedWorkPolicies(db *gorm.DB) error {
-	log.Println("Seeding work_policies table...")
-
+func SeedWorkPolicies(db *gorm.DB) (int64, error) {
 	workPolicies := []master.WorkPolicy{
 		{Name: "On-site", Code: "onsite", Order: 1},
 		{Name: "Remote", Code: "remote", Order: 2},
 		{Name: "Hybrid", Code: "hybrid", Order: 3},
 	}
 
-	result := db.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "code"}},
-		DoUpdates: clause.AssignmentColumns([]string{"name", "order"}),
-	}).Create(&workPolicies)
-
-	if result.Error != nil {
-		log.Printf("Error seeding work_policies: %v", result.Error)
-		return result.Error
-	}
-
-	log.Printf("Successfully seeded %d work policies", len(workPolicies))
-	return nil
+	return upsert(db, &workPolicies, []string{"code"}, []string{"name", "order"})
 }
 
 // SeedEducationLevels seeds the education_levels table
-func SeedEducationLevels(db *gorm.DB) error {
-	log.Println("Seeding education_levels table...")
-
-	// First, clear existing data to avoid constraint conflicts
-	if err := db.Exec("DELETE FROM education_levels").Error; err != nil {
-		log.Printf("Error clearing education_levels: %v", err)
-		return err
-	}
-
+func SeedEducationLevels(db *gorm.DB) (int64, error) {
 	educationLevels := []master.EducationLevel{
-		{ID: 1, Name: "SMA/SMK", Code: "sma", Order: 1},
-		{ID: 2, Name: "D3", Code: "d3", Order: 2},
-		{ID: 3, Name: "D4", Code: "d4", Order: 3},
-		{ID: 4, Name: "S1", Code: "s1", Order: 4},
-		{ID: 5, Name: "S2", Code: "s2", Order: 5},
-		{ID: 6, Name: "S3", Code: "s3", Order: 6},
-		{ID: 7, Name: "Tidak Ditentukan", Code: "any", Order: 7},
-	}
-
-	result := db.Create(&educationLevels)
-
-	if result.Error != nil {
-		log.Printf("Error seeding education_levels: %v", result.Error)
-		return result.Error
+		{Name: "SMA/SMK", Code: "sma", Order: 1},
+		{Name: "D3", Code: "d3", Order: 2},
+		{Name: "D4", Code: "d4", Order: 3},
+		{Name: "S1", Code: "s1", Order: 4},
+		{Name: "S2", Code: "s2", Order: 5},
+		{Name: "S3", Code: "s3", Order: 6},
+		{Name: "Tidak Ditentukan", Code: "any", Order: 7},
 	}
 
-	log.Printf("Successfully seeded %d education levels", len(educationLevels))
-	return nil
+	return upsert(db, &educationLevels, []string{"code"}, []string{"name", "order"})
 }
 
 // SeedExperienceLevels seeds the experience_levels table
-func SeedExperienceLevels(db *gorm.DB) error {
-	log.Println("Seeding experience_levels table...")
-
+func SeedExperienceLevels(db *gorm.DB) (int64, error) {
 	experienceLevels := []master.ExperienceLevel{
 		{Name: "Fresh Graduate", Code: "fresh", MinYears: 0, MaxYears: intPtr(0), Order: 1},
 		{Name: "1-2 Tahun", Code: "junior", MinYears: 1, MaxYears: intPtr(2), Order: 2},
@@ -125,48 +76,22 @@ func SeedExperienceLevels(db *gorm.DB) error {
 		{Name: "Tidak Ditentukan", Code: "any", MinYears: 0, MaxYears: nil, Order: 6},
 	}
 
-	result := db.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "code"}},
-		DoUpdates: clause.AssignmentColumns([]string{"name", "min_years", "max_years", "order"}),
-	}).Create(&experienceLevels)
-
-	if result.Error != nil {
-		log.Printf("Error seeding experience_levels: %v", result.Error)
-		return result.Error
-	}
-
-	log.Printf("Successfully seeded %d experience levels", len(experienceLevels))
-	return nil
+	return upsert(db, &experienceLevels, []string{"code"}, []string{"name", "min_years", "max_years", "order"})
 }
 
 // SeedGenderPreferences seeds the gender_preferences table
-func SeedGenderPreferences(db *gorm.DB) error {
-	log.Println("Seeding gender_preferences table...")
-
+func SeedGenderPreferences(db *gorm.DB) (int64, error) {
 	genderPreferences := []master.GenderPreference{
 		{Name: "Laki-laki", Code: "male", Order: 1},
 		{Name: "Perempuan", Code: "female", Order: 2},
 		{Name: "Semua", Code: "any", Order: 3},
 	}
 
-	result := db.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "code"}},
-		DoUpdates: clause.AssignmentColumns([]string{"name", "order"}),
-	}).Create(&genderPreferences)
-
-	if result.Error != nil {
-		log.Printf("Error seeding gender_preferences: %v", result.Error)
-		return result.Error
-	}
-
-	log.Printf("Successfully seeded %d gender preferences", len(genderPreferences))
-	return nil
+	return upsert(db, &genderPreferences, []string{"code"}, []string{"name", "order"})
 }
 
 // SeedJobTitles seeds the job_titles table with common job titles
-func SeedJobTitles(db *gorm.DB) error {
-	log.Println("Seeding job_titles table...")
-
+func SeedJobTitles(db *gorm.DB) (int64, error) {
 	jobTitles := []master.JobTitle{
 		// Technology & Software Development
 		{Name: "Software Engineer", NormalizedName: "software engineer", PopularityScore: 100, SearchCount: 1200, IsActive: true},
@@ -213,18 +138,8 @@ func SeedJobTitles(db *gorm.DB) error {
 		{Name: "Office Manager", NormalizedName: "office manager", PopularityScore: 78, SearchCount: 680, IsActive: true},
 	}
 
-	result := db.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "name"}},
-		DoUpdates: clause.AssignmentColumns([]string{"normalized_name", "popularity_score", "search_count"}),
-	}).Create(&jobTitles)
-
-	if result.Error != nil {
-		log.Printf("Error seeding job_titles: %v", result.Error)
-		return result.Error
-	}
-
-	log.Printf("Successfully seeded %d job titles", len(jobTitles))
-	return nil
+	// Popularity and search counts are maintained by the app, so existing titles keep theirs
+	return upsert(db, &jobTitles, []string{"name"}, []string{"normalized_name"})
 }
 
 // Helper function to create int pointer
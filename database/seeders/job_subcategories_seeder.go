@@ -1,18 +1,16 @@
 package seeders
 
 import (
+	"fmt"
 	"log"
 
 	"keerja-backend/internal/domain/job"
 
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
 // JobSubcategoriesSeeder seeds the job_subcategories table and links them to job_categories
-func JobSubcategoriesSeeder(db *gorm.DB) error {
-	log.Println("Seeding job_subcategories table...")
-
+func JobSubcategoriesSeeder(db *gorm.DB) (int64, error) {
 	// Define subcategories grouped by category code
 	subcatsByCategory := map[string][]job.JobSubcategory{
 		// Technology & IT
@@ -139,8 +137,7 @@ func JobSubcategoriesSeeder(db *gorm.DB) error {
 				log.Printf("Category with code '%s' not found, skipping its subcategories", code)
 				continue
 			}
-			log.Printf("Failed to lookup category '%s': %v", code, err)
-			return err
+			return 0, fmt.Errorf("failed to look up category %q: %w", code, err)
 		}
 
 		for _, s := range subs {
@@ -151,20 +148,8 @@ func JobSubcategoriesSeeder(db *gorm.DB) error {
 
 	if len(toCreate) == 0 {
 		log.Println("No job subcategories to seed (no matching categories found)")
-		return nil
-	}
-
-	// Upsert by code
-	result := db.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "code"}},
-		DoUpdates: clause.AssignmentColumns([]string{"name", "description", "is_active", "category_id", "updated_at"}),
-	}).Create(&toCreate)
-
-	if result.Error != nil {
-		log.Printf("Failed to seed job subcategories: %v", result.Error)
-		return result.Error
+		return 0, nil
 	}
 
-	log.Printf("Successfully seeded %d job subcategories", len(toCreate))
-	return nil
+	return upsert(db, &toCreate, []string{"code"}, []string{"name", "description", "is_active", "category_id", "updated_at"})
 }
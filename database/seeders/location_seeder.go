@@ -4,7 +4,6 @@ import (
 	"log"
 
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
 // Province represents a province entity
@@ -44,24 +43,8 @@ func (District) TableName() string {
 	return "districts"
 }
 
-// LocationSeeder seeds provinces, cities, and districts
-func LocationSeeder(db *gorm.DB) error {
-	if err := SeedProvinces(db); err != nil {
-		return err
-	}
-	if err := SeedCities(db); err != nil {
-		return err
-	}
-	if err := SeedDistricts(db); err != nil {
-		return err
-	}
-	return nil
-}
-
 // SeedProvinces seeds all 34 provinces of Indonesia
-func SeedProvinces(db *gorm.DB) error {
-	log.Println("Seeding provinces table...")
-
+func SeedProvinces(db *gorm.DB) (int64, error) {
 	provinces := []Province{
 		{Name: "Aceh", Code: "11"},
 		{Name: "Sumatera Utara", Code: "12"},
@@ -99,24 +82,11 @@ func SeedProvinces(db *gorm.DB) error {
 		{Name: "Papua", Code: "94"},
 	}
 
-	result := db.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "code"}},
-		DoUpdates: clause.AssignmentColumns([]string{"name"}),
-	}).Create(&provinces)
-
-	if result.Error != nil {
-		log.Printf("Failed to seed provinces: %v", result.Error)
-		return result.Error
-	}
-
-	log.Printf("Successfully seeded %d provinces", len(provinces))
-	return nil
+	return upsert(db, &provinces, []string{"code"}, []string{"name"})
 }
 
 // SeedCities seeds major cities in Indonesia
-func SeedCities(db *gorm.DB) error {
-	log.Println("Seeding cities table...")
-
+func SeedCities(db *gorm.DB) (int64, error) {
 	// Helper to get province ID by code
 	getProvinceID := func(code string) int64 {
 		var province Province
@@ -234,35 +204,23 @@ func SeedCities(db *gorm.DB) error {
 		{ProvinceID: kaltimID, Name: "Kota Samarinda", Type: "Kota", Code: "6472"},
 	}
 
-	// Insert cities one by one, skip if already exists
-	insertedCount := 0
+	// Cities whose province is missing are skipped
+	var seeded []City
 	for _, city := range cities {
-		if city.ProvinceID == 0 {
-			continue
+		if city.ProvinceID != 0 {
+			seeded = append(seeded, city)
 		}
-
-		// Check if city with this code already exists
-		var existing City
-		if err := db.Where("code = ?", city.Code).First(&existing).Error; err == nil {
-			// Already exists, skip
-			continue
-		}
-
-		if err := db.Create(&city).Error; err != nil {
-			log.Printf("Failed to seed city %s: %v", city.Name, err)
-			continue
-		}
-		insertedCount++
+	}
+	if len(seeded) == 0 {
+		log.Println("No provinces found, skipping cities")
+		return 0, nil
 	}
 
-	log.Printf("Successfully seeded %d cities", insertedCount)
-	return nil
+	return upsert(db, &seeded, []string{"code"}, []string{"province_id", "name", "type"})
 }
 
 // SeedDistricts seeds sample districts in major cities
-func SeedDistricts(db *gorm.DB) error {
-	log.Println("Seeding districts table...")
-
+func SeedDistricts(db *gorm.DB) (int64, error) {
 	// Helper to get city ID by code
 	getCityID := func(code string) int64 {
 		var city City
@@ -340,27 +298,17 @@ func SeedDistricts(db *gorm.DB) error {
 		{CityID: tangselID, Name: "Setu", Code: "3674070", PostalCode: postal("15314")},
 	}
 
-	// Insert districts one by one, skip if already exists
-	insertedCount := 0
+	// Districts whose city is missing are skipped
+	var seeded []District
 	for _, district := range districts {
-		if district.CityID == 0 {
-			continue
+		if district.CityID != 0 {
+			seeded = append(seeded, district)
 		}
-
-		// Check if district with this code already exists
-		var existing District
-		if err := db.Where("code = ?", district.Code).First(&existing).Error; err == nil {
-			// Already exists, skip
-			continue
-		}
-
-		if err := db.Create(&district).Error; err != nil {
-			log.Printf("Failed to seed district %s: %v", district.Name, err)
-			continue
-		}
-		insertedCount++
+	}
+	if len(seeded) == 0 {
+		log.Println("No cities found, skipping districts")
+		return 0, nil
 	}
 
-	log.Printf("Successfully seeded %d districts", insertedCount)
-	return nil
+	return upsert(db, &seeded, []string{"code"}, []string{"city_id", "name", "postal_code"})
 }
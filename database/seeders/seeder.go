@@ -1,82 +1,255 @@
 package seeders
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"path"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-// RunSeeders runs all seeders in the correct order
-func RunSeeders(db *gorm.DB) error {
-	log.Println("Starting database seeding...")
+// EnvProduction is the environment in which only production-safe seeders run
+const EnvProduction = "production"
+
+// Seeder is a named, independently runnable unit of seed data
+type Seeder struct {
+	Name string
+	// Version is bumped whenever the seeder's data changes so environments that already
+	// ran the previous version pick up the change
+	Version int
+	// Production marks reference data that is safe to seed into a production database.
+	// Demo data and accounts with well-known passwords are not.
+	Production bool
+	// Run seeds the data and returns how many rows it inserted or changed
+	Run func(db *gorm.DB) (int64, error)
+}
 
-	// Phase 1: Location data (provinces, cities, districts)
-	if err := LocationSeeder(db); err != nil {
-		log.Printf("Failed to run LocationSeeder: %v", err)
-		return err
+// registry lists every seeder in run order; later seeders look up rows created by earlier ones
+var registry = []Seeder{
+	{Name: "provinces", Version: 1, Production: true, Run: SeedProvinces},
+	{Name: "cities", Version: 1, Production: true, Run: SeedCities},
+	{Name: "districts", Version: 1, Production: true, Run: SeedDistricts},
+	{Name: "industries", Version: 1, Production: true, Run: IndustriesSeeder},
+	{Name: "company_sizes", Version: 1, Production: true, Run: CompanySizesSeeder},
+	{Name: "job_options", Version: 1, Production: true, Run: JobOptionsSeeder},
+	{Name: "job_titles", Version: 1, Production: true, Run: SeedJobTitles},
+	{Name: "skills", Version: 1, Production: true, Run: SkillsMasterSeeder},
+	{Name: "benefits", Version: 1, Production: true, Run: BenefitsMasterSeeder},
+	{Name: "job_categories", Version: 1, Production: true, Run: JobCategoriesSeeder},
+	{Name: "job_subcategories", Version: 1, Production: true, Run: JobSubcategoriesSeeder},
+	{Name: "admin_roles", Version: 1, Production: true, Run: AdminRolesSeeder},
+	{Name: "admin_user", Version: 1, Run: AdminUserSeeder},
+	{Name: "demo_companies", Version: 1, Run: CompaniesSeeder},
+	{Name: "demo_users", Version: 1, Run: EmployerUsersSeeder},
+}
+
+// Registered returns every registered seeder in run order
+func Registered() []Seeder {
+	return append([]Seeder(nil), registry...)
+}
+
+// Options selects which seeders run and how
+type Options struct {
+	Only    []string // Seeder names or glob patterns to run; empty runs all
+	Exclude []string // Seeder names or glob patterns to skip
+	Env     string   // EnvProduction restricts the run to production-safe seeders
+	DryRun  bool     // Run inside a transaction that is rolled back
+	Force   bool     // Run seeders even when their current version already ran
+}
+
+// Select returns the seeders the options pick, in run order. Patterns use path.Match
+// syntax, e.g. "demo_*". An -only pattern that matches no seeder is an error, so typos
+// do not silently seed nothing.
+func Select(seeders []Seeder, opts Options) ([]Seeder, error) {
+	for _, pattern := range opts.Only {
+		matched, err := matchesAny(seeders, pattern)
+		if err != nil {
+			return nil, err
+		}
+		if !matched {
+			return nil, fmt.Errorf("no seeder matches %q", pattern)
+		}
 	}
 
-	// Phase 2: Industries and Company Sizes
-	if err := IndustriesSeeder(db); err != nil {
-		log.Printf("Failed to run IndustriesSeeder: %v", err)
-		return err
+	var selected []Seeder
+	for _, s := range seeders {
+		if len(opts.Only) > 0 && !matchName(s.Name, opts.Only) {
+			continue
+		}
+		if matchName(s.Name, opts.Exclude) {
+			continue
+		}
+		if opts.Env == EnvProduction && !s.Production {
+			continue
+		}
+		selected = append(selected, s)
 	}
+	return selected, nil
+}
 
-	if err := CompanySizesSeeder(db); err != nil {
-		log.Printf("Failed to run CompanySizesSeeder: %v", err)
-		return err
+func matchesAny(seeders []Seeder, pattern string) (bool, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return false, fmt.Errorf("invalid seeder pattern %q: %w", pattern, err)
+	}
+	for _, s := range seeders {
+		if matchName(s.Name, []string{pattern}) {
+			return true, nil
+		}
 	}
+	return false, nil
+}
 
-	// Phase 3: Job master data (job_types, work_policies, education_levels, experience_levels, gender_preferences, job_titles)
-	if err := JobMasterDataSeeder(db); err != nil {
-		log.Printf("Failed to run JobMasterDataSeeder: %v", err)
-		return err
+func matchName(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
 	}
+	return false
+}
 
-	// Phase 4: Skills and Benefits
-	if err := SkillsMasterSeeder(db); err != nil {
-		log.Printf("Failed to run SkillsMasterSeeder: %v", err)
-		return err
+// Result reports the outcome of one seeder
+type Result struct {
+	Name    string
+	Version int
+	Rows    int64 // Rows inserted or changed
+	Skipped bool  // The current version already ran
+}
+
+// SeedRun records a seeder version that ran against the database
+type SeedRun struct {
+	ID           int64     `gorm:"primaryKey"`
+	SeederName   string    `gorm:"not null"`
+	Version      int       `gorm:"not null"`
+	RowsAffected int64     `gorm:"not null"`
+	RanAt        time.Time `gorm:"not null"`
+}
+
+func (SeedRun) TableName() string {
+	return "seed_runs"
+}
+
+// errDryRun rolls back a dry run's transaction
+var errDryRun = errors.New("dry run")
+
+// Run runs the registered seeders the options select. Each seeder runs in its own
+// transaction together with its seed_runs record; seeders whose current version already
+// ran are skipped unless Force is set. A dry run runs everything in one transaction that
+// is rolled back, so it reports what would change without changing anything.
+func Run(db *gorm.DB, opts Options) ([]Result, error) {
+	selected, err := Select(registry, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := BenefitsMasterSeeder(db); err != nil {
-		log.Printf("Failed to run BenefitsMasterSeeder: %v", err)
-		return err
+	if !opts.DryRun {
+		return runSeeders(db, selected, opts)
 	}
 
-	// Phase 5: Job categories and subcategories
-	if err := JobCategoriesSeeder(db); err != nil {
-		log.Printf("Failed to run JobCategoriesSeeder: %v", err)
-		return err
+	var results []Result
+	err = db.Transaction(func(tx *gorm.DB) error {
+		results, err = runSeeders(tx, selected, opts)
+		if err != nil {
+			return err
+		}
+		return errDryRun
+	})
+	if errors.Is(err, errDryRun) {
+		err = nil
 	}
+	return results, err
+}
 
-	if err := JobSubcategoriesSeeder(db); err != nil {
-		log.Printf("Failed to run JobSubcategoriesSeeder: %v", err)
-		return err
+func runSeeders(db *gorm.DB, seeders []Seeder, opts Options) ([]Result, error) {
+	results := make([]Result, 0, len(seeders))
+	for _, s := range seeders {
+		result := Result{Name: s.Name, Version: s.Version}
+
+		if !opts.Force {
+			var runs int64
+			if err := db.Model(&SeedRun{}).Where("seeder_name = ? AND version = ?", s.Name, s.Version).Count(&runs).Error; err != nil {
+				return results, fmt.Errorf("failed to read seed_runs (have migrations run?): %w", err)
+			}
+			if runs > 0 {
+				result.Skipped = true
+				results = append(results, result)
+				continue
+			}
+		}
+
+		log.Printf("Running seeder %s (v%d)...", s.Name, s.Version)
+		err := db.Transaction(func(tx *gorm.DB) error {
+			rows, err := s.Run(tx)
+			if err != nil {
+				return err
+			}
+			result.Rows = rows
+
+			return tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "seeder_name"}, {Name: "version"}},
+				DoUpdates: clause.AssignmentColumns([]string{"rows_affected", "ran_at"}),
+			}).Create(&SeedRun{SeederName: s.Name, Version: s.Version, RowsAffected: rows, RanAt: time.Now()}).Error
+		})
+		if err != nil {
+			return results, fmt.Errorf("seeder %s failed: %w", s.Name, err)
+		}
+		results = append(results, result)
 	}
+	return results, nil
+}
 
-	// Phase 6: Admin roles and users
-	if err := AdminRolesSeeder(db); err != nil {
-		log.Printf("Failed to run AdminRolesSeeder: %v", err)
+// RunSeeders runs every registered seeder that has not yet run at its current version
+func RunSeeders(db *gorm.DB) error {
+	log.Println("Starting database seeding...")
+
+	if _, err := Run(db, Options{}); err != nil {
+		log.Printf("Database seeding failed: %v", err)
 		return err
 	}
 
-	if err := AdminUserSeeder(db); err != nil {
-		log.Printf("Failed to run AdminUserSeeder: %v", err)
-		return err
+	log.Println("Database seeding completed successfully")
+	return nil
+}
+
+// upsert inserts rows, updating the given columns of rows whose conflict key already
+// exists. Existing rows that already hold the same values are left untouched, so the
+// returned count covers only rows that were inserted or changed and a repeated run
+// reports 0. updated_at may be listed in update; it is set but not compared.
+func upsert(db *gorm.DB, rows interface{}, conflict []string, update []string) (int64, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(rows); err != nil {
+		return 0, err
 	}
+	table := stmt.Schema.Table
 
-	// Phase 7: Companies and employer users (depends on location & industries)
-	if err := CompaniesSeeder(db); err != nil {
-		log.Printf("Failed to run CompaniesSeeder: %v", err)
-		return err
+	columns := make([]clause.Column, len(conflict))
+	for i, name := range conflict {
+		columns[i] = clause.Column{Name: name}
 	}
 
-	if err := EmployerUsersSeeder(db); err != nil {
-		log.Printf("Failed to run EmployerUsersSeeder: %v", err)
-		return err
+	var current, excluded []string
+	for _, name := range update {
+		if name == "updated_at" {
+			continue
+		}
+		current = append(current, fmt.Sprintf("%q.%q", table, name))
+		excluded = append(excluded, fmt.Sprintf("excluded.%q", name))
 	}
 
-	log.Println("Database seeding completed successfully")
-	return nil
+	onConflict := clause.OnConflict{
+		Columns:   columns,
+		DoUpdates: clause.AssignmentColumns(update),
+	}
+	if len(current) > 0 {
+		onConflict.Where = clause.Where{Exprs: []clause.Expression{clause.Expr{
+			SQL: fmt.Sprintf("(%s) IS DISTINCT FROM (%s)", strings.Join(current, ", "), strings.Join(excluded, ", ")),
+		}}}
+	}
+
+	result := db.Clauses(onConflict).Create(rows)
+	return result.RowsAffected, result.Error
 }
@@ -2,17 +2,13 @@ package seeders
 
 import (
 	"keerja-backend/internal/domain/master"
-	"log"
 	"strings"
 
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
 // SkillsMasterSeeder seeds the skills_master table
-func SkillsMasterSeeder(db *gorm.DB) error {
-	log.Println("Seeding skills_master table...")
-
+func SkillsMasterSeeder(db *gorm.DB) (int64, error) {
 	skills := []master.SkillsMaster{
 		// Programming Languages
 		{Code: "GO", Name: "Go", NormalizedName: "go", Description: "Programming language developed by Google", SkillType: "technical", DifficultyLevel: "intermediate", PopularityScore: 95, IsActive: true},
@@ -78,19 +74,7 @@ func SkillsMasterSeeder(db *gorm.DB) error {
 		{Code: "CREATIVITY", Name: "Creativity", NormalizedName: "creativity", Description: "Innovative and original thinking", SkillType: "soft", DifficultyLevel: "intermediate", PopularityScore: 85, IsActive: true},
 	}
 
-	// Use OnConflict to update existing skills or create new ones
-	result := db.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "name"}},
-		DoUpdates: clause.AssignmentColumns([]string{"code", "normalized_name", "description", "skill_type", "difficulty_level", "popularity_score", "is_active", "updated_at"}),
-	}).Create(&skills)
-
-	if result.Error != nil {
-		log.Printf("Failed to seed skills: %v", result.Error)
-		return result.Error
-	}
-
-	log.Printf("Successfully seeded %d skills", len(skills))
-	return nil
+	return upsert(db, &skills, []string{"name"}, []string{"code", "normalized_name", "description", "skill_type", "difficulty_level", "popularity_score", "is_active", "updated_at"})
 }
 
 // Helper function to normalize skill names
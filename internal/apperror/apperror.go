@@ -0,0 +1,125 @@
+// Package apperror provides typed errors that carry a machine-readable code so
+// clients can handle failures without matching on message text.
+package apperror
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Kind classifies an error; the HTTP layer maps each kind to a status code
+type Kind string
+
+const (
+	KindNotFound     Kind = "not_found"
+	KindConflict     Kind = "conflict"
+	KindValidation   Kind = "validation"
+	KindForbidden    Kind = "forbidden"
+	KindUnauthorized Kind = "unauthorized"
+	KindRateLimited  Kind = "rate_limited"
+)
+
+// Error is an application error safe to show to clients
+type Error struct {
+	Kind    Kind
+	Code    string            // Machine-readable code, e.g. JOB_NOT_FOUND
+	Message string            // Human-readable message
+	Details map[string]string // Optional per-field details, e.g. {"reason": "is required"}
+	Err     error             // Underlying cause, logged but never sent to clients
+}
+
+// Error returns the client-facing message
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the underlying cause
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is an *Error with the same code, so copies made by
+// WithDetails or Wrap still match the sentinel they came from
+func (e *Error) Is(target error) bool {
+	var t *Error
+	if !errors.As(target, &t) {
+		return false
+	}
+	return e.Code == t.Code
+}
+
+// WithDetails returns a copy of e carrying the given field details
+func (e *Error) WithDetails(details map[string]string) *Error {
+	cp := *e
+	cp.Details = details
+	return &cp
+}
+
+// WithField returns a copy of e with a single field detail
+func (e *Error) WithField(field, detail string) *Error {
+	return e.WithDetails(map[string]string{field: detail})
+}
+
+// WithCurrentVersion returns a copy of e telling the client the version the resource has
+// now, so after a version conflict it can reload the resource and reapply its changes
+func (e *Error) WithCurrentVersion(version int64) *Error {
+	return e.WithField("current_version", strconv.FormatInt(version, 10))
+}
+
+// WithMessage returns a copy of e with a different client-facing message
+func (e *Error) WithMessage(message string) *Error {
+	cp := *e
+	cp.Message = message
+	return &cp
+}
+
+// Wrap returns a copy of e recording cause as the underlying error
+func (e *Error) Wrap(cause error) *Error {
+	cp := *e
+	cp.Err = cause
+	return &cp
+}
+
+// New creates an error of the given kind
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// NotFound creates an error for a missing resource
+func NotFound(code, message string) *Error {
+	return New(KindNotFound, code, message)
+}
+
+// Conflict creates an error for a request that conflicts with the resource's current state
+func Conflict(code, message string) *Error {
+	return New(KindConflict, code, message)
+}
+
+// Validation creates an error for invalid input
+func Validation(code, message string) *Error {
+	return New(KindValidation, code, message)
+}
+
+// Forbidden creates an error for an authenticated caller lacking access
+func Forbidden(code, message string) *Error {
+	return New(KindForbidden, code, message)
+}
+
+// Unauthorized creates an error for a missing or invalid identity
+func Unauthorized(code, message string) *Error {
+	return New(KindUnauthorized, code, message)
+}
+
+// RateLimited creates an error for a caller that exceeded a usage limit
+func RateLimited(code, message string) *Error {
+	return New(KindRateLimited, code, message)
+}
+
+// As returns the first *Error in err's chain
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
@@ -4,11 +4,18 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"log"
+	"reflect"
 	"sort"
 	"sync"
 	"time"
+
+	"keerja-backend/internal/metrics"
 )
 
+// memoryCacheName labels the in-memory cache's hits, misses and evictions in the metrics
+const memoryCacheName = "memory"
+
 // Cache defines the interface for caching operations
 type Cache interface {
 	Get(key string) (interface{}, bool)
@@ -176,18 +183,21 @@ func (c *InMemoryCache) incrementHits() {
 	c.mu.Lock()
 	c.hits++
 	c.mu.Unlock()
+	metrics.Default().CacheEvent(memoryCacheName, metrics.CacheHit)
 }
 
 func (c *InMemoryCache) incrementMisses() {
 	c.mu.Lock()
 	c.misses++
 	c.mu.Unlock()
+	metrics.Default().CacheEvent(memoryCacheName, metrics.CacheMiss)
 }
 
 func (c *InMemoryCache) incrementEvictions() {
 	c.mu.Lock()
 	c.evictions++
 	c.mu.Unlock()
+	metrics.Default().CacheEvent(memoryCacheName, metrics.CacheEviction)
 }
 
 func (c *InMemoryCache) getCurrentSize() int {
@@ -252,7 +262,66 @@ func (c *InMemoryCache) cleanup() {
 
 // Helper functions
 
-// GenerateFilterHash creates a consistent hash from filter parameters
+// GetTyped returns the value cached under key as a T. A value of another type, such as
+// one left by an older build or decoded by a serializing backend, is logged, deleted and
+// reported as a miss, so callers fall through to their source of truth instead of panicking.
+func GetTyped[T any](c Cache, key string) (T, bool) {
+	var zero T
+	cached, ok := c.Get(key)
+	if !ok {
+		return zero, false
+	}
+
+	value, ok := cached.(T)
+	if !ok {
+		log.Printf("[WARN] cache: dropping key %q holding %T, expected %T", key, cached, zero)
+		c.Delete(key)
+		return zero, false
+	}
+	return value, true
+}
+
+// Bounds on the list queries whose results are cached. Deep pages and long free-text
+// filters are rarely requested twice, so caching them would only let clients fill the
+// cache with entries that are never read.
+const (
+	MaxCacheablePage        = 20
+	MaxCacheableFilterValue = 100
+)
+
+// CacheableFilter reports whether a list query with these filters and page should be
+// cached: the page is at most MaxCacheablePage and no string filter is longer than
+// MaxCacheableFilterValue bytes
+func CacheableFilter(filters map[string]interface{}, page int) bool {
+	if page > MaxCacheablePage {
+		return false
+	}
+	for _, v := range filters {
+		if s, ok := filterValue(v).(string); ok && len(s) > MaxCacheableFilterValue {
+			return false
+		}
+	}
+	return true
+}
+
+// filterValue dereferences pointer filter values, so filters hash by what they point to
+// rather than by address; nil pointers become nil
+func filterValue(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil
+	}
+	return rv.Interface()
+}
+
+// GenerateFilterHash creates a consistent hash from filter parameters. Pointer values are
+// hashed by the value they point to.
 func GenerateFilterHash(filters map[string]interface{}) string {
 	if len(filters) == 0 {
 		return "default"
@@ -268,7 +337,7 @@ func GenerateFilterHash(filters map[string]interface{}) string {
 	// Build filter string
 	var filterStr string
 	for _, k := range keys {
-		filterStr += fmt.Sprintf("%s:%v:", k, filters[k])
+		filterStr += fmt.Sprintf("%s:%v:", k, filterValue(filters[k]))
 	}
 
 	// Hash the filter string using SHA256
@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// FailoverKVStore serves from a primary store (Redis) and switches to an in-memory
+// fallback when the primary fails. Run promotes it back once the primary answers again.
+// Values written during an outage stay readable from the fallback until they expire.
+type FailoverKVStore struct {
+	primary  KeyValueStore
+	fallback *InMemoryKVStore
+	degraded atomic.Bool
+}
+
+// NewFailoverKVStore creates a failover store. Pass primaryUp false when the primary
+// is already known to be down so requests go to the fallback right away. A nil
+// primary keeps the store on the fallback for good.
+func NewFailoverKVStore(primary KeyValueStore, fallback *InMemoryKVStore, primaryUp bool) *FailoverKVStore {
+	s := &FailoverKVStore{primary: primary, fallback: fallback}
+	s.degraded.Store(primary == nil || !primaryUp)
+	return s
+}
+
+// Degraded reports whether requests are being served from the in-memory fallback
+func (s *FailoverKVStore) Degraded() bool {
+	return s.degraded.Load()
+}
+
+// Get implements KeyValueStore.Get
+func (s *FailoverKVStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if !s.Degraded() {
+		value, err := s.primary.Get(ctx, key)
+		if err == nil {
+			return value, nil
+		}
+		if !errors.Is(err, ErrKeyNotFound) {
+			s.markDegraded(err)
+		}
+	}
+	return s.fallback.Get(ctx, key)
+}
+
+// Set implements KeyValueStore.Set
+func (s *FailoverKVStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if !s.Degraded() {
+		err := s.primary.Set(ctx, key, value, ttl)
+		if err == nil {
+			return nil
+		}
+		s.markDegraded(err)
+	}
+	return s.fallback.Set(ctx, key, value, ttl)
+}
+
+// SetNX implements KeyValueStore.SetNX
+func (s *FailoverKVStore) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	if !s.Degraded() {
+		stored, err := s.primary.SetNX(ctx, key, value, ttl)
+		if err == nil {
+			return stored, nil
+		}
+		s.markDegraded(err)
+	}
+	return s.fallback.SetNX(ctx, key, value, ttl)
+}
+
+// Incr implements KeyValueStore.Incr. Counters restart in the fallback during an outage.
+func (s *FailoverKVStore) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	if !s.Degraded() {
+		n, err := s.primary.Incr(ctx, key, ttl)
+		if err == nil {
+			return n, nil
+		}
+		s.markDegraded(err)
+	}
+	return s.fallback.Incr(ctx, key, ttl)
+}
+
+// Delete implements KeyValueStore.Delete. The key is removed from both stores.
+func (s *FailoverKVStore) Delete(ctx context.Context, key string) error {
+	if !s.Degraded() {
+		if err := s.primary.Delete(ctx, key); err != nil {
+			s.markDegraded(err)
+		}
+	}
+	return s.fallback.Delete(ctx, key)
+}
+
+// Ping implements KeyValueStore.Ping by pinging the primary
+func (s *FailoverKVStore) Ping(ctx context.Context) error {
+	if s.primary == nil {
+		return errors.New("no primary store configured")
+	}
+	return s.primary.Ping(ctx)
+}
+
+// Run pings the primary every interval while degraded and promotes it back once it
+// answers. It returns when ctx is done.
+func (s *FailoverKVStore) Run(ctx context.Context, interval time.Duration) {
+	if s.primary == nil {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.Degraded() {
+				continue
+			}
+			pingCtx, cancel := context.WithTimeout(ctx, interval/2)
+			err := s.primary.Ping(pingCtx)
+			cancel()
+			if err == nil && s.degraded.CompareAndSwap(true, false) {
+				log.Println("[INFO] Redis is reachable again, switched back from the in-memory fallback")
+			}
+		}
+	}
+}
+
+// markDegraded switches requests to the fallback after a primary failure
+func (s *FailoverKVStore) markDegraded(err error) {
+	if s.degraded.CompareAndSwap(false, true) {
+		log.Printf("[WARN] Redis unavailable, using the in-memory fallback: %v", err)
+	}
+}
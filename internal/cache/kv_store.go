@@ -0,0 +1,154 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrKeyNotFound is returned by KeyValueStore.Get when the key is missing or expired
+var ErrKeyNotFound = errors.New("key not found")
+
+// KeyValueStore is a shared store for short-lived values such as OAuth states,
+// idempotency records and rate limit counters. It is backed by Redis, with an in-memory fallback for when
+// Redis is unavailable.
+type KeyValueStore interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// SetNX stores value only when key does not exist and reports whether it did
+	SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	// Incr increments the integer stored at key and returns the new value. A missing key
+	// starts at zero and expires after ttl; incrementing does not extend the expiry.
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	Delete(ctx context.Context, key string) error
+	Ping(ctx context.Context) error
+}
+
+// kvEntry is a value held by InMemoryKVStore
+type kvEntry struct {
+	value      []byte
+	expiration time.Time
+}
+
+// InMemoryKVStore implements KeyValueStore in process memory. Values are not shared
+// between instances.
+type InMemoryKVStore struct {
+	mu          sync.Mutex
+	data        map[string]kvEntry
+	stopCleanup chan struct{}
+	stopOnce    sync.Once
+}
+
+// NewInMemoryKVStore creates an in-memory store that removes expired keys every cleanupInterval
+func NewInMemoryKVStore(cleanupInterval time.Duration) *InMemoryKVStore {
+	s := &InMemoryKVStore{
+		data:        make(map[string]kvEntry),
+		stopCleanup: make(chan struct{}),
+	}
+
+	go s.startCleanup(cleanupInterval)
+
+	return s
+}
+
+// Get implements KeyValueStore.Get
+func (s *InMemoryKVStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.data[key]
+	if !ok || time.Now().After(entry.expiration) {
+		delete(s.data, key)
+		return nil, ErrKeyNotFound
+	}
+	return entry.value, nil
+}
+
+// Set implements KeyValueStore.Set
+func (s *InMemoryKVStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data[key] = kvEntry{value: append([]byte(nil), value...), expiration: time.Now().Add(ttl)}
+	return nil
+}
+
+// SetNX implements KeyValueStore.SetNX
+func (s *InMemoryKVStore) SetNX(_ context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.data[key]; ok && time.Now().Before(entry.expiration) {
+		return false, nil
+	}
+	s.data[key] = kvEntry{value: append([]byte(nil), value...), expiration: time.Now().Add(ttl)}
+	return true, nil
+}
+
+// Incr implements KeyValueStore.Incr. Counters are stored as decimal strings, as in Redis.
+func (s *InMemoryKVStore) Incr(_ context.Context, key string, ttl time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.data[key]
+	if !ok || time.Now().After(entry.expiration) {
+		entry = kvEntry{value: []byte("0"), expiration: time.Now().Add(ttl)}
+	}
+	n, err := strconv.ParseInt(string(entry.value), 10, 64)
+	if err != nil {
+		return 0, errors.New("value is not an integer")
+	}
+	n++
+	entry.value = []byte(strconv.FormatInt(n, 10))
+	s.data[key] = entry
+	return n, nil
+}
+
+// Delete implements KeyValueStore.Delete
+func (s *InMemoryKVStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.data, key)
+	return nil
+}
+
+// Ping implements KeyValueStore.Ping; memory is always reachable
+func (s *InMemoryKVStore) Ping(_ context.Context) error {
+	return nil
+}
+
+// Stop stops the cleanup goroutine
+func (s *InMemoryKVStore) Stop() {
+	s.stopOnce.Do(func() { close(s.stopCleanup) })
+}
+
+// startCleanup removes expired keys periodically
+func (s *InMemoryKVStore) startCleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.cleanup()
+		case <-s.stopCleanup:
+			return
+		}
+	}
+}
+
+// cleanup removes expired keys
+func (s *InMemoryKVStore) cleanup() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, entry := range s.data {
+		if now.After(entry.expiration) {
+			delete(s.data, key)
+		}
+	}
+}
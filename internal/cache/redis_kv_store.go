@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// incrScript increments a counter and sets its expiry only when the counter was created,
+// atomically so a crash between the two calls cannot leave a counter without a TTL
+var incrScript = redis.NewScript(`
+local n = redis.call('INCR', KEYS[1])
+if n == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return n
+`)
+
+// RedisKVStore implements KeyValueStore with Redis
+type RedisKVStore struct {
+	client *redis.Client
+}
+
+// NewRedisKVStore creates a Redis-backed key-value store
+func NewRedisKVStore(client *redis.Client) *RedisKVStore {
+	return &RedisKVStore{client: client}
+}
+
+// Get implements KeyValueStore.Get
+func (s *RedisKVStore) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := s.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrKeyNotFound
+	}
+	return value, err
+}
+
+// Set implements KeyValueStore.Set
+func (s *RedisKVStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.client.Set(ctx, key, value, ttl).Err()
+}
+
+// SetNX implements KeyValueStore.SetNX
+func (s *RedisKVStore) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, key, value, ttl).Result()
+}
+
+// Incr implements KeyValueStore.Incr
+func (s *RedisKVStore) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return incrScript.Run(ctx, s.client, []string{key}, ttl.Milliseconds()).Int64()
+}
+
+// Delete implements KeyValueStore.Delete
+func (s *RedisKVStore) Delete(ctx context.Context, key string) error {
+	return s.client.Del(ctx, key).Err()
+}
+
+// Ping implements KeyValueStore.Ping
+func (s *RedisKVStore) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -20,6 +21,14 @@ type Config struct {
 	AppEnv     string
 	AppName    string
 	AppVersion string
+	GitSHA     string // Commit the binary was built from, reported by the health endpoints
+
+	// RequestTimeout cancels a request's context once it passes; zero disables it
+	RequestTimeout time.Duration
+
+	// Metrics Configuration
+	MetricsEnabled bool   // Serves Prometheus metrics at /metrics
+	MetricsPort    string // Serves /metrics on its own port instead of the API port when set
 
 	// Database Configuration
 	DBHost     string
@@ -29,6 +38,11 @@ type Config struct {
 	DBName     string
 	DBSSLMode  string
 
+	// SQL logging: queries slower than the threshold are logged with their request ID;
+	// SQLLogLevel is silent, error, warn or info and defaults to info in development
+	SlowQueryThreshold time.Duration
+	SQLLogLevel        string
+
 	// JWT Configuration
 	JWTSecret                string
 	JWTExpirationHours       int
@@ -41,14 +55,32 @@ type Config struct {
 	SMTPPassword string
 	SMTPFrom     string
 
+	// Email queue: attempts before a queued email goes dead and emails sent per worker run
+	EmailQueueMaxAttempts int
+	EmailQueueBatchSize   int
+
 	// Storage Configuration
-	StorageProvider string // "local" or "s3" or "cloudinary"
+	StorageProvider string // "local" or "s3"
 	AWSRegion       string
 	AWSBucket       string
 	AWSAccessKey    string
 	AWSSecretKey    string
 	CloudinaryURL   string
 	UploadPath      string
+	S3Endpoint      string // custom endpoint for MinIO / GCS interop, empty for AWS
+	S3UseSSL        bool
+	S3PublicBaseURL string // public URL prefix for objects, derived from bucket when empty
+	UploadBaseURL   string // public URL prefix of locally stored files, served under /uploads
+
+	// Upload size limits in megabytes
+	UploadMaxAvatarMB   int
+	UploadMaxCoverMB    int
+	UploadMaxDocumentMB int
+
+	// Signed download links for private documents (verification documents, application documents).
+	// The secret falls back to JWTSecret when empty.
+	DownloadURLSecret string
+	DownloadURLTTL    time.Duration
 
 	// Redis Configuration (optional)
 	RedisHost     string
@@ -56,14 +88,33 @@ type Config struct {
 	RedisPassword string
 	RedisDB       int
 	RedisURL      string
+	// When false, the API starts without Redis and uses in-memory fallbacks until it connects
+	RedisRequired          bool
+	RedisReconnectInterval time.Duration
 
 	// Rate Limiting
 	RateLimitEnabled bool
 	RateLimitMax     int
 	RateLimitWindow  time.Duration
+	// Login, registration and OTP requests per window for each IP and email, and for each IP
+	// across all emails
+	RateLimitAuthMax    int
+	RateLimitAuthWindow time.Duration
+	RateLimitAuthIPMax  int
+	// Requests per RateLimitWindow for each authenticated employer
+	RateLimitEmployerMax int
+	// Lets requests with an admin token skip the global rate limiter
+	RateLimitAdminBypass bool
+	// Requests per window allowed for each company API key on integration routes
+	APIKeyRateLimitMax int
 
 	// CORS Configuration
-	AllowedOrigins []string
+	// Exact origins, subdomain wildcards (https://*.keerja.com) or "*"
+	CORSAllowedOrigins   []string
+	CORSAllowedMethods   []string
+	CORSAllowedHeaders   []string
+	CORSMaxAge           time.Duration
+	CORSAllowCredentials bool
 
 	// Pagination
 	DefaultPageSize int
@@ -89,6 +140,62 @@ type Config struct {
 	// Optional credentials JSON file path (downloaded from Google Console) for local dev
 	GoogleCredentialsFile string
 
+	// Email Verification Configuration
+	EmailVerificationGraceDays int // Days an account with an unverified email can still sign in
+
+	// Company Verification Configuration
+	VerificationGraceDays int // Days a company stays verified after its verification expiry date
+
+	// Job Posting Configuration
+	JobDefaultExpiryDays int // Days a job stays published when it is published without an expiry date
+	JobMaxAutoExtensions int // Times an auto_extend job is extended instead of expired
+	JobQuotaFree         int // Jobs a company on the free plan may have published at once
+	JobQuotaVerified     int // Jobs a company on the verified plan may have published at once
+	JobQuotaPremium      int // Jobs a company on the premium plan may have published at once
+
+	// Job view tracking. Anonymous viewers' IPs are stored as an HMAC keyed with
+	// JobViewHashSecret, which falls back to JWTSecret when empty.
+	JobViewHashSecret    string
+	JobViewRetentionDays int // Days view events are kept for view dedup and analytics
+
+	// Job Application Configuration
+	ReapplyAfterWithdrawDays int  // Days before an applicant who withdrew may apply to the same job again
+	ReapplyAfterRejectDays   int  // Days before a rejected applicant may apply to the same job again
+	ApplicationDocumentURLs  bool // Accept pre-uploaded file URLs for application documents besides file uploads
+
+	// Account Privacy Configuration
+	AccountDeletionGraceDays int // Days a job seeker can cancel an account deletion before it is carried out
+	DataExportLinkHours      int // Hours a personal data export download link stays valid
+
+	// Audit Log Configuration
+	AuditBufferSize int // Audit log entries queued for writing before new ones are dropped
+
+	// Admin Impersonation Configuration
+	ImpersonationTTL              time.Duration // How long an impersonation token stays valid
+	ImpersonationAllowedMutations []string      // "METHOD /api/v1/path" routes impersonated requests may call besides reads; ":param" matches one path segment
+
+	// Webhook Configuration
+	WebhookTimeout          int  // Seconds a webhook endpoint has to respond to a delivery
+	WebhookAllowPrivateURLs bool // Let webhooks target localhost and private networks (local development only)
+
+	// Geocoding Configuration
+	GeocoderProvider string // "nominatim" or "google"; empty disables address geocoding
+	GeocoderBaseURL  string // Optional provider endpoint override
+	GoogleMapsAPIKey string
+	GeocoderTimeout  int // Seconds
+
+	// SMS Configuration
+	SMSProvider string // "log" only logs messages, for development
+
+	// WhatsApp Configuration
+	WhatsAppProvider      string            // "cloud" or "log"; empty disables WhatsApp notifications
+	WhatsAppAPIBaseURL    string            // Optional Graph API endpoint override, including its version
+	WhatsAppToken         string            // Access token of the WhatsApp Business account
+	WhatsAppPhoneNumberID string            // Business phone number messages are sent from
+	WhatsAppLanguage      string            // Language code of the approved templates
+	WhatsAppTemplates     map[string]string // Approved template name per notification template
+	WhatsAppTimeout       int               // Seconds
+
 	// FCM Configuration
 	FCMEnabled         bool
 	FCMProjectID       string
@@ -100,6 +207,8 @@ type Config struct {
 	FCMMaxRetries             int
 	PushDefaultSound          string
 	PushDefaultTTL            int
+	PushMaxDevicesPerUser     int // Active device tokens kept per user; registering another evicts the least recently seen
+	DeviceTokenInactiveDays   int // Days without a delivery or registration before a device token is deleted
 }
 
 var globalConfig *Config
@@ -118,6 +227,13 @@ func LoadConfig() *Config {
 		AppEnv:     getEnv("APP_ENV", "development"),
 		AppName:    getEnv("APP_NAME", "Keerja API"),
 		AppVersion: getEnv("APP_VERSION", "1.0.0"),
+		GitSHA:     getEnv("GIT_SHA", "unknown"),
+
+		RequestTimeout: time.Duration(getEnvAsInt("REQUEST_TIMEOUT_SECONDS", 15)) * time.Second,
+
+		// Metrics Configuration
+		MetricsEnabled: getEnvAsBool("METRICS_ENABLED", true),
+		MetricsPort:    getEnv("METRICS_PORT", ""),
 
 		// Database Configuration
 		DBHost:     getEnv("DB_HOST", "localhost"),
@@ -127,6 +243,9 @@ func LoadConfig() *Config {
 		DBName:     getEnv("DB_NAME", "keerja"),
 		DBSSLMode:  getEnv("DB_SSLMODE", "disable"),
 
+		SlowQueryThreshold: time.Duration(getEnvAsInt("SLOW_QUERY_THRESHOLD_MS", int(DefaultSlowQueryThreshold.Milliseconds()))) * time.Millisecond,
+		SQLLogLevel:        getEnv("SQL_LOG_LEVEL", ""),
+
 		// JWT Configuration
 		JWTSecret:                getEnv("JWT_SECRET", "your-secret-key-change-this"),
 		JWTExpirationHours:       getEnvAsInt("JWT_EXPIRATION_HOURS", 24),
@@ -139,14 +258,28 @@ func LoadConfig() *Config {
 		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
 		SMTPFrom:     getEnv("SMTP_FROM", "noreply@keerja.com"),
 
+		EmailQueueMaxAttempts: getEnvAsInt("EMAIL_QUEUE_MAX_ATTEMPTS", 5),
+		EmailQueueBatchSize:   getEnvAsInt("EMAIL_QUEUE_BATCH_SIZE", 50),
+
 		// Storage Configuration
-		StorageProvider: getEnv("STORAGE_PROVIDER", "local"),
+		StorageProvider: getEnv("UPLOAD_PROVIDER", getEnv("STORAGE_PROVIDER", "local")),
 		AWSRegion:       getEnv("AWS_REGION", ""),
 		AWSBucket:       getEnv("AWS_BUCKET", ""),
 		AWSAccessKey:    getEnv("AWS_ACCESS_KEY", ""),
 		AWSSecretKey:    getEnv("AWS_SECRET_KEY", ""),
 		CloudinaryURL:   getEnv("CLOUDINARY_URL", ""),
 		UploadPath:      getEnv("UPLOAD_PATH", "./uploads"),
+		S3Endpoint:      getEnv("S3_ENDPOINT", ""),
+		S3UseSSL:        getEnvAsBool("S3_USE_SSL", true),
+		S3PublicBaseURL: getEnv("S3_PUBLIC_BASE_URL", ""),
+		UploadBaseURL:   getEnv("UPLOAD_BASE_URL", ""),
+
+		UploadMaxAvatarMB:   getEnvAsInt("UPLOAD_MAX_AVATAR_MB", 5),
+		UploadMaxCoverMB:    getEnvAsInt("UPLOAD_MAX_COVER_MB", 10),
+		UploadMaxDocumentMB: getEnvAsInt("UPLOAD_MAX_DOCUMENT_MB", 20),
+
+		DownloadURLSecret: getEnv("DOWNLOAD_URL_SECRET", ""),
+		DownloadURLTTL:    time.Duration(getEnvAsInt("DOWNLOAD_URL_TTL_SECONDS", 300)) * time.Second,
 
 		// Redis Configuration
 		RedisHost:     getEnv("REDIS_HOST", "localhost"),
@@ -154,14 +287,32 @@ func LoadConfig() *Config {
 		RedisPassword: getEnv("REDIS_PASSWORD", ""),
 		RedisDB:       getEnvAsInt("REDIS_DB", 0),
 		RedisURL:      getEnv("REDIS_URL", ""),
+		// Production refuses to start without Redis unless told otherwise
+		RedisRequired:          getEnvAsBool("REDIS_REQUIRED", getEnv("APP_ENV", "development") == "production"),
+		RedisReconnectInterval: time.Duration(getEnvAsInt("REDIS_RECONNECT_INTERVAL_SECONDS", 30)) * time.Second,
 
 		// Rate Limiting
 		RateLimitEnabled: getEnvAsBool("RATE_LIMIT_ENABLED", true),
 		RateLimitMax:     getEnvAsInt("RATE_LIMIT_MAX", 100),
 		RateLimitWindow:  time.Duration(getEnvAsInt("RATE_LIMIT_WINDOW_SECONDS", 60)) * time.Second,
+		// Named policies applied by the global limiter on top of the default per-IP limit
+		RateLimitAuthMax:     getEnvAsInt("RATE_LIMIT_AUTH_MAX", 10),
+		RateLimitAuthWindow:  time.Duration(getEnvAsInt("RATE_LIMIT_AUTH_WINDOW_SECONDS", 900)) * time.Second,
+		RateLimitAuthIPMax:   getEnvAsInt("RATE_LIMIT_AUTH_IP_MAX", 50),
+		RateLimitEmployerMax: getEnvAsInt("RATE_LIMIT_EMPLOYER_MAX", 600),
+		RateLimitAdminBypass: getEnvAsBool("RATE_LIMIT_ADMIN_BYPASS", true),
+		// API key limits are kept separate from the per-IP user limiter
+		APIKeyRateLimitMax: getEnvAsInt("API_KEY_RATE_LIMIT_MAX", 300),
 
 		// CORS Configuration
-		AllowedOrigins: getEnvAsSlice("ALLOWED_ORIGINS", []string{"http://localhost:3000", "http://localhost:5173"}),
+		CORSAllowedOrigins: getEnvAsSlice("CORS_ALLOWED_ORIGINS", getEnvAsSlice("ALLOWED_ORIGINS", defaultCORSOrigins(getEnv("APP_ENV", "development")))),
+		CORSAllowedMethods: getEnvAsSlice("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}),
+		CORSAllowedHeaders: getEnvAsSlice("CORS_ALLOWED_HEADERS", []string{
+			"Origin", "Content-Type", "Accept", "Accept-Language", "Authorization", "X-Requested-With",
+			"Idempotency-Key", "X-Company-ID", "X-Request-ID",
+		}),
+		CORSMaxAge:           time.Duration(getEnvAsInt("CORS_MAX_AGE_SECONDS", 3600)) * time.Second,
+		CORSAllowCredentials: getEnvAsBool("CORS_ALLOW_CREDENTIALS", true),
 
 		// Pagination
 		DefaultPageSize: getEnvAsInt("DEFAULT_PAGE_SIZE", 10),
@@ -190,6 +341,65 @@ func LoadConfig() *Config {
 		// Mobile redirect whitelist for OAuth
 		AllowedMobileRedirectURIs: getEnvAsSlice("ALLOWED_MOBILE_REDIRECT_URIS", []string{}),
 
+		// Email Verification Configuration
+		EmailVerificationGraceDays: getEnvAsInt("EMAIL_VERIFICATION_GRACE_DAYS", 3),
+
+		// Company Verification Configuration
+		VerificationGraceDays: getEnvAsInt("VERIFICATION_GRACE_DAYS", 14),
+
+		// Job Posting Configuration
+		JobDefaultExpiryDays: getEnvAsInt("JOB_DEFAULT_EXPIRY_DAYS", 30),
+		JobMaxAutoExtensions: getEnvAsInt("JOB_MAX_AUTO_EXTENSIONS", 2),
+		JobQuotaFree:         getEnvAsInt("JOB_QUOTA_FREE", 3),
+		JobQuotaVerified:     getEnvAsInt("JOB_QUOTA_VERIFIED", 10),
+		JobQuotaPremium:      getEnvAsInt("JOB_QUOTA_PREMIUM", 50),
+
+		JobViewHashSecret:    getEnv("JOB_VIEW_HASH_SECRET", ""),
+		JobViewRetentionDays: getEnvAsInt("JOB_VIEW_RETENTION_DAYS", 365),
+
+		// Job Application Configuration
+		ReapplyAfterWithdrawDays: getEnvAsInt("REAPPLY_AFTER_WITHDRAW_DAYS", 30),
+		ReapplyAfterRejectDays:   getEnvAsInt("REAPPLY_AFTER_REJECT_DAYS", 90),
+		ApplicationDocumentURLs:  getEnvAsBool("APPLICATION_DOCUMENT_URLS_ENABLED", true),
+
+		// Account Privacy Configuration
+		AccountDeletionGraceDays: getEnvAsInt("ACCOUNT_DELETION_GRACE_DAYS", 14),
+		DataExportLinkHours:      getEnvAsInt("DATA_EXPORT_LINK_HOURS", 48),
+
+		// Audit Log Configuration
+		AuditBufferSize: getEnvAsInt("AUDIT_BUFFER_SIZE", 1024),
+
+		// Admin Impersonation Configuration
+		ImpersonationTTL:              time.Duration(getEnvAsInt("IMPERSONATION_TTL_MINUTES", 15)) * time.Minute,
+		ImpersonationAllowedMutations: getEnvAsSlice("IMPERSONATION_ALLOWED_MUTATIONS", []string{}),
+
+		// Webhook Configuration
+		WebhookTimeout:          getEnvAsInt("WEBHOOK_TIMEOUT_SECONDS", 10),
+		WebhookAllowPrivateURLs: getEnvAsBool("WEBHOOK_ALLOW_PRIVATE_URLS", false),
+
+		// Geocoding Configuration
+		GeocoderProvider: getEnv("GEOCODER_PROVIDER", ""),
+		GeocoderBaseURL:  getEnv("GEOCODER_BASE_URL", ""),
+		GoogleMapsAPIKey: getEnv("GOOGLE_MAPS_API_KEY", ""),
+		GeocoderTimeout:  getEnvAsInt("GEOCODER_TIMEOUT_SECONDS", 5),
+
+		// SMS Configuration
+		SMSProvider: getEnv("SMS_PROVIDER", "log"),
+
+		// WhatsApp Configuration
+		WhatsAppProvider:      getEnv("WHATSAPP_PROVIDER", ""),
+		WhatsAppAPIBaseURL:    getEnv("WHATSAPP_API_BASE_URL", ""),
+		WhatsAppToken:         getEnv("WHATSAPP_TOKEN", ""),
+		WhatsAppPhoneNumberID: getEnv("WHATSAPP_PHONE_NUMBER_ID", ""),
+		WhatsAppLanguage:      getEnv("WHATSAPP_TEMPLATE_LANGUAGE", "id"),
+		WhatsAppTemplates: map[string]string{
+			"interview_scheduled":   getEnv("WHATSAPP_TEMPLATE_INTERVIEW_SCHEDULED", "interview_scheduled"),
+			"interview_rescheduled": getEnv("WHATSAPP_TEMPLATE_INTERVIEW_RESCHEDULED", "interview_rescheduled"),
+			"interview_cancelled":   getEnv("WHATSAPP_TEMPLATE_INTERVIEW_CANCELLED", "interview_cancelled"),
+			"offer_made":            getEnv("WHATSAPP_TEMPLATE_OFFER_MADE", "offer_made"),
+		},
+		WhatsAppTimeout: getEnvAsInt("WHATSAPP_TIMEOUT_SECONDS", 10),
+
 		// FCM Configuration
 		FCMEnabled:         getEnvAsBool("FCM_ENABLED", false),
 		FCMProjectID:       getEnv("FCM_PROJECT_ID", ""),
@@ -199,6 +409,9 @@ func LoadConfig() *Config {
 		FCMMaxRetries:      getEnvAsInt("FCM_MAX_RETRIES", 3),
 		PushDefaultSound:   getEnv("PUSH_DEFAULT_SOUND", "default"),
 		PushDefaultTTL:     getEnvAsInt("PUSH_DEFAULT_TTL", 86400), // 24 hours
+
+		PushMaxDevicesPerUser:   getEnvAsInt("PUSH_MAX_DEVICES_PER_USER", 5),
+		DeviceTokenInactiveDays: getEnvAsInt("DEVICE_TOKEN_INACTIVE_DAYS", 90),
 	}
 
 	// If a credentials JSON file is provided (downloaded from Google Console), prefer values from it when env vars are empty
@@ -266,6 +479,26 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.JobDefaultExpiryDays <= 0 {
+		return fmt.Errorf("JOB_DEFAULT_EXPIRY_DAYS must be greater than 0")
+	}
+
+	if c.JobQuotaFree <= 0 || c.JobQuotaVerified <= 0 || c.JobQuotaPremium <= 0 {
+		return fmt.Errorf("JOB_QUOTA_FREE, JOB_QUOTA_VERIFIED and JOB_QUOTA_PREMIUM must be greater than 0")
+	}
+
+	if c.ImpersonationTTL <= 0 {
+		return fmt.Errorf("IMPERSONATION_TTL_MINUTES must be greater than 0")
+	}
+
+	if err := c.ValidateUpload(); err != nil {
+		return fmt.Errorf("upload configuration error: %w", err)
+	}
+
+	if err := c.ValidateCORS(); err != nil {
+		return fmt.Errorf("CORS configuration error: %w", err)
+	}
+
 	// Validate FCM configuration if enabled
 	if c.FCMEnabled {
 		if err := c.ValidateFCM(); err != nil {
@@ -276,6 +509,69 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// ValidateUpload validates the upload storage configuration
+func (c *Config) ValidateUpload() error {
+	switch c.StorageProvider {
+	case StorageLocal:
+		if c.UploadPath == "" {
+			return fmt.Errorf("UPLOAD_PATH is required when UPLOAD_PROVIDER is local")
+		}
+		if c.UploadBaseURL == "" && c.AppEnv != EnvDevelopment {
+			return fmt.Errorf("UPLOAD_BASE_URL is required when UPLOAD_PROVIDER is local outside development")
+		}
+	case StorageS3:
+		if c.AWSBucket == "" {
+			return fmt.Errorf("AWS_BUCKET is required when UPLOAD_PROVIDER is s3")
+		}
+	default:
+		return fmt.Errorf("UPLOAD_PROVIDER must be local or s3, got %q", c.StorageProvider)
+	}
+
+	if c.UploadBaseURL != "" {
+		u, err := url.Parse(c.UploadBaseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("UPLOAD_BASE_URL must be an absolute http(s) URL, got %q", c.UploadBaseURL)
+		}
+	}
+
+	if c.UploadMaxAvatarMB <= 0 || c.UploadMaxCoverMB <= 0 || c.UploadMaxDocumentMB <= 0 {
+		return fmt.Errorf("UPLOAD_MAX_AVATAR_MB, UPLOAD_MAX_COVER_MB and UPLOAD_MAX_DOCUMENT_MB must be positive")
+	}
+
+	if c.DownloadURLTTL <= 0 {
+		return fmt.Errorf("DOWNLOAD_URL_TTL_SECONDS must be positive")
+	}
+
+	return nil
+}
+
+// ValidateCORS rejects malformed origins and credentials combined with the "*" origin,
+// which browsers refuse
+func (c *Config) ValidateCORS() error {
+	origins, err := ParseCORSOrigins(c.CORSAllowedOrigins)
+	if err != nil {
+		return err
+	}
+
+	if c.CORSAllowCredentials {
+		for _, origin := range origins {
+			if origin.IsAny() {
+				return fmt.Errorf("CORS_ALLOW_CREDENTIALS cannot be combined with the * origin; list the allowed origins instead")
+			}
+		}
+	}
+
+	if len(c.CORSAllowedMethods) == 0 {
+		return fmt.Errorf("CORS_ALLOWED_METHODS must not be empty")
+	}
+
+	if c.CORSMaxAge < 0 {
+		return fmt.Errorf("CORS_MAX_AGE_SECONDS must not be negative")
+	}
+
+	return nil
+}
+
 // ValidateFCM validates FCM-specific configuration
 func (c *Config) ValidateFCM() error {
 	if c.FCMProjectID == "" {
@@ -323,6 +619,58 @@ func (c *Config) GetDSN() string {
 	)
 }
 
+// GetS3PublicBaseURL returns the public URL prefix for uploaded objects
+func (c *Config) GetS3PublicBaseURL() string {
+	if c.S3PublicBaseURL != "" {
+		return strings.TrimSuffix(c.S3PublicBaseURL, "/")
+	}
+
+	scheme := "https"
+	if !c.S3UseSSL {
+		scheme = "http"
+	}
+
+	// Custom endpoints (MinIO, GCS interop) use path-style URLs
+	if c.S3Endpoint != "" {
+		return fmt.Sprintf("%s://%s/%s", scheme, c.S3Endpoint, c.AWSBucket)
+	}
+
+	if c.AWSRegion == "" {
+		return fmt.Sprintf("%s://%s.s3.amazonaws.com", scheme, c.AWSBucket)
+	}
+	return fmt.Sprintf("%s://%s.s3.%s.amazonaws.com", scheme, c.AWSBucket, c.AWSRegion)
+}
+
+// GetUploadBaseURL returns the public URL prefix of uploaded files: the bucket URL for s3,
+// otherwise UPLOAD_BASE_URL or the local server's /uploads route
+func (c *Config) GetUploadBaseURL() string {
+	if c.StorageProvider == StorageS3 {
+		return c.GetS3PublicBaseURL()
+	}
+	if c.UploadBaseURL != "" {
+		return strings.TrimSuffix(c.UploadBaseURL, "/")
+	}
+	return fmt.Sprintf("http://localhost:%s%s", c.ServerPort, UploadRoutePrefix)
+}
+
+// defaultCORSOrigins returns the web origins allowed when neither CORS_ALLOWED_ORIGINS
+// nor ALLOWED_ORIGINS is set
+func defaultCORSOrigins(appEnv string) []string {
+	switch appEnv {
+	case EnvProduction:
+		return []string{"https://keerja.com", "https://www.keerja.com"}
+	case EnvStaging:
+		return []string{"https://*.keerja.com"}
+	default:
+		return []string{
+			"http://localhost:3000",
+			"http://localhost:3001",
+			"http://localhost:5173", // Vite default
+			"http://127.0.0.1:3000",
+		}
+	}
+}
+
 // Helper functions
 
 func getEnv(key string, defaultValue string) string {
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// CORSAnyOrigin allows every origin. It cannot be combined with CORS_ALLOW_CREDENTIALS.
+const CORSAnyOrigin = "*"
+
+// CORSOrigin is a parsed CORS_ALLOWED_ORIGINS entry: "*", an exact origin such as
+// "https://keerja.com", or a subdomain wildcard such as "https://*.keerja.com"
+type CORSOrigin struct {
+	any    bool
+	scheme string
+	host   string // Without the leading "*" for wildcards, e.g. ".keerja.com"
+	port   string
+}
+
+// ParseCORSOrigin parses an allowed origin pattern. Patterns are scheme://host[:port]
+// without a path; a wildcard host "*.keerja.com" matches its subdomains but not keerja.com itself.
+func ParseCORSOrigin(pattern string) (CORSOrigin, error) {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	if pattern == CORSAnyOrigin {
+		return CORSOrigin{any: true}, nil
+	}
+
+	scheme, host, port, ok := splitOrigin(pattern)
+	if !ok {
+		return CORSOrigin{}, fmt.Errorf("CORS origin must look like https://host[:port], got %q", pattern)
+	}
+
+	if wildcard, found := strings.CutPrefix(host, "*."); found {
+		// "*.com" would allow every site on the TLD
+		if !strings.Contains(wildcard, ".") || !isOriginHost(wildcard) {
+			return CORSOrigin{}, fmt.Errorf("CORS wildcard origin must cover a domain like https://*.example.com, got %q", pattern)
+		}
+		host = "." + wildcard
+	} else if !isOriginHost(host) {
+		return CORSOrigin{}, fmt.Errorf("CORS origin has an invalid host, got %q", pattern)
+	}
+
+	return CORSOrigin{scheme: scheme, host: host, port: port}, nil
+}
+
+// ParseCORSOrigins parses every pattern, stopping at the first invalid one
+func ParseCORSOrigins(patterns []string) ([]CORSOrigin, error) {
+	origins := make([]CORSOrigin, 0, len(patterns))
+	for _, pattern := range patterns {
+		origin, err := ParseCORSOrigin(pattern)
+		if err != nil {
+			return nil, err
+		}
+		origins = append(origins, origin)
+	}
+	return origins, nil
+}
+
+// IsAny reports whether the pattern is "*"
+func (o CORSOrigin) IsAny() bool {
+	return o.any
+}
+
+// IsWildcard reports whether the pattern matches subdomains
+func (o CORSOrigin) IsWildcard() bool {
+	return strings.HasPrefix(o.host, ".")
+}
+
+// Matches reports whether an Origin header value is allowed by the pattern. The scheme
+// and port must match exactly.
+func (o CORSOrigin) Matches(origin string) bool {
+	if o.any {
+		return true
+	}
+
+	scheme, host, port, ok := splitOrigin(strings.ToLower(origin))
+	if !ok || scheme != o.scheme || port != o.port || !isOriginHost(host) {
+		return false
+	}
+	if o.IsWildcard() {
+		return len(host) > len(o.host) && strings.HasSuffix(host, o.host)
+	}
+	return host == o.host
+}
+
+// splitOrigin splits scheme://host[:port] into its parts
+func splitOrigin(origin string) (scheme, host, port string, ok bool) {
+	scheme, hostport, found := strings.Cut(origin, "://")
+	if !found || (scheme != "http" && scheme != "https") || hostport == "" || strings.ContainsAny(hostport, "/?#@") {
+		return "", "", "", false
+	}
+
+	host = hostport
+	if strings.Contains(hostport, ":") {
+		var err error
+		if host, port, err = net.SplitHostPort(hostport); err != nil {
+			return "", "", "", false
+		}
+		if n, err := strconv.Atoi(port); err != nil || n <= 0 || n > 65535 {
+			return "", "", "", false
+		}
+	}
+	return scheme, host, port, host != ""
+}
+
+// isOriginHost checks for a DNS name, IPv4 address or bracket-less IPv6 address
+func isOriginHost(host string) bool {
+	if net.ParseIP(host) != nil {
+		return true
+	}
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for _, r := range label {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return false
+			}
+		}
+	}
+	return true
+}
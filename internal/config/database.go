@@ -16,13 +16,12 @@ var DB *gorm.DB
 func InitDB(cfg *Config) (*gorm.DB, error) {
 	dsn := cfg.GetDSN()
 
-	// Configure GORM logger
-	var gormLogger gormlogger.Interface
+	// Configure GORM logger: failed and slow queries are logged with their request ID
+	level := gormlogger.Warn
 	if cfg.IsDevelopment() {
-		gormLogger = gormlogger.Default.LogMode(gormlogger.Info)
-	} else {
-		gormLogger = gormlogger.Default.LogMode(gormlogger.Error)
+		level = gormlogger.Info
 	}
+	gormLogger := NewSQLLogger(GetLogger(), ParseSQLLogLevel(cfg.SQLLogLevel, level), cfg.SlowQueryThreshold)
 
 	// Open database connection
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
@@ -36,6 +36,15 @@ const (
 	EnvAWSSecretKey    = "AWS_SECRET_KEY"
 	EnvCloudinaryURL   = "CLOUDINARY_URL"
 	EnvUploadPath      = "UPLOAD_PATH"
+	EnvS3Endpoint      = "S3_ENDPOINT"
+	EnvS3UseSSL        = "S3_USE_SSL"
+	EnvS3PublicBaseURL = "S3_PUBLIC_BASE_URL"
+	EnvUploadProvider  = "UPLOAD_PROVIDER" // Takes precedence over STORAGE_PROVIDER
+	EnvUploadBaseURL   = "UPLOAD_BASE_URL"
+
+	EnvUploadMaxAvatarMB   = "UPLOAD_MAX_AVATAR_MB"
+	EnvUploadMaxCoverMB    = "UPLOAD_MAX_COVER_MB"
+	EnvUploadMaxDocumentMB = "UPLOAD_MAX_DOCUMENT_MB"
 
 	// Redis
 	EnvRedisHost     = "REDIS_HOST"
@@ -43,6 +52,7 @@ const (
 	EnvRedisPassword = "REDIS_PASSWORD"
 	EnvRedisDB       = "REDIS_DB"
 	EnvRedisURL      = "REDIS_URL"
+	EnvRedisRequired = "REDIS_REQUIRED"
 
 	// Google credentials file for local development (JSON downloaded from Google Console)
 	EnvGoogleCredentialsFile = "GOOGLE_CREDENTIALS_FILE"
@@ -51,12 +61,22 @@ const (
 	EnvAllowedMobileRedirects = "ALLOWED_MOBILE_REDIRECT_URIS"
 
 	// Rate Limiting
-	EnvRateLimitEnabled       = "RATE_LIMIT_ENABLED"
-	EnvRateLimitMax           = "RATE_LIMIT_MAX"
-	EnvRateLimitWindowSeconds = "RATE_LIMIT_WINDOW_SECONDS"
+	EnvRateLimitEnabled           = "RATE_LIMIT_ENABLED"
+	EnvRateLimitMax               = "RATE_LIMIT_MAX"
+	EnvRateLimitWindowSeconds     = "RATE_LIMIT_WINDOW_SECONDS"
+	EnvRateLimitAuthMax           = "RATE_LIMIT_AUTH_MAX"
+	EnvRateLimitAuthWindowSeconds = "RATE_LIMIT_AUTH_WINDOW_SECONDS"
+	EnvRateLimitAuthIPMax         = "RATE_LIMIT_AUTH_IP_MAX"
+	EnvRateLimitEmployerMax       = "RATE_LIMIT_EMPLOYER_MAX"
+	EnvRateLimitAdminBypass       = "RATE_LIMIT_ADMIN_BYPASS"
 
 	// CORS
-	EnvAllowedOrigins = "ALLOWED_ORIGINS"
+	EnvCORSAllowedOrigins   = "CORS_ALLOWED_ORIGINS"
+	EnvCORSAllowedMethods   = "CORS_ALLOWED_METHODS"
+	EnvCORSAllowedHeaders   = "CORS_ALLOWED_HEADERS"
+	EnvCORSMaxAgeSeconds    = "CORS_MAX_AGE_SECONDS"
+	EnvCORSAllowCredentials = "CORS_ALLOW_CREDENTIALS"
+	EnvAllowedOrigins       = "ALLOWED_ORIGINS" // Read when CORS_ALLOWED_ORIGINS is unset
 
 	// Pagination
 	EnvDefaultPageSize = "DEFAULT_PAGE_SIZE"
@@ -90,8 +110,12 @@ const (
 	DefaultRedisDB   = 0
 	DefaultRedisURL  = ""
 
-	DefaultRateLimitMax           = 100
-	DefaultRateLimitWindowSeconds = 60
+	DefaultRateLimitMax               = 100
+	DefaultRateLimitWindowSeconds     = 60
+	DefaultRateLimitAuthMax           = 10
+	DefaultRateLimitAuthWindowSeconds = 900
+	DefaultRateLimitAuthIPMax         = 50
+	DefaultRateLimitEmployerMax       = 600
 
 	DefaultPageSize    = 10
 	DefaultMaxPageSize = 100
@@ -110,3 +134,9 @@ const (
 	StorageS3         = "s3"
 	StorageCloudinary = "cloudinary"
 )
+
+// UploadRoutePrefix is the route locally stored uploads are served from
+const UploadRoutePrefix = "/uploads"
+
+// DownloadRoutePrefix is the route private documents are downloaded from with a signed token
+const DownloadRoutePrefix = "/api/v1/downloads"
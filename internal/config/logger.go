@@ -1,6 +1,7 @@
 package config
 
 import (
+	"context"
 	"os"
 
 	"github.com/sirupsen/logrus"
@@ -51,3 +52,48 @@ func WithFields(fields logrus.Fields) *logrus.Entry {
 func WithError(err error) *logrus.Entry {
 	return GetLogger().WithError(err)
 }
+
+// RequestInfo identifies the HTTP request a context belongs to in logs
+type RequestInfo struct {
+	ID    string // X-Request-ID of the request
+	Route string // Method and path, e.g. "GET /api/v1/jobs/12"
+}
+
+type requestInfoKey struct{}
+
+// WithRequestInfo returns a copy of ctx carrying the request it belongs to
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFromContext returns the request ctx belongs to, empty outside a request
+func RequestInfoFromContext(ctx context.Context) RequestInfo {
+	if ctx == nil {
+		return RequestInfo{}
+	}
+	info, _ := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info
+}
+
+// RequestIDFromContext returns the ID of the request ctx belongs to, "" outside a request
+func RequestIDFromContext(ctx context.Context) string {
+	return RequestInfoFromContext(ctx).ID
+}
+
+// WithContext returns a log entry carrying the request ID and route of ctx, so every log
+// line of a request, SQL included, can be found by the request's X-Request-ID
+func WithContext(ctx context.Context) *logrus.Entry {
+	return entryWithContext(GetLogger(), ctx)
+}
+
+// entryWithContext returns an entry of l carrying the request of ctx
+func entryWithContext(l *logrus.Logger, ctx context.Context) *logrus.Entry {
+	info := RequestInfoFromContext(ctx)
+	if info.ID == "" {
+		return logrus.NewEntry(l)
+	}
+	return l.WithFields(logrus.Fields{
+		"request_id": info.ID,
+		"route":      info.Route,
+	})
+}
@@ -16,6 +16,26 @@ func InitRedis(cfg *Config) (*redis.Client, error) {
 		cfg = LoadConfig()
 	}
 
+	client, err := NewRedisClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	redisClient = client
+	return client, nil
+}
+
+// NewRedisClient creates a Redis client without checking that Redis is reachable.
+// The client connects lazily, so it can be created while Redis is down.
+func NewRedisClient(cfg *Config) (*redis.Client, error) {
 	var opts *redis.Options
 	var err error
 
@@ -32,17 +52,7 @@ func InitRedis(cfg *Config) (*redis.Client, error) {
 		}
 	}
 
-	client := redis.NewClient(opts)
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	if err := client.Ping(ctx).Err(); err != nil {
-		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
-	}
-
-	redisClient = client
-	return client, nil
+	return redis.NewClient(opts), nil
 }
 
 // GetRedis returns the initialized Redis client.
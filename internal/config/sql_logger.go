@@ -0,0 +1,116 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"keerja-backend/internal/metrics"
+)
+
+// DefaultSlowQueryThreshold is how long a query may take before it is logged as slow
+const DefaultSlowQueryThreshold = 200 * time.Millisecond
+
+// maxLoggedSQLLength truncates logged statements, bulk inserts can be megabytes long
+const maxLoggedSQLLength = 2000
+
+// SQLLogger is the GORM logger. It logs failed and slow queries with the request ID and
+// route of the query's context, and counts slow queries in the default metrics; at the info
+// level every query is logged at debug.
+type SQLLogger struct {
+	logger        *logrus.Logger
+	level         gormlogger.LogLevel
+	slowThreshold time.Duration
+}
+
+// NewSQLLogger creates a GORM logger writing to logger. Queries taking longer than
+// slowThreshold are slow; zero disables slow query logging.
+func NewSQLLogger(logger *logrus.Logger, level gormlogger.LogLevel, slowThreshold time.Duration) *SQLLogger {
+	return &SQLLogger{
+		logger:        logger,
+		level:         level,
+		slowThreshold: slowThreshold,
+	}
+}
+
+// ParseSQLLogLevel parses SQL_LOG_LEVEL: silent, error, warn or info. Anything else gives fallback.
+func ParseSQLLogLevel(level string, fallback gormlogger.LogLevel) gormlogger.LogLevel {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "silent":
+		return gormlogger.Silent
+	case "error":
+		return gormlogger.Error
+	case "warn", "warning":
+		return gormlogger.Warn
+	case "info":
+		return gormlogger.Info
+	}
+	return fallback
+}
+
+// LogMode returns a copy of the logger at level
+func (l *SQLLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	copied := *l
+	copied.level = level
+	return &copied
+}
+
+// Info logs a GORM message at info
+func (l *SQLLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Info {
+		entryWithContext(l.logger, ctx).Infof(msg, data...)
+	}
+}
+
+// Warn logs a GORM message at warn
+func (l *SQLLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		entryWithContext(l.logger, ctx).Warnf(msg, data...)
+	}
+}
+
+// Error logs a GORM message at error
+func (l *SQLLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Error {
+		entryWithContext(l.logger, ctx).Errorf(msg, data...)
+	}
+}
+
+// Trace logs a finished query. Record not found is an expected result, not a failure.
+func (l *SQLLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	elapsed := time.Since(begin)
+	slow := l.slowThreshold > 0 && elapsed > l.slowThreshold
+	if slow {
+		metrics.Default().SlowQuery()
+	}
+
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= gormlogger.Error:
+		l.queryEntry(ctx, elapsed, fc).WithError(err).Error("SQL query failed")
+	case slow && l.level >= gormlogger.Warn:
+		l.queryEntry(ctx, elapsed, fc).
+			WithField("threshold_ms", l.slowThreshold.Milliseconds()).
+			Warn("Slow SQL query")
+	case l.level >= gormlogger.Info:
+		l.queryEntry(ctx, elapsed, fc).Debug("SQL query")
+	}
+}
+
+// queryEntry returns a log entry describing a query of the request of ctx
+func (l *SQLLogger) queryEntry(ctx context.Context, elapsed time.Duration, fc func() (string, int64)) *logrus.Entry {
+	sql, rows := fc()
+	if len(sql) > maxLoggedSQLLength {
+		sql = fmt.Sprintf("%s... (%d bytes)", sql[:maxLoggedSQLLength], len(sql))
+	}
+	return entryWithContext(l.logger, ctx).WithFields(logrus.Fields{
+		"duration_ms": float64(elapsed.Microseconds()) / 1000,
+		"rows":        rows,
+		"sql":         sql,
+	})
+}
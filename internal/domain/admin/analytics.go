@@ -0,0 +1,115 @@
+package admin
+
+import (
+	"context"
+	"time"
+)
+
+// Platform metrics reported by the admin analytics summary
+const (
+	MetricNewUsers              = "new_users"
+	MetricNewCompanies          = "new_companies"
+	MetricJobsPublished         = "jobs_published"
+	MetricApplicationsSubmitted = "applications_submitted"
+	MetricHires                 = "hires"
+)
+
+// Analytics bucket intervals, named after the date_trunc field they group by
+const (
+	AnalyticsIntervalDay   = "day"
+	AnalyticsIntervalWeek  = "week"
+	AnalyticsIntervalMonth = "month"
+)
+
+// MaxAnalyticsBuckets caps the number of buckets in one summary series
+const MaxAnalyticsBuckets = 400
+
+// ValidAnalyticsInterval reports whether interval is a supported bucket interval
+func ValidAnalyticsInterval(interval string) bool {
+	switch interval {
+	case AnalyticsIntervalDay, AnalyticsIntervalWeek, AnalyticsIntervalMonth:
+		return true
+	}
+	return false
+}
+
+// TruncateToInterval returns the start of the bucket holding t, matching Postgres
+// date_trunc: days start at midnight, weeks on Monday and months on the 1st
+func TruncateToInterval(t time.Time, interval string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch interval {
+	case AnalyticsIntervalWeek:
+		offset := (int(day.Weekday()) + 6) % 7 // days since Monday
+		return day.AddDate(0, 0, -offset)
+	case AnalyticsIntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	default:
+		return day
+	}
+}
+
+// NextBucket returns the start of the bucket after the one starting at bucket
+func NextBucket(bucket time.Time, interval string) time.Time {
+	switch interval {
+	case AnalyticsIntervalWeek:
+		return bucket.AddDate(0, 0, 7)
+	case AnalyticsIntervalMonth:
+		return bucket.AddDate(0, 1, 0)
+	default:
+		return bucket.AddDate(0, 0, 1)
+	}
+}
+
+// AnalyticsBucketCount is the number of events of one metric in one bucket of a period
+type AnalyticsBucketCount struct {
+	Metric   string    `gorm:"column:metric"`
+	Previous bool      `gorm:"column:previous"` // Counted in the comparison period rather than the requested one
+	Bucket   time.Time `gorm:"column:bucket"`
+	Count    int64     `gorm:"column:count"`
+}
+
+// AdminAnalyticsRepository defines the aggregate queries behind the admin dashboard
+type AdminAnalyticsRepository interface {
+	// CountByBucket counts new users, new companies, published jobs, submitted applications
+	// and hires between previousFrom and to (exclusive), grouped by metric and interval
+	// bucket. Events before from are flagged as belonging to the previous period.
+	CountByBucket(ctx context.Context, previousFrom, from, to time.Time, interval string) ([]AnalyticsBucketCount, error)
+}
+
+// AdminAnalyticsService defines business logic for the admin dashboard analytics
+type AdminAnalyticsService interface {
+	// GetPlatformSummary returns the totals and bucketed series of every platform metric
+	// between from and to (exclusive), compared with the previous period of equal length
+	GetPlatformSummary(ctx context.Context, from, to time.Time, interval string) (*PlatformSummaryResponse, error)
+}
+
+// PlatformSummaryResponse is the admin dashboard summary for one period
+type PlatformSummaryResponse struct {
+	From                  time.Time      `json:"from"`
+	To                    time.Time      `json:"to"`
+	PreviousFrom          time.Time      `json:"previous_from"`
+	PreviousTo            time.Time      `json:"previous_to"`
+	Interval              string         `json:"interval"`
+	NewUsers              PlatformMetric `json:"new_users"`
+	NewCompanies          PlatformMetric `json:"new_companies"`
+	JobsPublished         PlatformMetric `json:"jobs_published"`
+	ApplicationsSubmitted PlatformMetric `json:"applications_submitted"`
+	Hires                 PlatformMetric `json:"hires"`
+	GeneratedAt           time.Time      `json:"generated_at"`
+}
+
+// PlatformMetric is a metric's total and series for a period with its change against
+// the previous period
+type PlatformMetric struct {
+	Total         int64            `json:"total"`
+	PreviousTotal int64            `json:"previous_total"`
+	Change        int64            `json:"change"`
+	ChangePercent *float64         `json:"change_percent"` // Nil when the previous period had no events
+	Series        []AnalyticsPoint `json:"series"`
+}
+
+// AnalyticsPoint is the count of one bucket of a series
+type AnalyticsPoint struct {
+	Bucket time.Time `json:"bucket"`
+	Count  int64     `json:"count"`
+}
@@ -3,20 +3,22 @@ package admin
 import (
 	"time"
 
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
 // AdminRole represents an administrative role with specific permissions
 // Maps to: admin_roles table
 type AdminRole struct {
-	ID              int64     `gorm:"primaryKey;autoIncrement" json:"id"`
-	RoleName        string    `gorm:"type:varchar(100);not null;uniqueIndex" json:"role_name" validate:"required,min=3,max=100"`
-	RoleDescription string    `gorm:"type:text" json:"role_description,omitempty"`
-	AccessLevel     int16     `gorm:"type:smallint;default:5" json:"access_level" validate:"min=1,max=10"`
-	IsSystemRole    bool      `gorm:"default:false" json:"is_system_role"`
-	CreatedBy       *int64    `gorm:"index" json:"created_by,omitempty"`
-	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt       time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID              int64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	RoleName        string         `gorm:"type:varchar(100);not null;uniqueIndex" json:"role_name" validate:"required,min=3,max=100"`
+	RoleDescription string         `gorm:"type:text" json:"role_description,omitempty"`
+	AccessLevel     int16          `gorm:"type:smallint;default:5" json:"access_level" validate:"min=1,max=10"`
+	IsSystemRole    bool           `gorm:"default:false" json:"is_system_role"`
+	Permissions     pq.StringArray `gorm:"type:text[];not null;default:'{}'" json:"permissions"`
+	CreatedBy       *int64         `gorm:"index" json:"created_by,omitempty"`
+	CreatedAt       time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
 
 	// Relationships
 	Creator *AdminUser  `gorm:"foreignKey:CreatedBy;constraint:OnDelete:SET NULL" json:"creator,omitempty"`
@@ -43,6 +45,16 @@ func (r *AdminRole) IsModerator() bool {
 	return r.AccessLevel >= 5
 }
 
+// HasPermission checks if the role grants perm
+func (r *AdminRole) HasPermission(perm string) bool {
+	for _, p := range r.Permissions {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
 // CanModifyRole checks if this role can modify another role based on access level
 func (r *AdminRole) CanModifyRole(targetRole *AdminRole) bool {
 	return r.AccessLevel > targetRole.AccessLevel
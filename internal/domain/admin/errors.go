@@ -0,0 +1,32 @@
+package admin
+
+import "keerja-backend/internal/apperror"
+
+var (
+	// ErrInvalidAnalyticsRange is returned when an analytics period is empty, reversed or has too many buckets
+	ErrInvalidAnalyticsRange = apperror.Validation("INVALID_ANALYTICS_RANGE", "analytics period must start before it ends and span at most 400 buckets")
+
+	// ErrInvalidAnalyticsInterval is returned for an unsupported analytics bucket interval
+	ErrInvalidAnalyticsInterval = apperror.Validation("INVALID_ANALYTICS_INTERVAL", "analytics interval must be day, week or month").WithField("interval", "must be one of: day week month")
+
+	// ErrPermissionDenied is returned when the admin's role lacks the permission a route requires
+	ErrPermissionDenied = apperror.Forbidden("ADMIN_PERMISSION_DENIED", "your admin role lacks the required permission")
+
+	// ErrAdminUserNotFound is returned when the admin user does not exist
+	ErrAdminUserNotFound = apperror.NotFound("ADMIN_USER_NOT_FOUND", "admin user not found")
+
+	// ErrAdminRoleNotFound is returned when the admin role does not exist
+	ErrAdminRoleNotFound = apperror.NotFound("ADMIN_ROLE_NOT_FOUND", "admin role not found")
+
+	// ErrCannotChangeOwnRole is returned when an admin assigns a role to themselves
+	ErrCannotChangeOwnRole = apperror.Forbidden("CANNOT_CHANGE_OWN_ROLE", "admins cannot change their own role")
+
+	// ErrImpersonationNotFound is returned when the impersonation session does not exist or was started by another admin
+	ErrImpersonationNotFound = apperror.NotFound("IMPERSONATION_NOT_FOUND", "impersonation session not found")
+
+	// ErrCannotImpersonateAdmin is returned when the user to impersonate has an admin account
+	ErrCannotImpersonateAdmin = apperror.Forbidden("CANNOT_IMPERSONATE_ADMIN", "admin accounts cannot be impersonated")
+
+	// ErrImpersonationReadOnly is returned for requests that change data while impersonating a user
+	ErrImpersonationReadOnly = apperror.Forbidden("IMPERSONATION_READ_ONLY", "changes cannot be made while impersonating a user")
+)
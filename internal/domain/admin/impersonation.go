@@ -0,0 +1,67 @@
+package admin
+
+import (
+	"context"
+	"time"
+)
+
+// ImpersonationSession is a period during which an admin views the API as a user, to see
+// what the user sees when handling a support request
+// Maps to: admin_impersonation_sessions table
+type ImpersonationSession struct {
+	ID        int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	AdminID   int64      `gorm:"not null;index" json:"admin_id"`
+	UserID    int64      `gorm:"not null;index" json:"user_id"`
+	Reason    string     `gorm:"type:text;not null" json:"reason"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"` // Set when the admin ends the session early
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for ImpersonationSession
+func (ImpersonationSession) TableName() string {
+	return "admin_impersonation_sessions"
+}
+
+// IsActive checks if the session was not ended and has not expired at now
+func (s *ImpersonationSession) IsActive(now time.Time) bool {
+	return s.EndedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// ImpersonationGrant is the access token an admin uses to make requests as the impersonated user
+type ImpersonationGrant struct {
+	Session     *ImpersonationSession
+	AccessToken string
+}
+
+// ImpersonatedRequest describes a request made with an impersonation token, for the audit log
+type ImpersonatedRequest struct {
+	SessionID int64
+	AdminID   int64
+	UserID    int64
+	Method    string
+	Path      string
+	Status    int
+	Blocked   bool // Refused because it would change data
+}
+
+// ImpersonationRepository defines data access methods for ImpersonationSession
+type ImpersonationRepository interface {
+	Create(ctx context.Context, session *ImpersonationSession) error
+	FindByID(ctx context.Context, id int64) (*ImpersonationSession, error)
+	// End sets ended_at on the session unless it was already ended
+	End(ctx context.Context, id int64, endedAt time.Time) error
+}
+
+// ImpersonationService lets admins act as a user with read-only access, auditing every request
+type ImpersonationService interface {
+	// StartImpersonation opens a session and issues a short-lived access token carrying both
+	// the user's identity and the admin's ID
+	StartImpersonation(ctx context.Context, adminID, userID int64, reason string) (*ImpersonationGrant, error)
+	// EndImpersonation ends a session the admin started, revoking its token
+	EndImpersonation(ctx context.Context, adminID, sessionID int64) error
+	// IsImpersonationActive checks if the session was not ended and has not expired
+	IsImpersonationActive(ctx context.Context, sessionID int64) (bool, error)
+	// RecordImpersonatedRequest writes the audit log entry of a request made while impersonating
+	RecordImpersonatedRequest(ctx context.Context, req ImpersonatedRequest)
+}
@@ -0,0 +1,51 @@
+package admin
+
+import "context"
+
+// Permissions an admin role can be granted. Admin routes require one of them on top of a
+// valid admin session.
+const (
+	PermCompaniesVerify  = "companies.verify"
+	PermReviewsModerate  = "reviews.moderate"
+	PermJobsReview       = "jobs.review"
+	PermJobsFeature      = "jobs.feature"
+	PermMasterDataWrite  = "master_data.write"
+	PermAnalyticsRead    = "analytics.read"
+	PermAdminsManage     = "admins.manage"     // Superadmin: role assignment and system operations
+	PermUsersImpersonate = "users.impersonate" // Superadmin: view the API as a user, read-only
+)
+
+// AllPermissions lists every permission that can be granted to a role
+var AllPermissions = []string{
+	PermCompaniesVerify,
+	PermReviewsModerate,
+	PermJobsReview,
+	PermJobsFeature,
+	PermMasterDataWrite,
+	PermAnalyticsRead,
+	PermAdminsManage,
+	PermUsersImpersonate,
+}
+
+// IsValidPermission checks if perm can be granted to a role
+func IsValidPermission(perm string) bool {
+	for _, p := range AllPermissions {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// AdminPermissionService resolves the permissions of admins and assigns their roles
+type AdminPermissionService interface {
+	// Permissions returns the permissions of the admin's role. The set is cached per admin
+	// and dropped when the admin's role changes.
+	Permissions(ctx context.Context, adminID int64) ([]string, error)
+	// HasPermission checks if the admin's role grants perm
+	HasPermission(ctx context.Context, adminID int64, perm string) (bool, error)
+	// ListRoles returns every role with its permissions, highest access level first
+	ListRoles(ctx context.Context) ([]AdminRole, error)
+	// AssignRole gives another admin a role and returns the updated admin
+	AssignRole(ctx context.Context, actorID, adminID, roleID int64) (*AdminUser, error)
+}
@@ -83,6 +83,13 @@ type AdminUserRepository interface {
 	GetUserActivity(ctx context.Context, userID int64, startDate, endDate time.Time) (*UserActivity, error)
 }
 
+// AdminCompanyRepository defines admin-facing company queries
+type AdminCompanyRepository interface {
+	// ListCompanies lists companies with their job, application, follower and pending
+	// document counts, aggregated in the query itself
+	ListCompanies(ctx context.Context, filter *AdminCompanyFilter) ([]AdminCompanyListItem, int64, error)
+}
+
 // AdminRoleFilter defines filter options for admin role queries
 type AdminRoleFilter struct {
 	Search       string
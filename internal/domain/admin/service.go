@@ -117,16 +117,22 @@ type AdminUserService interface {
 // Note: Actual implementation is in internal/service/admin_job_service.go
 type AdminJobService interface {
 	// Job approval/rejection (moderation)
-	// ApproveJob changes job status from pending_review to published
-	ApproveJob(ctx context.Context, jobID int64) (interface{}, error)
-	// RejectJob changes job status from pending_review back to draft
-	RejectJob(ctx context.Context, jobID int64, reason string) (interface{}, error)
+	// ApproveJob changes job status from pending_review to published and records the review
+	ApproveJob(ctx context.Context, jobID, adminID int64, notes string) (interface{}, error)
+	// RejectJob changes job status from pending_review back to draft and records the reason
+	RejectJob(ctx context.Context, jobID, adminID int64, reason string) (interface{}, error)
 
 	// Job list for approval
-	// GetPendingJobs retrieves all jobs pending review (status = pending_review)
-	GetPendingJobs(ctx context.Context, page, limit int) ([]interface{}, int64, error)
+	// GetPendingJobs retrieves jobs pending review (status = pending_review) with filters
+	GetPendingJobs(ctx context.Context, req *AdminPendingJobsRequest) ([]interface{}, int64, error)
 	// GetJobsForReview retrieves jobs for review with specific status
 	GetJobsForReview(ctx context.Context, status string, page, limit int) ([]interface{}, int64, error)
+
+	// Featured jobs curation
+	// FeatureJob features a published job for the requested window and drops the cached featured lists
+	FeatureJob(ctx context.Context, jobID, adminID int64, req *AdminFeatureJobRequest) (interface{}, error)
+	// UnfeatureJob clears the job's featured flag and window and drops the cached featured lists
+	UnfeatureJob(ctx context.Context, jobID, adminID int64) (interface{}, error)
 }
 
 // AdminCompanyService defines business logic for admin company management
@@ -134,7 +140,7 @@ type AdminJobService interface {
 type AdminCompanyService interface {
 	// Company Moderation Queue
 	// Task 2.1: List companies with filters, search, and pagination
-	ListCompanies(ctx context.Context, req *AdminCompanyListRequest) (*AdminCompanyListResponse, error)
+	ListCompaniesAdmin(ctx context.Context, filter *AdminCompanyFilter) (*AdminCompanyListResponse, error)
 
 	// Task 2.2: Get full company details for moderation
 	GetCompanyDetail(ctx context.Context, companyID int64) (*AdminCompanyDetailResponse, error)
@@ -148,6 +154,9 @@ type AdminCompanyService interface {
 	// Task 2.5: Delete company (with validation)
 	DeleteCompany(ctx context.Context, companyID int64, req *AdminDeleteCompanyRequest, adminID int64) error
 
+	// RestoreCompany undoes a company deletion, reactivating the employer memberships it deactivated
+	RestoreCompany(ctx context.Context, companyID int64, adminID int64) error
+
 	// Additional operations
 	GetCompanyStats(ctx context.Context, companyID int64) (*AdminCompanyStatsResponse, error)
 	GetDashboardStats(ctx context.Context) (*AdminDashboardStatsResponse, error)
@@ -372,25 +381,54 @@ type LoginRecord struct {
 // Admin Company Management DTOs
 // =============================================================================
 
-// AdminCompanyListRequest represents request for listing companies (Task 2.1)
-type AdminCompanyListRequest struct {
+// Admin company list sort fields
+const (
+	AdminCompanySortCreatedAt     = "created_at"
+	AdminCompanySortCompanyName   = "company_name"
+	AdminCompanySortVerifiedAt    = "verified_at"
+	AdminCompanySortUpdatedAt     = "updated_at"
+	AdminCompanySortJobCount      = "job_count"
+	AdminCompanySortFollowerCount = "follower_count"
+)
+
+// AdminCompanyFilter represents filters for the admin company list (Task 2.1)
+type AdminCompanyFilter struct {
 	Page               int
 	Limit              int
-	Search             string // Search by company name, email, legal name
-	Status             string // verification status filter
-	VerificationStatus string // From company_verifications table
+	Search             string // Search by company name or legal name
+	Verified           *bool
+	IsActive           *bool
+	Deleted            bool   // List soft-deleted companies instead of live ones
+	VerificationStatus string // pending, approved, rejected or expired; companies without a verification request are pending
+	DocumentStatus     string // Has an active document with this status: pending, approved, rejected or expired
+	DocumentType       string // Narrows DocumentStatus to one document type, e.g. NPWP
+	HasPublishedJobs   *bool
+	CreatedFrom        *time.Time
+	CreatedTo          *time.Time // Exclusive
 	IndustryID         *int64
 	CompanySizeID      *int64
 	ProvinceID         *int64
 	CityID             *int64
-	Verified           *bool
-	IsActive           *bool
-	CreatedFrom        string // Date filter
-	CreatedTo          string // Date filter
-	SortBy             string // company_name, created_at, verified_at, updated_at
+	SortBy             string // One of the AdminCompanySort* fields
 	SortOrder          string // asc, desc
 }
 
+// AdminPendingJobsRequest represents filters for the pending job review queue
+type AdminPendingJobsRequest struct {
+	Page          int
+	Limit         int
+	CompanyID     *int64
+	SubmittedFrom string // Date filter (2024-01-01)
+	SubmittedTo   string // Date filter (2024-12-31), inclusive
+}
+
+// AdminFeatureJobRequest schedules a job as featured. Without FeaturedFrom the job is
+// featured right away, without FeaturedUntil until it is unfeatured.
+type AdminFeatureJobRequest struct {
+	FeaturedFrom  *time.Time `json:"featured_from"`
+	FeaturedUntil *time.Time `json:"featured_until"`
+}
+
 // AdminCompanyListResponse represents paginated company list response
 type AdminCompanyListResponse struct {
 	Companies  []AdminCompanyListItem `json:"companies"`
@@ -420,6 +458,8 @@ type AdminCompanyListItem struct {
 	TotalJobs          int64      `json:"total_jobs"`
 	ActiveJobs         int64      `json:"active_jobs"`
 	TotalApplications  int64      `json:"total_applications"`
+	TotalFollowers     int64      `json:"total_followers"`
+	PendingDocuments   int64      `json:"pending_documents"`
 	CreatedAt          time.Time  `json:"created_at"`
 	UpdatedAt          time.Time  `json:"updated_at"`
 }
@@ -472,7 +512,7 @@ type CompanyDocumentDetail struct {
 	ID              int64      `json:"id"`
 	DocumentType    string     `json:"document_type"`
 	DocumentNumber  string     `json:"document_number,omitempty"`
-	FilePath        string     `json:"file_path"`
+	FilePath        string     `json:"-"` // Private; handed out as a signed download link
 	Status          string     `json:"status"`
 	VerifiedBy      *int64     `json:"verified_by,omitempty"`
 	VerifiedAt      *time.Time `json:"verified_at,omitempty"`
@@ -0,0 +1,69 @@
+package apikey
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Scopes an API key can be granted. Keys are read-only and limited to their company.
+const (
+	ScopeJobsRead         = "jobs:read"
+	ScopeApplicationsRead = "applications:read"
+)
+
+// KeyPrefix starts every plaintext key so leaked keys are easy to recognize
+const KeyPrefix = "kjk_"
+
+// DisplayPrefixLength is how many leading characters of a key are stored in the clear
+// and shown in listings so owners can tell their keys apart
+const DisplayPrefixLength = 12
+
+// ValidScopes lists every scope that can be granted to a key
+var ValidScopes = []string{ScopeJobsRead, ScopeApplicationsRead}
+
+// IsValidScope checks if scope can be granted to a key
+func IsValidScope(scope string) bool {
+	for _, s := range ValidScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// CompanyAPIKey lets a company's external systems (e.g. an ATS) read its jobs and
+// applications. Only the SHA-256 hash of the key is stored.
+type CompanyAPIKey struct {
+	ID         int64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	CompanyID  int64          `gorm:"column:company_id;not null;index" json:"company_id"`
+	Name       string         `gorm:"column:name;type:varchar(100);not null" json:"name"`
+	KeyPrefix  string         `gorm:"column:key_prefix;type:varchar(20);not null" json:"key_prefix"`
+	KeyHash    string         `gorm:"column:key_hash;type:varchar(64);not null;uniqueIndex" json:"-"`
+	Scopes     pq.StringArray `gorm:"column:scopes;type:text[];not null" json:"scopes"`
+	CreatedBy  *int64         `gorm:"column:created_by" json:"created_by,omitempty"`
+	LastUsedAt *time.Time     `gorm:"column:last_used_at" json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time     `gorm:"column:revoked_at" json:"revoked_at,omitempty"`
+	CreatedAt  time.Time      `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time      `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for CompanyAPIKey
+func (CompanyAPIKey) TableName() string {
+	return "company_api_keys"
+}
+
+// IsRevoked checks if the key has been revoked
+func (k *CompanyAPIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// HasScope checks if the key was granted scope
+func (k *CompanyAPIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
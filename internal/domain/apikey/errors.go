@@ -0,0 +1,23 @@
+package apikey
+
+import "keerja-backend/internal/apperror"
+
+var (
+	// ErrAPIKeyNotFound is returned when the key does not exist or belongs to another company
+	ErrAPIKeyNotFound = apperror.NotFound("API_KEY_NOT_FOUND", "API key not found")
+
+	// ErrInvalidAPIKey is returned when a request presents an unknown API key
+	ErrInvalidAPIKey = apperror.Unauthorized("INVALID_API_KEY", "invalid API key")
+
+	// ErrAPIKeyRevoked is returned when a request presents a revoked API key
+	ErrAPIKeyRevoked = apperror.Unauthorized("API_KEY_REVOKED", "API key has been revoked")
+
+	// ErrAPIKeyAlreadyRevoked is returned when revoking a key twice
+	ErrAPIKeyAlreadyRevoked = apperror.Conflict("API_KEY_ALREADY_REVOKED", "API key is already revoked")
+
+	// ErrInvalidAPIKeyScope is returned when creating a key with an unknown scope
+	ErrInvalidAPIKeyScope = apperror.Validation("INVALID_API_KEY_SCOPE", "scopes must be jobs:read or applications:read").WithField("scopes", "must be jobs:read or applications:read")
+
+	// ErrAPIKeyScopeDenied is returned when a key is used outside its scopes or company
+	ErrAPIKeyScopeDenied = apperror.Forbidden("API_KEY_SCOPE_DENIED", "API key does not grant access to this resource")
+)
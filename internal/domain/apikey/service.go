@@ -0,0 +1,42 @@
+package apikey
+
+import (
+	"context"
+	"time"
+)
+
+// APIKeyRepository defines the interface for company API key data operations
+type APIKeyRepository interface {
+	// Create inserts a new key
+	Create(ctx context.Context, key *CompanyAPIKey) error
+
+	// FindByID finds a key by ID, returning nil when it does not exist
+	FindByID(ctx context.Context, id int64) (*CompanyAPIKey, error)
+
+	// FindByHash finds a key by the hash of its plaintext, returning nil when it does not exist
+	FindByHash(ctx context.Context, keyHash string) (*CompanyAPIKey, error)
+
+	// ListByCompany lists a company's keys, newest first
+	ListByCompany(ctx context.Context, companyID int64) ([]CompanyAPIKey, error)
+
+	// Revoke marks a key revoked
+	Revoke(ctx context.Context, id int64, revokedAt time.Time) error
+
+	// TouchLastUsed records when a key was last used
+	TouchLastUsed(ctx context.Context, id int64, usedAt time.Time) error
+}
+
+// APIKeyService manages company API keys and authenticates requests made with them
+type APIKeyService interface {
+	// CreateKey creates a key for the company. The plaintext key is returned only here.
+	CreateKey(ctx context.Context, companyID, createdBy int64, name string, scopes []string) (*CompanyAPIKey, string, error)
+
+	// ListKeys lists a company's keys, newest first
+	ListKeys(ctx context.Context, companyID int64) ([]CompanyAPIKey, error)
+
+	// RevokeKey revokes one of the company's keys
+	RevokeKey(ctx context.Context, companyID, keyID int64) error
+
+	// Authenticate resolves a plaintext key to its active key record
+	Authenticate(ctx context.Context, rawKey string) (*CompanyAPIKey, error)
+}
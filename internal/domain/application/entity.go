@@ -2,24 +2,34 @@ package application
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // JobApplication represents a job application entity
 type JobApplication struct {
-	ID               int64     `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
-	JobID            int64     `gorm:"column:job_id;not null;index:idx_application_unique,unique" json:"job_id" validate:"required"`
-	UserID           int64     `gorm:"column:user_id;not null;index:idx_application_unique,unique" json:"user_id" validate:"required"`
-	CompanyID        *int64    `gorm:"column:company_id;index" json:"company_id,omitempty"`
-	AppliedAt        time.Time `gorm:"column:applied_at;default:now()" json:"applied_at"`
-	Status           string    `gorm:"column:status;type:varchar(30);default:'applied'" json:"status" validate:"omitempty,oneof='applied' 'screening' 'shortlisted' 'interview' 'offered' 'hired' 'rejected' 'withdrawn'"`
-	Source           string    `gorm:"column:source;type:varchar(50);default:'keerja_portal'" json:"source"`
-	MatchScore       float64   `gorm:"column:match_score;type:numeric(5,2);default:0.00" json:"match_score"`
-	NotesText        string    `gorm:"column:notes;type:text" json:"notes_text,omitempty"`
-	ViewedByEmployer bool      `gorm:"column:viewed_by_employer;default:false" json:"viewed_by_employer"`
-	IsBookmarked     bool      `gorm:"column:is_bookmarked;default:false" json:"is_bookmarked"`
-	ResumeURL        string    `gorm:"column:resume_url;type:text" json:"resume_url,omitempty"`
-	CreatedAt        time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
-	UpdatedAt        time.Time `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+	ID               int64      `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	JobID            int64      `gorm:"column:job_id;not null;index:idx_application_job_user" json:"job_id" validate:"required"`
+	UserID           int64      `gorm:"column:user_id;not null;index:idx_application_job_user" json:"user_id" validate:"required"`
+	CompanyID        *int64     `gorm:"column:company_id;index" json:"company_id,omitempty"`
+	AppliedAt        time.Time  `gorm:"column:applied_at;default:now()" json:"applied_at"`
+	Status           string     `gorm:"column:status;type:varchar(30);default:'applied'" json:"status" validate:"omitempty,oneof='applied' 'screening' 'shortlisted' 'interview' 'offered' 'hired' 'rejected' 'withdrawn' 'job_withdrawn'"`
+	Source           string     `gorm:"column:source;type:varchar(50);default:'keerja_portal'" json:"source"`
+	UTMSource        string     `gorm:"column:utm_source;type:varchar(100)" json:"utm_source,omitempty"`
+	UTMMedium        string     `gorm:"column:utm_medium;type:varchar(100)" json:"utm_medium,omitempty"`
+	UTMCampaign      string     `gorm:"column:utm_campaign;type:varchar(100)" json:"utm_campaign,omitempty"`
+	Referrer         string     `gorm:"column:referrer;type:varchar(500)" json:"referrer,omitempty"`
+	MatchScore       float64    `gorm:"column:match_score;type:numeric(5,2);default:0.00" json:"match_score"`
+	NotesText        string     `gorm:"column:notes;type:text" json:"notes_text,omitempty"`
+	ViewedByEmployer bool       `gorm:"column:viewed_by_employer;default:false" json:"viewed_by_employer"`
+	IsBookmarked     bool       `gorm:"column:is_bookmarked;default:false" json:"is_bookmarked"`
+	ResumeURL        string     `gorm:"column:resume_url;type:text" json:"resume_url,omitempty"`
+	ResumeSourceURL  *string    `gorm:"column:resume_source_url;type:text" json:"-"` // Library document ResumeURL was copied from at apply time
+	DoNotReapply     bool       `gorm:"column:do_not_reapply;default:false" json:"do_not_reapply"`
+	ClosedAt         *time.Time `gorm:"column:closed_at" json:"closed_at,omitempty"`
+	PreviousStatus   *string    `gorm:"column:status_before_job_withdrawn;type:varchar(30)" json:"-"` // Restored when the deleted job comes back
+	CreatedAt        time.Time  `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time  `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
 
 	// Relationships
 	Stages           []JobApplicationStage `gorm:"foreignKey:ApplicationID;references:ID;constraint:OnDelete:CASCADE" json:"stages,omitempty"`
@@ -33,52 +43,118 @@ func (JobApplication) TableName() string {
 	return "job_applications"
 }
 
+// HasResumeSnapshot reports whether ResumeURL is the application's own copy of the
+// applicant's library document rather than a reference to it
+func (ja *JobApplication) HasResumeSnapshot() bool {
+	return ja.ResumeSourceURL != nil
+}
+
 // IsApplied checks if application status is applied
 func (ja *JobApplication) IsApplied() bool {
-	return ja.Status == "applied"
+	return ja.Status == StatusApplied
 }
 
 // IsInProgress checks if application is in hiring process
 func (ja *JobApplication) IsInProgress() bool {
-	return ja.Status == "screening" || ja.Status == "shortlisted" || ja.Status == "interview" || ja.Status == "offered"
+	return ja.Status == StatusScreening || ja.Status == StatusShortlisted || ja.Status == StatusInterview || ja.Status == StatusOffered
 }
 
 // IsCompleted checks if application has final status
 func (ja *JobApplication) IsCompleted() bool {
-	return ja.Status == "hired" || ja.Status == "rejected" || ja.Status == "withdrawn"
+	return ja.Status == StatusHired || ja.Status == StatusRejected || ja.Status == StatusWithdrawn || ja.Status == StatusJobWithdrawn
 }
 
 // IsHired checks if application resulted in hire
 func (ja *JobApplication) IsHired() bool {
-	return ja.Status == "hired"
+	return ja.Status == StatusHired
 }
 
 // IsRejected checks if application was rejected
 func (ja *JobApplication) IsRejected() bool {
-	return ja.Status == "rejected"
+	return ja.Status == StatusRejected
 }
 
 // IsWithdrawn checks if application was withdrawn by user
 func (ja *JobApplication) IsWithdrawn() bool {
-	return ja.Status == "withdrawn"
+	return ja.Status == StatusWithdrawn
+}
+
+// IsJobWithdrawn checks if application was closed because its job was deleted
+func (ja *JobApplication) IsJobWithdrawn() bool {
+	return ja.Status == StatusJobWithdrawn
+}
+
+// IsOffered checks if the employer has made an offer
+func (ja *JobApplication) IsOffered() bool {
+	return ja.Status == StatusOffered
 }
 
-// CanWithdraw checks if user can withdraw application
+// CanWithdraw checks if user can withdraw application.
+// Withdrawal is closed once an offer has been made; the applicant declines the offer instead.
 func (ja *JobApplication) CanWithdraw() bool {
-	return !ja.IsCompleted()
+	return !ja.IsCompleted() && !ja.IsOffered()
+}
+
+// ReapplyPolicy sets how long an applicant waits before applying to the same job again
+type ReapplyPolicy struct {
+	WithdrawnCooldown time.Duration // After the applicant withdrew
+	RejectedCooldown  time.Duration // After the employer rejected, unless flagged do-not-reapply
+}
+
+// DefaultReapplyPolicy is used when no cooldowns are configured
+var DefaultReapplyPolicy = ReapplyPolicy{
+	WithdrawnCooldown: 30 * 24 * time.Hour,
+	RejectedCooldown:  90 * 24 * time.Hour,
+}
+
+// closedTime returns when the application reached its final status
+func (ja *JobApplication) closedTime() time.Time {
+	if ja.ClosedAt != nil {
+		return *ja.ClosedAt
+	}
+	return ja.UpdatedAt
+}
+
+// CheckReapply reports whether the applicant may apply to the same job again at now.
+// It returns ErrAlreadyApplied while the application is still open,
+// ErrNotEligibleToReapply when it can never be reopened, and a
+// ReapplyNotYetAvailable error carrying the date once the cooldown is still running.
+func (ja *JobApplication) CheckReapply(policy ReapplyPolicy, now time.Time) error {
+	var cooldown time.Duration
+	switch {
+	case ja.IsJobWithdrawn():
+		// The employer closed the application by deleting the job, not the applicant
+		return nil
+	case ja.IsWithdrawn():
+		cooldown = policy.WithdrawnCooldown
+	case ja.IsRejected() && !ja.DoNotReapply:
+		cooldown = policy.RejectedCooldown
+	case ja.IsRejected(), ja.IsHired():
+		return ErrNotEligibleToReapply
+	default:
+		return ErrAlreadyApplied
+	}
+
+	availableAt := ja.closedTime().Add(cooldown)
+	if now.Before(availableAt) {
+		return ReapplyNotYetAvailable(availableAt)
+	}
+	return nil
 }
 
 // JobApplicationStage represents application stage tracking entity
 type JobApplicationStage struct {
 	ID            int64      `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
 	ApplicationID int64      `gorm:"column:application_id;not null;index" json:"application_id" validate:"required"`
-	StageName     string     `gorm:"column:stage_name;type:varchar(50);not null" json:"stage_name" validate:"required,oneof='applied' 'screening' 'shortlisted' 'interview' 'offered' 'hired' 'rejected' 'withdrawn'"`
+	StageName     string     `gorm:"column:stage_name;type:varchar(50);not null" json:"stage_name" validate:"required,max=50"` // A status or a custom stage of the job's pipeline
+	Status        string     `gorm:"column:status;type:varchar(30);not null" json:"status"`                                    // Canonical status of the stage; set from StageName when empty
 	Description   string     `gorm:"column:description;type:text" json:"description,omitempty"`
 	HandledBy     *int64     `gorm:"column:handled_by;index" json:"handled_by,omitempty"`
 	StartedAt     time.Time  `gorm:"column:started_at;default:now()" json:"started_at"`
 	CompletedAt   *time.Time `gorm:"column:completed_at" json:"completed_at,omitempty"`
 	Duration      *string    `gorm:"column:duration;->;type:interval" json:"duration,omitempty"` // Generated column, read-only
 	Notes         string     `gorm:"column:notes;type:text" json:"notes,omitempty"`
+	SLARemindedAt *time.Time `gorm:"column:sla_reminded_at" json:"-"`
 	CreatedAt     time.Time  `gorm:"column:created_at;autoCreateTime" json:"created_at"`
 	UpdatedAt     time.Time  `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
 
@@ -93,6 +169,19 @@ func (JobApplicationStage) TableName() string {
 	return "job_application_stages"
 }
 
+// BeforeCreate hook for JobApplicationStage
+func (jas *JobApplicationStage) BeforeCreate(tx *gorm.DB) error {
+	if jas.Status == "" {
+		jas.Status = jas.StageName
+	}
+	return nil
+}
+
+// IsCustom reports whether the stage is a custom stage of the job's pipeline rather than a status
+func (jas *JobApplicationStage) IsCustom() bool {
+	return jas.Status != "" && jas.StageName != jas.Status
+}
+
 // IsCompleted checks if stage is completed
 func (jas *JobApplicationStage) IsCompleted() bool {
 	return jas.CompletedAt != nil
@@ -119,6 +208,8 @@ type ApplicationDocument struct {
 	FileURL       string     `gorm:"column:file_url;type:text;not null" json:"file_url" validate:"required"`
 	FileType      string     `gorm:"column:file_type;type:varchar(50)" json:"file_type,omitempty"`
 	FileSize      int64      `gorm:"column:file_size" json:"file_size,omitempty"`
+	Uploaded      bool       `gorm:"column:uploaded;default:false" json:"-"` // Stored through the upload service; pre-uploaded file URLs are never deleted by us
+	SourceURL     *string    `gorm:"column:source_url;type:text" json:"-"`   // Library document FileURL was copied from at apply time
 	UploadedAt    time.Time  `gorm:"column:uploaded_at;default:now()" json:"uploaded_at"`
 	IsVerified    bool       `gorm:"column:is_verified;default:false;index" json:"is_verified"`
 	VerifiedBy    *int64     `gorm:"column:verified_by" json:"verified_by,omitempty"`
@@ -154,6 +245,28 @@ func (ad *ApplicationDocument) Verify(verifierID int64) {
 	ad.VerifiedAt = &now
 }
 
+// RejectionReasonKnockout is recorded on the rejected stage of applications rejected by a knockout question
+const RejectionReasonKnockout = "knockout"
+
+// ApplicationAnswer is an applicant's answer to one of the job's screening questions.
+// Values are stored in the canonical form produced by the question type.
+type ApplicationAnswer struct {
+	ID            int64     `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	ApplicationID int64     `gorm:"column:application_id;not null;index" json:"application_id"`
+	QuestionID    int64     `gorm:"column:question_id;not null;index" json:"question_id"`
+	AnswerValue   string    `gorm:"column:answer_value;type:text;not null" json:"answer"`
+	CreatedAt     time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+
+	// Read-only, joined from job_questions when listing
+	QuestionText string `gorm:"column:question_text;->" json:"question_text,omitempty"`
+	QuestionType string `gorm:"column:question_type;->" json:"question_type,omitempty"`
+}
+
+// TableName specifies the table name for ApplicationAnswer
+func (ApplicationAnswer) TableName() string {
+	return "application_answers"
+}
+
 // ApplicationNote represents notes on application entity
 type ApplicationNote struct {
 	ID            int64     `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
@@ -229,6 +342,8 @@ type Interview struct {
 	InterviewType      string     `gorm:"column:interview_type;type:varchar(20);default:'online'" json:"interview_type" validate:"omitempty,oneof='online' 'onsite' 'hybrid'"`
 	MeetingLink        string     `gorm:"column:meeting_link;type:text" json:"meeting_link,omitempty"`
 	Location           string     `gorm:"column:location;type:text" json:"location,omitempty"`
+	Timezone           string     `gorm:"column:timezone;type:varchar(64);default:'UTC'" json:"timezone"`
+	CalendarSequence   int        `gorm:"column:calendar_sequence;default:0" json:"-"`
 	Status             string     `gorm:"column:status;type:varchar(20);default:'scheduled'" json:"status" validate:"omitempty,oneof='scheduled' 'completed' 'rescheduled' 'cancelled' 'no_show'"`
 	OverallScore       *float64   `gorm:"column:overall_score;type:numeric(4,2)" json:"overall_score,omitempty" validate:"omitempty,min=0,max=100"`
 	TechnicalScore     *float64   `gorm:"column:technical_score;type:numeric(4,2)" json:"technical_score,omitempty" validate:"omitempty,min=0,max=100"`
@@ -249,6 +364,15 @@ func (Interview) TableName() string {
 	return "interviews"
 }
 
+// LocalScheduledAt returns the scheduled time in the interview's timezone, falling back to UTC
+func (i *Interview) LocalScheduledAt() time.Time {
+	loc, err := time.LoadLocation(i.Timezone)
+	if err != nil || i.Timezone == "" {
+		loc = time.UTC
+	}
+	return i.ScheduledAt.In(loc)
+}
+
 // IsScheduled checks if interview is scheduled
 func (i *Interview) IsScheduled() bool {
 	return i.Status == "scheduled"
@@ -329,3 +453,126 @@ func (i *Interview) CalculateAverageScore() float64 {
 
 	return total / float64(count)
 }
+
+// InterviewSlot is a time an interviewer is available for interviews. Candidates book open
+// slots through a booking link; a slot is released again when its interview is cancelled.
+type InterviewSlot struct {
+	ID            int64      `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	CompanyID     int64      `gorm:"column:company_id;not null;index" json:"company_id"`
+	InterviewerID int64      `gorm:"column:interviewer_id;not null" json:"interviewer_id"`
+	StartsAt      time.Time  `gorm:"column:starts_at;not null" json:"starts_at"`
+	EndsAt        time.Time  `gorm:"column:ends_at;not null" json:"ends_at"`
+	Timezone      string     `gorm:"column:timezone;type:varchar(64);default:'UTC'" json:"timezone"`
+	InterviewType string     `gorm:"column:interview_type;type:varchar(20);default:'online'" json:"interview_type"`
+	MeetingLink   string     `gorm:"column:meeting_link;type:text" json:"meeting_link,omitempty"`
+	Location      string     `gorm:"column:location;type:text" json:"location,omitempty"`
+	ApplicationID *int64     `gorm:"column:application_id" json:"application_id,omitempty"`
+	InterviewID   *int64     `gorm:"column:interview_id" json:"interview_id,omitempty"`
+	BookedAt      *time.Time `gorm:"column:booked_at" json:"booked_at,omitempty"`
+	CreatedBy     int64      `gorm:"column:created_by;not null" json:"created_by"`
+	CreatedAt     time.Time  `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for InterviewSlot
+func (InterviewSlot) TableName() string {
+	return "interview_slots"
+}
+
+// IsBooked checks if an application has taken the slot
+func (s *InterviewSlot) IsBooked() bool {
+	return s.ApplicationID != nil
+}
+
+// InterviewBookingLink lets a candidate book one interview slot of the company without
+// signing in. Only the SHA-256 hash of the link's token is stored.
+type InterviewBookingLink struct {
+	ID            int64      `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	ApplicationID int64      `gorm:"column:application_id;not null;index" json:"application_id"`
+	CompanyID     int64      `gorm:"column:company_id;not null" json:"company_id"`
+	TokenHash     string     `gorm:"column:token_hash;type:varchar(64);not null;uniqueIndex" json:"-"`
+	ExpiresAt     time.Time  `gorm:"column:expires_at;not null" json:"expires_at"`
+	CreatedBy     int64      `gorm:"column:created_by;not null" json:"created_by"`
+	SlotID        *int64     `gorm:"column:slot_id" json:"slot_id,omitempty"`
+	UsedAt        *time.Time `gorm:"column:used_at" json:"used_at,omitempty"`
+	CreatedAt     time.Time  `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for InterviewBookingLink
+func (InterviewBookingLink) TableName() string {
+	return "interview_booking_links"
+}
+
+// IsUsed checks if a slot has been booked with the link
+func (l *InterviewBookingLink) IsUsed() bool {
+	return l.UsedAt != nil
+}
+
+// IsExpired checks if the link can no longer be used at now
+func (l *InterviewBookingLink) IsExpired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
+
+// Bulk action states. Running actions left behind by a restart are picked up again.
+const (
+	BulkActionQueued  = "queued"
+	BulkActionRunning = "running"
+	BulkActionDone    = "done"
+)
+
+// Bulk action item results
+const (
+	BulkItemPending   = "pending"
+	BulkItemSucceeded = "succeeded"
+	BulkItemFailed    = "failed"
+)
+
+// BulkAction moves a selection of a company's applications to one status in the background.
+// Each application is an item whose result is recorded as it is processed.
+type BulkAction struct {
+	ID             int64      `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	CompanyID      int64      `gorm:"column:company_id;not null;index" json:"company_id"`
+	CreatedBy      int64      `gorm:"column:created_by;not null" json:"created_by"`              // User ID the stage changes are made as
+	EmployerUserID *int64     `gorm:"column:employer_user_id" json:"employer_user_id,omitempty"` // Membership of CreatedBy in the company the action was queued for
+	EmployerRole   string     `gorm:"column:employer_role;type:varchar(30)" json:"-"`            // Role of the membership when the action was queued
+	TargetStatus   string     `gorm:"column:target_status;type:varchar(20);not null" json:"target_status"`
+	Notes          string     `gorm:"column:notes;type:text" json:"notes,omitempty"`
+	Status         string     `gorm:"column:status;type:varchar(20);not null;default:'queued'" json:"status"`
+	TotalCount     int        `gorm:"column:total_count;not null;default:0" json:"total_count"`
+	ProcessedCount int        `gorm:"column:processed_count;not null;default:0" json:"processed_count"`
+	SucceededCount int        `gorm:"column:succeeded_count;not null;default:0" json:"succeeded_count"`
+	FailedCount    int        `gorm:"column:failed_count;not null;default:0" json:"failed_count"`
+	SkippedCount   int        `gorm:"column:skipped_count;not null;default:0" json:"skipped_count"` // Requested IDs that are not the company's applications
+	StartedAt      *time.Time `gorm:"column:started_at" json:"started_at,omitempty"`
+	CompletedAt    *time.Time `gorm:"column:completed_at" json:"completed_at,omitempty"`
+	CreatedAt      time.Time  `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+
+	Items    []BulkActionItem `gorm:"foreignKey:BulkActionID" json:"-"`
+	Failures []BulkActionItem `gorm:"-" json:"failures,omitempty"`
+}
+
+// TableName specifies the table name for BulkAction
+func (BulkAction) TableName() string {
+	return "application_bulk_actions"
+}
+
+// IsDone checks if every item has been processed
+func (a *BulkAction) IsDone() bool {
+	return a.Status == BulkActionDone
+}
+
+// BulkActionItem is one application of a bulk action and its result
+type BulkActionItem struct {
+	ID            int64      `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	BulkActionID  int64      `gorm:"column:bulk_action_id;not null" json:"bulk_action_id"`
+	ApplicationID int64      `gorm:"column:application_id;not null" json:"application_id"`
+	Status        string     `gorm:"column:status;type:varchar(20);not null;default:'pending'" json:"status"`
+	Error         string     `gorm:"column:error;type:text" json:"error,omitempty"`
+	ProcessedAt   *time.Time `gorm:"column:processed_at" json:"processed_at,omitempty"`
+}
+
+// TableName specifies the table name for BulkActionItem
+func (BulkActionItem) TableName() string {
+	return "application_bulk_action_items"
+}
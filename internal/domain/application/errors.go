@@ -0,0 +1,138 @@
+package application
+
+import (
+	"fmt"
+	"time"
+
+	"keerja-backend/internal/apperror"
+)
+
+var (
+	// ErrApplicationNotFound is returned when the requested application does not exist
+	ErrApplicationNotFound = apperror.NotFound("APPLICATION_NOT_FOUND", "application not found")
+
+	// ErrAlreadyApplied is returned when the user already has an application for the job
+	ErrAlreadyApplied = apperror.Conflict("ALREADY_APPLIED", "you have already applied for this job")
+
+	// ErrReapplyNotYetAvailable is returned when the reapply cooldown has not elapsed; use ReapplyNotYetAvailable to add the date
+	ErrReapplyNotYetAvailable = apperror.Conflict("REAPPLY_NOT_YET_AVAILABLE", "you cannot reapply for this job yet")
+
+	// ErrNotEligibleToReapply is returned when a closed application can never be followed by a new one
+	ErrNotEligibleToReapply = apperror.Conflict("NOT_ELIGIBLE_TO_REAPPLY", "you are not eligible to reapply for this job")
+
+	// ErrJobNotAcceptingApplications is returned when applying to a job that is not open
+	ErrJobNotAcceptingApplications = apperror.Conflict("JOB_NOT_ACCEPTING_APPLICATIONS", "this job is not accepting applications")
+
+	// ErrApplicantInactive is returned when an inactive account tries to apply
+	ErrApplicantInactive = apperror.Forbidden("ACCOUNT_NOT_ACTIVE", "your account is not active")
+
+	// ErrNotJobSeeker is returned when a non job seeker account tries to apply
+	ErrNotJobSeeker = apperror.Forbidden("NOT_JOB_SEEKER", "only job seekers can apply for jobs")
+
+	// ErrNotApplicationOwner is returned when a job seeker acts on another user's application
+	ErrNotApplicationOwner = apperror.Forbidden("NOT_APPLICATION_OWNER", "you do not own this application")
+
+	// ErrCannotWithdraw is returned when the application's status no longer allows withdrawal
+	ErrCannotWithdraw = apperror.Conflict("CANNOT_WITHDRAW", "application cannot be withdrawn in current status")
+
+	// ErrWithdrawAfterOffer is returned when withdrawing an application that already has an offer
+	ErrWithdrawAfterOffer = apperror.Conflict("WITHDRAW_AFTER_OFFER", "application cannot be withdrawn after an offer has been made")
+
+	// ErrApplicationCompleted is returned when changing an application that is hired, rejected or withdrawn
+	ErrApplicationCompleted = apperror.Conflict("APPLICATION_COMPLETED", "cannot update completed application")
+
+	// ErrInvalidStatusTransition is returned when an application cannot move from its status to the requested one
+	ErrInvalidStatusTransition = apperror.Conflict("INVALID_APPLICATION_STATUS_TRANSITION", "application cannot move to that status from its current status")
+
+	// ErrStageNotInPipeline is returned when moving an application to a stage its job's pipeline does not have
+	ErrStageNotInPipeline = apperror.Validation("STAGE_NOT_IN_PIPELINE", "the job's hiring pipeline has no such stage")
+
+	// ErrDocumentTooLarge is returned when an uploaded application document exceeds the document size limit; use DocumentTooLarge to add the limit
+	ErrDocumentTooLarge = apperror.Validation("DOCUMENT_TOO_LARGE", "document is too large")
+
+	// ErrDocumentTypeNotAllowed is returned when an uploaded application document is not a supported document file
+	ErrDocumentTypeNotAllowed = apperror.Validation("DOCUMENT_TYPE_NOT_ALLOWED", "document must be a PDF, DOC, DOCX, TXT or RTF file").WithField("file", "must be a .pdf, .doc, .docx, .txt or .rtf file")
+
+	// ErrDocumentFileRequired is returned when a document has no file, or only a file_url while those are no longer accepted
+	ErrDocumentFileRequired = apperror.Validation("DOCUMENT_FILE_REQUIRED", "document file is required").WithField("file", "is required")
+
+	// ErrDocumentNotFound is returned when the document does not exist or belongs to another application
+	ErrDocumentNotFound = apperror.NotFound("DOCUMENT_NOT_FOUND", "document not found")
+
+	// ErrInterviewInPast is returned when an interview is scheduled for a time that has passed
+	ErrInterviewInPast = apperror.Validation("INTERVIEW_IN_PAST", "interview time must be in the future").WithField("scheduled_at", "must be in the future")
+
+	// ErrInvalidTimezone is returned when the scheduling timezone is not a known IANA zone
+	ErrInvalidTimezone = apperror.Validation("INVALID_TIMEZONE", "invalid timezone").WithField("timezone", "must be an IANA timezone such as Asia/Jakarta")
+
+	// ErrInvalidInterviewSlots is returned when an interview slot batch or slot has invalid times
+	ErrInvalidInterviewSlots = apperror.Validation("INVALID_INTERVIEW_SLOTS", "invalid interview slots")
+
+	// ErrInterviewSlotNotFound is returned when the interview slot does not exist or belongs to another company
+	ErrInterviewSlotNotFound = apperror.NotFound("INTERVIEW_SLOT_NOT_FOUND", "interview slot not found")
+
+	// ErrInterviewSlotExists is returned when the interviewer already has a slot starting at the same time
+	ErrInterviewSlotExists = apperror.Conflict("INTERVIEW_SLOT_EXISTS", "the interviewer already has a slot at this time")
+
+	// ErrInterviewSlotBooked is returned when changing or deleting a slot a candidate has booked
+	ErrInterviewSlotBooked = apperror.Conflict("INTERVIEW_SLOT_BOOKED", "interview slot is booked; cancel its interview first")
+
+	// ErrInterviewSlotTaken is returned when a candidate books a slot that is booked or has already started
+	ErrInterviewSlotTaken = apperror.Conflict("INTERVIEW_SLOT_TAKEN", "this interview slot is no longer available")
+
+	// ErrNoOpenInterviewSlots is returned when sending a booking link while the company has no open slots
+	ErrNoOpenInterviewSlots = apperror.Conflict("NO_OPEN_INTERVIEW_SLOTS", "add open interview slots before sending a booking link")
+
+	// ErrBookingLinkNotFound is returned when no booking link matches the token
+	ErrBookingLinkNotFound = apperror.NotFound("BOOKING_LINK_NOT_FOUND", "interview booking link not found")
+
+	// ErrBookingLinkExpired is returned when the booking link is past its expiry
+	ErrBookingLinkExpired = apperror.Conflict("BOOKING_LINK_EXPIRED", "interview booking link has expired")
+
+	// ErrBookingLinkUsed is returned when an interview was already booked with the link
+	ErrBookingLinkUsed = apperror.Conflict("BOOKING_LINK_USED", "an interview has already been booked with this link")
+
+	// ErrEmployerAccessDenied is returned when the employer user is not a member of the owning company
+	ErrEmployerAccessDenied = apperror.Forbidden("APPLICATION_ACCESS_DENIED", "you do not have access to this application")
+
+	// ErrInsufficientPermissions is returned when the employer user's company role cannot view applications
+	ErrInsufficientPermissions = apperror.Forbidden("INSUFFICIENT_PERMISSIONS", "insufficient permissions")
+
+	// ErrInvalidSearchFilter is returned when an application search filter has an unsupported value
+	ErrInvalidSearchFilter = apperror.Validation("INVALID_SEARCH_FILTER", "invalid application search filter")
+
+	// ErrAnswerRequired is returned when an application omits the answer to a required screening question
+	ErrAnswerRequired = apperror.Validation("ANSWER_REQUIRED", "a required screening question was not answered")
+
+	// ErrInvalidAnswer is returned when an answer does not fit its screening question's type
+	ErrInvalidAnswer = apperror.Validation("INVALID_ANSWER", "answer does not match the screening question type")
+
+	// ErrInvalidBulkAction is returned when a bulk action has no valid target status or selects
+	// applications both by ID and by filter, or by neither
+	ErrInvalidBulkAction = apperror.Validation("INVALID_BULK_ACTION", "invalid bulk action")
+
+	// ErrBulkActionTooLarge is returned when a bulk action selects more than MaxBulkActionItems applications
+	ErrBulkActionTooLarge = apperror.Validation("BULK_ACTION_TOO_LARGE", fmt.Sprintf("a bulk action may include at most %d applications", MaxBulkActionItems))
+
+	// ErrNoApplicationsSelected is returned when none of a bulk action's applications belong to the company
+	ErrNoApplicationsSelected = apperror.Validation("NO_APPLICATIONS_SELECTED", "no applications of the company were selected")
+
+	// ErrBulkActionNotFound is returned when the bulk action does not exist or belongs to another company
+	ErrBulkActionNotFound = apperror.NotFound("BULK_ACTION_NOT_FOUND", "bulk action not found")
+
+	// ErrUnknownQuestion is returned when an answer or filter refers to a question the job does not have
+	ErrUnknownQuestion = apperror.Validation("UNKNOWN_QUESTION", "screening question does not belong to this job")
+)
+
+// ReapplyNotYetAvailable returns ErrReapplyNotYetAvailable stating when the applicant may apply again
+func ReapplyNotYetAvailable(availableAt time.Time) error {
+	return ErrReapplyNotYetAvailable.
+		WithMessage(fmt.Sprintf("reapply available on %s", availableAt.Format("2006-01-02"))).
+		WithField("reapply_available_at", availableAt.UTC().Format(time.RFC3339))
+}
+
+// DocumentTooLarge returns ErrDocumentTooLarge stating the document size limit
+func DocumentTooLarge(maxSize int64) error {
+	limit := fmt.Sprintf("must be at most %d MB", maxSize/(1024*1024))
+	return ErrDocumentTooLarge.WithMessage("document "+limit).WithField("file", limit)
+}
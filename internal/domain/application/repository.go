@@ -19,6 +19,11 @@ type ApplicationRepository interface {
 	ListByUser(ctx context.Context, userID int64, filter ApplicationFilter, page, limit int) ([]JobApplication, int64, error)
 	ListByJob(ctx context.Context, jobID int64, filter ApplicationFilter, page, limit int) ([]JobApplication, int64, error)
 	ListByCompany(ctx context.Context, companyID int64, filter ApplicationFilter, page, limit int) ([]JobApplication, int64, error)
+	ListByCompanyCursor(ctx context.Context, companyID int64, filter ApplicationFilter, cursor string, limit int) ([]JobApplication, string, error)
+
+	// GetJobBoard returns the top perColumn applications of each status for a job, with each
+	// status' total count, in a single query
+	GetJobBoard(ctx context.Context, jobID int64, statuses []string, perColumn int, sortBy string) ([]ApplicationBoardRow, error)
 
 	// Application status operations
 	UpdateStatus(ctx context.Context, id int64, status string) error
@@ -47,7 +52,11 @@ type ApplicationRepository interface {
 	CompleteStage(ctx context.Context, id int64, notes string) error
 	ListStagesByApplication(ctx context.Context, applicationID int64) ([]JobApplicationStage, error)
 	GetCurrentStage(ctx context.Context, applicationID int64) (*JobApplicationStage, error)
+	// GetCurrentStages returns the open stage of each given application keyed by application ID
+	GetCurrentStages(ctx context.Context, applicationIDs []int64) (map[int64]JobApplicationStage, error)
 	GetStageHistory(ctx context.Context, applicationID int64) ([]JobApplicationStage, error)
+	FindStaleStages(ctx context.Context, defaultThresholdDays int, remindedBefore time.Time) ([]StaleStage, error)
+	MarkStagesReminded(ctx context.Context, stageIDs []int64, at time.Time) error
 
 	// ApplicationDocument operations
 	CreateDocument(ctx context.Context, document *ApplicationDocument) error
@@ -60,6 +69,21 @@ type ApplicationRepository interface {
 	VerifyDocument(ctx context.Context, id int64, verifiedBy int64) error
 	GetUnverifiedDocuments(ctx context.Context, page, limit int) ([]ApplicationDocument, int64, error)
 
+	// Application file snapshots
+	// SetResumeSnapshot points the application's resume at its snapshot and keeps the library
+	// document it was copied from. Applications that already have a snapshot are left as they are.
+	SetResumeSnapshot(ctx context.Context, applicationID int64, snapshotURL, sourceURL string) (bool, error)
+	// ListUnsnapshottedResumes returns up to limit applications after afterID, by ID, whose
+	// resume still references a file instead of a snapshot
+	ListUnsnapshottedResumes(ctx context.Context, afterID int64, limit int) ([]JobApplication, error)
+	// ListUnsnapshottedDocuments returns up to limit application documents after afterID, by ID,
+	// that still reference a pre-uploaded file instead of a snapshot
+	ListUnsnapshottedDocuments(ctx context.Context, afterID int64, limit int) ([]ApplicationDocument, error)
+
+	// ApplicationAnswer operations
+	CreateAnswers(ctx context.Context, answers []ApplicationAnswer) error
+	ListAnswersByApplication(ctx context.Context, applicationID int64) ([]ApplicationAnswer, error)
+
 	// ApplicationNote operations
 	CreateNote(ctx context.Context, note *ApplicationNote) error
 	FindNoteByID(ctx context.Context, id int64) (*ApplicationNote, error)
@@ -86,16 +110,62 @@ type ApplicationRepository interface {
 	RescheduleInterview(ctx context.Context, id int64, newSchedule time.Time) error
 	CancelInterview(ctx context.Context, id int64) error
 
+	// Interview slot operations
+	// CreateInterviewSlots inserts the slots, skipping any that start at the same time as another
+	// slot of the same interviewer, and returns how many were created
+	CreateInterviewSlots(ctx context.Context, slots []InterviewSlot) (int64, error)
+	FindInterviewSlotByID(ctx context.Context, id int64) (*InterviewSlot, error)
+	ListInterviewSlots(ctx context.Context, companyID int64, filter InterviewSlotFilter) ([]InterviewSlot, error)
+	// UpdateInterviewSlot saves an open slot; ErrInterviewSlotBooked when it has been booked meanwhile
+	UpdateInterviewSlot(ctx context.Context, slot *InterviewSlot) error
+	// DeleteInterviewSlot deletes an open slot; ErrInterviewSlotBooked when it is booked
+	DeleteInterviewSlot(ctx context.Context, id int64) error
+	// ClaimInterviewSlot marks the link used and the slot booked by the link's application in one
+	// transaction. It returns ErrBookingLinkUsed or ErrInterviewSlotTaken when the link was used or
+	// the slot was booked or started first.
+	ClaimInterviewSlot(ctx context.Context, link *InterviewBookingLink, slotID int64) error
+	// UnclaimInterviewSlot reverts ClaimInterviewSlot when the interview could not be scheduled
+	UnclaimInterviewSlot(ctx context.Context, linkID, slotID int64) error
+	SetInterviewSlotInterview(ctx context.Context, slotID, interviewID int64) error
+	// ReleaseInterviewSlot reopens the slot booked for the interview, if any
+	ReleaseInterviewSlot(ctx context.Context, interviewID int64) error
+
+	// Interview booking link operations
+	CreateBookingLink(ctx context.Context, link *InterviewBookingLink) error
+	FindBookingLinkByTokenHash(ctx context.Context, tokenHash string) (*InterviewBookingLink, error)
+
 	// Analytics and reporting
 	GetApplicationTrends(ctx context.Context, startDate, endDate time.Time) ([]ApplicationTrend, error)
 	GetConversionFunnel(ctx context.Context, jobID int64) (*ConversionFunnel, error)
 	GetAverageTimePerStage(ctx context.Context, companyID int64) ([]StageTimeStats, error)
 	GetTopApplicants(ctx context.Context, jobID int64, limit int) ([]JobApplication, error)
-	GetApplicationSourceStats(ctx context.Context, companyID int64) ([]SourceStats, error)
+	// GetApplicationSourceStats counts views, applications and hires per utm_source, grouping
+	// missing sources under SourceDirect. Rates are left for the caller to compute.
+	GetApplicationSourceStats(ctx context.Context, filter SourceStatsFilter) ([]SourceStats, error)
 
 	// Bulk operations
 	BulkCreateApplications(ctx context.Context, applications []JobApplication) error
 	BulkDeleteApplications(ctx context.Context, ids []int64) error
+
+	// Bulk action operations
+	// FilterCompanyApplicationIDs returns the IDs among ids of the company's applications
+	FilterCompanyApplicationIDs(ctx context.Context, companyID int64, ids []int64) ([]int64, error)
+	// ListCompanyApplicationIDs returns the IDs of up to limit of the company's applications
+	// matching filter, oldest first
+	ListCompanyApplicationIDs(ctx context.Context, companyID int64, filter ApplicationFilter, limit int) ([]int64, error)
+	// CreateBulkAction inserts the action together with a pending item per application
+	CreateBulkAction(ctx context.Context, action *BulkAction, applicationIDs []int64) error
+	FindBulkActionByID(ctx context.Context, id int64) (*BulkAction, error)
+	// FindUnfinishedBulkActions returns queued and running actions, oldest first
+	FindUnfinishedBulkActions(ctx context.Context, limit int) ([]BulkAction, error)
+	// StartBulkAction marks a queued action running
+	StartBulkAction(ctx context.Context, id int64, at time.Time) error
+	// CompleteBulkAction marks an action done
+	CompleteBulkAction(ctx context.Context, id int64, at time.Time) error
+	ListBulkActionItems(ctx context.Context, actionID int64, status string, limit int) ([]BulkActionItem, error)
+	// RecordBulkActionItem saves the result of a pending item and adds it to the action's counts
+	// in one transaction. Items that already have a result are left as they are.
+	RecordBulkActionItem(ctx context.Context, item *BulkActionItem) error
 }
 
 // ApplicationFilter defines filter criteria for application listing
@@ -111,20 +181,50 @@ type ApplicationFilter struct {
 	Source         string
 	AppliedAfter   *time.Time
 	AppliedBefore  *time.Time
-	SortBy         string // "latest", "score_desc", "score_asc"
+	SortBy         string           // "latest", "score_desc", "score_asc"
+	Answers        map[int64]string // Screening question ID to required answer value
 }
 
-// ApplicationSearchFilter defines advanced search criteria
+// ApplicationSearchFilter defines advanced search criteria. Besides the application itself it
+// filters on the applicant's profile: skills, education, work experience, location and availability.
 type ApplicationSearchFilter struct {
-	Keyword       string
-	JobIDs        []int64
-	CompanyIDs    []int64
-	Statuses      []string
-	MinScore      *float64
-	Sources       []string
-	AppliedWithin *int // days
-	HasDocuments  *bool
-	HasInterviews *bool
+	Keyword       string   `json:"keyword"`
+	JobIDs        []int64  `json:"job_ids"`
+	CompanyIDs    []int64  `json:"company_ids"` // Limited to the searching employer's companies by the service
+	Statuses      []string `json:"statuses"`
+	MinScore      *float64 `json:"min_score"`
+	Sources       []string `json:"sources"`
+	AppliedWithin *int     `json:"applied_within"` // days
+	HasDocuments  *bool    `json:"has_documents"`
+	HasInterviews *bool    `json:"has_interviews"`
+
+	// Applicant profile filters
+	SkillIDs             []int64  `json:"skill_ids"`            // skills_master IDs, matched by name like the job match score
+	SkillMatch           string   `json:"skill_match"`          // SkillMatchAll (default) or SkillMatchAny
+	MinDegreeLevel       string   `json:"min_degree_level"`     // Lowest accepted degree, see DegreeLevels
+	MinExperienceYears   *int     `json:"min_experience_years"` // Total years over all work experiences
+	CityIDs              []int64  `json:"city_ids"`
+	ProvinceIDs          []int64  `json:"province_ids"`
+	AvailabilityStatuses []string `json:"availability_statuses"` // open, looking_actively, not_looking
+}
+
+// Skill matching modes of ApplicationSearchFilter.SkillIDs
+const (
+	SkillMatchAll = "all"
+	SkillMatchAny = "any"
+)
+
+// DegreeLevels lists the ranked user education degree levels from lowest to highest
+var DegreeLevels = []string{"SMA", "D1", "D2", "D3", "S1", "S2", "S3"}
+
+// DegreeLevelsFrom returns minLevel and every degree level above it, or nil for an unknown level
+func DegreeLevelsFrom(minLevel string) []string {
+	for i, level := range DegreeLevels {
+		if level == minLevel {
+			return DegreeLevels[i:]
+		}
+	}
+	return nil
 }
 
 // InterviewFilter defines filter criteria for interview listing
@@ -136,6 +236,21 @@ type InterviewFilter struct {
 	CompletedOnly *bool
 }
 
+// Interview slot states for InterviewSlotFilter
+const (
+	InterviewSlotOpen   = "open"
+	InterviewSlotBooked = "booked"
+)
+
+// InterviewSlotFilter defines filter criteria for interview slot listing
+type InterviewSlotFilter struct {
+	From          *time.Time // Slots starting at or after From
+	To            *time.Time // Slots starting before To
+	Status        string     // InterviewSlotOpen, InterviewSlotBooked or empty for both
+	InterviewerID int64
+	Limit         int
+}
+
 // ApplicationStats represents application statistics
 type ApplicationStats struct {
 	ApplicationID         int64
@@ -164,6 +279,7 @@ type UserApplicationStats struct {
 	HiredCount          int64
 	RejectedCount       int64
 	WithdrawnCount      int64
+	JobWithdrawnCount   int64 // Closed because the job was deleted
 	AverageMatchScore   float64
 	SuccessRate         float64
 	AverageResponseTime float64 // in days
@@ -181,6 +297,7 @@ type JobApplicationStats struct {
 	HiredCount        int64
 	RejectedCount     int64
 	WithdrawnCount    int64
+	JobWithdrawnCount int64 // Closed because the job was deleted
 	AverageMatchScore float64
 	ConversionRate    float64
 	AverageTimeToHire float64 // in days
@@ -222,6 +339,20 @@ type ConversionFunnel struct {
 	ConversionRates  map[string]float64 // stage -> rate
 }
 
+// StaleStage is an application's current stage that has been open longer than its
+// company's reminder threshold
+type StaleStage struct {
+	StageID       int64
+	ApplicationID int64
+	StageName     string
+	StartedAt     time.Time
+	JobID         int64
+	JobTitle      string
+	CompanyID     int64
+	ApplicantName string
+	ThresholdDays int
+}
+
 // StageTimeStats represents average time spent in each stage
 type StageTimeStats struct {
 	StageName   string
@@ -231,13 +362,26 @@ type StageTimeStats struct {
 	Count       int64
 }
 
-// SourceStats represents application source statistics
+// SourceDirect is the source of views and applications without a utm_source
+const SourceDirect = "direct"
+
+// SourceStats represents job views, applications and hires attributed to one utm_source
 type SourceStats struct {
-	Source            string
-	Count             int64
-	HiredCount        int64
-	ConversionRate    float64
-	AverageMatchScore float64
+	Source             string  `json:"source"`
+	Views              int64   `json:"views"`
+	Count              int64   `json:"applications"`
+	HiredCount         int64   `json:"hires"`
+	ViewConversionRate float64 `json:"view_conversion_rate"` // Applications per 100 views
+	ConversionRate     float64 `json:"hire_rate"`            // Hires per 100 applications
+	AverageMatchScore  float64 `json:"average_match_score"`
+}
+
+// SourceStatsFilter selects the views and applications counted in source analytics
+type SourceStatsFilter struct {
+	CompanyID int64
+	JobID     *int64    // Only this job when set
+	From      time.Time // Inclusive
+	To        time.Time // Exclusive
 }
 
 // SourceCount represents source count
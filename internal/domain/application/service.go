@@ -2,7 +2,10 @@ package application
 
 import (
 	"context"
+	"mime/multipart"
 	"time"
+
+	"keerja-backend/internal/domain/employer"
 )
 
 // ApplicationService defines the interface for application business logic
@@ -13,35 +16,62 @@ type ApplicationService interface {
 	GetMyApplications(ctx context.Context, userID int64, filter ApplicationFilter, page, limit int) (*ApplicationListResponse, error)
 	GetApplicationDetail(ctx context.Context, applicationID, userID int64) (*ApplicationDetailResponse, error)
 	GetMyApplicationStats(ctx context.Context, userID int64) (*UserApplicationStats, error)
+	// GetCandidateTimeline returns the applicant's view of the application's progress, without
+	// the hiring team's notes, identities or evaluations
+	GetCandidateTimeline(ctx context.Context, applicationID, userID int64) (*CandidateTimeline, error)
 
 	// Application review and management (Employer)
-	GetJobApplications(ctx context.Context, jobID int64, filter ApplicationFilter, page, limit int) (*ApplicationListResponse, error)
-	GetCompanyApplications(ctx context.Context, companyID int64, filter ApplicationFilter, page, limit int) (*ApplicationListResponse, error)
-	GetApplicationForReview(ctx context.Context, applicationID, employerUserID int64) (*ApplicationDetailResponse, error)
-	MarkAsViewed(ctx context.Context, applicationID, employerUserID int64) error
-	ToggleBookmark(ctx context.Context, applicationID, employerUserID int64) error
-	GetBookmarkedApplications(ctx context.Context, companyID int64, page, limit int) (*ApplicationListResponse, error)
+	GetJobApplications(ctx context.Context, ec *employer.EmployerContext, jobID int64, filter ApplicationFilter, page, limit int) (*ApplicationListResponse, error)
+	GetCompanyApplications(ctx context.Context, ec *employer.EmployerContext, filter ApplicationFilter, page, limit int) (*ApplicationListResponse, error)
+	GetCompanyApplicationsByCursor(ctx context.Context, ec *employer.EmployerContext, filter ApplicationFilter, cursor string, limit int) ([]ApplicationSummary, string, error)
+	GetJobApplicationsBoard(ctx context.Context, ec *employer.EmployerContext, jobID int64, perColumn int, sortBy string) (*ApplicationBoard, error)
+	GetApplicationForReview(ctx context.Context, ec *employer.EmployerContext, applicationID int64) (*ApplicationDetailResponse, error)
+	MarkAsViewed(ctx context.Context, ec *employer.EmployerContext, applicationID int64) error
+	ToggleBookmark(ctx context.Context, ec *employer.EmployerContext, applicationID int64) error
+	GetBookmarkedApplications(ctx context.Context, ec *employer.EmployerContext, page, limit int) (*ApplicationListResponse, error)
 
 	// Application status workflow (Employer)
-	MoveToScreening(ctx context.Context, applicationID, handledBy int64, notes string) error
-	MoveToShortlist(ctx context.Context, applicationID, handledBy int64, notes string) error
-	MoveToInterview(ctx context.Context, applicationID, handledBy int64, notes string) error
-	MakeOffer(ctx context.Context, applicationID, handledBy int64, notes string) error
-	MarkAsHired(ctx context.Context, applicationID, handledBy int64, notes string) error
-	RejectApplication(ctx context.Context, applicationID, handledBy int64, reason string) error
-	BulkUpdateStatus(ctx context.Context, applicationIDs []int64, status string, handledBy int64) error
+	// MoveToStage moves an application to a stage of its job's pipeline, a status or a custom stage
+	MoveToStage(ctx context.Context, ec *employer.EmployerContext, applicationID int64, stage, notes string) error
+	MoveToScreening(ctx context.Context, ec *employer.EmployerContext, applicationID int64, notes string) error
+	MoveToShortlist(ctx context.Context, ec *employer.EmployerContext, applicationID int64, notes string) error
+	MoveToInterview(ctx context.Context, ec *employer.EmployerContext, applicationID int64, notes string) error
+	MakeOffer(ctx context.Context, ec *employer.EmployerContext, applicationID int64, notes string) error
+	MarkAsHired(ctx context.Context, ec *employer.EmployerContext, applicationID int64, notes string) error
+	RejectApplication(ctx context.Context, ec *employer.EmployerContext, applicationID int64, reason string, doNotReapply bool) error
+	BulkUpdateStatus(ctx context.Context, ec *employer.EmployerContext, applicationIDs []int64, status string) error
+	// CreateBulkAction queues moving the selected applications of the employer's company to a
+	// status. Applications of other companies are left out and counted as skipped.
+	CreateBulkAction(ctx context.Context, ec *employer.EmployerContext, req *BulkActionRequest) (*BulkAction, error)
+	// GetBulkAction returns the progress of one of the company's bulk actions with its failed items
+	GetBulkAction(ctx context.Context, ec *employer.EmployerContext, id int64) (*BulkAction, error)
+	// ProcessBulkActions works through queued and interrupted bulk actions in batches of
+	// BulkActionBatchSize and returns the number of items processed
+	ProcessBulkActions(ctx context.Context) (int, error)
 
 	// Stage management
 	GetApplicationStages(ctx context.Context, applicationID int64) ([]JobApplicationStage, error)
 	GetCurrentStage(ctx context.Context, applicationID int64) (*JobApplicationStage, error)
 	GetStageHistory(ctx context.Context, applicationID int64) ([]JobApplicationStage, error)
-	CompleteStage(ctx context.Context, stageID, handledBy int64, notes string) error
+	CompleteStage(ctx context.Context, ec *employer.EmployerContext, stageID int64, notes string) error
 
 	// Document management
-	UploadApplicationDocument(ctx context.Context, req *UploadDocumentRequest) (*ApplicationDocument, error)
+	// UploadApplicationDocument stores file for the application; with a nil file the request's
+	// pre-uploaded FileURL is used if document URLs are still accepted
+	UploadApplicationDocument(ctx context.Context, req *UploadDocumentRequest, file *multipart.FileHeader) (*ApplicationDocument, error)
 	UpdateDocument(ctx context.Context, documentID int64, req *UpdateDocumentRequest) (*ApplicationDocument, error)
 	DeleteDocument(ctx context.Context, documentID, userID int64) error
+	DeleteApplication(ctx context.Context, applicationID int64) error
 	GetApplicationDocuments(ctx context.Context, applicationID int64) ([]ApplicationDocument, error)
+	// GetDocumentForDownload returns an application document to the applicant or an employer
+	// of the hiring company
+	GetDocumentForDownload(ctx context.Context, applicationID, documentID, userID int64) (*ApplicationDocument, error)
+	// GetResumeForDownload returns the application whose resume the applicant or an employer of
+	// the hiring company downloads
+	GetResumeForDownload(ctx context.Context, applicationID, userID int64) (*JobApplication, error)
+	// BackfillFileSnapshots snapshots the resumes and documents of existing applications that
+	// still reference library documents, batchSize at a time
+	BackfillFileSnapshots(ctx context.Context, batchSize int) (*SnapshotBackfillResult, error)
 	GetDocumentsByType(ctx context.Context, applicationID int64, docType string) ([]ApplicationDocument, error)
 	VerifyDocument(ctx context.Context, documentID, verifiedBy int64, notes string) error
 	GetUnverifiedDocuments(ctx context.Context, page, limit int) ([]ApplicationDocument, int64, error)
@@ -52,8 +82,8 @@ type ApplicationService interface {
 	DeleteNote(ctx context.Context, noteID, authorID int64) error
 	GetApplicationNotes(ctx context.Context, applicationID int64, visibility string) ([]ApplicationNote, error)
 	GetStageNotes(ctx context.Context, stageID int64) ([]ApplicationNote, error)
-	PinNote(ctx context.Context, noteID, employerUserID int64) error
-	UnpinNote(ctx context.Context, noteID, employerUserID int64) error
+	PinNote(ctx context.Context, noteID, userID int64) error
+	UnpinNote(ctx context.Context, noteID, userID int64) error
 	GetPinnedNotes(ctx context.Context, applicationID int64) ([]ApplicationNote, error)
 
 	// Interview scheduling and management
@@ -64,12 +94,26 @@ type ApplicationService interface {
 	MarkInterviewNoShow(ctx context.Context, interviewID int64, markedBy int64) error
 	GetApplicationInterviews(ctx context.Context, applicationID int64) ([]Interview, error)
 	GetInterviewDetail(ctx context.Context, interviewID int64) (*Interview, error)
-	GetUpcomingInterviews(ctx context.Context, employerUserID int64, days int) ([]Interview, error)
+	GetUpcomingInterviews(ctx context.Context, userID int64, days int) ([]Interview, error)
 	GetInterviewsByDateRange(ctx context.Context, startDate, endDate time.Time) ([]Interview, error)
 	SendInterviewReminder(ctx context.Context, interviewID int64) error
 
+	// Interview slots and candidate self-scheduling
+	CreateInterviewSlots(ctx context.Context, companyID, createdBy int64, req *CreateInterviewSlotsRequest) (*InterviewSlotBatchResult, error)
+	ListInterviewSlots(ctx context.Context, companyID int64, filter InterviewSlotFilter) ([]InterviewSlot, error)
+	UpdateInterviewSlot(ctx context.Context, companyID, slotID int64, req *UpdateInterviewSlotRequest) (*InterviewSlot, error)
+	DeleteInterviewSlot(ctx context.Context, companyID, slotID int64) error
+	// SendInterviewBookingLink moves the application to the interview stage if needed and emails
+	// the candidate a link to book one of the company's open interview slots
+	SendInterviewBookingLink(ctx context.Context, ec *employer.EmployerContext, applicationID int64, req *SendBookingLinkRequest) (*InterviewBookingLinkResult, error)
+	GetInterviewBooking(ctx context.Context, token string) (*InterviewBooking, error)
+	// BookInterviewSlot books the slot for the link's application and schedules its interview
+	BookInterviewSlot(ctx context.Context, token string, slotID int64) (*Interview, error)
+
 	// Search and filtering
-	SearchApplications(ctx context.Context, filter ApplicationSearchFilter, page, limit int) (*ApplicationListResponse, error)
+	// SearchApplications searches the applications to the employer's active company, or to the
+	// filter's companies when the employer belongs to each of them
+	SearchApplications(ctx context.Context, ec *employer.EmployerContext, filter ApplicationSearchFilter, page, limit int) (*ApplicationListResponse, error)
 	GetHighScoreApplications(ctx context.Context, companyID int64, minScore float64, limit int) ([]JobApplication, error)
 	GetRecentApplications(ctx context.Context, companyID int64, hours int, limit int) ([]JobApplication, error)
 
@@ -81,7 +125,7 @@ type ApplicationService interface {
 	GetApplicationTrends(ctx context.Context, companyID int64, startDate, endDate time.Time) ([]ApplicationTrend, error)
 	GetAverageTimePerStage(ctx context.Context, companyID int64) ([]StageTimeStats, error)
 	GetTopApplicants(ctx context.Context, jobID int64, limit int) ([]JobApplication, error)
-	GetApplicationSourceAnalytics(ctx context.Context, companyID int64) ([]SourceStats, error)
+	GetApplicationSourceAnalytics(ctx context.Context, filter SourceStatsFilter) ([]SourceStats, error)
 
 	// Notifications
 	NotifyApplicationReceived(ctx context.Context, applicationID int64) error
@@ -92,13 +136,12 @@ type ApplicationService interface {
 	// Validation and permissions
 	ValidateApplication(ctx context.Context, application *JobApplication) error
 	CheckApplicationOwnership(ctx context.Context, applicationID, userID int64) error
-	CheckEmployerAccess(ctx context.Context, applicationID, employerUserID int64) error
 	CanApplyForJob(ctx context.Context, jobID, userID int64) error
 
 	// Bulk operations
-	BulkRejectApplications(ctx context.Context, applicationIDs []int64, rejectedBy int64, reason string) error
-	BulkMoveToStage(ctx context.Context, applicationIDs []int64, stage string, handledBy int64) error
-	ExportApplications(ctx context.Context, companyID int64, filter ApplicationFilter) ([]byte, error)
+	BulkRejectApplications(ctx context.Context, ec *employer.EmployerContext, applicationIDs []int64, reason string) error
+	BulkMoveToStage(ctx context.Context, ec *employer.EmployerContext, applicationIDs []int64, stage string) error
+	ExportApplications(ctx context.Context, ec *employer.EmployerContext, filter ApplicationFilter) ([]byte, error)
 }
 
 // ===== Request DTOs =====
@@ -111,18 +154,32 @@ type ApplyJobRequest struct {
 	CoverLetter string                  `json:"cover_letter,omitempty"`
 	Source      string                  `json:"source,omitempty"`
 	Documents   []UploadDocumentRequest `json:"documents,omitempty"`
+	Answers     []AnswerRequest         `json:"answers,omitempty"`
+
+	// Where the applicant came from, as captured from the job link
+	UTMSource   string `json:"utm_source,omitempty"`
+	UTMMedium   string `json:"utm_medium,omitempty"`
+	UTMCampaign string `json:"utm_campaign,omitempty"`
+	Referrer    string `json:"referrer,omitempty"`
+}
+
+// AnswerRequest is an answer to one of the job's screening questions. Booleans and
+// numbers are sent as strings, e.g. "true" or "7500000".
+type AnswerRequest struct {
+	QuestionID int64  `json:"question_id" validate:"required"`
+	Value      string `json:"value"`
 }
 
 // UploadDocumentRequest represents request to upload application document
 type UploadDocumentRequest struct {
 	ApplicationID int64  `json:"application_id" validate:"required"`
 	UserID        int64  `json:"user_id" validate:"required"`
-	DocumentType  string `json:"document_type" validate:"required,oneof='cv' 'cover_letter' 'portfolio' 'certificate' 'transcript' 'other'"`
-	FileName      string `json:"file_name,omitempty"`
-	FileURL       string `json:"file_url" validate:"required"`
-	FileType      string `json:"file_type,omitempty"`
-	FileSize      int64  `json:"file_size,omitempty"`
-	Notes         string `json:"notes,omitempty"`
+	DocumentType  string `json:"document_type" form:"document_type" validate:"required,oneof='cv' 'cover_letter' 'portfolio' 'certificate' 'transcript' 'other'"`
+	FileName      string `json:"file_name,omitempty" form:"file_name"`
+	FileURL       string `json:"file_url,omitempty" form:"file_url"` // Pre-uploaded file, only accepted while document URLs are enabled
+	FileType      string `json:"file_type,omitempty" form:"file_type"`
+	FileSize      int64  `json:"file_size,omitempty" form:"file_size"`
+	Notes         string `json:"notes,omitempty" form:"notes"`
 }
 
 // UpdateDocumentRequest represents request to update document
@@ -161,6 +218,7 @@ type ScheduleInterviewRequest struct {
 	InterviewType string    `json:"interview_type" validate:"omitempty,oneof='online' 'onsite' 'hybrid'"`
 	MeetingLink   string    `json:"meeting_link,omitempty"`
 	Location      string    `json:"location,omitempty"`
+	Timezone      string    `json:"timezone,omitempty"` // IANA name, e.g. Asia/Jakarta; defaults to UTC
 }
 
 // RescheduleInterviewRequest represents request to reschedule interview
@@ -169,6 +227,7 @@ type RescheduleInterviewRequest struct {
 	Reason      string    `json:"reason,omitempty"`
 	MeetingLink string    `json:"meeting_link,omitempty"`
 	Location    string    `json:"location,omitempty"`
+	Timezone    string    `json:"timezone,omitempty"` // Keeps the current timezone when empty
 }
 
 // CompleteInterviewRequest represents request to complete interview with evaluation
@@ -182,8 +241,94 @@ type CompleteInterviewRequest struct {
 	CompletedBy        int64    `json:"completed_by" validate:"required"`
 }
 
+// Interview slot batch limits
+const (
+	MaxInterviewSlotBatchDays = 31  // Longest date range of one batch
+	MaxInterviewSlotsPerBatch = 200 // Most slots one batch may create
+	MaxInterviewSlotsListed   = 500 // Most slots returned by one listing
+)
+
+// Booking link lifetimes
+const (
+	DefaultBookingLinkTTL = 72 * time.Hour
+	MaxBookingLinkTTL     = 14 * 24 * time.Hour
+)
+
+// CreateInterviewSlotsRequest describes a batch of interview slots. Every day from StartDate to
+// EndDate, weekdays only unless IncludeWeekends, is split from DayStart to DayEnd into slots of
+// DurationMinutes with BufferMinutes between consecutive slots. Slots that have already started
+// are left out.
+type CreateInterviewSlotsRequest struct {
+	InterviewerID   *int64 `json:"interviewer_id,omitempty"`       // Defaults to the recruiter creating the slots
+	StartDate       string `json:"start_date" validate:"required"` // YYYY-MM-DD
+	EndDate         string `json:"end_date" validate:"required"`   // YYYY-MM-DD, within MaxInterviewSlotBatchDays of StartDate
+	DayStart        string `json:"day_start" validate:"required"`  // HH:MM in Timezone
+	DayEnd          string `json:"day_end" validate:"required"`    // HH:MM in Timezone
+	DurationMinutes int    `json:"duration_minutes" validate:"required,min=10,max=480"`
+	BufferMinutes   int    `json:"buffer_minutes" validate:"min=0,max=240"`
+	IncludeWeekends bool   `json:"include_weekends"`
+	Timezone        string `json:"timezone,omitempty"` // IANA name, e.g. Asia/Jakarta; defaults to UTC
+	InterviewType   string `json:"interview_type" validate:"omitempty,oneof='online' 'onsite' 'hybrid'"`
+	MeetingLink     string `json:"meeting_link,omitempty"`
+	Location        string `json:"location,omitempty"`
+}
+
+// UpdateInterviewSlotRequest replaces the time and meeting details of an open interview slot
+type UpdateInterviewSlotRequest struct {
+	StartsAt        time.Time `json:"starts_at" validate:"required"`
+	DurationMinutes int       `json:"duration_minutes" validate:"required,min=10,max=480"`
+	Timezone        string    `json:"timezone,omitempty"` // Keeps the current timezone when empty
+	InterviewType   string    `json:"interview_type" validate:"omitempty,oneof='online' 'onsite' 'hybrid'"`
+	MeetingLink     string    `json:"meeting_link,omitempty"`
+	Location        string    `json:"location,omitempty"`
+}
+
+// SendBookingLinkRequest represents request to send a candidate an interview booking link
+type SendBookingLinkRequest struct {
+	ExpiresInHours int `json:"expires_in_hours" validate:"omitempty,min=1,max=336"` // Defaults to DefaultBookingLinkTTL
+}
+
+// BookInterviewSlotRequest represents a candidate's choice of interview slot
+type BookInterviewSlotRequest struct {
+	SlotID int64 `json:"slot_id" validate:"required"`
+}
+
+// Bulk action limits
+const (
+	MaxBulkActionItems  = 2000 // Most applications one bulk action may include
+	BulkActionBatchSize = 50   // Items loaded and processed at a time
+)
+
+// BulkActionStatuses are the statuses a bulk action can move applications to
+var BulkActionStatuses = []string{StatusScreening, StatusShortlisted, StatusInterview, StatusOffered, StatusHired, StatusRejected}
+
+// BulkActionRequest moves applications to Status. Applications are selected either by ID or by
+// the filter of the applications list, which is resolved to IDs when the action is queued.
+type BulkActionRequest struct {
+	Status         string            `json:"status" validate:"required"`
+	ApplicationIDs []int64           `json:"application_ids,omitempty"`
+	Filter         *BulkActionFilter `json:"filter,omitempty"`
+	Notes          string            `json:"notes,omitempty" validate:"max=1000"`
+}
+
+// BulkActionFilter selects the company's applications like the applications list filters
+type BulkActionFilter struct {
+	Status   string   `json:"status,omitempty"`
+	JobID    int64    `json:"job_id,omitempty"`
+	Source   string   `json:"source,omitempty"`
+	MinScore *float64 `json:"min_score,omitempty"`
+}
+
 // ===== Response DTOs =====
 
+// SnapshotBackfillResult counts the application files BackfillFileSnapshots went through
+type SnapshotBackfillResult struct {
+	Resumes   int `json:"resumes"`   // Resume snapshots created
+	Documents int `json:"documents"` // Document snapshots created
+	Skipped   int `json:"skipped"`   // Files outside the applicant's library or no longer stored
+	Failed    int `json:"failed"`
+}
+
 // ApplicationListResponse represents paginated application list
 type ApplicationListResponse struct {
 	Applications []ApplicationSummary `json:"applications"`
@@ -194,6 +339,40 @@ type ApplicationListResponse struct {
 	Stats        *ListStats           `json:"stats,omitempty"`
 }
 
+// InterviewSlotBatchResult reports how many slots a batch created and how many it skipped
+// because the interviewer already had a slot at that time
+type InterviewSlotBatchResult struct {
+	Created int64 `json:"created"`
+	Skipped int64 `json:"skipped"`
+}
+
+// InterviewBookingLinkResult is a sent booking link. The token is only returned here; the
+// link itself stores its hash.
+type InterviewBookingLinkResult struct {
+	ID            int64     `json:"id"`
+	ApplicationID int64     `json:"application_id"`
+	Token         string    `json:"token"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+// InterviewBooking is what a candidate sees when opening a booking link
+type InterviewBooking struct {
+	JobTitle    string                  `json:"job_title"`
+	CompanyName string                  `json:"company_name"`
+	ExpiresAt   time.Time               `json:"expires_at"`
+	Slots       []BookableInterviewSlot `json:"slots"`
+}
+
+// BookableInterviewSlot is an open slot offered to a candidate. Meeting details are sent
+// with the interview invitation once a slot is booked.
+type BookableInterviewSlot struct {
+	ID            int64     `json:"id"`
+	StartsAt      time.Time `json:"starts_at"`
+	EndsAt        time.Time `json:"ends_at"`
+	Timezone      string    `json:"timezone"`
+	InterviewType string    `json:"interview_type"`
+}
+
 // ApplicationSummary represents summary of application for listing
 type ApplicationSummary struct {
 	ID               int64     `json:"id"`
@@ -211,6 +390,55 @@ type ApplicationSummary struct {
 	DaysSinceApplied int       `json:"days_since_applied"`
 }
 
+// Board sort options for GetJobApplicationsBoard
+const (
+	BoardSortAppliedAt  = "applied_at"  // newest applications first
+	BoardSortMatchScore = "match_score" // best matches first
+)
+
+// Board column size limits for GetJobApplicationsBoard
+const (
+	DefaultBoardPerColumn = 10
+	MaxBoardPerColumn     = 50
+)
+
+// ApplicationBoardStatuses are the kanban columns in pipeline order. Withdrawn
+// applications are not shown on the board.
+var ApplicationBoardStatuses = []string{StatusApplied, StatusScreening, StatusShortlisted, StatusInterview, StatusOffered, StatusHired, StatusRejected}
+
+// BlindScreeningStatuses are the statuses in which applicants stay anonymous to recruiters of
+// companies with blind screening enabled. Their identity is revealed once shortlisted.
+var BlindScreeningStatuses = []string{StatusApplied, StatusScreening}
+
+// ApplicationBoard groups a job's applications by status for the recruiter kanban view
+type ApplicationBoard struct {
+	JobID     int64                             `json:"job_id"`
+	PerColumn int                               `json:"per_column"`
+	SortBy    string                            `json:"sort_by"`
+	Statuses  []string                          `json:"statuses"` // column order
+	Stages    []BoardStage                      `json:"stages"`   // the job's pipeline; an application's current_stage places it within its column
+	Columns   map[string]ApplicationBoardColumn `json:"columns"`
+}
+
+// BoardStage is a stage of the job's pipeline, shown within the column of its status
+type BoardStage struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Custom bool   `json:"custom"`
+}
+
+// ApplicationBoardColumn is one status column: its total count and the top applications
+type ApplicationBoardColumn struct {
+	Count        int64                `json:"count"`
+	Applications []ApplicationSummary `json:"applications"`
+}
+
+// ApplicationBoardRow is one ranked application returned by the board query
+type ApplicationBoardRow struct {
+	ApplicationSummary
+	StatusCount int64
+}
+
 // ApplicationDetailResponse represents detailed application information
 type ApplicationDetailResponse struct {
 	Application JobApplication        `json:"application"`
@@ -220,6 +448,7 @@ type ApplicationDetailResponse struct {
 	Documents   []ApplicationDocument `json:"documents"`
 	Notes       []ApplicationNote     `json:"notes"`
 	Interviews  []Interview           `json:"interviews"`
+	Answers     []ApplicationAnswer   `json:"answers"`
 	Stats       *ApplicationStats     `json:"stats,omitempty"`
 }
 
@@ -246,6 +475,14 @@ type ApplicantProfile struct {
 	Skills      []string `json:"skills"`
 	Education   string   `json:"education"`
 	ResumeURL   string   `json:"resume_url"`
+	Location    string   `json:"location"` // City and region, always shown
+	Address     string   `json:"address"`  // Exact address, like Phone only once contact details are revealed
+
+	// ContactRevealed is false while the company cannot see the phone number and address yet,
+	// see RevealsContactDetails
+	ContactRevealed bool `json:"contact_revealed"`
+	// AllowsDirectMessages is false when the applicant does not accept direct messages from employers
+	AllowsDirectMessages bool `json:"allows_direct_messages"`
 }
 
 // ListStats represents statistics for application list
@@ -276,6 +513,32 @@ type TimelineEvent struct {
 	Actor       string    `json:"actor"`
 }
 
+// CandidateTimeline represents an application's progress as shown to the applicant
+type CandidateTimeline struct {
+	ApplicationID int64                    `json:"application_id"`
+	Status        string                   `json:"status"`
+	StatusLabel   string                   `json:"status_label"`
+	NextStep      string                   `json:"next_step,omitempty"`
+	Events        []CandidateTimelineEvent `json:"events"`
+}
+
+// CandidateTimelineEvent represents one step of an application in the candidate timeline
+type CandidateTimelineEvent struct {
+	Date            time.Time `json:"date"`
+	EventType       string    `json:"event_type"`
+	Stage           string    `json:"stage,omitempty"`
+	Label           string    `json:"label"`
+	InterviewType   string    `json:"interview_type,omitempty"`
+	InterviewStatus string    `json:"interview_status,omitempty"`
+}
+
+// Candidate timeline event types
+const (
+	TimelineEventSubmitted = "application_submitted"
+	TimelineEventStage     = "stage_reached"
+	TimelineEventInterview = "interview"
+)
+
 // StageProgress represents stage progress
 type StageProgress struct {
 	StageName   string     `json:"stage_name"`
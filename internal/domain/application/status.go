@@ -0,0 +1,65 @@
+package application
+
+import "slices"
+
+// Application statuses, in hiring pipeline order up to hired. Hired, rejected, withdrawn
+// and job_withdrawn are final.
+const (
+	StatusApplied     = "applied"
+	StatusScreening   = "screening"
+	StatusShortlisted = "shortlisted"
+	StatusInterview   = "interview"
+	StatusOffered     = "offered"
+	StatusHired       = "hired"
+	StatusRejected    = "rejected"
+	StatusWithdrawn   = "withdrawn" // Withdrawn by the applicant
+
+	// StatusJobWithdrawn is the status of applications whose job the employer deleted. It is
+	// final unless the job is restored, which puts the application back in its earlier status.
+	StatusJobWithdrawn = "job_withdrawn"
+)
+
+// Statuses lists every application status, matching the job_applications_status_check constraint
+var Statuses = []string{
+	StatusApplied,
+	StatusScreening,
+	StatusShortlisted,
+	StatusInterview,
+	StatusOffered,
+	StatusHired,
+	StatusRejected,
+	StatusWithdrawn,
+	StatusJobWithdrawn,
+}
+
+// statusTransitions maps each open application status to the statuses it may move to.
+// Employers move applications forward through the pipeline, possibly skipping stages;
+// restoring a deleted job bypasses the map and puts applications back where they were.
+var statusTransitions = map[string][]string{
+	StatusApplied:     {StatusScreening, StatusShortlisted, StatusInterview, StatusOffered, StatusRejected, StatusWithdrawn, StatusJobWithdrawn},
+	StatusScreening:   {StatusShortlisted, StatusInterview, StatusOffered, StatusRejected, StatusWithdrawn, StatusJobWithdrawn},
+	StatusShortlisted: {StatusInterview, StatusOffered, StatusRejected, StatusWithdrawn, StatusJobWithdrawn},
+	StatusInterview:   {StatusOffered, StatusHired, StatusRejected, StatusWithdrawn, StatusJobWithdrawn},
+	StatusOffered:     {StatusHired, StatusRejected, StatusJobWithdrawn},
+}
+
+// ContactRevealStatuses are the statuses in which the company sees the applicant's phone
+// number and exact address. Applications closed before reaching them never reveal them.
+var ContactRevealStatuses = []string{StatusShortlisted, StatusInterview, StatusOffered, StatusHired}
+
+// RevealsContactDetails reports whether a company sees the applicant's phone number and exact
+// address on an application in status
+func RevealsContactDetails(status string) bool {
+	return slices.Contains(ContactRevealStatuses, status)
+}
+
+// IsValidStatus reports whether status is an application status
+func IsValidStatus(status string) bool {
+	return slices.Contains(Statuses, status)
+}
+
+// IsValidTransition reports whether an application may move from one status to another.
+// Staying in the same status is not a transition.
+func IsValidTransition(from, to string) bool {
+	return slices.Contains(statusTransitions[from], to)
+}
@@ -0,0 +1,24 @@
+package audit
+
+import "context"
+
+type actorKey struct{}
+
+// Actor identifies who performed an action and from where
+type Actor struct {
+	Type string
+	ID   int64
+	IP   string
+}
+
+// WithActor returns a copy of ctx carrying the acting user, used by AuditService.Record
+// to attribute entries whose actor is not set explicitly
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored by WithActor
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorKey{}).(Actor)
+	return actor, ok
+}
@@ -0,0 +1,122 @@
+package audit
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Actor types
+const (
+	ActorAdmin    = "admin"
+	ActorEmployer = "employer"
+	ActorUser     = "user"
+	ActorSystem   = "system"
+)
+
+// Entity types
+const (
+	EntityCompany      = "company"
+	EntityReview       = "company_review"
+	EntityEmployerUser = "employer_user"
+	EntityJob          = "job"
+	EntityProvince     = "province"
+	EntityCity         = "city"
+	EntityDistrict     = "district"
+	EntityIndustry     = "industry"
+	EntityJobType      = "job_type"
+	EntityCompanySize  = "company_size"
+	EntityAPIKey       = "company_api_key"
+	EntityMasterData   = "master_data"
+	EntityUser         = "user"
+	EntityAdminUser    = "admin_user"
+	EntityWebhook      = "company_webhook"
+	EntitySkill        = "skill"
+)
+
+// Actions
+const (
+	ActionVerificationApproved  = "verification.approved"
+	ActionVerificationRejected  = "verification.rejected"
+	ActionCompanyStatusChanged  = "company.status_changed"
+	ActionCompanyDeleted        = "company.deleted"
+	ActionCompanyRestored       = "company.restored"
+	ActionCompanyChangeApproved = "company.change_approved"
+	ActionCompanyChangeRejected = "company.change_rejected"
+	ActionCompanyPlanChanged    = "company.plan_changed"
+	ActionReviewApproved        = "review.approved"
+	ActionReviewRejected        = "review.rejected"
+	ActionReviewHidden          = "review.hidden"
+	ActionEmployerRoleUpdated   = "employer.role_updated"
+	ActionEmployerRemoved       = "employer.removed"
+	ActionJobApproved           = "job.approved"
+	ActionJobRejected           = "job.rejected"
+	ActionJobFeatured           = "job.featured"
+	ActionJobUnfeatured         = "job.unfeatured"
+	ActionMasterDataCreated     = "master_data.created"
+	ActionMasterDataUpdated     = "master_data.updated"
+	ActionMasterDataDeleted     = "master_data.deleted"
+	ActionMasterDataImported    = "master_data.imported"
+	ActionSkillMerged           = "skill.merged"
+	ActionDistrictMerged        = "district.merged"
+	ActionAPIKeyCreated         = "api_key.created"
+	ActionAPIKeyRevoked         = "api_key.revoked"
+	ActionDeletionRequested     = "account.deletion_requested"
+	ActionDeletionCanceled      = "account.deletion_canceled"
+	ActionAccountDeleted        = "account.deleted"
+	ActionDataExportRequested   = "account.export_requested"
+	ActionDataExportDownloaded  = "account.export_downloaded"
+	ActionAdminRoleAssigned     = "admin.role_assigned"
+	ActionWebhookCreated        = "webhook.created"
+	ActionWebhookUpdated        = "webhook.updated"
+	ActionWebhookDeleted        = "webhook.deleted"
+	ActionWebhookDisabled       = "webhook.disabled"
+	ActionImpersonationStarted  = "impersonation.started"
+	ActionImpersonationEnded    = "impersonation.ended"
+	ActionImpersonationRequest  = "impersonation.request"
+)
+
+// AuditLog records a sensitive action taken by an admin, an employer, a job seeker or the system
+type AuditLog struct {
+	ID         int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	ActorID    *int64    `gorm:"column:actor_id;index" json:"actor_id,omitempty"` // nil for system actions
+	ActorType  string    `gorm:"column:actor_type;type:varchar(20);not null" json:"actor_type"`
+	Action     string    `gorm:"column:action;type:varchar(50);not null" json:"action"`
+	EntityType string    `gorm:"column:entity_type;type:varchar(50);not null" json:"entity_type"`
+	EntityID   int64     `gorm:"column:entity_id;not null" json:"entity_id"`
+	Metadata   Metadata  `gorm:"column:metadata;type:jsonb;default:'{}'" json:"metadata"`
+	IP         *string   `gorm:"column:ip;type:varchar(45)" json:"ip,omitempty"`
+	CreatedAt  time.Time `gorm:"column:created_at;not null" json:"created_at"`
+}
+
+// TableName specifies the table name for AuditLog
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+// Metadata holds action-specific details of an audit log entry, stored as JSONB
+type Metadata map[string]interface{}
+
+// Value implements the driver.Valuer interface for GORM JSONB
+func (m Metadata) Value() (driver.Value, error) {
+	if m == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(m)
+}
+
+// Scan implements the sql.Scanner interface for GORM JSONB
+func (m *Metadata) Scan(value interface{}) error {
+	if value == nil {
+		*m = Metadata{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to unmarshal JSONB value")
+	}
+
+	return json.Unmarshal(bytes, m)
+}
@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// AuditLogFilter filters audit log listings. Empty fields do not filter.
+type AuditLogFilter struct {
+	ActorType  string
+	ActorID    *int64
+	EntityType string
+	EntityID   *int64
+	Action     string
+	From       *time.Time
+	To         *time.Time // Exclusive upper bound
+}
+
+// AuditLogRepository defines the interface for audit log data operations
+type AuditLogRepository interface {
+	// CreateBatch inserts audit log entries
+	CreateBatch(ctx context.Context, logs []AuditLog) error
+
+	// List lists audit log entries matching filter, newest first
+	List(ctx context.Context, filter AuditLogFilter, page, limit int) ([]AuditLog, int64, error)
+}
+
+// AuditService records and lists audit log entries
+type AuditService interface {
+	// Record queues an entry for writing and returns immediately. The actor and IP are
+	// taken from ctx (see WithActor) when not set on the entry. Entries are dropped,
+	// never blocking the caller, when the write buffer is full.
+	Record(ctx context.Context, entry AuditLog)
+
+	// ListLogs lists audit log entries matching filter, newest first
+	ListLogs(ctx context.Context, filter AuditLogFilter, page, limit int) ([]AuditLog, int64, error)
+
+	// Close stops accepting entries and waits for queued entries to be written
+	Close()
+}
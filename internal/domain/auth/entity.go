@@ -48,6 +48,7 @@ type OTPCode struct {
 	IsUsed    bool       `gorm:"default:false" json:"is_used"`
 	UsedAt    *time.Time `gorm:"type:timestamptz" json:"used_at,omitempty"`
 	Attempts  int        `gorm:"default:0" json:"attempts"`
+	IPAddress *string    `gorm:"type:varchar(45)" json:"-"` // Requesting IP, for per-IP issuance limits
 	CreatedAt time.Time  `gorm:"type:timestamptz;default:now()" json:"created_at"`
 	UpdatedAt time.Time  `gorm:"type:timestamptz;default:now()" json:"updated_at"`
 }
@@ -76,7 +77,9 @@ func (o *OTPCode) IsValid() bool {
 type RefreshToken struct {
 	ID            int64      `gorm:"primaryKey;autoIncrement" json:"id"`
 	UserID        int64      `gorm:"not null;index" json:"user_id"`
-	TokenHash     string     `gorm:"type:text;not null;uniqueIndex" json:"-"` // SHA256 hash, never expose
+	TokenHash     string     `gorm:"type:text;not null;uniqueIndex" json:"-"`   // SHA256 hash, never expose
+	FamilyID      string     `gorm:"type:uuid;not null;index" json:"family_id"` // shared by all tokens rotated from the same login
+	ReplacedBy    *int64     `gorm:"index" json:"replaced_by,omitempty"`        // token issued when this one was rotated
 	DeviceName    *string    `gorm:"type:varchar(255)" json:"device_name,omitempty"`
 	DeviceType    *string    `gorm:"type:varchar(50)" json:"device_type,omitempty"` // mobile, desktop, tablet, unknown
 	DeviceID      *string    `gorm:"type:varchar(255);index" json:"device_id,omitempty"`
@@ -106,6 +109,11 @@ func (r *RefreshToken) IsValid() bool {
 	return !r.IsExpired() && !r.Revoked
 }
 
+// IsRotated checks if the token was revoked because it was exchanged for a newer token
+func (r *RefreshToken) IsRotated() bool {
+	return r.ReplacedBy != nil
+}
+
 // Revoke marks the refresh token as revoked
 func (r *RefreshToken) Revoke(reason string) {
 	now := time.Now()
@@ -40,14 +40,18 @@ type OTPCodeRepository interface {
 	// MarkAsUsed marks an OTP code as used
 	MarkAsUsed(ctx context.Context, id int64) error
 
-	// IncrementAttempts increments failed verification attempts
-	IncrementAttempts(ctx context.Context, id int64) error
+	// ConsumeAttempt atomically records a verification attempt, returning false when the
+	// code is used or has no attempts left
+	ConsumeAttempt(ctx context.Context, id int64, maxAttempts int) (bool, error)
 
 	// DeleteExpired deletes all expired OTP codes
 	DeleteExpired(ctx context.Context) error
 
-	// CountRecentByUserID counts recent OTP requests by user
-	CountRecentByUserID(ctx context.Context, userID int64, since time.Time, otpType string) (int64, error)
+	// FindRecentByUserID finds OTP codes of a type issued to a user since the given time, newest first
+	FindRecentByUserID(ctx context.Context, userID int64, otpType string, since time.Time) ([]*OTPCode, error)
+
+	// FindRecentByIP finds OTP codes of any type requested from an IP since the given time, newest first
+	FindRecentByIP(ctx context.Context, ipAddress string, since time.Time) ([]*OTPCode, error)
 
 	// Update updates an OTP code
 	Update(ctx context.Context, otp *OTPCode) error
@@ -58,6 +62,9 @@ type RefreshTokenRepository interface {
 	// Create creates a new refresh token
 	Create(ctx context.Context, token *RefreshToken) error
 
+	// FindByID finds refresh token by ID
+	FindByID(ctx context.Context, id int64) (*RefreshToken, error)
+
 	// FindByTokenHash finds refresh token by hash
 	FindByTokenHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
 
@@ -85,6 +92,16 @@ type RefreshTokenRepository interface {
 	// RevokeByDeviceID revokes refresh token by device ID
 	RevokeByDeviceID(ctx context.Context, userID int64, deviceID string, reason string) error
 
+	// Rotate atomically revokes the token with oldID and stores next as its replacement.
+	// It returns false without storing next if the old token was already revoked.
+	Rotate(ctx context.Context, oldID int64, next *RefreshToken) (bool, error)
+
+	// RevokeFamily revokes every token in a rotation family
+	RevokeFamily(ctx context.Context, familyID string, reason string) error
+
+	// IsFamilyActive checks if a rotation family still has a non-revoked, non-expired token
+	IsFamilyActive(ctx context.Context, familyID string) (bool, error)
+
 	// DeleteExpired deletes all expired refresh tokens
 	DeleteExpired(ctx context.Context) error
 
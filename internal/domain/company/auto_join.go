@@ -0,0 +1,180 @@
+package company
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Domain verification methods
+const (
+	DomainVerificationEmail = "email" // A code emailed to postmaster@domain
+	DomainVerificationDNS   = "dns"   // A TXT record published on the domain
+)
+
+const (
+	// DomainVerificationCodeExpiry is how long an emailed domain verification code stays valid
+	DomainVerificationCodeExpiry = 24 * time.Hour
+
+	// DomainVerificationMaxAttempts is how many wrong codes are accepted before a new one is needed
+	DomainVerificationMaxAttempts = 5
+
+	// DomainVerificationTXTPrefix starts the TXT record value that proves domain ownership
+	DomainVerificationTXTPrefix = "keerja-domain-verification="
+
+	// DomainVerificationMailbox is the mailbox on the domain the verification code is sent to
+	DomainVerificationMailbox = "postmaster"
+)
+
+// DefaultAutoJoinRole is the role colleagues joining through the email domain request
+const DefaultAutoJoinRole = "viewer"
+
+// freeEmailDomains are public email providers nobody can claim as a company domain
+var freeEmailDomains = map[string]bool{
+	"gmail.com":      true,
+	"googlemail.com": true,
+	"yahoo.com":      true,
+	"yahoo.co.id":    true,
+	"ymail.com":      true,
+	"rocketmail.com": true,
+	"hotmail.com":    true,
+	"outlook.com":    true,
+	"outlook.co.id":  true,
+	"live.com":       true,
+	"msn.com":        true,
+	"icloud.com":     true,
+	"me.com":         true,
+	"mac.com":        true,
+	"aol.com":        true,
+	"proton.me":      true,
+	"protonmail.com": true,
+	"gmx.com":        true,
+	"mail.com":       true,
+	"zoho.com":       true,
+	"yandex.com":     true,
+	"qq.com":         true,
+	"163.com":        true,
+}
+
+// NormalizeEmailDomain lowercases domain and strips surrounding spaces, a leading "@" and a
+// trailing dot
+func NormalizeEmailDomain(domain string) string {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	domain = strings.TrimPrefix(domain, "@")
+	return strings.TrimSuffix(domain, ".")
+}
+
+// EmailDomainOf returns the normalized domain of an email address, or "" when it has none
+func EmailDomainOf(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+	return NormalizeEmailDomain(email[at+1:])
+}
+
+// IsFreeEmailDomain reports whether domain belongs to a public email provider
+func IsFreeEmailDomain(domain string) bool {
+	return freeEmailDomains[NormalizeEmailDomain(domain)]
+}
+
+// IsPendingJoin checks if the membership is a join request still waiting for an owner or admin
+func (eu *EmployerUser) IsPendingJoin() bool {
+	return eu.JoinRequestedAt != nil
+}
+
+// DomainVerification is a company's claim on its email domain. Token holds the hash of the
+// emailed code, or the TXT record value for DNS verification.
+type DomainVerification struct {
+	CompanyID  int64      `gorm:"primaryKey" json:"company_id"`
+	Domain     string     `gorm:"type:varchar(100);not null" json:"domain"`
+	Method     string     `gorm:"type:varchar(10);not null;check:method IN ('email','dns')" json:"method"`
+	Token      string     `gorm:"type:varchar(128);not null" json:"-"`
+	Attempts   int        `gorm:"not null;default:0" json:"-"`
+	ExpiresAt  *time.Time `gorm:"type:timestamp" json:"expires_at,omitempty"` // Emailed codes only
+	VerifiedAt *time.Time `gorm:"type:timestamp" json:"verified_at,omitempty"`
+	CreatedAt  time.Time  `gorm:"type:timestamp;default:now()" json:"created_at"`
+	UpdatedAt  time.Time  `gorm:"type:timestamp;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for DomainVerification
+func (DomainVerification) TableName() string {
+	return "company_domain_verifications"
+}
+
+// IsVerifiedFor checks if the company proved it owns domain. Changing the company's email
+// domain needs a new verification.
+func (v *DomainVerification) IsVerifiedFor(domain string) bool {
+	return v != nil && v.VerifiedAt != nil && v.Domain == NormalizeEmailDomain(domain)
+}
+
+// TXTRecordValue is the TXT record value a DNS verification looks for
+func (v *DomainVerification) TXTRecordValue() string {
+	return DomainVerificationTXTPrefix + v.Token
+}
+
+// DomainVerificationChallenge tells the company how to prove it owns its domain
+type DomainVerificationChallenge struct {
+	Domain         string     `json:"domain"`
+	Method         string     `json:"method"`
+	SentTo         string     `json:"sent_to,omitempty"`          // Email method: where the code was sent
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`       // Email method: when the code expires
+	TXTRecordName  string     `json:"txt_record_name,omitempty"`  // DNS method: the host of the record
+	TXTRecordValue string     `json:"txt_record_value,omitempty"` // DNS method: the value to publish
+}
+
+// CompanySuggestion is a company the user may ask to join because their verified email is on
+// the company's verified domain
+type CompanySuggestion struct {
+	CompanyID     int64   `json:"company_id"`
+	CompanyName   string  `json:"company_name"`
+	Slug          string  `json:"slug"`
+	LogoURL       *string `json:"logo_url,omitempty"`
+	Domain        string  `json:"domain"`
+	Role          string  `json:"role"`           // Role the user gets once approved
+	JoinRequested bool    `json:"join_requested"` // The user already asked to join
+}
+
+// JoinRequest is a pending domain join request shown next to the company's pending invitations
+type JoinRequest struct {
+	EmployerUserID int64     `gorm:"column:employer_user_id" json:"employer_user_id"`
+	UserID         int64     `gorm:"column:user_id" json:"user_id"`
+	FullName       string    `gorm:"column:full_name" json:"full_name"`
+	Email          string    `gorm:"column:email" json:"email"`
+	Role           string    `gorm:"column:role" json:"role"`
+	RequestedAt    time.Time `gorm:"column:requested_at" json:"requested_at"`
+}
+
+// DomainRepository stores domain verifications and finds the companies users may join by domain
+type DomainRepository interface {
+	FindDomainVerification(ctx context.Context, companyID int64) (*DomainVerification, error)
+	// SaveDomainVerification creates or replaces the company's domain verification
+	SaveDomainVerification(ctx context.Context, verification *DomainVerification) error
+	// FindAutoJoinCompanies lists the live companies on domain that verified it and enabled auto-join
+	FindAutoJoinCompanies(ctx context.Context, domain string) ([]Company, error)
+	// ListJoinRequests lists the company's pending join requests, oldest first
+	ListJoinRequests(ctx context.Context, companyID int64) ([]JoinRequest, error)
+}
+
+// DomainService verifies company email domains and lets colleagues on a verified domain ask
+// to join the company
+type DomainService interface {
+	// StartDomainVerification claims the company's email domain and emails a code to
+	// postmaster@domain or returns the TXT record to publish
+	StartDomainVerification(ctx context.Context, companyID int64, method string) (*DomainVerificationChallenge, error)
+	// VerifyDomain checks the emailed code, or looks up the TXT record for DNS verification
+	VerifyDomain(ctx context.Context, companyID int64, code string) (*DomainVerification, error)
+	// GetDomainVerification returns the company's domain verification, or nil without one
+	GetDomainVerification(ctx context.Context, companyID int64) (*DomainVerification, error)
+
+	// GetCompanySuggestions lists the companies the user may ask to join by their email domain
+	GetCompanySuggestions(ctx context.Context, userID int64) ([]CompanySuggestion, error)
+	// RequestToJoin adds the user to the company with its auto-join role, pending approval
+	RequestToJoin(ctx context.Context, companyID, userID int64) (*EmployerUser, error)
+	// ListJoinRequests lists the company's pending join requests
+	ListJoinRequests(ctx context.Context, companyID int64) ([]JoinRequest, error)
+	// ApproveJoinRequest activates the pending membership
+	ApproveJoinRequest(ctx context.Context, companyID, employerUserID, approvedBy int64) (*EmployerUser, error)
+	// RejectJoinRequest removes the pending membership
+	RejectJoinRequest(ctx context.Context, companyID, employerUserID int64) error
+}
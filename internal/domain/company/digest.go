@@ -0,0 +1,111 @@
+package company
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// WeeklyDigestPeriod is the week of activity a weekly digest summarizes
+	WeeklyDigestPeriod = 7 * 24 * time.Hour
+
+	// WeeklyDigestResendAfter is how long after a digest the company may get the next one;
+	// shorter than a week so the next weekly run is not skipped by a few minutes
+	WeeklyDigestResendAfter = 6 * 24 * time.Hour
+
+	// DigestAwaitingActionAfter is how long an application may stay applied before the
+	// digest reports it as awaiting action
+	DigestAwaitingActionAfter = 3 * 24 * time.Hour
+
+	// DigestLookahead is how far ahead the digest looks for interviews and expiring jobs
+	DigestLookahead = 7 * 24 * time.Hour
+)
+
+// DigestCompany is a company due a weekly digest
+type DigestCompany struct {
+	CompanyID     int64  `gorm:"column:company_id"`
+	CompanyName   string `gorm:"column:company_name"`
+	PublishedJobs int64  `gorm:"column:published_jobs"`
+}
+
+// DigestJobActivity is a job's application activity for the digest
+type DigestJobActivity struct {
+	JobID           int64  `gorm:"column:job_id"`
+	JobTitle        string `gorm:"column:job_title"`
+	NewApplications int64  `gorm:"column:new_applications"` // Applied during the digest week
+	AwaitingAction  int64  `gorm:"column:awaiting_action"`  // Still applied after DigestAwaitingActionAfter
+}
+
+// DigestInterview is an upcoming interview listed in the digest
+type DigestInterview struct {
+	InterviewID   int64     `gorm:"column:interview_id"`
+	ApplicationID int64     `gorm:"column:application_id"`
+	JobTitle      string    `gorm:"column:job_title"`
+	CandidateName string    `gorm:"column:candidate_name"`
+	ScheduledAt   time.Time `gorm:"column:scheduled_at"`
+}
+
+// DigestExpiringJob is a published job that expires soon
+type DigestExpiringJob struct {
+	JobID     int64     `gorm:"column:job_id"`
+	JobTitle  string    `gorm:"column:job_title"`
+	ExpiredAt time.Time `gorm:"column:expired_at"`
+}
+
+// FollowerGrowth counts a company's active followers and how many followed it recently
+type FollowerGrowth struct {
+	NewFollowers   int64 `gorm:"column:new_followers"`
+	TotalFollowers int64 `gorm:"column:total_followers"`
+}
+
+// WeeklyDigest is one company's weekly activity summary for its recruiters
+type WeeklyDigest struct {
+	Company      DigestCompany
+	Jobs         []DigestJobActivity
+	Interviews   []DigestInterview
+	ExpiringJobs []DigestExpiringJob
+	Followers    FollowerGrowth
+}
+
+// AwaitingAction totals the applications awaiting action over every job
+func (d *WeeklyDigest) AwaitingAction() int64 {
+	var total int64
+	for _, j := range d.Jobs {
+		total += j.AwaitingAction
+	}
+	return total
+}
+
+// HasActivity checks if anything happened or is coming up that the digest would report
+func (d *WeeklyDigest) HasActivity() bool {
+	return len(d.Jobs) > 0 || len(d.Interviews) > 0 || len(d.ExpiringJobs) > 0 || d.Followers.NewFollowers > 0
+}
+
+// ShouldSend checks if the digest is worth sending: companies with no activity and no
+// published jobs are skipped
+func (d *WeeklyDigest) ShouldSend() bool {
+	return d.HasActivity() || d.Company.PublishedJobs > 0
+}
+
+// DigestRepository aggregates the activity of the weekly employer digest
+type DigestRepository interface {
+	// FindWeeklyDigestCompanies lists the companies that have not opted out of the digest and
+	// were last sent one before sentBefore, or never
+	FindWeeklyDigestCompanies(ctx context.Context, sentBefore time.Time) ([]DigestCompany, error)
+
+	// GetDigestJobActivity counts per job the applications made since and those still applied
+	// since before awaitingBefore; jobs with neither are left out
+	GetDigestJobActivity(ctx context.Context, companyID int64, since, awaitingBefore time.Time) ([]DigestJobActivity, error)
+
+	// GetDigestInterviews lists the company's scheduled interviews between from and to, soonest first
+	GetDigestInterviews(ctx context.Context, companyID int64, from, to time.Time) ([]DigestInterview, error)
+
+	// GetDigestExpiringJobs lists the company's published jobs expiring between from and to, soonest first
+	GetDigestExpiringJobs(ctx context.Context, companyID int64, from, to time.Time) ([]DigestExpiringJob, error)
+
+	// GetFollowerGrowth counts the company's active followers and those who followed it since
+	GetFollowerGrowth(ctx context.Context, companyID int64, since time.Time) (*FollowerGrowth, error)
+
+	// MarkWeeklyDigestSent records when the company was last sent the digest
+	MarkWeeklyDigestSent(ctx context.Context, companyID int64, at time.Time) error
+}
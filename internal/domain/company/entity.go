@@ -6,8 +6,11 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
+	"gorm.io/gorm"
 
+	"keerja-backend/internal/domain/employer"
 	"keerja-backend/internal/domain/master"
+	"keerja-backend/internal/domain/upload"
 )
 
 // Company represents the main company entity
@@ -62,8 +65,16 @@ type Company struct {
 	VerifiedAt *time.Time     `gorm:"type:timestamp" json:"verified_at,omitempty"`
 	VerifiedBy *int64         `gorm:"type:bigint" json:"verified_by,omitempty"`
 	IsActive   bool           `gorm:"default:true" json:"is_active"`
+	Plan       *string        `gorm:"type:varchar(20)" json:"plan,omitempty"` // See EffectivePlan
+	Version    int64          `gorm:"not null;default:1" json:"version"`      // Incremented by every edit; updates require the loaded version
 	CreatedAt  time.Time      `gorm:"type:timestamp;default:now()" json:"created_at"`
 	UpdatedAt  time.Time      `gorm:"type:timestamp;default:now()" json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"` // Soft delete; queries through the model skip deleted companies
+
+	// Resized copies of the logo and banner by variant key (e.g. 200_webp); LogoURL and
+	// BannerURL keep pointing at the originals
+	LogoVariants   upload.ImageVariants `gorm:"type:jsonb;default:'{}'" json:"logo_variants,omitempty"`
+	BannerVariants upload.ImageVariants `gorm:"type:jsonb;default:'{}'" json:"banner_variants,omitempty"`
 
 	// Master Data Relationships
 	IndustryRelation    *master.Industry    `gorm:"foreignKey:IndustryID;references:ID" json:"industry_relation,omitempty"`
@@ -233,6 +244,7 @@ type CompanyProfile struct {
 	Verified         bool       `gorm:"default:false" json:"verified"`
 	VerifiedAt       *time.Time `gorm:"type:timestamp" json:"verified_at,omitempty"`
 	VerifiedBy       *int64     `gorm:"type:bigint" json:"verified_by,omitempty"`
+	Version          int64      `gorm:"not null;default:1" json:"version"` // Incremented by every edit; updates require the loaded version
 	CreatedAt        time.Time  `gorm:"type:timestamp;default:now()" json:"created_at"`
 	UpdatedAt        time.Time  `gorm:"type:timestamp;default:now()" json:"updated_at"`
 
@@ -293,30 +305,39 @@ func (CompanyFollower) TableName() string {
 
 // CompanyReview represents company reviews from employees/ex-employees
 type CompanyReview struct {
-	ID                 int64      `gorm:"primaryKey;autoIncrement" json:"id"`
-	CompanyID          int64      `gorm:"not null;index" json:"company_id"`
-	UserID             *int64     `gorm:"type:bigint" json:"user_id,omitempty"`
-	ReviewerType       *string    `gorm:"type:varchar(30);check:reviewer_type IN ('employee','ex-employee','applicant')" json:"reviewer_type,omitempty" validate:"omitempty,oneof=employee ex-employee applicant"`
-	PositionTitle      *string    `gorm:"type:varchar(100)" json:"position_title,omitempty"`
-	EmploymentPeriod   *string    `gorm:"type:varchar(50)" json:"employment_period,omitempty"`
-	RatingOverall      *float64   `gorm:"type:numeric(2,1);check:rating_overall >= 0 AND rating_overall <= 5" json:"rating_overall,omitempty" validate:"omitempty,min=0,max=5"`
-	RatingCulture      *float64   `gorm:"type:numeric(2,1)" json:"rating_culture,omitempty" validate:"omitempty,min=0,max=5"`
-	RatingWorkLife     *float64   `gorm:"type:numeric(2,1)" json:"rating_worklife,omitempty" validate:"omitempty,min=0,max=5"`
-	RatingSalary       *float64   `gorm:"type:numeric(2,1)" json:"rating_salary,omitempty" validate:"omitempty,min=0,max=5"`
-	RatingManagement   *float64   `gorm:"type:numeric(2,1)" json:"rating_management,omitempty" validate:"omitempty,min=0,max=5"`
-	Pros               *string    `gorm:"type:text" json:"pros,omitempty"`
-	Cons               *string    `gorm:"type:text" json:"cons,omitempty"`
-	AdviceToManagement *string    `gorm:"type:text" json:"advice_to_management,omitempty"`
-	IsAnonymous        bool       `gorm:"default:true" json:"is_anonymous"`
-	RecommendToFriend  bool       `gorm:"default:true" json:"recommend_to_friend"`
+	ID                 int64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	CompanyID          int64    `gorm:"not null;index" json:"company_id"`
+	UserID             *int64   `gorm:"type:bigint" json:"user_id,omitempty"`
+	ReviewerType       *string  `gorm:"type:varchar(30);check:reviewer_type IN ('employee','ex-employee','applicant')" json:"reviewer_type,omitempty" validate:"omitempty,oneof=employee ex-employee applicant"`
+	PositionTitle      *string  `gorm:"type:varchar(100)" json:"position_title,omitempty"`
+	EmploymentPeriod   *string  `gorm:"type:varchar(50)" json:"employment_period,omitempty"`
+	RatingOverall      *float64 `gorm:"type:numeric(2,1);check:rating_overall >= 0 AND rating_overall <= 5" json:"rating_overall,omitempty" validate:"omitempty,min=0,max=5"`
+	RatingCulture      *float64 `gorm:"type:numeric(2,1)" json:"rating_culture,omitempty" validate:"omitempty,min=0,max=5"`
+	RatingWorkLife     *float64 `gorm:"type:numeric(2,1)" json:"rating_worklife,omitempty" validate:"omitempty,min=0,max=5"`
+	RatingSalary       *float64 `gorm:"type:numeric(2,1)" json:"rating_salary,omitempty" validate:"omitempty,min=0,max=5"`
+	RatingManagement   *float64 `gorm:"type:numeric(2,1)" json:"rating_management,omitempty" validate:"omitempty,min=0,max=5"`
+	Pros               *string  `gorm:"type:text" json:"pros,omitempty"`
+	Cons               *string  `gorm:"type:text" json:"cons,omitempty"`
+	AdviceToManagement *string  `gorm:"type:text" json:"advice_to_management,omitempty"`
+	IsAnonymous        bool     `gorm:"default:true" json:"is_anonymous"`
+	RecommendToFriend  bool     `gorm:"default:true" json:"recommend_to_friend"`
+	// IsVerifiedReviewer is set when the reviewer was an employee of or hired by the company
+	IsVerifiedReviewer bool       `gorm:"not null;default:false" json:"is_verified_reviewer"`
 	Status             string     `gorm:"type:varchar(20);default:'pending';check:status IN ('pending','approved','rejected','hidden')" json:"status"`
 	ModeratedBy        *int64     `gorm:"type:bigint" json:"moderated_by,omitempty"`
 	ModeratedAt        *time.Time `gorm:"type:timestamp" json:"moderated_at,omitempty"`
-	CreatedAt          time.Time  `gorm:"type:timestamp;default:now()" json:"created_at"`
-	UpdatedAt          time.Time  `gorm:"type:timestamp;default:now()" json:"updated_at"`
+	// Vote counts are maintained by the repository from review_votes and never written through Save
+	HelpfulCount    int32     `gorm:"<-:false;default:0" json:"helpful_count"`
+	NotHelpfulCount int32     `gorm:"<-:false;default:0" json:"not_helpful_count"`
+	CreatedAt       time.Time `gorm:"type:timestamp;default:now()" json:"created_at"`
+	UpdatedAt       time.Time `gorm:"type:timestamp;default:now()" json:"updated_at"`
+
+	// MyVote is the requesting user's vote, filled in when listing for an authenticated viewer
+	MyVote *string `gorm:"-" json:"my_vote,omitempty"`
 
 	// Relationships
-	Company *Company `gorm:"foreignKey:CompanyID" json:"-"`
+	Company  *Company        `gorm:"foreignKey:CompanyID" json:"-"`
+	Response *ReviewResponse `gorm:"foreignKey:ReviewID" json:"response,omitempty"`
 }
 
 // TableName specifies the table name for CompanyReview
@@ -326,7 +347,85 @@ func (CompanyReview) TableName() string {
 
 // IsApproved checks if review is approved
 func (cr *CompanyReview) IsApproved() bool {
-	return cr.Status == "approved"
+	return cr.Status == ReviewStatusApproved
+}
+
+// IsHidden checks if review is hidden from public listings
+func (cr *CompanyReview) IsHidden() bool {
+	return cr.Status == ReviewStatusHidden
+}
+
+// CompanyRatingSummary holds a company's precomputed average ratings over its approved
+// reviews. It is refreshed whenever the company's reviews change, so reads never
+// aggregate company_reviews.
+type CompanyRatingSummary struct {
+	CompanyID    int64     `gorm:"primaryKey" json:"company_id"`
+	Overall      float64   `gorm:"type:numeric(3,2);not null;default:0" json:"overall"`
+	Culture      float64   `gorm:"type:numeric(3,2);not null;default:0" json:"culture"`
+	WorkLife     float64   `gorm:"type:numeric(3,2);not null;default:0" json:"work_life"`
+	Salary       float64   `gorm:"type:numeric(3,2);not null;default:0" json:"salary"`
+	Management   float64   `gorm:"type:numeric(3,2);not null;default:0" json:"management"`
+	TotalReviews int64     `gorm:"not null;default:0" json:"total_reviews"`
+	UpdatedAt    time.Time `gorm:"type:timestamp;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for CompanyRatingSummary
+func (CompanyRatingSummary) TableName() string {
+	return "company_rating_summaries"
+}
+
+// Review vote values
+const (
+	ReviewVoteHelpful    = "helpful"
+	ReviewVoteNotHelpful = "not_helpful"
+)
+
+// ReviewVote represents a user's helpful/not helpful vote on a review
+type ReviewVote struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	ReviewID  int64     `gorm:"not null;uniqueIndex:review_votes_review_id_user_id_key" json:"review_id"`
+	UserID    int64     `gorm:"not null;uniqueIndex:review_votes_review_id_user_id_key" json:"user_id"`
+	Vote      string    `gorm:"type:varchar(20);not null;check:vote IN ('helpful','not_helpful')" json:"vote" validate:"required,oneof=helpful not_helpful"`
+	CreatedAt time.Time `gorm:"type:timestamp;default:now()" json:"created_at"`
+	UpdatedAt time.Time `gorm:"type:timestamp;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for ReviewVote
+func (ReviewVote) TableName() string {
+	return "review_votes"
+}
+
+// ReviewReport represents an abuse report filed against a review
+type ReviewReport struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	ReviewID  int64     `gorm:"not null;uniqueIndex:review_reports_review_id_user_id_key" json:"review_id"`
+	UserID    int64     `gorm:"not null;uniqueIndex:review_reports_review_id_user_id_key" json:"user_id"`
+	Reason    string    `gorm:"type:text;not null" json:"reason" validate:"required"`
+	Status    string    `gorm:"type:varchar(20);default:'open';check:status IN ('open','resolved','dismissed')" json:"status"`
+	CreatedAt time.Time `gorm:"type:timestamp;default:now()" json:"created_at"`
+	UpdatedAt time.Time `gorm:"type:timestamp;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for ReviewReport
+func (ReviewReport) TableName() string {
+	return "review_reports"
+}
+
+// ReviewResponse is a company's public reply to a review. A review has at most one response.
+type ReviewResponse struct {
+	ID                      int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ReviewID                int64      `gorm:"not null;uniqueIndex:review_responses_review_id_key" json:"review_id"`
+	CompanyID               int64      `gorm:"not null;index" json:"company_id"`
+	ResponderEmployerUserID *int64     `gorm:"type:bigint" json:"responder_employer_user_id,omitempty"`
+	Body                    string     `gorm:"type:text;not null" json:"body"`
+	EditedAt                *time.Time `gorm:"type:timestamp" json:"edited_at,omitempty"`
+	CreatedAt               time.Time  `gorm:"type:timestamp;default:now()" json:"created_at"`
+	UpdatedAt               time.Time  `gorm:"type:timestamp;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for ReviewResponse
+func (ReviewResponse) TableName() string {
+	return "review_responses"
 }
 
 // CompanyDocument represents company legal documents
@@ -337,7 +436,7 @@ type CompanyDocument struct {
 	DocumentType    string     `gorm:"type:varchar(50);not null;uniqueIndex:idx_company_doc_type_num;check:document_type IN ('SIUP','NPWP','NIB','AKTA','TDP','ISO','SERTIFIKAT','LAINNYA')" json:"document_type" validate:"required,oneof=SIUP NPWP NIB AKTA TDP ISO SERTIFIKAT LAINNYA"`
 	DocumentNumber  *string    `gorm:"type:varchar(100);uniqueIndex:idx_company_doc_type_num" json:"document_number,omitempty"`
 	DocumentName    *string    `gorm:"type:varchar(150)" json:"document_name,omitempty"`
-	FilePath        string     `gorm:"type:text;not null" json:"file_path" validate:"required"`
+	FilePath        string     `gorm:"type:text;not null" json:"-" validate:"required"` // Private; downloaded through a signed link
 	IssueDate       *time.Time `gorm:"type:date" json:"issue_date,omitempty"`
 	ExpiryDate      *time.Time `gorm:"type:date" json:"expiry_date,omitempty"`
 	Status          string     `gorm:"type:varchar(20);default:'pending';check:status IN ('pending','approved','rejected','expired')" json:"status"`
@@ -376,6 +475,7 @@ type CompanyEmployee struct {
 	CompanyID        int64      `gorm:"not null;index" json:"company_id"`
 	UserID           *int64     `gorm:"type:bigint" json:"user_id,omitempty"`
 	FullName         *string    `gorm:"type:varchar(150)" json:"full_name,omitempty"`
+	Email            *string    `gorm:"type:varchar(150)" json:"email,omitempty"`
 	JobTitle         *string    `gorm:"type:varchar(100)" json:"job_title,omitempty"`
 	Department       *string    `gorm:"type:varchar(100)" json:"department,omitempty"`
 	EmploymentType   string     `gorm:"type:varchar(30);default:'permanent';check:employment_type IN ('permanent','contract','intern','freelance')" json:"employment_type"`
@@ -414,16 +514,18 @@ type CompanyVerification struct {
 	RequestedBy        *int64     `gorm:"type:bigint" json:"requested_by,omitempty"`
 	ReviewedBy         *int64     `gorm:"type:bigint" json:"reviewed_by,omitempty"`
 	ReviewedAt         *time.Time `gorm:"type:timestamp" json:"reviewed_at,omitempty"`
-	Status             string     `gorm:"type:varchar(20);default:'pending';check:status IN ('pending','under_review','verified','rejected','blacklisted','expired')" json:"status"`
+	Status             string     `gorm:"type:varchar(20);default:'pending';check:status IN ('pending','under_review','verified','rejected','suspended','blacklisted','expired')" json:"status"`
 	NPWPNumber         string     `gorm:"type:varchar(50);not null" json:"npwp_number" validate:"required"`
 	NIBNumber          *string    `gorm:"type:varchar(50)" json:"nib_number,omitempty"`
 	VerificationScore  float64    `gorm:"type:numeric(5,2);default:0.00" json:"verification_score"`
 	VerificationNotes  *string    `gorm:"type:text" json:"verification_notes,omitempty"`
 	RejectionReason    *string    `gorm:"type:text" json:"rejection_reason,omitempty"`
 	VerificationExpiry *time.Time `gorm:"type:date" json:"verification_expiry,omitempty"`
+	GraceUntil         *time.Time `gorm:"type:timestamp" json:"grace_until,omitempty"` // Set once the expiry has passed; the company stays verified until then
 	BadgeGranted       bool       `gorm:"default:false" json:"badge_granted"`
 	AutoExpired        bool       `gorm:"default:false" json:"auto_expired"`
 	LastChecked        *time.Time `gorm:"type:timestamp" json:"last_checked,omitempty"`
+	ExpiryWarningDays  *int       `gorm:"type:smallint" json:"-"` // Smallest days-before-expiry threshold already warned about
 	CreatedAt          time.Time  `gorm:"type:timestamp;default:now()" json:"created_at"`
 	UpdatedAt          time.Time  `gorm:"type:timestamp;default:now()" json:"updated_at"`
 
@@ -438,7 +540,7 @@ func (CompanyVerification) TableName() string {
 
 // IsVerified checks if verification is approved
 func (cv *CompanyVerification) IsVerified() bool {
-	return cv.Status == "verified"
+	return cv.Status == VerificationStatusVerified
 }
 
 // IsExpired checks if verification is expired
@@ -449,23 +551,32 @@ func (cv *CompanyVerification) IsExpired() bool {
 	return cv.VerificationExpiry.Before(time.Now())
 }
 
+// InGracePeriod reports whether the verification has passed its expiry but is still
+// honoured until GraceUntil. Clients show a renewal banner while this is true.
+func (cv *CompanyVerification) InGracePeriod() bool {
+	return cv.IsVerified() && cv.GraceUntil != nil && cv.GraceUntil.After(time.Now())
+}
+
 // EmployerUser represents users with employer privileges
 type EmployerUser struct {
-	ID            int64      `gorm:"primaryKey;autoIncrement" json:"id"`
-	UserID        int64      `gorm:"not null;uniqueIndex:idx_user_company" json:"user_id"`
-	CompanyID     int64      `gorm:"not null;uniqueIndex:idx_user_company" json:"company_id"`
-	Role          string     `gorm:"type:varchar(30);default:'recruiter';check:role IN ('owner','admin','recruiter','viewer')" json:"role" validate:"oneof=owner admin recruiter viewer"`
-	PositionTitle *string    `gorm:"type:varchar(100)" json:"position_title,omitempty"`
-	Department    *string    `gorm:"type:varchar(100)" json:"department,omitempty"`
-	EmailCompany  *string    `gorm:"type:varchar(150)" json:"email_company,omitempty" validate:"omitempty,email"`
-	PhoneCompany  *string    `gorm:"type:varchar(30)" json:"phone_company,omitempty"`
-	IsVerified    bool       `gorm:"default:false" json:"is_verified"`
-	VerifiedAt    *time.Time `gorm:"type:timestamp" json:"verified_at,omitempty"`
-	VerifiedBy    *int64     `gorm:"type:bigint" json:"verified_by,omitempty"`
-	IsActive      bool       `gorm:"default:true" json:"is_active"`
-	LastLogin     *time.Time `gorm:"type:timestamp" json:"last_login,omitempty"`
-	CreatedAt     time.Time  `gorm:"type:timestamp;default:now()" json:"created_at"`
-	UpdatedAt     time.Time  `gorm:"type:timestamp;default:now()" json:"updated_at"`
+	ID              int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID          int64      `gorm:"not null;uniqueIndex:idx_user_company" json:"user_id"`
+	CompanyID       int64      `gorm:"not null;uniqueIndex:idx_user_company" json:"company_id"`
+	Role            string     `gorm:"type:varchar(30);default:'recruiter';check:role IN ('owner','admin','recruiter','viewer')" json:"role" validate:"oneof=owner admin recruiter viewer"`
+	PositionTitle   *string    `gorm:"type:varchar(100)" json:"position_title,omitempty"`
+	Department      *string    `gorm:"type:varchar(100)" json:"department,omitempty"`
+	EmailCompany    *string    `gorm:"type:varchar(150)" json:"email_company,omitempty" validate:"omitempty,email"`
+	PhoneCompany    *string    `gorm:"type:varchar(30)" json:"phone_company,omitempty"`
+	IsVerified      bool       `gorm:"default:false" json:"is_verified"`
+	VerifiedAt      *time.Time `gorm:"type:timestamp" json:"verified_at,omitempty"`
+	VerifiedBy      *int64     `gorm:"type:bigint" json:"verified_by,omitempty"`
+	IsActive        bool       `gorm:"default:true" json:"is_active"`
+	LastLogin       *time.Time `gorm:"type:timestamp" json:"last_login,omitempty"`
+	ActivatedAt     *time.Time `gorm:"type:timestamp" json:"activated_at,omitempty"`      // Last time the user chose this company as their active company
+	JoinRequestedAt *time.Time `gorm:"type:timestamp" json:"join_requested_at,omitempty"` // Set while a domain join request waits for approval
+	Version         int64      `gorm:"not null;default:1" json:"version"`                 // Incremented by every edit; updates require the loaded version
+	CreatedAt       time.Time  `gorm:"type:timestamp;default:now()" json:"created_at"`
+	UpdatedAt       time.Time  `gorm:"type:timestamp;default:now()" json:"updated_at"`
 
 	// Relationships
 	Company *Company `gorm:"foreignKey:CompanyID" json:"-"`
@@ -478,17 +589,17 @@ func (EmployerUser) TableName() string {
 
 // IsOwner checks if user is company owner
 func (eu *EmployerUser) IsOwner() bool {
-	return eu.Role == "owner"
+	return eu.Role == employer.RoleOwner
 }
 
 // IsAdmin checks if user is company admin
 func (eu *EmployerUser) IsAdmin() bool {
-	return eu.Role == "admin" || eu.Role == "owner"
+	return eu.Role == employer.RoleAdmin || eu.Role == employer.RoleOwner
 }
 
 // CanManageJobs checks if user can manage jobs
 func (eu *EmployerUser) CanManageJobs() bool {
-	return eu.Role == "owner" || eu.Role == "admin" || eu.Role == "recruiter"
+	return eu.Role == employer.RoleOwner || eu.Role == employer.RoleAdmin || eu.Role == employer.RoleRecruiter
 }
 
 // CompanyInvitation represents company employee invitation
@@ -524,7 +635,7 @@ func (ci *CompanyInvitation) IsExpired() bool {
 
 // IsPending checks if invitation is pending
 func (ci *CompanyInvitation) IsPending() bool {
-	return ci.Status == "pending"
+	return ci.Status == InvitationStatusPending
 }
 
 // CompanyAddress represents a persistent address record for a company
@@ -543,6 +654,10 @@ type CompanyAddress struct {
 	UpdatedAt   time.Time  `gorm:"type:timestamp;default:now()" json:"updated_at"`
 	DeletedAt   *time.Time `gorm:"index" json:"deleted_at,omitempty"`
 
+	// Set when the coordinates were filled by geocoding rather than supplied by the client
+	GeocodeProvider   *string  `gorm:"type:varchar(20)" json:"geocode_provider,omitempty"`
+	GeocodeConfidence *float64 `gorm:"type:numeric(4,3)" json:"geocode_confidence,omitempty"`
+
 	// Relationships
 	Company  *Company         `gorm:"foreignKey:CompanyID" json:"-"`
 	Province *master.Province `gorm:"foreignKey:ProvinceID;references:ID;constraint:OnDelete:SET NULL" json:"province,omitempty"`
@@ -554,7 +669,88 @@ func (CompanyAddress) TableName() string {
 	return "company_addresses"
 }
 
+// DefaultStageReminderDays is how long an application may sit in a stage before
+// recruiters are reminded, for companies without their own setting
+const DefaultStageReminderDays = 7
+
+// CompanySettings holds per-company preferences; a missing row means defaults apply
+type CompanySettings struct {
+	CompanyID             int64     `gorm:"primaryKey" json:"company_id"`
+	StageReminderEnabled  bool      `gorm:"not null" json:"stage_reminder_enabled"`
+	StageReminderDays     int       `gorm:"not null;check:stage_reminder_days >= 1 AND stage_reminder_days <= 90" json:"stage_reminder_days" validate:"min=1,max=90"`
+	BlindScreeningEnabled bool      `gorm:"not null;default:false" json:"blind_screening_enabled"`
+	WeeklyDigestEnabled   bool      `gorm:"not null" json:"weekly_digest_enabled"`
+	AutoJoinEnabled       bool      `gorm:"not null;default:false" json:"auto_join_enabled"`
+	AutoJoinRole          string    `gorm:"type:varchar(30);not null;check:auto_join_role IN ('admin','recruiter','viewer')" json:"auto_join_role" validate:"oneof=admin recruiter viewer"`
+	CreatedAt             time.Time `gorm:"type:timestamp;default:now()" json:"created_at"`
+	UpdatedAt             time.Time `gorm:"type:timestamp;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for CompanySettings
+func (CompanySettings) TableName() string {
+	return "company_settings"
+}
+
+// DefaultCompanySettings returns the settings used when a company has not saved any
+func DefaultCompanySettings(companyID int64) *CompanySettings {
+	return &CompanySettings{
+		CompanyID:            companyID,
+		StageReminderEnabled: true,
+		StageReminderDays:    DefaultStageReminderDays,
+		WeeklyDigestEnabled:  true,
+		AutoJoinRole:         DefaultAutoJoinRole,
+	}
+}
+
+// Change request statuses
+const (
+	ChangeRequestPending  = "pending"
+	ChangeRequestApproved = "approved"
+	ChangeRequestRejected = "rejected"
+)
+
+// CompanyChangeRequest asks an admin to change company data employers cannot edit
+// themselves: industry, company size and district. Nil fields stay unchanged.
+type CompanyChangeRequest struct {
+	ID            int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	CompanyID     int64      `gorm:"not null;index" json:"company_id"`
+	RequestedBy   int64      `gorm:"not null" json:"requested_by"`
+	IndustryID    *int64     `json:"industry_id,omitempty"`
+	CompanySizeID *int64     `json:"company_size_id,omitempty"`
+	DistrictID    *int64     `json:"district_id,omitempty"`
+	Reason        string     `gorm:"type:text;not null" json:"reason"`
+	Status        string     `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	DecidedBy     *int64     `json:"decided_by,omitempty"`
+	DecidedAt     *time.Time `gorm:"type:timestamp" json:"decided_at,omitempty"`
+	DecisionNote  *string    `gorm:"type:text" json:"decision_note,omitempty"`
+	CreatedAt     time.Time  `gorm:"type:timestamp;default:now()" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"type:timestamp;default:now()" json:"updated_at"`
+
+	// Relationships
+	Company *Company `gorm:"foreignKey:CompanyID" json:"-"`
+}
+
+// TableName specifies the table name for CompanyChangeRequest
+func (CompanyChangeRequest) TableName() string {
+	return "company_change_requests"
+}
+
+// IsPending checks if the change request still awaits a decision
+func (r *CompanyChangeRequest) IsPending() bool {
+	return r.Status == ChangeRequestPending
+}
+
+// CompanyMasterDataChange is the set of company columns an approved change request writes.
+// City and province are derived from the district.
+type CompanyMasterDataChange struct {
+	IndustryID    *int64
+	CompanySizeID *int64
+	DistrictID    *int64
+	CityID        *int64
+	ProvinceID    *int64
+}
+
 // IsAccepted checks if invitation is accepted
 func (ci *CompanyInvitation) IsAccepted() bool {
-	return ci.Status == "accepted"
+	return ci.Status == InvitationStatusAccepted
 }
@@ -0,0 +1,182 @@
+package company
+
+import "keerja-backend/internal/apperror"
+
+var (
+	// ErrCompanyNotFound is returned when the requested company does not exist
+	ErrCompanyNotFound = apperror.NotFound("COMPANY_NOT_FOUND", "company not found")
+
+	// ErrCompanyNotDeleted is returned when restoring a company that has not been deleted
+	ErrCompanyNotDeleted = apperror.Conflict("COMPANY_NOT_DELETED", "company is not deleted")
+
+	// ErrCompanySlugTaken is returned when restoring a company whose slug another company now uses
+	ErrCompanySlugTaken = apperror.Conflict("COMPANY_SLUG_TAKEN", "another company now uses this company's slug")
+
+	// ErrCompanyHasActiveJobs is returned when deleting a company with published jobs without force
+	ErrCompanyHasActiveJobs = apperror.Conflict("COMPANY_HAS_ACTIVE_JOBS", "company has active jobs")
+
+	// ErrNotCompanyEmployer is returned when the user is not an active employer of the company
+	ErrNotCompanyEmployer = apperror.Forbidden("NOT_COMPANY_EMPLOYER", "you are not an employer of this company")
+
+	// ErrInsufficientCompanyRole is returned when the user's company role is too low for the action
+	ErrInsufficientCompanyRole = apperror.Forbidden("INSUFFICIENT_COMPANY_ROLE", "your company role does not permit this action")
+
+	// ErrNoEmployerCompany is returned when an employer route is used by a user who is not an
+	// active employer of any company
+	ErrNoEmployerCompany = apperror.Validation("NO_EMPLOYER_COMPANY", "you are not an employer of any company")
+
+	// ErrActiveCompanyRequired is returned when a user who belongs to several companies has not
+	// said which one the request is for
+	ErrActiveCompanyRequired = apperror.Validation("ACTIVE_COMPANY_REQUIRED", "choose the company to act for with the X-Company-ID header or by activating it")
+
+	// ErrDocumentNotFound is returned when the document does not exist or belongs to another company
+	ErrDocumentNotFound = apperror.NotFound("DOCUMENT_NOT_FOUND", "document not found")
+
+	// ErrDocumentAccessDenied is returned when a user who is neither a platform admin nor a company
+	// owner or admin asks for a company document
+	ErrDocumentAccessDenied = apperror.Forbidden("DOCUMENT_ACCESS_DENIED", "only company owners and admins can download company documents")
+
+	// ErrReviewNotFound is returned when the review does not exist
+	ErrReviewNotFound = apperror.NotFound("REVIEW_NOT_FOUND", "review not found")
+
+	// ErrNotReviewAuthor is returned when a user edits or deletes a review they did not write
+	ErrNotReviewAuthor = apperror.Forbidden("NOT_REVIEW_AUTHOR", "you can only modify your own review")
+
+	// ErrReviewAlreadyExists is returned when the user already reviewed the company
+	ErrReviewAlreadyExists = apperror.Conflict("REVIEW_ALREADY_EXISTS", "you have already reviewed this company; update your existing review instead")
+
+	// ErrReviewRateLimited is returned when the user already submitted the daily maximum of reviews
+	ErrReviewRateLimited = apperror.RateLimited("REVIEW_RATE_LIMITED", "you can submit at most 3 reviews per day")
+
+	// ErrAlreadyFollowing is returned when the user already follows the company
+	ErrAlreadyFollowing = apperror.Conflict("ALREADY_FOLLOWING", "already following this company")
+
+	// ErrInvalidReviewVote is returned when the vote is neither helpful nor not_helpful
+	ErrInvalidReviewVote = apperror.Validation("INVALID_REVIEW_VOTE", "vote must be helpful or not_helpful").WithField("vote", "must be helpful or not_helpful")
+
+	// ErrOwnReview is returned when a user votes on or reports their own review
+	ErrOwnReview = apperror.Forbidden("OWN_REVIEW", "you cannot vote on or report your own review")
+
+	// ErrReviewNotPublic is returned when acting on a review that is not approved
+	// Reported as not found so hidden and pending reviews are not disclosed
+	ErrReviewNotPublic = apperror.NotFound("REVIEW_NOT_PUBLIC", "review is not publicly visible")
+
+	// ErrReviewAlreadyReported is returned when the user already reported the review
+	ErrReviewAlreadyReported = apperror.Conflict("REVIEW_ALREADY_REPORTED", "you have already reported this review")
+
+	// ErrReviewResponseExists is returned when the review already has a company response
+	ErrReviewResponseExists = apperror.Conflict("REVIEW_RESPONSE_EXISTS", "this review already has a response; update it instead")
+
+	// ErrReviewResponseNotFound is returned when the review has no company response
+	ErrReviewResponseNotFound = apperror.NotFound("REVIEW_RESPONSE_NOT_FOUND", "review response not found")
+
+	// ErrReviewResponseForbidden is returned when a user who is not an active owner or admin of the
+	// reviewed company responds to a review
+	ErrReviewResponseForbidden = apperror.Forbidden("REVIEW_RESPONSE_FORBIDDEN", "only company owners and admins can respond to reviews")
+
+	// ErrInvalidReviewResponse is returned when a response is empty or longer than 2000 characters
+	ErrInvalidReviewResponse = apperror.Validation("INVALID_REVIEW_RESPONSE", "response must be between 1 and 2000 characters").WithField("body", "must be between 1 and 2000 characters")
+
+	// ErrInvalidReviewStatus is returned when filtering the moderation queue by an unknown status
+	ErrInvalidReviewStatus = apperror.Validation("INVALID_REVIEW_STATUS", "status must be pending, approved, rejected or hidden").WithField("status", "must be pending, approved, rejected or hidden")
+
+	// ErrInvalidVerificationTransition is returned when a verification cannot move from its status to the requested one
+	ErrInvalidVerificationTransition = apperror.Conflict("INVALID_VERIFICATION_STATUS_TRANSITION", "verification cannot move to that status from its current status")
+
+	// ErrChangeRequestNotFound is returned when the change request does not exist
+	ErrChangeRequestNotFound = apperror.NotFound("CHANGE_REQUEST_NOT_FOUND", "change request not found")
+
+	// ErrChangeRequestPending is returned when the company already has a change request awaiting a decision
+	ErrChangeRequestPending = apperror.Conflict("CHANGE_REQUEST_PENDING", "the company already has a pending change request")
+
+	// ErrChangeRequestDecided is returned when approving or rejecting a request that is no longer pending
+	ErrChangeRequestDecided = apperror.Conflict("CHANGE_REQUEST_DECIDED", "change request has already been decided")
+
+	// ErrChangeRequestEmpty is returned when a change request does not ask for any change
+	ErrChangeRequestEmpty = apperror.Validation("CHANGE_REQUEST_EMPTY", "at least one of industry_id, company_size_id or district_id is required")
+
+	// ErrInvalidChangeRequestData is returned when a requested industry, size or district is unknown or inactive
+	ErrInvalidChangeRequestData = apperror.Validation("INVALID_CHANGE_REQUEST_DATA", "requested master data is invalid")
+
+	// ErrInvalidChangeRequestStatus is returned when filtering change requests by an unknown status
+	ErrInvalidChangeRequestStatus = apperror.Validation("INVALID_CHANGE_REQUEST_STATUS", "status must be pending, approved or rejected").WithField("status", "must be pending, approved or rejected")
+
+	// ErrEmployeeImportTooLarge is returned when the employee import file exceeds MaxEmployeeImportFileSize
+	ErrEmployeeImportTooLarge = apperror.Validation("EMPLOYEE_IMPORT_TOO_LARGE", "import file must not exceed 5MB").WithField("file", "must not exceed 5MB")
+
+	// ErrEmployeeImportTooManyRows is returned when the employee import file has more than MaxEmployeeImportRows rows
+	ErrEmployeeImportTooManyRows = apperror.Validation("EMPLOYEE_IMPORT_TOO_MANY_ROWS", "import file must not have more than 5000 rows").WithField("file", "must not have more than 5000 rows")
+
+	// ErrInvitationNotFound is returned when no invitation has the given token
+	ErrInvitationNotFound = apperror.NotFound("INVITATION_NOT_FOUND", "invitation not found")
+
+	// ErrInvitationExpired is returned when the invitation was not accepted before it expired
+	ErrInvitationExpired = apperror.Conflict("INVITATION_EXPIRED", "invitation has expired; ask the company to resend it")
+
+	// ErrInvitationUnavailable is returned when the invitation was already accepted, rejected or canceled
+	ErrInvitationUnavailable = apperror.Conflict("INVITATION_UNAVAILABLE", "invitation is no longer available")
+
+	// ErrInvitationEmailMismatch is returned when signing up with an invitation sent to another email
+	ErrInvitationEmailMismatch = apperror.Validation("INVITATION_EMAIL_MISMATCH", "sign up with the email address the invitation was sent to").WithField("email", "must match the invited email address")
+
+	// ErrAlreadyCompanyEmployer is returned when accepting an invitation to a company the user already belongs to
+	ErrAlreadyCompanyEmployer = apperror.Conflict("ALREADY_COMPANY_EMPLOYER", "you are already an employer for this company")
+
+	// ErrEmployeeImportInvalidFile is returned when the employee import file is not a CSV with a usable header
+	ErrEmployeeImportInvalidFile = apperror.Validation("EMPLOYEE_IMPORT_INVALID_FILE", "import file must be a CSV with a header row")
+
+	// ErrCompanyVersionConflict is returned when the company changed since the editor loaded it
+	ErrCompanyVersionConflict = apperror.Conflict("COMPANY_VERSION_CONFLICT", "the company was changed by someone else; reload it and apply your changes again")
+
+	// ErrProfileVersionConflict is returned when the company profile changed since the editor loaded it
+	ErrProfileVersionConflict = apperror.Conflict("COMPANY_PROFILE_VERSION_CONFLICT", "the company profile was changed by someone else; reload it and apply your changes again")
+
+	// ErrEmployerUserVersionConflict is returned when the employer membership changed since the editor loaded it
+	ErrEmployerUserVersionConflict = apperror.Conflict("EMPLOYER_USER_VERSION_CONFLICT", "the employer profile was changed by someone else; reload it and apply your changes again")
+
+	// ErrJobQuotaExceeded is returned when publishing a job would take the company past its
+	// plan's published job limit
+	ErrJobQuotaExceeded = apperror.Conflict("QUOTA_EXCEEDED", "the company has reached the published job limit of its plan")
+
+	// ErrInvalidPlan is returned for plans that are not one of Plans
+	ErrInvalidPlan = apperror.Validation("INVALID_PLAN", "plan must be free, verified or premium")
+
+	// ErrInvalidPlanLimit is returned for published job limits below one
+	ErrInvalidPlanLimit = apperror.Validation("INVALID_PLAN_LIMIT", "max_published_jobs must be at least 1")
+
+	// ErrEmailDomainNotSet is returned when verifying the domain of a company without an email domain
+	ErrEmailDomainNotSet = apperror.Validation("EMAIL_DOMAIN_NOT_SET", "set the company email domain before verifying it").WithField("email_domain", "is required")
+
+	// ErrFreeEmailDomain is returned when claiming the domain of a public email provider
+	ErrFreeEmailDomain = apperror.Validation("FREE_EMAIL_DOMAIN", "public email provider domains cannot be claimed by a company").WithField("email_domain", "must be a company owned domain")
+
+	// ErrInvalidDomainVerificationMethod is returned for methods other than email and dns
+	ErrInvalidDomainVerificationMethod = apperror.Validation("INVALID_DOMAIN_VERIFICATION_METHOD", "method must be email or dns").WithField("method", "must be email or dns")
+
+	// ErrDomainVerificationNotFound is returned when verifying a domain the company has not claimed
+	ErrDomainVerificationNotFound = apperror.NotFound("DOMAIN_VERIFICATION_NOT_FOUND", "start a domain verification first")
+
+	// ErrDomainAlreadyVerified is returned when claiming a domain the company already verified
+	ErrDomainAlreadyVerified = apperror.Conflict("DOMAIN_ALREADY_VERIFIED", "the company email domain is already verified")
+
+	// ErrDomainVerificationExpired is returned when the emailed code expired or was guessed wrong too often
+	ErrDomainVerificationExpired = apperror.Validation("DOMAIN_VERIFICATION_EXPIRED", "the verification code has expired; request a new one")
+
+	// ErrInvalidDomainVerificationCode is returned when the emailed code does not match
+	ErrInvalidDomainVerificationCode = apperror.Validation("INVALID_DOMAIN_VERIFICATION_CODE", "the verification code is incorrect").WithField("code", "is incorrect")
+
+	// ErrDomainTXTRecordNotFound is returned when the domain does not publish the verification TXT record
+	ErrDomainTXTRecordNotFound = apperror.Validation("DOMAIN_TXT_RECORD_NOT_FOUND", "the verification TXT record was not found on the domain; DNS changes can take a while to appear")
+
+	// ErrAutoJoinUnavailable is returned when the company does not accept join requests from the user's email domain
+	ErrAutoJoinUnavailable = apperror.Forbidden("AUTO_JOIN_UNAVAILABLE", "this company does not accept join requests from your email domain")
+
+	// ErrEmailNotVerified is returned when asking to join a company before verifying the email address
+	ErrEmailNotVerified = apperror.Forbidden("EMAIL_NOT_VERIFIED", "verify your email address before joining a company")
+
+	// ErrJoinRequestPending is returned when the user already asked to join the company
+	ErrJoinRequestPending = apperror.Conflict("JOIN_REQUEST_PENDING", "your request to join this company is waiting for approval")
+
+	// ErrJoinRequestNotFound is returned when the membership is not a pending join request of the company
+	ErrJoinRequestNotFound = apperror.NotFound("JOIN_REQUEST_NOT_FOUND", "join request not found")
+)
@@ -1,5 +1,7 @@
 package company
 
+import "keerja-backend/internal/domain/employer"
+
 // Permission represents a specific action that can be performed
 type Permission string
 
@@ -40,7 +42,7 @@ const (
 
 // RolePermissions maps roles to their allowed permissions
 var RolePermissions = map[string][]Permission{
-	"admin": {
+	employer.RoleAdmin: {
 		// Company Management
 		PermissionUpdateCompany,
 		PermissionDeleteCompany,
@@ -74,7 +76,7 @@ var RolePermissions = map[string][]Permission{
 		PermissionViewAnalytics,
 	},
 
-	"recruiter": {
+	employer.RoleRecruiter: {
 		// Job Management
 		PermissionCreateJob,
 		PermissionUpdateJob,
@@ -99,7 +101,7 @@ var RolePermissions = map[string][]Permission{
 		PermissionViewEmployees,
 	},
 
-	"viewer": {
+	employer.RoleViewer: {
 		// Read-only permissions
 		PermissionViewJobs,
 		PermissionViewApplications,
@@ -165,22 +167,22 @@ func CanManageApplications(role string) bool {
 
 // CanViewOnly checks if role is view-only
 func CanViewOnly(role string) bool {
-	return role == "viewer"
+	return role == employer.RoleViewer
 }
 
 // IsAdmin checks if role is admin
 func IsAdmin(role string) bool {
-	return role == "admin"
+	return role == employer.RoleAdmin
 }
 
 // IsRecruiter checks if role is recruiter
 func IsRecruiter(role string) bool {
-	return role == "recruiter"
+	return role == employer.RoleRecruiter
 }
 
 // ValidateRole checks if a role is valid
 func ValidateRole(role string) bool {
-	validRoles := []string{"admin", "recruiter", "viewer"}
+	validRoles := []string{employer.RoleAdmin, employer.RoleRecruiter, employer.RoleViewer}
 	for _, r := range validRoles {
 		if r == role {
 			return true
@@ -192,10 +194,10 @@ func ValidateRole(role string) bool {
 // GetRoleDescription returns a description for each role
 func GetRoleDescription(role string) string {
 	descriptions := map[string]string{
-		"owner":     "Company owner with full control including ability to delete company and transfer ownership",
-		"admin":     "Full access to all company features including employee management, job postings, applications, and company settings",
-		"recruiter": "Can create and manage job postings, review applications, and respond to reviews. Cannot manage company settings or employees",
-		"viewer":    "Read-only access to company data. Can view jobs, applications, reviews, and statistics but cannot make changes",
+		employer.RoleOwner:     "Company owner with full control including ability to delete company and transfer ownership",
+		employer.RoleAdmin:     "Full access to all company features including employee management, job postings, applications, and company settings",
+		employer.RoleRecruiter: "Can create and manage job postings, review applications, and respond to reviews. Cannot manage company settings or employees",
+		employer.RoleViewer:    "Read-only access to company data. Can view jobs, applications, reviews, and statistics but cannot make changes",
 	}
 
 	if desc, exists := descriptions[role]; exists {
@@ -207,10 +209,10 @@ func GetRoleDescription(role string) string {
 
 // RoleHierarchy defines role hierarchy (higher number = more permissions)
 var RoleHierarchy = map[string]int{
-	"viewer":    1,
-	"recruiter": 2,
-	"admin":     3,
-	"owner":     4, // Highest privilege
+	employer.RoleViewer:    1,
+	employer.RoleRecruiter: 2,
+	employer.RoleAdmin:     3,
+	employer.RoleOwner:     4, // Highest privilege
 }
 
 // HasHigherRole checks if role1 has higher or equal hierarchy than role2
@@ -0,0 +1,103 @@
+package company
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Company plans. A company without a plan of its own is on the verified plan once it is
+// verified and on the free plan before that.
+const (
+	PlanFree     = "free"
+	PlanVerified = "verified"
+	PlanPremium  = "premium"
+)
+
+// Plans lists every company plan, matching the companies_plan_check constraint
+var Plans = []string{PlanFree, PlanVerified, PlanPremium}
+
+// IsValidPlan reports whether plan is one of Plans
+func IsValidPlan(plan string) bool {
+	return slices.Contains(Plans, plan)
+}
+
+// EffectivePlan returns the company's plan, derived from its verification when none is set
+func (c *Company) EffectivePlan() string {
+	if c.Plan != nil && *c.Plan != "" {
+		return *c.Plan
+	}
+	if c.Verified {
+		return PlanVerified
+	}
+	return PlanFree
+}
+
+// QuotaLimits maps each plan to the most jobs a company on it may have published at once
+type QuotaLimits map[string]int
+
+// DefaultQuotaLimits are used for plans missing from the configured limits
+var DefaultQuotaLimits = QuotaLimits{
+	PlanFree:     3,
+	PlanVerified: 10,
+	PlanPremium:  50,
+}
+
+// PlanQuota is an admin's override of a plan's published job limit. It takes precedence over
+// the configured limit and is read on every check, so changes apply without a restart.
+type PlanQuota struct {
+	Plan             string    `gorm:"column:plan;type:varchar(20);primaryKey" json:"plan"`
+	MaxPublishedJobs int       `gorm:"column:max_published_jobs;not null" json:"max_published_jobs"`
+	UpdatedBy        *int64    `gorm:"column:updated_by" json:"updated_by,omitempty"`
+	UpdatedAt        time.Time `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for PlanQuota
+func (PlanQuota) TableName() string {
+	return "company_plan_quotas"
+}
+
+// JobQuota is a company's use of its published job limit. Closed, expired and deleted jobs do
+// not count.
+type JobQuota struct {
+	CompanyID int64  `json:"company_id"`
+	Plan      string `json:"plan"`
+	Limit     int    `json:"limit"`
+	Published int    `json:"published"`
+	Remaining int    `json:"remaining"`
+}
+
+// Exceeded returns ErrJobQuotaExceeded carrying the limit and the current count
+func (q *JobQuota) Exceeded() error {
+	return ErrJobQuotaExceeded.WithDetails(map[string]string{
+		"plan":      q.Plan,
+		"limit":     strconv.Itoa(q.Limit),
+		"published": strconv.Itoa(q.Published),
+	})
+}
+
+// QuotaRepository stores plan limit overrides and company plans and counts published jobs
+type QuotaRepository interface {
+	ListPlanQuotas(ctx context.Context) ([]PlanQuota, error)
+	SavePlanQuota(ctx context.Context, quota *PlanQuota) error
+	// SetCompanyPlan sets the company's plan; nil derives it from the verification again
+	SetCompanyPlan(ctx context.Context, companyID int64, plan *string) error
+	// CountPublishedJobs counts the company's published jobs that have not expired
+	CountPublishedJobs(ctx context.Context, companyID int64) (int, error)
+}
+
+// QuotaService resolves and enforces the published job limits of company plans
+type QuotaService interface {
+	// GetJobQuota returns the company's plan, limit and published job count
+	GetJobQuota(ctx context.Context, companyID int64) (*JobQuota, error)
+	// CheckCanPublish returns the quota, or ErrJobQuotaExceeded with the limit and count when
+	// publishing one more job would go past it
+	CheckCanPublish(ctx context.Context, companyID int64) (*JobQuota, error)
+	// ListPlanLimits returns the limit in effect for each plan
+	ListPlanLimits(ctx context.Context) ([]PlanQuota, error)
+	// UpdatePlanLimit overrides a plan's limit
+	UpdatePlanLimit(ctx context.Context, plan string, maxPublishedJobs int, adminID int64) (*PlanQuota, error)
+	// SetCompanyPlan moves the company to plan; an empty plan derives it from the verification
+	SetCompanyPlan(ctx context.Context, companyID int64, plan string, adminID int64) (*JobQuota, error)
+}
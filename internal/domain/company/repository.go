@@ -2,6 +2,9 @@ package company
 
 import (
 	"context"
+	"time"
+
+	"keerja-backend/internal/domain/user"
 
 	"gorm.io/gorm"
 )
@@ -11,11 +14,17 @@ type CompanyRepository interface {
 	// Company CRUD
 	Create(ctx context.Context, company *Company) error
 	FindByID(ctx context.Context, id int64) (*Company, error)
+	// FindByIDs loads the given companies without relations in one query; missing IDs are skipped
+	FindByIDs(ctx context.Context, ids []int64) ([]Company, error)
 	FindByUUID(ctx context.Context, uuid string) (*Company, error)
 	FindBySlug(ctx context.Context, slug string) (*Company, error)
+	SlugExists(ctx context.Context, slug string, excludeID int64) (bool, error)
 	Update(ctx context.Context, company *Company) error
 	Delete(ctx context.Context, id int64) error
+	FindDeletedByID(ctx context.Context, id int64) (*Company, error)
+	Restore(ctx context.Context, id int64) error
 	List(ctx context.Context, filter *CompanyFilter) ([]Company, int64, error)
+	ListByCursor(ctx context.Context, filter *CompanyFilter, cursor string) ([]Company, string, error)
 
 	// Company CRUD with Master Data Preloading
 	FindByIDWithMasterData(ctx context.Context, id int64) (*Company, error)
@@ -37,18 +46,61 @@ type CompanyRepository interface {
 	CountFollowers(ctx context.Context, companyID int64) (int64, error)
 
 	// Review operations
+	// CreateReview returns ErrReviewAlreadyExists when the user already reviewed the company
 	CreateReview(ctx context.Context, review *CompanyReview) error
 	UpdateReview(ctx context.Context, review *CompanyReview) error
 	DeleteReview(ctx context.Context, id int64) error
 	FindReviewByID(ctx context.Context, id int64) (*CompanyReview, error)
 	GetReviewsByCompanyID(ctx context.Context, companyID int64, filter *ReviewFilter) ([]CompanyReview, int64, error)
 	GetReviewsByUserID(ctx context.Context, userID int64) ([]CompanyReview, error)
+	CountReviewsByUserSince(ctx context.Context, userID int64, since time.Time) (int64, error)
+	// IsVerifiedReviewer reports whether the user is on the company's employee list or was
+	// hired through one of its job applications
+	IsVerifiedReviewer(ctx context.Context, companyID, userID int64) (bool, error)
 	ApproveReview(ctx context.Context, id, moderatedBy int64) error
 	RejectReview(ctx context.Context, id, moderatedBy int64) error
+	GetReviewsByStatus(ctx context.Context, status string, filter *ReviewModerationFilter, page, limit int) ([]ModerationReview, int64, error)
 	CalculateAverageRatings(ctx context.Context, companyID int64) (*AverageRatings, error)
 
+	// Rating summary operations
+	RefreshRatingSummary(ctx context.Context, companyID int64) error
+	// RefreshStaleRatingSummaries recomputes the summaries of companies whose reviews changed
+	// since their summary was last refreshed, creating missing ones, and returns how many it refreshed
+	RefreshStaleRatingSummaries(ctx context.Context) (int64, error)
+
+	// Review vote and report operations
+	UpsertReviewVote(ctx context.Context, vote *ReviewVote) error
+	DeleteReviewVote(ctx context.Context, reviewID, userID int64) error
+	GetUserReviewVotes(ctx context.Context, userID int64, reviewIDs []int64) (map[int64]string, error)
+	CreateReviewReport(ctx context.Context, report *ReviewReport) error
+	CountOpenReviewReports(ctx context.Context, reviewID int64) (int64, error)
+
+	// Review response operations
+	// CreateReviewResponse returns ErrReviewResponseExists when the review already has a response
+	CreateReviewResponse(ctx context.Context, response *ReviewResponse) error
+	UpdateReviewResponse(ctx context.Context, response *ReviewResponse) error
+	DeleteReviewResponse(ctx context.Context, id int64) error
+	FindReviewResponseByReviewID(ctx context.Context, reviewID int64) (*ReviewResponse, error)
+
+	// Settings operations
+	FindSettings(ctx context.Context, companyID int64) (*CompanySettings, error)
+	UpsertSettings(ctx context.Context, settings *CompanySettings) error
+
+	// Change request operations
+	// CreateChangeRequest returns ErrChangeRequestPending when the company already has a pending request
+	CreateChangeRequest(ctx context.Context, req *CompanyChangeRequest) error
+	FindChangeRequestByID(ctx context.Context, id int64) (*CompanyChangeRequest, error)
+	ListChangeRequestsByCompany(ctx context.Context, companyID int64, limit int) ([]CompanyChangeRequest, error)
+	ListChangeRequests(ctx context.Context, status string, page, limit int) ([]CompanyChangeRequest, int64, error)
+	// ApproveChangeRequest marks a pending request approved and writes change to the company in
+	// one transaction. It returns ErrChangeRequestDecided when the request is no longer pending.
+	ApproveChangeRequest(ctx context.Context, id, decidedBy int64, note *string, change CompanyMasterDataChange) error
+	// RejectChangeRequest returns ErrChangeRequestDecided when the request is no longer pending
+	RejectChangeRequest(ctx context.Context, id, decidedBy int64, note *string) error
+
 	// Document operations
 	CreateDocument(ctx context.Context, doc *CompanyDocument) error
+	CreateDocumentTx(ctx context.Context, tx *gorm.DB, doc *CompanyDocument) error
 	UpdateDocument(ctx context.Context, doc *CompanyDocument) error
 	DeleteDocument(ctx context.Context, id int64) error
 	FindDocumentByID(ctx context.Context, id int64) (*CompanyDocument, error)
@@ -58,6 +110,7 @@ type CompanyRepository interface {
 
 	// Employee operations
 	AddEmployee(ctx context.Context, employee *CompanyEmployee) error
+	AddEmployees(ctx context.Context, employees []CompanyEmployee) error
 	UpdateEmployee(ctx context.Context, employee *CompanyEmployee) error
 	DeleteEmployee(ctx context.Context, id int64) error
 	GetEmployeesByCompanyID(ctx context.Context, companyID int64, includeInactive bool) ([]CompanyEmployee, error)
@@ -72,12 +125,24 @@ type CompanyRepository interface {
 	FindEmployerUserByUserAndCompany(ctx context.Context, userID, companyID int64) (*EmployerUser, error)
 	GetEmployerUsersByCompanyID(ctx context.Context, companyID int64) ([]EmployerUser, error)
 	GetCompaniesByUserID(ctx context.Context, userID int64) ([]Company, error)
+	// GetEmployerUsersByUserID returns the user's active memberships of companies that are not
+	// deleted, with the company loaded, most recently activated first
+	GetEmployerUsersByUserID(ctx context.Context, userID int64) ([]EmployerUser, error)
+	// SetEmployerUserActivatedAt records when the user chose the membership's company as their
+	// active company
+	SetEmployerUserActivatedAt(ctx context.Context, employerUserID int64, activatedAt time.Time) error
 
 	// Company Invitation operations
 	CreateInvitation(ctx context.Context, invitation *CompanyInvitation) error
 	FindInvitationByToken(ctx context.Context, token string) (*CompanyInvitation, error)
 	FindInvitationByID(ctx context.Context, id int64) (*CompanyInvitation, error)
 	UpdateInvitation(ctx context.Context, invitation *CompanyInvitation) error
+	// AcceptInvitation creates the employer user and marks the invitation accepted by it in one
+	// transaction. It returns ErrInvitationUnavailable if the invitation is no longer pending.
+	AcceptInvitation(ctx context.Context, invitation *CompanyInvitation, employerUser *EmployerUser) error
+	// AcceptInvitationForNewUser creates newUser and then does what AcceptInvitation does for
+	// it, all in one transaction
+	AcceptInvitationForNewUser(ctx context.Context, invitation *CompanyInvitation, newUser *user.User, employerUser *EmployerUser) error
 	GetPendingInvitationsByCompany(ctx context.Context, companyID int64) ([]CompanyInvitation, error)
 	GetPendingInvitationsByEmail(ctx context.Context, email string) ([]CompanyInvitation, error)
 	ExpireOldInvitations(ctx context.Context) error
@@ -87,10 +152,22 @@ type CompanyRepository interface {
 	CreateVerification(ctx context.Context, verification *CompanyVerification) error
 	UpdateVerification(ctx context.Context, verification *CompanyVerification) error
 	FindVerificationByCompanyID(ctx context.Context, companyID int64) (*CompanyVerification, error)
+	CreateVerificationTx(ctx context.Context, tx *gorm.DB, verification *CompanyVerification) error
+	UpdateVerificationTx(ctx context.Context, tx *gorm.DB, verification *CompanyVerification) error
+	// FindVerificationByCompanyIDTx reads the verification inside tx and locks it until tx ends
+	FindVerificationByCompanyIDTx(ctx context.Context, tx *gorm.DB, companyID int64) (*CompanyVerification, error)
 	RequestVerification(ctx context.Context, companyID, requestedBy int64) error
 	ApproveVerification(ctx context.Context, companyID, reviewedBy int64, notes string) error
 	RejectVerification(ctx context.Context, companyID, reviewedBy int64, reason string) error
 	GetPendingVerifications(ctx context.Context, page, limit int) ([]CompanyVerification, int64, error)
+	// FindVerificationsExpiringWithin returns verified verifications expiring within days
+	// that have not been warned at this threshold or a smaller one yet
+	FindVerificationsExpiringWithin(ctx context.Context, days int) ([]CompanyVerification, error)
+	// MarkVerificationExpiryWarned records the warning threshold; it returns false when an
+	// equal or smaller threshold was already recorded, e.g. by another instance
+	MarkVerificationExpiryWarned(ctx context.Context, verificationID int64, days int) (bool, error)
+	// FindLapsedVerifications returns verifications still marked verified whose expiry date has passed
+	FindLapsedVerifications(ctx context.Context) ([]CompanyVerification, error)
 
 	// Industry operations
 	CreateIndustry(ctx context.Context, industry *CompanyIndustry) error
@@ -144,14 +221,31 @@ type CompanyFilter struct {
 
 // ReviewFilter represents filters for querying reviews
 type ReviewFilter struct {
+	ReviewerType    *string
+	Status          *string
+	ExcludeStatuses []string
+	MinRating       *float64
+	MaxRating       *float64
+	ViewerUserID    *int64 // When set, each review's MyVote is filled for this user
+	Page            int
+	Limit           int
+	SortBy          string
+	SortOrder       string
+}
+
+// ReviewModerationFilter represents optional filters for the admin review moderation queue
+type ReviewModerationFilter struct {
+	CompanyID    *int64
 	ReviewerType *string
-	Status       *string
-	MinRating    *float64
-	MaxRating    *float64
-	Page         int
-	Limit        int
-	SortBy       string
-	SortOrder    string
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time // Exclusive upper bound
+}
+
+// ModerationReview is a review in the moderation queue with its company and reviewer names
+type ModerationReview struct {
+	CompanyReview
+	CompanyName  string
+	ReviewerName string
 }
 
 // AverageRatings represents average ratings for a company
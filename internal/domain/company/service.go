@@ -2,9 +2,11 @@ package company
 
 import (
 	"context"
+	"keerja-backend/internal/domain/employer"
 	"keerja-backend/internal/domain/job"
 	"keerja-backend/internal/domain/user"
 	"mime/multipart"
+	"time"
 )
 
 // CompanyService defines the business logic interface for company operations
@@ -13,9 +15,11 @@ type CompanyService interface {
 	RegisterCompany(ctx context.Context, req *RegisterCompanyRequest, userID int64) (*Company, error)
 	GetCompany(ctx context.Context, id int64) (*Company, error)
 	GetCompanyBySlug(ctx context.Context, slug string) (*Company, error)
+	GetPublicProfile(ctx context.Context, slug string, viewerUserID int64) (*PublicCompanyProfile, error)
 	UpdateCompany(ctx context.Context, companyID int64, req *UpdateCompanyRequest, bannerFile, logoFile *multipart.FileHeader) error
 	DeleteCompany(ctx context.Context, companyID int64) error
 	ListCompanies(ctx context.Context, filter *CompanyFilter) ([]Company, int64, error)
+	ListCompaniesByCursor(ctx context.Context, filter *CompanyFilter, cursor string) ([]Company, string, error)
 	SearchCompanies(ctx context.Context, query string, filter *CompanyFilter) ([]Company, int64, error)
 
 	// Profile management
@@ -46,19 +50,41 @@ type CompanyService interface {
 	GetReview(ctx context.Context, reviewID int64) (*CompanyReview, error)
 	GetCompanyReviews(ctx context.Context, companyID int64, filter *ReviewFilter) ([]CompanyReview, int64, error)
 	GetUserReviews(ctx context.Context, userID int64) ([]CompanyReview, error)
+	VoteReview(ctx context.Context, reviewID, userID int64, vote string) (*CompanyReview, error)
+	UnvoteReview(ctx context.Context, reviewID, userID int64) (*CompanyReview, error)
+	ReportReview(ctx context.Context, reviewID, userID int64, reason string) error
+
+	// Review responses (company owners and admins)
+	AddReviewResponse(ctx context.Context, reviewID, userID int64, body string) (*ReviewResponse, error)
+	UpdateReviewResponse(ctx context.Context, reviewID, userID int64, body string) (*ReviewResponse, error)
+	DeleteReviewResponse(ctx context.Context, reviewID, userID int64) error
 	GetAverageRatings(ctx context.Context, companyID int64) (*AverageRatings, error)
 
 	// Review moderation (admin only)
 	ApproveReview(ctx context.Context, reviewID, moderatedBy int64) error
 	RejectReview(ctx context.Context, reviewID, moderatedBy int64) error
 	HideReview(ctx context.Context, reviewID, moderatedBy int64) error
-	GetPendingReviews(ctx context.Context, page, limit int) ([]CompanyReview, int64, error)
+	GetPendingReviews(ctx context.Context, status string, filter *ReviewModerationFilter, page, limit int) ([]ModerationReview, int64, error)
+
+	// Settings management
+	GetSettings(ctx context.Context, companyID int64) (*CompanySettings, error)
+	UpdateSettings(ctx context.Context, companyID int64, req *UpdateSettingsRequest) (*CompanySettings, error)
+
+	// Change requests for industry, company size and district
+	RequestCompanyChange(ctx context.Context, companyID, requestedBy int64, req *ChangeRequestInput) (*CompanyChangeRequest, error)
+	GetChangeRequests(ctx context.Context, companyID int64) ([]CompanyChangeRequest, error)
+	ListChangeRequests(ctx context.Context, status string, page, limit int) ([]CompanyChangeRequest, int64, error)
+	ApproveChangeRequest(ctx context.Context, requestID, decidedBy int64, note *string) error
+	RejectChangeRequest(ctx context.Context, requestID, decidedBy int64, note *string) error
 
 	// Document management
 	UploadDocument(ctx context.Context, companyID int64, file *multipart.FileHeader, req *UploadDocumentRequest) (*CompanyDocument, error)
 	UpdateDocument(ctx context.Context, documentID int64, req *UpdateDocumentRequest) error
 	DeleteDocument(ctx context.Context, documentID, companyID int64) error
 	GetDocuments(ctx context.Context, companyID int64) ([]CompanyDocument, error)
+	// GetDocumentForDownload returns a company document for a platform admin (userType "admin")
+	// or an owner or admin employer of the company
+	GetDocumentForDownload(ctx context.Context, companyID, documentID, userID int64, userType string) (*CompanyDocument, error)
 
 	// Document verification (admin only)
 	ApproveDocument(ctx context.Context, documentID, verifiedBy int64) error
@@ -71,17 +97,37 @@ type CompanyService interface {
 	RemoveEmployee(ctx context.Context, employeeID, companyID int64) error
 	GetEmployees(ctx context.Context, companyID int64, includeInactive bool) ([]CompanyEmployee, error)
 	GetEmployeeCount(ctx context.Context, companyID int64) (int64, error)
+	ImportEmployees(ctx context.Context, companyID, addedBy int64, file *multipart.FileHeader, dryRun bool) (*EmployeeImportReport, error)
 
 	// Employer user management
-	InviteEmployer(ctx context.Context, req *InviteEmployerRequest) error
+	InviteEmployer(ctx context.Context, req *InviteEmployerRequest) (*InvitationResult, error)
 	AcceptInvitation(ctx context.Context, token string, userID int64) error
-	ResendInvitation(ctx context.Context, invitationID, requestedBy int64) error
+	// GetInvitationDetails returns what an invitee sees when opening the invitation link,
+	// without requiring an account
+	GetInvitationDetails(ctx context.Context, token string) (*InvitationDetails, error)
+	// CheckInvitationForSignup returns the invitation if it can still be accepted by a new
+	// account registered with email
+	CheckInvitationForSignup(ctx context.Context, token, email string) (*CompanyInvitation, error)
+	// AcceptInvitationForSignup creates newUser and its membership of the invitation's company
+	// in one transaction, so a failed accept leaves no account behind
+	AcceptInvitationForSignup(ctx context.Context, token string, newUser *user.User) error
+	ResendInvitation(ctx context.Context, invitationID, requestedBy int64) (*InvitationResult, error)
 	CancelInvitation(ctx context.Context, invitationID, canceledBy int64) error
 	GetPendingInvitations(ctx context.Context, companyID int64) ([]CompanyInvitation, error)
 	GetUserPendingInvitations(ctx context.Context, email string) ([]CompanyInvitation, error)
 	ExpireOldInvitations(ctx context.Context) (int64, error)
 	GetEmployerUser(ctx context.Context, userID, companyID int64) (*EmployerUser, error)
 	GetEmployerUserID(ctx context.Context, userID, companyID int64) (int64, error)
+	// ResolveEmployerContext resolves the user's active membership of the company. When
+	// companyID is 0 the company the user activated last is used, or their only company;
+	// ErrActiveCompanyRequired is returned when neither exists.
+	ResolveEmployerContext(ctx context.Context, userID, companyID int64) (*employer.EmployerContext, error)
+	// GetEmployerCompanies lists the companies the user is an employer of and marks the active
+	// one: activeCompanyID when it is not 0, otherwise the one ResolveEmployerContext would use
+	GetEmployerCompanies(ctx context.Context, userID, activeCompanyID int64) ([]EmployerCompany, error)
+	// ActivateCompany stores companyID as the company the user's employer requests act for
+	// when they do not name one
+	ActivateCompany(ctx context.Context, userID, companyID int64) (*employer.EmployerContext, error)
 	UpdateEmployerRole(ctx context.Context, employerUserID int64, newRole string) error
 	// UpdateEmployerUser updates fields of the employer_user record for the given user and company
 	UpdateEmployerUser(ctx context.Context, userID, companyID int64, req *UpdateEmployerUserRequest) error
@@ -93,13 +139,12 @@ type CompanyService interface {
 	CheckEmployerPermission(ctx context.Context, userID, companyID int64, requiredRole string) (bool, error)
 
 	// Verification management
-	RequestVerification(ctx context.Context, companyID, requestedBy int64, npwpNumber string, nibNumber *string, npwpFile *multipart.FileHeader, additionalFiles []*multipart.FileHeader) error
+	RequestVerification(ctx context.Context, companyID, requestedBy int64, npwpNumber string, nibNumber *string, npwpFile, nibFile *multipart.FileHeader, additionalFiles []*multipart.FileHeader) error
 	GetVerificationStatus(ctx context.Context, companyID int64) (*CompanyVerification, error)
 	ApproveVerification(ctx context.Context, companyID, reviewedBy int64, notes string) error
 	RejectVerification(ctx context.Context, companyID, reviewedBy int64, reason string) error
 	GetPendingVerifications(ctx context.Context, page, limit int) ([]CompanyVerification, int64, error)
 	RenewVerification(ctx context.Context, companyID int64) error
-	CheckVerificationExpiry(ctx context.Context) error
 
 	// Industry management (admin only)
 	CreateIndustry(ctx context.Context, req *CreateIndustryRequest) (*CompanyIndustry, error)
@@ -112,11 +157,12 @@ type CompanyService interface {
 	// Analytics and stats
 	GetCompanyStats(ctx context.Context, companyID int64) (*CompanyStats, error)
 	GetTopRatedCompanies(ctx context.Context, limit int) ([]Company, error)
+	RefreshStaleRatingSummaries(ctx context.Context) (int64, error)
 	GetVerifiedCompanies(ctx context.Context, page, limit int) ([]Company, int64, error)
 	GetCompanyEngagement(ctx context.Context, companyID int64) (*EngagementStats, error)
 
-	// Get jobs by specific status with pagination
-	GetJobsByStatus(ctx context.Context, userID int64, status string, page, limit int) ([]job.Job, int64, error)
+	// GetJobsByStatus lists the jobs the employer user posted at their company by UI status
+	GetJobsByStatus(ctx context.Context, ec *employer.EmployerContext, status string, page, limit int) ([]job.Job, int64, error)
 
 	// Company address management
 	CreateCompanyAddress(ctx context.Context, companyID int64, req *CreateCompanyAddressRequest) (*CompanyAddress, error)
@@ -128,6 +174,13 @@ type CompanyService interface {
 	SoftDeleteCompanyAddress(ctx context.Context, companyID, addressID int64) error
 }
 
+// VerificationExpiryService warns employers before their company verification expires,
+// keeps the company verified through a grace period and then expires it
+type VerificationExpiryService interface {
+	// CheckVerificationExpiry sends due expiry warnings and expires verifications whose grace period has ended
+	CheckVerificationExpiry(ctx context.Context) (*VerificationExpiryStats, error)
+}
+
 // Request DTOs
 
 type RegisterCompanyRequest struct {
@@ -183,6 +236,9 @@ type UpdateCompanyRequest struct {
 
 	// Verification status (optional)
 	Verified *bool // Set to true to verify company
+
+	// Version the client loaded; when set the update fails if the company changed since
+	Version *int64
 }
 
 type CreateProfileRequest struct {
@@ -219,6 +275,7 @@ type UpdateProfileRequest struct {
 	SEOTitle         *string
 	SEOKeywords      []string
 	SEODescription   *string
+	Version          *int64 // Version the client loaded; when set the update fails if the profile changed since
 }
 
 type AddReviewRequest struct {
@@ -254,6 +311,26 @@ type UpdateReviewRequest struct {
 	RecommendToFriend  *bool
 }
 
+type UpdateSettingsRequest struct {
+	StageReminderEnabled  *bool
+	StageReminderDays     *int
+	BlindScreeningEnabled *bool
+	WeeklyDigestEnabled   *bool
+	AutoJoinEnabled       *bool
+	AutoJoinRole          *string
+}
+
+// ChangeRequestHistoryLimit caps the change requests shown with the company settings
+const ChangeRequestHistoryLimit = 20
+
+// ChangeRequestInput holds the requested values; at least one ID must be set
+type ChangeRequestInput struct {
+	IndustryID    *int64
+	CompanySizeID *int64
+	DistrictID    *int64
+	Reason        string
+}
+
 type UploadDocumentRequest struct {
 	DocumentType   string
 	DocumentNumber *string
@@ -272,6 +349,7 @@ type UpdateDocumentRequest struct {
 type AddEmployeeRequest struct {
 	UserID           *int64
 	FullName         *string
+	Email            *string
 	JobTitle         *string
 	Department       *string
 	EmploymentType   string
@@ -283,6 +361,45 @@ type AddEmployeeRequest struct {
 	IsVisiblePublic  bool
 }
 
+// Employee import limits
+const (
+	MaxEmployeeImportFileSize = 5 * 1024 * 1024 // 5MB
+	MaxEmployeeImportRows     = 5000
+	EmployeeImportBatchSize   = 500
+)
+
+// EmployeeImportColumns are the CSV columns an employee import understands; only full_name is required
+var EmployeeImportColumns = []string{"full_name", "email", "job_title", "department", "employment_type", "join_date", "is_visible_public"}
+
+// Employee import row statuses
+const (
+	ImportRowCreated = "created" // Row was saved as a new employee
+	ImportRowValid   = "valid"   // Row passed validation in a dry run and would be created
+	ImportRowSkipped = "skipped" // Row duplicates an existing employee or an earlier row
+	ImportRowFailed  = "failed"  // Row failed validation or could not be saved
+)
+
+// EmployeeImportRow is the outcome of one CSV row; Row is the line number in the file
+type EmployeeImportRow struct {
+	Row        int    `json:"row"`
+	FullName   string `json:"full_name,omitempty"`
+	Email      string `json:"email,omitempty"`
+	Status     string `json:"status"`
+	Reason     string `json:"reason,omitempty"`
+	EmployeeID int64  `json:"employee_id,omitempty"`
+}
+
+// EmployeeImportReport summarizes an employee import with a per-row outcome
+type EmployeeImportReport struct {
+	DryRun    bool                `json:"dry_run"`
+	TotalRows int                 `json:"total_rows"`
+	Created   int                 `json:"created"`
+	Valid     int                 `json:"valid"`
+	Skipped   int                 `json:"skipped"`
+	Failed    int                 `json:"failed"`
+	Rows      []EmployeeImportRow `json:"rows"`
+}
+
 type UpdateEmployeeRequest struct {
 	FullName         *string
 	JobTitle         *string
@@ -299,18 +416,57 @@ type UpdateEmployeeRequest struct {
 
 type InviteEmployerRequest struct {
 	CompanyID     int64
+	InvitedBy     int64 // user ID of the inviting employer
 	Email         string
+	FullName      string
 	Role          string
 	PositionTitle *string
 	Department    *string
 }
 
+// InvitationResult holds a saved invitation and the outcome of sending its email.
+// The invitation is kept even when the email fails so the caller can offer a resend.
+type InvitationResult struct {
+	Invitation *CompanyInvitation
+	EmailSent  bool
+	EmailError string
+}
+
+// InvitationDetails is what an invitee sees when opening an invitation link
+type InvitationDetails struct {
+	Email          string    `json:"email"`
+	FullName       string    `json:"full_name"`
+	CompanyID      int64     `json:"company_id"`
+	CompanyName    string    `json:"company_name"`
+	CompanyLogoURL *string   `json:"company_logo_url,omitempty"`
+	Role           string    `json:"role"`
+	Position       *string   `json:"position,omitempty"`
+	InviterName    string    `json:"inviter_name"`
+	Status         string    `json:"status"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	IsExpired      bool      `json:"is_expired"`
+}
+
+// EmployerCompany is a company the user is an employer of, with the user's role there
+type EmployerCompany struct {
+	CompanyID      int64      `json:"company_id"`
+	CompanyName    string     `json:"company_name"`
+	Slug           string     `json:"slug"`
+	LogoURL        *string    `json:"logo_url,omitempty"`
+	Verified       bool       `json:"verified"`
+	EmployerUserID int64      `json:"employer_user_id"`
+	Role           string     `json:"role"`
+	IsActive       bool       `json:"is_active"` // The company the user's employer requests act for
+	ActivatedAt    *time.Time `json:"activated_at,omitempty"`
+}
+
 // UpdateEmployerUserRequest represents fields allowed to be updated on the employer_user record
 type UpdateEmployerUserRequest struct {
 	PositionTitle *string
 	Department    *string
 	EmailCompany  *string
 	PhoneCompany  *string
+	Version       *int64 // Version the client loaded; when set the update fails if the membership changed since
 }
 
 type CreateIndustryRequest struct {
@@ -370,3 +526,28 @@ type EngagementStats struct {
 	AverageRating  float64
 	ResponseRate   float64
 }
+
+// Public profile limits
+const (
+	PublicProfileJobLimit    = 5 // Latest published jobs shown on the public profile
+	PublicProfileReviewLimit = 3 // Most helpful approved reviews shown on the public profile
+)
+
+// PublicCompanyProfile is everything the public company page shows, loaded in one call.
+// Company.Profile is nil unless the profile is published.
+type PublicCompanyProfile struct {
+	Company       *Company
+	Jobs          []job.Job
+	JobsTotal     int64
+	Ratings       *AverageRatings
+	TopReviews    []CompanyReview
+	FollowerCount int64
+	IsFollowing   *bool // Nil for anonymous viewers
+}
+
+// VerificationExpiryStats summarizes one CheckVerificationExpiry run
+type VerificationExpiryStats struct {
+	WarningsSent int // Companies warned about an upcoming expiry
+	EnteredGrace int // Companies whose expiry passed and that are now in the grace period
+	Expired      int // Companies whose verification was expired
+}
@@ -0,0 +1,105 @@
+package company
+
+import "slices"
+
+// Review statuses. Only approved reviews are public; hidden reviews were taken down by a
+// moderator or by enough reports.
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+	ReviewStatusHidden   = "hidden"
+)
+
+// Verification statuses. Suspended is set by admins taking the badge away from a
+// verified company.
+const (
+	VerificationStatusPending     = "pending"
+	VerificationStatusUnderReview = "under_review"
+	VerificationStatusVerified    = "verified"
+	VerificationStatusRejected    = "rejected"
+	VerificationStatusSuspended   = "suspended"
+	VerificationStatusBlacklisted = "blacklisted"
+	VerificationStatusExpired     = "expired"
+)
+
+// Invitation statuses; only pending invitations can be accepted
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusRejected = "rejected"
+	InvitationStatusExpired  = "expired"
+)
+
+// ReviewStatuses lists every review status, matching the company_reviews_status_check constraint
+var ReviewStatuses = []string{ReviewStatusPending, ReviewStatusApproved, ReviewStatusRejected, ReviewStatusHidden}
+
+// VerificationStatuses lists every verification status, matching the
+// company_verifications_status_check constraint
+var VerificationStatuses = []string{
+	VerificationStatusPending,
+	VerificationStatusUnderReview,
+	VerificationStatusVerified,
+	VerificationStatusRejected,
+	VerificationStatusSuspended,
+	VerificationStatusBlacklisted,
+	VerificationStatusExpired,
+}
+
+// InvitationStatuses lists every invitation status, matching the company_invitations_status_check constraint
+var InvitationStatuses = []string{InvitationStatusPending, InvitationStatusAccepted, InvitationStatusRejected, InvitationStatusExpired}
+
+// reviewTransitions maps each review status to the statuses a moderator may move it to
+var reviewTransitions = map[string][]string{
+	ReviewStatusPending:  {ReviewStatusApproved, ReviewStatusRejected, ReviewStatusHidden},
+	ReviewStatusApproved: {ReviewStatusRejected, ReviewStatusHidden},
+	ReviewStatusRejected: {ReviewStatusApproved},
+	ReviewStatusHidden:   {ReviewStatusApproved, ReviewStatusRejected},
+}
+
+// verificationTransitions maps each verification status to the statuses it may move to.
+// Companies request verification again after a rejection, an expiry or a suspension.
+var verificationTransitions = map[string][]string{
+	VerificationStatusPending:     {VerificationStatusUnderReview, VerificationStatusVerified, VerificationStatusRejected, VerificationStatusSuspended, VerificationStatusBlacklisted},
+	VerificationStatusUnderReview: {VerificationStatusVerified, VerificationStatusRejected, VerificationStatusSuspended, VerificationStatusBlacklisted},
+	VerificationStatusVerified:    {VerificationStatusPending, VerificationStatusRejected, VerificationStatusSuspended, VerificationStatusBlacklisted, VerificationStatusExpired},
+	VerificationStatusRejected:    {VerificationStatusPending, VerificationStatusUnderReview, VerificationStatusVerified, VerificationStatusBlacklisted},
+	VerificationStatusSuspended:   {VerificationStatusPending, VerificationStatusVerified, VerificationStatusRejected, VerificationStatusBlacklisted},
+	VerificationStatusExpired:     {VerificationStatusPending, VerificationStatusVerified, VerificationStatusRejected, VerificationStatusBlacklisted},
+	VerificationStatusBlacklisted: {VerificationStatusVerified, VerificationStatusRejected},
+}
+
+// invitationTransitions maps each invitation status to the statuses it may move to
+var invitationTransitions = map[string][]string{
+	InvitationStatusPending: {InvitationStatusAccepted, InvitationStatusRejected, InvitationStatusExpired},
+}
+
+// IsValidReviewStatus reports whether status is a review status
+func IsValidReviewStatus(status string) bool {
+	return slices.Contains(ReviewStatuses, status)
+}
+
+// IsValidVerificationStatus reports whether status is a verification status
+func IsValidVerificationStatus(status string) bool {
+	return slices.Contains(VerificationStatuses, status)
+}
+
+// IsValidInvitationStatus reports whether status is an invitation status
+func IsValidInvitationStatus(status string) bool {
+	return slices.Contains(InvitationStatuses, status)
+}
+
+// IsValidReviewTransition reports whether a review may move from one status to another
+func IsValidReviewTransition(from, to string) bool {
+	return slices.Contains(reviewTransitions[from], to)
+}
+
+// IsValidVerificationTransition reports whether a verification may move from one status to another
+func IsValidVerificationTransition(from, to string) bool {
+	return slices.Contains(verificationTransitions[from], to)
+}
+
+// IsValidInvitationTransition reports whether an invitation may move from one status to another
+func IsValidInvitationTransition(from, to string) bool {
+	return slices.Contains(invitationTransitions[from], to)
+}
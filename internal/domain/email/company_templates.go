@@ -0,0 +1,298 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"regexp"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"keerja-backend/internal/domain/application"
+)
+
+// Events a company can customize the candidate email of
+const (
+	CompanyTemplateApplicationRejected    = "application_rejected"
+	CompanyTemplateApplicationShortlisted = "application_shortlisted"
+	CompanyTemplateInterviewScheduled     = "interview_scheduled"
+	CompanyTemplateOfferMade              = "offer_made"
+)
+
+// CompanyTemplateEvents lists every event a company email template can be written for
+var CompanyTemplateEvents = []string{
+	CompanyTemplateApplicationRejected,
+	CompanyTemplateApplicationShortlisted,
+	CompanyTemplateInterviewScheduled,
+	CompanyTemplateOfferMade,
+}
+
+// CompanyTemplateEventForStatus returns the template event sent when an application moves
+// to status, or "" when companies cannot customize that email
+func CompanyTemplateEventForStatus(status string) string {
+	switch status {
+	case application.StatusRejected:
+		return CompanyTemplateApplicationRejected
+	case application.StatusShortlisted:
+		return CompanyTemplateApplicationShortlisted
+	case application.StatusOffered:
+		return CompanyTemplateOfferMade
+	}
+	return ""
+}
+
+// Variables company email templates can use, written as {{candidate_name}}
+const (
+	TemplateVarCandidateName     = "candidate_name"
+	TemplateVarJobTitle          = "job_title"
+	TemplateVarCompanyName       = "company_name"
+	TemplateVarApplicationStatus = "application_status" // Status label in the candidate's language
+	TemplateVarInterviewDate     = "interview_date"
+	TemplateVarInterviewTime     = "interview_time" // Time with the interview's timezone
+	TemplateVarInterviewType     = "interview_type" // online, onsite or hybrid
+	TemplateVarInterviewLocation = "interview_location"
+	TemplateVarInterviewLink     = "interview_link"
+)
+
+var applicationTemplateVariables = []string{
+	TemplateVarCandidateName,
+	TemplateVarJobTitle,
+	TemplateVarCompanyName,
+	TemplateVarApplicationStatus,
+}
+
+// CompanyTemplateVariables lists the variables the templates of each event may use
+var CompanyTemplateVariables = map[string][]string{
+	CompanyTemplateApplicationRejected:    applicationTemplateVariables,
+	CompanyTemplateApplicationShortlisted: applicationTemplateVariables,
+	CompanyTemplateOfferMade:              applicationTemplateVariables,
+	CompanyTemplateInterviewScheduled: append(slices.Clone(applicationTemplateVariables),
+		TemplateVarInterviewDate,
+		TemplateVarInterviewTime,
+		TemplateVarInterviewType,
+		TemplateVarInterviewLocation,
+		TemplateVarInterviewLink,
+	),
+}
+
+// SampleCompanyTemplateVariables returns the values previews of event's templates are rendered with
+func SampleCompanyTemplateVariables(event string) map[string]string {
+	status := map[string]string{
+		CompanyTemplateApplicationRejected:    "Rejected",
+		CompanyTemplateApplicationShortlisted: "Shortlisted",
+		CompanyTemplateInterviewScheduled:     "Interview",
+		CompanyTemplateOfferMade:              "Offered",
+	}[event]
+
+	vars := map[string]string{
+		TemplateVarCandidateName:     "Budi Santoso",
+		TemplateVarJobTitle:          "Backend Engineer",
+		TemplateVarCompanyName:       "PT Contoh Teknologi",
+		TemplateVarApplicationStatus: status,
+	}
+	if event == CompanyTemplateInterviewScheduled {
+		vars[TemplateVarInterviewDate] = "Senin, 2 Maret 2026"
+		vars[TemplateVarInterviewTime] = "10:00 WIB (Asia/Jakarta)"
+		vars[TemplateVarInterviewType] = "online"
+		vars[TemplateVarInterviewLocation] = ""
+		vars[TemplateVarInterviewLink] = "https://meet.example.com/keerja-interview"
+	}
+	return vars
+}
+
+// Limits of company email templates
+const (
+	MaxCompanyTemplateSubjectLength = 200
+	MaxCompanyTemplateBodyLength    = 20000
+)
+
+// CompanyEmailTemplate is a company's own subject and body for the candidate email of an
+// event, sent instead of the platform default
+type CompanyEmailTemplate struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	CompanyID int64     `gorm:"column:company_id;not null;uniqueIndex:idx_company_email_templates_company_event" json:"company_id"`
+	EventType string    `gorm:"column:event_type;type:varchar(50);not null;uniqueIndex:idx_company_email_templates_company_event" json:"event_type"`
+	Subject   string    `gorm:"column:subject;type:varchar(200);not null" json:"subject"`
+	Body      string    `gorm:"column:body;type:text;not null" json:"body"` // HTML
+	UpdatedBy *int64    `gorm:"column:updated_by" json:"updated_by,omitempty"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for CompanyEmailTemplate
+func (CompanyEmailTemplate) TableName() string {
+	return "company_email_templates"
+}
+
+// RenderedEmail is the subject and HTML body of an email ready to be sent
+type RenderedEmail struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// templatePlaceholder matches a {{variable}} placeholder, spaces inside the braces allowed
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+
+// Company templates are parsed with delimiters no template text can contain, so the only
+// actions are the variable lookups placeholders are turned into: no functions, no pipelines
+const (
+	sandboxLeftDelim  = "\x00["
+	sandboxRightDelim = "]\x00"
+)
+
+// CompiledCompanyTemplate is a company email template checked against the variables of
+// its event and ready to render
+type CompiledCompanyTemplate struct {
+	event   string
+	subject *texttemplate.Template
+	body    *htmltemplate.Template
+}
+
+// CompileCompanyTemplate checks that subject and body only use the variables of event and
+// compiles them. Variable values are HTML-escaped in the body.
+func CompileCompanyTemplate(event, subject, body string) (*CompiledCompanyTemplate, error) {
+	allowed, ok := CompanyTemplateVariables[event]
+	if !ok {
+		return nil, ErrInvalidTemplateEvent
+	}
+
+	subjectText, err := sandboxTemplate("subject", subject, allowed)
+	if err != nil {
+		return nil, err
+	}
+	bodyText, err := sandboxTemplate("body", body, allowed)
+	if err != nil {
+		return nil, err
+	}
+
+	compiled := &CompiledCompanyTemplate{event: event}
+	compiled.subject, err = texttemplate.New("subject").Delims(sandboxLeftDelim, sandboxRightDelim).Parse(subjectText)
+	if err != nil {
+		return nil, ErrInvalidTemplateSyntax.WithField("subject", err.Error())
+	}
+	compiled.body, err = htmltemplate.New("body").Delims(sandboxLeftDelim, sandboxRightDelim).Parse(bodyText)
+	if err != nil {
+		return nil, ErrInvalidTemplateSyntax.WithField("body", err.Error())
+	}
+	return compiled, nil
+}
+
+// sandboxTemplate turns the placeholders of text into variable lookups, rejecting
+// variables not in allowed and braces that do not form a placeholder
+func sandboxTemplate(field, text string, allowed []string) (string, error) {
+	text = strings.ReplaceAll(text, "\x00", "")
+
+	var unknown []string
+	out := templatePlaceholder.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := templatePlaceholder.FindStringSubmatch(placeholder)[1]
+		if !slices.Contains(allowed, name) {
+			unknown = append(unknown, placeholder)
+			return ""
+		}
+		return sandboxLeftDelim + "." + name + sandboxRightDelim
+	})
+
+	if len(unknown) > 0 {
+		return "", ErrUnknownTemplateVariable.WithField(field, fmt.Sprintf("unknown variables %s; allowed are %s",
+			strings.Join(unknown, ", "), "{{"+strings.Join(allowed, "}}, {{")+"}}"))
+	}
+	if strings.Contains(out, "{{") || strings.Contains(out, "}}") {
+		return "", ErrInvalidTemplateSyntax.WithField(field, "every {{ must be closed by }} around a variable name")
+	}
+	return out, nil
+}
+
+// Render fills in the template's variables; variables missing from vars are left empty
+func (t *CompiledCompanyTemplate) Render(vars map[string]string) (*RenderedEmail, error) {
+	data := make(map[string]string, len(CompanyTemplateVariables[t.event]))
+	for _, name := range CompanyTemplateVariables[t.event] {
+		data[name] = vars[name]
+	}
+
+	var subject, body bytes.Buffer
+	if err := t.subject.Execute(&subject, data); err != nil {
+		return nil, fmt.Errorf("failed to render subject: %w", err)
+	}
+	if err := t.body.Execute(&body, data); err != nil {
+		return nil, fmt.Errorf("failed to render body: %w", err)
+	}
+
+	// A subject is a single header line
+	return &RenderedEmail{
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Body:    fmt.Sprintf(companyTemplateLayout, body.String()),
+	}, nil
+}
+
+// companyTemplateLayout frames a rendered company template body like the platform emails
+const companyTemplateLayout = `<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+%s
+    </div>
+</body>
+</html>
+`
+
+// CompanyTemplateInput holds the subject and body of a company email template
+type CompanyTemplateInput struct {
+	EventType string
+	Subject   string
+	Body      string
+}
+
+// CompanyTemplateRepository defines the interface for company email template data operations
+type CompanyTemplateRepository interface {
+	// Create inserts a new template, returning ErrCompanyTemplateExists when the company
+	// already has one for the event
+	Create(ctx context.Context, t *CompanyEmailTemplate) error
+
+	// FindByID finds a template by ID, returning nil when it does not exist
+	FindByID(ctx context.Context, id int64) (*CompanyEmailTemplate, error)
+
+	// FindByCompanyAndEvent finds a company's template for event, returning nil when it has none
+	FindByCompanyAndEvent(ctx context.Context, companyID int64, event string) (*CompanyEmailTemplate, error)
+
+	// ListByCompany lists a company's templates ordered by event
+	ListByCompany(ctx context.Context, companyID int64) ([]CompanyEmailTemplate, error)
+
+	// Update saves a template's subject and body
+	Update(ctx context.Context, t *CompanyEmailTemplate) error
+
+	// Delete deletes a template
+	Delete(ctx context.Context, id int64) error
+}
+
+// CompanyTemplateRenderer renders the company's own version of a candidate email
+type CompanyTemplateRenderer interface {
+	// RenderCompanyTemplate renders the company's template for event with vars. It returns
+	// nil when the company has no template for the event, so the platform default is sent.
+	RenderCompanyTemplate(ctx context.Context, companyID int64, event string, vars map[string]string) (*RenderedEmail, error)
+}
+
+// CompanyTemplateService manages company email templates
+type CompanyTemplateService interface {
+	CompanyTemplateRenderer
+
+	// ListTemplates lists a company's templates ordered by event
+	ListTemplates(ctx context.Context, companyID int64) ([]CompanyEmailTemplate, error)
+
+	// CreateTemplate adds the company's template for an event it has none for yet
+	CreateTemplate(ctx context.Context, companyID, userID int64, input CompanyTemplateInput) (*CompanyEmailTemplate, error)
+
+	// UpdateTemplate replaces the subject and body of one of the company's templates
+	UpdateTemplate(ctx context.Context, companyID, templateID, userID int64, input CompanyTemplateInput) (*CompanyEmailTemplate, error)
+
+	// DeleteTemplate deletes one of the company's templates, restoring the platform default
+	DeleteTemplate(ctx context.Context, companyID, templateID int64) error
+
+	// PreviewTemplate renders a template with sample data and the company's name without saving it
+	PreviewTemplate(ctx context.Context, companyID int64, input CompanyTemplateInput) (*RenderedEmail, error)
+}
@@ -0,0 +1,28 @@
+package email
+
+import "keerja-backend/internal/apperror"
+
+var (
+	// ErrQueuedEmailNotFound is returned when the queued email does not exist
+	ErrQueuedEmailNotFound = apperror.NotFound("QUEUED_EMAIL_NOT_FOUND", "queued email not found")
+
+	// ErrQueuedEmailNotDead is returned when retrying an email that has not been given up on
+	ErrQueuedEmailNotDead = apperror.Conflict("QUEUED_EMAIL_NOT_DEAD", "only dead emails can be retried")
+)
+
+var (
+	// ErrCompanyTemplateNotFound is returned when the email template does not exist or belongs to another company
+	ErrCompanyTemplateNotFound = apperror.NotFound("EMAIL_TEMPLATE_NOT_FOUND", "email template not found")
+
+	// ErrCompanyTemplateExists is returned when the company already has a template for the event
+	ErrCompanyTemplateExists = apperror.Conflict("EMAIL_TEMPLATE_EXISTS", "company already has an email template for this event; update it instead")
+
+	// ErrInvalidTemplateEvent is returned for an event companies cannot write templates for
+	ErrInvalidTemplateEvent = apperror.Validation("INVALID_EMAIL_TEMPLATE_EVENT", "event_type must be application_rejected, application_shortlisted, interview_scheduled or offer_made").WithField("event_type", "must be application_rejected, application_shortlisted, interview_scheduled or offer_made")
+
+	// ErrUnknownTemplateVariable is returned when a template uses a variable its event does not provide
+	ErrUnknownTemplateVariable = apperror.Validation("UNKNOWN_EMAIL_TEMPLATE_VARIABLE", "email template uses variables that are not available for its event")
+
+	// ErrInvalidTemplateSyntax is returned when template braces do not form {{variable}} placeholders
+	ErrInvalidTemplateSyntax = apperror.Validation("INVALID_EMAIL_TEMPLATE_SYNTAX", "email template placeholders must be written as {{variable}}")
+)
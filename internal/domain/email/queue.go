@@ -0,0 +1,106 @@
+package email
+
+import (
+	"context"
+	"time"
+)
+
+// Email queue statuses
+const (
+	QueueStatusPending = "pending" // Waiting for its next attempt
+	QueueStatusSent    = "sent"
+	QueueStatusDead    = "dead" // Gave up after the maximum number of attempts
+)
+
+// Queued email templates; each is sent through the EmailService method of the same name
+const (
+	QueueTemplateWelcome               = "welcome"
+	QueueTemplateJobApplication        = "job_application"
+	QueueTemplateJobStatusUpdate       = "job_status_update"
+	QueueTemplateCompanyTemplate       = "company_template"
+	QueueTemplateInterviewInvitation   = "interview_invitation"
+	QueueTemplateInterviewReminder     = "interview_reminder"
+	QueueTemplateInterviewCancellation = "interview_cancellation"
+	QueueTemplateCompanyInvitation     = "company_invitation"
+	QueueTemplateEmployerInvitation    = "employer_invitation"
+	QueueTemplateInvitationAccepted    = "invitation_accepted"
+	QueueTemplateInvitationExpired     = "invitation_expired"
+	QueueTemplateJobReview             = "job_review"
+	QueueTemplateStageReminder         = "stage_reminder"
+	QueueTemplateFollowedCompanyJob    = "followed_company_job"
+	QueueTemplateVerificationExpiry    = "verification_expiry"
+	QueueTemplateJobExpiryReminder     = "job_expiry_reminder"
+	QueueTemplateInterviewBooking      = "interview_booking"
+	QueueTemplateDataExport            = "data_export"
+	QueueTemplateWebhookDisabled       = "webhook_disabled"
+	QueueTemplateWeeklyDigest          = "weekly_digest"
+)
+
+// QueuedEmail is an email waiting in the outbox to be sent by the email queue worker
+type QueuedEmail struct {
+	ID            int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Recipient     string     `gorm:"column:recipient;type:varchar(255);not null" json:"recipient"`
+	Template      string     `gorm:"column:template;type:varchar(50);not null" json:"template"`
+	Payload       string     `gorm:"column:payload;type:jsonb;not null" json:"payload"`                  // JSON-encoded template arguments
+	Locale        string     `gorm:"column:locale;type:varchar(10);not null;default:'id'" json:"locale"` // Language the email is rendered in
+	DedupeKey     string     `gorm:"column:dedupe_key;type:varchar(64);not null" json:"-"`
+	Status        string     `gorm:"column:status;type:varchar(20);not null;default:'pending'" json:"status"`
+	Attempts      int        `gorm:"column:attempts;not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;not null" json:"next_attempt_at"`
+	LastError     *string    `gorm:"column:last_error;type:text" json:"last_error,omitempty"`
+	SentAt        *time.Time `gorm:"column:sent_at" json:"sent_at,omitempty"`
+	CreatedAt     time.Time  `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for QueuedEmail
+func (QueuedEmail) TableName() string {
+	return "email_queue"
+}
+
+// EmailQueueRepository defines the interface for email queue data operations
+type EmailQueueRepository interface {
+	// Enqueue inserts a pending email. It returns false without inserting when a pending
+	// email with the same dedupe key already exists.
+	Enqueue(ctx context.Context, queued *QueuedEmail) (bool, error)
+
+	// ClaimDue counts an attempt on up to limit pending emails whose next attempt is due
+	// and pushes their next attempt to leaseUntil, so overlapping workers never send the
+	// same email twice and emails of a crashed worker are retried after the lease
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]QueuedEmail, error)
+
+	// MarkSent marks an email sent
+	MarkSent(ctx context.Context, id int64, sentAt time.Time) error
+
+	// MarkRetry records a failed attempt and when to try again
+	MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error
+
+	// MarkDead records a failed attempt after which the email is no longer retried
+	MarkDead(ctx context.Context, id int64, lastError string) error
+
+	// FindByID finds a queued email by ID, returning nil when it does not exist
+	FindByID(ctx context.Context, id int64) (*QueuedEmail, error)
+
+	// ListByStatus lists queued emails with status, newest first
+	ListByStatus(ctx context.Context, status string, page, limit int) ([]QueuedEmail, int64, error)
+
+	// Requeue resets a dead email to pending with no attempts, due at now
+	Requeue(ctx context.Context, id int64, now time.Time) error
+}
+
+// EmailQueueService queues emails and sends them with retries
+type EmailQueueService interface {
+	// Enqueue queues an email built from template and its arguments. Queuing the same email
+	// again while it is still pending has no effect.
+	Enqueue(ctx context.Context, recipient, template string, payload interface{}) error
+
+	// ProcessDue sends the emails that are due, rescheduling failures with exponential
+	// backoff, and returns how many were sent
+	ProcessDue(ctx context.Context) (int, error)
+
+	// ListEmails lists queued emails with status, newest first
+	ListEmails(ctx context.Context, status string, page, limit int) ([]QueuedEmail, int64, error)
+
+	// RetryDead queues a dead email to be sent again
+	RetryDead(ctx context.Context, id int64) error
+}
@@ -1,6 +1,9 @@
 package email
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // EmailService defines the interface for email operations
 type EmailService interface {
@@ -22,12 +25,21 @@ type EmailService interface {
 	// SendJobApplicationEmail sends job application confirmation
 	SendJobApplicationEmail(ctx context.Context, to, jobTitle, companyName string) error
 
-	// SendInterviewInvitationEmail sends interview invitation
-	SendInterviewInvitationEmail(ctx context.Context, to, jobTitle string, interviewDate string) error
+	// SendInterviewInvitationEmail sends interview invitation with an iCalendar REQUEST attached
+	SendInterviewInvitationEmail(ctx context.Context, to string, interview InterviewEmailData) error
+
+	// SendInterviewReminderEmail sends an upcoming interview reminder with the current iCalendar invite attached
+	SendInterviewReminderEmail(ctx context.Context, to string, interview InterviewEmailData) error
+
+	// SendInterviewCancellationEmail sends an interview cancellation with an iCalendar CANCEL attached
+	SendInterviewCancellationEmail(ctx context.Context, to string, interview InterviewEmailData) error
 
 	// SendJobStatusUpdateEmail sends job status update notification
 	SendJobStatusUpdateEmail(ctx context.Context, to, jobTitle, status string) error
 
+	// SendCompanyTemplateEmail sends a candidate email rendered from a company's own template
+	SendCompanyTemplateEmail(ctx context.Context, to string, rendered RenderedEmail) error
+
 	// SendBulkEmail sends email to multiple recipients
 	SendBulkEmail(ctx context.Context, recipients []string, subject, body string) error
 
@@ -52,11 +64,69 @@ type EmailService interface {
 	// SendCompanyInvitationEmail sends company employee invitation email
 	SendCompanyInvitationEmail(ctx context.Context, to, name, companyName, inviterName, position, role, inviteURL string, expiryDays int) error
 
+	// SendEmployerInvitationEmail sends an invitation email with the accept link built from the invitation token
+	SendEmployerInvitationEmail(ctx context.Context, to, name, companyName, inviterName, position, role, token string, expiresAt time.Time) error
+
 	// SendInvitationAcceptedEmail sends notification when invitation is accepted
 	SendInvitationAcceptedEmail(ctx context.Context, to, inviterName, memberName, memberEmail, companyName, position, role string) error
 
 	// SendInvitationExpiredEmail sends notification when invitation expires
 	SendInvitationExpiredEmail(ctx context.Context, to, name, companyName, inviterName, position string) error
+
+	// SendJobReviewEmail notifies the job owner about an admin approval or rejection
+	SendJobReviewEmail(ctx context.Context, to, name, jobTitle, companyName, status, message string) error
+
+	// SendStageReminderEmail sends a recruiter the daily summary of applications stuck in a stage
+	SendStageReminderEmail(ctx context.Context, to, name, companyName string, thresholdDays int, groups []StageReminderGroup) error
+
+	// SendFollowedCompanyJobEmail tells a follower that a company they follow published a new job
+	SendFollowedCompanyJobEmail(ctx context.Context, to, name, companyName, jobTitle, jobSlug string) error
+
+	// SendVerificationExpiryEmail warns a company owner or admin that the company verification expires soon
+	SendVerificationExpiryEmail(ctx context.Context, to, name, companyName string, daysLeft int, expiry time.Time) error
+
+	// SendJobExpiryReminderEmail reminds the employer who posted a job that it expires soon;
+	// autoExtend tells them the job will be extended instead of expired
+	SendJobExpiryReminderEmail(ctx context.Context, to, name, jobTitle, companyName string, daysLeft int, expiry time.Time, autoExtend bool) error
+
+	// SendInterviewBookingEmail sends a candidate the link to book an interview slot with the token
+	SendInterviewBookingEmail(ctx context.Context, to, name, jobTitle, companyName, token string, expiresAt time.Time) error
+
+	// SendDataExportEmail sends a user the link to download their data export with the token
+	SendDataExportEmail(ctx context.Context, to, name, token string, expiresAt time.Time) error
+
+	// SendWebhookDisabledEmail tells a company owner or admin that a webhook was disabled after repeated failed deliveries
+	SendWebhookDisabledEmail(ctx context.Context, to, name, companyName, webhookURL string, failures int) error
+
+	// SendWeeklyDigestEmail sends a recruiter the company's weekly activity digest
+	SendWeeklyDigestEmail(ctx context.Context, to, name, companyName string, digest *WeeklyDigest) error
+
+	// SendDomainVerificationEmail sends the code proving the company owns domain to a mailbox on it
+	SendDomainVerificationEmail(ctx context.Context, to, companyName, domain, code string, expiresAt time.Time) error
+}
+
+// InterviewEmailData holds the interview details rendered into interview emails and calendar invites
+type InterviewEmailData struct {
+	InterviewID   int64
+	CandidateName string
+	JobTitle      string
+	CompanyName   string
+	ScheduledAt   time.Time
+	Duration      time.Duration
+	Timezone      string // IANA name the times are shown in; the attached invite is always UTC
+	InterviewType string
+	MeetingLink   string
+	Location      string
+	Sequence      int
+	Message       string
+	Custom        *RenderedEmail // The company's own invitation, sent instead of the platform template
+}
+
+// Attachment is a file attached to an outgoing email
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
 }
 
 // EmailFilter defines filters for email logs
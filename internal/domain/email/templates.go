@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"fmt"
 	"html/template"
+	"time"
+
+	"keerja-backend/internal/i18n"
 )
 
 // EmailTemplate represents email template types
@@ -16,6 +19,8 @@ const (
 	TemplateWelcome            EmailTemplate = "welcome"
 	TemplateApplicationUpdate  EmailTemplate = "application_update"
 	TemplateInterviewInvite    EmailTemplate = "interview_invite"
+	TemplateInterviewReminder  EmailTemplate = "interview_reminder"
+	TemplateInterviewCancelled EmailTemplate = "interview_cancelled"
 	TemplateJobAlert           EmailTemplate = "job_alert"
 	TemplateCompanyVerified    EmailTemplate = "company_verified"
 	TemplateOTP                EmailTemplate = "otp"
@@ -23,6 +28,16 @@ const (
 	TemplateCompanyInvitation  EmailTemplate = "company_invitation"
 	TemplateInvitationAccepted EmailTemplate = "invitation_accepted"
 	TemplateInvitationExpired  EmailTemplate = "invitation_expired"
+	TemplateJobReviewed        EmailTemplate = "job_reviewed"
+	TemplateStageReminder      EmailTemplate = "stage_reminder"
+	TemplateFollowedCompanyJob EmailTemplate = "followed_company_job"
+	TemplateVerificationExpiry EmailTemplate = "verification_expiry"
+	TemplateJobExpiryReminder  EmailTemplate = "job_expiry_reminder"
+	TemplateInterviewBooking   EmailTemplate = "interview_booking"
+	TemplateDataExport         EmailTemplate = "data_export"
+	TemplateWebhookDisabled    EmailTemplate = "webhook_disabled"
+	TemplateWeeklyDigest       EmailTemplate = "weekly_digest"
+	TemplateDomainVerification EmailTemplate = "domain_verification"
 )
 
 // TemplateData holds data for email templates
@@ -40,6 +55,7 @@ type TemplateData struct {
 	InterviewDate string
 	InterviewTime string
 	InterviewURL  string
+	Location      string
 	ApplicationID string
 	Status        string
 	Message       string
@@ -53,6 +69,76 @@ type TemplateData struct {
 	Role        string
 	InviteURL   string
 	ExpiryDays  string
+	// Stage reminder specific fields
+	ThresholdDays  string
+	ReminderGroups []StageReminderGroup
+	// Followed company job specific fields
+	JobURL string
+	// Verification expiry specific fields
+	ExpiryDate string
+	// Job expiry reminder specific fields
+	AutoExtend bool
+	// Interview booking specific fields
+	BookingURL string
+	// Weekly digest specific fields
+	Digest *WeeklyDigest
+	// Domain verification specific fields
+	Domain string
+}
+
+// StageReminderGroup lists one job's applications that have been waiting too long in a stage
+type StageReminderGroup struct {
+	JobTitle     string
+	Applications []StageReminderItem
+}
+
+// StageReminderItem is a single application waiting on a recruiter
+type StageReminderItem struct {
+	ApplicantName string
+	StageName     string
+	DaysInStage   int
+}
+
+// WeeklyDigest is a company's weekly activity summary sent to its recruiters. The URL
+// fields are deep links filled in by the email service when the digest is rendered.
+type WeeklyDigest struct {
+	Jobs              []WeeklyDigestJob         `json:"jobs"`
+	AwaitingAction    int64                     `json:"awaiting_action"`
+	Interviews        []WeeklyDigestInterview   `json:"interviews"`
+	ExpiringJobs      []WeeklyDigestExpiringJob `json:"expiring_jobs"`
+	NewFollowers      int64                     `json:"new_followers"`
+	TotalFollowers    int64                     `json:"total_followers"`
+	AwaitingActionURL string                    `json:"-"`
+	InterviewsURL     string                    `json:"-"`
+	FollowersURL      string                    `json:"-"`
+}
+
+// WeeklyDigestJob is a job's application activity in the weekly digest
+type WeeklyDigestJob struct {
+	JobID           int64  `json:"job_id"`
+	Title           string `json:"title"`
+	NewApplications int64  `json:"new_applications"`
+	AwaitingAction  int64  `json:"awaiting_action"`
+	URL             string `json:"-"`
+}
+
+// WeeklyDigestInterview is an upcoming interview in the weekly digest
+type WeeklyDigestInterview struct {
+	ApplicationID int64     `json:"application_id"`
+	CandidateName string    `json:"candidate_name"`
+	JobTitle      string    `json:"job_title"`
+	ScheduledAt   time.Time `json:"scheduled_at"`
+	Date          string    `json:"-"`
+	URL           string    `json:"-"`
+}
+
+// WeeklyDigestExpiringJob is a published job about to expire in the weekly digest
+type WeeklyDigestExpiringJob struct {
+	JobID     int64     `json:"job_id"`
+	Title     string    `json:"title"`
+	ExpiredAt time.Time `json:"expired_at"`
+	Date      string    `json:"-"`
+	URL       string    `json:"-"`
 }
 
 // Templates stores HTML templates
@@ -198,6 +284,7 @@ var templates = map[EmailTemplate]string{
         <div style="background-color: #f5f5f5; padding: 15px; border-radius: 4px; margin: 20px 0;">
             <p style="margin: 0;"><strong>Tanggal:</strong> {{.InterviewDate}}</p>
             <p style="margin: 10px 0;"><strong>Waktu:</strong> {{.InterviewTime}}</p>
+            {{if .Location}}<p style="margin: 10px 0;"><strong>Lokasi:</strong> {{.Location}}</p>{{end}}
             <p style="margin: 10px 0 0 0;"><strong>Link Interview:</strong> <a href="{{.InterviewURL}}" style="color: #2196F3;">{{.InterviewURL}}</a></p>
         </div>
         <p>{{.Message}}</p>
@@ -206,6 +293,7 @@ var templates = map[EmailTemplate]string{
                 Join Interview
             </a>
         </div>
+        <p>Undangan kalender terlampir agar Anda dapat menambahkannya ke kalender Anda.</p>
         <p>Pastikan Anda sudah siap dan hadir tepat waktu. Good luck!</p>
         <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
         <p style="font-size: 12px; color: #999;">
@@ -215,6 +303,66 @@ var templates = map[EmailTemplate]string{
     </div>
 </body>
 </html>
+`,
+
+	TemplateInterviewReminder: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Pengingat Interview</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #9C27B0;">Pengingat Interview</h2>
+        <p>Halo {{.Name}},</p>
+        <p>Ini adalah pengingat untuk interview posisi <strong>{{.JobTitle}}</strong> di <strong>{{.CompanyName}}</strong>.</p>
+        <div style="background-color: #f5f5f5; padding: 15px; border-radius: 4px; margin: 20px 0;">
+            <p style="margin: 0;"><strong>Tanggal:</strong> {{.InterviewDate}}</p>
+            <p style="margin: 10px 0;"><strong>Waktu:</strong> {{.InterviewTime}}</p>
+            {{if .Location}}<p style="margin: 10px 0;"><strong>Lokasi:</strong> {{.Location}}</p>{{end}}
+            <p style="margin: 10px 0 0 0;"><strong>Link Interview:</strong> <a href="{{.InterviewURL}}" style="color: #2196F3;">{{.InterviewURL}}</a></p>
+        </div>
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="{{.InterviewURL}}" style="background-color: #9C27B0; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; display: inline-block;">
+                Join Interview
+            </a>
+        </div>
+        <p>Undangan kalender terbaru terlampir. Pastikan Anda hadir tepat waktu. Good luck!</p>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            © {{.Year}} Keerja. All rights reserved.<br>
+            Butuh bantuan? Hubungi kami di {{.SupportEmail}}
+        </p>
+    </div>
+</body>
+</html>
+`,
+
+	TemplateInterviewCancelled: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Interview Dibatalkan</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #f44336;">Interview Dibatalkan</h2>
+        <p>Halo {{.Name}},</p>
+        <p>Interview untuk posisi <strong>{{.JobTitle}}</strong> di <strong>{{.CompanyName}}</strong> yang dijadwalkan pada {{.InterviewDate}} pukul {{.InterviewTime}} telah dibatalkan.</p>
+        {{if .Message}}<div style="background-color: #f5f5f5; padding: 15px; border-radius: 4px; margin: 20px 0;">
+            <p style="margin: 0;"><strong>Alasan:</strong> {{.Message}}</p>
+        </div>{{end}}
+        <p>Buka lampiran kalender untuk menghapus acara ini dari kalender Anda. Perusahaan akan menghubungi Anda jika ada jadwal pengganti.</p>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            © {{.Year}} Keerja. All rights reserved.<br>
+            Butuh bantuan? Hubungi kami di {{.SupportEmail}}
+        </p>
+    </div>
+</body>
+</html>
 `,
 
 	TemplateOTP: `
@@ -545,11 +693,343 @@ var templates = map[EmailTemplate]string{
 </body>
 </html>
 `,
+
+	TemplateJobReviewed: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Hasil Review Lowongan</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #2196F3;">Hasil Review Lowongan</h2>
+        <p>Halo {{.Name}},</p>
+        <p>Lowongan <strong>{{.JobTitle}}</strong> di <strong>{{.CompanyName}}</strong> telah direview oleh tim Keerja.</p>
+        <div style="background-color: #f5f5f5; padding: 15px; border-radius: 4px; margin: 20px 0;">
+            <p style="margin: 0;"><strong>Status:</strong> <span style="color: #2196F3;">{{.Status}}</span></p>
+            {{if .Message}}<p style="margin: 10px 0 0 0;"><strong>Catatan:</strong> {{.Message}}</p>{{end}}
+        </div>
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="{{.DashboardURL}}" style="background-color: #2196F3; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; display: inline-block;">
+                Lihat Lowongan
+            </a>
+        </div>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            © {{.Year}} Keerja. All rights reserved.<br>
+            Butuh bantuan? Hubungi kami di {{.SupportEmail}}
+        </p>
+    </div>
+</body>
+</html>
+`,
+
+	TemplateStageReminder: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Lamaran Menunggu Tindak Lanjut</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #FF9800;">Lamaran Menunggu Tindak Lanjut</h2>
+        <p>Halo {{.Name}},</p>
+        <p>Lamaran berikut di <strong>{{.CompanyName}}</strong> sudah berada di tahap yang sama lebih dari {{.ThresholdDays}} hari:</p>
+        {{range .ReminderGroups}}
+        <div style="background-color: #f5f5f5; padding: 15px; border-radius: 4px; margin: 20px 0;">
+            <p style="margin: 0 0 10px 0;"><strong>{{.JobTitle}}</strong></p>
+            <ul style="margin: 0; padding-left: 20px;">
+                {{range .Applications}}<li>{{.ApplicantName}} &mdash; {{.StageName}} ({{.DaysInStage}} hari)</li>{{end}}
+            </ul>
+        </div>
+        {{end}}
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="{{.DashboardURL}}" style="background-color: #FF9800; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; display: inline-block;">
+                Tinjau Lamaran
+            </a>
+        </div>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            Pengingat ini dapat dinonaktifkan atau diubah di pengaturan perusahaan.<br>
+            © {{.Year}} Keerja. All rights reserved.
+        </p>
+    </div>
+</body>
+</html>
+`,
+
+	TemplateFollowedCompanyJob: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Lowongan Baru</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #4CAF50;">Lowongan Baru di {{.CompanyName}}</h2>
+        <p>Halo {{.Name}},</p>
+        <p><strong>{{.CompanyName}}</strong>, perusahaan yang Anda ikuti, baru saja membuka lowongan:</p>
+        <div style="background-color: #f5f5f5; padding: 15px; border-radius: 4px; margin: 20px 0;">
+            <p style="margin: 0;"><strong>{{.JobTitle}}</strong></p>
+        </div>
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="{{.JobURL}}" style="background-color: #4CAF50; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; display: inline-block;">
+                Lihat Lowongan
+            </a>
+        </div>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            Email ini dikirim karena Anda mengikuti {{.CompanyName}}. Notifikasi email dapat dinonaktifkan di pengaturan akun.<br>
+            © {{.Year}} Keerja. All rights reserved.
+        </p>
+    </div>
+</body>
+</html>
+`,
+
+	TemplateVerificationExpiry: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Verifikasi Perusahaan Akan Berakhir</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #FF9800;">Verifikasi Perusahaan Akan Berakhir</h2>
+        <p>Halo {{.Name}},</p>
+        <p>Verifikasi <strong>{{.CompanyName}}</strong> akan berakhir dalam <strong>{{.ExpiryDays}} hari</strong>, pada tanggal {{.ExpiryDate}}.</p>
+        <p>Setelah verifikasi berakhir, lowongan yang sedang menunggu review tidak dapat dipublikasikan hingga verifikasi diperbarui. Perbarui verifikasi sekarang agar proses rekrutmen Anda tidak terganggu.</p>
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="{{.DashboardURL}}" style="background-color: #FF9800; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; display: inline-block;">
+                Perbarui Verifikasi
+            </a>
+        </div>
+        <p>Jika ada pertanyaan, hubungi kami di {{.SupportEmail}}.</p>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            © {{.Year}} Keerja. All rights reserved.
+        </p>
+    </div>
+</body>
+</html>
+`,
+
+	TemplateJobExpiryReminder: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Lowongan Akan Berakhir</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #FF9800;">Lowongan Akan Berakhir</h2>
+        <p>Halo {{.Name}},</p>
+        <p>Lowongan <strong>{{.JobTitle}}</strong> di {{.CompanyName}} akan berakhir dalam <strong>{{.ExpiryDays}} hari</strong>, pada tanggal {{.ExpiryDate}}.</p>
+        {{if .AutoExtend}}
+        <p>Perpanjangan otomatis aktif untuk lowongan ini, jadi lowongan akan diperpanjang secara otomatis dan tetap tayang. Nonaktifkan perpanjangan otomatis jika posisi ini sudah terisi.</p>
+        {{else}}
+        <p>Setelah berakhir, lowongan tidak lagi tampil dan tidak menerima lamaran baru. Perpanjang lowongan jika Anda masih mencari kandidat.</p>
+        {{end}}
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="{{.DashboardURL}}" style="background-color: #FF9800; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; display: inline-block;">
+                Kelola Lowongan
+            </a>
+        </div>
+        <p>Jika ada pertanyaan, hubungi kami di {{.SupportEmail}}.</p>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            © {{.Year}} Keerja. All rights reserved.
+        </p>
+    </div>
+</body>
+</html>
+`,
+
+	TemplateInterviewBooking: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Pilih Jadwal Interview</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #9C27B0;">Pilih Jadwal Interview</h2>
+        <p>Halo {{.Name}},</p>
+        <p>Selamat! Anda diundang interview untuk posisi <strong>{{.JobTitle}}</strong> di <strong>{{.CompanyName}}</strong>.</p>
+        <p>Silakan pilih salah satu jadwal yang tersedia. Undangan interview akan dikirim setelah Anda memilih jadwal.</p>
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="{{.BookingURL}}" style="background-color: #9C27B0; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; display: inline-block;">
+                Pilih Jadwal
+            </a>
+        </div>
+        <p>Tautan ini berlaku sampai {{.ExpiryDate}} dan hanya dapat digunakan sekali.</p>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            © {{.Year}} Keerja. All rights reserved.<br>
+            Butuh bantuan? Hubungi kami di {{.SupportEmail}}
+        </p>
+    </div>
+</body>
+</html>
+`,
+	TemplateDataExport: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Salinan Data Anda Siap Diunduh</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #4CAF50;">Salinan Data Anda Siap Diunduh</h2>
+        <p>Halo {{.Name}},</p>
+        <p>Salinan data akun Keerja yang Anda minta sudah siap. Berkas ini berisi profil, lamaran, ulasan, dan data tersimpan Anda dalam format JSON.</p>
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="{{.DownloadURL}}" style="background-color: #4CAF50; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; display: inline-block;">
+                Unduh Data
+            </a>
+        </div>
+        <p>Tautan ini berlaku sampai {{.ExpiryDate}}. Jangan bagikan tautan ini kepada siapa pun.</p>
+        <p>Jika Anda tidak meminta salinan data ini, segera ubah kata sandi Anda dan hubungi kami.</p>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            © {{.Year}} Keerja. All rights reserved.<br>
+            Butuh bantuan? Hubungi kami di {{.SupportEmail}}
+        </p>
+    </div>
+</body>
+</html>
+`,
+	TemplateWebhookDisabled: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Webhook Perusahaan Dinonaktifkan</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #F44336;">Webhook Perusahaan Dinonaktifkan</h2>
+        <p>Halo {{.Name}},</p>
+        <p>Webhook <strong>{{.CompanyName}}</strong> ke <strong>{{.WebhookURL}}</strong> dinonaktifkan karena {{.Failures}} pengiriman berturut-turut gagal.</p>
+        <p>Event yang terjadi selama webhook nonaktif tidak dikirim. Periksa endpoint Anda, lalu aktifkan kembali webhook dari dashboard perusahaan.</p>
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="{{.DashboardURL}}" style="background-color: #F44336; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; display: inline-block;">
+                Buka Dashboard
+            </a>
+        </div>
+        <p>Jika ada pertanyaan, hubungi kami di {{.SupportEmail}}.</p>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            © {{.Year}} Keerja. All rights reserved.
+        </p>
+    </div>
+</body>
+</html>
+`,
+	TemplateWeeklyDigest: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Ringkasan Mingguan Rekrutmen</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #2196F3;">Ringkasan Mingguan {{.CompanyName}}</h2>
+        <p>Halo {{.Name}},</p>
+        <p>Berikut aktivitas rekrutmen <strong>{{.CompanyName}}</strong> selama 7 hari terakhir dan yang akan datang minggu ini.</p>
+        {{with .Digest}}
+        {{if .Jobs}}
+        <div style="background-color: #f5f5f5; padding: 15px; border-radius: 4px; margin: 20px 0;">
+            <p style="margin: 0 0 10px 0;"><strong>Lamaran per Lowongan</strong></p>
+            <ul style="margin: 0; padding-left: 20px;">
+                {{range .Jobs}}<li><a href="{{.URL}}">{{.Title}}</a> &mdash; {{.NewApplications}} lamaran baru{{if .AwaitingAction}}, {{.AwaitingAction}} menunggu tindakan{{end}}</li>{{end}}
+            </ul>
+        </div>
+        {{end}}
+        {{if .AwaitingAction}}
+        <p><strong>{{.AwaitingAction}} lamaran</strong> masih berstatus dilamar lebih dari 3 hari. <a href="{{.AwaitingActionURL}}">Tinjau sekarang</a>.</p>
+        {{end}}
+        {{if .Interviews}}
+        <div style="background-color: #f5f5f5; padding: 15px; border-radius: 4px; margin: 20px 0;">
+            <p style="margin: 0 0 10px 0;"><strong>Interview 7 Hari ke Depan</strong> (<a href="{{.InterviewsURL}}">lihat semua</a>)</p>
+            <ul style="margin: 0; padding-left: 20px;">
+                {{range .Interviews}}<li>{{.Date}} &mdash; <a href="{{.URL}}">{{.CandidateName}}</a>, {{.JobTitle}}</li>{{end}}
+            </ul>
+        </div>
+        {{end}}
+        {{if .ExpiringJobs}}
+        <div style="background-color: #f5f5f5; padding: 15px; border-radius: 4px; margin: 20px 0;">
+            <p style="margin: 0 0 10px 0;"><strong>Lowongan Segera Berakhir</strong></p>
+            <ul style="margin: 0; padding-left: 20px;">
+                {{range .ExpiringJobs}}<li><a href="{{.URL}}">{{.Title}}</a> &mdash; berakhir {{.Date}}</li>{{end}}
+            </ul>
+        </div>
+        {{end}}
+        <p><a href="{{.FollowersURL}}">Pengikut</a>: {{.TotalFollowers}} ({{if .NewFollowers}}+{{.NewFollowers}}{{else}}tidak ada yang baru{{end}} minggu ini)</p>
+        {{end}}
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="{{.DashboardURL}}" style="background-color: #2196F3; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; display: inline-block;">
+                Buka Dashboard
+            </a>
+        </div>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            Ringkasan ini dapat dinonaktifkan di pengaturan perusahaan, dan notifikasi email di pengaturan akun.<br>
+            © {{.Year}} Keerja. All rights reserved.
+        </p>
+    </div>
+</body>
+</html>
+`,
+	TemplateDomainVerification: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Verifikasi Domain Perusahaan</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #4CAF50;">Verifikasi Domain Perusahaan</h2>
+        <p>Halo,</p>
+        <p><strong>{{.CompanyName}}</strong> ingin memverifikasi bahwa domain <strong>{{.Domain}}</strong> miliknya di Keerja. Setelah terverifikasi, rekan kerja dengan email di domain ini dapat meminta bergabung ke perusahaan.</p>
+        <p>Berikan kode berikut kepada admin perusahaan Anda:</p>
+        <div style="background-color: #f5f5f5; padding: 20px; text-align: center; margin: 30px 0; border-radius: 4px;">
+            <h1 style="color: #4CAF50; letter-spacing: 8px; margin: 0;">{{.OTPCode}}</h1>
+        </div>
+        <p>Kode ini berlaku sampai {{.ExpiryDate}}.</p>
+        <p>Jika Anda tidak mengenal permintaan ini, abaikan email ini; domain tidak akan terverifikasi tanpa kode ini.</p>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            © {{.Year}} Keerja. All rights reserved.<br>
+            Butuh bantuan? Hubungi kami di {{.SupportEmail}}
+        </p>
+    </div>
+</body>
+</html>
+`,
+}
+
+// localizedTemplates holds the template bodies translated from the default Indonesian ones
+var localizedTemplates = map[i18n.Locale]map[EmailTemplate]string{
+	i18n.English: templatesEN,
 }
 
-// RenderTemplate renders email template with data
-func RenderTemplate(templateType EmailTemplate, data TemplateData) (string, error) {
-	tmplStr, ok := templates[templateType]
+// RenderTemplate renders email template in locale with data. Templates that are not
+// translated into locale are rendered in the default locale.
+func RenderTemplate(templateType EmailTemplate, locale i18n.Locale, data TemplateData) (string, error) {
+	tmplStr, ok := localizedTemplates[locale][templateType]
+	if !ok {
+		tmplStr, ok = templates[templateType]
+	}
 	if !ok {
 		return "", fmt.Errorf("template %s not found", templateType)
 	}
@@ -567,26 +1047,12 @@ func RenderTemplate(templateType EmailTemplate, data TemplateData) (string, erro
 	return buf.String(), nil
 }
 
-// GetSubject returns email subject based on template type
-func GetSubject(templateType EmailTemplate) string {
-	subjects := map[EmailTemplate]string{
-		TemplateVerification:       "Verifikasi Email Anda - Keerja",
-		TemplateForgotPassword:     "Reset Password Akun Keerja Anda",
-		TemplatePasswordReset:      "Password Anda Telah Direset",
-		TemplateWelcome:            "Selamat Datang di Keerja!",
-		TemplateApplicationUpdate:  "Update Status Lamaran Pekerjaan",
-		TemplateInterviewInvite:    "Undangan Interview - Keerja",
-		TemplateJobAlert:           "Job Alert: Pekerjaan Baru Sesuai Preferensi Anda",
-		TemplateCompanyVerified:    "Perusahaan Anda Telah Terverifikasi",
-		TemplateOTP:                "Kode OTP Verifikasi - Keerja",
-		TemplateOTPRegistration:    "Verifikasi Email Registrasi - Keerja",
-		TemplateCompanyInvitation:  "Undangan Bergabung ke Tim - Keerja",
-		TemplateInvitationAccepted: "Undangan Diterima - Anggota Baru Bergabung",
-		TemplateInvitationExpired:  "Undangan Kadaluarsa - Keerja",
-	}
-
-	if subject, ok := subjects[templateType]; ok {
-		return subject
+// GetSubject returns the email subject in locale based on template type
+func GetSubject(templateType EmailTemplate, locale i18n.Locale) string {
+	t := i18n.New(locale)
+	key := "email.subject." + string(templateType)
+	if t.Has(key) {
+		return t.T(key)
 	}
-	return "Notifikasi dari Keerja"
+	return t.T("email.subject.default")
 }
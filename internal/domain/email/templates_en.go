@@ -0,0 +1,384 @@
+package email
+
+// templatesEN stores the English HTML templates. Templates missing here are sent in Indonesian.
+var templatesEN = map[EmailTemplate]string{
+	TemplateVerification: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Verify Your Email</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #4CAF50;">Welcome to Keerja!</h2>
+        <p>Hi {{.Name}},</p>
+        <p>Thank you for signing up for Keerja. Please verify your email by clicking the button below:</p>
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="{{.VerifyURL}}" style="background-color: #4CAF50; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; display: inline-block;">
+                Verify Email
+            </a>
+        </div>
+        <p>Or copy and paste the following link into your browser:</p>
+        <p style="word-break: break-all; color: #666;">{{.VerifyURL}}</p>
+        <p>This verification link expires in 24 hours.</p>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            If you did not sign up for Keerja, please ignore this email.<br>
+            Need help? Contact us at {{.SupportEmail}}
+        </p>
+    </div>
+</body>
+</html>
+`,
+
+	TemplateForgotPassword: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Reset Your Password</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #FF9800;">Reset Password</h2>
+        <p>Hi {{.Name}},</p>
+        <p>We received a request to reset the password of your account. Click the button below to create a new password:</p>
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="{{.ResetURL}}" style="background-color: #FF9800; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; display: inline-block;">
+                Reset Password
+            </a>
+        </div>
+        <p>Or copy and paste the following link into your browser:</p>
+        <p style="word-break: break-all; color: #666;">{{.ResetURL}}</p>
+        <p>This password reset link expires in 1 hour.</p>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            If you did not request a password reset, please ignore this email. Your password remains safe.<br>
+            Need help? Contact us at {{.SupportEmail}}
+        </p>
+    </div>
+</body>
+</html>
+`,
+
+	TemplateWelcome: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Welcome to Keerja!</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #4CAF50;">Welcome to Keerja!</h2>
+        <p>Hi {{.Name}},</p>
+        <p>Your email has been verified! You can now start using Keerja to find your dream job.</p>
+        <h3>Next Steps:</h3>
+        <ul>
+            <li>Complete your profile to increase your visibility</li>
+            <li>Upload your CV and supporting documents</li>
+            <li>Start searching and applying for jobs</li>
+            <li>Set up job alerts to be notified of new jobs</li>
+        </ul>
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="{{.DashboardURL}}" style="background-color: #4CAF50; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; display: inline-block;">
+                Go to Dashboard
+            </a>
+        </div>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            © {{.Year}} Keerja. All rights reserved.<br>
+            Need help? Contact us at {{.SupportEmail}}
+        </p>
+    </div>
+</body>
+</html>
+`,
+
+	TemplateApplicationUpdate: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Application Status Update</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #2196F3;">Application Status Update</h2>
+        <p>Hi {{.Name}},</p>
+        <p>The status of your application for <strong>{{.JobTitle}}</strong> at <strong>{{.CompanyName}}</strong> has been updated.</p>
+        <div style="background-color: #f5f5f5; padding: 15px; border-radius: 4px; margin: 20px 0;">
+            <p style="margin: 0;"><strong>Application ID:</strong> {{.ApplicationID}}</p>
+            <p style="margin: 10px 0;"><strong>Status:</strong> <span style="color: #2196F3;">{{.Status}}</span></p>
+            <p style="margin: 10px 0 0 0;"><strong>Message:</strong> {{.Message}}</p>
+        </div>
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="{{.DashboardURL}}" style="background-color: #2196F3; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; display: inline-block;">
+                View Details
+            </a>
+        </div>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            © {{.Year}} Keerja. All rights reserved.<br>
+            Need help? Contact us at {{.SupportEmail}}
+        </p>
+    </div>
+</body>
+</html>
+`,
+
+	TemplateInterviewInvite: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Interview Invitation</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #9C27B0;">Interview Invitation</h2>
+        <p>Hi {{.Name}},</p>
+        <p>Congratulations! You have been selected for an interview for <strong>{{.JobTitle}}</strong> at <strong>{{.CompanyName}}</strong>.</p>
+        <div style="background-color: #f5f5f5; padding: 15px; border-radius: 4px; margin: 20px 0;">
+            <p style="margin: 0;"><strong>Date:</strong> {{.InterviewDate}}</p>
+            <p style="margin: 10px 0;"><strong>Time:</strong> {{.InterviewTime}}</p>
+            {{if .Location}}<p style="margin: 10px 0;"><strong>Location:</strong> {{.Location}}</p>{{end}}
+            <p style="margin: 10px 0 0 0;"><strong>Interview Link:</strong> <a href="{{.InterviewURL}}" style="color: #2196F3;">{{.InterviewURL}}</a></p>
+        </div>
+        <p>{{.Message}}</p>
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="{{.InterviewURL}}" style="background-color: #9C27B0; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; display: inline-block;">
+                Join Interview
+            </a>
+        </div>
+        <p>A calendar invite is attached so you can add it to your calendar.</p>
+        <p>Make sure you are prepared and on time. Good luck!</p>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            © {{.Year}} Keerja. All rights reserved.<br>
+            Need help? Contact us at {{.SupportEmail}}
+        </p>
+    </div>
+</body>
+</html>
+`,
+
+	TemplateInterviewReminder: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Interview Reminder</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #9C27B0;">Interview Reminder</h2>
+        <p>Hi {{.Name}},</p>
+        <p>This is a reminder of your interview for <strong>{{.JobTitle}}</strong> at <strong>{{.CompanyName}}</strong>.</p>
+        <div style="background-color: #f5f5f5; padding: 15px; border-radius: 4px; margin: 20px 0;">
+            <p style="margin: 0;"><strong>Date:</strong> {{.InterviewDate}}</p>
+            <p style="margin: 10px 0;"><strong>Time:</strong> {{.InterviewTime}}</p>
+            {{if .Location}}<p style="margin: 10px 0;"><strong>Location:</strong> {{.Location}}</p>{{end}}
+            <p style="margin: 10px 0 0 0;"><strong>Interview Link:</strong> <a href="{{.InterviewURL}}" style="color: #2196F3;">{{.InterviewURL}}</a></p>
+        </div>
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="{{.InterviewURL}}" style="background-color: #9C27B0; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; display: inline-block;">
+                Join Interview
+            </a>
+        </div>
+        <p>The latest calendar invite is attached. Make sure you are on time. Good luck!</p>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            © {{.Year}} Keerja. All rights reserved.<br>
+            Need help? Contact us at {{.SupportEmail}}
+        </p>
+    </div>
+</body>
+</html>
+`,
+
+	TemplateInterviewCancelled: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Interview Cancelled</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #f44336;">Interview Cancelled</h2>
+        <p>Hi {{.Name}},</p>
+        <p>Your interview for <strong>{{.JobTitle}}</strong> at <strong>{{.CompanyName}}</strong> scheduled on {{.InterviewDate}} at {{.InterviewTime}} has been cancelled.</p>
+        {{if .Message}}<div style="background-color: #f5f5f5; padding: 15px; border-radius: 4px; margin: 20px 0;">
+            <p style="margin: 0;"><strong>Reason:</strong> {{.Message}}</p>
+        </div>{{end}}
+        <p>Open the calendar attachment to remove this event from your calendar. The company will contact you if the interview is rescheduled.</p>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            © {{.Year}} Keerja. All rights reserved.<br>
+            Need help? Contact us at {{.SupportEmail}}
+        </p>
+    </div>
+</body>
+</html>
+`,
+
+	TemplateOTP: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Your OTP Code</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #4CAF50;">OTP Verification Code</h2>
+        <p>Hi {{.Name}},</p>
+        <p>Here is your OTP code for {{.Purpose}}:</p>
+        <div style="background-color: #f5f5f5; padding: 20px; border-radius: 4px; margin: 30px 0; text-align: center;">
+            <h1 style="margin: 0; font-size: 48px; letter-spacing: 10px; color: #4CAF50;">{{.OTPCode}}</h1>
+        </div>
+        <p><strong>This code expires in {{.ExpiryMinutes}} minutes.</strong></p>
+        <p>Do not share this code with anyone. The Keerja team will never ask for your OTP code.</p>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            If you did not request this OTP code, please ignore this email.<br>
+            Need help? Contact us at {{.SupportEmail}}
+        </p>
+    </div>
+</body>
+</html>
+`,
+
+	TemplateOTPRegistration: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Verify Your Registration Email</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #4CAF50;">Welcome to Keerja!</h2>
+        <p>Hi {{.Name}},</p>
+        <p>Thank you for signing up for Keerja. To complete your registration, please verify your email by entering the following OTP code:</p>
+        <div style="background-color: #f5f5f5; padding: 30px; border-radius: 8px; margin: 30px 0; text-align: center; border: 2px solid #4CAF50;">
+            <h1 style="margin: 0; font-size: 56px; letter-spacing: 15px; color: #4CAF50; font-weight: bold;">{{.OTPCode}}</h1>
+        </div>
+        <p style="text-align: center; font-size: 14px; color: #666;"><strong>This OTP code expires in {{.ExpiryMinutes}} minutes.</strong></p>
+
+        <div style="background-color: #fff3cd; border-left: 4px solid #ffc107; padding: 15px; margin: 20px 0; border-radius: 4px;">
+            <p style="margin: 0; color: #856404;"><strong>⚠️ Security Notice:</strong></p>
+            <ul style="margin: 10px 0 0 0; color: #856404;">
+                <li>Do not share this OTP code with anyone</li>
+                <li>The Keerja team will never ask for your OTP code</li>
+                <li>This code can only be used once</li>
+            </ul>
+        </div>
+
+        <p>Once verified, you can start to:</p>
+        <ul>
+            <li>📝 Complete your profile</li>
+            <li>📄 Upload your CV and supporting documents</li>
+            <li>🔍 Search and apply for your dream job</li>
+            <li>🔔 Turn on job alerts for the latest openings</li>
+        </ul>
+
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            If you did not sign up for Keerja, please ignore this email.<br>
+            Need help? Contact us at {{.SupportEmail}}<br>
+            © {{.Year}} Keerja. All rights reserved.
+        </p>
+    </div>
+</body>
+</html>
+`,
+
+	TemplateInterviewBooking: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Choose Your Interview Time</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #9C27B0;">Choose Your Interview Time</h2>
+        <p>Hi {{.Name}},</p>
+        <p>Congratulations! You have been invited to interview for <strong>{{.JobTitle}}</strong> at <strong>{{.CompanyName}}</strong>.</p>
+        <p>Please pick one of the available times. We will send you the interview invitation once you have chosen.</p>
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="{{.BookingURL}}" style="background-color: #9C27B0; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; display: inline-block;">
+                Choose a Time
+            </a>
+        </div>
+        <p>This link is valid until {{.ExpiryDate}} and can only be used once.</p>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            © {{.Year}} Keerja. All rights reserved.<br>
+            Need help? Contact us at {{.SupportEmail}}
+        </p>
+    </div>
+</body>
+</html>
+`,
+
+	TemplateDataExport: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Your Data Export Is Ready</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #4CAF50;">Your Data Export Is Ready</h2>
+        <p>Hi {{.Name}},</p>
+        <p>The copy of your Keerja account data you asked for is ready. It contains your profile, applications, reviews and saved data in JSON format.</p>
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="{{.DownloadURL}}" style="background-color: #4CAF50; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; display: inline-block;">
+                Download Data
+            </a>
+        </div>
+        <p>This link is valid until {{.ExpiryDate}}. Do not share it with anyone.</p>
+        <p>If you did not ask for a copy of your data, change your password right away and contact us.</p>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            © {{.Year}} Keerja. All rights reserved.<br>
+            Need help? Contact us at {{.SupportEmail}}
+        </p>
+    </div>
+</body>
+</html>
+`,
+
+	TemplateWebhookDisabled: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Company Webhook Disabled</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #F44336;">Company Webhook Disabled</h2>
+        <p>Hi {{.Name}},</p>
+        <p>The <strong>{{.CompanyName}}</strong> webhook to <strong>{{.WebhookURL}}</strong> was disabled after {{.Failures}} deliveries in a row failed.</p>
+        <p>Events that happen while the webhook is disabled are not sent. Check your endpoint, then enable the webhook again from the company dashboard.</p>
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="{{.DashboardURL}}" style="background-color: #F44336; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; display: inline-block;">
+                Open Dashboard
+            </a>
+        </div>
+        <p>If you have any questions, contact us at {{.SupportEmail}}.</p>
+        <hr style="border: none; border-top: 1px solid #eee; margin: 30px 0;">
+        <p style="font-size: 12px; color: #999;">
+            © {{.Year}} Keerja. All rights reserved.
+        </p>
+    </div>
+</body>
+</html>
+`,
+}
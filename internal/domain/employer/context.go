@@ -0,0 +1,63 @@
+package employer
+
+import "slices"
+
+// Employer roles, lowest to highest privilege
+const (
+	RoleViewer    = "viewer"
+	RoleRecruiter = "recruiter"
+	RoleAdmin     = "admin"
+	RoleOwner     = "owner"
+)
+
+// Roles lists every employer role, lowest to highest privilege
+var Roles = []string{RoleViewer, RoleRecruiter, RoleAdmin, RoleOwner}
+
+// IsValidRole reports whether role is an employer role
+func IsValidRole(role string) bool {
+	return slices.Contains(Roles, role)
+}
+
+// EmployerContext identifies an authenticated user acting for one company. UserID is the
+// users.id from the auth token and EmployerUserID the employer_users.id of the user's
+// membership in the company, which is what jobs and other employer records store. The two
+// are different numbers; resolve the context once per request and pass it to services
+// instead of either ID on its own.
+type EmployerContext struct {
+	UserID         int64
+	EmployerUserID int64
+	CompanyID      int64
+	Role           string
+}
+
+// InCompany reports whether the context acts for companyID
+func (e *EmployerContext) InCompany(companyID int64) bool {
+	return e != nil && e.CompanyID == companyID
+}
+
+// IsPoster reports whether employerUserID, as stored on a record, is this employer user
+func (e *EmployerContext) IsPoster(employerUserID *int64) bool {
+	return e != nil && employerUserID != nil && *employerUserID == e.EmployerUserID
+}
+
+// CanManageJobs reports whether the role may create and manage the company's jobs
+// (recruiter and above)
+func (e *EmployerContext) CanManageJobs() bool {
+	if e == nil {
+		return false
+	}
+	switch e.Role {
+	case RoleRecruiter, RoleAdmin, RoleOwner:
+		return true
+	}
+	return false
+}
+
+// CanViewApplications reports whether the role may view the company's applications
+// (viewer and above)
+func (e *EmployerContext) CanViewApplications() bool {
+	if e == nil {
+		return false
+	}
+	return e.Role == RoleViewer || e.CanManageJobs()
+}
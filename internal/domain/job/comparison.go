@@ -0,0 +1,181 @@
+package job
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MaxCompareJobs is the most jobs one comparison may list
+const MaxCompareJobs = 3
+
+// JobComparisonService compares jobs side by side for job seekers
+type JobComparisonService interface {
+	// CompareJobs compares the jobs with the given IDs in the order given. Jobs the caller may
+	// not see (unpublished jobs they did not apply to) are reported in NotFound instead of
+	// failing the comparison. userID 0 is an anonymous caller, who only sees published jobs
+	// and gets no personalized columns.
+	CompareJobs(ctx context.Context, ids []int64, userID int64) (*JobComparison, error)
+}
+
+// JobComparison is the comparison matrix of up to MaxCompareJobs jobs
+type JobComparison struct {
+	Personalized bool                `json:"personalized"` // Skills held, distance and match scores are filled in
+	Jobs         []ComparedJob       `json:"jobs"`
+	NotFound     []ComparisonMissing `json:"not_found"`
+}
+
+// ComparedJob is one column of a job comparison
+type ComparedJob struct {
+	JobID      int64              `json:"job_id"`
+	Title      string             `json:"title"`
+	Slug       string             `json:"slug"`
+	Company    ComparedCompany    `json:"company"`
+	Salary     ComparedSalary     `json:"salary"`
+	WorkPolicy string             `json:"work_policy,omitempty"`
+	JobType    string             `json:"job_type,omitempty"`
+	Experience ComparedExperience `json:"experience"`
+	Education  string             `json:"education,omitempty"`
+	Skills     []ComparedSkill    `json:"skills"`
+	Location   ComparedLocation   `json:"location"`
+	MatchScore *MatchScore        `json:"match_score,omitempty"` // Personalized only
+}
+
+// ComparedCompany is the company of a compared job with its rating summary
+type ComparedCompany struct {
+	ID           int64   `json:"id"`
+	Name         string  `json:"name,omitempty"`
+	Rating       float64 `json:"rating"`
+	TotalReviews int64   `json:"total_reviews"`
+}
+
+// ComparedSalary is the salary of a compared job as its SalaryDisplay shows it
+type ComparedSalary struct {
+	Display  string   `json:"display"`
+	Min      *float64 `json:"min,omitempty"`
+	Max      *float64 `json:"max,omitempty"`
+	Currency string   `json:"currency"`
+}
+
+// ComparedExperience is the experience a compared job asks for
+type ComparedExperience struct {
+	Level    string `json:"level,omitempty"`
+	MinYears *int16 `json:"min_years,omitempty"`
+	MaxYears *int16 `json:"max_years,omitempty"`
+}
+
+// ComparedSkill is a skill a compared job asks for
+type ComparedSkill struct {
+	Name       string `json:"name"`
+	Importance string `json:"importance"`
+	UserHas    *bool  `json:"user_has,omitempty"` // Personalized only
+}
+
+// ComparedLocation is where a compared job is done
+type ComparedLocation struct {
+	City       string   `json:"city,omitempty"`
+	Province   string   `json:"province,omitempty"`
+	Remote     bool     `json:"remote"`
+	DistanceKm *float64 `json:"distance_km,omitempty"` // Personalized only, when both places have coordinates
+}
+
+// ComparisonMissing reports a job the caller asked to compare but may not see
+type ComparisonMissing struct {
+	JobID   int64  `json:"job_id"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ParseCompareJobIDs parses the comma-separated job IDs of a comparison, dropping repeats.
+// It returns ErrInvalidCompareJobIDs unless there are 1 to MaxCompareJobs valid IDs.
+func ParseCompareJobIDs(raw string) ([]int64, error) {
+	var ids []int64
+	seen := make(map[int64]bool)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, ErrInvalidCompareJobIDs
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 || len(ids) > MaxCompareJobs {
+		return nil, ErrInvalidCompareJobIDs
+	}
+	return ids, nil
+}
+
+// NewComparedJob builds the comparison column of j without the personalized fields
+func NewComparedJob(j *Job) ComparedJob {
+	compared := ComparedJob{
+		JobID:      j.ID,
+		Title:      j.Title,
+		Slug:       j.Slug,
+		Company:    ComparedCompany{ID: j.CompanyID},
+		Salary:     ComparedSalary{Display: j.SalaryDisplay, Currency: j.Currency},
+		WorkPolicy: j.GetWorkPolicyName(),
+		JobType:    j.GetJobTypeName(),
+		Experience: ComparedExperience{
+			Level:    j.GetExperienceLevelName(),
+			MinYears: j.ExperienceMin,
+			MaxYears: j.ExperienceMax,
+		},
+		Education: j.GetEducationLevelName(),
+		Skills:    make([]ComparedSkill, 0, len(j.Skills)),
+		Location:  ComparedLocation{City: j.City, Province: j.Province, Remote: j.RemoteOption},
+	}
+	compared.Salary.Min, compared.Salary.Max = j.PublicSalary()
+	if compared.Salary.Currency == "" {
+		compared.Salary.Currency = "IDR"
+	}
+
+	if loc := j.PrimaryLocation(); loc != nil {
+		compared.Location.Remote = compared.Location.Remote || loc.LocationType == "remote"
+		if loc.City != "" {
+			compared.Location.City, compared.Location.Province = loc.City, loc.Province
+		}
+	}
+
+	for _, skill := range j.Skills {
+		if skill.Skill == nil {
+			continue
+		}
+		importance := skill.ImportanceLevel
+		if importance == "" {
+			importance = "required"
+		}
+		compared.Skills = append(compared.Skills, ComparedSkill{Name: skill.Skill.Name, Importance: importance})
+	}
+	return compared
+}
+
+// Coordinates returns where the job is done: its primary location when it has coordinates,
+// else its company address. ok is false when neither has coordinates.
+func (j *Job) Coordinates() (lat, lng float64, ok bool) {
+	if loc := j.PrimaryLocation(); loc != nil && loc.Latitude != nil && loc.Longitude != nil {
+		return *loc.Latitude, *loc.Longitude, true
+	}
+	if addr := j.CompanyAddress; addr != nil && addr.Latitude != nil && addr.Longitude != nil {
+		return *addr.Latitude, *addr.Longitude, true
+	}
+	return 0, 0, false
+}
+
+// DistanceKm returns the great-circle distance in kilometres between two coordinates
+func DistanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	const earthRadiusKm = 6371
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return earthRadiusKm * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
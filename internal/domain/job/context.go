@@ -0,0 +1,41 @@
+package job
+
+import "context"
+
+type viewerIPKey struct{}
+
+// WithViewerIP returns a copy of ctx carrying the viewer's IP address, used by
+// JobService.IncrementView to deduplicate anonymous views
+func WithViewerIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, viewerIPKey{}, ip)
+}
+
+// ViewerIPFromContext returns the viewer IP stored by WithViewerIP, or "" if none
+func ViewerIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(viewerIPKey{}).(string)
+	return ip
+}
+
+// Attribution is where a job viewer came from: the utm_* parameters of the job link and the
+// referring page
+type Attribution struct {
+	Source   string
+	Medium   string
+	Campaign string
+	Referrer string
+}
+
+type attributionKey struct{}
+
+// WithAttribution returns a copy of ctx carrying the viewer's attribution, recorded by
+// JobService.IncrementView on the view event
+func WithAttribution(ctx context.Context, attribution Attribution) context.Context {
+	return context.WithValue(ctx, attributionKey{}, attribution)
+}
+
+// AttributionFromContext returns the attribution stored by WithAttribution, or a zero
+// Attribution if none
+func AttributionFromContext(ctx context.Context) Attribution {
+	attribution, _ := ctx.Value(attributionKey{}).(Attribution)
+	return attribution
+}
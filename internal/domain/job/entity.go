@@ -1,11 +1,16 @@
 package job
 
 import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"keerja-backend/internal/domain/master"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
@@ -55,13 +60,24 @@ type Job struct {
 	// Category/Subcategory
 	JobSubcategoryID *int64 `gorm:"column:job_subcategory_id;index" json:"job_subcategory_id,omitempty"`
 
-	Status            string     `gorm:"column:status;type:varchar(20);check:status IN ('in_review','draft','pending_review','published','closed','expired','suspended','rejected');default:'draft';index" json:"status" validate:"omitempty,oneof='in_review' 'draft' 'pending_review' 'published' 'closed' 'expired' 'suspended' 'rejected'"`
-	ViewsCount        int64      `gorm:"column:views_count;default:0" json:"views_count"`
-	ApplicationsCount int64      `gorm:"column:applications_count;default:0" json:"applications_count"`
-	PublishedAt       *time.Time `gorm:"column:published_at" json:"published_at,omitempty"`
-	ExpiredAt         *time.Time `gorm:"column:expired_at" json:"expired_at,omitempty"`
-	CreatedAt         time.Time  `gorm:"column:created_at;autoCreateTime" json:"created_at"`
-	UpdatedAt         time.Time  `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+	Status              string         `gorm:"column:status;type:varchar(20);check:status IN ('in_review','draft','pending_review','published','inactive','closed','expired','suspended','rejected');default:'draft';index" json:"status" validate:"omitempty,oneof='in_review' 'draft' 'pending_review' 'published' 'inactive' 'closed' 'expired' 'suspended' 'rejected'"`
+	ViewsCount          int64          `gorm:"column:views_count;default:0" json:"views_count"`
+	ApplicationsCount   int64          `gorm:"column:applications_count;default:0" json:"applications_count"`
+	PublishedAt         *time.Time     `gorm:"column:published_at" json:"published_at,omitempty"`
+	ExpiredAt           *time.Time     `gorm:"column:expired_at" json:"expired_at,omitempty"`
+	SubmittedAt         *time.Time     `gorm:"column:submitted_at;index" json:"submitted_at,omitempty"`
+	FollowersNotifiedAt *time.Time     `gorm:"column:followers_notified_at" json:"-"`
+	IsFeatured          bool           `gorm:"column:is_featured;default:false" json:"is_featured"`
+	FeaturedFrom        *time.Time     `gorm:"column:featured_from" json:"featured_from,omitempty"`
+	FeaturedUntil       *time.Time     `gorm:"column:featured_until" json:"featured_until,omitempty"`
+	AutoExtend          bool           `gorm:"column:auto_extend;default:false" json:"auto_extend"`
+	AutoExtendCount     int            `gorm:"column:auto_extend_count;default:0" json:"auto_extend_count"`
+	ExpiryReminderDays  *int           `gorm:"column:expiry_reminder_days" json:"-"`                           // Most urgent reminder sent for the current expiry date
+	DistanceKm          *float64       `gorm:"column:distance_km;->;-:migration" json:"distance_km,omitempty"` // Only set by location searches
+	Version             int64          `gorm:"column:version;not null;default:1" json:"version"`               // Incremented by every edit and status change; updates require the loaded version
+	CreatedAt           time.Time      `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	UpdatedAt           time.Time      `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `gorm:"column:deleted_at;index" json:"-"` // Soft delete; queries through the model skip deleted jobs
 
 	// Relationships
 	Category        *JobCategory     `gorm:"foreignKey:CategoryID;references:ID;constraint:OnDelete:SET NULL" json:"category,omitempty"`
@@ -95,27 +111,27 @@ func (j *Job) BeforeCreate(tx *gorm.DB) error {
 
 // IsPublished checks if job is published
 func (j *Job) IsPublished() bool {
-	return j.Status == "published"
+	return j.Status == StatusPublished
 }
 
 // IsPendingReview checks if job is pending review
 func (j *Job) IsPendingReview() bool {
-	return j.Status == "pending_review"
+	return j.Status == StatusPendingReview
 }
 
 // IsDraft checks if job is draft
 func (j *Job) IsDraft() bool {
-	return j.Status == "draft"
+	return j.Status == StatusDraft
 }
 
 // IsRejected checks if job is rejected
 func (j *Job) IsRejected() bool {
-	return j.Status == "rejected"
+	return j.Status == StatusRejected
 }
 
 // IsClosed checks if job is closed
 func (j *Job) IsClosed() bool {
-	return j.Status == "closed" || j.Status == "expired"
+	return j.Status == StatusClosed || j.Status == StatusExpired
 }
 
 // IsExpired checks if job has expired
@@ -128,7 +144,43 @@ func (j *Job) IsExpired() bool {
 
 // IsActive checks if job is active and accepting applications
 func (j *Job) IsActive() bool {
-	return j.Status == "published" && !j.IsExpired()
+	return j.Status == StatusPublished && !j.IsExpired()
+}
+
+// DaysUntilExpiry returns the whole days left before the job expires, 0 once it has
+// expired, or nil when the job has no expiry date
+func (j *Job) DaysUntilExpiry(now time.Time) *int {
+	if j.ExpiredAt == nil {
+		return nil
+	}
+	days := max(int(j.ExpiredAt.Sub(now).Hours()/24), 0)
+	return &days
+}
+
+// CanAutoExtend reports whether the job is extended instead of expired under policy
+func (j *Job) CanAutoExtend(policy ExpiryPolicy) bool {
+	return j.AutoExtend && j.AutoExtendCount < policy.MaxAutoExtensions
+}
+
+// ExpiryPolicy sets how long a published job stays open and how often it may extend itself
+type ExpiryPolicy struct {
+	DefaultPeriod     time.Duration // Open period of a job published without a future expiry date
+	MaxAutoExtensions int           // Times an auto_extend job is extended instead of expired
+}
+
+// DefaultExpiryPolicy is used when no expiry period is configured
+var DefaultExpiryPolicy = ExpiryPolicy{
+	DefaultPeriod:     30 * 24 * time.Hour,
+	MaxAutoExtensions: 2,
+}
+
+// PublishExpiry returns the expiry date of a job published at now: the job's own expiry
+// date while it is still in the future, otherwise now plus the default period
+func (p ExpiryPolicy) PublishExpiry(j *Job, now time.Time) time.Time {
+	if j.ExpiredAt != nil && j.ExpiredAt.After(now) {
+		return *j.ExpiredAt
+	}
+	return now.Add(p.DefaultPeriod)
 }
 
 // CanApply checks if job accepts applications
@@ -136,6 +188,72 @@ func (j *Job) CanApply() bool {
 	return j.IsActive()
 }
 
+// RestoreWindow is how long after deletion a job can still be restored
+const RestoreWindow = 30 * 24 * time.Hour
+
+// CanRestore checks if a deleted job is still within the restore window at now
+func (j *Job) CanRestore(now time.Time) bool {
+	return j.DeletedAt.Valid && now.Sub(j.DeletedAt.Time) <= RestoreWindow
+}
+
+// CloneAsDraft returns an unsaved draft copy of the job with its locations, benefits,
+// skills and requirements. The copy gets a fresh UUID and slug on creation, zeroed
+// counters and no publication or expiry dates; master data is shared by FK only.
+func (j *Job) CloneAsDraft() *Job {
+	dup := *j
+	dup.ID = 0
+	dup.UUID = uuid.Nil
+	dup.Slug = ""
+	dup.Status = StatusDraft
+	dup.ViewsCount = 0
+	dup.ApplicationsCount = 0
+	dup.PublishedAt = nil
+	dup.ExpiredAt = nil
+	dup.SubmittedAt = nil
+	dup.FollowersNotifiedAt = nil
+	dup.DistanceKm = nil
+	dup.Version = 0
+	dup.CreatedAt = time.Time{}
+	dup.UpdatedAt = time.Time{}
+
+	dup.CompanyAddress = nil
+	dup.Category = nil
+	dup.JobSubcategory = nil
+	dup.JobTitle = nil
+	dup.JobType = nil
+	dup.WorkPolicy = nil
+	dup.EducationLevelM = nil
+	dup.ExperienceLevelM = nil
+	dup.GenderPreference = nil
+
+	dup.Locations = make([]JobLocation, 0, len(j.Locations))
+	for _, loc := range j.Locations {
+		loc.ID, loc.JobID, loc.Job = 0, 0, nil
+		loc.CreatedAt, loc.UpdatedAt = time.Time{}, time.Time{}
+		dup.Locations = append(dup.Locations, loc)
+	}
+	dup.Benefits = make([]JobBenefit, 0, len(j.Benefits))
+	for _, benefit := range j.Benefits {
+		benefit.ID, benefit.JobID, benefit.Job = 0, 0, nil
+		benefit.CreatedAt, benefit.UpdatedAt = time.Time{}, time.Time{}
+		dup.Benefits = append(dup.Benefits, benefit)
+	}
+	dup.Skills = make([]JobSkill, 0, len(j.Skills))
+	for _, skill := range j.Skills {
+		skill.ID, skill.JobID, skill.Job, skill.Skill = 0, 0, nil, nil
+		skill.CreatedAt, skill.UpdatedAt = time.Time{}, time.Time{}
+		dup.Skills = append(dup.Skills, skill)
+	}
+	dup.JobRequirements = make([]JobRequirement, 0, len(j.JobRequirements))
+	for _, req := range j.JobRequirements {
+		req.ID, req.JobID, req.Job = 0, 0, nil
+		req.CreatedAt, req.UpdatedAt = time.Time{}, time.Time{}
+		dup.JobRequirements = append(dup.JobRequirements, req)
+	}
+
+	return &dup
+}
+
 // ==========================================
 // MASTER DATA HELPER METHODS
 // ==========================================
@@ -406,6 +524,170 @@ func (jr *JobRequirement) IsSkillRequirement() bool {
 	return jr.RequirementType == "skill"
 }
 
+// JobReview represents an admin moderation decision on a job submitted for review
+type JobReview struct {
+	ID        int64     `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	JobID     int64     `gorm:"column:job_id;not null;index" json:"job_id"`
+	AdminID   *int64    `gorm:"column:admin_id" json:"admin_id,omitempty"`
+	Action    string    `gorm:"column:action;type:varchar(20);not null;check:action IN ('approved','rejected')" json:"action"`
+	Reason    *string   `gorm:"column:reason;type:text" json:"reason,omitempty"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for JobReview
+func (JobReview) TableName() string {
+	return "job_reviews"
+}
+
+// IsRejected checks if the review rejected the job
+func (jr *JobReview) IsRejected() bool {
+	return jr.Action == "rejected"
+}
+
+// JobViewEvent records a single counted view of a job, used for view dedup and analytics
+type JobViewEvent struct {
+	ID       int64     `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	JobID    int64     `gorm:"column:job_id;not null;index" json:"job_id"`
+	UserID   *int64    `gorm:"column:user_id" json:"user_id,omitempty"`
+	IPHash   *string   `gorm:"column:ip_hash;type:varchar(64)" json:"-"`
+	ViewedAt time.Time `gorm:"column:viewed_at;not null" json:"viewed_at"`
+
+	// Where the viewer came from, as captured from the job link
+	UTMSource   string `gorm:"column:utm_source;type:varchar(100)" json:"utm_source,omitempty"`
+	UTMMedium   string `gorm:"column:utm_medium;type:varchar(100)" json:"utm_medium,omitempty"`
+	UTMCampaign string `gorm:"column:utm_campaign;type:varchar(100)" json:"utm_campaign,omitempty"`
+	Referrer    string `gorm:"column:referrer;type:varchar(500)" json:"referrer,omitempty"`
+}
+
+// TableName specifies the table name for JobViewEvent
+func (JobViewEvent) TableName() string {
+	return "job_view_events"
+}
+
+// MatchScoreFreshness is how long a precomputed match score is used before it is recomputed.
+// Scores are refreshed nightly, so this covers a missed run.
+const MatchScoreFreshness = 36 * time.Hour
+
+// UserJobMatchScore is a precomputed match score of a published job for a job seeker. Only
+// the component scores are kept; matched and missing skills are computed when needed.
+type UserJobMatchScore struct {
+	UserID     int64     `gorm:"column:user_id;primaryKey" json:"user_id"`
+	JobID      int64     `gorm:"column:job_id;primaryKey" json:"job_id"`
+	Overall    float64   `gorm:"column:overall;not null" json:"overall"`
+	Skill      float64   `gorm:"column:skill;not null" json:"skill"`
+	Experience float64   `gorm:"column:experience;not null" json:"experience"`
+	Education  float64   `gorm:"column:education;not null" json:"education"`
+	Location   float64   `gorm:"column:location;not null" json:"location"`
+	ComputedAt time.Time `gorm:"column:computed_at;not null" json:"computed_at"`
+}
+
+// TableName specifies the table name for UserJobMatchScore
+func (UserJobMatchScore) TableName() string {
+	return "user_job_match_scores"
+}
+
+// IsFresh checks if the score was computed recently enough to be used at now
+func (m *UserJobMatchScore) IsFresh(now time.Time) bool {
+	return now.Sub(m.ComputedAt) < MatchScoreFreshness
+}
+
+// MaxDraftRevisions is how many payload snapshots are kept per draft
+const MaxDraftRevisions = 5
+
+// JobDraftRevision is a snapshot of the payload a draft was saved with, so an employer
+// can roll an autosaved draft back to an earlier version
+type JobDraftRevision struct {
+	ID        int64     `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	JobID     int64     `gorm:"column:job_id;not null;index" json:"job_id"`
+	Payload   string    `gorm:"column:payload;type:jsonb;not null" json:"-"` // JSON-encoded SaveJobDraftRequest
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for JobDraftRevision
+func (JobDraftRevision) TableName() string {
+	return "job_draft_revisions"
+}
+
+// Screening question types
+const (
+	QuestionTypeText    = "text"
+	QuestionTypeBoolean = "boolean"
+	QuestionTypeNumber  = "number"
+	QuestionTypeSelect  = "select"
+)
+
+// JobQuestion is a screening question applicants answer when applying for a job. A knockout
+// question rejects the application automatically when the answer does not match KnockoutValue.
+type JobQuestion struct {
+	ID            int64          `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	JobID         int64          `gorm:"column:job_id;not null;index" json:"job_id"`
+	QuestionText  string         `gorm:"column:question_text;type:text;not null" json:"question_text"`
+	QuestionType  string         `gorm:"column:question_type;type:varchar(20);not null" json:"type"`
+	Options       pq.StringArray `gorm:"column:options;type:text[]" json:"options,omitempty"`
+	IsRequired    bool           `gorm:"column:is_required;default:false" json:"is_required"`
+	IsKnockout    bool           `gorm:"column:is_knockout;default:false" json:"is_knockout"`
+	KnockoutValue string         `gorm:"column:knockout_value;type:text" json:"knockout_value,omitempty"`
+	SortOrder     int            `gorm:"column:sort_order;default:0" json:"sort_order"`
+	CreatedAt     time.Time      `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time      `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for JobQuestion
+func (JobQuestion) TableName() string {
+	return "job_questions"
+}
+
+// NormalizeAnswer checks that value is a valid answer for the question type and returns
+// it in canonical form: booleans as "true"/"false", numbers without trailing zeros and
+// select answers spelled exactly like the matching option
+func (q *JobQuestion) NormalizeAnswer(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", errors.New("is required")
+	}
+
+	switch q.QuestionType {
+	case QuestionTypeBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", errors.New("must be true or false")
+		}
+		return strconv.FormatBool(b), nil
+	case QuestionTypeNumber:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", errors.New("must be a number")
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	case QuestionTypeSelect:
+		idx := slices.IndexFunc(q.Options, func(option string) bool {
+			return strings.EqualFold(option, value)
+		})
+		if idx < 0 {
+			return "", errors.New("must be one of the question options")
+		}
+		return q.Options[idx], nil
+	default:
+		if len(value) > 2000 {
+			return "", errors.New("must be at most 2000 characters")
+		}
+		return value, nil
+	}
+}
+
+// PassesKnockout reports whether a normalized answer keeps the application in the running.
+// Text answers are compared case-insensitively.
+func (q *JobQuestion) PassesKnockout(answer string) bool {
+	if !q.IsKnockout {
+		return true
+	}
+	expected, err := q.NormalizeAnswer(q.KnockoutValue)
+	if err != nil {
+		return true
+	}
+	return strings.EqualFold(answer, expected)
+}
+
 // CompanyAddress represents a minimal company address structure for job relations
 type CompanyAddress struct {
 	ID          int64      `gorm:"primaryKey;autoIncrement" json:"id"`
@@ -0,0 +1,83 @@
+package job
+
+import "keerja-backend/internal/apperror"
+
+var (
+	// ErrJobNotFound is returned when the requested job does not exist
+	ErrJobNotFound = apperror.NotFound("JOB_NOT_FOUND", "job not found")
+
+	// ErrJobNotPendingReview is returned when a review is attempted on a job that is not pending_review
+	ErrJobNotPendingReview = apperror.Conflict("JOB_NOT_PENDING_REVIEW", "only jobs with pending_review status can be reviewed")
+
+	// ErrRejectionReasonRequired is returned when a job is rejected without a reason
+	ErrRejectionReasonRequired = apperror.Validation("REJECTION_REASON_REQUIRED", "rejection reason is required").WithField("reason", "is required")
+
+	// ErrJobPermissionDenied is returned when the employer user may not modify the job
+	ErrJobPermissionDenied = apperror.Forbidden("JOB_PERMISSION_DENIED", "you do not have permission to modify this job")
+
+	// ErrJobAlreadyPendingReview is returned when publishing a job that is already awaiting review
+	ErrJobAlreadyPendingReview = apperror.Conflict("JOB_ALREADY_PENDING_REVIEW", "job is already pending review")
+
+	// ErrJobAlreadyPublished is returned when publishing a job that is already published
+	ErrJobAlreadyPublished = apperror.Conflict("JOB_ALREADY_PUBLISHED", "job is already published")
+
+	// ErrJobNotPublishable is returned when publishing a job whose status does not allow it
+	ErrJobNotPublishable = apperror.Conflict("JOB_NOT_PUBLISHABLE", "job cannot be published in its current status")
+
+	// ErrJobIncomplete is returned when a job is missing information required to go live
+	ErrJobIncomplete = apperror.Validation("JOB_INCOMPLETE", "job is missing required information")
+
+	// ErrInvalidJobData is returned when a job references master data or addresses that are invalid
+	ErrInvalidJobData = apperror.Validation("INVALID_JOB_DATA", "job data is invalid")
+
+	// ErrJobNotDraft is returned when saving a draft for a job that has left draft status
+	ErrJobNotDraft = apperror.Conflict("JOB_NOT_DRAFT", "job is no longer in draft status")
+
+	// ErrInvalidJobStatus is returned when setting a status that is not a job status
+	ErrInvalidJobStatus = apperror.Validation("INVALID_JOB_STATUS", "invalid job status")
+
+	// ErrInvalidStatusTransition is returned when a job cannot move from its status to the requested one
+	ErrInvalidStatusTransition = apperror.Conflict("INVALID_JOB_STATUS_TRANSITION", "job cannot move to that status from its current status")
+
+	// ErrJobNotReopenable is returned when reopening a job that is neither closed nor expired
+	ErrJobNotReopenable = apperror.Conflict("JOB_NOT_REOPENABLE", "only closed or expired jobs can be reopened")
+
+	// ErrDraftRevisionNotFound is returned when restoring a revision the draft does not have
+	ErrDraftRevisionNotFound = apperror.NotFound("DRAFT_REVISION_NOT_FOUND", "draft revision not found")
+
+	// ErrJobQuestionNotFound is returned when the screening question does not exist on the job
+	ErrJobQuestionNotFound = apperror.NotFound("JOB_QUESTION_NOT_FOUND", "screening question not found")
+
+	// ErrJobQuestionsLocked is returned when changing screening questions of a job that has left draft or review
+	ErrJobQuestionsLocked = apperror.Conflict("JOB_QUESTIONS_LOCKED", "screening questions can only be changed while the job is draft or pending review")
+
+	// ErrInvalidJobQuestion is returned when a screening question's options or knockout value do not fit its type
+	ErrInvalidJobQuestion = apperror.Validation("INVALID_JOB_QUESTION", "screening question is invalid")
+
+	// ErrJobPipelineLocked is returned when changing the pipeline of a job that has left draft or review
+	ErrJobPipelineLocked = apperror.Conflict("JOB_PIPELINE_LOCKED", "the hiring pipeline can only be changed while the job is draft or pending review")
+
+	// ErrInvalidPipeline is returned when custom pipeline stages are named or placed wrongly
+	ErrInvalidPipeline = apperror.Validation("INVALID_PIPELINE", "hiring pipeline is invalid")
+
+	// ErrJobNotDeleted is returned when restoring a job that has not been deleted
+	ErrJobNotDeleted = apperror.NotFound("JOB_NOT_DELETED", "deleted job not found")
+
+	// ErrJobRestoreExpired is returned when restoring a job deleted longer ago than the restore window
+	ErrJobRestoreExpired = apperror.Conflict("JOB_RESTORE_EXPIRED", "deleted jobs can only be restored within 30 days")
+
+	// ErrInvalidFeaturedWindow is returned when a featured window ends before it starts or has already ended
+	ErrInvalidFeaturedWindow = apperror.Validation("INVALID_FEATURED_WINDOW", "featured window is invalid").WithField("featured_until", "must be after featured_from and in the future")
+
+	// ErrJobNotFeaturable is returned when featuring a job that is not published
+	ErrJobNotFeaturable = apperror.Conflict("JOB_NOT_FEATURABLE", "only published jobs can be featured")
+
+	// ErrSitemapPageNotFound is returned when asking for a jobs sitemap page past the last one
+	ErrSitemapPageNotFound = apperror.NotFound("SITEMAP_PAGE_NOT_FOUND", "sitemap page not found")
+
+	// ErrInvalidCompareJobIDs is returned when a job comparison does not list 1 to MaxCompareJobs job IDs
+	ErrInvalidCompareJobIDs = apperror.Validation("INVALID_COMPARE_JOB_IDS", "jobs to compare are invalid").WithField("ids", "must list 1 to 3 comma-separated job IDs")
+
+	// ErrJobVersionConflict is returned when the job changed since the editor loaded it
+	ErrJobVersionConflict = apperror.Conflict("JOB_VERSION_CONFLICT", "the job was changed by someone else; reload it and apply your changes again")
+)
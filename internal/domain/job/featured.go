@@ -0,0 +1,80 @@
+package job
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// FeaturedViewWindow is how far back views count towards the featured fill
+	FeaturedViewWindow = 7 * 24 * time.Hour
+
+	// FeaturedViewHalfLife is the age at which a view counts half towards the featured fill,
+	// so a burst of views fades out instead of holding the homepage
+	FeaturedViewHalfLife = 48 * time.Hour
+
+	// TrendingViewWindow is how far back views count towards trending jobs
+	TrendingViewWindow = 48 * time.Hour
+
+	// FeaturedJobsCacheTTL is how long the featured and trending lists are cached
+	FeaturedJobsCacheTTL = 5 * time.Minute
+
+	// MaxFeaturedJobs is the longest featured or trending list that is computed and cached;
+	// requests for more are capped
+	MaxFeaturedJobs = 50
+)
+
+// Cache keys of the featured and trending lists
+const (
+	FeaturedJobsCacheKey = "jobs:featured"
+	TrendingJobsCacheKey = "jobs:trending"
+)
+
+// IsFeaturedAt reports whether an admin featured the job and now is within its featured
+// window. The window includes featured_from and excludes featured_until; a missing bound
+// leaves that side open.
+func (j *Job) IsFeaturedAt(now time.Time) bool {
+	if !j.IsFeatured {
+		return false
+	}
+	if j.FeaturedFrom != nil && now.Before(*j.FeaturedFrom) {
+		return false
+	}
+	return j.FeaturedUntil == nil || now.Before(*j.FeaturedUntil)
+}
+
+// JobViewBucket is the number of counted views a job got in the hour starting at Hour
+type JobViewBucket struct {
+	JobID int64     `gorm:"column:job_id"`
+	Hour  time.Time `gorm:"column:hour"`
+	Views int64     `gorm:"column:views"`
+}
+
+// RankJobsByViews scores each job by its views at now and returns the job IDs best first.
+// With a halfLife a view counts 0.5^(age/halfLife), aged from the start of its hour;
+// without one every view counts 1. Equal scores are ordered by descending job ID, so newer
+// jobs come first and the order never depends on how the buckets were read.
+func RankJobsByViews(buckets []JobViewBucket, now time.Time, halfLife time.Duration) []int64 {
+	scores := make(map[int64]float64)
+	for _, b := range buckets {
+		weight := 1.0
+		if halfLife > 0 {
+			age := max(now.Sub(b.Hour), 0)
+			weight = math.Pow(0.5, float64(age)/float64(halfLife))
+		}
+		scores[b.JobID] += float64(b.Views) * weight
+	}
+
+	ids := make([]int64, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, k int) bool {
+		if scores[ids[i]] != scores[ids[k]] {
+			return scores[ids[i]] > scores[ids[k]]
+		}
+		return ids[i] > ids[k]
+	})
+	return ids
+}
@@ -0,0 +1,226 @@
+package job
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxSitemapURLs is the most URLs one sitemap file may list; larger sitemaps are split
+	// into pages listed by a sitemap index
+	MaxSitemapURLs = 50000
+
+	// SitemapCacheTTL is how long a generated sitemap page is cached
+	SitemapCacheTTL = time.Hour
+
+	// SitemapCacheKeyPrefix prefixes the cache keys of generated sitemap pages
+	SitemapCacheKeyPrefix = "jobs:sitemap:"
+)
+
+// SitemapEntry is a published job listed in the jobs sitemap
+type SitemapEntry struct {
+	Slug      string    `gorm:"column:slug"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+// SitemapPageCount returns how many sitemap pages list total jobs; an empty sitemap still has one page
+func SitemapPageCount(total int64) int {
+	return max(int((total+MaxSitemapURLs-1)/MaxSitemapURLs), 1)
+}
+
+// JobFeedService publishes published jobs to search engines
+type JobFeedService interface {
+	// WriteSitemap writes a page of the jobs sitemap to w. Page 0 is the sitemap itself when
+	// every job fits in one file, otherwise a sitemap index linking sitemapURL?page=N for
+	// each page. Pages past the last return ErrSitemapPageNotFound.
+	WriteSitemap(ctx context.Context, sitemapURL string, page int, w io.Writer) error
+
+	// GetStructuredData returns the schema.org JobPosting of a published, unexpired job
+	GetStructuredData(ctx context.Context, slug string) (*JobPosting, error)
+}
+
+// JobPosting is the schema.org JobPosting structured data search engines read from job pages
+type JobPosting struct {
+	Context                       string              `json:"@context"`
+	Type                          string              `json:"@type"`
+	Title                         string              `json:"title"`
+	Description                   string              `json:"description"`
+	DatePosted                    string              `json:"datePosted"`
+	ValidThrough                  string              `json:"validThrough,omitempty"`
+	EmploymentType                string              `json:"employmentType,omitempty"`
+	URL                           string              `json:"url"`
+	HiringOrganization            PostingOrganization `json:"hiringOrganization"`
+	JobLocation                   *PostingPlace       `json:"jobLocation,omitempty"`
+	JobLocationType               string              `json:"jobLocationType,omitempty"`
+	ApplicantLocationRequirements *PostingCountry     `json:"applicantLocationRequirements,omitempty"`
+	BaseSalary                    *PostingSalary      `json:"baseSalary,omitempty"`
+}
+
+// PostingOrganization is the company hiring for a JobPosting
+type PostingOrganization struct {
+	Type   string `json:"@type"`
+	Name   string `json:"name"`
+	SameAs string `json:"sameAs,omitempty"`
+	Logo   string `json:"logo,omitempty"`
+}
+
+// PostingPlace is where the job of a JobPosting is done
+type PostingPlace struct {
+	Type    string         `json:"@type"`
+	Address PostingAddress `json:"address"`
+}
+
+// PostingAddress is the postal address of a PostingPlace
+type PostingAddress struct {
+	Type            string `json:"@type"`
+	StreetAddress   string `json:"streetAddress,omitempty"`
+	AddressLocality string `json:"addressLocality,omitempty"`
+	AddressRegion   string `json:"addressRegion,omitempty"`
+	PostalCode      string `json:"postalCode,omitempty"`
+	AddressCountry  string `json:"addressCountry"`
+}
+
+// PostingCountry is the country remote applicants must live in
+type PostingCountry struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+// PostingSalary is the base salary of a JobPosting
+type PostingSalary struct {
+	Type     string              `json:"@type"`
+	Currency string              `json:"currency"`
+	Value    PostingSalaryAmount `json:"value"`
+}
+
+// PostingSalaryAmount is the amount or range of a PostingSalary per UnitText
+type PostingSalaryAmount struct {
+	Type     string   `json:"@type"`
+	MinValue *float64 `json:"minValue,omitempty"`
+	MaxValue *float64 `json:"maxValue,omitempty"`
+	UnitText string   `json:"unitText"`
+}
+
+// postingEmploymentTypes maps job type codes to schema.org employment types
+var postingEmploymentTypes = map[string]string{
+	"full_time":  "FULL_TIME",
+	"part_time":  "PART_TIME",
+	"contract":   "CONTRACTOR",
+	"freelance":  "CONTRACTOR",
+	"internship": "INTERN",
+}
+
+// NewJobPosting builds the JobPosting of j, published at jobURL by org. description is the
+// job description already reduced to plain HTML. The location is the job's primary
+// location, else its company address, else fallback; the salary is left out unless
+// SalaryDisplay shows an amount.
+func NewJobPosting(j *Job, jobURL, description string, org PostingOrganization, fallback *PostingAddress) *JobPosting {
+	posting := &JobPosting{
+		Context:            "https://schema.org/",
+		Type:               "JobPosting",
+		Title:              j.Title,
+		Description:        description,
+		DatePosted:         j.CreatedAt.Format(time.RFC3339),
+		URL:                jobURL,
+		HiringOrganization: org,
+	}
+	posting.HiringOrganization.Type = "Organization"
+	if j.PublishedAt != nil {
+		posting.DatePosted = j.PublishedAt.Format(time.RFC3339)
+	}
+	if j.ExpiredAt != nil {
+		posting.ValidThrough = j.ExpiredAt.Format(time.RFC3339)
+	}
+	if j.JobType != nil {
+		posting.EmploymentType = postingEmploymentTypes[j.JobType.Code]
+	}
+
+	remote := j.RemoteOption
+	if loc := j.PrimaryLocation(); loc != nil {
+		remote = remote || loc.LocationType == "remote"
+		posting.JobLocation = newPostingPlace(PostingAddress{
+			StreetAddress:   loc.Address,
+			AddressLocality: loc.City,
+			AddressRegion:   loc.Province,
+			PostalCode:      loc.PostalCode,
+			AddressCountry:  loc.Country,
+		})
+	} else if addr := j.CompanyAddress; addr != nil {
+		address := PostingAddress{StreetAddress: addr.FullAddress}
+		if addr.City != nil {
+			address.AddressLocality = addr.City.Name
+		}
+		if addr.Province != nil {
+			address.AddressRegion = addr.Province.Name
+		}
+		posting.JobLocation = newPostingPlace(address)
+	} else if fallback != nil {
+		posting.JobLocation = newPostingPlace(*fallback)
+	}
+	if remote {
+		posting.JobLocationType = "TELECOMMUTE"
+		country := "Indonesia"
+		if posting.JobLocation != nil && posting.JobLocation.Address.AddressCountry != "ID" {
+			country = posting.JobLocation.Address.AddressCountry
+		}
+		posting.ApplicantLocationRequirements = &PostingCountry{Type: "Country", Name: country}
+	}
+
+	posting.BaseSalary = newPostingSalary(j)
+	return posting
+}
+
+// PrimaryLocation returns the job's primary location, else its first location, or nil
+// when the job has none
+func (j *Job) PrimaryLocation() *JobLocation {
+	for i := range j.Locations {
+		if j.Locations[i].IsPrimary {
+			return &j.Locations[i]
+		}
+	}
+	if len(j.Locations) > 0 {
+		return &j.Locations[0]
+	}
+	return nil
+}
+
+func newPostingPlace(address PostingAddress) *PostingPlace {
+	address.Type = "PostalAddress"
+	// Search engines expect ISO 3166 country codes; jobs and companies store country names
+	if address.AddressCountry == "" || strings.EqualFold(address.AddressCountry, "Indonesia") {
+		address.AddressCountry = "ID"
+	}
+	return &PostingPlace{Type: "Place", Address: address}
+}
+
+// PublicSalary returns the salary amounts SalaryDisplay shows publicly; both are nil when
+// the job hides its salary
+func (j *Job) PublicSalary() (minSalary, maxSalary *float64) {
+	switch j.SalaryDisplay {
+	case "range":
+		return j.SalaryMin, j.SalaryMax
+	case "min_only":
+		return j.SalaryMin, nil
+	case "max_only":
+		return nil, j.SalaryMax
+	default:
+		return nil, nil
+	}
+}
+
+// newPostingSalary returns the salary SalaryDisplay shows publicly, or nil when it hides the amounts
+func newPostingSalary(j *Job) *PostingSalary {
+	amount := PostingSalaryAmount{Type: "QuantitativeValue", UnitText: "MONTH"}
+	amount.MinValue, amount.MaxValue = j.PublicSalary()
+	if amount.MinValue == nil && amount.MaxValue == nil {
+		return nil
+	}
+
+	currency := j.Currency
+	if currency == "" {
+		currency = "IDR"
+	}
+	return &PostingSalary{Type: "MonetaryAmount", Currency: currency, Value: amount}
+}
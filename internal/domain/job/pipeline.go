@@ -0,0 +1,101 @@
+package job
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"keerja-backend/internal/domain/application"
+)
+
+// MaxCustomPipelineStages is the most custom stages a job's pipeline may have
+const MaxCustomPipelineStages = 10
+
+// PipelineStatuses are the canonical stages every pipeline has, in order. They are named after
+// the application statuses an employer moves applications through.
+var PipelineStatuses = []string{
+	application.StatusApplied,
+	application.StatusScreening,
+	application.StatusShortlisted,
+	application.StatusInterview,
+	application.StatusOffered,
+	application.StatusHired,
+}
+
+// JobPipelineStage is a stage of a job's hiring pipeline. Canonical stages are named after
+// their status; custom stages, such as a technical test, sit after the canonical stage of the
+// status they map to, which is the status applications have while in them.
+type JobPipelineStage struct {
+	ID           int64     `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	JobID        int64     `gorm:"column:job_id;not null;index" json:"job_id"`
+	Name         string    `gorm:"column:name;type:varchar(50);not null" json:"name"`
+	StageOrder   int       `gorm:"column:stage_order;not null" json:"order"`
+	MapsToStatus string    `gorm:"column:maps_to_status;type:varchar(30);not null" json:"maps_to_status"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for JobPipelineStage
+func (JobPipelineStage) TableName() string {
+	return "job_pipeline_stages"
+}
+
+// IsCustom reports whether the stage was added by the employer rather than being canonical
+func (s *JobPipelineStage) IsCustom() bool {
+	return s.Name != s.MapsToStatus
+}
+
+// DefaultPipeline returns the pipeline of canonical stages new jobs start with
+func DefaultPipeline(jobID int64) []JobPipelineStage {
+	stages, _ := BuildPipeline(jobID, nil)
+	return stages
+}
+
+// BuildPipeline returns the job's pipeline with the custom stages placed after the canonical
+// stage of the status they map to, in the order given. Custom stage names must be unique and
+// may not be application statuses; they cannot map to hired, which ends the pipeline.
+func BuildPipeline(jobID int64, custom []CustomPipelineStage) ([]JobPipelineStage, error) {
+	if len(custom) > MaxCustomPipelineStages {
+		return nil, ErrInvalidPipeline.WithField("stages", "at most 10 custom stages are allowed")
+	}
+
+	seen := make(map[string]bool, len(custom))
+	for _, stage := range custom {
+		name := strings.ToLower(strings.TrimSpace(stage.Name))
+		switch {
+		case name == "" || len(name) > 50:
+			return nil, ErrInvalidPipeline.WithField("name", "must be 1 to 50 characters")
+		case application.IsValidStatus(name):
+			return nil, ErrInvalidPipeline.WithField("name", "'"+stage.Name+"' is an application status")
+		case seen[name]:
+			return nil, ErrInvalidPipeline.WithField("name", "'"+stage.Name+"' is used more than once")
+		case !slices.Contains(PipelineStatuses, stage.MapsToStatus) || stage.MapsToStatus == application.StatusHired:
+			return nil, ErrInvalidPipeline.WithField("maps_to_status", "must be applied, screening, shortlisted, interview or offered")
+		}
+		seen[name] = true
+	}
+
+	stages := make([]JobPipelineStage, 0, len(PipelineStatuses)+len(custom))
+	add := func(name, status string) {
+		stages = append(stages, JobPipelineStage{JobID: jobID, Name: name, StageOrder: len(stages) + 1, MapsToStatus: status})
+	}
+	for _, status := range PipelineStatuses {
+		add(status, status)
+		for _, stage := range custom {
+			if stage.MapsToStatus == status {
+				add(strings.TrimSpace(stage.Name), status)
+			}
+		}
+	}
+	return stages, nil
+}
+
+// FindPipelineStage returns the stage of the pipeline named name, ignoring case, or nil
+func FindPipelineStage(pipeline []JobPipelineStage, name string) *JobPipelineStage {
+	name = strings.TrimSpace(name)
+	for i := range pipeline {
+		if strings.EqualFold(pipeline[i].Name, name) {
+			return &pipeline[i]
+		}
+	}
+	return nil
+}
@@ -10,18 +10,30 @@ type JobRepository interface {
 	UpdateStatusByCompany(ctx context.Context, companyID int64, fromStatus, toStatus string) error
 	// Job CRUD operations
 	Create(ctx context.Context, job *Job) error
+	CreateWithRelations(ctx context.Context, job *Job) error
 	FindByID(ctx context.Context, id int64) (*Job, error)
+	// FindByIDs loads the given jobs without relations in one query; missing IDs are skipped
+	FindByIDs(ctx context.Context, ids []int64) ([]Job, error)
 	FindByUUID(ctx context.Context, uuid string) (*Job, error)
 	FindBySlug(ctx context.Context, slug string) (*Job, error)
+	SlugExists(ctx context.Context, slug string, excludeID int64) (bool, error)
 	Update(ctx context.Context, job *Job) error
 	Delete(ctx context.Context, id int64) error
-	SoftDelete(ctx context.Context, id int64) error
+	// SoftDelete hides the job and moves its open applications to job_withdrawn, returning them
+	SoftDelete(ctx context.Context, id int64) ([]WithdrawnApplication, error)
+	// FindDeletedByID finds a soft-deleted job, returning nil when there is none
+	FindDeletedByID(ctx context.Context, id int64) (*Job, error)
+	// Restore brings back a soft-deleted job and returns its job_withdrawn applications to
+	// the status they had
+	Restore(ctx context.Context, id int64) error
 
 	// Job listing and search
 	List(ctx context.Context, filter JobFilter, page, limit int) ([]Job, int64, error)
+	ListByCursor(ctx context.Context, filter JobFilter, cursor string, limit int) ([]Job, string, error)
 	ListByCompany(ctx context.Context, companyID int64, filter JobFilter, page, limit int) ([]Job, int64, error)
 	ListByEmployer(ctx context.Context, employerUserID int64, filter JobFilter, page, limit int) ([]Job, int64, error)
 	SearchJobs(ctx context.Context, filter JobSearchFilter, page, limit int) ([]Job, int64, error)
+	GetSearchFacets(ctx context.Context, filter JobSearchFilter) (*SearchFacets, error)
 
 	// Job status operations
 	UpdateStatus(ctx context.Context, id int64, status string) error
@@ -33,8 +45,34 @@ type JobRepository interface {
 	GetExpiredJobs(ctx context.Context) ([]Job, error)
 	GetExpiringJobs(ctx context.Context, days int) ([]Job, error)
 
+	// Expiry reminders and auto-extend
+	FindJobsExpiringWithin(ctx context.Context, days int) ([]Job, error)
+	// MarkExpiryReminded records the reminder threshold; it returns false when an equal or
+	// more urgent reminder was already recorded for the job's current expiry date
+	MarkExpiryReminded(ctx context.Context, jobID int64, days int) (bool, error)
+	// AutoExtendJob moves a published auto_extend job's expiry date to expiredAt unless it
+	// was already extended maxExtensions times; it reports whether the job was extended
+	AutoExtendJob(ctx context.Context, jobID int64, expiredAt time.Time, maxExtensions int) (bool, error)
+
+	// Admin review
+	SubmitForReview(ctx context.Context, id int64) error
+	ReviewJob(ctx context.Context, review *JobReview, status string, publishedAt, expiredAt *time.Time) error
+	FindLatestReview(ctx context.Context, jobID int64) (*JobReview, error)
+
+	// Follower notifications
+	MarkFollowersNotified(ctx context.Context, jobID int64, window time.Duration) (bool, error)
+
+	// Draft revisions
+	CreateDraftRevision(ctx context.Context, revision *JobDraftRevision, keep int) error
+	ListDraftRevisions(ctx context.Context, jobID int64) ([]JobDraftRevision, error)
+	FindDraftRevision(ctx context.Context, jobID, revisionID int64) (*JobDraftRevision, error)
+	DeleteDraftRevisions(ctx context.Context, jobID int64) error
+
 	// Job statistics
 	IncrementViews(ctx context.Context, id int64) error
+	RecordView(ctx context.Context, event *JobViewEvent, window time.Duration) (bool, error)
+	DeleteViewEventsBefore(ctx context.Context, before time.Time) (int64, error)
+	GetDailyViews(ctx context.Context, jobID int64, from, to time.Time) ([]TimeSeriesData, error)
 	IncrementApplications(ctx context.Context, id int64) error
 	GetJobStats(ctx context.Context, jobID int64) (*JobStats, error)
 	GetCompanyJobStats(ctx context.Context, companyID int64) (*CompanyJobStats, error)
@@ -59,8 +97,8 @@ type JobRepository interface {
 	GetCategoryTree(ctx context.Context) ([]JobCategory, error)
 	GetActiveCategories(ctx context.Context) ([]JobCategory, error)
 
-	// Get jobs by specific status
-	GetJobsByStatus(ctx context.Context, userID int64, status string, page, limit int) ([]Job, int64, error)
+	// GetJobsByStatus lists the jobs posted by the employer user by UI status
+	GetJobsByStatus(ctx context.Context, employerUserID int64, status string, page, limit int) ([]Job, int64, error)
 
 	// JobSubcategory CRUD
 	CreateSubcategory(ctx context.Context, subcategory *JobSubcategory) error
@@ -89,6 +127,8 @@ type JobRepository interface {
 	GetHighlightedBenefits(ctx context.Context, jobID int64) ([]JobBenefit, error)
 	BulkCreateBenefits(ctx context.Context, benefits []JobBenefit) error
 	BulkDeleteBenefits(ctx context.Context, jobID int64) error
+	// ReplaceBenefits swaps the job's benefits for the given set in one transaction
+	ReplaceBenefits(ctx context.Context, jobID int64, benefits []JobBenefit) error
 
 	// JobSkill operations
 	CreateSkill(ctx context.Context, skill *JobSkill) error
@@ -100,6 +140,19 @@ type JobRepository interface {
 	GetPreferredSkills(ctx context.Context, jobID int64) ([]JobSkill, error)
 	BulkCreateSkills(ctx context.Context, skills []JobSkill) error
 	BulkDeleteSkills(ctx context.Context, jobID int64) error
+	// ReplaceSkills swaps the job's skills for the given set in one transaction
+	ReplaceSkills(ctx context.Context, jobID int64, skills []JobSkill) error
+
+	// JobQuestion operations
+	CreateQuestion(ctx context.Context, question *JobQuestion) error
+	FindQuestionByID(ctx context.Context, id int64) (*JobQuestion, error)
+	UpdateQuestion(ctx context.Context, question *JobQuestion) error
+	DeleteQuestion(ctx context.Context, id int64) error
+	ListQuestionsByJob(ctx context.Context, jobID int64) ([]JobQuestion, error)
+
+	// Pipeline operations
+	ListPipelineStages(ctx context.Context, jobID int64) ([]JobPipelineStage, error)
+	ReplacePipelineStages(ctx context.Context, jobID int64, stages []JobPipelineStage) error
 
 	// JobRequirement operations
 	CreateRequirement(ctx context.Context, requirement *JobRequirement) error
@@ -110,10 +163,31 @@ type JobRepository interface {
 	GetMandatoryRequirements(ctx context.Context, jobID int64) ([]JobRequirement, error)
 	BulkCreateRequirements(ctx context.Context, requirements []JobRequirement) error
 	BulkDeleteRequirements(ctx context.Context, jobID int64) error
+	// ReplaceRequirements swaps the job's requirements for the given set in one transaction
+	ReplaceRequirements(ctx context.Context, jobID int64, requirements []JobRequirement) error
+
+	// Featured and trending
+	// ListFeatured returns up to limit published jobs that are featured at now, the most
+	// recently started featured window first
+	ListFeatured(ctx context.Context, now time.Time, limit int) ([]Job, error)
+	// ListViewBuckets returns hourly view counts since the given time of published jobs
+	ListViewBuckets(ctx context.Context, since time.Time) ([]JobViewBucket, error)
+	// FindPublishedByIDs loads the given published jobs with their listing relations;
+	// missing and unpublished IDs are skipped
+	FindPublishedByIDs(ctx context.Context, ids []int64) ([]Job, error)
+	// SetFeatured sets the job's featured flag and window
+	SetFeatured(ctx context.Context, jobID int64, featured bool, from, until *time.Time) error
+
+	// Sitemap
+	// CountSitemapEntries counts the jobs that are published and unexpired at now
+	CountSitemapEntries(ctx context.Context, now time.Time) (int64, error)
+	// StreamSitemapEntries calls fn for up to limit jobs published and unexpired at now,
+	// in ID order after skipping offset, reading rows one at a time
+	StreamSitemapEntries(ctx context.Context, now time.Time, offset, limit int, fn func(SitemapEntry) error) error
 
 	// Analytics
-	GetTrendingJobs(ctx context.Context, limit int) ([]Job, error)
 	GetPopularCategories(ctx context.Context, limit int) ([]CategoryStats, error)
+	GetTopCompanies(ctx context.Context, limit int) ([]CompanyStats, error)
 	GetJobsByDateRange(ctx context.Context, startDate, endDate time.Time, filter JobFilter) ([]Job, error)
 
 	// Master Data Preload
@@ -121,27 +195,62 @@ type JobRepository interface {
 	FindByIDWithMasterData(ctx context.Context, id int64) (*Job, error)
 }
 
+// MatchScoreRepository stores precomputed job match scores and loads what computing them needs
+type MatchScoreRepository interface {
+	// UpsertScores inserts the scores, replacing existing scores of the same user and job
+	UpsertScores(ctx context.Context, scores []UserJobMatchScore) error
+
+	// FindScores loads the user's stored scores of the given jobs; jobs without one are skipped
+	FindScores(ctx context.Context, userID int64, jobIDs []int64) ([]UserJobMatchScore, error)
+
+	// TopJobsForUser returns up to limit published, unexpired jobs with the user's highest
+	// scores computed at or after freshSince, best first, with their scores
+	TopJobsForUser(ctx context.Context, userID int64, freshSince time.Time, limit int) ([]JobWithScore, error)
+
+	// DeleteByUser deletes the user's scores
+	DeleteByUser(ctx context.Context, userID int64) error
+
+	// DeleteByJob deletes the job's scores
+	DeleteByJob(ctx context.Context, jobID int64) error
+
+	// DeleteComputedBefore deletes scores computed before cutoff and returns how many were deleted
+	DeleteComputedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+
+	// ListActiveJobSeekerIDs returns up to limit IDs greater than afterID of active job seekers
+	// who signed in at or after activeSince, in ascending order
+	ListActiveJobSeekerIDs(ctx context.Context, activeSince time.Time, afterID int64, limit int) ([]int64, error)
+
+	// ListScoringJobs returns published, unexpired jobs published at or after publishedSince,
+	// with the relations match scores read (skills with their master entries, education level)
+	ListScoringJobs(ctx context.Context, publishedSince time.Time) ([]Job, error)
+}
+
 // JobFilter defines filter criteria for job listing
 type JobFilter struct {
-	Status         string
-	CompanyID      int64
-	CategoryID     int64
-	SubcategoryID  int64
-	City           string
-	Province       string
-	JobLevel       string
-	EmploymentType string
-	RemoteOption   *bool
-	MinSalary      *float64
-	MaxSalary      *float64
-	MinExperience  *int16
-	MaxExperience  *int16
-	EducationLevel string
-	IsActive       *bool
-	PublishedAfter *time.Time
-	SortBy         string // "latest", "salary_asc", "salary_desc", "views", "applications"
+	Status          string
+	CompanyID       int64
+	CategoryID      int64
+	SubcategoryID   int64
+	City            string
+	Province        string
+	JobLevel        string
+	EmploymentType  string
+	RemoteOption    *bool
+	MinSalary       *float64
+	MaxSalary       *float64
+	MinExperience   *int16
+	MaxExperience   *int16
+	EducationLevel  string
+	IsActive        *bool
+	PublishedAfter  *time.Time
+	SubmittedAfter  *time.Time
+	SubmittedBefore *time.Time
+	SortBy          string // "latest", "salary_asc", "salary_desc", "views", "applications", "submitted"
 }
 
+// DefaultSearchRadiusKm is the radius used by location searches that do not specify one
+const DefaultSearchRadiusKm = 25.0
+
 // JobSearchFilter defines advanced search criteria
 type JobSearchFilter struct {
 	Keyword         string
@@ -164,6 +273,11 @@ type JobSearchFilter struct {
 	WorkPolicyIDs     []int64 // On-site, Remote, Hybrid
 	EducationLevelID  *int64
 	ExperienceLevelID *int64
+
+	// Location search: jobs within RadiusKm of the point, nearest first (see DefaultSearchRadiusKm)
+	Latitude  *float64
+	Longitude *float64
+	RadiusKm  float64
 }
 
 // CategoryFilter defines filter criteria for category listing
@@ -201,6 +315,13 @@ type CompanyJobStats struct {
 	AverageApplicationsPerJob float64
 }
 
+// WithdrawnApplication is an application closed because its job was deleted
+type WithdrawnApplication struct {
+	ApplicationID  int64
+	UserID         int64
+	PreviousStatus string
+}
+
 // CategoryStats represents category statistics
 type CategoryStats struct {
 	CategoryID        int64
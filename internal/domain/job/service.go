@@ -3,37 +3,50 @@ package job
 import (
 	"context"
 	"time"
+
+	"keerja-backend/internal/apperror"
+	"keerja-backend/internal/domain/employer"
 )
 
 // JobService defines the interface for job business logic
 type JobService interface {
 	// Job management (Employer)
-	CreateJob(ctx context.Context, req *CreateJobRequest) (*Job, error)
-	UpdateJob(ctx context.Context, jobID int64, req *UpdateJobRequest) (*Job, error)
-	DeleteJob(ctx context.Context, jobID int64, employerUserID int64) error
+	CreateJob(ctx context.Context, ec *employer.EmployerContext, req *CreateJobRequest) (*Job, error)
+	UpdateJob(ctx context.Context, jobID int64, ec *employer.EmployerContext, req *UpdateJobRequest) (*Job, error)
+	DeleteJob(ctx context.Context, jobID int64, ec *employer.EmployerContext) error
+	RestoreJob(ctx context.Context, jobID int64, ec *employer.EmployerContext) error
+	DuplicateJob(ctx context.Context, jobID int64, ec *employer.EmployerContext) (*Job, error)
 	GetJob(ctx context.Context, jobID int64) (*Job, error)
 	GetJobBySlug(ctx context.Context, slug string) (*Job, error)
+	GetLatestReview(ctx context.Context, jobID int64) (*JobReview, error)
+	// GetJobCompanyID returns the company that owns the job, including jobs deleted within the restore window
+	GetJobCompanyID(ctx context.Context, jobID int64) (int64, error)
 	GetJobByUUID(ctx context.Context, uuid string) (*Job, error)
-	GetMyJobs(ctx context.Context, employerUserID int64, filter JobFilter, page, limit int) ([]Job, int64, error)
+	GetMyJobs(ctx context.Context, ec *employer.EmployerContext, filter JobFilter, page, limit int) ([]Job, int64, error)
 	GetCompanyJobs(ctx context.Context, companyID int64, filter JobFilter, page, limit int) ([]Job, int64, error)
 
 	// Phase 6: Job draft workflow
 	SaveJobDraft(ctx context.Context, companyID int64, req *SaveJobDraftRequest) (*Job, error)
+	ListCompanyDrafts(ctx context.Context, companyID int64, page, limit int) ([]JobDraft, int64, error)
+	ListDraftRevisions(ctx context.Context, jobID int64, ec *employer.EmployerContext) ([]JobDraftRevision, error)
+	RestoreDraftRevision(ctx context.Context, jobID, revisionID int64, ec *employer.EmployerContext) (*Job, error)
 
 	// Job status management
-	PublishJob(ctx context.Context, jobID int64, employerUserID int64, expiredAt *time.Time) error
-	UnpublishJob(ctx context.Context, jobID int64, employerUserID int64) error
-	InactivateJob(ctx context.Context, jobID int64, employerUserID int64) error
-	CloseJob(ctx context.Context, jobID int64, employerUserID int64) error
-	ReopenJob(ctx context.Context, jobID int64, employerUserID int64) error
-	SuspendJob(ctx context.Context, jobID int64, employerUserID int64, reason string) error
+	PublishJob(ctx context.Context, jobID int64, ec *employer.EmployerContext, expiredAt *time.Time) error
+	UnpublishJob(ctx context.Context, jobID int64, ec *employer.EmployerContext) error
+	InactivateJob(ctx context.Context, jobID int64, ec *employer.EmployerContext) error
+	CloseJob(ctx context.Context, jobID int64, ec *employer.EmployerContext) error
+	ReopenJob(ctx context.Context, jobID int64, ec *employer.EmployerContext) error
+	SuspendJob(ctx context.Context, jobID int64, ec *employer.EmployerContext, reason string) error
 	SetJobExpiry(ctx context.Context, jobID int64, expiryDate time.Time) error
 	ExtendJobExpiry(ctx context.Context, jobID int64, days int) error
-	AutoExpireJobs(ctx context.Context) error
+	AutoExpireJobs(ctx context.Context) (*ExpiryStats, error)
+	SendExpiryReminders(ctx context.Context) (int, error)
 	UpdateStatus(ctx context.Context, jobID int64, status string) error
 
 	// Job search and discovery (Public)
 	ListJobs(ctx context.Context, filter JobFilter, page, limit int) ([]Job, int64, error)
+	ListJobsByCursor(ctx context.Context, filter JobFilter, cursor string, limit int) ([]Job, string, error)
 	SearchJobs(ctx context.Context, filter JobSearchFilter, page, limit int) (*JobSearchResponse, error)
 	SearchJobsByLocation(ctx context.Context, latitude, longitude, radius float64, filter JobFilter, page, limit int) ([]Job, int64, error)
 	GetFeaturedJobs(ctx context.Context, limit int) ([]Job, error)
@@ -45,6 +58,7 @@ type JobService interface {
 	// Job matching
 	CalculateMatchScore(ctx context.Context, jobID, userID int64) (*MatchScore, error)
 	GetMatchingJobs(ctx context.Context, userID int64, filter JobFilter, page, limit int) (*MatchResponse, error)
+	RefreshMatchScores(ctx context.Context) (int, error)
 
 	// Job views and interactions
 	IncrementView(ctx context.Context, jobID int64, userID *int64) error
@@ -67,6 +81,15 @@ type JobService interface {
 	DeleteSkill(ctx context.Context, jobSkillID int64) error
 	BulkAddSkills(ctx context.Context, jobID int64, skills []AddSkillRequest) error
 
+	AddQuestion(ctx context.Context, jobID int64, ec *employer.EmployerContext, req *AddQuestionRequest) (*JobQuestion, error)
+	UpdateQuestion(ctx context.Context, jobID, questionID int64, ec *employer.EmployerContext, req *UpdateQuestionRequest) (*JobQuestion, error)
+	DeleteQuestion(ctx context.Context, jobID, questionID int64, ec *employer.EmployerContext) error
+	ListQuestions(ctx context.Context, jobID int64) ([]JobQuestion, error)
+
+	// Hiring pipeline; custom stages can only be changed while the job is draft or pending review
+	GetPipeline(ctx context.Context, jobID int64, ec *employer.EmployerContext) ([]JobPipelineStage, error)
+	ConfigurePipeline(ctx context.Context, jobID int64, ec *employer.EmployerContext, req *ConfigurePipelineRequest) ([]JobPipelineStage, error)
+
 	AddRequirement(ctx context.Context, jobID int64, req *AddRequirementRequest) (*JobRequirement, error)
 	UpdateRequirement(ctx context.Context, requirementID int64, req *UpdateRequirementRequest) (*JobRequirement, error)
 	DeleteRequirement(ctx context.Context, requirementID int64) error
@@ -98,13 +121,13 @@ type JobService interface {
 	GetTopCompanies(ctx context.Context, limit int) ([]CompanyStats, error)
 
 	// Bulk operations
-	BulkPublishJobs(ctx context.Context, jobIDs []int64) error
+	BulkPublishJobs(ctx context.Context, jobIDs []int64) (*BulkPublishResult, error)
 	BulkCloseJobs(ctx context.Context, jobIDs []int64) error
 	BulkDeleteJobs(ctx context.Context, jobIDs []int64) error
 
 	// Validation
 	ValidateJob(ctx context.Context, job *Job) error
-	CheckJobOwnership(ctx context.Context, jobID, employerUserID int64) error
+	CheckJobOwnership(ctx context.Context, jobID int64, ec *employer.EmployerContext) error
 	CheckJobStatus(ctx context.Context, jobID int64) (string, error)
 
 	// Master Data Validation
@@ -117,9 +140,8 @@ type JobService interface {
 // CreateJobRequest represents request to create a new job (master data only)
 type CreateJobRequest struct {
 	// Required Fields
-	CompanyID      int64  `json:"company_id" validate:"required"`
-	EmployerUserID int64  `json:"employer_user_id" validate:"required"`
-	Description    string `json:"description" validate:"required"`
+	CompanyID   int64  `json:"company_id" validate:"required"`
+	Description string `json:"description" validate:"required"`
 
 	// Master Data IDs (All Required)
 	JobTitleID         *int64 `json:"job_title_id" validate:"omitempty"`
@@ -151,10 +173,6 @@ type CreateJobRequest struct {
 
 // UpdateJobRequest represents request to update job (master data only)
 type UpdateJobRequest struct {
-	// Internal - for ownership verification (not from API)
-	EmployerUserID int64 `json:"employer_user_id,omitempty"` // Will be set by handler
-	CompanyID      int64 `json:"company_id,omitempty"`       // Will be set by handler
-
 	// Master Data IDs (Optional for updates)
 	JobTitleID         *int64 `json:"job_title_id,omitempty"`
 	JobTypeID          *int64 `json:"job_type_id,omitempty"`
@@ -171,7 +189,17 @@ type UpdateJobRequest struct {
 	MinAge           *int              `json:"min_age,omitempty" validate:"omitempty,min=17,max=65"`
 	MaxAge           *int              `json:"max_age,omitempty" validate:"omitempty,min=17,max=65,gtefield=MinAge"`
 	CompanyAddressID *int64            `json:"company_address_id,omitempty" validate:"omitempty,min=1"`
+	AutoExtend       *bool             `json:"auto_extend,omitempty"`
 	Skills           []AddSkillRequest `json:"skills,omitempty"`
+
+	// Version the client loaded; when set the update fails if the job changed since
+	Version *int64 `json:"version,omitempty"`
+}
+
+// JobDraft is a draft job with the fields it still needs before it can be published
+type JobDraft struct {
+	Job           Job
+	MissingFields []string // Empty when the draft passes publish validation's required-field checks
 }
 
 // SaveJobDraftRequest represents request to save job draft (Phase 6)
@@ -261,6 +289,40 @@ type UpdateSkillRequest struct {
 	Weight          *float64 `json:"weight,omitempty"`
 }
 
+// AddQuestionRequest represents request to add a screening question to a job
+type AddQuestionRequest struct {
+	QuestionText  string   `json:"question_text" validate:"required,max=500"`
+	QuestionType  string   `json:"type" validate:"required,oneof=text boolean number select"`
+	Options       []string `json:"options,omitempty" validate:"omitempty,max=20,dive,required,max=200"`
+	IsRequired    bool     `json:"is_required"`
+	IsKnockout    bool     `json:"is_knockout"`
+	KnockoutValue string   `json:"knockout_value,omitempty" validate:"omitempty,max=200"`
+	SortOrder     int      `json:"sort_order" validate:"omitempty,min=0"`
+}
+
+// ConfigurePipelineRequest sets the custom stages of a job's hiring pipeline, replacing the
+// current ones. An empty list restores the default pipeline.
+type ConfigurePipelineRequest struct {
+	Stages []CustomPipelineStage `json:"stages" validate:"max=10,dive"`
+}
+
+// CustomPipelineStage is a custom stage placed after the canonical stage of MapsToStatus
+type CustomPipelineStage struct {
+	Name         string `json:"name" validate:"required,max=50"`
+	MapsToStatus string `json:"maps_to_status" validate:"required,oneof=applied screening shortlisted interview offered"`
+}
+
+// UpdateQuestionRequest represents request to update a screening question
+type UpdateQuestionRequest struct {
+	QuestionText  string   `json:"question_text,omitempty" validate:"omitempty,max=500"`
+	QuestionType  string   `json:"type,omitempty" validate:"omitempty,oneof=text boolean number select"`
+	Options       []string `json:"options,omitempty" validate:"omitempty,max=20,dive,required,max=200"`
+	IsRequired    *bool    `json:"is_required,omitempty"`
+	IsKnockout    *bool    `json:"is_knockout,omitempty"`
+	KnockoutValue *string  `json:"knockout_value,omitempty" validate:"omitempty,max=200"`
+	SortOrder     *int     `json:"sort_order,omitempty" validate:"omitempty,min=0"`
+}
+
 // AddRequirementRequest represents request to add job requirement
 type AddRequirementRequest struct {
 	RequirementType string `json:"requirement_type" validate:"omitempty,oneof='education' 'experience' 'skill' 'language' 'certification' 'other'"`
@@ -343,10 +405,14 @@ type SearchFacets struct {
 	JobLevels       []FacetItem `json:"job_levels"`
 	EmploymentTypes []FacetItem `json:"employment_types"`
 	SalaryRanges    []FacetItem `json:"salary_ranges"`
+	JobTypes        []FacetItem `json:"job_types"`
+	WorkPolicies    []FacetItem `json:"work_policies"`
+	Cities          []FacetItem `json:"cities"`
 }
 
 // FacetItem represents a facet item with count
 type FacetItem struct {
+	ID    int64  `json:"id,omitempty"` // master data ID, when the facet is backed by one
 	Value string `json:"value"`
 	Count int64  `json:"count"`
 }
@@ -448,3 +514,33 @@ type CompanyStats struct {
 	TotalViews        int64  `json:"total_views"`
 	TotalApplications int64  `json:"total_applications"`
 }
+
+// ExpiryStats summarizes one AutoExpireJobs run
+type ExpiryStats struct {
+	Extended int // Published auto_extend jobs given another expiry period
+	Expired  int // Published jobs past their expiry date that were expired
+}
+
+// BulkPublishResult reports which jobs of a bulk publish were published and why the others were not
+type BulkPublishResult struct {
+	Published []int64            `json:"published"`
+	Failed    []BulkPublishError `json:"failed,omitempty"`
+}
+
+// BulkPublishError is a job a bulk publish left unpublished, with the error's code and details
+type BulkPublishError struct {
+	JobID   int64             `json:"job_id"`
+	Code    string            `json:"code,omitempty"`
+	Error   string            `json:"error"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// AddFailure records that the job could not be published
+func (r *BulkPublishResult) AddFailure(jobID int64, err error) {
+	failure := BulkPublishError{JobID: jobID, Error: err.Error()}
+	if appErr, ok := apperror.As(err); ok {
+		failure.Code = appErr.Code
+		failure.Details = appErr.Details
+	}
+	r.Failed = append(r.Failed, failure)
+}
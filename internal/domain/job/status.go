@@ -0,0 +1,80 @@
+package job
+
+import "slices"
+
+// Job statuses. Jobs of unverified companies are created in_review and become drafts once
+// the company is verified; pending_review jobs wait for an admin to approve them.
+const (
+	StatusInReview      = "in_review"
+	StatusDraft         = "draft"
+	StatusPendingReview = "pending_review"
+	StatusPublished     = "published"
+	StatusInactive      = "inactive" // Hidden from job seekers by the employer
+	StatusClosed        = "closed"
+	StatusExpired       = "expired"
+	StatusSuspended     = "suspended"
+	StatusRejected      = "rejected"
+)
+
+// Statuses lists every job status, matching the jobs_status_check constraint
+var Statuses = []string{
+	StatusInReview,
+	StatusDraft,
+	StatusPendingReview,
+	StatusPublished,
+	StatusInactive,
+	StatusClosed,
+	StatusExpired,
+	StatusSuspended,
+	StatusRejected,
+}
+
+// statusTransitions maps each job status to the statuses a job may move to from it
+var statusTransitions = map[string][]string{
+	StatusInReview:      {StatusDraft, StatusRejected},
+	StatusDraft:         {StatusPendingReview, StatusPublished, StatusInactive, StatusClosed},
+	StatusPendingReview: {StatusPublished, StatusDraft, StatusRejected, StatusSuspended},
+	StatusPublished:     {StatusDraft, StatusInactive, StatusClosed, StatusExpired, StatusSuspended},
+	StatusInactive:      {StatusDraft, StatusPublished, StatusClosed},
+	StatusClosed:        {StatusDraft, StatusPublished},
+	StatusExpired:       {StatusDraft, StatusPublished, StatusClosed},
+	StatusSuspended:     {StatusDraft, StatusPublished, StatusClosed},
+	StatusRejected:      {StatusDraft},
+}
+
+// IsValidStatus reports whether status is a job status
+func IsValidStatus(status string) bool {
+	return slices.Contains(Statuses, status)
+}
+
+// IsValidTransition reports whether a job may move from one status to another. Staying in
+// the same status is not a transition.
+func IsValidTransition(from, to string) bool {
+	return slices.Contains(statusTransitions[from], to)
+}
+
+// Employer job list tabs. A tab groups several job statuses.
+const (
+	TabActive   = "active"
+	TabInactive = "inactive"
+	TabDraft    = "draft"
+	TabInReview = "in_review"
+)
+
+// TabStatuses maps each employer job list tab to the job statuses it shows
+var TabStatuses = map[string][]string{
+	TabActive:   {StatusPublished},
+	TabInactive: {StatusInactive, StatusClosed},
+	TabDraft:    {StatusDraft},
+	TabInReview: {StatusInReview, StatusPendingReview},
+}
+
+// TabForStatus returns the employer job list tab showing jobs in status, or "" when no tab does
+func TabForStatus(status string) string {
+	for tab, statuses := range TabStatuses {
+		if slices.Contains(statuses, status) {
+			return tab
+		}
+	}
+	return ""
+}
@@ -1,6 +1,9 @@
 package master
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 // AdminIndustryService defines complete CRUD operations for industry master data
 type AdminIndustryService interface {
@@ -97,7 +100,7 @@ type AdminDistrictService interface {
 	// Update updates an existing district
 	Update(ctx context.Context, id int64, req UpdateDistrictRequest) (*DistrictResponse, error)
 
-	// Delete deletes a district if not referenced by companies
+	// Delete deletes a district if nothing references it; see GetUsage
 	Delete(ctx context.Context, id int64) error
 
 	// CheckDuplicateNameInCity checks if a district with the given name exists in the city
@@ -105,6 +108,13 @@ type AdminDistrictService interface {
 
 	// CountCompanyReferences counts how many companies reference this district
 	CountCompanyReferences(ctx context.Context, id int64) (int64, error)
+
+	// GetUsage reports the records referencing this district
+	GetUsage(ctx context.Context, id int64) (*DistrictUsage, error)
+
+	// Merge moves the references of a district to another one, e.g. after a boundary change,
+	// and deactivates it
+	Merge(ctx context.Context, sourceID, targetID int64) (*DistrictMergeResult, error)
 }
 
 // AdminJobTypeService defines complete CRUD operations for job type master data
@@ -130,3 +140,67 @@ type AdminJobTypeService interface {
 	// CountJobReferences counts how many jobs reference this job type
 	CountJobReferences(ctx context.Context, id int64) (int64, error)
 }
+
+// Master data types that can be exported and imported as CSV
+const (
+	MasterDataProvinces    = "provinces"
+	MasterDataCities       = "cities"
+	MasterDataDistricts    = "districts"
+	MasterDataIndustries   = "industries"
+	MasterDataCompanySizes = "company-sizes"
+	MasterDataJobTitles    = "job-titles"
+	MasterDataJobOptions   = "job-options"
+)
+
+// MasterDataTransferTypes lists the master data types in the order their imports should
+// run; cities need their provinces and districts need their cities
+var MasterDataTransferTypes = []string{
+	MasterDataProvinces,
+	MasterDataCities,
+	MasterDataDistricts,
+	MasterDataIndustries,
+	MasterDataCompanySizes,
+	MasterDataJobTitles,
+	MasterDataJobOptions,
+}
+
+// MaxMasterDataImportFileSize caps the size of a master data import file
+const MaxMasterDataImportFileSize = 20 * 1024 * 1024 // 20MB
+
+// Master data import row statuses
+const (
+	MasterDataRowCreated = "created" // Row was inserted
+	MasterDataRowUpdated = "updated" // Row updated the existing record with the same key
+	MasterDataRowFailed  = "failed"  // Row failed validation or could not be saved
+)
+
+// MasterDataImportRow is the outcome of one CSV row; Row is the line number in the file
+// and Key the code or natural key the row upserts on
+type MasterDataImportRow struct {
+	Row    int    `json:"row"`
+	Key    string `json:"key,omitempty"`
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// MasterDataImportReport summarizes a master data import with a per-row outcome
+type MasterDataImportReport struct {
+	Type      string                `json:"type"`
+	TotalRows int                   `json:"total_rows"`
+	Created   int                   `json:"created"`
+	Updated   int                   `json:"updated"`
+	Failed    int                   `json:"failed"`
+	Rows      []MasterDataImportRow `json:"rows"`
+}
+
+// AdminMasterDataTransferService exports and bulk imports master data as CSV.
+// The CSV columns of each type are the same for export and import, so an export of one
+// environment can be imported into another.
+type AdminMasterDataTransferService interface {
+	// Export writes every record of the type as CSV, header row first
+	Export(ctx context.Context, dataType string, w io.Writer) error
+
+	// Import upserts the CSV rows of the type by their code or natural key in one
+	// transaction. Invalid rows are reported without stopping the import.
+	Import(ctx context.Context, dataType string, r io.Reader) (*MasterDataImportReport, error)
+}
@@ -2,6 +2,7 @@ package master
 
 import (
 	"database/sql"
+	"strings"
 	"time"
 )
 
@@ -259,6 +260,31 @@ func (s *SkillsMaster) IncrementPopularity(amount float64) {
 	}
 }
 
+// SkillAlias maps an alternate name to a canonical skill
+// Maps to: skill_aliases table
+type SkillAlias struct {
+	ID              int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	SkillID         int64     `gorm:"not null;index" json:"skill_id"`
+	Alias           string    `gorm:"type:varchar(150);not null" json:"alias"`
+	NormalizedAlias string    `gorm:"type:varchar(150);not null;uniqueIndex" json:"normalized_alias"`
+	MergedSkillID   *int64    `gorm:"index" json:"merged_skill_id,omitempty"` // Skill merged into SkillID that this alias was recorded for
+	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relationships
+	Skill *SkillsMaster `gorm:"foreignKey:SkillID;constraint:OnDelete:CASCADE" json:"skill,omitempty"`
+}
+
+// TableName specifies the table name for SkillAlias
+func (SkillAlias) TableName() string {
+	return "skill_aliases"
+}
+
+// NormalizeSkillName lowercases a skill name and collapses surrounding and repeated
+// whitespace, the form skill names and aliases are compared in
+func NormalizeSkillName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
 // AddAlias adds a new alias to the skill
 func (s *SkillsMaster) AddAlias(alias string) {
 	for _, existing := range s.Aliases {
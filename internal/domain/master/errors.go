@@ -0,0 +1,36 @@
+package master
+
+import "keerja-backend/internal/apperror"
+
+var (
+	// ErrUnknownMasterDataType is returned when exporting or importing a type not in MasterDataTransferTypes
+	ErrUnknownMasterDataType = apperror.NotFound("UNKNOWN_MASTER_DATA_TYPE", "unknown master data type")
+
+	// ErrMasterDataImportTooLarge is returned when the import file exceeds MaxMasterDataImportFileSize
+	ErrMasterDataImportTooLarge = apperror.Validation("MASTER_DATA_IMPORT_TOO_LARGE", "import file must not exceed 20MB").WithField("file", "must not exceed 20MB")
+
+	// ErrMasterDataImportInvalidFile is returned when the import file is not a CSV with the required header columns
+	ErrMasterDataImportInvalidFile = apperror.Validation("MASTER_DATA_IMPORT_INVALID_FILE", "import file must be a CSV with a header row")
+
+	// ErrSkillNotFound is returned when a skill ID matches neither a skill nor a skill merged into another
+	ErrSkillNotFound = apperror.NotFound("SKILL_NOT_FOUND", "skill not found")
+
+	// ErrSkillMergeIntoSelf is returned when a skill is merged into itself
+	ErrSkillMergeIntoSelf = apperror.Validation("SKILL_MERGE_INTO_SELF", "a skill cannot be merged into itself")
+
+	// ErrSkillMergeTargetInactive is returned when the skill to merge into is deactivated
+	ErrSkillMergeTargetInactive = apperror.Validation("SKILL_MERGE_TARGET_INACTIVE", "skills can only be merged into an active skill")
+
+	// ErrDistrictNotFound is returned when a district to report on or merge does not exist
+	ErrDistrictNotFound = apperror.NotFound("DISTRICT_NOT_FOUND", "district not found")
+
+	// ErrDistrictInUse is returned when deleting a district that is still referenced; deactivate
+	// or merge it instead
+	ErrDistrictInUse = apperror.Conflict("DISTRICT_IN_USE", "district is still referenced; deactivate or merge it instead")
+
+	// ErrDistrictMergeIntoSelf is returned when a district is merged into itself
+	ErrDistrictMergeIntoSelf = apperror.Validation("DISTRICT_MERGE_INTO_SELF", "a district cannot be merged into itself")
+
+	// ErrDistrictMergeTargetInactive is returned when the district to merge into is deactivated
+	ErrDistrictMergeTargetInactive = apperror.Validation("DISTRICT_MERGE_TARGET_INACTIVE", "districts can only be merged into an active district")
+)
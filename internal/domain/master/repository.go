@@ -84,6 +84,21 @@ type SkillsMasterRepository interface {
 	UpdateAliases(ctx context.Context, id int64, aliases []string) error
 	FindByAliases(ctx context.Context, aliases []string) ([]SkillsMaster, error)
 
+	// Taxonomy Operations
+	// ResolveSkill returns the skill with the ID or, for the ID of a merged skill, the skill
+	// it was merged into; nil when neither exists
+	ResolveSkill(ctx context.Context, id int64) (*SkillsMaster, error)
+	// FindCanonicalByName returns the skill whose name or skill_aliases entry equals name after
+	// normalizing both with NormalizeSkillName; nil when there is none
+	FindCanonicalByName(ctx context.Context, name string) (*SkillsMaster, error)
+	// SuggestSkills returns active skills whose name or an alias starts with or resembles
+	// query, best matches first
+	SuggestSkills(ctx context.Context, query string, limit int) ([]SkillsMaster, error)
+	// MergeSkill moves every job_skills, job_requirements and user_skills reference of the
+	// source skill to the target in one transaction, records the source's names as aliases
+	// of the target and deletes the source
+	MergeSkill(ctx context.Context, sourceID, targetID int64) (*SkillMergeResult, error)
+
 	// Status Operations
 	Activate(ctx context.Context, id int64) error
 	Deactivate(ctx context.Context, id int64) error
@@ -357,6 +372,14 @@ type DistrictRepository interface {
 
 	// ExistsByID checks if a district exists by ID
 	ExistsByID(ctx context.Context, id int64) (bool, error)
+
+	// CountUsage counts the companies, company addresses, user profiles, jobs and change
+	// requests referencing a district, soft-deleted rows included
+	CountUsage(ctx context.Context, id int64) (*DistrictUsage, error)
+
+	// MergeInto moves every reference of the source district to the target in one transaction,
+	// updating the city and province stored next to it, and deactivates the source
+	MergeInto(ctx context.Context, sourceID, targetID int64) (*DistrictMergeResult, error)
 }
 
 // JobTitleRepository defines data access methods for JobTitle
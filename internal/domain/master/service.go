@@ -87,6 +87,8 @@ type SkillsMasterService interface {
 	RemoveAlias(ctx context.Context, skillID int64, alias string) error
 	UpdateAliases(ctx context.Context, skillID int64, aliases []string) error
 	SearchByAlias(ctx context.Context, alias string) ([]SkillResponse, error)
+	SuggestSkills(ctx context.Context, query string, limit int) ([]SkillResponse, error)
+	MergeSkill(ctx context.Context, sourceID, targetID int64) (*SkillMergeResult, error)
 
 	// Status Management
 	ActivateSkill(ctx context.Context, id int64) error
@@ -240,6 +242,18 @@ type SkillListResponse struct {
 	TotalPages int             `json:"total_pages"`
 }
 
+// SkillMergeResult reports what merging a duplicate skill into its canonical skill changed
+type SkillMergeResult struct {
+	SourceID          int64    `json:"source_id"`
+	TargetID          int64    `json:"target_id"`
+	JobSkillsMoved    int64    `json:"job_skills_moved"`
+	JobSkillsMerged   int64    `json:"job_skills_merged"` // Dropped because the job already required the target
+	RequirementsMoved int64    `json:"requirements_moved"`
+	UserSkillsRenamed int64    `json:"user_skills_renamed"`
+	UserSkillsMerged  int64    `json:"user_skills_merged"` // Dropped because the user already had the target
+	Aliases           []string `json:"aliases"`
+}
+
 // SkillTreeResponse represents a hierarchical skill tree
 type SkillTreeResponse struct {
 	Skill    *SkillResponse      `json:"skill"`
@@ -411,8 +425,10 @@ type DistrictService interface {
 	// GetByID retrieves a district by ID with full location hierarchy
 	GetByID(ctx context.Context, id int64) (*DistrictResponse, error)
 
-	// ValidateDistrictID checks if a district ID exists, is active, and belongs to the given city
-	ValidateDistrictID(ctx context.Context, districtID, cityID int64) error
+	// ValidateDistrictID checks if a district ID exists, is active, and belongs to the given city.
+	// currentDistrictID is the district the entity already references, if any: keeping a district
+	// that was deactivated after it was assigned is allowed, only new assignments are blocked.
+	ValidateDistrictID(ctx context.Context, districtID, cityID int64, currentDistrictID *int64) error
 
 	// ValidateLocationHierarchy validates the complete location hierarchy (province -> city -> district)
 	ValidateLocationHierarchy(ctx context.Context, provinceID, cityID, districtID int64) error
@@ -461,14 +477,40 @@ type CityResponse struct {
 
 // DistrictResponse represents a district response
 type DistrictResponse struct {
-	ID               int64         `json:"id"`
-	Name             string        `json:"name"`
-	Code             string        `json:"code"`
-	PostalCode       string        `json:"postal_code,omitempty"`
-	CityID           int64         `json:"city_id"`
-	City             *CityResponse `json:"city,omitempty"`
-	FullLocationPath string        `json:"full_location_path,omitempty"` // e.g., "Batujajar, Kabupaten Bandung Barat, Jawa Barat"
-	IsActive         bool          `json:"is_active"`
+	ID               int64          `json:"id"`
+	Name             string         `json:"name"`
+	Code             string         `json:"code"`
+	PostalCode       string         `json:"postal_code,omitempty"`
+	CityID           int64          `json:"city_id"`
+	City             *CityResponse  `json:"city,omitempty"`
+	FullLocationPath string         `json:"full_location_path,omitempty"` // e.g., "Batujajar, Kabupaten Bandung Barat, Jawa Barat"
+	IsActive         bool           `json:"is_active"`
+	Usage            *DistrictUsage `json:"usage,omitempty"` // Set when an update deactivates the district
+}
+
+// DistrictUsage counts the records that reference a district
+type DistrictUsage struct {
+	DistrictID       int64 `json:"district_id"`
+	Companies        int64 `json:"companies"`
+	CompanyAddresses int64 `json:"company_addresses"`
+	UserProfiles     int64 `json:"user_profiles"`
+	Jobs             int64 `json:"jobs"`            // Jobs located at one of the company addresses
+	ChangeRequests   int64 `json:"change_requests"` // Company change requests asking for the district
+}
+
+// InUse reports whether anything references the district
+func (u *DistrictUsage) InUse() bool {
+	return u.Companies+u.CompanyAddresses+u.UserProfiles+u.Jobs+u.ChangeRequests > 0
+}
+
+// DistrictMergeResult reports what merging a district into another changed
+type DistrictMergeResult struct {
+	SourceID              int64 `json:"source_id"`
+	TargetID              int64 `json:"target_id"`
+	CompaniesMoved        int64 `json:"companies_moved"`
+	CompanyAddressesMoved int64 `json:"company_addresses_moved"`
+	UserProfilesMoved     int64 `json:"user_profiles_moved"`
+	ChangeRequestsMoved   int64 `json:"change_requests_moved"`
 }
 
 // JobTitleService defines business logic for job title master data
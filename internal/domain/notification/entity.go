@@ -4,7 +4,11 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"regexp"
 	"time"
+
+	"keerja-backend/internal/domain/user"
 )
 
 // Notification represents a notification
@@ -80,7 +84,9 @@ func (n *Notification) MarkAsSent() {
 	n.SentAt = &now
 }
 
-// NotificationPreference represents user notification preferences
+// NotificationPreference represents user notification preferences. It decides which
+// notifications are added to the in-app feed; email and push delivery follow the user's
+// notification settings, enforced by the NotificationDispatcher.
 type NotificationPreference struct {
 	ID                        int64     `json:"id" gorm:"primaryKey;autoIncrement"`
 	UserID                    int64     `json:"user_id" gorm:"not null;uniqueIndex"`
@@ -116,7 +122,7 @@ func (np *NotificationPreference) IsPushEnabled() bool {
 // CanSendNotification checks if notification can be sent for given type
 func (np *NotificationPreference) CanSendNotification(notificationType string) bool {
 	switch notificationType {
-	case "job_application":
+	case "job_application", "application_received":
 		return np.JobApplicationsEnabled
 	case "interview":
 		return np.InterviewEnabled
@@ -124,7 +130,7 @@ func (np *NotificationPreference) CanSendNotification(notificationType string) b
 		return np.StatusUpdatesEnabled
 	case "job_recommendation":
 		return np.JobRecommendationsEnabled
-	case "company_update":
+	case "company_update", "review_response":
 		return np.CompanyUpdatesEnabled
 	case "marketing":
 		return np.MarketingEnabled
@@ -179,7 +185,7 @@ func (d *DeviceInfo) Scan(value interface{}) error {
 type DeviceToken struct {
 	ID            int64      `json:"id" gorm:"primaryKey;autoIncrement"`
 	UserID        int64      `json:"user_id" gorm:"not null;index:idx_device_tokens_user_id"`
-	Token         string     `json:"token" gorm:"type:varchar(4096);not null;index:idx_device_tokens_token,unique:unique_user_token"`
+	Token         string     `json:"token" gorm:"type:varchar(4096);not null;uniqueIndex:uq_device_tokens_token"`
 	Platform      Platform   `json:"platform" gorm:"type:varchar(20);not null;index:idx_device_tokens_platform;check:platform IN ('android', 'ios', 'web')"`
 	DeviceInfo    DeviceInfo `json:"device_info" gorm:"type:jsonb;default:'{}'"`
 	IsActive      bool       `json:"is_active" gorm:"default:true;not null;index:idx_device_tokens_user_id,idx_device_tokens_token,idx_device_tokens_user_platform"`
@@ -201,6 +207,14 @@ func (dt *DeviceToken) IsValid() bool {
 	return dt.IsActive && dt.Token != ""
 }
 
+// LastSeenAt returns when the token was last used, or when it was registered if never used
+func (dt *DeviceToken) LastSeenAt() time.Time {
+	if dt.LastUsedAt != nil {
+		return *dt.LastUsedAt
+	}
+	return dt.CreatedAt
+}
+
 // MarkAsUsed updates the last used timestamp
 func (dt *DeviceToken) MarkAsUsed() {
 	now := time.Now()
@@ -286,4 +300,209 @@ type PushResult struct {
 	MessageID    string `json:"message_id,omitempty"`    // FCM message ID
 	ErrorCode    string `json:"error_code,omitempty"`    // Error code from FCM
 	ErrorMessage string `json:"error_message,omitempty"` // Error message
+	TokenPruned  bool   `json:"token_pruned,omitempty"`  // Token was rejected by FCM as invalid and deleted
+}
+
+// Topic prefixes for audience segments derived from user attributes
+const (
+	TopicPrefixRole     = "role_"
+	TopicPrefixProvince = "province_"
+)
+
+// topicPattern matches the topic names FCM accepts
+var topicPattern = regexp.MustCompile(`^[a-zA-Z0-9\-_.~%]{1,900}$`)
+
+// IsValidTopic checks if topic is a valid FCM topic name
+func IsValidTopic(topic string) bool {
+	return topicPattern.MatchString(topic)
+}
+
+// UserTopics returns the topics a user's devices should be subscribed to, e.g.
+// role_jobseeker and province_31
+func UserTopics(userType string, provinceID *int64) []string {
+	var topics []string
+	if userType != "" {
+		topics = append(topics, TopicPrefixRole+userType)
+	}
+	if provinceID != nil && *provinceID > 0 {
+		topics = append(topics, fmt.Sprintf("%s%d", TopicPrefixProvince, *provinceID))
+	}
+	return topics
+}
+
+// DeviceTokenTopic records that a device token is subscribed to an FCM topic, so
+// subscriptions can be reconciled when the owner's attributes change
+type DeviceTokenTopic struct {
+	DeviceTokenID int64     `json:"device_token_id" gorm:"primaryKey"`
+	Topic         string    `json:"topic" gorm:"primaryKey;type:varchar(900)"`
+	CreatedAt     time.Time `json:"created_at" gorm:"type:timestamp;default:now()"`
+}
+
+// TableName specifies the table name
+func (DeviceTokenTopic) TableName() string {
+	return "device_token_topics"
+}
+
+// Push campaign statuses
+const (
+	CampaignStatusScheduled = "scheduled"
+	CampaignStatusSending   = "sending"
+	CampaignStatusCompleted = "completed"
+	CampaignStatusFailed    = "failed"
+)
+
+// PushData is a push data payload stored as JSONB
+type PushData map[string]string
+
+// Value implements the driver.Valuer interface for GORM JSONB
+func (d PushData) Value() (driver.Value, error) {
+	if d == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(d)
+}
+
+// Scan implements the sql.Scanner interface for GORM JSONB
+func (d *PushData) Scan(value interface{}) error {
+	if value == nil {
+		*d = PushData{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to unmarshal JSONB value")
+	}
+
+	return json.Unmarshal(bytes, d)
+}
+
+// PushSegment selects active device tokens by attributes of their owners. Empty
+// fields do not filter.
+type PushSegment struct {
+	Role              *string `json:"role,omitempty"`
+	ProvinceID        *int64  `json:"province_id,omitempty"`
+	FollowedCompanyID *int64  `json:"followed_company_id,omitempty"`
+}
+
+// PushCampaign is an admin broadcast sent either to an FCM topic or to the device
+// tokens matching a segment
+type PushCampaign struct {
+	ID                  int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Title               string     `json:"title" gorm:"type:varchar(255);not null"`
+	Body                string     `json:"body" gorm:"type:text;not null"`
+	Data                PushData   `json:"data" gorm:"type:jsonb;default:'{}'"`
+	Topic               *string    `json:"topic,omitempty" gorm:"type:varchar(900)"`
+	Role                *string    `json:"role,omitempty" gorm:"type:varchar(20)"`
+	ProvinceID          *int64     `json:"province_id,omitempty"`
+	FollowedCompanyID   *int64     `json:"followed_company_id,omitempty"`
+	Status              string     `json:"status" gorm:"type:varchar(20);not null;default:'scheduled';index"`
+	ScheduledAt         time.Time  `json:"scheduled_at" gorm:"not null;index"`
+	StartedAt           *time.Time `json:"started_at,omitempty"`
+	CompletedAt         *time.Time `json:"completed_at,omitempty"`
+	SentCount           int        `json:"sent_count" gorm:"not null;default:0"`
+	FailedCount         int        `json:"failed_count" gorm:"not null;default:0"`
+	InvalidTokensPruned int        `json:"invalid_tokens_pruned" gorm:"not null;default:0"`
+	ErrorMessage        *string    `json:"error_message,omitempty" gorm:"type:text"`
+	CreatedBy           *int64     `json:"created_by,omitempty"`
+	CreatedAt           time.Time  `json:"created_at" gorm:"type:timestamp;default:now()"`
+	UpdatedAt           time.Time  `json:"updated_at" gorm:"type:timestamp;default:now()"`
+}
+
+// TableName specifies the table name
+func (PushCampaign) TableName() string {
+	return "push_campaigns"
+}
+
+// IsTopicCampaign checks if the campaign is sent to an FCM topic rather than a segment
+func (pc *PushCampaign) IsTopicCampaign() bool {
+	return pc.Topic != nil && *pc.Topic != ""
+}
+
+// Segment returns the device token filter of a segment campaign
+func (pc *PushCampaign) Segment() PushSegment {
+	return PushSegment{
+		Role:              pc.Role,
+		ProvinceID:        pc.ProvinceID,
+		FollowedCompanyID: pc.FollowedCompanyID,
+	}
+}
+
+// Message builds the push message sent for the campaign
+func (pc *PushCampaign) Message() *PushMessage {
+	data := map[string]string(pc.Data)
+	return &PushMessage{
+		Title: pc.Title,
+		Body:  pc.Body,
+		Data:  data,
+	}
+}
+
+// EventOf returns the preference matrix event a notification type belongs to. Types outside
+// the matrix, such as company invitations and job reviews, are transactional.
+func EventOf(notificationType string) user.NotificationEvent {
+	switch notificationType {
+	case "job_application", "status_update", "application_received":
+		return user.NotificationEventApplicationStatus
+	case "interview":
+		return user.NotificationEventInterview
+	case "message":
+		return user.NotificationEventMessages
+	case "job_recommendation":
+		return user.NotificationEventJobAlerts
+	case "company_update", "followed_company_job", "review_response":
+		return user.NotificationEventCompanyFollows
+	case "marketing":
+		return user.NotificationEventMarketing
+	default:
+		return user.NotificationEventTransactional
+	}
+}
+
+// DeferredPush is a push held back by the recipient's quiet hours. The deferred push job
+// sends it once DeliverAt has passed.
+type DeferredPush struct {
+	ID          int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID      int64     `json:"user_id" gorm:"not null;index"`
+	Event       string    `json:"event" gorm:"type:varchar(30);not null"`
+	Title       string    `json:"title" gorm:"type:varchar(255);not null"`
+	Body        string    `json:"body" gorm:"type:text;not null"`
+	ImageURL    string    `json:"image_url,omitempty" gorm:"type:varchar(500)"`
+	Data        PushData  `json:"data" gorm:"type:jsonb;default:'{}'"`
+	Priority    string    `json:"priority,omitempty" gorm:"type:varchar(20)"`
+	ClickAction string    `json:"click_action,omitempty" gorm:"type:varchar(500)"`
+	DeliverAt   time.Time `json:"deliver_at" gorm:"not null;index"`
+	CreatedAt   time.Time `json:"created_at" gorm:"type:timestamp;default:now()"`
+}
+
+// TableName specifies the table name
+func (DeferredPush) TableName() string {
+	return "deferred_pushes"
+}
+
+// NewDeferredPush defers message about event to the user until deliverAt
+func NewDeferredPush(userID int64, event user.NotificationEvent, message *PushMessage, deliverAt time.Time) *DeferredPush {
+	return &DeferredPush{
+		UserID:      userID,
+		Event:       string(event),
+		Title:       message.Title,
+		Body:        message.Body,
+		ImageURL:    message.ImageURL,
+		Data:        PushData(message.Data),
+		Priority:    message.Priority,
+		ClickAction: message.ClickAction,
+		DeliverAt:   deliverAt,
+	}
+}
+
+// Message builds the push message sent once the push is delivered
+func (dp *DeferredPush) Message() *PushMessage {
+	return &PushMessage{
+		Title:       dp.Title,
+		Body:        dp.Body,
+		ImageURL:    dp.ImageURL,
+		Data:        map[string]string(dp.Data),
+		Priority:    dp.Priority,
+		ClickAction: dp.ClickAction,
+	}
 }
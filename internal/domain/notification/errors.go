@@ -0,0 +1,17 @@
+package notification
+
+import "keerja-backend/internal/apperror"
+
+var (
+	// ErrNotificationNotFound is returned when a notification does not exist or belongs to another user
+	ErrNotificationNotFound = apperror.NotFound("NOTIFICATION_NOT_FOUND", "notification not found")
+
+	// ErrCampaignNotFound is returned when the requested push campaign does not exist
+	ErrCampaignNotFound = apperror.NotFound("PUSH_CAMPAIGN_NOT_FOUND", "push campaign not found")
+
+	// ErrCampaignTarget is returned when a campaign sets both or neither of a topic and a filter
+	ErrCampaignTarget = apperror.Validation("PUSH_CAMPAIGN_TARGET", "campaign needs either a topic or a filter, not both")
+
+	// ErrInvalidTopic is returned when a topic name is not accepted by FCM
+	ErrInvalidTopic = apperror.Validation("INVALID_PUSH_TOPIC", "topic may only contain letters, digits and -_.~%").WithField("topic", "is invalid")
+)
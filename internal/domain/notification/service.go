@@ -3,6 +3,8 @@ package notification
 import (
 	"context"
 	"time"
+
+	"keerja-backend/internal/domain/user"
 )
 
 // NotificationService defines the interface for notification operations
@@ -58,6 +60,18 @@ type NotificationService interface {
 	// NotifyCompanyUpdate sends company update notification
 	NotifyCompanyUpdate(ctx context.Context, userIDs []int64, companyID int64, updateType string) error
 
+	// NotifyApplicationReceived tells a company's employer users about a new application
+	NotifyApplicationReceived(ctx context.Context, employerUserIDs []int64, jobID, applicationID int64, jobTitle string) error
+
+	// NotifyInvitationReceived tells an existing user they were invited to join a company
+	NotifyInvitationReceived(ctx context.Context, userID, companyID, invitationID int64, companyName string) error
+
+	// NotifyJobReviewed tells the job owner an admin approved or rejected their job
+	NotifyJobReviewed(ctx context.Context, userID, jobID int64, jobTitle string, approved bool, reason string) error
+
+	// NotifyReviewResponse tells a review author the company responded to their review
+	NotifyReviewResponse(ctx context.Context, userID, companyID, reviewID int64, companyName string) error
+
 	// GetNotificationPreferences retrieves user notification preferences
 	GetNotificationPreferences(ctx context.Context, userID int64) (*NotificationPreference, error)
 
@@ -174,6 +188,9 @@ type DeviceTokenRepository interface {
 	// Create creates a new device token
 	Create(ctx context.Context, token *DeviceToken) error
 
+	// Upsert creates a device token, or reassigns and reactivates it when the token string is already registered
+	Upsert(ctx context.Context, token *DeviceToken) error
+
 	// FindByID finds device token by ID
 	FindByID(ctx context.Context, id int64) (*DeviceToken, error)
 
@@ -201,7 +218,7 @@ type DeviceTokenRepository interface {
 	// FindInactiveTokens finds inactive tokens that haven't been used for specified days
 	FindInactiveTokens(ctx context.Context, inactiveDays int, limit int) ([]DeviceToken, error)
 
-	// FindInactive finds tokens inactive since the cutoff date
+	// FindInactive finds tokens not seen since the cutoff date
 	FindInactive(ctx context.Context, cutoffDate time.Time) ([]DeviceToken, error)
 
 	// FindByFailureCount finds tokens with failure count >= minFailures
@@ -218,6 +235,24 @@ type DeviceTokenRepository interface {
 
 	// FindTokensWithHighFailureCount finds tokens with high failure counts for cleanup
 	FindTokensWithHighFailureCount(ctx context.Context, minFailures int, limit int) ([]DeviceToken, error)
+
+	// FindBySegment finds active device tokens matching segment with ID greater than afterID, ordered by ID
+	FindBySegment(ctx context.Context, segment PushSegment, afterID int64, limit int) ([]DeviceToken, error)
+
+	// DeleteByTokens deletes device tokens by token string and returns how many were removed
+	DeleteByTokens(ctx context.Context, tokens []string) (int64, error)
+
+	// MarkDelivered records a successful delivery to each token at the given time
+	MarkDelivered(ctx context.Context, tokens []string, at time.Time) error
+
+	// FindTopics finds the topics a device token is subscribed to
+	FindTopics(ctx context.Context, deviceTokenID int64) ([]string, error)
+
+	// AddTopic records a topic subscription of a device token
+	AddTopic(ctx context.Context, deviceTokenID int64, topic string) error
+
+	// RemoveTopic removes a topic subscription of a device token
+	RemoveTopic(ctx context.Context, deviceTokenID int64, topic string) error
 }
 
 // PushNotificationService defines the interface for FCM push notification operations
@@ -234,8 +269,18 @@ type PushNotificationService interface {
 	// SendToTopic sends push notification to a topic
 	SendToTopic(ctx context.Context, topic string, message *PushMessage) (*PushResult, error)
 
-	// RegisterDeviceToken registers a new device token for a user
-	RegisterDeviceToken(ctx context.Context, userID int64, token string, platform Platform, deviceInfo *DeviceInfo) error
+	// SendToTokens sends push notification to device tokens in multicast batches; results follow the order of tokens
+	SendToTokens(ctx context.Context, tokens []string, message *PushMessage) ([]PushResult, error)
+
+	// SubscribeToTopic subscribes device tokens to a topic
+	SubscribeToTopic(ctx context.Context, tokens []string, topic string) error
+
+	// UnsubscribeFromTopic unsubscribes device tokens from a topic
+	UnsubscribeFromTopic(ctx context.Context, tokens []string, topic string) error
+
+	// RegisterDeviceToken registers a device token for a user, taking it over if another user registered it
+	// before and evicting the user's least recently seen devices beyond the per-user limit
+	RegisterDeviceToken(ctx context.Context, userID int64, token string, platform Platform, deviceInfo *DeviceInfo) (*DeviceToken, error)
 
 	// UnregisterDeviceToken removes a device token
 	UnregisterDeviceToken(ctx context.Context, userID int64, token string) error
@@ -282,3 +327,118 @@ type PushNotificationLogRepository interface {
 	// CleanupOldLogs removes logs older than specified days
 	CleanupOldLogs(ctx context.Context, retentionDays int) error
 }
+
+// PushTopicService keeps device token topic subscriptions in line with user attributes
+type PushTopicService interface {
+	// SyncUserTopics subscribes the user's devices to the topics derived from their
+	// role and province and unsubscribes them from topics that no longer apply
+	SyncUserTopics(ctx context.Context, userID int64) error
+
+	// UnsubscribeToken removes all topic subscriptions of a device token
+	UnsubscribeToken(ctx context.Context, token *DeviceToken) error
+}
+
+// NotificationDispatcher is the one place that decides whether an email, push or WhatsApp
+// message about an event reaches a user. It enforces the user's per-event channel matrix and
+// quiet hours; notify paths hand their messages to it rather than deciding themselves.
+type NotificationDispatcher interface {
+	// Email calls send when the user accepts emails about the event and reports whether it
+	// did. userID 0 is a recipient without an account, who is always emailed. Quiet hours
+	// do not hold back emails.
+	Email(ctx context.Context, userID int64, event user.NotificationEvent, send func(ctx context.Context) error) (bool, error)
+
+	// Push sends message to the user's devices when the user accepts pushes about the
+	// event. During the user's quiet hours it is deferred until they end instead.
+	Push(ctx context.Context, userID int64, event user.NotificationEvent, message *PushMessage) (*PushDispatchStats, error)
+
+	// PushToUsers is Push for many users, sending one batch to those not in quiet hours
+	PushToUsers(ctx context.Context, userIDs []int64, event user.NotificationEvent, message *PushMessage) (*PushDispatchStats, error)
+
+	// SendDeferredPushes sends the deferred pushes whose quiet hours have ended, checking
+	// the recipients' current preferences again
+	SendDeferredPushes(ctx context.Context) (int, error)
+
+	// WhatsApp sends the template message to the user's verified phone when WhatsApp is
+	// configured and the user accepts WhatsApp messages about the event, and reports whether
+	// it was sent. It is an extra channel next to email and push: every attempt is recorded as
+	// a WhatsAppDelivery and failures are logged, never returned.
+	WhatsApp(ctx context.Context, userID int64, event user.NotificationEvent, message *WhatsAppMessage) bool
+}
+
+// PushDispatchStats counts what happened to the recipients of a dispatched push
+type PushDispatchStats struct {
+	Sent     int // Recipients with at least one device that accepted the push
+	Deferred int // Recipients in quiet hours whose push was deferred
+	Muted    int // Recipients who turned pushes about the event off
+}
+
+// DeferredPushRepository defines the interface for deferred push data operations
+type DeferredPushRepository interface {
+	// Create stores a deferred push
+	Create(ctx context.Context, push *DeferredPush) error
+
+	// ClaimDue removes up to limit pushes due at now and returns them, so overlapping
+	// scheduler runs never send the same push twice
+	ClaimDue(ctx context.Context, now time.Time, limit int) ([]DeferredPush, error)
+}
+
+// FollowerNotifier notifies company followers about jobs the company publishes
+type FollowerNotifier interface {
+	// NotifyJobPublished sends the new-job email and push to the followers of the job's
+	// company, respecting their preferences. Followers are notified at most once per
+	// job per day, so unpublishing and republishing does not repeat it.
+	NotifyJobPublished(ctx context.Context, jobID int64) (*FollowerNotifyStats, error)
+}
+
+// FollowerNotifyStats counts the notifications sent for one published job
+type FollowerNotifyStats struct {
+	Followers  int
+	EmailsSent int
+	PushesSent int
+	Skipped    bool // Followers were already notified within the dedup window
+}
+
+// PushCampaignRepository defines the interface for push campaign data operations
+type PushCampaignRepository interface {
+	// Create creates a new push campaign
+	Create(ctx context.Context, campaign *PushCampaign) error
+
+	// FindByID finds push campaign by ID
+	FindByID(ctx context.Context, id int64) (*PushCampaign, error)
+
+	// List lists push campaigns, newest first
+	List(ctx context.Context, page, limit int) ([]PushCampaign, int64, error)
+
+	// ClaimDue marks up to limit scheduled campaigns due at now as sending and returns them
+	ClaimDue(ctx context.Context, now time.Time, limit int) ([]PushCampaign, error)
+
+	// Update updates push campaign
+	Update(ctx context.Context, campaign *PushCampaign) error
+}
+
+// PushCampaignService defines the interface for admin push campaigns
+type PushCampaignService interface {
+	// CreateCampaign validates and schedules a push campaign
+	CreateCampaign(ctx context.Context, req *CreatePushCampaignRequest) (*PushCampaign, error)
+
+	// GetCampaign retrieves a push campaign with its delivery stats
+	GetCampaign(ctx context.Context, id int64) (*PushCampaign, error)
+
+	// ListCampaigns lists push campaigns, newest first
+	ListCampaigns(ctx context.Context, page, limit int) ([]PushCampaign, int64, error)
+
+	// SendDueCampaigns sends every campaign whose scheduled time has passed
+	SendDueCampaigns(ctx context.Context) (int, error)
+}
+
+// CreatePushCampaignRequest represents a request to schedule a push campaign.
+// Exactly one of Topic or Segment must be set.
+type CreatePushCampaignRequest struct {
+	Title       string
+	Body        string
+	Data        map[string]string
+	Topic       string
+	Segment     *PushSegment
+	ScheduledAt *time.Time // Sent on the next scheduler run when nil
+	CreatedBy   int64
+}
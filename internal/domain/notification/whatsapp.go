@@ -0,0 +1,97 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// WhatsApp templates the platform sends. They are only used for the high-value events a
+// candidate should not miss; the name of each approved template is configured per key.
+const (
+	WhatsAppTemplateInterviewScheduled   = "interview_scheduled"
+	WhatsAppTemplateInterviewRescheduled = "interview_rescheduled"
+	WhatsAppTemplateInterviewCancelled   = "interview_cancelled"
+	WhatsAppTemplateOfferMade            = "offer_made"
+)
+
+// WhatsAppTemplates lists every WhatsApp template key
+var WhatsAppTemplates = []string{
+	WhatsAppTemplateInterviewScheduled,
+	WhatsAppTemplateInterviewRescheduled,
+	WhatsAppTemplateInterviewCancelled,
+	WhatsAppTemplateOfferMade,
+}
+
+// Named parameters of the WhatsApp templates
+const (
+	WhatsAppParamCandidateName = "candidate_name"
+	WhatsAppParamJobTitle      = "job_title"
+	WhatsAppParamCompanyName   = "company_name"
+	WhatsAppParamInterviewDate = "interview_date"
+	WhatsAppParamInterviewTime = "interview_time"
+)
+
+// ErrWhatsAppTemplateRejected is returned when WhatsApp refuses a template message, e.g.
+// because the template is not approved or its parameters do not match
+var ErrWhatsAppTemplateRejected = errors.New("whatsapp template rejected")
+
+// WhatsAppMessage is a template message with the values of its named parameters
+type WhatsAppMessage struct {
+	Template string
+	Params   map[string]string
+}
+
+// WhatsAppProvider sends WhatsApp template messages
+type WhatsAppProvider interface {
+	// SendTemplate sends the message to an E.164 phone number and returns the provider's
+	// message ID. A refused template is reported as ErrWhatsAppTemplateRejected.
+	SendTemplate(ctx context.Context, to string, message *WhatsAppMessage) (string, error)
+}
+
+// WhatsApp delivery statuses
+const (
+	WhatsAppDeliverySent             = "sent"
+	WhatsAppDeliveryFailed           = "failed"
+	WhatsAppDeliveryTemplateRejected = "template_rejected"
+)
+
+// WhatsAppDelivery records one WhatsApp message sent to a user and how the provider took it,
+// so support can see why a candidate did or did not get one
+type WhatsAppDelivery struct {
+	ID                int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID            int64     `json:"user_id" gorm:"not null;index"`
+	Event             string    `json:"event" gorm:"type:varchar(30);not null"`
+	Template          string    `json:"template" gorm:"type:varchar(100);not null"`
+	Phone             string    `json:"phone" gorm:"type:varchar(20);not null"`
+	Status            string    `json:"status" gorm:"type:varchar(20);not null"`
+	ProviderMessageID *string   `json:"provider_message_id,omitempty" gorm:"type:varchar(255)"`
+	Error             *string   `json:"error,omitempty" gorm:"type:text"`
+	CreatedAt         time.Time `json:"created_at" gorm:"type:timestamp;default:now()"`
+}
+
+// TableName specifies the table name
+func (WhatsAppDelivery) TableName() string {
+	return "whatsapp_deliveries"
+}
+
+// WhatsAppDeliveryFilter narrows a list of WhatsApp deliveries; zero values match everything
+type WhatsAppDeliveryFilter struct {
+	UserID int64
+	Status string
+}
+
+// WhatsAppDeliveryRepository defines the interface for WhatsApp delivery data operations
+type WhatsAppDeliveryRepository interface {
+	// Create stores a delivery
+	Create(ctx context.Context, delivery *WhatsAppDelivery) error
+
+	// List lists deliveries matching filter, newest first
+	List(ctx context.Context, filter WhatsAppDeliveryFilter, page, limit int) ([]WhatsAppDelivery, int64, error)
+}
+
+// WhatsAppDeliveryService lets support look up the WhatsApp messages sent to users
+type WhatsAppDeliveryService interface {
+	// ListDeliveries lists deliveries matching filter, newest first
+	ListDeliveries(ctx context.Context, filter WhatsAppDeliveryFilter, page, limit int) ([]WhatsAppDelivery, int64, error)
+}
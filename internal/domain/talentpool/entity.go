@@ -0,0 +1,80 @@
+package talentpool
+
+import (
+	"time"
+
+	"keerja-backend/internal/domain/job"
+
+	"github.com/lib/pq"
+)
+
+// Limits of talent pools
+const (
+	MaxPoolNameLength = 100
+	MaxTagsPerMember  = 20
+	MaxTagLength      = 50
+	MaxNotesLength    = 2000
+)
+
+// Pool matching: members scored per request and the matches returned
+const (
+	MaxMembersScored  = 500
+	DefaultMatchLimit = 10
+	MaxMatchLimit     = 50
+)
+
+// TalentPool is a company's named list of candidates to reach out to for future roles
+type TalentPool struct {
+	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	CompanyID   int64     `gorm:"column:company_id;not null;index" json:"company_id"`
+	Name        string    `gorm:"column:name;type:varchar(100);not null" json:"name"`
+	Description string    `gorm:"column:description;type:text" json:"description,omitempty"`
+	CreatedBy   *int64    `gorm:"column:created_by" json:"created_by,omitempty"`
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+
+	// MemberCount counts the members who have not opted out of talent pools, filled by listings
+	MemberCount int64 `gorm:"->;column:member_count" json:"member_count"`
+}
+
+// TableName specifies the table name for TalentPool
+func (TalentPool) TableName() string {
+	return "company_talent_pools"
+}
+
+// TalentPoolMember is a candidate saved to a pool, usually from one of their applications
+type TalentPoolMember struct {
+	ID                  int64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	PoolID              int64          `gorm:"column:pool_id;not null;uniqueIndex:idx_talent_pool_members_pool_user" json:"pool_id"`
+	UserID              int64          `gorm:"column:user_id;not null;uniqueIndex:idx_talent_pool_members_pool_user" json:"user_id"`
+	SourceApplicationID *int64         `gorm:"column:source_application_id" json:"source_application_id,omitempty"`
+	Tags                pq.StringArray `gorm:"column:tags;type:text[];not null;default:'{}'" json:"tags"`
+	Notes               string         `gorm:"column:notes;type:text" json:"notes,omitempty"`
+	AddedBy             *int64         `gorm:"column:added_by" json:"added_by,omitempty"`
+	CreatedAt           time.Time      `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	UpdatedAt           time.Time      `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+
+	// Candidate details, filled by listings
+	CandidateName string  `gorm:"->;column:candidate_name" json:"candidate_name,omitempty"`
+	Headline      *string `gorm:"->;column:headline" json:"headline,omitempty"`
+}
+
+// TableName specifies the table name for TalentPoolMember
+func (TalentPoolMember) TableName() string {
+	return "talent_pool_members"
+}
+
+// MemberFilter narrows a list of a company's pool members
+type MemberFilter struct {
+	CompanyID int64
+	PoolID    int64
+	Tags      []string // Members with all of these tags
+	Skills    []string // Members whose profile lists all of these skills
+	Query     string   // Matches the candidate's name or the notes
+}
+
+// PoolMatch is a pool member scored against a job
+type PoolMatch struct {
+	Member TalentPoolMember `json:"member"`
+	Score  job.MatchScore   `json:"score"`
+}
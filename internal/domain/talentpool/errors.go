@@ -0,0 +1,24 @@
+package talentpool
+
+import "keerja-backend/internal/apperror"
+
+var (
+	// ErrPoolNotFound is returned when the pool does not exist or belongs to another company
+	ErrPoolNotFound = apperror.NotFound("TALENT_POOL_NOT_FOUND", "talent pool not found")
+
+	// ErrMemberNotFound is returned when the member does not exist or belongs to another pool
+	ErrMemberNotFound = apperror.NotFound("TALENT_POOL_MEMBER_NOT_FOUND", "talent pool member not found")
+
+	// ErrPoolNameExists is returned when the company already has a pool with the name
+	ErrPoolNameExists = apperror.Conflict("TALENT_POOL_NAME_EXISTS", "company already has a talent pool with this name")
+
+	// ErrApplicationNotInCompany is returned when adding a candidate from another company's application
+	ErrApplicationNotInCompany = apperror.Validation("APPLICATION_NOT_IN_COMPANY", "application was not made to this company").WithField("application_id", "must be an application to this company")
+
+	// ErrCandidateOptedOut is returned when the candidate does not allow companies to keep them in
+	// talent pools, or made their profile private
+	ErrCandidateOptedOut = apperror.Forbidden("CANDIDATE_OPTED_OUT", "candidate has opted out of talent pools")
+
+	// ErrInvalidTags is returned when a member has too many or too long tags
+	ErrInvalidTags = apperror.Validation("INVALID_TALENT_POOL_TAGS", "at most 20 tags of at most 50 characters").WithField("tags", "at most 20 tags of at most 50 characters")
+)
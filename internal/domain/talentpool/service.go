@@ -0,0 +1,77 @@
+package talentpool
+
+import "context"
+
+// CreatePoolInput holds the name and description of a new pool
+type CreatePoolInput struct {
+	Name        string
+	Description string
+}
+
+// AddMemberInput holds the application a candidate is added from, with the recruiter's tags and notes
+type AddMemberInput struct {
+	ApplicationID int64
+	Tags          []string
+	Notes         string
+}
+
+// TalentPoolRepository defines the interface for talent pool data operations
+type TalentPoolRepository interface {
+	// CreatePool inserts a new pool, returning ErrPoolNameExists when the company already
+	// has a pool with the name, ignoring case
+	CreatePool(ctx context.Context, p *TalentPool) error
+
+	// FindPoolByID finds a pool by ID, returning nil when it does not exist
+	FindPoolByID(ctx context.Context, id int64) (*TalentPool, error)
+
+	// ListPoolsByCompany lists a company's pools by name with their member counts
+	ListPoolsByCompany(ctx context.Context, companyID int64) ([]TalentPool, error)
+
+	// DeletePool deletes a pool with its members
+	DeletePool(ctx context.Context, id int64) error
+
+	// AddMember inserts m unless its user is already in the pool, then loads the stored
+	// member into m. It reports whether the member was added.
+	AddMember(ctx context.Context, m *TalentPoolMember) (bool, error)
+
+	// FindMemberByID finds a member by ID, returning nil when it does not exist
+	FindMemberByID(ctx context.Context, id int64) (*TalentPoolMember, error)
+
+	// ListMembers lists the members matching filter, newest first, leaving out candidates
+	// who opted out of talent pools
+	ListMembers(ctx context.Context, filter MemberFilter, page, limit int) ([]TalentPoolMember, int64, error)
+
+	// ListMatchCandidates lists up to limit members of the company's pools, or of one pool
+	// when poolID is set, once per candidate. Candidates who opted out of talent pools or
+	// already applied to the job are left out.
+	ListMatchCandidates(ctx context.Context, companyID, poolID, jobID int64, limit int) ([]TalentPoolMember, error)
+
+	// RemoveMember removes a member from its pool
+	RemoveMember(ctx context.Context, id int64) error
+}
+
+// TalentPoolService manages a company's talent pools
+type TalentPoolService interface {
+	// CreatePool creates a pool for the company
+	CreatePool(ctx context.Context, companyID, userID int64, input CreatePoolInput) (*TalentPool, error)
+
+	// ListPools lists the company's pools with their member counts
+	ListPools(ctx context.Context, companyID int64) ([]TalentPool, error)
+
+	// DeletePool deletes one of the company's pools with its members
+	DeletePool(ctx context.Context, companyID, poolID int64) error
+
+	// AddMember adds the applicant of one of the company's applications to a pool. Adding a
+	// candidate already in the pool returns the existing member and false.
+	AddMember(ctx context.Context, companyID, poolID, userID int64, input AddMemberInput) (*TalentPoolMember, bool, error)
+
+	// ListMembers lists the members of one of the company's pools
+	ListMembers(ctx context.Context, filter MemberFilter, page, limit int) ([]TalentPoolMember, int64, error)
+
+	// RemoveMember removes a member from one of the company's pools
+	RemoveMember(ctx context.Context, companyID, poolID, memberID int64) error
+
+	// MatchJob scores the candidates of the company's pools, or of one pool when poolID is
+	// set, against one of the company's jobs and returns the best limit matches
+	MatchJob(ctx context.Context, companyID, jobID, poolID int64, limit int) ([]PoolMatch, error)
+}
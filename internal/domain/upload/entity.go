@@ -1,6 +1,11 @@
 package upload
 
-import "time"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
 
 // FileUpload represents an uploaded file
 type FileUpload struct {
@@ -77,3 +82,39 @@ func (f *FileUpload) GetSizeInKB() float64 {
 func (f *FileUpload) GetSizeInMB() float64 {
 	return float64(f.FileSize) / (1024 * 1024)
 }
+
+// ImageVariants maps the variants of a stored image to their URLs, keyed by variant and
+// format (e.g. "200_webp"), stored as JSONB
+type ImageVariants map[string]string
+
+// URLs returns the variant URLs, for deleting them along with the original
+func (v ImageVariants) URLs() []string {
+	urls := make([]string, 0, len(v))
+	for _, url := range v {
+		urls = append(urls, url)
+	}
+	return urls
+}
+
+// Value implements the driver.Valuer interface for GORM JSONB
+func (v ImageVariants) Value() (driver.Value, error) {
+	if v == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(v)
+}
+
+// Scan implements the sql.Scanner interface for GORM JSONB
+func (v *ImageVariants) Scan(value interface{}) error {
+	if value == nil {
+		*v = ImageVariants{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to unmarshal JSONB value")
+	}
+
+	return json.Unmarshal(bytes, v)
+}
@@ -4,24 +4,27 @@ import (
 	"time"
 
 	"keerja-backend/internal/domain/master"
+	"keerja-backend/internal/domain/upload"
 
 	"github.com/google/uuid"
 )
 
 // User represents the main user entity
 type User struct {
-	ID           int64      `gorm:"primaryKey;autoIncrement" json:"id"`
-	UUID         uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();uniqueIndex" json:"uuid"`
-	FullName     string     `gorm:"type:varchar(150);not null" json:"full_name" validate:"required,min=3,max=150"`
-	Email        string     `gorm:"type:varchar(150);uniqueIndex;not null" json:"email" validate:"required,email,max=150"`
-	Phone        *string    `gorm:"type:varchar(20)" json:"phone,omitempty" validate:"omitempty,min=10,max=20"`
-	PasswordHash string     `gorm:"type:text;not null" json:"-"`
-	UserType     string     `gorm:"type:varchar(20);check:user_type IN ('jobseeker','employer','admin')" json:"user_type" validate:"required,oneof=jobseeker employer admin"`
-	IsVerified   bool       `gorm:"default:false" json:"is_verified"`
-	Status       string     `gorm:"type:varchar(20);default:'active';check:status IN ('active','inactive','suspended')" json:"status"`
-	LastLogin    *time.Time `gorm:"type:timestamp" json:"last_login,omitempty"`
-	CreatedAt    time.Time  `gorm:"type:timestamp;default:now()" json:"created_at"`
-	UpdatedAt    time.Time  `gorm:"type:timestamp;default:now()" json:"updated_at"`
+	ID              int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UUID            uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();uniqueIndex" json:"uuid"`
+	FullName        string     `gorm:"type:varchar(150);not null" json:"full_name" validate:"required,min=3,max=150"`
+	Email           string     `gorm:"type:varchar(150);uniqueIndex;not null" json:"email" validate:"required,email,max=150"`
+	Phone           *string    `gorm:"type:varchar(20)" json:"phone,omitempty" validate:"omitempty,min=10,max=20"` // E.164, see utils.NormalizePhone
+	PhoneVerifiedAt *time.Time `gorm:"type:timestamp" json:"phone_verified_at,omitempty"`                          // Cleared when the phone changes
+	PasswordHash    string     `gorm:"type:text;not null" json:"-"`
+	UserType        string     `gorm:"type:varchar(20);check:user_type IN ('jobseeker','employer','admin')" json:"user_type" validate:"required,oneof=jobseeker employer admin"`
+	IsVerified      bool       `gorm:"default:false" json:"is_verified"` // Kept in step with EmailVerifiedAt by MarkEmailVerified
+	EmailVerifiedAt *time.Time `gorm:"type:timestamp" json:"email_verified_at,omitempty"`
+	Status          string     `gorm:"type:varchar(20);default:'active';check:status IN ('active','inactive','suspended','deleted')" json:"status"`
+	LastLogin       *time.Time `gorm:"type:timestamp" json:"last_login,omitempty"`
+	CreatedAt       time.Time  `gorm:"type:timestamp;default:now()" json:"created_at"`
+	UpdatedAt       time.Time  `gorm:"type:timestamp;default:now()" json:"updated_at"`
 
 	// Relationships
 	Profile        *UserProfile        `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"profile,omitempty"`
@@ -40,11 +43,71 @@ func (User) TableName() string {
 	return "users"
 }
 
+// HasPassword reports whether the user can sign in with a password. Accounts created
+// through OAuth have none until one is set with password reset.
+func (u *User) HasPassword() bool {
+	return u.PasswordHash != ""
+}
+
+// StatusDeleted is the status of accounts whose personal data has been erased. The row is
+// kept so the user's closed applications still count in employers' statistics.
+const StatusDeleted = "deleted"
+
+// UserEmailChange records the email a user had before changing it
+type UserEmailChange struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    int64     `gorm:"not null;index" json:"user_id"`
+	OldEmail  string    `gorm:"type:varchar(150);not null" json:"old_email"`
+	NewEmail  string    `gorm:"type:varchar(150);not null" json:"new_email"`
+	ChangedAt time.Time `gorm:"not null;default:now()" json:"changed_at"`
+}
+
+// TableName specifies the table name for UserEmailChange
+func (UserEmailChange) TableName() string {
+	return "user_email_changes"
+}
+
+// IsEmailVerified reports whether the user has verified their current email. Rows written
+// with only the older is_verified flag, e.g. by seeders, count as verified too.
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil || u.IsVerified
+}
+
+// MarkEmailVerified records that the user verified their email at now
+func (u *User) MarkEmailVerified(now time.Time) {
+	u.IsVerified = true
+	u.EmailVerifiedAt = &now
+}
+
+// IsPhoneVerified reports whether the user has verified their current phone number
+func (u *User) IsPhoneVerified() bool {
+	return u.Phone != nil && u.PhoneVerifiedAt != nil
+}
+
+// SetPhone changes the user's phone number. A different number needs verifying again.
+func (u *User) SetPhone(phone *string) {
+	if phone == nil || u.Phone == nil || *phone != *u.Phone {
+		u.PhoneVerifiedAt = nil
+	}
+	u.Phone = phone
+}
+
+// ClearEmailVerification marks the email unverified, e.g. after it changed
+func (u *User) ClearEmailVerification() {
+	u.IsVerified = false
+	u.EmailVerifiedAt = nil
+}
+
 // IsActive checks if user is active
 func (u *User) IsActive() bool {
 	return u.Status == "active"
 }
 
+// IsDeleted checks if the account has been deleted and its personal data erased
+func (u *User) IsDeleted() bool {
+	return u.Status == StatusDeleted
+}
+
 // IsJobseeker checks if user is a jobseeker
 func (u *User) IsJobseeker() bool {
 	return u.UserType == "jobseeker"
@@ -99,6 +162,11 @@ type UserProfile struct {
 	CreatedAt          time.Time `gorm:"type:timestamp;default:now()" json:"created_at"`
 	UpdatedAt          time.Time `gorm:"type:timestamp;default:now()" json:"updated_at"`
 
+	// Resized copies of the avatar and cover by variant key (e.g. 200_webp); AvatarURL and
+	// CoverURL keep pointing at the originals
+	AvatarVariants upload.ImageVariants `gorm:"type:jsonb;default:'{}'" json:"avatar_variants,omitempty"`
+	CoverVariants  upload.ImageVariants `gorm:"type:jsonb;default:'{}'" json:"cover_variants,omitempty"`
+
 	// Relationships
 	User *User `gorm:"foreignKey:UserID" json:"-"`
 
@@ -134,18 +202,26 @@ type UserPreference struct {
 	PreferredIndustry *string `gorm:"type:varchar(100)" json:"preferred_industry,omitempty"`
 	PreferredLocation *string `gorm:"type:varchar(100)" json:"preferred_location,omitempty"`
 
-	PreferredSalaryMin  *float64  `gorm:"type:numeric(12,2)" json:"preferred_salary_min,omitempty"`
-	PreferredSalaryMax  *float64  `gorm:"type:numeric(12,2)" json:"preferred_salary_max,omitempty"`
-	EmailNotifications  bool      `gorm:"default:true" json:"email_notifications"`
-	SMSNotifications    bool      `gorm:"default:false" json:"sms_notifications"`
-	PushNotifications   bool      `gorm:"default:true" json:"push_notifications"`
-	EmailMarketing      bool      `gorm:"default:false" json:"email_marketing"`
-	ProfileVisibility   string    `gorm:"type:varchar(20);default:'public';check:profile_visibility IN ('public','private','recruiter-only')" json:"profile_visibility"`
-	ShowOnlineStatus    bool      `gorm:"default:true" json:"show_online_status"`
-	AllowDirectMessages bool      `gorm:"default:true" json:"allow_direct_messages"`
-	DataSharingConsent  bool      `gorm:"default:true" json:"data_sharing_consent"`
-	CreatedAt           time.Time `gorm:"type:timestamp;default:now()" json:"created_at"`
-	UpdatedAt           time.Time `gorm:"type:timestamp;default:now()" json:"updated_at"`
+	PreferredSalaryMin  *float64 `gorm:"type:numeric(12,2)" json:"preferred_salary_min,omitempty"`
+	PreferredSalaryMax  *float64 `gorm:"type:numeric(12,2)" json:"preferred_salary_max,omitempty"`
+	EmailNotifications  bool     `gorm:"default:true" json:"email_notifications"`
+	SMSNotifications    bool     `gorm:"default:false" json:"sms_notifications"`
+	PushNotifications   bool     `gorm:"default:true" json:"push_notifications"`
+	EmailMarketing      bool     `gorm:"default:false" json:"email_marketing"`
+	ProfileVisibility   string   `gorm:"type:varchar(20);default:'public';check:profile_visibility IN ('public','private','recruiter-only')" json:"profile_visibility"`
+	ShowOnlineStatus    bool     `gorm:"default:true" json:"show_online_status"`
+	AllowDirectMessages bool     `gorm:"default:true" json:"allow_direct_messages"`
+	DataSharingConsent  bool     `gorm:"default:true" json:"data_sharing_consent"`
+	AllowTalentPools    bool     `gorm:"default:true" json:"allow_talent_pools"` // False keeps companies from saving the user to talent pools
+
+	// Per-event channels and quiet hours, enforced by the notification dispatcher
+	NotificationMatrix NotificationMatrix `gorm:"type:jsonb;not null;default:'{}'" json:"notification_matrix"`
+	QuietHoursStart    *string            `gorm:"type:varchar(5)" json:"quiet_hours_start,omitempty"` // HH:MM in QuietHoursTimezone
+	QuietHoursEnd      *string            `gorm:"type:varchar(5)" json:"quiet_hours_end,omitempty"`   // HH:MM in QuietHoursTimezone
+	QuietHoursTimezone string             `gorm:"type:varchar(64);not null;default:'Asia/Jakarta'" json:"quiet_hours_timezone"`
+
+	CreatedAt time.Time `gorm:"type:timestamp;default:now()" json:"created_at"`
+	UpdatedAt time.Time `gorm:"type:timestamp;default:now()" json:"updated_at"`
 
 	// Relationships
 	User *User `gorm:"foreignKey:UserID" json:"-"`
@@ -495,3 +571,84 @@ type UserDocument struct {
 func (UserDocument) TableName() string {
 	return "user_documents"
 }
+
+// Account deletion request statuses
+const (
+	DeletionRequestPending   = "pending"
+	DeletionRequestCanceled  = "canceled"
+	DeletionRequestCompleted = "completed"
+)
+
+// DefaultAccountDeletionGracePeriod is how long a deletion request can be canceled before
+// the account is erased
+const DefaultAccountDeletionGracePeriod = 14 * 24 * time.Hour
+
+// AccountDeletionRequest schedules the erasure of a user's account. Until ScheduledFor the
+// user can cancel it; afterwards the account_deletion job anonymizes the account.
+type AccountDeletionRequest struct {
+	ID           int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID       int64      `gorm:"not null;index" json:"user_id"`
+	Status       string     `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	ScheduledFor time.Time  `gorm:"not null" json:"scheduled_for"`
+	CanceledAt   *time.Time `json:"canceled_at,omitempty"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	CreatedAt    time.Time  `gorm:"type:timestamp;default:now()" json:"created_at"`
+	UpdatedAt    time.Time  `gorm:"type:timestamp;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for AccountDeletionRequest
+func (AccountDeletionRequest) TableName() string {
+	return "account_deletion_requests"
+}
+
+// IsPending checks if the deletion is still scheduled
+func (r *AccountDeletionRequest) IsPending() bool {
+	return r.Status == DeletionRequestPending
+}
+
+// IsDue checks if the grace period of a pending request has ended at now
+func (r *AccountDeletionRequest) IsDue(now time.Time) bool {
+	return r.IsPending() && !now.Before(r.ScheduledFor)
+}
+
+// Data export statuses
+const (
+	DataExportPending = "pending"
+	DataExportReady   = "ready"
+	DataExportFailed  = "failed"
+	DataExportExpired = "expired"
+)
+
+// DefaultDataExportTTL is how long the download link of a data export stays valid
+const DefaultDataExportTTL = 48 * time.Hour
+
+// DataExport is a JSON bundle of a user's personal data. It is generated in the background
+// and downloaded with a link emailed to the user; only the SHA-256 hash of the link's
+// token is stored. The bundle is dropped once the link expires.
+type DataExport struct {
+	ID          int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID      int64      `gorm:"not null;index" json:"user_id"`
+	Status      string     `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	TokenHash   *string    `gorm:"type:varchar(64)" json:"-"`
+	Data        []byte     `gorm:"type:jsonb" json:"-"`
+	Error       *string    `gorm:"type:text" json:"-"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	CreatedAt   time.Time  `gorm:"type:timestamp;default:now()" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"type:timestamp;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for DataExport
+func (DataExport) TableName() string {
+	return "account_data_exports"
+}
+
+// IsPending checks if the export is waiting to be generated
+func (e *DataExport) IsPending() bool {
+	return e.Status == DataExportPending
+}
+
+// IsDownloadable checks if the export is ready and its link has not expired at now
+func (e *DataExport) IsDownloadable(now time.Time) bool {
+	return e.Status == DataExportReady && e.ExpiresAt != nil && now.Before(*e.ExpiresAt)
+}
@@ -0,0 +1,47 @@
+package user
+
+import "keerja-backend/internal/apperror"
+
+var (
+	// ErrEmailTaken is returned when another account already uses an email address
+	ErrEmailTaken = apperror.Conflict("EMAIL_ALREADY_EXISTS", "email already exists")
+
+	// ErrDeletionAlreadyRequested is returned when the account is already scheduled for deletion
+	ErrDeletionAlreadyRequested = apperror.Conflict("ACCOUNT_DELETION_PENDING", "account deletion has already been requested")
+
+	// ErrNoPendingDeletion is returned when there is no scheduled deletion to cancel
+	ErrNoPendingDeletion = apperror.NotFound("ACCOUNT_DELETION_NOT_FOUND", "no pending account deletion request")
+
+	// ErrDataExportNotFound is returned when no data export matches the download token
+	ErrDataExportNotFound = apperror.NotFound("DATA_EXPORT_NOT_FOUND", "data export not found")
+
+	// ErrDataExportExpired is returned when the download link of a data export has expired
+	ErrDataExportExpired = apperror.Conflict("DATA_EXPORT_EXPIRED", "data export download link has expired")
+
+	// ErrUnknownNotificationEvent is returned when notification settings name an event outside the matrix
+	ErrUnknownNotificationEvent = apperror.Validation("UNKNOWN_NOTIFICATION_EVENT", "unknown notification event").WithField("events", "must be application_status, interview, messages, job_alerts, company_follows or marketing")
+
+	// ErrInvalidQuietHours is returned when quiet hours are not both HH:MM times or both empty
+	ErrInvalidQuietHours = apperror.Validation("INVALID_QUIET_HOURS", "invalid quiet hours").WithField("quiet_hours", "start and end must both be HH:MM times, or both empty to turn quiet hours off")
+
+	// ErrInvalidTimezone is returned when the quiet hours timezone is not a known IANA zone
+	ErrInvalidTimezone = apperror.Validation("INVALID_TIMEZONE", "invalid timezone").WithField("quiet_hours_timezone", "must be an IANA timezone such as Asia/Jakarta")
+
+	// ErrVerificationRequired is returned when signing in after the email verification grace period without verifying
+	ErrVerificationRequired = apperror.Forbidden("VERIFICATION_REQUIRED", "email verification required; verify your email or request a new verification code to sign in")
+
+	// ErrEmailVerificationNeeded is returned when an account with an unverified email applies for a job or creates a company
+	ErrEmailVerificationNeeded = apperror.Forbidden("EMAIL_NOT_VERIFIED", "verify your email before applying for jobs or creating a company")
+
+	// ErrInvalidPhone is returned when a phone number cannot be parsed
+	ErrInvalidPhone = apperror.Validation("INVALID_PHONE", "invalid phone number").WithField("phone", "must be a phone number such as 0812 3456 7890 or +62 812 3456 7890")
+
+	// ErrPhoneNotSet is returned when phone verification is requested without a phone number on the account
+	ErrPhoneNotSet = apperror.Validation("PHONE_NOT_SET", "add a phone number to your profile before verifying it")
+
+	// ErrPhoneAlreadyVerified is returned when the user's phone number is already verified
+	ErrPhoneAlreadyVerified = apperror.Conflict("PHONE_ALREADY_VERIFIED", "phone number already verified")
+
+	// ErrPhoneTaken is returned when another account has already verified the phone number
+	ErrPhoneTaken = apperror.Conflict("PHONE_ALREADY_EXISTS", "phone number is already verified by another account")
+)
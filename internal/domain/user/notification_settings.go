@@ -0,0 +1,214 @@
+package user
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// NotificationEvent is a kind of notification users choose delivery channels for
+type NotificationEvent string
+
+// Notification events of the preference matrix
+const (
+	NotificationEventApplicationStatus NotificationEvent = "application_status"
+	NotificationEventInterview         NotificationEvent = "interview"
+	NotificationEventMessages          NotificationEvent = "messages"
+	NotificationEventJobAlerts         NotificationEvent = "job_alerts"
+	NotificationEventCompanyFollows    NotificationEvent = "company_follows"
+	NotificationEventMarketing         NotificationEvent = "marketing"
+)
+
+// NotificationEventTransactional covers account notices such as company invitations, job
+// reviews and verification expiry. They are not part of the matrix and cannot be turned
+// off; only quiet hours apply to their pushes.
+const NotificationEventTransactional NotificationEvent = "transactional"
+
+// NotificationEvents lists the events of the preference matrix
+var NotificationEvents = []NotificationEvent{
+	NotificationEventApplicationStatus,
+	NotificationEventInterview,
+	NotificationEventMessages,
+	NotificationEventJobAlerts,
+	NotificationEventCompanyFollows,
+	NotificationEventMarketing,
+}
+
+// IsValid checks if the event is part of the preference matrix
+func (e NotificationEvent) IsValid() bool {
+	for _, event := range NotificationEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationChannel is a channel notifications are delivered over outside the app
+type NotificationChannel string
+
+// Notification channels of the preference matrix
+const (
+	NotificationChannelEmail    NotificationChannel = "email"
+	NotificationChannelPush     NotificationChannel = "push"
+	NotificationChannelWhatsApp NotificationChannel = "whatsapp"
+)
+
+// NotificationChannels holds whether an event is delivered by email, by push and by WhatsApp
+type NotificationChannels struct {
+	Email    bool `json:"email"`
+	Push     bool `json:"push"`
+	WhatsApp bool `json:"whatsapp"`
+}
+
+// WhatsAppEvents are the events worth a WhatsApp message; WhatsApp is never used for others
+var WhatsAppEvents = []NotificationEvent{
+	NotificationEventApplicationStatus,
+	NotificationEventInterview,
+}
+
+// HasWhatsApp checks if WhatsApp messages are sent about the event at all
+func (e NotificationEvent) HasWhatsApp() bool {
+	for _, event := range WhatsAppEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationMatrix maps events to the channels they are delivered over, stored as JSONB.
+// Events missing from the matrix use their default channels.
+type NotificationMatrix map[NotificationEvent]NotificationChannels
+
+// Value implements the driver.Valuer interface for GORM JSONB
+func (m NotificationMatrix) Value() (driver.Value, error) {
+	if m == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(m)
+}
+
+// Scan implements the sql.Scanner interface for GORM JSONB
+func (m *NotificationMatrix) Scan(value interface{}) error {
+	if value == nil {
+		*m = NotificationMatrix{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to unmarshal JSONB value")
+	}
+
+	return json.Unmarshal(bytes, m)
+}
+
+// DefaultQuietHoursTimezone is the timezone quiet hours are read in when none was chosen
+const DefaultQuietHoursTimezone = "Asia/Jakarta"
+
+// quietHoursLayout is the clock time format of quiet hours
+const quietHoursLayout = "15:04"
+
+// ParseQuietHoursTime parses a quiet hours clock time such as 22:00 into minutes after midnight
+func ParseQuietHoursTime(value string) (int, error) {
+	t, err := time.Parse(quietHoursLayout, value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quiet hours time %q: %w", value, err)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// NotificationChannelsFor returns the channels the event is delivered over. A nil
+// preference, as for users who never saved one, uses the defaults: every event by email and
+// push except marketing, which is emailed only with the email marketing consent, and the
+// WhatsApp events by WhatsApp too.
+func (upref *UserPreference) NotificationChannelsFor(event NotificationEvent) NotificationChannels {
+	if upref != nil {
+		if channels, ok := upref.NotificationMatrix[event]; ok {
+			return channels
+		}
+	}
+
+	if event == NotificationEventMarketing {
+		return NotificationChannels{Email: upref != nil && upref.EmailMarketing}
+	}
+	return NotificationChannels{Email: true, Push: true, WhatsApp: event.HasWhatsApp()}
+}
+
+// AllowsNotification checks if the user accepts notifications about the event over the
+// channel. EmailNotifications and PushNotifications switch a whole channel off, and the
+// matrix decides per event. Transactional notifications are always allowed, except by
+// WhatsApp, which only carries the WhatsApp events.
+func (upref *UserPreference) AllowsNotification(event NotificationEvent, channel NotificationChannel) bool {
+	if channel == NotificationChannelWhatsApp && !event.HasWhatsApp() {
+		return false
+	}
+	if event == NotificationEventTransactional {
+		return true
+	}
+
+	channels := upref.NotificationChannelsFor(event)
+	switch channel {
+	case NotificationChannelEmail:
+		return (upref == nil || upref.EmailNotifications) && channels.Email
+	case NotificationChannelPush:
+		return (upref == nil || upref.PushNotifications) && channels.Push
+	case NotificationChannelWhatsApp:
+		return channels.WhatsApp
+	default:
+		return false
+	}
+}
+
+// HasQuietHours checks if the user set quiet hours. Equal start and end times mean none.
+func (upref *UserPreference) HasQuietHours() bool {
+	return upref != nil && upref.QuietHoursStart != nil && upref.QuietHoursEnd != nil &&
+		*upref.QuietHoursStart != *upref.QuietHoursEnd
+}
+
+// NextPushTime returns the earliest time at or after now that a push may be sent to the
+// user: now itself outside quiet hours, otherwise the end of the current quiet hours in
+// the user's timezone. Quiet hours past midnight, such as 22:00 to 07:00, end the next day.
+func (upref *UserPreference) NextPushTime(now time.Time) time.Time {
+	if !upref.HasQuietHours() {
+		return now
+	}
+
+	start, err := ParseQuietHoursTime(*upref.QuietHoursStart)
+	if err != nil {
+		return now
+	}
+	end, err := ParseQuietHoursTime(*upref.QuietHoursEnd)
+	if err != nil {
+		return now
+	}
+
+	timezone := upref.QuietHoursTimezone
+	if timezone == "" {
+		timezone = DefaultQuietHoursTimezone
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return now
+	}
+
+	local := now.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	endOn := func(days int) time.Time {
+		return time.Date(local.Year(), local.Month(), local.Day()+days, end/60, end%60, 0, 0, loc)
+	}
+
+	switch {
+	case start < end && minute >= start && minute < end:
+		return endOn(0)
+	case start > end && minute >= start:
+		return endOn(1)
+	case start > end && minute < end:
+		return endOn(0)
+	default:
+		return now
+	}
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -11,9 +12,19 @@ type UserRepository interface {
 	// User CRUD
 	Create(ctx context.Context, user *User) error
 	FindByID(ctx context.Context, id int64) (*User, error)
+	// FindByIDs loads the given users without relations in one query; missing IDs are skipped
+	FindByIDs(ctx context.Context, ids []int64) ([]User, error)
 	FindByUUID(ctx context.Context, uuid string) (*User, error)
 	FindByEmail(ctx context.Context, email string) (*User, error)
 	Update(ctx context.Context, user *User) error
+	// ChangeEmail switches a user's email from oldEmail to newEmail and records the change.
+	// Returns ErrEmailTaken when another account uses newEmail.
+	ChangeEmail(ctx context.Context, userID int64, oldEmail, newEmail string) error
+	// FindByVerifiedPhone finds the user who verified an E.164 phone number, returning nil when none did
+	FindByVerifiedPhone(ctx context.Context, phone string) (*User, error)
+	// MarkPhoneVerified records that the user verified phone, provided it is still their number.
+	// Returns ErrPhoneTaken when another account has verified the number.
+	MarkPhoneVerified(ctx context.Context, userID int64, phone string, verifiedAt time.Time) error
 	Delete(ctx context.Context, id int64) error
 	List(ctx context.Context, filter *UserFilter) ([]User, int64, error)
 
@@ -75,6 +86,38 @@ type UserRepository interface {
 	GetFullProfile(ctx context.Context, userID int64) (*User, error)
 }
 
+// AccountPrivacyRepository stores account deletion requests and data exports, and erases
+// the personal data of deleted accounts
+type AccountPrivacyRepository interface {
+	// Deletion requests. FindPendingDeletionRequest returns nil, nil when there is none.
+	// CreateDeletionRequest returns ErrDeletionAlreadyRequested when one is already pending.
+	CreateDeletionRequest(ctx context.Context, req *AccountDeletionRequest) error
+	FindPendingDeletionRequest(ctx context.Context, userID int64) (*AccountDeletionRequest, error)
+	UpdateDeletionRequest(ctx context.Context, req *AccountDeletionRequest) error
+	// FindDueDeletionRequests returns up to limit pending requests scheduled at or before now,
+	// oldest first
+	FindDueDeletionRequests(ctx context.Context, now time.Time, limit int) ([]AccountDeletionRequest, error)
+
+	// AnonymizeUser erases the user's personal data in one transaction: the user row keeps
+	// only placeholderEmail and a deleted status, profile data, documents, tokens, sign-in
+	// methods, notifications, follows and exports are deleted, open applications are
+	// withdrawn, and the remaining applications and reviews are kept without personal data.
+	// It returns the URLs of the uploaded files the deleted rows pointed at, for the caller
+	// to remove from storage once the transaction has committed.
+	AnonymizeUser(ctx context.Context, userID int64, placeholderEmail string) ([]string, error)
+
+	// Data exports. FindOpenDataExport returns the user's pending export, or its ready export
+	// that has not expired at now, and nil, nil when there is none.
+	CreateDataExport(ctx context.Context, export *DataExport) error
+	FindOpenDataExport(ctx context.Context, userID int64, now time.Time) (*DataExport, error)
+	FindDataExportByTokenHash(ctx context.Context, tokenHash string) (*DataExport, error)
+	FindPendingDataExports(ctx context.Context, limit int) ([]DataExport, error)
+	UpdateDataExport(ctx context.Context, export *DataExport) error
+	// ExpireDataExports marks ready exports whose link expired before now as expired, drops
+	// their data and returns how many were expired
+	ExpireDataExports(ctx context.Context, now time.Time) (int64, error)
+}
+
 // UserFilter represents filters for querying users
 type UserFilter struct {
 	UserType         *string
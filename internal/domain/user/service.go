@@ -24,6 +24,8 @@ type UserService interface {
 	// Preference management
 	GetPreferences(ctx context.Context, userID int64) (*UserPreference, error)
 	UpdatePreferences(ctx context.Context, userID int64, req *UpdatePreferenceRequest) error
+	GetNotificationSettings(ctx context.Context, userID int64) (*UserPreference, error)
+	UpdateNotificationSettings(ctx context.Context, userID int64, req *UpdateNotificationSettingsRequest) (*UserPreference, error)
 
 	// Education management
 	AddEducation(ctx context.Context, userID int64, req *AddEducationRequest) (*UserEducation, error)
@@ -66,6 +68,7 @@ type UserService interface {
 	UploadDocument(ctx context.Context, userID int64, file *multipart.FileHeader, req *UploadDocumentRequest) (*UserDocument, error)
 	DeleteDocument(ctx context.Context, userID int64, documentID int64) error
 	GetDocuments(ctx context.Context, userID int64) ([]UserDocument, error)
+	ParseResume(ctx context.Context, file *multipart.FileHeader) (*ParsedResume, error)
 
 	// Search and discovery
 	SearchUsers(ctx context.Context, filter *UserFilter) ([]User, int64, error)
@@ -73,6 +76,7 @@ type UserService interface {
 
 	// Profile completion and analytics
 	GetProfileCompletionPercentage(ctx context.Context, userID int64) (int, error)
+	GetProfileCompleteness(ctx context.Context, userID int64) (*ProfileCompleteness, error)
 	UpdateLastLogin(ctx context.Context, userID int64) error
 
 	// Account management
@@ -82,6 +86,28 @@ type UserService interface {
 	DeleteAccount(ctx context.Context, userID int64) error
 }
 
+// AccountPrivacyService lets job seekers delete their account and export their personal data
+type AccountPrivacyService interface {
+	// RequestDeletion schedules the account for deletion once the grace period has ended
+	RequestDeletion(ctx context.Context, userID int64) (*AccountDeletionRequest, error)
+	// CancelDeletion cancels the user's pending deletion request
+	CancelDeletion(ctx context.Context, userID int64) error
+	// GetPendingDeletion returns the user's pending deletion request, or nil when there is none
+	GetPendingDeletion(ctx context.Context, userID int64) (*AccountDeletionRequest, error)
+	// ExecuteDueDeletions anonymizes the accounts whose grace period has ended and returns
+	// how many were deleted
+	ExecuteDueDeletions(ctx context.Context) (int, error)
+
+	// RequestDataExport queues a data export, or returns the user's export that is still
+	// pending or downloadable. The download link is emailed once the export is ready.
+	RequestDataExport(ctx context.Context, userID int64) (*DataExport, error)
+	// ProcessDataExports generates pending exports, emails their links and expires old ones.
+	// It returns how many exports were generated or expired.
+	ProcessDataExports(ctx context.Context) (int, error)
+	// DownloadDataExport returns the JSON bundle of the export with the download token
+	DownloadDataExport(ctx context.Context, token string) (*DataExport, error)
+}
+
 // Request DTOs (simplified - will be detailed in DTO layer)
 
 type RegisterRequest struct {
@@ -90,6 +116,8 @@ type RegisterRequest struct {
 	Phone    *string
 	Password string
 	UserType string
+	// InvitationToken, when set, joins the new user to the company that sent the invitation
+	InvitationToken string
 }
 
 type UpdateProfileRequest struct {
@@ -136,6 +164,25 @@ type UpdatePreferenceRequest struct {
 	ShowOnlineStatus    *bool
 	AllowDirectMessages *bool
 	DataSharingConsent  *bool
+	AllowTalentPools    *bool
+}
+
+// UpdateNotificationSettingsRequest changes the notification matrix and quiet hours. Nil
+// fields and events missing from Events are left unchanged. Empty quiet hours times clear them.
+type UpdateNotificationSettingsRequest struct {
+	EmailNotifications *bool
+	PushNotifications  *bool
+	Events             map[NotificationEvent]UpdateNotificationChannels
+	QuietHoursStart    *string
+	QuietHoursEnd      *string
+	QuietHoursTimezone *string
+}
+
+// UpdateNotificationChannels changes the channels of one event; nil channels are left unchanged
+type UpdateNotificationChannels struct {
+	Email    *bool
+	Push     *bool
+	WhatsApp *bool
 }
 
 type AddEducationRequest struct {
@@ -289,3 +336,48 @@ type UploadDocumentRequest struct {
 	DocumentName string
 	Description  *string
 }
+
+// ParsedResume holds profile suggestions extracted from an uploaded resume.
+// Nothing is saved until the user confirms the suggestions.
+type ParsedResume struct {
+	Email        *string
+	Phone        *string
+	LinkedInURL  *string
+	Skills       []ParsedResumeSkill
+	DegreeLevels []string // UserEducation degree levels, highest first
+}
+
+// ParsedResumeSkill is a skills master entry found in a resume
+type ParsedResumeSkill struct {
+	SkillID int64
+	Name    string
+}
+
+// Profile completeness checklist items, in the order the app shows them
+const (
+	CompletenessAvatar      = "avatar"
+	CompletenessHeadline    = "headline"
+	CompletenessBio         = "bio"
+	CompletenessEducation   = "education"
+	CompletenessExperience  = "experience"
+	CompletenessSkills      = "skills"
+	CompletenessResume      = "resume"
+	CompletenessLocation    = "location"
+	CompletenessPreferences = "job_preferences"
+	CompletenessPhone       = "phone"
+)
+
+// ProfileCompleteness is a weighted checklist of what a job seeker's profile is missing
+type ProfileCompleteness struct {
+	Score       int // Sum of the weights of completed items, 0-100
+	Items       []ProfileCompletenessItem
+	NextActions []string // Actions for the missing items, highest weight first
+}
+
+// ProfileCompletenessItem is one checklist item of the profile completeness
+type ProfileCompletenessItem struct {
+	Key        string
+	Weight     int
+	Completed  bool
+	NextAction string
+}
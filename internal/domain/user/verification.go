@@ -0,0 +1,54 @@
+package user
+
+import "time"
+
+// DefaultEmailVerificationGracePeriod is how long an account can sign in without verifying its email
+const DefaultEmailVerificationGracePeriod = 3 * 24 * time.Hour
+
+// EmailVerificationPolicy decides what an account with an unverified email may do. It can sign
+// in and browse for GracePeriod after signing up, but cannot apply for jobs or create companies;
+// once the grace period has ended, signing in needs a verified email.
+type EmailVerificationPolicy struct {
+	GracePeriod time.Duration
+}
+
+// NewEmailVerificationPolicy returns the policy with gracePeriod, or the default grace period when it is not positive
+func NewEmailVerificationPolicy(gracePeriod time.Duration) EmailVerificationPolicy {
+	if gracePeriod <= 0 {
+		gracePeriod = DefaultEmailVerificationGracePeriod
+	}
+	return EmailVerificationPolicy{GracePeriod: gracePeriod}
+}
+
+// EmailVerificationState describes where an account is in email verification
+type EmailVerificationState struct {
+	Verified      bool       `json:"verified"`
+	VerifiedAt    *time.Time `json:"verified_at,omitempty"`
+	GraceEndsAt   *time.Time `json:"grace_ends_at,omitempty"` // Unverified accounts only
+	DaysRemaining int        `json:"days_remaining"`          // Whole or partial days left in the grace period
+	Required      bool       `json:"required"`                // The grace period has ended; signing in is blocked
+}
+
+// GraceEndsAt returns when the account's grace period ends
+func (p EmailVerificationPolicy) GraceEndsAt(u *User) time.Time {
+	return u.CreatedAt.Add(p.GracePeriod)
+}
+
+// CanLogin reports whether the account may sign in at now: verified, or still in its grace period
+func (p EmailVerificationPolicy) CanLogin(u *User, now time.Time) bool {
+	return u.IsEmailVerified() || now.Before(p.GraceEndsAt(u))
+}
+
+// State reports the account's verification state at now
+func (p EmailVerificationPolicy) State(u *User, now time.Time) EmailVerificationState {
+	if u.IsEmailVerified() {
+		return EmailVerificationState{Verified: true, VerifiedAt: u.EmailVerifiedAt}
+	}
+
+	endsAt := p.GraceEndsAt(u)
+	state := EmailVerificationState{GraceEndsAt: &endsAt, Required: !now.Before(endsAt)}
+	if remaining := endsAt.Sub(now); remaining > 0 {
+		state.DaysRemaining = int((remaining + 24*time.Hour - 1) / (24 * time.Hour))
+	}
+	return state
+}
@@ -0,0 +1,27 @@
+package user
+
+// Profile visibility settings of UserPreference.ProfileVisibility
+const (
+	ProfileVisibilityPublic        = "public"
+	ProfileVisibilityPrivate       = "private"        // Hidden from employer search and talent pools
+	ProfileVisibilityRecruiterOnly = "recruiter-only" // Shown to employers only
+)
+
+// IsProfilePrivate reports whether the user keeps their profile out of anything employers
+// browse on their own initiative. Companies the user applied to still see the applications.
+// Users without preferences are public.
+func (p *UserPreference) IsProfilePrivate() bool {
+	return p != nil && p.ProfileVisibility == ProfileVisibilityPrivate
+}
+
+// IsDiscoverable reports whether companies may keep the user in talent pools and find them
+// when browsing candidates
+func (p *UserPreference) IsDiscoverable() bool {
+	return p == nil || (p.AllowTalentPools && !p.IsProfilePrivate())
+}
+
+// AcceptsDirectMessages reports whether employers may message the user directly. Users
+// without preferences accept messages, like the column default.
+func (p *UserPreference) AcceptsDirectMessages() bool {
+	return p == nil || p.AllowDirectMessages
+}
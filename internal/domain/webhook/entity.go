@@ -0,0 +1,180 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Events a webhook can subscribe to
+const (
+	EventApplicationCreated       = "application.created"
+	EventApplicationStatusChanged = "application.status_changed"
+	EventInterviewScheduled       = "interview.scheduled"
+	EventJobPublished             = "job.published"
+	EventJobExpired               = "job.expired"
+)
+
+// ValidEvents lists every event a webhook can subscribe to
+var ValidEvents = []string{
+	EventApplicationCreated,
+	EventApplicationStatusChanged,
+	EventInterviewScheduled,
+	EventJobPublished,
+	EventJobExpired,
+}
+
+// IsValidEvent checks if a webhook can subscribe to event
+func IsValidEvent(event string) bool {
+	for _, e := range ValidEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Headers sent with every delivery
+const (
+	SignatureHeader = "X-Keerja-Signature" // "sha256=" followed by the hex HMAC-SHA256 of the body
+	EventHeader     = "X-Keerja-Event"
+	EventIDHeader   = "X-Keerja-Event-Id" // The same for redeliveries of an event
+)
+
+// SecretPrefix starts every generated signing secret
+const SecretPrefix = "whsec_"
+
+// MaxWebhooksPerCompany caps the endpoints a company can register
+const MaxWebhooksPerCompany = 10
+
+// Delivery statuses
+const (
+	DeliveryStatusPending   = "pending" // Waiting for its next attempt
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusFailed    = "failed" // Gave up after the maximum number of attempts or the endpoint was disabled
+)
+
+// Circuit breaker: an endpoint is disabled once BreakerFailureThreshold attempts in a row
+// failed over at least BreakerFailingPeriod, so a short outage does not disable it
+const (
+	BreakerFailureThreshold = 20
+	BreakerFailingPeriod    = 6 * time.Hour
+)
+
+// CompanyWebhook is an endpoint of a company's external system (e.g. an ATS) that is sent
+// the events it subscribes to. The secret signs each delivery so the receiver can verify it.
+type CompanyWebhook struct {
+	ID           int64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	CompanyID    int64          `gorm:"column:company_id;not null;index" json:"company_id"`
+	URL          string         `gorm:"column:url;type:text;not null" json:"url"`
+	Secret       string         `gorm:"column:secret;type:varchar(100);not null" json:"-"`
+	Events       pq.StringArray `gorm:"column:events;type:text[];not null" json:"events"`
+	Active       bool           `gorm:"column:active;not null;default:true" json:"active"`
+	FailureCount int            `gorm:"column:failure_count;not null;default:0" json:"failure_count"` // Failed attempts in a row
+	FailingSince *time.Time     `gorm:"column:failing_since" json:"failing_since,omitempty"`          // First failed attempt of the current run
+	DisabledAt   *time.Time     `gorm:"column:disabled_at" json:"disabled_at,omitempty"`              // Set when the circuit breaker disabled the endpoint
+	CreatedBy    *int64         `gorm:"column:created_by" json:"created_by,omitempty"`
+	CreatedAt    time.Time      `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time      `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for CompanyWebhook
+func (CompanyWebhook) TableName() string {
+	return "company_webhooks"
+}
+
+// Subscribes checks if the webhook is sent event
+func (w *CompanyWebhook) Subscribes(event string) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// ShouldTrip checks if the failures so far are sustained enough to disable the endpoint
+func (w *CompanyWebhook) ShouldTrip(now time.Time) bool {
+	return w.FailureCount >= BreakerFailureThreshold &&
+		w.FailingSince != nil && now.Sub(*w.FailingSince) >= BreakerFailingPeriod
+}
+
+// WebhookDelivery is one event sent, or waiting to be sent, to a webhook. A redelivery is a
+// new delivery of the same event, so the history of earlier attempts is kept.
+type WebhookDelivery struct {
+	ID            int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	WebhookID     int64      `gorm:"column:webhook_id;not null;index" json:"webhook_id"`
+	CompanyID     int64      `gorm:"column:company_id;not null" json:"company_id"`
+	EventID       string     `gorm:"column:event_id;type:uuid;not null" json:"event_id"`
+	Event         string     `gorm:"column:event;type:varchar(50);not null" json:"event"`
+	Payload       string     `gorm:"column:payload;type:jsonb;not null" json:"payload"` // The request body, an Envelope
+	Status        string     `gorm:"column:status;type:varchar(20);not null;default:'pending'" json:"status"`
+	Attempts      int        `gorm:"column:attempts;not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;not null" json:"next_attempt_at"`
+	ResponseCode  *int       `gorm:"column:response_code" json:"response_code,omitempty"` // Status code of the last attempt, nil when no response arrived
+	LastError     *string    `gorm:"column:last_error;type:text" json:"last_error,omitempty"`
+	DeliveredAt   *time.Time `gorm:"column:delivered_at" json:"delivered_at,omitempty"`
+	CreatedAt     time.Time  `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for WebhookDelivery
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// Envelope is the JSON body of every delivery
+type Envelope struct {
+	ID        string      `json:"id"` // Event ID, lets receivers drop duplicate deliveries
+	Event     string      `json:"event"`
+	CompanyID int64       `json:"company_id"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// ApplicationEventData is the data of application.created and application.status_changed.
+// It identifies the application only; integrations read the applicant through the API, which
+// applies the company's blind screening settings.
+type ApplicationEventData struct {
+	ApplicationID  int64     `json:"application_id"`
+	JobID          int64     `json:"job_id"`
+	Status         string    `json:"status"`
+	PreviousStatus string    `json:"previous_status,omitempty"`
+	OccurredAt     time.Time `json:"occurred_at"`
+}
+
+// InterviewEventData is the data of interview.scheduled
+type InterviewEventData struct {
+	InterviewID   int64     `json:"interview_id"`
+	ApplicationID int64     `json:"application_id"`
+	JobID         int64     `json:"job_id"`
+	ScheduledAt   time.Time `json:"scheduled_at"`
+	Timezone      string    `json:"timezone"`
+	InterviewType string    `json:"interview_type"`
+}
+
+// JobEventData is the data of job.published and job.expired
+type JobEventData struct {
+	JobID       int64      `json:"job_id"`
+	Title       string     `json:"title"`
+	Slug        string     `json:"slug"`
+	Status      string     `json:"status"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	ExpiredAt   *time.Time `json:"expired_at,omitempty"`
+}
+
+// Sign returns the X-Keerja-Signature value of body: "sha256=" followed by the hex
+// HMAC-SHA256 of the body keyed with the webhook secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks a signature in constant time, as receivers should
+func VerifySignature(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
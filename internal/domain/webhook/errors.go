@@ -0,0 +1,23 @@
+package webhook
+
+import "keerja-backend/internal/apperror"
+
+var (
+	// ErrWebhookNotFound is returned when the webhook does not exist or belongs to another company
+	ErrWebhookNotFound = apperror.NotFound("WEBHOOK_NOT_FOUND", "webhook not found")
+
+	// ErrDeliveryNotFound is returned when the delivery does not exist or belongs to another company
+	ErrDeliveryNotFound = apperror.NotFound("WEBHOOK_DELIVERY_NOT_FOUND", "webhook delivery not found")
+
+	// ErrInvalidWebhookURL is returned when a webhook URL is not an https URL of a public host
+	ErrInvalidWebhookURL = apperror.Validation("INVALID_WEBHOOK_URL", "url must be an https URL of a public host").WithField("url", "must be an https URL of a public host")
+
+	// ErrInvalidWebhookEvent is returned when subscribing to an unknown event
+	ErrInvalidWebhookEvent = apperror.Validation("INVALID_WEBHOOK_EVENT", "events must be application.created, application.status_changed, interview.scheduled, job.published or job.expired").WithField("events", "must be application.created, application.status_changed, interview.scheduled, job.published or job.expired")
+
+	// ErrWebhookInactive is returned when redelivering to a disabled webhook
+	ErrWebhookInactive = apperror.Conflict("WEBHOOK_INACTIVE", "webhook is disabled; enable it before redelivering")
+
+	// ErrTooManyWebhooks is returned when a company already has MaxWebhooksPerCompany webhooks
+	ErrTooManyWebhooks = apperror.Conflict("TOO_MANY_WEBHOOKS", "company already has the maximum number of webhooks")
+)
@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"context"
+	"time"
+)
+
+// DeliveryFilter narrows a list of deliveries; zero values match everything
+type DeliveryFilter struct {
+	CompanyID int64
+	WebhookID int64
+	Status    string
+	Event     string
+}
+
+// Matches checks if the delivery falls within the filter
+func (f DeliveryFilter) Matches(d *WebhookDelivery) bool {
+	return (f.CompanyID == 0 || d.CompanyID == f.CompanyID) &&
+		(f.WebhookID == 0 || d.WebhookID == f.WebhookID) &&
+		(f.Status == "" || d.Status == f.Status) &&
+		(f.Event == "" || d.Event == f.Event)
+}
+
+// UpdateWebhookInput holds the changes to a webhook; nil fields are left as they are
+type UpdateWebhookInput struct {
+	URL    *string
+	Events []string
+	Active *bool // Enabling a webhook clears its failure count
+}
+
+// WebhookRepository defines the interface for company webhook data operations
+type WebhookRepository interface {
+	// Create inserts a new webhook
+	Create(ctx context.Context, w *CompanyWebhook) error
+
+	// FindByID finds a webhook by ID, returning nil when it does not exist
+	FindByID(ctx context.Context, id int64) (*CompanyWebhook, error)
+
+	// ListByCompany lists a company's webhooks, newest first
+	ListByCompany(ctx context.Context, companyID int64) ([]CompanyWebhook, error)
+
+	// CountByCompany counts a company's webhooks
+	CountByCompany(ctx context.Context, companyID int64) (int64, error)
+
+	// Update saves a webhook's URL, events, active flag and failure state
+	Update(ctx context.Context, w *CompanyWebhook) error
+
+	// Delete deletes a webhook with its deliveries
+	Delete(ctx context.Context, id int64) error
+
+	// EnqueueEvent inserts a pending delivery of the payload for every active webhook of the
+	// company that subscribes to event, due at now, and returns how many were queued
+	EnqueueEvent(ctx context.Context, companyID int64, event, eventID, payload string, now time.Time) (int64, error)
+
+	// CreateDelivery inserts a delivery
+	CreateDelivery(ctx context.Context, d *WebhookDelivery) error
+
+	// ClaimDue counts an attempt on up to limit pending deliveries of active webhooks whose
+	// next attempt is due and pushes their next attempt to leaseUntil, so overlapping
+	// workers never send the same delivery twice
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]WebhookDelivery, error)
+
+	// MarkDelivered marks a delivery delivered with the endpoint's response code
+	MarkDelivered(ctx context.Context, id int64, responseCode int, deliveredAt time.Time) error
+
+	// MarkRetry records a failed attempt and when to try again
+	MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, responseCode *int, lastError string) error
+
+	// MarkFailed records a failed attempt after which the delivery is no longer retried
+	MarkFailed(ctx context.Context, id int64, responseCode *int, lastError string) error
+
+	// RecordSuccess clears a webhook's failure count
+	RecordSuccess(ctx context.Context, webhookID int64) error
+
+	// RecordFailure counts a failed attempt on a webhook and returns the updated webhook,
+	// or nil when it no longer exists
+	RecordFailure(ctx context.Context, webhookID int64, now time.Time) (*CompanyWebhook, error)
+
+	// Disable deactivates an active webhook and fails its pending deliveries. It returns
+	// false when the webhook was not active.
+	Disable(ctx context.Context, webhookID int64, now time.Time) (bool, error)
+
+	// FindDeliveryByID finds a delivery by ID, returning nil when it does not exist
+	FindDeliveryByID(ctx context.Context, id int64) (*WebhookDelivery, error)
+
+	// ListDeliveries lists deliveries matching filter, newest first
+	ListDeliveries(ctx context.Context, filter DeliveryFilter, page, limit int) ([]WebhookDelivery, int64, error)
+}
+
+// EventPublisher sends company events to the company's webhooks
+type EventPublisher interface {
+	// Publish queues a delivery of the event to each of the company's active webhooks that
+	// subscribes to it. Delivery is asynchronous; failures to queue are logged, not returned.
+	Publish(ctx context.Context, companyID int64, event string, data interface{})
+}
+
+// WebhookService manages company webhooks and delivers events to them with retries
+type WebhookService interface {
+	EventPublisher
+
+	// CreateWebhook registers an endpoint for the company with a generated signing secret,
+	// which is returned in the webhook
+	CreateWebhook(ctx context.Context, companyID, createdBy int64, url string, events []string) (*CompanyWebhook, error)
+
+	// ListWebhooks lists a company's webhooks, newest first
+	ListWebhooks(ctx context.Context, companyID int64) ([]CompanyWebhook, error)
+
+	// UpdateWebhook changes one of the company's webhooks
+	UpdateWebhook(ctx context.Context, companyID, webhookID int64, input UpdateWebhookInput) (*CompanyWebhook, error)
+
+	// DeleteWebhook deletes one of the company's webhooks
+	DeleteWebhook(ctx context.Context, companyID, webhookID int64) error
+
+	// ProcessDue sends the deliveries that are due, rescheduling failures with exponential
+	// backoff and disabling endpoints that keep failing, and returns how many were delivered
+	ProcessDue(ctx context.Context) (int, error)
+
+	// ListDeliveries lists deliveries matching filter, newest first
+	ListDeliveries(ctx context.Context, filter DeliveryFilter, page, limit int) ([]WebhookDelivery, int64, error)
+
+	// Redeliver queues the event of a delivery to be sent again as a new delivery. The
+	// delivery must match scope; admins pass an empty scope.
+	Redeliver(ctx context.Context, deliveryID int64, scope DeliveryFilter) (*WebhookDelivery, error)
+}
@@ -0,0 +1,26 @@
+package mapper
+
+import (
+	"keerja-backend/internal/domain/audit"
+	"keerja-backend/internal/dto/response"
+)
+
+// ToAdminAuditLogResponse converts an audit log entry to response DTO
+func ToAdminAuditLogResponse(l *audit.AuditLog) response.AdminAuditLogResponse {
+	metadata := map[string]interface{}(l.Metadata)
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+
+	return response.AdminAuditLogResponse{
+		ID:         l.ID,
+		ActorID:    l.ActorID,
+		ActorType:  l.ActorType,
+		Action:     l.Action,
+		EntityType: l.EntityType,
+		EntityID:   l.EntityID,
+		Metadata:   metadata,
+		IP:         l.IP,
+		CreatedAt:  l.CreatedAt,
+	}
+}
@@ -25,13 +25,7 @@ func ToAdminAuthResponse(adminUser *admin.AdminUser, accessToken, refreshToken s
 
 	// Map role info if exists
 	if adminUser.Role != nil {
-		resp.Role = &response.AdminRoleInfo{
-			RoleID:          adminUser.Role.ID,
-			RoleName:        adminUser.Role.RoleName,
-			RoleDescription: adminUser.Role.RoleDescription,
-			AccessLevel:     adminUser.Role.AccessLevel,
-			IsSystemRole:    adminUser.Role.IsSystemRole,
-		}
+		resp.Role = ToAdminRoleInfo(adminUser.Role)
 	}
 
 	return resp
@@ -54,18 +48,26 @@ func ToAdminProfileResponse(adminUser *admin.AdminUser) *response.AdminProfileRe
 
 	// Map role info if exists
 	if adminUser.Role != nil {
-		resp.Role = &response.AdminRoleInfo{
-			RoleID:          adminUser.Role.ID,
-			RoleName:        adminUser.Role.RoleName,
-			RoleDescription: adminUser.Role.RoleDescription,
-			AccessLevel:     adminUser.Role.AccessLevel,
-			IsSystemRole:    adminUser.Role.IsSystemRole,
-		}
+		resp.Role = ToAdminRoleInfo(adminUser.Role)
 	}
 
 	return resp
 }
 
+// ToAdminRoleInfo converts AdminRole to AdminRoleInfo
+func ToAdminRoleInfo(role *admin.AdminRole) *response.AdminRoleInfo {
+	perms := []string{}
+	perms = append(perms, role.Permissions...)
+	return &response.AdminRoleInfo{
+		RoleID:          role.ID,
+		RoleName:        role.RoleName,
+		RoleDescription: role.RoleDescription,
+		AccessLevel:     role.AccessLevel,
+		IsSystemRole:    role.IsSystemRole,
+		Permissions:     perms,
+	}
+}
+
 // ToAdminTokenResponse creates token response
 func ToAdminTokenResponse(accessToken, refreshToken string, expiresIn int64) *response.AdminTokenResponse {
 	return &response.AdminTokenResponse{
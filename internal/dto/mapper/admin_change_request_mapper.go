@@ -0,0 +1,24 @@
+package mapper
+
+import (
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/dto/response"
+)
+
+// ToAdminChangeRequestResponse converts a queued company change request to response DTO
+func ToAdminChangeRequestResponse(r *company.CompanyChangeRequest) response.AdminChangeRequestResponse {
+	resp := response.AdminChangeRequestResponse{
+		CompanyChangeRequestResponse: *ToCompanyChangeRequestResponse(r),
+		RequestedBy:                  r.RequestedBy,
+		DecidedBy:                    r.DecidedBy,
+	}
+
+	if r.Company != nil {
+		resp.CompanyName = r.Company.CompanyName
+		resp.CurrentIndustryID = r.Company.IndustryID
+		resp.CurrentCompanySizeID = r.Company.CompanySizeID
+		resp.CurrentDistrictID = r.Company.DistrictID
+	}
+
+	return resp
+}
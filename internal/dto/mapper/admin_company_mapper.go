@@ -24,6 +24,8 @@ func ToAdminCompanyListItemResponse(item *admin.AdminCompanyListItem) response.A
 		TotalJobs:          item.TotalJobs,
 		ActiveJobs:         item.ActiveJobs,
 		TotalApplications:  item.TotalApplications,
+		TotalFollowers:     item.TotalFollowers,
+		PendingDocuments:   item.PendingDocuments,
 		CreatedAt:          item.CreatedAt,
 		UpdatedAt:          item.UpdatedAt,
 	}
@@ -77,7 +79,6 @@ func ToAdminCompanyDetailResponse(detail *admin.AdminCompanyDetailResponse) resp
 				ID:              doc.ID,
 				DocumentType:    doc.DocumentType,
 				DocumentNumber:  doc.DocumentNumber,
-				FilePath:        doc.FilePath,
 				Status:          doc.Status,
 				VerifiedBy:      doc.VerifiedBy,
 				VerifiedAt:      doc.VerifiedAt,
@@ -0,0 +1,25 @@
+package mapper
+
+import (
+	"encoding/json"
+
+	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/dto/response"
+)
+
+// ToAdminQueuedEmailResponse converts a queued email to response DTO
+func ToAdminQueuedEmailResponse(e *email.QueuedEmail) response.AdminQueuedEmailResponse {
+	return response.AdminQueuedEmailResponse{
+		ID:            e.ID,
+		Recipient:     e.Recipient,
+		Template:      e.Template,
+		Payload:       json.RawMessage(e.Payload),
+		Status:        e.Status,
+		Attempts:      e.Attempts,
+		NextAttemptAt: e.NextAttemptAt,
+		LastError:     e.LastError,
+		SentAt:        e.SentAt,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}
+}
@@ -0,0 +1,37 @@
+package mapper
+
+import (
+	"keerja-backend/internal/domain/notification"
+	"keerja-backend/internal/dto/response"
+)
+
+// ToPushCampaignResponse converts a push campaign to response DTO
+func ToPushCampaignResponse(c *notification.PushCampaign) response.PushCampaignResponse {
+	resp := response.PushCampaignResponse{
+		ID:                  c.ID,
+		Title:               c.Title,
+		Body:                c.Body,
+		Data:                c.Data,
+		Topic:               c.Topic,
+		Status:              c.Status,
+		ScheduledAt:         c.ScheduledAt,
+		StartedAt:           c.StartedAt,
+		CompletedAt:         c.CompletedAt,
+		SentCount:           c.SentCount,
+		FailedCount:         c.FailedCount,
+		InvalidTokensPruned: c.InvalidTokensPruned,
+		ErrorMessage:        c.ErrorMessage,
+		CreatedBy:           c.CreatedBy,
+		CreatedAt:           c.CreatedAt,
+	}
+
+	if !c.IsTopicCampaign() {
+		resp.Filter = &response.PushFilterResult{
+			Role:              c.Role,
+			ProvinceID:        c.ProvinceID,
+			FollowedCompanyID: c.FollowedCompanyID,
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,40 @@
+package mapper
+
+import (
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/dto/response"
+)
+
+// ToAdminReviewResponse converts a moderation queue review to response DTO
+func ToAdminReviewResponse(r *company.ModerationReview) response.AdminReviewResponse {
+	resp := response.AdminReviewResponse{
+		ID:                 r.ID,
+		CompanyID:          r.CompanyID,
+		CompanyName:        r.CompanyName,
+		ReviewerName:       r.ReviewerName,
+		ReviewerType:       r.ReviewerType,
+		PositionTitle:      r.PositionTitle,
+		EmploymentPeriod:   r.EmploymentPeriod,
+		RatingOverall:      r.RatingOverall,
+		RatingCulture:      r.RatingCulture,
+		RatingWorkLife:     r.RatingWorkLife,
+		RatingSalary:       r.RatingSalary,
+		RatingManagement:   r.RatingManagement,
+		Pros:               r.Pros,
+		Cons:               r.Cons,
+		AdviceToManagement: r.AdviceToManagement,
+		IsAnonymous:        r.IsAnonymous,
+		RecommendToFriend:  r.RecommendToFriend,
+		Status:             r.Status,
+		ModeratedBy:        r.ModeratedBy,
+		ModeratedAt:        r.ModeratedAt,
+		CreatedAt:          r.CreatedAt,
+		UpdatedAt:          r.UpdatedAt,
+	}
+
+	if !r.IsAnonymous {
+		resp.UserID = r.UserID
+	}
+
+	return resp
+}
@@ -0,0 +1,63 @@
+package mapper
+
+import (
+	"keerja-backend/internal/dto/response"
+	"keerja-backend/internal/jobs"
+)
+
+// Scheduled job statuses shown to admins
+const (
+	scheduledJobIdle      = "idle"
+	scheduledJobRunning   = "running"
+	scheduledJobSucceeded = "succeeded"
+	scheduledJobFailed    = "failed"
+)
+
+// ToAdminScheduledJobResponse converts a job schedule to response DTO. The status is
+// running while a run is in progress, otherwise the outcome of the last run.
+func ToAdminScheduledJobResponse(s *jobs.JobSchedule) response.AdminScheduledJobResponse {
+	resp := response.AdminScheduledJobResponse{
+		Name:           s.Name,
+		Schedule:       s.Schedule,
+		TimeoutSeconds: int64(s.Timeout.Seconds()),
+		Status:         scheduledJobIdle,
+		NextRunAt:      s.NextRunAt,
+	}
+
+	if s.LastRun != nil {
+		lastRun := ToAdminJobRunResponse(s.LastRun)
+		resp.LastRun = &lastRun
+		switch {
+		case !s.LastRun.IsFinished():
+			resp.Status = scheduledJobRunning
+		case s.LastRun.Success:
+			resp.Status = scheduledJobSucceeded
+		default:
+			resp.Status = scheduledJobFailed
+		}
+	}
+	if s.Running {
+		resp.Status = scheduledJobRunning
+	}
+
+	return resp
+}
+
+// ToAdminJobRunResponse converts a job run to response DTO
+func ToAdminJobRunResponse(r *jobs.JobRun) response.AdminJobRunResponse {
+	resp := response.AdminJobRunResponse{
+		ID:             r.ID,
+		JobName:        r.JobName,
+		TriggeredBy:    r.TriggeredBy,
+		StartedAt:      r.StartedAt,
+		FinishedAt:     r.FinishedAt,
+		Success:        r.Success,
+		Error:          r.Error,
+		ItemsProcessed: r.ItemsProcessed,
+	}
+	if r.IsFinished() {
+		durationMs := r.Duration().Milliseconds()
+		resp.DurationMs = &durationMs
+	}
+	return resp
+}
@@ -0,0 +1,21 @@
+package mapper
+
+import (
+	"keerja-backend/internal/domain/notification"
+	"keerja-backend/internal/dto/response"
+)
+
+// ToAdminWhatsAppDeliveryResponse converts a WhatsApp delivery to response DTO
+func ToAdminWhatsAppDeliveryResponse(d *notification.WhatsAppDelivery) response.AdminWhatsAppDeliveryResponse {
+	return response.AdminWhatsAppDeliveryResponse{
+		ID:                d.ID,
+		UserID:            d.UserID,
+		Event:             d.Event,
+		Template:          d.Template,
+		Phone:             d.Phone,
+		Status:            d.Status,
+		ProviderMessageID: d.ProviderMessageID,
+		Error:             d.Error,
+		CreatedAt:         d.CreatedAt,
+	}
+}
@@ -118,11 +118,10 @@ func ToApplicationDocumentResponse(d *application.ApplicationDocument) *response
 		return nil
 	}
 
-	return &response.ApplicationDocumentResponse{
+	resp := &response.ApplicationDocumentResponse{
 		ID:           d.ID,
 		DocumentType: d.DocumentType,
 		FileName:     d.FileName,
-		FileURL:      d.FileURL,
 		FileType:     d.FileType,
 		FileSize:     d.FileSize,
 		UploadedAt:   d.UploadedAt,
@@ -133,6 +132,11 @@ func ToApplicationDocumentResponse(d *application.ApplicationDocument) *response
 		CreatedAt:    d.CreatedAt,
 		UpdatedAt:    d.UpdatedAt,
 	}
+	// Uploaded files are private and fetched through signed links; pre-uploaded links are shown as is
+	if !d.Uploaded {
+		resp.FileURL = d.FileURL
+	}
+	return resp
 }
 
 // ToApplicationNoteResponse maps ApplicationNote entity to ApplicationNoteResponse DTO
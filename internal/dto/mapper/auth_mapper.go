@@ -139,6 +139,31 @@ func ToDeviceListResponse(tokens []*auth.RefreshToken) *response.DeviceListRespo
 	}
 }
 
+// ToSessionListResponse converts refresh tokens to SessionListResponse.
+// currentSessionID is the session (token family) of the access token used for the request.
+func ToSessionListResponse(tokens []*auth.RefreshToken, currentSessionID string) *response.SessionListResponse {
+	sessions := make([]response.SessionInfo, 0, len(tokens))
+
+	for _, token := range tokens {
+		sessions = append(sessions, response.SessionInfo{
+			ID:         token.ID,
+			DeviceName: token.DeviceName,
+			DeviceType: token.DeviceType,
+			UserAgent:  token.UserAgent,
+			IPAddress:  token.IPAddress,
+			LastUsedAt: token.LastUsedAt.Format("2006-01-02T15:04:05Z07:00"),
+			CreatedAt:  token.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			ExpiresAt:  token.ExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
+			IsCurrent:  currentSessionID != "" && token.FamilyID == currentSessionID,
+		})
+	}
+
+	return &response.SessionListResponse{
+		Sessions: sessions,
+		Total:    len(sessions),
+	}
+}
+
 // ToOAuthProviderResponse converts OAuthProvider to response DTO
 func ToOAuthProviderResponse(provider *auth.OAuthProvider) *response.OAuthProviderResponse {
 	if provider == nil {
@@ -1,7 +1,13 @@
 package mapper
 
 import (
+	"encoding/json"
+
+	"keerja-backend/internal/domain/apikey"
 	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/domain/talentpool"
+	"keerja-backend/internal/domain/webhook"
 	"keerja-backend/internal/dto/request"
 	"keerja-backend/internal/dto/response"
 )
@@ -29,6 +35,8 @@ func ToCompanyResponse(c *company.Company) *response.CompanyResponse {
 		Country:      c.Country,
 		LogoURL:      PtrToString(c.LogoURL),
 		BannerURL:    PtrToString(c.BannerURL),
+		LogoURLs:     c.LogoVariants,
+		BannerURLs:   c.BannerVariants,
 		About:        PtrToString(c.About),
 		Verified:     c.Verified,
 		VerifiedAt:   c.VerifiedAt,
@@ -92,11 +100,14 @@ func ToCompanyDetailResponse(c *company.Company) *response.CompanyDetailResponse
 		Longitude:  c.Longitude,
 		LogoURL:    PtrToString(c.LogoURL),
 		BannerURL:  PtrToString(c.BannerURL),
+		LogoURLs:   c.LogoVariants,
+		BannerURLs: c.BannerVariants,
 		Culture:    PtrToString(c.Culture),
 		Benefits:   []string(c.Benefits), // Convert PostgreSQL array to Go slice
 		Verified:   c.Verified,
 		VerifiedAt: c.VerifiedAt,
 		IsActive:   c.IsActive,
+		Version:    c.Version,
 		CreatedAt:  c.CreatedAt,
 		UpdatedAt:  c.UpdatedAt,
 	}
@@ -201,6 +212,7 @@ func ToCompanyProfileResponse(p *company.CompanyProfile) *response.CompanyProfil
 		Description: PtrToString(p.LongDescription),
 		Mission:     PtrToString(p.Mission),
 		Vision:      PtrToString(p.Vision),
+		Version:     p.Version,
 		UpdatedAt:   p.UpdatedAt,
 		// Note: Other fields need to be mapped manually or use entity fields differently
 	}
@@ -215,10 +227,114 @@ func ToCompanyReviewResponse(r *company.CompanyReview) *response.CompanyReviewRe
 
 	// Basic mapping - handlers should fill in remaining fields from related data
 	return &response.CompanyReviewResponse{
-		ID:          r.ID,
-		IsAnonymous: r.IsAnonymous,
-		CreatedAt:   r.CreatedAt,
-		UpdatedAt:   r.UpdatedAt,
+		ID:                 r.ID,
+		IsAnonymous:        r.IsAnonymous,
+		HelpfulCount:       r.HelpfulCount,
+		NotHelpfulCount:    r.NotHelpfulCount,
+		MyVote:             r.MyVote,
+		IsVerifiedReviewer: r.IsVerifiedReviewer,
+		Response:           ToReviewReplyResponse(r.Response),
+		CreatedAt:          r.CreatedAt,
+		UpdatedAt:          r.UpdatedAt,
+	}
+}
+
+// ToReviewReplyResponse maps a company's ReviewResponse entity to ReviewReplyResponse DTO
+func ToReviewReplyResponse(r *company.ReviewResponse) *response.ReviewReplyResponse {
+	if r == nil {
+		return nil
+	}
+
+	return &response.ReviewReplyResponse{
+		ID:        r.ID,
+		ReviewID:  r.ReviewID,
+		Body:      r.Body,
+		EditedAt:  r.EditedAt,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+// ToPublicCompanyProfileResponse maps PublicCompanyProfile to PublicCompanyProfileResponse DTO.
+// Verification document numbers are left out of the public page.
+func ToPublicCompanyProfileResponse(p *company.PublicCompanyProfile) *response.PublicCompanyProfileResponse {
+	if p == nil {
+		return nil
+	}
+
+	comp := ToCompanyDetailResponse(p.Company)
+	comp.NPWPNumber = ""
+	comp.NIBNumber = ""
+	comp.FollowersCount = p.FollowerCount
+	comp.JobsCount = p.JobsTotal
+	if p.IsFollowing != nil {
+		comp.IsFollowing = *p.IsFollowing
+	}
+
+	resp := &response.PublicCompanyProfileResponse{
+		Company:       comp,
+		Jobs:          make([]response.JobResponse, 0, len(p.Jobs)),
+		FollowerCount: p.FollowerCount,
+		IsFollowing:   p.IsFollowing,
+	}
+	for i := range p.Jobs {
+		resp.Jobs = append(resp.Jobs, *ToJobResponse(&p.Jobs[i]))
+	}
+
+	resp.ReviewSummary.TopReviews = make([]response.CompanyReviewResponse, 0, len(p.TopReviews))
+	for i := range p.TopReviews {
+		resp.ReviewSummary.TopReviews = append(resp.ReviewSummary.TopReviews, *ToCompanyReviewResponse(&p.TopReviews[i]))
+	}
+	if r := p.Ratings; r != nil {
+		resp.ReviewSummary.Overall = r.Overall
+		resp.ReviewSummary.Culture = r.Culture
+		resp.ReviewSummary.WorkLife = r.WorkLife
+		resp.ReviewSummary.Salary = r.Salary
+		resp.ReviewSummary.Management = r.Management
+		resp.ReviewSummary.TotalReviews = r.TotalReviews
+		comp.AverageRating = r.Overall
+		comp.ReviewsCount = r.TotalReviews
+	}
+
+	return resp
+}
+
+// ToCompanySettingsResponse maps CompanySettings entity to CompanySettingsResponse DTO
+func ToCompanySettingsResponse(s *company.CompanySettings) *response.CompanySettingsResponse {
+	if s == nil {
+		return nil
+	}
+
+	return &response.CompanySettingsResponse{
+		CompanyID:             s.CompanyID,
+		StageReminderEnabled:  s.StageReminderEnabled,
+		StageReminderDays:     s.StageReminderDays,
+		BlindScreeningEnabled: s.BlindScreeningEnabled,
+		WeeklyDigestEnabled:   s.WeeklyDigestEnabled,
+		AutoJoinEnabled:       s.AutoJoinEnabled,
+		AutoJoinRole:          s.AutoJoinRole,
+		UpdatedAt:             s.UpdatedAt,
+		ChangeRequests:        []response.CompanyChangeRequestResponse{},
+	}
+}
+
+// ToCompanyChangeRequestResponse maps CompanyChangeRequest entity to CompanyChangeRequestResponse DTO
+func ToCompanyChangeRequestResponse(r *company.CompanyChangeRequest) *response.CompanyChangeRequestResponse {
+	if r == nil {
+		return nil
+	}
+
+	return &response.CompanyChangeRequestResponse{
+		ID:            r.ID,
+		CompanyID:     r.CompanyID,
+		IndustryID:    r.IndustryID,
+		CompanySizeID: r.CompanySizeID,
+		DistrictID:    r.DistrictID,
+		Reason:        r.Reason,
+		Status:        r.Status,
+		DecisionNote:  r.DecisionNote,
+		DecidedAt:     r.DecidedAt,
+		CreatedAt:     r.CreatedAt,
 	}
 }
 
@@ -277,6 +393,23 @@ func ToCompanyFollowerResponse(f *company.CompanyFollower) *response.CompanyFoll
 	}
 }
 
+// ToCompanyAPIKeyResponse maps CompanyAPIKey entity to CompanyAPIKeyResponse DTO
+func ToCompanyAPIKeyResponse(k *apikey.CompanyAPIKey) *response.CompanyAPIKeyResponse {
+	if k == nil {
+		return nil
+	}
+
+	return &response.CompanyAPIKeyResponse{
+		ID:         k.ID,
+		Name:       k.Name,
+		KeyPrefix:  k.KeyPrefix,
+		Scopes:     k.Scopes,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt,
+	}
+}
+
 // Request DTO to Entity Mappers
 
 // RegisterCompanyRequestToEntity converts RegisterCompanyRequest to Company entity
@@ -367,3 +500,109 @@ func UpdateCompanyProfileRequestToEntity(req *request.UpdateCompanyProfileReques
 	}
 	// Note: Additional fields should be mapped based on actual request structure
 }
+
+// ToCompanyWebhookResponse converts a company webhook to response DTO
+func ToCompanyWebhookResponse(w *webhook.CompanyWebhook) *response.CompanyWebhookResponse {
+	if w == nil {
+		return nil
+	}
+
+	return &response.CompanyWebhookResponse{
+		ID:           w.ID,
+		URL:          w.URL,
+		Events:       w.Events,
+		Active:       w.Active,
+		FailureCount: w.FailureCount,
+		DisabledAt:   w.DisabledAt,
+		CreatedAt:    w.CreatedAt,
+		UpdatedAt:    w.UpdatedAt,
+	}
+}
+
+// ToCompanyEmailTemplateResponse converts a company email template to response DTO
+func ToCompanyEmailTemplateResponse(t *email.CompanyEmailTemplate) *response.CompanyEmailTemplateResponse {
+	if t == nil {
+		return nil
+	}
+
+	return &response.CompanyEmailTemplateResponse{
+		ID:        t.ID,
+		EventType: t.EventType,
+		Subject:   t.Subject,
+		Body:      t.Body,
+		UpdatedBy: t.UpdatedBy,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+// ToTalentPoolResponse converts a talent pool to response DTO
+func ToTalentPoolResponse(p *talentpool.TalentPool) *response.TalentPoolResponse {
+	if p == nil {
+		return nil
+	}
+
+	return &response.TalentPoolResponse{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		MemberCount: p.MemberCount,
+		CreatedBy:   p.CreatedBy,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
+
+// ToTalentPoolMemberResponse converts a talent pool member to response DTO
+func ToTalentPoolMemberResponse(m *talentpool.TalentPoolMember) response.TalentPoolMemberResponse {
+	tags := []string(m.Tags)
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return response.TalentPoolMemberResponse{
+		ID:                  m.ID,
+		PoolID:              m.PoolID,
+		UserID:              m.UserID,
+		CandidateName:       m.CandidateName,
+		Headline:            m.Headline,
+		SourceApplicationID: m.SourceApplicationID,
+		Tags:                tags,
+		Notes:               m.Notes,
+		AddedBy:             m.AddedBy,
+		CreatedAt:           m.CreatedAt,
+	}
+}
+
+// ToTalentPoolMatchResponse converts a scored talent pool candidate to response DTO
+func ToTalentPoolMatchResponse(match *talentpool.PoolMatch) response.TalentPoolMatchResponse {
+	return response.TalentPoolMatchResponse{
+		Member:          ToTalentPoolMemberResponse(&match.Member),
+		OverallScore:    match.Score.OverallScore,
+		SkillScore:      match.Score.SkillScore,
+		ExperienceScore: match.Score.ExperienceScore,
+		EducationScore:  match.Score.EducationScore,
+		LocationScore:   match.Score.LocationScore,
+		MatchedSkills:   match.Score.MatchedSkills,
+		MissingSkills:   match.Score.MissingSkills,
+	}
+}
+
+// ToWebhookDeliveryResponse converts a webhook delivery to response DTO
+func ToWebhookDeliveryResponse(d *webhook.WebhookDelivery) response.WebhookDeliveryResponse {
+	return response.WebhookDeliveryResponse{
+		ID:            d.ID,
+		WebhookID:     d.WebhookID,
+		CompanyID:     d.CompanyID,
+		EventID:       d.EventID,
+		Event:         d.Event,
+		Payload:       json.RawMessage(d.Payload),
+		Status:        d.Status,
+		Attempts:      d.Attempts,
+		NextAttemptAt: d.NextAttemptAt,
+		ResponseCode:  d.ResponseCode,
+		LastError:     d.LastError,
+		DeliveredAt:   d.DeliveredAt,
+		CreatedAt:     d.CreatedAt,
+	}
+}
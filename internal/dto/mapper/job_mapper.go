@@ -1,6 +1,9 @@
 package mapper
 
 import (
+	"encoding/json"
+	"time"
+
 	"keerja-backend/internal/domain/company"
 	"keerja-backend/internal/domain/job"
 	"keerja-backend/internal/dto/response"
@@ -15,9 +18,8 @@ func ToJobResponse(j *job.Job) *response.JobResponse {
 	}
 
 	var daysRemaining *int
-	if j.ExpiredAt != nil && j.IsActive() {
-		days := int(j.ExpiredAt.Sub(j.CreatedAt).Hours() / 24)
-		daysRemaining = &days
+	if j.IsActive() {
+		daysRemaining = j.DaysUntilExpiry(time.Now())
 	}
 
 	resp := &response.JobResponse{
@@ -37,9 +39,12 @@ func ToJobResponse(j *job.Job) *response.JobResponse {
 		ApplicationsCount: j.ApplicationsCount,
 		PublishedAt:       j.PublishedAt,
 		ExpiredAt:         j.ExpiredAt,
+		SubmittedAt:       j.SubmittedAt,
 		CreatedAt:         j.CreatedAt,
 		IsExpired:         j.IsExpired(),
 		DaysRemaining:     daysRemaining,
+		DistanceKm:        j.DistanceKm,
+		IsFeatured:        j.IsFeaturedAt(time.Now()),
 	}
 
 	// Populate City and Province from CompanyAddress if available
@@ -161,9 +166,8 @@ func ToJobDetailResponse(j *job.Job) *response.JobDetailResponse {
 	}
 
 	var daysRemaining *int
-	if j.ExpiredAt != nil && j.IsActive() {
-		days := int(j.ExpiredAt.Sub(j.CreatedAt).Hours() / 24)
-		daysRemaining = &days
+	if j.IsActive() {
+		daysRemaining = j.DaysUntilExpiry(time.Now())
 	}
 
 	resp := &response.JobDetailResponse{
@@ -187,10 +191,15 @@ func ToJobDetailResponse(j *job.Job) *response.JobDetailResponse {
 		ApplicationsCount: j.ApplicationsCount,
 		PublishedAt:       j.PublishedAt,
 		ExpiredAt:         j.ExpiredAt,
+		SubmittedAt:       j.SubmittedAt,
+		Version:           j.Version,
 		CreatedAt:         j.CreatedAt,
 		UpdatedAt:         j.UpdatedAt,
 		IsExpired:         j.IsExpired(),
 		DaysRemaining:     daysRemaining,
+		IsFeatured:        j.IsFeatured,
+		FeaturedFrom:      j.FeaturedFrom,
+		FeaturedUntil:     j.FeaturedUntil,
 	}
 
 	// Category/Subcategory objects are populated below (no numeric IDs in response)
@@ -520,6 +529,108 @@ func ToJobRequirementResponse(r *job.JobRequirement) *response.JobRequirementRes
 	}
 }
 
+// ToJobReviewResponse converts a job review entity to response DTO
+func ToJobReviewResponse(r *job.JobReview) *response.JobReviewResponse {
+	if r == nil {
+		return nil
+	}
+
+	resp := &response.JobReviewResponse{
+		Action:     r.Action,
+		ReviewedAt: r.CreatedAt,
+	}
+	if r.Reason != nil {
+		resp.Reason = *r.Reason
+	}
+	return resp
+}
+
+// ToJobDraftResponse converts a draft and its missing fields to response
+func ToJobDraftResponse(d *job.JobDraft) *response.JobDraftResponse {
+	if d == nil {
+		return nil
+	}
+
+	missing := d.MissingFields
+	if missing == nil {
+		missing = []string{}
+	}
+
+	return &response.JobDraftResponse{
+		JobResponse:   *ToJobResponse(&d.Job),
+		IsComplete:    len(missing) == 0,
+		MissingFields: missing,
+	}
+}
+
+// ToJobDraftRevisionResponse converts a draft revision to response
+func ToJobDraftRevisionResponse(r *job.JobDraftRevision) *response.JobDraftRevisionResponse {
+	if r == nil {
+		return nil
+	}
+
+	return &response.JobDraftRevisionResponse{
+		ID:        r.ID,
+		Payload:   json.RawMessage(r.Payload),
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+// ToJobQuestionResponse converts a screening question to the employer's response, including knockout settings
+func ToJobQuestionResponse(q *job.JobQuestion) *response.JobQuestionResponse {
+	resp := ToPublicJobQuestionResponse(q)
+	if resp == nil {
+		return nil
+	}
+
+	resp.IsKnockout = &q.IsKnockout
+	if q.IsKnockout {
+		resp.KnockoutValue = &q.KnockoutValue
+	}
+	return resp
+}
+
+// ToPublicJobQuestionResponse converts a screening question to the applicant's response, hiding knockout settings
+func ToPublicJobQuestionResponse(q *job.JobQuestion) *response.JobQuestionResponse {
+	if q == nil {
+		return nil
+	}
+
+	return &response.JobQuestionResponse{
+		ID:           q.ID,
+		QuestionText: q.QuestionText,
+		Type:         q.QuestionType,
+		Options:      q.Options,
+		IsRequired:   q.IsRequired || q.IsKnockout,
+		SortOrder:    q.SortOrder,
+	}
+}
+
+// ToSearchFacetsResponse converts job search facets to response
+func ToSearchFacetsResponse(f *job.SearchFacets) *response.SearchFacetsResponse {
+	if f == nil {
+		return nil
+	}
+
+	return &response.SearchFacetsResponse{
+		JobTypes:     toFacetItemResponses(f.JobTypes),
+		WorkPolicies: toFacetItemResponses(f.WorkPolicies),
+		Cities:       toFacetItemResponses(f.Cities),
+	}
+}
+
+func toFacetItemResponses(items []job.FacetItem) []response.FacetItemResponse {
+	result := make([]response.FacetItemResponse, 0, len(items))
+	for _, item := range items {
+		result = append(result, response.FacetItemResponse{
+			ID:    item.ID,
+			Value: item.Value,
+			Count: item.Count,
+		})
+	}
+	return result
+}
+
 // ToJobCategoryResponse maps JobCategory entity to JobCategoryResponse DTO
 func ToJobCategoryResponse(c *job.JobCategory) *response.JobCategoryResponse {
 	if c == nil {
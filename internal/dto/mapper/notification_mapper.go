@@ -122,10 +122,16 @@ func ToSendNotificationRequest(req *request.SendNotificationRequest) *notificati
 
 // ToNotificationFilter converts request filter to domain filter
 func ToNotificationFilter(req *request.NotificationFilterRequest) notification.NotificationFilter {
+	isRead := req.IsRead
+	if req.Unread {
+		unread := false
+		isRead = &unread
+	}
+
 	return notification.NotificationFilter{
 		Type:     req.Type,
 		Category: req.Category,
-		IsRead:   req.IsRead,
+		IsRead:   isRead,
 		Priority: req.Priority,
 		DateFrom: req.DateFrom,
 		DateTo:   req.DateTo,
@@ -13,16 +13,17 @@ func ToUserResponse(u *user.User) *response.UserResponse {
 	}
 
 	resp := &response.UserResponse{
-		ID:         u.ID,
-		UUID:       u.UUID.String(),
-		FullName:   u.FullName,
-		Email:      u.Email,
-		Phone:      PtrToString(u.Phone),
-		UserType:   u.UserType,
-		IsVerified: u.IsVerified,
-		Status:     u.Status,
-		LastLogin:  u.LastLogin,
-		CreatedAt:  u.CreatedAt,
+		ID:            u.ID,
+		UUID:          u.UUID.String(),
+		FullName:      u.FullName,
+		Email:         u.Email,
+		Phone:         PtrToString(u.Phone),
+		PhoneVerified: u.IsPhoneVerified(),
+		UserType:      u.UserType,
+		IsVerified:    u.IsEmailVerified(),
+		Status:        u.Status,
+		LastLogin:     u.LastLogin,
+		CreatedAt:     u.CreatedAt,
 	}
 
 	// Map profile if exists
@@ -33,6 +34,17 @@ func ToUserResponse(u *user.User) *response.UserResponse {
 	return resp
 }
 
+// ToEmailVerificationResponse converts an email verification state to EmailVerificationResponse DTO
+func ToEmailVerificationResponse(state user.EmailVerificationState) *response.EmailVerificationResponse {
+	return &response.EmailVerificationResponse{
+		Verified:      state.Verified,
+		VerifiedAt:    state.VerifiedAt,
+		GraceEndsAt:   state.GraceEndsAt,
+		DaysRemaining: state.DaysRemaining,
+		Required:      state.Required,
+	}
+}
+
 // ToUserDetailResponse converts User entity with relations to UserDetailResponse DTO
 func ToUserDetailResponse(u *user.User) *response.UserDetailResponse {
 	if u == nil {
@@ -40,16 +52,17 @@ func ToUserDetailResponse(u *user.User) *response.UserDetailResponse {
 	}
 
 	resp := &response.UserDetailResponse{
-		ID:         u.ID,
-		UUID:       u.UUID.String(),
-		FullName:   u.FullName,
-		Email:      u.Email,
-		Phone:      PtrToString(u.Phone),
-		UserType:   u.UserType,
-		IsVerified: u.IsVerified,
-		Status:     u.Status,
-		LastLogin:  u.LastLogin,
-		CreatedAt:  u.CreatedAt,
+		ID:            u.ID,
+		UUID:          u.UUID.String(),
+		FullName:      u.FullName,
+		Email:         u.Email,
+		Phone:         PtrToString(u.Phone),
+		PhoneVerified: u.IsPhoneVerified(),
+		UserType:      u.UserType,
+		IsVerified:    u.IsEmailVerified(),
+		Status:        u.Status,
+		LastLogin:     u.LastLogin,
+		CreatedAt:     u.CreatedAt,
 	}
 
 	// Map profile
@@ -149,16 +162,17 @@ func ToUserResponseWithIncludes(u *user.User, includes []string) *response.UserD
 	}
 
 	resp := &response.UserDetailResponse{
-		ID:         u.ID,
-		UUID:       u.UUID.String(),
-		FullName:   u.FullName,
-		Email:      u.Email,
-		Phone:      PtrToString(u.Phone),
-		UserType:   u.UserType,
-		IsVerified: u.IsVerified,
-		Status:     u.Status,
-		LastLogin:  u.LastLogin,
-		CreatedAt:  u.CreatedAt,
+		ID:            u.ID,
+		UUID:          u.UUID.String(),
+		FullName:      u.FullName,
+		Email:         u.Email,
+		Phone:         PtrToString(u.Phone),
+		PhoneVerified: u.IsPhoneVerified(),
+		UserType:      u.UserType,
+		IsVerified:    u.IsEmailVerified(),
+		Status:        u.Status,
+		LastLogin:     u.LastLogin,
+		CreatedAt:     u.CreatedAt,
 	}
 
 	// Always include basic profile (it's lightweight)
@@ -276,6 +290,8 @@ func ToUserProfileResponse(p *user.UserProfile) *response.UserProfileResponse {
 		Slug:               p.Slug,
 		AvatarURL:          p.AvatarURL,
 		CoverURL:           p.CoverURL,
+		AvatarURLs:         p.AvatarVariants,
+		CoverURLs:          p.CoverVariants,
 		CreatedAt:          p.CreatedAt,
 		UpdatedAt:          p.UpdatedAt,
 	}
@@ -459,6 +475,54 @@ func ToUserDocumentResponse(d *user.UserDocument) *response.UserDocumentResponse
 	}
 }
 
+// ToParsedResumeResponse converts parsed resume suggestions to their response DTO
+func ToParsedResumeResponse(p *user.ParsedResume) *response.ParsedResumeResponse {
+	if p == nil {
+		return nil
+	}
+
+	skills := make([]response.ParsedResumeSkillResponse, 0, len(p.Skills))
+	for _, skill := range p.Skills {
+		skills = append(skills, response.ParsedResumeSkillResponse{SkillID: skill.SkillID, Name: skill.Name})
+	}
+
+	degreeLevels := p.DegreeLevels
+	if degreeLevels == nil {
+		degreeLevels = []string{}
+	}
+
+	return &response.ParsedResumeResponse{
+		Email:        p.Email,
+		Phone:        p.Phone,
+		LinkedInURL:  p.LinkedInURL,
+		Skills:       skills,
+		DegreeLevels: degreeLevels,
+	}
+}
+
+// ToNotificationSettingsResponse converts the notification settings of a UserPreference to
+// NotificationSettingsResponse DTO, listing the channels of every event
+func ToNotificationSettingsResponse(p *user.UserPreference) *response.NotificationSettingsResponse {
+	if p == nil {
+		return nil
+	}
+
+	events := make(map[string]response.NotificationChannelsResponse, len(user.NotificationEvents))
+	for _, event := range user.NotificationEvents {
+		channels := p.NotificationChannelsFor(event)
+		events[string(event)] = response.NotificationChannelsResponse{Email: channels.Email, Push: channels.Push, WhatsApp: channels.WhatsApp}
+	}
+
+	return &response.NotificationSettingsResponse{
+		EmailNotifications: p.EmailNotifications,
+		PushNotifications:  p.PushNotifications,
+		Events:             events,
+		QuietHoursStart:    p.QuietHoursStart,
+		QuietHoursEnd:      p.QuietHoursEnd,
+		QuietHoursTimezone: p.QuietHoursTimezone,
+	}
+}
+
 // ToUserPreferenceResponse converts UserPreference entity to UserPreferenceResponse DTO
 func ToUserPreferenceResponse(p *user.UserPreference) *response.UserPreferenceResponse {
 	if p == nil {
@@ -510,3 +574,25 @@ func ToUserPreferenceResponse(p *user.UserPreference) *response.UserPreferenceRe
 		UpdatedAt:          p.UpdatedAt,
 	}
 }
+
+// ToProfileCompletenessResponse converts profile completeness to response DTO
+func ToProfileCompletenessResponse(c *user.ProfileCompleteness) *response.ProfileCompletenessResponse {
+	items := make([]response.ProfileCompletenessItemResponse, 0, len(c.Items))
+	for _, item := range c.Items {
+		resp := response.ProfileCompletenessItemResponse{
+			Key:       item.Key,
+			Weight:    item.Weight,
+			Completed: item.Completed,
+		}
+		if !item.Completed {
+			resp.NextAction = item.NextAction
+		}
+		items = append(items, resp)
+	}
+
+	return &response.ProfileCompletenessResponse{
+		Score:       c.Score,
+		Items:       items,
+		NextActions: c.NextActions,
+	}
+}
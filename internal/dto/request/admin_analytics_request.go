@@ -0,0 +1,13 @@
+package request
+
+// AdminAnalyticsSummaryRequest represents query parameters for the admin dashboard summary
+type AdminAnalyticsSummaryRequest struct {
+	// Period ending today, ignored when from is set
+	Period string `query:"period" validate:"omitempty,oneof=7d 30d 90d"`
+
+	// Custom date range, inclusive
+	From string `query:"from"` // Format: 2024-01-01
+	To   string `query:"to"`   // Format: 2024-12-31
+
+	Interval string `query:"interval" validate:"omitempty,oneof=day week month"`
+}
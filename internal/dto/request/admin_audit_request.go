@@ -0,0 +1,19 @@
+package request
+
+// AdminGetAuditLogsRequest represents query parameters for the admin audit log
+type AdminGetAuditLogsRequest struct {
+	// Pagination
+	Page  int `query:"page" validate:"omitempty,min=1"`
+	Limit int `query:"limit" validate:"omitempty,min=1,max=100"`
+
+	// Filters
+	ActorType  string `query:"actor_type" validate:"omitempty,oneof=admin employer user system"`
+	ActorID    *int64 `query:"actor_id"`
+	EntityType string `query:"entity_type"`
+	EntityID   *int64 `query:"entity_id"`
+	Action     string `query:"action"`
+
+	// Date range
+	CreatedFrom string `query:"created_from"` // Format: 2024-01-01
+	CreatedTo   string `query:"created_to"`   // Format: 2024-12-31
+}
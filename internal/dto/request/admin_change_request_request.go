@@ -0,0 +1,15 @@
+package request
+
+// AdminGetChangeRequestsRequest represents query parameters for the admin company change request queue
+type AdminGetChangeRequestsRequest struct {
+	// Pagination
+	Page  int `query:"page" validate:"omitempty,min=1"`
+	Limit int `query:"limit" validate:"omitempty,min=1,max=100"`
+
+	Status string `query:"status" validate:"omitempty,oneof=pending approved rejected"` // Default: pending
+}
+
+// AdminDecideChangeRequestRequest represents an admin's approval or rejection of a change request
+type AdminDecideChangeRequestRequest struct {
+	Note *string `json:"note" validate:"omitempty,max=1000"`
+}
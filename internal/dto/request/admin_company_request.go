@@ -7,25 +7,28 @@ type AdminGetCompaniesRequest struct {
 	Limit int `query:"limit" validate:"omitempty,min=1,max=100"`
 
 	// Search
-	Search string `query:"search"` // Search by company name, email, legal name
+	Search string `query:"search"` // Search by company name, legal name
 
 	// Filters
-	Status             string `query:"status"`              // pending_verification, verified, rejected, suspended
-	VerificationStatus string `query:"verification_status"` // For company_verifications.status
+	VerificationStatus string `query:"verification_status" validate:"omitempty,oneof=pending approved rejected expired"`
+	DocumentStatus     string `query:"document_status" validate:"omitempty,oneof=pending approved rejected expired"` // Has a document with this status
+	DocumentType       string `query:"document_type" validate:"omitempty,oneof=SIUP NPWP NIB AKTA TDP ISO SERTIFIKAT LAINNYA"`
+	HasPublishedJobs   *bool  `query:"has_published_jobs"`
 	IndustryID         *int64 `query:"industry_id"`
 	CompanySizeID      *int64 `query:"company_size_id"`
 	ProvinceID         *int64 `query:"province_id"`
 	CityID             *int64 `query:"city_id"`
 	Verified           *bool  `query:"verified"`  // true/false
 	IsActive           *bool  `query:"is_active"` // true/false
+	Deleted            bool   `query:"deleted"`   // true lists soft-deleted companies
 
 	// Date range
 	CreatedFrom string `query:"created_from"` // Format: 2024-01-01
-	CreatedTo   string `query:"created_to"`   // Format: 2024-12-31
+	CreatedTo   string `query:"created_to"`   // Format: 2024-12-31, inclusive
 
 	// Sorting
-	SortBy    string `query:"sort_by" validate:"omitempty,oneof=company_name created_at verified_at updated_at"` // Default: created_at
-	SortOrder string `query:"sort_order" validate:"omitempty,oneof=asc desc"`                                    // Default: desc
+	SortBy    string `query:"sort_by" validate:"omitempty,oneof=company_name created_at verified_at updated_at job_count follower_count"` // Default: created_at
+	SortOrder string `query:"sort_order" validate:"omitempty,oneof=asc desc"`                                                             // Default: desc
 }
 
 // AdminUpdateCompanyStatusRequest represents the request for updating company verification status
@@ -0,0 +1,8 @@
+package request
+
+// AdminGetEmailQueueRequest represents query parameters for the admin email queue
+type AdminGetEmailQueueRequest struct {
+	Page   int    `query:"page" validate:"omitempty,min=1"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Status string `query:"status" validate:"omitempty,oneof=pending sent dead"` // Defaults to dead
+}
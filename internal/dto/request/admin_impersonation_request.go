@@ -0,0 +1,6 @@
+package request
+
+// StartImpersonationRequest represents the request to impersonate a user
+type StartImpersonationRequest struct {
+	Reason string `json:"reason" validate:"required,min=5,max=500"` // Why the admin needs to see the user's view, e.g. a support ticket
+}
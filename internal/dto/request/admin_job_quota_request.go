@@ -0,0 +1,12 @@
+package request
+
+// UpdatePlanQuotaRequest represents the request to override a plan's published job limit
+type UpdatePlanQuotaRequest struct {
+	MaxPublishedJobs int `json:"max_published_jobs" validate:"required,min=1"`
+}
+
+// SetCompanyPlanRequest represents the request to move a company to a plan. An empty plan
+// derives it from the company's verification again.
+type SetCompanyPlanRequest struct {
+	Plan string `json:"plan" validate:"omitempty,oneof=free verified premium"`
+}
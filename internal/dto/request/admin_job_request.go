@@ -0,0 +1,15 @@
+package request
+
+// AdminGetPendingJobsRequest represents query parameters for the admin job review queue
+type AdminGetPendingJobsRequest struct {
+	// Pagination
+	Page  int `query:"page" validate:"omitempty,min=1"`
+	Limit int `query:"limit" validate:"omitempty,min=1,max=100"`
+
+	// Filters
+	CompanyID *int64 `query:"company_id"`
+
+	// Date range
+	SubmittedFrom string `query:"submitted_from"` // Format: 2024-01-01
+	SubmittedTo   string `query:"submitted_to"`   // Format: 2024-12-31
+}
@@ -0,0 +1,20 @@
+package request
+
+import "time"
+
+// CreatePushCampaignRequest represents an admin push campaign; set either topic or filter
+type CreatePushCampaignRequest struct {
+	Topic       string                     `json:"topic" validate:"omitempty,max=900"`
+	Filter      *PushCampaignFilterRequest `json:"filter"`
+	Title       string                     `json:"title" validate:"required,max=255"`
+	Body        string                     `json:"body" validate:"required,max=1000"`
+	Data        map[string]string          `json:"data"`
+	ScheduledAt *time.Time                 `json:"scheduled_at"` // Optional: RFC 3339, sent right away when omitted
+}
+
+// PushCampaignFilterRequest selects campaign recipients by user attributes
+type PushCampaignFilterRequest struct {
+	Role              *string `json:"role" validate:"omitempty,oneof=jobseeker employer"`
+	ProvinceID        *int64  `json:"province_id" validate:"omitempty,min=1"`
+	FollowedCompanyID *int64  `json:"followed_company_id" validate:"omitempty,min=1"`
+}
@@ -0,0 +1,17 @@
+package request
+
+// AdminGetReviewsRequest represents query parameters for the admin review moderation queue
+type AdminGetReviewsRequest struct {
+	// Pagination
+	Page  int `query:"page" validate:"omitempty,min=1"`
+	Limit int `query:"limit" validate:"omitempty,min=1,max=100"`
+
+	// Filters
+	Status       string `query:"status" validate:"omitempty,oneof=pending approved rejected hidden"` // Default: pending
+	CompanyID    *int64 `query:"company_id"`
+	ReviewerType string `query:"reviewer_type" validate:"omitempty,oneof=employee ex-employee applicant"`
+
+	// Date range
+	CreatedFrom string `query:"created_from"` // Format: 2024-01-01
+	CreatedTo   string `query:"created_to"`   // Format: 2024-12-31
+}
@@ -0,0 +1,6 @@
+package request
+
+// AssignAdminRoleRequest represents the request to give an admin user a role
+type AssignAdminRoleRequest struct {
+	RoleID int64 `json:"role_id" validate:"required,min=1"`
+}
@@ -0,0 +1,11 @@
+package request
+
+// AdminGetWebhookDeliveriesRequest represents query parameters for the admin webhook delivery list
+type AdminGetWebhookDeliveriesRequest struct {
+	Page      int    `query:"page" validate:"omitempty,min=1"`
+	Limit     int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	CompanyID int64  `query:"company_id" validate:"omitempty,min=1"`
+	WebhookID int64  `query:"webhook_id" validate:"omitempty,min=1"`
+	Status    string `query:"status" validate:"omitempty,oneof=pending delivered failed"`
+	Event     string `query:"event" validate:"omitempty,max=50"`
+}
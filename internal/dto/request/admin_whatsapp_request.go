@@ -0,0 +1,9 @@
+package request
+
+// AdminGetWhatsAppDeliveriesRequest represents query parameters for the admin WhatsApp delivery list
+type AdminGetWhatsAppDeliveriesRequest struct {
+	Page   int    `query:"page" validate:"omitempty,min=1"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	UserID int64  `query:"user_id" validate:"omitempty,min=1"`
+	Status string `query:"status" validate:"omitempty,oneof=sent failed template_rejected"`
+}
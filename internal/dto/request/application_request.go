@@ -12,6 +12,10 @@ type ApplyJobRequest struct {
 	CoverLetter string                       `json:"cover_letter" validate:"max=2000"`
 	Source      string                       `json:"source" validate:"omitempty,max=100"`
 	Documents   []ApplicationDocumentRequest `json:"documents" validate:"omitempty,dive"`
+	UTMSource   string                       `json:"utm_source" validate:"omitempty,max=100"`
+	UTMMedium   string                       `json:"utm_medium" validate:"omitempty,max=100"`
+	UTMCampaign string                       `json:"utm_campaign" validate:"omitempty,max=100"`
+	Referrer    string                       `json:"referrer" validate:"omitempty,max=500"`
 }
 
 // ApplicationDocumentRequest represents document upload in application
@@ -113,7 +117,7 @@ type ApplicationSearchRequest struct {
 	JobID           *int64     `json:"job_id" validate:"omitempty"`
 	CompanyID       *int64     `json:"company_id" validate:"omitempty"`
 	UserID          *int64     `json:"user_id" validate:"omitempty"`
-	Status          string     `json:"status" validate:"omitempty,oneof=pending screening shortlisted interview offered rejected withdrawn"`
+	Status          string     `json:"status" validate:"omitempty,oneof=pending screening shortlisted interview offered rejected withdrawn job_withdrawn"`
 	AppliedFrom     *time.Time `json:"applied_from" validate:"omitempty"`
 	AppliedTo       *time.Time `json:"applied_to" validate:"omitempty,gtefield=AppliedFrom"`
 	MinSalary       *float64   `json:"min_salary" validate:"omitempty,gt=0"`
@@ -129,7 +133,7 @@ type ApplicationSearchRequest struct {
 type ApplicationFilterRequest struct {
 	JobID         *int64   `json:"job_id" validate:"omitempty"`
 	CompanyID     *int64   `json:"company_id" validate:"omitempty"`
-	Status        string   `json:"status" validate:"omitempty,oneof=pending screening shortlisted interview offered rejected withdrawn"`
+	Status        string   `json:"status" validate:"omitempty,oneof=pending screening shortlisted interview offered rejected withdrawn job_withdrawn"`
 	IsViewed      *bool    `json:"is_viewed"`
 	HasNotes      *bool    `json:"has_notes"`
 	HasInterview  *bool    `json:"has_interview"`
@@ -153,3 +157,13 @@ type RateApplicationExperienceRequest struct {
 	Rating   int16  `json:"rating" validate:"required,min=1,max=5"`
 	Feedback string `json:"feedback" validate:"max=1000"`
 }
+
+// SourceAnalyticsRequest represents the query of application source analytics
+type SourceAnalyticsRequest struct {
+	// Date range, inclusive; defaults to the last 30 days
+	From string `query:"from"` // Format: 2024-01-01
+	To   string `query:"to"`   // Format: 2024-12-31
+
+	// Only count views of and applications to this job
+	JobID int64 `query:"job_id" validate:"omitempty,min=1"`
+}
@@ -7,6 +7,9 @@ type RegisterRequest struct {
 	Phone    string `json:"phone" validate:"omitempty,min=10,max=20"`
 	Password string `json:"password" validate:"required,min=8,max=72"`
 	UserType string `json:"user_type" validate:"required,oneof=jobseeker employer"`
+	// InvitationToken joins the new account to the company that invited it; the email must be
+	// the invited one and the account is created as an employer
+	InvitationToken string `json:"invitation_token" validate:"omitempty,max=64"`
 }
 
 // LoginRequest represents user login request
@@ -37,6 +40,23 @@ type ChangePasswordRequest struct {
 	NewPassword string `json:"new_password" validate:"required,min=8,max=72"`
 }
 
+// ChangeEmailRequest represents a request to change the account email
+type ChangeEmailRequest struct {
+	NewEmail string `json:"new_email" validate:"required,email,max=150"`
+	Password string `json:"password" validate:"required"`
+}
+
+// VerifyEmailChangeRequest represents email change OTP verification request
+type VerifyEmailChangeRequest struct {
+	NewEmail string `json:"new_email" validate:"required,email,max=150"`
+	OTPCode  string `json:"otp_code" validate:"required,len=6,numeric"`
+}
+
+// VerifyPhoneOTPRequest represents phone verification OTP request
+type VerifyPhoneOTPRequest struct {
+	OTPCode string `json:"otp_code" validate:"required,len=6,numeric"`
+}
+
 // RefreshTokenRequest represents refresh token request
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" validate:"required"`
@@ -91,6 +111,9 @@ type RegisterWithOTPRequest struct {
 	Phone    string `json:"phone" validate:"omitempty,min=10,max=20"`
 	Password string `json:"password" validate:"required,min=8,max=72"`
 	UserType string `json:"user_type" validate:"required,oneof=jobseeker employer"`
+	// InvitationToken joins the new account to the company that invited it; the email must be
+	// the invited one and the account is created as an employer
+	InvitationToken string `json:"invitation_token" validate:"omitempty,max=64"`
 }
 
 // VerifyEmailOTPRequest represents email verification with OTP
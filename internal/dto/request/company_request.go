@@ -67,6 +67,9 @@ type UpdateCompanyRequest struct {
 	// Rich Text Descriptions
 	CompanyDescription *string `form:"company_description" json:"company_description" validate:"required"` // Deskripsi Perusahaan (required)
 	CompanyCulture     *string `form:"company_culture" json:"company_culture" validate:"omitempty"`        // Budaya Perusahaan (optional)
+
+	// Version of the company the client loaded; the If-Match header may send it instead
+	Version *int64 `form:"version" json:"version" validate:"omitempty,min=1"`
 } // UpdateCompanyProfileRequest represents company profile update request
 type UpdateCompanyProfileRequest struct {
 	FoundedYear    *int16  `json:"founded_year" validate:"omitempty,min=1800,max=2100"`
@@ -82,6 +85,7 @@ type UpdateCompanyProfileRequest struct {
 	YoutubeURL     *string `json:"youtube_url" validate:"omitempty,url"`
 	Awards         *string `json:"awards" validate:"omitempty"`
 	Certifications *string `json:"certifications" validate:"omitempty"`
+	Version        *int64  `json:"version" validate:"omitempty,min=1"` // Version of the profile the client loaded; the If-Match header may send it instead
 }
 
 // AddCompanyIndustryRequest represents add industry request
@@ -91,19 +95,20 @@ type AddCompanyIndustryRequest struct {
 
 // AddReviewRequest represents add company review request
 type AddReviewRequest struct {
-	RatingOverall      float64  `json:"rating_overall" validate:"required,min=1,max=5"`
-	RatingCulture      *float64 `json:"rating_culture" validate:"omitempty,min=1,max=5"`
-	RatingWorkLife     *float64 `json:"rating_work_life" validate:"omitempty,min=1,max=5"`
-	RatingSalary       *float64 `json:"rating_salary" validate:"omitempty,min=1,max=5"`
-	RatingManagement   *float64 `json:"rating_management" validate:"omitempty,min=1,max=5"`
-	ReviewerType       *string  `json:"reviewer_type" validate:"omitempty,max=50"`
-	PositionTitle      *string  `json:"position_title" validate:"omitempty,max=150"`
-	EmploymentPeriod   *string  `json:"employment_period" validate:"omitempty,max=50"`
-	Pros               *string  `json:"pros" validate:"omitempty"`
-	Cons               *string  `json:"cons" validate:"omitempty"`
-	AdviceToManagement *string  `json:"advice_to_management" validate:"omitempty"`
-	IsAnonymous        bool     `json:"is_anonymous"`
-	RecommendToFriend  bool     `json:"recommend_to_friend"`
+	RatingOverall    float64  `json:"rating_overall" validate:"required,min=1,max=5"`
+	RatingCulture    *float64 `json:"rating_culture" validate:"omitempty,min=1,max=5"`
+	RatingWorkLife   *float64 `json:"rating_work_life" validate:"omitempty,min=1,max=5"`
+	RatingSalary     *float64 `json:"rating_salary" validate:"omitempty,min=1,max=5"`
+	RatingManagement *float64 `json:"rating_management" validate:"omitempty,min=1,max=5"`
+	ReviewerType     *string  `json:"reviewer_type" validate:"omitempty,max=50"`
+	PositionTitle    *string  `json:"position_title" validate:"omitempty,max=150"`
+	EmploymentPeriod *string  `json:"employment_period" validate:"omitempty,max=50"`
+	// At least one of pros and cons is required, and each given one needs 20 characters
+	Pros               *string `json:"pros" validate:"required_without=Cons,omitempty,min=20,max=5000"`
+	Cons               *string `json:"cons" validate:"required_without=Pros,omitempty,min=20,max=5000"`
+	AdviceToManagement *string `json:"advice_to_management" validate:"omitempty"`
+	IsAnonymous        bool    `json:"is_anonymous"`
+	RecommendToFriend  bool    `json:"recommend_to_friend"`
 }
 
 // UpdateReviewRequest represents update review request
@@ -116,12 +121,45 @@ type UpdateReviewRequest struct {
 	RatingWorkLife     *float64 `json:"rating_work_life" validate:"omitempty,min=1,max=5"`
 	RatingSalary       *float64 `json:"rating_salary" validate:"omitempty,min=1,max=5"`
 	RatingManagement   *float64 `json:"rating_management" validate:"omitempty,min=1,max=5"`
-	Pros               *string  `json:"pros" validate:"omitempty"`
-	Cons               *string  `json:"cons" validate:"omitempty"`
+	Pros               *string  `json:"pros" validate:"omitempty,min=20,max=5000"`
+	Cons               *string  `json:"cons" validate:"omitempty,min=20,max=5000"`
 	AdviceToManagement *string  `json:"advice_to_management" validate:"omitempty"`
 	RecommendToFriend  *bool    `json:"recommend_to_friend"`
 }
 
+// UpdateCompanySettingsRequest represents update company settings request
+type UpdateCompanySettingsRequest struct {
+	StageReminderEnabled  *bool   `json:"stage_reminder_enabled"`
+	StageReminderDays     *int    `json:"stage_reminder_days" validate:"omitempty,min=1,max=90"`
+	BlindScreeningEnabled *bool   `json:"blind_screening_enabled"`
+	WeeklyDigestEnabled   *bool   `json:"weekly_digest_enabled"`
+	AutoJoinEnabled       *bool   `json:"auto_join_enabled"`
+	AutoJoinRole          *string `json:"auto_join_role" validate:"omitempty,oneof=admin recruiter viewer"`
+}
+
+// CreateCompanyChangeRequest represents a request to change the company's industry, size or district
+type CreateCompanyChangeRequest struct {
+	IndustryID    *int64 `json:"industry_id" validate:"omitempty,min=1"`
+	CompanySizeID *int64 `json:"company_size_id" validate:"omitempty,min=1"`
+	DistrictID    *int64 `json:"district_id" validate:"omitempty,min=1"`
+	Reason        string `json:"reason" validate:"required,min=10,max=1000"`
+}
+
+// VoteReviewRequest represents a helpful/not helpful vote on a review
+type VoteReviewRequest struct {
+	Vote string `json:"vote" validate:"required,oneof=helpful not_helpful"`
+}
+
+// ReportReviewRequest represents an abuse report on a review
+type ReportReviewRequest struct {
+	Reason string `json:"reason" validate:"required,min=5,max=500"`
+}
+
+// ReviewResponseRequest represents a company's response to a review
+type ReviewResponseRequest struct {
+	Body string `json:"body" validate:"required,max=2000"`
+}
+
 // InviteEmployeeRequest represents employee invitation request
 type InviteEmployeeRequest struct {
 	Email    string `json:"email" validate:"required,email"`
@@ -163,6 +201,7 @@ type CompanySearchRequest struct {
 	Limit        int    `json:"limit" query:"limit" validate:"omitempty,min=1,max=100"`
 	SortBy       string `json:"sort_by" query:"sort_by" validate:"omitempty,oneof=name created_at followers rating"`
 	SortOrder    string `json:"sort_order" query:"sort_order" validate:"omitempty,oneof=asc desc"`
+	Cursor       string `json:"cursor" query:"cursor"` // Opts into cursor pagination; Page is ignored
 }
 
 // CreateCompanyAddressRequest represents creating a reusable company address
@@ -192,4 +231,85 @@ type UpdateEmployerUserRequest struct {
 	ProvinceID    *int64  `json:"province_id" validate:"omitempty"`
 	CityID        *int64  `json:"city_id" validate:"omitempty"`
 	DistrictID    *int64  `json:"district_id" validate:"omitempty"`
+	Version       *int64  `json:"version" validate:"omitempty,min=1"` // Version of the employer profile the client loaded; the If-Match header may send it instead
+}
+
+// CreateCompanyAPIKeyRequest represents creating an API key for a company integration
+type CreateCompanyAPIKeyRequest struct {
+	Name   string   `json:"name" validate:"required,min=1,max=100"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=jobs:read applications:read"`
+}
+
+// CreateCompanyWebhookRequest represents registering a webhook endpoint for a company integration
+type CreateCompanyWebhookRequest struct {
+	URL    string   `json:"url" validate:"required,url,max=2048"`
+	Events []string `json:"events" validate:"required,min=1,dive,oneof=application.created application.status_changed interview.scheduled job.published job.expired"`
+}
+
+// UpdateCompanyWebhookRequest represents changing a company webhook; omitted fields are left as they are
+type UpdateCompanyWebhookRequest struct {
+	URL    *string  `json:"url" validate:"omitempty,url,max=2048"`
+	Events []string `json:"events" validate:"omitempty,min=1,dive,oneof=application.created application.status_changed interview.scheduled job.published job.expired"`
+	Active *bool    `json:"active"` // true re-enables a webhook disabled after repeated failures
+}
+
+// CreateCompanyEmailTemplateRequest represents a company's own candidate email for an event,
+// also used to preview a template without saving it
+type CreateCompanyEmailTemplateRequest struct {
+	EventType string `json:"event_type" validate:"required,oneof=application_rejected application_shortlisted interview_scheduled offer_made"`
+	Subject   string `json:"subject" validate:"required,max=200"`
+	Body      string `json:"body" validate:"required,max=20000"`
+}
+
+// UpdateCompanyEmailTemplateRequest represents replacing the subject and body of a company email template
+type UpdateCompanyEmailTemplateRequest struct {
+	Subject string `json:"subject" validate:"required,max=200"`
+	Body    string `json:"body" validate:"required,max=20000"`
+}
+
+// CreateTalentPoolRequest represents creating a company talent pool
+type CreateTalentPoolRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"omitempty,max=1000"`
+}
+
+// AddTalentPoolMemberRequest represents saving an applicant to a talent pool
+type AddTalentPoolMemberRequest struct {
+	ApplicationID int64    `json:"application_id" validate:"required,min=1"`
+	Tags          []string `json:"tags" validate:"omitempty,max=20,dive,max=50"`
+	Notes         string   `json:"notes" validate:"omitempty,max=2000"`
+}
+
+// ListTalentPoolMembersRequest represents filters on the members of a talent pool
+type ListTalentPoolMembersRequest struct {
+	Page   int    `query:"page" validate:"omitempty,min=1"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Tags   string `query:"tags" validate:"omitempty,max=500"`   // Comma-separated, members with all of them
+	Skills string `query:"skills" validate:"omitempty,max=500"` // Comma-separated, members with all of them
+	Query  string `query:"q" validate:"omitempty,max=100"`
+}
+
+// MatchTalentPoolRequest represents scoring talent pool candidates against a job
+type MatchTalentPoolRequest struct {
+	JobID  int64 `query:"job_id" validate:"required,min=1"`
+	PoolID int64 `query:"pool_id" validate:"omitempty,min=1"` // All of the company's pools when omitted
+	Limit  int   `query:"limit" validate:"omitempty,min=1,max=50"`
+}
+
+// ListWebhookDeliveriesRequest represents query parameters for a webhook's deliveries
+type ListWebhookDeliveriesRequest struct {
+	Page   int    `query:"page" validate:"omitempty,min=1"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Status string `query:"status" validate:"omitempty,oneof=pending delivered failed"`
+	Event  string `query:"event" validate:"omitempty,max=50"`
+}
+
+// StartDomainVerificationRequest represents claiming the company's email domain
+type StartDomainVerificationRequest struct {
+	Method string `json:"method" validate:"required,oneof=email dns"`
+}
+
+// VerifyDomainRequest represents completing a domain claim; the code is only needed for the email method
+type VerifyDomainRequest struct {
+	Code string `json:"code" validate:"omitempty,len=6,numeric"`
 }
@@ -95,7 +95,9 @@ type UpdateJobRequest struct {
 	MinAge           *int              `json:"min_age" validate:"omitempty,min=17,max=65"`
 	MaxAge           *int              `json:"max_age" validate:"omitempty,min=17,max=65,gtefield=MinAge"`
 	CompanyAddressID *int64            `json:"company_address_id" validate:"omitempty,min=1"`
+	AutoExtend       *bool             `json:"auto_extend"` // Extend the job by the default period instead of expiring it
 	Skills           []AddSkillRequest `json:"skills,omitempty" validate:"omitempty,dive"`
+	Version          *int64            `json:"version" validate:"omitempty,min=1"` // Version of the job the client loaded; the If-Match header may send it instead
 	// NOTE: Status should NOT be updated by users - it's controlled by workflow
 	// - draft: initial state (automatic)
 	// - pending_approval: submitted for review (automatic when published)
@@ -144,6 +146,15 @@ type JobSearchRequest struct {
 	WorkPolicyIDs     []int64 `json:"work_policy_ids" query:"work_policy_ids" validate:"omitempty"` // On-site, Remote, Hybrid
 	EducationLevelID  *int64  `json:"education_level_id" query:"education_level_id" validate:"omitempty"`
 	ExperienceLevelID *int64  `json:"experience_level_id" query:"experience_level_id" validate:"omitempty"`
+
+	GeoSearchRequest
+}
+
+// GeoSearchRequest limits a search to jobs near a point; lat and lng must be sent together
+type GeoSearchRequest struct {
+	Lat      *float64 `json:"lat" query:"lat" validate:"required_with=Lng,omitempty,min=-90,max=90"`
+	Lng      *float64 `json:"lng" query:"lng" validate:"required_with=Lat,omitempty,min=-180,max=180"`
+	RadiusKm *float64 `json:"radius_km" query:"radius_km" validate:"omitempty,gt=0,max=500"`
 }
 
 // JobFilterRequest represents job filter request
@@ -157,6 +168,7 @@ type JobFilterRequest struct {
 	Limit          int    `json:"limit" query:"limit" validate:"omitempty,min=1,max=100"`
 	SortBy         string `json:"sort_by" query:"sort_by" validate:"omitempty"`
 	SortOrder      string `json:"sort_order" query:"sort_order" validate:"omitempty,oneof=asc desc"`
+	Cursor         string `json:"cursor" query:"cursor"` // Opts into cursor pagination; Page is ignored
 }
 
 // PublishJobRequest represents publish job request
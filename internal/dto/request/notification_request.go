@@ -56,12 +56,13 @@ type UpdateNotificationPreferencesRequest struct {
 
 // NotificationFilterRequest represents filters for notification queries
 type NotificationFilterRequest struct {
-	Type     string     `json:"type" validate:"omitempty,max=50"`
-	Category string     `json:"category" validate:"omitempty,oneof=application job account system company"`
-	IsRead   *bool      `json:"is_read" validate:"omitempty"`
-	Priority string     `json:"priority" validate:"omitempty,oneof=low normal high urgent"`
-	DateFrom *time.Time `json:"date_from" validate:"omitempty"`
-	DateTo   *time.Time `json:"date_to" validate:"omitempty"`
+	Type     string     `json:"type" query:"type" validate:"omitempty,max=50"`
+	Category string     `json:"category" query:"category" validate:"omitempty,oneof=application job account system company"`
+	IsRead   *bool      `json:"is_read" query:"is_read" validate:"omitempty"`
+	Unread   bool       `json:"unread" query:"unread"` // Only unread notifications; shorthand for is_read=false
+	Priority string     `json:"priority" query:"priority" validate:"omitempty,oneof=low normal high urgent"`
+	DateFrom *time.Time `json:"date_from" query:"-" validate:"omitempty"`
+	DateTo   *time.Time `json:"date_to" query:"-" validate:"omitempty"`
 }
 
 // MarkNotificationsAsReadRequest represents a request to mark notifications as read
@@ -201,6 +201,27 @@ type UpdateUserPreferencesRequest struct {
 	ShowOnlineStatus    *bool    `json:"show_online_status"`
 	AllowDirectMessages *bool    `json:"allow_direct_messages"`
 	DataSharingConsent  *bool    `json:"data_sharing_consent"`
+	AllowTalentPools    *bool    `json:"allow_talent_pools"`
+}
+
+// UpdateNotificationSettingsRequest represents a change to the notification channels per
+// event and quiet hours. Omitted fields and events are left unchanged; empty quiet hours
+// times turn quiet hours off.
+type UpdateNotificationSettingsRequest struct {
+	EmailNotifications *bool                                 `json:"email_notifications"`
+	PushNotifications  *bool                                 `json:"push_notifications"`
+	Events             map[string]UpdateNotificationChannels `json:"events" validate:"omitempty,dive,keys,oneof=application_status interview messages job_alerts company_follows marketing,endkeys"`
+	QuietHoursStart    *string                               `json:"quiet_hours_start" validate:"omitempty,max=5"`
+	QuietHoursEnd      *string                               `json:"quiet_hours_end" validate:"omitempty,max=5"`
+	QuietHoursTimezone *string                               `json:"quiet_hours_timezone" validate:"omitempty,max=64"`
+}
+
+// UpdateNotificationChannels represents a change to whether an event is emailed, pushed and
+// sent by WhatsApp. WhatsApp only applies to application status and interview events.
+type UpdateNotificationChannels struct {
+	Email    *bool `json:"email"`
+	Push     *bool `json:"push"`
+	WhatsApp *bool `json:"whatsapp"`
 }
 
 // UserSearchRequest represents user search request
@@ -0,0 +1,21 @@
+package response
+
+import "time"
+
+// AdminAuditLogResponse represents an audit log entry
+type AdminAuditLogResponse struct {
+	ID         int64                  `json:"id"`
+	ActorID    *int64                 `json:"actor_id,omitempty"`
+	ActorType  string                 `json:"actor_type"`
+	Action     string                 `json:"action"`
+	EntityType string                 `json:"entity_type"`
+	EntityID   int64                  `json:"entity_id"`
+	Metadata   map[string]interface{} `json:"metadata"`
+	IP         *string                `json:"ip,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// AdminAuditLogListResponse represents a page of audit log entries
+type AdminAuditLogListResponse struct {
+	Logs []AdminAuditLogResponse `json:"logs"`
+}
@@ -22,11 +22,18 @@ type AdminAuthResponse struct {
 
 // AdminRoleInfo represents role information in auth response
 type AdminRoleInfo struct {
-	RoleID          int64  `json:"role_id"`
-	RoleName        string `json:"role_name"`
-	RoleDescription string `json:"role_description,omitempty"`
-	AccessLevel     int16  `json:"access_level"`
-	IsSystemRole    bool   `json:"is_system_role"`
+	RoleID          int64    `json:"role_id"`
+	RoleName        string   `json:"role_name"`
+	RoleDescription string   `json:"role_description,omitempty"`
+	AccessLevel     int16    `json:"access_level"`
+	IsSystemRole    bool     `json:"is_system_role"`
+	Permissions     []string `json:"permissions"`
+}
+
+// AdminRoleListResponse lists the admin roles with their permissions
+type AdminRoleListResponse struct {
+	Roles       []AdminRoleInfo `json:"roles"`
+	Permissions []string        `json:"permissions"` // Every permission a role can be granted
 }
 
 // AdminProfileResponse represents current admin profile
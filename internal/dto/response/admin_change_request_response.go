@@ -0,0 +1,18 @@
+package response
+
+// AdminChangeRequestResponse represents a company change request in the admin queue,
+// with the company's current values next to the requested ones
+type AdminChangeRequestResponse struct {
+	CompanyChangeRequestResponse
+	CompanyName          string `json:"company_name"`
+	RequestedBy          int64  `json:"requested_by"`
+	DecidedBy            *int64 `json:"decided_by,omitempty"`
+	CurrentIndustryID    *int64 `json:"current_industry_id,omitempty"`
+	CurrentCompanySizeID *int64 `json:"current_company_size_id,omitempty"`
+	CurrentDistrictID    *int64 `json:"current_district_id,omitempty"`
+}
+
+// AdminChangeRequestListResponse represents the admin company change request queue
+type AdminChangeRequestListResponse struct {
+	ChangeRequests []AdminChangeRequestResponse `json:"change_requests"`
+}
@@ -32,6 +32,7 @@ type AdminCompanyListItemResponse struct {
 	ActiveJobs        int64 `json:"active_jobs"`
 	TotalApplications int64 `json:"total_applications"`
 	TotalFollowers    int64 `json:"total_followers"`
+	PendingDocuments  int64 `json:"pending_documents"`
 }
 
 // AdminCompanyDetailResponse represents full company details for admin
@@ -132,8 +133,7 @@ type AdminCompanyDocumentResponse struct {
 	DocumentType    string     `json:"document_type"`
 	DocumentNumber  string     `json:"document_number,omitempty"`
 	DocumentName    string     `json:"document_name,omitempty"`
-	FilePath        string     `json:"file_path"`
-	FileURL         string     `json:"file_url,omitempty"`
+	FileURL         string     `json:"file_url,omitempty"` // Short-lived signed download link
 	IssueDate       *time.Time `json:"issue_date,omitempty"`
 	ExpiryDate      *time.Time `json:"expiry_date,omitempty"`
 	Status          string     `json:"status"` // pending, approved, rejected, expired
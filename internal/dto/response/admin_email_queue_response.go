@@ -0,0 +1,26 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AdminQueuedEmailResponse represents an email in the email queue
+type AdminQueuedEmailResponse struct {
+	ID            int64           `json:"id"`
+	Recipient     string          `json:"recipient"`
+	Template      string          `json:"template"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	LastError     *string         `json:"last_error,omitempty"`
+	SentAt        *time.Time      `json:"sent_at,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// AdminQueuedEmailListResponse represents a page of queued emails
+type AdminQueuedEmailListResponse struct {
+	Emails []AdminQueuedEmailResponse `json:"emails"`
+}
@@ -0,0 +1,13 @@
+package response
+
+import "time"
+
+// ImpersonationResponse carries the access token an admin uses to act as a user
+type ImpersonationResponse struct {
+	SessionID   int64     `json:"session_id"`
+	UserID      int64     `json:"user_id"`
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type"`
+	ExpiresIn   int64     `json:"expires_in"` // seconds
+	ExpiresAt   time.Time `json:"expires_at"`
+}
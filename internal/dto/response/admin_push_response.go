@@ -0,0 +1,35 @@
+package response
+
+import "time"
+
+// PushCampaignResponse represents an admin push campaign with its delivery stats
+type PushCampaignResponse struct {
+	ID                  int64             `json:"id"`
+	Title               string            `json:"title"`
+	Body                string            `json:"body"`
+	Data                map[string]string `json:"data,omitempty"`
+	Topic               *string           `json:"topic,omitempty"`
+	Filter              *PushFilterResult `json:"filter,omitempty"`
+	Status              string            `json:"status"`
+	ScheduledAt         time.Time         `json:"scheduled_at"`
+	StartedAt           *time.Time        `json:"started_at,omitempty"`
+	CompletedAt         *time.Time        `json:"completed_at,omitempty"`
+	SentCount           int               `json:"sent_count"`
+	FailedCount         int               `json:"failed_count"`
+	InvalidTokensPruned int               `json:"invalid_tokens_pruned"`
+	ErrorMessage        *string           `json:"error_message,omitempty"`
+	CreatedBy           *int64            `json:"created_by,omitempty"`
+	CreatedAt           time.Time         `json:"created_at"`
+}
+
+// PushFilterResult represents the recipient filter of a segment campaign
+type PushFilterResult struct {
+	Role              *string `json:"role,omitempty"`
+	ProvinceID        *int64  `json:"province_id,omitempty"`
+	FollowedCompanyID *int64  `json:"followed_company_id,omitempty"`
+}
+
+// PushCampaignListResponse represents a page of push campaigns
+type PushCampaignListResponse struct {
+	Campaigns []PushCampaignResponse `json:"campaigns"`
+}
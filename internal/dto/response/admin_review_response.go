@@ -0,0 +1,35 @@
+package response
+
+import "time"
+
+// AdminReviewResponse represents a review in the admin moderation queue
+type AdminReviewResponse struct {
+	ID                 int64      `json:"id"`
+	CompanyID          int64      `json:"company_id"`
+	CompanyName        string     `json:"company_name"`
+	UserID             *int64     `json:"user_id,omitempty"` // Hidden if anonymous
+	ReviewerName       string     `json:"reviewer_name"`     // Masked if anonymous
+	ReviewerType       *string    `json:"reviewer_type,omitempty"`
+	PositionTitle      *string    `json:"position_title,omitempty"`
+	EmploymentPeriod   *string    `json:"employment_period,omitempty"`
+	RatingOverall      *float64   `json:"rating_overall,omitempty"`
+	RatingCulture      *float64   `json:"rating_culture,omitempty"`
+	RatingWorkLife     *float64   `json:"rating_worklife,omitempty"`
+	RatingSalary       *float64   `json:"rating_salary,omitempty"`
+	RatingManagement   *float64   `json:"rating_management,omitempty"`
+	Pros               *string    `json:"pros,omitempty"`
+	Cons               *string    `json:"cons,omitempty"`
+	AdviceToManagement *string    `json:"advice_to_management,omitempty"`
+	IsAnonymous        bool       `json:"is_anonymous"`
+	RecommendToFriend  bool       `json:"recommend_to_friend"`
+	Status             string     `json:"status"`
+	ModeratedBy        *int64     `json:"moderated_by,omitempty"`
+	ModeratedAt        *time.Time `json:"moderated_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// AdminReviewListResponse represents the admin review moderation queue
+type AdminReviewListResponse struct {
+	Reviews []AdminReviewResponse `json:"reviews"`
+}
@@ -0,0 +1,31 @@
+package response
+
+import "time"
+
+// AdminScheduledJobResponse represents a registered background job with its last and next run
+type AdminScheduledJobResponse struct {
+	Name           string               `json:"name"`
+	Schedule       string               `json:"schedule"`
+	TimeoutSeconds int64                `json:"timeout_seconds"`
+	Status         string               `json:"status"`
+	LastRun        *AdminJobRunResponse `json:"last_run,omitempty"`
+	NextRunAt      *time.Time           `json:"next_run_at,omitempty"`
+}
+
+// AdminJobRunResponse represents one run of a background job
+type AdminJobRunResponse struct {
+	ID             int64      `json:"id,omitempty"`
+	JobName        string     `json:"job_name"`
+	TriggeredBy    string     `json:"triggered_by"`
+	StartedAt      time.Time  `json:"started_at"`
+	FinishedAt     *time.Time `json:"finished_at,omitempty"`
+	DurationMs     *int64     `json:"duration_ms,omitempty"`
+	Success        bool       `json:"success"`
+	Error          *string    `json:"error,omitempty"`
+	ItemsProcessed int        `json:"items_processed"`
+}
+
+// AdminJobScheduleResponse represents the background job schedule
+type AdminJobScheduleResponse struct {
+	Jobs []AdminScheduledJobResponse `json:"jobs"`
+}
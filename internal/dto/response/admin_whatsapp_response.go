@@ -0,0 +1,21 @@
+package response
+
+import "time"
+
+// AdminWhatsAppDeliveryResponse represents a WhatsApp message sent to a user
+type AdminWhatsAppDeliveryResponse struct {
+	ID                int64     `json:"id"`
+	UserID            int64     `json:"user_id"`
+	Event             string    `json:"event"`
+	Template          string    `json:"template"`
+	Phone             string    `json:"phone"`
+	Status            string    `json:"status"`
+	ProviderMessageID *string   `json:"provider_message_id,omitempty"`
+	Error             *string   `json:"error,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// AdminWhatsAppDeliveryListResponse represents a page of WhatsApp deliveries
+type AdminWhatsAppDeliveryListResponse struct {
+	Deliveries []AdminWhatsAppDeliveryResponse `json:"deliveries"`
+}
@@ -72,7 +72,7 @@ type ApplicationDocumentResponse struct {
 	ID           int64      `json:"id"`
 	DocumentType string     `json:"document_type"`
 	FileName     string     `json:"file_name,omitempty"`
-	FileURL      string     `json:"file_url"`
+	FileURL      string     `json:"file_url,omitempty"` // Pre-uploaded links only; uploaded files are fetched through a signed download link
 	FileType     string     `json:"file_type,omitempty"`
 	FileSize     int64      `json:"file_size,omitempty"`
 	UploadedAt   time.Time  `json:"uploaded_at"`
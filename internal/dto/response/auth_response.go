@@ -79,6 +79,25 @@ type DeviceListResponse struct {
 	Total   int          `json:"total"`
 }
 
+// SessionInfo represents an active login session (refresh token)
+type SessionInfo struct {
+	ID         int64   `json:"id"`
+	DeviceName *string `json:"device_name"`
+	DeviceType *string `json:"device_type"`
+	UserAgent  *string `json:"user_agent"`
+	IPAddress  *string `json:"ip_address"`
+	LastUsedAt string  `json:"last_used_at"`
+	CreatedAt  string  `json:"created_at"`
+	ExpiresAt  string  `json:"expires_at"`
+	IsCurrent  bool    `json:"is_current"` // whether the request was made with this session
+}
+
+// SessionListResponse represents list of active sessions
+type SessionListResponse struct {
+	Sessions []SessionInfo `json:"sessions"`
+	Total    int           `json:"total"`
+}
+
 // OAuthProviderResponse represents connected OAuth provider
 type OAuthProviderResponse struct {
 	ID          int64   `json:"id"`
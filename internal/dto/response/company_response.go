@@ -1,6 +1,9 @@
 package response
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // CompanyResponse represents company public response
 type CompanyResponse struct {
@@ -36,6 +39,10 @@ type CompanyResponse struct {
 	Status       string `json:"status,omitempty"`        // "verified", "pending", "under_review", "rejected", "not_requested"
 	BadgeGranted bool   `json:"badge_granted,omitempty"` // Whether company has verification badge
 
+	// Resized logo and banner variants by key, e.g. "200_webp"; LogoURL and BannerURL are the originals
+	LogoURLs   map[string]string `json:"logo_urls,omitempty"`
+	BannerURLs map[string]string `json:"banner_urls,omitempty"`
+
 	IsActive  bool      `json:"is_active"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -100,7 +107,12 @@ type CompanyDetailResponse struct {
 	NPWPNumber   string `json:"npwp_number,omitempty"` // NPWP number if verified
 	NIBNumber    string `json:"nib_number,omitempty"`  // NIB number if provided
 
+	// Resized logo and banner variants by key, e.g. "200_webp"; LogoURL and BannerURL are the originals
+	LogoURLs   map[string]string `json:"logo_urls,omitempty"`
+	BannerURLs map[string]string `json:"banner_urls,omitempty"`
+
 	IsActive  bool      `json:"is_active"`
+	Version   int64     `json:"version"` // Send back as version or If-Match when updating the company
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
@@ -118,6 +130,26 @@ type CompanyDetailResponse struct {
 	IsFollowing    bool    `json:"is_following,omitempty"` // For authenticated users
 }
 
+// PublicCompanyProfileResponse represents the composed public company page
+type PublicCompanyProfileResponse struct {
+	Company       *CompanyDetailResponse       `json:"company"`
+	Jobs          []JobResponse                `json:"jobs"`
+	ReviewSummary CompanyReviewSummaryResponse `json:"review_summary"`
+	FollowerCount int64                        `json:"follower_count"`
+	IsFollowing   *bool                        `json:"is_following,omitempty"` // Only for authenticated users
+}
+
+// CompanyReviewSummaryResponse represents average ratings with the most helpful reviews
+type CompanyReviewSummaryResponse struct {
+	Overall      float64                 `json:"overall"`
+	Culture      float64                 `json:"culture"`
+	WorkLife     float64                 `json:"work_life"`
+	Salary       float64                 `json:"salary"`
+	Management   float64                 `json:"management"`
+	TotalReviews int64                   `json:"total_reviews"`
+	TopReviews   []CompanyReviewResponse `json:"top_reviews"`
+}
+
 // CompanyProfileResponse represents company profile response
 type CompanyProfileResponse struct {
 	ID             int64     `json:"id"`
@@ -134,6 +166,7 @@ type CompanyProfileResponse struct {
 	YoutubeURL     string    `json:"youtube_url,omitempty"`
 	Awards         string    `json:"awards,omitempty"`
 	Certifications string    `json:"certifications,omitempty"`
+	Version        int64     `json:"version"` // Send back as version or If-Match when updating the profile
 	UpdatedAt      time.Time `json:"updated_at"`
 }
 
@@ -146,22 +179,38 @@ type CompanyIndustryResponse struct {
 
 // CompanyReviewResponse represents company review response
 type CompanyReviewResponse struct {
-	ID             int64     `json:"id"`
-	UserID         int64     `json:"user_id,omitempty"`   // Hidden if anonymous
-	UserName       string    `json:"user_name,omitempty"` // Hidden if anonymous
-	Rating         int16     `json:"rating"`
-	ReviewText     string    `json:"review_text"`
-	ReviewTitle    string    `json:"review_title,omitempty"`
-	Position       string    `json:"position"`
-	EmploymentType string    `json:"employment_type"`
-	WorkDuration   string    `json:"work_duration,omitempty"`
-	IsAnonymous    bool      `json:"is_anonymous"`
-	Pros           string    `json:"pros,omitempty"`
-	Cons           string    `json:"cons,omitempty"`
-	HelpfulCount   int32     `json:"helpful_count"`
-	IsVerified     bool      `json:"is_verified"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	ID              int64   `json:"id"`
+	UserID          int64   `json:"user_id,omitempty"`   // Hidden if anonymous
+	UserName        string  `json:"user_name,omitempty"` // Hidden if anonymous
+	Rating          int16   `json:"rating"`
+	ReviewText      string  `json:"review_text"`
+	ReviewTitle     string  `json:"review_title,omitempty"`
+	Position        string  `json:"position"`
+	EmploymentType  string  `json:"employment_type"`
+	WorkDuration    string  `json:"work_duration,omitempty"`
+	IsAnonymous     bool    `json:"is_anonymous"`
+	Pros            string  `json:"pros,omitempty"`
+	Cons            string  `json:"cons,omitempty"`
+	HelpfulCount    int32   `json:"helpful_count"`
+	NotHelpfulCount int32   `json:"not_helpful_count"`
+	MyVote          *string `json:"my_vote,omitempty"` // Only set for authenticated viewers who voted
+	IsVerified      bool    `json:"is_verified"`
+	// IsVerifiedReviewer marks reviews by employees or hired applicants of the company
+	IsVerifiedReviewer bool `json:"is_verified_reviewer"`
+	// Response is the company's public reply, if it posted one
+	Response  *ReviewReplyResponse `json:"response,omitempty"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+// ReviewReplyResponse represents a company's response to a review
+type ReviewReplyResponse struct {
+	ID        int64      `json:"id"`
+	ReviewID  int64      `json:"review_id"`
+	Body      string     `json:"body"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
 }
 
 // CompanyFollowerResponse represents company follower response
@@ -226,6 +275,33 @@ type CompanyStatsResponse struct {
 	TotalEmployees    int64   `json:"total_employees"`
 }
 
+// CompanySettingsResponse represents company settings response
+type CompanySettingsResponse struct {
+	CompanyID             int64                          `json:"company_id"`
+	StageReminderEnabled  bool                           `json:"stage_reminder_enabled"`
+	StageReminderDays     int                            `json:"stage_reminder_days"`
+	BlindScreeningEnabled bool                           `json:"blind_screening_enabled"`
+	WeeklyDigestEnabled   bool                           `json:"weekly_digest_enabled"`
+	AutoJoinEnabled       bool                           `json:"auto_join_enabled"`
+	AutoJoinRole          string                         `json:"auto_join_role"`
+	UpdatedAt             time.Time                      `json:"updated_at"`
+	ChangeRequests        []CompanyChangeRequestResponse `json:"change_requests"`
+}
+
+// CompanyChangeRequestResponse represents a request to change the company's industry, size or district
+type CompanyChangeRequestResponse struct {
+	ID            int64      `json:"id"`
+	CompanyID     int64      `json:"company_id"`
+	IndustryID    *int64     `json:"industry_id,omitempty"`
+	CompanySizeID *int64     `json:"company_size_id,omitempty"`
+	DistrictID    *int64     `json:"district_id,omitempty"`
+	Reason        string     `json:"reason"`
+	Status        string     `json:"status"`
+	DecisionNote  *string    `json:"decision_note,omitempty"`
+	DecidedAt     *time.Time `json:"decided_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
 // =============================================================================
 // Master Data Responses
 // =============================================================================
@@ -285,13 +361,15 @@ type DistrictResponse struct {
 
 // CompanyAddressResponse represents company address for job posting
 type CompanyAddressResponse struct {
-	ID          int64   `json:"id"`
-	FullAddress string  `json:"full_address"`
-	Latitude    float64 `json:"latitude,omitempty"`
-	Longitude   float64 `json:"longitude,omitempty"`
-	ProvinceID  *int64  `json:"province_id,omitempty"`
-	CityID      *int64  `json:"city_id,omitempty"`
-	DistrictID  *int64  `json:"district_id,omitempty"`
+	ID                int64    `json:"id"`
+	FullAddress       string   `json:"full_address"`
+	Latitude          float64  `json:"latitude,omitempty"`
+	Longitude         float64  `json:"longitude,omitempty"`
+	GeocodeProvider   *string  `json:"geocode_provider,omitempty"`   // Set when coordinates were geocoded
+	GeocodeConfidence *float64 `json:"geocode_confidence,omitempty"` // 0..1
+	ProvinceID        *int64   `json:"province_id,omitempty"`
+	CityID            *int64   `json:"city_id,omitempty"`
+	DistrictID        *int64   `json:"district_id,omitempty"`
 }
 
 // Extended nested location fields for address responses
@@ -313,15 +391,131 @@ func (r *CompanyAddressResponse) WithLocations(prov *ProvinceResponse, city *Cit
 		Province    *ProvinceResponse `json:"province,omitempty"`
 		Latitude    float64           `json:"latitude,omitempty"`
 		Longitude   float64           `json:"longitude,omitempty"`
+
+		GeocodeProvider   *string  `json:"geocode_provider,omitempty"`
+		GeocodeConfidence *float64 `json:"geocode_confidence,omitempty"`
 	}{
-		ID:          r.ID,
-		FullAddress: r.FullAddress,
-		City:        city,
-		District:    dist,
-		Province:    prov,
-		Latitude:    r.Latitude,
-		Longitude:   r.Longitude,
+		ID:                r.ID,
+		FullAddress:       r.FullAddress,
+		City:              city,
+		District:          dist,
+		Province:          prov,
+		Latitude:          r.Latitude,
+		Longitude:         r.Longitude,
+		GeocodeProvider:   r.GeocodeProvider,
+		GeocodeConfidence: r.GeocodeConfidence,
 	}
 
 	return out
 }
+
+// CompanyAPIKeyResponse represents a company API key. Only the key prefix is shown.
+type CompanyAPIKeyResponse struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	KeyPrefix  string     `json:"key_prefix"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedCompanyAPIKeyResponse is returned once on creation and includes the plaintext key
+type CreatedCompanyAPIKeyResponse struct {
+	CompanyAPIKeyResponse
+	Key string `json:"key"`
+}
+
+// CompanyWebhookResponse represents a company webhook. The signing secret is never shown again
+// after creation.
+type CompanyWebhookResponse struct {
+	ID           int64      `json:"id"`
+	URL          string     `json:"url"`
+	Events       []string   `json:"events"`
+	Active       bool       `json:"active"`
+	FailureCount int        `json:"failure_count"`
+	DisabledAt   *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// CompanyEmailTemplateResponse represents a company's own candidate email for an event
+type CompanyEmailTemplateResponse struct {
+	ID        int64     `json:"id"`
+	EventType string    `json:"event_type"`
+	Subject   string    `json:"subject"`
+	Body      string    `json:"body"`
+	UpdatedBy *int64    `json:"updated_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TalentPoolResponse represents a company talent pool
+type TalentPoolResponse struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	MemberCount int64     `json:"member_count"`
+	CreatedBy   *int64    `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TalentPoolMemberResponse represents a candidate saved to a talent pool
+type TalentPoolMemberResponse struct {
+	ID                  int64     `json:"id"`
+	PoolID              int64     `json:"pool_id"`
+	UserID              int64     `json:"user_id"`
+	CandidateName       string    `json:"candidate_name,omitempty"`
+	Headline            *string   `json:"headline,omitempty"`
+	SourceApplicationID *int64    `json:"source_application_id,omitempty"`
+	Tags                []string  `json:"tags"`
+	Notes               string    `json:"notes,omitempty"`
+	AddedBy             *int64    `json:"added_by,omitempty"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+// TalentPoolMemberListResponse represents a page of talent pool members
+type TalentPoolMemberListResponse struct {
+	Members []TalentPoolMemberResponse `json:"members"`
+}
+
+// TalentPoolMatchResponse represents a talent pool candidate scored against a job
+type TalentPoolMatchResponse struct {
+	Member          TalentPoolMemberResponse `json:"member"`
+	OverallScore    float64                  `json:"overall_score"`
+	SkillScore      float64                  `json:"skill_score"`
+	ExperienceScore float64                  `json:"experience_score"`
+	EducationScore  float64                  `json:"education_score"`
+	LocationScore   float64                  `json:"location_score"`
+	MatchedSkills   []string                 `json:"matched_skills"`
+	MissingSkills   []string                 `json:"missing_skills"`
+}
+
+// CreatedCompanyWebhookResponse is returned once on creation and includes the signing secret
+type CreatedCompanyWebhookResponse struct {
+	CompanyWebhookResponse
+	Secret string `json:"secret"`
+}
+
+// WebhookDeliveryResponse represents an event sent, or waiting to be sent, to a webhook
+type WebhookDeliveryResponse struct {
+	ID            int64           `json:"id"`
+	WebhookID     int64           `json:"webhook_id"`
+	CompanyID     int64           `json:"company_id"`
+	EventID       string          `json:"event_id"`
+	Event         string          `json:"event"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	ResponseCode  *int            `json:"response_code,omitempty"`
+	LastError     *string         `json:"last_error,omitempty"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// WebhookDeliveryListResponse represents a page of webhook deliveries
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+}
@@ -0,0 +1,17 @@
+package response
+
+// CursorPageResponse represents one page of a cursor-paginated list. NextCursor is
+// null on the last page.
+type CursorPageResponse[T any] struct {
+	Items      []T     `json:"items"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+// NewCursorPage builds a cursor page, mapping an empty next cursor to null
+func NewCursorPage[T any](items []T, nextCursor string) CursorPageResponse[T] {
+	page := CursorPageResponse[T]{Items: items}
+	if nextCursor != "" {
+		page.NextCursor = &nextCursor
+	}
+	return page
+}
@@ -0,0 +1,11 @@
+package response
+
+import "time"
+
+// DownloadLinkResponse is a link for downloading a document. Links to private documents are
+// signed and expire at ExpiresAt; links to documents hosted elsewhere do not expire.
+type DownloadLinkResponse struct {
+	URL       string     `json:"url" example:"https://api.keerja.com/api/v1/downloads/eyJwIjoi...Q8s"`
+	FileName  string     `json:"file_name" example:"npwp.pdf"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2025-11-01T10:05:00Z"`
+}
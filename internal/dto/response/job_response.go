@@ -1,6 +1,9 @@
 package response
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // JobResponse represents job public response (simplified - master data only)
 type JobResponse struct {
@@ -32,17 +35,20 @@ type JobResponse struct {
 	Currency       string                  `json:"currency"`
 
 	// Location
-	City     string `json:"city,omitempty"`
-	Province string `json:"province,omitempty"`
+	City       string   `json:"city,omitempty"`
+	Province   string   `json:"province,omitempty"`
+	DistanceKm *float64 `json:"distance_km,omitempty"` // Only set for location searches
 
 	Status            string     `json:"status"`
 	ViewsCount        int64      `json:"views_count"`
 	ApplicationsCount int64      `json:"applications_count"`
 	PublishedAt       *time.Time `json:"published_at,omitempty"`
 	ExpiredAt         *time.Time `json:"expired_at,omitempty"`
+	SubmittedAt       *time.Time `json:"submitted_at,omitempty"`
 	CreatedAt         time.Time  `json:"created_at"`
 	IsExpired         bool       `json:"is_expired"`
 	DaysRemaining     *int       `json:"days_remaining,omitempty"`
+	IsFeatured        bool       `json:"is_featured,omitempty"` // Featured by an admin right now
 }
 
 // JobCompanyResponse represents company info embedded in job detail
@@ -122,6 +128,8 @@ type JobDetailResponse struct {
 	ApplicationsCount  int64                    `json:"applications_count"`
 	PublishedAt        *time.Time               `json:"published_at,omitempty"`
 	ExpiredAt          *time.Time               `json:"expired_at,omitempty"`
+	SubmittedAt        *time.Time               `json:"submitted_at,omitempty"`
+	Version            int64                    `json:"version"` // Send back as version or If-Match when updating the job
 	CreatedAt          time.Time                `json:"created_at"`
 	UpdatedAt          time.Time                `json:"updated_at"`
 	IsExpired          bool                     `json:"is_expired"`
@@ -134,6 +142,22 @@ type JobDetailResponse struct {
 	IsSaved            bool                     `json:"is_saved,omitempty"`
 	// Optional selected company address (when job references a company_address_id)
 	CompanyAddress *CompanyAddressResponse `json:"company_address,omitempty"`
+	// Latest admin review decision (only shown to the job's employer)
+	Review *JobReviewResponse `json:"review,omitempty"`
+	// Expiry details of the employer's own job listing
+	AutoExtend      *bool `json:"auto_extend,omitempty"`
+	DaysUntilExpiry *int  `json:"days_until_expiry,omitempty"`
+	// Admin curation window of a featured job
+	IsFeatured    bool       `json:"is_featured"`
+	FeaturedFrom  *time.Time `json:"featured_from,omitempty"`
+	FeaturedUntil *time.Time `json:"featured_until,omitempty"`
+}
+
+// JobReviewResponse represents an admin review decision on a job
+type JobReviewResponse struct {
+	Action     string    `json:"action"`
+	Reason     string    `json:"reason,omitempty"`
+	ReviewedAt time.Time `json:"reviewed_at"`
 }
 
 // JobMasterDataItem represents a generic master data item for job details
@@ -215,6 +239,53 @@ type JobListResponse struct {
 	Jobs []JobResponse `json:"jobs"`
 }
 
+// JobDraftResponse represents a draft job with its completeness for publishing
+type JobDraftResponse struct {
+	JobResponse
+	IsComplete    bool     `json:"is_complete"`
+	MissingFields []string `json:"missing_fields"`
+}
+
+// JobDraftRevisionResponse represents a saved snapshot of a draft's payload
+type JobDraftRevisionResponse struct {
+	ID        int64           `json:"id"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// JobQuestionResponse represents a job screening question. Knockout settings are only
+// included for the job's employer.
+type JobQuestionResponse struct {
+	ID            int64    `json:"id"`
+	QuestionText  string   `json:"question_text"`
+	Type          string   `json:"type"`
+	Options       []string `json:"options,omitempty"`
+	IsRequired    bool     `json:"is_required"`
+	IsKnockout    *bool    `json:"is_knockout,omitempty"`
+	KnockoutValue *string  `json:"knockout_value,omitempty"`
+	SortOrder     int      `json:"sort_order"`
+}
+
+// JobSearchResponse represents job search results with facet counts
+type JobSearchResponse struct {
+	Jobs   []JobResponse         `json:"jobs"`
+	Facets *SearchFacetsResponse `json:"facets,omitempty"`
+}
+
+// SearchFacetsResponse represents facet counts for the current search
+type SearchFacetsResponse struct {
+	JobTypes     []FacetItemResponse `json:"job_types"`
+	WorkPolicies []FacetItemResponse `json:"work_policies"`
+	Cities       []FacetItemResponse `json:"cities"`
+}
+
+// FacetItemResponse represents a single facet value with its job count
+type FacetItemResponse struct {
+	ID    int64  `json:"id,omitempty"`
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
 // JobStatsResponse represents job statistics response
 type JobStatsResponse struct {
 	TotalViews         int64   `json:"total_views"`
@@ -4,17 +4,29 @@ import "time"
 
 // UserResponse represents user public response
 type UserResponse struct {
-	ID         int64                `json:"id"`
-	UUID       string               `json:"uuid"`
-	FullName   string               `json:"full_name"`
-	Email      string               `json:"email"`
-	Phone      string               `json:"phone,omitempty"`
-	UserType   string               `json:"user_type"`
-	IsVerified bool                 `json:"is_verified"`
-	Status     string               `json:"status"`
-	LastLogin  *time.Time           `json:"last_login,omitempty"`
-	CreatedAt  time.Time            `json:"created_at"`
-	Profile    *UserProfileResponse `json:"profile,omitempty"`
+	ID            int64                `json:"id"`
+	UUID          string               `json:"uuid"`
+	FullName      string               `json:"full_name"`
+	Email         string               `json:"email"`
+	Phone         string               `json:"phone,omitempty"`
+	PhoneVerified bool                 `json:"phone_verified"`
+	UserType      string               `json:"user_type"`
+	IsVerified    bool                 `json:"is_verified"`
+	Status        string               `json:"status"`
+	LastLogin     *time.Time           `json:"last_login,omitempty"`
+	CreatedAt     time.Time            `json:"created_at"`
+	Profile       *UserProfileResponse `json:"profile,omitempty"`
+
+	EmailVerification *EmailVerificationResponse `json:"email_verification,omitempty"`
+}
+
+// EmailVerificationResponse represents the account's email verification state
+type EmailVerificationResponse struct {
+	Verified      bool       `json:"verified"`
+	VerifiedAt    *time.Time `json:"verified_at,omitempty"`
+	GraceEndsAt   *time.Time `json:"grace_ends_at,omitempty"`
+	DaysRemaining int        `json:"days_remaining"`
+	Required      bool       `json:"required"`
 }
 
 // UserProfileResponse represents user profile response
@@ -46,6 +58,10 @@ type UserProfileResponse struct {
 	CoverURL           *string    `json:"cover_url,omitempty"`
 	CreatedAt          time.Time  `json:"created_at"`
 	UpdatedAt          time.Time  `json:"updated_at"`
+
+	// Resized avatar and cover variants by key, e.g. "200_webp"
+	AvatarURLs map[string]string `json:"avatar_urls,omitempty"`
+	CoverURLs  map[string]string `json:"cover_urls,omitempty"`
 }
 
 // UserDetailResponse represents detailed user response with all relations
@@ -55,6 +71,7 @@ type UserDetailResponse struct {
 	FullName       string                      `json:"full_name"`
 	Email          string                      `json:"email"`
 	Phone          string                      `json:"phone,omitempty"`
+	PhoneVerified  bool                        `json:"phone_verified"`
 	UserType       string                      `json:"user_type"`
 	IsVerified     bool                        `json:"is_verified"`
 	Status         string                      `json:"status"`
@@ -69,6 +86,8 @@ type UserDetailResponse struct {
 	Projects       []UserProjectResponse       `json:"projects,omitempty"`
 	Documents      []UserDocumentResponse      `json:"documents,omitempty"`
 	Preference     *UserPreferenceResponse     `json:"preference,omitempty"`
+
+	EmailVerification *EmailVerificationResponse `json:"email_verification,omitempty"`
 }
 
 // UserEducationResponse represents education response
@@ -163,6 +182,28 @@ type UserDocumentResponse struct {
 	UploadedAt   time.Time `json:"uploaded_at"`
 }
 
+// UserDocumentUploadResponse represents an uploaded document; resume uploads also carry
+// the profile suggestions parsed from the file for the user to confirm
+type UserDocumentUploadResponse struct {
+	UserDocumentResponse
+	ParsedResume *ParsedResumeResponse `json:"parsed_resume,omitempty"`
+}
+
+// ParsedResumeResponse represents profile suggestions parsed from a resume
+type ParsedResumeResponse struct {
+	Email        *string                     `json:"email,omitempty"`
+	Phone        *string                     `json:"phone,omitempty"`
+	LinkedInURL  *string                     `json:"linkedin_url,omitempty"`
+	Skills       []ParsedResumeSkillResponse `json:"skills"`
+	DegreeLevels []string                    `json:"degree_levels"`
+}
+
+// ParsedResumeSkillResponse represents a skills master entry found in a resume
+type ParsedResumeSkillResponse struct {
+	SkillID int64  `json:"skill_id"`
+	Name    string `json:"name"`
+}
+
 // UserPreferenceResponse represents user preference response
 type UserPreferenceResponse struct {
 	ID                  int64     `json:"id"`
@@ -184,7 +225,39 @@ type UserPreferenceResponse struct {
 	UpdatedAt           time.Time `json:"updated_at"`
 }
 
+// NotificationSettingsResponse represents the notification channels per event and quiet hours
+type NotificationSettingsResponse struct {
+	EmailNotifications bool                                    `json:"email_notifications"`
+	PushNotifications  bool                                    `json:"push_notifications"`
+	Events             map[string]NotificationChannelsResponse `json:"events"`
+	QuietHoursStart    *string                                 `json:"quiet_hours_start"`
+	QuietHoursEnd      *string                                 `json:"quiet_hours_end"`
+	QuietHoursTimezone string                                  `json:"quiet_hours_timezone"`
+}
+
+// NotificationChannelsResponse represents whether an event is emailed, pushed and sent by WhatsApp
+type NotificationChannelsResponse struct {
+	Email    bool `json:"email"`
+	Push     bool `json:"push"`
+	WhatsApp bool `json:"whatsapp"`
+}
+
 // UserListResponse represents list of users response
 type UserListResponse struct {
 	Users []UserResponse `json:"users"`
 }
+
+// ProfileCompletenessResponse represents the job seeker profile completeness checklist
+type ProfileCompletenessResponse struct {
+	Score       int                               `json:"score"`
+	Items       []ProfileCompletenessItemResponse `json:"items"`
+	NextActions []string                          `json:"next_actions"`
+}
+
+// ProfileCompletenessItemResponse represents one profile completeness checklist item
+type ProfileCompletenessItemResponse struct {
+	Key        string `json:"key"`
+	Weight     int    `json:"weight"`
+	Completed  bool   `json:"completed"`
+	NextAction string `json:"next_action,omitempty"`
+}
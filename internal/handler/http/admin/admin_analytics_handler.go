@@ -0,0 +1,77 @@
+package admin
+
+import (
+	"time"
+
+	"keerja-backend/internal/domain/admin"
+	"keerja-backend/internal/dto/request"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// analyticsPeriodDays maps the summary period presets to their length in days
+var analyticsPeriodDays = map[string]int{
+	"7d":  7,
+	"30d": 30,
+	"90d": 90,
+}
+
+// AdminAnalyticsHandler handles admin dashboard analytics endpoints
+type AdminAnalyticsHandler struct {
+	analyticsService admin.AdminAnalyticsService
+}
+
+// NewAdminAnalyticsHandler creates a new admin analytics handler
+func NewAdminAnalyticsHandler(analyticsService admin.AdminAnalyticsService) *AdminAnalyticsHandler {
+	return &AdminAnalyticsHandler{analyticsService: analyticsService}
+}
+
+// GetSummary returns new users, new companies, published jobs, submitted applications and
+// hires over a period in time buckets, with deltas against the previous period
+// GET /api/v1/admin/analytics/summary
+func (h *AdminAnalyticsHandler) GetSummary(c *fiber.Ctx) error {
+	var req request.AdminAnalyticsSummaryRequest
+	if err := c.QueryParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidQueryParams)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	// Periods end with today (UTC) so the cached summary is shared for the whole day
+	now := time.Now().UTC()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	if req.To != "" {
+		parsed, err := time.Parse("2006-01-02", req.To)
+		if err != nil {
+			return utils.BadRequestResponse(c, "invalid to date, expected YYYY-MM-DD")
+		}
+		// Include the whole end day
+		to = parsed.AddDate(0, 0, 1)
+	}
+
+	var from time.Time
+	if req.From != "" {
+		parsed, err := time.Parse("2006-01-02", req.From)
+		if err != nil {
+			return utils.BadRequestResponse(c, "invalid from date, expected YYYY-MM-DD")
+		}
+		from = parsed
+	} else {
+		period := req.Period
+		if period == "" {
+			period = "30d"
+		}
+		from = to.AddDate(0, 0, -analyticsPeriodDays[period])
+	}
+
+	summary, err := h.analyticsService.GetPlatformSummary(c.UserContext(), from, to, req.Interval)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, summary)
+}
@@ -0,0 +1,80 @@
+package admin
+
+import (
+	"time"
+
+	"keerja-backend/internal/domain/audit"
+	"keerja-backend/internal/dto/mapper"
+	"keerja-backend/internal/dto/request"
+	"keerja-backend/internal/dto/response"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminAuditHandler handles admin audit log endpoints
+type AdminAuditHandler struct {
+	auditService audit.AuditService
+}
+
+// NewAdminAuditHandler creates a new admin audit handler
+func NewAdminAuditHandler(auditService audit.AuditService) *AdminAuditHandler {
+	return &AdminAuditHandler{auditService: auditService}
+}
+
+// GetAuditLogs lists audit log entries filtered by actor, entity and date range, newest first
+// GET /api/v1/admin/audit-logs
+func (h *AdminAuditHandler) GetAuditLogs(c *fiber.Ctx) error {
+	page, limit, err := utils.ParsePagination(c, 10, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
+
+	var req request.AdminGetAuditLogsRequest
+	if err := c.QueryParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidQueryParams)
+	}
+	req.Page, req.Limit = page, limit
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	filter := audit.AuditLogFilter{
+		ActorType:  req.ActorType,
+		ActorID:    req.ActorID,
+		EntityType: req.EntityType,
+		EntityID:   req.EntityID,
+		Action:     req.Action,
+	}
+	if req.CreatedFrom != "" {
+		from, err := time.Parse("2006-01-02", req.CreatedFrom)
+		if err != nil {
+			return utils.BadRequestResponse(c, "invalid created_from date, expected YYYY-MM-DD")
+		}
+		filter.From = &from
+	}
+	if req.CreatedTo != "" {
+		to, err := time.Parse("2006-01-02", req.CreatedTo)
+		if err != nil {
+			return utils.BadRequestResponse(c, "invalid created_to date, expected YYYY-MM-DD")
+		}
+		// Include the whole end day
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+
+	logs, total, err := h.auditService.ListLogs(c.UserContext(), filter, req.Page, req.Limit)
+	if err != nil {
+		return err
+	}
+
+	respLogs := make([]response.AdminAuditLogResponse, 0, len(logs))
+	for i := range logs {
+		respLogs = append(respLogs, mapper.ToAdminAuditLogResponse(&logs[i]))
+	}
+
+	meta := utils.GetPaginationMeta(req.Page, req.Limit, total)
+	return utils.SuccessResponseWithMeta(c, common.MsgFetchedSuccess, response.AdminAuditLogListResponse{Logs: respLogs}, meta)
+}
@@ -21,7 +21,7 @@ func NewAdminAuthHandler(adminAuthService *service.AdminAuthService) *AdminAuthH
 }
 
 func (h *AdminAuthHandler) Login(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var req request.AdminLoginRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -29,7 +29,7 @@ func (h *AdminAuthHandler) Login(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
@@ -56,7 +56,7 @@ func (h *AdminAuthHandler) Login(c *fiber.Ctx) error {
 }
 
 func (h *AdminAuthHandler) Logout(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	adminID := middleware.GetAdminID(c)
 	if adminID == 0 {
@@ -71,7 +71,7 @@ func (h *AdminAuthHandler) Logout(c *fiber.Ctx) error {
 }
 
 func (h *AdminAuthHandler) RefreshToken(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var req request.AdminRefreshTokenRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -79,7 +79,7 @@ func (h *AdminAuthHandler) RefreshToken(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
@@ -104,7 +104,7 @@ func (h *AdminAuthHandler) RefreshToken(c *fiber.Ctx) error {
 }
 
 func (h *AdminAuthHandler) GetProfile(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	adminID := middleware.GetAdminID(c)
 	if adminID == 0 {
@@ -125,7 +125,7 @@ func (h *AdminAuthHandler) GetProfile(c *fiber.Ctx) error {
 }
 
 func (h *AdminAuthHandler) ChangePassword(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	adminID := middleware.GetAdminID(c)
 	if adminID == 0 {
@@ -138,7 +138,7 @@ func (h *AdminAuthHandler) ChangePassword(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
@@ -0,0 +1,100 @@
+package admin
+
+import (
+	"context"
+
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/dto/mapper"
+	"keerja-backend/internal/dto/request"
+	"keerja-backend/internal/dto/response"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminChangeRequestHandler handles admin decisions on company change requests
+type AdminChangeRequestHandler struct {
+	companyService company.CompanyService
+}
+
+// NewAdminChangeRequestHandler creates a new admin change request handler
+func NewAdminChangeRequestHandler(companyService company.CompanyService) *AdminChangeRequestHandler {
+	return &AdminChangeRequestHandler{companyService: companyService}
+}
+
+// GetChangeRequests lists company change requests, pending ones by default
+// GET /api/v1/admin/company-change-requests
+func (h *AdminChangeRequestHandler) GetChangeRequests(c *fiber.Ctx) error {
+	page, limit, err := utils.ParsePagination(c, 10, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
+
+	var req request.AdminGetChangeRequestsRequest
+	if err := c.QueryParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidQueryParams)
+	}
+	req.Page, req.Limit = page, limit
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	requests, total, err := h.companyService.ListChangeRequests(c.UserContext(), req.Status, req.Page, req.Limit)
+	if err != nil {
+		return err
+	}
+
+	respRequests := make([]response.AdminChangeRequestResponse, 0, len(requests))
+	for i := range requests {
+		respRequests = append(respRequests, mapper.ToAdminChangeRequestResponse(&requests[i]))
+	}
+
+	meta := utils.GetPaginationMeta(req.Page, req.Limit, total)
+	return utils.SuccessResponseWithMeta(c, common.MsgFetchedSuccess, response.AdminChangeRequestListResponse{ChangeRequests: respRequests}, meta)
+}
+
+// ApproveChangeRequest applies a change request to its company
+// POST /api/v1/admin/company-change-requests/:id/approve
+func (h *AdminChangeRequestHandler) ApproveChangeRequest(c *fiber.Ctx) error {
+	return h.decide(c, h.companyService.ApproveChangeRequest, "Change request approved and applied")
+}
+
+// RejectChangeRequest rejects a change request
+// POST /api/v1/admin/company-change-requests/:id/reject
+func (h *AdminChangeRequestHandler) RejectChangeRequest(c *fiber.Ctx) error {
+	return h.decide(c, h.companyService.RejectChangeRequest, "Change request rejected")
+}
+
+// decide runs a decision on the change request in the :id param with the optional note from the body
+func (h *AdminChangeRequestHandler) decide(c *fiber.Ctx, action func(ctx context.Context, requestID, decidedBy int64, note *string) error, successMsg string) error {
+	id, err := utils.ParseIDParam(c, "id")
+	if err != nil || id <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	var req request.AdminDecideChangeRequestRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+		}
+	}
+	if req.Note != nil {
+		note := utils.SanitizeString(*req.Note)
+		req.Note = &note
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	adminID := c.Locals("admin_id").(int64)
+
+	if err := action(middleware.AuditContext(c), id, adminID, req.Note); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, successMsg, nil)
+}
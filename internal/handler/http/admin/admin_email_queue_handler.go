@@ -0,0 +1,74 @@
+package admin
+
+import (
+	"strconv"
+
+	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/dto/mapper"
+	"keerja-backend/internal/dto/request"
+	"keerja-backend/internal/dto/response"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminEmailQueueHandler handles admin email queue endpoints
+type AdminEmailQueueHandler struct {
+	queueService email.EmailQueueService
+}
+
+// NewAdminEmailQueueHandler creates a new admin email queue handler
+func NewAdminEmailQueueHandler(queueService email.EmailQueueService) *AdminEmailQueueHandler {
+	return &AdminEmailQueueHandler{queueService: queueService}
+}
+
+// ListEmails lists queued emails by status, dead ones by default, most recently updated first
+// GET /api/v1/admin/email-queue
+func (h *AdminEmailQueueHandler) ListEmails(c *fiber.Ctx) error {
+	page, limit, err := utils.ParsePagination(c, 10, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
+
+	var req request.AdminGetEmailQueueRequest
+	if err := c.QueryParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidQueryParams)
+	}
+	req.Page, req.Limit = page, limit
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+	if req.Status == "" {
+		req.Status = email.QueueStatusDead
+	}
+
+	emails, total, err := h.queueService.ListEmails(c.UserContext(), req.Status, req.Page, req.Limit)
+	if err != nil {
+		return err
+	}
+
+	respEmails := make([]response.AdminQueuedEmailResponse, 0, len(emails))
+	for i := range emails {
+		respEmails = append(respEmails, mapper.ToAdminQueuedEmailResponse(&emails[i]))
+	}
+
+	meta := utils.GetPaginationMeta(req.Page, req.Limit, total)
+	return utils.SuccessResponseWithMeta(c, common.MsgFetchedSuccess, response.AdminQueuedEmailListResponse{Emails: respEmails}, meta)
+}
+
+// RetryEmail queues a dead email to be sent again
+// POST /api/v1/admin/email-queue/:id/retry
+func (h *AdminEmailQueueHandler) RetryEmail(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	if err := h.queueService.RetryDead(c.UserContext(), id); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, "Email queued for retry", nil)
+}
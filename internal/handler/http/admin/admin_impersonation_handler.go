@@ -0,0 +1,76 @@
+package admin
+
+import (
+	"strconv"
+	"time"
+
+	"keerja-backend/internal/domain/admin"
+	"keerja-backend/internal/dto/request"
+	"keerja-backend/internal/dto/response"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminImpersonationHandler handles the endpoints admins use to act as a user
+type AdminImpersonationHandler struct {
+	impersonationService admin.ImpersonationService
+}
+
+// NewAdminImpersonationHandler creates a new admin impersonation handler
+func NewAdminImpersonationHandler(impersonationService admin.ImpersonationService) *AdminImpersonationHandler {
+	return &AdminImpersonationHandler{impersonationService: impersonationService}
+}
+
+// StartImpersonation issues a short-lived, read-only access token for acting as the user
+// POST /api/v1/admin/impersonate/:userId
+func (h *AdminImpersonationHandler) StartImpersonation(c *fiber.Ctx) error {
+	userID, err := strconv.ParseInt(c.Params("userId"), 10, 64)
+	if err != nil || userID <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	var req request.StartImpersonationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	adminID := c.Locals("admin_id").(int64)
+
+	grant, err := h.impersonationService.StartImpersonation(middleware.AuditContext(c), adminID, userID, req.Reason)
+	if err != nil {
+		return err
+	}
+
+	return utils.CreatedResponse(c, "Impersonation started", response.ImpersonationResponse{
+		SessionID:   grant.Session.ID,
+		UserID:      grant.Session.UserID,
+		AccessToken: grant.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(time.Until(grant.Session.ExpiresAt).Seconds()),
+		ExpiresAt:   grant.Session.ExpiresAt,
+	})
+}
+
+// EndImpersonation ends an impersonation session, revoking its token
+// DELETE /api/v1/admin/impersonate/:sessionId
+func (h *AdminImpersonationHandler) EndImpersonation(c *fiber.Ctx) error {
+	sessionID, err := strconv.ParseInt(c.Params("sessionId"), 10, 64)
+	if err != nil || sessionID <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	adminID := c.Locals("admin_id").(int64)
+
+	if err := h.impersonationService.EndImpersonation(middleware.AuditContext(c), adminID, sessionID); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, "Impersonation ended", nil)
+}
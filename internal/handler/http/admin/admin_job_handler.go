@@ -2,11 +2,15 @@ package admin
 
 import (
 	"strconv"
+	"strings"
 
 	"keerja-backend/internal/domain/admin"
 	"keerja-backend/internal/domain/job"
 	"keerja-backend/internal/dto/mapper"
+	"keerja-backend/internal/dto/request"
+	"keerja-backend/internal/dto/response"
 	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/middleware"
 	"keerja-backend/internal/utils"
 
 	"github.com/gofiber/fiber/v2"
@@ -21,7 +25,7 @@ func NewAdminJobHandler(adminJobService admin.AdminJobService) *AdminJobHandler
 }
 
 func (h *AdminJobHandler) ApproveJob(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := middleware.AuditContext(c)
 
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil || id <= 0 {
@@ -31,26 +35,15 @@ func (h *AdminJobHandler) ApproveJob(c *fiber.Ctx) error {
 	var req job.ApproveJobRequest
 	_ = c.BodyParser(&req)
 	if err := utils.ValidateStruct(&req); err != nil {
-		errs := utils.FormatValidationErrors(err)
+		errs := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
 	}
 
-	approvedJob, err := h.adminJobService.ApproveJob(ctx, id)
-	if err != nil {
-		errMsg := err.Error()
-
-		if errMsg == "job not found: record not found" {
-			return utils.NotFoundResponse(c, "Job not found")
-		}
+	adminID := c.Locals("admin_id").(int64)
 
-		if errMsg == "only jobs with pending_review status can be approved (current status: draft)" ||
-			errMsg == "only jobs with pending_review status can be approved (current status: published)" ||
-			errMsg == "only jobs with pending_review status can be approved (current status: closed)" ||
-			errMsg == "only jobs with pending_review status can be approved (current status: expired)" {
-			return utils.ErrorResponse(c, fiber.StatusConflict, "Job status conflict", errMsg)
-		}
-
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to approve job", err.Error())
+	approvedJob, err := h.adminJobService.ApproveJob(ctx, id, adminID, req.Notes)
+	if err != nil {
+		return err
 	}
 
 	resp := mapper.ToJobDetailResponse(approvedJob.(*job.Job))
@@ -58,7 +51,7 @@ func (h *AdminJobHandler) ApproveJob(c *fiber.Ctx) error {
 }
 
 func (h *AdminJobHandler) RejectJob(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := middleware.AuditContext(c)
 
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil || id <= 0 {
@@ -70,28 +63,109 @@ func (h *AdminJobHandler) RejectJob(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
 	}
 	if err := utils.ValidateStruct(&req); err != nil {
-		errs := utils.FormatValidationErrors(err)
+		errs := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
 	}
 
-	rejectedJob, err := h.adminJobService.RejectJob(ctx, id, req.Reason)
+	adminID := c.Locals("admin_id").(int64)
+
+	rejectedJob, err := h.adminJobService.RejectJob(ctx, id, adminID, req.Reason)
 	if err != nil {
-		errMsg := err.Error()
+		return err
+	}
+
+	resp := mapper.ToJobDetailResponse(rejectedJob.(*job.Job))
+	return utils.SuccessResponse(c, "Job rejected and reverted to draft status", resp)
+}
+
+// FeatureJob features a published job, optionally from featured_from and until featured_until
+// PUT /api/v1/admin/jobs/:id/feature
+func (h *AdminJobHandler) FeatureJob(c *fiber.Ctx) error {
+	ctx := middleware.AuditContext(c)
 
-		if errMsg == "job not found: record not found" {
-			return utils.NotFoundResponse(c, "Job not found")
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	var req admin.AdminFeatureJobRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.BadRequestResponse(c, common.ErrInvalidRequest)
 		}
+	}
+
+	adminID := c.Locals("admin_id").(int64)
+
+	featuredJob, err := h.adminJobService.FeatureJob(ctx, id, adminID, &req)
+	if err != nil {
+		return err
+	}
+
+	resp := mapper.ToJobDetailResponse(featuredJob.(*job.Job))
+	return utils.SuccessResponse(c, "Job featured successfully", resp)
+}
 
-		if errMsg == "only jobs with pending_review status can be rejected (current status: draft)" ||
-			errMsg == "only jobs with pending_review status can be rejected (current status: published)" ||
-			errMsg == "only jobs with pending_review status can be rejected (current status: closed)" ||
-			errMsg == "only jobs with pending_review status can be rejected (current status: expired)" {
-			return utils.ErrorResponse(c, fiber.StatusConflict, "Job status conflict", errMsg)
+// UnfeatureJob removes a job from the curated featured jobs
+// DELETE /api/v1/admin/jobs/:id/feature
+func (h *AdminJobHandler) UnfeatureJob(c *fiber.Ctx) error {
+	ctx := middleware.AuditContext(c)
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	adminID := c.Locals("admin_id").(int64)
+
+	unfeaturedJob, err := h.adminJobService.UnfeatureJob(ctx, id, adminID)
+	if err != nil {
+		return err
+	}
+
+	resp := mapper.ToJobDetailResponse(unfeaturedJob.(*job.Job))
+	return utils.SuccessResponse(c, "Job unfeatured successfully", resp)
+}
+
+// GetPendingJobs lists jobs waiting for admin review
+// GET /api/v1/admin/jobs/pending
+func (h *AdminJobHandler) GetPendingJobs(c *fiber.Ctx) error {
+	page, limit, err := utils.ParsePagination(c, 10, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
+
+	var req request.AdminGetPendingJobsRequest
+	if err := c.QueryParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidQueryParams)
+	}
+	req.Page, req.Limit = page, limit
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	jobs, total, err := h.adminJobService.GetPendingJobs(c.UserContext(), &admin.AdminPendingJobsRequest{
+		Page:          req.Page,
+		Limit:         req.Limit,
+		CompanyID:     req.CompanyID,
+		SubmittedFrom: req.SubmittedFrom,
+		SubmittedTo:   req.SubmittedTo,
+	})
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid submitted_") {
+			return utils.BadRequestResponse(c, err.Error())
 		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get pending jobs", err.Error())
+	}
 
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to reject job", err.Error())
+	respJobs := make([]response.JobResponse, 0, len(jobs))
+	for _, item := range jobs {
+		if jobResp := mapper.ToJobResponse(item.(*job.Job)); jobResp != nil {
+			respJobs = append(respJobs, *jobResp)
+		}
 	}
 
-	resp := mapper.ToJobDetailResponse(rejectedJob.(*job.Job))
-	return utils.SuccessResponse(c, "Job rejected and reverted to draft status", resp)
+	meta := utils.GetPaginationMeta(req.Page, req.Limit, total)
+	return utils.SuccessResponseWithMeta(c, common.MsgFetchedSuccess, response.JobListResponse{Jobs: respJobs}, meta)
 }
@@ -0,0 +1,80 @@
+package admin
+
+import (
+	"strconv"
+
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/dto/request"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminJobQuotaHandler handles admin endpoints for company plans and their published job limits
+type AdminJobQuotaHandler struct {
+	quotaService company.QuotaService
+}
+
+// NewAdminJobQuotaHandler creates a new admin job quota handler
+func NewAdminJobQuotaHandler(quotaService company.QuotaService) *AdminJobQuotaHandler {
+	return &AdminJobQuotaHandler{quotaService: quotaService}
+}
+
+// ListPlanQuotas lists the published job limit in effect for each plan
+// GET /api/v1/admin/job-quotas
+func (h *AdminJobQuotaHandler) ListPlanQuotas(c *fiber.Ctx) error {
+	quotas, err := h.quotaService.ListPlanLimits(c.UserContext())
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, fiber.Map{"quotas": quotas})
+}
+
+// UpdatePlanQuota overrides a plan's published job limit; it applies to the next publish
+// PUT /api/v1/admin/job-quotas/:plan
+func (h *AdminJobQuotaHandler) UpdatePlanQuota(c *fiber.Ctx) error {
+	var req request.UpdatePlanQuotaRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	adminID := c.Locals("admin_id").(int64)
+
+	quota, err := h.quotaService.UpdatePlanLimit(middleware.AuditContext(c), c.Params("plan"), req.MaxPublishedJobs, adminID)
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, "Plan quota updated successfully", quota)
+}
+
+// SetCompanyPlan moves a company to a plan and returns its job quota on the new plan
+// PUT /api/v1/admin/companies/:id/plan
+func (h *AdminJobQuotaHandler) SetCompanyPlan(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	var req request.SetCompanyPlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	adminID := c.Locals("admin_id").(int64)
+
+	quota, err := h.quotaService.SetCompanyPlan(middleware.AuditContext(c), id, req.Plan, adminID)
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, "Company plan updated successfully", quota)
+}
@@ -0,0 +1,98 @@
+package admin
+
+import (
+	"strconv"
+
+	"keerja-backend/internal/domain/notification"
+	"keerja-backend/internal/dto/mapper"
+	"keerja-backend/internal/dto/request"
+	"keerja-backend/internal/dto/response"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminPushHandler handles admin push campaign endpoints
+type AdminPushHandler struct {
+	campaignService notification.PushCampaignService
+}
+
+// NewAdminPushHandler creates a new admin push handler
+func NewAdminPushHandler(campaignService notification.PushCampaignService) *AdminPushHandler {
+	return &AdminPushHandler{campaignService: campaignService}
+}
+
+// CreateCampaign schedules a push campaign to a topic or a filtered audience
+// POST /api/v1/admin/push/campaigns
+func (h *AdminPushHandler) CreateCampaign(c *fiber.Ctx) error {
+	var req request.CreatePushCampaignRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	domainReq := &notification.CreatePushCampaignRequest{
+		Title:       req.Title,
+		Body:        req.Body,
+		Data:        req.Data,
+		Topic:       req.Topic,
+		ScheduledAt: req.ScheduledAt,
+		CreatedBy:   c.Locals("admin_id").(int64),
+	}
+	if req.Filter != nil {
+		domainReq.Segment = &notification.PushSegment{
+			Role:              req.Filter.Role,
+			ProvinceID:        req.Filter.ProvinceID,
+			FollowedCompanyID: req.Filter.FollowedCompanyID,
+		}
+	}
+
+	campaign, err := h.campaignService.CreateCampaign(c.UserContext(), domainReq)
+	if err != nil {
+		return err
+	}
+
+	return utils.CreatedResponse(c, "Push campaign scheduled successfully", mapper.ToPushCampaignResponse(campaign))
+}
+
+// ListCampaigns lists push campaigns with their delivery stats, newest first
+// GET /api/v1/admin/push/campaigns
+func (h *AdminPushHandler) ListCampaigns(c *fiber.Ctx) error {
+	page, limit, err := utils.ParsePagination(c, 20, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
+
+	campaigns, total, err := h.campaignService.ListCampaigns(c.UserContext(), page, limit)
+	if err != nil {
+		return err
+	}
+
+	respCampaigns := make([]response.PushCampaignResponse, 0, len(campaigns))
+	for i := range campaigns {
+		respCampaigns = append(respCampaigns, mapper.ToPushCampaignResponse(&campaigns[i]))
+	}
+
+	meta := utils.GetPaginationMeta(page, limit, total)
+	return utils.SuccessResponseWithMeta(c, common.MsgFetchedSuccess, response.PushCampaignListResponse{Campaigns: respCampaigns}, meta)
+}
+
+// GetCampaign returns a push campaign with its delivery stats
+// GET /api/v1/admin/push/campaigns/:id
+func (h *AdminPushHandler) GetCampaign(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	campaign, err := h.campaignService.GetCampaign(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, mapper.ToPushCampaignResponse(campaign))
+}
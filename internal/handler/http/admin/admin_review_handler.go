@@ -0,0 +1,121 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/dto/mapper"
+	"keerja-backend/internal/dto/request"
+	"keerja-backend/internal/dto/response"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminReviewHandler handles admin company review moderation endpoints
+type AdminReviewHandler struct {
+	companyService company.CompanyService
+}
+
+// NewAdminReviewHandler creates a new admin review handler
+func NewAdminReviewHandler(companyService company.CompanyService) *AdminReviewHandler {
+	return &AdminReviewHandler{companyService: companyService}
+}
+
+// GetReviews lists company reviews for moderation, pending ones by default
+// GET /api/v1/admin/reviews
+func (h *AdminReviewHandler) GetReviews(c *fiber.Ctx) error {
+	page, limit, err := utils.ParsePagination(c, 10, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
+
+	var req request.AdminGetReviewsRequest
+	if err := c.QueryParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidQueryParams)
+	}
+	req.Page, req.Limit = page, limit
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	filter := &company.ReviewModerationFilter{CompanyID: req.CompanyID}
+	if req.ReviewerType != "" {
+		filter.ReviewerType = &req.ReviewerType
+	}
+	if req.CreatedFrom != "" {
+		from, err := time.Parse("2006-01-02", req.CreatedFrom)
+		if err != nil {
+			return utils.BadRequestResponse(c, "invalid created_from date, expected YYYY-MM-DD")
+		}
+		filter.CreatedFrom = &from
+	}
+	if req.CreatedTo != "" {
+		to, err := time.Parse("2006-01-02", req.CreatedTo)
+		if err != nil {
+			return utils.BadRequestResponse(c, "invalid created_to date, expected YYYY-MM-DD")
+		}
+		// Include the whole end day
+		to = to.AddDate(0, 0, 1)
+		filter.CreatedTo = &to
+	}
+
+	reviews, total, err := h.companyService.GetPendingReviews(c.UserContext(), req.Status, filter, req.Page, req.Limit)
+	if err != nil {
+		if errors.Is(err, company.ErrInvalidReviewStatus) {
+			return utils.BadRequestResponse(c, err.Error())
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get reviews", err.Error())
+	}
+
+	respReviews := make([]response.AdminReviewResponse, 0, len(reviews))
+	for i := range reviews {
+		respReviews = append(respReviews, mapper.ToAdminReviewResponse(&reviews[i]))
+	}
+
+	meta := utils.GetPaginationMeta(req.Page, req.Limit, total)
+	return utils.SuccessResponseWithMeta(c, common.MsgFetchedSuccess, response.AdminReviewListResponse{Reviews: respReviews}, meta)
+}
+
+// ApproveReview publishes a review
+// POST /api/v1/admin/reviews/:id/approve
+func (h *AdminReviewHandler) ApproveReview(c *fiber.Ctx) error {
+	return h.moderate(c, h.companyService.ApproveReview, "Review approved successfully", "Failed to approve review")
+}
+
+// RejectReview rejects a review
+// POST /api/v1/admin/reviews/:id/reject
+func (h *AdminReviewHandler) RejectReview(c *fiber.Ctx) error {
+	return h.moderate(c, h.companyService.RejectReview, "Review rejected successfully", "Failed to reject review")
+}
+
+// HideReview hides a review from public listings
+// POST /api/v1/admin/reviews/:id/hide
+func (h *AdminReviewHandler) HideReview(c *fiber.Ctx) error {
+	return h.moderate(c, h.companyService.HideReview, "Review hidden successfully", "Failed to hide review")
+}
+
+// moderate runs a moderation action on the review in the :id param
+func (h *AdminReviewHandler) moderate(c *fiber.Ctx, action func(ctx context.Context, reviewID, moderatedBy int64) error, successMsg, failMsg string) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	adminID := c.Locals("admin_id").(int64)
+
+	if err := action(middleware.AuditContext(c), id, adminID); err != nil {
+		if errors.Is(err, company.ErrReviewNotFound) {
+			return utils.NotFoundResponse(c, common.ErrReviewNotFound)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, failMsg, err.Error())
+	}
+
+	return utils.SuccessResponse(c, successMsg, nil)
+}
@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"strconv"
+
+	"keerja-backend/internal/domain/admin"
+	"keerja-backend/internal/dto/mapper"
+	"keerja-backend/internal/dto/request"
+	"keerja-backend/internal/dto/response"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminRoleHandler handles admin role and permission management endpoints
+type AdminRoleHandler struct {
+	permissionService admin.AdminPermissionService
+}
+
+// NewAdminRoleHandler creates a new admin role handler
+func NewAdminRoleHandler(permissionService admin.AdminPermissionService) *AdminRoleHandler {
+	return &AdminRoleHandler{permissionService: permissionService}
+}
+
+// ListRoles lists the admin roles with the permissions each grants
+// GET /api/v1/admin/roles
+func (h *AdminRoleHandler) ListRoles(c *fiber.Ctx) error {
+	roles, err := h.permissionService.ListRoles(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	respRoles := make([]response.AdminRoleInfo, 0, len(roles))
+	for i := range roles {
+		respRoles = append(respRoles, *mapper.ToAdminRoleInfo(&roles[i]))
+	}
+
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, response.AdminRoleListResponse{
+		Roles:       respRoles,
+		Permissions: admin.AllPermissions,
+	})
+}
+
+// AssignRole gives another admin user a role
+// PUT /api/v1/admin/admins/:id/role
+func (h *AdminRoleHandler) AssignRole(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	var req request.AssignAdminRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	adminID := c.Locals("admin_id").(int64)
+
+	adminUser, err := h.permissionService.AssignRole(middleware.AuditContext(c), adminID, id, req.RoleID)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, "Admin role assigned successfully", mapper.ToAdminProfileResponse(adminUser))
+}
@@ -0,0 +1,61 @@
+package admin
+
+import (
+	"context"
+
+	"keerja-backend/internal/dto/mapper"
+	"keerja-backend/internal/dto/response"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/jobs"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// JobScheduler exposes the background job schedule to admins
+type JobScheduler interface {
+	Schedules(ctx context.Context) ([]jobs.JobSchedule, error)
+	Trigger(jobName string) (*jobs.JobRun, error)
+}
+
+// AdminSchedulerHandler handles admin background job endpoints
+type AdminSchedulerHandler struct {
+	scheduler JobScheduler
+}
+
+// NewAdminSchedulerHandler creates a new admin scheduler handler
+func NewAdminSchedulerHandler(scheduler JobScheduler) *AdminSchedulerHandler {
+	return &AdminSchedulerHandler{scheduler: scheduler}
+}
+
+// GetSchedule lists the registered background jobs with their status and last and next run
+// GET /api/v1/admin/jobs/schedule
+func (h *AdminSchedulerHandler) GetSchedule(c *fiber.Ctx) error {
+	schedules, err := h.scheduler.Schedules(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	respJobs := make([]response.AdminScheduledJobResponse, 0, len(schedules))
+	for i := range schedules {
+		respJobs = append(respJobs, mapper.ToAdminScheduledJobResponse(&schedules[i]))
+	}
+
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, response.AdminJobScheduleResponse{Jobs: respJobs})
+}
+
+// RunJob starts a run of a background job now. The run continues after the response, which
+// is 202 Accepted with the started run.
+// POST /api/v1/admin/jobs/schedule/:name/run
+func (h *AdminSchedulerHandler) RunJob(c *fiber.Ctx) error {
+	run, err := h.scheduler.Trigger(c.Params("name"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(utils.Response{
+		Success: true,
+		Message: "Job run started",
+		Data:    mapper.ToAdminJobRunResponse(run),
+	})
+}
@@ -0,0 +1,78 @@
+package admin
+
+import (
+	"strconv"
+
+	"keerja-backend/internal/domain/webhook"
+	"keerja-backend/internal/dto/mapper"
+	"keerja-backend/internal/dto/request"
+	"keerja-backend/internal/dto/response"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminWebhookHandler handles admin endpoints for company webhook deliveries
+type AdminWebhookHandler struct {
+	webhookService webhook.WebhookService
+}
+
+// NewAdminWebhookHandler creates a new admin webhook handler
+func NewAdminWebhookHandler(webhookService webhook.WebhookService) *AdminWebhookHandler {
+	return &AdminWebhookHandler{webhookService: webhookService}
+}
+
+// ListDeliveries lists webhook deliveries of every company, newest first
+// GET /api/v1/admin/webhook-deliveries
+func (h *AdminWebhookHandler) ListDeliveries(c *fiber.Ctx) error {
+	page, limit, err := utils.ParsePagination(c, 10, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
+
+	var req request.AdminGetWebhookDeliveriesRequest
+	if err := c.QueryParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidQueryParams)
+	}
+	req.Page, req.Limit = page, limit
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	filter := webhook.DeliveryFilter{
+		CompanyID: req.CompanyID,
+		WebhookID: req.WebhookID,
+		Status:    req.Status,
+		Event:     req.Event,
+	}
+	deliveries, total, err := h.webhookService.ListDeliveries(c.UserContext(), filter, req.Page, req.Limit)
+	if err != nil {
+		return err
+	}
+
+	respDeliveries := make([]response.WebhookDeliveryResponse, 0, len(deliveries))
+	for i := range deliveries {
+		respDeliveries = append(respDeliveries, mapper.ToWebhookDeliveryResponse(&deliveries[i]))
+	}
+
+	meta := utils.GetPaginationMeta(req.Page, req.Limit, total)
+	return utils.SuccessResponseWithMeta(c, common.MsgFetchedSuccess, response.WebhookDeliveryListResponse{Deliveries: respDeliveries}, meta)
+}
+
+// Redeliver sends the event of a delivery again
+// POST /api/v1/admin/webhook-deliveries/:id/redeliver
+func (h *AdminWebhookHandler) Redeliver(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	d, err := h.webhookService.Redeliver(c.UserContext(), id, webhook.DeliveryFilter{})
+	if err != nil {
+		return err
+	}
+
+	return utils.CreatedResponse(c, "Delivery queued", mapper.ToWebhookDeliveryResponse(d))
+}
@@ -0,0 +1,56 @@
+package admin
+
+import (
+	"keerja-backend/internal/domain/notification"
+	"keerja-backend/internal/dto/mapper"
+	"keerja-backend/internal/dto/request"
+	"keerja-backend/internal/dto/response"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminWhatsAppHandler handles admin WhatsApp delivery endpoints
+type AdminWhatsAppHandler struct {
+	deliveryService notification.WhatsAppDeliveryService
+}
+
+// NewAdminWhatsAppHandler creates a new admin WhatsApp handler
+func NewAdminWhatsAppHandler(deliveryService notification.WhatsAppDeliveryService) *AdminWhatsAppHandler {
+	return &AdminWhatsAppHandler{deliveryService: deliveryService}
+}
+
+// ListDeliveries lists the WhatsApp messages sent to users, optionally of one user or with
+// one status, newest first
+// GET /api/v1/admin/whatsapp-deliveries
+func (h *AdminWhatsAppHandler) ListDeliveries(c *fiber.Ctx) error {
+	page, limit, err := utils.ParsePagination(c, 20, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
+
+	var req request.AdminGetWhatsAppDeliveriesRequest
+	if err := c.QueryParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidQueryParams)
+	}
+	req.Page, req.Limit = page, limit
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	filter := notification.WhatsAppDeliveryFilter{UserID: req.UserID, Status: req.Status}
+	deliveries, total, err := h.deliveryService.ListDeliveries(c.UserContext(), filter, req.Page, req.Limit)
+	if err != nil {
+		return err
+	}
+
+	respDeliveries := make([]response.AdminWhatsAppDeliveryResponse, 0, len(deliveries))
+	for i := range deliveries {
+		respDeliveries = append(respDeliveries, mapper.ToAdminWhatsAppDeliveryResponse(&deliveries[i]))
+	}
+
+	meta := utils.GetPaginationMeta(req.Page, req.Limit, total)
+	return utils.SuccessResponseWithMeta(c, common.MsgFetchedSuccess, response.AdminWhatsAppDeliveryListResponse{Deliveries: respDeliveries}, meta)
+}
@@ -1,12 +1,20 @@
 package admin
 
 import (
+	"errors"
+	"fmt"
 	"strconv"
+	"time"
 
 	"keerja-backend/internal/domain/admin"
+	"keerja-backend/internal/domain/company"
 	"keerja-backend/internal/dto/mapper"
 	"keerja-backend/internal/dto/request"
 	"keerja-backend/internal/dto/response"
+	"keerja-backend/internal/handler/http/common"
+	uploadhandler "keerja-backend/internal/handler/http/upload"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/service"
 	"keerja-backend/internal/utils"
 
 	"github.com/gofiber/fiber/v2"
@@ -15,12 +23,15 @@ import (
 // CompanyHandler handles admin company management endpoints
 type CompanyHandler struct {
 	adminCompanyService admin.AdminCompanyService
+	signer              *service.DownloadSigner
 }
 
-// NewCompanyHandler creates a new admin company handler
-func NewCompanyHandler(adminCompanyService admin.AdminCompanyService) *CompanyHandler {
+// NewCompanyHandler creates a new admin company handler; signer signs the download links of
+// company documents
+func NewCompanyHandler(adminCompanyService admin.AdminCompanyService, signer *service.DownloadSigner) *CompanyHandler {
 	return &CompanyHandler{
 		adminCompanyService: adminCompanyService,
+		signer:              signer,
 	}
 }
 
@@ -55,27 +66,44 @@ func (h *CompanyHandler) ListCompanies(c *fiber.Ctx) error {
 		req.SortOrder = "desc"
 	}
 
-	// Convert to service request
-	serviceReq := &admin.AdminCompanyListRequest{
+	// Convert to service filter
+	filter := &admin.AdminCompanyFilter{
 		Page:               req.Page,
 		Limit:              req.Limit,
 		Search:             req.Search,
-		Status:             req.Status,
+		Verified:           req.Verified,
+		IsActive:           req.IsActive,
+		Deleted:            req.Deleted,
 		VerificationStatus: req.VerificationStatus,
+		DocumentStatus:     req.DocumentStatus,
+		DocumentType:       req.DocumentType,
+		HasPublishedJobs:   req.HasPublishedJobs,
 		IndustryID:         req.IndustryID,
 		CompanySizeID:      req.CompanySizeID,
 		ProvinceID:         req.ProvinceID,
 		CityID:             req.CityID,
-		Verified:           req.Verified,
-		IsActive:           req.IsActive,
-		CreatedFrom:        req.CreatedFrom,
-		CreatedTo:          req.CreatedTo,
 		SortBy:             req.SortBy,
 		SortOrder:          req.SortOrder,
 	}
+	if req.CreatedFrom != "" {
+		from, err := time.Parse("2006-01-02", req.CreatedFrom)
+		if err != nil {
+			return utils.BadRequestResponse(c, "invalid created_from date, expected YYYY-MM-DD")
+		}
+		filter.CreatedFrom = &from
+	}
+	if req.CreatedTo != "" {
+		to, err := time.Parse("2006-01-02", req.CreatedTo)
+		if err != nil {
+			return utils.BadRequestResponse(c, "invalid created_to date, expected YYYY-MM-DD")
+		}
+		// Include the whole end day
+		to = to.AddDate(0, 0, 1)
+		filter.CreatedTo = &to
+	}
 
 	// Call service
-	result, err := h.adminCompanyService.ListCompanies(c.Context(), serviceReq)
+	result, err := h.adminCompanyService.ListCompaniesAdmin(c.UserContext(), filter)
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to fetch companies", err.Error())
 	}
@@ -114,7 +142,7 @@ func (h *CompanyHandler) GetCompanyDetail(c *fiber.Ctx) error {
 	}
 
 	// Call service
-	detail, err := h.adminCompanyService.GetCompanyDetail(c.Context(), companyID)
+	detail, err := h.adminCompanyService.GetCompanyDetail(c.UserContext(), companyID)
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusNotFound, "Company not found", err.Error())
 	}
@@ -122,6 +150,12 @@ func (h *CompanyHandler) GetCompanyDetail(c *fiber.Ctx) error {
 	// Map to response
 	resp := mapper.ToAdminCompanyDetailResponse(detail)
 
+	// Documents are private; hand out short-lived signed links instead of their paths
+	for i, doc := range detail.Documents {
+		fileName := uploadhandler.DownloadFileName(fmt.Sprintf("%s-%d", doc.DocumentType, doc.ID), doc.FilePath)
+		resp.Documents[i].FileURL, _ = h.signer.URL(c.BaseURL(), doc.FilePath, fileName)
+	}
+
 	return utils.SuccessResponse(c, "Company detail retrieved successfully", resp)
 }
 
@@ -149,7 +183,7 @@ func (h *CompanyHandler) UpdateCompanyStatus(c *fiber.Ctx) error {
 	}
 
 	// Additional validation: rejection_reason required when status is rejected
-	if req.Status == "rejected" && (req.RejectionReason == nil || *req.RejectionReason == "") {
+	if req.Status == company.VerificationStatusRejected && (req.RejectionReason == nil || *req.RejectionReason == "") {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Validation failed", "rejection_reason is required when status is rejected")
 	}
 
@@ -165,7 +199,7 @@ func (h *CompanyHandler) UpdateCompanyStatus(c *fiber.Ctx) error {
 	}
 
 	// Call service
-	err = h.adminCompanyService.UpdateCompanyStatus(c.Context(), companyID, serviceReq, adminID)
+	err = h.adminCompanyService.UpdateCompanyStatus(middleware.AuditContext(c), companyID, serviceReq, adminID)
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update company status", err.Error())
 	}
@@ -225,7 +259,7 @@ func (h *CompanyHandler) UpdateCompany(c *fiber.Ctx) error {
 	}
 
 	// Call service
-	err = h.adminCompanyService.UpdateCompany(c.Context(), companyID, serviceReq, adminID)
+	err = h.adminCompanyService.UpdateCompany(c.UserContext(), companyID, serviceReq, adminID)
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update company", err.Error())
 	}
@@ -277,11 +311,13 @@ func (h *CompanyHandler) DeleteCompany(c *fiber.Ctx) error {
 	}
 
 	// Call service
-	err = h.adminCompanyService.DeleteCompany(c.Context(), companyID, serviceReq, adminID)
+	err = h.adminCompanyService.DeleteCompany(middleware.AuditContext(c), companyID, serviceReq, adminID)
 	if err != nil {
-		// Check if error is due to active jobs
-		if err.Error() == "company has active jobs" && !req.Force {
+		switch {
+		case errors.Is(err, company.ErrCompanyHasActiveJobs):
 			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Cannot delete company with active jobs", "Use force=true to delete anyway")
+		case errors.Is(err, company.ErrCompanyNotFound):
+			return utils.NotFoundResponse(c, common.ErrCompanyNotFound)
 		}
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to delete company", err.Error())
 	}
@@ -293,6 +329,32 @@ func (h *CompanyHandler) DeleteCompany(c *fiber.Ctx) error {
 	})
 }
 
+// RestoreCompany restores a deleted company
+// POST /api/v1/admin/companies/:id/restore
+func (h *CompanyHandler) RestoreCompany(c *fiber.Ctx) error {
+	companyID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid company ID", err.Error())
+	}
+
+	adminID := c.Locals("admin_id").(int64)
+
+	if err := h.adminCompanyService.RestoreCompany(middleware.AuditContext(c), companyID, adminID); err != nil {
+		switch {
+		case errors.Is(err, company.ErrCompanyNotFound):
+			return utils.NotFoundResponse(c, common.ErrCompanyNotFound)
+		case errors.Is(err, company.ErrCompanyNotDeleted), errors.Is(err, company.ErrCompanySlugTaken):
+			return utils.ConflictResponse(c, err.Error())
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to restore company", err.Error())
+	}
+
+	return utils.SuccessResponse(c, "Company restored successfully", fiber.Map{
+		"company_id":  companyID,
+		"restored_by": adminID,
+	})
+}
+
 // =============================================================================
 // Additional Endpoints
 // =============================================================================
@@ -305,7 +367,7 @@ func (h *CompanyHandler) GetCompanyStats(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid company ID", err.Error())
 	}
 
-	stats, err := h.adminCompanyService.GetCompanyStats(c.Context(), companyID)
+	stats, err := h.adminCompanyService.GetCompanyStats(c.UserContext(), companyID)
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to fetch stats", err.Error())
 	}
@@ -316,7 +378,7 @@ func (h *CompanyHandler) GetCompanyStats(c *fiber.Ctx) error {
 // GetDashboardStats retrieves overall dashboard statistics
 // GET /api/v1/admin/dashboard/stats
 func (h *CompanyHandler) GetDashboardStats(c *fiber.Ctx) error {
-	stats, err := h.adminCompanyService.GetDashboardStats(c.Context())
+	stats, err := h.adminCompanyService.GetDashboardStats(c.UserContext())
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to fetch dashboard stats", err.Error())
 	}
@@ -332,10 +394,12 @@ func (h *CompanyHandler) GetAuditLogs(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid company ID", err.Error())
 	}
 
-	page := c.QueryInt("page", 1)
-	limit := c.QueryInt("limit", 20)
+	page, limit, err := utils.ParsePagination(c, 20, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
 
-	logs, err := h.adminCompanyService.GetAuditLogs(c.Context(), companyID, page, limit)
+	logs, err := h.adminCompanyService.GetAuditLogs(c.UserContext(), companyID, page, limit)
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to fetch audit logs", err.Error())
 	}
@@ -1,10 +1,17 @@
 package admin
 
 import (
+	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"keerja-backend/internal/apperror"
+	"keerja-backend/internal/domain/audit"
 	"keerja-backend/internal/domain/master"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/middleware"
 	"keerja-backend/internal/utils"
 
 	"github.com/gofiber/fiber/v2"
@@ -18,6 +25,9 @@ type AdminMasterDataHandler struct {
 	industryService    master.AdminIndustryService
 	companySizeService master.AdminCompanySizeService
 	jobTypeService     master.AdminJobTypeService
+	skillsService      master.SkillsMasterService
+	transferService    master.AdminMasterDataTransferService
+	auditService       audit.AuditService
 }
 
 // NewAdminMasterDataHandler creates a new admin master data handler
@@ -28,6 +38,9 @@ func NewAdminMasterDataHandler(
 	industryService master.AdminIndustryService,
 	companySizeService master.AdminCompanySizeService,
 	jobTypeService master.AdminJobTypeService,
+	skillsService master.SkillsMasterService,
+	transferService master.AdminMasterDataTransferService,
+	auditService audit.AuditService,
 ) *AdminMasterDataHandler {
 	return &AdminMasterDataHandler{
 		provinceService:    provinceService,
@@ -36,9 +49,21 @@ func NewAdminMasterDataHandler(
 		industryService:    industryService,
 		companySizeService: companySizeService,
 		jobTypeService:     jobTypeService,
+		skillsService:      skillsService,
+		transferService:    transferService,
+		auditService:       auditService,
 	}
 }
 
+// recordChange records a master data mutation in the audit log
+func (h *AdminMasterDataHandler) recordChange(c *fiber.Ctx, action, entityType string, id int64) {
+	h.auditService.Record(middleware.AuditContext(c), audit.AuditLog{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   id,
+	})
+}
+
 // ========================================
 // PROVINCE CRUD ENDPOINTS
 // ========================================
@@ -52,12 +77,12 @@ func (h *AdminMasterDataHandler) CreateProvince(c *fiber.Ctx) error {
 
 	// Validate request
 	if err := utils.ValidateStruct(req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
 	// Check duplicate code
-	exists, err := h.provinceService.CheckDuplicateCode(c.Context(), req.Code)
+	exists, err := h.provinceService.CheckDuplicateCode(c.UserContext(), req.Code)
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, "Failed to check duplicate province code")
 	}
@@ -66,11 +91,13 @@ func (h *AdminMasterDataHandler) CreateProvince(c *fiber.Ctx) error {
 	}
 
 	// Create province
-	province, err := h.provinceService.Create(c.Context(), req)
+	province, err := h.provinceService.Create(c.UserContext(), req)
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, "Failed to create province")
 	}
 
+	h.recordChange(c, audit.ActionMasterDataCreated, audit.EntityProvince, province.ID)
+
 	return utils.CreatedResponse(c, "Province created successfully", province)
 }
 
@@ -83,9 +110,9 @@ func (h *AdminMasterDataHandler) GetProvinces(c *fiber.Ctx) error {
 	var err error
 
 	if activeParam == "true" {
-		provinces, err = h.provinceService.GetActive(c.Context(), search)
+		provinces, err = h.provinceService.GetActive(c.UserContext(), search)
 	} else {
-		provinces, err = h.provinceService.GetAll(c.Context(), search)
+		provinces, err = h.provinceService.GetAll(c.UserContext(), search)
 	}
 
 	if err != nil {
@@ -102,7 +129,7 @@ func (h *AdminMasterDataHandler) GetProvinceByID(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, "Invalid province ID")
 	}
 
-	province, err := h.provinceService.GetByID(c.Context(), id)
+	province, err := h.provinceService.GetByID(c.UserContext(), id)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return utils.NotFoundResponse(c, "Province not found")
@@ -127,19 +154,19 @@ func (h *AdminMasterDataHandler) UpdateProvince(c *fiber.Ctx) error {
 
 	// Validate request
 	if err := utils.ValidateStruct(req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
 	// Check duplicate code if code is being updated
 	if req.Code != "" {
-		exists, err := h.provinceService.CheckDuplicateCode(c.Context(), req.Code)
+		exists, err := h.provinceService.CheckDuplicateCode(c.UserContext(), req.Code)
 		if err != nil {
 			return utils.InternalServerErrorResponse(c, "Failed to check duplicate province code")
 		}
 		if exists {
 			// Verify it's not the same record
-			existing, err := h.provinceService.GetByID(c.Context(), id)
+			existing, err := h.provinceService.GetByID(c.UserContext(), id)
 			if err == nil && existing != nil && existing.Code != req.Code {
 				return utils.ConflictResponse(c, "Province with this code already exists")
 			}
@@ -147,7 +174,7 @@ func (h *AdminMasterDataHandler) UpdateProvince(c *fiber.Ctx) error {
 	}
 
 	// Update province
-	province, err := h.provinceService.Update(c.Context(), id, req)
+	province, err := h.provinceService.Update(c.UserContext(), id, req)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return utils.NotFoundResponse(c, "Province not found")
@@ -155,6 +182,8 @@ func (h *AdminMasterDataHandler) UpdateProvince(c *fiber.Ctx) error {
 		return utils.InternalServerErrorResponse(c, "Failed to update province")
 	}
 
+	h.recordChange(c, audit.ActionMasterDataUpdated, audit.EntityProvince, id)
+
 	return utils.SuccessResponse(c, "Province updated successfully", province)
 }
 
@@ -166,7 +195,7 @@ func (h *AdminMasterDataHandler) DeleteProvince(c *fiber.Ctx) error {
 	}
 
 	// Check references before deleting
-	cities, companies, err := h.provinceService.CountReferences(c.Context(), id)
+	cities, companies, err := h.provinceService.CountReferences(c.UserContext(), id)
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, "Failed to check province references")
 	}
@@ -177,13 +206,15 @@ func (h *AdminMasterDataHandler) DeleteProvince(c *fiber.Ctx) error {
 	}
 
 	// Delete province
-	if err := h.provinceService.Delete(c.Context(), id); err != nil {
+	if err := h.provinceService.Delete(c.UserContext(), id); err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return utils.NotFoundResponse(c, "Province not found")
 		}
 		return utils.InternalServerErrorResponse(c, "Failed to delete province")
 	}
 
+	h.recordChange(c, audit.ActionMasterDataDeleted, audit.EntityProvince, id)
+
 	return utils.SuccessResponse(c, "Province deleted successfully", nil)
 }
 
@@ -200,12 +231,12 @@ func (h *AdminMasterDataHandler) CreateCity(c *fiber.Ctx) error {
 
 	// Validate request
 	if err := utils.ValidateStruct(req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
 	// Check duplicate name in province
-	exists, err := h.cityService.CheckDuplicateNameInProvince(c.Context(), req.Name, req.ProvinceID)
+	exists, err := h.cityService.CheckDuplicateNameInProvince(c.UserContext(), req.Name, req.ProvinceID)
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, "Failed to check duplicate city name")
 	}
@@ -214,11 +245,13 @@ func (h *AdminMasterDataHandler) CreateCity(c *fiber.Ctx) error {
 	}
 
 	// Create city
-	city, err := h.cityService.Create(c.Context(), req)
+	city, err := h.cityService.Create(c.UserContext(), req)
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, "Failed to create city")
 	}
 
+	h.recordChange(c, audit.ActionMasterDataCreated, audit.EntityCity, city.ID)
+
 	return utils.CreatedResponse(c, "City created successfully", city)
 }
 
@@ -239,9 +272,9 @@ func (h *AdminMasterDataHandler) GetCities(c *fiber.Ctx) error {
 
 	var cities []master.CityResponse
 	if activeParam == "true" {
-		cities, err = h.cityService.GetActiveByProvinceID(c.Context(), provinceID, search)
+		cities, err = h.cityService.GetActiveByProvinceID(c.UserContext(), provinceID, search)
 	} else {
-		cities, err = h.cityService.GetByProvinceID(c.Context(), provinceID, search)
+		cities, err = h.cityService.GetByProvinceID(c.UserContext(), provinceID, search)
 	}
 
 	if err != nil {
@@ -261,7 +294,7 @@ func (h *AdminMasterDataHandler) GetCityByID(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, "Invalid city ID")
 	}
 
-	city, err := h.cityService.GetByID(c.Context(), id)
+	city, err := h.cityService.GetByID(c.UserContext(), id)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return utils.NotFoundResponse(c, "City not found")
@@ -286,7 +319,7 @@ func (h *AdminMasterDataHandler) UpdateCity(c *fiber.Ctx) error {
 
 	// Validate request
 	if err := utils.ValidateStruct(req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
@@ -295,20 +328,20 @@ func (h *AdminMasterDataHandler) UpdateCity(c *fiber.Ctx) error {
 		provinceID := req.ProvinceID
 		if provinceID == nil {
 			// Get existing city to use its province ID
-			existing, err := h.cityService.GetByID(c.Context(), id)
+			existing, err := h.cityService.GetByID(c.UserContext(), id)
 			if err != nil {
 				return utils.NotFoundResponse(c, "City not found")
 			}
 			provinceID = &existing.ProvinceID
 		}
 
-		exists, err := h.cityService.CheckDuplicateNameInProvince(c.Context(), req.Name, *provinceID)
+		exists, err := h.cityService.CheckDuplicateNameInProvince(c.UserContext(), req.Name, *provinceID)
 		if err != nil {
 			return utils.InternalServerErrorResponse(c, "Failed to check duplicate city name")
 		}
 		if exists {
 			// Verify it's not the same record
-			existing, err := h.cityService.GetByID(c.Context(), id)
+			existing, err := h.cityService.GetByID(c.UserContext(), id)
 			if err == nil && existing != nil && existing.Name != req.Name {
 				return utils.ConflictResponse(c, "City with this name already exists in the province")
 			}
@@ -316,7 +349,7 @@ func (h *AdminMasterDataHandler) UpdateCity(c *fiber.Ctx) error {
 	}
 
 	// Update city
-	city, err := h.cityService.Update(c.Context(), id, req)
+	city, err := h.cityService.Update(c.UserContext(), id, req)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return utils.NotFoundResponse(c, "City not found")
@@ -324,6 +357,8 @@ func (h *AdminMasterDataHandler) UpdateCity(c *fiber.Ctx) error {
 		return utils.InternalServerErrorResponse(c, "Failed to update city")
 	}
 
+	h.recordChange(c, audit.ActionMasterDataUpdated, audit.EntityCity, id)
+
 	return utils.SuccessResponse(c, "City updated successfully", city)
 }
 
@@ -335,7 +370,7 @@ func (h *AdminMasterDataHandler) DeleteCity(c *fiber.Ctx) error {
 	}
 
 	// Check references before deleting
-	districts, companies, err := h.cityService.CountReferences(c.Context(), id)
+	districts, companies, err := h.cityService.CountReferences(c.UserContext(), id)
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, "Failed to check city references")
 	}
@@ -346,13 +381,15 @@ func (h *AdminMasterDataHandler) DeleteCity(c *fiber.Ctx) error {
 	}
 
 	// Delete city
-	if err := h.cityService.Delete(c.Context(), id); err != nil {
+	if err := h.cityService.Delete(c.UserContext(), id); err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return utils.NotFoundResponse(c, "City not found")
 		}
 		return utils.InternalServerErrorResponse(c, "Failed to delete city")
 	}
 
+	h.recordChange(c, audit.ActionMasterDataDeleted, audit.EntityCity, id)
+
 	return utils.SuccessResponse(c, "City deleted successfully", nil)
 }
 
@@ -369,12 +406,12 @@ func (h *AdminMasterDataHandler) CreateDistrict(c *fiber.Ctx) error {
 
 	// Validate request
 	if err := utils.ValidateStruct(req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
 	// Check duplicate name in city
-	exists, err := h.districtService.CheckDuplicateNameInCity(c.Context(), req.Name, req.CityID)
+	exists, err := h.districtService.CheckDuplicateNameInCity(c.UserContext(), req.Name, req.CityID)
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, "Failed to check duplicate district name")
 	}
@@ -383,11 +420,13 @@ func (h *AdminMasterDataHandler) CreateDistrict(c *fiber.Ctx) error {
 	}
 
 	// Create district
-	district, err := h.districtService.Create(c.Context(), req)
+	district, err := h.districtService.Create(c.UserContext(), req)
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, "Failed to create district")
 	}
 
+	h.recordChange(c, audit.ActionMasterDataCreated, audit.EntityDistrict, district.ID)
+
 	return utils.CreatedResponse(c, "District created successfully", district)
 }
 
@@ -408,9 +447,9 @@ func (h *AdminMasterDataHandler) GetDistricts(c *fiber.Ctx) error {
 
 	var districts []master.DistrictResponse
 	if activeParam == "true" {
-		districts, err = h.districtService.GetActiveByCityID(c.Context(), cityID, search)
+		districts, err = h.districtService.GetActiveByCityID(c.UserContext(), cityID, search)
 	} else {
-		districts, err = h.districtService.GetByCityID(c.Context(), cityID, search)
+		districts, err = h.districtService.GetByCityID(c.UserContext(), cityID, search)
 	}
 
 	if err != nil {
@@ -430,7 +469,7 @@ func (h *AdminMasterDataHandler) GetDistrictByID(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, "Invalid district ID")
 	}
 
-	district, err := h.districtService.GetByID(c.Context(), id)
+	district, err := h.districtService.GetByID(c.UserContext(), id)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return utils.NotFoundResponse(c, "District not found")
@@ -455,7 +494,7 @@ func (h *AdminMasterDataHandler) UpdateDistrict(c *fiber.Ctx) error {
 
 	// Validate request
 	if err := utils.ValidateStruct(req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
@@ -464,20 +503,20 @@ func (h *AdminMasterDataHandler) UpdateDistrict(c *fiber.Ctx) error {
 		cityID := req.CityID
 		if cityID == nil {
 			// Get existing district to use its city ID
-			existing, err := h.districtService.GetByID(c.Context(), id)
+			existing, err := h.districtService.GetByID(c.UserContext(), id)
 			if err != nil {
 				return utils.NotFoundResponse(c, "District not found")
 			}
 			cityID = &existing.CityID
 		}
 
-		exists, err := h.districtService.CheckDuplicateNameInCity(c.Context(), req.Name, *cityID)
+		exists, err := h.districtService.CheckDuplicateNameInCity(c.UserContext(), req.Name, *cityID)
 		if err != nil {
 			return utils.InternalServerErrorResponse(c, "Failed to check duplicate district name")
 		}
 		if exists {
 			// Verify it's not the same record
-			existing, err := h.districtService.GetByID(c.Context(), id)
+			existing, err := h.districtService.GetByID(c.UserContext(), id)
 			if err == nil && existing != nil && existing.Name != req.Name {
 				return utils.ConflictResponse(c, "District with this name already exists in the city")
 			}
@@ -485,7 +524,7 @@ func (h *AdminMasterDataHandler) UpdateDistrict(c *fiber.Ctx) error {
 	}
 
 	// Update district
-	district, err := h.districtService.Update(c.Context(), id, req)
+	district, err := h.districtService.Update(c.UserContext(), id, req)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return utils.NotFoundResponse(c, "District not found")
@@ -493,36 +532,86 @@ func (h *AdminMasterDataHandler) UpdateDistrict(c *fiber.Ctx) error {
 		return utils.InternalServerErrorResponse(c, "Failed to update district")
 	}
 
+	h.recordChange(c, audit.ActionMasterDataUpdated, audit.EntityDistrict, id)
+
 	return utils.SuccessResponse(c, "District updated successfully", district)
 }
 
-// DeleteDistrict handles DELETE /api/v1/admin/master/districts/:id
+// DeleteDistrict handles DELETE /api/v1/admin/master/districts/:id. Districts still referenced
+// by companies, addresses, profiles or jobs cannot be deleted; deactivate or merge them instead.
 func (h *AdminMasterDataHandler) DeleteDistrict(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil || id <= 0 {
 		return utils.BadRequestResponse(c, "Invalid district ID")
 	}
 
-	// Check references before deleting
-	companies, err := h.districtService.CountCompanyReferences(c.Context(), id)
-	if err != nil {
-		return utils.InternalServerErrorResponse(c, "Failed to check district references")
+	// Delete district
+	if err := h.districtService.Delete(c.UserContext(), id); err != nil {
+		if _, ok := apperror.As(err); ok {
+			return err
+		}
+		return utils.InternalServerErrorResponse(c, "Failed to delete district")
 	}
 
-	if companies > 0 {
-		return utils.ConflictResponse(c,
-			"Cannot delete district: it is still referenced by companies")
+	h.recordChange(c, audit.ActionMasterDataDeleted, audit.EntityDistrict, id)
+
+	return utils.SuccessResponse(c, "District deleted successfully", nil)
+}
+
+// GetDistrictUsage handles GET /api/v1/admin/master-data/districts/:id/usage and counts the
+// companies, company addresses, user profiles, jobs and change requests referencing a district
+func (h *AdminMasterDataHandler) GetDistrictUsage(c *fiber.Ctx) error {
+	id, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, "Invalid district ID")
 	}
 
-	// Delete district
-	if err := h.districtService.Delete(c.Context(), id); err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return utils.NotFoundResponse(c, "District not found")
+	usage, err := h.districtService.GetUsage(c.UserContext(), id)
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return err
 		}
-		return utils.InternalServerErrorResponse(c, "Failed to delete district")
+		return utils.InternalServerErrorResponse(c, "Failed to check district usage")
 	}
 
-	return utils.SuccessResponse(c, "District deleted successfully", nil)
+	return utils.SuccessResponse(c, "District usage retrieved successfully", usage)
+}
+
+// MergeDistrict handles POST /api/v1/admin/master/districts/:id/merge-into/:targetId. Companies,
+// addresses, profiles and change requests referencing the district move to the target, e.g.
+// after a boundary change, and the district is deactivated.
+func (h *AdminMasterDataHandler) MergeDistrict(c *fiber.Ctx) error {
+	sourceID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, "Invalid district ID")
+	}
+	targetID, err := utils.ParseIDParam(c, "targetId")
+	if err != nil {
+		return utils.BadRequestResponse(c, "Invalid target district ID")
+	}
+
+	result, err := h.districtService.Merge(c.UserContext(), sourceID, targetID)
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return err
+		}
+		return utils.InternalServerErrorResponse(c, "Failed to merge district")
+	}
+
+	h.auditService.Record(middleware.AuditContext(c), audit.AuditLog{
+		Action:     audit.ActionDistrictMerged,
+		EntityType: audit.EntityDistrict,
+		EntityID:   sourceID,
+		Metadata: audit.Metadata{
+			"target_id":               targetID,
+			"companies_moved":         result.CompaniesMoved,
+			"company_addresses_moved": result.CompanyAddressesMoved,
+			"user_profiles_moved":     result.UserProfilesMoved,
+			"change_requests_moved":   result.ChangeRequestsMoved,
+		},
+	})
+
+	return utils.SuccessResponse(c, "District merged successfully", result)
 }
 
 // ========================================
@@ -538,12 +627,12 @@ func (h *AdminMasterDataHandler) CreateIndustry(c *fiber.Ctx) error {
 
 	// Validate request
 	if err := utils.ValidateStruct(req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
 	// Check duplicate name
-	exists, err := h.industryService.CheckDuplicateName(c.Context(), req.Name)
+	exists, err := h.industryService.CheckDuplicateName(c.UserContext(), req.Name)
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, "Failed to check duplicate industry name")
 	}
@@ -552,11 +641,13 @@ func (h *AdminMasterDataHandler) CreateIndustry(c *fiber.Ctx) error {
 	}
 
 	// Create industry
-	industry, err := h.industryService.Create(c.Context(), req)
+	industry, err := h.industryService.Create(c.UserContext(), req)
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, "Failed to create industry")
 	}
 
+	h.recordChange(c, audit.ActionMasterDataCreated, audit.EntityIndustry, industry.ID)
+
 	return utils.CreatedResponse(c, "Industry created successfully", industry)
 }
 
@@ -569,9 +660,9 @@ func (h *AdminMasterDataHandler) GetIndustries(c *fiber.Ctx) error {
 	var err error
 
 	if activeParam == "true" {
-		industries, err = h.industryService.GetActive(c.Context(), search)
+		industries, err = h.industryService.GetActive(c.UserContext(), search)
 	} else {
-		industries, err = h.industryService.GetAll(c.Context(), search)
+		industries, err = h.industryService.GetAll(c.UserContext(), search)
 	}
 
 	if err != nil {
@@ -588,7 +679,7 @@ func (h *AdminMasterDataHandler) GetIndustryByID(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, "Invalid industry ID")
 	}
 
-	industry, err := h.industryService.GetByID(c.Context(), id)
+	industry, err := h.industryService.GetByID(c.UserContext(), id)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return utils.NotFoundResponse(c, "Industry not found")
@@ -613,19 +704,19 @@ func (h *AdminMasterDataHandler) UpdateIndustry(c *fiber.Ctx) error {
 
 	// Validate request
 	if err := utils.ValidateStruct(req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
 	// Check duplicate name if name is being updated
 	if req.Name != "" {
-		exists, err := h.industryService.CheckDuplicateName(c.Context(), req.Name)
+		exists, err := h.industryService.CheckDuplicateName(c.UserContext(), req.Name)
 		if err != nil {
 			return utils.InternalServerErrorResponse(c, "Failed to check duplicate industry name")
 		}
 		if exists {
 			// Verify it's not the same record
-			existing, err := h.industryService.GetByID(c.Context(), id)
+			existing, err := h.industryService.GetByID(c.UserContext(), id)
 			if err == nil && existing != nil && existing.Name != req.Name {
 				return utils.ConflictResponse(c, "Industry with this name already exists")
 			}
@@ -633,7 +724,7 @@ func (h *AdminMasterDataHandler) UpdateIndustry(c *fiber.Ctx) error {
 	}
 
 	// Update industry
-	industry, err := h.industryService.Update(c.Context(), id, req)
+	industry, err := h.industryService.Update(c.UserContext(), id, req)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return utils.NotFoundResponse(c, "Industry not found")
@@ -641,6 +732,8 @@ func (h *AdminMasterDataHandler) UpdateIndustry(c *fiber.Ctx) error {
 		return utils.InternalServerErrorResponse(c, "Failed to update industry")
 	}
 
+	h.recordChange(c, audit.ActionMasterDataUpdated, audit.EntityIndustry, id)
+
 	return utils.SuccessResponse(c, "Industry updated successfully", industry)
 }
 
@@ -652,7 +745,7 @@ func (h *AdminMasterDataHandler) DeleteIndustry(c *fiber.Ctx) error {
 	}
 
 	// Check references before deleting
-	companies, err := h.industryService.CountCompanyReferences(c.Context(), id)
+	companies, err := h.industryService.CountCompanyReferences(c.UserContext(), id)
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, "Failed to check industry references")
 	}
@@ -663,13 +756,15 @@ func (h *AdminMasterDataHandler) DeleteIndustry(c *fiber.Ctx) error {
 	}
 
 	// Delete industry
-	if err := h.industryService.Delete(c.Context(), id); err != nil {
+	if err := h.industryService.Delete(c.UserContext(), id); err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return utils.NotFoundResponse(c, "Industry not found")
 		}
 		return utils.InternalServerErrorResponse(c, "Failed to delete industry")
 	}
 
+	h.recordChange(c, audit.ActionMasterDataDeleted, audit.EntityIndustry, id)
+
 	return utils.SuccessResponse(c, "Industry deleted successfully", nil)
 }
 
@@ -686,12 +781,12 @@ func (h *AdminMasterDataHandler) CreateJobType(c *fiber.Ctx) error {
 
 	// Validate request
 	if err := utils.ValidateStruct(req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
 	// Check duplicate code
-	exists, err := h.jobTypeService.CheckDuplicateCode(c.Context(), req.Code, nil)
+	exists, err := h.jobTypeService.CheckDuplicateCode(c.UserContext(), req.Code, nil)
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, "Failed to check duplicate job type code")
 	}
@@ -700,17 +795,19 @@ func (h *AdminMasterDataHandler) CreateJobType(c *fiber.Ctx) error {
 	}
 
 	// Create job type
-	jobType, err := h.jobTypeService.Create(c.Context(), req)
+	jobType, err := h.jobTypeService.Create(c.UserContext(), req)
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, "Failed to create job type")
 	}
 
+	h.recordChange(c, audit.ActionMasterDataCreated, audit.EntityJobType, jobType.ID)
+
 	return utils.CreatedResponse(c, "Job type created successfully", jobType)
 }
 
 // GetJobTypes handles GET /api/v1/admin/master/job-types
 func (h *AdminMasterDataHandler) GetJobTypes(c *fiber.Ctx) error {
-	jobTypes, err := h.jobTypeService.GetJobTypes(c.Context())
+	jobTypes, err := h.jobTypeService.GetJobTypes(c.UserContext())
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, "Failed to retrieve job types")
 	}
@@ -736,7 +833,7 @@ func (h *AdminMasterDataHandler) GetJobTypeByID(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, "Invalid job type ID")
 	}
 
-	jobType, err := h.jobTypeService.GetJobTypeByID(c.Context(), id)
+	jobType, err := h.jobTypeService.GetJobTypeByID(c.UserContext(), id)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return utils.NotFoundResponse(c, "Job type not found")
@@ -772,14 +869,14 @@ func (h *AdminMasterDataHandler) UpdateJobType(c *fiber.Ctx) error {
 
 	// Validate request
 	if err := utils.ValidateStruct(req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
 	// Check duplicate code if code is being updated
 	if req.Code != "" {
 		excludeID := &id
-		exists, err := h.jobTypeService.CheckDuplicateCode(c.Context(), req.Code, excludeID)
+		exists, err := h.jobTypeService.CheckDuplicateCode(c.UserContext(), req.Code, excludeID)
 		if err != nil {
 			return utils.InternalServerErrorResponse(c, "Failed to check duplicate job type code")
 		}
@@ -789,7 +886,7 @@ func (h *AdminMasterDataHandler) UpdateJobType(c *fiber.Ctx) error {
 	}
 
 	// Update job type
-	jobType, err := h.jobTypeService.Update(c.Context(), id, req)
+	jobType, err := h.jobTypeService.Update(c.UserContext(), id, req)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return utils.NotFoundResponse(c, "Job type not found")
@@ -797,6 +894,8 @@ func (h *AdminMasterDataHandler) UpdateJobType(c *fiber.Ctx) error {
 		return utils.InternalServerErrorResponse(c, "Failed to update job type")
 	}
 
+	h.recordChange(c, audit.ActionMasterDataUpdated, audit.EntityJobType, id)
+
 	return utils.SuccessResponse(c, "Job type updated successfully", jobType)
 }
 
@@ -808,7 +907,7 @@ func (h *AdminMasterDataHandler) DeleteJobType(c *fiber.Ctx) error {
 	}
 
 	// Check references before deleting
-	jobs, err := h.jobTypeService.CountJobReferences(c.Context(), id)
+	jobs, err := h.jobTypeService.CountJobReferences(c.UserContext(), id)
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, "Failed to check job type references")
 	}
@@ -819,13 +918,15 @@ func (h *AdminMasterDataHandler) DeleteJobType(c *fiber.Ctx) error {
 	}
 
 	// Delete job type
-	if err := h.jobTypeService.Delete(c.Context(), id); err != nil {
+	if err := h.jobTypeService.Delete(c.UserContext(), id); err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return utils.NotFoundResponse(c, "Job type not found")
 		}
 		return utils.InternalServerErrorResponse(c, "Failed to delete job type")
 	}
 
+	h.recordChange(c, audit.ActionMasterDataDeleted, audit.EntityJobType, id)
+
 	return utils.SuccessResponse(c, "Job type deleted successfully", nil)
 }
 
@@ -842,12 +943,12 @@ func (h *AdminMasterDataHandler) CreateCompanySize(c *fiber.Ctx) error {
 
 	// Validate request
 	if err := utils.ValidateStruct(req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
 	// Check duplicate label
-	exists, err := h.companySizeService.CheckDuplicateCategory(c.Context(), req.Label)
+	exists, err := h.companySizeService.CheckDuplicateCategory(c.UserContext(), req.Label)
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, "Failed to check duplicate company size label")
 	}
@@ -856,11 +957,13 @@ func (h *AdminMasterDataHandler) CreateCompanySize(c *fiber.Ctx) error {
 	}
 
 	// Create company size
-	companySize, err := h.companySizeService.Create(c.Context(), req)
+	companySize, err := h.companySizeService.Create(c.UserContext(), req)
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, "Failed to create company size")
 	}
 
+	h.recordChange(c, audit.ActionMasterDataCreated, audit.EntityCompanySize, companySize.ID)
+
 	return utils.CreatedResponse(c, "Company size created successfully", companySize)
 }
 
@@ -872,9 +975,9 @@ func (h *AdminMasterDataHandler) GetCompanySizes(c *fiber.Ctx) error {
 	var err error
 
 	if activeParam == "true" {
-		companySizes, err = h.companySizeService.GetActive(c.Context())
+		companySizes, err = h.companySizeService.GetActive(c.UserContext())
 	} else {
-		companySizes, err = h.companySizeService.GetAll(c.Context())
+		companySizes, err = h.companySizeService.GetAll(c.UserContext())
 	}
 
 	if err != nil {
@@ -891,7 +994,7 @@ func (h *AdminMasterDataHandler) GetCompanySizeByID(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, "Invalid company size ID")
 	}
 
-	companySize, err := h.companySizeService.GetByID(c.Context(), id)
+	companySize, err := h.companySizeService.GetByID(c.UserContext(), id)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return utils.NotFoundResponse(c, "Company size not found")
@@ -916,19 +1019,19 @@ func (h *AdminMasterDataHandler) UpdateCompanySize(c *fiber.Ctx) error {
 
 	// Validate request
 	if err := utils.ValidateStruct(req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
 	// Check duplicate label if label is being updated
 	if req.Label != "" {
-		exists, err := h.companySizeService.CheckDuplicateCategory(c.Context(), req.Label)
+		exists, err := h.companySizeService.CheckDuplicateCategory(c.UserContext(), req.Label)
 		if err != nil {
 			return utils.InternalServerErrorResponse(c, "Failed to check duplicate company size label")
 		}
 		if exists {
 			// Verify it's not the same record
-			existing, err := h.companySizeService.GetByID(c.Context(), id)
+			existing, err := h.companySizeService.GetByID(c.UserContext(), id)
 			if err == nil && existing != nil && existing.Label != req.Label {
 				return utils.ConflictResponse(c, "Company size with this label already exists")
 			}
@@ -936,7 +1039,7 @@ func (h *AdminMasterDataHandler) UpdateCompanySize(c *fiber.Ctx) error {
 	}
 
 	// Update company size
-	companySize, err := h.companySizeService.Update(c.Context(), id, req)
+	companySize, err := h.companySizeService.Update(c.UserContext(), id, req)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return utils.NotFoundResponse(c, "Company size not found")
@@ -944,6 +1047,8 @@ func (h *AdminMasterDataHandler) UpdateCompanySize(c *fiber.Ctx) error {
 		return utils.InternalServerErrorResponse(c, "Failed to update company size")
 	}
 
+	h.recordChange(c, audit.ActionMasterDataUpdated, audit.EntityCompanySize, id)
+
 	return utils.SuccessResponse(c, "Company size updated successfully", companySize)
 }
 
@@ -955,7 +1060,7 @@ func (h *AdminMasterDataHandler) DeleteCompanySize(c *fiber.Ctx) error {
 	}
 
 	// Check references before deleting
-	companies, err := h.companySizeService.CountCompanyReferences(c.Context(), id)
+	companies, err := h.companySizeService.CountCompanyReferences(c.UserContext(), id)
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, "Failed to check company size references")
 	}
@@ -966,12 +1071,122 @@ func (h *AdminMasterDataHandler) DeleteCompanySize(c *fiber.Ctx) error {
 	}
 
 	// Delete company size
-	if err := h.companySizeService.Delete(c.Context(), id); err != nil {
+	if err := h.companySizeService.Delete(c.UserContext(), id); err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return utils.NotFoundResponse(c, "Company size not found")
 		}
 		return utils.InternalServerErrorResponse(c, "Failed to delete company size")
 	}
 
+	h.recordChange(c, audit.ActionMasterDataDeleted, audit.EntityCompanySize, id)
+
 	return utils.SuccessResponse(c, "Company size deleted successfully", nil)
 }
+
+// ========================================
+// BULK EXPORT / IMPORT ENDPOINTS
+// ========================================
+
+// ExportMasterData handles GET /api/v1/admin/master-data/:type/export
+func (h *AdminMasterDataHandler) ExportMasterData(c *fiber.Ctx) error {
+	dataType := c.Params("type")
+	if !slices.Contains(master.MasterDataTransferTypes, dataType) {
+		return master.ErrUnknownMasterDataType
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s-%s.csv"`, dataType, time.Now().Format("20060102")))
+
+	if err := h.transferService.Export(c.UserContext(), dataType, c); err != nil {
+		c.Response().ResetBody()
+		c.Set(fiber.HeaderContentDisposition, "")
+		return utils.InternalServerErrorResponse(c, "Failed to export master data")
+	}
+	return nil
+}
+
+// ========================================
+// SKILL TAXONOMY ENDPOINTS
+// ========================================
+
+// MergeSkill handles POST /api/v1/admin/skills/:id/merge-into/:targetId. Jobs, requirements
+// and user skills referencing the skill move to the target and the skill's names become
+// aliases of the target.
+func (h *AdminMasterDataHandler) MergeSkill(c *fiber.Ctx) error {
+	sourceID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, "Invalid skill ID")
+	}
+	targetID, err := utils.ParseIDParam(c, "targetId")
+	if err != nil {
+		return utils.BadRequestResponse(c, "Invalid target skill ID")
+	}
+
+	result, err := h.skillsService.MergeSkill(c.UserContext(), sourceID, targetID)
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return err
+		}
+		return utils.InternalServerErrorResponse(c, "Failed to merge skill")
+	}
+
+	h.auditService.Record(middleware.AuditContext(c), audit.AuditLog{
+		Action:     audit.ActionSkillMerged,
+		EntityType: audit.EntitySkill,
+		EntityID:   sourceID,
+		Metadata: audit.Metadata{
+			"target_id":           targetID,
+			"aliases":             result.Aliases,
+			"job_skills_moved":    result.JobSkillsMoved,
+			"job_skills_merged":   result.JobSkillsMerged,
+			"user_skills_renamed": result.UserSkillsRenamed,
+			"user_skills_merged":  result.UserSkillsMerged,
+		},
+	})
+
+	return utils.SuccessResponse(c, "Skill merged successfully", result)
+}
+
+// ImportMasterData handles POST /api/v1/admin/master-data/:type/import with a CSV upload
+// in the "file" field and returns a per-row report
+func (h *AdminMasterDataHandler) ImportMasterData(c *fiber.Ctx) error {
+	dataType := c.Params("type")
+	if !slices.Contains(master.MasterDataTransferTypes, dataType) {
+		return master.ErrUnknownMasterDataType
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrNoFileUploaded)
+	}
+	if file.Size > master.MaxMasterDataImportFileSize {
+		return master.ErrMasterDataImportTooLarge
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return utils.InternalServerErrorResponse(c, "Failed to read import file")
+	}
+	defer src.Close()
+
+	report, err := h.transferService.Import(c.UserContext(), dataType, src)
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return err
+		}
+		return utils.InternalServerErrorResponse(c, "Failed to import master data")
+	}
+
+	h.auditService.Record(middleware.AuditContext(c), audit.AuditLog{
+		Action:     audit.ActionMasterDataImported,
+		EntityType: audit.EntityMasterData,
+		Metadata: audit.Metadata{
+			"type":    dataType,
+			"created": report.Created,
+			"updated": report.Updated,
+			"failed":  report.Failed,
+		},
+	})
+
+	return utils.SuccessResponse(c, "Master data import completed", report)
+}
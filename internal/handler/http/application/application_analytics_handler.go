@@ -0,0 +1,81 @@
+package applicationhandler
+
+import (
+	"time"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/dto/request"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Source analytics cover at most a year; without a range they cover the last 30 days
+const (
+	defaultSourceAnalyticsDays = 30
+	maxSourceAnalyticsDays     = 366
+)
+
+// GetSourceAnalytics returns a company's job views, applications and hires per utm_source
+// with conversion rates. Query: from, to (YYYY-MM-DD, inclusive), job_id
+// GET /api/v1/companies/:id/analytics/sources
+func (h *ApplicationHandler) GetSourceAnalytics(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	var req request.SourceAnalyticsRequest
+	if err := c.QueryParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidQueryParams)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	now := time.Now().UTC()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	if req.To != "" {
+		parsed, err := time.Parse("2006-01-02", req.To)
+		if err != nil {
+			return utils.BadRequestResponse(c, "invalid to date, expected YYYY-MM-DD")
+		}
+		// Include the whole end day
+		to = parsed.AddDate(0, 0, 1)
+	}
+
+	from := to.AddDate(0, 0, -defaultSourceAnalyticsDays)
+	if req.From != "" {
+		parsed, err := time.Parse("2006-01-02", req.From)
+		if err != nil {
+			return utils.BadRequestResponse(c, "invalid from date, expected YYYY-MM-DD")
+		}
+		from = parsed
+	}
+
+	if err := utils.ValidateDateRange(from, to); err != nil {
+		return utils.BadRequestResponse(c, "from must not be after to")
+	}
+	if to.Sub(from) > maxSourceAnalyticsDays*24*time.Hour {
+		return utils.BadRequestResponse(c, "date range must not exceed 366 days")
+	}
+
+	filter := application.SourceStatsFilter{CompanyID: companyID, From: from, To: to}
+	if req.JobID != 0 {
+		filter.JobID = &req.JobID
+	}
+
+	stats, err := h.appService.GetApplicationSourceAnalytics(c.UserContext(), filter)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, fiber.Map{
+		"from":    from.Format("2006-01-02"),
+		"to":      to.AddDate(0, 0, -1).Format("2006-01-02"),
+		"job_id":  filter.JobID,
+		"sources": stats,
+	})
+}
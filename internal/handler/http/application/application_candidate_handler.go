@@ -4,7 +4,10 @@ import (
 	"strconv"
 
 	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/dto/mapper"
+	"keerja-backend/internal/dto/response"
 	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/handler/http/upload"
 	"keerja-backend/internal/middleware"
 	"keerja-backend/internal/utils"
 
@@ -12,7 +15,7 @@ import (
 )
 
 func (h *ApplicationHandler) Apply(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	var req application.ApplyJobRequest
@@ -23,17 +26,20 @@ func (h *ApplicationHandler) Apply(c *fiber.Ctx) error {
 	req.UserID = userID
 	req.CoverLetter = utils.SanitizeHTML(req.CoverLetter)
 	req.Source = utils.SanitizeString(req.Source)
+	for i := range req.Answers {
+		req.Answers[i].Value = utils.SanitizeString(req.Answers[i].Value)
+	}
 
 	app, err := h.appService.ApplyForJob(ctx, &req)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, common.ErrApplicationNotFound, err.Error())
+		return err
 	}
 
 	return utils.CreatedResponse(c, common.MsgApplicationSubmit, app)
 }
 
 func (h *ApplicationHandler) ApplyToJob(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	jobID, err := strconv.ParseInt(c.Params("job_id"), 10, 64)
@@ -50,21 +56,26 @@ func (h *ApplicationHandler) ApplyToJob(c *fiber.Ctx) error {
 	req.UserID = userID
 	req.CoverLetter = utils.SanitizeHTML(req.CoverLetter)
 	req.Source = utils.SanitizeString(req.Source)
+	for i := range req.Answers {
+		req.Answers[i].Value = utils.SanitizeString(req.Answers[i].Value)
+	}
 
 	app, err := h.appService.ApplyForJob(ctx, &req)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, common.ErrAlreadyApplied, err.Error())
+		return err
 	}
 
 	return utils.CreatedResponse(c, common.MsgApplicationSubmit, app)
 }
 
 func (h *ApplicationHandler) GetMyApplications(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	page, limit, err := utils.ParsePagination(c, 20, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
 
 	filter := application.ApplicationFilter{}
 	if status := c.Query("status"); status != "" {
@@ -78,14 +89,14 @@ func (h *ApplicationHandler) GetMyApplications(c *fiber.Ctx) error {
 
 	response, err := h.appService.GetMyApplications(ctx, userID, filter, page, limit)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, common.ErrInternalServer, err.Error())
+		return err
 	}
 
 	return utils.SuccessResponse(c, common.MsgFetchedSuccess, response)
 }
 
 func (h *ApplicationHandler) GetApplication(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	appID, err := strconv.ParseInt(c.Params("id"), 10, 64)
@@ -95,14 +106,30 @@ func (h *ApplicationHandler) GetApplication(c *fiber.Ctx) error {
 
 	response, err := h.appService.GetApplicationDetail(ctx, appID, userID)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusNotFound, common.ErrApplicationNotFound, err.Error())
+		return err
 	}
 
 	return utils.SuccessResponse(c, common.MsgFetchedSuccess, response)
 }
 
+// GetTimeline returns the applicant's view of an application's progress with a hint about
+// what happens next
+func (h *ApplicationHandler) GetTimeline(c *fiber.Ctx) error {
+	appID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	timeline, err := h.appService.GetCandidateTimeline(c.UserContext(), appID, middleware.GetUserID(c))
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, timeline)
+}
+
 func (h *ApplicationHandler) Withdraw(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	appID, err := strconv.ParseInt(c.Params("id"), 10, 64)
@@ -111,14 +138,14 @@ func (h *ApplicationHandler) Withdraw(c *fiber.Ctx) error {
 	}
 
 	if err := h.appService.WithdrawApplication(ctx, appID, userID); err != nil {
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, common.ErrCannotWithdraw, err.Error())
+		return err
 	}
 
 	return utils.SuccessResponse(c, common.MsgOperationSuccess, nil)
 }
 
 func (h *ApplicationHandler) UploadDocument(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	appID, err := strconv.ParseInt(c.Params("id"), 10, 64)
@@ -134,12 +161,76 @@ func (h *ApplicationHandler) UploadDocument(c *fiber.Ctx) error {
 	req.ApplicationID = appID
 	req.UserID = userID
 
-	doc, err := h.appService.UploadApplicationDocument(ctx, &req)
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, err)
+	}
+
+	// The file is optional while pre-uploaded file URLs are still accepted
+	file, err := c.FormFile("file")
+	if err != nil {
+		file = nil
+	}
+
+	doc, err := h.appService.UploadApplicationDocument(ctx, &req, file)
+	if err != nil {
+		return err
+	}
+
+	return utils.CreatedResponse(c, common.MsgUploadSuccess, mapper.ToApplicationDocumentResponse(doc))
+}
+
+// DownloadDocument returns a short-lived signed link to an application document for the
+// applicant or an employer of the hiring company. Pre-uploaded documents are hosted
+// elsewhere, so their own URL is returned.
+func (h *ApplicationHandler) DownloadDocument(c *fiber.Ctx) error {
+	appID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+	docID, err := strconv.ParseInt(c.Params("docId"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	doc, err := h.appService.GetDocumentForDownload(c.UserContext(), appID, docID, middleware.GetUserID(c))
+	if err != nil {
+		return err
+	}
+
+	fileName := upload.DownloadFileName(doc.FileName, doc.FileURL)
+	if !doc.Uploaded {
+		return utils.SuccessResponse(c, "Download link created", response.DownloadLinkResponse{URL: doc.FileURL, FileName: fileName})
+	}
+
+	url, expiresAt := h.signer.URL(c.BaseURL(), doc.FileURL, fileName)
+	return utils.SuccessResponse(c, "Download link created", response.DownloadLinkResponse{
+		URL:       url,
+		FileName:  fileName,
+		ExpiresAt: &expiresAt,
+	})
+}
+
+// DownloadResume returns a short-lived signed link to the resume an application was submitted
+// with, for the applicant or an employer of the hiring company. Resumes live in private upload
+// folders, so the link is signed even when the resume was not copied into the application.
+func (h *ApplicationHandler) DownloadResume(c *fiber.Ctx) error {
+	appID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	app, err := h.appService.GetResumeForDownload(c.UserContext(), appID, middleware.GetUserID(c))
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, common.ErrFileUploadFailed, err.Error())
+		return err
 	}
 
-	return utils.CreatedResponse(c, common.MsgUploadSuccess, doc)
+	fileName := upload.DownloadFileName("resume", app.ResumeURL)
+	url, expiresAt := h.signer.URL(c.BaseURL(), app.ResumeURL, fileName)
+	return utils.SuccessResponse(c, "Download link created", response.DownloadLinkResponse{
+		URL:       url,
+		FileName:  fileName,
+		ExpiresAt: &expiresAt,
+	})
 }
 
 func (h *ApplicationHandler) RateExperience(c *fiber.Ctx) error {
@@ -1,9 +1,14 @@
 package applicationhandler
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
+	"keerja-backend/internal/apperror"
 	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/dto/response"
 	"keerja-backend/internal/handler/http/common"
 	"keerja-backend/internal/middleware"
 	"keerja-backend/internal/utils"
@@ -12,51 +17,162 @@ import (
 )
 
 func (h *ApplicationHandler) ListByJob(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
+	ec := middleware.GetEmployerContext(c)
 
 	jobID, err := strconv.ParseInt(c.Params("job_id"), 10, 64)
 	if err != nil {
 		return utils.BadRequestResponse(c, common.ErrInvalidID)
 	}
 
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	page, limit, err := utils.ParsePagination(c, 20, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
 
 	filter := application.ApplicationFilter{}
 	if status := c.Query("status"); status != "" {
 		filter.Status = status
 	}
+	answers, err := parseAnswerFilter(c)
+	if err != nil {
+		return utils.BadRequestResponse(c, err.Error())
+	}
+	filter.Answers = answers
 
-	response, err := h.appService.GetJobApplications(ctx, jobID, filter, page, limit)
+	response, err := h.appService.GetJobApplications(ctx, ec, jobID, filter, page, limit)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, common.ErrInternalServer, err.Error())
+		return err
 	}
 
 	return utils.SuccessResponse(c, common.MsgFetchedSuccess, response)
 }
 
+// parseAnswerFilter reads screening answer filters given as ?answer[<question_id>]=<value>
+func parseAnswerFilter(c *fiber.Ctx) (map[int64]string, error) {
+	var (
+		answers map[int64]string
+		err     error
+	)
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		k := string(key)
+		if err != nil || !strings.HasPrefix(k, "answer[") || !strings.HasSuffix(k, "]") {
+			return
+		}
+		questionID, parseErr := strconv.ParseInt(k[len("answer["):len(k)-1], 10, 64)
+		if parseErr != nil || questionID <= 0 {
+			err = fmt.Errorf("invalid answer filter %q", k)
+			return
+		}
+		if answers == nil {
+			answers = make(map[int64]string)
+		}
+		answers[questionID] = string(value)
+	})
+	return answers, err
+}
+
+// ListByCompany lists a company's applications. Passing ?cursor= switches from page/limit
+// to cursor pagination.
+func (h *ApplicationHandler) ListByCompany(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ec := middleware.GetEmployerContext(c)
+
+	page, limit, err := utils.ParsePagination(c, 20, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
+
+	filter := application.ApplicationFilter{}
+	if status := c.Query("status"); status != "" {
+		filter.Status = status
+	}
+
+	if utils.UsesCursor(c) {
+		summaries, next, err := h.appService.GetCompanyApplicationsByCursor(ctx, ec, filter, c.Query("cursor"), limit)
+		if err != nil {
+			return err
+		}
+		return utils.SuccessResponse(c, common.MsgFetchedSuccess, response.NewCursorPage(summaries, next))
+	}
+
+	result, err := h.appService.GetCompanyApplications(ctx, ec, filter, page, limit)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, result)
+}
+
+// ExportByCompany downloads a company's applications as CSV, optionally filtered by ?status=
+func (h *ApplicationHandler) ExportByCompany(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ec := middleware.GetEmployerContext(c)
+
+	filter := application.ApplicationFilter{Status: c.Query("status")}
+	data, err := h.appService.ExportApplications(ctx, ec, filter)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="applications-%d-%s.csv"`, ec.CompanyID, time.Now().Format("20060102")))
+	return c.Send(data)
+}
+
+// GetJobApplicationsBoard returns a job's applications grouped by status for the kanban view
+func (h *ApplicationHandler) GetJobApplicationsBoard(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ec := middleware.GetEmployerContext(c)
+
+	jobID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	perColumn := c.QueryInt("per_column", application.DefaultBoardPerColumn)
+	if perColumn < 1 || perColumn > application.MaxBoardPerColumn {
+		return utils.BadRequestResponse(c, "per_column must be between 1 and 50")
+	}
+
+	sortBy := c.Query("sort", application.BoardSortAppliedAt)
+	if sortBy != application.BoardSortAppliedAt && sortBy != application.BoardSortMatchScore {
+		return utils.BadRequestResponse(c, "sort must be applied_at or match_score")
+	}
+
+	board, err := h.appService.GetJobApplicationsBoard(ctx, ec, jobID, perColumn, sortBy)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, board)
+}
+
 func (h *ApplicationHandler) SearchApplications(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var filter application.ApplicationSearchFilter
 	if err := c.BodyParser(&filter); err != nil {
 		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
 	}
 
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	page, limit, err := utils.ParsePagination(c, 20, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
 
-	response, err := h.appService.SearchApplications(ctx, filter, page, limit)
+	// Without company_ids the company RequireActiveCompany resolved is searched
+	response, err := h.appService.SearchApplications(ctx, middleware.GetEmployerContext(c), filter, page, limit)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, common.ErrInternalServer, err.Error())
+		return err
 	}
 
 	return utils.SuccessResponse(c, common.MsgFetchedSuccess, response)
 }
 
 func (h *ApplicationHandler) UpdateStatus(c *fiber.Ctx) error {
-	ctx := c.Context()
-	employerID := middleware.GetUserID(c)
+	ctx := c.UserContext()
+	ec := middleware.GetEmployerContext(c)
 
 	appID, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
@@ -64,8 +180,9 @@ func (h *ApplicationHandler) UpdateStatus(c *fiber.Ctx) error {
 	}
 
 	type UpdateStatusRequest struct {
-		Status string `json:"status" validate:"required"`
-		Notes  string `json:"notes"`
+		Status       string `json:"status" validate:"required"`
+		Notes        string `json:"notes"`
+		DoNotReapply bool   `json:"do_not_reapply"` // Only used when rejecting
 	}
 
 	var req UpdateStatusRequest
@@ -75,18 +192,18 @@ func (h *ApplicationHandler) UpdateStatus(c *fiber.Ctx) error {
 
 	var updateErr error
 	switch req.Status {
-	case "screening":
-		updateErr = h.appService.MoveToScreening(ctx, appID, employerID, req.Notes)
-	case "shortlisted":
-		updateErr = h.appService.MoveToShortlist(ctx, appID, employerID, req.Notes)
-	case "interview":
-		updateErr = h.appService.MoveToInterview(ctx, appID, employerID, req.Notes)
-	case "offered":
-		updateErr = h.appService.MakeOffer(ctx, appID, employerID, req.Notes)
-	case "hired":
-		updateErr = h.appService.MarkAsHired(ctx, appID, employerID, req.Notes)
-	case "rejected":
-		updateErr = h.appService.RejectApplication(ctx, appID, employerID, req.Notes)
+	case application.StatusScreening:
+		updateErr = h.appService.MoveToScreening(ctx, ec, appID, req.Notes)
+	case application.StatusShortlisted:
+		updateErr = h.appService.MoveToShortlist(ctx, ec, appID, req.Notes)
+	case application.StatusInterview:
+		updateErr = h.appService.MoveToInterview(ctx, ec, appID, req.Notes)
+	case application.StatusOffered:
+		updateErr = h.appService.MakeOffer(ctx, ec, appID, req.Notes)
+	case application.StatusHired:
+		updateErr = h.appService.MarkAsHired(ctx, ec, appID, req.Notes)
+	case application.StatusRejected:
+		updateErr = h.appService.RejectApplication(ctx, ec, appID, req.Notes, req.DoNotReapply)
 	default:
 		return utils.BadRequestResponse(c, common.ErrInvalidApplicationStage)
 	}
@@ -99,8 +216,8 @@ func (h *ApplicationHandler) UpdateStatus(c *fiber.Ctx) error {
 }
 
 func (h *ApplicationHandler) BulkUpdateStatus(c *fiber.Ctx) error {
-	ctx := c.Context()
-	employerID := middleware.GetUserID(c)
+	ctx := c.UserContext()
+	ec := middleware.GetEmployerContext(c)
 
 	type BulkUpdateRequest struct {
 		ApplicationIDs []int64 `json:"application_ids" validate:"required,min=1"`
@@ -112,27 +229,101 @@ func (h *ApplicationHandler) BulkUpdateStatus(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
 	}
 
-	if err := h.appService.BulkUpdateStatus(ctx, req.ApplicationIDs, req.Status, employerID); err != nil {
+	if err := h.appService.BulkUpdateStatus(ctx, ec, req.ApplicationIDs, req.Status); err != nil {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, common.ErrFailedOperation, err.Error())
 	}
 
 	return utils.SuccessResponse(c, common.MsgOperationSuccess, nil)
 }
 
+// CreateBulkAction queues moving the selected company applications to a status. Progress is
+// polled with GetBulkAction.
+func (h *ApplicationHandler) CreateBulkAction(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ec := middleware.GetEmployerContext(c)
+
+	var req application.BulkActionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, err)
+	}
+
+	action, err := h.appService.CreateBulkAction(ctx, ec, &req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(utils.Response{
+		Success: true,
+		Message: common.MsgOperationSuccess,
+		Data:    action,
+	})
+}
+
+// GetBulkAction returns the progress of a company bulk action
+func (h *ApplicationHandler) GetBulkAction(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ec := middleware.GetEmployerContext(c)
+
+	actionID, err := utils.ParseIDParam(c, "actionId")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	action, err := h.appService.GetBulkAction(ctx, ec, actionID)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, action)
+}
+
+// UpdateStage moves an application to a stage of its job's pipeline, which may be a custom
+// stage. Rejections go through UpdateStatus so do_not_reapply can be set.
 func (h *ApplicationHandler) UpdateStage(c *fiber.Ctx) error {
-	return h.UpdateStatus(c)
+	ctx := c.UserContext()
+	ec := middleware.GetEmployerContext(c)
+
+	appID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	type UpdateStageRequest struct {
+		Stage string `json:"stage" validate:"required,max=50"`
+		Notes string `json:"notes"`
+	}
+
+	var req UpdateStageRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, utils.FormatValidationErrors(c, err))
+	}
+
+	if err := h.appService.MoveToStage(ctx, ec, appID, req.Stage, req.Notes); err != nil {
+		if _, ok := apperror.As(err); ok {
+			return err
+		}
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, common.ErrFailedOperation, err.Error())
+	}
+
+	return utils.SuccessResponse(c, common.MsgStatusUpdated, nil)
 }
 
 func (h *ApplicationHandler) Bookmark(c *fiber.Ctx) error {
-	ctx := c.Context()
-	employerID := middleware.GetUserID(c)
+	ctx := c.UserContext()
+	ec := middleware.GetEmployerContext(c)
 
 	appID, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
 		return utils.BadRequestResponse(c, common.ErrInvalidID)
 	}
 
-	if err := h.appService.ToggleBookmark(ctx, appID, employerID); err != nil {
+	if err := h.appService.ToggleBookmark(ctx, ec, appID); err != nil {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, common.ErrFailedOperation, err.Error())
 	}
 
@@ -140,15 +331,15 @@ func (h *ApplicationHandler) Bookmark(c *fiber.Ctx) error {
 }
 
 func (h *ApplicationHandler) MarkViewed(c *fiber.Ctx) error {
-	ctx := c.Context()
-	employerID := middleware.GetUserID(c)
+	ctx := c.UserContext()
+	ec := middleware.GetEmployerContext(c)
 
 	appID, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
 		return utils.BadRequestResponse(c, common.ErrInvalidID)
 	}
 
-	if err := h.appService.MarkAsViewed(ctx, appID, employerID); err != nil {
+	if err := h.appService.MarkAsViewed(ctx, ec, appID); err != nil {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, common.ErrFailedOperation, err.Error())
 	}
 
@@ -2,16 +2,20 @@ package applicationhandler
 
 import (
 	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/service"
 )
 
 // ApplicationHandler handles application-related HTTP requests
 type ApplicationHandler struct {
 	appService application.ApplicationService
+	signer     *service.DownloadSigner
 }
 
-// NewApplicationHandler creates a new instance of ApplicationHandler
-func NewApplicationHandler(appService application.ApplicationService) *ApplicationHandler {
+// NewApplicationHandler creates a new instance of ApplicationHandler; signer signs the
+// download links of uploaded application documents
+func NewApplicationHandler(appService application.ApplicationService, signer *service.DownloadSigner) *ApplicationHandler {
 	return &ApplicationHandler{
 		appService: appService,
+		signer:     signer,
 	}
 }
@@ -3,6 +3,7 @@ package applicationhandler
 import (
 	"strconv"
 
+	"keerja-backend/internal/apperror"
 	"keerja-backend/internal/domain/application"
 	"keerja-backend/internal/handler/http/common"
 	"keerja-backend/internal/middleware"
@@ -12,7 +13,7 @@ import (
 )
 
 func (h *ApplicationHandler) ScheduleInterview(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	employerID := middleware.GetUserID(c)
 
 	appID, err := strconv.ParseInt(c.Params("id"), 10, 64)
@@ -30,6 +31,9 @@ func (h *ApplicationHandler) ScheduleInterview(c *fiber.Ctx) error {
 
 	interview, err := h.appService.ScheduleInterview(ctx, &req)
 	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return err
+		}
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, common.ErrFailedOperation, err.Error())
 	}
 
@@ -37,7 +41,7 @@ func (h *ApplicationHandler) ScheduleInterview(c *fiber.Ctx) error {
 }
 
 func (h *ApplicationHandler) UpdateInterview(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	interviewID, err := strconv.ParseInt(c.Params("interview_id"), 10, 64)
 	if err != nil {
@@ -58,7 +62,7 @@ func (h *ApplicationHandler) UpdateInterview(c *fiber.Ctx) error {
 }
 
 func (h *ApplicationHandler) RescheduleInterview(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	interviewID, err := strconv.ParseInt(c.Params("interview_id"), 10, 64)
 	if err != nil {
@@ -72,6 +76,9 @@ func (h *ApplicationHandler) RescheduleInterview(c *fiber.Ctx) error {
 
 	interview, err := h.appService.RescheduleInterview(ctx, interviewID, &req)
 	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return err
+		}
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, common.ErrInterviewConflict, err.Error())
 	}
 
@@ -79,7 +86,7 @@ func (h *ApplicationHandler) RescheduleInterview(c *fiber.Ctx) error {
 }
 
 func (h *ApplicationHandler) CompleteInterview(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	employerID := middleware.GetUserID(c)
 
 	interviewID, err := strconv.ParseInt(c.Params("interview_id"), 10, 64)
@@ -103,7 +110,7 @@ func (h *ApplicationHandler) CompleteInterview(c *fiber.Ctx) error {
 }
 
 func (h *ApplicationHandler) CancelInterview(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	employerID := middleware.GetUserID(c)
 
 	interviewID, err := strconv.ParseInt(c.Params("interview_id"), 10, 64)
@@ -0,0 +1,185 @@
+package applicationhandler
+
+import (
+	"strconv"
+	"time"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ListInterviewSlots lists a company's interview slots. Query: from, to (RFC3339),
+// status (open|booked), interviewer_id
+func (h *ApplicationHandler) ListInterviewSlots(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	filter := application.InterviewSlotFilter{Status: c.Query("status")}
+	for _, bound := range []struct {
+		name string
+		dst  **time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		raw := c.Query(bound.name)
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return utils.BadRequestResponse(c, bound.name+" must be an RFC3339 timestamp")
+		}
+		*bound.dst = &t
+	}
+	if raw := c.Query("interviewer_id"); raw != "" {
+		interviewerID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return utils.BadRequestResponse(c, common.ErrInvalidID)
+		}
+		filter.InterviewerID = interviewerID
+	}
+
+	slots, err := h.appService.ListInterviewSlots(ctx, companyID, filter)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, slots)
+}
+
+// CreateInterviewSlots creates a batch of interview slots for the company
+func (h *ApplicationHandler) CreateInterviewSlots(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := middleware.GetUserID(c)
+
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	var req application.CreateInterviewSlotsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, err)
+	}
+
+	result, err := h.appService.CreateInterviewSlots(ctx, companyID, userID, &req)
+	if err != nil {
+		return err
+	}
+
+	return utils.CreatedResponse(c, common.MsgCreatedSuccess, result)
+}
+
+// UpdateInterviewSlot moves an open interview slot or changes its meeting details
+func (h *ApplicationHandler) UpdateInterviewSlot(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+	slotID, err := utils.ParseIDParam(c, "slotId")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	var req application.UpdateInterviewSlotRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, err)
+	}
+
+	slot, err := h.appService.UpdateInterviewSlot(ctx, companyID, slotID, &req)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, common.MsgUpdatedSuccess, slot)
+}
+
+// DeleteInterviewSlot deletes an open interview slot
+func (h *ApplicationHandler) DeleteInterviewSlot(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+	slotID, err := utils.ParseIDParam(c, "slotId")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	if err := h.appService.DeleteInterviewSlot(ctx, companyID, slotID); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, common.MsgDeletedSuccess, nil)
+}
+
+// SendInterviewBookingLink emails the candidate a link to book an interview slot
+func (h *ApplicationHandler) SendInterviewBookingLink(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	ec := middleware.GetEmployerContext(c)
+
+	appID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	var req application.SendBookingLinkRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+		}
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, err)
+	}
+
+	link, err := h.appService.SendInterviewBookingLink(ctx, ec, appID, &req)
+	if err != nil {
+		return err
+	}
+
+	return utils.CreatedResponse(c, common.MsgCreatedSuccess, link)
+}
+
+// GetInterviewBooking returns the open slots a candidate can book with the link's token
+func (h *ApplicationHandler) GetInterviewBooking(c *fiber.Ctx) error {
+	booking, err := h.appService.GetInterviewBooking(c.UserContext(), c.Params("token"))
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, booking)
+}
+
+// BookInterviewSlot books the chosen slot with the link's token and schedules the interview
+func (h *ApplicationHandler) BookInterviewSlot(c *fiber.Ctx) error {
+	var req application.BookInterviewSlotRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, err)
+	}
+
+	interview, err := h.appService.BookInterviewSlot(c.UserContext(), c.Params("token"), req.SlotID)
+	if err != nil {
+		return err
+	}
+
+	return utils.CreatedResponse(c, common.MsgCreatedSuccess, interview)
+}
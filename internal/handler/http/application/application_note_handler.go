@@ -12,7 +12,7 @@ import (
 )
 
 func (h *ApplicationHandler) AddNote(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	employerID := middleware.GetUserID(c)
 
 	appID, err := strconv.ParseInt(c.Params("id"), 10, 64)
@@ -37,7 +37,7 @@ func (h *ApplicationHandler) AddNote(c *fiber.Ctx) error {
 }
 
 func (h *ApplicationHandler) UpdateNote(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	noteID, err := strconv.ParseInt(c.Params("note_id"), 10, 64)
 	if err != nil {
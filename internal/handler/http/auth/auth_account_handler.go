@@ -0,0 +1,201 @@
+package authhandler
+
+import (
+	"keerja-backend/internal/apperror"
+	"keerja-backend/internal/dto/mapper"
+	"keerja-backend/internal/dto/request"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ChangePassword changes the password of the logged-in user and signs out every other session
+func (h *AuthHandler) ChangePassword(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	userClaims := middleware.GetClaims(c)
+	if userClaims == nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", "No user context found")
+	}
+
+	var req request.ChangePasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, "Validation failed", errors)
+	}
+
+	if err := h.authService.ChangePassword(ctx, userClaims.UserID, req.OldPassword, req.NewPassword); err != nil {
+		return err
+	}
+
+	if err := h.refreshTokenService.RevokeOtherSessions(ctx, userClaims.UserID, userClaims.SessionID, "password_changed"); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Password changed but failed to sign out other sessions", err.Error())
+	}
+
+	return utils.SuccessResponse(c, "Password changed successfully. Other sessions have been signed out.", nil)
+}
+
+// ChangeEmail sends an OTP to the requested new email; the email changes once it is verified
+func (h *AuthHandler) ChangeEmail(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	userClaims := middleware.GetClaims(c)
+	if userClaims == nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", "No user context found")
+	}
+
+	var req request.ChangeEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, "Validation failed", errors)
+	}
+
+	req.NewEmail = utils.SanitizeString(req.NewEmail)
+
+	if err := h.registrationService.RequestEmailChange(ctx, userClaims.UserID, req.NewEmail, req.Password, c.IP()); err != nil {
+		if limitErr, ok := asOTPRateLimit(err); ok {
+			return otpRateLimitResponse(c, limitErr, "Too many email change requests. Please try again later.")
+		}
+		if _, ok := apperror.As(err); ok {
+			return err
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to request email change", err.Error())
+	}
+
+	return utils.SuccessResponse(c, "A verification code has been sent to your new email.", fiber.Map{
+		"new_email": req.NewEmail,
+		"note":      "OTP code is valid for 10 minutes.",
+	})
+}
+
+// VerifyEmailChange verifies the OTP sent to the new email and applies the change
+func (h *AuthHandler) VerifyEmailChange(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	userClaims := middleware.GetClaims(c)
+	if userClaims == nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", "No user context found")
+	}
+
+	var req request.VerifyEmailChangeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, "Validation failed", errors)
+	}
+
+	req.NewEmail = utils.SanitizeString(req.NewEmail)
+	req.OTPCode = utils.SanitizeString(req.OTPCode)
+
+	usr, err := h.registrationService.ConfirmEmailChange(ctx, userClaims.UserID, req.NewEmail, req.OTPCode)
+	if err != nil {
+		if err == service.ErrOTPCodeNotFound {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "No pending email change", err.Error())
+		}
+		if err == service.ErrInvalidOTPCode {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid OTP code", err.Error())
+		}
+		if err == service.ErrOTPCodeExpired {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "OTP code has expired", err.Error())
+		}
+		if err == service.ErrOTPCodeAlreadyUsed {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "OTP code has already been used", err.Error())
+		}
+		if err == service.ErrTooManyOTPAttempts {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Too many failed attempts. Please request a new OTP.", err.Error())
+		}
+		if _, ok := apperror.As(err); ok {
+			return err
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to change email", err.Error())
+	}
+
+	return utils.SuccessResponse(c, "Email changed successfully", mapper.ToUserBasic(usr))
+}
+
+// RequestPhoneOTP sends an OTP by SMS to the phone number on the user's profile
+func (h *AuthHandler) RequestPhoneOTP(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	userClaims := middleware.GetClaims(c)
+	if userClaims == nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", "No user context found")
+	}
+
+	if err := h.registrationService.RequestPhoneVerification(ctx, userClaims.UserID, c.IP()); err != nil {
+		if limitErr, ok := asOTPRateLimit(err); ok {
+			return otpRateLimitResponse(c, limitErr, "Too many phone verification requests. Please try again later.")
+		}
+		if _, ok := apperror.As(err); ok {
+			return err
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to send phone verification code", err.Error())
+	}
+
+	return utils.SuccessResponse(c, "A verification code has been sent to your phone.", fiber.Map{
+		"note": "OTP code is valid for 5 minutes.",
+	})
+}
+
+// VerifyPhoneOTP verifies the OTP sent to the user's phone and marks the number verified
+func (h *AuthHandler) VerifyPhoneOTP(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	userClaims := middleware.GetClaims(c)
+	if userClaims == nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", "No user context found")
+	}
+
+	var req request.VerifyPhoneOTPRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, "Validation failed", errors)
+	}
+
+	req.OTPCode = utils.SanitizeString(req.OTPCode)
+
+	usr, err := h.registrationService.VerifyPhoneOTP(ctx, userClaims.UserID, req.OTPCode)
+	if err != nil {
+		if err == service.ErrOTPCodeNotFound {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "No pending phone verification", err.Error())
+		}
+		if err == service.ErrInvalidOTPCode {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid OTP code", err.Error())
+		}
+		if err == service.ErrOTPCodeExpired {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "OTP code has expired", err.Error())
+		}
+		if err == service.ErrOTPCodeAlreadyUsed {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "OTP code has already been used", err.Error())
+		}
+		if err == service.ErrTooManyOTPAttempts {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Too many failed attempts. Please request a new OTP.", err.Error())
+		}
+		if _, ok := apperror.As(err); ok {
+			return err
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to verify phone", err.Error())
+	}
+
+	return utils.SuccessResponse(c, "Phone number verified successfully", fiber.Map{
+		"phone":             usr.Phone,
+		"phone_verified_at": usr.PhoneVerifiedAt,
+	})
+}
@@ -1,18 +1,19 @@
 package authhandler
 
 import (
+	"strings"
+
 	"keerja-backend/internal/domain/user"
 	"keerja-backend/internal/dto/mapper"
 	"keerja-backend/internal/dto/request"
 	"keerja-backend/internal/middleware"
-	"keerja-backend/internal/service"
 	"keerja-backend/internal/utils"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 func (h *AuthHandler) Register(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var req request.RegisterRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -20,7 +21,7 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
@@ -41,14 +42,13 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 		Phone:    phone,
 		Password: req.Password,
 		UserType: req.UserType,
+
+		InvitationToken: strings.TrimSpace(req.InvitationToken),
 	}
 
 	usr, verificationToken, err := h.authService.Register(ctx, domainReq)
 	if err != nil {
-		if err == service.ErrEmailAlreadyExists {
-			return utils.ErrorResponse(c, fiber.StatusConflict, "Email already exists", err.Error())
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to register user", err.Error())
+		return err
 	}
 
 	response := mapper.ToAuthResponse(usr, "", verificationToken)
@@ -57,7 +57,7 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 }
 
 func (h *AuthHandler) Login(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var req request.LoginRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -65,7 +65,7 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
@@ -73,13 +73,7 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 
 	usr, accessToken, err := h.authService.Login(ctx, req.Email, req.Password)
 	if err != nil {
-		if err == service.ErrInvalidCredentials {
-			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid email or password", err.Error())
-		}
-		if err == service.ErrEmailNotVerified {
-			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Email not verified", "Please verify your email before logging in")
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to login", err.Error())
+		return err
 	}
 
 	authResponse := h.buildAuthResponse(ctx, usr, accessToken, "")
@@ -88,7 +82,7 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 }
 
 func (h *AuthHandler) VerifyEmail(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var req request.VerifyEmailRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -96,27 +90,21 @@ func (h *AuthHandler) VerifyEmail(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
 	req.Token = utils.SanitizeString(req.Token)
 
 	if err := h.authService.VerifyEmail(ctx, req.Token); err != nil {
-		if err == service.ErrInvalidVerificationToken {
-			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid verification token", err.Error())
-		}
-		if err == service.ErrTokenExpired {
-			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Verification token expired", err.Error())
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to verify email", err.Error())
+		return err
 	}
 
 	return utils.SuccessResponse(c, "Email verified successfully", nil)
 }
 
 func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	if userID == 0 {
@@ -139,7 +127,7 @@ func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
 }
 
 func (h *AuthHandler) Logout(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	if err := h.authService.Logout(ctx, userID); err != nil {
@@ -1,9 +1,11 @@
 package authhandler
 
 import (
+	"keerja-backend/internal/apperror"
 	"keerja-backend/internal/domain/auth"
 	"keerja-backend/internal/dto/mapper"
 	"keerja-backend/internal/dto/request"
+	"keerja-backend/internal/middleware"
 	"keerja-backend/internal/service"
 	"keerja-backend/internal/utils"
 
@@ -11,7 +13,7 @@ import (
 )
 
 func (h *AuthHandler) LoginWithRememberMe(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var req request.LoginWithRememberMeRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -19,19 +21,20 @@ func (h *AuthHandler) LoginWithRememberMe(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
 	req.Email = utils.SanitizeString(req.Email)
 
-	usr, accessToken, err := h.authService.Login(ctx, req.Email, req.Password)
+	usr, _, err := h.authService.Login(ctx, req.Email, req.Password)
 	if err != nil {
 		if err == service.ErrInvalidCredentials {
 			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid email or password", err.Error())
 		}
-		if err == service.ErrEmailNotVerified {
-			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Email not verified", "Please verify your email before logging in")
+		if _, ok := apperror.As(err); ok {
+			// e.g. user.ErrVerificationRequired or a suspended account
+			return err
 		}
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to login", err.Error())
 	}
@@ -42,7 +45,8 @@ func (h *AuthHandler) LoginWithRememberMe(c *fiber.Ctx) error {
 		IPAddress: c.IP(),
 	}
 
-	refreshToken, err := h.refreshTokenService.CreateRefreshToken(ctx, usr.ID, deviceInfo, req.RememberMe)
+	// Issue an access token bound to the new session so revoking the session also rejects it
+	accessToken, refreshToken, err := h.refreshTokenService.CreateSession(ctx, usr, deviceInfo, req.RememberMe)
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create refresh token", err.Error())
 	}
@@ -53,7 +57,7 @@ func (h *AuthHandler) LoginWithRememberMe(c *fiber.Ctx) error {
 }
 
 func (h *AuthHandler) RefreshAccessToken(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var req request.RefreshAccessTokenRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -61,32 +65,27 @@ func (h *AuthHandler) RefreshAccessToken(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
-	claims := c.Locals("user")
-	if claims == nil {
-		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", "No user context found")
+	// The access token is usually expired here, so the session is identified by the refresh token alone
+	deviceInfo := service.DeviceInfo{
+		UserAgent: string(c.Request().Header.UserAgent()),
+		IPAddress: c.IP(),
 	}
 
-	userClaims := claims.(*utils.Claims)
-
-	newAccessToken, newRefreshToken, err := h.refreshTokenService.RefreshAccessToken(
-		ctx,
-		req.RefreshToken,
-		userClaims.UserID,
-		userClaims.Email,
-		userClaims.UserType,
-	)
+	newAccessToken, newRefreshToken, err := h.refreshTokenService.RefreshAccessToken(ctx, req.RefreshToken, deviceInfo)
 	if err != nil {
 		switch err {
-		case service.ErrRefreshTokenNotFound:
+		case service.ErrRefreshTokenNotFound, service.ErrInvalidRefreshToken:
 			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid refresh token", err.Error())
 		case service.ErrRefreshTokenExpired:
 			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Refresh token expired", err.Error())
 		case service.ErrRefreshTokenRevoked:
 			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Refresh token has been revoked", err.Error())
+		case service.ErrRefreshTokenReused:
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Refresh token reuse detected. Please login again.", err.Error())
 		default:
 			return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to refresh token", err.Error())
 		}
@@ -98,15 +97,13 @@ func (h *AuthHandler) RefreshAccessToken(c *fiber.Ctx) error {
 }
 
 func (h *AuthHandler) GetActiveDevices(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
-	claims := c.Locals("user")
-	if claims == nil {
+	userClaims := middleware.GetClaims(c)
+	if userClaims == nil {
 		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", "No user context found")
 	}
 
-	userClaims := claims.(*utils.Claims)
-
 	devices, err := h.refreshTokenService.GetUserDevices(ctx, userClaims.UserID)
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get devices", err.Error())
@@ -123,22 +120,20 @@ func (h *AuthHandler) GetActiveDevices(c *fiber.Ctx) error {
 }
 
 func (h *AuthHandler) RevokeDevice(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
-	claims := c.Locals("user")
-	if claims == nil {
+	userClaims := middleware.GetClaims(c)
+	if userClaims == nil {
 		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", "No user context found")
 	}
 
-	userClaims := claims.(*utils.Claims)
-
 	var req request.RevokeDeviceRequest
 	if err := c.BodyParser(&req); err != nil {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
@@ -150,18 +145,60 @@ func (h *AuthHandler) RevokeDevice(c *fiber.Ctx) error {
 }
 
 func (h *AuthHandler) LogoutAllDevices(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
-	claims := c.Locals("user")
-	if claims == nil {
+	userClaims := middleware.GetClaims(c)
+	if userClaims == nil {
 		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", "No user context found")
 	}
 
-	userClaims := claims.(*utils.Claims)
-
 	if err := h.refreshTokenService.RevokeAllUserTokens(ctx, userClaims.UserID, "logout_all_devices"); err != nil {
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to logout from all devices", err.Error())
 	}
 
 	return utils.SuccessResponse(c, "Logged out from all devices successfully", nil)
 }
+
+func (h *AuthHandler) GetSessions(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	userClaims := middleware.GetClaims(c)
+	if userClaims == nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", "No user context found")
+	}
+
+	sessions, err := h.refreshTokenService.GetUserDevices(ctx, userClaims.UserID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get sessions", err.Error())
+	}
+
+	sessionPointers := make([]*auth.RefreshToken, len(sessions))
+	for i := range sessions {
+		sessionPointers[i] = &sessions[i]
+	}
+
+	return utils.SuccessResponse(c, "Active sessions retrieved successfully", mapper.ToSessionListResponse(sessionPointers, userClaims.SessionID))
+}
+
+func (h *AuthHandler) RevokeSession(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	userClaims := middleware.GetClaims(c)
+	if userClaims == nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized", "No user context found")
+	}
+
+	sessionID, err := c.ParamsInt("id")
+	if err != nil || sessionID <= 0 {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid session ID", "")
+	}
+
+	if err := h.refreshTokenService.RevokeSession(ctx, userClaims.UserID, int64(sessionID)); err != nil {
+		if err == service.ErrRefreshTokenNotFound {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Session not found", err.Error())
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to revoke session", err.Error())
+	}
+
+	return utils.SuccessResponse(c, "Session revoked successfully", nil)
+}
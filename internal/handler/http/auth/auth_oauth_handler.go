@@ -14,7 +14,7 @@ import (
 )
 
 func (h *AuthHandler) InitiateGoogleLogin(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	req := service.GoogleAuthURLRequest{
 		ClientType:           c.Query("client"),
@@ -37,7 +37,7 @@ func (h *AuthHandler) InitiateGoogleLogin(c *fiber.Ctx) error {
 }
 
 func (h *AuthHandler) HandleGoogleCallback(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	code := c.Query("code")
 	state := c.Query("state")
@@ -74,7 +74,7 @@ func (h *AuthHandler) HandleGoogleCallback(c *fiber.Ctx) error {
 }
 
 func (h *AuthHandler) ExchangeGoogleOAuthCode(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var req request.GoogleOAuthExchangeRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -82,7 +82,7 @@ func (h *AuthHandler) ExchangeGoogleOAuthCode(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
@@ -100,7 +100,7 @@ func (h *AuthHandler) ExchangeGoogleOAuthCode(c *fiber.Ctx) error {
 }
 
 func (h *AuthHandler) ExchangeOneTimeCode(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var req request.OneTimeCodeExchangeRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -108,7 +108,7 @@ func (h *AuthHandler) ExchangeOneTimeCode(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
@@ -127,7 +127,7 @@ func (h *AuthHandler) ExchangeOneTimeCode(c *fiber.Ctx) error {
 }
 
 func (h *AuthHandler) GetConnectedProviders(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	claims := c.Locals("user")
 	if claims == nil {
@@ -152,7 +152,7 @@ func (h *AuthHandler) GetConnectedProviders(c *fiber.Ctx) error {
 }
 
 func (h *AuthHandler) DisconnectOAuth(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	claims := c.Locals("user")
 	if claims == nil {
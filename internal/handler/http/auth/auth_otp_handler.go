@@ -1,6 +1,11 @@
 package authhandler
 
 import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"keerja-backend/internal/apperror"
 	"keerja-backend/internal/dto/request"
 	"keerja-backend/internal/service"
 	"keerja-backend/internal/utils"
@@ -9,7 +14,7 @@ import (
 )
 
 func (h *AuthHandler) RegisterWithOTP(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var req request.RegisterRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -17,7 +22,7 @@ func (h *AuthHandler) RegisterWithOTP(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
@@ -27,12 +32,16 @@ func (h *AuthHandler) RegisterWithOTP(c *fiber.Ctx) error {
 		req.Phone = utils.SanitizeString(req.Phone)
 	}
 
-	if err := h.registrationService.RegisterUser(ctx, req.FullName, req.Email, req.Password, req.Phone, req.UserType); err != nil {
+	if err := h.registrationService.RegisterUser(ctx, req.FullName, req.Email, req.Password, req.Phone, req.UserType, c.IP(), strings.TrimSpace(req.InvitationToken)); err != nil {
 		if err == service.ErrEmailAlreadyExists {
 			return utils.ErrorResponse(c, fiber.StatusConflict, "Email already exists", err.Error())
 		}
-		if err == service.ErrTooManyOTPRequests {
-			return utils.ErrorResponse(c, fiber.StatusTooManyRequests, "Too many OTP requests. Please try again later.", err.Error())
+		if limitErr, ok := asOTPRateLimit(err); ok {
+			return otpRateLimitResponse(c, limitErr, "Too many OTP requests. Please try again later.")
+		}
+		if _, ok := apperror.As(err); ok {
+			// Invitation errors (expired, another email, ...) carry their own status
+			return err
 		}
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to register user", err.Error())
 	}
@@ -44,7 +53,7 @@ func (h *AuthHandler) RegisterWithOTP(c *fiber.Ctx) error {
 }
 
 func (h *AuthHandler) VerifyEmailOTP(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var req request.VerifyEmailOTPRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -52,7 +61,7 @@ func (h *AuthHandler) VerifyEmailOTP(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
@@ -85,7 +94,7 @@ func (h *AuthHandler) VerifyEmailOTP(c *fiber.Ctx) error {
 }
 
 func (h *AuthHandler) ResendOTP(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var req request.ResendOTPRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -93,21 +102,21 @@ func (h *AuthHandler) ResendOTP(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
 	req.Email = utils.SanitizeString(req.Email)
 
-	if err := h.registrationService.ResendOTP(ctx, req.Email); err != nil {
+	if err := h.registrationService.ResendOTP(ctx, req.Email, c.IP()); err != nil {
 		if err == service.ErrUserAlreadyVerified {
 			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Email already verified", err.Error())
 		}
-		if err == service.ErrResendTooSoon {
-			return utils.ErrorResponse(c, fiber.StatusTooManyRequests, "Please wait before requesting a new OTP", err.Error())
-		}
-		if err == service.ErrTooManyOTPRequests {
-			return utils.ErrorResponse(c, fiber.StatusTooManyRequests, "Too many OTP requests. Please try again later.", err.Error())
+		if limitErr, ok := asOTPRateLimit(err); ok {
+			if errors.Is(limitErr, service.ErrResendTooSoon) {
+				return otpRateLimitResponse(c, limitErr, "Please wait before requesting a new OTP")
+			}
+			return otpRateLimitResponse(c, limitErr, "Too many OTP requests. Please try again later.")
 		}
 		return utils.SuccessResponse(c, "If the email exists and is not verified, a new OTP has been sent", nil)
 	}
@@ -119,7 +128,7 @@ func (h *AuthHandler) ResendOTP(c *fiber.Ctx) error {
 }
 
 func (h *AuthHandler) ResendVerification(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var req request.ResendVerificationRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -127,15 +136,43 @@ func (h *AuthHandler) ResendVerification(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
 	req.Email = utils.SanitizeString(req.Email)
 
-	if err := h.authService.ResendVerificationEmail(ctx, req.Email); err != nil {
-		return utils.SuccessResponse(c, "If the email exists and is not verified, a verification link has been sent", nil)
+	// Sends a verification OTP under the same issuance limits as /resend-otp. Unknown and
+	// already verified emails get the neutral response so accounts cannot be enumerated.
+	if err := h.registrationService.ResendOTP(ctx, req.Email, c.IP()); err != nil {
+		if limitErr, ok := asOTPRateLimit(err); ok {
+			if errors.Is(limitErr, service.ErrResendTooSoon) {
+				return otpRateLimitResponse(c, limitErr, "Please wait before requesting a new verification code")
+			}
+			return otpRateLimitResponse(c, limitErr, "Too many verification requests. Please try again later.")
+		}
 	}
 
-	return utils.SuccessResponse(c, "Verification email sent successfully", nil)
+	return utils.SuccessResponse(c, "If the email exists and is not verified, a verification code has been sent", fiber.Map{
+		"note": "Verify the code at /api/v1/auth/verify-email-otp. It is valid for 5 minutes.",
+	})
+}
+
+// asOTPRateLimit reports whether err is an OTP issuance limit
+func asOTPRateLimit(err error) (*service.OTPRateLimitError, bool) {
+	var limitErr *service.OTPRateLimitError
+	if errors.As(err, &limitErr) {
+		return limitErr, true
+	}
+	return nil, false
+}
+
+// otpRateLimitResponse writes a 429 with a Retry-After header and a retry_after field in seconds
+func otpRateLimitResponse(c *fiber.Ctx, limitErr *service.OTPRateLimitError, message string) error {
+	retryAfter := limitErr.RetryAfterSeconds()
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+	return utils.ErrorResponseWithErrors(c, fiber.StatusTooManyRequests, message, fiber.Map{
+		"error":       limitErr.Err.Error(),
+		"retry_after": retryAfter,
+	})
 }
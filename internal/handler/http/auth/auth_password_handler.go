@@ -9,7 +9,7 @@ import (
 )
 
 func (h *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var req request.ForgotPasswordRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -17,7 +17,7 @@ func (h *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
@@ -31,7 +31,7 @@ func (h *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
 }
 
 func (h *AuthHandler) ResetPassword(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var req request.ResetPasswordRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -39,7 +39,7 @@ func (h *AuthHandler) ResetPassword(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
@@ -59,7 +59,7 @@ func (h *AuthHandler) ResetPassword(c *fiber.Ctx) error {
 }
 
 func (h *AuthHandler) ForgotPasswordOTP(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var req request.ForgotPasswordOTPRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -67,15 +67,15 @@ func (h *AuthHandler) ForgotPasswordOTP(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
 	req.Email = utils.SanitizeString(req.Email)
 
-	if err := h.registrationService.RequestPasswordResetOTP(ctx, req.Email); err != nil {
-		if err == service.ErrTooManyOTPRequests {
-			return utils.ErrorResponse(c, fiber.StatusTooManyRequests, "Too many password reset requests. Please try again later.", err.Error())
+	if err := h.registrationService.RequestPasswordResetOTP(ctx, req.Email, c.IP()); err != nil {
+		if limitErr, ok := asOTPRateLimit(err); ok {
+			return otpRateLimitResponse(c, limitErr, "Too many password reset requests. Please try again later.")
 		}
 		if err == service.ErrEmailNotVerified {
 			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Email not verified", err.Error())
@@ -93,7 +93,7 @@ func (h *AuthHandler) ForgotPasswordOTP(c *fiber.Ctx) error {
 }
 
 func (h *AuthHandler) ResetPasswordOTP(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var req request.ResetPasswordOTPRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -101,7 +101,7 @@ func (h *AuthHandler) ResetPasswordOTP(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
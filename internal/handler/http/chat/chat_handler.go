@@ -35,7 +35,7 @@ func NewChatHandler(
 
 // CreateConversation handles POST /conversations
 func (h *ChatHandler) CreateConversation(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	var req request.CreateConversationRequest
@@ -63,7 +63,7 @@ func (h *ChatHandler) CreateConversation(c *fiber.Ctx) error {
 
 // GetConversations handles GET /conversations
 func (h *ChatHandler) GetConversations(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	var filter request.ConversationFilterRequest
@@ -100,7 +100,7 @@ func (h *ChatHandler) GetConversations(c *fiber.Ctx) error {
 
 // GetConversationMessages handles GET /conversations/:id/messages
 func (h *ChatHandler) GetConversationMessages(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	conversationID, err := strconv.ParseInt(c.Params("id"), 10, 64)
@@ -132,7 +132,7 @@ func (h *ChatHandler) GetConversationMessages(c *fiber.Ctx) error {
 
 // SendMessage handles POST /conversations/:id/messages
 func (h *ChatHandler) SendMessage(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	conversationID, err := strconv.ParseInt(c.Params("id"), 10, 64)
@@ -166,7 +166,7 @@ func (h *ChatHandler) SendMessage(c *fiber.Ctx) error {
 
 // MarkAsRead handles PUT /conversations/:id/messages/:msgId/read
 func (h *ChatHandler) MarkAsRead(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	messageID, err := strconv.ParseInt(c.Params("msgId"), 10, 64)
@@ -183,7 +183,7 @@ func (h *ChatHandler) MarkAsRead(c *fiber.Ctx) error {
 
 // ArchiveConversation handles PUT /conversations/:id/archive
 func (h *ChatHandler) ArchiveConversation(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	conversationID, err := strconv.ParseInt(c.Params("id"), 10, 64)
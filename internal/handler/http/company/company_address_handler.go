@@ -36,7 +36,7 @@ func NewCompanyAddressHandler(
 }
 
 func (h *CompanyAddressHandler) GetMyAddresses(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	userID := middleware.GetUserID(c)
 	if userID == 0 {
@@ -67,8 +67,10 @@ func (h *CompanyAddressHandler) GetMyAddresses(c *fiber.Ctx) error {
 	responses := make([]interface{}, 0, len(addrs))
 	for _, a := range addrs {
 		addrResp := response.CompanyAddressResponse{
-			ID:          a.ID,
-			FullAddress: a.FullAddress,
+			ID:                a.ID,
+			FullAddress:       a.FullAddress,
+			GeocodeProvider:   a.GeocodeProvider,
+			GeocodeConfidence: a.GeocodeConfidence,
 		}
 		if a.Latitude != nil {
 			addrResp.Latitude = *a.Latitude
@@ -119,7 +121,7 @@ func (h *CompanyAddressHandler) GetMyAddresses(c *fiber.Ctx) error {
 }
 
 func (h *CompanyAddressHandler) CreateMyAddress(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	userID := middleware.GetUserID(c)
 	if userID == 0 {
@@ -131,7 +133,7 @@ func (h *CompanyAddressHandler) CreateMyAddress(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, common.ErrInvalidBody)
 	}
 	if err := utils.ValidateStruct(&req); err != nil {
-		errs := utils.FormatValidationErrors(err)
+		errs := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
 	}
 
@@ -165,8 +167,10 @@ func (h *CompanyAddressHandler) CreateMyAddress(c *fiber.Ctx) error {
 	}
 
 	resp := response.CompanyAddressResponse{
-		ID:          addr.ID,
-		FullAddress: addr.FullAddress,
+		ID:                addr.ID,
+		FullAddress:       addr.FullAddress,
+		GeocodeProvider:   addr.GeocodeProvider,
+		GeocodeConfidence: addr.GeocodeConfidence,
 	}
 	if addr.Latitude != nil {
 		resp.Latitude = *addr.Latitude
@@ -179,7 +183,7 @@ func (h *CompanyAddressHandler) CreateMyAddress(c *fiber.Ctx) error {
 }
 
 func (h *CompanyAddressHandler) UpdateMyAddress(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	userID := middleware.GetUserID(c)
 	if userID == 0 {
@@ -196,7 +200,7 @@ func (h *CompanyAddressHandler) UpdateMyAddress(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, common.ErrInvalidBody)
 	}
 	if err := utils.ValidateStruct(&req); err != nil {
-		errs := utils.FormatValidationErrors(err)
+		errs := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
 	}
 
@@ -241,8 +245,10 @@ func (h *CompanyAddressHandler) UpdateMyAddress(c *fiber.Ctx) error {
 	}
 
 	resp := response.CompanyAddressResponse{
-		ID:          updated.ID,
-		FullAddress: updated.FullAddress,
+		ID:                updated.ID,
+		FullAddress:       updated.FullAddress,
+		GeocodeProvider:   updated.GeocodeProvider,
+		GeocodeConfidence: updated.GeocodeConfidence,
 	}
 	if updated.Latitude != nil {
 		resp.Latitude = *updated.Latitude
@@ -264,7 +270,7 @@ func (h *CompanyAddressHandler) UpdateMyAddress(c *fiber.Ctx) error {
 }
 
 func (h *CompanyAddressHandler) DeleteMyAddress(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	userID := middleware.GetUserID(c)
 	if userID == 0 {
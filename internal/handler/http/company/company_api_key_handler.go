@@ -0,0 +1,86 @@
+package companyhandler
+
+import (
+	"keerja-backend/internal/domain/apikey"
+	"keerja-backend/internal/dto/mapper"
+	"keerja-backend/internal/dto/request"
+	"keerja-backend/internal/dto/response"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CompanyAPIKeyHandler handles management of company API keys used by ATS integrations
+type CompanyAPIKeyHandler struct {
+	apiKeyService apikey.APIKeyService
+}
+
+// NewCompanyAPIKeyHandler creates a new instance of CompanyAPIKeyHandler
+func NewCompanyAPIKeyHandler(apiKeyService apikey.APIKeyService) *CompanyAPIKeyHandler {
+	return &CompanyAPIKeyHandler{apiKeyService: apiKeyService}
+}
+
+// CreateAPIKey creates an API key for the company. The plaintext key is only returned here.
+func (h *CompanyAPIKeyHandler) CreateAPIKey(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+
+	var req request.CreateCompanyAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	req.Name = utils.SanitizeString(req.Name)
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	key, rawKey, err := h.apiKeyService.CreateKey(middleware.AuditContext(c), companyID, middleware.GetUserID(c), req.Name, req.Scopes)
+	if err != nil {
+		return err
+	}
+
+	resp := response.CreatedCompanyAPIKeyResponse{
+		CompanyAPIKeyResponse: *mapper.ToCompanyAPIKeyResponse(key),
+		Key:                   rawKey,
+	}
+	return utils.CreatedResponse(c, "API key created. Store it now, it will not be shown again.", resp)
+}
+
+// ListAPIKeys lists the company's API keys, showing key prefixes only
+func (h *CompanyAPIKeyHandler) ListAPIKeys(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+
+	keys, err := h.apiKeyService.ListKeys(c.UserContext(), companyID)
+	if err != nil {
+		return err
+	}
+
+	resp := mapper.MapEntities[apikey.CompanyAPIKey, response.CompanyAPIKeyResponse](keys, mapper.ToCompanyAPIKeyResponse)
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, resp)
+}
+
+// RevokeAPIKey revokes one of the company's API keys
+func (h *CompanyAPIKeyHandler) RevokeAPIKey(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+	keyID, err := utils.ParseIDParam(c, "keyId")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	if err := h.apiKeyService.RevokeKey(middleware.AuditContext(c), companyID, keyID); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, "API key revoked successfully", nil)
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/employer"
 	"keerja-backend/internal/domain/master"
 	"keerja-backend/internal/dto/mapper"
 	"keerja-backend/internal/dto/request"
@@ -48,27 +49,42 @@ func NewCompanyBasicHandler(
 }
 
 func (h *CompanyBasicHandler) ListCompanies(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
+
+	page, limit, err := utils.ParsePagination(c, 10, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
 
 	var q request.CompanySearchRequest
 	if err := c.QueryParser(&q); err != nil {
 		return utils.BadRequestResponse(c, common.ErrInvalidQueryParams)
 	}
+	q.Page, q.Limit = page, limit
 	if err := utils.ValidateStruct(&q); err != nil {
-		errs := utils.FormatValidationErrors(err)
+		errs := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
 	}
-	q.Page, q.Limit = utils.ValidatePagination(q.Page, q.Limit, 100)
 
 	q.Query = utils.SanitizeString(q.Query)
 	q.Location = utils.SanitizeString(q.Location)
 
 	filter := helpers.BuildCompanyFilter(q)
 
+	if utils.UsesCursor(c) {
+		if (q.SortBy != "" && q.SortBy != "created_at") || q.SortOrder == "asc" {
+			return utils.ErrCursorSortUnsupported
+		}
+		companies, next, err := h.companyService.ListCompaniesByCursor(ctx, filter, q.Cursor)
+		if err != nil {
+			return err
+		}
+		return utils.SuccessResponse(c, common.MsgFetchedSuccess, response.NewCursorPage(toCompanyResponses(companies), next))
+	}
+
 	var (
 		companies []company.Company
 		total     int64
-		err       error
 	)
 	if q.Query != "" {
 		companies, total, err = h.companyService.SearchCompanies(ctx, q.Query, filter)
@@ -79,6 +95,13 @@ func (h *CompanyBasicHandler) ListCompanies(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, common.ErrFailedOperation, err.Error())
 	}
 
+	meta := utils.GetPaginationMeta(q.Page, q.Limit, total)
+	payload := response.CompanyListResponse{Companies: toCompanyResponses(companies)}
+	return utils.SuccessResponseWithMeta(c, common.MsgFetchedSuccess, payload, meta)
+}
+
+// toCompanyResponses maps companies to list responses
+func toCompanyResponses(companies []company.Company) []response.CompanyResponse {
 	respList := make([]response.CompanyResponse, 0, len(companies))
 	for _, comp := range companies {
 		cr := mapper.ToCompanyResponse(&comp)
@@ -86,14 +109,11 @@ func (h *CompanyBasicHandler) ListCompanies(c *fiber.Ctx) error {
 			respList = append(respList, *cr)
 		}
 	}
-
-	meta := utils.GetPaginationMeta(q.Page, q.Limit, total)
-	payload := response.CompanyListResponse{Companies: respList}
-	return utils.SuccessResponseWithMeta(c, common.MsgFetchedSuccess, payload, meta)
+	return respList
 }
 
 func (h *CompanyBasicHandler) CreateCompany(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	userID := middleware.GetUserID(c)
 	if userID == 0 {
@@ -195,7 +215,7 @@ func (h *CompanyBasicHandler) CreateCompany(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errors)
 	}
 
@@ -374,7 +394,7 @@ func (h *CompanyBasicHandler) CreateCompany(c *fiber.Ctx) error {
 }
 
 func (h *CompanyBasicHandler) GetCompany(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	companyID, err := utils.ParseIDParam(c, "id")
 	if err != nil || companyID <= 0 {
@@ -383,7 +403,7 @@ func (h *CompanyBasicHandler) GetCompany(c *fiber.Ctx) error {
 
 	companyData, err := h.companyService.GetCompany(ctx, companyID)
 	if err != nil {
-		return utils.NotFoundResponse(c, common.ErrNotFound)
+		return err
 	}
 
 	response := mapper.ToCompanyResponse(companyData)
@@ -391,7 +411,7 @@ func (h *CompanyBasicHandler) GetCompany(c *fiber.Ctx) error {
 }
 
 func (h *CompanyBasicHandler) GetCompanyBySlug(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	slug := utils.SanitizeString(strings.TrimSpace(c.Params("slug")))
 	if slug == "" {
 		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
@@ -399,14 +419,30 @@ func (h *CompanyBasicHandler) GetCompanyBySlug(c *fiber.Ctx) error {
 
 	companyData, err := h.companyService.GetCompanyBySlug(ctx, slug)
 	if err != nil {
-		return utils.NotFoundResponse(c, common.ErrNotFound)
+		return err
 	}
 	responseDTO := mapper.ToCompanyResponse(companyData)
 	return utils.SuccessResponse(c, common.MsgFetchedSuccess, responseDTO)
 }
 
+// GetPublicProfile returns the public company page in one response: company, latest jobs,
+// review summary and follower count. is_following is included for signed-in users.
+func (h *CompanyBasicHandler) GetPublicProfile(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	slug := utils.SanitizeString(strings.TrimSpace(c.Params("slug")))
+	if slug == "" {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+
+	profile, err := h.companyService.GetPublicProfile(ctx, slug, middleware.GetUserID(c))
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, mapper.ToPublicCompanyProfileResponse(profile))
+}
+
 func (h *CompanyBasicHandler) UpdateCompany(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	userID := middleware.GetUserID(c)
 	if userID == 0 {
@@ -434,10 +470,15 @@ func (h *CompanyBasicHandler) UpdateCompany(c *fiber.Ctx) error {
 		}
 
 		if err := utils.ValidateStruct(&req); err != nil {
-			errors := utils.FormatValidationErrors(err)
+			errors := utils.FormatValidationErrors(c, err)
 			return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errors)
 		}
 
+		version, err := utils.RequestVersion(c, req.Version)
+		if err != nil {
+			return utils.BadRequestResponse(c, err.Error())
+		}
+
 		domainReq := &company.UpdateCompanyRequest{
 			FullAddress:        req.FullAddress,
 			ShortDescription:   req.ShortDescription,
@@ -448,10 +489,11 @@ func (h *CompanyBasicHandler) UpdateCompany(c *fiber.Ctx) error {
 			TwitterURL:         req.TwitterURL,
 			CompanyDescription: req.CompanyDescription,
 			CompanyCulture:     req.CompanyCulture,
+			Version:            version,
 		}
 
 		if err := h.companyService.UpdateCompany(ctx, companyID, domainReq, nil, nil); err != nil {
-			return utils.InternalServerErrorResponse(c, common.ErrFailedOperation)
+			return err
 		}
 
 		return utils.SuccessResponse(c, common.MsgUpdatedSuccess, nil)
@@ -496,9 +538,16 @@ func (h *CompanyBasicHandler) UpdateCompany(c *fiber.Ctx) error {
 	if companyCulture != "" {
 		req.CompanyCulture = &companyCulture
 	}
+	if formVersion := c.FormValue("version"); formVersion != "" {
+		version, err := strconv.ParseInt(formVersion, 10, 64)
+		if err != nil {
+			return utils.BadRequestResponse(c, "version must be a number")
+		}
+		req.Version = &version
+	}
 
 	if err := utils.ValidateStruct(req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errors)
 	}
 
@@ -535,6 +584,11 @@ func (h *CompanyBasicHandler) UpdateCompany(c *fiber.Ctx) error {
 		logoFile = files[0]
 	}
 
+	version, err := utils.RequestVersion(c, req.Version)
+	if err != nil {
+		return utils.BadRequestResponse(c, err.Error())
+	}
+
 	domainReq := &company.UpdateCompanyRequest{
 		FullAddress:        req.FullAddress,
 		ShortDescription:   req.ShortDescription,
@@ -545,17 +599,18 @@ func (h *CompanyBasicHandler) UpdateCompany(c *fiber.Ctx) error {
 		TwitterURL:         req.TwitterURL,
 		CompanyDescription: req.CompanyDescription,
 		CompanyCulture:     req.CompanyCulture,
+		Version:            version,
 	}
 
 	if err := h.companyService.UpdateCompany(ctx, companyID, domainReq, bannerFile, logoFile); err != nil {
-		return utils.InternalServerErrorResponse(c, common.ErrFailedOperation)
+		return err
 	}
 
 	return utils.SuccessResponse(c, common.MsgUpdatedSuccess, nil)
 }
 
 func (h *CompanyBasicHandler) DeleteCompany(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	userID := middleware.GetUserID(c)
 	if userID == 0 {
@@ -567,7 +622,7 @@ func (h *CompanyBasicHandler) DeleteCompany(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
 	}
 
-	isOwner, err := h.companyService.CheckEmployerPermission(ctx, userID, int64(companyID), "owner")
+	isOwner, err := h.companyService.CheckEmployerPermission(ctx, userID, int64(companyID), employer.RoleOwner)
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to check user permission", err.Error())
 	}
@@ -575,15 +630,100 @@ func (h *CompanyBasicHandler) DeleteCompany(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusForbidden, "You don't have permission to delete this company. Only company owner can perform this action.", "")
 	}
 
-	if err := h.companyService.DeleteCompany(ctx, int64(companyID)); err != nil {
+	if err := h.companyService.DeleteCompany(middleware.AuditContext(c), int64(companyID)); err != nil {
 		return utils.InternalServerErrorResponse(c, common.ErrFailedOperation)
 	}
 
 	return utils.SuccessResponse(c, common.MsgDeletedSuccess, nil)
 }
 
+func (h *CompanyBasicHandler) GetSettings(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil || companyID <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+
+	settings, err := h.companyService.GetSettings(ctx, companyID)
+	if err != nil {
+		return utils.InternalServerErrorResponse(c, common.ErrFailedOperation)
+	}
+	changeRequests, err := h.companyService.GetChangeRequests(ctx, companyID)
+	if err != nil {
+		return utils.InternalServerErrorResponse(c, common.ErrFailedOperation)
+	}
+
+	resp := mapper.ToCompanySettingsResponse(settings)
+	resp.ChangeRequests = mapper.MapEntities[company.CompanyChangeRequest, response.CompanyChangeRequestResponse](changeRequests, mapper.ToCompanyChangeRequestResponse)
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, resp)
+}
+
+// CreateChangeRequest asks an admin to change the company's industry, size or district,
+// which employers cannot edit directly
+func (h *CompanyBasicHandler) CreateChangeRequest(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil || companyID <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+
+	var req request.CreateCompanyChangeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	req.Reason = utils.SanitizeString(req.Reason)
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	changeReq, err := h.companyService.RequestCompanyChange(c.UserContext(), companyID, middleware.GetUserID(c), &company.ChangeRequestInput{
+		IndustryID:    req.IndustryID,
+		CompanySizeID: req.CompanySizeID,
+		DistrictID:    req.DistrictID,
+		Reason:        req.Reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	return utils.CreatedResponse(c, "Change request submitted for admin review", mapper.ToCompanyChangeRequestResponse(changeReq))
+}
+
+func (h *CompanyBasicHandler) UpdateSettings(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil || companyID <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+
+	var req request.UpdateCompanySettingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	settings, err := h.companyService.UpdateSettings(ctx, companyID, &company.UpdateSettingsRequest{
+		StageReminderEnabled:  req.StageReminderEnabled,
+		StageReminderDays:     req.StageReminderDays,
+		BlindScreeningEnabled: req.BlindScreeningEnabled,
+		WeeklyDigestEnabled:   req.WeeklyDigestEnabled,
+		AutoJoinEnabled:       req.AutoJoinEnabled,
+		AutoJoinRole:          req.AutoJoinRole,
+	})
+	if err != nil {
+		return utils.InternalServerErrorResponse(c, common.ErrFailedOperation)
+	}
+
+	return utils.SuccessResponse(c, common.MsgUpdatedSuccess, mapper.ToCompanySettingsResponse(settings))
+}
+
 func (h *CompanyBasicHandler) GetMyCompanies(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	userID := middleware.GetUserID(c)
 	if userID == 0 {
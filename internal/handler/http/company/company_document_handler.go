@@ -0,0 +1,60 @@
+package companyhandler
+
+import (
+	"fmt"
+
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/dto/response"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/handler/http/upload"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CompanyDocumentHandler hands out signed download links for company legal and verification documents
+type CompanyDocumentHandler struct {
+	companyService company.CompanyService
+	signer         *service.DownloadSigner
+}
+
+// NewCompanyDocumentHandler creates a new instance of CompanyDocumentHandler
+func NewCompanyDocumentHandler(companyService company.CompanyService, signer *service.DownloadSigner) *CompanyDocumentHandler {
+	return &CompanyDocumentHandler{
+		companyService: companyService,
+		signer:         signer,
+	}
+}
+
+// DownloadDocument returns a short-lived signed link to one of the company's documents.
+// Only platform admins and the company's owners and admins may download them.
+func (h *CompanyDocumentHandler) DownloadDocument(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+	documentID, err := utils.ParseIDParam(c, "docId")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	doc, err := h.companyService.GetDocumentForDownload(c.UserContext(), companyID, documentID, middleware.GetUserID(c), middleware.GetUserType(c))
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%d", doc.DocumentType, doc.ID)
+	if doc.DocumentName != nil && *doc.DocumentName != "" {
+		name = *doc.DocumentName
+	}
+	fileName := upload.DownloadFileName(name, doc.FilePath)
+	url, expiresAt := h.signer.URL(c.BaseURL(), doc.FilePath, fileName)
+
+	return utils.SuccessResponse(c, "Download link created", response.DownloadLinkResponse{
+		URL:       url,
+		FileName:  fileName,
+		ExpiresAt: &expiresAt,
+	})
+}
@@ -0,0 +1,158 @@
+package companyhandler
+
+import (
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/dto/request"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CompanyDomainHandler handles company email domain verification and domain join requests
+type CompanyDomainHandler struct {
+	domainService company.DomainService
+}
+
+// NewCompanyDomainHandler creates a new instance of CompanyDomainHandler
+func NewCompanyDomainHandler(domainService company.DomainService) *CompanyDomainHandler {
+	return &CompanyDomainHandler{domainService: domainService}
+}
+
+// StartDomainVerification claims the company's email domain by email code or DNS TXT record
+func (h *CompanyDomainHandler) StartDomainVerification(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+
+	var req request.StartDomainVerificationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	challenge, err := h.domainService.StartDomainVerification(c.UserContext(), companyID, req.Method)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, "Domain verification started", challenge)
+}
+
+// VerifyDomain completes the company's domain claim
+func (h *CompanyDomainHandler) VerifyDomain(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+
+	var req request.VerifyDomainRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	verification, err := h.domainService.VerifyDomain(c.UserContext(), companyID, req.Code)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, "Domain verified successfully", verification)
+}
+
+// GetDomainVerification returns the state of the company's domain claim
+func (h *CompanyDomainHandler) GetDomainVerification(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+
+	verification, err := h.domainService.GetDomainVerification(c.UserContext(), companyID)
+	if err != nil {
+		return err
+	}
+	if verification == nil {
+		return utils.NotFoundResponse(c, "No domain verification has been started")
+	}
+
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, verification)
+}
+
+// GetCompanySuggestions lists the companies on the user's email domain they may ask to join
+func (h *CompanyDomainHandler) GetCompanySuggestions(c *fiber.Ctx) error {
+	suggestions, err := h.domainService.GetCompanySuggestions(c.UserContext(), middleware.GetUserID(c))
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, suggestions)
+}
+
+// RequestToJoin asks to join a company on the user's email domain
+func (h *CompanyDomainHandler) RequestToJoin(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+
+	employerUser, err := h.domainService.RequestToJoin(c.UserContext(), companyID, middleware.GetUserID(c))
+	if err != nil {
+		return err
+	}
+
+	return utils.CreatedResponse(c, "Join request sent. A company admin needs to approve it.", fiber.Map{
+		"employer_user_id":  employerUser.ID,
+		"company_id":        employerUser.CompanyID,
+		"role":              employerUser.Role,
+		"join_requested_at": employerUser.JoinRequestedAt,
+	})
+}
+
+// ApproveJoinRequest activates a pending domain join request
+func (h *CompanyDomainHandler) ApproveJoinRequest(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+	employerUserID, err := utils.ParseIDParam(c, "employerUserId")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	employerUser, err := h.domainService.ApproveJoinRequest(c.UserContext(), companyID, employerUserID, middleware.GetUserID(c))
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, "Join request approved", fiber.Map{
+		"employer_user_id": employerUser.ID,
+		"user_id":          employerUser.UserID,
+		"role":             employerUser.Role,
+	})
+}
+
+// RejectJoinRequest removes a pending domain join request
+func (h *CompanyDomainHandler) RejectJoinRequest(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+	employerUserID, err := utils.ParseIDParam(c, "employerUserId")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	if err := h.domainService.RejectJoinRequest(c.UserContext(), companyID, employerUserID); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, "Join request rejected", nil)
+}
@@ -0,0 +1,149 @@
+package companyhandler
+
+import (
+	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/dto/mapper"
+	"keerja-backend/internal/dto/request"
+	"keerja-backend/internal/dto/response"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CompanyEmailTemplateHandler handles the company's own versions of candidate emails
+type CompanyEmailTemplateHandler struct {
+	templateService email.CompanyTemplateService
+}
+
+// NewCompanyEmailTemplateHandler creates a new instance of CompanyEmailTemplateHandler
+func NewCompanyEmailTemplateHandler(templateService email.CompanyTemplateService) *CompanyEmailTemplateHandler {
+	return &CompanyEmailTemplateHandler{templateService: templateService}
+}
+
+// ListVariables lists the {{variable}} placeholders the templates of each event may use
+func (h *CompanyEmailTemplateHandler) ListVariables(c *fiber.Ctx) error {
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, email.CompanyTemplateVariables)
+}
+
+// ListTemplates lists the company's email templates
+func (h *CompanyEmailTemplateHandler) ListTemplates(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+
+	templates, err := h.templateService.ListTemplates(c.UserContext(), companyID)
+	if err != nil {
+		return err
+	}
+
+	resp := mapper.MapEntities[email.CompanyEmailTemplate, response.CompanyEmailTemplateResponse](templates, mapper.ToCompanyEmailTemplateResponse)
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, resp)
+}
+
+// CreateTemplate adds the company's template for an event
+func (h *CompanyEmailTemplateHandler) CreateTemplate(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+
+	var req request.CreateCompanyEmailTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	t, err := h.templateService.CreateTemplate(c.UserContext(), companyID, middleware.GetUserID(c), email.CompanyTemplateInput{
+		EventType: req.EventType,
+		Subject:   req.Subject,
+		Body:      req.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	return utils.CreatedResponse(c, "Email template created successfully", mapper.ToCompanyEmailTemplateResponse(t))
+}
+
+// UpdateTemplate replaces the subject and body of one of the company's templates
+func (h *CompanyEmailTemplateHandler) UpdateTemplate(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+	templateID, err := utils.ParseIDParam(c, "templateId")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	var req request.UpdateCompanyEmailTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	t, err := h.templateService.UpdateTemplate(c.UserContext(), companyID, templateID, middleware.GetUserID(c), email.CompanyTemplateInput{
+		Subject: req.Subject,
+		Body:    req.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, "Email template updated successfully", mapper.ToCompanyEmailTemplateResponse(t))
+}
+
+// DeleteTemplate deletes one of the company's templates, restoring the platform email
+func (h *CompanyEmailTemplateHandler) DeleteTemplate(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+	templateID, err := utils.ParseIDParam(c, "templateId")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	if err := h.templateService.DeleteTemplate(c.UserContext(), companyID, templateID); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, "Email template deleted successfully", nil)
+}
+
+// PreviewTemplate renders a template with sample data without saving it
+func (h *CompanyEmailTemplateHandler) PreviewTemplate(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+
+	var req request.CreateCompanyEmailTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	rendered, err := h.templateService.PreviewTemplate(c.UserContext(), companyID, email.CompanyTemplateInput{
+		EventType: req.EventType,
+		Subject:   req.Subject,
+		Body:      req.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, "Email template rendered successfully", rendered)
+}
@@ -0,0 +1,50 @@
+package companyhandler
+
+import (
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CompanyEmployeeHandler handles company employee roster operations
+type CompanyEmployeeHandler struct {
+	companyService company.CompanyService
+}
+
+// NewCompanyEmployeeHandler creates a new instance of CompanyEmployeeHandler
+func NewCompanyEmployeeHandler(companyService company.CompanyService) *CompanyEmployeeHandler {
+	return &CompanyEmployeeHandler{
+		companyService: companyService,
+	}
+}
+
+// ImportEmployees imports the company's employee roster from an uploaded CSV file
+// and returns a per-row report. With dry_run=true the rows are only validated.
+func (h *CompanyEmployeeHandler) ImportEmployees(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := middleware.GetUserID(c)
+
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil || companyID <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrNoFileUploaded)
+	}
+
+	dryRun := c.QueryBool("dry_run", false)
+	report, err := h.companyService.ImportEmployees(ctx, companyID, userID, file, dryRun)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		return utils.SuccessResponse(c, "Employee import validated", report)
+	}
+	return utils.SuccessResponse(c, "Employee import completed", report)
+}
@@ -1,6 +1,8 @@
 package companyhandler
 
 import (
+	"strconv"
+
 	"keerja-backend/internal/domain/company"
 	"keerja-backend/internal/domain/master"
 	"keerja-backend/internal/domain/user"
@@ -39,22 +41,19 @@ func NewCompanyEmployerHandler(
 }
 
 func (h *CompanyEmployerHandler) GetMyEmployerProfile(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	userID := middleware.GetUserID(c)
 	if userID == 0 {
 		return utils.ErrorResponse(c, fiber.StatusUnauthorized, common.ErrUnauthorized, "userID not found in context")
 	}
 
-	companies, err := h.companyService.GetUserCompanies(ctx, userID)
-	if err != nil {
-		return utils.InternalServerErrorResponse(c, common.ErrFailedOperation)
-	}
-	if len(companies) == 0 {
-		return utils.ErrorResponse(c, fiber.StatusNotFound, common.ErrCompanyNotFound, "User is not affiliated with any company")
+	// The employer profile is the user's membership of the company RequireActiveCompany resolved
+	ec := middleware.GetEmployerContext(c)
+	if ec == nil {
+		return company.ErrNotCompanyEmployer
 	}
-
-	companyID := companies[0].ID
+	companyID := ec.CompanyID
 
 	employerUser, err := h.companyService.GetEmployerUser(ctx, userID, companyID)
 	if err != nil || employerUser == nil {
@@ -108,6 +107,7 @@ func (h *CompanyEmployerHandler) GetMyEmployerProfile(c *fiber.Ctx) error {
 		PositionTitle  *string `json:"position_title,omitempty"`
 		FullName       string  `json:"full_name,omitempty"`
 		User           any     `json:"user,omitempty"`
+		Version        int64   `json:"version"`
 	}
 
 	resp := employerResp{
@@ -116,6 +116,7 @@ func (h *CompanyEmployerHandler) GetMyEmployerProfile(c *fiber.Ctx) error {
 		CompanyID:      employerUser.CompanyID,
 		Role:           employerUser.Role,
 		PositionTitle:  employerUser.PositionTitle,
+		Version:        employerUser.Version,
 		FullName:       fullName,
 		User:           userObj,
 	}
@@ -124,7 +125,7 @@ func (h *CompanyEmployerHandler) GetMyEmployerProfile(c *fiber.Ctx) error {
 }
 
 func (h *CompanyEmployerHandler) UpdateMyEmployerProfile(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	userID := middleware.GetUserID(c)
 	if userID == 0 {
@@ -136,26 +137,23 @@ func (h *CompanyEmployerHandler) UpdateMyEmployerProfile(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, common.ErrInvalidBody)
 	}
 	if err := utils.ValidateStruct(&req); err != nil {
-		errs := utils.FormatValidationErrors(err)
+		errs := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
 	}
 
-	companies, err := h.companyService.GetUserCompanies(ctx, userID)
-	if err != nil {
-		return utils.InternalServerErrorResponse(c, common.ErrFailedOperation)
-	}
-	if len(companies) == 0 {
-		return utils.ErrorResponse(c, fiber.StatusNotFound, common.ErrCompanyNotFound, "User is not affiliated with any company")
+	// The employer profile is the user's membership of the company RequireActiveCompany resolved
+	ec := middleware.GetEmployerContext(c)
+	if ec == nil {
+		return company.ErrNotCompanyEmployer
 	}
+	companyID := ec.CompanyID
 
-	companyID := companies[0].ID
-
-	_, err = h.companyService.GetEmployerUser(ctx, userID, companyID)
+	version, err := utils.RequestVersion(c, req.Version)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusForbidden, common.ErrForbidden, "You are not an employer of this company")
+		return utils.BadRequestResponse(c, err.Error())
 	}
 
-	domainReq := &company.UpdateEmployerUserRequest{}
+	domainReq := &company.UpdateEmployerUserRequest{Version: version}
 	if req.PositionTitle != nil {
 		domainReq.PositionTitle = req.PositionTitle
 	}
@@ -178,7 +176,7 @@ func (h *CompanyEmployerHandler) UpdateMyEmployerProfile(c *fiber.Ctx) error {
 	}
 
 	if err := h.companyService.UpdateEmployerUserWithProfile(ctx, userID, companyID, userReq, domainReq); err != nil {
-		return utils.InternalServerErrorResponse(c, common.ErrFailedOperation)
+		return err
 	}
 
 	updated, err := h.companyService.GetEmployerUser(ctx, userID, companyID)
@@ -219,6 +217,7 @@ func (h *CompanyEmployerHandler) UpdateMyEmployerProfile(c *fiber.Ctx) error {
 		PositionTitle  *string `json:"position_title,omitempty"`
 		FullName       string  `json:"full_name,omitempty"`
 		User           any     `json:"user,omitempty"`
+		Version        int64   `json:"version"`
 	}
 
 	fullName := ""
@@ -232,9 +231,48 @@ func (h *CompanyEmployerHandler) UpdateMyEmployerProfile(c *fiber.Ctx) error {
 		CompanyID:      updated.CompanyID,
 		Role:           updated.Role,
 		PositionTitle:  updated.PositionTitle,
+		Version:        updated.Version,
 		FullName:       fullName,
 		User:           userObj,
 	}
 
 	return utils.SuccessResponse(c, common.MsgUpdatedSuccess, resp)
 }
+
+// ListMyCompanies lists the companies the authenticated user is an employer of, with their
+// role in each and which one is active. The X-Company-ID header takes precedence over the
+// stored choice.
+func (h *CompanyEmployerHandler) ListMyCompanies(c *fiber.Ctx) error {
+	activeCompanyID, err := middleware.ActiveCompanyID(c)
+	if err != nil {
+		return err
+	}
+
+	companies, err := h.companyService.GetEmployerCompanies(c.UserContext(), middleware.GetUserID(c), activeCompanyID)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, companies)
+}
+
+// ActivateCompany stores the company as the one the user's employer requests act for when
+// they do not send X-Company-ID
+func (h *CompanyEmployerHandler) ActivateCompany(c *fiber.Ctx) error {
+	companyID, err := c.ParamsInt("id")
+	if err != nil || companyID <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	ec, err := h.companyService.ActivateCompany(c.UserContext(), middleware.GetUserID(c), int64(companyID))
+	if err != nil {
+		return err
+	}
+
+	c.Set(middleware.CompanyIDHeader, strconv.FormatInt(ec.CompanyID, 10))
+	return utils.SuccessResponse(c, "Active company updated", fiber.Map{
+		"company_id":       ec.CompanyID,
+		"employer_user_id": ec.EmployerUserID,
+		"role":             ec.Role,
+	})
+}
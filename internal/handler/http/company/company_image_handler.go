@@ -1,6 +1,7 @@
 package companyhandler
 
 import (
+	"keerja-backend/internal/apperror"
 	"keerja-backend/internal/domain/company"
 	"keerja-backend/internal/handler/http/common"
 	"keerja-backend/internal/middleware"
@@ -22,7 +23,7 @@ func NewCompanyImageHandler(companyService company.CompanyService) *CompanyImage
 }
 
 func (h *CompanyImageHandler) UploadLogo(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	companyID, err := utils.ParseIDParam(c, "id")
 	if err != nil || companyID <= 0 {
@@ -36,13 +37,16 @@ func (h *CompanyImageHandler) UploadLogo(c *fiber.Ctx) error {
 
 	url, err := h.companyService.UploadLogo(ctx, companyID, file)
 	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return err
+		}
 		return utils.InternalServerErrorResponse(c, common.ErrFileUploadFailed)
 	}
 	return utils.CreatedResponse(c, common.MsgUploadSuccess, fiber.Map{"logo_url": url})
 }
 
 func (h *CompanyImageHandler) UploadBanner(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	companyID, err := utils.ParseIDParam(c, "id")
 	if err != nil || companyID <= 0 {
@@ -56,13 +60,16 @@ func (h *CompanyImageHandler) UploadBanner(c *fiber.Ctx) error {
 
 	url, err := h.companyService.UploadBanner(ctx, companyID, file)
 	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return err
+		}
 		return utils.InternalServerErrorResponse(c, common.ErrFileUploadFailed)
 	}
 	return utils.CreatedResponse(c, common.MsgUploadSuccess, fiber.Map{"banner_url": url})
 }
 
 func (h *CompanyImageHandler) DeleteLogo(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	companyID, err := utils.ParseIDParam(c, "id")
 	if err != nil || companyID <= 0 {
@@ -76,7 +83,7 @@ func (h *CompanyImageHandler) DeleteLogo(c *fiber.Ctx) error {
 }
 
 func (h *CompanyImageHandler) DeleteBanner(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	companyID, err := utils.ParseIDParam(c, "id")
 	if err != nil || companyID <= 0 {
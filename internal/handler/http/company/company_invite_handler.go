@@ -1,11 +1,7 @@
 package companyhandler
 
 import (
-	"fmt"
-
 	"keerja-backend/internal/domain/company"
-	"keerja-backend/internal/domain/email"
-	"keerja-backend/internal/domain/user"
 	"keerja-backend/internal/dto/request"
 	"keerja-backend/internal/handler/http/common"
 	"keerja-backend/internal/middleware"
@@ -17,21 +13,19 @@ import (
 // CompanyInviteHandler handles company employee invitation operations
 type CompanyInviteHandler struct {
 	companyService company.CompanyService
-	emailService   email.EmailService
-	userService    user.UserService
+	domainService  company.DomainService
 }
 
 // NewCompanyInviteHandler creates a new instance of CompanyInviteHandler
-func NewCompanyInviteHandler(companyService company.CompanyService, emailService email.EmailService, userService user.UserService) *CompanyInviteHandler {
+func NewCompanyInviteHandler(companyService company.CompanyService, domainService company.DomainService) *CompanyInviteHandler {
 	return &CompanyInviteHandler{
 		companyService: companyService,
-		emailService:   emailService,
-		userService:    userService,
+		domainService:  domainService,
 	}
 }
 
 func (h *CompanyInviteHandler) InviteEmployee(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	// Get company ID from path parameter
 	companyID, err := c.ParamsInt("id")
@@ -53,7 +47,7 @@ func (h *CompanyInviteHandler) InviteEmployee(c *fiber.Ctx) error {
 
 	// Validate request
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errors)
 	}
 
@@ -66,7 +60,7 @@ func (h *CompanyInviteHandler) InviteEmployee(c *fiber.Ctx) error {
 	// Get company to verify ownership and existence
 	comp, err := h.companyService.GetCompany(ctx, int64(companyID))
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusNotFound, common.ErrCompanyNotFound, err.Error())
+		return err
 	}
 
 	// Check if user is authorized to invite employees (owner or admin)
@@ -81,73 +75,39 @@ func (h *CompanyInviteHandler) InviteEmployee(c *fiber.Ctx) error {
 	// Create invitation request
 	inviteReq := &company.InviteEmployerRequest{
 		CompanyID:     int64(companyID),
+		InvitedBy:     userID,
 		Email:         req.Email,
+		FullName:      req.FullName,
 		Role:          req.Role,
 		PositionTitle: &req.Position,
 	}
 
-	// Save invitation to database
-	if err := h.companyService.InviteEmployer(ctx, inviteReq); err != nil {
+	// Save invitation and send the invitation email
+	result, err := h.companyService.InviteEmployer(ctx, inviteReq)
+	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, common.ErrFailedOperation, err.Error())
 	}
 
-	// Get invitation to get token for email
-	invitations, err := h.companyService.GetPendingInvitations(ctx, int64(companyID))
-	if err != nil || len(invitations) == 0 {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, common.ErrFailedOperation, "")
-	}
-
-	// Find the latest invitation for this email
-	var invitation *company.CompanyInvitation
-	for i := range invitations {
-		if invitations[i].Email == req.Email && invitations[i].Status == "pending" {
-			invitation = &invitations[i]
-			break
-		}
-	}
-
-	if invitation == nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, common.ErrFailedOperation, "")
+	message := "Invitation sent successfully"
+	if !result.EmailSent {
+		message = "Invitation created but the email could not be sent. Please resend the invitation."
 	}
 
-	// Generate invitation URL
-	inviteURL := fmt.Sprintf("%s/accept-invite?token=%s", c.BaseURL(), invitation.Token)
-
-	// Get inviter name from user service
-	inviterName := "Administrator" // Default fallback
-	inviterUser, err := h.userService.GetProfile(ctx, userID)
-	if err == nil && inviterUser != nil {
-		inviterName = inviterUser.FullName
-	}
-
-	// Send invitation email using template
-	if err := h.emailService.SendCompanyInvitationEmail(
-		ctx,
-		req.Email,
-		req.FullName,
-		comp.CompanyName,
-		inviterName,
-		req.Position,
-		req.Role,
-		inviteURL,
-		7, // 7 days expiry
-	); err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, common.ErrFailedOperation, err.Error())
-	}
-
-	return utils.SuccessResponse(c, "Invitation sent successfully", fiber.Map{
+	return utils.SuccessResponse(c, message, fiber.Map{
 		"email":         req.Email,
 		"name":          req.FullName,
 		"position":      req.Position,
 		"role":          req.Role,
 		"company":       comp.CompanyName,
-		"expires_at":    invitation.ExpiresAt,
-		"invitation_id": invitation.ID,
+		"expires_at":    result.Invitation.ExpiresAt,
+		"invitation_id": result.Invitation.ID,
+		"email_sent":    result.EmailSent,
+		"email_error":   result.EmailError,
 	})
 }
 
 func (h *CompanyInviteHandler) AcceptInvitation(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	// Get token from query parameter
 	token := c.Query("token")
@@ -163,7 +123,7 @@ func (h *CompanyInviteHandler) AcceptInvitation(c *fiber.Ctx) error {
 
 	// Accept invitation
 	if err := h.companyService.AcceptInvitation(ctx, token, userID); err != nil {
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, common.ErrFailedOperation, err.Error())
+		return err
 	}
 
 	return utils.SuccessResponse(c, "Invitation accepted successfully", fiber.Map{
@@ -171,8 +131,19 @@ func (h *CompanyInviteHandler) AcceptInvitation(c *fiber.Ctx) error {
 	})
 }
 
+// GetInvitation returns the company, role, inviter and expiry of an invitation by its token,
+// so invitees without an account can see what they are signing up for
+func (h *CompanyInviteHandler) GetInvitation(c *fiber.Ctx) error {
+	details, err := h.companyService.GetInvitationDetails(c.UserContext(), c.Params("token"))
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, details)
+}
+
 func (h *CompanyInviteHandler) ResendInvitation(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	// Get company ID and invitation ID from path parameters
 	companyID, err := c.ParamsInt("id")
@@ -198,15 +169,26 @@ func (h *CompanyInviteHandler) ResendInvitation(c *fiber.Ctx) error {
 	}
 
 	// Resend invitation
-	if err := h.companyService.ResendInvitation(ctx, int64(invitationID), userID); err != nil {
+	result, err := h.companyService.ResendInvitation(ctx, int64(invitationID), userID)
+	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, common.ErrFailedOperation, err.Error())
 	}
 
-	return utils.SuccessResponse(c, "Invitation resent successfully", nil)
+	message := "Invitation resent successfully"
+	if !result.EmailSent {
+		message = "Invitation renewed but the email could not be sent. Please try again."
+	}
+
+	return utils.SuccessResponse(c, message, fiber.Map{
+		"invitation_id": result.Invitation.ID,
+		"expires_at":    result.Invitation.ExpiresAt,
+		"email_sent":    result.EmailSent,
+		"email_error":   result.EmailError,
+	})
 }
 
 func (h *CompanyInviteHandler) CancelInvitation(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	// Get invitation ID from path parameter (company ID validation done by permission check)
 	invitationID, err := c.ParamsInt("invitationId")
@@ -229,7 +211,7 @@ func (h *CompanyInviteHandler) CancelInvitation(c *fiber.Ctx) error {
 }
 
 func (h *CompanyInviteHandler) GetPendingInvitations(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	// Get company ID from path parameter
 	companyID, err := c.ParamsInt("id")
@@ -255,8 +237,15 @@ func (h *CompanyInviteHandler) GetPendingInvitations(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, common.ErrFailedOperation, err.Error())
 	}
 
+	// Colleagues who asked to join through the company's email domain wait for approval here too
+	joinRequests, err := h.domainService.ListJoinRequests(ctx, int64(companyID))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, common.ErrFailedOperation, err.Error())
+	}
+
 	return utils.SuccessResponse(c, "Invitations retrieved successfully", fiber.Map{
-		"invitations": invitations,
-		"total":       len(invitations),
+		"invitations":   invitations,
+		"total":         len(invitations),
+		"join_requests": joinRequests,
 	})
 }
@@ -27,7 +27,7 @@ func NewCompanyProfileHandler(companyService company.CompanyService) *CompanyPro
 }
 
 func (h *CompanyProfileHandler) GetProfile(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	companyID, err := strconv.Atoi(c.Params("id"))
 	if err != nil || companyID <= 0 {
@@ -43,7 +43,7 @@ func (h *CompanyProfileHandler) GetProfile(c *fiber.Ctx) error {
 }
 
 func (h *CompanyProfileHandler) UpdateProfile(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	companyID, err := strconv.Atoi(c.Params("id"))
 	if err != nil || companyID <= 0 {
@@ -55,7 +55,7 @@ func (h *CompanyProfileHandler) UpdateProfile(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
 	}
 	if err := utils.ValidateStruct(&req); err != nil {
-		errs := utils.FormatValidationErrors(err)
+		errs := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
 	}
 
@@ -149,14 +149,20 @@ func (h *CompanyProfileHandler) UpdateProfile(c *fiber.Ctx) error {
 		SocialLinks:     social,
 	}
 
+	version, err := utils.RequestVersion(c, req.Version)
+	if err != nil {
+		return utils.BadRequestResponse(c, err.Error())
+	}
+	domainReq.Version = version
+
 	if err := h.companyService.UpdateProfile(ctx, int64(companyID), domainReq); err != nil {
-		return utils.InternalServerErrorResponse(c, common.ErrFailedOperation)
+		return err
 	}
 	return utils.SuccessResponse(c, common.MsgUpdatedSuccess, nil)
 }
 
 func (h *CompanyProfileHandler) PublishProfile(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	companyID, err := strconv.Atoi(c.Params("id"))
 	if err != nil || companyID <= 0 {
@@ -169,7 +175,7 @@ func (h *CompanyProfileHandler) PublishProfile(c *fiber.Ctx) error {
 }
 
 func (h *CompanyProfileHandler) UnpublishProfile(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	companyID, err := strconv.Atoi(c.Params("id"))
 	if err != nil || companyID <= 0 {
@@ -182,7 +188,7 @@ func (h *CompanyProfileHandler) UnpublishProfile(c *fiber.Ctx) error {
 }
 
 func (h *CompanyProfileHandler) FollowCompany(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	companyID, err := strconv.Atoi(c.Params("id"))
@@ -191,13 +197,13 @@ func (h *CompanyProfileHandler) FollowCompany(c *fiber.Ctx) error {
 	}
 
 	if err := h.companyService.FollowCompany(ctx, int64(companyID), userID); err != nil {
-		return utils.InternalServerErrorResponse(c, common.ErrFailedOperation)
+		return err
 	}
 	return utils.SuccessResponse(c, common.MsgOperationSuccess, fiber.Map{"followed": true})
 }
 
 func (h *CompanyProfileHandler) UnfollowCompany(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	companyID, err := strconv.Atoi(c.Params("id"))
@@ -212,15 +218,16 @@ func (h *CompanyProfileHandler) UnfollowCompany(c *fiber.Ctx) error {
 }
 
 func (h *CompanyProfileHandler) GetFollowers(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	companyID, err := strconv.Atoi(c.Params("id"))
 	if err != nil || companyID <= 0 {
 		return utils.BadRequestResponse(c, common.ErrInvalidID)
 	}
-	page := c.QueryInt("page", 1)
-	limit := c.QueryInt("limit", 10)
-	page, limit = utils.ValidatePagination(page, limit, 100)
+	page, limit, err := utils.ParsePagination(c, 10, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
 
 	followers, total, err := h.companyService.GetFollowers(ctx, int64(companyID), page, limit)
 	if err != nil {
@@ -235,11 +242,12 @@ func (h *CompanyProfileHandler) GetFollowers(c *fiber.Ctx) error {
 }
 
 func (h *CompanyProfileHandler) GetFollowedCompanies(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
-	page := c.QueryInt("page", 1)
-	limit := c.QueryInt("limit", 10)
-	page, limit = utils.ValidatePagination(page, limit, 100)
+	page, limit, err := utils.ParsePagination(c, 10, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
 
 	companies, total, err := h.companyService.GetFollowedCompanies(ctx, userID, page, limit)
 	if err != nil {
@@ -26,7 +26,7 @@ func NewCompanyReviewHandler(companyService company.CompanyService) *CompanyRevi
 }
 
 func (h *CompanyReviewHandler) AddReview(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	companyID, err := strconv.Atoi(c.Params("id"))
@@ -39,7 +39,7 @@ func (h *CompanyReviewHandler) AddReview(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
 	}
 	if err := utils.ValidateStruct(&req); err != nil {
-		errs := utils.FormatValidationErrors(err)
+		errs := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
 	}
 
@@ -90,14 +90,14 @@ func (h *CompanyReviewHandler) AddReview(c *fiber.Ctx) error {
 
 	rev, err := h.companyService.AddReview(ctx, domainReq)
 	if err != nil {
-		return utils.InternalServerErrorResponse(c, common.ErrFailedOperation)
+		return err
 	}
 	resp := mapper.ToCompanyReviewResponse(rev)
 	return utils.CreatedResponse(c, common.MsgCreatedSuccess, resp)
 }
 
 func (h *CompanyReviewHandler) UpdateReview(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	reviewID, err := strconv.Atoi(c.Params("id"))
@@ -110,7 +110,7 @@ func (h *CompanyReviewHandler) UpdateReview(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
 	}
 	if err := utils.ValidateStruct(&req); err != nil {
-		errs := utils.FormatValidationErrors(err)
+		errs := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
 	}
 
@@ -157,13 +157,13 @@ func (h *CompanyReviewHandler) UpdateReview(c *fiber.Ctx) error {
 	}
 
 	if err := h.companyService.UpdateReview(ctx, int64(reviewID), userID, domainReq); err != nil {
-		return utils.InternalServerErrorResponse(c, common.ErrFailedOperation)
+		return err
 	}
 	return utils.SuccessResponse(c, common.MsgUpdatedSuccess, nil)
 }
 
 func (h *CompanyReviewHandler) DeleteReview(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	reviewID, err := strconv.Atoi(c.Params("id"))
@@ -172,21 +172,22 @@ func (h *CompanyReviewHandler) DeleteReview(c *fiber.Ctx) error {
 	}
 
 	if err := h.companyService.DeleteReview(ctx, int64(reviewID), userID); err != nil {
-		return utils.InternalServerErrorResponse(c, common.ErrFailedOperation)
+		return err
 	}
 	return utils.SuccessResponse(c, common.MsgDeletedSuccess, nil)
 }
 
 func (h *CompanyReviewHandler) GetCompanyReviews(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	companyID, err := strconv.Atoi(c.Params("id"))
 	if err != nil || companyID <= 0 {
 		return utils.BadRequestResponse(c, common.ErrInvalidID)
 	}
-	page := c.QueryInt("page", 1)
-	limit := c.QueryInt("limit", 10)
-	page, limit = utils.ValidatePagination(page, limit, 100)
+	page, limit, err := utils.ParsePagination(c, 10, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
 
 	// Optional filters via query - sanitize filter values
 	var filt company.ReviewFilter
@@ -213,6 +214,12 @@ func (h *CompanyReviewHandler) GetCompanyReviews(c *fiber.Ctx) error {
 	filt.SortBy = utils.SanitizeString(c.Query("sort_by"))
 	filt.SortOrder = utils.SanitizeString(c.Query("sort_order"))
 
+	// Reviews hidden by moderation or abuse reports never appear in public listings
+	filt.ExcludeStatuses = []string{company.ReviewStatusHidden}
+	if userID := middleware.GetUserID(c); userID > 0 {
+		filt.ViewerUserID = &userID
+	}
+
 	reviews, total, err := h.companyService.GetCompanyReviews(ctx, int64(companyID), &filt)
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, common.ErrFailedOperation)
@@ -225,8 +232,139 @@ func (h *CompanyReviewHandler) GetCompanyReviews(c *fiber.Ctx) error {
 	return utils.SuccessResponseWithMeta(c, common.MsgFetchedSuccess, fiber.Map{"reviews": resp}, meta)
 }
 
+func (h *CompanyReviewHandler) VoteReview(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := middleware.GetUserID(c)
+
+	reviewID, err := strconv.Atoi(c.Params("id"))
+	if err != nil || reviewID <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	var req request.VoteReviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	rev, err := h.companyService.VoteReview(ctx, int64(reviewID), userID, req.Vote)
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, common.MsgUpdatedSuccess, mapper.ToCompanyReviewResponse(rev))
+}
+
+func (h *CompanyReviewHandler) UnvoteReview(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := middleware.GetUserID(c)
+
+	reviewID, err := strconv.Atoi(c.Params("id"))
+	if err != nil || reviewID <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	rev, err := h.companyService.UnvoteReview(ctx, int64(reviewID), userID)
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, common.MsgDeletedSuccess, mapper.ToCompanyReviewResponse(rev))
+}
+
+func (h *CompanyReviewHandler) ReportReview(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := middleware.GetUserID(c)
+
+	reviewID, err := strconv.Atoi(c.Params("id"))
+	if err != nil || reviewID <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	var req request.ReportReviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	req.Reason = utils.SanitizeString(req.Reason)
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	if err := h.companyService.ReportReview(ctx, int64(reviewID), userID, req.Reason); err != nil {
+		return err
+	}
+	return utils.CreatedResponse(c, common.MsgCreatedSuccess, nil)
+}
+
+func (h *CompanyReviewHandler) AddReviewResponse(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := middleware.GetUserID(c)
+
+	reviewID, err := strconv.Atoi(c.Params("id"))
+	if err != nil || reviewID <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	var req request.ReviewResponseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	resp, err := h.companyService.AddReviewResponse(ctx, int64(reviewID), userID, req.Body)
+	if err != nil {
+		return err
+	}
+	return utils.CreatedResponse(c, common.MsgCreatedSuccess, mapper.ToReviewReplyResponse(resp))
+}
+
+func (h *CompanyReviewHandler) UpdateReviewResponse(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := middleware.GetUserID(c)
+
+	reviewID, err := strconv.Atoi(c.Params("id"))
+	if err != nil || reviewID <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	var req request.ReviewResponseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	resp, err := h.companyService.UpdateReviewResponse(ctx, int64(reviewID), userID, req.Body)
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, common.MsgUpdatedSuccess, mapper.ToReviewReplyResponse(resp))
+}
+
+func (h *CompanyReviewHandler) DeleteReviewResponse(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := middleware.GetUserID(c)
+
+	reviewID, err := strconv.Atoi(c.Params("id"))
+	if err != nil || reviewID <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	if err := h.companyService.DeleteReviewResponse(ctx, int64(reviewID), userID); err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, common.MsgDeletedSuccess, nil)
+}
+
 func (h *CompanyReviewHandler) GetAverageRatings(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	companyID, err := strconv.Atoi(c.Params("id"))
 	if err != nil || companyID <= 0 {
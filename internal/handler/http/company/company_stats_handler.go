@@ -24,10 +24,11 @@ func NewCompanyStatsHandler(companyService company.CompanyService) *CompanyStats
 }
 
 func (h *CompanyStatsHandler) GetVerifiedCompanies(c *fiber.Ctx) error {
-	ctx := c.Context()
-	page := c.QueryInt("page", 1)
-	limit := c.QueryInt("limit", 10)
-	page, limit = utils.ValidatePagination(page, limit, 100)
+	ctx := c.UserContext()
+	page, limit, err := utils.ParsePagination(c, 10, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
 
 	companies, total, err := h.companyService.GetVerifiedCompanies(ctx, page, limit)
 	if err != nil {
@@ -43,10 +44,10 @@ func (h *CompanyStatsHandler) GetVerifiedCompanies(c *fiber.Ctx) error {
 }
 
 func (h *CompanyStatsHandler) GetTopRatedCompanies(c *fiber.Ctx) error {
-	ctx := c.Context()
-	limit := c.QueryInt("limit", 10)
-	if limit <= 0 {
-		limit = 10
+	ctx := c.UserContext()
+	_, limit, err := utils.ParsePagination(c, 10, utils.MaxPageLimit)
+	if err != nil {
+		return err
 	}
 
 	companies, err := h.companyService.GetTopRatedCompanies(ctx, limit)
@@ -62,7 +63,7 @@ func (h *CompanyStatsHandler) GetTopRatedCompanies(c *fiber.Ctx) error {
 }
 
 func (h *CompanyStatsHandler) GetCompanyStats(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	companyID, err := strconv.Atoi(c.Params("id"))
 	if err != nil || companyID <= 0 {
 		return utils.BadRequestResponse(c, common.ErrInvalidID)
@@ -0,0 +1,230 @@
+package companyhandler
+
+import (
+	"strings"
+
+	"keerja-backend/internal/domain/talentpool"
+	"keerja-backend/internal/dto/mapper"
+	"keerja-backend/internal/dto/request"
+	"keerja-backend/internal/dto/response"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CompanyTalentPoolHandler handles the company's talent pools of candidates for future roles
+type CompanyTalentPoolHandler struct {
+	talentPoolService talentpool.TalentPoolService
+}
+
+// NewCompanyTalentPoolHandler creates a new instance of CompanyTalentPoolHandler
+func NewCompanyTalentPoolHandler(talentPoolService talentpool.TalentPoolService) *CompanyTalentPoolHandler {
+	return &CompanyTalentPoolHandler{talentPoolService: talentPoolService}
+}
+
+// ListPools lists the company's talent pools
+func (h *CompanyTalentPoolHandler) ListPools(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+
+	pools, err := h.talentPoolService.ListPools(c.UserContext(), companyID)
+	if err != nil {
+		return err
+	}
+
+	resp := mapper.MapEntities[talentpool.TalentPool, response.TalentPoolResponse](pools, mapper.ToTalentPoolResponse)
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, resp)
+}
+
+// CreatePool creates a talent pool for the company
+func (h *CompanyTalentPoolHandler) CreatePool(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+
+	var req request.CreateTalentPoolRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	p, err := h.talentPoolService.CreatePool(c.UserContext(), companyID, middleware.GetUserID(c), talentpool.CreatePoolInput{
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		return err
+	}
+
+	return utils.CreatedResponse(c, "Talent pool created successfully", mapper.ToTalentPoolResponse(p))
+}
+
+// DeletePool deletes one of the company's talent pools with its members
+func (h *CompanyTalentPoolHandler) DeletePool(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+	poolID, err := utils.ParseIDParam(c, "poolId")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	if err := h.talentPoolService.DeletePool(c.UserContext(), companyID, poolID); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, "Talent pool deleted successfully", nil)
+}
+
+// ListMembers lists the members of one of the company's talent pools
+func (h *CompanyTalentPoolHandler) ListMembers(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+	poolID, err := utils.ParseIDParam(c, "poolId")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	page, limit, err := utils.ParsePagination(c, 20, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
+
+	var req request.ListTalentPoolMembersRequest
+	if err := c.QueryParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidQueryParams)
+	}
+	req.Page, req.Limit = page, limit
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	filter := talentpool.MemberFilter{
+		CompanyID: companyID,
+		PoolID:    poolID,
+		Tags:      splitList(req.Tags),
+		Skills:    splitList(req.Skills),
+		Query:     req.Query,
+	}
+	members, total, err := h.talentPoolService.ListMembers(c.UserContext(), filter, req.Page, req.Limit)
+	if err != nil {
+		return err
+	}
+
+	respMembers := make([]response.TalentPoolMemberResponse, 0, len(members))
+	for i := range members {
+		respMembers = append(respMembers, mapper.ToTalentPoolMemberResponse(&members[i]))
+	}
+
+	meta := utils.GetPaginationMeta(req.Page, req.Limit, total)
+	return utils.SuccessResponseWithMeta(c, common.MsgFetchedSuccess, response.TalentPoolMemberListResponse{Members: respMembers}, meta)
+}
+
+// AddMember saves the applicant of one of the company's applications to a talent pool.
+// Saving a candidate already in the pool returns the existing member.
+func (h *CompanyTalentPoolHandler) AddMember(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+	poolID, err := utils.ParseIDParam(c, "poolId")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	var req request.AddTalentPoolMemberRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	m, added, err := h.talentPoolService.AddMember(c.UserContext(), companyID, poolID, middleware.GetUserID(c), talentpool.AddMemberInput{
+		ApplicationID: req.ApplicationID,
+		Tags:          req.Tags,
+		Notes:         req.Notes,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !added {
+		return utils.SuccessResponse(c, "Candidate is already in the talent pool", mapper.ToTalentPoolMemberResponse(m))
+	}
+	return utils.CreatedResponse(c, "Candidate added to the talent pool", mapper.ToTalentPoolMemberResponse(m))
+}
+
+// RemoveMember removes a member from one of the company's talent pools
+func (h *CompanyTalentPoolHandler) RemoveMember(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+	poolID, err := utils.ParseIDParam(c, "poolId")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+	memberID, err := utils.ParseIDParam(c, "memberId")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	if err := h.talentPoolService.RemoveMember(c.UserContext(), companyID, poolID, memberID); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, "Candidate removed from the talent pool", nil)
+}
+
+// MatchJob returns the talent pool candidates who best match one of the company's jobs
+func (h *CompanyTalentPoolHandler) MatchJob(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+
+	var req request.MatchTalentPoolRequest
+	if err := c.QueryParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidQueryParams)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	matches, err := h.talentPoolService.MatchJob(c.UserContext(), companyID, req.JobID, req.PoolID, req.Limit)
+	if err != nil {
+		return err
+	}
+
+	resp := make([]response.TalentPoolMatchResponse, 0, len(matches))
+	for i := range matches {
+		resp = append(resp, mapper.ToTalentPoolMatchResponse(&matches[i]))
+	}
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, resp)
+}
+
+// splitList splits a comma-separated query parameter, dropping blank entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
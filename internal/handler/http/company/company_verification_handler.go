@@ -26,7 +26,7 @@ func NewCompanyVerificationHandler(companyService company.CompanyService) *Compa
 }
 
 func (h *CompanyVerificationHandler) GetCompanyVerificationStatus(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	companyID, err := utils.ParseIDParam(c, "id")
 	if err != nil || companyID <= 0 {
@@ -35,7 +35,7 @@ func (h *CompanyVerificationHandler) GetCompanyVerificationStatus(c *fiber.Ctx)
 
 	comp, err := h.companyService.GetCompany(ctx, companyID)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusNotFound, common.ErrCompanyNotFound, err.Error())
+		return err
 	}
 
 	verification, err := h.companyService.GetVerificationStatus(ctx, companyID)
@@ -55,6 +55,8 @@ func (h *CompanyVerificationHandler) GetCompanyVerificationStatus(c *fiber.Ctx)
 		resp["nib_number"] = verification.NIBNumber
 		resp["reviewed_at"] = verification.ReviewedAt
 		resp["verification_expiry"] = verification.VerificationExpiry
+		resp["grace_until"] = verification.GraceUntil
+		resp["in_grace_period"] = verification.InGracePeriod()
 		resp["badge_granted"] = verification.BadgeGranted
 		resp["rejection_reason"] = verification.RejectionReason
 	} else {
@@ -65,7 +67,7 @@ func (h *CompanyVerificationHandler) GetCompanyVerificationStatus(c *fiber.Ctx)
 }
 
 func (h *CompanyVerificationHandler) GetMyCompanyVerificationStatus(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	userID := middleware.GetUserID(c)
 	if userID == 0 {
@@ -100,6 +102,8 @@ func (h *CompanyVerificationHandler) GetMyCompanyVerificationStatus(c *fiber.Ctx
 		resp["nib_number"] = verification.NIBNumber
 		resp["reviewed_at"] = verification.ReviewedAt
 		resp["verification_expiry"] = verification.VerificationExpiry
+		resp["grace_until"] = verification.GraceUntil
+		resp["in_grace_period"] = verification.InGracePeriod()
 		resp["badge_granted"] = verification.BadgeGranted
 		resp["rejection_reason"] = verification.RejectionReason
 	} else {
@@ -110,7 +114,7 @@ func (h *CompanyVerificationHandler) GetMyCompanyVerificationStatus(c *fiber.Ctx
 }
 
 func (h *CompanyVerificationHandler) RequestVerification(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	companyID, err := utils.ParseIDParam(c, "id")
 	if err != nil {
@@ -124,7 +128,7 @@ func (h *CompanyVerificationHandler) RequestVerification(c *fiber.Ctx) error {
 
 	comp, err := h.companyService.GetCompany(ctx, companyID)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusNotFound, common.ErrCompanyNotFound, err.Error())
+		return err
 	}
 
 	if comp.Verified {
@@ -133,7 +137,7 @@ func (h *CompanyVerificationHandler) RequestVerification(c *fiber.Ctx) error {
 
 	verificationStatus, err := h.companyService.GetVerificationStatus(ctx, companyID)
 	if err == nil && verificationStatus != nil {
-		if verificationStatus.Status == "pending" || verificationStatus.Status == "under_review" {
+		if verificationStatus.Status == company.VerificationStatusPending || verificationStatus.Status == company.VerificationStatusUnderReview {
 			return utils.ErrorResponse(c, fiber.StatusConflict, "Verification request already submitted",
 				"A verification request is already pending for this company")
 		}
@@ -176,6 +180,15 @@ func (h *CompanyVerificationHandler) RequestVerification(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "NPWP file too large", "Maximum file size is 10MB")
 	}
 
+	// NIB document is optional
+	nibFile, err := c.FormFile("nib_file")
+	if err != nil {
+		nibFile = nil
+	}
+	if nibFile != nil && nibFile.Size > 10*1024*1024 {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "NIB file too large", "Maximum file size is 10MB")
+	}
+
 	form, err := c.MultipartForm()
 	var additionalFiles []*multipart.FileHeader
 	if err == nil && form != nil {
@@ -202,6 +215,7 @@ func (h *CompanyVerificationHandler) RequestVerification(c *fiber.Ctx) error {
 		*req.NPWPNumber,
 		req.NIBNumber,
 		npwpFile,
+		nibFile,
 		additionalFiles,
 	); err != nil {
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, common.ErrFailedOperation, err.Error())
@@ -209,7 +223,7 @@ func (h *CompanyVerificationHandler) RequestVerification(c *fiber.Ctx) error {
 
 	return utils.SuccessResponse(c, "Verification request submitted successfully", fiber.Map{
 		"company_id": companyID,
-		"status":     "pending",
+		"status":     company.VerificationStatusPending,
 		"message":    "Your verification request has been submitted and will be reviewed by our admin team",
 	})
 }
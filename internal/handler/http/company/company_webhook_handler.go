@@ -0,0 +1,187 @@
+package companyhandler
+
+import (
+	"keerja-backend/internal/domain/webhook"
+	"keerja-backend/internal/dto/mapper"
+	"keerja-backend/internal/dto/request"
+	"keerja-backend/internal/dto/response"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CompanyWebhookHandler handles management of company webhooks and their deliveries
+type CompanyWebhookHandler struct {
+	webhookService webhook.WebhookService
+}
+
+// NewCompanyWebhookHandler creates a new instance of CompanyWebhookHandler
+func NewCompanyWebhookHandler(webhookService webhook.WebhookService) *CompanyWebhookHandler {
+	return &CompanyWebhookHandler{webhookService: webhookService}
+}
+
+// CreateWebhook registers a webhook for the company. The signing secret is only returned here.
+func (h *CompanyWebhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+
+	var req request.CreateCompanyWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	w, err := h.webhookService.CreateWebhook(middleware.AuditContext(c), companyID, middleware.GetUserID(c), req.URL, req.Events)
+	if err != nil {
+		return err
+	}
+
+	resp := response.CreatedCompanyWebhookResponse{
+		CompanyWebhookResponse: *mapper.ToCompanyWebhookResponse(w),
+		Secret:                 w.Secret,
+	}
+	return utils.CreatedResponse(c, "Webhook created. Store the secret now, it will not be shown again.", resp)
+}
+
+// ListWebhooks lists the company's webhooks
+func (h *CompanyWebhookHandler) ListWebhooks(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+
+	webhooks, err := h.webhookService.ListWebhooks(c.UserContext(), companyID)
+	if err != nil {
+		return err
+	}
+
+	resp := mapper.MapEntities[webhook.CompanyWebhook, response.CompanyWebhookResponse](webhooks, mapper.ToCompanyWebhookResponse)
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, resp)
+}
+
+// UpdateWebhook changes the URL, events or active flag of one of the company's webhooks
+func (h *CompanyWebhookHandler) UpdateWebhook(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+	webhookID, err := utils.ParseIDParam(c, "webhookId")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	var req request.UpdateCompanyWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	w, err := h.webhookService.UpdateWebhook(middleware.AuditContext(c), companyID, webhookID, webhook.UpdateWebhookInput{
+		URL:    req.URL,
+		Events: req.Events,
+		Active: req.Active,
+	})
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, "Webhook updated successfully", mapper.ToCompanyWebhookResponse(w))
+}
+
+// DeleteWebhook deletes one of the company's webhooks
+func (h *CompanyWebhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+	webhookID, err := utils.ParseIDParam(c, "webhookId")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	if err := h.webhookService.DeleteWebhook(middleware.AuditContext(c), companyID, webhookID); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, "Webhook deleted successfully", nil)
+}
+
+// ListDeliveries lists the deliveries of one of the company's webhooks, newest first
+func (h *CompanyWebhookHandler) ListDeliveries(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+	webhookID, err := utils.ParseIDParam(c, "webhookId")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	page, limit, err := utils.ParsePagination(c, 10, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
+
+	var req request.ListWebhookDeliveriesRequest
+	if err := c.QueryParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidQueryParams)
+	}
+	req.Page, req.Limit = page, limit
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	filter := webhook.DeliveryFilter{
+		CompanyID: companyID,
+		WebhookID: webhookID,
+		Status:    req.Status,
+		Event:     req.Event,
+	}
+	deliveries, total, err := h.webhookService.ListDeliveries(c.UserContext(), filter, req.Page, req.Limit)
+	if err != nil {
+		return err
+	}
+
+	respDeliveries := make([]response.WebhookDeliveryResponse, 0, len(deliveries))
+	for i := range deliveries {
+		respDeliveries = append(respDeliveries, mapper.ToWebhookDeliveryResponse(&deliveries[i]))
+	}
+
+	meta := utils.GetPaginationMeta(req.Page, req.Limit, total)
+	return utils.SuccessResponseWithMeta(c, common.MsgFetchedSuccess, response.WebhookDeliveryListResponse{Deliveries: respDeliveries}, meta)
+}
+
+// Redeliver sends the event of one of the webhook's deliveries again
+func (h *CompanyWebhookHandler) Redeliver(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidCompanyID)
+	}
+	webhookID, err := utils.ParseIDParam(c, "webhookId")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+	deliveryID, err := utils.ParseIDParam(c, "deliveryId")
+	if err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	scope := webhook.DeliveryFilter{CompanyID: companyID, WebhookID: webhookID}
+	d, err := h.webhookService.Redeliver(c.UserContext(), deliveryID, scope)
+	if err != nil {
+		return err
+	}
+
+	return utils.CreatedResponse(c, "Delivery queued", mapper.ToWebhookDeliveryResponse(d))
+}
@@ -0,0 +1,96 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"keerja-backend/internal/config"
+	"keerja-backend/internal/jobs"
+)
+
+// SchedulerMonitor exposes the background scheduler state
+type SchedulerMonitor interface {
+	Status() jobs.SchedulerStatus
+}
+
+// StorageProber verifies that upload storage is writable
+type StorageProber interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// SchedulerCheck reports the scheduler down when it is not running, and degraded
+// when no job has started within maxTickAge
+func SchedulerCheck(scheduler SchedulerMonitor, maxTickAge time.Duration) CheckFunc {
+	return func(ctx context.Context) ComponentHealth {
+		status := scheduler.Status()
+		if !status.Running {
+			return ComponentHealth{
+				Status:  StatusDown,
+				Message: "scheduler not running",
+			}
+		}
+
+		lastActivity := status.LastTick
+		if lastActivity.IsZero() {
+			lastActivity = status.StartedAt
+		}
+		if idle := time.Since(lastActivity); idle > maxTickAge {
+			return ComponentHealth{
+				Status:  StatusDegraded,
+				Message: fmt.Sprintf("no job has run for %s", idle.Round(time.Second)),
+			}
+		}
+
+		message := fmt.Sprintf("%d jobs, waiting for first tick", status.Jobs)
+		if !status.LastTick.IsZero() {
+			message = fmt.Sprintf("%d jobs, last tick %s", status.Jobs, status.LastTick.UTC().Format(time.RFC3339))
+		}
+		return ComponentHealth{
+			Status:  StatusOK,
+			Message: message,
+		}
+	}
+}
+
+// FCMCheck reports whether the FCM client was initialized. FCM being disabled by
+// configuration is not a failure.
+func FCMCheck(enabled bool) CheckFunc {
+	return func(ctx context.Context) ComponentHealth {
+		if !enabled {
+			return ComponentHealth{
+				Status:  StatusOK,
+				Message: "fcm disabled by configuration",
+			}
+		}
+
+		if _, err := config.GetFCMClient(); err != nil {
+			return ComponentHealth{
+				Status:  StatusDegraded,
+				Message: "fcm client unavailable, check credentials: " + err.Error(),
+			}
+		}
+
+		return ComponentHealth{
+			Status:  StatusOK,
+			Message: "fcm client initialized",
+		}
+	}
+}
+
+// StorageCheck writes and deletes a probe file in upload storage
+func StorageCheck(storage StorageProber) CheckFunc {
+	return func(ctx context.Context) ComponentHealth {
+		if err := storage.HealthCheck(ctx); err != nil {
+			return ComponentHealth{
+				Status:  StatusDegraded,
+				Message: "upload storage probe failed: " + err.Error(),
+			}
+		}
+
+		return ComponentHealth{
+			Status:  StatusOK,
+			Message: "upload storage writable",
+		}
+	}
+}
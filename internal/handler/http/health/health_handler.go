@@ -7,8 +7,9 @@ import (
 	"sync"
 	"time"
 
+	"keerja-backend/internal/cache"
+
 	"github.com/gofiber/fiber/v2"
-	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
@@ -16,21 +17,31 @@ import (
 type Status string
 
 const (
-	StatusUp   Status = "up"
-	StatusDown Status = "down"
+	StatusOK       Status = "ok"
+	StatusDegraded Status = "degraded"
+	StatusDown     Status = "down"
 )
 
+// DefaultCheckTimeout bounds a single component check so a hung dependency
+// cannot block the health endpoints
+const DefaultCheckTimeout = 3 * time.Second
+
 // ComponentHealth represents health info for a single component
 type ComponentHealth struct {
-	Status  Status `json:"status"`
-	Message string `json:"message,omitempty"`
-	Latency string `json:"latency,omitempty"`
+	Status   Status `json:"status"`
+	Critical bool   `json:"critical"`
+	Message  string `json:"message,omitempty"`
+	Latency  string `json:"latency,omitempty"`
 }
 
+// CheckFunc checks a single component. It should return promptly once ctx is done.
+type CheckFunc func(ctx context.Context) ComponentHealth
+
 // HealthResponse represents the full health check response
 type HealthResponse struct {
 	Status     Status                     `json:"status"`
 	Version    string                     `json:"version"`
+	GitSHA     string                     `json:"git_sha"`
 	Uptime     string                     `json:"uptime"`
 	Timestamp  string                     `json:"timestamp"`
 	Components map[string]ComponentHealth `json:"components,omitempty"`
@@ -39,6 +50,9 @@ type HealthResponse struct {
 // ReadinessResponse represents readiness check response
 type ReadinessResponse struct {
 	Ready      bool                       `json:"ready"`
+	Status     Status                     `json:"status"`
+	Version    string                     `json:"version"`
+	GitSHA     string                     `json:"git_sha"`
 	Components map[string]ComponentHealth `json:"components"`
 }
 
@@ -59,40 +73,75 @@ type SystemInfo struct {
 	NumGC         uint32 `json:"num_gc"`
 }
 
+// degradable is a store that can fall back to a secondary backend, such as
+// cache.FailoverKVStore
+type degradable interface {
+	Degraded() bool
+}
+
+// componentCheck is a registered component check
+type componentCheck struct {
+	name     string
+	critical bool
+	check    CheckFunc
+}
+
 // HealthHandler handles health check endpoints
 type HealthHandler struct {
-	db        *gorm.DB
-	redis     *redis.Client
-	version   string
-	startTime time.Time
+	db           *gorm.DB
+	redis        cache.KeyValueStore
+	version      string
+	gitSHA       string
+	startTime    time.Time
+	checkTimeout time.Duration
+	checks       []componentCheck
+}
+
+// NewHealthHandler creates a new health handler. The database and Redis are
+// critical components: the service is not ready when either of them is down.
+// Redis is not critical when the store has an in-memory fallback; it is then
+// reported degraded while the fallback is in use.
+func NewHealthHandler(db *gorm.DB, redis cache.KeyValueStore, version, gitSHA string) *HealthHandler {
+	h := &HealthHandler{
+		db:           db,
+		redis:        redis,
+		version:      version,
+		gitSHA:       gitSHA,
+		startTime:    time.Now(),
+		checkTimeout: DefaultCheckTimeout,
+	}
+	_, redisOptional := redis.(degradable)
+	h.checks = []componentCheck{
+		{name: "database", critical: true, check: h.checkDatabase},
+		{name: "redis", critical: !redisOptional, check: h.checkRedis},
+	}
+	return h
+}
+
+// AddCheck registers a non-critical component. A failing non-critical component
+// marks the service degraded but keeps it ready. Checks must be added before the
+// handler starts serving requests.
+func (h *HealthHandler) AddCheck(name string, check CheckFunc) {
+	h.checks = append(h.checks, componentCheck{name: name, check: check})
 }
 
-// NewHealthHandler creates a new health handler
-func NewHealthHandler(db *gorm.DB, redis *redis.Client, version string) *HealthHandler {
-	return &HealthHandler{
-		db:        db,
-		redis:     redis,
-		version:   version,
-		startTime: time.Now(),
+// SetCheckTimeout overrides DefaultCheckTimeout
+func (h *HealthHandler) SetCheckTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		h.checkTimeout = timeout
 	}
 }
 
 // Health returns the overall health status of the application
 // GET /health
 func (h *HealthHandler) Health(c *fiber.Ctx) error {
-	components := h.checkAllComponents(c.Context())
-
-	overallStatus := StatusUp
-	for _, comp := range components {
-		if comp.Status == StatusDown {
-			overallStatus = StatusDown
-			break
-		}
-	}
+	components := h.checkAllComponents(c.UserContext())
+	overallStatus := overallStatus(components)
 
 	response := HealthResponse{
 		Status:     overallStatus,
 		Version:    h.version,
+		GitSHA:     h.gitSHA,
 		Uptime:     time.Since(h.startTime).Round(time.Second).String(),
 		Timestamp:  time.Now().UTC().Format(time.RFC3339),
 		Components: components,
@@ -116,22 +165,21 @@ func (h *HealthHandler) Liveness(c *fiber.Ctx) error {
 	})
 }
 
-// Readiness indicates if the application is ready to receive traffic
+// Readiness indicates if the application is ready to receive traffic. Only a
+// critical component being down makes it unready; degraded components are reported
+// but still return 200.
 // GET /health/ready
 // Used by Kubernetes readiness probe
 func (h *HealthHandler) Readiness(c *fiber.Ctx) error {
-	components := h.checkAllComponents(c.Context())
-
-	ready := true
-	for _, comp := range components {
-		if comp.Status == StatusDown {
-			ready = false
-			break
-		}
-	}
+	components := h.checkAllComponents(c.UserContext())
+	status := overallStatus(components)
+	ready := status != StatusDown
 
 	response := ReadinessResponse{
 		Ready:      ready,
+		Status:     status,
+		Version:    h.version,
+		GitSHA:     h.gitSHA,
 		Components: components,
 	}
 
@@ -164,37 +212,71 @@ func (h *HealthHandler) SystemInfo(c *fiber.Ctx) error {
 		"system":  info,
 		"uptime":  time.Since(h.startTime).Round(time.Second).String(),
 		"version": h.version,
+		"git_sha": h.gitSHA,
 	})
 }
 
-// checkAllComponents checks all service dependencies concurrently
+// checkAllComponents runs every registered check concurrently
 func (h *HealthHandler) checkAllComponents(ctx context.Context) map[string]ComponentHealth {
-	components := make(map[string]ComponentHealth)
+	components := make(map[string]ComponentHealth, len(h.checks))
 	var mu sync.Mutex
 	var wg sync.WaitGroup
 
-	// Check database
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		health := h.checkDatabase(ctx)
-		mu.Lock()
-		components["database"] = health
-		mu.Unlock()
-	}()
+	for _, c := range h.checks {
+		wg.Add(1)
+		go func(c componentCheck) {
+			defer wg.Done()
+			health := h.runCheck(ctx, c)
+			mu.Lock()
+			components[c.name] = health
+			mu.Unlock()
+		}(c)
+	}
+
+	wg.Wait()
+	return components
+}
 
-	// Check Redis
-	wg.Add(1)
+// runCheck runs a single check, reporting it down if it outlives the check timeout
+func (h *HealthHandler) runCheck(ctx context.Context, c componentCheck) ComponentHealth {
+	ctx, cancel := context.WithTimeout(ctx, h.checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan ComponentHealth, 1)
 	go func() {
-		defer wg.Done()
-		health := h.checkRedis(ctx)
-		mu.Lock()
-		components["redis"] = health
-		mu.Unlock()
+		done <- c.check(ctx)
 	}()
 
-	wg.Wait()
-	return components
+	var health ComponentHealth
+	select {
+	case health = <-done:
+	case <-ctx.Done():
+		health = ComponentHealth{
+			Status:  StatusDown,
+			Message: fmt.Sprintf("check timed out after %s", h.checkTimeout),
+		}
+	}
+
+	health.Critical = c.critical
+	health.Latency = time.Since(start).Round(time.Microsecond).String()
+	return health
+}
+
+// overallStatus is down when a critical component is down, degraded when any
+// component is not ok, and ok otherwise
+func overallStatus(components map[string]ComponentHealth) Status {
+	status := StatusOK
+	for _, comp := range components {
+		if comp.Status == StatusOK {
+			continue
+		}
+		if comp.Critical && comp.Status == StatusDown {
+			return StatusDown
+		}
+		status = StatusDegraded
+	}
+	return status
 }
 
 // checkDatabase checks PostgreSQL connection
@@ -206,8 +288,6 @@ func (h *HealthHandler) checkDatabase(ctx context.Context) ComponentHealth {
 		}
 	}
 
-	start := time.Now()
-
 	sqlDB, err := h.db.DB()
 	if err != nil {
 		return ComponentHealth{
@@ -216,11 +296,7 @@ func (h *HealthHandler) checkDatabase(ctx context.Context) ComponentHealth {
 		}
 	}
 
-	// Use context with timeout for the ping
-	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
-
-	if err := sqlDB.PingContext(pingCtx); err != nil {
+	if err := sqlDB.PingContext(ctx); err != nil {
 		return ComponentHealth{
 			Status:  StatusDown,
 			Message: "database ping failed: " + err.Error(),
@@ -228,9 +304,8 @@ func (h *HealthHandler) checkDatabase(ctx context.Context) ComponentHealth {
 	}
 
 	return ComponentHealth{
-		Status:  StatusUp,
+		Status:  StatusOK,
 		Message: "postgresql connected",
-		Latency: time.Since(start).Round(time.Microsecond).String(),
 	}
 }
 
@@ -243,13 +318,14 @@ func (h *HealthHandler) checkRedis(ctx context.Context) ComponentHealth {
 		}
 	}
 
-	start := time.Now()
-
-	// Use context with timeout for the ping
-	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
+	if store, ok := h.redis.(degradable); ok && store.Degraded() {
+		return ComponentHealth{
+			Status:  StatusDegraded,
+			Message: "redis unavailable, using in-memory fallback",
+		}
+	}
 
-	if err := h.redis.Ping(pingCtx).Err(); err != nil {
+	if err := h.redis.Ping(ctx); err != nil {
 		return ComponentHealth{
 			Status:  StatusDown,
 			Message: "redis ping failed: " + err.Error(),
@@ -257,9 +333,8 @@ func (h *HealthHandler) checkRedis(ctx context.Context) ComponentHealth {
 	}
 
 	return ComponentHealth{
-		Status:  StatusUp,
+		Status:  StatusOK,
 		Message: "redis connected",
-		Latency: time.Since(start).Round(time.Microsecond).String(),
 	}
 }
 
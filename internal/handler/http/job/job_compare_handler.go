@@ -0,0 +1,37 @@
+package jobhandler
+
+import (
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// JobCompareHandler compares jobs side by side for job seekers
+type JobCompareHandler struct {
+	comparisonService job.JobComparisonService
+}
+
+// NewJobCompareHandler creates a new instance of JobCompareHandler
+func NewJobCompareHandler(comparisonService job.JobComparisonService) *JobCompareHandler {
+	return &JobCompareHandler{comparisonService: comparisonService}
+}
+
+// CompareJobs returns the comparison matrix of up to 3 jobs. Signed-in callers also get
+// their skills, distance and match score per job; jobs the caller may not see are listed
+// under not_found.
+// GET /api/v1/jobs/compare?ids=1,2,3
+func (h *JobCompareHandler) CompareJobs(c *fiber.Ctx) error {
+	ids, err := job.ParseCompareJobIDs(c.Query("ids"))
+	if err != nil {
+		return err
+	}
+
+	comparison, err := h.comparisonService.CompareJobs(c.UserContext(), ids, middleware.GetUserID(c))
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, comparison)
+}
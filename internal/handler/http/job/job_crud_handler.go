@@ -1,7 +1,10 @@
 package jobhandler
 
 import (
+	"context"
+
 	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/employer"
 	"keerja-backend/internal/domain/job"
 	"keerja-backend/internal/dto/mapper"
 	"keerja-backend/internal/dto/request"
@@ -14,17 +17,22 @@ import (
 )
 
 func (h *JobHandler) ListJobs(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
+
+	page, limit, err := utils.ParsePagination(c, 10, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
 
 	var q request.JobFilterRequest
 	if err := c.QueryParser(&q); err != nil {
 		return utils.BadRequestResponse(c, common.ErrInvalidQueryParams)
 	}
+	q.Page, q.Limit = page, limit
 	if err := utils.ValidateStruct(&q); err != nil {
-		errs := utils.FormatValidationErrors(err)
+		errs := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
 	}
-	q.Page, q.Limit = utils.ValidatePagination(q.Page, q.Limit, 100)
 
 	// Build domain filter
 	f := job.JobFilter{
@@ -42,11 +50,29 @@ func (h *JobHandler) ListJobs(c *fiber.Ctx) error {
 		f.CategoryID = *q.CategoryID
 	}
 
+	if utils.UsesCursor(c) {
+		if q.SortBy != "" {
+			return utils.ErrCursorSortUnsupported
+		}
+		jobs, next, err := h.jobService.ListJobsByCursor(ctx, f, q.Cursor, q.Limit)
+		if err != nil {
+			return err
+		}
+		return utils.SuccessResponse(c, common.MsgFetchedSuccess, response.NewCursorPage(h.toJobResponses(ctx, jobs), next))
+	}
+
 	jobs, total, err := h.jobService.ListJobs(ctx, f, q.Page, q.Limit)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to list jobs", err.Error())
+		return err
 	}
 
+	meta := utils.GetPaginationMeta(q.Page, q.Limit, total)
+	payload := response.JobListResponse{Jobs: h.toJobResponses(ctx, jobs)}
+	return utils.SuccessResponseWithMeta(c, common.MsgFetchedSuccess, payload, meta)
+}
+
+// toJobResponses maps jobs to responses with their company data
+func (h *JobHandler) toJobResponses(ctx context.Context, jobs []job.Job) []response.JobResponse {
 	// Collect unique company IDs for batch fetching
 	companyIDMap := make(map[int64]bool)
 	for _, j := range jobs {
@@ -71,14 +97,11 @@ func (h *JobHandler) ListJobs(c *fiber.Ctx) error {
 			respJobs = append(respJobs, *jobResp)
 		}
 	}
-
-	meta := utils.GetPaginationMeta(q.Page, q.Limit, total)
-	payload := response.JobListResponse{Jobs: respJobs}
-	return utils.SuccessResponseWithMeta(c, common.MsgFetchedSuccess, payload, meta)
+	return respJobs
 }
 
 func (h *JobHandler) GetJob(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	id, err := utils.ParseIDParam(c, "id")
 	if err != nil || id <= 0 {
 		return utils.BadRequestResponse(c, common.ErrInvalidID)
@@ -86,24 +109,48 @@ func (h *JobHandler) GetJob(c *fiber.Ctx) error {
 
 	j, err := h.jobService.GetJob(ctx, id)
 	if err != nil {
-		return utils.NotFoundResponse(c, common.ErrJobNotFound)
+		return err
+	}
+
+	// Count the view (best-effort); repeat views by the same user or IP are ignored by the service
+	if j.Status == job.StatusPublished {
+		var viewerID *int64
+		if userID := middleware.GetUserID(c); userID != 0 {
+			viewerID = &userID
+		}
+		viewCtx := job.WithAttribution(job.WithViewerIP(ctx, c.IP()), job.Attribution{
+			Source:   c.Query("utm_source"),
+			Medium:   c.Query("utm_medium"),
+			Campaign: c.Query("utm_campaign"),
+			Referrer: c.Query("referrer"),
+		})
+		_ = h.jobService.IncrementView(viewCtx, id, viewerID)
 	}
 
 	comp, _ := h.companyService.GetCompany(ctx, j.CompanyID)
 	resp := mapper.ToJobDetailResponseWithCompany(j, comp, nil)
+
+	// Show the latest admin review (e.g. rejection reason) to the job's employer only
+	if userID := middleware.GetUserID(c); userID != 0 && j.Status != job.StatusPublished {
+		if ok, _ := h.companyService.CheckEmployerPermission(ctx, userID, j.CompanyID, employer.RoleViewer); ok {
+			if review, err := h.jobService.GetLatestReview(ctx, id); err == nil {
+				resp.Review = mapper.ToJobReviewResponse(review)
+			}
+		}
+	}
+
 	return utils.SuccessResponse(c, common.MsgFetchedSuccess, resp)
 }
 
 func (h *JobHandler) CreateJob(c *fiber.Ctx) error {
-	ctx := c.Context()
-	userID := middleware.GetUserID(c)
+	ctx := c.UserContext()
 
 	var req request.CreateJobRequest
 	if err := c.BodyParser(&req); err != nil {
 		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
 	}
 	if err := utils.ValidateStruct(&req); err != nil {
-		errs := utils.FormatValidationErrors(err)
+		errs := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
 	}
 
@@ -121,9 +168,13 @@ func (h *JobHandler) CreateJob(c *fiber.Ctx) error {
 		})
 	}
 
+	ec, err := h.companyService.ResolveEmployerContext(ctx, middleware.GetUserID(c), req.CompanyID)
+	if err != nil {
+		return err
+	}
+
 	domainReq := &job.CreateJobRequest{
 		CompanyID:          req.CompanyID,
-		EmployerUserID:     userID,
 		Description:        req.Description,
 		JobTitleID:         req.JobTitleID,
 		JobCategoryID:      req.JobCategoryID,
@@ -142,9 +193,9 @@ func (h *JobHandler) CreateJob(c *fiber.Ctx) error {
 		Skills:             skills,
 	}
 
-	created, err := h.jobService.CreateJob(ctx, domainReq)
+	created, err := h.jobService.CreateJob(ctx, ec, domainReq)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create job", err.Error())
+		return err
 	}
 
 	comp, _ := h.companyService.GetCompany(ctx, req.CompanyID)
@@ -153,8 +204,7 @@ func (h *JobHandler) CreateJob(c *fiber.Ctx) error {
 }
 
 func (h *JobHandler) UpdateJob(c *fiber.Ctx) error {
-	ctx := c.Context()
-	employerID := middleware.GetUserID(c)
+	ctx := c.UserContext()
 
 	id, err := utils.ParseIDParam(c, "id")
 	if err != nil || id <= 0 {
@@ -166,29 +216,33 @@ func (h *JobHandler) UpdateJob(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
 	}
 	if err := utils.ValidateStruct(&req); err != nil {
-		errs := utils.FormatValidationErrors(err)
+		errs := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
 	}
 
-	existingJob, err := h.jobService.GetJob(ctx, id)
+	version, err := utils.RequestVersion(c, req.Version)
 	if err != nil {
-		return utils.NotFoundResponse(c, common.ErrJobNotFound)
+		return utils.BadRequestResponse(c, err.Error())
 	}
-	if existingJob.EmployerUserID == nil {
-		return utils.ForbiddenResponse(c, common.ErrNotJobOwner)
+
+	ec, err := h.employerContextForJob(c, id)
+	if err != nil {
+		return err
 	}
 
-	skills := make([]job.AddSkillRequest, 0, len(req.Skills))
-	for _, s := range req.Skills {
-		skills = append(skills, job.AddSkillRequest{
-			SkillID:         s.SkillID,
-			ImportanceLevel: s.ImportanceLevel,
-		})
+	// Leave skills nil when the field is omitted so the existing skills are kept
+	var skills []job.AddSkillRequest
+	if req.Skills != nil {
+		skills = make([]job.AddSkillRequest, 0, len(req.Skills))
+		for _, s := range req.Skills {
+			skills = append(skills, job.AddSkillRequest{
+				SkillID:         s.SkillID,
+				ImportanceLevel: s.ImportanceLevel,
+			})
+		}
 	}
 
 	domainReq := &job.UpdateJobRequest{
-		EmployerUserID:     employerID,
-		CompanyID:          existingJob.CompanyID,
 		JobTitleID:         req.JobTitleID,
 		JobTypeID:          req.JobTypeID,
 		WorkPolicyID:       req.WorkPolicyID,
@@ -201,7 +255,9 @@ func (h *JobHandler) UpdateJob(c *fiber.Ctx) error {
 		MinAge:             req.MinAge,
 		MaxAge:             req.MaxAge,
 		CompanyAddressID:   req.CompanyAddressID,
+		AutoExtend:         req.AutoExtend,
 		Skills:             skills,
+		Version:            version,
 	}
 
 	if req.Description != nil {
@@ -212,14 +268,14 @@ func (h *JobHandler) UpdateJob(c *fiber.Ctx) error {
 		domainReq.Description = sanitized
 	}
 
-	_, err = h.jobService.UpdateJob(ctx, id, domainReq)
+	_, err = h.jobService.UpdateJob(ctx, id, ec, domainReq)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update job", err.Error())
+		return err
 	}
 
 	latestJob, err := h.jobService.GetJob(ctx, id)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve updated job", err.Error())
+		return err
 	}
 
 	comp, _ := h.companyService.GetCompany(ctx, latestJob.CompanyID)
@@ -228,17 +284,65 @@ func (h *JobHandler) UpdateJob(c *fiber.Ctx) error {
 }
 
 func (h *JobHandler) DeleteJob(c *fiber.Ctx) error {
-	ctx := c.Context()
-	employerID := middleware.GetUserID(c)
+	ctx := c.UserContext()
 
 	id, err := utils.ParseIDParam(c, "id")
 	if err != nil || id <= 0 {
 		return utils.BadRequestResponse(c, common.ErrInvalidID)
 	}
 
-	if err := h.jobService.DeleteJob(ctx, id, employerID); err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, common.ErrInternalServer, err.Error())
+	ec, err := h.employerContextForJob(c, id)
+	if err != nil {
+		return err
+	}
+
+	if err := h.jobService.DeleteJob(ctx, id, ec); err != nil {
+		return err
 	}
 
 	return utils.SuccessResponse(c, common.MsgDeletedSuccess, fiber.Map{"deleted": true})
 }
+
+// RestoreJob brings back a job deleted within the last 30 days
+// POST /api/v1/jobs/:id/restore
+func (h *JobHandler) RestoreJob(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	id, err := utils.ParseIDParam(c, "id")
+	if err != nil || id <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	ec, err := h.employerContextForJob(c, id)
+	if err != nil {
+		return err
+	}
+
+	if err := h.jobService.RestoreJob(ctx, id, ec); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, "Job restored successfully", fiber.Map{"restored": true})
+}
+
+func (h *JobHandler) DuplicateJob(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	id, err := utils.ParseIDParam(c, "id")
+	if err != nil || id <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	ec, err := h.employerContextForJob(c, id)
+	if err != nil {
+		return err
+	}
+
+	dup, err := h.jobService.DuplicateJob(ctx, id, ec)
+	if err != nil {
+		return err
+	}
+
+	comp, _ := h.companyService.GetCompany(ctx, dup.CompanyID)
+	return utils.CreatedResponse(c, "Job duplicated successfully", mapper.ToJobDetailResponseWithCompany(dup, comp, nil))
+}
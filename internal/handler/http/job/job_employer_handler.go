@@ -1,6 +1,8 @@
 package jobhandler
 
 import (
+	"time"
+
 	"keerja-backend/internal/domain/job"
 	"keerja-backend/internal/dto/mapper"
 	"keerja-backend/internal/dto/request"
@@ -21,11 +23,13 @@ func (h *JobHandler) getJobsByStatusHelper(c *fiber.Ctx, status string) error {
 	}
 
 	// Parse pagination
-	page := c.QueryInt("page", 1)
-	limit := c.QueryInt("limit", 10)
-	page, limit = utils.ValidatePagination(page, limit, 100)
+	page, limit, err := utils.ParsePagination(c, 10, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
 
-	jobs, total, err := h.companyService.GetJobsByStatus(ctx, userID, status, page, limit)
+	// Only the jobs posted at the company RequireActiveCompany resolved are listed
+	jobs, total, err := h.companyService.GetJobsByStatus(ctx, middleware.GetEmployerContext(c), status, page, limit)
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, "Failed to retrieve "+status+" jobs")
 	}
@@ -43,37 +47,42 @@ func (h *JobHandler) getJobsByStatusHelper(c *fiber.Ctx, status string) error {
 
 // GetActiveJobs returns jobs with active/published status
 func (h *JobHandler) GetActiveJobs(c *fiber.Ctx) error {
-	return h.getJobsByStatusHelper(c, "active")
+	return h.getJobsByStatusHelper(c, job.TabActive)
 }
 
 // GetDraftJobs returns jobs with draft status
 func (h *JobHandler) GetDraftJobs(c *fiber.Ctx) error {
-	return h.getJobsByStatusHelper(c, "draft")
+	return h.getJobsByStatusHelper(c, job.TabDraft)
 }
 
 // GetInReviewJobs returns jobs with in_review status
 func (h *JobHandler) GetInReviewJobs(c *fiber.Ctx) error {
-	return h.getJobsByStatusHelper(c, "in_review")
+	return h.getJobsByStatusHelper(c, job.TabInReview)
 }
 
 // GetInactiveJobs returns jobs with inactive status
 func (h *JobHandler) GetInactiveJobs(c *fiber.Ctx) error {
-	return h.getJobsByStatusHelper(c, "inactive")
+	return h.getJobsByStatusHelper(c, job.TabInactive)
 }
 
 func (h *JobHandler) GetMyJobs(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
+	page, limit, err := utils.ParsePagination(c, 10, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
+
 	var f request.JobFilterRequest
 	if err := c.QueryParser(&f); err != nil {
 		return utils.BadRequestResponse(c, common.ErrInvalidQueryParams)
 	}
+	f.Page, f.Limit = page, limit
 	if err := utils.ValidateStruct(&f); err != nil {
-		errs := utils.FormatValidationErrors(err)
+		errs := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
 	}
-	f.Page, f.Limit = utils.ValidatePagination(f.Page, f.Limit, 100)
 
 	df := job.JobFilter{
 		Status:   f.Status,
@@ -88,33 +97,28 @@ func (h *JobHandler) GetMyJobs(c *fiber.Ctx) error {
 		df.CategoryID = *f.CategoryID
 	}
 
-	var employerID int64
-	if f.CompanyID != nil {
-		resolvedID, err := h.companyService.GetEmployerUserID(ctx, userID, *f.CompanyID)
-		if err != nil {
-			return utils.ErrorResponse(c, fiber.StatusForbidden, "Failed to resolve employer user ID", err.Error())
-		}
-		employerID = resolvedID
-	} else {
-		companies, err := h.companyService.GetUserCompanies(ctx, userID)
-		if err != nil || len(companies) == 0 {
-			return utils.ErrorResponse(c, fiber.StatusForbidden, "No companies found for user", "No companies found")
+	// Without a company filter the jobs posted at the company named by X-Company-ID, or
+	// else at the user's active company, are listed
+	companyID := df.CompanyID
+	if companyID == 0 {
+		if companyID, err = middleware.ActiveCompanyID(c); err != nil {
+			return err
 		}
-		resolvedID, err := h.companyService.GetEmployerUserID(ctx, userID, companies[0].ID)
-		if err != nil {
-			return utils.ErrorResponse(c, fiber.StatusForbidden, "Failed to resolve employer user ID", err.Error())
-		}
-		employerID = resolvedID
+	}
+	ec, err := h.companyService.ResolveEmployerContext(ctx, userID, companyID)
+	if err != nil {
+		return err
 	}
 
-	jobs, total, err := h.jobService.GetMyJobs(ctx, employerID, df, f.Page, f.Limit)
+	jobs, total, err := h.jobService.GetMyJobs(ctx, ec, df, f.Page, f.Limit)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get my jobs", err.Error())
+		return err
 	}
 
 	respJobs := mapper.MapEntities[job.Job, response.JobDetailResponse](jobs, func(j *job.Job) *response.JobDetailResponse {
 		return mapper.ToJobDetailResponse(j)
 	})
+	now := time.Now()
 	for i := range respJobs {
 		comp, err := h.companyService.GetCompany(ctx, jobs[i].CompanyID)
 		if err == nil && comp != nil {
@@ -122,6 +126,10 @@ func (h *JobHandler) GetMyJobs(c *fiber.Ctx) error {
 			respJobs[i].CompanySlug = comp.Slug
 			respJobs[i].CompanyVerified = comp.IsVerified()
 		}
+		respJobs[i].AutoExtend = &jobs[i].AutoExtend
+		if jobs[i].IsPublished() {
+			respJobs[i].DaysUntilExpiry = jobs[i].DaysUntilExpiry(now)
+		}
 	}
 
 	meta := utils.GetPaginationMeta(f.Page, f.Limit, total)
@@ -132,7 +140,7 @@ func (h *JobHandler) GetMyJobs(c *fiber.Ctx) error {
 }
 
 func (h *JobHandler) SaveJobDraft(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	userID := middleware.GetUserID(c)
 	if userID == 0 {
@@ -145,7 +153,7 @@ func (h *JobHandler) SaveJobDraft(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errs := utils.FormatValidationErrors(err)
+		errs := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
 	}
 
@@ -172,7 +180,7 @@ func (h *JobHandler) SaveJobDraft(c *fiber.Ctx) error {
 
 	draft, err := h.jobService.SaveJobDraft(ctx, companyID, domainReq)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to save job draft", err.Error())
+		return err
 	}
 
 	comp, _ := h.companyService.GetCompany(ctx, draft.CompanyID)
@@ -183,3 +191,134 @@ func (h *JobHandler) SaveJobDraft(c *fiber.Ctx) error {
 	}
 	return utils.SuccessResponse(c, "Job draft updated successfully", resp)
 }
+
+// ListCompanyDrafts returns a company's draft jobs with the fields each still needs before publishing
+func (h *JobHandler) ListCompanyDrafts(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil || companyID <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	page, limit, err := utils.ParsePagination(c, 10, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
+
+	drafts, total, err := h.jobService.ListCompanyDrafts(ctx, companyID, page, limit)
+	if err != nil {
+		return err
+	}
+
+	respDrafts := mapper.MapEntities[job.JobDraft, response.JobDraftResponse](drafts, mapper.ToJobDraftResponse)
+
+	meta := utils.GetPaginationMeta(page, limit, total)
+	payload := struct {
+		Drafts []response.JobDraftResponse `json:"drafts"`
+	}{Drafts: respDrafts}
+	return utils.SuccessResponseWithMeta(c, common.MsgFetchedSuccess, payload, meta)
+}
+
+// GetCompanyQuota returns the company's plan, published job limit and published job count
+func (h *JobHandler) GetCompanyQuota(c *fiber.Ctx) error {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil || companyID <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	quota, err := h.quotaService.GetJobQuota(c.UserContext(), companyID)
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, quota)
+}
+
+// ListCompanyJobs returns a company's jobs, published ones unless another status is requested
+func (h *JobHandler) ListCompanyJobs(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil || companyID <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	page, limit, err := utils.ParsePagination(c, 10, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
+
+	var q request.JobFilterRequest
+	if err := c.QueryParser(&q); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidQueryParams)
+	}
+	q.Page, q.Limit = page, limit
+	if err := utils.ValidateStruct(&q); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	f := job.JobFilter{
+		Status:    q.Status,
+		CompanyID: companyID,
+		SortBy:    q.SortBy,
+	}
+	jobs, total, err := h.jobService.ListJobs(ctx, f, q.Page, q.Limit)
+	if err != nil {
+		return err
+	}
+
+	meta := utils.GetPaginationMeta(q.Page, q.Limit, total)
+	payload := response.JobListResponse{Jobs: h.toJobResponses(ctx, jobs)}
+	return utils.SuccessResponseWithMeta(c, common.MsgFetchedSuccess, payload, meta)
+}
+
+// ListDraftRevisions returns the saved revisions of a draft, newest first
+func (h *JobHandler) ListDraftRevisions(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	id, err := utils.ParseIDParam(c, "id")
+	if err != nil || id <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	ec, err := h.employerContextForJob(c, id)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := h.jobService.ListDraftRevisions(ctx, id, ec)
+	if err != nil {
+		return err
+	}
+
+	resp := mapper.MapEntities[job.JobDraftRevision, response.JobDraftRevisionResponse](revisions, mapper.ToJobDraftRevisionResponse)
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, fiber.Map{"revisions": resp})
+}
+
+// RestoreDraftRevision saves a draft again with the payload of one of its revisions
+func (h *JobHandler) RestoreDraftRevision(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	id, err := utils.ParseIDParam(c, "id")
+	if err != nil || id <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+	revisionID, err := utils.ParseIDParam(c, "revisionId")
+	if err != nil || revisionID <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	ec, err := h.employerContextForJob(c, id)
+	if err != nil {
+		return err
+	}
+
+	draft, err := h.jobService.RestoreDraftRevision(ctx, id, revisionID, ec)
+	if err != nil {
+		return err
+	}
+
+	comp, _ := h.companyService.GetCompany(ctx, draft.CompanyID)
+	return utils.SuccessResponse(c, "Job draft restored successfully", mapper.ToJobDetailResponseWithCompany(draft, comp, nil))
+}
@@ -0,0 +1,55 @@
+package jobhandler
+
+import (
+	"fmt"
+
+	"keerja-backend/internal/domain/job"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// JobFeedHandler serves the jobs sitemap and structured data read by search engines
+type JobFeedHandler struct {
+	feedService job.JobFeedService
+}
+
+// NewJobFeedHandler creates a new instance of JobFeedHandler
+func NewJobFeedHandler(feedService job.JobFeedService) *JobFeedHandler {
+	return &JobFeedHandler{feedService: feedService}
+}
+
+// GetSitemap streams the sitemap of published jobs, or a sitemap index over its pages when
+// there are more jobs than one sitemap may list
+// GET /sitemap-jobs.xml?page=1
+func (h *JobFeedHandler) GetSitemap(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 0)
+	if page < 0 {
+		return job.ErrSitemapPageNotFound
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationXMLCharsetUTF8)
+	c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", int(job.SitemapCacheTTL.Seconds())))
+
+	if err := h.feedService.WriteSitemap(c.UserContext(), c.BaseURL()+c.Path(), page, c); err != nil {
+		c.Response().ResetBody()
+		c.Set(fiber.HeaderCacheControl, "no-store")
+		return err
+	}
+	return nil
+}
+
+// GetStructuredData returns the schema.org JobPosting JSON-LD of a published job, for job
+// pages to embed
+// GET /api/v1/jobs/:slug/structured-data
+func (h *JobFeedHandler) GetStructuredData(c *fiber.Ctx) error {
+	posting, err := h.feedService.GetStructuredData(c.UserContext(), c.Params("slug"))
+	if err != nil {
+		return err
+	}
+
+	if err := c.JSON(posting); err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, "application/ld+json; charset=utf-8")
+	return nil
+}
@@ -2,8 +2,12 @@ package jobhandler
 
 import (
 	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/employer"
 	"keerja-backend/internal/domain/job"
 	"keerja-backend/internal/domain/master"
+	"keerja-backend/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
 )
 
 // JobHandler handles job-related operations
@@ -12,6 +16,7 @@ type JobHandler struct {
 	companyService    company.CompanyService
 	jobOptionsService master.JobOptionsService
 	skillsService     master.SkillsMasterService
+	quotaService      company.QuotaService
 }
 
 // NewJobHandler creates a new instance of JobHandler
@@ -20,11 +25,24 @@ func NewJobHandler(
 	companyService company.CompanyService,
 	jobOptionsService master.JobOptionsService,
 	skillService master.SkillsMasterService,
+	quotaService company.QuotaService,
 ) *JobHandler {
 	return &JobHandler{
 		jobService:        jobService,
 		companyService:    companyService,
 		jobOptionsService: jobOptionsService,
 		skillsService:     skillService,
+		quotaService:      quotaService,
+	}
+}
+
+// employerContextForJob resolves the authenticated user's employer context at the company
+// that owns the job
+func (h *JobHandler) employerContextForJob(c *fiber.Ctx, jobID int64) (*employer.EmployerContext, error) {
+	ctx := c.UserContext()
+	companyID, err := h.jobService.GetJobCompanyID(ctx, jobID)
+	if err != nil {
+		return nil, err
 	}
+	return h.companyService.ResolveEmployerContext(ctx, middleware.GetUserID(c), companyID)
 }
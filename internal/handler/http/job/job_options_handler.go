@@ -10,7 +10,7 @@ import (
 )
 
 func (h *JobHandler) GetJobTypesOptions(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	userID := middleware.GetUserID(c)
 	if userID == 0 {
@@ -91,7 +91,7 @@ func (h *JobHandler) GetJobTypesOptions(c *fiber.Ctx) error {
 }
 
 func (h *JobHandler) GetJobRequirements(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	userID := middleware.GetUserID(c)
 	if userID == 0 {
@@ -0,0 +1,65 @@
+package jobhandler
+
+import (
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetPipeline returns the stages of a job's hiring pipeline in order
+func (h *JobHandler) GetPipeline(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	id, err := utils.ParseIDParam(c, "id")
+	if err != nil || id <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	ec, err := h.employerContextForJob(c, id)
+	if err != nil {
+		return err
+	}
+
+	stages, err := h.jobService.GetPipeline(ctx, id, ec)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, fiber.Map{"stages": stages})
+}
+
+// ConfigurePipeline replaces the custom stages of a draft or pending-review job's pipeline
+func (h *JobHandler) ConfigurePipeline(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	id, err := utils.ParseIDParam(c, "id")
+	if err != nil || id <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	var req job.ConfigurePipelineRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+	for i := range req.Stages {
+		req.Stages[i].Name = utils.SanitizeString(req.Stages[i].Name)
+	}
+
+	ec, err := h.employerContextForJob(c, id)
+	if err != nil {
+		return err
+	}
+
+	stages, err := h.jobService.ConfigurePipeline(ctx, id, ec, &req)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, common.MsgUpdatedSuccess, fiber.Map{"stages": stages})
+}
@@ -0,0 +1,136 @@
+package jobhandler
+
+import (
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/dto/mapper"
+	"keerja-backend/internal/dto/response"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ListQuestions returns a job's screening questions. Knockout settings are only shown to
+// members of the job's company.
+func (h *JobHandler) ListQuestions(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	id, err := utils.ParseIDParam(c, "id")
+	if err != nil || id <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	j, err := h.jobService.GetJob(ctx, id)
+	if err != nil {
+		return err
+	}
+	questions, err := h.jobService.ListQuestions(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	toResponse := mapper.ToPublicJobQuestionResponse
+	if userID := middleware.GetUserID(c); userID != 0 {
+		if ok, _ := h.companyService.CheckEmployerPermission(ctx, userID, j.CompanyID, employer.RoleViewer); ok {
+			toResponse = mapper.ToJobQuestionResponse
+		}
+	}
+
+	resp := mapper.MapEntities[job.JobQuestion, response.JobQuestionResponse](questions, toResponse)
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, fiber.Map{"questions": resp})
+}
+
+// AddQuestion adds a screening question to a draft or pending-review job
+func (h *JobHandler) AddQuestion(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	id, err := utils.ParseIDParam(c, "id")
+	if err != nil || id <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	var req job.AddQuestionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+	req.QuestionText = utils.SanitizeString(req.QuestionText)
+
+	ec, err := h.employerContextForJob(c, id)
+	if err != nil {
+		return err
+	}
+
+	question, err := h.jobService.AddQuestion(ctx, id, ec, &req)
+	if err != nil {
+		return err
+	}
+
+	return utils.CreatedResponse(c, common.MsgCreatedSuccess, mapper.ToJobQuestionResponse(question))
+}
+
+// UpdateQuestion updates a screening question of a draft or pending-review job
+func (h *JobHandler) UpdateQuestion(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	id, err := utils.ParseIDParam(c, "id")
+	if err != nil || id <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+	questionID, err := utils.ParseIDParam(c, "questionId")
+	if err != nil || questionID <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	var req job.UpdateQuestionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+	req.QuestionText = utils.SanitizeIfNonEmpty(req.QuestionText)
+
+	ec, err := h.employerContextForJob(c, id)
+	if err != nil {
+		return err
+	}
+
+	question, err := h.jobService.UpdateQuestion(ctx, id, questionID, ec, &req)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, common.MsgUpdatedSuccess, mapper.ToJobQuestionResponse(question))
+}
+
+// DeleteQuestion removes a screening question from a draft or pending-review job
+func (h *JobHandler) DeleteQuestion(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	id, err := utils.ParseIDParam(c, "id")
+	if err != nil || id <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+	questionID, err := utils.ParseIDParam(c, "questionId")
+	if err != nil || questionID <= 0 {
+		return utils.BadRequestResponse(c, common.ErrInvalidID)
+	}
+
+	ec, err := h.employerContextForJob(c, id)
+	if err != nil {
+		return err
+	}
+
+	if err := h.jobService.DeleteQuestion(ctx, id, questionID, ec); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, common.MsgDeletedSuccess, nil)
+}
@@ -2,6 +2,7 @@ package jobhandler
 
 import (
 	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/job"
 	"keerja-backend/internal/dto/mapper"
 	"keerja-backend/internal/dto/request"
 	"keerja-backend/internal/dto/response"
@@ -13,14 +14,25 @@ import (
 )
 
 func (h *JobHandler) SearchJobs(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var q request.JobSearchRequest
 	if err := c.BodyParser(&q); err != nil {
 		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
 	}
+	// lat/lng/radius_km may also be sent as query parameters
+	var geo request.GeoSearchRequest
+	if err := c.QueryParser(&geo); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if geo.Lat != nil || geo.Lng != nil {
+		q.Lat, q.Lng = geo.Lat, geo.Lng
+	}
+	if geo.RadiusKm != nil {
+		q.RadiusKm = geo.RadiusKm
+	}
 	if err := utils.ValidateStruct(&q); err != nil {
-		errs := utils.FormatValidationErrors(err)
+		errs := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
 	}
 	q.Page, q.Limit = utils.ValidatePagination(q.Page, q.Limit, 100)
@@ -32,7 +44,7 @@ func (h *JobHandler) SearchJobs(c *fiber.Ctx) error {
 
 	result, err := h.jobService.SearchJobs(ctx, f, q.Page, q.Limit)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to search jobs", err.Error())
+		return err
 	}
 
 	// Collect unique company IDs for batch fetching
@@ -61,6 +73,44 @@ func (h *JobHandler) SearchJobs(c *fiber.Ctx) error {
 	}
 
 	meta := utils.GetPaginationMeta(result.Page, result.Limit, result.Total)
-	payload := response.JobListResponse{Jobs: respJobs}
+	payload := response.JobSearchResponse{
+		Jobs:   respJobs,
+		Facets: mapper.ToSearchFacetsResponse(result.Facets),
+	}
 	return utils.SuccessResponseWithMeta(c, common.MsgFetchedSuccess, payload, meta)
 }
+
+// GetFeaturedJobs lists the homepage's featured jobs: admin-curated jobs first, then the
+// jobs with the most recent views
+// GET /api/v1/jobs/featured?limit=10
+func (h *JobHandler) GetFeaturedJobs(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	_, limit, err := utils.ParsePagination(c, 10, job.MaxFeaturedJobs)
+	if err != nil {
+		return err
+	}
+
+	jobs, err := h.jobService.GetFeaturedJobs(ctx, limit)
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, response.JobListResponse{Jobs: h.toJobResponses(ctx, jobs)})
+}
+
+// GetTrendingJobs lists the jobs with the most views in the last two days
+// GET /api/v1/jobs/trending?limit=10
+func (h *JobHandler) GetTrendingJobs(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	_, limit, err := utils.ParsePagination(c, 10, job.MaxFeaturedJobs)
+	if err != nil {
+		return err
+	}
+
+	jobs, err := h.jobService.GetTrendingJobs(ctx, limit)
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, response.JobListResponse{Jobs: h.toJobResponses(ctx, jobs)})
+}
@@ -1,20 +1,18 @@
 package jobhandler
 
 import (
-	"strings"
 	"time"
 
+	"keerja-backend/internal/domain/job"
 	"keerja-backend/internal/dto/request"
 	"keerja-backend/internal/handler/http/common"
-	"keerja-backend/internal/middleware"
 	"keerja-backend/internal/utils"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 func (h *JobHandler) PublishJob(c *fiber.Ctx) error {
-	ctx := c.Context()
-	employerID := middleware.GetUserID(c)
+	ctx := c.UserContext()
 
 	id, err := utils.ParseIDParam(c, "id")
 	if err != nil || id <= 0 {
@@ -24,7 +22,7 @@ func (h *JobHandler) PublishJob(c *fiber.Ctx) error {
 	var req request.PublishJobRequest
 	_ = c.BodyParser(&req)
 	if err := utils.ValidateStruct(&req); err != nil {
-		errs := utils.FormatValidationErrors(err)
+		errs := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
 	}
 
@@ -42,22 +40,13 @@ func (h *JobHandler) PublishJob(c *fiber.Ctx) error {
 		}
 	}
 
-	if err := h.jobService.PublishJob(ctx, id, employerID, expiredAtPtr); err != nil {
-		errMsg := err.Error()
-
-		if errMsg == "company is not verified yet" {
-			return utils.ForbiddenResponse(c, common.ErrCompanyNotVerified)
-		}
-
-		if errMsg == "job not found: record not found" || errMsg == "not authorized to manage this job" {
-			return utils.NotFoundResponse(c, common.ErrJobNotFound)
-		}
-
-		if errMsg == "job is already pending review" || errMsg == "job is already published" {
-			return utils.ErrorResponse(c, fiber.StatusConflict, common.ErrConflict, errMsg)
-		}
+	ec, err := h.employerContextForJob(c, id)
+	if err != nil {
+		return err
+	}
 
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, common.ErrInternalServer, err.Error())
+	if err := h.jobService.PublishJob(ctx, id, ec, expiredAtPtr); err != nil {
+		return err
 	}
 
 	jobObj, err := h.jobService.GetJob(ctx, id)
@@ -66,14 +55,14 @@ func (h *JobHandler) PublishJob(c *fiber.Ctx) error {
 	}
 
 	switch jobObj.Status {
-	case "published":
+	case job.StatusPublished:
 		return utils.SuccessResponse(c, "Job published successfully", fiber.Map{
-			"status":  "published",
+			"status":  job.StatusPublished,
 			"message": "Your job is now live",
 		})
-	case "pending_review", "in_review":
+	case job.StatusPendingReview, job.StatusInReview:
 		return utils.SuccessResponse(c, "Job submitted for review successfully", fiber.Map{
-			"status":  "pending_review",
+			"status":  job.StatusPendingReview,
 			"message": "Your job has been submitted and is waiting for admin approval",
 		})
 	default:
@@ -82,8 +71,7 @@ func (h *JobHandler) PublishJob(c *fiber.Ctx) error {
 }
 
 func (h *JobHandler) CloseJob(c *fiber.Ctx) error {
-	ctx := c.Context()
-	employerID := middleware.GetUserID(c)
+	ctx := c.UserContext()
 
 	id, err := utils.ParseIDParam(c, "id")
 	if err != nil || id <= 0 {
@@ -93,37 +81,36 @@ func (h *JobHandler) CloseJob(c *fiber.Ctx) error {
 	var req request.CloseJobRequest
 	_ = c.BodyParser(&req)
 	if err := utils.ValidateStruct(&req); err != nil {
-		errs := utils.FormatValidationErrors(err)
+		errs := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
 	}
 
-	if err := h.jobService.CloseJob(ctx, id, employerID); err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, common.ErrInternalServer, err.Error())
+	ec, err := h.employerContextForJob(c, id)
+	if err != nil {
+		return err
+	}
+
+	if err := h.jobService.CloseJob(ctx, id, ec); err != nil {
+		return err
 	}
 	return utils.SuccessResponse(c, common.MsgUpdatedSuccess, fiber.Map{"closed": true})
 }
 
 func (h *JobHandler) InactivateJob(c *fiber.Ctx) error {
-	ctx := c.Context()
-	employerID := middleware.GetUserID(c)
+	ctx := c.UserContext()
 
 	id, err := utils.ParseIDParam(c, "id")
 	if err != nil || id <= 0 {
 		return utils.BadRequestResponse(c, common.ErrInvalidID)
 	}
 
-	if employerID == 0 {
-		return utils.ErrorResponse(c, fiber.StatusUnauthorized, common.ErrUnauthorized, "userID not found in context")
+	ec, err := h.employerContextForJob(c, id)
+	if err != nil {
+		return err
 	}
 
-	if err := h.jobService.InactivateJob(ctx, id, employerID); err != nil {
-		if err.Error() == "you do not have permission to modify this job" {
-			return utils.ForbiddenResponse(c, common.ErrNotJobOwner)
-		}
-		if strings.Contains(err.Error(), "job not found") {
-			return utils.NotFoundResponse(c, common.ErrJobNotFound)
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, common.ErrInternalServer, err.Error())
+	if err := h.jobService.InactivateJob(ctx, id, ec); err != nil {
+		return err
 	}
 
 	return utils.SuccessResponse(c, common.MsgOperationSuccess, fiber.Map{"id": id})
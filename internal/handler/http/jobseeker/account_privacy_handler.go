@@ -0,0 +1,84 @@
+package userhandler
+
+import (
+	"fmt"
+
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/handler/http/common"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AccountPrivacyHandler handles account deletion and personal data export requests
+type AccountPrivacyHandler struct {
+	privacyService user.AccountPrivacyService
+}
+
+// NewAccountPrivacyHandler creates a new instance of AccountPrivacyHandler
+func NewAccountPrivacyHandler(privacyService user.AccountPrivacyService) *AccountPrivacyHandler {
+	return &AccountPrivacyHandler{
+		privacyService: privacyService,
+	}
+}
+
+// RequestDeletion schedules the user's account for deletion after the grace period
+func (h *AccountPrivacyHandler) RequestDeletion(c *fiber.Ctx) error {
+	req, err := h.privacyService.RequestDeletion(middleware.AuditContext(c), middleware.GetUserID(c))
+	if err != nil {
+		return err
+	}
+
+	return utils.CreatedResponse(c, "Account deletion scheduled", req)
+}
+
+// GetDeletionRequest returns the user's pending deletion request
+func (h *AccountPrivacyHandler) GetDeletionRequest(c *fiber.Ctx) error {
+	req, err := h.privacyService.GetPendingDeletion(c.UserContext(), middleware.GetUserID(c))
+	if err != nil {
+		return err
+	}
+	if req == nil {
+		return user.ErrNoPendingDeletion
+	}
+
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, req)
+}
+
+// CancelDeletion cancels the user's pending deletion request
+func (h *AccountPrivacyHandler) CancelDeletion(c *fiber.Ctx) error {
+	if err := h.privacyService.CancelDeletion(middleware.AuditContext(c), middleware.GetUserID(c)); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, "Account deletion canceled", nil)
+}
+
+// RequestDataExport queues a personal data export. The download link is emailed once the
+// export is ready.
+func (h *AccountPrivacyHandler) RequestDataExport(c *fiber.Ctx) error {
+	export, err := h.privacyService.RequestDataExport(middleware.AuditContext(c), middleware.GetUserID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(utils.Response{
+		Success: true,
+		Message: "Data export requested; the download link will be emailed when it is ready",
+		Data:    export,
+	})
+}
+
+// DownloadDataExport sends the export file for the download token in the emailed link
+func (h *AccountPrivacyHandler) DownloadDataExport(c *fiber.Ctx) error {
+	export, err := h.privacyService.DownloadDataExport(middleware.AuditContext(c), c.Params("token"))
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	c.Attachment(fmt.Sprintf("keerja-data-export-%d.json", export.ID))
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+	return c.Send(export.Data)
+}
@@ -3,9 +3,12 @@ package userhandler
 import (
 	"keerja-backend/internal/domain/user"
 	"keerja-backend/internal/dto/mapper"
+	"keerja-backend/internal/dto/response"
 	"keerja-backend/internal/handler/http/common"
+	uploadhandler "keerja-backend/internal/handler/http/upload"
 	"keerja-backend/internal/helpers"
 	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/service"
 	"keerja-backend/internal/utils"
 
 	"github.com/gofiber/fiber/v2"
@@ -14,27 +17,39 @@ import (
 // UserDocumentHandler handles user document operations
 type UserDocumentHandler struct {
 	userService user.UserService
+	signer      *service.DownloadSigner
 }
 
 // NewUserDocumentHandler creates a new instance of UserDocumentHandler
-func NewUserDocumentHandler(userService user.UserService) *UserDocumentHandler {
+func NewUserDocumentHandler(userService user.UserService, signer *service.DownloadSigner) *UserDocumentHandler {
 	return &UserDocumentHandler{
 		userService: userService,
+		signer:      signer,
 	}
 }
 
+// toDocumentResponse maps a document and swaps its private path for a short-lived signed link
+func (h *UserDocumentHandler) toDocumentResponse(c *fiber.Ctx, doc *user.UserDocument) *response.UserDocumentResponse {
+	resp := mapper.ToUserDocumentResponse(doc)
+	fileName := uploadhandler.DownloadFileName(doc.DocumentName, doc.FileURL)
+	resp.FileURL, _ = h.signer.URL(c.BaseURL(), doc.FileURL, fileName)
+	return resp
+}
+
 func (h *UserDocumentHandler) GetDocuments(c *fiber.Ctx) error {
 	usr, err := helpers.GetProfile(c, h.userService)
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, common.ErrFailedOperation, err.Error())
 	}
 
-	documents := mapper.MapEntities(usr.Documents, mapper.ToUserDocumentResponse)
+	documents := mapper.MapEntities(usr.Documents, func(d *user.UserDocument) *response.UserDocumentResponse {
+		return h.toDocumentResponse(c, d)
+	})
 	return utils.SuccessResponse(c, common.MsgOperationSuccess, documents)
 }
 
 func (h *UserDocumentHandler) UploadDocument(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	file := middleware.GetUploadedFile(c)
@@ -75,5 +90,15 @@ func (h *UserDocumentHandler) UploadDocument(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to upload document", err.Error())
 	}
 
-	return utils.CreatedResponse(c, "Document uploaded successfully", document)
+	resp := response.UserDocumentUploadResponse{UserDocumentResponse: *h.toDocumentResponse(c, document)}
+
+	// Resume parsing is best-effort: the upload already succeeded, so a parse failure
+	// only means the client gets no suggestions to confirm
+	if documentType == "resume" {
+		if parsed, err := h.userService.ParseResume(ctx, file); err == nil {
+			resp.ParsedResume = mapper.ToParsedResumeResponse(parsed)
+		}
+	}
+
+	return utils.CreatedResponse(c, "Document uploaded successfully", resp)
 }
@@ -35,7 +35,7 @@ func (h *UserEducationHandler) GetEducations(c *fiber.Ctx) error {
 }
 
 func (h *UserEducationHandler) AddEducation(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	var req request.AddEducationRequest
@@ -44,7 +44,7 @@ func (h *UserEducationHandler) AddEducation(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errors)
 	}
 
@@ -76,7 +76,7 @@ func (h *UserEducationHandler) AddEducation(c *fiber.Ctx) error {
 }
 
 func (h *UserEducationHandler) UpdateEducation(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	educationID, err := utils.ParseIDParam(c, "id")
@@ -90,7 +90,7 @@ func (h *UserEducationHandler) UpdateEducation(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errors)
 	}
 
@@ -120,7 +120,7 @@ func (h *UserEducationHandler) UpdateEducation(c *fiber.Ctx) error {
 }
 
 func (h *UserEducationHandler) DeleteEducation(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	educationID, err := utils.ParseIDParam(c, "id")
@@ -35,7 +35,7 @@ func (h *UserExperienceHandler) GetExperiences(c *fiber.Ctx) error {
 }
 
 func (h *UserExperienceHandler) AddExperience(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	var req request.AddExperienceRequest
@@ -44,7 +44,7 @@ func (h *UserExperienceHandler) AddExperience(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
@@ -81,7 +81,7 @@ func (h *UserExperienceHandler) AddExperience(c *fiber.Ctx) error {
 }
 
 func (h *UserExperienceHandler) UpdateExperience(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	experienceID, err := utils.ParseIDParam(c, "id")
@@ -95,7 +95,7 @@ func (h *UserExperienceHandler) UpdateExperience(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
@@ -130,7 +130,7 @@ func (h *UserExperienceHandler) UpdateExperience(c *fiber.Ctx) error {
 }
 
 func (h *UserExperienceHandler) DeleteExperience(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	experienceID, err := utils.ParseIDParam(c, "id")
@@ -2,7 +2,9 @@ package userhandler
 
 import (
 	"strings"
+	"time"
 
+	"keerja-backend/internal/apperror"
 	"keerja-backend/internal/domain/user"
 	"keerja-backend/internal/dto/mapper"
 	"keerja-backend/internal/dto/request"
@@ -15,18 +17,21 @@ import (
 
 // UserProfileHandler handles user profile operations
 type UserProfileHandler struct {
-	userService user.UserService
+	userService  user.UserService
+	verification user.EmailVerificationPolicy
 }
 
-// NewUserProfileHandler creates a new instance of UserProfileHandler
-func NewUserProfileHandler(userService user.UserService) *UserProfileHandler {
+// NewUserProfileHandler creates a new instance of UserProfileHandler. verification is the
+// policy used to report the email verification state on the profile.
+func NewUserProfileHandler(userService user.UserService, verification user.EmailVerificationPolicy) *UserProfileHandler {
 	return &UserProfileHandler{
-		userService: userService,
+		userService:  userService,
+		verification: verification,
 	}
 }
 
 func (h *UserProfileHandler) GetProfile(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	includeParam := c.Query("include", "")
@@ -37,25 +42,46 @@ func (h *UserProfileHandler) GetProfile(c *fiber.Ctx) error {
 	}
 
 	var response any
+	verification := mapper.ToEmailVerificationResponse(h.verification.State(usr, time.Now()))
 
 	switch includeParam {
 	case "":
-		response = mapper.ToUserResponse(usr)
+		resp := mapper.ToUserResponse(usr)
+		resp.EmailVerification = verification
+		response = resp
 	case "all":
-		response = mapper.ToUserDetailResponse(usr)
+		resp := mapper.ToUserDetailResponse(usr)
+		resp.EmailVerification = verification
+		response = resp
 	default:
 		includes := strings.Split(includeParam, ",")
 		for i := range includes {
 			includes[i] = strings.TrimSpace(includes[i])
 		}
-		response = mapper.ToUserResponseWithIncludes(usr, includes)
+		resp := mapper.ToUserResponseWithIncludes(usr, includes)
+		resp.EmailVerification = verification
+		response = resp
 	}
 
 	return utils.SuccessResponse(c, "Profile retrieved successfully", response)
 }
 
+// GetProfileCompleteness returns the weighted profile completeness checklist with next actions
+// GET /api/v1/jobseeker/profile/completeness
+func (h *UserProfileHandler) GetProfileCompleteness(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := middleware.GetUserID(c)
+
+	completeness, err := h.userService.GetProfileCompleteness(ctx, userID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get profile completeness", err.Error())
+	}
+
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, mapper.ToProfileCompletenessResponse(completeness))
+}
+
 func (h *UserProfileHandler) UpdateProfile(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	var req request.UpdateProfileRequest
@@ -64,7 +90,7 @@ func (h *UserProfileHandler) UpdateProfile(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errors)
 	}
 
@@ -101,6 +127,9 @@ func (h *UserProfileHandler) UpdateProfile(c *fiber.Ctx) error {
 	}
 
 	if err := h.userService.UpdateProfile(ctx, userID, domainReq); err != nil {
+		if _, ok := apperror.As(err); ok {
+			return err
+		}
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, common.ErrFailedOperation, err.Error())
 	}
 
@@ -108,7 +137,7 @@ func (h *UserProfileHandler) UpdateProfile(c *fiber.Ctx) error {
 }
 
 func (h *UserProfileHandler) GetPreferences(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	prefs, err := h.userService.GetPreferences(ctx, userID)
@@ -121,7 +150,7 @@ func (h *UserProfileHandler) GetPreferences(c *fiber.Ctx) error {
 }
 
 func (h *UserProfileHandler) UpdatePreferences(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	var req request.UpdateUserPreferencesRequest
@@ -130,7 +159,7 @@ func (h *UserProfileHandler) UpdatePreferences(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errors)
 	}
 
@@ -157,6 +186,7 @@ func (h *UserProfileHandler) UpdatePreferences(c *fiber.Ctx) error {
 		ShowOnlineStatus:    req.ShowOnlineStatus,
 		AllowDirectMessages: req.AllowDirectMessages,
 		DataSharingConsent:  req.DataSharingConsent,
+		AllowTalentPools:    req.AllowTalentPools,
 	}
 
 	if err := h.userService.UpdatePreferences(ctx, userID, domainReq); err != nil {
@@ -172,8 +202,53 @@ func (h *UserProfileHandler) UpdatePreferences(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, common.MsgOperationSuccess, resp)
 }
 
+// GetNotificationSettings returns the user's email and push channels per notification event and quiet hours
+// GET /api/v1/jobseeker/preferences/notifications
+func (h *UserProfileHandler) GetNotificationSettings(c *fiber.Ctx) error {
+	prefs, err := h.userService.GetNotificationSettings(c.UserContext(), middleware.GetUserID(c))
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, common.MsgFetchedSuccess, mapper.ToNotificationSettingsResponse(prefs))
+}
+
+// UpdateNotificationSettings changes the user's channels per notification event and quiet hours
+// PUT /api/v1/jobseeker/preferences/notifications
+func (h *UserProfileHandler) UpdateNotificationSettings(c *fiber.Ctx) error {
+	var req request.UpdateNotificationSettingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, common.ErrInvalidRequest)
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errs := utils.FormatValidationErrors(c, err)
+		return utils.ValidationErrorResponse(c, common.ErrValidationFailed, errs)
+	}
+
+	domainReq := &user.UpdateNotificationSettingsRequest{
+		EmailNotifications: req.EmailNotifications,
+		PushNotifications:  req.PushNotifications,
+		QuietHoursStart:    req.QuietHoursStart,
+		QuietHoursEnd:      req.QuietHoursEnd,
+		QuietHoursTimezone: req.QuietHoursTimezone,
+	}
+	if len(req.Events) > 0 {
+		domainReq.Events = make(map[user.NotificationEvent]user.UpdateNotificationChannels, len(req.Events))
+		for event, channels := range req.Events {
+			domainReq.Events[user.NotificationEvent(event)] = user.UpdateNotificationChannels{Email: channels.Email, Push: channels.Push, WhatsApp: channels.WhatsApp}
+		}
+	}
+
+	prefs, err := h.userService.UpdateNotificationSettings(c.UserContext(), middleware.GetUserID(c), domainReq)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, common.MsgUpdatedSuccess, mapper.ToNotificationSettingsResponse(prefs))
+}
+
 func (h *UserProfileHandler) UploadProfilePhoto(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	if userID == 0 {
@@ -187,6 +262,9 @@ func (h *UserProfileHandler) UploadProfilePhoto(c *fiber.Ctx) error {
 
 	avatarURL, err := h.userService.UploadAvatar(ctx, userID, file)
 	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return err
+		}
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to upload profile photo", err.Error())
 	}
 
@@ -37,7 +37,7 @@ func (h *UserSkillHandler) GetSkills(c *fiber.Ctx) error {
 }
 
 func (h *UserSkillHandler) AddSkills(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	var req request.AddUserSkillsRequest
@@ -46,7 +46,7 @@ func (h *UserSkillHandler) AddSkills(c *fiber.Ctx) error {
 	}
 
 	if err := utils.ValidateStruct(&req); err != nil {
-		errors := utils.FormatValidationErrors(err)
+		errors := utils.FormatValidationErrors(c, err)
 		return utils.ValidationErrorResponse(c, "Validation failed", errors)
 	}
 
@@ -94,7 +94,7 @@ func (h *UserSkillHandler) AddSkills(c *fiber.Ctx) error {
 }
 
 func (h *UserSkillHandler) DeleteSkill(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	skillID, err := utils.ParseIDParam(c, "id")
@@ -23,7 +23,7 @@ func NewCompanySizeHandler(service master.CompanySizeService) *CompanySizeHandle
 }
 
 func (h *CompanySizeHandler) GetAllCompanySizes(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	// Parse query parameters
 	activeParam := strings.TrimSpace(c.Query("active"))
@@ -48,7 +48,7 @@ func (h *CompanySizeHandler) GetAllCompanySizes(c *fiber.Ctx) error {
 }
 
 func (h *CompanySizeHandler) GetCompanySizeByID(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	// Parse ID from path parameter
 	idParam := c.Params("id")
@@ -22,7 +22,7 @@ func NewIndustryHandler(service master.IndustryService) *IndustryHandler {
 }
 
 func (h *IndustryHandler) GetAllIndustries(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	// Parse query parameters
 	activeParam := strings.TrimSpace(c.Query("active"))
@@ -48,7 +48,7 @@ func (h *IndustryHandler) GetAllIndustries(c *fiber.Ctx) error {
 }
 
 func (h *IndustryHandler) GetIndustryByID(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	// Parse ID from path parameter
 	id, err := utils.ParseIDParam(c, "id")
@@ -34,7 +34,7 @@ func NewLocationHandler(
 // ========================================
 
 func (h *LocationHandler) GetAllProvinces(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	// Parse query parameters
 	activeParam := strings.TrimSpace(c.Query("active"))
@@ -60,7 +60,7 @@ func (h *LocationHandler) GetAllProvinces(c *fiber.Ctx) error {
 }
 
 func (h *LocationHandler) GetProvinceByID(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	// Parse ID from path parameter
 	id, err := utils.ParseIDParam(c, "id")
@@ -86,7 +86,7 @@ func (h *LocationHandler) GetProvinceByID(c *fiber.Ctx) error {
 // ========================================
 
 func (h *LocationHandler) GetCities(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	// Parse query parameters
 	provinceID := int64(c.QueryInt("province_id", 0))
@@ -122,7 +122,7 @@ func (h *LocationHandler) GetCities(c *fiber.Ctx) error {
 }
 
 func (h *LocationHandler) GetCityByID(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	// Parse ID from path parameter
 	id, err := utils.ParseIDParam(c, "id")
@@ -148,7 +148,7 @@ func (h *LocationHandler) GetCityByID(c *fiber.Ctx) error {
 // ========================================
 
 func (h *LocationHandler) GetDistricts(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	// Parse query parameters
 	cityID := int64(c.QueryInt("city_id", 0))
@@ -184,7 +184,7 @@ func (h *LocationHandler) GetDistricts(c *fiber.Ctx) error {
 }
 
 func (h *LocationHandler) GetDistrictByID(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	// Parse ID from path parameter
 	id, err := utils.ParseIDParam(c, "id")
@@ -47,7 +47,7 @@ func (h *MasterDataHandler) GetJobTitles(c *fiber.Ctx) error {
 		limit = 20
 	}
 
-	jobTitles, err := h.jobTitleService.SearchJobTitles(c.Context(), query, limit)
+	jobTitles, err := h.jobTitleService.SearchJobTitles(c.UserContext(), query, limit)
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, "Failed to retrieve job titles")
 	}
@@ -63,7 +63,7 @@ func (h *MasterDataHandler) GetJobTitles(c *fiber.Ctx) error {
 // Query params:
 //   - q: search keyword for category name (optional)
 func (h *MasterDataHandler) GetJobDetailsOptions(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	query := c.Query("q", "")
 
 	var categories []job.JobCategory
@@ -100,7 +100,7 @@ func (h *MasterDataHandler) GetJobDetailsOptions(c *fiber.Ctx) error {
 // GET /api/v1/master/job-form/job-type
 // Requires auth for company addresses
 func (h *MasterDataHandler) GetJobTypeOptions(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	// Get job types
 	jobTypes, err := h.jobOptionsService.GetJobTypes(ctx)
@@ -179,7 +179,7 @@ func (h *MasterDataHandler) GetJobTypeOptions(c *fiber.Ctx) error {
 // Returns: gender preferences, skills (paginated), education levels, experience levels
 // GET /api/v1/master/job-form/job-requirements
 func (h *MasterDataHandler) GetJobRequirementsOptions(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	// Get all job options
 	options, err := h.jobOptionsService.GetJobOptions(ctx)
@@ -188,12 +188,12 @@ func (h *MasterDataHandler) GetJobRequirementsOptions(c *fiber.Ctx) error {
 	}
 
 	// Get skills with pagination
-	skillsPage := c.QueryInt("skills_page", 1)
-	skillsLimit := c.QueryInt("skills_limit", 50)
+	skillsPage, skillsLimit, err := utils.PageQuery{PageParam: "skills_page", LimitParam: "skills_limit", DefaultLimit: 50}.Parse(c)
+	if err != nil {
+		return err
+	}
 	skillsQuery := c.Query("skills_q", "")
 
-	skillsPage, skillsLimit = utils.ValidatePagination(skillsPage, skillsLimit, 100)
-
 	filter := &master.SkillsFilter{
 		Search:   skillsQuery,
 		IsActive: nil,
@@ -264,7 +264,7 @@ func (h *MasterDataHandler) GetJobDescriptionOptions(c *fiber.Ctx) error {
 
 // GetJobOptions returns all job options (legacy endpoint)
 func (h *MasterDataHandler) GetJobOptions(c *fiber.Ctx) error {
-	options, err := h.jobOptionsService.GetJobOptions(c.Context())
+	options, err := h.jobOptionsService.GetJobOptions(c.UserContext())
 	if err != nil {
 		return utils.InternalServerErrorResponse(c, "Failed to retrieve job options")
 	}
@@ -275,12 +275,12 @@ func (h *MasterDataHandler) GetJobOptions(c *fiber.Ctx) error {
 // GetSkillsPaginated returns paginated skills with optional search
 // GET /api/v1/master/skills?q=search&page=1&limit=50
 func (h *MasterDataHandler) GetSkillsPaginated(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	query := c.Query("q", "")
-	page := c.QueryInt("page", 1)
-	limit := c.QueryInt("limit", 50)
-
-	page, limit = utils.ValidatePagination(page, limit, 100)
+	page, limit, err := utils.ParsePagination(c, 50, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
 
 	filter := &master.SkillsFilter{
 		Search:   query,
@@ -305,6 +305,26 @@ func (h *MasterDataHandler) GetSkillsPaginated(c *fiber.Ctx) error {
 	}, meta)
 }
 
+// SuggestSkills returns skills for autocomplete, matching names and aliases by prefix or
+// similarity. Aliases resolve to their canonical skill.
+// GET /api/v1/master/skills/suggest?q=gola&limit=10
+func (h *MasterDataHandler) SuggestSkills(c *fiber.Ctx) error {
+	query := c.Query("q", "")
+	limit, err := strconv.Atoi(c.Query("limit", "10"))
+	if err != nil || limit <= 0 {
+		limit = 10
+	}
+
+	skills, err := h.skillsService.SuggestSkills(c.UserContext(), query, limit)
+	if err != nil {
+		return utils.InternalServerErrorResponse(c, "Failed to suggest skills")
+	}
+
+	return utils.SuccessResponse(c, "Skills retrieved successfully", fiber.Map{
+		"skills": skills,
+	})
+}
+
 // ==================== ADMIN ENDPOINTS ====================
 
 func (h *MasterDataHandler) CreateJobTitle(c *fiber.Ctx) error {
@@ -313,7 +333,7 @@ func (h *MasterDataHandler) CreateJobTitle(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, "Invalid request body")
 	}
 
-	jobTitle, err := h.jobTitleService.CreateJobTitle(c.Context(), &req)
+	jobTitle, err := h.jobTitleService.CreateJobTitle(c.UserContext(), &req)
 	if err != nil {
 		if err.Error() == "job title with this name already exists" {
 			return utils.ConflictResponse(c, err.Error())
@@ -335,7 +355,7 @@ func (h *MasterDataHandler) UpdateJobTitle(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, "Invalid request body")
 	}
 
-	jobTitle, err := h.jobTitleService.UpdateJobTitle(c.Context(), id, &req)
+	jobTitle, err := h.jobTitleService.UpdateJobTitle(c.UserContext(), id, &req)
 	if err != nil {
 		if err.Error() == "job title not found" {
 			return utils.NotFoundResponse(c, err.Error())
@@ -355,7 +375,7 @@ func (h *MasterDataHandler) DeleteJobTitle(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, "Invalid job title ID")
 	}
 
-	if err := h.jobTitleService.DeleteJobTitle(c.Context(), id); err != nil {
+	if err := h.jobTitleService.DeleteJobTitle(c.UserContext(), id); err != nil {
 		if err.Error() == "job title not found" {
 			return utils.NotFoundResponse(c, err.Error())
 		}
@@ -371,7 +391,7 @@ func (h *MasterDataHandler) GetJobTitleByID(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, "Invalid job title ID")
 	}
 
-	jobTitle, err := h.jobTitleService.GetJobTitle(c.Context(), id)
+	jobTitle, err := h.jobTitleService.GetJobTitle(c.UserContext(), id)
 	if err != nil {
 		if err.Error() == "job title not found" {
 			return utils.NotFoundResponse(c, err.Error())
@@ -9,6 +9,9 @@ import (
 	"keerja-backend/internal/utils"
 )
 
+// skillsPageQuery reads the page and page_size parameters of the skills list endpoints
+var skillsPageQuery = utils.PageQuery{LimitParam: "page_size", DefaultLimit: 20}
+
 type SkillsMasterHandler struct {
 	service master.SkillsMasterService
 }
@@ -20,7 +23,7 @@ func NewSkillsMasterHandler(service master.SkillsMasterService) *SkillsMasterHan
 }
 
 func (h *SkillsMasterHandler) GetAllSkills(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	filter := &master.SkillsFilter{
 		Search:          c.Query("search", ""),
@@ -32,8 +35,11 @@ func (h *SkillsMasterHandler) GetAllSkills(c *fiber.Ctx) error {
 		SortOrder:       c.Query("sort_order", "ASC"),
 	}
 
-	filter.Page = c.QueryInt("page", 1)
-	filter.PageSize = c.QueryInt("page_size", 20)
+	page, pageSize, err := skillsPageQuery.Parse(c)
+	if err != nil {
+		return err
+	}
+	filter.Page, filter.PageSize = page, pageSize
 
 	if v := c.QueryInt("category_id", 0); v > 0 {
 		cid := int64(v)
@@ -73,7 +79,7 @@ func (h *SkillsMasterHandler) GetAllSkills(c *fiber.Ctx) error {
 }
 
 func (h *SkillsMasterHandler) SearchSkills(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	query := c.Query("q", "")
 	if query == "" {
@@ -83,8 +89,10 @@ func (h *SkillsMasterHandler) SearchSkills(c *fiber.Ctx) error {
 		)
 	}
 
-	page := c.QueryInt("page", 1)
-	pageSize := c.QueryInt("page_size", 20)
+	page, pageSize, err := skillsPageQuery.Parse(c)
+	if err != nil {
+		return err
+	}
 
 	result, err := h.service.SearchSkills(ctx, query, page, pageSize)
 	if err != nil {
@@ -101,7 +109,7 @@ func (h *SkillsMasterHandler) SearchSkills(c *fiber.Ctx) error {
 }
 
 func (h *SkillsMasterHandler) GetSkillsByType(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	skillType := c.Params("type")
 	if skillType == "" {
@@ -144,7 +152,7 @@ func (h *SkillsMasterHandler) GetSkillsByType(c *fiber.Ctx) error {
 }
 
 func (h *SkillsMasterHandler) GetSkillByID(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	id, err := utils.ParseIDParam(c, "id")
 	if err != nil {
@@ -169,7 +177,7 @@ func (h *SkillsMasterHandler) GetSkillByID(c *fiber.Ctx) error {
 }
 
 func (h *SkillsMasterHandler) GetSkillsByIDs(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var req struct {
 		IDs []int64 `json:"ids" validate:"required,min=1"`
@@ -214,10 +222,13 @@ func (h *SkillsMasterHandler) GetSkillsByIDs(c *fiber.Ctx) error {
 }
 
 func (h *SkillsMasterHandler) GetRecommendedSkills(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	skillType := c.Query("skill_type", "")
-	limit := c.QueryInt("limit", 10)
+	_, limit, err := utils.ParsePagination(c, 10, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
 
 	filter := &master.SkillsFilter{
 		SkillType: skillType,
@@ -16,23 +16,26 @@ import (
 type DeviceTokenHandler struct {
 	deviceTokenRepo notification.DeviceTokenRepository
 	pushService     notification.PushNotificationService
+	topicService    notification.PushTopicService
 	logger          *logrus.Logger
 }
 
 func NewDeviceTokenHandler(
 	deviceTokenRepo notification.DeviceTokenRepository,
 	pushService notification.PushNotificationService,
+	topicService notification.PushTopicService,
 	logger *logrus.Logger,
 ) *DeviceTokenHandler {
 	return &DeviceTokenHandler{
 		deviceTokenRepo: deviceTokenRepo,
 		pushService:     pushService,
+		topicService:    topicService,
 		logger:          logger,
 	}
 }
 
 func (h *DeviceTokenHandler) RegisterDeviceToken(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	var req request.RegisterDeviceTokenRequest
@@ -67,21 +70,26 @@ func (h *DeviceTokenHandler) RegisterDeviceToken(c *fiber.Ctx) error {
 				return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update device token", err.Error())
 			}
 
+			h.syncTopics(c, userID)
+
 			response := mapper.ToDeviceTokenResponse(existingToken)
 			h.logger.WithField("user_id", userID).Info("Device token updated successfully")
 			return utils.SuccessResponse(c, "Device token updated successfully", response)
 		}
 
-		existingToken.Deactivate()
-		_ = h.deviceTokenRepo.Update(ctx, existingToken)
+		// The token is moving to this user, so it must stop receiving the previous owner's topics
+		h.unsubscribeTopics(c, existingToken)
 	}
 
-	deviceToken := mapper.ToDeviceToken(userID, &req)
-	if err := h.deviceTokenRepo.Create(ctx, deviceToken); err != nil {
-		h.logger.WithError(err).Error("Failed to create device token")
+	requested := mapper.ToDeviceToken(userID, &req)
+	deviceToken, err := h.pushService.RegisterDeviceToken(ctx, userID, requested.Token, requested.Platform, &requested.DeviceInfo)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to register device token")
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to register device token", err.Error())
 	}
 
+	h.syncTopics(c, userID)
+
 	response := mapper.ToDeviceTokenResponse(deviceToken)
 	h.logger.WithFields(logrus.Fields{
 		"user_id":  userID,
@@ -92,7 +100,7 @@ func (h *DeviceTokenHandler) RegisterDeviceToken(c *fiber.Ctx) error {
 }
 
 func (h *DeviceTokenHandler) UnregisterDeviceToken(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 	token := c.Params("token")
 
@@ -114,6 +122,8 @@ func (h *DeviceTokenHandler) UnregisterDeviceToken(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusForbidden, "You don't have permission to delete this token", "")
 	}
 
+	h.unsubscribeTopics(c, deviceToken)
+
 	if err := h.deviceTokenRepo.DeleteByToken(ctx, token); err != nil {
 		h.logger.WithError(err).Error("Failed to delete device token")
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to unregister device token", err.Error())
@@ -128,7 +138,7 @@ func (h *DeviceTokenHandler) UnregisterDeviceToken(c *fiber.Ctx) error {
 }
 
 func (h *DeviceTokenHandler) GetUserDevices(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	page := c.QueryInt("page", 1)
@@ -165,7 +175,7 @@ func (h *DeviceTokenHandler) GetUserDevices(c *fiber.Ctx) error {
 }
 
 func (h *DeviceTokenHandler) ValidateDeviceToken(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	var req request.ValidateDeviceTokenRequest
@@ -219,7 +229,7 @@ func (h *DeviceTokenHandler) ValidateDeviceToken(c *fiber.Ctx) error {
 }
 
 func (h *DeviceTokenHandler) GetDeviceTokenStats(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	tokens, err := h.deviceTokenRepo.FindByUser(ctx, userID)
@@ -255,7 +265,7 @@ func (h *DeviceTokenHandler) GetDeviceTokenStats(c *fiber.Ctx) error {
 }
 
 func (h *DeviceTokenHandler) GetDeviceTokenByID(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
@@ -280,3 +290,19 @@ func (h *DeviceTokenHandler) GetDeviceTokenByID(c *fiber.Ctx) error {
 	response := mapper.ToDeviceTokenResponse(deviceToken)
 	return utils.SuccessResponse(c, "Device token retrieved successfully", response)
 }
+
+// syncTopics subscribes the user's devices to their role and province topics.
+// Failures are logged only; the next sync retries them.
+func (h *DeviceTokenHandler) syncTopics(c *fiber.Ctx, userID int64) {
+	if err := h.topicService.SyncUserTopics(c.UserContext(), userID); err != nil {
+		h.logger.WithError(err).WithField("user_id", userID).Warn("Failed to sync push topics")
+	}
+}
+
+// unsubscribeTopics removes a device token from all of its topics before it stops
+// belonging to the user
+func (h *DeviceTokenHandler) unsubscribeTopics(c *fiber.Ctx, token *notification.DeviceToken) {
+	if err := h.topicService.UnsubscribeToken(c.UserContext(), token); err != nil {
+		h.logger.WithError(err).WithField("user_id", token.UserID).Warn("Failed to unsubscribe device token from push topics")
+	}
+}
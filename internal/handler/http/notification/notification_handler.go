@@ -21,11 +21,13 @@ func NewNotificationHandler(notifService notification.NotificationService) *Noti
 }
 
 func (h *NotificationHandler) GetNotifications(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
-	page := c.QueryInt("page", 1)
-	limit := c.QueryInt("limit", 20)
+	page, limit, err := utils.ParsePagination(c, 20, utils.MaxPageLimit)
+	if err != nil {
+		return err
+	}
 
 	var filterReq request.NotificationFilterRequest
 	if err := c.QueryParser(&filterReq); err != nil {
@@ -44,7 +46,7 @@ func (h *NotificationHandler) GetNotifications(c *fiber.Ctx) error {
 }
 
 func (h *NotificationHandler) GetUnreadNotifications(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	limit := c.QueryInt("limit", 50)
@@ -64,7 +66,7 @@ func (h *NotificationHandler) GetUnreadNotifications(c *fiber.Ctx) error {
 }
 
 func (h *NotificationHandler) GetUnreadCount(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	count, err := h.notifService.GetUnreadCount(ctx, userID)
@@ -78,7 +80,7 @@ func (h *NotificationHandler) GetUnreadCount(c *fiber.Ctx) error {
 }
 
 func (h *NotificationHandler) GetNotificationByID(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 	id, err := utils.ParseIDParam(c, "id")
 	if err != nil {
@@ -87,7 +89,7 @@ func (h *NotificationHandler) GetNotificationByID(c *fiber.Ctx) error {
 
 	notif, err := h.notifService.GetNotificationByID(ctx, id, userID)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusNotFound, "Notification not found", err.Error())
+		return err
 	}
 
 	response := mapper.ToNotificationResponse(notif)
@@ -95,7 +97,7 @@ func (h *NotificationHandler) GetNotificationByID(c *fiber.Ctx) error {
 }
 
 func (h *NotificationHandler) MarkAsRead(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	id, err := utils.ParseIDParam(c, "id")
@@ -104,14 +106,14 @@ func (h *NotificationHandler) MarkAsRead(c *fiber.Ctx) error {
 	}
 
 	if err := h.notifService.MarkAsRead(ctx, id, userID); err != nil {
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to mark notification as read", err.Error())
+		return err
 	}
 
 	return utils.SuccessResponse(c, "Notification marked as read", nil)
 }
 
 func (h *NotificationHandler) MarkAsUnread(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	id, err := utils.ParseIDParam(c, "id")
@@ -127,7 +129,7 @@ func (h *NotificationHandler) MarkAsUnread(c *fiber.Ctx) error {
 }
 
 func (h *NotificationHandler) MarkAllAsRead(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	if err := h.notifService.MarkAllAsRead(ctx, userID); err != nil {
@@ -138,7 +140,7 @@ func (h *NotificationHandler) MarkAllAsRead(c *fiber.Ctx) error {
 }
 
 func (h *NotificationHandler) DeleteNotification(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	id, err := utils.ParseIDParam(c, "id")
@@ -154,7 +156,7 @@ func (h *NotificationHandler) DeleteNotification(c *fiber.Ctx) error {
 }
 
 func (h *NotificationHandler) DeleteAllNotifications(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	if err := h.notifService.DeleteAllNotifications(ctx, userID); err != nil {
@@ -165,7 +167,7 @@ func (h *NotificationHandler) DeleteAllNotifications(c *fiber.Ctx) error {
 }
 
 func (h *NotificationHandler) GetNotificationStats(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	stats, err := h.notifService.GetNotificationStats(ctx, userID)
@@ -178,7 +180,7 @@ func (h *NotificationHandler) GetNotificationStats(c *fiber.Ctx) error {
 }
 
 func (h *NotificationHandler) GetPreferences(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	prefs, err := h.notifService.GetNotificationPreferences(ctx, userID)
@@ -191,7 +193,7 @@ func (h *NotificationHandler) GetPreferences(c *fiber.Ctx) error {
 }
 
 func (h *NotificationHandler) UpdatePreferences(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	var req request.UpdateNotificationPreferencesRequest
@@ -213,7 +215,7 @@ func (h *NotificationHandler) UpdatePreferences(c *fiber.Ctx) error {
 }
 
 func (h *NotificationHandler) SendNotification(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var req request.SendNotificationRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -234,7 +236,7 @@ func (h *NotificationHandler) SendNotification(c *fiber.Ctx) error {
 }
 
 func (h *NotificationHandler) SendBulkNotification(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 
 	var req request.SendBulkNotificationRequest
 	if err := c.BodyParser(&req); err != nil {
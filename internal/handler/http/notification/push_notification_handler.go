@@ -29,7 +29,7 @@ func NewPushNotificationHandler(
 }
 
 func (h *PushNotificationHandler) SendPushToDevice(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	var req request.SendPushToDeviceRequest
@@ -67,7 +67,7 @@ func (h *PushNotificationHandler) SendPushToDevice(c *fiber.Ctx) error {
 }
 
 func (h *PushNotificationHandler) SendPushToUser(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	senderID := middleware.GetUserID(c)
 
 	targetUserID, err := utils.ParseIDParam(c, "id")
@@ -110,7 +110,7 @@ func (h *PushNotificationHandler) SendPushToUser(c *fiber.Ctx) error {
 }
 
 func (h *PushNotificationHandler) SendPushToMultipleUsers(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	senderID := middleware.GetUserID(c)
 
 	var req request.SendPushToMultipleUsersRequest
@@ -146,7 +146,7 @@ func (h *PushNotificationHandler) SendPushToMultipleUsers(c *fiber.Ctx) error {
 }
 
 func (h *PushNotificationHandler) SendPushToTopic(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	senderID := middleware.GetUserID(c)
 
 	var req request.SendPushToTopicRequest
@@ -184,7 +184,7 @@ func (h *PushNotificationHandler) SendPushToTopic(c *fiber.Ctx) error {
 }
 
 func (h *PushNotificationHandler) SendTestNotification(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 
 	var req request.TestPushNotificationRequest
@@ -0,0 +1,76 @@
+package upload
+
+import (
+	"errors"
+	"mime"
+	"path"
+
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// DownloadHandler serves private documents through signed, expiring download links
+type DownloadHandler struct {
+	signer  *service.DownloadSigner
+	uploads service.UploadService
+}
+
+// NewDownloadHandler creates a handler serving the files granted by signer's tokens
+func NewDownloadHandler(signer *service.DownloadSigner, uploads service.UploadService) *DownloadHandler {
+	return &DownloadHandler{
+		signer:  signer,
+		uploads: uploads,
+	}
+}
+
+// Download streams the file granted by the token path parameter as an attachment.
+// Tampered tokens are answered with 403, as are expired ones so clients know to request
+// a fresh link.
+func (h *DownloadHandler) Download(c *fiber.Ctx) error {
+	claims, err := h.signer.Verify(c.Params("token"))
+	if err != nil {
+		if errors.Is(err, service.ErrDownloadLinkExpired) {
+			return utils.ForbiddenResponse(c, "Download link has expired")
+		}
+		return utils.ForbiddenResponse(c, "Download link is invalid")
+	}
+
+	body, err := h.uploads.OpenFile(c.Context(), claims.FilePath)
+	if err != nil {
+		if errors.Is(err, service.ErrStoredFileNotFound) || errors.Is(err, service.ErrInvalidFilePath) {
+			return utils.NotFoundResponse(c, "File not found")
+		}
+		return utils.InternalServerErrorResponse(c, "Failed to read file")
+	}
+
+	fileName := claims.FileName
+	if fileName == "" {
+		fileName = path.Base(claims.FilePath)
+	}
+	if contentType := mime.TypeByExtension(path.Ext(fileName)); contentType != "" {
+		c.Set(fiber.HeaderContentType, contentType)
+	} else {
+		c.Set(fiber.HeaderContentType, fiber.MIMEOctetStream)
+	}
+
+	// The link is short-lived and grants access to a private document; keep it out of caches
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	return c.SendStream(body)
+}
+
+// DownloadFileName is the attachment name for a stored file: name with the stored file's
+// extension, or the stored file's own name when name is empty
+func DownloadFileName(name, stored string) string {
+	ext := path.Ext(stored)
+	if name == "" {
+		return path.Base(stored)
+	}
+	if path.Ext(name) == "" {
+		return name + ext
+	}
+	return name
+}
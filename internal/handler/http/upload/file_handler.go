@@ -0,0 +1,72 @@
+package upload
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// DefaultCacheMaxAge is how long clients may cache served uploads. Uploaded files get a
+// unique name and are never overwritten, so they can be cached for long.
+const DefaultCacheMaxAge = 7 * 24 * time.Hour
+
+// FileHandler serves files from the local upload storage
+type FileHandler struct {
+	root        string
+	cacheMaxAge time.Duration
+}
+
+// NewFileHandler creates a handler serving the files below root
+func NewFileHandler(root string, cacheMaxAge time.Duration) *FileHandler {
+	return &FileHandler{
+		root:        root,
+		cacheMaxAge: cacheMaxAge,
+	}
+}
+
+// Serve sends the uploaded file named by the wildcard path parameter. Only files in the public
+// image folders are served; paths that leave the upload root, hidden files, directories and
+// anything else, such as documents that are only downloadable through signed links, are
+// answered with 404.
+func (h *FileHandler) Serve(c *fiber.Ctx) error {
+	relativePath, err := url.PathUnescape(c.Params("*"))
+	if err != nil || hasHiddenSegment(relativePath) || !service.IsPublicUpload(relativePath) {
+		return utils.NotFoundResponse(c, "File not found")
+	}
+
+	filePath, err := service.LocalUploadPath(h.root, relativePath)
+	if err != nil {
+		return utils.NotFoundResponse(c, "File not found")
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil || info.IsDir() {
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return utils.InternalServerErrorResponse(c, "Failed to read file")
+		}
+		return utils.NotFoundResponse(c, "File not found")
+	}
+
+	c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d, immutable", int(h.cacheMaxAge.Seconds())))
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	return c.SendFile(filePath)
+}
+
+// hasHiddenSegment reports whether a path names a dot file or directory, such as the
+// storage health check probes
+func hasHiddenSegment(relativePath string) bool {
+	for _, segment := range strings.Split(strings.ReplaceAll(relativePath, "\\", "/"), "/") {
+		if strings.HasPrefix(segment, ".") {
+			return true
+		}
+	}
+	return false
+}
@@ -70,7 +70,7 @@ func (h *Handler) HandleWebSocket(c *fiber.Ctx) error {
 	userID := claims.UserID
 
 	// Verify user is participant in the conversation
-	ctx := c.Context()
+	ctx := c.UserContext()
 	isParticipant, err := h.conversationRepo.IsUserParticipant(ctx, conversationID, userID)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to check participant")
@@ -14,7 +14,7 @@ import (
 // BuildJobFilter builds job.JobFilter from request.JobSearchRequest
 func BuildJobFilter(q request.JobSearchRequest) job.JobFilter {
 	f := job.JobFilter{
-		Status:         "published",
+		Status:         job.StatusPublished,
 		City:           q.City,
 		Province:       q.Province,
 		JobLevel:       q.JobLevel,
@@ -116,6 +116,15 @@ func BuildJobSearchFilter(q request.JobSearchRequest) job.JobSearchFilter {
 		f.EducationLevels = []string{q.EducationLevel}
 	}
 
+	// Location search
+	if q.Lat != nil && q.Lng != nil {
+		f.Latitude, f.Longitude = q.Lat, q.Lng
+		f.RadiusKm = job.DefaultSearchRadiusKm
+		if q.RadiusKm != nil {
+			f.RadiusKm = *q.RadiusKm
+		}
+	}
+
 	return f
 }
 
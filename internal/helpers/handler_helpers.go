@@ -9,7 +9,7 @@ import (
 
 // GetProfile fetches authenticated user's profile using middleware to get user id
 func GetProfile(c *fiber.Ctx, svc user.UserService) (*user.User, error) {
-	ctx := c.Context()
+	ctx := c.UserContext()
 	userID := middleware.GetUserID(c)
 	return svc.GetProfile(ctx, userID)
 }
@@ -0,0 +1,21 @@
+package i18n
+
+import (
+	"fmt"
+	"time"
+)
+
+// FormatLongDate formats t like "Monday, 02 January 2006" with day and month names in locale
+func FormatLongDate(locale Locale, t time.Time) string {
+	return fmt.Sprintf("%s, %02d %s %d",
+		Translate(locale, fmt.Sprintf("date.weekday.%d", t.Weekday())),
+		t.Day(),
+		Translate(locale, fmt.Sprintf("date.month.%d", t.Month())),
+		t.Year(),
+	)
+}
+
+// FormatDate formats t like "2 January 2006" with the month name in locale
+func FormatDate(locale Locale, t time.Time) string {
+	return fmt.Sprintf("%d %s %d", t.Day(), Translate(locale, fmt.Sprintf("date.month.%d", t.Month())), t.Year())
+}
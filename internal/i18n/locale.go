@@ -0,0 +1,118 @@
+package i18n
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale is a supported language code
+type Locale string
+
+// Supported locales
+const (
+	Indonesian Locale = "id"
+	English    Locale = "en"
+
+	// DefaultLocale is used when nothing is known about the reader and for keys missing from a catalog
+	DefaultLocale = Indonesian
+
+	// FallbackLocale is used when the client asks only for languages we do not support
+	FallbackLocale = English
+)
+
+// contextKey is the type of keys this package stores in contexts
+type contextKey string
+
+// LocaleKey is the context and Fiber locals key holding the request locale
+const LocaleKey contextKey = "locale"
+
+// ParseLocale returns the supported locale for a language tag such as "en-US", reporting
+// false when the language is not supported
+func ParseLocale(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+
+	switch Locale(tag) {
+	case Indonesian, English:
+		return Locale(tag), true
+	}
+	return "", false
+}
+
+// FromPreference returns the locale stored as a user's language preference, or the
+// default locale when the preference is unset or unsupported
+func FromPreference(pref *string) Locale {
+	if pref == nil {
+		return DefaultLocale
+	}
+	if locale, ok := ParseLocale(*pref); ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// FromAcceptLanguage picks the best supported locale from an Accept-Language header.
+// An empty header yields the default locale; a header naming only unsupported
+// languages yields the fallback locale.
+func FromAcceptLanguage(header string) Locale {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return DefaultLocale
+	}
+
+	type candidate struct {
+		locale Locale
+		q      float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if value, ok := strings.CutPrefix(param, "q="); ok {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		if locale, ok := ParseLocale(fields[0]); ok {
+			candidates = append(candidates, candidate{locale: locale, q: q})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return FallbackLocale
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+	return candidates[0].locale
+}
+
+// WithLocale returns a copy of ctx carrying locale
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, LocaleKey, locale)
+}
+
+// FromContext returns the locale carried by ctx, or the default locale. It also reads
+// the locale the localization middleware stored in Fiber locals, since handlers pass
+// the request context to services.
+func FromContext(ctx context.Context) Locale {
+	if ctx == nil {
+		return DefaultLocale
+	}
+	if locale, ok := ctx.Value(LocaleKey).(Locale); ok && locale != "" {
+		return locale
+	}
+	return DefaultLocale
+}
@@ -0,0 +1,124 @@
+package i18n
+
+// messagesEN is the English catalog. Keys missing here fall back to the Indonesian catalog.
+var messagesEN = map[string]string{
+	// Validation errors; the first argument is the field name
+	"validation.required":         "%s is required",
+	"validation.email":            "%s must be a valid email address",
+	"validation.min":              "%s must be at least %s characters",
+	"validation.max":              "%s must not exceed %s characters",
+	"validation.gte":              "%s must be greater than or equal to %s",
+	"validation.lte":              "%s must be less than or equal to %s",
+	"validation.oneof":            "%s must be one of: %s",
+	"validation.url":              "%s must be a valid URL",
+	"validation.uuid":             "%s must be a valid UUID",
+	"validation.required_without": "%s is required when %s is empty",
+	"validation.invalid":          "%s is invalid",
+	"validation.failed":           "Validation failed",
+
+	// Application status names
+	"application.status.applied":       "Applied",
+	"application.status.screening":     "Screening",
+	"application.status.shortlisted":   "Shortlisted",
+	"application.status.interview":     "Interview",
+	"application.status.offered":       "Offered",
+	"application.status.hired":         "Hired",
+	"application.status.rejected":      "Rejected",
+	"application.status.withdrawn":     "Withdrawn",
+	"application.status.job_withdrawn": "Job Removed",
+
+	// Candidate application timeline
+	"application.timeline.submitted":             "Application submitted",
+	"application.timeline.interview.scheduled":   "Interview scheduled",
+	"application.timeline.interview.rescheduled": "Interview rescheduled",
+	"application.timeline.interview.completed":   "Interview completed",
+	"application.timeline.interview.cancelled":   "Interview cancelled",
+	"application.timeline.interview.no_show":     "Missed interview",
+
+	// What candidates can expect next; the typical variants take a number of days
+	"application.next_step.applied":             "The employer will review your application",
+	"application.next_step.applied.typical":     "This employer typically responds within %d days",
+	"application.next_step.screening":           "The employer is reviewing your application",
+	"application.next_step.screening.typical":   "This employer typically finishes reviewing within %d days",
+	"application.next_step.shortlisted":         "The employer will contact you about the next step",
+	"application.next_step.shortlisted.typical": "This employer typically contacts shortlisted candidates within %d days",
+	"application.next_step.interview":           "The employer will schedule your interview",
+	"application.next_step.interview.typical":   "This employer typically follows up on interviews within %d days",
+	"application.next_step.interview.scheduled": "Your interview is scheduled for %s",
+	"application.next_step.offered":             "Review the offer and reply to the employer",
+	"application.next_step.offered.typical":     "Review the offer and reply to the employer; this typically takes %d days",
+	"application.next_step.hired":               "Congratulations! The employer will contact you about your first day",
+	"application.next_step.rejected":            "Don't be discouraged, browse other jobs that match you",
+	"application.next_step.withdrawn":           "You withdrew this application",
+	"application.next_step.job_withdrawn":       "This job is no longer available, browse other jobs that match you",
+
+	// Application status update notifications
+	"application.status_update.title":         "Application Status Update",
+	"application.status_update.default":       "Your application status has been updated",
+	"application.status_update.screening":     "Your application is being reviewed",
+	"application.status_update.shortlisted":   "Congratulations! You've been shortlisted",
+	"application.status_update.interview":     "You've been invited for an interview",
+	"application.status_update.offered":       "Congratulations! You've received a job offer",
+	"application.status_update.hired":         "Congratulations! You've been hired",
+	"application.status_update.rejected":      "Your application status has been updated",
+	"application.status_update.job_withdrawn": "The job you applied for has been removed by the employer",
+
+	// In-app notifications
+	"notification.application_received.title":   "New Application",
+	"notification.application_received.message": "You received a new application for %s",
+	"notification.invitation.title":             "Company Invitation",
+	"notification.invitation.message":           "You have been invited to join %s",
+	"notification.job_approved.title":           "Job Approved",
+	"notification.job_approved.message":         "Your job %s has been approved and is now live",
+	"notification.job_rejected.title":           "Job Rejected",
+	"notification.job_rejected.message":         "Your job %s was rejected: %s",
+	"notification.review_response.title":        "Company Responded",
+	"notification.review_response.message":      "%s responded to your review",
+
+	// Match recommendations
+	"job.match.excellent": "Excellent match! You meet most of the requirements for this position.",
+	"job.match.good":      "Good match. You have many of the skills and qualifications needed.",
+	"job.match.fair":      "Fair match. Consider highlighting your relevant experience in your application.",
+	"job.match.low":       "This position may be challenging, but don't let that stop you from applying if you're interested.",
+
+	// Email subjects
+	"email.subject.default":             "Notification from Keerja",
+	"email.subject.verification":        "Verify Your Email - Keerja",
+	"email.subject.forgot_password":     "Reset Your Keerja Password",
+	"email.subject.password_reset":      "Your Password Has Been Reset",
+	"email.subject.welcome":             "Welcome to Keerja!",
+	"email.subject.application_update":  "Job Application Status Update",
+	"email.subject.interview_invite":    "Interview Invitation - Keerja",
+	"email.subject.interview_reminder":  "Interview Reminder - Keerja",
+	"email.subject.interview_cancelled": "Interview Cancelled - Keerja",
+	"email.subject.interview_booking":   "Choose Your Interview Time - Keerja",
+	"email.subject.data_export":         "Your Data Export Is Ready - Keerja",
+	"email.subject.weekly_digest":       "Your Weekly Hiring Digest - Keerja",
+	"email.subject.domain_verification": "Company Domain Verification Code - Keerja",
+	"email.subject.otp":                 "Your Verification Code - Keerja",
+	"email.subject.otp_registration":    "Verify Your Registration Email - Keerja",
+
+	// Email body snippets
+	"email.application_update.message": "Your application has been updated.",
+
+	// Date names
+	"date.weekday.0": "Sunday",
+	"date.weekday.1": "Monday",
+	"date.weekday.2": "Tuesday",
+	"date.weekday.3": "Wednesday",
+	"date.weekday.4": "Thursday",
+	"date.weekday.5": "Friday",
+	"date.weekday.6": "Saturday",
+	"date.month.1":   "January",
+	"date.month.2":   "February",
+	"date.month.3":   "March",
+	"date.month.4":   "April",
+	"date.month.5":   "May",
+	"date.month.6":   "June",
+	"date.month.7":   "July",
+	"date.month.8":   "August",
+	"date.month.9":   "September",
+	"date.month.10":  "October",
+	"date.month.11":  "November",
+	"date.month.12":  "December",
+}
@@ -0,0 +1,134 @@
+package i18n
+
+// messagesID is the Indonesian catalog. It is the default locale, so every key must exist here.
+var messagesID = map[string]string{
+	// Validation errors; the first argument is the field name
+	"validation.required":         "%s wajib diisi",
+	"validation.email":            "%s harus berupa alamat email yang valid",
+	"validation.min":              "%s minimal %s karakter",
+	"validation.max":              "%s maksimal %s karakter",
+	"validation.gte":              "%s harus lebih besar atau sama dengan %s",
+	"validation.lte":              "%s harus lebih kecil atau sama dengan %s",
+	"validation.oneof":            "%s harus salah satu dari: %s",
+	"validation.url":              "%s harus berupa URL yang valid",
+	"validation.uuid":             "%s harus berupa UUID yang valid",
+	"validation.required_without": "%s wajib diisi jika %s kosong",
+	"validation.invalid":          "%s tidak valid",
+	"validation.failed":           "Validasi gagal",
+
+	// Application status names
+	"application.status.applied":       "Dikirim",
+	"application.status.screening":     "Sedang Ditinjau",
+	"application.status.shortlisted":   "Masuk Daftar Pendek",
+	"application.status.interview":     "Interview",
+	"application.status.offered":       "Ditawari",
+	"application.status.hired":         "Diterima",
+	"application.status.rejected":      "Ditolak",
+	"application.status.withdrawn":     "Dibatalkan",
+	"application.status.job_withdrawn": "Lowongan Dihapus",
+
+	// Candidate application timeline
+	"application.timeline.submitted":             "Lamaran dikirim",
+	"application.timeline.interview.scheduled":   "Interview dijadwalkan",
+	"application.timeline.interview.rescheduled": "Interview dijadwalkan ulang",
+	"application.timeline.interview.completed":   "Interview selesai",
+	"application.timeline.interview.cancelled":   "Interview dibatalkan",
+	"application.timeline.interview.no_show":     "Tidak hadir di interview",
+
+	// What candidates can expect next; the typical variants take a number of days
+	"application.next_step.applied":             "Perusahaan akan meninjau lamaran Anda",
+	"application.next_step.applied.typical":     "Perusahaan ini biasanya merespons dalam %d hari",
+	"application.next_step.screening":           "Lamaran Anda sedang ditinjau oleh perusahaan",
+	"application.next_step.screening.typical":   "Perusahaan ini biasanya menyelesaikan peninjauan dalam %d hari",
+	"application.next_step.shortlisted":         "Perusahaan akan menghubungi Anda untuk tahap berikutnya",
+	"application.next_step.shortlisted.typical": "Perusahaan ini biasanya menghubungi kandidat terpilih dalam %d hari",
+	"application.next_step.interview":           "Perusahaan akan menjadwalkan interview Anda",
+	"application.next_step.interview.typical":   "Perusahaan ini biasanya memberi kabar setelah interview dalam %d hari",
+	"application.next_step.interview.scheduled": "Interview Anda dijadwalkan pada %s",
+	"application.next_step.offered":             "Tinjau tawaran dan beri jawaban kepada perusahaan",
+	"application.next_step.offered.typical":     "Tinjau tawaran dan beri jawaban kepada perusahaan; proses ini biasanya selesai dalam %d hari",
+	"application.next_step.hired":               "Selamat! Perusahaan akan menghubungi Anda tentang hari pertama kerja",
+	"application.next_step.rejected":            "Jangan berkecil hati, lihat lowongan lain yang cocok untuk Anda",
+	"application.next_step.withdrawn":           "Anda telah membatalkan lamaran ini",
+	"application.next_step.job_withdrawn":       "Lowongan ini sudah tidak tersedia, lihat lowongan lain yang cocok untuk Anda",
+
+	// Application status update notifications
+	"application.status_update.title":         "Update Status Lamaran",
+	"application.status_update.default":       "Status lamaran Anda telah diperbarui",
+	"application.status_update.screening":     "Lamaran Anda sedang ditinjau",
+	"application.status_update.shortlisted":   "Selamat! Anda masuk daftar pendek",
+	"application.status_update.interview":     "Anda diundang untuk interview",
+	"application.status_update.offered":       "Selamat! Anda menerima tawaran kerja",
+	"application.status_update.hired":         "Selamat! Anda diterima bekerja",
+	"application.status_update.rejected":      "Status lamaran Anda telah diperbarui",
+	"application.status_update.job_withdrawn": "Lowongan yang Anda lamar telah dihapus oleh perusahaan",
+
+	// In-app notifications
+	"notification.application_received.title":   "Lamaran Baru",
+	"notification.application_received.message": "Anda menerima lamaran baru untuk %s",
+	"notification.invitation.title":             "Undangan Perusahaan",
+	"notification.invitation.message":           "Anda diundang untuk bergabung dengan %s",
+	"notification.job_approved.title":           "Lowongan disetujui",
+	"notification.job_approved.message":         "Lowongan %s telah disetujui dan sekarang tayang.",
+	"notification.job_rejected.title":           "Lowongan ditolak",
+	"notification.job_rejected.message":         "Lowongan %s ditolak: %s",
+	"notification.review_response.title":        "Tanggapan Perusahaan",
+	"notification.review_response.message":      "%s menanggapi ulasan Anda",
+
+	// Match recommendations
+	"job.match.excellent": "Sangat cocok! Anda memenuhi sebagian besar persyaratan untuk posisi ini.",
+	"job.match.good":      "Cocok. Anda memiliki banyak keahlian dan kualifikasi yang dibutuhkan.",
+	"job.match.fair":      "Cukup cocok. Tonjolkan pengalaman Anda yang relevan dalam lamaran.",
+	"job.match.low":       "Posisi ini mungkin menantang, tetapi jangan ragu untuk melamar jika Anda tertarik.",
+
+	// Email subjects
+	"email.subject.default":              "Notifikasi dari Keerja",
+	"email.subject.verification":         "Verifikasi Email Anda - Keerja",
+	"email.subject.forgot_password":      "Reset Password Akun Keerja Anda",
+	"email.subject.password_reset":       "Password Anda Telah Direset",
+	"email.subject.welcome":              "Selamat Datang di Keerja!",
+	"email.subject.application_update":   "Update Status Lamaran Pekerjaan",
+	"email.subject.interview_invite":     "Undangan Interview - Keerja",
+	"email.subject.interview_reminder":   "Pengingat Interview - Keerja",
+	"email.subject.interview_cancelled":  "Interview Dibatalkan - Keerja",
+	"email.subject.job_alert":            "Job Alert: Pekerjaan Baru Sesuai Preferensi Anda",
+	"email.subject.company_verified":     "Perusahaan Anda Telah Terverifikasi",
+	"email.subject.otp":                  "Kode OTP Verifikasi - Keerja",
+	"email.subject.otp_registration":     "Verifikasi Email Registrasi - Keerja",
+	"email.subject.company_invitation":   "Undangan Bergabung ke Tim - Keerja",
+	"email.subject.invitation_accepted":  "Undangan Diterima - Anggota Baru Bergabung",
+	"email.subject.invitation_expired":   "Undangan Kadaluarsa - Keerja",
+	"email.subject.job_reviewed":         "Hasil Review Lowongan - Keerja",
+	"email.subject.stage_reminder":       "Lamaran Menunggu Tindak Lanjut - Keerja",
+	"email.subject.followed_company_job": "Lowongan Baru dari Perusahaan yang Anda Ikuti - Keerja",
+	"email.subject.verification_expiry":  "Verifikasi Perusahaan Anda Akan Berakhir - Keerja",
+	"email.subject.job_expiry_reminder":  "Lowongan Anda Akan Berakhir - Keerja",
+	"email.subject.interview_booking":    "Pilih Jadwal Interview Anda - Keerja",
+	"email.subject.data_export":          "Salinan Data Anda Siap Diunduh - Keerja",
+	"email.subject.weekly_digest":        "Ringkasan Mingguan Rekrutmen - Keerja",
+	"email.subject.domain_verification":  "Kode Verifikasi Domain Perusahaan - Keerja",
+
+	// Email body snippets
+	"email.application_update.message": "Lamaran Anda telah diperbarui.",
+
+	// Date names
+	"date.weekday.0": "Minggu",
+	"date.weekday.1": "Senin",
+	"date.weekday.2": "Selasa",
+	"date.weekday.3": "Rabu",
+	"date.weekday.4": "Kamis",
+	"date.weekday.5": "Jumat",
+	"date.weekday.6": "Sabtu",
+	"date.month.1":   "Januari",
+	"date.month.2":   "Februari",
+	"date.month.3":   "Maret",
+	"date.month.4":   "April",
+	"date.month.5":   "Mei",
+	"date.month.6":   "Juni",
+	"date.month.7":   "Juli",
+	"date.month.8":   "Agustus",
+	"date.month.9":   "September",
+	"date.month.10":  "Oktober",
+	"date.month.11":  "November",
+	"date.month.12":  "Desember",
+}
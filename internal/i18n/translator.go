@@ -0,0 +1,60 @@
+package i18n
+
+import (
+	"fmt"
+)
+
+// Translator looks up messages for one locale
+type Translator struct {
+	locale Locale
+}
+
+// New creates a translator for locale
+func New(locale Locale) *Translator {
+	return &Translator{locale: locale}
+}
+
+// Locale returns the translator's locale
+func (t *Translator) Locale() Locale {
+	return t.locale
+}
+
+// T returns the message for key formatted with args. Keys missing from the locale's
+// catalog fall back to the default locale, and unknown keys are returned as is.
+func (t *Translator) T(key string, args ...interface{}) string {
+	return Translate(t.locale, key, args...)
+}
+
+// Has reports whether key exists in the locale's catalog or the default catalog
+func (t *Translator) Has(key string) bool {
+	_, ok := lookup(t.locale, key)
+	return ok
+}
+
+// Translate returns the message for key in locale formatted with args, with the same
+// fallbacks as Translator.T
+func Translate(locale Locale, key string, args ...interface{}) string {
+	msg, ok := lookup(locale, key)
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// lookup finds key in the locale's catalog, then in the default catalog
+func lookup(locale Locale, key string) (string, bool) {
+	if msg, ok := catalogs[locale][key]; ok {
+		return msg, true
+	}
+	msg, ok := catalogs[DefaultLocale][key]
+	return msg, ok
+}
+
+// catalogs holds the messages of every supported locale
+var catalogs = map[Locale]map[string]string{
+	Indonesian: messagesID,
+	English:    messagesEN,
+}
@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"keerja-backend/internal/domain/user"
+
+	"github.com/sirupsen/logrus"
+)
+
+// AccountDeletionJob deletes the accounts whose deletion grace period has ended
+type AccountDeletionJob struct {
+	privacyService user.AccountPrivacyService
+	logger         *logrus.Logger
+}
+
+// NewAccountDeletionJob creates a new account deletion job
+func NewAccountDeletionJob(privacyService user.AccountPrivacyService, logger *logrus.Logger) *AccountDeletionJob {
+	return &AccountDeletionJob{
+		privacyService: privacyService,
+		logger:         logger,
+	}
+}
+
+// Name returns the job name
+func (j *AccountDeletionJob) Name() string {
+	return "account_deletion"
+}
+
+// Schedule returns the cron schedule (every hour)
+func (j *AccountDeletionJob) Schedule() string {
+	return "0 15 * * * *" // Every hour at minute 15
+}
+
+// Run anonymizes the accounts that are due for deletion
+func (j *AccountDeletionJob) Run(ctx context.Context) (int, error) {
+	deleted, err := j.privacyService.ExecuteDueDeletions(ctx)
+	if deleted > 0 {
+		j.logger.WithField("deleted", deleted).Info("Deleted accounts past their grace period")
+	}
+	if err != nil {
+		return deleted, fmt.Errorf("failed to execute account deletions: %w", err)
+	}
+	return deleted, nil
+}
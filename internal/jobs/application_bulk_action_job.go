@@ -0,0 +1,47 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"keerja-backend/internal/domain/application"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ApplicationBulkActionJob works through queued application bulk actions, resuming the ones a
+// restart interrupted
+type ApplicationBulkActionJob struct {
+	appService application.ApplicationService
+	logger     *logrus.Logger
+}
+
+// NewApplicationBulkActionJob creates a new application bulk action job
+func NewApplicationBulkActionJob(appService application.ApplicationService, logger *logrus.Logger) *ApplicationBulkActionJob {
+	return &ApplicationBulkActionJob{
+		appService: appService,
+		logger:     logger,
+	}
+}
+
+// Name returns the job name
+func (j *ApplicationBulkActionJob) Name() string {
+	return "application_bulk_action"
+}
+
+// Schedule returns the cron schedule (every 10 seconds)
+func (j *ApplicationBulkActionJob) Schedule() string {
+	return "*/10 * * * * *"
+}
+
+// Run processes the items of unfinished bulk actions
+func (j *ApplicationBulkActionJob) Run(ctx context.Context) (int, error) {
+	processed, err := j.appService.ProcessBulkActions(ctx)
+	if processed > 0 {
+		j.logger.WithField("items", processed).Info("Processed application bulk action items")
+	}
+	if err != nil {
+		return processed, fmt.Errorf("failed to process application bulk actions: %w", err)
+	}
+	return processed, nil
+}
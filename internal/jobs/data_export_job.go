@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"keerja-backend/internal/domain/user"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DataExportJob generates requested personal data exports and expires lapsed download links
+type DataExportJob struct {
+	privacyService user.AccountPrivacyService
+	logger         *logrus.Logger
+}
+
+// NewDataExportJob creates a new data export job
+func NewDataExportJob(privacyService user.AccountPrivacyService, logger *logrus.Logger) *DataExportJob {
+	return &DataExportJob{
+		privacyService: privacyService,
+		logger:         logger,
+	}
+}
+
+// Name returns the job name
+func (j *DataExportJob) Name() string {
+	return "data_export"
+}
+
+// Schedule returns the cron schedule (every minute)
+func (j *DataExportJob) Schedule() string {
+	return "30 * * * * *" // Every minute at second 30
+}
+
+// Run generates pending data exports and expires the ones past their link lifetime
+func (j *DataExportJob) Run(ctx context.Context) (int, error) {
+	processed, err := j.privacyService.ProcessDataExports(ctx)
+	if processed > 0 {
+		j.logger.WithField("processed", processed).Info("Processed personal data exports")
+	}
+	if err != nil {
+		return processed, fmt.Errorf("failed to process data exports: %w", err)
+	}
+	return processed, nil
+}
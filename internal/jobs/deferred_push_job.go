@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"keerja-backend/internal/domain/notification"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DeferredPushJob sends the pushes held back by quiet hours once the quiet hours have ended
+type DeferredPushJob struct {
+	dispatcher notification.NotificationDispatcher
+	logger     *logrus.Logger
+}
+
+// NewDeferredPushJob creates a new deferred push job
+func NewDeferredPushJob(dispatcher notification.NotificationDispatcher, logger *logrus.Logger) *DeferredPushJob {
+	return &DeferredPushJob{
+		dispatcher: dispatcher,
+		logger:     logger,
+	}
+}
+
+// Name returns the job name
+func (j *DeferredPushJob) Name() string {
+	return "deferred_push"
+}
+
+// Schedule returns the cron schedule (every minute)
+func (j *DeferredPushJob) Schedule() string {
+	return "15 * * * * *" // Every minute at second 15
+}
+
+// Run sends the deferred pushes that are due
+func (j *DeferredPushJob) Run(ctx context.Context) (int, error) {
+	count, err := j.dispatcher.SendDeferredPushes(ctx)
+	if count > 0 {
+		j.logger.WithField("pushes", count).Info("Sent deferred pushes")
+	}
+	if err != nil {
+		return count, fmt.Errorf("failed to send deferred pushes: %w", err)
+	}
+	return count, nil
+}
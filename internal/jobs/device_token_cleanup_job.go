@@ -67,33 +67,33 @@ func (j *DeviceTokenCleanupJob) Schedule() string {
 }
 
 // Run executes the cleanup job
-func (j *DeviceTokenCleanupJob) Run(ctx context.Context) error {
+func (j *DeviceTokenCleanupJob) Run(ctx context.Context) (int, error) {
 	startTime := time.Now()
 
 	j.logger.Info("🧹 Starting device token cleanup job...")
 
-	var totalCleaned int64
+	var inactiveCleaned, failureCleaned int64
 	var errors []error
 
 	// Cleanup 1: Remove inactive tokens (not used for X days)
 	if j.config.EnableInactiveClean {
-		inactiveCleaned, err := j.cleanupInactiveTokens(ctx)
+		cleaned, err := j.cleanupInactiveTokens(ctx)
 		if err != nil {
 			j.logger.WithError(err).Error("Failed to cleanup inactive tokens")
 			errors = append(errors, fmt.Errorf("inactive cleanup: %w", err))
 		} else {
-			totalCleaned += inactiveCleaned
+			inactiveCleaned = cleaned
 		}
 	}
 
 	// Cleanup 2: Remove tokens with high failure counts
 	if j.config.EnableFailureClean {
-		failureCleaned, err := j.cleanupFailedTokens(ctx)
+		cleaned, err := j.cleanupFailedTokens(ctx)
 		if err != nil {
 			j.logger.WithError(err).Error("Failed to cleanup failed tokens")
 			errors = append(errors, fmt.Errorf("failure cleanup: %w", err))
 		} else {
-			totalCleaned += failureCleaned
+			failureCleaned = cleaned
 		}
 	}
 
@@ -101,17 +101,19 @@ func (j *DeviceTokenCleanupJob) Run(ctx context.Context) error {
 
 	// Log results
 	j.logger.WithFields(logrus.Fields{
-		"total_cleaned": totalCleaned,
-		"duration_ms":   duration.Milliseconds(),
-		"errors":        len(errors),
+		"inactive_pruned": inactiveCleaned,
+		"failed_pruned":   failureCleaned,
+		"total_cleaned":   inactiveCleaned + failureCleaned,
+		"duration_ms":     duration.Milliseconds(),
+		"errors":          len(errors),
 	}).Info("✅ Device token cleanup job completed")
 
 	// Return error if any cleanup failed
 	if len(errors) > 0 {
-		return fmt.Errorf("cleanup job completed with %d errors: %v", len(errors), errors)
+		return int(inactiveCleaned + failureCleaned), fmt.Errorf("cleanup job completed with %d errors: %v", len(errors), errors)
 	}
 
-	return nil
+	return int(inactiveCleaned + failureCleaned), nil
 }
 
 // cleanupInactiveTokens removes tokens that haven't been used for X days
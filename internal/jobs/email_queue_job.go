@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"keerja-backend/internal/domain/email"
+
+	"github.com/sirupsen/logrus"
+)
+
+// EmailQueueJob sends queued emails that are due, retrying failures with backoff
+type EmailQueueJob struct {
+	queueService email.EmailQueueService
+	logger       *logrus.Logger
+}
+
+// NewEmailQueueJob creates a new email queue job
+func NewEmailQueueJob(queueService email.EmailQueueService, logger *logrus.Logger) *EmailQueueJob {
+	return &EmailQueueJob{
+		queueService: queueService,
+		logger:       logger,
+	}
+}
+
+// Name returns the job name
+func (j *EmailQueueJob) Name() string {
+	return "email_queue"
+}
+
+// Schedule returns the cron schedule (every 30 seconds)
+func (j *EmailQueueJob) Schedule() string {
+	return "*/30 * * * * *"
+}
+
+// Run sends the due queued emails
+func (j *EmailQueueJob) Run(ctx context.Context) (int, error) {
+	count, err := j.queueService.ProcessDue(ctx)
+	if count > 0 {
+		j.logger.WithField("emails", count).Info("Sent queued emails")
+	}
+	if err != nil {
+		return count, fmt.Errorf("failed to process email queue: %w", err)
+	}
+	return count, nil
+}
@@ -30,13 +30,13 @@ func (j *InvitationExpiryJob) Schedule() string {
 }
 
 // Run executes the job
-func (j *InvitationExpiryJob) Run(ctx context.Context) error {
+func (j *InvitationExpiryJob) Run(ctx context.Context) (int, error) {
 	fmt.Println("Running invitation expiry job...")
 
 	// Expire old invitations
 	count, err := j.companyService.ExpireOldInvitations(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to expire invitations: %w", err)
+		return 0, fmt.Errorf("failed to expire invitations: %w", err)
 	}
 
 	if count > 0 {
@@ -45,5 +45,5 @@ func (j *InvitationExpiryJob) Run(ctx context.Context) error {
 		fmt.Println("No invitations to expire")
 	}
 
-	return nil
+	return int(count), nil
 }
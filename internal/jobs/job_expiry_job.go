@@ -0,0 +1,60 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"keerja-backend/internal/domain/job"
+
+	"github.com/sirupsen/logrus"
+)
+
+// JobExpiryJob reminds employers about expiring job postings and expires (or, for
+// auto_extend jobs, extends) the postings whose expiry date has passed
+type JobExpiryJob struct {
+	jobService job.JobService
+	logger     *logrus.Logger
+}
+
+// NewJobExpiryJob creates a new job expiry job
+func NewJobExpiryJob(jobService job.JobService, logger *logrus.Logger) *JobExpiryJob {
+	return &JobExpiryJob{
+		jobService: jobService,
+		logger:     logger,
+	}
+}
+
+// Name returns the job name
+func (j *JobExpiryJob) Name() string {
+	return "job_expiry"
+}
+
+// Schedule returns the cron schedule (every hour)
+func (j *JobExpiryJob) Schedule() string {
+	return "0 15 * * * *" // Every hour at minute 15
+}
+
+// Run sends due expiry reminders, then expires or extends jobs past their expiry date
+func (j *JobExpiryJob) Run(ctx context.Context) (int, error) {
+	reminded, remindErr := j.jobService.SendExpiryReminders(ctx)
+	stats, err := j.jobService.AutoExpireJobs(ctx)
+	processed := reminded
+	if stats != nil {
+		processed += stats.Extended + stats.Expired
+	}
+	if processed > 0 {
+		fields := logrus.Fields{"reminders_sent": reminded}
+		if stats != nil {
+			fields["extended"] = stats.Extended
+			fields["expired"] = stats.Expired
+		}
+		j.logger.WithFields(fields).Info("Processed job posting expiry")
+	}
+	if remindErr != nil {
+		return processed, fmt.Errorf("failed to send job expiry reminders: %w", remindErr)
+	}
+	if err != nil {
+		return processed, fmt.Errorf("failed to expire jobs: %w", err)
+	}
+	return processed, nil
+}
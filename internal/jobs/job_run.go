@@ -0,0 +1,54 @@
+package jobs
+
+import (
+	"context"
+	"time"
+)
+
+// Job run triggers
+const (
+	RunTriggerSchedule = "schedule"
+	RunTriggerManual   = "manual"
+)
+
+// JobRun records one execution of a background job
+type JobRun struct {
+	ID             int64      `gorm:"primaryKey" json:"id"`
+	JobName        string     `gorm:"type:varchar(100);not null" json:"job_name"`
+	TriggeredBy    string     `gorm:"type:varchar(20);not null" json:"triggered_by"`
+	StartedAt      time.Time  `gorm:"not null" json:"started_at"`
+	FinishedAt     *time.Time `json:"finished_at,omitempty"`
+	Success        bool       `gorm:"not null;default:false" json:"success"`
+	Error          *string    `gorm:"type:text" json:"error,omitempty"`
+	ItemsProcessed int        `gorm:"not null;default:0" json:"items_processed"`
+}
+
+// TableName specifies the table name for JobRun
+func (JobRun) TableName() string {
+	return "job_runs"
+}
+
+// IsFinished checks if the run has ended, successfully or not
+func (r *JobRun) IsFinished() bool {
+	return r.FinishedAt != nil
+}
+
+// Duration returns how long the run took, or zero while it is still running
+func (r *JobRun) Duration() time.Duration {
+	if r.FinishedAt == nil {
+		return 0
+	}
+	return r.FinishedAt.Sub(r.StartedAt)
+}
+
+// RunRepository stores the job run history
+type RunRepository interface {
+	// Create records a run that has just started
+	Create(ctx context.Context, run *JobRun) error
+	// Finish records the outcome of a run
+	Finish(ctx context.Context, run *JobRun) error
+	// LatestByJob returns the most recent run of each job, keyed by job name
+	LatestByJob(ctx context.Context) (map[string]JobRun, error)
+	// DeleteStartedBefore removes runs started before the given time
+	DeleteStartedBefore(ctx context.Context, before time.Time) (int64, error)
+}
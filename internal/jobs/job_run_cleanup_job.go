@@ -0,0 +1,55 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultJobRunRetentionDays is how long job runs are kept when no retention is configured
+const DefaultJobRunRetentionDays = 30
+
+// JobRunCleanupJob removes job runs older than the retention period, since frequent jobs
+// such as the email queue add thousands of runs a day
+type JobRunCleanupJob struct {
+	runs          RunRepository
+	logger        *logrus.Logger
+	retentionDays int
+}
+
+// NewJobRunCleanupJob creates a new job run cleanup job
+func NewJobRunCleanupJob(runs RunRepository, logger *logrus.Logger, retentionDays int) *JobRunCleanupJob {
+	if retentionDays <= 0 {
+		retentionDays = DefaultJobRunRetentionDays
+	}
+
+	return &JobRunCleanupJob{
+		runs:          runs,
+		logger:        logger,
+		retentionDays: retentionDays,
+	}
+}
+
+// Name returns the job name
+func (j *JobRunCleanupJob) Name() string {
+	return "job_run_cleanup"
+}
+
+// Schedule returns the cron schedule (daily at 01:00)
+func (j *JobRunCleanupJob) Schedule() string {
+	return "0 0 1 * * *" // Every day at 01:00:00
+}
+
+// Run deletes the runs that started before the retention period
+func (j *JobRunCleanupJob) Run(ctx context.Context) (int, error) {
+	deleted, err := j.runs.DeleteStartedBefore(ctx, time.Now().AddDate(0, 0, -j.retentionDays))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old job runs: %w", err)
+	}
+	if deleted > 0 {
+		j.logger.WithField("deleted", deleted).Info("Deleted old job runs")
+	}
+	return int(deleted), nil
+}
@@ -0,0 +1,58 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"keerja-backend/internal/domain/job"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultJobViewRetentionDays is how long job view events are kept when no retention is configured
+const DefaultJobViewRetentionDays = 365
+
+// JobViewCleanupJob removes job view events older than the retention period. Every counted
+// view adds an event, so the table would otherwise grow without limit; jobs keep their
+// views_count, only the per-view analytics history is dropped.
+type JobViewCleanupJob struct {
+	jobRepo       job.JobRepository
+	logger        *logrus.Logger
+	retentionDays int
+}
+
+// NewJobViewCleanupJob creates a new job view cleanup job
+func NewJobViewCleanupJob(jobRepo job.JobRepository, logger *logrus.Logger, retentionDays int) *JobViewCleanupJob {
+	if retentionDays <= 0 {
+		retentionDays = DefaultJobViewRetentionDays
+	}
+
+	return &JobViewCleanupJob{
+		jobRepo:       jobRepo,
+		logger:        logger,
+		retentionDays: retentionDays,
+	}
+}
+
+// Name returns the job name
+func (j *JobViewCleanupJob) Name() string {
+	return "job_view_cleanup"
+}
+
+// Schedule returns the cron schedule (daily at 01:30)
+func (j *JobViewCleanupJob) Schedule() string {
+	return "0 30 1 * * *" // Every day at 01:30:00
+}
+
+// Run deletes the view events recorded before the retention period
+func (j *JobViewCleanupJob) Run(ctx context.Context) (int, error) {
+	deleted, err := j.jobRepo.DeleteViewEventsBefore(ctx, time.Now().AddDate(0, 0, -j.retentionDays))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old job view events: %w", err)
+	}
+	if deleted > 0 {
+		j.logger.WithField("deleted", deleted).Info("Deleted old job view events")
+	}
+	return int(deleted), nil
+}
@@ -0,0 +1,53 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"keerja-backend/internal/domain/job"
+
+	"github.com/sirupsen/logrus"
+)
+
+// MatchScoreRefreshJob precomputes job match scores for active job seekers against recently
+// published jobs, so job matching and recommendations don't score every job per request
+type MatchScoreRefreshJob struct {
+	jobService job.JobService
+	logger     *logrus.Logger
+}
+
+// NewMatchScoreRefreshJob creates a new match score refresh job
+func NewMatchScoreRefreshJob(jobService job.JobService, logger *logrus.Logger) *MatchScoreRefreshJob {
+	return &MatchScoreRefreshJob{
+		jobService: jobService,
+		logger:     logger,
+	}
+}
+
+// Name returns the job name
+func (j *MatchScoreRefreshJob) Name() string {
+	return "match_score_refresh"
+}
+
+// Schedule returns the cron schedule (daily at 02:00)
+func (j *MatchScoreRefreshJob) Schedule() string {
+	return "0 0 2 * * *" // Every day at 02:00:00
+}
+
+// Timeout allows the refresh more time than other jobs, since it scores every active user
+func (j *MatchScoreRefreshJob) Timeout() time.Duration {
+	return 2 * time.Hour
+}
+
+// Run recomputes the match scores
+func (j *MatchScoreRefreshJob) Run(ctx context.Context) (int, error) {
+	stored, err := j.jobService.RefreshMatchScores(ctx)
+	if err != nil {
+		return stored, fmt.Errorf("failed to refresh match scores: %w", err)
+	}
+	if stored > 0 {
+		j.logger.WithField("stored", stored).Info("Refreshed job match scores")
+	}
+	return stored, nil
+}
@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"keerja-backend/internal/domain/notification"
+
+	"github.com/sirupsen/logrus"
+)
+
+// PushCampaignJob sends admin push campaigns once their scheduled time has passed
+type PushCampaignJob struct {
+	campaignService notification.PushCampaignService
+	logger          *logrus.Logger
+}
+
+// NewPushCampaignJob creates a new push campaign job
+func NewPushCampaignJob(campaignService notification.PushCampaignService, logger *logrus.Logger) *PushCampaignJob {
+	return &PushCampaignJob{
+		campaignService: campaignService,
+		logger:          logger,
+	}
+}
+
+// Name returns the job name
+func (j *PushCampaignJob) Name() string {
+	return "push_campaign"
+}
+
+// Schedule returns the cron schedule (every minute)
+func (j *PushCampaignJob) Schedule() string {
+	return "0 * * * * *" // Every minute at second 0
+}
+
+// Run sends the due push campaigns
+func (j *PushCampaignJob) Run(ctx context.Context) (int, error) {
+	count, err := j.campaignService.SendDueCampaigns(ctx)
+	if count > 0 {
+		j.logger.WithField("campaigns", count).Info("Sent due push campaigns")
+	}
+	if err != nil {
+		return count, fmt.Errorf("failed to send push campaigns: %w", err)
+	}
+	return count, nil
+}
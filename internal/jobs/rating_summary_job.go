@@ -0,0 +1,48 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"keerja-backend/internal/domain/company"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RatingSummaryJob recomputes company rating summaries that fell behind their reviews, e.g.
+// when a refresh after a review change failed. On its first run it also backfills companies
+// that have reviews but no summary yet.
+type RatingSummaryJob struct {
+	companyService company.CompanyService
+	logger         *logrus.Logger
+}
+
+// NewRatingSummaryJob creates a new rating summary job
+func NewRatingSummaryJob(companyService company.CompanyService, logger *logrus.Logger) *RatingSummaryJob {
+	return &RatingSummaryJob{
+		companyService: companyService,
+		logger:         logger,
+	}
+}
+
+// Name returns the job name
+func (j *RatingSummaryJob) Name() string {
+	return "rating_summary_refresh"
+}
+
+// Schedule returns the cron schedule (every 30 minutes)
+func (j *RatingSummaryJob) Schedule() string {
+	return "0 */30 * * * *"
+}
+
+// Run refreshes stale rating summaries
+func (j *RatingSummaryJob) Run(ctx context.Context) (int, error) {
+	refreshed, err := j.companyService.RefreshStaleRatingSummaries(ctx)
+	if err != nil {
+		return int(refreshed), fmt.Errorf("failed to refresh rating summaries: %w", err)
+	}
+	if refreshed > 0 {
+		j.logger.WithField("refreshed", refreshed).Info("Refreshed stale company rating summaries")
+	}
+	return int(refreshed), nil
+}
@@ -3,41 +3,104 @@ package jobs
 import (
 	"context"
 	"fmt"
+	"runtime/debug"
+	"sort"
 	"sync"
 	"time"
 
+	"keerja-backend/internal/apperror"
+	"keerja-backend/internal/metrics"
+
 	"github.com/robfig/cron/v3"
 )
 
+// DefaultJobTimeout is the time limit of a run for jobs that do not set their own
+const DefaultJobTimeout = 30 * time.Minute
+
+// runRecordTimeout bounds each write of the run history, so a slow database cannot hold up jobs
+const runRecordTimeout = 5 * time.Second
+
+var (
+	ErrJobNotFound       = apperror.NotFound("SCHEDULED_JOB_NOT_FOUND", "scheduled job not found")
+	ErrJobAlreadyRunning = apperror.Conflict("SCHEDULED_JOB_RUNNING", "job is already running")
+	ErrSchedulerStopped  = apperror.Conflict("SCHEDULER_STOPPED", "scheduler has been stopped")
+)
+
 // Job represents a background job
 type Job interface {
 	// Name returns the job name
 	Name() string
 
-	// Run executes the job
-	Run(ctx context.Context) error
+	// Run executes the job and returns the number of items it processed. The context
+	// carries the run deadline.
+	Run(ctx context.Context) (int, error)
 
 	// Schedule returns the cron schedule (e.g., "0 * * * *" for every hour)
 	Schedule() string
 }
 
+// TimeoutJob is implemented by jobs that need a run time limit other than DefaultJobTimeout
+type TimeoutJob interface {
+	Timeout() time.Duration
+}
+
 // Scheduler manages background jobs
 type Scheduler struct {
 	cron   *cron.Cron
-	jobs   map[string]Job
+	jobs   map[string]*scheduledJob
+	runs   RunRepository
 	ctx    context.Context
 	cancel context.CancelFunc
 	wg     sync.WaitGroup
 	mu     sync.RWMutex
+
+	running   bool
+	startedAt time.Time
+
+	// lastTick has its own lock because Stop holds mu while waiting for running jobs
+	tickMu   sync.Mutex
+	lastTick time.Time
+
+	// stateMu guards the running flag and last run of every scheduled job
+	stateMu sync.Mutex
 }
 
-// NewScheduler creates a new job scheduler
-func NewScheduler() *Scheduler {
+// scheduledJob is a registered job with its cron entry and run state
+type scheduledJob struct {
+	job     Job
+	entryID cron.EntryID
+
+	running bool
+	lastRun *JobRun
+}
+
+// SchedulerStatus is a snapshot of the scheduler state, used by health checks
+type SchedulerStatus struct {
+	Running   bool
+	Jobs      int
+	StartedAt time.Time
+	LastTick  time.Time // Zero until the first job has started
+}
+
+// JobSchedule describes a registered job with its last and next run
+type JobSchedule struct {
+	Name      string
+	Schedule  string
+	Timeout   time.Duration
+	Running   bool
+	LastRun   *JobRun
+	NextRunAt *time.Time // Nil while the scheduler is stopped
+}
+
+// NewScheduler creates a new job scheduler. Runs are recorded in runs when it is not nil;
+// otherwise only the last run of each job is kept in memory.
+func NewScheduler(runs RunRepository) *Scheduler {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	return &Scheduler{
 		cron:   cron.New(cron.WithSeconds()),
-		jobs:   make(map[string]Job),
+		jobs:   make(map[string]*scheduledJob),
+		runs:   runs,
 		ctx:    ctx,
 		cancel: cancel,
 	}
@@ -55,16 +118,24 @@ func (s *Scheduler) Register(job Job) error {
 		return fmt.Errorf("job %s already registered", name)
 	}
 
+	sj := &scheduledJob{job: job}
+
 	// Add job to cron
-	_, err := s.cron.AddFunc(job.Schedule(), func() {
-		s.runJob(job)
+	entryID, err := s.cron.AddFunc(job.Schedule(), func() {
+		run, err := s.start(sj, RunTriggerSchedule)
+		if err != nil {
+			fmt.Printf("Skipping job %s: %v\n", name, err)
+			return
+		}
+		s.execute(sj, run)
 	})
 
 	if err != nil {
 		return fmt.Errorf("failed to add job %s to cron: %w", name, err)
 	}
 
-	s.jobs[name] = job
+	sj.entryID = entryID
+	s.jobs[name] = sj
 	fmt.Printf("Registered background job: %s (schedule: %s)\n", name, job.Schedule())
 
 	return nil
@@ -81,6 +152,8 @@ func (s *Scheduler) Start() {
 	}
 
 	s.cron.Start()
+	s.running = true
+	s.startedAt = time.Now()
 	fmt.Printf("Background job scheduler started with %d jobs\n", len(s.jobs))
 }
 
@@ -102,49 +175,152 @@ func (s *Scheduler) Stop() {
 
 	// Wait for all running jobs to complete
 	s.wg.Wait()
+	s.running = false
 
 	fmt.Println("Background job scheduler stopped")
 }
 
-// RunNow runs a specific job immediately
-func (s *Scheduler) RunNow(jobName string) error {
+// RunNow runs a specific job immediately and waits for it to finish
+func (s *Scheduler) RunNow(jobName string) (*JobRun, error) {
+	sj, err := s.lookup(jobName)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Running job %s immediately...\n", jobName)
+	run, err := s.start(sj, RunTriggerManual)
+	if err != nil {
+		return nil, err
+	}
+	s.execute(sj, run)
+
+	return run, nil
+}
+
+// Trigger starts a run of a specific job in the background and returns it as started
+func (s *Scheduler) Trigger(jobName string) (*JobRun, error) {
+	sj, err := s.lookup(jobName)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Triggering job %s...\n", jobName)
+	run, err := s.start(sj, RunTriggerManual)
+	if err != nil {
+		return nil, err
+	}
+
+	started := *run
+	go s.execute(sj, run)
+
+	return &started, nil
+}
+
+// lookup returns the registered job with the given name
+func (s *Scheduler) lookup(jobName string) (*scheduledJob, error) {
 	s.mu.RLock()
-	job, exists := s.jobs[jobName]
-	s.mu.RUnlock()
+	defer s.mu.RUnlock()
 
+	sj, exists := s.jobs[jobName]
 	if !exists {
-		return fmt.Errorf("job %s not found", jobName)
+		return nil, ErrJobNotFound
 	}
+	return sj, nil
+}
 
-	fmt.Printf("Running job %s immediately...\n", jobName)
-	s.runJob(job)
+// start marks the job running and records the start of a run. It fails when the job is
+// still running, so a slow run is never overlapped by the next tick or a manual trigger.
+func (s *Scheduler) start(sj *scheduledJob, trigger string) (*JobRun, error) {
+	if s.ctx.Err() != nil {
+		return nil, ErrSchedulerStopped
+	}
 
-	return nil
-}
+	s.stateMu.Lock()
+	if sj.running {
+		s.stateMu.Unlock()
+		return nil, ErrJobAlreadyRunning
+	}
+	sj.running = true
+	s.stateMu.Unlock()
 
-// runJob executes a job with error handling
-func (s *Scheduler) runJob(job Job) {
 	s.wg.Add(1)
-	defer s.wg.Done()
 
-	name := job.Name()
-	startTime := time.Now()
+	run := &JobRun{JobName: sj.job.Name(), TriggeredBy: trigger, StartedAt: time.Now()}
+
+	s.tickMu.Lock()
+	s.lastTick = run.StartedAt
+	s.tickMu.Unlock()
+
+	if s.runs != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), runRecordTimeout)
+		defer cancel()
+		if err := s.runs.Create(ctx, run); err != nil {
+			fmt.Printf("Failed to record start of job %s: %v\n", run.JobName, err)
+		}
+	}
 
+	return run, nil
+}
+
+// execute runs a started job with its timeout and records the outcome
+func (s *Scheduler) execute(sj *scheduledJob, run *JobRun) {
+	defer s.wg.Done()
+
+	name := run.JobName
 	fmt.Printf("Starting job: %s\n", name)
 
 	// Run with timeout context
-	ctx, cancel := context.WithTimeout(s.ctx, 30*time.Minute)
+	ctx, cancel := context.WithTimeout(s.ctx, jobTimeout(sj.job))
 	defer cancel()
 
-	// Execute job
-	if err := job.Run(ctx); err != nil {
-		duration := time.Since(startTime)
-		fmt.Printf("Job %s failed after %v: %v\n", name, duration, err)
-		return
+	processed, err := runSafely(ctx, sj.job)
+
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.ItemsProcessed = processed
+	run.Success = err == nil
+	if err != nil {
+		message := err.Error()
+		run.Error = &message
+		fmt.Printf("Job %s failed after %v: %v\n", name, run.Duration(), err)
+	} else {
+		fmt.Printf("Job %s completed successfully in %v (%d items)\n", name, run.Duration(), processed)
+	}
+	metrics.Default().ObserveJobRun(name, run.Duration(), err)
+
+	if s.runs != nil {
+		recordCtx, recordCancel := context.WithTimeout(context.Background(), runRecordTimeout)
+		defer recordCancel()
+		if err := s.runs.Finish(recordCtx, run); err != nil {
+			fmt.Printf("Failed to record result of job %s: %v\n", name, err)
+		}
 	}
 
-	duration := time.Since(startTime)
-	fmt.Printf("Job %s completed successfully in %v\n", name, duration)
+	finished := *run
+	s.stateMu.Lock()
+	sj.running = false
+	sj.lastRun = &finished
+	s.stateMu.Unlock()
+}
+
+// runSafely runs the job, turning a panic into an error so it cannot take down the process
+func runSafely(ctx context.Context, job Job) (processed int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Printf("Job %s panicked: %v\n%s", job.Name(), r, debug.Stack())
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return job.Run(ctx)
+}
+
+// jobTimeout returns the run time limit of the job
+func jobTimeout(job Job) time.Duration {
+	if j, ok := job.(TimeoutJob); ok && j.Timeout() > 0 {
+		return j.Timeout()
+	}
+	return DefaultJobTimeout
 }
 
 // GetRegisteredJobs returns list of registered job names
@@ -162,16 +338,80 @@ func (s *Scheduler) GetRegisteredJobs() []string {
 
 // GetJobInfo returns information about a specific job
 func (s *Scheduler) GetJobInfo(jobName string) (map[string]interface{}, error) {
-	s.mu.RLock()
-	job, exists := s.jobs[jobName]
-	s.mu.RUnlock()
-
-	if !exists {
-		return nil, fmt.Errorf("job %s not found", jobName)
+	sj, err := s.lookup(jobName)
+	if err != nil {
+		return nil, err
 	}
 
 	return map[string]interface{}{
-		"name":     job.Name(),
-		"schedule": job.Schedule(),
+		"name":     sj.job.Name(),
+		"schedule": sj.job.Schedule(),
 	}, nil
 }
+
+// Schedules returns the registered jobs sorted by name. The last run is taken from the run
+// history when that is newer than this instance's, so runs made by other instances show too.
+func (s *Scheduler) Schedules(ctx context.Context) ([]JobSchedule, error) {
+	var latest map[string]JobRun
+	if s.runs != nil {
+		var err error
+		if latest, err = s.runs.LatestByJob(ctx); err != nil {
+			return nil, fmt.Errorf("failed to get job runs: %w", err)
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	schedules := make([]JobSchedule, 0, len(s.jobs))
+	for name, sj := range s.jobs {
+		schedule := JobSchedule{
+			Name:     name,
+			Schedule: sj.job.Schedule(),
+			Timeout:  jobTimeout(sj.job),
+		}
+
+		s.stateMu.Lock()
+		schedule.Running = sj.running
+		if sj.lastRun != nil {
+			lastRun := *sj.lastRun
+			schedule.LastRun = &lastRun
+		}
+		s.stateMu.Unlock()
+
+		if run, ok := latest[name]; ok && (schedule.LastRun == nil || run.StartedAt.After(schedule.LastRun.StartedAt)) {
+			schedule.LastRun = &run
+		}
+
+		if s.running {
+			if next := s.cron.Entry(sj.entryID).Next; !next.IsZero() {
+				schedule.NextRunAt = &next
+			}
+		}
+
+		schedules = append(schedules, schedule)
+	}
+
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].Name < schedules[j].Name
+	})
+
+	return schedules, nil
+}
+
+// Status returns the current scheduler state
+func (s *Scheduler) Status() SchedulerStatus {
+	s.tickMu.Lock()
+	lastTick := s.lastTick
+	s.tickMu.Unlock()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return SchedulerStatus{
+		Running:   s.running,
+		Jobs:      len(s.jobs),
+		StartedAt: s.startedAt,
+		LastTick:  lastTick,
+	}
+}
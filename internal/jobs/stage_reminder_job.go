@@ -0,0 +1,193 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/domain/user"
+
+	"github.com/sirupsen/logrus"
+)
+
+// stageReminderInterval is the minimum time before the same stage is included in another reminder
+const stageReminderInterval = 24 * time.Hour
+
+// StageReminderJob emails recruiters a daily summary of applications whose current
+// stage has been open longer than the company's threshold
+type StageReminderJob struct {
+	appRepo      application.ApplicationRepository
+	companyRepo  company.CompanyRepository
+	userRepo     user.UserRepository
+	emailService email.EmailService
+	logger       *logrus.Logger
+	config       StageReminderConfig
+}
+
+// StageReminderConfig holds configuration for the stage reminder job
+type StageReminderConfig struct {
+	DefaultThresholdDays int // Used for companies that have not saved their own threshold
+}
+
+// NewStageReminderJob creates a new stage reminder job
+func NewStageReminderJob(
+	appRepo application.ApplicationRepository,
+	companyRepo company.CompanyRepository,
+	userRepo user.UserRepository,
+	emailService email.EmailService,
+	logger *logrus.Logger,
+	config StageReminderConfig,
+) *StageReminderJob {
+	if config.DefaultThresholdDays == 0 {
+		config.DefaultThresholdDays = company.DefaultStageReminderDays
+	}
+
+	return &StageReminderJob{
+		appRepo:      appRepo,
+		companyRepo:  companyRepo,
+		userRepo:     userRepo,
+		emailService: emailService,
+		logger:       logger,
+		config:       config,
+	}
+}
+
+// Name returns the job name
+func (j *StageReminderJob) Name() string {
+	return "stage_reminder"
+}
+
+// Schedule returns the cron schedule (daily at 08:00)
+func (j *StageReminderJob) Schedule() string {
+	return "0 0 8 * * *" // Every day at 08:00:00
+}
+
+// Run executes the stage reminder job and returns the number of stale stages handled
+func (j *StageReminderJob) Run(ctx context.Context) (int, error) {
+	// Truncate so a run that starts a few seconds later tomorrow still sees today's marks as 24h old
+	runAt := time.Now().Truncate(time.Hour)
+
+	stages, err := j.appRepo.FindStaleStages(ctx, j.config.DefaultThresholdDays, runAt.Add(-stageReminderInterval))
+	if err != nil {
+		return 0, fmt.Errorf("failed to find stale stages: %w", err)
+	}
+
+	if len(stages) == 0 {
+		j.logger.Info("No stale application stages to remind")
+		return 0, nil
+	}
+
+	byCompany := make(map[int64][]application.StaleStage)
+	var companyIDs []int64
+	for _, stage := range stages {
+		if _, ok := byCompany[stage.CompanyID]; !ok {
+			companyIDs = append(companyIDs, stage.CompanyID)
+		}
+		byCompany[stage.CompanyID] = append(byCompany[stage.CompanyID], stage)
+	}
+
+	var failed, handled int
+	for _, companyID := range companyIDs {
+		if err := j.remindCompany(ctx, companyID, byCompany[companyID], runAt); err != nil {
+			j.logger.WithError(err).WithField("company_id", companyID).Error("Failed to send stage reminders")
+			failed++
+			continue
+		}
+		handled += len(byCompany[companyID])
+	}
+
+	j.logger.WithFields(logrus.Fields{
+		"stages":    len(stages),
+		"companies": len(companyIDs),
+		"failed":    failed,
+	}).Info("Stage reminder job completed")
+
+	if failed > 0 {
+		return handled, fmt.Errorf("stage reminders failed for %d of %d companies", failed, len(companyIDs))
+	}
+
+	return handled, nil
+}
+
+// remindCompany sends one summary to each recruiter-or-above of the company and marks the
+// stages as reminded once at least one recipient received it
+func (j *StageReminderJob) remindCompany(ctx context.Context, companyID int64, stages []application.StaleStage, runAt time.Time) error {
+	comp, err := j.companyRepo.FindByID(ctx, companyID)
+	if err != nil {
+		return fmt.Errorf("failed to get company: %w", err)
+	}
+	if comp == nil {
+		return fmt.Errorf("company %d not found", companyID)
+	}
+
+	employers, err := j.companyRepo.GetEmployerUsersByCompanyID(ctx, companyID)
+	if err != nil {
+		return fmt.Errorf("failed to get employer users: %w", err)
+	}
+
+	groups := groupStaleStagesByJob(stages, time.Now())
+	thresholdDays := stages[0].ThresholdDays
+
+	var recipients, sent int
+	for _, employer := range employers {
+		if !employer.CanManageJobs() {
+			continue
+		}
+		recipients++
+
+		recipient, err := j.userRepo.FindByID(ctx, employer.UserID)
+		if err != nil || recipient == nil {
+			j.logger.WithField("user_id", employer.UserID).Warn("Skipping stage reminder for unknown user")
+			continue
+		}
+
+		if err := j.emailService.SendStageReminderEmail(ctx, recipient.Email, recipient.FullName, comp.CompanyName, thresholdDays, groups); err != nil {
+			j.logger.WithError(err).WithField("user_id", employer.UserID).Warn("Failed to send stage reminder email")
+			continue
+		}
+		sent++
+	}
+
+	if recipients == 0 {
+		j.logger.WithField("company_id", companyID).Info("No recruiters to receive stage reminders")
+		return nil
+	}
+	if sent == 0 {
+		return fmt.Errorf("no recruiter received the reminder")
+	}
+
+	stageIDs := make([]int64, len(stages))
+	for i, stage := range stages {
+		stageIDs[i] = stage.StageID
+	}
+	if err := j.appRepo.MarkStagesReminded(ctx, stageIDs, runAt); err != nil {
+		return fmt.Errorf("failed to mark stages reminded: %w", err)
+	}
+
+	return nil
+}
+
+// groupStaleStagesByJob groups stages by job, keeping the order the stages arrived in
+func groupStaleStagesByJob(stages []application.StaleStage, now time.Time) []email.StageReminderGroup {
+	var groups []email.StageReminderGroup
+	index := make(map[int64]int)
+
+	for _, stage := range stages {
+		i, ok := index[stage.JobID]
+		if !ok {
+			i = len(groups)
+			index[stage.JobID] = i
+			groups = append(groups, email.StageReminderGroup{JobTitle: stage.JobTitle})
+		}
+		groups[i].Applications = append(groups[i].Applications, email.StageReminderItem{
+			ApplicantName: stage.ApplicantName,
+			StageName:     stage.StageName,
+			DaysInStage:   int(now.Sub(stage.StartedAt).Hours() / 24),
+		})
+	}
+
+	return groups
+}
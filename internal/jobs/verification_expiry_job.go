@@ -0,0 +1,55 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"keerja-backend/internal/domain/company"
+
+	"github.com/sirupsen/logrus"
+)
+
+// VerificationExpiryJob warns employers about expiring company verifications and
+// expires them once the grace period has ended
+type VerificationExpiryJob struct {
+	expiryService company.VerificationExpiryService
+	logger        *logrus.Logger
+}
+
+// NewVerificationExpiryJob creates a new verification expiry job
+func NewVerificationExpiryJob(expiryService company.VerificationExpiryService, logger *logrus.Logger) *VerificationExpiryJob {
+	return &VerificationExpiryJob{
+		expiryService: expiryService,
+		logger:        logger,
+	}
+}
+
+// Name returns the job name
+func (j *VerificationExpiryJob) Name() string {
+	return "verification_expiry"
+}
+
+// Schedule returns the cron schedule (every hour)
+func (j *VerificationExpiryJob) Schedule() string {
+	return "0 30 * * * *" // Every hour at minute 30
+}
+
+// Run sends due expiry warnings and expires lapsed verifications
+func (j *VerificationExpiryJob) Run(ctx context.Context) (int, error) {
+	stats, err := j.expiryService.CheckVerificationExpiry(ctx)
+	var processed int
+	if stats != nil {
+		processed = stats.WarningsSent + stats.EnteredGrace + stats.Expired
+	}
+	if processed > 0 {
+		j.logger.WithFields(logrus.Fields{
+			"warnings_sent": stats.WarningsSent,
+			"entered_grace": stats.EnteredGrace,
+			"expired":       stats.Expired,
+		}).Info("Processed company verification expiry")
+	}
+	if err != nil {
+		return processed, fmt.Errorf("failed to check verification expiry: %w", err)
+	}
+	return processed, nil
+}
@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"keerja-backend/internal/domain/webhook"
+
+	"github.com/sirupsen/logrus"
+)
+
+// WebhookDeliveryJob sends company webhook deliveries that are due, retrying failures with backoff
+type WebhookDeliveryJob struct {
+	webhookService webhook.WebhookService
+	logger         *logrus.Logger
+}
+
+// NewWebhookDeliveryJob creates a new webhook delivery job
+func NewWebhookDeliveryJob(webhookService webhook.WebhookService, logger *logrus.Logger) *WebhookDeliveryJob {
+	return &WebhookDeliveryJob{
+		webhookService: webhookService,
+		logger:         logger,
+	}
+}
+
+// Name returns the job name
+func (j *WebhookDeliveryJob) Name() string {
+	return "webhook_delivery"
+}
+
+// Schedule returns the cron schedule (every 15 seconds)
+func (j *WebhookDeliveryJob) Schedule() string {
+	return "*/15 * * * * *"
+}
+
+// Run sends the due webhook deliveries
+func (j *WebhookDeliveryJob) Run(ctx context.Context) (int, error) {
+	count, err := j.webhookService.ProcessDue(ctx)
+	if count > 0 {
+		j.logger.WithField("deliveries", count).Info("Delivered webhook events")
+	}
+	if err != nil {
+		return count, fmt.Errorf("failed to process webhook deliveries: %w", err)
+	}
+	return count, nil
+}
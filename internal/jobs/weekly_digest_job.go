@@ -0,0 +1,215 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/domain/user"
+
+	"github.com/sirupsen/logrus"
+)
+
+// WeeklyDigestJob emails the recruiters of each company a weekly summary of new and
+// waiting applications, upcoming interviews, expiring jobs and follower growth
+type WeeklyDigestJob struct {
+	digestRepo   company.DigestRepository
+	companyRepo  company.CompanyRepository
+	userRepo     user.UserRepository
+	emailService email.EmailService
+	logger       *logrus.Logger
+}
+
+// NewWeeklyDigestJob creates a new weekly digest job
+func NewWeeklyDigestJob(
+	digestRepo company.DigestRepository,
+	companyRepo company.CompanyRepository,
+	userRepo user.UserRepository,
+	emailService email.EmailService,
+	logger *logrus.Logger,
+) *WeeklyDigestJob {
+	return &WeeklyDigestJob{
+		digestRepo:   digestRepo,
+		companyRepo:  companyRepo,
+		userRepo:     userRepo,
+		emailService: emailService,
+		logger:       logger,
+	}
+}
+
+// Name returns the job name
+func (j *WeeklyDigestJob) Name() string {
+	return "weekly_digest"
+}
+
+// Schedule returns the cron schedule (Mondays at 08:00)
+func (j *WeeklyDigestJob) Schedule() string {
+	return "0 0 8 * * 1" // Every Monday at 08:00:00
+}
+
+// Run executes the weekly digest job and returns the number of companies sent a digest
+func (j *WeeklyDigestJob) Run(ctx context.Context) (int, error) {
+	runAt := time.Now().Truncate(time.Hour)
+
+	// Companies sent a digest in the last few days are left out, so a rerun sends no duplicates
+	companies, err := j.digestRepo.FindWeeklyDigestCompanies(ctx, runAt.Add(-company.WeeklyDigestResendAfter))
+	if err != nil {
+		return 0, fmt.Errorf("failed to find digest companies: %w", err)
+	}
+
+	var failed, sent, skipped int
+	for _, comp := range companies {
+		digest, err := j.buildDigest(ctx, comp, runAt)
+		if err != nil {
+			j.logger.WithError(err).WithField("company_id", comp.CompanyID).Error("Failed to build weekly digest")
+			failed++
+			continue
+		}
+		if !digest.ShouldSend() {
+			skipped++
+			continue
+		}
+
+		if err := j.sendDigest(ctx, digest, runAt); err != nil {
+			j.logger.WithError(err).WithField("company_id", comp.CompanyID).Error("Failed to send weekly digest")
+			failed++
+			continue
+		}
+		sent++
+	}
+
+	j.logger.WithFields(logrus.Fields{
+		"companies": len(companies),
+		"sent":      sent,
+		"skipped":   skipped,
+		"failed":    failed,
+	}).Info("Weekly digest job completed")
+
+	if failed > 0 {
+		return sent, fmt.Errorf("weekly digest failed for %d of %d companies", failed, len(companies))
+	}
+
+	return sent, nil
+}
+
+// buildDigest aggregates the company's activity for the week up to runAt
+func (j *WeeklyDigestJob) buildDigest(ctx context.Context, comp company.DigestCompany, runAt time.Time) (*company.WeeklyDigest, error) {
+	since := runAt.Add(-company.WeeklyDigestPeriod)
+	until := runAt.Add(company.DigestLookahead)
+
+	jobs, err := j.digestRepo.GetDigestJobActivity(ctx, comp.CompanyID, since, runAt.Add(-company.DigestAwaitingActionAfter))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job activity: %w", err)
+	}
+	interviews, err := j.digestRepo.GetDigestInterviews(ctx, comp.CompanyID, runAt, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get interviews: %w", err)
+	}
+	expiring, err := j.digestRepo.GetDigestExpiringJobs(ctx, comp.CompanyID, runAt, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expiring jobs: %w", err)
+	}
+	followers, err := j.digestRepo.GetFollowerGrowth(ctx, comp.CompanyID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follower growth: %w", err)
+	}
+
+	return &company.WeeklyDigest{
+		Company:      comp,
+		Jobs:         jobs,
+		Interviews:   interviews,
+		ExpiringJobs: expiring,
+		Followers:    *followers,
+	}, nil
+}
+
+// sendDigest sends the digest to each recruiter-or-above who accepts email notifications and
+// marks the company sent once at least one of them received it, or none of them wants it
+func (j *WeeklyDigestJob) sendDigest(ctx context.Context, digest *company.WeeklyDigest, runAt time.Time) error {
+	companyID := digest.Company.CompanyID
+	employers, err := j.companyRepo.GetEmployerUsersByCompanyID(ctx, companyID)
+	if err != nil {
+		return fmt.Errorf("failed to get employer users: %w", err)
+	}
+
+	data := toDigestEmail(digest)
+
+	var recipients, sent int
+	for _, employer := range employers {
+		if !employer.CanManageJobs() {
+			continue
+		}
+
+		pref, err := j.userRepo.FindPreferenceByUserID(ctx, employer.UserID)
+		if err != nil {
+			j.logger.WithError(err).WithField("user_id", employer.UserID).Warn("Skipping weekly digest for user without readable preferences")
+			continue
+		}
+		if pref != nil && !pref.EmailNotifications {
+			continue
+		}
+		recipients++
+
+		recipient, err := j.userRepo.FindByID(ctx, employer.UserID)
+		if err != nil || recipient == nil {
+			j.logger.WithField("user_id", employer.UserID).Warn("Skipping weekly digest for unknown user")
+			continue
+		}
+
+		if err := j.emailService.SendWeeklyDigestEmail(ctx, recipient.Email, recipient.FullName, digest.Company.CompanyName, data); err != nil {
+			j.logger.WithError(err).WithField("user_id", employer.UserID).Warn("Failed to send weekly digest email")
+			continue
+		}
+		sent++
+	}
+
+	if recipients > 0 && sent == 0 {
+		return fmt.Errorf("no recruiter received the digest")
+	}
+	if recipients == 0 {
+		j.logger.WithField("company_id", companyID).Info("No recruiters to receive the weekly digest")
+	}
+
+	if err := j.digestRepo.MarkWeeklyDigestSent(ctx, companyID, runAt); err != nil {
+		return fmt.Errorf("failed to mark weekly digest sent: %w", err)
+	}
+
+	return nil
+}
+
+// toDigestEmail converts the aggregated digest into the email's template data
+func toDigestEmail(digest *company.WeeklyDigest) *email.WeeklyDigest {
+	data := &email.WeeklyDigest{
+		AwaitingAction: digest.AwaitingAction(),
+		NewFollowers:   digest.Followers.NewFollowers,
+		TotalFollowers: digest.Followers.TotalFollowers,
+	}
+
+	for _, j := range digest.Jobs {
+		data.Jobs = append(data.Jobs, email.WeeklyDigestJob{
+			JobID:           j.JobID,
+			Title:           j.JobTitle,
+			NewApplications: j.NewApplications,
+			AwaitingAction:  j.AwaitingAction,
+		})
+	}
+	for _, i := range digest.Interviews {
+		data.Interviews = append(data.Interviews, email.WeeklyDigestInterview{
+			ApplicationID: i.ApplicationID,
+			CandidateName: i.CandidateName,
+			JobTitle:      i.JobTitle,
+			ScheduledAt:   i.ScheduledAt,
+		})
+	}
+	for _, j := range digest.ExpiringJobs {
+		data.ExpiringJobs = append(data.ExpiringJobs, email.WeeklyDigestExpiringJob{
+			JobID:     j.JobID,
+			Title:     j.JobTitle,
+			ExpiredAt: j.ExpiredAt,
+		})
+	}
+
+	return data
+}
@@ -0,0 +1,211 @@
+// Package metrics records runtime metrics and serves them in the Prometheus text format.
+//
+// A nil *Metrics is the no-op mode: every method returns without recording. Default is nil
+// until SetDefault is called, so tests and commands that never serve /metrics pay nothing
+// for the instrumentation in the cache, scheduler and push code.
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Path is where the metrics endpoint is served
+const Path = "/metrics"
+
+// namespace prefixes the application's own metrics
+const namespace = "keerja"
+
+// Cache event labels
+const (
+	CacheHit      = "hit"
+	CacheMiss     = "miss"
+	CacheEviction = "eviction"
+)
+
+// scrapeTimeout bounds the queries gauges run while /metrics is scraped
+const scrapeTimeout = 2 * time.Second
+
+// Metrics holds the collectors of one registry
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	cacheEvents  *prometheus.CounterVec
+	jobRuns      *prometheus.CounterVec
+	jobDuration  *prometheus.HistogramVec
+	pushSends    *prometheus.CounterVec
+	slowQueries  prometheus.Counter
+}
+
+// New creates metrics on a new registry, which also collects Go runtime and process stats
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route pattern and status.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method, route pattern and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		cacheEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_events_total",
+			Help:      "Cache hits, misses and evictions by cache.",
+		}, []string{"cache", "event"}),
+		jobRuns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "job_runs_total",
+			Help:      "Scheduled job runs by job and result.",
+		}, []string{"job", "result"}),
+		jobDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "job_run_duration_seconds",
+			Help:      "Scheduled job run duration by job.",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 4, 8), // 0.1s to about 27 minutes
+		}, []string{"job"}),
+		pushSends: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "push_sends_total",
+			Help:      "FCM push messages by result.",
+		}, []string{"result"}),
+		slowQueries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "db_slow_queries_total",
+			Help:      "SQL queries slower than the slow query threshold.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.cacheEvents,
+		m.jobRuns,
+		m.jobDuration,
+		m.pushSends,
+		m.slowQueries,
+	)
+	return m
+}
+
+// Handler serves the registry in the Prometheus text format
+func (m *Metrics) Handler() fiber.Handler {
+	if m == nil {
+		return func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusNotFound)
+		}
+	}
+	return adaptor.HTTPHandler(promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+}
+
+// ObserveRequest records an HTTP request. route is the matched route pattern, never the raw
+// path, so IDs in URLs do not create a series per request.
+func (m *Metrics) ObserveRequest(method, route string, status int, elapsed time.Duration) {
+	if m == nil {
+		return
+	}
+	code := strconv.Itoa(status)
+	m.httpRequests.WithLabelValues(method, route, code).Inc()
+	m.httpDuration.WithLabelValues(method, route, code).Observe(elapsed.Seconds())
+}
+
+// CacheEvent records a cache hit, miss or eviction
+func (m *Metrics) CacheEvent(cache, event string) {
+	if m == nil {
+		return
+	}
+	m.cacheEvents.WithLabelValues(cache, event).Inc()
+}
+
+// ObserveJobRun records a scheduled job run and whether it failed
+func (m *Metrics) ObserveJobRun(job string, elapsed time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	m.jobRuns.WithLabelValues(job, result).Inc()
+	m.jobDuration.WithLabelValues(job).Observe(elapsed.Seconds())
+}
+
+// ObservePushSends records how many push messages FCM accepted and rejected
+func (m *Metrics) ObservePushSends(success, failure int) {
+	if m == nil {
+		return
+	}
+	if success > 0 {
+		m.pushSends.WithLabelValues("success").Add(float64(success))
+	}
+	if failure > 0 {
+		m.pushSends.WithLabelValues("failure").Add(float64(failure))
+	}
+}
+
+// SlowQuery records a SQL query slower than the slow query threshold
+func (m *Metrics) SlowQuery() {
+	if m == nil {
+		return
+	}
+	m.slowQueries.Inc()
+}
+
+// RegisterDB collects the connection pool stats of db, labeled with name
+func (m *Metrics) RegisterDB(db *sql.DB, name string) error {
+	if m == nil {
+		return nil
+	}
+	return m.registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// RegisterEmailQueueDepth reports the number of pending queued emails, counted by pending
+// on every scrape. A failed count is reported as NaN rather than a misleading number.
+func (m *Metrics) RegisterEmailQueueDepth(pending func(ctx context.Context) (int64, error)) error {
+	if m == nil {
+		return nil
+	}
+	return m.registry.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "email_queue_depth",
+		Help:      "Queued emails waiting to be sent.",
+	}, func() float64 {
+		ctx, cancel := context.WithTimeout(context.Background(), scrapeTimeout)
+		defer cancel()
+		n, err := pending(ctx)
+		if err != nil {
+			return math.NaN()
+		}
+		return float64(n)
+	}))
+}
+
+var current atomic.Pointer[Metrics]
+
+// Default returns the metrics the instrumented packages record to, nil until SetDefault
+func Default() *Metrics {
+	return current.Load()
+}
+
+// SetDefault makes m the metrics the instrumented packages record to; nil turns them off
+func SetDefault(m *Metrics) {
+	current.Store(m)
+}
@@ -14,13 +14,15 @@ import (
 type AdminAuthMiddleware struct {
 	config        *config.Config
 	adminUserRepo admin.AdminUserRepository
+	permissions   admin.AdminPermissionService
 }
 
 // NewAdminAuthMiddleware creates new admin auth middleware
-func NewAdminAuthMiddleware(cfg *config.Config, adminUserRepo admin.AdminUserRepository) *AdminAuthMiddleware {
+func NewAdminAuthMiddleware(cfg *config.Config, adminUserRepo admin.AdminUserRepository, permissions admin.AdminPermissionService) *AdminAuthMiddleware {
 	return &AdminAuthMiddleware{
 		config:        cfg,
 		adminUserRepo: adminUserRepo,
+		permissions:   permissions,
 	}
 }
 
@@ -43,7 +45,7 @@ func (m *AdminAuthMiddleware) AdminAuthRequired() fiber.Handler {
 		}
 
 		// Verify admin exists and is active
-		adminUser, err := m.adminUserRepo.FindByID(c.Context(), claims.AdminID)
+		adminUser, err := m.adminUserRepo.FindByID(c.UserContext(), claims.AdminID)
 		if err != nil || adminUser == nil {
 			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Admin not found", "Admin account does not exist")
 		}
@@ -82,6 +84,29 @@ func (m *AdminAuthMiddleware) AdminRoleRequired(minAccessLevel int16) fiber.Hand
 	}
 }
 
+// RequirePermission middleware allows admins whose role grants perm. Denied requests get
+// 403 naming the missing permission. Must run after AdminAuthRequired.
+func (m *AdminAuthMiddleware) RequirePermission(perm string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		adminID, ok := c.Locals("admin_id").(int64)
+		if !ok {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Authentication required", "Admin not found in context")
+		}
+
+		allowed, err := m.permissions.HasPermission(c.UserContext(), adminID, perm)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return admin.ErrPermissionDenied.
+				WithMessage("your admin role lacks the "+perm+" permission").
+				WithField("permission", perm)
+		}
+
+		return c.Next()
+	}
+}
+
 // SuperAdminOnly middleware allows only super admins (access level >= 9)
 func (m *AdminAuthMiddleware) SuperAdminOnly() fiber.Handler {
 	return m.AdminRoleRequired(9)
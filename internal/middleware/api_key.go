@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"keerja-backend/internal/domain/apikey"
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HeaderAPIKey carries a company API key on integration requests
+const HeaderAPIKey = "X-Api-Key"
+
+// ContextKeyAPIKey stores the APIKeyPrincipal of a request authenticated by APIKeyAuth
+const ContextKeyAPIKey = "api_key"
+
+// APIKeyPrincipal is the restricted identity of a request made with a company API key.
+// It carries no user, so routes guarded by AuthRequired stay out of reach.
+type APIKeyPrincipal struct {
+	KeyID     int64
+	CompanyID int64
+	Scopes    []string
+}
+
+// HasScope checks if the key behind the request was granted scope
+func (p *APIKeyPrincipal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeyAuth authenticates requests by the X-Api-Key header and stores the key's
+// principal and company in the context, along with a viewer EmployerContext for the
+// company so employer handlers serve the key read-only. API keys are read-only, so any
+// method other than GET or HEAD is denied.
+func APIKeyAuth(apiKeys apikey.APIKeyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		rawKey := c.Get(HeaderAPIKey)
+		if rawKey == "" {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "API key required", HeaderAPIKey+" header missing")
+		}
+
+		key, err := apiKeys.Authenticate(c.UserContext(), rawKey)
+		if err != nil {
+			return err
+		}
+
+		if c.Method() != fiber.MethodGet && c.Method() != fiber.MethodHead {
+			return apikey.ErrAPIKeyScopeDenied
+		}
+
+		c.Locals(ContextKeyAPIKey, &APIKeyPrincipal{
+			KeyID:     key.ID,
+			CompanyID: key.CompanyID,
+			Scopes:    key.Scopes,
+		})
+		c.Locals("company_id", key.CompanyID)
+		c.Locals("employer_context", &employer.EmployerContext{CompanyID: key.CompanyID, Role: employer.RoleViewer})
+
+		return c.Next()
+	}
+}
+
+// RequireAPIKeyScope allows requests whose key was granted scope and, when the route has
+// a company :id, belongs to that company. Must run after APIKeyAuth.
+func RequireAPIKeyScope(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		principal := GetAPIKeyPrincipal(c)
+		if principal == nil {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "API key required", HeaderAPIKey+" header missing")
+		}
+		if !principal.HasScope(scope) {
+			return apikey.ErrAPIKeyScopeDenied
+		}
+
+		if c.Params("id") != "" {
+			companyID, err := c.ParamsInt("id")
+			if err != nil {
+				return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid company ID", err.Error())
+			}
+			if int64(companyID) != principal.CompanyID {
+				return apikey.ErrAPIKeyScopeDenied
+			}
+		}
+
+		return c.Next()
+	}
+}
+
+// GetAPIKeyPrincipal retrieves the API key principal from context
+func GetAPIKeyPrincipal(c *fiber.Ctx) *APIKeyPrincipal {
+	if principal, ok := c.Locals(ContextKeyAPIKey).(*APIKeyPrincipal); ok {
+		return principal
+	}
+	return nil
+}
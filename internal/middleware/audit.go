@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"context"
+
+	"keerja-backend/internal/domain/audit"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AuditContext returns the request context carrying the authenticated admin, employer or
+// job seeker and the client IP, so audit log entries recorded by services are attributed to them.
+// Requests made while impersonating a user are attributed to the impersonating admin.
+func AuditContext(c *fiber.Ctx) context.Context {
+	actor := audit.Actor{IP: c.IP()}
+	if adminID := GetAdminID(c); adminID != 0 {
+		actor.Type, actor.ID = audit.ActorAdmin, adminID
+	} else if imp := GetImpersonation(c); imp != nil {
+		actor.Type, actor.ID = audit.ActorAdmin, imp.AdminID
+	} else if userID := GetUserID(c); userID != 0 {
+		actor.Type, actor.ID = audit.ActorEmployer, userID
+		if GetUserType(c) == "jobseeker" {
+			actor.Type = audit.ActorUser
+		}
+	}
+	return audit.WithActor(c.UserContext(), actor)
+}
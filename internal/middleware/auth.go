@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"strings"
 
 	"keerja-backend/internal/config"
@@ -17,15 +18,26 @@ const (
 	ContextKeyClaims   = "claims"
 )
 
+// SessionValidator reports whether a refresh token session is still active
+type SessionValidator interface {
+	IsSessionActive(ctx context.Context, sessionID string) (bool, error)
+}
+
 // AuthMiddleware creates authentication middleware
 type AuthMiddleware struct {
-	config *config.Config
+	config        *config.Config
+	sessions      SessionValidator
+	impersonation ImpersonationTracker
 }
 
-// NewAuthMiddleware creates a new auth middleware instance
-func NewAuthMiddleware(cfg *config.Config) *AuthMiddleware {
+// NewAuthMiddleware creates a new auth middleware instance.
+// When sessions is set, access tokens bound to a revoked session are rejected.
+// Impersonation tokens are accepted only when impersonation is set.
+func NewAuthMiddleware(cfg *config.Config, sessions SessionValidator, impersonation ImpersonationTracker) *AuthMiddleware {
 	return &AuthMiddleware{
-		config: cfg,
+		config:        cfg,
+		sessions:      sessions,
+		impersonation: impersonation,
 	}
 }
 
@@ -47,12 +59,23 @@ func (m *AuthMiddleware) AuthRequired() fiber.Handler {
 			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid token", err.Error())
 		}
 
+		if claims.IsImpersonation() {
+			if !m.impersonationActive(c, claims) {
+				return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Impersonation ended", "This impersonation session has ended.")
+			}
+		} else if !m.sessionActive(c, claims) {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Session revoked", "This session has been revoked. Please login again.")
+		}
+
 		// Store user info in context
 		c.Locals(ContextKeyUserID, claims.UserID)
 		c.Locals(ContextKeyEmail, claims.Email)
 		c.Locals(ContextKeyUserType, claims.UserType)
 		c.Locals(ContextKeyClaims, claims)
 
+		if claims.IsImpersonation() {
+			return m.serveImpersonated(c, claims)
+		}
 		return c.Next()
 	}
 }
@@ -69,8 +92,8 @@ func (m *AuthMiddleware) OptionalAuth() fiber.Handler {
 
 		// Validate token
 		claims, err := utils.ValidateToken(token, m.config.JWTSecret)
-		if err != nil {
-			// Invalid token, continue without authentication
+		if err != nil || !m.tokenActive(c, claims) {
+			// Invalid token, revoked session or ended impersonation, continue without authentication
 			return c.Next()
 		}
 
@@ -80,10 +103,31 @@ func (m *AuthMiddleware) OptionalAuth() fiber.Handler {
 		c.Locals(ContextKeyUserType, claims.UserType)
 		c.Locals(ContextKeyClaims, claims)
 
+		if claims.IsImpersonation() {
+			return m.serveImpersonated(c, claims)
+		}
 		return c.Next()
 	}
 }
 
+// tokenActive checks that the session or impersonation the token was issued for is still active
+func (m *AuthMiddleware) tokenActive(c *fiber.Ctx, claims *utils.Claims) bool {
+	if claims.IsImpersonation() {
+		return m.impersonationActive(c, claims)
+	}
+	return m.sessionActive(c, claims)
+}
+
+// sessionActive checks that the session the token was issued for (if any) is not revoked
+func (m *AuthMiddleware) sessionActive(c *fiber.Ctx, claims *utils.Claims) bool {
+	if claims.SessionID == "" || m.sessions == nil {
+		return true
+	}
+
+	active, err := m.sessions.IsSessionActive(c.UserContext(), claims.SessionID)
+	return err == nil && active
+}
+
 // RoleRequired middleware checks if user has required role
 func (m *AuthMiddleware) RoleRequired(allowedRoles ...string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -1,159 +1,96 @@
 package middleware
 
 import (
+	"strconv"
 	"strings"
 
 	"keerja-backend/internal/config"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/log"
 )
 
-// CORSConfig returns CORS middleware with configuration
+// corsExposedHeaders are the response headers browsers let scripts read. They come from
+// the constants the middlewares set, so a renamed header stays exposed.
+var corsExposedHeaders = strings.Join([]string{
+	fiber.HeaderContentLength,
+	fiber.HeaderContentType,
+	fiber.HeaderAuthorization,
+	CompanyIDHeader,
+	RequestIDHeader,
+	HeaderRateLimitLimit,
+	HeaderRateLimitRemaining,
+	HeaderRateLimitReset,
+	fiber.HeaderRetryAfter,
+	HeaderIdempotentReplayed,
+	HeaderImpersonatedBy,
+}, ", ")
+
+// CORSConfig returns the CORS middleware for cfg's allowed origins. Only the request's
+// origin is echoed back when it matches, and preflight requests are answered here so
+// they never reach the rate limiter or auth.
 func CORSConfig(cfg *config.Config) fiber.Handler {
-	// Determine allowed origins
-	allowedOrigins := cfg.AllowedOrigins
-	if len(allowedOrigins) == 0 {
-		// Default to localhost in development
-		if cfg.AppEnv == "development" {
-			allowedOrigins = []string{
-				"http://localhost:3000",
-				"http://localhost:3001",
-				"http://localhost:5173", // Vite default
-				"http://127.0.0.1:3000",
-			}
-		} else {
-			// In production, must specify allowed origins
-			allowedOrigins = []string{"https://yourdomain.com"}
+	origins := make([]config.CORSOrigin, 0, len(cfg.CORSAllowedOrigins))
+	for _, pattern := range cfg.CORSAllowedOrigins {
+		origin, err := config.ParseCORSOrigin(pattern)
+		if err != nil {
+			log.Warnf("cors: ignoring allowed origin: %v", err)
+			continue
 		}
+		origins = append(origins, origin)
 	}
 
-	return cors.New(cors.Config{
-		AllowOrigins: strings.Join(allowedOrigins, ","),
-		AllowMethods: strings.Join([]string{
-			fiber.MethodGet,
-			fiber.MethodPost,
-			fiber.MethodPut,
-			fiber.MethodPatch,
-			fiber.MethodDelete,
-			fiber.MethodOptions,
-		}, ","),
-		AllowHeaders: strings.Join([]string{
-			"Origin",
-			"Content-Type",
-			"Accept",
-			"Authorization",
-			"X-Requested-With",
-		}, ","),
-		AllowCredentials: true,
-		ExposeHeaders: strings.Join([]string{
-			"Content-Length",
-			"Content-Type",
-			"Authorization",
-		}, ", "),
-		MaxAge: 3600, // 1 hour
-	})
-}
+	allowMethods := strings.Join(cfg.CORSAllowedMethods, ", ")
+	allowHeaders := strings.Join(cfg.CORSAllowedHeaders, ", ")
+	maxAge := strconv.Itoa(int(cfg.CORSMaxAge.Seconds()))
 
-// CustomCORS creates a custom CORS middleware with more control
-type CustomCORSConfig struct {
-	AllowedOrigins   []string
-	AllowedMethods   []string
-	AllowedHeaders   []string
-	ExposedHeaders   []string
-	AllowCredentials bool
-	MaxAge           int
-}
+	return func(c *fiber.Ctx) error {
+		origin := c.Get(fiber.HeaderOrigin)
+		preflight := c.Method() == fiber.MethodOptions && c.Get(fiber.HeaderAccessControlRequestMethod) != ""
 
-// NewCustomCORS creates a custom CORS middleware
-func NewCustomCORS(config CustomCORSConfig) fiber.Handler {
-	// Set defaults
-	if len(config.AllowedMethods) == 0 {
-		config.AllowedMethods = []string{
-			fiber.MethodGet,
-			fiber.MethodPost,
-			fiber.MethodPut,
-			fiber.MethodPatch,
-			fiber.MethodDelete,
-			fiber.MethodOptions,
+		// Responses differ by origin, so shared caches must not mix them up
+		c.Vary(fiber.HeaderOrigin)
+		if origin == "" {
+			return c.Next()
 		}
-	}
 
-	if len(config.AllowedHeaders) == 0 {
-		config.AllowedHeaders = []string{
-			"Origin",
-			"Content-Type",
-			"Accept",
-			"Authorization",
+		if !isOriginAllowed(origin, origins) {
+			log.Debugf("cors: rejected origin %q for %s %s", origin, c.Method(), c.Path())
+			if preflight {
+				return c.SendStatus(fiber.StatusNoContent)
+			}
+			return c.Next()
 		}
-	}
-
-	if config.MaxAge == 0 {
-		config.MaxAge = 3600
-	}
 
-	return cors.New(cors.Config{
-		AllowOrigins:     strings.Join(config.AllowedOrigins, ", "),
-		AllowMethods:     strings.Join(config.AllowedMethods, ", "),
-		AllowHeaders:     strings.Join(config.AllowedHeaders, ", "),
-		ExposeHeaders:    strings.Join(config.ExposedHeaders, ", "),
-		AllowCredentials: config.AllowCredentials,
-		MaxAge:           config.MaxAge,
-	})
-}
-
-// DynamicCORS validates origins dynamically
-func DynamicCORS(allowedOrigins []string) fiber.Handler {
-	return func(c *fiber.Ctx) error {
-		origin := c.Get("Origin")
-
-		// Check if origin is allowed
-		if isOriginAllowed(origin, allowedOrigins) {
-			c.Set("Access-Control-Allow-Origin", origin)
-			c.Set("Access-Control-Allow-Credentials", "true")
-			c.Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-			c.Set("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization, X-Requested-With")
-			c.Set("Access-Control-Expose-Headers", "Content-Length, Content-Type, Authorization")
-			c.Set("Access-Control-Max-Age", "3600")
+		c.Set(fiber.HeaderAccessControlAllowOrigin, origin)
+		if cfg.CORSAllowCredentials {
+			c.Set(fiber.HeaderAccessControlAllowCredentials, "true")
 		}
 
-		// Handle preflight requests
-		if c.Method() == fiber.MethodOptions {
-			return c.SendStatus(fiber.StatusNoContent)
+		if !preflight {
+			c.Set(fiber.HeaderAccessControlExposeHeaders, corsExposedHeaders)
+			return c.Next()
 		}
 
-		return c.Next()
+		c.Vary(fiber.HeaderAccessControlRequestMethod, fiber.HeaderAccessControlRequestHeaders)
+		c.Set(fiber.HeaderAccessControlAllowMethods, allowMethods)
+		if allowHeaders != "" {
+			c.Set(fiber.HeaderAccessControlAllowHeaders, allowHeaders)
+		}
+		if cfg.CORSMaxAge > 0 {
+			c.Set(fiber.HeaderAccessControlMaxAge, maxAge)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
 	}
 }
 
-// isOriginAllowed checks if origin is in allowed list
-func isOriginAllowed(origin string, allowedOrigins []string) bool {
-	// Allow all origins if list is empty (development only)
-	if len(allowedOrigins) == 0 {
-		return true
-	}
-
-	// Check if origin matches any allowed origin
-	for _, allowed := range allowedOrigins {
-		// Support wildcard (*) in allowed origins
-		if allowed == "*" {
-			return true
-		}
-
-		// Exact match
-		if origin == allowed {
+// isOriginAllowed checks the origin against the parsed allowed origins
+func isOriginAllowed(origin string, allowed []config.CORSOrigin) bool {
+	for _, pattern := range allowed {
+		if pattern.Matches(origin) {
 			return true
 		}
-
-		// Support subdomain wildcard (*.example.com)
-		if strings.HasPrefix(allowed, "*.") {
-			domain := strings.TrimPrefix(allowed, "*.")
-			if strings.HasSuffix(origin, domain) {
-				return true
-			}
-		}
 	}
-
 	return false
 }
 
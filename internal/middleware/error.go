@@ -1,16 +1,33 @@
 package middleware
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 
+	"keerja-backend/internal/apperror"
 	"keerja-backend/internal/utils"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/log"
+	fiberutils "github.com/gofiber/fiber/v2/utils"
 	"gorm.io/gorm"
 )
 
+// codeInternalError is the code sent for errors that are not application errors
+const codeInternalError = "INTERNAL_ERROR"
+
+// appErrorStatus maps application error kinds to HTTP status codes
+var appErrorStatus = map[apperror.Kind]int{
+	apperror.KindNotFound:     fiber.StatusNotFound,
+	apperror.KindConflict:     fiber.StatusConflict,
+	apperror.KindValidation:   fiber.StatusBadRequest,
+	apperror.KindForbidden:    fiber.StatusForbidden,
+	apperror.KindUnauthorized: fiber.StatusUnauthorized,
+	apperror.KindRateLimited:  fiber.StatusTooManyRequests,
+}
+
 // ErrorHandler is a global error handler for the application
 func ErrorHandler(isDevelopment bool) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -22,6 +39,17 @@ func ErrorHandler(isDevelopment bool) fiber.Handler {
 			return nil
 		}
 
+		// Application errors are expected outcomes and carry their own code
+		if appErr, ok := apperror.As(err); ok {
+			return handleAppError(c, appErr)
+		}
+
+		// The request deadline passed or the request was cancelled before the handler finished
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			log.Warnf("%s %s: %v", c.Method(), c.Path(), err)
+			return utils.CodedErrorResponse(c, fiber.StatusServiceUnavailable, "REQUEST_TIMEOUT", "The request took too long to process. Please try again.", nil)
+		}
+
 		// Log the error
 		log.Errorf("Error occurred: %v", err)
 
@@ -58,6 +86,34 @@ func ErrorHandler(isDevelopment bool) fiber.Handler {
 	}
 }
 
+// handleAppError writes an application error as {code, message, details} with its kind's status
+func handleAppError(c *fiber.Ctx, err *apperror.Error) error {
+	status, ok := appErrorStatus[err.Kind]
+	if !ok {
+		status = fiber.StatusInternalServerError
+	}
+
+	if err.Err != nil {
+		log.Warnf("%s %s: %s: %v", c.Method(), c.Path(), err.Code, err.Err)
+	}
+
+	var details any
+	if len(err.Details) > 0 {
+		details = err.Details
+	}
+
+	return utils.CodedErrorResponse(c, status, err.Code, err.Message, details)
+}
+
+// statusCode derives a machine-readable code from an HTTP status, e.g. 404 becomes NOT_FOUND
+func statusCode(status int) string {
+	text := fiberutils.StatusMessage(status)
+	if text == "" {
+		return codeInternalError
+	}
+	return strings.ToUpper(strings.ReplaceAll(text, " ", "_"))
+}
+
 // handleFiberError handles Fiber-specific errors
 func handleFiberError(c *fiber.Ctx, err *fiber.Error, isDevelopment bool) error {
 	code := err.Code
@@ -75,36 +131,27 @@ func handleFiberError(c *fiber.Ctx, err *fiber.Error, isDevelopment bool) error
 		message = "Unsupported media type"
 	}
 
-	response := fiber.Map{
-		"success": false,
-		"message": message,
-		"code":    code,
-	}
-
-	// Add error details in development
+	var details any
 	if isDevelopment {
-		response["error"] = err.Error()
+		details = fiber.Map{"error": err.Error()}
 	}
 
-	return c.Status(code).JSON(response)
+	return utils.CodedErrorResponse(c, code, statusCode(code), message, details)
 }
 
-// handleInternalError handles internal server errors
+// handleInternalError handles internal server errors; the original error is only
+// exposed in development and has already been logged
 func handleInternalError(c *fiber.Ctx, err error, isDevelopment bool) error {
-	response := fiber.Map{
-		"success": false,
-		"message": "An internal server error occurred",
-		"code":    fiber.StatusInternalServerError,
-	}
-
-	// Add error details in development
+	var details any
 	if isDevelopment {
-		response["error"] = err.Error()
-		response["path"] = c.Path()
-		response["method"] = c.Method()
+		details = fiber.Map{
+			"error":  err.Error(),
+			"path":   c.Path(),
+			"method": c.Method(),
+		}
 	}
 
-	return c.Status(fiber.StatusInternalServerError).JSON(response)
+	return utils.CodedErrorResponse(c, fiber.StatusInternalServerError, codeInternalError, "An internal server error occurred", details)
 }
 
 // RecoverPanic recovers from panics and returns 500 error
@@ -145,6 +192,7 @@ func RecoverPanic(isDevelopment bool) fiber.Handler {
 // NotFoundHandler handles 404 errors
 func NotFoundHandler() fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		c.Locals(routeUnmatchedKey, true)
 		return utils.ErrorResponse(
 			c,
 			fiber.StatusNotFound,
@@ -173,9 +221,9 @@ func ValidationErrorHandler() fiber.Handler {
 
 		if err != nil {
 			// Check if it's a validation error
-			validationErrors := utils.FormatValidationErrors(err)
+			validationErrors := utils.FormatValidationErrors(c, err)
 			if len(validationErrors) > 0 {
-				return utils.ValidationErrorResponse(c, "Validation failed", validationErrors)
+				return utils.ValidationErrorResponse(c, GetTranslator(c).T("validation.failed"), validationErrors)
 			}
 		}
 
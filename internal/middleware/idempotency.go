@@ -0,0 +1,260 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"keerja-backend/internal/apperror"
+	"keerja-backend/internal/cache"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+const (
+	// HeaderIdempotencyKey is the request header clients use to make a mutation safe to retry
+	HeaderIdempotencyKey = "Idempotency-Key"
+
+	// HeaderIdempotentReplayed marks a response that was replayed from an earlier request
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	// DefaultIdempotencyTTL is how long a stored response can be replayed
+	DefaultIdempotencyTTL = 24 * time.Hour
+
+	// idempotencyPendingTTL bounds how long a key stays reserved by a request that never
+	// finished, e.g. because the instance crashed mid-request
+	idempotencyPendingTTL = 2 * time.Minute
+
+	idempotencyKeyPrefix = "idempotency:"
+	maxIdempotencyKeyLen = 255
+)
+
+var (
+	// ErrIdempotencyKeyReused is returned when a key is replayed with a different request body
+	ErrIdempotencyKeyReused = apperror.Conflict("IDEMPOTENCY_KEY_REUSED", "idempotency key was already used with a different request")
+
+	// ErrIdempotencyRequestInProgress is returned when a retry arrives while the original request is still running
+	ErrIdempotencyRequestInProgress = apperror.Conflict("IDEMPOTENCY_REQUEST_IN_PROGRESS", "a request with this idempotency key is still being processed")
+
+	// ErrIdempotencyKeyInvalid is returned for an oversized key
+	ErrIdempotencyKeyInvalid = apperror.Validation("IDEMPOTENCY_KEY_INVALID", "idempotency key must be at most 255 characters")
+)
+
+// IdempotencyRecord is the stored outcome of a request made with an idempotency key.
+// A record without a status code belongs to a request that is still running.
+type IdempotencyRecord struct {
+	RequestHash string `json:"request_hash"`
+	StatusCode  int    `json:"status_code,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// IsPending reports whether the original request has not finished yet
+func (r *IdempotencyRecord) IsPending() bool {
+	return r.StatusCode == 0
+}
+
+// IdempotencyStore persists idempotency records with a TTL
+type IdempotencyStore interface {
+	// Reserve stores record under key unless a record already exists. It returns the
+	// existing record, or nil when the reservation succeeded.
+	Reserve(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) (*IdempotencyRecord, error)
+	// Save overwrites the record under key
+	Save(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) error
+	// Delete removes the record under key
+	Delete(ctx context.Context, key string) error
+}
+
+// Idempotency makes a route safe to retry with an Idempotency-Key header. The first
+// request with a key runs normally and its response is stored; a retry with the same
+// key and body gets the stored response, and a retry with a different body gets 409.
+// Keys are scoped to the authenticated user (or client IP) and the request path.
+// Requests without the header, and every request when store is nil, pass through.
+// A zero ttl uses DefaultIdempotencyTTL.
+func Idempotency(store IdempotencyStore, ttl time.Duration) fiber.Handler {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+
+	return func(c *fiber.Ctx) error {
+		key := c.Get(HeaderIdempotencyKey)
+		if store == nil || key == "" {
+			return c.Next()
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			return ErrIdempotencyKeyInvalid
+		}
+
+		ctx := c.UserContext()
+		storeKey := idempotencyStoreKey(c, key)
+		requestHash := hashRequestBody(c.Body())
+
+		existing, err := store.Reserve(ctx, storeKey, &IdempotencyRecord{RequestHash: requestHash}, min(ttl, idempotencyPendingTTL))
+		if err != nil {
+			// Without the store the request can still be served, just not deduplicated
+			log.Warnf("Idempotency store unavailable, processing request without it: %v", err)
+			return c.Next()
+		}
+		if existing != nil {
+			if existing.RequestHash != requestHash {
+				return ErrIdempotencyKeyReused
+			}
+			if existing.IsPending() {
+				return ErrIdempotencyRequestInProgress
+			}
+			c.Set(HeaderIdempotentReplayed, "true")
+			if existing.ContentType != "" {
+				c.Set(fiber.HeaderContentType, existing.ContentType)
+			}
+			return c.Status(existing.StatusCode).Send(existing.Body)
+		}
+
+		// Failed requests release the key so the client can retry them
+		handlerErr := c.Next()
+		status := c.Response().StatusCode()
+		if handlerErr != nil || status >= fiber.StatusInternalServerError {
+			if err := store.Delete(ctx, storeKey); err != nil {
+				log.Warnf("Failed to release idempotency key: %v", err)
+			}
+			return handlerErr
+		}
+
+		record := &IdempotencyRecord{
+			RequestHash: requestHash,
+			StatusCode:  status,
+			ContentType: string(c.Response().Header.ContentType()),
+			Body:        append([]byte(nil), c.Response().Body()...),
+		}
+		if err := store.Save(ctx, storeKey, record, ttl); err != nil {
+			log.Warnf("Failed to store idempotent response: %v", err)
+		}
+		return nil
+	}
+}
+
+// idempotencyStoreKey scopes a client key to the caller and route so keys from
+// different users or endpoints never collide
+func idempotencyStoreKey(c *fiber.Ctx, key string) string {
+	caller := "ip:" + c.IP()
+	if userID := GetUserID(c); userID != 0 {
+		caller = fmt.Sprintf("user:%d", userID)
+	}
+	sum := sha256.Sum256([]byte(c.Method() + " " + c.Path() + " " + key))
+	return idempotencyKeyPrefix + caller + ":" + hex.EncodeToString(sum[:])
+}
+
+// hashRequestBody fingerprints a request body
+func hashRequestBody(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// KVIdempotencyStore stores idempotency records in the shared key-value store (Redis,
+// or its in-memory fallback while Redis is unavailable)
+type KVIdempotencyStore struct {
+	store cache.KeyValueStore
+}
+
+// NewKVIdempotencyStore creates a new key-value idempotency store
+func NewKVIdempotencyStore(store cache.KeyValueStore) *KVIdempotencyStore {
+	return &KVIdempotencyStore{store: store}
+}
+
+// Reserve implements IdempotencyStore.Reserve with SET NX
+func (s *KVIdempotencyStore) Reserve(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) (*IdempotencyRecord, error) {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal idempotency record: %w", err)
+	}
+
+	reserved, err := s.store.SetNX(ctx, key, payload, ttl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	if reserved {
+		return nil, nil
+	}
+
+	value, err := s.store.Get(ctx, key)
+	if errors.Is(err, cache.ErrKeyNotFound) {
+		// Expired between SET NX and GET; try once more
+		return s.Reserve(ctx, key, record, ttl)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch idempotency record: %w", err)
+	}
+
+	var existing IdempotencyRecord
+	if err := json.Unmarshal(value, &existing); err != nil {
+		return nil, fmt.Errorf("failed to decode idempotency record: %w", err)
+	}
+	return &existing, nil
+}
+
+// Save implements IdempotencyStore.Save
+func (s *KVIdempotencyStore) Save(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) error {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal idempotency record: %w", err)
+	}
+	return s.store.Set(ctx, key, payload, ttl)
+}
+
+// Delete implements IdempotencyStore.Delete
+func (s *KVIdempotencyStore) Delete(ctx context.Context, key string) error {
+	return s.store.Delete(ctx, key)
+}
+
+// InMemoryIdempotencyStore stores idempotency records in process memory. It is meant
+// for tests and single-instance development setups.
+type InMemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]inMemoryIdempotencyEntry
+}
+
+type inMemoryIdempotencyEntry struct {
+	record    IdempotencyRecord
+	expiresAt time.Time
+}
+
+// NewInMemoryIdempotencyStore creates a new in-memory idempotency store
+func NewInMemoryIdempotencyStore() *InMemoryIdempotencyStore {
+	return &InMemoryIdempotencyStore{records: make(map[string]inMemoryIdempotencyEntry)}
+}
+
+// Reserve implements IdempotencyStore.Reserve
+func (s *InMemoryIdempotencyStore) Reserve(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) (*IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.records[key]; ok && time.Now().Before(entry.expiresAt) {
+		existing := entry.record
+		return &existing, nil
+	}
+	s.records[key] = inMemoryIdempotencyEntry{record: *record, expiresAt: time.Now().Add(ttl)}
+	return nil, nil
+}
+
+// Save implements IdempotencyStore.Save
+func (s *InMemoryIdempotencyStore) Save(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[key] = inMemoryIdempotencyEntry{record: *record, expiresAt: time.Now().Add(ttl)}
+	return nil
+}
+
+// Delete implements IdempotencyStore.Delete
+func (s *InMemoryIdempotencyStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
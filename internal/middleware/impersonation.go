@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"keerja-backend/internal/apperror"
+	"keerja-backend/internal/domain/admin"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Context keys and response header of impersonated requests
+const (
+	ContextKeyImpersonatorID  = "impersonator_id"
+	ContextKeyImpersonationID = "impersonation_id"
+
+	// HeaderImpersonatedBy carries the impersonating admin's ID on every impersonated
+	// response, so the frontend can show an impersonation banner
+	HeaderImpersonatedBy = "X-Impersonated-By"
+)
+
+// ImpersonationTracker checks impersonation sessions and audits the requests made under them
+type ImpersonationTracker interface {
+	IsImpersonationActive(ctx context.Context, sessionID int64) (bool, error)
+	RecordImpersonatedRequest(ctx context.Context, req admin.ImpersonatedRequest)
+}
+
+// Impersonation identifies the admin acting as the authenticated user
+type Impersonation struct {
+	AdminID   int64
+	SessionID int64
+}
+
+// GetImpersonation returns the impersonation the request is made under, or nil when the
+// user is making it themselves
+func GetImpersonation(c *fiber.Ctx) *Impersonation {
+	adminID, ok := c.Locals(ContextKeyImpersonatorID).(int64)
+	if !ok || adminID == 0 {
+		return nil
+	}
+	sessionID, _ := c.Locals(ContextKeyImpersonationID).(int64)
+	return &Impersonation{AdminID: adminID, SessionID: sessionID}
+}
+
+// impersonationActive checks that the impersonation session of the token has not ended.
+// Without a tracker impersonation tokens are never accepted.
+func (m *AuthMiddleware) impersonationActive(c *fiber.Ctx, claims *utils.Claims) bool {
+	if m.impersonation == nil || claims.ImpersonationID == 0 {
+		return false
+	}
+
+	active, err := m.impersonation.IsImpersonationActive(c.UserContext(), claims.ImpersonationID)
+	return err == nil && active
+}
+
+// serveImpersonated runs the rest of the chain for a request made with an impersonation
+// token. Requests that could change data are refused unless allow-listed, and every
+// request is audited.
+func (m *AuthMiddleware) serveImpersonated(c *fiber.Ctx, claims *utils.Claims) error {
+	// Routes authenticated both by their group and by themselves are checked and audited once
+	if GetImpersonation(c) != nil {
+		return c.Next()
+	}
+
+	c.Locals(ContextKeyImpersonatorID, claims.ImpersonatorID)
+	c.Locals(ContextKeyImpersonationID, claims.ImpersonationID)
+	c.Set(HeaderImpersonatedBy, strconv.FormatInt(claims.ImpersonatorID, 10))
+
+	req := admin.ImpersonatedRequest{
+		SessionID: claims.ImpersonationID,
+		AdminID:   claims.ImpersonatorID,
+		UserID:    claims.UserID,
+		Method:    c.Method(),
+		Path:      c.Path(),
+	}
+
+	var err error
+	if m.mutationAllowed(c.Method(), c.Path()) {
+		err = c.Next()
+	} else {
+		req.Blocked = true
+		err = admin.ErrImpersonationReadOnly
+	}
+
+	req.Status = responseStatus(c, err)
+	m.impersonation.RecordImpersonatedRequest(AuditContext(c), req)
+	return err
+}
+
+// mutationAllowed checks if an impersonated request may be made: reads always are, other
+// methods only when listed in ImpersonationAllowedMutations
+func (m *AuthMiddleware) mutationAllowed(method, path string) bool {
+	switch method {
+	case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+		return true
+	}
+
+	for _, entry := range m.config.ImpersonationAllowedMutations {
+		allowedMethod, pattern, ok := strings.Cut(strings.TrimSpace(entry), " ")
+		if ok && strings.EqualFold(allowedMethod, method) && matchPathPattern(strings.TrimSpace(pattern), path) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPathPattern checks if path matches pattern, where a ":name" segment of the pattern
+// matches any one path segment
+func matchPathPattern(pattern, path string) bool {
+	patternSegs := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegs := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternSegs) != len(pathSegs) {
+		return false
+	}
+
+	for i, seg := range patternSegs {
+		if strings.HasPrefix(seg, ":") {
+			if pathSegs[i] == "" {
+				return false
+			}
+			continue
+		}
+		if seg != pathSegs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// responseStatus returns the status the request is answered with once err, if any, has
+// been turned into a response by ErrorHandler
+func responseStatus(c *fiber.Ctx, err error) int {
+	if err == nil {
+		return c.Response().StatusCode()
+	}
+	if appErr, ok := apperror.As(err); ok {
+		if status, ok := appErrorStatus[appErr.Kind]; ok {
+			return status
+		}
+	}
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+	return fiber.StatusInternalServerError
+}
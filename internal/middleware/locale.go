@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"keerja-backend/internal/i18n"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Localization resolves the request locale from the Accept-Language header and stores it
+// in the Fiber locals and the user context, so both handlers and the services they call
+// render messages in the client's language
+func Localization() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		locale := i18n.FromAcceptLanguage(c.Get(fiber.HeaderAcceptLanguage))
+
+		c.Locals(i18n.LocaleKey, locale)
+		c.SetUserContext(i18n.WithLocale(c.UserContext(), locale))
+		c.Set(fiber.HeaderContentLanguage, string(locale))
+		c.Vary(fiber.HeaderAcceptLanguage)
+
+		return c.Next()
+	}
+}
+
+// GetLocale returns the request locale resolved by the localization middleware
+func GetLocale(c *fiber.Ctx) i18n.Locale {
+	return i18n.FromContext(c.UserContext())
+}
+
+// GetTranslator returns a translator for the request locale
+func GetTranslator(c *fiber.Ctx) *i18n.Translator {
+	return i18n.New(GetLocale(c))
+}
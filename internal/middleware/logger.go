@@ -35,7 +35,7 @@ func RequestLogger() fiber.Handler {
 
 		// Build log message
 		logMsg := fmt.Sprintf(
-			"[%s] %s %s | Status: %d | Duration: %v | IP: %s | UserID: %d | UserType: %s",
+			"[%s] %s %s | Status: %d | Duration: %v | IP: %s | UserID: %d | UserType: %s | RequestID: %s",
 			time.Now().Format("2006-01-02 15:04:05"),
 			method,
 			path,
@@ -44,6 +44,7 @@ func RequestLogger() fiber.Handler {
 			ip,
 			userID,
 			userType,
+			GetRequestID(c),
 		)
 
 		// Log based on status code
@@ -66,6 +67,7 @@ func DetailedLogger() fiber.Handler {
 
 		// Log request
 		log.Infof("📥 Incoming Request:")
+		log.Infof("  Request ID: %s", GetRequestID(c))
 		log.Infof("  Method: %s", c.Method())
 		log.Infof("  Path: %s", c.Path())
 		log.Infof("  IP: %s", c.IP())
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"keerja-backend/internal/metrics"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// unmatchedRoute labels requests no route matched, so scanners probing random paths do not
+// add a series per path
+const unmatchedRoute = "unmatched"
+
+// routeUnmatchedKey is set by NotFoundHandler for Metrics to label the request unmatched
+const routeUnmatchedKey = "route_unmatched"
+
+// Metrics records the count and latency of every request by method, route pattern and
+// status. It must run before the other middleware to time the whole request; a nil m
+// records nothing.
+func Metrics(m *metrics.Metrics) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if m == nil || c.Path() == metrics.Path {
+			return c.Next()
+		}
+
+		start := time.Now()
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		var fe *fiber.Error
+		if errors.As(err, &fe) {
+			status = fe.Code
+		} else if err != nil {
+			status = fiber.StatusInternalServerError
+		}
+
+		route := c.Route().Path
+		if unmatched, _ := c.Locals(routeUnmatchedKey).(bool); unmatched || errors.Is(err, fiber.ErrNotFound) {
+			route = unmatchedRoute
+		}
+
+		// The method is copied because Fiber reuses the request buffer it points into
+		m.ObserveRequest(strings.Clone(c.Method()), route, status, time.Since(start))
+		return err
+	}
+}
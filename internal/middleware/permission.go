@@ -1,12 +1,24 @@
 package middleware
 
 import (
+	"strconv"
+	"strings"
+
+	"keerja-backend/internal/apperror"
 	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/employer"
 	"keerja-backend/internal/utils"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+// CompanyIDHeader names the company an employer request acts for when the user belongs to
+// several companies
+const CompanyIDHeader = "X-Company-ID"
+
+// ErrInvalidCompanyHeader is returned when the X-Company-ID header is not a company ID
+var ErrInvalidCompanyHeader = apperror.Validation("INVALID_COMPANY_HEADER", "X-Company-ID must be a company ID").WithField(CompanyIDHeader, "must be a positive whole number")
+
 // PermissionMiddleware handles role-based permission checks for employer users
 type PermissionMiddleware struct {
 	companyService company.CompanyService
@@ -22,7 +34,7 @@ func NewPermissionMiddleware(companyService company.CompanyService) *PermissionM
 // RequirePermission checks if the user has a specific permission for a company
 func (pm *PermissionMiddleware) RequirePermission(permission company.Permission) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		ctx := c.Context()
+		ctx := c.UserContext()
 
 		// Get user ID from context (set by auth middleware)
 		userID := GetUserID(c)
@@ -48,8 +60,7 @@ func (pm *PermissionMiddleware) RequirePermission(permission company.Permission)
 		}
 
 		// Store employer user in context for handlers to use
-		c.Locals("employer_user", employerUser)
-		c.Locals("company_id", int64(companyID))
+		storeEmployer(c, employerUser, int64(companyID))
 
 		return c.Next()
 	}
@@ -58,7 +69,7 @@ func (pm *PermissionMiddleware) RequirePermission(permission company.Permission)
 // RequireRole checks if the user has a specific role or higher
 func (pm *PermissionMiddleware) RequireRole(requiredRole string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		ctx := c.Context()
+		ctx := c.UserContext()
 
 		// Get user ID from context
 		userID := GetUserID(c)
@@ -84,8 +95,7 @@ func (pm *PermissionMiddleware) RequireRole(requiredRole string) fiber.Handler {
 		}
 
 		// Store employer user in context
-		c.Locals("employer_user", employerUser)
-		c.Locals("company_id", int64(companyID))
+		storeEmployer(c, employerUser, int64(companyID))
 
 		return c.Next()
 	}
@@ -103,13 +113,13 @@ func (pm *PermissionMiddleware) RequireOwnerOrAdmin() fiber.Handler {
 
 // RequireRecruiterOrAbove checks if the user is a recruiter or admin
 func (pm *PermissionMiddleware) RequireRecruiterOrAbove() fiber.Handler {
-	return pm.RequireRole("recruiter")
+	return pm.RequireRole(employer.RoleRecruiter)
 }
 
 // CanManageJobs checks if user can manage jobs
 func (pm *PermissionMiddleware) CanManageJobs() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		ctx := c.Context()
+		ctx := c.UserContext()
 
 		userID := GetUserID(c)
 		if userID == 0 {
@@ -130,8 +140,7 @@ func (pm *PermissionMiddleware) CanManageJobs() fiber.Handler {
 			return utils.ErrorResponse(c, fiber.StatusForbidden, "You don't have permission to manage jobs", "")
 		}
 
-		c.Locals("employer_user", employerUser)
-		c.Locals("company_id", int64(companyID))
+		storeEmployer(c, employerUser, int64(companyID))
 
 		return c.Next()
 	}
@@ -140,7 +149,7 @@ func (pm *PermissionMiddleware) CanManageJobs() fiber.Handler {
 // CanManageEmployees checks if user can manage employees
 func (pm *PermissionMiddleware) CanManageEmployees() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		ctx := c.Context()
+		ctx := c.UserContext()
 
 		userID := GetUserID(c)
 		if userID == 0 {
@@ -161,8 +170,7 @@ func (pm *PermissionMiddleware) CanManageEmployees() fiber.Handler {
 			return utils.ErrorResponse(c, fiber.StatusForbidden, "You don't have permission to manage employees", "")
 		}
 
-		c.Locals("employer_user", employerUser)
-		c.Locals("company_id", int64(companyID))
+		storeEmployer(c, employerUser, int64(companyID))
 
 		return c.Next()
 	}
@@ -171,7 +179,7 @@ func (pm *PermissionMiddleware) CanManageEmployees() fiber.Handler {
 // CanManageApplications checks if user can manage applications
 func (pm *PermissionMiddleware) CanManageApplications() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		ctx := c.Context()
+		ctx := c.UserContext()
 
 		userID := GetUserID(c)
 		if userID == 0 {
@@ -192,13 +200,74 @@ func (pm *PermissionMiddleware) CanManageApplications() fiber.Handler {
 			return utils.ErrorResponse(c, fiber.StatusForbidden, "You don't have permission to manage applications", "")
 		}
 
-		c.Locals("employer_user", employerUser)
-		c.Locals("company_id", int64(companyID))
+		storeEmployer(c, employerUser, int64(companyID))
+
+		return c.Next()
+	}
+}
+
+// RequireActiveCompany resolves the company the request acts for on routes without a company
+// in the path: the X-Company-ID header, else the company the user activated last, else their
+// only company. Users with several companies and no choice get ErrActiveCompanyRequired.
+func (pm *PermissionMiddleware) RequireActiveCompany() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID := GetUserID(c)
+		if userID == 0 {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not authenticated", "")
+		}
+
+		companyID, err := ActiveCompanyID(c)
+		if err != nil {
+			return err
+		}
+
+		ec, err := pm.companyService.ResolveEmployerContext(c.UserContext(), userID, companyID)
+		if err != nil {
+			return err
+		}
+
+		c.Locals("company_id", ec.CompanyID)
+		c.Locals("employer_context", ec)
+		c.Set(CompanyIDHeader, strconv.FormatInt(ec.CompanyID, 10))
 
 		return c.Next()
 	}
 }
 
+// ActiveCompanyID returns the company named by the X-Company-ID header, or 0 without one
+func ActiveCompanyID(c *fiber.Ctx) (int64, error) {
+	value := strings.TrimSpace(c.Get(CompanyIDHeader))
+	if value == "" {
+		return 0, nil
+	}
+	companyID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || companyID <= 0 {
+		return 0, ErrInvalidCompanyHeader
+	}
+	return companyID, nil
+}
+
+// storeEmployer stores the employer user the permission checks resolved, and the
+// EmployerContext services take, for handlers to use
+func storeEmployer(c *fiber.Ctx, employerUser *company.EmployerUser, companyID int64) {
+	c.Locals("employer_user", employerUser)
+	c.Locals("company_id", companyID)
+	c.Locals("employer_context", &employer.EmployerContext{
+		UserID:         employerUser.UserID,
+		EmployerUserID: employerUser.ID,
+		CompanyID:      companyID,
+		Role:           employerUser.Role,
+	})
+}
+
+// GetEmployerContext retrieves the employer context stored by the permission checks
+func GetEmployerContext(c *fiber.Ctx) *employer.EmployerContext {
+	if ec, ok := c.Locals("employer_context").(*employer.EmployerContext); ok {
+		return ec
+	}
+	return nil
+}
+
 // GetEmployerUser retrieves employer user from context
 func GetEmployerUser(c *fiber.Ctx) *company.EmployerUser {
 	if employerUser, ok := c.Locals("employer_user").(*company.EmployerUser); ok {
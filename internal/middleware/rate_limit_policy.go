@@ -0,0 +1,285 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/config"
+	"keerja-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// Rate limit policy names
+const (
+	RateLimitPolicyDefault  = "default"
+	RateLimitPolicyAuth     = "auth"
+	RateLimitPolicyAuthIP   = "auth_ip"
+	RateLimitPolicyEmployer = "employer"
+)
+
+// Rate limit response headers
+const (
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+)
+
+const rateLimitKeyPrefix = "ratelimit:"
+
+// authRateLimitedPaths are the login, registration and OTP endpoints covered by the auth policy
+var authRateLimitedPaths = map[string]bool{
+	"/api/v1/auth/login":               true,
+	"/api/v1/auth/login-remember":      true,
+	"/api/v1/auth/register":            true,
+	"/api/v1/auth/register-otp":        true,
+	"/api/v1/auth/verify-email-otp":    true,
+	"/api/v1/auth/resend-otp":          true,
+	"/api/v1/auth/forgot-password-otp": true,
+	"/api/v1/auth/reset-password-otp":  true,
+	"/api/v1/auth/admin/login":         true,
+}
+
+// RateLimitPrincipal is the caller a request is made by, read from its bearer token.
+// The zero value is an anonymous caller.
+type RateLimitPrincipal struct {
+	UserID   int64
+	UserType string
+	Admin    bool
+}
+
+// RateLimitPolicy is a named limit of Max requests per Window for each key
+type RateLimitPolicy struct {
+	Name   string
+	Max    int
+	Window time.Duration
+	// Match reports whether the policy applies to the request; nil matches every request
+	Match func(c *fiber.Ctx, principal RateLimitPrincipal) bool
+	// Key returns the bucket the request is counted in
+	Key func(c *fiber.Ctx, principal RateLimitPrincipal) string
+	// Fallthrough lets the next matching policy limit the request as well, e.g. to cap an IP
+	// across all the buckets of a narrower policy
+	Fallthrough bool
+}
+
+// PolicyRateLimiterConfig configures PolicyRateLimiter
+type PolicyRateLimiterConfig struct {
+	Store     cache.KeyValueStore
+	JWTSecret string
+	// Policies are tried in order; the first that matches limits the request, along with the
+	// ones after it while the matching policies fall through
+	Policies []RateLimitPolicy
+	// AdminBypass lets requests with a valid admin token through unlimited
+	AdminBypass bool
+	// Next skips the limiter for a request when it returns true
+	Next func(c *fiber.Ctx) bool
+}
+
+// DefaultRateLimitPolicies returns the policies of the global rate limiter: auth endpoints
+// by IP and email and, so rotating emails doesn't lift the limit, by IP alone; authenticated
+// employers by user ID and every other request by IP
+func DefaultRateLimitPolicies(cfg *config.Config) []RateLimitPolicy {
+	window := cfg.RateLimitWindow
+	if window == 0 {
+		window = 1 * time.Minute
+	}
+	defaultMax := cfg.RateLimitMax
+	if defaultMax == 0 {
+		defaultMax = 100
+	}
+	authMax := cfg.RateLimitAuthMax
+	if authMax == 0 {
+		authMax = 10
+	}
+	authWindow := cfg.RateLimitAuthWindow
+	if authWindow == 0 {
+		authWindow = 15 * time.Minute
+	}
+	authIPMax := cfg.RateLimitAuthIPMax
+	if authIPMax == 0 {
+		authIPMax = 50
+	}
+	matchAuth := func(c *fiber.Ctx, _ RateLimitPrincipal) bool {
+		return c.Method() == fiber.MethodPost && authRateLimitedPaths[c.Path()]
+	}
+	employerMax := cfg.RateLimitEmployerMax
+	if employerMax == 0 {
+		employerMax = 600
+	}
+
+	return []RateLimitPolicy{
+		{
+			Name:   RateLimitPolicyAuth,
+			Max:    authMax,
+			Window: authWindow,
+			Match:  matchAuth,
+			Key: func(c *fiber.Ctx, _ RateLimitPrincipal) string {
+				return "ip:" + c.IP() + ":email:" + requestEmailHash(c)
+			},
+			Fallthrough: true,
+		},
+		{
+			Name:   RateLimitPolicyAuthIP,
+			Max:    authIPMax,
+			Window: authWindow,
+			Match:  matchAuth,
+			Key: func(c *fiber.Ctx, _ RateLimitPrincipal) string {
+				return "ip:" + c.IP()
+			},
+		},
+		{
+			Name:   RateLimitPolicyEmployer,
+			Max:    employerMax,
+			Window: window,
+			Match: func(_ *fiber.Ctx, principal RateLimitPrincipal) bool {
+				return principal.UserType == "employer" && principal.UserID > 0
+			},
+			Key: func(_ *fiber.Ctx, principal RateLimitPrincipal) string {
+				return fmt.Sprintf("user:%d", principal.UserID)
+			},
+		},
+		{
+			Name:   RateLimitPolicyDefault,
+			Max:    defaultMax,
+			Window: window,
+			Key: func(c *fiber.Ctx, _ RateLimitPrincipal) string {
+				return "ip:" + c.IP()
+			},
+		},
+	}
+}
+
+// PolicyRateLimiter limits each request by the first policy that matches it, and by the
+// policies after it while the matching ones fall through. Counters are kept in the shared
+// store with a sliding window, so every instance enforces the same limit. Responses carry
+// X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset (seconds until the current
+// window ends) of the policy with the fewest requests left; limited responses add
+// Retry-After. When the store fails the request is served unlimited.
+func PolicyRateLimiter(cfg PolicyRateLimiterConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if cfg.Next != nil && cfg.Next(c) {
+			return c.Next()
+		}
+
+		principal := rateLimitPrincipal(c, cfg.JWTSecret)
+		if principal.Admin && cfg.AdminBypass {
+			return c.Next()
+		}
+
+		remaining := -1
+		for _, policy := range matchRateLimitPolicies(c, principal, cfg.Policies) {
+			key := rateLimitKeyPrefix + policy.Name + ":" + policy.Key(c, principal)
+			hits, reset, err := slidingWindowHits(c.UserContext(), cfg.Store, key, policy.Window, time.Now())
+			if err != nil {
+				log.Warnf("Rate limit store unavailable, serving request without limiting: %v", err)
+				return c.Next()
+			}
+
+			left := max(policy.Max-hits, 0)
+			resetSeconds := strconv.Itoa(int((reset + time.Second - 1) / time.Second))
+			if remaining < 0 || left < remaining {
+				remaining = left
+				c.Set(HeaderRateLimitLimit, strconv.Itoa(policy.Max))
+				c.Set(HeaderRateLimitRemaining, strconv.Itoa(left))
+				c.Set(HeaderRateLimitReset, resetSeconds)
+			}
+
+			if hits > policy.Max {
+				c.Set(HeaderRateLimitLimit, strconv.Itoa(policy.Max))
+				c.Set(HeaderRateLimitRemaining, "0")
+				c.Set(HeaderRateLimitReset, resetSeconds)
+				c.Set(fiber.HeaderRetryAfter, resetSeconds)
+				return utils.ErrorResponse(
+					c,
+					fiber.StatusTooManyRequests,
+					"Rate limit exceeded",
+					fmt.Sprintf("Too many requests. Please try again later. Limit: %d requests per %v", policy.Max, policy.Window),
+				)
+			}
+		}
+
+		return c.Next()
+	}
+}
+
+// matchRateLimitPolicies returns the first policy that applies to the request, followed by
+// the next ones that apply for as long as the matched policies fall through
+func matchRateLimitPolicies(c *fiber.Ctx, principal RateLimitPrincipal, policies []RateLimitPolicy) []*RateLimitPolicy {
+	var matched []*RateLimitPolicy
+	for i := range policies {
+		if policies[i].Match != nil && !policies[i].Match(c, principal) {
+			continue
+		}
+		matched = append(matched, &policies[i])
+		if !policies[i].Fallthrough {
+			break
+		}
+	}
+	return matched
+}
+
+// slidingWindowHits counts the request in its fixed window and returns the sliding window
+// estimate, i.e. this window's hits plus the previous window's weighted by how much of it
+// still overlaps, along with the time left in the current window
+func slidingWindowHits(ctx context.Context, store cache.KeyValueStore, key string, window time.Duration, now time.Time) (int, time.Duration, error) {
+	current := now.UnixNano() / int64(window)
+	elapsed := time.Duration(now.UnixNano() % int64(window))
+
+	hits, err := store.Incr(ctx, fmt.Sprintf("%s:%d", key, current), 2*window)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var previous int64
+	raw, err := store.Get(ctx, fmt.Sprintf("%s:%d", key, current-1))
+	switch {
+	case err == nil:
+		previous, _ = strconv.ParseInt(string(raw), 10, 64)
+	case !errors.Is(err, cache.ErrKeyNotFound):
+		return 0, 0, err
+	}
+
+	weight := float64(window-elapsed) / float64(window)
+	return int(hits) + int(float64(previous)*weight), window - elapsed, nil
+}
+
+// rateLimitPrincipal reads the caller from the bearer token. Only the signature and expiry
+// are checked; sessions are left to the auth middleware since the limiter only needs a
+// stable identity to count against.
+func rateLimitPrincipal(c *fiber.Ctx, secret string) RateLimitPrincipal {
+	parts := strings.Fields(c.Get(fiber.HeaderAuthorization))
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") || secret == "" {
+		return RateLimitPrincipal{}
+	}
+
+	if claims, err := utils.ValidateAdminToken(parts[1], secret); err == nil && claims.UserType == "admin" && claims.AdminID > 0 {
+		return RateLimitPrincipal{UserID: claims.AdminID, UserType: claims.UserType, Admin: true}
+	}
+	if claims, err := utils.ValidateToken(parts[1], secret); err == nil && claims.UserID > 0 {
+		return RateLimitPrincipal{UserID: claims.UserID, UserType: claims.UserType}
+	}
+	return RateLimitPrincipal{}
+}
+
+// requestEmailHash returns a hash of the email in a JSON request body, so counters are
+// kept per account without storing addresses in the rate limit store
+func requestEmailHash(c *fiber.Ctx) string {
+	var body struct {
+		Email string `json:"email"`
+	}
+	if err := json.Unmarshal(c.Body(), &body); err != nil || body.Email == "" {
+		return "none"
+	}
+
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(body.Email))))
+	return hex.EncodeToString(sum[:8])
+}
@@ -2,8 +2,10 @@ package middleware
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"keerja-backend/internal/cache"
 	"keerja-backend/internal/config"
 	"keerja-backend/internal/utils"
 
@@ -11,8 +13,9 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/limiter"
 )
 
-// RateLimiter creates a rate limiter middleware
-func RateLimiter(cfg *config.Config) fiber.Handler {
+// RateLimiter creates the global rate limiter, which applies DefaultRateLimitPolicies with
+// counters in store so the limits hold across instances
+func RateLimiter(cfg *config.Config, store cache.KeyValueStore) fiber.Handler {
 	// Skip if rate limiting is disabled
 	if !cfg.RateLimitEnabled {
 		return func(c *fiber.Ctx) error {
@@ -20,35 +23,63 @@ func RateLimiter(cfg *config.Config) fiber.Handler {
 		}
 	}
 
-	// Default values
-	max := cfg.RateLimitMax
-	if max == 0 {
-		max = 100 // Default: 100 requests
+	return PolicyRateLimiter(PolicyRateLimiterConfig{
+		Store:       store,
+		JWTSecret:   cfg.JWTSecret,
+		Policies:    DefaultRateLimitPolicies(cfg),
+		AdminBypass: cfg.RateLimitAdminBypass,
+		// API key requests have their own limits (see APIKeyRateLimiter)
+		Next: func(c *fiber.Ctx) bool {
+			return strings.HasPrefix(c.Path(), IntegrationPathPrefix)
+		},
+	})
+}
+
+// IntegrationPathPrefix is where routes authenticated by company API keys are mounted
+const IntegrationPathPrefix = "/api/v1/integrations/"
+
+// APIKeyRateLimiter limits integration requests per API key, separately from the
+// per-IP limiter used for users. Must run after APIKeyAuth.
+func APIKeyRateLimiter(cfg *config.Config) fiber.Handler {
+	if !cfg.RateLimitEnabled {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
 	}
 
+	max := cfg.APIKeyRateLimitMax
+	if max == 0 {
+		max = 300
+	}
 	window := cfg.RateLimitWindow
 	if window == 0 {
-		window = 1 * time.Minute // Default: per minute
+		window = 1 * time.Minute
 	}
 
-	return limiter.New(limiter.Config{
-		Max:        max,
-		Expiration: window,
+	return NewCustomRateLimiter(RateLimiterConfig{
+		Max:    max,
+		Window: window,
 		KeyGenerator: func(c *fiber.Ctx) string {
-			// Use IP address as key
-			return c.IP()
+			if principal := GetAPIKeyPrincipal(c); principal != nil {
+				return fmt.Sprintf("apikey:%d", principal.KeyID)
+			}
+			return fmt.Sprintf("apikey:ip:%s", c.IP())
 		},
-		LimitReached: func(c *fiber.Ctx) error {
-			return utils.ErrorResponse(
-				c,
-				fiber.StatusTooManyRequests,
-				"Rate limit exceeded",
-				fmt.Sprintf("Too many requests. Please try again later. Limit: %d requests per %v", max, window),
-			)
+		Message: fmt.Sprintf("API key rate limit exceeded. Limit: %d requests per %v", max, window),
+	})
+}
+
+// APIKeyFailureRateLimiter limits failed API key attempts by IP to slow down key guessing.
+// Successful requests are not counted.
+func APIKeyFailureRateLimiter() fiber.Handler {
+	return NewCustomRateLimiter(RateLimiterConfig{
+		Max:    20,              // 20 failed attempts
+		Window: 1 * time.Minute, // per minute
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return fmt.Sprintf("apikey:failed:%s", c.IP())
 		},
-		SkipFailedRequests:     false,
-		SkipSuccessfulRequests: false,
-		LimiterMiddleware:      limiter.SlidingWindow{},
+		SkipSuccessful: true,
+		Message:        "Too many invalid API key requests. Please try again later.",
 	})
 }
 
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"strings"
+
+	"keerja-backend/internal/config"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the ID correlating a request with its logs
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey stores the request ID in the Fiber locals
+const requestIDKey = "request_id"
+
+// maxRequestIDLength bounds client request IDs written to every log line of the request
+const maxRequestIDLength = 128
+
+// RequestID honors the client's X-Request-ID or generates one, returns it in the response
+// headers and stores it with the route in the user context, so the SQL and service logs of
+// the request carry it. It must run before the middleware deriving new user contexts.
+func RequestID() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Get(RequestIDHeader)
+		if validRequestID(id) {
+			// Fiber reuses the request buffer the header points into
+			id = strings.Clone(id)
+		} else {
+			id = uuid.NewString()
+		}
+
+		c.Locals(requestIDKey, id)
+		c.Set(RequestIDHeader, id)
+		c.SetUserContext(config.WithRequestInfo(c.UserContext(), config.RequestInfo{
+			ID:    id,
+			Route: strings.Clone(c.Method()) + " " + strings.Clone(c.Path()),
+		}))
+
+		return c.Next()
+	}
+}
+
+// GetRequestID returns the request ID set by the RequestID middleware
+func GetRequestID(c *fiber.Ctx) string {
+	id, _ := c.Locals(requestIDKey).(string)
+	return id
+}
+
+// validRequestID accepts IDs of letters, digits and -_.: only, so a client cannot forge
+// log lines through the header
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_.:", r)) {
+			return false
+		}
+	}
+	return true
+}
@@ -3,9 +3,13 @@ package middleware
 import (
 	"fmt"
 	"mime/multipart"
+	"net/url"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
+	"keerja-backend/internal/apperror"
 	"keerja-backend/internal/utils"
 
 	"github.com/gofiber/fiber/v2"
@@ -21,8 +25,8 @@ func ValidateRequest(target interface{}) fiber.Handler {
 
 		// Validate struct
 		if err := utils.ValidateStruct(target); err != nil {
-			errors := utils.FormatValidationErrors(err)
-			return utils.ValidationErrorResponse(c, "Validation failed", errors)
+			errors := utils.FormatValidationErrors(c, err)
+			return utils.ValidationErrorResponse(c, GetTranslator(c).T("validation.failed"), errors)
 		}
 
 		// Store validated data in context for handler to use
@@ -42,8 +46,8 @@ func ValidateQuery(target interface{}) fiber.Handler {
 
 		// Validate struct
 		if err := utils.ValidateStruct(target); err != nil {
-			errors := utils.FormatValidationErrors(err)
-			return utils.ValidationErrorResponse(c, "Validation failed", errors)
+			errors := utils.FormatValidationErrors(c, err)
+			return utils.ValidationErrorResponse(c, GetTranslator(c).T("validation.failed"), errors)
 		}
 
 		// Store validated data in context
@@ -53,6 +57,94 @@ func ValidateQuery(target interface{}) fiber.Handler {
 	}
 }
 
+// MaxSearchLength is the longest free-text search term, in characters, that validated
+// routes accept
+const MaxSearchLength = 100
+
+// ErrInvalidQuery is returned when query parameters do not have the format the route expects
+var ErrInvalidQuery = apperror.Validation("INVALID_QUERY", "query parameters are invalid")
+
+// QueryRules lists the query parameters a route group checks before its handlers run.
+// Parameters that are not listed pass through unchecked but are left out of CacheKey.
+type QueryRules struct {
+	Ints   []string // Whole numbers
+	Bools  []string // Booleans as accepted by strconv.ParseBool
+	Search []string // Free text of at most MaxSearchLength characters
+}
+
+// MasterDataQueryRules covers the query parameters of the /master endpoints
+var MasterDataQueryRules = QueryRules{
+	Ints:   []string{"page", "limit", "province_id", "city_id", "skills_page", "skills_limit"},
+	Bools:  []string{"active"},
+	Search: []string{"q", "search", "skills_q"},
+}
+
+// SkillsQueryRules covers the query parameters of the /skills endpoints
+var SkillsQueryRules = QueryRules{
+	Ints:   []string{"page", "page_size", "limit", "category_id"},
+	Bools:  []string{"is_active"},
+	Search: []string{"q", "search"},
+}
+
+// ValidateQueryParams rejects requests whose query parameters break rules with a 400 validation
+// error naming each offending parameter
+func ValidateQueryParams(rules QueryRules) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if details := rules.Check(c); len(details) > 0 {
+			return ErrInvalidQuery.WithDetails(details)
+		}
+		return c.Next()
+	}
+}
+
+// Check returns a problem description per query parameter of the request that breaks the
+// rules, or nil when all are valid. Blank parameters count as absent.
+func (r QueryRules) Check(c *fiber.Ctx) map[string]string {
+	var details map[string]string
+	fail := func(key, detail string) {
+		if details == nil {
+			details = make(map[string]string)
+		}
+		details[key] = detail
+	}
+
+	for _, key := range r.Ints {
+		if value := strings.TrimSpace(c.Query(key)); value != "" {
+			if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+				fail(key, "must be a whole number")
+			}
+		}
+	}
+	for _, key := range r.Bools {
+		if value := strings.TrimSpace(c.Query(key)); value != "" {
+			if _, err := strconv.ParseBool(value); err != nil {
+				fail(key, "must be true or false")
+			}
+		}
+	}
+	for _, key := range r.Search {
+		if utf8.RuneCountInString(strings.TrimSpace(c.Query(key))) > MaxSearchLength {
+			fail(key, "must be at most "+strconv.Itoa(MaxSearchLength)+" characters")
+		}
+	}
+	return details
+}
+
+// CacheKey returns a response cache key of the request path and the trimmed values of the
+// parameters named by the rules, in a fixed order. Unlisted parameters and their order do
+// not change the key, so clients cannot grow a response cache by varying the query string.
+func (r QueryRules) CacheKey(c *fiber.Ctx) string {
+	values := url.Values{}
+	for _, group := range [][]string{r.Ints, r.Bools, r.Search} {
+		for _, key := range group {
+			if value := strings.TrimSpace(c.Query(key)); value != "" {
+				values.Set(key, value)
+			}
+		}
+	}
+	return c.Path() + "?" + values.Encode()
+}
+
 // ValidateParams validates path parameters
 func ValidateParams(target interface{}) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -63,8 +155,8 @@ func ValidateParams(target interface{}) fiber.Handler {
 
 		// Validate struct
 		if err := utils.ValidateStruct(target); err != nil {
-			errors := utils.FormatValidationErrors(err)
-			return utils.ValidationErrorResponse(c, "Validation failed", errors)
+			errors := utils.FormatValidationErrors(c, err)
+			return utils.ValidationErrorResponse(c, GetTranslator(c).T("validation.failed"), errors)
 		}
 
 		// Store validated data in context
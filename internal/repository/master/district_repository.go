@@ -3,10 +3,12 @@ package master
 import (
 	"context"
 	"strings"
+	"time"
 
 	"keerja-backend/internal/domain/master"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // districtRepositoryImpl implements master.DistrictRepository
@@ -160,3 +162,89 @@ func (r *districtRepositoryImpl) GetByNameAndCityID(ctx context.Context, name st
 	}
 	return &district, nil
 }
+
+// CountUsage counts the records referencing a district. Soft-deleted addresses are included
+// since companies can reuse them.
+func (r *districtRepositoryImpl) CountUsage(ctx context.Context, id int64) (*master.DistrictUsage, error) {
+	usage := &master.DistrictUsage{DistrictID: id}
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT
+			(SELECT COUNT(*) FROM companies WHERE district_id = @id) AS companies,
+			(SELECT COUNT(*) FROM company_addresses WHERE district_id = @id) AS company_addresses,
+			(SELECT COUNT(*) FROM user_profiles WHERE district_id = @id) AS user_profiles,
+			(SELECT COUNT(*) FROM jobs j JOIN company_addresses a ON a.id = j.company_address_id WHERE a.district_id = @id) AS jobs,
+			(SELECT COUNT(*) FROM company_change_requests WHERE district_id = @id) AS change_requests`,
+		map[string]interface{}{"id": id},
+	).Scan(usage).Error
+	if err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// MergeInto moves every reference of the source district to the target district. Rows that
+// store the city and province next to the district get the target's, so a district moved to
+// another city by a boundary change stays consistent. Jobs follow their company address.
+func (r *districtRepositoryImpl) MergeInto(ctx context.Context, sourceID, targetID int64) (*master.DistrictMergeResult, error) {
+	result := &master.DistrictMergeResult{SourceID: sourceID, TargetID: targetID}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock both districts so a concurrent merge or edit involving either waits for this one
+		var districts []master.District
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("City").
+			Where("id IN ?", []int64{sourceID, targetID}).
+			Order("id ASC").
+			Find(&districts).Error
+		if err != nil {
+			return err
+		}
+		var source, target *master.District
+		for i := range districts {
+			switch districts[i].ID {
+			case sourceID:
+				source = &districts[i]
+			case targetID:
+				target = &districts[i]
+			}
+		}
+		if source == nil || target == nil || target.City == nil {
+			return master.ErrDistrictNotFound
+		}
+		cityID, provinceID := target.CityID, target.City.ProvinceID
+
+		res := tx.Exec(`UPDATE companies SET district_id = ?, city_id = ?, province_id = ?, updated_at = now() WHERE district_id = ?`,
+			targetID, cityID, provinceID, sourceID)
+		if res.Error != nil {
+			return res.Error
+		}
+		result.CompaniesMoved = res.RowsAffected
+
+		res = tx.Exec(`UPDATE company_addresses SET district_id = ?, city_id = ?, province_id = ?, updated_at = now() WHERE district_id = ?`,
+			targetID, cityID, provinceID, sourceID)
+		if res.Error != nil {
+			return res.Error
+		}
+		result.CompanyAddressesMoved = res.RowsAffected
+
+		res = tx.Exec(`UPDATE user_profiles SET district_id = ?, city_id = ?, province_id = ?, updated_at = now() WHERE district_id = ?`,
+			targetID, cityID, provinceID, sourceID)
+		if res.Error != nil {
+			return res.Error
+		}
+		result.UserProfilesMoved = res.RowsAffected
+
+		res = tx.Exec(`UPDATE company_change_requests SET district_id = ? WHERE district_id = ?`, targetID, sourceID)
+		if res.Error != nil {
+			return res.Error
+		}
+		result.ChangeRequestsMoved = res.RowsAffected
+
+		return tx.Model(&master.District{}).Where("id = ?", sourceID).
+			Updates(map[string]interface{}{"is_active": false, "updated_at": time.Now()}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
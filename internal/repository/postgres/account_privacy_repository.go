@@ -0,0 +1,288 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"keerja-backend/internal/domain/upload"
+	"keerja-backend/internal/domain/user"
+
+	"gorm.io/gorm"
+)
+
+// anonymizedUserTables hold data that belongs to the user alone and is deleted with the
+// account. Profiles and documents, which point at uploaded files, are deleted separately.
+var anonymizedUserTables = []string{
+	"user_preferences",
+	"user_educations",
+	"user_experiences",
+	"user_skills",
+	"user_certifications",
+	"user_languages",
+	"user_projects",
+	"user_email_changes",
+	"device_tokens",
+	"refresh_tokens",
+	"oauth_providers",
+	"otp_codes",
+	"notifications",
+	"notification_preferences",
+	"push_notification_logs",
+	"company_followers",
+	"review_votes",
+	"account_data_exports",
+}
+
+// accountPrivacyRepository implements the user.AccountPrivacyRepository interface
+type accountPrivacyRepository struct {
+	db *gorm.DB
+}
+
+// NewAccountPrivacyRepository creates a new account privacy repository instance
+func NewAccountPrivacyRepository(db *gorm.DB) user.AccountPrivacyRepository {
+	return &accountPrivacyRepository{db: db}
+}
+
+// CreateDeletionRequest saves a deletion request
+func (r *accountPrivacyRepository) CreateDeletionRequest(ctx context.Context, req *user.AccountDeletionRequest) error {
+	err := r.db.WithContext(ctx).Create(req).Error
+	if isUniqueViolation(err, "account_deletion_requests_one_pending") {
+		return user.ErrDeletionAlreadyRequested
+	}
+	return err
+}
+
+// FindPendingDeletionRequest finds the user's pending deletion request, returning nil when there is none
+func (r *accountPrivacyRepository) FindPendingDeletionRequest(ctx context.Context, userID int64) (*user.AccountDeletionRequest, error) {
+	var req user.AccountDeletionRequest
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status = ?", userID, user.DeletionRequestPending).
+		First(&req).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &req, nil
+}
+
+// UpdateDeletionRequest updates a deletion request
+func (r *accountPrivacyRepository) UpdateDeletionRequest(ctx context.Context, req *user.AccountDeletionRequest) error {
+	return r.db.WithContext(ctx).Save(req).Error
+}
+
+// FindDueDeletionRequests returns pending requests whose grace period has ended, oldest first
+func (r *accountPrivacyRepository) FindDueDeletionRequests(ctx context.Context, now time.Time, limit int) ([]user.AccountDeletionRequest, error) {
+	var reqs []user.AccountDeletionRequest
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND scheduled_for <= ?", user.DeletionRequestPending, now).
+		Order("scheduled_for ASC, id ASC").
+		Limit(limit).
+		Find(&reqs).Error
+	return reqs, err
+}
+
+// AnonymizeUser erases the user's personal data in one transaction and returns the URLs of
+// the uploaded files that belonged to the deleted rows and of the applications' resume snapshots
+func (r *accountPrivacyRepository) AnonymizeUser(ctx context.Context, userID int64, placeholderEmail string) ([]string, error) {
+	var fileURLs []string
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var profiles []struct {
+			AvatarURL      *string
+			CoverURL       *string
+			AvatarVariants upload.ImageVariants
+			CoverVariants  upload.ImageVariants
+		}
+		if err := tx.Raw(
+			"DELETE FROM user_profiles WHERE user_id = ? RETURNING avatar_url, cover_url, avatar_variants, cover_variants",
+			userID,
+		).Scan(&profiles).Error; err != nil {
+			return fmt.Errorf("failed to delete profile: %w", err)
+		}
+		for _, profile := range profiles {
+			for _, url := range []*string{profile.AvatarURL, profile.CoverURL} {
+				if url != nil && *url != "" {
+					fileURLs = append(fileURLs, *url)
+				}
+			}
+			fileURLs = append(fileURLs, profile.AvatarVariants.URLs()...)
+			fileURLs = append(fileURLs, profile.CoverVariants.URLs()...)
+		}
+
+		var documentURLs []string
+		if err := tx.Raw("DELETE FROM user_documents WHERE user_id = ? RETURNING file_url", userID).
+			Scan(&documentURLs).Error; err != nil {
+			return fmt.Errorf("failed to delete documents: %w", err)
+		}
+		fileURLs = append(fileURLs, documentURLs...)
+
+		// Pre-uploaded application document URLs were never stored by us, so only uploads are returned
+		var applicationDocuments []struct {
+			FileURL  string
+			Uploaded bool
+		}
+		if err := tx.Raw("DELETE FROM application_documents WHERE user_id = ? RETURNING file_url, uploaded", userID).
+			Scan(&applicationDocuments).Error; err != nil {
+			return fmt.Errorf("failed to delete application documents: %w", err)
+		}
+		for _, doc := range applicationDocuments {
+			if doc.Uploaded {
+				fileURLs = append(fileURLs, doc.FileURL)
+			}
+		}
+
+		// Screening answers are written by the applicant, so they go with the account
+		if err := tx.Exec(
+			"DELETE FROM application_answers WHERE application_id IN (SELECT id FROM job_applications WHERE user_id = ?)",
+			userID,
+		).Error; err != nil {
+			return fmt.Errorf("failed to delete application answers: %w", err)
+		}
+
+		// Open applications are withdrawn with a stage entry, like a withdrawal by the applicant
+		if err := tx.Exec(`
+			WITH withdrawn AS (
+				UPDATE job_applications
+				SET status = 'withdrawn', closed_at = now(), updated_at = now()
+				WHERE user_id = ? AND status IN ('applied', 'screening', 'shortlisted', 'interview', 'offered')
+				RETURNING id
+			)
+			INSERT INTO job_application_stages (application_id, stage_name, status, description, notes, started_at, completed_at)
+			SELECT id, 'withdrawn', 'withdrawn', 'Application withdrawn by applicant', 'Account deleted', now(), now()
+			FROM withdrawn`,
+			userID,
+		).Error; err != nil {
+			return fmt.Errorf("failed to withdraw applications: %w", err)
+		}
+
+		// Resume snapshots are the applications' own copies of library documents, so they are
+		// deleted with the account like the library itself
+		var resumeSnapshotURLs []string
+		if err := tx.Raw(
+			"SELECT resume_url FROM job_applications WHERE user_id = ? AND resume_source_url IS NOT NULL AND resume_url <> ''",
+			userID,
+		).Scan(&resumeSnapshotURLs).Error; err != nil {
+			return fmt.Errorf("failed to get resume snapshots: %w", err)
+		}
+		fileURLs = append(fileURLs, resumeSnapshotURLs...)
+
+		// Applications stay for employers' statistics, without the resume link or cover note
+		if err := tx.Exec(
+			"UPDATE job_applications SET resume_url = NULL, resume_source_url = NULL, notes = NULL, updated_at = now() WHERE user_id = ?",
+			userID,
+		).Error; err != nil {
+			return fmt.Errorf("failed to unlink applications: %w", err)
+		}
+
+		// Reviews keep counting in company ratings but no longer point at the author
+		if err := tx.Exec(
+			"UPDATE company_reviews SET user_id = NULL, is_anonymous = true, updated_at = now() WHERE user_id = ?",
+			userID,
+		).Error; err != nil {
+			return fmt.Errorf("failed to unlink reviews: %w", err)
+		}
+
+		for _, table := range []string{"company_employees", "job_view_events"} {
+			if err := tx.Exec("UPDATE "+table+" SET user_id = NULL WHERE user_id = ?", userID).Error; err != nil {
+				return fmt.Errorf("failed to unlink %s: %w", table, err)
+			}
+		}
+
+		for _, table := range anonymizedUserTables {
+			if err := tx.Exec("DELETE FROM "+table+" WHERE user_id = ?", userID).Error; err != nil {
+				return fmt.Errorf("failed to delete %s: %w", table, err)
+			}
+		}
+
+		result := tx.Model(&user.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"full_name":         "",
+			"email":             placeholderEmail,
+			"phone":             nil,
+			"phone_verified_at": nil,
+			"password_hash":     "",
+			"is_verified":       false,
+			"email_verified_at": nil,
+			"status":            user.StatusDeleted,
+			"last_login":        nil,
+			"updated_at":        time.Now(),
+		})
+		if result.Error != nil {
+			return fmt.Errorf("failed to anonymize user: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fileURLs, nil
+}
+
+// CreateDataExport saves a data export
+func (r *accountPrivacyRepository) CreateDataExport(ctx context.Context, export *user.DataExport) error {
+	return r.db.WithContext(ctx).Create(export).Error
+}
+
+// FindOpenDataExport finds the user's pending or still downloadable export, returning nil when there is none
+func (r *accountPrivacyRepository) FindOpenDataExport(ctx context.Context, userID int64, now time.Time) (*user.DataExport, error) {
+	var export user.DataExport
+	err := r.db.WithContext(ctx).
+		Omit("data").
+		Where("user_id = ?", userID).
+		Where("status = ? OR (status = ? AND expires_at > ?)", user.DataExportPending, user.DataExportReady, now).
+		Order("created_at DESC, id DESC").
+		First(&export).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &export, nil
+}
+
+// FindDataExportByTokenHash finds the export with the download token hash, returning nil when there is none
+func (r *accountPrivacyRepository) FindDataExportByTokenHash(ctx context.Context, tokenHash string) (*user.DataExport, error) {
+	var export user.DataExport
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&export).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &export, nil
+}
+
+// FindPendingDataExports returns up to limit exports waiting to be generated, oldest first
+func (r *accountPrivacyRepository) FindPendingDataExports(ctx context.Context, limit int) ([]user.DataExport, error) {
+	var exports []user.DataExport
+	err := r.db.WithContext(ctx).
+		Where("status = ?", user.DataExportPending).
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Find(&exports).Error
+	return exports, err
+}
+
+// UpdateDataExport updates a data export
+func (r *accountPrivacyRepository) UpdateDataExport(ctx context.Context, export *user.DataExport) error {
+	return r.db.WithContext(ctx).Save(export).Error
+}
+
+// ExpireDataExports expires ready exports whose link expired before now and drops their data
+func (r *accountPrivacyRepository) ExpireDataExports(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&user.DataExport{}).
+		Where("status = ? AND expires_at <= ?", user.DataExportReady, now).
+		Updates(map[string]interface{}{
+			"status":     user.DataExportExpired,
+			"data":       nil,
+			"updated_at": now,
+		})
+	return result.RowsAffected, result.Error
+}
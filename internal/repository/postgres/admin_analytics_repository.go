@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"keerja-backend/internal/domain/admin"
+)
+
+// adminAnalyticsEventsQuery selects the timestamp of every counted event in a period, one
+// branch per metric. Each branch takes the metric name and the period bounds.
+const adminAnalyticsEventsQuery = `
+	SELECT ?::text AS metric, created_at AS at FROM users
+	WHERE created_at >= ? AND created_at < ?
+	UNION ALL
+	SELECT ?::text, created_at FROM companies
+	WHERE created_at >= ? AND created_at < ?
+	UNION ALL
+	SELECT ?::text, published_at FROM jobs
+	WHERE published_at >= ? AND published_at < ? AND deleted_at IS NULL
+	UNION ALL
+	SELECT ?::text, applied_at FROM job_applications
+	WHERE applied_at >= ? AND applied_at < ?
+	UNION ALL
+	SELECT ?::text, started_at FROM job_application_stages
+	WHERE stage_name = 'hired' AND started_at >= ? AND started_at < ?`
+
+// adminAnalyticsMetrics lists the metrics in the order of the events query branches
+var adminAnalyticsMetrics = []string{
+	admin.MetricNewUsers,
+	admin.MetricNewCompanies,
+	admin.MetricJobsPublished,
+	admin.MetricApplicationsSubmitted,
+	admin.MetricHires,
+}
+
+// adminAnalyticsRepository implements admin.AdminAnalyticsRepository interface
+type adminAnalyticsRepository struct {
+	db *gorm.DB
+}
+
+// NewAdminAnalyticsRepository creates a new instance of admin analytics repository
+func NewAdminAnalyticsRepository(db *gorm.DB) admin.AdminAnalyticsRepository {
+	return &adminAnalyticsRepository{db: db}
+}
+
+// CountByBucket counts every metric over both periods in one grouped query, bucketing the
+// event timestamps with date_trunc
+func (r *adminAnalyticsRepository) CountByBucket(ctx context.Context, previousFrom, from, to time.Time, interval string) ([]admin.AnalyticsBucketCount, error) {
+	args := make([]interface{}, 0, 2+3*len(adminAnalyticsMetrics))
+	args = append(args, from, interval)
+	for _, metric := range adminAnalyticsMetrics {
+		args = append(args, metric, previousFrom, to)
+	}
+
+	var counts []admin.AnalyticsBucketCount
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT e.metric, e.at < ? AS previous, date_trunc(?, e.at) AS bucket, COUNT(*) AS count
+		FROM (`+adminAnalyticsEventsQuery+`) e
+		GROUP BY e.metric, previous, bucket
+		ORDER BY e.metric, bucket`,
+		args...,
+	).Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
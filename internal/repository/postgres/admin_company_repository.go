@@ -0,0 +1,177 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"keerja-backend/internal/domain/admin"
+	"keerja-backend/internal/domain/company"
+)
+
+// adminCompanySortColumns maps admin company sort fields to the columns they order by
+var adminCompanySortColumns = map[string]string{
+	admin.AdminCompanySortCreatedAt:     "c.created_at",
+	admin.AdminCompanySortCompanyName:   "c.company_name",
+	admin.AdminCompanySortVerifiedAt:    "c.verified_at",
+	admin.AdminCompanySortUpdatedAt:     "c.updated_at",
+	admin.AdminCompanySortJobCount:      "total_jobs",
+	admin.AdminCompanySortFollowerCount: "total_followers",
+}
+
+// adminVerificationStatuses maps the verification status filter to company_verifications statuses
+var adminVerificationStatuses = map[string][]string{
+	"pending":  {company.VerificationStatusPending, company.VerificationStatusUnderReview},
+	"approved": {company.VerificationStatusVerified},
+	"rejected": {company.VerificationStatusRejected},
+	"expired":  {company.VerificationStatusExpired},
+}
+
+// adminCompanyColumns selects a company list row with its aggregate counts
+const adminCompanyColumns = `c.id, c.uuid, c.company_name, c.slug,
+	COALESCE(c.legal_name, '') AS legal_name,
+	COALESCE(c.registration_number, '') AS registration_number,
+	COALESCE(i.name, c.industry, '') AS industry,
+	COALESCE(cs.label, c.size_category, '') AS company_size,
+	CONCAT_WS(', ', COALESCE(ci.name, c.city), COALESCE(p.name, c.province)) AS location,
+	c.verified, c.verified_at, c.is_active,
+	COALESCE(cv.status, 'pending') AS verification_status,
+	COALESCE(js.total_jobs, 0) AS total_jobs,
+	COALESCE(js.active_jobs, 0) AS active_jobs,
+	COALESCE(aps.total_applications, 0) AS total_applications,
+	COALESCE(fs.total_followers, 0) AS total_followers,
+	COALESCE(ds.pending_documents, 0) AS pending_documents,
+	c.created_at, c.updated_at`
+
+// adminCompanyRepository implements admin.AdminCompanyRepository interface
+type adminCompanyRepository struct {
+	db *gorm.DB
+}
+
+// NewAdminCompanyRepository creates a new instance of admin company repository
+func NewAdminCompanyRepository(db *gorm.DB) admin.AdminCompanyRepository {
+	return &adminCompanyRepository{db: db}
+}
+
+// ListCompanies lists companies matching filter with their aggregate counts. Each count
+// comes from one grouped subquery joined on company_id rather than a query per company.
+func (r *adminCompanyRepository) ListCompanies(ctx context.Context, filter *admin.AdminCompanyFilter) ([]admin.AdminCompanyListItem, int64, error) {
+	var total int64
+	if err := r.filtered(ctx, filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	sortColumn, ok := adminCompanySortColumns[filter.SortBy]
+	if !ok {
+		sortColumn = adminCompanySortColumns[admin.AdminCompanySortCreatedAt]
+	}
+	sortOrder := "DESC"
+	if strings.EqualFold(filter.SortOrder, "asc") {
+		sortOrder = "ASC"
+	}
+
+	var items []admin.AdminCompanyListItem
+	err := r.filtered(ctx, filter).
+		Select(adminCompanyColumns).
+		Joins("LEFT JOIN industries i ON i.id = c.industry_id").
+		Joins("LEFT JOIN company_sizes cs ON cs.id = c.company_size_id").
+		Joins("LEFT JOIN cities ci ON ci.id = c.city_id").
+		Joins("LEFT JOIN provinces p ON p.id = c.province_id").
+		Joins(`LEFT JOIN (
+			SELECT company_id, COUNT(*) AS total_jobs, COUNT(*) FILTER (WHERE status = 'published') AS active_jobs
+			FROM jobs WHERE deleted_at IS NULL GROUP BY company_id
+		) js ON js.company_id = c.id`).
+		Joins(`LEFT JOIN (
+			SELECT company_id, COUNT(*) AS total_applications
+			FROM job_applications GROUP BY company_id
+		) aps ON aps.company_id = c.id`).
+		Joins(`LEFT JOIN (
+			SELECT company_id, COUNT(*) AS total_followers
+			FROM company_followers WHERE is_active = true GROUP BY company_id
+		) fs ON fs.company_id = c.id`).
+		Joins(`LEFT JOIN (
+			SELECT company_id, COUNT(*) AS pending_documents
+			FROM company_documents WHERE is_active = true AND status = 'pending' GROUP BY company_id
+		) ds ON ds.company_id = c.id`).
+		Order(fmt.Sprintf("%s %s NULLS LAST, c.id %s", sortColumn, sortOrder, sortOrder)).
+		Offset((filter.Page - 1) * filter.Limit).
+		Limit(filter.Limit).
+		Scan(&items).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+// filtered returns the companies matching filter, joined with their verification
+func (r *adminCompanyRepository) filtered(ctx context.Context, filter *admin.AdminCompanyFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).
+		Table("companies AS c").
+		Joins("LEFT JOIN company_verifications cv ON cv.company_id = c.id")
+
+	if filter.Deleted {
+		query = query.Where("c.deleted_at IS NOT NULL")
+	} else {
+		query = query.Where("c.deleted_at IS NULL")
+	}
+	if filter.Search != "" {
+		pattern := "%" + strings.ToLower(filter.Search) + "%"
+		query = query.Where("(LOWER(c.company_name) LIKE ? OR LOWER(c.legal_name) LIKE ?)", pattern, pattern)
+	}
+	if filter.Verified != nil {
+		query = query.Where("c.verified = ?", *filter.Verified)
+	}
+	if filter.IsActive != nil {
+		query = query.Where("c.is_active = ?", *filter.IsActive)
+	}
+	if statuses, ok := adminVerificationStatuses[filter.VerificationStatus]; ok {
+		if filter.VerificationStatus == "pending" {
+			query = query.Where("(cv.status IS NULL OR cv.status IN ?)", statuses)
+		} else {
+			query = query.Where("cv.status IN ?", statuses)
+		}
+	}
+	if filter.DocumentStatus != "" || filter.DocumentType != "" {
+		docQuery := r.db.Table("company_documents d").
+			Select("1").
+			Where("d.company_id = c.id AND d.is_active = true")
+		if filter.DocumentStatus != "" {
+			docQuery = docQuery.Where("d.status = ?", filter.DocumentStatus)
+		}
+		if filter.DocumentType != "" {
+			docQuery = docQuery.Where("d.document_type = ?", filter.DocumentType)
+		}
+		query = query.Where("EXISTS (?)", docQuery)
+	}
+	if filter.HasPublishedJobs != nil {
+		published := "EXISTS (SELECT 1 FROM jobs j WHERE j.company_id = c.id AND j.status = 'published' AND j.deleted_at IS NULL)"
+		if *filter.HasPublishedJobs {
+			query = query.Where(published)
+		} else {
+			query = query.Where("NOT " + published)
+		}
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("c.created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("c.created_at < ?", *filter.CreatedTo)
+	}
+	if filter.IndustryID != nil {
+		query = query.Where("c.industry_id = ?", *filter.IndustryID)
+	}
+	if filter.CompanySizeID != nil {
+		query = query.Where("c.company_size_id = ?", *filter.CompanySizeID)
+	}
+	if filter.ProvinceID != nil {
+		query = query.Where("c.province_id = ?", *filter.ProvinceID)
+	}
+	if filter.CityID != nil {
+		query = query.Where("c.city_id = ?", *filter.CityID)
+	}
+
+	return query
+}
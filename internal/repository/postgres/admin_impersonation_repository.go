@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"keerja-backend/internal/domain/admin"
+)
+
+// impersonationRepository implements admin.ImpersonationRepository interface
+type impersonationRepository struct {
+	db *gorm.DB
+}
+
+// NewImpersonationRepository creates a new instance of impersonation session repository
+func NewImpersonationRepository(db *gorm.DB) admin.ImpersonationRepository {
+	return &impersonationRepository{db: db}
+}
+
+// Create creates a new impersonation session record
+func (r *impersonationRepository) Create(ctx context.Context, session *admin.ImpersonationSession) error {
+	return r.db.WithContext(ctx).Create(session).Error
+}
+
+// FindByID retrieves an impersonation session by ID
+func (r *impersonationRepository) FindByID(ctx context.Context, id int64) (*admin.ImpersonationSession, error) {
+	var session admin.ImpersonationSession
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+// End sets ended_at on the session unless it was already ended
+func (r *impersonationRepository) End(ctx context.Context, id int64, endedAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&admin.ImpersonationSession{}).
+		Where("id = ? AND ended_at IS NULL", id).
+		Update("ended_at", endedAt).Error
+}
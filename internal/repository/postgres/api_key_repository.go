@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"keerja-backend/internal/domain/apikey"
+
+	"gorm.io/gorm"
+)
+
+// apiKeyRepository implements the apikey.APIKeyRepository interface
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new company API key repository instance
+func NewAPIKeyRepository(db *gorm.DB) apikey.APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+// Create inserts a new key
+func (r *apiKeyRepository) Create(ctx context.Context, key *apikey.CompanyAPIKey) error {
+	return r.db.WithContext(ctx).Create(key).Error
+}
+
+// FindByID finds a key by ID, returning nil when it does not exist
+func (r *apiKeyRepository) FindByID(ctx context.Context, id int64) (*apikey.CompanyAPIKey, error) {
+	var key apikey.CompanyAPIKey
+	err := r.db.WithContext(ctx).First(&key, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+// FindByHash finds a key by the hash of its plaintext, returning nil when it does not exist
+func (r *apiKeyRepository) FindByHash(ctx context.Context, keyHash string) (*apikey.CompanyAPIKey, error) {
+	var key apikey.CompanyAPIKey
+	err := r.db.WithContext(ctx).Where("key_hash = ?", keyHash).First(&key).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+// ListByCompany lists a company's keys, newest first
+func (r *apiKeyRepository) ListByCompany(ctx context.Context, companyID int64) ([]apikey.CompanyAPIKey, error) {
+	var keys []apikey.CompanyAPIKey
+	err := r.db.WithContext(ctx).
+		Where("company_id = ?", companyID).
+		Order("created_at DESC, id DESC").
+		Find(&keys).Error
+	return keys, err
+}
+
+// Revoke marks a key revoked
+func (r *apiKeyRepository) Revoke(ctx context.Context, id int64, revokedAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&apikey.CompanyAPIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"revoked_at": revokedAt,
+			"updated_at": revokedAt,
+		}).Error
+}
+
+// TouchLastUsed records when a key was last used
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id int64, usedAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&apikey.CompanyAPIKey{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", usedAt).Error
+}
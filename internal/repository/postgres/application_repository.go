@@ -2,12 +2,15 @@ package postgres
 
 import (
 	"context"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
 	"keerja-backend/internal/domain/application"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // applicationRepository implements the application.ApplicationRepository interface
@@ -24,9 +27,14 @@ func NewApplicationRepository(db *gorm.DB) application.ApplicationRepository {
 // JobApplication CRUD Operations
 // ============================================================================
 
-// Create creates a new job application
+// Create creates a new job application.
+// Returns application.ErrAlreadyApplied when the user already has an open application for the job.
 func (r *applicationRepository) Create(ctx context.Context, app *application.JobApplication) error {
-	return r.db.WithContext(ctx).Create(app).Error
+	err := r.db.WithContext(ctx).Create(app).Error
+	if isUniqueViolation(err, "uq_job_applications_open_job_user") {
+		return application.ErrAlreadyApplied
+	}
+	return err
 }
 
 // FindByID finds an application by ID with relationships preloaded
@@ -51,11 +59,12 @@ func (r *applicationRepository) FindByID(ctx context.Context, id int64) (*applic
 	return &app, nil
 }
 
-// FindByJobAndUser finds an application by job ID and user ID
+// FindByJobAndUser finds the user's most recent application for a job
 func (r *applicationRepository) FindByJobAndUser(ctx context.Context, jobID, userID int64) (*application.JobApplication, error) {
 	var app application.JobApplication
 	err := r.db.WithContext(ctx).
 		Where("job_id = ? AND user_id = ?", jobID, userID).
+		Order("applied_at DESC, id DESC").
 		Preload("Stages", func(db *gorm.DB) *gorm.DB {
 			return db.Order("started_at DESC")
 		}).
@@ -128,12 +137,91 @@ func (r *applicationRepository) ListByJob(ctx context.Context, jobID int64, filt
 	return r.List(ctx, filter, page, limit)
 }
 
+// boardSortOrders maps board sort options to the window ordering used to pick each column's top rows
+var boardSortOrders = map[string]string{
+	application.BoardSortAppliedAt:  "a.applied_at DESC, a.id DESC",
+	application.BoardSortMatchScore: "a.match_score DESC, a.applied_at DESC, a.id DESC",
+}
+
+// GetJobBoard returns the top perColumn applications of each status for a job, with each
+// status' total count, ranking within a status by the chosen sort
+func (r *applicationRepository) GetJobBoard(ctx context.Context, jobID int64, statuses []string, perColumn int, sortBy string) ([]application.ApplicationBoardRow, error) {
+	orderBy, ok := boardSortOrders[sortBy]
+	if !ok {
+		orderBy = boardSortOrders[application.BoardSortAppliedAt]
+	}
+
+	var rows []application.ApplicationBoardRow
+	err := r.db.WithContext(ctx).Raw(`
+		WITH ranked AS (
+			SELECT a.id, a.job_id, a.user_id, a.status, a.match_score, a.applied_at,
+				a.viewed_by_employer, a.is_bookmarked,
+				ROW_NUMBER() OVER (PARTITION BY a.status ORDER BY `+orderBy+`) AS status_rank,
+				COUNT(*) OVER (PARTITION BY a.status) AS status_count
+			FROM job_applications a
+			WHERE a.job_id = ? AND a.status IN ?
+		)
+		SELECT r.id, r.job_id, r.user_id, r.status, r.match_score, r.applied_at,
+			r.viewed_by_employer, r.is_bookmarked, r.status_count,
+			j.title AS job_title,
+			COALESCE(c.company_name, '') AS company_name,
+			COALESCE(u.full_name, '') AS user_name,
+			COALESCE(st.stage_name, r.status) AS current_stage
+		FROM ranked r
+		JOIN jobs j ON j.id = r.job_id AND j.deleted_at IS NULL
+		LEFT JOIN companies c ON c.id = j.company_id
+		LEFT JOIN users u ON u.id = r.user_id
+		LEFT JOIN LATERAL (
+			SELECT s.stage_name
+			FROM job_application_stages s
+			WHERE s.application_id = r.id AND s.completed_at IS NULL
+			ORDER BY s.started_at DESC
+			LIMIT 1
+		) st ON TRUE
+		WHERE r.status_rank <= ?
+		ORDER BY r.status, r.status_rank`,
+		jobID, statuses, perColumn,
+	).Scan(&rows).Error
+	return rows, err
+}
+
 // ListByCompany lists applications by company ID
 func (r *applicationRepository) ListByCompany(ctx context.Context, companyID int64, filter application.ApplicationFilter, page, limit int) ([]application.JobApplication, int64, error) {
 	filter.CompanyID = companyID
 	return r.List(ctx, filter, page, limit)
 }
 
+// ListByCompanyCursor lists a company's applications newest first, starting after the given cursor
+func (r *applicationRepository) ListByCompanyCursor(ctx context.Context, companyID int64, filter application.ApplicationFilter, cursor string, limit int) ([]application.JobApplication, string, error) {
+	var apps []application.JobApplication
+
+	if limit < 1 {
+		limit = 10
+	}
+	filter.CompanyID = companyID
+
+	query := r.db.WithContext(ctx).Model(&application.JobApplication{})
+	query = r.applyApplicationFilter(query, filter)
+	query, err := applyCursor(query, "job_applications", cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = query.
+		Preload("Stages", func(db *gorm.DB) *gorm.DB {
+			return db.Order("started_at DESC").Limit(1)
+		}).
+		Find(&apps).Error
+	if err != nil {
+		return nil, "", err
+	}
+
+	apps, next := cursorPage(apps, limit, func(app *application.JobApplication) (time.Time, int64) {
+		return app.CreatedAt, app.ID
+	})
+	return apps, next, nil
+}
+
 // ============================================================================
 // Application Status Operations
 // ============================================================================
@@ -195,7 +283,23 @@ func (r *applicationRepository) GetBookmarkedApplications(ctx context.Context, c
 // Application Search
 // ============================================================================
 
-// SearchApplications performs advanced search on applications
+// applicantSkillNameSQL normalizes user_skills.skill_name like the job match score does; it
+// matches the expression of idx_user_skills_user_normalized_name
+const applicantSkillNameSQL = `lower(regexp_replace(btrim(us.skill_name), '\s+', ' ', 'g'))`
+
+// applicantSkillsSQL counts the requested skills_master entries the applicant has a skill for,
+// by master name, normalized name or alias
+const applicantSkillsSQL = `(
+	SELECT COUNT(DISTINCT sm.id) FROM skills_master sm
+	JOIN user_skills us ON us.user_id = job_applications.user_id AND (
+		` + applicantSkillNameSQL + ` IN (lower(sm.name), lower(sm.normalized_name))
+		OR ` + applicantSkillNameSQL + ` IN (SELECT lower(alias) FROM unnest(sm.aliases) AS alias)
+	)
+	WHERE sm.id IN ?
+)`
+
+// SearchApplications performs advanced search on applications. Profile filters are correlated
+// subqueries on the applicant, so the search stays a single statement without duplicate rows.
 func (r *applicationRepository) SearchApplications(ctx context.Context, filter application.ApplicationSearchFilter, page, limit int) ([]application.JobApplication, int64, error) {
 	var apps []application.JobApplication
 	var total int64
@@ -205,48 +309,88 @@ func (r *applicationRepository) SearchApplications(ctx context.Context, filter a
 	// Apply keyword search
 	if filter.Keyword != "" {
 		keyword := "%" + strings.ToLower(filter.Keyword) + "%"
-		query = query.Where("LOWER(notes) LIKE ?", keyword)
+		query = query.Where("LOWER(job_applications.notes) LIKE ?", keyword)
 	}
 
 	// Filter by job IDs
 	if len(filter.JobIDs) > 0 {
-		query = query.Where("job_id IN ?", filter.JobIDs)
+		query = query.Where("job_applications.job_id IN ?", filter.JobIDs)
 	}
 
 	// Filter by company IDs
 	if len(filter.CompanyIDs) > 0 {
-		query = query.Where("company_id IN ?", filter.CompanyIDs)
+		query = query.Where("job_applications.company_id IN ?", filter.CompanyIDs)
 	}
 
 	// Filter by statuses
 	if len(filter.Statuses) > 0 {
-		query = query.Where("status IN ?", filter.Statuses)
+		query = query.Where("job_applications.status IN ?", filter.Statuses)
 	}
 
 	// Filter by minimum score
 	if filter.MinScore != nil {
-		query = query.Where("match_score >= ?", *filter.MinScore)
+		query = query.Where("job_applications.match_score >= ?", *filter.MinScore)
 	}
 
 	// Filter by sources
 	if len(filter.Sources) > 0 {
-		query = query.Where("source IN ?", filter.Sources)
+		query = query.Where("job_applications.source IN ?", filter.Sources)
 	}
 
 	// Filter by applied within days
 	if filter.AppliedWithin != nil {
 		cutoffDate := time.Now().AddDate(0, 0, -*filter.AppliedWithin)
-		query = query.Where("applied_at >= ?", cutoffDate)
+		query = query.Where("job_applications.applied_at >= ?", cutoffDate)
 	}
 
 	// Filter by has documents
 	if filter.HasDocuments != nil && *filter.HasDocuments {
-		query = query.Joins("INNER JOIN application_documents ON application_documents.application_id = job_applications.id")
+		query = query.Where("EXISTS (SELECT 1 FROM application_documents ad WHERE ad.application_id = job_applications.id)")
 	}
 
 	// Filter by has interviews
 	if filter.HasInterviews != nil && *filter.HasInterviews {
-		query = query.Joins("INNER JOIN interviews ON interviews.application_id = job_applications.id")
+		query = query.Where("EXISTS (SELECT 1 FROM interviews i WHERE i.application_id = job_applications.id)")
+	}
+
+	// Filter by applicant skills, requiring all of them unless any one is enough
+	if len(filter.SkillIDs) > 0 {
+		required := len(uniqueInt64s(filter.SkillIDs))
+		if filter.SkillMatch == application.SkillMatchAny {
+			required = 1
+		}
+		query = query.Where(applicantSkillsSQL+" >= ?", filter.SkillIDs, required)
+	}
+
+	// Filter by the applicant's highest degree
+	if filter.MinDegreeLevel != "" {
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM user_educations ue WHERE ue.user_id = job_applications.user_id AND ue.degree_level IN ?)",
+			application.DegreeLevelsFrom(filter.MinDegreeLevel),
+		)
+	}
+
+	// Filter by total years of work experience, counting current positions up to today
+	if filter.MinExperienceYears != nil && *filter.MinExperienceYears > 0 {
+		query = query.Where(`(
+			SELECT COALESCE(SUM(COALESCE(ux.end_date, CURRENT_DATE) - ux.start_date), 0)
+			FROM user_experiences ux WHERE ux.user_id = job_applications.user_id
+		) >= ? * 365`, *filter.MinExperienceYears)
+	}
+
+	// Filter by the applicant's location and availability
+	if len(filter.CityIDs) > 0 || len(filter.ProvinceIDs) > 0 || len(filter.AvailabilityStatuses) > 0 {
+		profile := r.db.Table("user_profiles up").Select("1").Where("up.user_id = job_applications.user_id")
+		if len(filter.CityIDs) > 0 {
+			profile = profile.Where("up.city_id IN ?", filter.CityIDs)
+		}
+		if len(filter.ProvinceIDs) > 0 {
+			profile = profile.Where("up.province_id IN ?", filter.ProvinceIDs)
+		}
+		if len(filter.AvailabilityStatuses) > 0 {
+			profile = profile.Where("up.availability_status IN ?", filter.AvailabilityStatuses)
+		}
+		query = query.Where("EXISTS (?)", profile)
 	}
 
 	// Count total
@@ -261,7 +405,7 @@ func (r *applicationRepository) SearchApplications(ctx context.Context, filter a
 		Preload("Stages", func(db *gorm.DB) *gorm.DB {
 			return db.Order("started_at DESC").Limit(1)
 		}).
-		Order("applied_at DESC").
+		Order("job_applications.applied_at DESC, job_applications.id DESC").
 		Offset(offset).
 		Limit(limit).
 		Find(&apps).Error
@@ -269,6 +413,19 @@ func (r *applicationRepository) SearchApplications(ctx context.Context, filter a
 	return apps, total, err
 }
 
+// uniqueInt64s returns ids without duplicates, keeping their order
+func uniqueInt64s(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
 // GetApplicationsWithHighScore gets applications with match score above threshold
 func (r *applicationRepository) GetApplicationsWithHighScore(ctx context.Context, minScore float64, limit int) ([]application.JobApplication, error) {
 	var apps []application.JobApplication
@@ -392,22 +549,24 @@ func (r *applicationRepository) GetUserApplicationStats(ctx context.Context, use
 
 	for _, sc := range statusCounts {
 		switch sc.Status {
-		case "applied":
+		case application.StatusApplied:
 			stats.AppliedCount = sc.Count
-		case "screening":
+		case application.StatusScreening:
 			stats.ScreeningCount = sc.Count
-		case "shortlisted":
+		case application.StatusShortlisted:
 			stats.ShortlistedCount = sc.Count
-		case "interview":
+		case application.StatusInterview:
 			stats.InterviewCount = sc.Count
-		case "offered":
+		case application.StatusOffered:
 			stats.OfferedCount = sc.Count
-		case "hired":
+		case application.StatusHired:
 			stats.HiredCount = sc.Count
-		case "rejected":
+		case application.StatusRejected:
 			stats.RejectedCount = sc.Count
-		case "withdrawn":
+		case application.StatusWithdrawn:
 			stats.WithdrawnCount = sc.Count
+		case application.StatusJobWithdrawn:
+			stats.JobWithdrawnCount = sc.Count
 		}
 	}
 
@@ -434,7 +593,7 @@ func (r *applicationRepository) GetUserApplicationStats(ctx context.Context, use
 	r.db.WithContext(ctx).
 		Table("job_applications").
 		Select("COALESCE(AVG(EXTRACT(EPOCH FROM (updated_at - applied_at)) / 86400), 0) as avg").
-		Where("user_id = ? AND status != ?", userID, "applied").
+		Where("user_id = ? AND status != ?", userID, application.StatusApplied).
 		Scan(&avgResponseTime)
 	stats.AverageResponseTime = avgResponseTime.Avg
 
@@ -466,22 +625,24 @@ func (r *applicationRepository) GetJobApplicationStats(ctx context.Context, jobI
 
 	for _, sc := range statusCounts {
 		switch sc.Status {
-		case "applied":
+		case application.StatusApplied:
 			stats.AppliedCount = sc.Count
-		case "screening":
+		case application.StatusScreening:
 			stats.ScreeningCount = sc.Count
-		case "shortlisted":
+		case application.StatusShortlisted:
 			stats.ShortlistedCount = sc.Count
-		case "interview":
+		case application.StatusInterview:
 			stats.InterviewCount = sc.Count
-		case "offered":
+		case application.StatusOffered:
 			stats.OfferedCount = sc.Count
-		case "hired":
+		case application.StatusHired:
 			stats.HiredCount = sc.Count
-		case "rejected":
+		case application.StatusRejected:
 			stats.RejectedCount = sc.Count
-		case "withdrawn":
+		case application.StatusWithdrawn:
 			stats.WithdrawnCount = sc.Count
+		case application.StatusJobWithdrawn:
+			stats.JobWithdrawnCount = sc.Count
 		}
 	}
 
@@ -508,7 +669,7 @@ func (r *applicationRepository) GetJobApplicationStats(ctx context.Context, jobI
 	r.db.WithContext(ctx).
 		Table("job_applications").
 		Select("COALESCE(AVG(EXTRACT(EPOCH FROM (updated_at - applied_at)) / 86400), 0) as avg").
-		Where("job_id = ? AND status = ?", jobID, "hired").
+		Where("job_id = ? AND status = ?", jobID, application.StatusHired).
 		Scan(&avgTimeToHire)
 	stats.AverageTimeToHire = avgTimeToHire.Avg
 
@@ -551,7 +712,7 @@ func (r *applicationRepository) GetCompanyApplicationStats(ctx context.Context,
 	// Count total hires
 	r.db.WithContext(ctx).
 		Model(&application.JobApplication{}).
-		Where("company_id = ? AND status = ?", companyID, "hired").
+		Where("company_id = ? AND status = ?", companyID, application.StatusHired).
 		Count(&stats.TotalHires)
 
 	// Calculate average match score
@@ -572,7 +733,7 @@ func (r *applicationRepository) GetCompanyApplicationStats(ctx context.Context,
 	r.db.WithContext(ctx).
 		Table("job_applications").
 		Select("COALESCE(AVG(EXTRACT(EPOCH FROM (updated_at - applied_at)) / 86400), 0) as avg").
-		Where("company_id = ? AND status = ?", companyID, "hired").
+		Where("company_id = ? AND status = ?", companyID, application.StatusHired).
 		Scan(&avgTimeToHire)
 	stats.AverageTimeToHire = avgTimeToHire.Avg
 
@@ -676,6 +837,73 @@ func (r *applicationRepository) GetCurrentStage(ctx context.Context, application
 	return &stage, nil
 }
 
+// GetCurrentStages returns the latest open stage of each application in one query
+func (r *applicationRepository) GetCurrentStages(ctx context.Context, applicationIDs []int64) (map[int64]application.JobApplicationStage, error) {
+	current := make(map[int64]application.JobApplicationStage, len(applicationIDs))
+	if len(applicationIDs) == 0 {
+		return current, nil
+	}
+
+	var stages []application.JobApplicationStage
+	err := r.db.WithContext(ctx).
+		Raw(`SELECT DISTINCT ON (application_id) *
+			FROM job_application_stages
+			WHERE application_id IN ? AND completed_at IS NULL
+			ORDER BY application_id, started_at DESC`, applicationIDs).
+		Scan(&stages).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stage := range stages {
+		current[stage.ApplicationID] = stage
+	}
+	return current, nil
+}
+
+// FindStaleStages finds current stages of active applications that have been open longer
+// than the company's reminder threshold and were not reminded since remindedBefore.
+// Companies without settings use defaultThresholdDays; opted-out companies are skipped.
+func (r *applicationRepository) FindStaleStages(ctx context.Context, defaultThresholdDays int, remindedBefore time.Time) ([]application.StaleStage, error) {
+	var stages []application.StaleStage
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT s.id AS stage_id, s.application_id, s.stage_name, s.started_at,
+			j.id AS job_id, j.title AS job_title, j.company_id,
+			u.full_name AS applicant_name,
+			COALESCE(cs.stage_reminder_days, ?) AS threshold_days
+		FROM job_application_stages s
+		JOIN job_applications a ON a.id = s.application_id
+		JOIN jobs j ON j.id = a.job_id AND j.deleted_at IS NULL
+		JOIN users u ON u.id = a.user_id
+		LEFT JOIN company_settings cs ON cs.company_id = j.company_id
+		WHERE s.completed_at IS NULL
+			AND a.status NOT IN ('hired', 'rejected', 'withdrawn', 'job_withdrawn')
+			AND COALESCE(cs.stage_reminder_enabled, TRUE)
+			AND s.started_at < NOW() - make_interval(days => COALESCE(cs.stage_reminder_days, ?))
+			AND (s.sla_reminded_at IS NULL OR s.sla_reminded_at <= ?)
+			AND NOT EXISTS (
+				SELECT 1 FROM job_application_stages newer
+				WHERE newer.application_id = s.application_id
+					AND newer.completed_at IS NULL
+					AND newer.started_at > s.started_at
+			)
+		ORDER BY j.company_id, j.id, s.started_at`,
+		defaultThresholdDays, defaultThresholdDays, remindedBefore,
+	).Scan(&stages).Error
+	return stages, err
+}
+
+// MarkStagesReminded records that the stages were included in a reminder
+func (r *applicationRepository) MarkStagesReminded(ctx context.Context, stageIDs []int64, at time.Time) error {
+	if len(stageIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Model(&application.JobApplicationStage{}).
+		Where("id IN ?", stageIDs).
+		UpdateColumn("sla_reminded_at", at).Error
+}
+
 // GetStageHistory gets the stage history for an application
 func (r *applicationRepository) GetStageHistory(ctx context.Context, applicationID int64) ([]application.JobApplicationStage, error) {
 	var stages []application.JobApplicationStage
@@ -793,6 +1021,68 @@ func (r *applicationRepository) GetUnverifiedDocuments(ctx context.Context, page
 	return documents, total, err
 }
 
+// SetResumeSnapshot points the application's resume at its snapshot unless it already has one
+func (r *applicationRepository) SetResumeSnapshot(ctx context.Context, applicationID int64, snapshotURL, sourceURL string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&application.JobApplication{}).
+		Where("id = ? AND resume_source_url IS NULL", applicationID).
+		Updates(map[string]interface{}{
+			"resume_url":        snapshotURL,
+			"resume_source_url": sourceURL,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ListUnsnapshottedResumes lists applications whose resume has no snapshot yet, by ID
+func (r *applicationRepository) ListUnsnapshottedResumes(ctx context.Context, afterID int64, limit int) ([]application.JobApplication, error) {
+	var apps []application.JobApplication
+	err := r.db.WithContext(ctx).
+		Where("id > ? AND resume_url <> '' AND resume_source_url IS NULL", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&apps).Error
+
+	return apps, err
+}
+
+// ListUnsnapshottedDocuments lists pre-uploaded application documents without a snapshot, by ID
+func (r *applicationRepository) ListUnsnapshottedDocuments(ctx context.Context, afterID int64, limit int) ([]application.ApplicationDocument, error) {
+	var documents []application.ApplicationDocument
+	err := r.db.WithContext(ctx).
+		Where("id > ? AND uploaded = ? AND source_url IS NULL", afterID, false).
+		Order("id ASC").
+		Limit(limit).
+		Find(&documents).Error
+
+	return documents, err
+}
+
+// ============================================================================
+// ApplicationAnswer Operations
+// ============================================================================
+
+// CreateAnswers stores an application's screening answers
+func (r *applicationRepository) CreateAnswers(ctx context.Context, answers []application.ApplicationAnswer) error {
+	if len(answers) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(&answers).Error
+}
+
+// ListAnswersByApplication lists an application's answers with their question text, in question order
+func (r *applicationRepository) ListAnswersByApplication(ctx context.Context, applicationID int64) ([]application.ApplicationAnswer, error) {
+	var answers []application.ApplicationAnswer
+	err := r.db.WithContext(ctx).
+		Table("application_answers").
+		Select("application_answers.*, job_questions.question_text, job_questions.question_type").
+		Joins("JOIN job_questions ON job_questions.id = application_answers.question_id").
+		Where("application_answers.application_id = ?", applicationID).
+		Order("job_questions.sort_order ASC, job_questions.id ASC").
+		Find(&answers).Error
+
+	return answers, err
+}
+
 // ============================================================================
 // ApplicationNote Operations
 // ============================================================================
@@ -1053,6 +1343,196 @@ func (r *applicationRepository) CancelInterview(ctx context.Context, id int64) e
 		Update("status", "cancelled").Error
 }
 
+// ============================================================================
+// Interview Slot Operations
+// ============================================================================
+
+// CreateInterviewSlots inserts the slots, skipping any that start at the same time as another
+// slot of the same interviewer, and returns how many were created
+func (r *applicationRepository) CreateInterviewSlots(ctx context.Context, slots []application.InterviewSlot) (int64, error) {
+	if len(slots) == 0 {
+		return 0, nil
+	}
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "interviewer_id"}, {Name: "starts_at"}},
+			DoNothing: true,
+		}).
+		Create(&slots)
+
+	return result.RowsAffected, result.Error
+}
+
+// FindInterviewSlotByID finds an interview slot by ID
+func (r *applicationRepository) FindInterviewSlotByID(ctx context.Context, id int64) (*application.InterviewSlot, error) {
+	var slot application.InterviewSlot
+	if err := r.db.WithContext(ctx).First(&slot, id).Error; err != nil {
+		return nil, err
+	}
+	return &slot, nil
+}
+
+// ListInterviewSlots lists a company's interview slots by start time
+func (r *applicationRepository) ListInterviewSlots(ctx context.Context, companyID int64, filter application.InterviewSlotFilter) ([]application.InterviewSlot, error) {
+	var slots []application.InterviewSlot
+	query := r.db.WithContext(ctx).Where("company_id = ?", companyID)
+
+	if filter.From != nil {
+		query = query.Where("starts_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("starts_at < ?", *filter.To)
+	}
+	switch filter.Status {
+	case application.InterviewSlotOpen:
+		query = query.Where("application_id IS NULL")
+	case application.InterviewSlotBooked:
+		query = query.Where("application_id IS NOT NULL")
+	}
+	if filter.InterviewerID > 0 {
+		query = query.Where("interviewer_id = ?", filter.InterviewerID)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	err := query.Order("starts_at ASC, id ASC").Find(&slots).Error
+	return slots, err
+}
+
+// UpdateInterviewSlot saves the time and meeting details of an open slot.
+// Returns application.ErrInterviewSlotBooked when the slot has been booked meanwhile.
+func (r *applicationRepository) UpdateInterviewSlot(ctx context.Context, slot *application.InterviewSlot) error {
+	result := r.db.WithContext(ctx).
+		Model(&application.InterviewSlot{}).
+		Where("id = ? AND application_id IS NULL", slot.ID).
+		Updates(map[string]interface{}{
+			"starts_at":      slot.StartsAt,
+			"ends_at":        slot.EndsAt,
+			"timezone":       slot.Timezone,
+			"interview_type": slot.InterviewType,
+			"meeting_link":   slot.MeetingLink,
+			"location":       slot.Location,
+			"updated_at":     time.Now(),
+		})
+
+	if isUniqueViolation(result.Error, "interview_slots_interviewer_start") {
+		return application.ErrInterviewSlotExists
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return application.ErrInterviewSlotBooked
+	}
+	return nil
+}
+
+// DeleteInterviewSlot deletes an open slot.
+// Returns application.ErrInterviewSlotBooked when the slot is booked.
+func (r *applicationRepository) DeleteInterviewSlot(ctx context.Context, id int64) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND application_id IS NULL", id).
+		Delete(&application.InterviewSlot{})
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return application.ErrInterviewSlotBooked
+	}
+	return nil
+}
+
+// ClaimInterviewSlot marks the booking link used and the slot booked by the link's application.
+// Both updates are conditional, so of two concurrent bookings of the same slot or with the same
+// link only one succeeds.
+func (r *applicationRepository) ClaimInterviewSlot(ctx context.Context, link *application.InterviewBookingLink, slotID int64) error {
+	now := time.Now()
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		used := tx.Model(&application.InterviewBookingLink{}).
+			Where("id = ? AND used_at IS NULL AND expires_at > ?", link.ID, now).
+			Updates(map[string]interface{}{"used_at": now, "slot_id": slotID})
+		if used.Error != nil {
+			return used.Error
+		}
+		if used.RowsAffected == 0 {
+			return application.ErrBookingLinkUsed
+		}
+
+		booked := tx.Model(&application.InterviewSlot{}).
+			Where("id = ? AND company_id = ? AND application_id IS NULL AND starts_at > ?", slotID, link.CompanyID, now).
+			Updates(map[string]interface{}{
+				"application_id": link.ApplicationID,
+				"booked_at":      now,
+				"updated_at":     now,
+			})
+		if booked.Error != nil {
+			return booked.Error
+		}
+		if booked.RowsAffected == 0 {
+			return application.ErrInterviewSlotTaken
+		}
+		return nil
+	})
+}
+
+// UnclaimInterviewSlot reopens the link and the slot claimed by ClaimInterviewSlot
+func (r *applicationRepository) UnclaimInterviewSlot(ctx context.Context, linkID, slotID int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&application.InterviewBookingLink{}).
+			Where("id = ?", linkID).
+			Updates(map[string]interface{}{"used_at": nil, "slot_id": nil}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&application.InterviewSlot{}).
+			Where("id = ? AND interview_id IS NULL", slotID).
+			Updates(map[string]interface{}{
+				"application_id": nil,
+				"booked_at":      nil,
+				"updated_at":     time.Now(),
+			}).Error
+	})
+}
+
+// SetInterviewSlotInterview records the interview scheduled for a booked slot
+func (r *applicationRepository) SetInterviewSlotInterview(ctx context.Context, slotID, interviewID int64) error {
+	return r.db.WithContext(ctx).
+		Model(&application.InterviewSlot{}).
+		Where("id = ?", slotID).
+		Updates(map[string]interface{}{"interview_id": interviewID, "updated_at": time.Now()}).Error
+}
+
+// ReleaseInterviewSlot reopens the slot booked for the interview, if any
+func (r *applicationRepository) ReleaseInterviewSlot(ctx context.Context, interviewID int64) error {
+	return r.db.WithContext(ctx).
+		Model(&application.InterviewSlot{}).
+		Where("interview_id = ?", interviewID).
+		Updates(map[string]interface{}{
+			"application_id": nil,
+			"interview_id":   nil,
+			"booked_at":      nil,
+			"updated_at":     time.Now(),
+		}).Error
+}
+
+// CreateBookingLink creates an interview booking link
+func (r *applicationRepository) CreateBookingLink(ctx context.Context, link *application.InterviewBookingLink) error {
+	return r.db.WithContext(ctx).Create(link).Error
+}
+
+// FindBookingLinkByTokenHash finds the booking link with the token hash
+func (r *applicationRepository) FindBookingLinkByTokenHash(ctx context.Context, tokenHash string) (*application.InterviewBookingLink, error) {
+	var link application.InterviewBookingLink
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&link).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
 // ============================================================================
 // Analytics and Reporting
 // ============================================================================
@@ -1097,17 +1577,17 @@ func (r *applicationRepository) GetConversionFunnel(ctx context.Context, jobID i
 
 	for _, sc := range statusCounts {
 		switch sc.Status {
-		case "applied":
+		case application.StatusApplied:
 			funnel.AppliedCount = sc.Count
-		case "screening":
+		case application.StatusScreening:
 			funnel.ScreeningCount = sc.Count
-		case "shortlisted":
+		case application.StatusShortlisted:
 			funnel.ShortlistedCount = sc.Count
-		case "interview":
+		case application.StatusInterview:
 			funnel.InterviewCount = sc.Count
-		case "offered":
+		case application.StatusOffered:
 			funnel.OfferedCount = sc.Count
-		case "hired":
+		case application.StatusHired:
 			funnel.HiredCount = sc.Count
 		}
 	}
@@ -1116,11 +1596,11 @@ func (r *applicationRepository) GetConversionFunnel(ctx context.Context, jobID i
 	funnel.ConversionRates = make(map[string]float64)
 
 	if funnel.AppliedCount > 0 {
-		funnel.ConversionRates["screening"] = float64(funnel.ScreeningCount) / float64(funnel.AppliedCount) * 100
-		funnel.ConversionRates["shortlisted"] = float64(funnel.ShortlistedCount) / float64(funnel.AppliedCount) * 100
-		funnel.ConversionRates["interview"] = float64(funnel.InterviewCount) / float64(funnel.AppliedCount) * 100
-		funnel.ConversionRates["offered"] = float64(funnel.OfferedCount) / float64(funnel.AppliedCount) * 100
-		funnel.ConversionRates["hired"] = float64(funnel.HiredCount) / float64(funnel.AppliedCount) * 100
+		funnel.ConversionRates[application.StatusScreening] = float64(funnel.ScreeningCount) / float64(funnel.AppliedCount) * 100
+		funnel.ConversionRates[application.StatusShortlisted] = float64(funnel.ShortlistedCount) / float64(funnel.AppliedCount) * 100
+		funnel.ConversionRates[application.StatusInterview] = float64(funnel.InterviewCount) / float64(funnel.AppliedCount) * 100
+		funnel.ConversionRates[application.StatusOffered] = float64(funnel.OfferedCount) / float64(funnel.AppliedCount) * 100
+		funnel.ConversionRates[application.StatusHired] = float64(funnel.HiredCount) / float64(funnel.AppliedCount) * 100
 	}
 
 	return &funnel, nil
@@ -1160,23 +1640,48 @@ func (r *applicationRepository) GetTopApplicants(ctx context.Context, jobID int6
 	return apps, err
 }
 
-// GetApplicationSourceStats gets statistics by application source
-func (r *applicationRepository) GetApplicationSourceStats(ctx context.Context, companyID int64) ([]application.SourceStats, error) {
-	var stats []application.SourceStats
+// GetApplicationSourceStats counts a company's job views, applications and hires per utm_source
+// in one query. Sources seen only in views or only in applications are both listed.
+func (r *applicationRepository) GetApplicationSourceStats(ctx context.Context, filter application.SourceStatsFilter) ([]application.SourceStats, error) {
+	jobCondition := ""
+	args := map[string]interface{}{
+		"company_id": filter.CompanyID,
+		"from":       filter.From,
+		"to":         filter.To,
+		"direct":     application.SourceDirect,
+		"hired":      application.StatusHired,
+	}
+	if filter.JobID != nil {
+		jobCondition = "AND job_id = @job_id"
+		args["job_id"] = *filter.JobID
+	}
 
-	err := r.db.WithContext(ctx).
-		Table("job_applications").
-		Select(`
-			source,
-			COUNT(*) as count,
-			SUM(CASE WHEN status = 'hired' THEN 1 ELSE 0 END) as hired_count,
-			CASE WHEN COUNT(*) > 0 THEN (SUM(CASE WHEN status = 'hired' THEN 1 ELSE 0 END)::float / COUNT(*) * 100) ELSE 0 END as conversion_rate,
-			COALESCE(AVG(match_score), 0) as average_match_score
-		`).
-		Where("company_id = ?", companyID).
-		Group("source").
-		Order("count DESC").
-		Scan(&stats).Error
+	var stats []application.SourceStats
+	err := r.db.WithContext(ctx).Raw(`
+		WITH apps AS (
+			SELECT COALESCE(NULLIF(utm_source, ''), @direct) AS source,
+				COUNT(*) AS applications,
+				COUNT(*) FILTER (WHERE status = @hired) AS hires,
+				COALESCE(AVG(match_score), 0) AS average_match_score
+			FROM job_applications
+			WHERE company_id = @company_id AND applied_at >= @from AND applied_at < @to `+jobCondition+`
+			GROUP BY 1
+		), views AS (
+			SELECT COALESCE(NULLIF(utm_source, ''), @direct) AS source, COUNT(*) AS views
+			FROM job_view_events
+			WHERE job_id IN (SELECT id FROM jobs WHERE company_id = @company_id)
+				AND viewed_at >= @from AND viewed_at < @to `+jobCondition+`
+			GROUP BY 1
+		)
+		SELECT COALESCE(apps.source, views.source) AS source,
+			COALESCE(views.views, 0) AS views,
+			COALESCE(apps.applications, 0) AS count,
+			COALESCE(apps.hires, 0) AS hired_count,
+			COALESCE(apps.average_match_score, 0) AS average_match_score
+		FROM apps
+		FULL OUTER JOIN views ON views.source = apps.source
+		ORDER BY count DESC, views DESC, source ASC
+	`, args).Scan(&stats).Error
 
 	return stats, err
 }
@@ -1195,6 +1700,127 @@ func (r *applicationRepository) BulkDeleteApplications(ctx context.Context, ids
 	return r.db.WithContext(ctx).Delete(&application.JobApplication{}, ids).Error
 }
 
+// ============================================================================
+// Bulk Action Operations
+// ============================================================================
+
+// FilterCompanyApplicationIDs returns the IDs among ids of the company's applications
+func (r *applicationRepository) FilterCompanyApplicationIDs(ctx context.Context, companyID int64, ids []int64) ([]int64, error) {
+	var found []int64
+	if len(ids) == 0 {
+		return found, nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Model(&application.JobApplication{}).
+		Where("company_id = ? AND id IN ?", companyID, ids).
+		Order("id ASC").
+		Pluck("id", &found).Error
+	return found, err
+}
+
+// ListCompanyApplicationIDs returns the IDs of up to limit of the company's applications
+// matching filter, oldest first
+func (r *applicationRepository) ListCompanyApplicationIDs(ctx context.Context, companyID int64, filter application.ApplicationFilter, limit int) ([]int64, error) {
+	var ids []int64
+	filter.CompanyID = companyID
+
+	query := r.db.WithContext(ctx).Model(&application.JobApplication{})
+	err := r.applyApplicationFilter(query, filter).
+		Order("id ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// CreateBulkAction inserts the action together with a pending item per application
+func (r *applicationRepository) CreateBulkAction(ctx context.Context, action *application.BulkAction, applicationIDs []int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Items").Create(action).Error; err != nil {
+			return err
+		}
+
+		items := make([]application.BulkActionItem, len(applicationIDs))
+		for i, id := range applicationIDs {
+			items[i] = application.BulkActionItem{BulkActionID: action.ID, ApplicationID: id, Status: application.BulkItemPending}
+		}
+		return tx.CreateInBatches(items, 500).Error
+	})
+}
+
+// FindBulkActionByID finds a bulk action by ID
+func (r *applicationRepository) FindBulkActionByID(ctx context.Context, id int64) (*application.BulkAction, error) {
+	var action application.BulkAction
+	if err := r.db.WithContext(ctx).First(&action, id).Error; err != nil {
+		return nil, err
+	}
+	return &action, nil
+}
+
+// FindUnfinishedBulkActions returns queued and running actions, oldest first
+func (r *applicationRepository) FindUnfinishedBulkActions(ctx context.Context, limit int) ([]application.BulkAction, error) {
+	var actions []application.BulkAction
+	err := r.db.WithContext(ctx).
+		Where("status IN ?", []string{application.BulkActionQueued, application.BulkActionRunning}).
+		Order("id ASC").
+		Limit(limit).
+		Find(&actions).Error
+	return actions, err
+}
+
+// StartBulkAction marks a queued action running
+func (r *applicationRepository) StartBulkAction(ctx context.Context, id int64, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&application.BulkAction{}).
+		Where("id = ? AND status = ?", id, application.BulkActionQueued).
+		Updates(map[string]interface{}{"status": application.BulkActionRunning, "started_at": at, "updated_at": at}).Error
+}
+
+// CompleteBulkAction marks an action done
+func (r *applicationRepository) CompleteBulkAction(ctx context.Context, id int64, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&application.BulkAction{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"status": application.BulkActionDone, "completed_at": at, "updated_at": at}).Error
+}
+
+// ListBulkActionItems lists up to limit of an action's items with the status, in ID order
+func (r *applicationRepository) ListBulkActionItems(ctx context.Context, actionID int64, status string, limit int) ([]application.BulkActionItem, error) {
+	var items []application.BulkActionItem
+	err := r.db.WithContext(ctx).
+		Where("bulk_action_id = ? AND status = ?", actionID, status).
+		Order("id ASC").
+		Limit(limit).
+		Find(&items).Error
+	return items, err
+}
+
+// RecordBulkActionItem saves the result of a pending item and adds it to the action's counts.
+// Only a pending item is updated, so an item processed twice after a restart is counted once.
+func (r *applicationRepository) RecordBulkActionItem(ctx context.Context, item *application.BulkActionItem) error {
+	counter := "failed_count"
+	if item.Status == application.BulkItemSucceeded {
+		counter = "succeeded_count"
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&application.BulkActionItem{}).
+			Where("id = ? AND status = ?", item.ID, application.BulkItemPending).
+			Updates(map[string]interface{}{"status": item.Status, "error": item.Error, "processed_at": item.ProcessedAt})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		return tx.Model(&application.BulkAction{}).
+			Where("id = ?", item.BulkActionID).
+			Updates(map[string]interface{}{
+				"processed_count": gorm.Expr("processed_count + 1"),
+				counter:           gorm.Expr(counter + " + 1"),
+				"updated_at":      time.Now(),
+			}).Error
+	})
+}
+
 // ============================================================================
 // Helper Functions
 // ============================================================================
@@ -1245,6 +1871,13 @@ func (r *applicationRepository) applyApplicationFilter(query *gorm.DB, filter ap
 		query = query.Where("applied_at <= ?", filter.AppliedBefore)
 	}
 
+	for _, questionID := range slices.Sorted(maps.Keys(filter.Answers)) {
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM application_answers aa WHERE aa.application_id = job_applications.id AND aa.question_id = ? AND LOWER(aa.answer_value) = LOWER(?))",
+			questionID, filter.Answers[questionID],
+		)
+	}
+
 	return query
 }
 
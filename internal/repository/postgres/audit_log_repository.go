@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+
+	"keerja-backend/internal/domain/audit"
+
+	"gorm.io/gorm"
+)
+
+// auditLogRepository implements the audit.AuditLogRepository interface
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository creates a new audit log repository instance
+func NewAuditLogRepository(db *gorm.DB) audit.AuditLogRepository {
+	return &auditLogRepository{db: db}
+}
+
+// CreateBatch inserts audit log entries
+func (r *auditLogRepository) CreateBatch(ctx context.Context, logs []audit.AuditLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(&logs).Error
+}
+
+// List lists audit log entries matching filter, newest first
+func (r *auditLogRepository) List(ctx context.Context, filter audit.AuditLogFilter, page, limit int) ([]audit.AuditLog, int64, error) {
+	var logs []audit.AuditLog
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&audit.AuditLog{})
+	if filter.ActorType != "" {
+		query = query.Where("actor_type = ?", filter.ActorType)
+	}
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&logs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
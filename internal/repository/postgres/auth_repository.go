@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"keerja-backend/internal/domain/auth"
@@ -146,12 +147,17 @@ func (r *otpCodeRepository) Update(ctx context.Context, otp *auth.OTPCode) error
 	return r.db.WithContext(ctx).Save(otp).Error
 }
 
-// IncrementAttempts increments failed verification attempts
-func (r *otpCodeRepository) IncrementAttempts(ctx context.Context, id int64) error {
-	return r.db.WithContext(ctx).
+// ConsumeAttempt atomically records a verification attempt. The conditional update keeps
+// concurrent guesses from exceeding maxAttempts.
+func (r *otpCodeRepository) ConsumeAttempt(ctx context.Context, id int64, maxAttempts int) (bool, error) {
+	result := r.db.WithContext(ctx).
 		Model(&auth.OTPCode{}).
-		Where("id = ?", id).
-		UpdateColumn("attempts", gorm.Expr("attempts + ?", 1)).Error
+		Where("id = ? AND is_used = false AND attempts < ?", id, maxAttempts).
+		UpdateColumn("attempts", gorm.Expr("attempts + ?", 1))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
 }
 
 // DeleteExpired deletes all expired OTP codes
@@ -161,20 +167,33 @@ func (r *otpCodeRepository) DeleteExpired(ctx context.Context) error {
 		Delete(&auth.OTPCode{}).Error
 }
 
-// CountRecentByUserID counts recent OTP requests by user
-func (r *otpCodeRepository) CountRecentByUserID(ctx context.Context, userID int64, since time.Time, otpType string) (int64, error) {
-	var count int64
+// FindRecentByUserID finds OTP codes of a type issued to a user since the given time, newest first
+func (r *otpCodeRepository) FindRecentByUserID(ctx context.Context, userID int64, otpType string, since time.Time) ([]*auth.OTPCode, error) {
+	var otps []*auth.OTPCode
 	err := r.db.WithContext(ctx).
-		Model(&auth.OTPCode{}).
 		Where("user_id = ? AND type = ? AND created_at >= ?", userID, otpType, since).
-		Count(&count).Error
-	return count, err
+		Order("created_at DESC").
+		Find(&otps).Error
+	return otps, err
+}
+
+// FindRecentByIP finds OTP codes of any type requested from an IP since the given time, newest first
+func (r *otpCodeRepository) FindRecentByIP(ctx context.Context, ipAddress string, since time.Time) ([]*auth.OTPCode, error) {
+	var otps []*auth.OTPCode
+	err := r.db.WithContext(ctx).
+		Where("ip_address = ? AND created_at >= ?", ipAddress, since).
+		Order("created_at DESC").
+		Find(&otps).Error
+	return otps, err
 }
 
 // ===========================================
 // RefreshToken Repository Implementation
 // ===========================================
 
+// errRotationLost aborts a rotation transaction when the old token was already revoked
+var errRotationLost = errors.New("refresh token already rotated")
+
 // refreshTokenRepository implements auth.RefreshTokenRepository
 type refreshTokenRepository struct {
 	db *gorm.DB
@@ -190,6 +209,20 @@ func (r *refreshTokenRepository) Create(ctx context.Context, token *auth.Refresh
 	return r.db.WithContext(ctx).Create(token).Error
 }
 
+// FindByID finds refresh token by ID
+func (r *refreshTokenRepository) FindByID(ctx context.Context, id int64) (*auth.RefreshToken, error) {
+	var token auth.RefreshToken
+	err := r.db.WithContext(ctx).First(&token, id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
 // FindByTokenHash finds refresh token by hash
 func (r *refreshTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*auth.RefreshToken, error) {
 	var token auth.RefreshToken
@@ -295,6 +328,65 @@ func (r *refreshTokenRepository) RevokeByDeviceID(ctx context.Context, userID in
 		}).Error
 }
 
+// Rotate atomically revokes the old token and stores its replacement
+func (r *refreshTokenRepository) Rotate(ctx context.Context, oldID int64, next *auth.RefreshToken) (bool, error) {
+	rotated := false
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+
+		// Only the first concurrent use of the old token wins; the rest see no affected rows
+		result := tx.Model(&auth.RefreshToken{}).
+			Where("id = ? AND revoked = ?", oldID, false).
+			Updates(map[string]interface{}{
+				"revoked":        true,
+				"revoked_at":     time.Now(),
+				"revoked_reason": "rotated",
+				"replaced_by":    next.ID,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			// Roll back the replacement token
+			return errRotationLost
+		}
+
+		rotated = true
+		return nil
+	})
+
+	if errors.Is(err, errRotationLost) {
+		return false, nil
+	}
+	return rotated, err
+}
+
+// RevokeFamily revokes every token in a rotation family
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string, reason string) error {
+	now := time.Now()
+	return r.db.WithContext(ctx).
+		Model(&auth.RefreshToken{}).
+		Where("family_id = ? AND revoked = ?", familyID, false).
+		Updates(map[string]interface{}{
+			"revoked":        true,
+			"revoked_at":     now,
+			"revoked_reason": reason,
+		}).Error
+}
+
+// IsFamilyActive checks if a rotation family still has a usable token
+func (r *refreshTokenRepository) IsFamilyActive(ctx context.Context, familyID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&auth.RefreshToken{}).
+		Where("family_id = ? AND revoked = ? AND expires_at > ?", familyID, false, time.Now()).
+		Count(&count).Error
+	return count > 0, err
+}
+
 // DeleteExpired deletes all expired refresh tokens
 func (r *refreshTokenRepository) DeleteExpired(ctx context.Context) error {
 	return r.db.WithContext(ctx).
@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"keerja-backend/internal/domain/company"
+
+	"gorm.io/gorm"
+)
+
+// companyDigestRepository implements the company.DigestRepository interface
+type companyDigestRepository struct {
+	db *gorm.DB
+}
+
+// NewCompanyDigestRepository creates a new company digest repository instance
+func NewCompanyDigestRepository(db *gorm.DB) company.DigestRepository {
+	return &companyDigestRepository{db: db}
+}
+
+// FindWeeklyDigestCompanies lists the live companies due a digest with their published job count
+func (r *companyDigestRepository) FindWeeklyDigestCompanies(ctx context.Context, sentBefore time.Time) ([]company.DigestCompany, error) {
+	var companies []company.DigestCompany
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT c.id AS company_id, c.company_name,
+			(SELECT COUNT(*) FROM jobs j
+				WHERE j.company_id = c.id AND j.deleted_at IS NULL AND j.status = 'published'
+					AND (j.expired_at IS NULL OR j.expired_at > NOW())) AS published_jobs
+		FROM companies c
+		LEFT JOIN company_settings cs ON cs.company_id = c.id
+		WHERE c.deleted_at IS NULL
+			AND COALESCE(cs.weekly_digest_enabled, TRUE)
+			AND (cs.weekly_digest_sent_at IS NULL OR cs.weekly_digest_sent_at <= ?)
+		ORDER BY c.id`,
+		sentBefore,
+	).Scan(&companies).Error
+	return companies, err
+}
+
+// GetDigestJobActivity counts new and waiting applications per job in a single grouped query
+func (r *companyDigestRepository) GetDigestJobActivity(ctx context.Context, companyID int64, since, awaitingBefore time.Time) ([]company.DigestJobActivity, error) {
+	var activity []company.DigestJobActivity
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT j.id AS job_id, j.title AS job_title,
+			COUNT(*) FILTER (WHERE a.applied_at >= ?) AS new_applications,
+			COUNT(*) FILTER (WHERE a.status = 'applied' AND a.applied_at < ?) AS awaiting_action
+		FROM job_applications a
+		JOIN jobs j ON j.id = a.job_id AND j.deleted_at IS NULL
+		WHERE j.company_id = ?
+			AND (a.applied_at >= ? OR (a.status = 'applied' AND a.applied_at < ?))
+		GROUP BY j.id, j.title
+		ORDER BY new_applications DESC, awaiting_action DESC, j.id`,
+		since, awaitingBefore, companyID, since, awaitingBefore,
+	).Scan(&activity).Error
+	return activity, err
+}
+
+// GetDigestInterviews lists the scheduled and rescheduled interviews in the window
+func (r *companyDigestRepository) GetDigestInterviews(ctx context.Context, companyID int64, from, to time.Time) ([]company.DigestInterview, error) {
+	var interviews []company.DigestInterview
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT i.id AS interview_id, i.application_id, j.title AS job_title,
+			u.full_name AS candidate_name, i.scheduled_at
+		FROM interviews i
+		JOIN job_applications a ON a.id = i.application_id
+		JOIN jobs j ON j.id = a.job_id AND j.deleted_at IS NULL
+		JOIN users u ON u.id = a.user_id
+		WHERE j.company_id = ?
+			AND i.status IN ('scheduled', 'rescheduled')
+			AND i.scheduled_at >= ? AND i.scheduled_at < ?
+		ORDER BY i.scheduled_at, i.id`,
+		companyID, from, to,
+	).Scan(&interviews).Error
+	return interviews, err
+}
+
+// GetDigestExpiringJobs lists the published jobs whose expiry date falls in the window
+func (r *companyDigestRepository) GetDigestExpiringJobs(ctx context.Context, companyID int64, from, to time.Time) ([]company.DigestExpiringJob, error) {
+	var jobs []company.DigestExpiringJob
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT id AS job_id, title AS job_title, expired_at
+		FROM jobs
+		WHERE company_id = ? AND deleted_at IS NULL AND status = 'published'
+			AND expired_at >= ? AND expired_at < ?
+		ORDER BY expired_at, id`,
+		companyID, from, to,
+	).Scan(&jobs).Error
+	return jobs, err
+}
+
+// GetFollowerGrowth counts active followers, and those among them who followed since
+func (r *companyDigestRepository) GetFollowerGrowth(ctx context.Context, companyID int64, since time.Time) (*company.FollowerGrowth, error) {
+	var growth company.FollowerGrowth
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT COUNT(*) FILTER (WHERE followed_at >= ?) AS new_followers,
+			COUNT(*) AS total_followers
+		FROM company_followers
+		WHERE company_id = ? AND is_active`,
+		since, companyID,
+	).Scan(&growth).Error
+	if err != nil {
+		return nil, err
+	}
+	return &growth, nil
+}
+
+// MarkWeeklyDigestSent stores the digest timestamp, creating the settings row with its
+// defaults when the company never saved any
+func (r *companyDigestRepository) MarkWeeklyDigestSent(ctx context.Context, companyID int64, at time.Time) error {
+	return r.db.WithContext(ctx).Exec(`
+		INSERT INTO company_settings (company_id, weekly_digest_sent_at)
+		VALUES (?, ?)
+		ON CONFLICT (company_id) DO UPDATE SET weekly_digest_sent_at = EXCLUDED.weekly_digest_sent_at`,
+		companyID, at,
+	).Error
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"keerja-backend/internal/domain/company"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// companyDomainRepository implements the company.DomainRepository interface
+type companyDomainRepository struct {
+	db *gorm.DB
+}
+
+// NewCompanyDomainRepository creates a new company domain repository instance
+func NewCompanyDomainRepository(db *gorm.DB) company.DomainRepository {
+	return &companyDomainRepository{db: db}
+}
+
+// FindDomainVerification returns the company's domain verification, or nil without one
+func (r *companyDomainRepository) FindDomainVerification(ctx context.Context, companyID int64) (*company.DomainVerification, error) {
+	var verification company.DomainVerification
+	err := r.db.WithContext(ctx).First(&verification, "company_id = ?", companyID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &verification, nil
+}
+
+// SaveDomainVerification creates or replaces the company's domain verification
+func (r *companyDomainRepository) SaveDomainVerification(ctx context.Context, verification *company.DomainVerification) error {
+	verification.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "company_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"domain", "method", "token", "attempts", "expires_at", "verified_at", "updated_at"}),
+	}).Create(verification).Error
+}
+
+// FindAutoJoinCompanies lists the live companies whose email domain is domain, verified by the
+// company, with auto-join enabled
+func (r *companyDomainRepository) FindAutoJoinCompanies(ctx context.Context, domain string) ([]company.Company, error) {
+	var companies []company.Company
+	err := r.db.WithContext(ctx).
+		Joins("JOIN company_domain_verifications v ON v.company_id = companies.id AND v.verified_at IS NOT NULL AND v.domain = ?", domain).
+		Joins("JOIN company_settings cs ON cs.company_id = companies.id AND cs.auto_join_enabled").
+		Where("LOWER(TRIM(LEADING '@' FROM companies.email_domain)) = ?", domain).
+		Order("companies.company_name ASC").
+		Find(&companies).Error
+	return companies, err
+}
+
+// ListJoinRequests lists the company's pending join requests with the requesting users, oldest first
+func (r *companyDomainRepository) ListJoinRequests(ctx context.Context, companyID int64) ([]company.JoinRequest, error) {
+	var requests []company.JoinRequest
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT eu.id AS employer_user_id, eu.user_id, u.full_name, u.email, eu.role,
+			eu.join_requested_at AS requested_at
+		FROM employer_users eu
+		JOIN users u ON u.id = eu.user_id
+		WHERE eu.company_id = ? AND eu.join_requested_at IS NOT NULL
+		ORDER BY eu.join_requested_at, eu.id`,
+		companyID,
+	).Scan(&requests).Error
+	return requests, err
+}
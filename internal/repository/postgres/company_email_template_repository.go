@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"keerja-backend/internal/domain/email"
+
+	"gorm.io/gorm"
+)
+
+// companyTemplateRepository implements the email.CompanyTemplateRepository interface
+type companyTemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewCompanyTemplateRepository creates a new company email template repository instance
+func NewCompanyTemplateRepository(db *gorm.DB) email.CompanyTemplateRepository {
+	return &companyTemplateRepository{db: db}
+}
+
+// Create inserts a new template, one per company and event
+func (r *companyTemplateRepository) Create(ctx context.Context, t *email.CompanyEmailTemplate) error {
+	err := r.db.WithContext(ctx).Create(t).Error
+	if isUniqueViolation(err, "idx_company_email_templates_company_event") {
+		return email.ErrCompanyTemplateExists
+	}
+	return err
+}
+
+// FindByID finds a template by ID, returning nil when it does not exist
+func (r *companyTemplateRepository) FindByID(ctx context.Context, id int64) (*email.CompanyEmailTemplate, error) {
+	var t email.CompanyEmailTemplate
+	err := r.db.WithContext(ctx).First(&t, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+// FindByCompanyAndEvent finds a company's template for event, returning nil when it has none
+func (r *companyTemplateRepository) FindByCompanyAndEvent(ctx context.Context, companyID int64, event string) (*email.CompanyEmailTemplate, error) {
+	var t email.CompanyEmailTemplate
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND event_type = ?", companyID, event).
+		First(&t).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+// ListByCompany lists a company's templates ordered by event
+func (r *companyTemplateRepository) ListByCompany(ctx context.Context, companyID int64) ([]email.CompanyEmailTemplate, error) {
+	var templates []email.CompanyEmailTemplate
+	err := r.db.WithContext(ctx).
+		Where("company_id = ?", companyID).
+		Order("event_type ASC").
+		Find(&templates).Error
+	return templates, err
+}
+
+// Update saves a template's subject and body
+func (r *companyTemplateRepository) Update(ctx context.Context, t *email.CompanyEmailTemplate) error {
+	t.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).
+		Model(&email.CompanyEmailTemplate{}).
+		Where("id = ?", t.ID).
+		Updates(map[string]interface{}{
+			"subject":    t.Subject,
+			"body":       t.Body,
+			"updated_by": t.UpdatedBy,
+			"updated_at": t.UpdatedAt,
+		}).Error
+}
+
+// Delete deletes a template
+func (r *companyTemplateRepository) Delete(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Delete(&email.CompanyEmailTemplate{}, id).Error
+}
@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/job"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// companyQuotaRepository implements the company.QuotaRepository interface
+type companyQuotaRepository struct {
+	db *gorm.DB
+}
+
+// NewCompanyQuotaRepository creates a new company quota repository instance
+func NewCompanyQuotaRepository(db *gorm.DB) company.QuotaRepository {
+	return &companyQuotaRepository{db: db}
+}
+
+// ListPlanQuotas lists the plan limit overrides
+func (r *companyQuotaRepository) ListPlanQuotas(ctx context.Context) ([]company.PlanQuota, error) {
+	var quotas []company.PlanQuota
+	err := r.db.WithContext(ctx).Order("plan").Find(&quotas).Error
+	return quotas, err
+}
+
+// SavePlanQuota inserts or replaces the override of the quota's plan
+func (r *companyQuotaRepository) SavePlanQuota(ctx context.Context, quota *company.PlanQuota) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "plan"}},
+			DoUpdates: clause.AssignmentColumns([]string{"max_published_jobs", "updated_by", "updated_at"}),
+		}).
+		Create(quota).Error
+}
+
+// SetCompanyPlan sets the company's plan; nil derives it from the verification again
+func (r *companyQuotaRepository) SetCompanyPlan(ctx context.Context, companyID int64, plan *string) error {
+	result := r.db.WithContext(ctx).
+		Model(&company.Company{}).
+		Where("id = ?", companyID).
+		UpdateColumn("plan", plan)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return company.ErrCompanyNotFound
+	}
+	return nil
+}
+
+// CountPublishedJobs counts the company's published jobs that have not expired
+func (r *companyQuotaRepository) CountPublishedJobs(ctx context.Context, companyID int64) (int, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&job.Job{}).
+		Where("company_id = ? AND status = ?", companyID, job.StatusPublished).
+		Where("(expired_at IS NULL OR expired_at > ?)", time.Now()).
+		Count(&count).Error
+	return int(count), err
+}
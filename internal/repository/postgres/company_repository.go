@@ -7,8 +7,10 @@ import (
 	"time"
 
 	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/user"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // companyRepository implements company.CompanyRepository
@@ -46,6 +48,16 @@ func (r *companyRepository) FindByID(ctx context.Context, id int64) (*company.Co
 	return &c, nil
 }
 
+// FindByIDs finds companies by IDs without preloading relations
+func (r *companyRepository) FindByIDs(ctx context.Context, ids []int64) ([]company.Company, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var companies []company.Company
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&companies).Error
+	return companies, err
+}
+
 // FindByIDWithMasterData finds a company by ID with all master data relations preloaded
 func (r *companyRepository) FindByIDWithMasterData(ctx context.Context, id int64) (*company.Company, error) {
 	var c company.Company
@@ -127,6 +139,19 @@ func (r *companyRepository) FindBySlug(ctx context.Context, slug string) (*compa
 	return &c, nil
 }
 
+// SlugExists reports whether another company, including soft-deleted ones, uses the slug
+func (r *companyRepository) SlugExists(ctx context.Context, slug string, excludeID int64) (bool, error) {
+	var count int64
+	query := r.db.WithContext(ctx).Unscoped().
+		Model(&company.Company{}).
+		Where("slug = ?", slug)
+	if excludeID > 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
 // FindBySlugWithMasterData finds a company by slug with all master data relations preloaded
 func (r *companyRepository) FindBySlugWithMasterData(ctx context.Context, slug string) (*company.Company, error) {
 	var c company.Company
@@ -151,14 +176,67 @@ func (r *companyRepository) FindBySlugWithMasterData(ctx context.Context, slug s
 	return &c, nil
 }
 
-// Update updates a company
+// Update updates a company if it still has the version it was loaded with, and increments
+// the version. A company changed since returns company.ErrCompanyVersionConflict.
 func (r *companyRepository) Update(ctx context.Context, c *company.Company) error {
-	return r.db.WithContext(ctx).Save(c).Error
+	return updateVersioned(ctx, r.db, c, &c.Version, company.ErrCompanyVersionConflict, func(query *gorm.DB) *gorm.DB {
+		return query.Select("*").Updates(c)
+	})
 }
 
-// Delete soft deletes a company
+// Delete soft deletes a company and deactivates its active employer memberships, marking
+// them with the deletion time so Restore brings back exactly those
 func (r *companyRepository) Delete(ctx context.Context, id int64) error {
-	return r.db.WithContext(ctx).Delete(&company.Company{}, id).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Model(&company.Company{}).Where("id = ?", id).Update("deleted_at", now).Error; err != nil {
+			return err
+		}
+		return tx.Model(&company.EmployerUser{}).
+			Where("company_id = ? AND is_active = ?", id, true).
+			Updates(map[string]interface{}{
+				"is_active":          false,
+				"company_deleted_at": now,
+			}).Error
+	})
+}
+
+// FindDeletedByID finds a soft-deleted company by ID
+func (r *companyRepository) FindDeletedByID(ctx context.Context, id int64) (*company.Company, error) {
+	var c company.Company
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL").
+		First(&c, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Restore clears a company's soft delete and reactivates the employer memberships the
+// deletion deactivated
+func (r *companyRepository) Restore(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&company.Company{}).Where("id = ?", id).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		return tx.Model(&company.EmployerUser{}).
+			Where("company_id = ? AND company_deleted_at IS NOT NULL", id).
+			Updates(map[string]interface{}{
+				"is_active":          true,
+				"company_deleted_at": nil,
+			}).Error
+	})
+}
+
+// liveCompanyCondition restricts rows of a company child table to companies that are not
+// soft-deleted. Queries through the company model get this from gorm; queries on child
+// tables have to add it themselves.
+func liveCompanyCondition(table string) string {
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM companies WHERE companies.id = %s.company_id AND companies.deleted_at IS NULL)", table)
 }
 
 // List retrieves companies with filtering, pagination, and sorting
@@ -167,37 +245,7 @@ func (r *companyRepository) List(ctx context.Context, filter *company.CompanyFil
 	var total int64
 
 	query := r.db.WithContext(ctx).Model(&company.Company{})
-
-	// Apply filters
-	if filter != nil {
-		if filter.Industry != nil {
-			query = query.Where("industry = ?", *filter.Industry)
-		}
-		if filter.CompanyType != nil {
-			query = query.Where("company_type = ?", *filter.CompanyType)
-		}
-		if filter.SizeCategory != nil {
-			query = query.Where("size_category = ?", *filter.SizeCategory)
-		}
-		if filter.City != nil {
-			query = query.Where("city = ?", *filter.City)
-		}
-		if filter.Province != nil {
-			query = query.Where("province = ?", *filter.Province)
-		}
-		if filter.Verified != nil {
-			query = query.Where("verified = ?", *filter.Verified)
-		}
-		if filter.IsActive != nil {
-			query = query.Where("is_active = ?", *filter.IsActive)
-		}
-
-		// Search by company name
-		if filter.SearchQuery != nil && *filter.SearchQuery != "" {
-			searchPattern := "%" + strings.ToLower(*filter.SearchQuery) + "%"
-			query = query.Where("LOWER(company_name) LIKE ? OR LOWER(legal_name) LIKE ?", searchPattern, searchPattern)
-		}
-	}
+	query = applyCompanyFilter(query, filter)
 
 	// Count total records
 	if err := query.Count(&total).Error; err != nil {
@@ -241,6 +289,71 @@ func (r *companyRepository) List(ctx context.Context, filter *company.CompanyFil
 	return companies, total, err
 }
 
+// ListByCursor retrieves companies newest first, starting after the given cursor.
+// Only filter.Limit is used for paging; Page, SortBy and SortOrder are ignored.
+func (r *companyRepository) ListByCursor(ctx context.Context, filter *company.CompanyFilter, cursor string) ([]company.Company, string, error) {
+	var companies []company.Company
+
+	limit := 10
+	if filter != nil && filter.Limit > 0 {
+		limit = filter.Limit
+	}
+
+	query := r.db.WithContext(ctx).Model(&company.Company{})
+	query = applyCompanyFilter(query, filter)
+	query, err := applyCursor(query, "companies", cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = query.
+		Preload("Profile").
+		Preload("Verification").
+		Find(&companies).Error
+	if err != nil {
+		return nil, "", err
+	}
+
+	companies, next := cursorPage(companies, limit, func(c *company.Company) (time.Time, int64) {
+		return c.CreatedAt, c.ID
+	})
+	return companies, next, nil
+}
+
+// applyCompanyFilter applies the legacy company filters and the name search to query
+func applyCompanyFilter(query *gorm.DB, filter *company.CompanyFilter) *gorm.DB {
+	if filter != nil {
+		if filter.Industry != nil {
+			query = query.Where("industry = ?", *filter.Industry)
+		}
+		if filter.CompanyType != nil {
+			query = query.Where("company_type = ?", *filter.CompanyType)
+		}
+		if filter.SizeCategory != nil {
+			query = query.Where("size_category = ?", *filter.SizeCategory)
+		}
+		if filter.City != nil {
+			query = query.Where("city = ?", *filter.City)
+		}
+		if filter.Province != nil {
+			query = query.Where("province = ?", *filter.Province)
+		}
+		if filter.Verified != nil {
+			query = query.Where("verified = ?", *filter.Verified)
+		}
+		if filter.IsActive != nil {
+			query = query.Where("is_active = ?", *filter.IsActive)
+		}
+
+		// Search by company name
+		if filter.SearchQuery != nil && *filter.SearchQuery != "" {
+			searchPattern := "%" + strings.ToLower(*filter.SearchQuery) + "%"
+			query = query.Where("LOWER(company_name) LIKE ? OR LOWER(legal_name) LIKE ?", searchPattern, searchPattern)
+		}
+	}
+	return query
+}
+
 // ListWithMasterData retrieves companies with filtering, pagination, sorting, and master data preloaded
 func (r *companyRepository) ListWithMasterData(ctx context.Context, filter *company.CompanyFilter) ([]company.Company, int64, error) {
 	var companies []company.Company
@@ -358,6 +471,7 @@ func (r *companyRepository) FindProfileByCompanyID(ctx context.Context, companyI
 	var profile company.CompanyProfile
 	err := r.db.WithContext(ctx).
 		Where("company_id = ?", companyID).
+		Where(liveCompanyCondition("company_profiles")).
 		First(&profile).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -368,9 +482,12 @@ func (r *companyRepository) FindProfileByCompanyID(ctx context.Context, companyI
 	return &profile, nil
 }
 
-// UpdateProfile updates a company profile
+// UpdateProfile updates a company profile if it still has the version it was loaded with,
+// and increments the version. A profile changed since returns company.ErrProfileVersionConflict.
 func (r *companyRepository) UpdateProfile(ctx context.Context, profile *company.CompanyProfile) error {
-	return r.db.WithContext(ctx).Save(profile).Error
+	return updateVersioned(ctx, r.db, profile, &profile.Version, company.ErrProfileVersionConflict, func(query *gorm.DB) *gorm.DB {
+		return query.Select("*").Updates(profile)
+	})
 }
 
 // ===========================================
@@ -440,7 +557,8 @@ func (r *companyRepository) GetFollowers(ctx context.Context, companyID int64, p
 
 	query := r.db.WithContext(ctx).
 		Model(&company.CompanyFollower{}).
-		Where("company_id = ? AND is_active = ?", companyID, true)
+		Where("company_id = ? AND is_active = ?", companyID, true).
+		Where(liveCompanyCondition("company_followers"))
 
 	// Count total
 	if err := query.Count(&total).Error; err != nil {
@@ -506,6 +624,7 @@ func (r *companyRepository) CountFollowers(ctx context.Context, companyID int64)
 	err := r.db.WithContext(ctx).
 		Model(&company.CompanyFollower{}).
 		Where("company_id = ? AND is_active = ?", companyID, true).
+		Where(liveCompanyCondition("company_followers")).
 		Count(&count).Error
 	return count, err
 }
@@ -516,7 +635,11 @@ func (r *companyRepository) CountFollowers(ctx context.Context, companyID int64)
 
 // CreateReview creates a company review
 func (r *companyRepository) CreateReview(ctx context.Context, review *company.CompanyReview) error {
-	return r.db.WithContext(ctx).Create(review).Error
+	err := r.db.WithContext(ctx).Create(review).Error
+	if isUniqueViolation(err, "company_reviews_company_id_user_id_key") {
+		return company.ErrReviewAlreadyExists
+	}
+	return err
 }
 
 // UpdateReview updates a company review
@@ -551,7 +674,8 @@ func (r *companyRepository) GetReviewsByCompanyID(ctx context.Context, companyID
 
 	query := r.db.WithContext(ctx).
 		Model(&company.CompanyReview{}).
-		Where("company_id = ?", companyID)
+		Where("company_id = ?", companyID).
+		Where(liveCompanyCondition("company_reviews"))
 
 	// Apply filters
 	if filter != nil {
@@ -561,6 +685,9 @@ func (r *companyRepository) GetReviewsByCompanyID(ctx context.Context, companyID
 		if filter.Status != nil {
 			query = query.Where("status = ?", *filter.Status)
 		}
+		if len(filter.ExcludeStatuses) > 0 {
+			query = query.Where("status NOT IN ?", filter.ExcludeStatuses)
+		}
 		if filter.MinRating != nil {
 			query = query.Where("rating_overall >= ?", *filter.MinRating)
 		}
@@ -587,25 +714,54 @@ func (r *companyRepository) GetReviewsByCompanyID(ctx context.Context, companyID
 	}
 	offset := (page - 1) * limit
 
-	// Sorting
+	// Sorting - only whitelisted columns reach the ORDER BY clause
 	sortBy := "created_at"
 	sortOrder := "DESC"
 	if filter != nil {
-		if filter.SortBy != "" {
-			sortBy = filter.SortBy
+		if column, ok := reviewSortColumns[filter.SortBy]; ok {
+			sortBy = column
 		}
-		if filter.SortOrder != "" {
-			sortOrder = strings.ToUpper(filter.SortOrder)
+		if strings.EqualFold(filter.SortOrder, "asc") {
+			sortOrder = "ASC"
 		}
 	}
 
 	err := query.
-		Order(fmt.Sprintf("%s %s", sortBy, sortOrder)).
+		Preload("Response").
+		Order(fmt.Sprintf("%s %s, id DESC", sortBy, sortOrder)).
 		Limit(limit).
 		Offset(offset).
 		Find(&reviews).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if filter != nil && filter.ViewerUserID != nil && len(reviews) > 0 {
+		reviewIDs := make([]int64, len(reviews))
+		for i := range reviews {
+			reviewIDs[i] = reviews[i].ID
+		}
+		votes, err := r.GetUserReviewVotes(ctx, *filter.ViewerUserID, reviewIDs)
+		if err != nil {
+			return nil, 0, err
+		}
+		for i := range reviews {
+			if vote, ok := votes[reviews[i].ID]; ok {
+				reviews[i].MyVote = &vote
+			}
+		}
+	}
+
+	return reviews, total, nil
+}
 
-	return reviews, total, err
+// reviewSortColumns maps accepted sort_by values to review columns
+var reviewSortColumns = map[string]string{
+	"created_at":     "created_at",
+	"rating_overall": "rating_overall",
+	"rating":         "rating_overall",
+	"helpful_count":  "helpful_count",
+	"helpful":        "helpful_count",
 }
 
 // GetReviewsByUserID retrieves all reviews by a user
@@ -614,11 +770,35 @@ func (r *companyRepository) GetReviewsByUserID(ctx context.Context, userID int64
 	err := r.db.WithContext(ctx).
 		Preload("Company").
 		Where("user_id = ?", userID).
+		Where(liveCompanyCondition("company_reviews")).
 		Order("created_at DESC").
 		Find(&reviews).Error
 	return reviews, err
 }
 
+// CountReviewsByUserSince counts the reviews a user created since the given time
+func (r *companyRepository) CountReviewsByUserSince(ctx context.Context, userID int64, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&company.CompanyReview{}).
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Count(&count).Error
+	return count, err
+}
+
+// IsVerifiedReviewer reports whether the user is a (current or former) employee of the
+// company or has a hired application at it
+func (r *companyRepository) IsVerifiedReviewer(ctx context.Context, companyID, userID int64) (bool, error) {
+	var verified bool
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT EXISTS (
+			SELECT 1 FROM company_employees WHERE company_id = ? AND user_id = ?
+		) OR EXISTS (
+			SELECT 1 FROM job_applications WHERE company_id = ? AND user_id = ? AND status = 'hired'
+		)`, companyID, userID, companyID, userID).Scan(&verified).Error
+	return verified, err
+}
+
 // ApproveReview approves a company review
 func (r *companyRepository) ApproveReview(ctx context.Context, id, moderatedBy int64) error {
 	now := time.Now()
@@ -626,7 +806,7 @@ func (r *companyRepository) ApproveReview(ctx context.Context, id, moderatedBy i
 		Model(&company.CompanyReview{}).
 		Where("id = ?", id).
 		Updates(map[string]interface{}{
-			"status":       "approved",
+			"status":       company.ReviewStatusApproved,
 			"moderated_by": moderatedBy,
 			"moderated_at": now,
 		}).Error
@@ -639,50 +819,390 @@ func (r *companyRepository) RejectReview(ctx context.Context, id, moderatedBy in
 		Model(&company.CompanyReview{}).
 		Where("id = ?", id).
 		Updates(map[string]interface{}{
-			"status":       "rejected",
+			"status":       company.ReviewStatusRejected,
 			"moderated_by": moderatedBy,
 			"moderated_at": now,
 		}).Error
 }
 
-// CalculateAverageRatings calculates average ratings for a company
-func (r *companyRepository) CalculateAverageRatings(ctx context.Context, companyID int64) (*company.AverageRatings, error) {
-	var result struct {
-		AvgOverall    float64
-		AvgCulture    float64
-		AvgWorkLife   float64
-		AvgSalary     float64
-		AvgManagement float64
-		TotalReviews  int64
+// GetReviewsByStatus retrieves reviews in a moderation status, oldest first, with company and reviewer names
+func (r *companyRepository) GetReviewsByStatus(ctx context.Context, status string, filter *company.ReviewModerationFilter, page, limit int) ([]company.ModerationReview, int64, error) {
+	var reviews []company.ModerationReview
+	var total int64
+
+	query := r.db.WithContext(ctx).
+		Model(&company.CompanyReview{}).
+		Where("company_reviews.status = ?", status).
+		Where(liveCompanyCondition("company_reviews"))
+
+	if filter != nil {
+		if filter.CompanyID != nil {
+			query = query.Where("company_reviews.company_id = ?", *filter.CompanyID)
+		}
+		if filter.ReviewerType != nil {
+			query = query.Where("company_reviews.reviewer_type = ?", *filter.ReviewerType)
+		}
+		if filter.CreatedFrom != nil {
+			query = query.Where("company_reviews.created_at >= ?", *filter.CreatedFrom)
+		}
+		if filter.CreatedTo != nil {
+			query = query.Where("company_reviews.created_at < ?", *filter.CreatedTo)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.
+		Select("company_reviews.*, companies.company_name AS company_name, COALESCE(users.full_name, '') AS reviewer_name").
+		Joins("LEFT JOIN companies ON companies.id = company_reviews.company_id").
+		Joins("LEFT JOIN users ON users.id = company_reviews.user_id").
+		Order("company_reviews.created_at ASC, company_reviews.id ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&reviews).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return reviews, total, nil
+}
+
+// UpsertReviewVote records or changes a user's vote on a review and refreshes the review's vote counts
+func (r *companyRepository) UpsertReviewVote(ctx context.Context, vote *company.ReviewVote) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "review_id"}, {Name: "user_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"vote": vote.Vote, "updated_at": time.Now()}),
+		}).Create(vote).Error
+		if err != nil {
+			return err
+		}
+		return refreshReviewVoteCounts(tx, vote.ReviewID)
+	})
+}
+
+// DeleteReviewVote removes a user's vote on a review and refreshes the review's vote counts
+func (r *companyRepository) DeleteReviewVote(ctx context.Context, reviewID, userID int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("review_id = ? AND user_id = ?", reviewID, userID).
+			Delete(&company.ReviewVote{}).Error
+		if err != nil {
+			return err
+		}
+		return refreshReviewVoteCounts(tx, reviewID)
+	})
+}
+
+// refreshReviewVoteCounts recounts the denormalized vote counts from review_votes
+func refreshReviewVoteCounts(tx *gorm.DB, reviewID int64) error {
+	return tx.Exec(`
+		UPDATE company_reviews SET
+			helpful_count = (SELECT COUNT(*) FROM review_votes WHERE review_id = ? AND vote = ?),
+			not_helpful_count = (SELECT COUNT(*) FROM review_votes WHERE review_id = ? AND vote = ?)
+		WHERE id = ?`,
+		reviewID, company.ReviewVoteHelpful,
+		reviewID, company.ReviewVoteNotHelpful,
+		reviewID,
+	).Error
+}
+
+// GetUserReviewVotes returns the user's votes keyed by review ID
+func (r *companyRepository) GetUserReviewVotes(ctx context.Context, userID int64, reviewIDs []int64) (map[int64]string, error) {
+	votes := make(map[int64]string)
+	if len(reviewIDs) == 0 {
+		return votes, nil
 	}
 
+	var rows []company.ReviewVote
 	err := r.db.WithContext(ctx).
-		Model(&company.CompanyReview{}).
-		Where("company_id = ? AND status = ?", companyID, "approved").
-		Select(`
-			COALESCE(AVG(rating_overall), 0) as avg_overall,
-			COALESCE(AVG(rating_culture), 0) as avg_culture,
-			COALESCE(AVG(rating_worklife), 0) as avg_worklife,
-			COALESCE(AVG(rating_salary), 0) as avg_salary,
-			COALESCE(AVG(rating_management), 0) as avg_management,
-			COUNT(*) as total_reviews
-		`).
-		Scan(&result).Error
+		Where("user_id = ? AND review_id IN ?", userID, reviewIDs).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		votes[row.ReviewID] = row.Vote
+	}
+	return votes, nil
+}
+
+// CreateReviewReport files an abuse report against a review
+func (r *companyRepository) CreateReviewReport(ctx context.Context, report *company.ReviewReport) error {
+	err := r.db.WithContext(ctx).Create(report).Error
+	if isUniqueViolation(err, "review_reports_review_id_user_id_key") {
+		return company.ErrReviewAlreadyReported
+	}
+	return err
+}
+
+// CountOpenReviewReports counts unresolved abuse reports on a review
+func (r *companyRepository) CountOpenReviewReports(ctx context.Context, reviewID int64) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&company.ReviewReport{}).
+		Where("review_id = ? AND status = ?", reviewID, "open").
+		Count(&count).Error
+	return count, err
+}
+
+// CreateReviewResponse creates the company's response to a review
+func (r *companyRepository) CreateReviewResponse(ctx context.Context, response *company.ReviewResponse) error {
+	err := r.db.WithContext(ctx).Create(response).Error
+	if isUniqueViolation(err, "review_responses_review_id_key") {
+		return company.ErrReviewResponseExists
+	}
+	return err
+}
+
+// UpdateReviewResponse saves an edited review response
+func (r *companyRepository) UpdateReviewResponse(ctx context.Context, response *company.ReviewResponse) error {
+	return r.db.WithContext(ctx).Save(response).Error
+}
+
+// DeleteReviewResponse deletes a review response
+func (r *companyRepository) DeleteReviewResponse(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Delete(&company.ReviewResponse{}, id).Error
+}
+
+// FindReviewResponseByReviewID finds the response to a review, returning nil when there is none
+func (r *companyRepository) FindReviewResponseByReviewID(ctx context.Context, reviewID int64) (*company.ReviewResponse, error) {
+	var response company.ReviewResponse
+	err := r.db.WithContext(ctx).First(&response, "review_id = ?", reviewID).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &response, nil
+}
+
+// FindSettings finds a company's settings, returning nil when none were saved
+func (r *companyRepository) FindSettings(ctx context.Context, companyID int64) (*company.CompanySettings, error) {
+	var settings company.CompanySettings
+	err := r.db.WithContext(ctx).First(&settings, "company_id = ?", companyID).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpsertSettings creates or replaces a company's settings
+func (r *companyRepository) UpsertSettings(ctx context.Context, settings *company.CompanySettings) error {
+	settings.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "company_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"stage_reminder_enabled", "stage_reminder_days", "blind_screening_enabled", "weekly_digest_enabled", "auto_join_enabled", "auto_join_role", "updated_at"}),
+	}).Create(settings).Error
+}
+
+// ===========================================
+// CHANGE REQUEST OPERATIONS
+// ===========================================
+
+// CreateChangeRequest creates a change request; only one per company may be pending
+func (r *companyRepository) CreateChangeRequest(ctx context.Context, req *company.CompanyChangeRequest) error {
+	err := r.db.WithContext(ctx).Create(req).Error
+	if isUniqueViolation(err, "company_change_requests_one_pending") {
+		return company.ErrChangeRequestPending
+	}
+	return err
+}
+
+// FindChangeRequestByID finds a change request by ID, returning nil when it does not exist
+func (r *companyRepository) FindChangeRequestByID(ctx context.Context, id int64) (*company.CompanyChangeRequest, error) {
+	var req company.CompanyChangeRequest
+	err := r.db.WithContext(ctx).First(&req, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &req, nil
+}
+
+// ListChangeRequestsByCompany lists a company's most recent change requests, newest first
+func (r *companyRepository) ListChangeRequestsByCompany(ctx context.Context, companyID int64, limit int) ([]company.CompanyChangeRequest, error) {
+	var requests []company.CompanyChangeRequest
+	err := r.db.WithContext(ctx).
+		Where("company_id = ?", companyID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&requests).Error
+	return requests, err
+}
+
+// ListChangeRequests lists change requests with the given status, oldest first, with their companies
+func (r *companyRepository) ListChangeRequests(ctx context.Context, status string, page, limit int) ([]company.CompanyChangeRequest, int64, error) {
+	var requests []company.CompanyChangeRequest
+	var total int64
+
+	query := r.db.WithContext(ctx).
+		Model(&company.CompanyChangeRequest{}).
+		Where("status = ?", status).
+		Where(liveCompanyCondition("company_change_requests"))
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.
+		Preload("Company").
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&requests).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return requests, total, nil
+}
+
+// ApproveChangeRequest marks a pending change request approved and applies it to the company
+// in one transaction
+func (r *companyRepository) ApproveChangeRequest(ctx context.Context, id, decidedBy int64, note *string, change company.CompanyMasterDataChange) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		req, err := decideChangeRequest(tx, id, decidedBy, note, company.ChangeRequestApproved)
+		if err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{"updated_at": time.Now()}
+		if change.IndustryID != nil {
+			updates["industry_id"] = *change.IndustryID
+		}
+		if change.CompanySizeID != nil {
+			updates["company_size_id"] = *change.CompanySizeID
+		}
+		if change.DistrictID != nil {
+			updates["district_id"] = *change.DistrictID
+			updates["city_id"] = change.CityID
+			updates["province_id"] = change.ProvinceID
+		}
+
+		return tx.Model(&company.Company{}).Where("id = ?", req.CompanyID).Updates(updates).Error
+	})
+}
 
+// RejectChangeRequest marks a pending change request rejected
+func (r *companyRepository) RejectChangeRequest(ctx context.Context, id, decidedBy int64, note *string) error {
+	_, err := decideChangeRequest(r.db.WithContext(ctx), id, decidedBy, note, company.ChangeRequestRejected)
+	return err
+}
+
+// decideChangeRequest moves a pending change request to status, returning
+// company.ErrChangeRequestDecided when it was decided in the meantime
+func decideChangeRequest(db *gorm.DB, id, decidedBy int64, note *string, status string) (*company.CompanyChangeRequest, error) {
+	var req company.CompanyChangeRequest
+	now := time.Now()
+	result := db.Model(&req).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND status = ?", id, company.ChangeRequestPending).
+		Updates(map[string]interface{}{
+			"status":        status,
+			"decided_by":    decidedBy,
+			"decided_at":    now,
+			"decision_note": note,
+			"updated_at":    now,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, company.ErrChangeRequestDecided
+	}
+	return &req, nil
+}
+
+// CalculateAverageRatings returns a company's average ratings from its rating summary
+func (r *companyRepository) CalculateAverageRatings(ctx context.Context, companyID int64) (*company.AverageRatings, error) {
+	var summary company.CompanyRatingSummary
+	err := r.db.WithContext(ctx).
+		Where("company_id = ?", companyID).
+		Where(liveCompanyCondition("company_rating_summaries")).
+		First(&summary).Error
 	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			// No approved reviews have been summarized yet
+			return &company.AverageRatings{}, nil
+		}
 		return nil, err
 	}
 
 	return &company.AverageRatings{
-		Overall:      result.AvgOverall,
-		Culture:      result.AvgCulture,
-		WorkLife:     result.AvgWorkLife,
-		Salary:       result.AvgSalary,
-		Management:   result.AvgManagement,
-		TotalReviews: result.TotalReviews,
+		Overall:      summary.Overall,
+		Culture:      summary.Culture,
+		WorkLife:     summary.WorkLife,
+		Salary:       summary.Salary,
+		Management:   summary.Management,
+		TotalReviews: summary.TotalReviews,
 	}, nil
 }
 
+// RefreshRatingSummary recomputes a company's rating summary from its approved reviews
+func (r *companyRepository) RefreshRatingSummary(ctx context.Context, companyID int64) error {
+	return refreshRatingSummaries(r.db.WithContext(ctx),
+		"SELECT id AS company_id FROM companies WHERE id = ?", companyID).Error
+}
+
+// RefreshStaleRatingSummaries recomputes the summaries of companies with reviews updated after
+// their summary, companies with reviews but no summary, and summaries whose review count no
+// longer matches (a review was deleted)
+func (r *companyRepository) RefreshStaleRatingSummaries(ctx context.Context) (int64, error) {
+	result := refreshRatingSummaries(r.db.WithContext(ctx), `
+		SELECT DISTINCT company_reviews.company_id
+		FROM company_reviews
+		LEFT JOIN company_rating_summaries ON company_rating_summaries.company_id = company_reviews.company_id
+		WHERE company_rating_summaries.company_id IS NULL
+			OR company_reviews.updated_at > company_rating_summaries.updated_at
+		UNION
+		SELECT company_rating_summaries.company_id
+		FROM company_rating_summaries
+		WHERE company_rating_summaries.total_reviews <> (
+			SELECT COUNT(*) FROM company_reviews
+			WHERE company_reviews.company_id = company_rating_summaries.company_id
+				AND company_reviews.status = 'approved'
+		)`)
+	return result.RowsAffected, result.Error
+}
+
+// refreshRatingSummaries upserts the rating summaries of the company IDs the targets query selects
+func refreshRatingSummaries(db *gorm.DB, targets string, args ...interface{}) *gorm.DB {
+	return db.Exec(`
+		WITH targets AS (`+targets+`)
+		INSERT INTO company_rating_summaries (company_id, overall, culture, work_life, salary, management, total_reviews, updated_at)
+		SELECT targets.company_id,
+			COALESCE(AVG(company_reviews.rating_overall), 0),
+			COALESCE(AVG(company_reviews.rating_culture), 0),
+			COALESCE(AVG(company_reviews.rating_worklife), 0),
+			COALESCE(AVG(company_reviews.rating_salary), 0),
+			COALESCE(AVG(company_reviews.rating_management), 0),
+			COUNT(company_reviews.id),
+			NOW()
+		FROM targets
+		LEFT JOIN company_reviews ON company_reviews.company_id = targets.company_id AND company_reviews.status = 'approved'
+		GROUP BY targets.company_id
+		ON CONFLICT (company_id) DO UPDATE SET
+			overall = EXCLUDED.overall,
+			culture = EXCLUDED.culture,
+			work_life = EXCLUDED.work_life,
+			salary = EXCLUDED.salary,
+			management = EXCLUDED.management,
+			total_reviews = EXCLUDED.total_reviews,
+			updated_at = EXCLUDED.updated_at
+	`, args...)
+}
+
 // ===========================================
 // DOCUMENT OPERATIONS
 // ===========================================
@@ -692,6 +1212,11 @@ func (r *companyRepository) CreateDocument(ctx context.Context, doc *company.Com
 	return r.db.WithContext(ctx).Create(doc).Error
 }
 
+// CreateDocumentTx creates a company document within a transaction
+func (r *companyRepository) CreateDocumentTx(ctx context.Context, tx *gorm.DB, doc *company.CompanyDocument) error {
+	return tx.WithContext(ctx).Create(doc).Error
+}
+
 // UpdateDocument updates a company document
 func (r *companyRepository) UpdateDocument(ctx context.Context, doc *company.CompanyDocument) error {
 	return r.db.WithContext(ctx).Save(doc).Error
@@ -780,6 +1305,13 @@ func (r *companyRepository) UpdateCompanyAddress(ctx context.Context, address *c
 	} else {
 		updates["district_id"] = nil
 	}
+	if address.GeocodeProvider != nil {
+		updates["geocode_provider"] = address.GeocodeProvider
+		updates["geocode_confidence"] = address.GeocodeConfidence
+	} else {
+		updates["geocode_provider"] = nil
+		updates["geocode_confidence"] = nil
+	}
 
 	return r.db.WithContext(ctx).
 		Model(&company.CompanyAddress{}).
@@ -833,6 +1365,16 @@ func (r *companyRepository) AddEmployee(ctx context.Context, employee *company.C
 	return r.db.WithContext(ctx).Create(employee).Error
 }
 
+// AddEmployees adds employee records in one transaction, so either all of them are saved or none
+func (r *companyRepository) AddEmployees(ctx context.Context, employees []company.CompanyEmployee) error {
+	if len(employees) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(&employees).Error
+	})
+}
+
 // UpdateEmployee updates an employee record
 func (r *companyRepository) UpdateEmployee(ctx context.Context, employee *company.CompanyEmployee) error {
 	return r.db.WithContext(ctx).Save(employee).Error
@@ -883,14 +1425,19 @@ func (r *companyRepository) CreateEmployerUser(ctx context.Context, employerUser
 	return r.db.WithContext(ctx).Create(employerUser).Error
 }
 
-// UpdateEmployerUser updates an employer user
+// UpdateEmployerUser updates an employer user if it still has the version it was loaded
+// with, and increments the version. A membership changed since returns
+// company.ErrEmployerUserVersionConflict.
 func (r *companyRepository) UpdateEmployerUser(ctx context.Context, employerUser *company.EmployerUser) error {
-	return r.db.WithContext(ctx).Save(employerUser).Error
+	return r.UpdateEmployerUserTx(ctx, r.db, employerUser)
 }
 
-// UpdateEmployerUserTx updates an employer_user record using the provided transaction.
+// UpdateEmployerUserTx updates an employer_user record using the provided transaction,
+// with the same version check as UpdateEmployerUser.
 func (r *companyRepository) UpdateEmployerUserTx(ctx context.Context, tx *gorm.DB, employerUser *company.EmployerUser) error {
-	return tx.WithContext(ctx).Save(employerUser).Error
+	return updateVersioned(ctx, tx, employerUser, &employerUser.Version, company.ErrEmployerUserVersionConflict, func(query *gorm.DB) *gorm.DB {
+		return query.Select("*").Updates(employerUser)
+	})
 }
 
 // DeleteEmployerUser soft deletes an employer user
@@ -939,6 +1486,27 @@ func (r *companyRepository) GetEmployerUsersByCompanyID(ctx context.Context, com
 	return employerUsers, err
 }
 
+// GetEmployerUsersByUserID retrieves the user's active memberships of companies that are not
+// deleted, most recently activated first
+func (r *companyRepository) GetEmployerUsersByUserID(ctx context.Context, userID int64) ([]company.EmployerUser, error) {
+	var employerUsers []company.EmployerUser
+	err := r.db.WithContext(ctx).
+		Joins("INNER JOIN companies ON companies.id = employer_users.company_id AND companies.deleted_at IS NULL").
+		Where("employer_users.user_id = ? AND employer_users.is_active = ?", userID, true).
+		Preload("Company").
+		Order("employer_users.activated_at DESC NULLS LAST, employer_users.created_at ASC").
+		Find(&employerUsers).Error
+	return employerUsers, err
+}
+
+// SetEmployerUserActivatedAt records when the user chose the membership's company as active
+func (r *companyRepository) SetEmployerUserActivatedAt(ctx context.Context, employerUserID int64, activatedAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&company.EmployerUser{}).
+		Where("id = ?", employerUserID).
+		Update("activated_at", activatedAt).Error
+}
+
 // GetCompaniesByUserID retrieves all companies managed by a user
 func (r *companyRepository) GetCompaniesByUserID(ctx context.Context, userID int64) ([]company.Company, error) {
 	var companies []company.Company
@@ -991,12 +1559,43 @@ func (r *companyRepository) FindVerificationByCompanyID(ctx context.Context, com
 	return &verification, nil
 }
 
+// CreateVerificationTx creates a verification record within a transaction
+func (r *companyRepository) CreateVerificationTx(ctx context.Context, tx *gorm.DB, verification *company.CompanyVerification) error {
+	return tx.WithContext(ctx).Create(verification).Error
+}
+
+// UpdateVerificationTx updates a verification record within a transaction
+func (r *companyRepository) UpdateVerificationTx(ctx context.Context, tx *gorm.DB, verification *company.CompanyVerification) error {
+	return tx.WithContext(ctx).
+		Model(&company.CompanyVerification{}).
+		Where("id = ?", verification.ID).
+		Select("*").
+		Updates(verification).Error
+}
+
+// FindVerificationByCompanyIDTx finds a company's verification within a transaction and
+// locks the row, so concurrent requests for the same company are applied one at a time
+func (r *companyRepository) FindVerificationByCompanyIDTx(ctx context.Context, tx *gorm.DB, companyID int64) (*company.CompanyVerification, error) {
+	var verification company.CompanyVerification
+	err := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("company_id = ?", companyID).
+		First(&verification).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &verification, nil
+}
+
 // RequestVerification requests company verification
 func (r *companyRepository) RequestVerification(ctx context.Context, companyID, requestedBy int64) error {
 	verification := &company.CompanyVerification{
 		CompanyID:   companyID,
 		RequestedBy: &requestedBy,
-		Status:      "pending",
+		Status:      company.VerificationStatusPending,
 	}
 	return r.db.WithContext(ctx).Create(verification).Error
 }
@@ -1018,7 +1617,7 @@ func (r *companyRepository) ApproveVerification(ctx context.Context, companyID,
 			CompanyID:          companyID,
 			ReviewedBy:         &reviewedBy,
 			ReviewedAt:         &now,
-			Status:             "verified",
+			Status:             company.VerificationStatusVerified,
 			NPWPNumber:         "", // Will be empty if not provided during request
 			VerificationScore:  100.0,
 			VerificationNotes:  &notes,
@@ -1035,12 +1634,14 @@ func (r *companyRepository) ApproveVerification(ctx context.Context, companyID,
 		if err := r.db.WithContext(ctx).
 			Model(&verification).
 			Updates(map[string]interface{}{
-				"status":              "verified",
+				"status":              company.VerificationStatusVerified,
 				"reviewed_by":         reviewedBy,
 				"reviewed_at":         now,
 				"verification_score":  100.0,
 				"verification_notes":  notes,
 				"verification_expiry": expiry,
+				"grace_until":         nil,
+				"expiry_warning_days": nil,
 				"badge_granted":       true,
 				"updated_at":          now,
 			}).Error; err != nil {
@@ -1066,7 +1667,7 @@ func (r *companyRepository) RejectVerification(ctx context.Context, companyID, r
 		Model(&company.CompanyVerification{}).
 		Where("company_id = ?", companyID).
 		Updates(map[string]interface{}{
-			"status":           "rejected",
+			"status":           company.VerificationStatusRejected,
 			"reviewed_by":      reviewedBy,
 			"reviewed_at":      now,
 			"rejection_reason": reason,
@@ -1080,7 +1681,8 @@ func (r *companyRepository) GetPendingVerifications(ctx context.Context, page, l
 
 	query := r.db.WithContext(ctx).
 		Model(&company.CompanyVerification{}).
-		Where("status IN ?", []string{"pending", "under_review"})
+		Where("status IN ?", []string{company.VerificationStatusPending, company.VerificationStatusUnderReview}).
+		Where(liveCompanyCondition("company_verifications"))
 
 	// Count total
 	if err := query.Count(&total).Error; err != nil {
@@ -1106,6 +1708,51 @@ func (r *companyRepository) GetPendingVerifications(ctx context.Context, page, l
 	return verifications, total, err
 }
 
+// FindVerificationsExpiringWithin returns verified verifications of active companies that
+// expire within days and have not been warned at this threshold or a smaller one yet
+func (r *companyRepository) FindVerificationsExpiringWithin(ctx context.Context, days int) ([]company.CompanyVerification, error) {
+	var verifications []company.CompanyVerification
+
+	err := r.db.WithContext(ctx).
+		Joins("INNER JOIN companies ON companies.id = company_verifications.company_id").
+		Where("company_verifications.status = ?", company.VerificationStatusVerified).
+		Where("company_verifications.verification_expiry >= CURRENT_DATE").
+		Where("company_verifications.verification_expiry <= CURRENT_DATE + ?::int", days).
+		Where("company_verifications.expiry_warning_days IS NULL OR company_verifications.expiry_warning_days > ?", days).
+		Where("companies.is_active = ? AND companies.deleted_at IS NULL", true).
+		Preload("Company").
+		Find(&verifications).Error
+
+	return verifications, err
+}
+
+// MarkVerificationExpiryWarned records that the verification was warned at the days threshold.
+// The conditional update makes concurrent runs send each warning only once.
+func (r *companyRepository) MarkVerificationExpiryWarned(ctx context.Context, verificationID int64, days int) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&company.CompanyVerification{}).
+		Where("id = ? AND (expiry_warning_days IS NULL OR expiry_warning_days > ?)", verificationID, days).
+		UpdateColumn("expiry_warning_days", days)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// FindLapsedVerifications returns verifications still marked verified whose expiry date has passed
+func (r *companyRepository) FindLapsedVerifications(ctx context.Context) ([]company.CompanyVerification, error) {
+	var verifications []company.CompanyVerification
+
+	err := r.db.WithContext(ctx).
+		Where("status = ?", company.VerificationStatusVerified).
+		Where("verification_expiry < CURRENT_DATE").
+		Where(liveCompanyCondition("company_verifications")).
+		Preload("Company").
+		Find(&verifications).Error
+
+	return verifications, err
+}
+
 // ===========================================
 // INDUSTRY OPERATIONS
 // ===========================================
@@ -1215,18 +1862,17 @@ func (r *companyRepository) GetVerifiedCompanies(ctx context.Context, page, limi
 	return r.List(ctx, filter)
 }
 
-// GetTopRatedCompanies retrieves top rated companies
+// GetTopRatedCompanies retrieves the companies with the highest overall rating among those
+// with at least 5 approved reviews
 func (r *companyRepository) GetTopRatedCompanies(ctx context.Context, limit int) ([]company.Company, error) {
 	var companies []company.Company
 
 	err := r.db.WithContext(ctx).
 		Model(&company.Company{}).
-		Select("companies.*, COALESCE(AVG(company_reviews.rating_overall), 0) as avg_rating").
-		Joins("LEFT JOIN company_reviews ON company_reviews.company_id = companies.id AND company_reviews.status = ?", "approved").
+		Joins("JOIN company_rating_summaries ON company_rating_summaries.company_id = companies.id").
 		Where("companies.is_active = ?", true).
-		Group("companies.id").
-		Having("COUNT(company_reviews.id) >= ?", 5).
-		Order("avg_rating DESC").
+		Where("company_rating_summaries.total_reviews >= ?", 5).
+		Order("company_rating_summaries.overall DESC, company_rating_summaries.total_reviews DESC, companies.id").
 		Limit(limit).
 		Preload("Profile").
 		Preload("Verification").
@@ -1243,7 +1889,7 @@ func (r *companyRepository) GetCompaniesNeedingVerificationRenewal(ctx context.C
 
 	err := r.db.WithContext(ctx).
 		Joins("INNER JOIN company_verifications ON company_verifications.company_id = companies.id").
-		Where("company_verifications.status = ?", "verified").
+		Where("company_verifications.status = ?", company.VerificationStatusVerified).
 		Where("company_verifications.verification_expiry <= ?", thirtyDaysFromNow).
 		Where("companies.is_active = ?", true).
 		Preload("Profile").
@@ -1263,7 +1909,7 @@ func (r *companyRepository) GetFullCompanyProfile(ctx context.Context, companyID
 			return db.Where("is_active = ?", true).Order("followed_at DESC").Limit(100)
 		}).
 		Preload("Reviews", func(db *gorm.DB) *gorm.DB {
-			return db.Where("status = ?", "approved").Order("created_at DESC").Limit(50)
+			return db.Where("status = ?", company.ReviewStatusApproved).Order("created_at DESC").Limit(50)
 		}).
 		Preload("Documents", func(db *gorm.DB) *gorm.DB {
 			return db.Where("is_active = ?", true).Order("document_type ASC")
@@ -1303,7 +1949,7 @@ func (r *companyRepository) FindInvitationByToken(ctx context.Context, token str
 		First(&invitation).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("invitation not found")
+			return nil, company.ErrInvitationNotFound
 		}
 		return nil, err
 	}
@@ -1330,11 +1976,65 @@ func (r *companyRepository) UpdateInvitation(ctx context.Context, invitation *co
 	return r.db.WithContext(ctx).Save(invitation).Error
 }
 
+// AcceptInvitation creates the employer user and marks the invitation accepted in one
+// transaction. Only a pending invitation is updated, so concurrent accepts of the same
+// token create a single employer user.
+func (r *companyRepository) AcceptInvitation(ctx context.Context, invitation *company.CompanyInvitation, employerUser *company.EmployerUser) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return acceptInvitation(tx, invitation, employerUser)
+	})
+	return acceptInvitationError(err)
+}
+
+// AcceptInvitationForNewUser creates the user, then its employer user, and marks the
+// invitation accepted by it in one transaction, so a signup whose accept fails leaves no
+// user behind
+func (r *companyRepository) AcceptInvitationForNewUser(ctx context.Context, invitation *company.CompanyInvitation, newUser *user.User, employerUser *company.EmployerUser) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(newUser).Error; err != nil {
+			return err
+		}
+		employerUser.UserID = newUser.ID
+		invitation.AcceptedBy = &newUser.ID
+		return acceptInvitation(tx, invitation, employerUser)
+	})
+	return acceptInvitationError(err)
+}
+
+// acceptInvitation marks the invitation accepted if it is still pending and creates the
+// employer user within tx
+func acceptInvitation(tx *gorm.DB, invitation *company.CompanyInvitation, employerUser *company.EmployerUser) error {
+	result := tx.Model(&company.CompanyInvitation{}).
+		Where("id = ? AND status = ?", invitation.ID, company.InvitationStatusPending).
+		Updates(map[string]interface{}{
+			"status":      company.InvitationStatusAccepted,
+			"accepted_by": invitation.AcceptedBy,
+			"accepted_at": invitation.AcceptedAt,
+			"updated_at":  invitation.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return company.ErrInvitationUnavailable
+	}
+
+	return tx.Create(employerUser).Error
+}
+
+// acceptInvitationError maps a duplicate membership to ErrAlreadyCompanyEmployer
+func acceptInvitationError(err error) error {
+	if isUniqueViolation(err, "employer_users_user_id_company_id_key") {
+		return company.ErrAlreadyCompanyEmployer
+	}
+	return err
+}
+
 // GetPendingInvitationsByCompany retrieves pending invitations for a company
 func (r *companyRepository) GetPendingInvitationsByCompany(ctx context.Context, companyID int64) ([]company.CompanyInvitation, error) {
 	var invitations []company.CompanyInvitation
 	err := r.db.WithContext(ctx).
-		Where("company_id = ? AND status = ?", companyID, "pending").
+		Where("company_id = ? AND status = ?", companyID, company.InvitationStatusPending).
 		Where("expires_at > ?", time.Now()).
 		Order("created_at DESC").
 		Find(&invitations).Error
@@ -1346,7 +2046,7 @@ func (r *companyRepository) GetPendingInvitationsByEmail(ctx context.Context, em
 	var invitations []company.CompanyInvitation
 	err := r.db.WithContext(ctx).
 		Preload("Company").
-		Where("email = ? AND status = ?", email, "pending").
+		Where("email = ? AND status = ?", email, company.InvitationStatusPending).
 		Where("expires_at > ?", time.Now()).
 		Order("created_at DESC").
 		Find(&invitations).Error
@@ -1357,8 +2057,8 @@ func (r *companyRepository) GetPendingInvitationsByEmail(ctx context.Context, em
 func (r *companyRepository) ExpireOldInvitations(ctx context.Context) error {
 	return r.db.WithContext(ctx).
 		Model(&company.CompanyInvitation{}).
-		Where("status = ? AND expires_at < ?", "pending", time.Now()).
-		Update("status", "expired").Error
+		Where("status = ? AND expires_at < ?", company.InvitationStatusPending, time.Now()).
+		Update("status", company.InvitationStatusExpired).Error
 }
 
 // DeleteInvitation deletes an invitation
@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"keerja-backend/internal/utils"
+)
+
+// applyCursor orders query by created_at DESC, id DESC, skips rows up to and including
+// the cursor position and fetches one row more than limit so the caller can tell
+// whether another page follows
+func applyCursor(query *gorm.DB, table, cursor string, limit int) (*gorm.DB, error) {
+	pos, err := utils.DecodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if pos != nil {
+		query = query.Where("("+table+".created_at, "+table+".id) < (?, ?)", pos.CreatedAt, pos.ID)
+	}
+	return query.
+		Order(table + ".created_at DESC").
+		Order(table + ".id DESC").
+		Limit(limit + 1), nil
+}
+
+// cursorPage trims the extra row fetched by applyCursor and returns the cursor of
+// the last row kept, or an empty string on the last page
+func cursorPage[T any](rows []T, limit int, position func(*T) (time.Time, int64)) ([]T, string) {
+	if len(rows) <= limit {
+		return rows, ""
+	}
+
+	rows = rows[:limit]
+	createdAt, id := position(&rows[limit-1])
+	return rows, utils.EncodeCursor(createdAt, id)
+}
@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"keerja-backend/internal/domain/notification"
+
+	"gorm.io/gorm"
+)
+
+// deferredPushRepository implements the notification.DeferredPushRepository interface
+type deferredPushRepository struct {
+	db *gorm.DB
+}
+
+// NewDeferredPushRepository creates a new deferred push repository instance
+func NewDeferredPushRepository(db *gorm.DB) notification.DeferredPushRepository {
+	return &deferredPushRepository{db: db}
+}
+
+// Create stores a deferred push
+func (r *deferredPushRepository) Create(ctx context.Context, push *notification.DeferredPush) error {
+	return r.db.WithContext(ctx).Create(push).Error
+}
+
+// ClaimDue deletes due pushes in one statement and returns them, so overlapping scheduler
+// runs never send the same push twice
+func (r *deferredPushRepository) ClaimDue(ctx context.Context, now time.Time, limit int) ([]notification.DeferredPush, error) {
+	var pushes []notification.DeferredPush
+	err := r.db.WithContext(ctx).Raw(`
+		DELETE FROM deferred_pushes
+		WHERE id IN (
+			SELECT id FROM deferred_pushes
+			WHERE deliver_at <= ?
+			ORDER BY deliver_at ASC, id ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		now, limit,
+	).Scan(&pushes).Error
+	if err != nil {
+		return nil, err
+	}
+	return pushes, nil
+}
@@ -8,6 +8,7 @@ import (
 	"keerja-backend/internal/domain/notification"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // DeviceTokenRepository implements notification.DeviceTokenRepository
@@ -28,6 +29,36 @@ func (r *DeviceTokenRepository) Create(ctx context.Context, token *notification.
 	return nil
 }
 
+// Upsert stores a device token, reassigning an already registered token string to the
+// token's user instead of creating a second row for it. The token comes back active,
+// with its failure history cleared and last_used_at set to now.
+func (r *DeviceTokenRepository) Upsert(ctx context.Context, token *notification.DeviceToken) error {
+	now := time.Now()
+	token.IsActive = true
+	token.LastUsedAt = &now
+
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "token"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"user_id":         token.UserID,
+				"platform":        token.Platform,
+				"device_info":     token.DeviceInfo,
+				"is_active":       true,
+				"last_used_at":    now,
+				"failure_count":   0,
+				"last_failure_at": nil,
+				"failure_reason":  "",
+				"updated_at":      now,
+			}),
+		}).
+		Create(token).Error
+	if err != nil {
+		return fmt.Errorf("failed to upsert device token: %w", err)
+	}
+	return nil
+}
+
 // FindByID finds device token by ID
 func (r *DeviceTokenRepository) FindByID(ctx context.Context, id int64) (*notification.DeviceToken, error) {
 	var token notification.DeviceToken
@@ -133,13 +164,14 @@ func (r *DeviceTokenRepository) FindInactiveTokens(ctx context.Context, inactive
 	return tokens, nil
 }
 
-// FindInactive finds tokens inactive since the cutoff date (for cleanup job)
+// FindInactive finds tokens not seen since the cutoff date (for cleanup job).
+// Tokens that were never used count from their registration time.
 func (r *DeviceTokenRepository) FindInactive(ctx context.Context, cutoffDate time.Time) ([]notification.DeviceToken, error) {
 	var tokens []notification.DeviceToken
 
 	if err := r.db.WithContext(ctx).
-		Where("is_active = ? AND (last_used_at IS NULL OR last_used_at < ?)", true, cutoffDate).
-		Order("last_used_at ASC").
+		Where("COALESCE(last_used_at, created_at) < ?", cutoffDate).
+		Order("COALESCE(last_used_at, created_at) ASC").
 		Find(&tokens).Error; err != nil {
 		return nil, fmt.Errorf("failed to find inactive device tokens: %w", err)
 	}
@@ -233,3 +265,99 @@ func (r *DeviceTokenRepository) FindTokensWithHighFailureCount(ctx context.Conte
 	}
 	return tokens, nil
 }
+
+// FindBySegment finds active device tokens whose owners match segment, using keyset pagination on ID
+func (r *DeviceTokenRepository) FindBySegment(ctx context.Context, segment notification.PushSegment, afterID int64, limit int) ([]notification.DeviceToken, error) {
+	var tokens []notification.DeviceToken
+
+	query := r.db.WithContext(ctx).
+		Select("device_tokens.*").
+		Where("device_tokens.is_active = ? AND device_tokens.id > ?", true, afterID)
+
+	if segment.Role != nil {
+		query = query.Joins("JOIN users ON users.id = device_tokens.user_id").
+			Where("users.user_type = ?", *segment.Role)
+	}
+	if segment.ProvinceID != nil {
+		query = query.Joins("JOIN user_profiles ON user_profiles.user_id = device_tokens.user_id").
+			Where("user_profiles.province_id = ?", *segment.ProvinceID)
+	}
+	if segment.FollowedCompanyID != nil {
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM company_followers cf WHERE cf.user_id = device_tokens.user_id AND cf.company_id = ? AND cf.is_active = ?)",
+			*segment.FollowedCompanyID, true,
+		)
+	}
+
+	if err := query.Order("device_tokens.id ASC").Limit(limit).Find(&tokens).Error; err != nil {
+		return nil, fmt.Errorf("failed to find device tokens by segment: %w", err)
+	}
+	return tokens, nil
+}
+
+// DeleteByTokens deletes device tokens by token string
+func (r *DeviceTokenRepository) DeleteByTokens(ctx context.Context, tokens []string) (int64, error) {
+	if len(tokens) == 0 {
+		return 0, nil
+	}
+
+	result := r.db.WithContext(ctx).Where("token IN ?", tokens).Delete(&notification.DeviceToken{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete device tokens: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// MarkDelivered records a successful delivery to each token: last_used_at moves to at
+// and the failure count resets
+func (r *DeviceTokenRepository) MarkDelivered(ctx context.Context, tokens []string, at time.Time) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Model(&notification.DeviceToken{}).
+		Where("token IN ?", tokens).
+		Updates(map[string]interface{}{
+			"last_used_at":  at,
+			"failure_count": 0,
+			"updated_at":    at,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to mark device tokens delivered: %w", err)
+	}
+	return nil
+}
+
+// FindTopics finds the topics a device token is subscribed to
+func (r *DeviceTokenRepository) FindTopics(ctx context.Context, deviceTokenID int64) ([]string, error) {
+	var topics []string
+	if err := r.db.WithContext(ctx).
+		Model(&notification.DeviceTokenTopic{}).
+		Where("device_token_id = ?", deviceTokenID).
+		Order("topic").
+		Pluck("topic", &topics).Error; err != nil {
+		return nil, fmt.Errorf("failed to find device token topics: %w", err)
+	}
+	return topics, nil
+}
+
+// AddTopic records a topic subscription; recording an existing subscription is a no-op
+func (r *DeviceTokenRepository) AddTopic(ctx context.Context, deviceTokenID int64, topic string) error {
+	subscription := &notification.DeviceTokenTopic{DeviceTokenID: deviceTokenID, Topic: topic}
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(subscription).Error; err != nil {
+		return fmt.Errorf("failed to add device token topic: %w", err)
+	}
+	return nil
+}
+
+// RemoveTopic removes a topic subscription
+func (r *DeviceTokenRepository) RemoveTopic(ctx context.Context, deviceTokenID int64, topic string) error {
+	if err := r.db.WithContext(ctx).
+		Where("device_token_id = ? AND topic = ?", deviceTokenID, topic).
+		Delete(&notification.DeviceTokenTopic{}).Error; err != nil {
+		return fmt.Errorf("failed to remove device token topic: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"keerja-backend/internal/domain/email"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// uqEmailQueuePendingDedupe is the partial unique index on dedupe_key of pending emails
+const uqEmailQueuePendingDedupe = "uq_email_queue_pending_dedupe"
+
+// emailQueueRepository implements the email.EmailQueueRepository interface
+type emailQueueRepository struct {
+	db *gorm.DB
+}
+
+// NewEmailQueueRepository creates a new email queue repository instance
+func NewEmailQueueRepository(db *gorm.DB) email.EmailQueueRepository {
+	return &emailQueueRepository{db: db}
+}
+
+// Enqueue inserts a pending email unless an identical one is already pending
+func (r *emailQueueRepository) Enqueue(ctx context.Context, queued *email.QueuedEmail) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "dedupe_key"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Eq{Column: "status", Value: email.QueueStatusPending}}},
+			DoNothing:   true,
+		}).
+		Create(queued)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ClaimDue counts an attempt on due pending emails and leases them in one statement
+func (r *emailQueueRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]email.QueuedEmail, error) {
+	var emails []email.QueuedEmail
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE email_queue
+		SET attempts = attempts + 1, next_attempt_at = ?, updated_at = ?
+		WHERE id IN (
+			SELECT id FROM email_queue
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at ASC, id ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		leaseUntil, now,
+		email.QueueStatusPending, now, limit,
+	).Scan(&emails).Error
+	if err != nil {
+		return nil, err
+	}
+	return emails, nil
+}
+
+// MarkSent marks an email sent
+func (r *emailQueueRepository) MarkSent(ctx context.Context, id int64, sentAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&email.QueuedEmail{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     email.QueueStatusSent,
+			"sent_at":    sentAt,
+			"last_error": nil,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+// MarkRetry records a failed attempt and when to try again
+func (r *emailQueueRepository) MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error {
+	return r.db.WithContext(ctx).
+		Model(&email.QueuedEmail{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"next_attempt_at": nextAttemptAt,
+			"last_error":      lastError,
+			"updated_at":      time.Now(),
+		}).Error
+}
+
+// MarkDead records a failed attempt after which the email is no longer retried
+func (r *emailQueueRepository) MarkDead(ctx context.Context, id int64, lastError string) error {
+	return r.db.WithContext(ctx).
+		Model(&email.QueuedEmail{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     email.QueueStatusDead,
+			"last_error": lastError,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+// FindByID finds a queued email by ID, returning nil when it does not exist
+func (r *emailQueueRepository) FindByID(ctx context.Context, id int64) (*email.QueuedEmail, error) {
+	var queued email.QueuedEmail
+	err := r.db.WithContext(ctx).First(&queued, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &queued, nil
+}
+
+// ListByStatus lists queued emails with status, newest first
+func (r *emailQueueRepository) ListByStatus(ctx context.Context, status string, page, limit int) ([]email.QueuedEmail, int64, error) {
+	var emails []email.QueuedEmail
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&email.QueuedEmail{}).Where("status = ?", status)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.
+		Order("updated_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&emails).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return emails, total, nil
+}
+
+// Requeue resets a dead email to pending with no attempts, due at now. When an
+// identical email has been queued since, that one is sent instead and the dead
+// email is left as is.
+func (r *emailQueueRepository) Requeue(ctx context.Context, id int64, now time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&email.QueuedEmail{}).
+		Where("id = ? AND status = ?", id, email.QueueStatusDead).
+		Updates(map[string]interface{}{
+			"status":          email.QueueStatusPending,
+			"attempts":        0,
+			"next_attempt_at": now,
+			"updated_at":      now,
+		}).Error
+	if isUniqueViolation(err, uqEmailQueuePendingDedupe) {
+		return nil
+	}
+	return err
+}
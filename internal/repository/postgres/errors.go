@@ -0,0 +1,20 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// pgUniqueViolation is the PostgreSQL SQLSTATE for unique_violation
+const pgUniqueViolation = "23505"
+
+// isUniqueViolation reports whether err is a unique constraint violation.
+// When constraint is non-empty, the violated constraint must match it.
+func isUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != pgUniqueViolation {
+		return false
+	}
+	return constraint == "" || pgErr.ConstraintName == constraint
+}
@@ -2,12 +2,14 @@ package postgres
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
 	"keerja-backend/internal/domain/job"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // jobRepository implements job.JobRepository
@@ -29,6 +31,51 @@ func (r *jobRepository) Create(ctx context.Context, j *job.Job) error {
 	return r.db.WithContext(ctx).Create(j).Error
 }
 
+// CreateWithRelations creates a job together with its locations, benefits, skills and
+// requirements in a single transaction, so a failure leaves no partially copied job behind
+func (r *jobRepository) CreateWithRelations(ctx context.Context, j *job.Job) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(j).Error; err != nil {
+			return err
+		}
+
+		for i := range j.Locations {
+			j.Locations[i].JobID = j.ID
+		}
+		for i := range j.Benefits {
+			j.Benefits[i].JobID = j.ID
+		}
+		for i := range j.Skills {
+			j.Skills[i].JobID = j.ID
+		}
+		for i := range j.JobRequirements {
+			j.JobRequirements[i].JobID = j.ID
+		}
+
+		if len(j.Locations) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&j.Locations).Error; err != nil {
+				return err
+			}
+		}
+		if len(j.Benefits) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&j.Benefits).Error; err != nil {
+				return err
+			}
+		}
+		if len(j.Skills) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&j.Skills).Error; err != nil {
+				return err
+			}
+		}
+		if len(j.JobRequirements) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&j.JobRequirements).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // FindByID finds a job by ID
 func (r *jobRepository) FindByID(ctx context.Context, id int64) (*job.Job, error) {
 	var j job.Job
@@ -58,6 +105,16 @@ func (r *jobRepository) FindByID(ctx context.Context, id int64) (*job.Job, error
 	return &j, nil
 }
 
+// FindByIDs finds jobs by IDs without preloading relations
+func (r *jobRepository) FindByIDs(ctx context.Context, ids []int64) ([]job.Job, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var jobs []job.Job
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&jobs).Error
+	return jobs, err
+}
+
 // FindByUUID finds a job by UUID
 func (r *jobRepository) FindByUUID(ctx context.Context, uuid string) (*job.Job, error) {
 	var j job.Job
@@ -100,6 +157,7 @@ func (r *jobRepository) FindBySlug(ctx context.Context, slug string) (*job.Job,
 		Preload("Benefits").
 		Preload("Skills.Skill").
 		Preload("JobRequirements").
+		Preload("JobType").
 		Where("slug = ?", slug).
 		First(&j).Error
 	if err != nil {
@@ -111,37 +169,56 @@ func (r *jobRepository) FindBySlug(ctx context.Context, slug string) (*job.Job,
 	return &j, nil
 }
 
-// Update updates a job
+// SlugExists reports whether another job, including soft-deleted ones, uses the slug
+func (r *jobRepository) SlugExists(ctx context.Context, slug string, excludeID int64) (bool, error) {
+	var count int64
+	query := r.db.WithContext(ctx).Unscoped().
+		Model(&job.Job{}).
+		Where("slug = ?", slug)
+	if excludeID > 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// Update updates a job if it still has the version it was loaded with, and increments the
+// version. A job changed since returns job.ErrJobVersionConflict.
 func (r *jobRepository) Update(ctx context.Context, j *job.Job) error {
-	// Use Updates with Select to avoid GORM overwriting our pointer values
-	// This ensures we only update the specific fields we want (all fields except CompanyID)
-	return r.db.WithContext(ctx).Model(j).Select(
-		// Basic fields
-		"Title", "Slug", "Description",
-		"JobLevel", "EmploymentType",
-		"Requirements", "Responsibilities",
+	return updateVersioned(ctx, r.db, j, &j.Version, job.ErrJobVersionConflict, func(query *gorm.DB) *gorm.DB {
+		// Use Updates with Select to avoid GORM overwriting our pointer values
+		// This ensures we only update the specific fields we want (all fields except CompanyID)
+		return query.Select(
+			// Basic fields
+			"Title", "Slug", "Description",
+			"JobLevel", "EmploymentType",
+			"Requirements", "Responsibilities",
+
+			// Master Data IDs
+			"JobTitleID", "JobTypeID", "WorkPolicyID",
+			"EducationLevelID", "ExperienceLevelID", "GenderPreferenceID",
+			"CategoryID",
 
-		// Master Data IDs
-		"JobTitleID", "JobTypeID", "WorkPolicyID",
-		"EducationLevelID", "ExperienceLevelID", "GenderPreferenceID",
-		"CategoryID",
+			// Location fields
+			"Location", "City", "Province", "RemoteOption",
 
-		// Location fields
-		"Location", "City", "Province", "RemoteOption",
+			// Salary fields
+			"SalaryMin", "SalaryMax", "SalaryDisplay", "MinAge", "MaxAge", "Currency",
 
-		// Salary fields
-		"SalaryMin", "SalaryMax", "SalaryDisplay", "MinAge", "MaxAge", "Currency",
+			// Experience and Education (legacy fields)
+			"ExperienceMin", "ExperienceMax", "EducationLevel",
 
-		// Experience and Education (legacy fields)
-		"ExperienceMin", "ExperienceMax", "EducationLevel",
+			// Job metadata
+			"TotalHires", "Status", "AutoExtend",
+			"ViewsCount", "ApplicationsCount",
 
-		// Job metadata
-		"TotalHires", "Status",
-		"ViewsCount", "ApplicationsCount",
+			// Dates
+			"PublishedAt", "ExpiredAt", "ExpiryReminderDays", "UpdatedAt",
 
-		// Dates
-		"PublishedAt", "ExpiredAt", "UpdatedAt",
-	).Updates(j).Error
+			// Optimistic locking
+			"Version",
+		).Updates(j)
+	})
 }
 
 // Delete hard deletes a job
@@ -149,9 +226,74 @@ func (r *jobRepository) Delete(ctx context.Context, id int64) error {
 	return r.db.WithContext(ctx).Unscoped().Delete(&job.Job{}, id).Error
 }
 
-// SoftDelete soft deletes a job
-func (r *jobRepository) SoftDelete(ctx context.Context, id int64) error {
-	return r.db.WithContext(ctx).Delete(&job.Job{}, id).Error
+// SoftDelete soft deletes a job and, in the same transaction, moves its open applications
+// to job_withdrawn with a stage entry. Skills, benefits and locations are kept so a restore
+// brings the job back unchanged.
+func (r *jobRepository) SoftDelete(ctx context.Context, id int64) ([]job.WithdrawnApplication, error) {
+	var withdrawn []job.WithdrawnApplication
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&job.Job{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		// The previous status is kept so a restore can reopen the application where it was
+		if err := tx.Raw(`
+			WITH withdrawn AS (
+				UPDATE job_applications
+				SET status_before_job_withdrawn = status, status = 'job_withdrawn', closed_at = now(), updated_at = now()
+				WHERE job_id = ? AND status IN ('applied', 'screening', 'shortlisted', 'interview', 'offered')
+				RETURNING id, user_id, status_before_job_withdrawn
+			), stages AS (
+				INSERT INTO job_application_stages (application_id, stage_name, status, description, started_at, completed_at)
+				SELECT id, 'job_withdrawn', 'job_withdrawn', 'Job removed by employer', now(), now()
+				FROM withdrawn
+			)
+			SELECT id AS application_id, user_id, status_before_job_withdrawn AS previous_status FROM withdrawn`,
+			id,
+		).Scan(&withdrawn).Error; err != nil {
+			return fmt.Errorf("failed to withdraw applications: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return withdrawn, nil
+}
+
+// FindDeletedByID finds a soft-deleted job by ID
+func (r *jobRepository) FindDeletedByID(ctx context.Context, id int64) (*job.Job, error) {
+	var j job.Job
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL").
+		First(&j, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &j, nil
+}
+
+// Restore clears a job's soft delete and returns the applications the deletion withdrew to
+// the status they had
+func (r *jobRepository) Restore(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&job.Job{}).Where("id = ?", id).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`
+			UPDATE job_applications
+			SET status = status_before_job_withdrawn, status_before_job_withdrawn = NULL, closed_at = NULL, updated_at = now()
+			WHERE job_id = ? AND status = 'job_withdrawn' AND status_before_job_withdrawn IS NOT NULL`,
+			id,
+		).Error
+	})
 }
 
 // ===========================================
@@ -205,6 +347,46 @@ func (r *jobRepository) List(ctx context.Context, filter job.JobFilter, page, li
 	return jobs, total, err
 }
 
+// ListByCursor retrieves jobs newest first, starting after the given cursor
+func (r *jobRepository) ListByCursor(ctx context.Context, filter job.JobFilter, cursor string, limit int) ([]job.Job, string, error) {
+	var jobs []job.Job
+
+	if limit < 1 {
+		limit = 10
+	}
+
+	query := r.db.WithContext(ctx).Model(&job.Job{})
+	query = r.applyJobFilter(query, filter)
+	query, err := applyCursor(query, "jobs", cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = query.
+		Preload("Category").
+		Preload("CompanyAddress.Province").
+		Preload("CompanyAddress.City").
+		Preload("JobSubcategory").
+		Preload("JobTitle").
+		Preload("JobType").
+		Preload("WorkPolicy").
+		Preload("EducationLevelM").
+		Preload("ExperienceLevelM").
+		Preload("GenderPreference").
+		Preload("Locations").
+		Preload("Benefits").
+		Preload("Skills.Skill").
+		Find(&jobs).Error
+	if err != nil {
+		return nil, "", err
+	}
+
+	jobs, next := cursorPage(jobs, limit, func(j *job.Job) (time.Time, int64) {
+		return j.CreatedAt, j.ID
+	})
+	return jobs, next, nil
+}
+
 // ListByCompany retrieves jobs by company ID
 func (r *jobRepository) ListByCompany(ctx context.Context, companyID int64, filter job.JobFilter, page, limit int) ([]job.Job, int64, error) {
 	filter.CompanyID = companyID
@@ -258,17 +440,119 @@ func (r *jobRepository) ListByEmployer(ctx context.Context, employerUserID int64
 	return jobs, total, err
 }
 
-// SearchJobs performs advanced job search
+// jobSearchConfig is the text search configuration used to build jobs.search_vector
+const jobSearchConfig = "english"
+
+// searchFacetLimit caps the number of values returned for open-ended facets like city
+const searchFacetLimit = 20
+
+// SearchJobs performs advanced job search. With a keyword, results are matched against
+// the full-text search vector and ordered by relevance; otherwise they are ordered by
+// publish date.
 func (r *jobRepository) SearchJobs(ctx context.Context, filter job.JobSearchFilter, page, limit int) ([]job.Job, int64, error) {
 	var jobs []job.Job
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&job.Job{})
+	query := r.applySearchFilter(r.db.WithContext(ctx).Model(&job.Job{}), filter)
 
-	// Keyword search on title and description
-	if filter.Keyword != "" {
-		kw := "%" + strings.ToLower(filter.Keyword) + "%"
-		query = query.Where("LOWER(title) LIKE ? OR LOWER(description) LIKE ?", kw, kw)
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Pagination defaults
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	offset := (page - 1) * limit
+
+	// Report distances and list nearest jobs first for location searches
+	if filter.Latitude != nil && filter.Longitude != nil {
+		query = selectDistance(query, *filter.Latitude, *filter.Longitude)
+	}
+
+	// Rank keyword matches by relevance before recency
+	if keyword := strings.TrimSpace(filter.Keyword); keyword != "" {
+		query = query.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "ts_rank(jobs.search_vector, websearch_to_tsquery(?::regconfig, ?)) DESC",
+			Vars:               []interface{}{jobSearchConfig, keyword},
+			WithoutParentheses: true,
+		}})
+	}
+
+	// Execute final query
+	err := query.
+		Preload("Category").
+		Preload("CompanyAddress.Province").
+		Preload("CompanyAddress.City").
+		Preload("JobSubcategory").
+		Preload("JobTitle").
+		Preload("JobType").
+		Preload("WorkPolicy").
+		Preload("EducationLevelM").
+		Preload("ExperienceLevelM").
+		Preload("GenderPreference").
+		Preload("Locations").
+		Preload("Benefits").
+		Preload("Skills.Skill").
+		Order("published_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&jobs).Error
+
+	return jobs, total, err
+}
+
+// GetSearchFacets counts jobs matching the search filter by job type, work policy and city
+func (r *jobRepository) GetSearchFacets(ctx context.Context, filter job.JobSearchFilter) (*job.SearchFacets, error) {
+	matching := r.applySearchFilter(r.db.WithContext(ctx).Model(&job.Job{}), filter).Select("jobs.id")
+	facets := &job.SearchFacets{}
+
+	err := r.db.WithContext(ctx).Table("jobs").
+		Select("job_types.id AS id, job_types.name AS value, COUNT(*) AS count").
+		Joins("JOIN job_types ON job_types.id = jobs.job_type_id").
+		Where("jobs.id IN (?)", matching).
+		Group("job_types.id, job_types.name").
+		Order("count DESC, value ASC").
+		Scan(&facets.JobTypes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.WithContext(ctx).Table("jobs").
+		Select("work_policies.id AS id, work_policies.name AS value, COUNT(*) AS count").
+		Joins("JOIN work_policies ON work_policies.id = jobs.work_policy_id").
+		Where("jobs.id IN (?)", matching).
+		Group("work_policies.id, work_policies.name").
+		Order("count DESC, value ASC").
+		Scan(&facets.WorkPolicies).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.WithContext(ctx).Table("jobs").
+		Select("jobs.city AS value, COUNT(*) AS count").
+		Where("jobs.id IN (?)", matching).
+		Where("COALESCE(jobs.city, '') <> ''").
+		Group("jobs.city").
+		Order("count DESC, value ASC").
+		Limit(searchFacetLimit).
+		Scan(&facets.Cities).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return facets, nil
+}
+
+// applySearchFilter applies the JobSearchFilter criteria shared by SearchJobs and GetSearchFacets
+func (r *jobRepository) applySearchFilter(query *gorm.DB, filter job.JobSearchFilter) *gorm.DB {
+	// Full-text search on title, skill names and description
+	if keyword := strings.TrimSpace(filter.Keyword); keyword != "" {
+		query = query.Where("jobs.search_vector @@ websearch_to_tsquery(?::regconfig, ?)", jobSearchConfig, keyword)
 	}
 
 	// Location (match city, province or location text)
@@ -340,7 +624,7 @@ func (r *jobRepository) SearchJobs(ctx context.Context, filter job.JobSearchFilt
 
 	// Company IDs filter
 	if len(filter.CompanyIDs) > 0 {
-		query = query.Where("company_id IN ?", filter.CompanyIDs)
+		query = query.Where("jobs.company_id IN ?", filter.CompanyIDs)
 	}
 
 	// Posted within filter (days)
@@ -349,72 +633,36 @@ func (r *jobRepository) SearchJobs(ctx context.Context, filter job.JobSearchFilt
 		query = query.Where("published_at >= ?", daysAgo)
 	}
 
-	// Skills filter (ensure jobs contain all requested skills)
+	// Skills filter (ensure jobs contain all requested skills). A subquery rather than a
+	// grouped join keeps the outer query free to select per-row columns like distance_km.
 	if len(filter.SkillIDs) > 0 {
-		query = query.Joins("INNER JOIN job_skills ON job_skills.job_id = jobs.id").
-			Where("job_skills.skill_id IN ?", filter.SkillIDs).
-			Group("jobs.id").
-			Having("COUNT(DISTINCT job_skills.skill_id) = ?", len(filter.SkillIDs))
+		withAllSkills := r.db.Table("job_skills").
+			Select("job_id").
+			Where("skill_id IN ?", filter.SkillIDs).
+			Group("job_id").
+			Having("COUNT(DISTINCT skill_id) = ?", len(filter.SkillIDs))
+		query = query.Where("jobs.id IN (?)", withAllSkills)
 	}
 
-	// Only active (published and not expired)
-	query = query.Where("status = ?", "published").
-		Where("(expired_at IS NULL OR expired_at > ?)", time.Now())
-
-	// Count total
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	// Distance filter
+	if filter.Latitude != nil && filter.Longitude != nil {
+		query = applyDistanceFilter(query, *filter.Latitude, *filter.Longitude, filter.RadiusKm)
 	}
 
-	// Pagination defaults
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 {
-		limit = 10
-	}
-	offset := (page - 1) * limit
-
-	// Execute final query
-	err := query.
-		Preload("Category").
-		Preload("CompanyAddress.Province").
-		Preload("CompanyAddress.City").
-		Preload("JobSubcategory").
-		Preload("JobTitle").
-		Preload("JobType").
-		Preload("WorkPolicy").
-		Preload("EducationLevelM").
-		Preload("ExperienceLevelM").
-		Preload("GenderPreference").
-		Preload("Locations").
-		Preload("Benefits").
-		Preload("Skills.Skill").
-		Order("published_at DESC").
-		Limit(limit).
-		Offset(offset).
-		Find(&jobs).Error
-
-	return jobs, total, err
+	// Only active (published and not expired)
+	return query.Where("status = ?", job.StatusPublished).
+		Where("(expired_at IS NULL OR expired_at > ?)", time.Now())
 }
 
-// GetJobsByStatus returns jobs by specific status for a user with pagination
-func (r *jobRepository) GetJobsByStatus(ctx context.Context, userID int64, status string, page, limit int) ([]job.Job, int64, error) {
+// GetJobsByStatus returns the jobs posted by the employer user by specific status with pagination.
+// An employer user is a membership of one company, so other companies' jobs are not included.
+func (r *jobRepository) GetJobsByStatus(ctx context.Context, employerUserID int64, status string, page, limit int) ([]job.Job, int64, error) {
 	var jobs []job.Job
 	var total int64
 
 	// Map UI status to database status
-	dbStatuses := []string{}
-	switch status {
-	case "active":
-		dbStatuses = []string{"published"}
-	case "inactive":
-		dbStatuses = []string{"inactive", "closed"}
-	case "draft":
-		dbStatuses = []string{"draft"}
-	case "in_review":
-		dbStatuses = []string{"in_review", "pending_review"}
-	default:
+	dbStatuses, ok := job.TabStatuses[status]
+	if !ok {
 		return nil, 0, nil
 	}
 
@@ -422,8 +670,7 @@ func (r *jobRepository) GetJobsByStatus(ctx context.Context, userID int64, statu
 		Model(&job.Job{}).
 		Preload("CompanyAddress.Province").
 		Preload("CompanyAddress.City").
-		Joins("JOIN employer_users eu ON eu.id = jobs.employer_user_id").
-		Where("eu.user_id = ?", userID).
+		Where("jobs.employer_user_id = ?", employerUserID).
 		Where("jobs.status IN ?", dbStatuses)
 
 	// Count total
@@ -454,7 +701,7 @@ func (r *jobRepository) UpdateStatusByCompany(ctx context.Context, companyID int
 	return r.db.WithContext(ctx).
 		Model(&job.Job{}).
 		Where("company_id = ? AND status = ?", companyID, fromStatus).
-		Update("status", toStatus).Error
+		Updates(map[string]interface{}{"status": toStatus, "version": bumpVersion}).Error
 }
 
 // UpdateStatus updates job status
@@ -462,18 +709,20 @@ func (r *jobRepository) UpdateStatus(ctx context.Context, id int64, status strin
 	return r.db.WithContext(ctx).
 		Model(&job.Job{}).
 		Where("id = ?", id).
-		Update("status", status).Error
+		Updates(map[string]interface{}{"status": status, "version": bumpVersion}).Error
 }
 
-// UpdateStatusWithExpiry updates job status and optionally sets published_at and expired_at
+// UpdateStatusWithExpiry updates job status and optionally sets published_at and expired_at.
+// A new expiry date starts a fresh expiry period, so reminders and auto-extensions reset.
 func (r *jobRepository) UpdateStatusWithExpiry(ctx context.Context, id int64, status string, publishedAt *time.Time, expiredAt *time.Time) error {
 	updates := map[string]interface{}{}
 	updates["status"] = status
+	updates["version"] = bumpVersion
 	if publishedAt != nil {
 		updates["published_at"] = *publishedAt
 	}
 	if expiredAt != nil {
-		updates["expired_at"] = *expiredAt
+		setExpiryPeriod(updates, *expiredAt)
 	}
 
 	return r.db.WithContext(ctx).
@@ -489,24 +738,140 @@ func (r *jobRepository) PublishJob(ctx context.Context, id int64) error {
 		Model(&job.Job{}).
 		Where("id = ?", id).
 		Updates(map[string]interface{}{
-			"status":       "published",
+			"status":       job.StatusPublished,
 			"published_at": now,
+			"version":      bumpVersion,
 		}).Error
 }
 
+// MarkFollowersNotified records that the job's followers were notified, unless they
+// already were within window. It reports whether the mark was set, so concurrent
+// publishes of the same job notify followers only once.
+func (r *jobRepository) MarkFollowersNotified(ctx context.Context, jobID int64, window time.Duration) (bool, error) {
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&job.Job{}).
+		Where("id = ? AND (followers_notified_at IS NULL OR followers_notified_at <= ?)", jobID, now.Add(-window)).
+		UpdateColumn("followers_notified_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// SubmitForReview moves a job to pending_review and records the submission time
+func (r *jobRepository) SubmitForReview(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).
+		Model(&job.Job{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":       job.StatusPendingReview,
+			"submitted_at": time.Now(),
+			"version":      bumpVersion,
+		}).Error
+}
+
+// ReviewJob updates the job status and stores the review record in a single transaction.
+// The status only changes while the job is still pending_review, so concurrent reviews
+// of the same job cannot both succeed.
+func (r *jobRepository) ReviewJob(ctx context.Context, review *job.JobReview, status string, publishedAt, expiredAt *time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{"status": status, "version": bumpVersion}
+		if publishedAt != nil {
+			updates["published_at"] = *publishedAt
+		}
+		if expiredAt != nil {
+			setExpiryPeriod(updates, *expiredAt)
+		}
+
+		result := tx.Model(&job.Job{}).
+			Where("id = ? AND status = ?", review.JobID, job.StatusPendingReview).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return job.ErrJobNotPendingReview
+		}
+
+		return tx.Create(review).Error
+	})
+}
+
+// FindLatestReview retrieves the most recent review of a job
+func (r *jobRepository) FindLatestReview(ctx context.Context, jobID int64) (*job.JobReview, error) {
+	var review job.JobReview
+	err := r.db.WithContext(ctx).
+		Where("job_id = ?", jobID).
+		Order("created_at DESC, id DESC").
+		First(&review).Error
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// CreateDraftRevision stores a draft revision and prunes all but the newest keep revisions of the job
+func (r *jobRepository) CreateDraftRevision(ctx context.Context, revision *job.JobDraftRevision, keep int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(revision).Error; err != nil {
+			return err
+		}
+
+		newest := tx.Model(&job.JobDraftRevision{}).
+			Select("id").
+			Where("job_id = ?", revision.JobID).
+			Order("created_at DESC, id DESC").
+			Limit(keep)
+
+		return tx.Where("job_id = ? AND id NOT IN (?)", revision.JobID, newest).
+			Delete(&job.JobDraftRevision{}).Error
+	})
+}
+
+// ListDraftRevisions retrieves a job's draft revisions, newest first
+func (r *jobRepository) ListDraftRevisions(ctx context.Context, jobID int64) ([]job.JobDraftRevision, error) {
+	var revisions []job.JobDraftRevision
+	err := r.db.WithContext(ctx).
+		Where("job_id = ?", jobID).
+		Order("created_at DESC, id DESC").
+		Find(&revisions).Error
+	return revisions, err
+}
+
+// FindDraftRevision retrieves a single draft revision of a job
+func (r *jobRepository) FindDraftRevision(ctx context.Context, jobID, revisionID int64) (*job.JobDraftRevision, error) {
+	var revision job.JobDraftRevision
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND job_id = ?", revisionID, jobID).
+		First(&revision).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// DeleteDraftRevisions removes all draft revisions of a job
+func (r *jobRepository) DeleteDraftRevisions(ctx context.Context, jobID int64) error {
+	return r.db.WithContext(ctx).Where("job_id = ?", jobID).Delete(&job.JobDraftRevision{}).Error
+}
+
 // CloseJob closes a job
 func (r *jobRepository) CloseJob(ctx context.Context, id int64) error {
-	return r.UpdateStatus(ctx, id, "closed")
+	return r.UpdateStatus(ctx, id, job.StatusClosed)
 }
 
 // ExpireJob marks a job as expired
 func (r *jobRepository) ExpireJob(ctx context.Context, id int64) error {
-	return r.UpdateStatus(ctx, id, "expired")
+	return r.UpdateStatus(ctx, id, job.StatusExpired)
 }
 
 // SuspendJob suspends a job
 func (r *jobRepository) SuspendJob(ctx context.Context, id int64) error {
-	return r.UpdateStatus(ctx, id, "suspended")
+	return r.UpdateStatus(ctx, id, job.StatusSuspended)
 }
 
 // GetExpiredJobs retrieves jobs that have expired
@@ -514,7 +879,7 @@ func (r *jobRepository) GetExpiredJobs(ctx context.Context) ([]job.Job, error) {
 	var jobs []job.Job
 	now := time.Now()
 	err := r.db.WithContext(ctx).
-		Where("status = ? AND expired_at <= ?", "published", now).
+		Where("status = ? AND expired_at <= ?", job.StatusPublished, now).
 		Find(&jobs).Error
 	return jobs, err
 }
@@ -525,11 +890,61 @@ func (r *jobRepository) GetExpiringJobs(ctx context.Context, days int) ([]job.Jo
 	now := time.Now()
 	futureDate := now.AddDate(0, 0, days)
 	err := r.db.WithContext(ctx).
-		Where("status = ? AND expired_at > ? AND expired_at <= ?", "published", now, futureDate).
+		Where("status = ? AND expired_at > ? AND expired_at <= ?", job.StatusPublished, now, futureDate).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// FindJobsExpiringWithin retrieves published jobs that expire within days and have not
+// been reminded at this or a more urgent threshold
+func (r *jobRepository) FindJobsExpiringWithin(ctx context.Context, days int) ([]job.Job, error) {
+	var jobs []job.Job
+	now := time.Now()
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND expired_at > ? AND expired_at <= ?", job.StatusPublished, now, now.AddDate(0, 0, days)).
+		Where("expiry_reminder_days IS NULL OR expiry_reminder_days > ?", days).
+		Order("expired_at ASC").
 		Find(&jobs).Error
 	return jobs, err
 }
 
+// MarkExpiryReminded records that the days-before-expiry reminder of a job was sent
+func (r *jobRepository) MarkExpiryReminded(ctx context.Context, jobID int64, days int) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&job.Job{}).
+		Where("id = ? AND (expiry_reminder_days IS NULL OR expiry_reminder_days > ?)", jobID, days).
+		UpdateColumn("expiry_reminder_days", days)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// AutoExtendJob extends a published auto_extend job to expiredAt and counts the extension
+func (r *jobRepository) AutoExtendJob(ctx context.Context, jobID int64, expiredAt time.Time, maxExtensions int) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&job.Job{}).
+		Where("id = ? AND status = ? AND auto_extend AND auto_extend_count < ?", jobID, job.StatusPublished, maxExtensions).
+		UpdateColumns(map[string]interface{}{
+			"expired_at":           expiredAt,
+			"auto_extend_count":    gorm.Expr("auto_extend_count + 1"),
+			"expiry_reminder_days": nil,
+			"updated_at":           time.Now(),
+			"version":              bumpVersion,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// setExpiryPeriod adds the updates that start a new expiry period ending at expiredAt
+func setExpiryPeriod(updates map[string]interface{}, expiredAt time.Time) {
+	updates["expired_at"] = expiredAt
+	updates["expiry_reminder_days"] = nil
+	updates["auto_extend_count"] = 0
+}
+
 // ===========================================
 // JOB STATISTICS
 // ===========================================
@@ -542,6 +957,90 @@ func (r *jobRepository) IncrementViews(ctx context.Context, id int64) error {
 		UpdateColumn("views_count", gorm.Expr("views_count + ?", 1)).Error
 }
 
+// RecordView stores a view event and increments the job's view counter, unless the same
+// user (or, for anonymous viewers, the same IP hash) already viewed the job within window.
+// It reports whether the view was counted.
+func (r *jobRepository) RecordView(ctx context.Context, event *job.JobViewEvent, window time.Duration) (bool, error) {
+	counted := false
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if event.ViewedAt.IsZero() {
+			event.ViewedAt = time.Now()
+		}
+
+		if event.UserID != nil || event.IPHash != nil {
+			// Serialize concurrent views of the job by the same viewer only, with a lock that is
+			// released on commit, so views by different viewers don't queue on the job row
+			viewer := ""
+			query := tx.Model(&job.JobViewEvent{}).
+				Where("job_id = ? AND viewed_at > ?", event.JobID, event.ViewedAt.Add(-window))
+			if event.UserID != nil {
+				viewer = fmt.Sprintf("user:%d", *event.UserID)
+				query = query.Where("user_id = ?", *event.UserID)
+			} else {
+				viewer = "ip:" + *event.IPHash
+				query = query.Where("user_id IS NULL AND ip_hash = ?", *event.IPHash)
+			}
+
+			lockKey := fmt.Sprintf("job_view:%d:%s", event.JobID, viewer)
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", lockKey).Error; err != nil {
+				return err
+			}
+
+			var recent int64
+			if err := query.Count(&recent).Error; err != nil {
+				return err
+			}
+			if recent > 0 {
+				return nil
+			}
+		}
+
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&job.Job{}).
+			Where("id = ?", event.JobID).
+			UpdateColumn("views_count", gorm.Expr("views_count + ?", 1)).Error; err != nil {
+			return err
+		}
+
+		counted = true
+		return nil
+	})
+
+	return counted, err
+}
+
+// DeleteViewEventsBefore deletes the view events recorded before the given time and returns
+// how many were deleted. Jobs keep their views_count.
+func (r *jobRepository) DeleteViewEventsBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("viewed_at < ?", before).
+		Delete(&job.JobViewEvent{})
+	return result.RowsAffected, result.Error
+}
+
+// GetDailyViews returns counted views per day for a job from the day of from through the day of to.
+// Days without views are omitted.
+func (r *jobRepository) GetDailyViews(ctx context.Context, jobID int64, from, to time.Time) ([]job.TimeSeriesData, error) {
+	var data []job.TimeSeriesData
+
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, to.Location())
+
+	err := r.db.WithContext(ctx).
+		Model(&job.JobViewEvent{}).
+		Select("date_trunc('day', viewed_at) AS date, COUNT(*) AS value").
+		Where("job_id = ? AND viewed_at >= ? AND viewed_at < ?", jobID, from, to.AddDate(0, 0, 1)).
+		Group("date_trunc('day', viewed_at)").
+		Order("date ASC").
+		Scan(&data).Error
+
+	return data, err
+}
+
 // IncrementApplications increments job application count
 func (r *jobRepository) IncrementApplications(ctx context.Context, id int64) error {
 	return r.db.WithContext(ctx).
@@ -583,7 +1082,7 @@ func (r *jobRepository) GetCompanyJobStats(ctx context.Context, companyID int64)
 
 	// Active jobs
 	r.db.WithContext(ctx).Model(&job.Job{}).
-		Where("company_id = ? AND status = ?", companyID, "published").
+		Where("company_id = ? AND status = ?", companyID, job.StatusPublished).
 		Where("(expired_at IS NULL OR expired_at > ?)", time.Now()).
 		Count(&stats.ActiveJobs)
 
@@ -634,7 +1133,7 @@ func (r *jobRepository) GetRecommendedJobs(ctx context.Context, userID int64, li
 	// Simplified recommendation: get latest published jobs
 	// In production, implement ML-based recommendation
 	err := r.db.WithContext(ctx).
-		Where("status = ?", "published").
+		Where("status = ?", job.StatusPublished).
 		Where("(expired_at IS NULL OR expired_at > ?)", time.Now()).
 		Order("published_at DESC").
 		Limit(limit).
@@ -657,7 +1156,7 @@ func (r *jobRepository) GetSimilarJobs(ctx context.Context, jobID int64, limit i
 	var jobs []job.Job
 	query := r.db.WithContext(ctx).
 		Where("id != ?", jobID).
-		Where("status = ?", "published").
+		Where("status = ?", job.StatusPublished).
 		Where("(expired_at IS NULL OR expired_at > ?)", time.Now())
 
 	// Match by category or location
@@ -683,7 +1182,7 @@ func (r *jobRepository) GetSimilarJobs(ctx context.Context, jobID int64, limit i
 func (r *jobRepository) GetMatchingJobs(ctx context.Context, userID int64, filter job.JobFilter, page, limit int) ([]job.Job, int64, error) {
 	// Simplified matching: return published jobs
 	// In production, match against user skills, experience, preferences
-	filter.Status = "published"
+	filter.Status = job.StatusPublished
 	isActive := true
 	filter.IsActive = &isActive
 	return r.List(ctx, filter, page, limit)
@@ -693,24 +1192,50 @@ func (r *jobRepository) GetMatchingJobs(ctx context.Context, userID int64, filte
 // ADVANCED SEARCH
 // ===========================================
 
-// SearchByLocation searches jobs near a location
+// jobGeoJoins resolve a job's coordinates: its primary (or first) job location that has
+// coordinates, falling back to the company address the job is posted at
+const jobGeoJoins = `LEFT JOIN LATERAL (
+		SELECT job_locations.latitude, job_locations.longitude
+		FROM job_locations
+		WHERE job_locations.job_id = jobs.id
+			AND job_locations.latitude IS NOT NULL AND job_locations.longitude IS NOT NULL
+		ORDER BY job_locations.is_primary DESC, job_locations.id ASC
+		LIMIT 1
+	) job_geo ON TRUE
+	LEFT JOIN company_addresses job_address
+		ON job_address.id = jobs.company_address_id AND job_address.deleted_at IS NULL`
+
+// jobDistanceSQL is the Haversine distance in km between a job (see jobGeoJoins) and
+// the point bound as (latitude, longitude, latitude). The acos argument is clamped so
+// rounding never takes it outside [-1, 1].
+const jobDistanceSQL = `(6371 * acos(LEAST(1, GREATEST(-1,
+		cos(radians(?)) * cos(radians(COALESCE(job_geo.latitude, job_address.latitude))) *
+		cos(radians(COALESCE(job_geo.longitude, job_address.longitude)) - radians(?)) +
+		sin(radians(?)) * sin(radians(COALESCE(job_geo.latitude, job_address.latitude)))
+	))))`
+
+// applyDistanceFilter keeps jobs within radiusKm of the point. Jobs without coordinates
+// are excluded.
+func applyDistanceFilter(query *gorm.DB, latitude, longitude, radiusKm float64) *gorm.DB {
+	return query.
+		Joins(jobGeoJoins).
+		Where(jobDistanceSQL+" <= ?", latitude, longitude, latitude, radiusKm)
+}
+
+// selectDistance selects distance_km alongside the job columns and orders nearest first.
+// The query must already have the joins added by applyDistanceFilter.
+func selectDistance(query *gorm.DB, latitude, longitude float64) *gorm.DB {
+	return query.
+		Select("jobs.*, ROUND("+jobDistanceSQL+"::numeric, 2) AS distance_km", latitude, longitude, latitude).
+		Order("distance_km ASC")
+}
+
+// SearchByLocation searches jobs within radius km of a location, nearest first
 func (r *jobRepository) SearchByLocation(ctx context.Context, latitude, longitude, radius float64, filter job.JobFilter, page, limit int) ([]job.Job, int64, error) {
 	var jobs []job.Job
 	var total int64
 
-	// Use Haversine formula for distance calculation
-	query := r.db.WithContext(ctx).
-		Table("jobs").
-		Select("jobs.*").
-		Joins("INNER JOIN job_locations ON job_locations.job_id = jobs.id").
-		Where(`(
-			6371 * acos(
-				cos(radians(?)) * cos(radians(job_locations.latitude)) *
-				cos(radians(job_locations.longitude) - radians(?)) +
-				sin(radians(?)) * sin(radians(job_locations.latitude))
-			)
-		) <= ?`, latitude, longitude, latitude, radius)
-
+	query := applyDistanceFilter(r.db.WithContext(ctx).Model(&job.Job{}), latitude, longitude, radius)
 	query = r.applyJobFilter(query, filter)
 
 	// Count total
@@ -727,8 +1252,9 @@ func (r *jobRepository) SearchByLocation(ctx context.Context, latitude, longitud
 	}
 	offset := (page - 1) * limit
 
-	err := query.
+	err := selectDistance(query, latitude, longitude).
 		Preload("Category").
+		Preload("CompanyAddress").
 		Preload("Locations").
 		Preload("Benefits").
 		Limit(limit).
@@ -1113,6 +1639,19 @@ func (r *jobRepository) BulkDeleteBenefits(ctx context.Context, jobID int64) err
 		Delete(&job.JobBenefit{}).Error
 }
 
+// ReplaceBenefits deletes the job's benefits and inserts the given set in one transaction
+func (r *jobRepository) ReplaceBenefits(ctx context.Context, jobID int64, benefits []job.JobBenefit) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("job_id = ?", jobID).Delete(&job.JobBenefit{}).Error; err != nil {
+			return err
+		}
+		if len(benefits) == 0 {
+			return nil
+		}
+		return tx.Create(&benefits).Error
+	})
+}
+
 // ===========================================
 // JOB SKILL OPERATIONS
 // ===========================================
@@ -1187,6 +1726,92 @@ func (r *jobRepository) BulkDeleteSkills(ctx context.Context, jobID int64) error
 		Delete(&job.JobSkill{}).Error
 }
 
+// ReplaceSkills deletes the job's skills and inserts the given set in one transaction
+func (r *jobRepository) ReplaceSkills(ctx context.Context, jobID int64, skills []job.JobSkill) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("job_id = ?", jobID).Delete(&job.JobSkill{}).Error; err != nil {
+			return err
+		}
+		if len(skills) == 0 {
+			return nil
+		}
+		return tx.Create(&skills).Error
+	})
+}
+
+// ===========================================
+// JOB QUESTION OPERATIONS
+// ===========================================
+
+// CreateQuestion creates a screening question
+func (r *jobRepository) CreateQuestion(ctx context.Context, question *job.JobQuestion) error {
+	return r.db.WithContext(ctx).Create(question).Error
+}
+
+// FindQuestionByID finds a screening question by ID
+func (r *jobRepository) FindQuestionByID(ctx context.Context, id int64) (*job.JobQuestion, error) {
+	var question job.JobQuestion
+	err := r.db.WithContext(ctx).First(&question, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &question, nil
+}
+
+// UpdateQuestion updates a screening question
+func (r *jobRepository) UpdateQuestion(ctx context.Context, question *job.JobQuestion) error {
+	return r.db.WithContext(ctx).Save(question).Error
+}
+
+// DeleteQuestion deletes a screening question
+func (r *jobRepository) DeleteQuestion(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Delete(&job.JobQuestion{}, id).Error
+}
+
+// ListQuestionsByJob retrieves a job's screening questions in display order
+func (r *jobRepository) ListQuestionsByJob(ctx context.Context, jobID int64) ([]job.JobQuestion, error) {
+	var questions []job.JobQuestion
+	err := r.db.WithContext(ctx).
+		Where("job_id = ?", jobID).
+		Order("sort_order ASC, id ASC").
+		Find(&questions).Error
+	return questions, err
+}
+
+// ===========================================
+// JOB PIPELINE OPERATIONS
+// ===========================================
+
+// ListPipelineStages lists a job's pipeline stages in order
+func (r *jobRepository) ListPipelineStages(ctx context.Context, jobID int64) ([]job.JobPipelineStage, error) {
+	var stages []job.JobPipelineStage
+	err := r.db.WithContext(ctx).
+		Where("job_id = ?", jobID).
+		Order("stage_order ASC").
+		Find(&stages).Error
+	return stages, err
+}
+
+// ReplacePipelineStages replaces a job's pipeline with stages in a single transaction
+func (r *jobRepository) ReplacePipelineStages(ctx context.Context, jobID int64, stages []job.JobPipelineStage) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("job_id = ?", jobID).Delete(&job.JobPipelineStage{}).Error; err != nil {
+			return err
+		}
+		if len(stages) == 0 {
+			return nil
+		}
+		for i := range stages {
+			stages[i].ID = 0
+			stages[i].JobID = jobID
+		}
+		return tx.Create(&stages).Error
+	})
+}
+
 // ===========================================
 // JOB REQUIREMENT OPERATIONS
 // ===========================================
@@ -1251,18 +1876,32 @@ func (r *jobRepository) BulkDeleteRequirements(ctx context.Context, jobID int64)
 		Delete(&job.JobRequirement{}).Error
 }
 
+// ReplaceRequirements deletes the job's requirements and inserts the given set in one transaction
+func (r *jobRepository) ReplaceRequirements(ctx context.Context, jobID int64, requirements []job.JobRequirement) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("job_id = ?", jobID).Delete(&job.JobRequirement{}).Error; err != nil {
+			return err
+		}
+		if len(requirements) == 0 {
+			return nil
+		}
+		return tx.Create(&requirements).Error
+	})
+}
+
 // ===========================================
 // ANALYTICS
 // ===========================================
 
-// GetTrendingJobs retrieves trending jobs
-func (r *jobRepository) GetTrendingJobs(ctx context.Context, limit int) ([]job.Job, error) {
+// ListFeatured retrieves published, unexpired jobs that are featured at now
+func (r *jobRepository) ListFeatured(ctx context.Context, now time.Time, limit int) ([]job.Job, error) {
 	var jobs []job.Job
-	sevenDaysAgo := time.Now().AddDate(0, 0, -7)
 	err := r.db.WithContext(ctx).
-		Where("status = ?", "published").
-		Where("published_at >= ?", sevenDaysAgo).
-		Order("views_count DESC, applications_count DESC").
+		Where("status = ? AND is_featured", job.StatusPublished).
+		Where("(expired_at IS NULL OR expired_at > ?)", now).
+		Where("(featured_from IS NULL OR featured_from <= ?)", now).
+		Where("(featured_until IS NULL OR featured_until > ?)", now).
+		Order("COALESCE(featured_from, published_at) DESC, id DESC").
 		Limit(limit).
 		Preload("Category").
 		Preload("CompanyAddress.Province").
@@ -1273,6 +1912,93 @@ func (r *jobRepository) GetTrendingJobs(ctx context.Context, limit int) ([]job.J
 	return jobs, err
 }
 
+// ListViewBuckets counts views per job and hour since the given time, for published,
+// unexpired jobs only
+func (r *jobRepository) ListViewBuckets(ctx context.Context, since time.Time) ([]job.JobViewBucket, error) {
+	var buckets []job.JobViewBucket
+	err := r.db.WithContext(ctx).
+		Table("job_view_events AS v").
+		Select("v.job_id, date_trunc('hour', v.viewed_at) AS hour, COUNT(*) AS views").
+		Joins("JOIN jobs ON jobs.id = v.job_id").
+		Where("v.viewed_at >= ?", since).
+		Where("jobs.status = ? AND jobs.deleted_at IS NULL", job.StatusPublished).
+		Where("(jobs.expired_at IS NULL OR jobs.expired_at > ?)", time.Now()).
+		Group("v.job_id, date_trunc('hour', v.viewed_at)").
+		Scan(&buckets).Error
+	return buckets, err
+}
+
+// FindPublishedByIDs finds published jobs by IDs with the relations job listings show
+func (r *jobRepository) FindPublishedByIDs(ctx context.Context, ids []int64) ([]job.Job, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var jobs []job.Job
+	err := r.db.WithContext(ctx).
+		Where("id IN ? AND status = ?", ids, job.StatusPublished).
+		Preload("Category").
+		Preload("CompanyAddress.Province").
+		Preload("CompanyAddress.City").
+		Preload("Locations").
+		Preload("Benefits").
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// SetFeatured updates a job's featured flag and window
+func (r *jobRepository) SetFeatured(ctx context.Context, jobID int64, featured bool, from, until *time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&job.Job{}).
+		Where("id = ?", jobID).
+		Updates(map[string]interface{}{
+			"is_featured":    featured,
+			"featured_from":  from,
+			"featured_until": until,
+		}).Error
+}
+
+// sitemapJobs scopes a query to the jobs listed in the sitemap at now: published,
+// unexpired and with a slug
+func (r *jobRepository) sitemapJobs(ctx context.Context, now time.Time) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Model(&job.Job{}).
+		Where("status = ? AND slug <> ''", job.StatusPublished).
+		Where("(expired_at IS NULL OR expired_at > ?)", now)
+}
+
+// CountSitemapEntries counts the jobs listed in the sitemap at now
+func (r *jobRepository) CountSitemapEntries(ctx context.Context, now time.Time) (int64, error) {
+	var count int64
+	err := r.sitemapJobs(ctx, now).Count(&count).Error
+	return count, err
+}
+
+// StreamSitemapEntries reads a page of sitemap entries row by row, so a full sitemap page
+// is never held in memory
+func (r *jobRepository) StreamSitemapEntries(ctx context.Context, now time.Time, offset, limit int, fn func(job.SitemapEntry) error) error {
+	query := r.sitemapJobs(ctx, now).
+		Select("slug, updated_at").
+		Order("id").
+		Offset(offset).
+		Limit(limit)
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry job.SitemapEntry
+		if err := query.ScanRows(rows, &entry); err != nil {
+			return err
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 // GetPopularCategories retrieves popular categories
 func (r *jobRepository) GetPopularCategories(ctx context.Context, limit int) ([]job.CategoryStats, error) {
 	var stats []job.CategoryStats
@@ -1294,6 +2020,27 @@ func (r *jobRepository) GetPopularCategories(ctx context.Context, limit int) ([]
 	return stats, err
 }
 
+// GetTopCompanies retrieves companies with the most applications across their jobs
+func (r *jobRepository) GetTopCompanies(ctx context.Context, limit int) ([]job.CompanyStats, error) {
+	var stats []job.CompanyStats
+	err := r.db.WithContext(ctx).
+		Model(&job.Job{}).
+		Select(`
+			companies.id as company_id,
+			companies.company_name as company_name,
+			COUNT(jobs.id) as total_jobs,
+			COUNT(CASE WHEN jobs.status = 'published' AND (jobs.expired_at IS NULL OR jobs.expired_at > NOW()) THEN 1 END) as active_jobs,
+			COALESCE(SUM(jobs.views_count), 0) as total_views,
+			COALESCE(SUM(jobs.applications_count), 0) as total_applications
+		`).
+		Joins("INNER JOIN companies ON companies.id = jobs.company_id AND companies.deleted_at IS NULL").
+		Group("companies.id, companies.company_name").
+		Order("total_applications DESC, total_views DESC, companies.id").
+		Limit(limit).
+		Scan(&stats).Error
+	return stats, err
+}
+
 // GetJobsByDateRange retrieves jobs within date range
 func (r *jobRepository) GetJobsByDateRange(ctx context.Context, startDate, endDate time.Time, filter job.JobFilter) ([]job.Job, error) {
 	var jobs []job.Job
@@ -1355,7 +2102,7 @@ func (r *jobRepository) applyJobFilter(query *gorm.DB, filter job.JobFilter) *go
 		query = query.Where("status = ?", filter.Status)
 	}
 	if filter.CompanyID > 0 {
-		query = query.Where("company_id = ?", filter.CompanyID)
+		query = query.Where("jobs.company_id = ?", filter.CompanyID)
 	}
 	if filter.CategoryID > 0 {
 		query = query.Where("category_id = ?", filter.CategoryID)
@@ -1391,12 +2138,18 @@ func (r *jobRepository) applyJobFilter(query *gorm.DB, filter job.JobFilter) *go
 		query = query.Where("education_level = ?", filter.EducationLevel)
 	}
 	if filter.IsActive != nil && *filter.IsActive {
-		query = query.Where("status = ?", "published").
+		query = query.Where("status = ?", job.StatusPublished).
 			Where("(expired_at IS NULL OR expired_at > ?)", time.Now())
 	}
 	if filter.PublishedAfter != nil {
 		query = query.Where("published_at >= ?", *filter.PublishedAfter)
 	}
+	if filter.SubmittedAfter != nil {
+		query = query.Where("submitted_at >= ?", *filter.SubmittedAfter)
+	}
+	if filter.SubmittedBefore != nil {
+		query = query.Where("submitted_at < ?", *filter.SubmittedBefore)
+	}
 	return query
 }
 
@@ -1413,6 +2166,8 @@ func (r *jobRepository) applySorting(query *gorm.DB, sortBy string) *gorm.DB {
 		return query.Order("views_count DESC")
 	case "applications":
 		return query.Order("applications_count DESC")
+	case "submitted":
+		return query.Order("submitted_at ASC NULLS LAST")
 	default:
 		return query.Order("created_at DESC")
 	}
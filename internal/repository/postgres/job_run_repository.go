@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"keerja-backend/internal/jobs"
+
+	"gorm.io/gorm"
+)
+
+// jobRunRepository implements the jobs.RunRepository interface
+type jobRunRepository struct {
+	db *gorm.DB
+}
+
+// NewJobRunRepository creates a new job run repository instance
+func NewJobRunRepository(db *gorm.DB) jobs.RunRepository {
+	return &jobRunRepository{db: db}
+}
+
+// Create records a run that has just started
+func (r *jobRunRepository) Create(ctx context.Context, run *jobs.JobRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+// Finish records the outcome of a run
+func (r *jobRunRepository) Finish(ctx context.Context, run *jobs.JobRun) error {
+	if run.ID == 0 {
+		// The start was not recorded, so keep the finished run on its own
+		return r.db.WithContext(ctx).Create(run).Error
+	}
+
+	return r.db.WithContext(ctx).
+		Model(&jobs.JobRun{}).
+		Where("id = ?", run.ID).
+		Updates(map[string]interface{}{
+			"finished_at":     run.FinishedAt,
+			"success":         run.Success,
+			"error":           run.Error,
+			"items_processed": run.ItemsProcessed,
+		}).Error
+}
+
+// LatestByJob returns the most recent run of each job, keyed by job name
+func (r *jobRunRepository) LatestByJob(ctx context.Context) (map[string]jobs.JobRun, error) {
+	var runs []jobs.JobRun
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT DISTINCT ON (job_name) *
+		FROM job_runs
+		ORDER BY job_name, started_at DESC, id DESC`,
+	).Scan(&runs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]jobs.JobRun, len(runs))
+	for _, run := range runs {
+		latest[run.JobName] = run
+	}
+	return latest, nil
+}
+
+// DeleteStartedBefore removes runs started before the given time
+func (r *jobRunRepository) DeleteStartedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("started_at < ?", before).Delete(&jobs.JobRun{})
+	return result.RowsAffected, result.Error
+}
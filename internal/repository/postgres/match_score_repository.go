@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"keerja-backend/internal/domain/job"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// matchScoreUpsertBatch is how many scores are written per INSERT statement
+const matchScoreUpsertBatch = 500
+
+// matchScoreRepository implements the job.MatchScoreRepository interface
+type matchScoreRepository struct {
+	db *gorm.DB
+}
+
+// NewMatchScoreRepository creates a new job match score repository instance
+func NewMatchScoreRepository(db *gorm.DB) job.MatchScoreRepository {
+	return &matchScoreRepository{db: db}
+}
+
+// UpsertScores inserts the scores, replacing existing scores of the same user and job
+func (r *matchScoreRepository) UpsertScores(ctx context.Context, scores []job.UserJobMatchScore) error {
+	if len(scores) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "job_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"overall", "skill", "experience", "education", "location", "computed_at"}),
+		}).
+		CreateInBatches(scores, matchScoreUpsertBatch).Error
+}
+
+// FindScores loads the user's stored scores of the given jobs
+func (r *matchScoreRepository) FindScores(ctx context.Context, userID int64, jobIDs []int64) ([]job.UserJobMatchScore, error) {
+	if len(jobIDs) == 0 {
+		return nil, nil
+	}
+	var scores []job.UserJobMatchScore
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND job_id IN ?", userID, jobIDs).
+		Find(&scores).Error
+	return scores, err
+}
+
+// TopJobsForUser returns the published, unexpired jobs with the user's highest fresh scores
+func (r *matchScoreRepository) TopJobsForUser(ctx context.Context, userID int64, freshSince time.Time, limit int) ([]job.JobWithScore, error) {
+	var scores []job.UserJobMatchScore
+	err := r.db.WithContext(ctx).
+		Table("user_job_match_scores AS s").
+		Select("s.*").
+		Joins("JOIN jobs ON jobs.id = s.job_id").
+		Where("s.user_id = ? AND s.computed_at >= ?", userID, freshSince).
+		Where("jobs.status = ? AND jobs.deleted_at IS NULL", job.StatusPublished).
+		Where("(jobs.expired_at IS NULL OR jobs.expired_at > ?)", time.Now()).
+		Order("s.overall DESC, s.job_id DESC").
+		Limit(limit).
+		Find(&scores).Error
+	if err != nil || len(scores) == 0 {
+		return nil, err
+	}
+
+	ids := make([]int64, len(scores))
+	for i, s := range scores {
+		ids[i] = s.JobID
+	}
+	var jobs []job.Job
+	err = r.db.WithContext(ctx).
+		Where("id IN ?", ids).
+		Preload("Category").
+		Preload("CompanyAddress.Province").
+		Preload("CompanyAddress.City").
+		Preload("Locations").
+		Preload("Benefits").
+		Find(&jobs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]job.Job, len(jobs))
+	for _, j := range jobs {
+		byID[j.ID] = j
+	}
+	result := make([]job.JobWithScore, 0, len(scores))
+	for _, s := range scores {
+		if j, ok := byID[s.JobID]; ok {
+			result = append(result, job.JobWithScore{Job: j, MatchScore: s.Overall})
+		}
+	}
+	return result, nil
+}
+
+// DeleteByUser deletes the user's scores
+func (r *matchScoreRepository) DeleteByUser(ctx context.Context, userID int64) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&job.UserJobMatchScore{}).Error
+}
+
+// DeleteByJob deletes the job's scores
+func (r *matchScoreRepository) DeleteByJob(ctx context.Context, jobID int64) error {
+	return r.db.WithContext(ctx).Where("job_id = ?", jobID).Delete(&job.UserJobMatchScore{}).Error
+}
+
+// DeleteComputedBefore deletes scores computed before cutoff
+func (r *matchScoreRepository) DeleteComputedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("computed_at < ?", cutoff).Delete(&job.UserJobMatchScore{})
+	return result.RowsAffected, result.Error
+}
+
+// ListActiveJobSeekerIDs returns a page of active job seekers who signed in recently, by ID
+func (r *matchScoreRepository) ListActiveJobSeekerIDs(ctx context.Context, activeSince time.Time, afterID int64, limit int) ([]int64, error) {
+	var ids []int64
+	err := r.db.WithContext(ctx).
+		Table("users").
+		Where("user_type = ? AND status = ? AND last_login >= ? AND id > ?", "jobseeker", "active", activeSince, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// ListScoringJobs returns the recently published, unexpired jobs with the relations match scores read
+func (r *matchScoreRepository) ListScoringJobs(ctx context.Context, publishedSince time.Time) ([]job.Job, error) {
+	var jobs []job.Job
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND published_at >= ?", job.StatusPublished, publishedSince).
+		Where("(expired_at IS NULL OR expired_at > ?)", time.Now()).
+		Preload("Skills.Skill").
+		Preload("EducationLevelM").
+		Order("id ASC").
+		Find(&jobs).Error
+	return jobs, err
+}
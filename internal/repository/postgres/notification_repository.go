@@ -68,7 +68,7 @@ func (r *notificationRepository) ListByUser(ctx context.Context, userID int64, f
 	// Apply pagination
 	offset := (page - 1) * limit
 	err := query.
-		Order("priority DESC, created_at DESC").
+		Order("created_at DESC, id DESC").
 		Offset(offset).
 		Limit(limit).
 		Find(&notifs).Error
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"keerja-backend/internal/domain/notification"
+
+	"gorm.io/gorm"
+)
+
+// pushCampaignRepository implements the notification.PushCampaignRepository interface
+type pushCampaignRepository struct {
+	db *gorm.DB
+}
+
+// NewPushCampaignRepository creates a new push campaign repository instance
+func NewPushCampaignRepository(db *gorm.DB) notification.PushCampaignRepository {
+	return &pushCampaignRepository{db: db}
+}
+
+// Create creates a new push campaign
+func (r *pushCampaignRepository) Create(ctx context.Context, campaign *notification.PushCampaign) error {
+	return r.db.WithContext(ctx).Create(campaign).Error
+}
+
+// FindByID finds push campaign by ID, returning nil when it does not exist
+func (r *pushCampaignRepository) FindByID(ctx context.Context, id int64) (*notification.PushCampaign, error) {
+	var campaign notification.PushCampaign
+	err := r.db.WithContext(ctx).First(&campaign, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &campaign, nil
+}
+
+// List lists push campaigns, newest first
+func (r *pushCampaignRepository) List(ctx context.Context, page, limit int) ([]notification.PushCampaign, int64, error) {
+	var campaigns []notification.PushCampaign
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&notification.PushCampaign{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&campaigns).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return campaigns, total, nil
+}
+
+// ClaimDue marks due scheduled campaigns as sending in one statement, so overlapping
+// scheduler runs never send the same campaign twice
+func (r *pushCampaignRepository) ClaimDue(ctx context.Context, now time.Time, limit int) ([]notification.PushCampaign, error) {
+	var campaigns []notification.PushCampaign
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE push_campaigns
+		SET status = ?, started_at = ?, updated_at = ?
+		WHERE id IN (
+			SELECT id FROM push_campaigns
+			WHERE status = ? AND scheduled_at <= ?
+			ORDER BY scheduled_at ASC, id ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		notification.CampaignStatusSending, now, now,
+		notification.CampaignStatusScheduled, now, limit,
+	).Scan(&campaigns).Error
+	if err != nil {
+		return nil, err
+	}
+	return campaigns, nil
+}
+
+// Update updates push campaign
+func (r *pushCampaignRepository) Update(ctx context.Context, campaign *notification.PushCampaign) error {
+	campaign.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Save(campaign).Error
+}
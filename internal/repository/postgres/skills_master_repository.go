@@ -3,10 +3,12 @@ package postgres
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
 
 	"github.com/lib/pq"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"keerja-backend/internal/domain/master"
 )
@@ -293,6 +295,254 @@ func (r *skillsMasterRepository) FindByAliases(ctx context.Context, aliases []st
 	return skills, err
 }
 
+// skillNameSQL normalizes a skill name column the way master.NormalizeSkillName does
+func skillNameSQL(column string) string {
+	return `lower(regexp_replace(btrim(` + column + `), '\s+', ' ', 'g'))`
+}
+
+// ResolveSkill retrieves a skill by ID, following skills merged into another
+func (r *skillsMasterRepository) ResolveSkill(ctx context.Context, id int64) (*master.SkillsMaster, error) {
+	skill, err := r.FindByID(ctx, id)
+	if err != nil || skill != nil {
+		return skill, err
+	}
+
+	var alias master.SkillAlias
+	err = r.db.WithContext(ctx).
+		Where("merged_skill_id = ?", id).
+		First(&alias).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.FindByID(ctx, alias.SkillID)
+}
+
+// FindCanonicalByName retrieves the skill named name or having it as an alias
+func (r *skillsMasterRepository) FindCanonicalByName(ctx context.Context, name string) (*master.SkillsMaster, error) {
+	normalized := master.NormalizeSkillName(name)
+	if normalized == "" {
+		return nil, nil
+	}
+
+	var skill master.SkillsMaster
+	err := r.db.WithContext(ctx).
+		Preload("Parent").
+		Preload("Children").
+		Where(skillNameSQL("name")+" = ?", normalized).
+		Order("id ASC").
+		First(&skill).Error
+	if err == nil {
+		return &skill, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	var alias master.SkillAlias
+	err = r.db.WithContext(ctx).
+		Where("normalized_alias = ?", normalized).
+		First(&alias).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.FindByID(ctx, alias.SkillID)
+}
+
+// suggestSkillsSQL ranks skills by their best matching name or alias. A prefix match
+// outranks any similarity-only match; both are served by the trigram indexes.
+const suggestSkillsSQL = `
+SELECT sm.* FROM skills_master sm
+JOIN (
+	SELECT skill_id, MAX(score) AS score FROM (
+		SELECT id AS skill_id, CASE WHEN lower(name) LIKE @prefix THEN 1 ELSE 0 END + similarity(lower(name), @query) AS score
+		FROM skills_master
+		WHERE lower(name) LIKE @prefix OR lower(name) % @query
+		UNION ALL
+		SELECT skill_id, CASE WHEN normalized_alias LIKE @prefix THEN 1 ELSE 0 END + similarity(normalized_alias, @query)
+		FROM skill_aliases
+		WHERE normalized_alias LIKE @prefix OR normalized_alias % @query
+	) matched
+	GROUP BY skill_id
+) matches ON matches.skill_id = sm.id
+WHERE sm.is_active = true
+ORDER BY matches.score DESC, sm.popularity_score DESC, sm.name ASC
+LIMIT @limit`
+
+// SuggestSkills retrieves active skills whose name or an alias starts with or resembles query
+func (r *skillsMasterRepository) SuggestSkills(ctx context.Context, query string, limit int) ([]master.SkillsMaster, error) {
+	query = master.NormalizeSkillName(query)
+	if query == "" {
+		return []master.SkillsMaster{}, nil
+	}
+
+	// The query is matched literally, so LIKE wildcards in it are escaped
+	prefix := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(query) + "%"
+
+	var skills []master.SkillsMaster
+	err := r.db.WithContext(ctx).
+		Raw(suggestSkillsSQL, map[string]interface{}{"prefix": prefix, "query": query, "limit": limit}).
+		Scan(&skills).Error
+	return skills, err
+}
+
+// MergeSkill merges the source skill into the target skill. Jobs and users that already
+// have the target lose their source entry instead of getting a duplicate.
+func (r *skillsMasterRepository) MergeSkill(ctx context.Context, sourceID, targetID int64) (*master.SkillMergeResult, error) {
+	result := &master.SkillMergeResult{SourceID: sourceID, TargetID: targetID, Aliases: []string{}}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock both skills so a concurrent merge involving either waits for this one
+		var skills []master.SkillsMaster
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", []int64{sourceID, targetID}).
+			Order("id ASC").
+			Find(&skills).Error
+		if err != nil {
+			return err
+		}
+		var source, target *master.SkillsMaster
+		for i := range skills {
+			switch skills[i].ID {
+			case sourceID:
+				source = &skills[i]
+			case targetID:
+				target = &skills[i]
+			}
+		}
+		if source == nil || target == nil {
+			return master.ErrSkillNotFound
+		}
+
+		// Job skills are unique per job
+		res := tx.Exec(`DELETE FROM job_skills s WHERE s.skill_id = ?
+			AND EXISTS (SELECT 1 FROM job_skills t WHERE t.job_id = s.job_id AND t.skill_id = ?)`, sourceID, targetID)
+		if res.Error != nil {
+			return res.Error
+		}
+		result.JobSkillsMerged = res.RowsAffected
+
+		res = tx.Exec(`UPDATE job_skills SET skill_id = ?, updated_at = now() WHERE skill_id = ?`, targetID, sourceID)
+		if res.Error != nil {
+			return res.Error
+		}
+		result.JobSkillsMoved = res.RowsAffected
+
+		res = tx.Exec(`UPDATE job_requirements SET skill_id = ? WHERE skill_id = ?`, targetID, sourceID)
+		if res.Error != nil {
+			return res.Error
+		}
+		result.RequirementsMoved = res.RowsAffected
+
+		// User skills only store names: every name of the source becomes the target's name
+		var sourceAliases, targetAliases []string
+		if err := tx.Model(&master.SkillAlias{}).Where("skill_id = ?", sourceID).Pluck("normalized_alias", &sourceAliases).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&master.SkillAlias{}).Where("skill_id = ?", targetID).Pluck("normalized_alias", &targetAliases).Error; err != nil {
+			return err
+		}
+		targetNames := skillNames(target, targetAliases)
+		sourceNames := make([]string, 0)
+		for _, name := range skillNames(source, sourceAliases) {
+			if !slices.Contains(targetNames, name) {
+				sourceNames = append(sourceNames, name)
+			}
+		}
+
+		if len(sourceNames) > 0 {
+			// A user keeps one entry: their target entry, or else their oldest source entry
+			res = tx.Exec(`DELETE FROM user_skills s WHERE `+skillNameSQL("s.skill_name")+` IN ?
+				AND EXISTS (
+					SELECT 1 FROM user_skills o WHERE o.user_id = s.user_id AND (
+						`+skillNameSQL("o.skill_name")+` IN ?
+						OR (`+skillNameSQL("o.skill_name")+` IN ? AND o.id < s.id)
+					)
+				)`, sourceNames, targetNames, sourceNames)
+			if res.Error != nil {
+				return res.Error
+			}
+			result.UserSkillsMerged = res.RowsAffected
+
+			res = tx.Exec(`UPDATE user_skills SET skill_name = ?, updated_at = now() WHERE `+skillNameSQL("skill_name")+` IN ?`,
+				target.Name, sourceNames)
+			if res.Error != nil {
+				return res.Error
+			}
+			result.UserSkillsRenamed = res.RowsAffected
+		}
+
+		// Children of the source move to the target; a target under the source takes the source's place
+		err = tx.Exec(`UPDATE skills_master SET parent_id = CASE WHEN id = ? THEN ? ELSE ? END WHERE parent_id = ?`,
+			targetID, source.ParentID, targetID, sourceID).Error
+		if err != nil {
+			return err
+		}
+
+		// The source's aliases, including those of skills merged into it earlier, move to the
+		// target, and the source's own name is recorded with its ID
+		if err := tx.Model(&master.SkillAlias{}).Where("skill_id = ?", sourceID).Update("skill_id", targetID).Error; err != nil {
+			return err
+		}
+		for _, name := range sourceNames {
+			alias := master.SkillAlias{SkillID: targetID, Alias: name, NormalizedAlias: name}
+			if name == master.NormalizeSkillName(source.Name) {
+				alias.Alias = source.Name
+				alias.MergedSkillID = &source.ID
+			}
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "normalized_alias"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{"skill_id": targetID, "merged_skill_id": alias.MergedSkillID}),
+			}).Create(&alias).Error
+			if err != nil {
+				return err
+			}
+		}
+		result.Aliases = sourceNames
+
+		// The aliases array is what job matching and applicant search compare names with
+		aliases := slices.Clone(target.Aliases)
+		for _, name := range sourceNames {
+			if !slices.Contains(aliases, name) {
+				aliases = append(aliases, name)
+			}
+		}
+		err = tx.Model(&master.SkillsMaster{}).Where("id = ?", targetID).Updates(map[string]interface{}{
+			"aliases":          pq.Array(aliases),
+			"popularity_score": gorm.Expr("GREATEST(popularity_score, ?)", source.PopularityScore),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(&master.SkillsMaster{}, sourceID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// skillNames returns the distinct normalized names of a skill: its name, normalized name,
+// aliases array entries and skill_aliases entries
+func skillNames(skill *master.SkillsMaster, aliases []string) []string {
+	candidates := append([]string{skill.Name, skill.NormalizedName}, skill.Aliases...)
+	candidates = append(candidates, aliases...)
+
+	names := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		if name := master.NormalizeSkillName(candidate); name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // GetRootSkills retrieves all root skills (without parent)
 func (r *skillsMasterRepository) GetRootSkills(ctx context.Context) ([]master.SkillsMaster, error) {
 	var skills []master.SkillsMaster
@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"keerja-backend/internal/domain/talentpool"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// talentPoolRepository implements the talentpool.TalentPoolRepository interface
+type talentPoolRepository struct {
+	db *gorm.DB
+}
+
+// NewTalentPoolRepository creates a new talent pool repository instance
+func NewTalentPoolRepository(db *gorm.DB) talentpool.TalentPoolRepository {
+	return &talentPoolRepository{db: db}
+}
+
+// optedInSQL keeps talent_pool_members m rows of users who have not opted out of talent pools
+// nor made their profile private, mirroring user.UserPreference.IsDiscoverable
+const optedInSQL = `NOT EXISTS (
+	SELECT 1 FROM user_preferences up
+	WHERE up.user_id = m.user_id AND (up.allow_talent_pools = false OR up.profile_visibility = 'private')
+)`
+
+// CreatePool inserts a new pool
+func (r *talentPoolRepository) CreatePool(ctx context.Context, p *talentpool.TalentPool) error {
+	err := r.db.WithContext(ctx).Create(p).Error
+	if isUniqueViolation(err, "idx_company_talent_pools_company_name") {
+		return talentpool.ErrPoolNameExists
+	}
+	return err
+}
+
+// FindPoolByID finds a pool by ID, returning nil when it does not exist
+func (r *talentPoolRepository) FindPoolByID(ctx context.Context, id int64) (*talentpool.TalentPool, error) {
+	var p talentpool.TalentPool
+	err := r.db.WithContext(ctx).First(&p, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// ListPoolsByCompany lists a company's pools by name with their member counts
+func (r *talentPoolRepository) ListPoolsByCompany(ctx context.Context, companyID int64) ([]talentpool.TalentPool, error) {
+	var pools []talentpool.TalentPool
+	err := r.db.WithContext(ctx).
+		Table("company_talent_pools p").
+		Select(`p.*, (
+			SELECT count(*) FROM talent_pool_members m WHERE m.pool_id = p.id AND `+optedInSQL+`
+		) AS member_count`).
+		Where("p.company_id = ?", companyID).
+		Order("lower(p.name), p.id").
+		Scan(&pools).Error
+	return pools, err
+}
+
+// DeletePool deletes a pool; its members go with it through the foreign key
+func (r *talentPoolRepository) DeletePool(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Delete(&talentpool.TalentPool{}, id).Error
+}
+
+// AddMember inserts m unless its user is already in the pool, then loads the stored member
+func (r *talentPoolRepository) AddMember(ctx context.Context, m *talentpool.TalentPoolMember) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(m)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Where("pool_id = ? AND user_id = ?", m.PoolID, m.UserID).
+		First(m).Error
+	return false, err
+}
+
+// FindMemberByID finds a member by ID, returning nil when it does not exist
+func (r *talentPoolRepository) FindMemberByID(ctx context.Context, id int64) (*talentpool.TalentPoolMember, error) {
+	var m talentpool.TalentPoolMember
+	err := r.db.WithContext(ctx).First(&m, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+// memberQuery selects the discoverable members of the company's pools with their candidate details
+func (r *talentPoolRepository) memberQuery(ctx context.Context, companyID int64) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Table("talent_pool_members m").
+		Joins("JOIN company_talent_pools p ON p.id = m.pool_id").
+		Joins("JOIN users u ON u.id = m.user_id").
+		Joins("LEFT JOIN user_profiles prof ON prof.user_id = m.user_id").
+		Where("p.company_id = ?", companyID).
+		Where(optedInSQL)
+}
+
+// memberColumns are the columns of a listed member
+const memberColumns = "m.*, u.full_name AS candidate_name, prof.headline AS headline"
+
+// ListMembers lists the members matching filter, newest first
+func (r *talentPoolRepository) ListMembers(ctx context.Context, filter talentpool.MemberFilter, page, limit int) ([]talentpool.TalentPoolMember, int64, error) {
+	query := r.memberQuery(ctx, filter.CompanyID)
+	if filter.PoolID != 0 {
+		query = query.Where("m.pool_id = ?", filter.PoolID)
+	}
+	if len(filter.Tags) > 0 {
+		query = query.Where("m.tags @> ?", pq.Array(filter.Tags))
+	}
+	if len(filter.Skills) > 0 {
+		// Every skill must be on the candidate's profile
+		query = query.Where(`(
+			SELECT count(DISTINCT `+skillNameSQL("us.skill_name")+`) FROM user_skills us
+			WHERE us.user_id = m.user_id AND `+skillNameSQL("us.skill_name")+` IN ?
+		) = ?`, filter.Skills, len(filter.Skills))
+	}
+	if filter.Query != "" {
+		// The query is matched literally, so LIKE wildcards in it are escaped
+		pattern := "%" + strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(filter.Query) + "%"
+		query = query.Where("(u.full_name ILIKE ? OR m.notes ILIKE ?)", pattern, pattern)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var members []talentpool.TalentPoolMember
+	err := query.
+		Select(memberColumns).
+		Order("m.created_at DESC, m.id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Scan(&members).Error
+	return members, total, err
+}
+
+// ListMatchCandidates lists pool members once per candidate, most recently added first
+func (r *talentPoolRepository) ListMatchCandidates(ctx context.Context, companyID, poolID, jobID int64, limit int) ([]talentpool.TalentPoolMember, error) {
+	query := r.memberQuery(ctx, companyID).
+		Where("NOT EXISTS (SELECT 1 FROM job_applications ja WHERE ja.job_id = ? AND ja.user_id = m.user_id)", jobID)
+	if poolID != 0 {
+		query = query.Where("m.pool_id = ?", poolID)
+	}
+
+	// A candidate in several pools is listed with their latest membership
+	latest := query.
+		Select("DISTINCT ON (m.user_id) " + memberColumns).
+		Order("m.user_id, m.created_at DESC")
+
+	var members []talentpool.TalentPoolMember
+	err := r.db.WithContext(ctx).
+		Table("(?) AS c", latest).
+		Order("c.created_at DESC, c.id DESC").
+		Limit(limit).
+		Scan(&members).Error
+	return members, err
+}
+
+// RemoveMember removes a member from its pool
+func (r *talentPoolRepository) RemoveMember(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Delete(&talentpool.TalentPoolMember{}, id).Error
+}
@@ -0,0 +1,338 @@
+package testutil
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/master"
+	"keerja-backend/internal/domain/user"
+)
+
+var sequence atomic.Int64
+
+// unique returns a suffix that keeps emails and slugs apart across tests and test runs
+func unique() string {
+	return fmt.Sprintf("%d-%d", time.Now().UnixNano(), sequence.Add(1))
+}
+
+// create inserts a fixture row without its associations
+func create(t testing.TB, db *gorm.DB, value any) {
+	t.Helper()
+	require.NoError(t, db.Omit(clause.Associations).Create(value).Error, "failed to create %T fixture", value)
+}
+
+// setFalse stores false in boolean columns whose database default is true, which gorm
+// leaves out of inserts
+func setFalse(t testing.TB, db *gorm.DB, model any, columns ...string) {
+	t.Helper()
+
+	values := make(map[string]any, len(columns))
+	for _, column := range columns {
+		values[column] = false
+	}
+	require.NoError(t, db.Unscoped().Model(model).UpdateColumns(values).Error, "failed to update %T fixture", model)
+}
+
+// UserOption customizes a fixture user
+type UserOption func(*user.User)
+
+// WithEmail sets the user's email
+func WithEmail(email string) UserOption {
+	return func(u *user.User) { u.Email = email }
+}
+
+// WithUserType sets the user type: jobseeker, employer or admin
+func WithUserType(userType string) UserOption {
+	return func(u *user.User) { u.UserType = userType }
+}
+
+// WithUserStatus sets the account status
+func WithUserStatus(status string) UserOption {
+	return func(u *user.User) { u.Status = status }
+}
+
+// WithUserCreatedAt sets when the user registered
+func WithUserCreatedAt(createdAt time.Time) UserOption {
+	return func(u *user.User) { u.CreatedAt = createdAt }
+}
+
+// WithUnverifiedEmail leaves the user's email unverified
+func WithUnverifiedEmail() UserOption {
+	return func(u *user.User) { u.ClearEmailVerification() }
+}
+
+// CreateUser inserts an active jobseeker with a unique, verified email
+func CreateUser(t testing.TB, db *gorm.DB, opts ...UserOption) *user.User {
+	t.Helper()
+
+	u := &user.User{
+		FullName:     "Test User",
+		Email:        fmt.Sprintf("user-%s@example.com", unique()),
+		PasswordHash: "not-a-password-hash",
+		UserType:     "jobseeker",
+		Status:       "active",
+	}
+	u.MarkEmailVerified(time.Now())
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	create(t, db, u)
+	return u
+}
+
+// CompanyOption customizes a fixture company
+type CompanyOption func(*company.Company)
+
+// WithCompanyName sets the company name; the slug stays unique
+func WithCompanyName(name string) CompanyOption {
+	return func(c *company.Company) { c.CompanyName = name }
+}
+
+// WithCompanyCity sets the legacy city column
+func WithCompanyCity(city string) CompanyOption {
+	return func(c *company.Company) { c.City = &city }
+}
+
+// WithCompanyIndustry sets the legacy industry column
+func WithCompanyIndustry(industry string) CompanyOption {
+	return func(c *company.Company) { c.Industry = &industry }
+}
+
+// WithCompanyType sets the company type: private, public, startup, ngo or government
+func WithCompanyType(companyType string) CompanyOption {
+	return func(c *company.Company) { c.CompanyType = &companyType }
+}
+
+// WithCompanyEmailDomain sets the email domain of the company's staff
+func WithCompanyEmailDomain(domain string) CompanyOption {
+	return func(c *company.Company) { c.EmailDomain = &domain }
+}
+
+// WithCompanyCreatedAt sets when the company was created
+func WithCompanyCreatedAt(createdAt time.Time) CompanyOption {
+	return func(c *company.Company) { c.CreatedAt = createdAt }
+}
+
+// WithCompanyVerified marks the company verified
+func WithCompanyVerified() CompanyOption {
+	return func(c *company.Company) {
+		now := time.Now()
+		c.Verified = true
+		c.VerifiedAt = &now
+	}
+}
+
+// WithCompanyInactive deactivates the company
+func WithCompanyInactive() CompanyOption {
+	return func(c *company.Company) { c.IsActive = false }
+}
+
+// WithCompanyDeleted soft deletes the company
+func WithCompanyDeleted() CompanyOption {
+	return func(c *company.Company) { c.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true} }
+}
+
+// CreateCompany inserts an active, unverified company with a unique slug
+func CreateCompany(t testing.TB, db *gorm.DB, opts ...CompanyOption) *company.Company {
+	t.Helper()
+
+	suffix := unique()
+	c := &company.Company{
+		CompanyName: "Test Company " + suffix,
+		Slug:        "test-company-" + suffix,
+		IsActive:    true,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	active := c.IsActive
+	create(t, db, c)
+	if !active {
+		setFalse(t, db, c, "is_active")
+		c.IsActive = false
+	}
+	return c
+}
+
+// EmployerUserOption customizes a fixture company membership
+type EmployerUserOption func(*company.EmployerUser)
+
+// WithRole sets the member's role: owner, admin, recruiter or viewer
+func WithRole(role string) EmployerUserOption {
+	return func(eu *company.EmployerUser) { eu.Role = role }
+}
+
+// WithEmployerUserInactive deactivates the membership
+func WithEmployerUserInactive() EmployerUserOption {
+	return func(eu *company.EmployerUser) { eu.IsActive = false }
+}
+
+// WithJoinRequested makes the membership an inactive domain join request awaiting approval
+func WithJoinRequested(at time.Time) EmployerUserOption {
+	return func(eu *company.EmployerUser) {
+		eu.IsActive = false
+		eu.JoinRequestedAt = &at
+	}
+}
+
+// CreateEmployerUser inserts an active recruiter membership of the user in the company
+func CreateEmployerUser(t testing.TB, db *gorm.DB, userID, companyID int64, opts ...EmployerUserOption) *company.EmployerUser {
+	t.Helper()
+
+	eu := &company.EmployerUser{
+		UserID:    userID,
+		CompanyID: companyID,
+		Role:      employer.RoleRecruiter,
+		IsActive:  true,
+	}
+	for _, opt := range opts {
+		opt(eu)
+	}
+
+	active := eu.IsActive
+	create(t, db, eu)
+	if !active {
+		setFalse(t, db, eu, "is_active")
+		eu.IsActive = false
+	}
+	return eu
+}
+
+// JobOption customizes a fixture job
+type JobOption func(*job.Job)
+
+// WithJobTitle sets the job title
+func WithJobTitle(title string) JobOption {
+	return func(j *job.Job) { j.Title = title }
+}
+
+// WithJobDescription sets the job description, which full-text search matches along with the title
+func WithJobDescription(description string) JobOption {
+	return func(j *job.Job) { j.Description = description }
+}
+
+// WithJobStatus sets the job status. Jobs that are not published have no publish date.
+func WithJobStatus(status string) JobOption {
+	return func(j *job.Job) {
+		j.Status = status
+		if status != job.StatusPublished {
+			j.PublishedAt = nil
+		}
+	}
+}
+
+// WithJobCity sets the job's city
+func WithJobCity(city string) JobOption {
+	return func(j *job.Job) { j.City = city }
+}
+
+// WithJobPublishedAt sets when the job was published
+func WithJobPublishedAt(publishedAt time.Time) JobOption {
+	return func(j *job.Job) { j.PublishedAt = &publishedAt }
+}
+
+// WithJobExpiredAt sets when the job expires
+func WithJobExpiredAt(expiredAt time.Time) JobOption {
+	return func(j *job.Job) { j.ExpiredAt = &expiredAt }
+}
+
+// WithJobEmployerUser sets the membership that posted the job
+func WithJobEmployerUser(employerUserID int64) JobOption {
+	return func(j *job.Job) { j.EmployerUserID = &employerUserID }
+}
+
+// WithJobDeleted soft deletes the job
+func WithJobDeleted() JobOption {
+	return func(j *job.Job) { j.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true} }
+}
+
+// CreateJob inserts a job of the company published now that expires in 30 days
+func CreateJob(t testing.TB, db *gorm.DB, companyID int64, opts ...JobOption) *job.Job {
+	t.Helper()
+
+	now := time.Now()
+	expiredAt := now.AddDate(0, 0, 30)
+	j := &job.Job{
+		CompanyID:   companyID,
+		Title:       "Software Engineer",
+		Slug:        "test-job-" + unique(),
+		Description: "Build and maintain backend services.",
+		Status:      job.StatusPublished,
+		PublishedAt: &now,
+		ExpiredAt:   &expiredAt,
+	}
+	for _, opt := range opts {
+		opt(j)
+	}
+
+	create(t, db, j)
+	return j
+}
+
+// ApplicationOption customizes a fixture job application
+type ApplicationOption func(*application.JobApplication)
+
+// WithApplicationStatus sets the application status
+func WithApplicationStatus(status string) ApplicationOption {
+	return func(a *application.JobApplication) { a.Status = status }
+}
+
+// WithAppliedAt sets when the application was submitted
+func WithAppliedAt(appliedAt time.Time) ApplicationOption {
+	return func(a *application.JobApplication) { a.AppliedAt = appliedAt }
+}
+
+// WithMatchScore sets the application's match score
+func WithMatchScore(score float64) ApplicationOption {
+	return func(a *application.JobApplication) { a.MatchScore = score }
+}
+
+// CreateApplication inserts an application of the user to the job submitted now
+func CreateApplication(t testing.TB, db *gorm.DB, j *job.Job, userID int64, opts ...ApplicationOption) *application.JobApplication {
+	t.Helper()
+
+	companyID := j.CompanyID
+	a := &application.JobApplication{
+		JobID:     j.ID,
+		UserID:    userID,
+		CompanyID: &companyID,
+		AppliedAt: time.Now(),
+		Status:    application.StatusApplied,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	create(t, db, a)
+	return a
+}
+
+// CreateSkill inserts an active skill of the taxonomy with a unique code. Names must be unique,
+// so tests add a suffix of their own.
+func CreateSkill(t testing.TB, db *gorm.DB, name string, aliases ...string) *master.SkillsMaster {
+	t.Helper()
+
+	skill := &master.SkillsMaster{
+		Code:           "skill-" + unique(),
+		Name:           name,
+		NormalizedName: strings.ToLower(name),
+		Aliases:        aliases,
+		IsActive:       true,
+	}
+
+	create(t, db, skill)
+	return skill
+}
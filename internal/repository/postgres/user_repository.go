@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"keerja-backend/internal/domain/user"
 
@@ -45,6 +46,16 @@ func (r *userRepository) FindByID(ctx context.Context, id int64) (*user.User, er
 	return &u, nil
 }
 
+// FindByIDs finds users by IDs without preloading relations
+func (r *userRepository) FindByIDs(ctx context.Context, ids []int64) ([]user.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var users []user.User
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&users).Error
+	return users, err
+}
+
 // FindByUUID finds a user by UUID
 func (r *userRepository) FindByUUID(ctx context.Context, uuid string) (*user.User, error) {
 	var u user.User
@@ -84,6 +95,71 @@ func (r *userRepository) Update(ctx context.Context, u *user.User) error {
 	return r.db.WithContext(ctx).Save(u).Error
 }
 
+// ChangeEmail switches a user's email and records the previous one in user_email_changes
+func (r *userRepository) ChangeEmail(ctx context.Context, userID int64, oldEmail, newEmail string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Matching the old email too keeps a concurrent change from being overwritten. The new
+		// address was confirmed with a code sent to it, so it counts as verified.
+		result := tx.Model(&user.User{}).
+			Where("id = ? AND email = ?", userID, oldEmail).
+			Updates(map[string]interface{}{
+				"email":             newEmail,
+				"is_verified":       true,
+				"email_verified_at": gorm.Expr("COALESCE(email_verified_at, NOW())"),
+				"updated_at":        gorm.Expr("NOW()"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Create(&user.UserEmailChange{UserID: userID, OldEmail: oldEmail, NewEmail: newEmail}).Error
+	})
+	if isUniqueViolation(err, "users_email_key") {
+		return user.ErrEmailTaken
+	}
+	return err
+}
+
+// FindByVerifiedPhone finds the user who verified phone, returning nil when none did
+func (r *userRepository) FindByVerifiedPhone(ctx context.Context, phone string) (*user.User, error) {
+	var u user.User
+	err := r.db.WithContext(ctx).
+		Where("phone = ? AND phone_verified_at IS NOT NULL", phone).
+		First(&u).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &u, nil
+}
+
+// MarkPhoneVerified records the verification of the user's phone. Matching the phone keeps a
+// number changed since the code was sent from being marked verified.
+func (r *userRepository) MarkPhoneVerified(ctx context.Context, userID int64, phone string, verifiedAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&user.User{}).
+		Where("id = ? AND phone = ?", userID, phone).
+		Updates(map[string]interface{}{
+			"phone_verified_at": verifiedAt,
+			"updated_at":        gorm.Expr("NOW()"),
+		})
+	if isUniqueViolation(result.Error, "idx_users_phone_verified") {
+		return user.ErrPhoneTaken
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
 // Delete soft deletes a user
 func (r *userRepository) Delete(ctx context.Context, id int64) error {
 	return r.db.WithContext(ctx).Delete(&user.User{}, id).Error
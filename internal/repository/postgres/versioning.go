@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"keerja-backend/internal/apperror"
+)
+
+// bumpVersion increments a row's optimistic locking version in an update
+var bumpVersion = gorm.Expr("version + 1")
+
+// updateVersioned runs update on model, a loaded row whose version field is version, only
+// while the stored row still has that version, and increments the version on both. When
+// another edit got there first nothing is written and conflict is returned carrying the
+// row's current version.
+func updateVersioned(ctx context.Context, db *gorm.DB, model interface{}, version *int64, conflict *apperror.Error, update func(query *gorm.DB) *gorm.DB) error {
+	loaded := *version
+	*version = loaded + 1
+
+	result := update(db.WithContext(ctx).Model(model).Where("version = ?", loaded))
+	if result.Error == nil && result.RowsAffected > 0 {
+		return nil
+	}
+	*version = loaded
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// The model's primary key restricts the lookup to the row that was to be updated
+	var current int64
+	lookup := db.WithContext(ctx).Model(model).Select("version").Scan(&current)
+	if lookup.Error != nil {
+		return conflict.Wrap(lookup.Error)
+	}
+	if lookup.RowsAffected == 0 {
+		return conflict
+	}
+	return conflict.WithCurrentVersion(current)
+}
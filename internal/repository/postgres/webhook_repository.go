@@ -0,0 +1,268 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"keerja-backend/internal/domain/webhook"
+
+	"gorm.io/gorm"
+)
+
+// webhookRepository implements the webhook.WebhookRepository interface
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new company webhook repository instance
+func NewWebhookRepository(db *gorm.DB) webhook.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+// Create inserts a new webhook
+func (r *webhookRepository) Create(ctx context.Context, w *webhook.CompanyWebhook) error {
+	return r.db.WithContext(ctx).Create(w).Error
+}
+
+// FindByID finds a webhook by ID, returning nil when it does not exist
+func (r *webhookRepository) FindByID(ctx context.Context, id int64) (*webhook.CompanyWebhook, error) {
+	var w webhook.CompanyWebhook
+	err := r.db.WithContext(ctx).First(&w, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &w, nil
+}
+
+// ListByCompany lists a company's webhooks, newest first
+func (r *webhookRepository) ListByCompany(ctx context.Context, companyID int64) ([]webhook.CompanyWebhook, error) {
+	var webhooks []webhook.CompanyWebhook
+	err := r.db.WithContext(ctx).
+		Where("company_id = ?", companyID).
+		Order("created_at DESC, id DESC").
+		Find(&webhooks).Error
+	return webhooks, err
+}
+
+// CountByCompany counts a company's webhooks
+func (r *webhookRepository) CountByCompany(ctx context.Context, companyID int64) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&webhook.CompanyWebhook{}).Where("company_id = ?", companyID).Count(&count).Error
+	return count, err
+}
+
+// Update saves a webhook's URL, events, active flag and failure state
+func (r *webhookRepository) Update(ctx context.Context, w *webhook.CompanyWebhook) error {
+	return r.db.WithContext(ctx).
+		Model(&webhook.CompanyWebhook{}).
+		Where("id = ?", w.ID).
+		Updates(map[string]interface{}{
+			"url":           w.URL,
+			"events":        w.Events,
+			"active":        w.Active,
+			"failure_count": w.FailureCount,
+			"failing_since": w.FailingSince,
+			"disabled_at":   w.DisabledAt,
+			"updated_at":    time.Now(),
+		}).Error
+}
+
+// Delete deletes a webhook; its deliveries go with it through the foreign key
+func (r *webhookRepository) Delete(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Delete(&webhook.CompanyWebhook{}, id).Error
+}
+
+// EnqueueEvent queues the payload for the company's active webhooks subscribed to event
+func (r *webhookRepository) EnqueueEvent(ctx context.Context, companyID int64, event, eventID, payload string, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Exec(`
+		INSERT INTO webhook_deliveries (webhook_id, company_id, event_id, event, payload, status, next_attempt_at, created_at, updated_at)
+		SELECT id, company_id, ?, ?, ?::jsonb, ?, ?, ?, ?
+		FROM company_webhooks
+		WHERE company_id = ? AND active AND ? = ANY(events)`,
+		eventID, event, payload, webhook.DeliveryStatusPending, now, now, now,
+		companyID, event,
+	)
+	return result.RowsAffected, result.Error
+}
+
+// CreateDelivery inserts a delivery
+func (r *webhookRepository) CreateDelivery(ctx context.Context, d *webhook.WebhookDelivery) error {
+	return r.db.WithContext(ctx).Create(d).Error
+}
+
+// ClaimDue counts an attempt on due pending deliveries of active webhooks and leases them
+// in one statement
+func (r *webhookRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]webhook.WebhookDelivery, error) {
+	var deliveries []webhook.WebhookDelivery
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE webhook_deliveries
+		SET attempts = attempts + 1, next_attempt_at = ?, updated_at = ?
+		WHERE id IN (
+			SELECT d.id FROM webhook_deliveries d
+			JOIN company_webhooks w ON w.id = d.webhook_id
+			WHERE d.status = ? AND d.next_attempt_at <= ? AND w.active
+			ORDER BY d.next_attempt_at ASC, d.id ASC
+			LIMIT ?
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		RETURNING *`,
+		leaseUntil, now,
+		webhook.DeliveryStatusPending, now, limit,
+	).Scan(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// MarkDelivered marks a delivery delivered
+func (r *webhookRepository) MarkDelivered(ctx context.Context, id int64, responseCode int, deliveredAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&webhook.WebhookDelivery{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":        webhook.DeliveryStatusDelivered,
+			"response_code": responseCode,
+			"delivered_at":  deliveredAt,
+			"last_error":    nil,
+			"updated_at":    time.Now(),
+		}).Error
+}
+
+// MarkRetry records a failed attempt and when to try again
+func (r *webhookRepository) MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, responseCode *int, lastError string) error {
+	return r.db.WithContext(ctx).
+		Model(&webhook.WebhookDelivery{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"next_attempt_at": nextAttemptAt,
+			"response_code":   responseCode,
+			"last_error":      lastError,
+			"updated_at":      time.Now(),
+		}).Error
+}
+
+// MarkFailed records a failed attempt after which the delivery is no longer retried
+func (r *webhookRepository) MarkFailed(ctx context.Context, id int64, responseCode *int, lastError string) error {
+	return r.db.WithContext(ctx).
+		Model(&webhook.WebhookDelivery{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":        webhook.DeliveryStatusFailed,
+			"response_code": responseCode,
+			"last_error":    lastError,
+			"updated_at":    time.Now(),
+		}).Error
+}
+
+// RecordSuccess clears a webhook's failure count
+func (r *webhookRepository) RecordSuccess(ctx context.Context, webhookID int64) error {
+	return r.db.WithContext(ctx).
+		Model(&webhook.CompanyWebhook{}).
+		Where("id = ? AND failure_count > 0", webhookID).
+		Updates(map[string]interface{}{
+			"failure_count": 0,
+			"failing_since": nil,
+			"updated_at":    time.Now(),
+		}).Error
+}
+
+// RecordFailure counts a failed attempt in one statement, so concurrent workers do not lose counts
+func (r *webhookRepository) RecordFailure(ctx context.Context, webhookID int64, now time.Time) (*webhook.CompanyWebhook, error) {
+	var webhooks []webhook.CompanyWebhook
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE company_webhooks
+		SET failure_count = failure_count + 1, failing_since = COALESCE(failing_since, ?), updated_at = ?
+		WHERE id = ?
+		RETURNING *`,
+		now, now, webhookID,
+	).Scan(&webhooks).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(webhooks) == 0 {
+		return nil, nil
+	}
+	return &webhooks[0], nil
+}
+
+// Disable deactivates an active webhook and fails its pending deliveries in one transaction
+func (r *webhookRepository) Disable(ctx context.Context, webhookID int64, now time.Time) (bool, error) {
+	disabled := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&webhook.CompanyWebhook{}).
+			Where("id = ? AND active", webhookID).
+			Updates(map[string]interface{}{
+				"active":      false,
+				"disabled_at": now,
+				"updated_at":  now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		disabled = true
+
+		return tx.Model(&webhook.WebhookDelivery{}).
+			Where("webhook_id = ? AND status = ?", webhookID, webhook.DeliveryStatusPending).
+			Updates(map[string]interface{}{
+				"status":     webhook.DeliveryStatusFailed,
+				"last_error": "webhook disabled after repeated failures",
+				"updated_at": now,
+			}).Error
+	})
+	return disabled, err
+}
+
+// FindDeliveryByID finds a delivery by ID, returning nil when it does not exist
+func (r *webhookRepository) FindDeliveryByID(ctx context.Context, id int64) (*webhook.WebhookDelivery, error) {
+	var d webhook.WebhookDelivery
+	err := r.db.WithContext(ctx).First(&d, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+// ListDeliveries lists deliveries matching filter, newest first
+func (r *webhookRepository) ListDeliveries(ctx context.Context, filter webhook.DeliveryFilter, page, limit int) ([]webhook.WebhookDelivery, int64, error) {
+	var deliveries []webhook.WebhookDelivery
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&webhook.WebhookDelivery{})
+	if filter.CompanyID != 0 {
+		query = query.Where("company_id = ?", filter.CompanyID)
+	}
+	if filter.WebhookID != 0 {
+		query = query.Where("webhook_id = ?", filter.WebhookID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Event != "" {
+		query = query.Where("event = ?", filter.Event)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, total, nil
+}
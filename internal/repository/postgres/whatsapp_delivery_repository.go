@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+
+	"keerja-backend/internal/domain/notification"
+
+	"gorm.io/gorm"
+)
+
+// whatsAppDeliveryRepository implements the notification.WhatsAppDeliveryRepository interface
+type whatsAppDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewWhatsAppDeliveryRepository creates a new WhatsApp delivery repository instance
+func NewWhatsAppDeliveryRepository(db *gorm.DB) notification.WhatsAppDeliveryRepository {
+	return &whatsAppDeliveryRepository{db: db}
+}
+
+// Create stores a delivery
+func (r *whatsAppDeliveryRepository) Create(ctx context.Context, delivery *notification.WhatsAppDelivery) error {
+	return r.db.WithContext(ctx).Create(delivery).Error
+}
+
+// List lists deliveries matching filter, newest first
+func (r *whatsAppDeliveryRepository) List(ctx context.Context, filter notification.WhatsAppDeliveryFilter, page, limit int) ([]notification.WhatsAppDelivery, int64, error) {
+	var deliveries []notification.WhatsAppDelivery
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&notification.WhatsAppDelivery{})
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, total, nil
+}
@@ -1,6 +1,7 @@
 package routes
 
 import (
+	admindomain "keerja-backend/internal/domain/admin"
 	"keerja-backend/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
@@ -8,12 +9,32 @@ import (
 
 // SetupAdminRoutes configures admin routes
 // Routes: /api/v1/admin/*
+//
+// Every route needs an admin session; routes that change data or expose analytics also
+// need a permission of the admin's role (see admindomain.AllPermissions).
 func SetupAdminRoutes(api fiber.Router, deps *Dependencies, adminAuthMw *middleware.AdminAuthMiddleware) {
 	admin := api.Group("/admin")
 
 	// All admin routes require admin authentication
 	admin.Use(adminAuthMw.AdminAuthRequired())
 
+	verifyCompanies := adminAuthMw.RequirePermission(admindomain.PermCompaniesVerify)
+	moderateReviews := adminAuthMw.RequirePermission(admindomain.PermReviewsModerate)
+	reviewJobs := adminAuthMw.RequirePermission(admindomain.PermJobsReview)
+	featureJobs := adminAuthMw.RequirePermission(admindomain.PermJobsFeature)
+	readAnalytics := adminAuthMw.RequirePermission(admindomain.PermAnalyticsRead)
+	manageAdmins := adminAuthMw.RequirePermission(admindomain.PermAdminsManage)
+	impersonateUsers := adminAuthMw.RequirePermission(admindomain.PermUsersImpersonate)
+
+	// Admin roles and permissions (permission: admins.manage)
+	admin.Get("/roles", manageAdmins, deps.AdminRoleHandler.ListRoles)
+	admin.Put("/admins/:id/role", manageAdmins, deps.AdminRoleHandler.AssignRole)
+
+	// User impersonation (permission: users.impersonate)
+	// The token acts as the user, read-only; every request made with it is audited
+	admin.Post("/impersonate/:userId", impersonateUsers, deps.AdminImpersonationHandler.StartImpersonation)
+	admin.Delete("/impersonate/:sessionId", impersonateUsers, deps.AdminImpersonationHandler.EndImpersonation)
+
 	// Dashboard
 	admin.Get("/dashboard", func(c *fiber.Ctx) error {
 		// TODO: Implement GetDashboard handler
@@ -55,21 +76,65 @@ func SetupAdminRoutes(api fiber.Router, deps *Dependencies, adminAuthMw *middlew
 	// Task 2.2: Get company detail for review
 	admin.Get("/companies/:id", deps.AdminCompanyHandler.GetCompanyDetail)
 
-	// Task 2.3: Update company status (approve/reject/suspend)
-	admin.Patch("/companies/:id/status", deps.AdminCompanyHandler.UpdateCompanyStatus)
+	// Task 2.3: Update company status (approve/reject/suspend) (permission: companies.verify)
+	admin.Patch("/companies/:id/status", verifyCompanies, deps.AdminCompanyHandler.UpdateCompanyStatus)
 
-	// Task 2.4: Edit company details (admin support)
-	admin.Put("/companies/:id", deps.AdminCompanyHandler.UpdateCompany)
+	// Task 2.4: Edit company details (admin support) (permission: companies.verify)
+	admin.Put("/companies/:id", verifyCompanies, deps.AdminCompanyHandler.UpdateCompany)
 
-	// Task 2.5: Delete company with validation
-	admin.Delete("/companies/:id", deps.AdminCompanyHandler.DeleteCompany)
+	// Task 2.5: Delete company with validation (permission: companies.verify)
+	admin.Delete("/companies/:id", verifyCompanies, deps.AdminCompanyHandler.DeleteCompany)
+	admin.Post("/companies/:id/restore", verifyCompanies, deps.AdminCompanyHandler.RestoreCompany)
+
+	// Company plan, which sets its published job limit (permission: companies.verify)
+	// Body: { plan: free|verified|premium }; an empty plan follows the verification again
+	admin.Put("/companies/:id/plan", verifyCompanies, deps.AdminJobQuotaHandler.SetCompanyPlan)
 
 	// Additional company endpoints
 	admin.Get("/companies/:id/stats", deps.AdminCompanyHandler.GetCompanyStats)
 	admin.Get("/companies/:id/audit-logs", deps.AdminCompanyHandler.GetAuditLogs)
 
-	// Dashboard stats
-	admin.Get("/dashboard/stats", deps.AdminCompanyHandler.GetDashboardStats)
+	// Dashboard stats (permission: analytics.read)
+	admin.Get("/dashboard/stats", readAnalytics, deps.AdminCompanyHandler.GetDashboardStats)
+	admin.Get("/analytics/summary", readAnalytics, deps.AdminAnalyticsHandler.GetSummary)
+
+	// Company review moderation (permission: reviews.moderate)
+	admin.Get("/reviews", moderateReviews, deps.AdminReviewHandler.GetReviews)
+	admin.Post("/reviews/:id/approve", moderateReviews, deps.AdminReviewHandler.ApproveReview)
+	admin.Post("/reviews/:id/reject", moderateReviews, deps.AdminReviewHandler.RejectReview)
+	admin.Post("/reviews/:id/hide", moderateReviews, deps.AdminReviewHandler.HideReview)
+
+	// Company change requests (industry, size, district) (permission: companies.verify)
+	admin.Get("/company-change-requests", verifyCompanies, deps.AdminChangeRequestHandler.GetChangeRequests)
+	admin.Post("/company-change-requests/:id/approve", verifyCompanies, deps.AdminChangeRequestHandler.ApproveChangeRequest)
+	admin.Post("/company-change-requests/:id/reject", verifyCompanies, deps.AdminChangeRequestHandler.RejectChangeRequest)
+
+	// Audit log (permission: admins.manage)
+	admin.Get("/audit-logs", manageAdmins, deps.AdminAuditHandler.GetAuditLogs)
+
+	// Push campaigns (permission: admins.manage)
+	admin.Post("/push/campaigns", manageAdmins, deps.AdminPushHandler.CreateCampaign)
+	admin.Get("/push/campaigns", manageAdmins, deps.AdminPushHandler.ListCampaigns)
+	admin.Get("/push/campaigns/:id", manageAdmins, deps.AdminPushHandler.GetCampaign)
+
+	// Email queue (permission: admins.manage)
+	admin.Get("/email-queue", manageAdmins, deps.AdminEmailQueueHandler.ListEmails)
+	admin.Post("/email-queue/:id/retry", manageAdmins, deps.AdminEmailQueueHandler.RetryEmail)
+
+	// WhatsApp messages sent to users, for support (permission: admins.manage)
+	admin.Get("/whatsapp-deliveries", manageAdmins, deps.AdminWhatsAppHandler.ListDeliveries)
+
+	// Company webhook deliveries (permission: admins.manage)
+	admin.Get("/webhook-deliveries", manageAdmins, deps.AdminWebhookHandler.ListDeliveries)
+	admin.Post("/webhook-deliveries/:id/redeliver", manageAdmins, deps.AdminWebhookHandler.Redeliver)
+
+	// Published job limits per plan, overriding the configured ones (permission: admins.manage)
+	admin.Get("/job-quotas", manageAdmins, deps.AdminJobQuotaHandler.ListPlanQuotas)
+	admin.Put("/job-quotas/:plan", manageAdmins, deps.AdminJobQuotaHandler.UpdatePlanQuota)
+
+	// Background job schedule (permission: admins.manage)
+	admin.Get("/jobs/schedule", manageAdmins, deps.AdminSchedulerHandler.GetSchedule)
+	admin.Post("/jobs/schedule/:name/run", manageAdmins, deps.AdminSchedulerHandler.RunJob)
 
 	// Job management
 	admin.Get("/jobs", func(c *fiber.Ctx) error {
@@ -79,14 +144,27 @@ func SetupAdminRoutes(api fiber.Router, deps *Dependencies, adminAuthMw *middlew
 		})
 	})
 
+	// Job review queue (permission: jobs.review)
+	admin.Get("/jobs/pending", reviewJobs, deps.AdminJobHandler.GetPendingJobs)
+
+	admin.Post("/jobs/:id/approve", reviewJobs, deps.AdminJobHandler.ApproveJob)
+	admin.Post("/jobs/:id/reject", reviewJobs, deps.AdminJobHandler.RejectJob)
+
+	// PATCH kept for existing clients
 	admin.Patch("/jobs/:id/approve",
+		reviewJobs,
 		deps.AdminJobHandler.ApproveJob,
 	)
 
 	admin.Patch("/jobs/:id/reject",
+		reviewJobs,
 		deps.AdminJobHandler.RejectJob,
 	)
 
+	// Featured jobs curation (permission: jobs.feature)
+	admin.Put("/jobs/:id/feature", featureJobs, deps.AdminJobHandler.FeatureJob)
+	admin.Delete("/jobs/:id/feature", featureJobs, deps.AdminJobHandler.UnfeatureJob)
+
 	admin.Put("/jobs/:id/status", func(c *fiber.Ctx) error {
 		// TODO: Implement UpdateJobStatus handler for other status changes
 		return c.Status(fiber.StatusNotImplemented).JSON(fiber.Map{
@@ -128,57 +206,72 @@ func SetupAdminRoutes(api fiber.Router, deps *Dependencies, adminAuthMw *middlew
 		})
 	})
 
-	// Master Data Management
-	setupAdminMasterDataRoutes(admin, deps)
+	// Master Data Management (permission: master_data.write)
+	setupAdminMasterDataRoutes(admin, deps, adminAuthMw.RequirePermission(admindomain.PermMasterDataWrite))
 }
 
-// setupAdminMasterDataRoutes configures admin master data CRUD routes
-func setupAdminMasterDataRoutes(admin fiber.Router, deps *Dependencies) {
+// setupAdminMasterDataRoutes configures admin master data CRUD routes. Reads stay open to
+// every admin; writes, imports and exports need writeMasterData.
+func setupAdminMasterDataRoutes(admin fiber.Router, deps *Dependencies, writeMasterData fiber.Handler) {
 	// Provinces CRUD
 	provinces := admin.Group("/master/provinces")
-	provinces.Post("/", deps.AdminMasterDataHandler.CreateProvince)
+	provinces.Post("/", writeMasterData, deps.AdminMasterDataHandler.CreateProvince)
 	provinces.Get("/", deps.AdminMasterDataHandler.GetProvinces)
 	provinces.Get("/:id", deps.AdminMasterDataHandler.GetProvinceByID)
-	provinces.Put("/:id", deps.AdminMasterDataHandler.UpdateProvince)
-	provinces.Delete("/:id", deps.AdminMasterDataHandler.DeleteProvince)
+	provinces.Put("/:id", writeMasterData, deps.AdminMasterDataHandler.UpdateProvince)
+	provinces.Delete("/:id", writeMasterData, deps.AdminMasterDataHandler.DeleteProvince)
 
 	// Cities CRUD
 	cities := admin.Group("/master/cities")
-	cities.Post("/", deps.AdminMasterDataHandler.CreateCity)
+	cities.Post("/", writeMasterData, deps.AdminMasterDataHandler.CreateCity)
 	cities.Get("/", deps.AdminMasterDataHandler.GetCities)
 	cities.Get("/:id", deps.AdminMasterDataHandler.GetCityByID)
-	cities.Put("/:id", deps.AdminMasterDataHandler.UpdateCity)
-	cities.Delete("/:id", deps.AdminMasterDataHandler.DeleteCity)
+	cities.Put("/:id", writeMasterData, deps.AdminMasterDataHandler.UpdateCity)
+	cities.Delete("/:id", writeMasterData, deps.AdminMasterDataHandler.DeleteCity)
 
 	// Districts CRUD
 	districts := admin.Group("/master/districts")
-	districts.Post("/", deps.AdminMasterDataHandler.CreateDistrict)
+	districts.Post("/", writeMasterData, deps.AdminMasterDataHandler.CreateDistrict)
 	districts.Get("/", deps.AdminMasterDataHandler.GetDistricts)
 	districts.Get("/:id", deps.AdminMasterDataHandler.GetDistrictByID)
-	districts.Put("/:id", deps.AdminMasterDataHandler.UpdateDistrict)
-	districts.Delete("/:id", deps.AdminMasterDataHandler.DeleteDistrict)
+	districts.Put("/:id", writeMasterData, deps.AdminMasterDataHandler.UpdateDistrict)
+	districts.Delete("/:id", writeMasterData, deps.AdminMasterDataHandler.DeleteDistrict)
+	// Move a district's references to another district (boundary changes) and deactivate it
+	districts.Post("/:id/merge-into/:targetId", writeMasterData, deps.AdminMasterDataHandler.MergeDistrict)
 
 	// Industries CRUD
 	industries := admin.Group("/master/industries")
-	industries.Post("/", deps.AdminMasterDataHandler.CreateIndustry)
+	industries.Post("/", writeMasterData, deps.AdminMasterDataHandler.CreateIndustry)
 	industries.Get("/", deps.AdminMasterDataHandler.GetIndustries)
 	industries.Get("/:id", deps.AdminMasterDataHandler.GetIndustryByID)
-	industries.Put("/:id", deps.AdminMasterDataHandler.UpdateIndustry)
-	industries.Delete("/:id", deps.AdminMasterDataHandler.DeleteIndustry)
+	industries.Put("/:id", writeMasterData, deps.AdminMasterDataHandler.UpdateIndustry)
+	industries.Delete("/:id", writeMasterData, deps.AdminMasterDataHandler.DeleteIndustry)
 
 	// Job Types CRUD
 	jobTypes := admin.Group("/master/job-types")
-	jobTypes.Post("/", deps.AdminMasterDataHandler.CreateJobType)
+	jobTypes.Post("/", writeMasterData, deps.AdminMasterDataHandler.CreateJobType)
 	jobTypes.Get("/", deps.AdminMasterDataHandler.GetJobTypes)
 	jobTypes.Get("/:id", deps.AdminMasterDataHandler.GetJobTypeByID)
-	jobTypes.Put("/:id", deps.AdminMasterDataHandler.UpdateJobType)
-	jobTypes.Delete("/:id", deps.AdminMasterDataHandler.DeleteJobType)
+	jobTypes.Put("/:id", writeMasterData, deps.AdminMasterDataHandler.UpdateJobType)
+	jobTypes.Delete("/:id", writeMasterData, deps.AdminMasterDataHandler.DeleteJobType)
 
 	// Company Sizes CRUD (note: endpoint is /admin/meta/company-sizes as per requirement)
 	companySizes := admin.Group("/meta/company-sizes")
-	companySizes.Post("/", deps.AdminMasterDataHandler.CreateCompanySize)
+	companySizes.Post("/", writeMasterData, deps.AdminMasterDataHandler.CreateCompanySize)
 	companySizes.Get("/", deps.AdminMasterDataHandler.GetCompanySizes)
 	companySizes.Get("/:id", deps.AdminMasterDataHandler.GetCompanySizeByID)
-	companySizes.Put("/:id", deps.AdminMasterDataHandler.UpdateCompanySize)
-	companySizes.Delete("/:id", deps.AdminMasterDataHandler.DeleteCompanySize)
+	companySizes.Put("/:id", writeMasterData, deps.AdminMasterDataHandler.UpdateCompanySize)
+	companySizes.Delete("/:id", writeMasterData, deps.AdminMasterDataHandler.DeleteCompanySize)
+
+	masterData := admin.Group("/master-data")
+
+	// Records referencing a district, checked before deactivating, merging or deleting it
+	masterData.Get("/districts/:id/usage", deps.AdminMasterDataHandler.GetDistrictUsage)
+
+	// Bulk CSV export/import, :type is one of master.MasterDataTransferTypes
+	masterData.Get("/:type/export", writeMasterData, deps.AdminMasterDataHandler.ExportMasterData)
+	masterData.Post("/:type/import", writeMasterData, deps.AdminMasterDataHandler.ImportMasterData)
+
+	// Skill taxonomy: merge a duplicate skill into the one that replaces it
+	admin.Post("/skills/:id/merge-into/:targetId", writeMasterData, deps.AdminMasterDataHandler.MergeSkill)
 }
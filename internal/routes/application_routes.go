@@ -18,7 +18,10 @@ import (
 //   - POST   /:id/documents            Upload application document
 //   - POST   /:id/rate                 Rate application experience
 //
-// Employer Endpoints (14):
+// Shared Endpoints (1):
+//   - GET    /:id/documents/:docId/download  Signed document download link
+//
+// Employer Endpoints (15):
 //   - GET    /job/:job_id              List applications for job
 //   - POST   /search                   Search applications
 //   - PATCH  /:id/status               Update application status
@@ -31,11 +34,12 @@ import (
 //   - PATCH  /:id/interviews/:int_id/reschedule  Reschedule interview
 //   - PATCH  /:id/interviews/:int_id/complete    Complete interview
 //   - DELETE /:id/interviews/:int_id   Cancel interview
+//   - POST   /:id/interview-booking-links  Send interview booking link
 //   - PATCH  /:id/bookmark             Toggle bookmark
 //   - PATCH  /:id/viewed               Mark as viewed
 //
-// Total: 21 endpoints
-func SetupApplicationRoutes(api fiber.Router, deps *Dependencies, authMw *middleware.AuthMiddleware) {
+// Total: 23 endpoints
+func SetupApplicationRoutes(api fiber.Router, deps *Dependencies, authMw *middleware.AuthMiddleware, permMw *middleware.PermissionMiddleware) {
 	// ============================================
 	// CANDIDATE ROUTES (7 endpoints)
 	// All require authentication
@@ -49,6 +53,7 @@ func SetupApplicationRoutes(api fiber.Router, deps *Dependencies, authMw *middle
 	applications.Post("/",
 		authMw.JobSeekerOnly(),
 		middleware.ApplicationRateLimiter(),
+		middleware.Idempotency(deps.IdempotencyStore, middleware.DefaultIdempotencyTTL),
 		deps.ApplicationHandler.Apply,
 	)
 
@@ -59,6 +64,7 @@ func SetupApplicationRoutes(api fiber.Router, deps *Dependencies, authMw *middle
 	applications.Post("/jobs/:job_id/apply",
 		authMw.JobSeekerOnly(),
 		middleware.ApplicationRateLimiter(),
+		middleware.Idempotency(deps.IdempotencyStore, middleware.DefaultIdempotencyTTL),
 		deps.ApplicationHandler.ApplyToJob,
 	)
 
@@ -86,7 +92,7 @@ func SetupApplicationRoutes(api fiber.Router, deps *Dependencies, authMw *middle
 	)
 
 	// POST /api/v1/applications/:id/documents - Upload application document
-	// Body: multipart/form-data with document file
+	// Body: multipart/form-data { file, document_type, file_name, notes }
 	// Rate limit: 100 requests/minute
 	applications.Post("/:id/documents",
 		authMw.JobSeekerOnly(),
@@ -94,6 +100,20 @@ func SetupApplicationRoutes(api fiber.Router, deps *Dependencies, authMw *middle
 		deps.ApplicationHandler.UploadDocument,
 	)
 
+	// GET /api/v1/applications/:id/documents/:docId/download - Get a document download link
+	// Returns: a short-lived signed link; only the applicant and the hiring company's
+	// employers may download application documents
+	applications.Get("/:id/documents/:docId/download",
+		deps.ApplicationHandler.DownloadDocument,
+	)
+
+	// GET /api/v1/applications/:id/resume/download - Get a link to the submitted resume
+	// Returns: a short-lived signed link to the application's copy of the resume; only the
+	// applicant and the hiring company's employers may download it
+	applications.Get("/:id/resume/download",
+		deps.ApplicationHandler.DownloadResume,
+	)
+
 	// POST /api/v1/applications/:id/rate - Rate application experience
 	// Body: { rating, comment }
 	// Candidate can rate their experience after process completion
@@ -103,25 +123,33 @@ func SetupApplicationRoutes(api fiber.Router, deps *Dependencies, authMw *middle
 	)
 
 	// ============================================
-	// EMPLOYER ROUTES (14 endpoints)
-	// All require authentication + employer role
+	// EMPLOYER ROUTES (15 endpoints)
+	// All require authentication + employer role. Routes with RequireActiveCompany act for
+	// the company named by X-Company-ID, or else the caller's active company, and only reach
+	// that company's applications.
 	// ============================================
 	employer := applications.Group("")
 	employer.Use(authMw.EmployerOnly())
 
 	// GET /api/v1/applications/job/:job_id - List applications for specific job
-	// Query params: page, limit, status, stage
+	// Query params: page, limit, status, stage, answer[<question_id>] (screening answer)
 	// Rate limit: 30 requests/minute
 	employer.Get("/job/:job_id",
 		middleware.SearchRateLimiter(),
+		permMw.RequireActiveCompany(),
 		deps.ApplicationHandler.ListByJob,
 	)
 
 	// POST /api/v1/applications/search - Search applications
-	// Body: { query, filters, pagination }
+	// Query params: page, limit
+	// Body: { keyword, job_ids, company_ids, statuses, skill_ids, skill_match (all|any),
+	//         min_degree_level, min_experience_years, city_ids, province_ids, availability_statuses, ... }
+	// Without company_ids only applications to the company named by X-Company-ID, or else to
+	// the caller's active company, are searched; company_ids must be the caller's companies
 	// Rate limit: 30 requests/minute
 	employer.Post("/search",
 		middleware.SearchRateLimiter(),
+		permMw.RequireActiveCompany(),
 		deps.ApplicationHandler.SearchApplications,
 	)
 
@@ -129,21 +157,25 @@ func SetupApplicationRoutes(api fiber.Router, deps *Dependencies, authMw *middle
 	// Body: { status, reason }
 	// Status: pending, reviewing, shortlisted, rejected, accepted
 	employer.Patch("/:id/status",
+		permMw.RequireActiveCompany(),
 		deps.ApplicationHandler.UpdateStatus,
 	)
 
 	// POST /api/v1/applications/bulk-status - Bulk update application status
+	// Runs synchronously; large selections go through POST /api/v1/companies/:id/applications/bulk-actions
 	// Body: { application_ids: [], status, reason }
 	// Rate limit: 30 requests/minute (bulk operation)
 	employer.Post("/bulk-status",
 		middleware.SearchRateLimiter(),
+		permMw.RequireActiveCompany(),
 		deps.ApplicationHandler.BulkUpdateStatus,
 	)
 
-	// PATCH /api/v1/applications/:id/stage - Update hiring stage
-	// Body: { stage }
-	// Stage: applied, screening, interview, offer, hired
+	// PATCH /api/v1/applications/:id/stage - Move to a stage of the job's pipeline
+	// Body: { stage, notes }
+	// Stage: a status (screening, shortlisted, interview, offered, hired, rejected) or a custom stage of the job
 	employer.Patch("/:id/stage",
+		permMw.RequireActiveCompany(),
 		deps.ApplicationHandler.UpdateStage,
 	)
 
@@ -193,15 +225,52 @@ func SetupApplicationRoutes(api fiber.Router, deps *Dependencies, authMw *middle
 		deps.ApplicationHandler.CancelInterview,
 	)
 
+	// POST /api/v1/applications/:id/interview-booking-links - Send interview booking link
+	// Body: { expires_in_hours } (optional, default 72, max 336)
+	// Moves the application to the interview stage and emails the candidate a link to
+	// pick one of the company's open interview slots
+	employer.Post("/:id/interview-booking-links",
+		middleware.ApplicationRateLimiter(),
+		permMw.RequireActiveCompany(),
+		deps.ApplicationHandler.SendInterviewBookingLink,
+	)
+
 	// PATCH /api/v1/applications/:id/bookmark - Toggle bookmark
 	// Body: { bookmarked: true/false }
 	employer.Patch("/:id/bookmark",
+		permMw.RequireActiveCompany(),
 		deps.ApplicationHandler.Bookmark,
 	)
 
 	// PATCH /api/v1/applications/:id/viewed - Mark application as viewed
 	// Body: { viewed: true }
 	employer.Patch("/:id/viewed",
+		permMw.RequireActiveCompany(),
 		deps.ApplicationHandler.MarkViewed,
 	)
 }
+
+// SetupInterviewBookingRoutes configures the public interview booking routes candidates
+// reach from their booking link email. The token authenticates the request.
+// Routes: /api/v1/interview-booking/*
+//
+// Public Endpoints (2):
+//   - GET    /:token                   Get open interview slots for the link
+//   - POST   /:token                   Book an interview slot
+func SetupInterviewBookingRoutes(api fiber.Router, deps *Dependencies) {
+	booking := api.Group("/interview-booking")
+	booking.Use(middleware.APIRateLimiter())
+
+	// GET /api/v1/interview-booking/:token - Get open interview slots
+	// Returns: job title, company name, link expiry and the open slots
+	booking.Get("/:token",
+		deps.ApplicationHandler.GetInterviewBooking,
+	)
+
+	// POST /api/v1/interview-booking/:token - Book an interview slot
+	// Body: { slot_id }
+	// Schedules the interview; each link books one slot
+	booking.Post("/:token",
+		deps.ApplicationHandler.BookInterviewSlot,
+	)
+}
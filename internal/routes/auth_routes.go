@@ -107,6 +107,28 @@ func SetupAuthRoutes(api fiber.Router, deps *Dependencies, authMw *middleware.Au
 		deps.AuthHandler.ExchangeOneTimeCode,
 	)
 
+	// ===========================================
+	// Protected Routes - Account Credentials
+	// ===========================================
+
+	auth.Post("/change-password",
+		authMw.AuthRequired(),
+		middleware.AuthRateLimiter(),
+		deps.AuthHandler.ChangePassword,
+	)
+
+	auth.Post("/change-email",
+		authMw.AuthRequired(),
+		middleware.EmailRateLimiter(),
+		deps.AuthHandler.ChangeEmail,
+	)
+
+	auth.Post("/change-email/verify",
+		authMw.AuthRequired(),
+		middleware.AuthRateLimiter(),
+		deps.AuthHandler.VerifyEmailChange,
+	)
+
 	// ===========================================
 	// Protected Routes - Device Management
 	// ===========================================
@@ -126,6 +148,25 @@ func SetupAuthRoutes(api fiber.Router, deps *Dependencies, authMw *middleware.Au
 		deps.AuthHandler.LogoutAllDevices,
 	)
 
+	// ===========================================
+	// Protected Routes - Session Management
+	// ===========================================
+
+	auth.Get("/sessions",
+		authMw.AuthRequired(),
+		deps.AuthHandler.GetSessions,
+	)
+
+	auth.Delete("/sessions/:id",
+		authMw.AuthRequired(),
+		deps.AuthHandler.RevokeSession,
+	)
+
+	auth.Delete("/sessions",
+		authMw.AuthRequired(),
+		deps.AuthHandler.LogoutAllDevices,
+	)
+
 	// ===========================================
 	// Protected Routes - OAuth Management
 	// ===========================================
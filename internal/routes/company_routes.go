@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"keerja-backend/internal/domain/company"
 	"keerja-backend/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
@@ -10,16 +11,26 @@ import (
 // Routes: /api/v1/companies/*
 //
 // Route Organization:
-// - Basic CRUD: CompanyBasicHandler (7 endpoints)
+// - Basic CRUD & Settings: CompanyBasicHandler (11 endpoints)
 // - Image: CompanyImageHandler (4 endpoints)
 // - Address: CompanyAddressHandler (4 endpoints)
 // - Employer Profile: CompanyEmployerHandler (2 endpoints)
 // - Verification: CompanyVerificationHandler (3 endpoints)
 // - Profile & Social: CompanyProfileHandler (8 endpoints)
-// - Reviews & Ratings: CompanyReviewHandler (5 endpoints)
+// - Reviews & Ratings: CompanyReviewHandler (11 endpoints)
 // - Statistics & Queries: CompanyStatsHandler (3 endpoints)
 // - Invitations: CompanyInviteHandler (5 endpoints)
-// Total: 41 endpoints
+// - Domain Verification & Join Requests: CompanyDomainHandler (6 endpoints)
+// - Employee Roster: CompanyEmployeeHandler (1 endpoint)
+// - Integration API Keys: CompanyAPIKeyHandler (3 endpoints)
+// - Integration Webhooks: CompanyWebhookHandler (6 endpoints)
+// - Candidate Email Templates: CompanyEmailTemplateHandler (6 endpoints)
+// - Talent Pools: CompanyTalentPoolHandler (7 endpoints)
+// - Documents: CompanyDocumentHandler (1 endpoint)
+// - Interview Slots: ApplicationHandler (4 endpoints)
+// - Source Analytics: ApplicationHandler (1 endpoint)
+// - Application Bulk Actions: ApplicationHandler (2 endpoints)
+// Total: 89 endpoints
 func SetupCompanyRoutes(api fiber.Router, deps *Dependencies, authMw *middleware.AuthMiddleware, permMw *middleware.PermissionMiddleware) {
 	companies := api.Group("/companies")
 
@@ -73,6 +84,12 @@ func SetupCompanyRoutes(api fiber.Router, deps *Dependencies, authMw *middleware
 		deps.CompanyBasicHandler.GetCompanyBySlug,
 	)
 
+	// Get the composed public company page (company, latest jobs, review summary, followers)
+	companies.Get("/:slug/public",
+		authMw.OptionalAuth(),
+		deps.CompanyBasicHandler.GetPublicProfile,
+	)
+
 	// Get company verification status
 	companies.Get("/:id/verification-status",
 		deps.CompanyVerificationHandler.GetCompanyVerificationStatus,
@@ -96,8 +113,9 @@ func SetupCompanyRoutes(api fiber.Router, deps *Dependencies, authMw *middleware
 	// PUBLIC ROUTES - Reviews (CompanyReviewHandler)
 	// ==========================================
 
-	// Get company reviews (public)
+	// Get company reviews (public, includes the caller's votes when authenticated)
 	companies.Get("/:id/reviews",
+		authMw.OptionalAuth(),
 		deps.CompanyReviewHandler.GetCompanyReviews,
 	)
 
@@ -137,12 +155,15 @@ func SetupCompanyRoutes(api fiber.Router, deps *Dependencies, authMw *middleware
 	)
 
 	// Update authenticated employer user's company profile (position, department, company contact)
+	// The profile is the one at the company named by X-Company-ID, or else the active company
 	protected.Put("/me/employer",
+		permMw.RequireActiveCompany(),
 		deps.CompanyEmployerHandler.UpdateMyEmployerProfile,
 	)
 
 	// Get authenticated employer user's company profile
 	protected.Get("/me/employer",
+		permMw.RequireActiveCompany(),
 		deps.CompanyEmployerHandler.GetMyEmployerProfile,
 	)
 
@@ -162,6 +183,7 @@ func SetupCompanyRoutes(api fiber.Router, deps *Dependencies, authMw *middleware
 
 	// Create company (register)
 	protected.Post("/",
+		middleware.Idempotency(deps.IdempotencyStore, middleware.DefaultIdempotencyTTL),
 		deps.CompanyBasicHandler.CreateCompany,
 	)
 
@@ -177,6 +199,24 @@ func SetupCompanyRoutes(api fiber.Router, deps *Dependencies, authMw *middleware
 		deps.CompanyBasicHandler.DeleteCompany,
 	)
 
+	// Get company settings with the company's change request history (admin only)
+	protected.Get("/:id/settings",
+		permMw.RequireAdmin(),
+		deps.CompanyBasicHandler.GetSettings,
+	)
+
+	// Update company settings, e.g. stage reminder threshold or blind screening (admin only)
+	protected.Put("/:id/settings",
+		permMw.RequireAdmin(),
+		deps.CompanyBasicHandler.UpdateSettings,
+	)
+
+	// Request an industry, company size or district change for admin review (admin only)
+	protected.Post("/:id/change-requests",
+		permMw.RequireAdmin(),
+		deps.CompanyBasicHandler.CreateChangeRequest,
+	)
+
 	// Upload company logo (admin only)
 	protected.Post("/:id/logo",
 		permMw.RequireAdmin(),
@@ -201,6 +241,81 @@ func SetupCompanyRoutes(api fiber.Router, deps *Dependencies, authMw *middleware
 		deps.CompanyImageHandler.DeleteBanner,
 	)
 
+	// List company draft jobs with publish completeness (job managers only)
+	protected.Get("/:id/jobs/drafts",
+		permMw.CanManageJobs(),
+		deps.JobHandler.ListCompanyDrafts,
+	)
+
+	// Get the company's plan, published job limit and published job count (job managers only)
+	protected.Get("/:id/quota",
+		permMw.CanManageJobs(),
+		deps.JobHandler.GetCompanyQuota,
+	)
+
+	// List company applications newest first, by page or by cursor (application viewers only)
+	protected.Get("/:id/applications",
+		permMw.RequirePermission(company.PermissionViewApplications),
+		deps.ApplicationHandler.ListByCompany,
+	)
+
+	// Export company applications as CSV (application viewers only)
+	protected.Get("/:id/applications/export",
+		permMw.RequirePermission(company.PermissionViewApplications),
+		deps.ApplicationHandler.ExportByCompany,
+	)
+
+	// Queue moving applications to a status in the background (recruiters only)
+	// Body: { status, application_ids | filter: { status, job_id, source, min_score }, notes }
+	protected.Post("/:id/applications/bulk-actions",
+		permMw.RequirePermission(company.PermissionUpdateApplicationStatus),
+		deps.ApplicationHandler.CreateBulkAction,
+	)
+
+	// Poll the progress of a bulk action with its failed items (application viewers only)
+	protected.Get("/:id/applications/bulk-actions/:actionId",
+		permMw.RequirePermission(company.PermissionViewApplications),
+		deps.ApplicationHandler.GetBulkAction,
+	)
+
+	// Job views, applications and hires per utm_source with conversion rates (analytics viewers only)
+	// Query params: from, to (YYYY-MM-DD, inclusive), job_id
+	protected.Get("/:id/analytics/sources",
+		permMw.RequirePermission(company.PermissionViewAnalytics),
+		deps.ApplicationHandler.GetSourceAnalytics,
+	)
+
+	// ------------------------------------------
+	// Interview Slots (ApplicationHandler)
+	// ------------------------------------------
+
+	// List interview slots (application viewers only)
+	// Query params: from, to (RFC3339), status (open|booked), interviewer_id
+	protected.Get("/:id/interview-slots",
+		permMw.RequirePermission(company.PermissionViewApplications),
+		deps.ApplicationHandler.ListInterviewSlots,
+	)
+
+	// Create a batch of interview slots (recruiters only)
+	// Body: { interviewer_id, start_date, end_date, day_start, day_end, duration_minutes,
+	//         buffer_minutes, include_weekends, timezone, interview_type, meeting_link, location }
+	protected.Post("/:id/interview-slots",
+		permMw.RequirePermission(company.PermissionUpdateApplicationStatus),
+		deps.ApplicationHandler.CreateInterviewSlots,
+	)
+
+	// Move an open interview slot (recruiters only)
+	protected.Put("/:id/interview-slots/:slotId",
+		permMw.RequirePermission(company.PermissionUpdateApplicationStatus),
+		deps.ApplicationHandler.UpdateInterviewSlot,
+	)
+
+	// Delete an open interview slot (recruiters only)
+	protected.Delete("/:id/interview-slots/:slotId",
+		permMw.RequirePermission(company.PermissionUpdateApplicationStatus),
+		deps.ApplicationHandler.DeleteInterviewSlot,
+	)
+
 	// ------------------------------------------
 	// Profile Management (CompanyProfileHandler)
 	// ------------------------------------------
@@ -244,6 +359,7 @@ func SetupCompanyRoutes(api fiber.Router, deps *Dependencies, authMw *middleware
 	// Add company review with rate limiting (prevent spam)
 	protected.Post("/:id/review",
 		middleware.APIRateLimiter(), // Rate limit reviews - 100 req/min
+		middleware.Idempotency(deps.IdempotencyStore, middleware.DefaultIdempotencyTTL),
 		deps.CompanyReviewHandler.AddReview,
 	)
 
@@ -257,6 +373,38 @@ func SetupCompanyRoutes(api fiber.Router, deps *Dependencies, authMw *middleware
 		deps.CompanyReviewHandler.DeleteReview,
 	)
 
+	// Vote a review helpful / not helpful (not on own review)
+	protected.Post("/reviews/:id/vote",
+		deps.CompanyReviewHandler.VoteReview,
+	)
+
+	// Remove own vote on a review
+	protected.Delete("/reviews/:id/vote",
+		deps.CompanyReviewHandler.UnvoteReview,
+	)
+
+	// Report an abusive review (hidden automatically after enough reports)
+	protected.Post("/reviews/:id/report",
+		middleware.APIRateLimiter(),
+		deps.CompanyReviewHandler.ReportReview,
+	)
+
+	// Respond publicly to an approved review (company owner/admin only, checked by the service)
+	protected.Post("/reviews/:id/response",
+		middleware.Idempotency(deps.IdempotencyStore, middleware.DefaultIdempotencyTTL),
+		deps.CompanyReviewHandler.AddReviewResponse,
+	)
+
+	// Edit the company's response to a review
+	protected.Put("/reviews/:id/response",
+		deps.CompanyReviewHandler.UpdateReviewResponse,
+	)
+
+	// Delete the company's response to a review
+	protected.Delete("/reviews/:id/response",
+		deps.CompanyReviewHandler.DeleteReviewResponse,
+	)
+
 	// ------------------------------------------
 	// Additional Protected Routes (Employee Invitations)
 	// ------------------------------------------
@@ -292,6 +440,238 @@ func SetupCompanyRoutes(api fiber.Router, deps *Dependencies, authMw *middleware
 		deps.CompanyInviteHandler.CancelInvitation,
 	)
 
+	// ------------------------------------------
+	// Domain Verification & Join Requests (CompanyDomainHandler)
+	// ------------------------------------------
+
+	// Claim the company's email domain (owner or admin only)
+	// Body: { method: "email" | "dns" }
+	// email: a 6-digit code is sent to postmaster@<domain>; dns: returns the TXT record to publish
+	// Public email providers (gmail.com, yahoo.com, ...) cannot be claimed
+	protected.Post("/:id/domain-verification",
+		middleware.EmailRateLimiter(),
+		permMw.RequireOwnerOrAdmin(),
+		deps.CompanyDomainHandler.StartDomainVerification,
+	)
+
+	// Complete the domain claim (owner or admin only)
+	// Body: { code } for the email method; the dns method looks up the TXT record
+	protected.Post("/:id/domain-verification/verify",
+		middleware.APIRateLimiter(),
+		permMw.RequireOwnerOrAdmin(),
+		deps.CompanyDomainHandler.VerifyDomain,
+	)
+
+	// Get the state of the domain claim (owner or admin only)
+	protected.Get("/:id/domain-verification",
+		permMw.RequireOwnerOrAdmin(),
+		deps.CompanyDomainHandler.GetDomainVerification,
+	)
+
+	// Ask to join a company on my verified email domain that enabled auto-join
+	// The membership gets the company's auto_join_role and waits for an owner or admin;
+	// pending requests are listed by GET /:id/invitations under join_requests
+	protected.Post("/:id/join",
+		middleware.APIRateLimiter(),
+		authMw.EmployerOnly(),
+		deps.CompanyDomainHandler.RequestToJoin,
+	)
+
+	// Approve a pending join request (admin only)
+	protected.Post("/:id/join-requests/:employerUserId/approve",
+		permMw.CanManageEmployees(),
+		deps.CompanyDomainHandler.ApproveJoinRequest,
+	)
+
+	// Reject a pending join request (admin only)
+	protected.Delete("/:id/join-requests/:employerUserId",
+		permMw.CanManageEmployees(),
+		deps.CompanyDomainHandler.RejectJoinRequest,
+	)
+
+	// ------------------------------------------
+	// Employee Roster (CompanyEmployeeHandler)
+	// ------------------------------------------
+
+	// Import employees from a CSV upload (employee managers only)
+	// Body: multipart/form-data { file } with columns full_name, email, job_title, department,
+	//       employment_type, join_date, is_visible_public; max 5MB / 5000 rows
+	// Query param: dry_run=true validates without saving
+	protected.Post("/:id/employees/import",
+		middleware.APIRateLimiter(),
+		permMw.CanManageEmployees(),
+		deps.CompanyEmployeeHandler.ImportEmployees,
+	)
+
+	// ------------------------------------------
+	// Integration API Keys (CompanyAPIKeyHandler)
+	// ------------------------------------------
+
+	// Create an API key; the plaintext key is returned only once (owner or admin only)
+	protected.Post("/:id/api-keys",
+		permMw.RequireOwnerOrAdmin(),
+		deps.CompanyAPIKeyHandler.CreateAPIKey,
+	)
+
+	// List API keys, showing key prefixes only (owner or admin only)
+	protected.Get("/:id/api-keys",
+		permMw.RequireOwnerOrAdmin(),
+		deps.CompanyAPIKeyHandler.ListAPIKeys,
+	)
+
+	// Revoke an API key (owner or admin only)
+	protected.Delete("/:id/api-keys/:keyId",
+		permMw.RequireOwnerOrAdmin(),
+		deps.CompanyAPIKeyHandler.RevokeAPIKey,
+	)
+
+	// ------------------------------------------
+	// Integration Webhooks (CompanyWebhookHandler)
+	// ------------------------------------------
+
+	// Register a webhook; the signing secret is returned only once (owner or admin only)
+	// Body: { url, events: [application.created, application.status_changed,
+	//         interview.scheduled, job.published, job.expired] }
+	protected.Post("/:id/webhooks",
+		permMw.RequireOwnerOrAdmin(),
+		deps.CompanyWebhookHandler.CreateWebhook,
+	)
+
+	// List webhooks (owner or admin only)
+	protected.Get("/:id/webhooks",
+		permMw.RequireOwnerOrAdmin(),
+		deps.CompanyWebhookHandler.ListWebhooks,
+	)
+
+	// Change a webhook's url, events or active flag (owner or admin only)
+	protected.Put("/:id/webhooks/:webhookId",
+		permMw.RequireOwnerOrAdmin(),
+		deps.CompanyWebhookHandler.UpdateWebhook,
+	)
+
+	// Delete a webhook (owner or admin only)
+	protected.Delete("/:id/webhooks/:webhookId",
+		permMw.RequireOwnerOrAdmin(),
+		deps.CompanyWebhookHandler.DeleteWebhook,
+	)
+
+	// List a webhook's deliveries (owner or admin only)
+	// Query params: page, limit, status (pending|delivered|failed), event
+	protected.Get("/:id/webhooks/:webhookId/deliveries",
+		permMw.RequireOwnerOrAdmin(),
+		deps.CompanyWebhookHandler.ListDeliveries,
+	)
+
+	// Send a delivery's event again (owner or admin only)
+	protected.Post("/:id/webhooks/:webhookId/deliveries/:deliveryId/redeliver",
+		middleware.APIRateLimiter(),
+		permMw.RequireOwnerOrAdmin(),
+		deps.CompanyWebhookHandler.Redeliver,
+	)
+
+	// ------------------------------------------
+	// Candidate Email Templates (CompanyEmailTemplateHandler)
+	// ------------------------------------------
+
+	// List the variables each event's templates may use (owner or admin only)
+	protected.Get("/:id/email-templates/variables",
+		permMw.RequireOwnerOrAdmin(),
+		deps.CompanyEmailTemplateHandler.ListVariables,
+	)
+
+	// Render a template with sample data without saving it (owner or admin only)
+	// Body: { event_type, subject, body }
+	protected.Post("/:id/email-templates/preview",
+		permMw.RequireOwnerOrAdmin(),
+		deps.CompanyEmailTemplateHandler.PreviewTemplate,
+	)
+
+	// List the company's email templates (owner or admin only)
+	protected.Get("/:id/email-templates",
+		permMw.RequireOwnerOrAdmin(),
+		deps.CompanyEmailTemplateHandler.ListTemplates,
+	)
+
+	// Add a template for an event; one per event (owner or admin only)
+	// Body: { event_type: application_rejected|application_shortlisted|interview_scheduled|offer_made,
+	//         subject, body } with {{variable}} placeholders
+	protected.Post("/:id/email-templates",
+		permMw.RequireOwnerOrAdmin(),
+		deps.CompanyEmailTemplateHandler.CreateTemplate,
+	)
+
+	// Replace a template's subject and body (owner or admin only)
+	protected.Put("/:id/email-templates/:templateId",
+		permMw.RequireOwnerOrAdmin(),
+		deps.CompanyEmailTemplateHandler.UpdateTemplate,
+	)
+
+	// Delete a template, restoring the platform default (owner or admin only)
+	protected.Delete("/:id/email-templates/:templateId",
+		permMw.RequireOwnerOrAdmin(),
+		deps.CompanyEmailTemplateHandler.DeleteTemplate,
+	)
+
+	// ------------------------------------------
+	// Talent Pools (CompanyTalentPoolHandler)
+	// ------------------------------------------
+
+	// Score pool candidates who have not applied yet against a job (application viewers only)
+	// Query params: job_id, pool_id (all pools when omitted), limit (max 50)
+	protected.Get("/:id/talent-pools/matches",
+		permMw.RequirePermission(company.PermissionViewApplications),
+		deps.CompanyTalentPoolHandler.MatchJob,
+	)
+
+	// List the company's talent pools (application viewers only)
+	protected.Get("/:id/talent-pools",
+		permMw.RequirePermission(company.PermissionViewApplications),
+		deps.CompanyTalentPoolHandler.ListPools,
+	)
+
+	// Create a talent pool (recruiters only)
+	// Body: { name, description }
+	protected.Post("/:id/talent-pools",
+		permMw.RequirePermission(company.PermissionUpdateApplicationStatus),
+		deps.CompanyTalentPoolHandler.CreatePool,
+	)
+
+	// Delete a talent pool with its members (recruiters only)
+	protected.Delete("/:id/talent-pools/:poolId",
+		permMw.RequirePermission(company.PermissionUpdateApplicationStatus),
+		deps.CompanyTalentPoolHandler.DeletePool,
+	)
+
+	// List a pool's members (application viewers only)
+	// Query params: tags, skills (comma-separated, all must match), q, page, limit
+	protected.Get("/:id/talent-pools/:poolId/members",
+		permMw.RequirePermission(company.PermissionViewApplications),
+		deps.CompanyTalentPoolHandler.ListMembers,
+	)
+
+	// Save an applicant to a pool; saving them again returns the existing member (recruiters only)
+	// Body: { application_id, tags, notes }
+	protected.Post("/:id/talent-pools/:poolId/members",
+		permMw.RequirePermission(company.PermissionUpdateApplicationStatus),
+		deps.CompanyTalentPoolHandler.AddMember,
+	)
+
+	// Remove a member from a pool (recruiters only)
+	protected.Delete("/:id/talent-pools/:poolId/members/:memberId",
+		permMw.RequirePermission(company.PermissionUpdateApplicationStatus),
+		deps.CompanyTalentPoolHandler.RemoveMember,
+	)
+
+	// ------------------------------------------
+	// Documents (CompanyDocumentHandler)
+	// ------------------------------------------
+
+	// Get a short-lived signed download link for a legal or verification document
+	// (platform admins and company owners or admins; checked by the service)
+	protected.Get("/:id/documents/:docId/download",
+		deps.CompanyDocumentHandler.DownloadDocument,
+	)
+
 	// Request company verification
 	protected.Post("/:id/verify",
 		middleware.APIRateLimiter(), // Rate limit verification requests
@@ -299,3 +679,49 @@ func SetupCompanyRoutes(api fiber.Router, deps *Dependencies, authMw *middleware
 		deps.CompanyVerificationHandler.RequestVerification,
 	)
 }
+
+// SetupInvitationRoutes configures the public company invitation routes
+// Routes: /api/v1/invitations/*
+func SetupInvitationRoutes(api fiber.Router, deps *Dependencies) {
+	invitations := api.Group("/invitations")
+	invitations.Use(middleware.APIRateLimiter())
+
+	// GET /api/v1/invitations/:token - Get invitation details without an account
+	// Returns: company name and logo, role, position, inviter, status and expiry.
+	// Invitees without an account sign up with { invitation_token } to accept it.
+	invitations.Get("/:token",
+		deps.CompanyInviteHandler.GetInvitation,
+	)
+}
+
+// SetupEmployerCompanyRoutes configures the routes employers who belong to several companies
+// use to see and switch the company they act for, and to find companies they may join
+// Routes: /api/v1/me/companies/*, /api/v1/me/company-suggestions
+func SetupEmployerCompanyRoutes(api fiber.Router, deps *Dependencies, authMw *middleware.AuthMiddleware) {
+	companies := api.Group("/me/companies")
+	companies.Use(authMw.AuthRequired())
+	companies.Use(authMw.EmployerOnly())
+
+	// GET /api/v1/me/companies - List my companies
+	// Returns: each company with my role there and is_active for the company requests act for
+	// (X-Company-ID when sent, else the last activated company, else the only company)
+	companies.Get("/",
+		deps.CompanyEmployerHandler.ListMyCompanies,
+	)
+
+	// POST /api/v1/me/companies/:id/activate - Make a company my active company
+	// Employer requests without X-Company-ID act for it from then on
+	companies.Post("/:id/activate",
+		middleware.APIRateLimiter(),
+		deps.CompanyEmployerHandler.ActivateCompany,
+	)
+
+	// GET /api/v1/me/company-suggestions - Companies I may ask to join
+	// Returns: companies that verified the domain of my verified email and enabled auto-join,
+	// with the role I would get and whether I already asked. Public email domains get none.
+	api.Get("/me/company-suggestions",
+		authMw.AuthRequired(),
+		authMw.EmployerOnly(),
+		deps.CompanyDomainHandler.GetCompanySuggestions,
+	)
+}
@@ -19,14 +19,14 @@ func SetupHealthRoutes(app *fiber.App, handler *health.HealthHandler) {
 	healthGroup := app.Group("/health")
 
 	// Full health check with component status
-	// Returns 200 if healthy, 503 if any component is down
+	// Returns 200 when ok or degraded, 503 only if the database or a required Redis is down
 	healthGroup.Get("/", handler.Health)
 
 	// Liveness probe - always returns 200 if app is running
 	// Used by Kubernetes to know when to restart the container
 	healthGroup.Get("/live", handler.Liveness)
 
-	// Readiness probe - returns 200 if ready, 503 if not ready
+	// Readiness probe - returns 200 if ready (possibly degraded), 503 if the database or a required Redis is down
 	// Used by Kubernetes to know when to send traffic
 	healthGroup.Get("/ready", handler.Readiness)
 
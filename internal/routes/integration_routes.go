@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"keerja-backend/internal/domain/apikey"
+	"keerja-backend/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupIntegrationRoutes configures read-only routes for external systems such as an ATS,
+// authenticated by a company API key (X-Api-Key) instead of a user token
+// Routes: /api/v1/integrations/*
+//
+// A key only reaches its own company's jobs and applications, within its scopes.
+// Requests are rate limited per key, separately from the per-IP user limiter.
+func SetupIntegrationRoutes(api fiber.Router, deps *Dependencies) {
+	integrations := api.Group("/integrations",
+		middleware.APIKeyFailureRateLimiter(),
+		middleware.APIKeyAuth(deps.APIKeyService),
+		middleware.APIKeyRateLimiter(deps.Config),
+	)
+
+	// List the company's jobs (scope: jobs:read)
+	integrations.Get("/companies/:id/jobs",
+		middleware.RequireAPIKeyScope(apikey.ScopeJobsRead),
+		deps.JobHandler.ListCompanyJobs,
+	)
+
+	// List the company's applications, by page or by cursor (scope: applications:read)
+	integrations.Get("/companies/:id/applications",
+		middleware.RequireAPIKeyScope(apikey.ScopeApplicationsRead),
+		deps.ApplicationHandler.ListByCompany,
+	)
+}
@@ -71,6 +71,13 @@ func SetupJobMasterDataRoutes(api fiber.Router, handler *master.MasterDataHandle
 		handler.GetJobOptions,
 	)
 
+	// Skills autocomplete over names and aliases, typo tolerant
+	// GET /api/v1/master/skills/suggest?q=gola&limit=10
+	master.Get("/skills/suggest",
+		middleware.RateLimitByIP(120, 1*time.Minute),
+		handler.SuggestSkills,
+	)
+
 	// Paginated skills endpoint (standalone)
 	// GET /api/v1/master/skills?q=search&page=1&limit=50
 	master.Get("/skills",
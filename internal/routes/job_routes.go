@@ -9,30 +9,42 @@ import (
 // SetupJobRoutes configures job routes
 // Routes: /api/v1/jobs/*
 //
-// Public Endpoints (3):
+// Public Endpoints (8):
 //   - GET    /                   List all jobs with filters & pagination
+//   - GET    /featured           Featured jobs (admin-curated, then by recent views)
+//   - GET    /trending           Trending jobs (most viewed in the last 48 hours)
+//   - GET    /compare            Compare up to 3 jobs side by side
 //   - GET    /:id                Get job details by ID
+//   - GET    /:id/questions      List screening questions
+//   - GET    /:slug/structured-data  schema.org JobPosting JSON-LD of a published job
 //   - POST   /search             Advanced job search
 //
-// Employer Endpoints (10):
+// Employer Endpoints (14):
 //   - POST   /                   Create new job posting
 //   - POST   /draft              Save job draft (Phase 6)
 //   - PUT    /:id                Update existing job
 //   - DELETE /:id                Delete job posting
+//   - POST   /:id/duplicate      Copy job into a new draft
 //   - GET    /my-jobs            List employer's own jobs
 //   - PATCH  /:id/publish        Publish draft job
 //   - PATCH  /:id/close          Close active job
+//   - GET    /:id/applications/board  Applications grouped by status
+//   - GET    /:id/pipeline            Hiring pipeline stages
+//   - PUT    /:id/pipeline            Set custom pipeline stages
+//   - POST   /:id/questions      Add screening question (draft/pending review only)
+//   - PUT    /:id/questions/:questionId     Update screening question
+//   - DELETE /:id/questions/:questionId     Delete screening question
 //   - GET    /status/active      Get active jobs with pagination
 //   - GET    /status/draft       Get draft jobs with pagination
 //   - GET    /status/in-review   Get in-review jobs with pagination
 //   - GET    /status/inactive    Get inactive jobs with pagination
 //
-// Total: 13 endpoints
-func SetupJobRoutes(api fiber.Router, deps *Dependencies, authMw *middleware.AuthMiddleware) {
+// Total: 22 endpoints
+func SetupJobRoutes(api fiber.Router, deps *Dependencies, authMw *middleware.AuthMiddleware, permMw *middleware.PermissionMiddleware) {
 	jobs := api.Group("/jobs")
 
 	// ============================================
-	// PUBLIC ROUTES (5 endpoints)
+	// PUBLIC ROUTES (8 endpoints)
 	// ============================================
 
 	// GET /api/v1/jobs/job-types - Get job types options for mobile
@@ -57,6 +69,45 @@ func SetupJobRoutes(api fiber.Router, deps *Dependencies, authMw *middleware.Aut
 		deps.JobHandler.ListJobs,
 	)
 
+	// GET /api/v1/jobs/featured - Featured jobs for the homepage, cached for 5 minutes
+	// Query params: limit (max 50)
+	jobs.Get("/featured",
+		middleware.SearchRateLimiter(),
+		deps.JobHandler.GetFeaturedJobs,
+	)
+
+	// GET /api/v1/jobs/trending - Trending jobs, cached for 5 minutes
+	// Query params: limit (max 50)
+	jobs.Get("/trending",
+		middleware.SearchRateLimiter(),
+		deps.JobHandler.GetTrendingJobs,
+	)
+
+	// GET /api/v1/jobs/compare - Compare up to 3 jobs side by side
+	// Query params: ids (comma-separated job IDs)
+	// Optional auth adds the caller's skills, distance and match score per job, and lets them
+	// compare jobs they applied to that are no longer published
+	// IMPORTANT: This must be defined BEFORE /:id routes to avoid conflicts
+	jobs.Get("/compare",
+		middleware.SearchRateLimiter(),
+		authMw.OptionalAuth(),
+		deps.JobCompareHandler.CompareJobs,
+	)
+
+	// GET /api/v1/jobs/:id/questions - List screening questions to answer when applying
+	// Optional auth lets the job's employer see knockout settings
+	jobs.Get("/:id/questions",
+		authMw.OptionalAuth(),
+		deps.JobHandler.ListQuestions,
+	)
+
+	// GET /api/v1/jobs/:slug/structured-data - JobPosting JSON-LD for the job page to embed
+	// Unpublished and expired jobs return 404
+	jobs.Get("/:slug/structured-data",
+		middleware.SearchRateLimiter(),
+		deps.JobFeedHandler.GetStructuredData,
+	)
+
 	// ============================================
 	// PROTECTED ROUTES - EMPLOYER ONLY (7 endpoints)
 	// ============================================
@@ -65,7 +116,7 @@ func SetupJobRoutes(api fiber.Router, deps *Dependencies, authMw *middleware.Aut
 	protected.Use(authMw.EmployerOnly())
 
 	// GET /api/v1/jobs/my-jobs - List employer's jobs
-	// Query params: page, limit, status
+	// Query params: page, limit, status, company_id (defaults to X-Company-ID or the active company)
 	// Rate limit: 30 requests/minute
 	// IMPORTANT: This must be defined BEFORE /:id routes to avoid conflicts
 	protected.Get("/my-jobs",
@@ -73,27 +124,34 @@ func SetupJobRoutes(api fiber.Router, deps *Dependencies, authMw *middleware.Aut
 		deps.JobHandler.GetMyJobs,
 	)
 
+	// Status lists show the jobs posted at the company named by X-Company-ID, or else at the
+	// user's active company; users with several companies and no choice get 400
+
 	// GET /api/v1/jobs/status/active - Get active jobs with pagination
 	protected.Get("/status/active",
 		middleware.SearchRateLimiter(),
+		permMw.RequireActiveCompany(),
 		deps.JobHandler.GetActiveJobs,
 	)
 
 	// GET /api/v1/jobs/status/draft - Get draft jobs with pagination
 	protected.Get("/status/draft",
 		middleware.SearchRateLimiter(),
+		permMw.RequireActiveCompany(),
 		deps.JobHandler.GetDraftJobs,
 	)
 
 	// GET /api/v1/jobs/status/in-review - Get in-review jobs with pagination
 	protected.Get("/status/in-review",
 		middleware.SearchRateLimiter(),
+		permMw.RequireActiveCompany(),
 		deps.JobHandler.GetInReviewJobs,
 	)
 
 	// GET /api/v1/jobs/status/inactive - Get inactive jobs with pagination
 	protected.Get("/status/inactive",
 		middleware.SearchRateLimiter(),
+		permMw.RequireActiveCompany(),
 		deps.JobHandler.GetInactiveJobs,
 	)
 
@@ -103,6 +161,16 @@ func SetupJobRoutes(api fiber.Router, deps *Dependencies, authMw *middleware.Aut
 		deps.JobHandler.SaveJobDraft,
 	)
 
+	// GET /api/v1/jobs/:id/draft/revisions - List saved revisions of a draft (newest first, max 5)
+	protected.Get("/:id/draft/revisions",
+		deps.JobHandler.ListDraftRevisions,
+	)
+
+	// POST /api/v1/jobs/:id/draft/revisions/:revisionId/restore - Restore a draft to a saved revision
+	protected.Post("/:id/draft/revisions/:revisionId/restore",
+		deps.JobHandler.RestoreDraftRevision,
+	)
+
 	// POST /api/v1/jobs - Create new job posting
 	protected.Post("/",
 		middleware.ApplicationRateLimiter(),
@@ -120,6 +188,17 @@ func SetupJobRoutes(api fiber.Router, deps *Dependencies, authMw *middleware.Aut
 		deps.JobHandler.DeleteJob,
 	)
 
+	// POST /api/v1/jobs/:id/restore - Restore a job deleted within the last 30 days
+	protected.Post("/:id/restore",
+		deps.JobHandler.RestoreJob,
+	)
+
+	// POST /api/v1/jobs/:id/duplicate - Copy job into a new draft
+	protected.Post("/:id/duplicate",
+		middleware.ApplicationRateLimiter(),
+		deps.JobHandler.DuplicateJob,
+	)
+
 	// PATCH /api/v1/jobs/:id/publish - Publish draft job (Phase 7)
 	protected.Patch("/:id/publish",
 		deps.JobHandler.PublishJob,
@@ -135,8 +214,46 @@ func SetupJobRoutes(api fiber.Router, deps *Dependencies, authMw *middleware.Aut
 		deps.JobHandler.CloseJob,
 	)
 
+	// GET /api/v1/jobs/:id/applications/board - Applications grouped by status (kanban)
+	// Query params: per_column (1-50, default 10), sort (applied_at|match_score)
+	protected.Get("/:id/applications/board",
+		middleware.SearchRateLimiter(),
+		permMw.RequireActiveCompany(),
+		deps.ApplicationHandler.GetJobApplicationsBoard,
+	)
+
+	// POST /api/v1/jobs/:id/questions - Add a screening question
+	// Body: { question_text, type (text|boolean|number|select), options, is_required, is_knockout, knockout_value, sort_order }
+	protected.Post("/:id/questions",
+		deps.JobHandler.AddQuestion,
+	)
+
+	// PUT /api/v1/jobs/:id/questions/:questionId - Update a screening question
+	protected.Put("/:id/questions/:questionId",
+		deps.JobHandler.UpdateQuestion,
+	)
+
+	// DELETE /api/v1/jobs/:id/questions/:questionId - Delete a screening question
+	protected.Delete("/:id/questions/:questionId",
+		deps.JobHandler.DeleteQuestion,
+	)
+
+	// GET /api/v1/jobs/:id/pipeline - Hiring pipeline stages in order
+	protected.Get("/:id/pipeline",
+		deps.JobHandler.GetPipeline,
+	)
+
+	// PUT /api/v1/jobs/:id/pipeline - Set custom pipeline stages (draft or pending review only)
+	// Body: { stages: [{ name, maps_to_status (applied|screening|shortlisted|interview|offered) }] }
+	protected.Put("/:id/pipeline",
+		deps.JobHandler.ConfigurePipeline,
+	)
+
 	// GET /api/v1/jobs/:id - Get job details
+	// Optional auth lets the job's employer see the latest review result
+	// Query params: utm_source, utm_medium, utm_campaign, referrer (recorded on the view)
 	jobs.Get("/:id",
+		authMw.OptionalAuth(),
 		deps.JobHandler.GetJob,
 	)
 
@@ -146,3 +263,16 @@ func SetupJobRoutes(api fiber.Router, deps *Dependencies, authMw *middleware.Aut
 		deps.JobHandler.SearchJobs,
 	)
 }
+
+// SetupSitemapRoutes configures the jobs sitemap read by search engines
+//
+// Endpoints:
+//   - GET /sitemap-jobs.xml  - Sitemap of published, unexpired jobs; a sitemap index
+//     linking ?page=N when there are more than 50,000 jobs
+func SetupSitemapRoutes(app *fiber.App, deps *Dependencies) {
+	// Outside of /api/v1 so crawlers find it at the conventional root path
+	app.Get("/sitemap-jobs.xml",
+		middleware.APIRateLimiter(),
+		deps.JobFeedHandler.GetSitemap,
+	)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/limiter"
 
 	"keerja-backend/internal/handler/http/master"
+	"keerja-backend/internal/middleware"
 )
 
 // MasterDataHandlers holds all master data handlers
@@ -24,8 +25,9 @@ func SetupMasterDataRoutes(api fiber.Router, handlers *MasterDataHandlers) {
 	// Master data group - /api/v1/master
 	master := api.Group("/master")
 
-	// Apply middleware to all master data routes
-	master.Use(setupMasterDataMiddleware())
+	// Apply middleware to all master data routes. Query parameters are validated first so
+	// malformed requests are neither served nor cached.
+	master.Use(middleware.ValidateQueryParams(middleware.MasterDataQueryRules), setupMasterDataMiddleware())
 
 	// Industry routes
 	setupIndustryRoutes(master, handlers.IndustryHandler)
@@ -48,11 +50,10 @@ func setupMasterDataMiddleware() fiber.Handler {
 		},
 		Expiration:   5 * time.Minute, // Cache for 5 minutes
 		CacheControl: true,            // Add Cache-Control header
-		KeyGenerator: func(c *fiber.Ctx) string {
-			// Generate cache key from URL and query params
-			return c.Path() + "?" + string(c.Request().URI().QueryString())
-		},
-		Storage: nil, // Use in-memory cache (Fiber default)
+		// Key on the path and the known query parameters only, so arbitrary query strings
+		// can't add cache entries
+		KeyGenerator: middleware.MasterDataQueryRules.CacheKey,
+		Storage:      nil, // Use in-memory cache (Fiber default)
 	})
 }
 
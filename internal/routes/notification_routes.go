@@ -0,0 +1,51 @@
+package routes
+
+import (
+	notificationhandler "keerja-backend/internal/handler/http/notification"
+	"keerja-backend/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupNotificationRoutes configures the in-app notification center routes
+// Routes: /api/v1/notifications/*
+//
+// Endpoints (4):
+//   - GET    /                     List my notifications (with pagination)
+//   - GET    /unread-count         Get unread notification count
+//   - POST   /read-all             Mark all notifications as read
+//   - POST   /:id/read             Mark notification as read
+//
+// Total: 4 endpoints
+func SetupNotificationRoutes(api fiber.Router, handler *notificationhandler.NotificationHandler, authMw *middleware.AuthMiddleware) {
+	// Notification routes group
+	notifications := api.Group("/notifications")
+
+	// Apply authentication middleware to all notification routes
+	notifications.Use(authMw.AuthRequired())
+
+	// GET /api/v1/notifications - List user's notifications, newest first
+	// Query params: page, limit, unread, type, category, is_read, priority
+	// Rate limit: 30 requests/minute
+	notifications.Get("/",
+		middleware.SearchRateLimiter(),
+		handler.GetNotifications,
+	)
+
+	// GET /api/v1/notifications/unread-count - Get unread notification count
+	// Returns: { unread_count }
+	notifications.Get("/unread-count",
+		handler.GetUnreadCount,
+	)
+
+	// POST /api/v1/notifications/read-all - Mark all notifications as read
+	notifications.Post("/read-all",
+		handler.MarkAllAsRead,
+	)
+
+	// POST /api/v1/notifications/:id/read - Mark notification as read
+	// Only the notification's recipient can mark it
+	notifications.Post("/:id/read",
+		handler.MarkAsRead,
+	)
+}
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"keerja-backend/internal/config"
+	"keerja-backend/internal/domain/apikey"
 	"keerja-backend/internal/domain/company"
 	"keerja-backend/internal/handler/http/admin"
 	applicationhandler "keerja-backend/internal/handler/http/application"
@@ -12,6 +13,7 @@ import (
 	userhandler "keerja-backend/internal/handler/http/jobseeker"
 	"keerja-backend/internal/handler/http/master"
 	notificationhandler "keerja-backend/internal/handler/http/notification"
+	uploadhandler "keerja-backend/internal/handler/http/upload"
 	"keerja-backend/internal/handler/websocket"
 	"keerja-backend/internal/middleware"
 
@@ -23,14 +25,28 @@ type Dependencies struct {
 	Config                 *config.Config
 	AuthHandler            *authhandler.AuthHandler
 	JobHandler             *jobhandler.JobHandler                 // Job management (10 endpoints)
-	ApplicationHandler     *applicationhandler.ApplicationHandler // Application management (21 endpoints)
+	JobFeedHandler         *jobhandler.JobFeedHandler             // Sitemap and structured data (2 endpoints)
+	JobCompareHandler      *jobhandler.JobCompareHandler          // Job comparison (1 endpoint)
+	ApplicationHandler     *applicationhandler.ApplicationHandler // Application management (22 endpoints)
 	AdminJobHandler        *admin.AdminJobHandler                 // Admin moderation & job approval
 	AdminMasterDataHandler *admin.AdminMasterDataHandler          // Admin master data CRUD
 
 	// Admin handlers
-	AdminAuthHandler    *admin.AdminAuthHandler         // Admin authentication
-	AdminCompanyHandler *admin.CompanyHandler           // Company moderation
-	AdminAuthMiddleware *middleware.AdminAuthMiddleware // Admin auth middleware
+	AdminAuthHandler          *admin.AdminAuthHandler          // Admin authentication
+	AdminCompanyHandler       *admin.CompanyHandler            // Company moderation
+	AdminReviewHandler        *admin.AdminReviewHandler        // Company review moderation
+	AdminChangeRequestHandler *admin.AdminChangeRequestHandler // Company change requests
+	AdminPushHandler          *admin.AdminPushHandler          // Push campaigns
+	AdminAuditHandler         *admin.AdminAuditHandler         // Audit log
+	AdminEmailQueueHandler    *admin.AdminEmailQueueHandler    // Email queue
+	AdminWhatsAppHandler      *admin.AdminWhatsAppHandler      // WhatsApp deliveries
+	AdminAnalyticsHandler     *admin.AdminAnalyticsHandler     // Dashboard analytics
+	AdminSchedulerHandler     *admin.AdminSchedulerHandler     // Background job schedule
+	AdminRoleHandler          *admin.AdminRoleHandler          // Admin roles and permissions
+	AdminWebhookHandler       *admin.AdminWebhookHandler       // Company webhook deliveries
+	AdminImpersonationHandler *admin.AdminImpersonationHandler // User impersonation
+	AdminJobQuotaHandler      *admin.AdminJobQuotaHandler      // Plan job quotas
+	AdminAuthMiddleware       *middleware.AdminAuthMiddleware  // Admin auth middleware
 
 	// User handlers (split by domain for better organization)
 	UserProfileHandler    *userhandler.UserProfileHandler    // Profile & preferences (5 endpoints)
@@ -39,17 +55,29 @@ type Dependencies struct {
 	UserSkillHandler      *userhandler.UserSkillHandler      // Skills management (3 endpoints)
 	UserDocumentHandler   *userhandler.UserDocumentHandler   // Document upload (2 endpoints)
 	UserMiscHandler       *userhandler.UserMiscHandler       // Certifications, languages, projects (3 endpoints)
+	AccountPrivacyHandler *userhandler.AccountPrivacyHandler // Account deletion & data export (5 endpoints)
 
 	// Company handlers (split by domain for better organization)
-	CompanyBasicHandler        *companyhandler.CompanyBasicHandler        // CRUD operations (7 endpoints)
-	CompanyImageHandler        *companyhandler.CompanyImageHandler        // Image upload/delete (4 endpoints)
-	CompanyAddressHandler      *companyhandler.CompanyAddressHandler      // Address CRUD (4 endpoints)
-	CompanyEmployerHandler     *companyhandler.CompanyEmployerHandler     // Employer profile (2 endpoints)
-	CompanyVerificationHandler *companyhandler.CompanyVerificationHandler // Verification (3 endpoints)
-	CompanyProfileHandler      *companyhandler.CompanyProfileHandler      // Profile & social features (8 endpoints)
-	CompanyReviewHandler       *companyhandler.CompanyReviewHandler       // Review system (5 endpoints)
-	CompanyStatsHandler        *companyhandler.CompanyStatsHandler        // Statistics & queries (3 endpoints)
-	CompanyInviteHandler       *companyhandler.CompanyInviteHandler       // Employee invitation (5 endpoints)
+	CompanyBasicHandler         *companyhandler.CompanyBasicHandler         // CRUD operations (7 endpoints)
+	CompanyImageHandler         *companyhandler.CompanyImageHandler         // Image upload/delete (4 endpoints)
+	CompanyAddressHandler       *companyhandler.CompanyAddressHandler       // Address CRUD (4 endpoints)
+	CompanyEmployerHandler      *companyhandler.CompanyEmployerHandler      // Employer profile (2 endpoints)
+	CompanyVerificationHandler  *companyhandler.CompanyVerificationHandler  // Verification (3 endpoints)
+	CompanyProfileHandler       *companyhandler.CompanyProfileHandler       // Profile & social features (8 endpoints)
+	CompanyReviewHandler        *companyhandler.CompanyReviewHandler        // Review system (5 endpoints)
+	CompanyStatsHandler         *companyhandler.CompanyStatsHandler         // Statistics & queries (3 endpoints)
+	CompanyInviteHandler        *companyhandler.CompanyInviteHandler        // Employee invitation (5 endpoints)
+	CompanyDomainHandler        *companyhandler.CompanyDomainHandler        // Domain verification & join requests (7 endpoints)
+	CompanyEmployeeHandler      *companyhandler.CompanyEmployeeHandler      // Employee roster import (1 endpoint)
+	CompanyAPIKeyHandler        *companyhandler.CompanyAPIKeyHandler        // Integration API keys (3 endpoints)
+	CompanyWebhookHandler       *companyhandler.CompanyWebhookHandler       // Integration webhooks (6 endpoints)
+	CompanyEmailTemplateHandler *companyhandler.CompanyEmailTemplateHandler // Candidate email templates (6 endpoints)
+	CompanyTalentPoolHandler    *companyhandler.CompanyTalentPoolHandler    // Talent pools (7 endpoints)
+	CompanyDocumentHandler      *companyhandler.CompanyDocumentHandler      // Document download links (1 endpoint)
+
+	// Signed downloads of private documents
+	DownloadHandler *uploadhandler.DownloadHandler // Download by signed token (1 endpoint)
+
 	// Master data handlers
 	SkillsMasterHandler *master.SkillsMasterHandler // Skills master data (8 endpoints)
 	MasterDataHandlers  *MasterDataHandlers         // Industry, company size, location (10 endpoints)
@@ -58,6 +86,7 @@ type Dependencies struct {
 	// FCM Notification handlers (Firebase Cloud Messaging)
 	DeviceTokenHandler      *notificationhandler.DeviceTokenHandler      // Device token management (6 endpoints)
 	PushNotificationHandler *notificationhandler.PushNotificationHandler // Push notifications (5 endpoints)
+	NotificationHandler     *notificationhandler.NotificationHandler     // In-app notification center (4 endpoints)
 
 	// Chat handlers
 	ChatHandler      *chathandler.ChatHandler // Chat HTTP handler (6 endpoints)
@@ -65,14 +94,18 @@ type Dependencies struct {
 	WebSocketHandler *websocket.Handler       // WebSocket handler
 
 	// Services (for middlewares)
-	CompanyService company.CompanyService
+	CompanyService       company.CompanyService
+	APIKeyService        apikey.APIKeyService            // Authenticates integration requests by X-Api-Key
+	SessionValidator     middleware.SessionValidator     // Rejects access tokens of revoked sessions
+	ImpersonationTracker middleware.ImpersonationTracker // Accepts and audits admin impersonation tokens
+	IdempotencyStore     middleware.IdempotencyStore     // Stores responses of routes opted in to Idempotency-Key
 }
 
 // SetupRoutes configures all application routes
 // This is the main entry point for route configuration
 func SetupRoutes(app *fiber.App, deps *Dependencies) {
 	// Initialize auth middleware
-	authMw := middleware.NewAuthMiddleware(deps.Config)
+	authMw := middleware.NewAuthMiddleware(deps.Config, deps.SessionValidator, deps.ImpersonationTracker)
 
 	// Initialize permission middleware
 	permMw := middleware.NewPermissionMiddleware(deps.CompanyService)
@@ -84,14 +117,25 @@ func SetupRoutes(app *fiber.App, deps *Dependencies) {
 	api := app.Group("/api/v1")
 
 	// Setup route groups (each in separate file)
-	SetupAuthRoutes(api, deps, authMw)               // auth_routes.go
-	SetupUserRoutes(api, deps, authMw)               // user_routes.go
-	SetupJobRoutes(api, deps, authMw)                // job_routes.go
-	SetupApplicationRoutes(api, deps, authMw)        // application_routes.go
-	SetupCompanyRoutes(api, deps, authMw, permMw)    // company_routes.go
-	SetupAdminAuthRoutes(api, deps, adminAuthMw)     // admin_auth_routes.go
-	SetupAdminRoutes(api, deps, adminAuthMw)         // admin_routes.go
-	SetupSkillsRoutes(api, deps.SkillsMasterHandler) // skills_routes.go
+	SetupAuthRoutes(api, deps, authMw)                // auth_routes.go
+	SetupUserRoutes(api, deps, authMw)                // user_routes.go
+	SetupJobRoutes(api, deps, authMw, permMw)         // job_routes.go
+	SetupSitemapRoutes(app, deps)                     // job_routes.go
+	SetupApplicationRoutes(api, deps, authMw, permMw) // application_routes.go
+	SetupInterviewBookingRoutes(api, deps)            // application_routes.go
+	SetupDataExportRoutes(api, deps)                  // user_routes.go
+	SetupDownloadRoutes(app, deps)                    // upload_routes.go
+	SetupCompanyRoutes(api, deps, authMw, permMw)     // company_routes.go
+	SetupInvitationRoutes(api, deps)                  // company_routes.go
+	SetupEmployerCompanyRoutes(api, deps, authMw)     // company_routes.go
+	SetupAdminAuthRoutes(api, deps, adminAuthMw)      // admin_auth_routes.go
+	SetupAdminRoutes(api, deps, adminAuthMw)          // admin_routes.go
+	SetupSkillsRoutes(api, deps.SkillsMasterHandler)  // skills_routes.go
+
+	// Integration routes (company API keys)
+	if deps.APIKeyService != nil {
+		SetupIntegrationRoutes(api, deps) // integration_routes.go
+	}
 
 	// Master data routes (industries, company sizes, locations)
 	if deps.MasterDataHandlers != nil {
@@ -110,6 +154,9 @@ func SetupRoutes(app *fiber.App, deps *Dependencies) {
 	if deps.PushNotificationHandler != nil {
 		SetupPushNotificationRoutes(api, deps.PushNotificationHandler, authMw) // push_notification_routes.go
 	}
+	if deps.NotificationHandler != nil {
+		SetupNotificationRoutes(api, deps.NotificationHandler, authMw) // notification_routes.go
+	}
 
 	// Chat routes
 	if deps.ChatHandler != nil {
@@ -4,11 +4,13 @@ import (
 	"github.com/gofiber/fiber/v2"
 
 	"keerja-backend/internal/handler/http/master"
+	"keerja-backend/internal/middleware"
 )
 
 // SetupSkillsRoutes sets up routes for skills master data
 func SetupSkillsRoutes(api fiber.Router, handler *master.SkillsMasterHandler) {
 	skills := api.Group("/skills")
+	skills.Use(middleware.ValidateQueryParams(middleware.SkillsQueryRules))
 
 	// Public endpoints - no authentication required for mobile app to fetch skills
 	skills.Get("/", handler.GetAllSkills)                    // GET /api/v1/skills - Get all skills with filters
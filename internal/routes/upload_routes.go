@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"keerja-backend/internal/config"
+	"keerja-backend/internal/handler/http/upload"
+	"keerja-backend/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupUploadRoutes serves locally stored uploads. Only used with the local storage
+// provider; s3 files are served from the bucket's public URL.
+//
+// Endpoints:
+//   - GET /uploads/*  - Uploaded image (avatars, covers, company logos and banners); every
+//     other folder is refused, documents are only served by the signed download route
+func SetupUploadRoutes(app *fiber.App, handler *upload.FileHandler) {
+	// Outside of /api/v1 so file URLs stay short and stable
+	app.Get(config.UploadRoutePrefix+"/*", handler.Serve)
+}
+
+// SetupDownloadRoutes configures the signed download route for private documents such as
+// company verification documents and application documents. The signed token authenticates
+// the request, so links work in a plain browser tab.
+//
+// Endpoints:
+//   - GET /api/v1/downloads/:token  - Download the document granted by the token
+func SetupDownloadRoutes(app *fiber.App, deps *Dependencies) {
+	// Registered on the app so the route always matches the links signed with DownloadRoutePrefix
+	app.Get(config.DownloadRoutePrefix+"/:token",
+		middleware.APIRateLimiter(),
+		deps.DownloadHandler.Download,
+	)
+}
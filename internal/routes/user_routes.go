@@ -7,7 +7,7 @@ import (
 )
 
 // SetupUserRoutes configures user routes
-// Routes: /api/v1/users/*
+// Routes: /api/v1/users/*, /api/v1/jobseeker/*
 func SetupUserRoutes(api fiber.Router, deps *Dependencies, authMw *middleware.AuthMiddleware) {
 	users := api.Group("/users")
 
@@ -41,6 +41,7 @@ func SetupUserRoutes(api fiber.Router, deps *Dependencies, authMw *middleware.Au
 	users.Get("/me/documents", deps.UserDocumentHandler.GetDocuments)
 	users.Post("/me/documents",
 		middleware.UploadRateLimiter(),
+		middleware.Idempotency(deps.IdempotencyStore, middleware.DefaultIdempotencyTTL),
 		middleware.ValidateFileUpload(middleware.FileUploadConfig{
 			MaxFileSize: 10 * 1024 * 1024, // 10MB
 			AllowedMimeTypes: []string{
@@ -74,4 +75,38 @@ func SetupUserRoutes(api fiber.Router, deps *Dependencies, authMw *middleware.Au
 		}),
 		deps.UserProfileHandler.UploadProfilePhoto,
 	)
+
+	// Job seeker only routes
+	jobseeker := api.Group("/jobseeker", authMw.AuthRequired(), authMw.JobSeekerOnly())
+	jobseeker.Get("/profile/completeness", deps.UserProfileHandler.GetProfileCompleteness)
+
+	// Application progress without the hiring team's notes and evaluations
+	jobseeker.Get("/applications/:id/timeline", deps.ApplicationHandler.GetTimeline)
+
+	// Notification channels per event and quiet hours, enforced by the notification dispatcher
+	jobseeker.Get("/preferences/notifications", deps.UserProfileHandler.GetNotificationSettings)
+	jobseeker.Put("/preferences/notifications", deps.UserProfileHandler.UpdateNotificationSettings)
+
+	// Phone verification by SMS OTP for the number on the profile (AuthHandler)
+	jobseeker.Post("/phone/request-otp", middleware.EmailRateLimiter(), deps.AuthHandler.RequestPhoneOTP)
+	jobseeker.Post("/phone/verify", middleware.AuthRateLimiter(), deps.AuthHandler.VerifyPhoneOTP)
+
+	// Account privacy routes (AccountPrivacyHandler)
+	// Deletion is carried out by the account_deletion job once the grace period has ended
+	jobseeker.Post("/account/delete-request", deps.AccountPrivacyHandler.RequestDeletion)
+	jobseeker.Get("/account/delete-request", deps.AccountPrivacyHandler.GetDeletionRequest)
+	jobseeker.Delete("/account/delete-request", deps.AccountPrivacyHandler.CancelDeletion)
+	// The export is generated by the data_export job, which emails the download link
+	jobseeker.Get("/account/export", deps.AccountPrivacyHandler.RequestDataExport)
+}
+
+// SetupDataExportRoutes configures the public personal data export download route users
+// reach from their export email. The token authenticates the request.
+// Routes: /api/v1/account/data-export/*
+func SetupDataExportRoutes(api fiber.Router, deps *Dependencies) {
+	exports := api.Group("/account/data-export")
+	exports.Use(middleware.APIRateLimiter())
+
+	// GET /api/v1/account/data-export/:token - Download the export as a JSON file
+	exports.Get("/:token", deps.AccountPrivacyHandler.DownloadDataExport)
 }
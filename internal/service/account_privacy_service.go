@@ -0,0 +1,481 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/audit"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/utils"
+)
+
+const (
+	// accountDeletionBatchSize caps the accounts deleted in one run of the account deletion job
+	accountDeletionBatchSize = 50
+	// dataExportBatchSize caps the exports generated in one run of the data export job
+	dataExportBatchSize = 20
+	// dataExportPageSize is the page size used to read a user's applications and follows
+	dataExportPageSize = 100
+	// dataExportTokenLength is the number of random bytes in a data export download token
+	dataExportTokenLength = 32
+	// deletedEmailDomain is the domain of the placeholder emails of deleted accounts. The
+	// .invalid TLD can never receive mail.
+	deletedEmailDomain = "deleted.keerja.invalid"
+)
+
+// AccountPrivacyConfig holds the account deletion grace period and the data export link lifetime
+type AccountPrivacyConfig struct {
+	DeletionGracePeriod time.Duration // Defaults to user.DefaultAccountDeletionGracePeriod
+	ExportTTL           time.Duration // Defaults to user.DefaultDataExportTTL
+}
+
+// accountPrivacyService implements user.AccountPrivacyService
+type accountPrivacyService struct {
+	privacyRepo   user.AccountPrivacyRepository
+	userRepo      user.UserRepository
+	appRepo       application.ApplicationRepository
+	companyRepo   company.CompanyRepository
+	uploadService UploadService
+	emailService  email.EmailService
+	auditService  audit.AuditService
+	gracePeriod   time.Duration
+	exportTTL     time.Duration
+}
+
+// NewAccountPrivacyService creates a new account privacy service
+func NewAccountPrivacyService(
+	privacyRepo user.AccountPrivacyRepository,
+	userRepo user.UserRepository,
+	appRepo application.ApplicationRepository,
+	companyRepo company.CompanyRepository,
+	uploadService UploadService,
+	emailService email.EmailService,
+	auditService audit.AuditService,
+	cfg AccountPrivacyConfig,
+) user.AccountPrivacyService {
+	if cfg.DeletionGracePeriod <= 0 {
+		cfg.DeletionGracePeriod = user.DefaultAccountDeletionGracePeriod
+	}
+	if cfg.ExportTTL <= 0 {
+		cfg.ExportTTL = user.DefaultDataExportTTL
+	}
+	return &accountPrivacyService{
+		privacyRepo:   privacyRepo,
+		userRepo:      userRepo,
+		appRepo:       appRepo,
+		companyRepo:   companyRepo,
+		uploadService: uploadService,
+		emailService:  emailService,
+		auditService:  auditService,
+		gracePeriod:   cfg.DeletionGracePeriod,
+		exportTTL:     cfg.ExportTTL,
+	}
+}
+
+// ===== Account Deletion =====
+
+// RequestDeletion schedules the account for deletion once the grace period has ended
+func (s *accountPrivacyService) RequestDeletion(ctx context.Context, userID int64) (*user.AccountDeletionRequest, error) {
+	if _, err := s.activeUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	pending, err := s.privacyRepo.FindPendingDeletionRequest(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deletion request: %w", err)
+	}
+	if pending != nil {
+		return nil, user.ErrDeletionAlreadyRequested
+	}
+
+	req := &user.AccountDeletionRequest{
+		UserID:       userID,
+		Status:       user.DeletionRequestPending,
+		ScheduledFor: time.Now().Add(s.gracePeriod),
+	}
+	if err := s.privacyRepo.CreateDeletionRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	s.recordUserAudit(ctx, userID, audit.ActionDeletionRequested, audit.Metadata{
+		"request_id":    req.ID,
+		"scheduled_for": req.ScheduledFor,
+	})
+	return req, nil
+}
+
+// CancelDeletion cancels the user's pending deletion request
+func (s *accountPrivacyService) CancelDeletion(ctx context.Context, userID int64) error {
+	req, err := s.privacyRepo.FindPendingDeletionRequest(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get deletion request: %w", err)
+	}
+	if req == nil {
+		return user.ErrNoPendingDeletion
+	}
+
+	now := time.Now()
+	req.Status = user.DeletionRequestCanceled
+	req.CanceledAt = &now
+	if err := s.privacyRepo.UpdateDeletionRequest(ctx, req); err != nil {
+		return fmt.Errorf("failed to cancel deletion request: %w", err)
+	}
+
+	s.recordUserAudit(ctx, userID, audit.ActionDeletionCanceled, audit.Metadata{"request_id": req.ID})
+	return nil
+}
+
+// GetPendingDeletion returns the user's pending deletion request, or nil when there is none
+func (s *accountPrivacyService) GetPendingDeletion(ctx context.Context, userID int64) (*user.AccountDeletionRequest, error) {
+	req, err := s.privacyRepo.FindPendingDeletionRequest(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deletion request: %w", err)
+	}
+	return req, nil
+}
+
+// ExecuteDueDeletions anonymizes the accounts whose grace period has ended. A failed
+// deletion is logged and left pending, so the next run retries it.
+func (s *accountPrivacyService) ExecuteDueDeletions(ctx context.Context) (int, error) {
+	reqs, err := s.privacyRepo.FindDueDeletionRequests(ctx, time.Now(), accountDeletionBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get due deletion requests: %w", err)
+	}
+
+	deleted := 0
+	for i := range reqs {
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+		if err := s.executeDeletion(ctx, &reqs[i]); err != nil {
+			log.Printf("Failed to delete account of user %d: %v", reqs[i].UserID, err)
+			continue
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// executeDeletion anonymizes the account of a due request, removes its uploaded files and
+// completes the request
+func (s *accountPrivacyService) executeDeletion(ctx context.Context, req *user.AccountDeletionRequest) error {
+	usr, err := s.userRepo.FindByID(ctx, req.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	filesDeleted := 0
+	if usr != nil && !usr.IsDeleted() {
+		fileURLs, err := s.privacyRepo.AnonymizeUser(ctx, usr.ID, deletedUserEmail(usr))
+		if err != nil {
+			return fmt.Errorf("failed to anonymize user: %w", err)
+		}
+
+		// Files are removed after the commit, so a failed transaction never leaves rows
+		// pointing at missing files. A file that cannot be removed is only logged.
+		for _, fileURL := range fileURLs {
+			if err := s.uploadService.DeleteFile(ctx, fileURL); err != nil {
+				log.Printf("Failed to delete file of deleted user %d: %v", usr.ID, err)
+				continue
+			}
+			filesDeleted++
+		}
+	}
+
+	now := time.Now()
+	req.Status = user.DeletionRequestCompleted
+	req.CompletedAt = &now
+	if err := s.privacyRepo.UpdateDeletionRequest(ctx, req); err != nil {
+		return fmt.Errorf("failed to complete deletion request: %w", err)
+	}
+
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		ActorType:  audit.ActorSystem,
+		Action:     audit.ActionAccountDeleted,
+		EntityType: audit.EntityUser,
+		EntityID:   req.UserID,
+		Metadata:   audit.Metadata{"request_id": req.ID, "files_deleted": filesDeleted},
+	})
+	return nil
+}
+
+// deletedUserEmail returns the placeholder email of a deleted account. It is a hash of the
+// account's random UUID rather than of the old email, so it cannot be matched back to the
+// address, and it stays unique so the old address can register again.
+func deletedUserEmail(usr *user.User) string {
+	sum := sha256.Sum256([]byte("deleted-user:" + usr.UUID.String()))
+	return "deleted-" + hex.EncodeToString(sum[:12]) + "@" + deletedEmailDomain
+}
+
+// ===== Data Export =====
+
+// dataExportBundle is the JSON document a user downloads with their data export
+type dataExportBundle struct {
+	GeneratedAt       time.Time               `json:"generated_at"`
+	Account           *user.User              `json:"account"`
+	Applications      []dataExportApplication `json:"applications"`
+	Reviews           []company.CompanyReview `json:"reviews"`
+	FollowedCompanies []dataExportCompany     `json:"followed_companies"`
+}
+
+// dataExportApplication is an application as the applicant submitted it. Employer-side
+// data such as notes, bookmarks and match scores is left out. Resumes and documents copied
+// from the document library at apply time name the library document they were copied from.
+type dataExportApplication struct {
+	ID              int64                `json:"id"`
+	JobID           int64                `json:"job_id"`
+	CompanyID       *int64               `json:"company_id,omitempty"`
+	Status          string               `json:"status"`
+	Source          string               `json:"source"`
+	ResumeURL       string               `json:"resume_url,omitempty"`
+	ResumeSourceURL *string              `json:"resume_source_url,omitempty"`
+	CoverNote       string               `json:"cover_note,omitempty"`
+	AppliedAt       time.Time            `json:"applied_at"`
+	ClosedAt        *time.Time           `json:"closed_at,omitempty"`
+	Documents       []dataExportDocument `json:"documents,omitempty"`
+}
+
+// dataExportDocument is an application document with the library document it was copied from
+type dataExportDocument struct {
+	application.ApplicationDocument
+	SourceURL *string `json:"source_url,omitempty"`
+}
+
+// dataExportCompany is a company the user follows
+type dataExportCompany struct {
+	ID          int64  `json:"id"`
+	CompanyName string `json:"company_name"`
+	Slug        string `json:"slug"`
+}
+
+// RequestDataExport queues a data export, or returns the user's export that is still
+// pending or downloadable
+func (s *accountPrivacyService) RequestDataExport(ctx context.Context, userID int64) (*user.DataExport, error) {
+	if _, err := s.activeUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	open, err := s.privacyRepo.FindOpenDataExport(ctx, userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data export: %w", err)
+	}
+	if open != nil {
+		return open, nil
+	}
+
+	export := &user.DataExport{UserID: userID, Status: user.DataExportPending}
+	if err := s.privacyRepo.CreateDataExport(ctx, export); err != nil {
+		return nil, fmt.Errorf("failed to create data export: %w", err)
+	}
+
+	s.recordUserAudit(ctx, userID, audit.ActionDataExportRequested, audit.Metadata{"export_id": export.ID})
+	return export, nil
+}
+
+// ProcessDataExports expires exports whose link has lapsed, then generates pending ones
+// and emails their download links
+func (s *accountPrivacyService) ProcessDataExports(ctx context.Context) (int, error) {
+	now := time.Now()
+	expired, err := s.privacyRepo.ExpireDataExports(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire data exports: %w", err)
+	}
+	processed := int(expired)
+
+	pending, err := s.privacyRepo.FindPendingDataExports(ctx, dataExportBatchSize)
+	if err != nil {
+		return processed, fmt.Errorf("failed to get pending data exports: %w", err)
+	}
+
+	for i := range pending {
+		if err := ctx.Err(); err != nil {
+			return processed, err
+		}
+		if err := s.generateDataExport(ctx, &pending[i]); err != nil {
+			log.Printf("Failed to generate data export %d: %v", pending[i].ID, err)
+			continue
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// generateDataExport builds the export bundle, stores it with a download token and emails
+// the link. Exports that cannot be built are marked failed.
+func (s *accountPrivacyService) generateDataExport(ctx context.Context, export *user.DataExport) error {
+	usr, data, err := s.buildDataExport(ctx, export.UserID)
+	if err != nil {
+		message := err.Error()
+		export.Status = user.DataExportFailed
+		export.Error = &message
+		if updateErr := s.privacyRepo.UpdateDataExport(ctx, export); updateErr != nil {
+			return fmt.Errorf("failed to mark data export failed: %w", updateErr)
+		}
+		return err
+	}
+
+	token, err := utils.GenerateSecureToken(dataExportTokenLength)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.exportTTL)
+	tokenHash := hashDataExportToken(token)
+	export.Status = user.DataExportReady
+	export.Data = data
+	export.TokenHash = &tokenHash
+	export.CompletedAt = &now
+	export.ExpiresAt = &expiresAt
+	if err := s.privacyRepo.UpdateDataExport(ctx, export); err != nil {
+		return fmt.Errorf("failed to save data export: %w", err)
+	}
+
+	if err := s.emailService.SendDataExportEmail(ctx, usr.Email, usr.FullName, token, expiresAt); err != nil {
+		log.Printf("Failed to send data export email for export %d: %v", export.ID, err)
+	}
+	return nil
+}
+
+// buildDataExport collects the user's profile, applications, reviews and followed companies
+// into the JSON export bundle
+func (s *accountPrivacyService) buildDataExport(ctx context.Context, userID int64) (*user.User, []byte, error) {
+	usr, err := s.userRepo.GetFullProfile(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+	if usr == nil || usr.IsDeleted() {
+		return nil, nil, ErrUserNotFound
+	}
+
+	bundle := dataExportBundle{
+		GeneratedAt:       time.Now(),
+		Account:           usr,
+		Applications:      []dataExportApplication{},
+		FollowedCompanies: []dataExportCompany{},
+	}
+
+	documents, err := s.appRepo.ListDocumentsByUser(ctx, userID, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get application documents: %w", err)
+	}
+	documentsByApp := make(map[int64][]dataExportDocument)
+	for _, doc := range documents {
+		documentsByApp[doc.ApplicationID] = append(documentsByApp[doc.ApplicationID], dataExportDocument{ApplicationDocument: doc, SourceURL: doc.SourceURL})
+	}
+
+	for page := 1; ; page++ {
+		apps, _, err := s.appRepo.ListByUser(ctx, userID, application.ApplicationFilter{}, page, dataExportPageSize)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get applications: %w", err)
+		}
+		for _, app := range apps {
+			bundle.Applications = append(bundle.Applications, dataExportApplication{
+				ID:              app.ID,
+				JobID:           app.JobID,
+				CompanyID:       app.CompanyID,
+				Status:          app.Status,
+				Source:          app.Source,
+				ResumeURL:       app.ResumeURL,
+				ResumeSourceURL: app.ResumeSourceURL,
+				CoverNote:       app.NotesText,
+				AppliedAt:       app.AppliedAt,
+				ClosedAt:        app.ClosedAt,
+				Documents:       documentsByApp[app.ID],
+			})
+		}
+		if len(apps) < dataExportPageSize {
+			break
+		}
+	}
+
+	bundle.Reviews, err = s.companyRepo.GetReviewsByUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get reviews: %w", err)
+	}
+	if bundle.Reviews == nil {
+		bundle.Reviews = []company.CompanyReview{}
+	}
+
+	for page := 1; ; page++ {
+		companies, _, err := s.companyRepo.GetFollowedCompanies(ctx, userID, page, dataExportPageSize)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get followed companies: %w", err)
+		}
+		for _, comp := range companies {
+			bundle.FollowedCompanies = append(bundle.FollowedCompanies, dataExportCompany{
+				ID:          comp.ID,
+				CompanyName: comp.CompanyName,
+				Slug:        comp.Slug,
+			})
+		}
+		if len(companies) < dataExportPageSize {
+			break
+		}
+	}
+
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode data export: %w", err)
+	}
+	return usr, data, nil
+}
+
+// DownloadDataExport returns the export with the download token while its link is valid
+func (s *accountPrivacyService) DownloadDataExport(ctx context.Context, token string) (*user.DataExport, error) {
+	if token == "" {
+		return nil, user.ErrDataExportNotFound
+	}
+
+	export, err := s.privacyRepo.FindDataExportByTokenHash(ctx, hashDataExportToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data export: %w", err)
+	}
+	if export == nil {
+		return nil, user.ErrDataExportNotFound
+	}
+	if !export.IsDownloadable(time.Now()) {
+		return nil, user.ErrDataExportExpired
+	}
+
+	s.recordUserAudit(ctx, export.UserID, audit.ActionDataExportDownloaded, audit.Metadata{"export_id": export.ID})
+	return export, nil
+}
+
+// hashDataExportToken returns the SHA-256 hex digest stored for a data export download token
+func hashDataExportToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// activeUser returns the user, failing for accounts that do not exist or are already deleted
+func (s *accountPrivacyService) activeUser(ctx context.Context, userID int64) (*user.User, error) {
+	usr, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if usr == nil || usr.IsDeleted() {
+		return nil, ErrUserNotFound
+	}
+	return usr, nil
+}
+
+// recordUserAudit records an action the user took on their own account
+func (s *accountPrivacyService) recordUserAudit(ctx context.Context, userID int64, action string, metadata audit.Metadata) {
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		ActorType:  audit.ActorUser,
+		ActorID:    &userID,
+		Action:     action,
+		EntityType: audit.EntityUser,
+		EntityID:   userID,
+		Metadata:   metadata,
+	})
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/admin"
+)
+
+// adminAnalyticsSummaryTTL is how long a dashboard summary is served from cache
+const adminAnalyticsSummaryTTL = 10 * time.Minute
+
+// adminAnalyticsService implements admin.AdminAnalyticsService interface
+type adminAnalyticsService struct {
+	analyticsRepo admin.AdminAnalyticsRepository
+	cache         cache.Cache
+}
+
+// NewAdminAnalyticsService creates a new admin analytics service instance
+func NewAdminAnalyticsService(analyticsRepo admin.AdminAnalyticsRepository, cacheService cache.Cache) admin.AdminAnalyticsService {
+	return &adminAnalyticsService{
+		analyticsRepo: analyticsRepo,
+		cache:         cacheService,
+	}
+}
+
+// GetPlatformSummary returns the platform metrics between from and to with daily, weekly
+// or monthly series and deltas against the previous period of the same length
+func (s *adminAnalyticsService) GetPlatformSummary(ctx context.Context, from, to time.Time, interval string) (*admin.PlatformSummaryResponse, error) {
+	if interval == "" {
+		interval = admin.AnalyticsIntervalDay
+	}
+	if !admin.ValidAnalyticsInterval(interval) {
+		return nil, admin.ErrInvalidAnalyticsInterval
+	}
+
+	from, to = from.UTC(), to.UTC()
+	if !from.Before(to) {
+		return nil, admin.ErrInvalidAnalyticsRange
+	}
+	buckets := analyticsBuckets(from, to, interval)
+	if len(buckets) > admin.MaxAnalyticsBuckets {
+		return nil, admin.ErrInvalidAnalyticsRange
+	}
+
+	cacheKey := fmt.Sprintf("admin:analytics:summary:%d:%d:%s", from.Unix(), to.Unix(), interval)
+	if s.cache != nil {
+		if summary, ok := cache.GetTyped[*admin.PlatformSummaryResponse](s.cache, cacheKey); ok {
+			return summary, nil
+		}
+	}
+
+	previousFrom := from.Add(-to.Sub(from))
+	counts, err := s.analyticsRepo.CountByBucket(ctx, previousFrom, from, to, interval)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count platform metrics: %w", err)
+	}
+
+	summary := buildPlatformSummary(counts, buckets, interval)
+	summary.From = from
+	summary.To = to
+	summary.PreviousFrom = previousFrom
+	summary.PreviousTo = from
+	summary.GeneratedAt = time.Now().UTC()
+
+	if s.cache != nil {
+		s.cache.Set(cacheKey, summary, adminAnalyticsSummaryTTL)
+	}
+
+	return summary, nil
+}
+
+// analyticsBuckets returns the start of every bucket overlapping [from, to)
+func analyticsBuckets(from, to time.Time, interval string) []time.Time {
+	var buckets []time.Time
+	for bucket := admin.TruncateToInterval(from, interval); bucket.Before(to); bucket = admin.NextBucket(bucket, interval) {
+		buckets = append(buckets, bucket)
+		if len(buckets) > admin.MaxAnalyticsBuckets {
+			break
+		}
+	}
+	return buckets
+}
+
+// buildPlatformSummary totals the bucket counts of each metric and lays the current
+// period's counts over a zero-filled series
+func buildPlatformSummary(counts []admin.AnalyticsBucketCount, buckets []time.Time, interval string) *admin.PlatformSummaryResponse {
+	type metricCounts struct {
+		total, previous int64
+		byBucket        map[time.Time]int64
+	}
+
+	metrics := make(map[string]*metricCounts)
+	for _, c := range counts {
+		m, ok := metrics[c.Metric]
+		if !ok {
+			m = &metricCounts{byBucket: make(map[time.Time]int64)}
+			metrics[c.Metric] = m
+		}
+		if c.Previous {
+			m.previous += c.Count
+			continue
+		}
+		m.total += c.Count
+		m.byBucket[c.Bucket.UTC()] += c.Count
+	}
+
+	metric := func(name string) admin.PlatformMetric {
+		m, ok := metrics[name]
+		if !ok {
+			m = &metricCounts{}
+		}
+
+		series := make([]admin.AnalyticsPoint, 0, len(buckets))
+		for _, bucket := range buckets {
+			series = append(series, admin.AnalyticsPoint{Bucket: bucket, Count: m.byBucket[bucket]})
+		}
+
+		result := admin.PlatformMetric{
+			Total:         m.total,
+			PreviousTotal: m.previous,
+			Change:        m.total - m.previous,
+			Series:        series,
+		}
+		if m.previous > 0 {
+			percent := math.Round(float64(m.total-m.previous)/float64(m.previous)*1000) / 10
+			result.ChangePercent = &percent
+		}
+		return result
+	}
+
+	return &admin.PlatformSummaryResponse{
+		Interval:              interval,
+		NewUsers:              metric(admin.MetricNewUsers),
+		NewCompanies:          metric(admin.MetricNewCompanies),
+		JobsPublished:         metric(admin.MetricJobsPublished),
+		ApplicationsSubmitted: metric(admin.MetricApplicationsSubmitted),
+		Hires:                 metric(admin.MetricHires),
+	}
+}
@@ -7,138 +7,60 @@ import (
 
 	"keerja-backend/internal/cache"
 	"keerja-backend/internal/domain/admin"
+	"keerja-backend/internal/domain/audit"
 	"keerja-backend/internal/domain/company"
 	"keerja-backend/internal/domain/job"
 )
 
 // adminCompanyService implements admin.AdminCompanyService interface
 type adminCompanyService struct {
-	companyRepo  company.CompanyRepository
-	jobRepo      job.JobRepository
-	emailService EmailService
-	cache        cache.Cache
+	companyRepo      company.CompanyRepository
+	adminCompanyRepo admin.AdminCompanyRepository
+	jobRepo          job.JobRepository
+	emailService     EmailService
+	cache            cache.Cache
+	auditService     audit.AuditService
 }
 
 // NewAdminCompanyService creates a new admin company service instance
 func NewAdminCompanyService(
 	companyRepo company.CompanyRepository,
+	adminCompanyRepo admin.AdminCompanyRepository,
 	jobRepo job.JobRepository,
 	emailService EmailService,
 	cacheService cache.Cache,
+	auditService audit.AuditService,
 ) admin.AdminCompanyService {
 	return &adminCompanyService{
-		companyRepo:  companyRepo,
-		jobRepo:      jobRepo,
-		emailService: emailService,
-		cache:        cacheService,
+		companyRepo:      companyRepo,
+		adminCompanyRepo: adminCompanyRepo,
+		jobRepo:          jobRepo,
+		emailService:     emailService,
+		cache:            cacheService,
+		auditService:     auditService,
 	}
 }
 
-// ListCompanies retrieves companies with filters, pagination, and search
-// Task 2.1: List companies with advanced filtering
-func (s *adminCompanyService) ListCompanies(ctx context.Context, req *admin.AdminCompanyListRequest) (*admin.AdminCompanyListResponse, error) {
-	// Build company filter
-	filter := &company.CompanyFilter{
-		IndustryID:    req.IndustryID,
-		CompanySizeID: req.CompanySizeID,
-		ProvinceID:    req.ProvinceID,
-		CityID:        req.CityID,
-		Verified:      req.Verified,
-		IsActive:      req.IsActive,
-		SearchQuery:   toStringPtr(req.Search),
-		Page:          req.Page,
-		Limit:         req.Limit,
-		SortBy:        req.SortBy,
-		SortOrder:     req.SortOrder,
-	}
-
-	// Fetch companies with master data
-	companies, total, err := s.companyRepo.ListWithMasterData(ctx, filter)
+// ListCompaniesAdmin retrieves companies with filters, aggregate counts, and pagination
+func (s *adminCompanyService) ListCompaniesAdmin(ctx context.Context, filter *admin.AdminCompanyFilter) (*admin.AdminCompanyListResponse, error) {
+	items, total, err := s.adminCompanyRepo.ListCompanies(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch companies: %w", err)
 	}
-
-	// Map companies to response items
-	items := make([]admin.AdminCompanyListItem, 0, len(companies))
-	for _, c := range companies {
-		// Get industry name
-		industryName := ""
-		if c.Industry != nil {
-			industryName = *c.Industry
-		}
-
-		// Get company size name
-		companySizeName := ""
-		if c.SizeCategory != nil {
-			companySizeName = *c.SizeCategory
-		}
-
-		// Build location string
-		location := ""
-		if c.Province != nil {
-			location = *c.Province
-			if c.City != nil {
-				location = *c.City + ", " + location
-			}
-		}
-
-		// Get verification status
-		verificationStatus := "pending"
-		if c.Verified {
-			verificationStatus = "verified"
-		}
-
-		// Get legal name
-		legalName := ""
-		if c.LegalName != nil {
-			legalName = *c.LegalName
-		}
-
-		// Get registration number
-		registrationNumber := ""
-		if c.RegistrationNumber != nil {
-			registrationNumber = *c.RegistrationNumber
-		}
-
-		// Count total jobs for this company
-		jobFilter := job.JobFilter{
-			CompanyID: c.ID,
-		}
-		_, totalJobs, _ := s.jobRepo.List(ctx, jobFilter, 1, 1)
-
-		item := admin.AdminCompanyListItem{
-			ID:                 c.ID,
-			UUID:               c.UUID.String(),
-			CompanyName:        c.CompanyName,
-			Slug:               c.Slug,
-			LegalName:          legalName,
-			RegistrationNumber: registrationNumber,
-			Industry:           industryName,
-			CompanySize:        companySizeName,
-			Location:           location,
-			Verified:           c.Verified,
-			VerifiedAt:         c.VerifiedAt,
-			IsActive:           c.IsActive,
-			VerificationStatus: verificationStatus,
-			TotalJobs:          totalJobs,
-			ActiveJobs:         totalJobs, // TODO: Get active jobs count
-			TotalApplications:  0,         // TODO: Get from application table
-			CreatedAt:          c.CreatedAt,
-			UpdatedAt:          c.UpdatedAt,
-		}
-		items = append(items, item)
+	if items == nil {
+		items = []admin.AdminCompanyListItem{}
 	}
 
 	// Calculate pagination
-	totalPages := (total + int64(req.Limit) - 1) / int64(req.Limit)
-	hasNext := req.Page < int(totalPages)
-	hasPrev := req.Page > 1
+	totalPages := (total + int64(filter.Limit) - 1) / int64(filter.Limit)
+	hasNext := filter.Page < int(totalPages)
+	hasPrev := filter.Page > 1
 
 	return &admin.AdminCompanyListResponse{
 		Companies:  items,
 		Total:      total,
-		Page:       req.Page,
-		Limit:      req.Limit,
+		Page:       filter.Page,
+		Limit:      filter.Limit,
 		TotalPages: int(totalPages),
 		HasNext:    hasNext,
 		HasPrev:    hasPrev,
@@ -165,19 +87,21 @@ func (s *adminCompanyService) UpdateCompanyStatus(ctx context.Context, companyID
 		return fmt.Errorf("company not found: %w", err)
 	}
 
+	previousVerified := comp.Verified
+
 	// Validate status transition
 	validStatuses := map[string]bool{
-		"pending":   true,
-		"verified":  true,
-		"rejected":  true,
-		"suspended": true,
+		company.VerificationStatusPending:   true,
+		company.VerificationStatusVerified:  true,
+		company.VerificationStatusRejected:  true,
+		company.VerificationStatusSuspended: true,
 	}
 	if !validStatuses[req.Status] {
 		return fmt.Errorf("invalid status: %s", req.Status)
 	}
 
 	// Validation: rejection_reason required for rejected status
-	if req.Status == "rejected" && (req.RejectionReason == nil || *req.RejectionReason == "") {
+	if req.Status == company.VerificationStatusRejected && (req.RejectionReason == nil || *req.RejectionReason == "") {
 		return fmt.Errorf("rejection_reason is required when status is rejected")
 	}
 
@@ -195,7 +119,10 @@ func (s *adminCompanyService) UpdateCompanyStatus(ctx context.Context, companyID
 			NPWPNumber: "", // Empty NPWP when created by admin without verification request
 		}
 	} else {
-		// Update existing verification record
+		// Update existing verification record; setting the current status again renews it
+		if verification.Status != req.Status && !company.IsValidVerificationTransition(verification.Status, req.Status) {
+			return company.ErrInvalidVerificationTransition.WithField("status", verification.Status)
+		}
 		verification.Status = req.Status
 	}
 
@@ -208,7 +135,7 @@ func (s *adminCompanyService) UpdateCompanyStatus(ctx context.Context, companyID
 		verification.BadgeGranted = *req.GrantBadge
 	}
 
-	if req.Status == "verified" {
+	if req.Status == company.VerificationStatusVerified {
 		// Set verified flag
 		comp.Verified = true
 		comp.VerifiedAt = &now
@@ -216,16 +143,18 @@ func (s *adminCompanyService) UpdateCompanyStatus(ctx context.Context, companyID
 		// Set verification expiry to 1 year from now
 		expiry := now.AddDate(1, 0, 0)
 		verification.VerificationExpiry = &expiry
+		verification.GraceUntil = nil
+		verification.ExpiryWarningDays = nil
 
 		// Update all jobs for this company from 'in_review' to 'draft'
-		if err := s.jobRepo.UpdateStatusByCompany(ctx, companyID, "in_review", "draft"); err != nil {
+		if err := s.jobRepo.UpdateStatusByCompany(ctx, companyID, job.StatusInReview, job.StatusDraft); err != nil {
 			return fmt.Errorf("failed to update jobs to draft: %w", err)
 		}
-	} else if req.Status == "rejected" {
+	} else if req.Status == company.VerificationStatusRejected {
 		// Keep verified as false, set rejection reason
 		comp.Verified = false
 		verification.RejectionReason = req.RejectionReason
-	} else if req.Status == "suspended" {
+	} else if req.Status == company.VerificationStatusSuspended {
 		// Suspend the company
 		comp.Verified = false
 	}
@@ -272,13 +201,40 @@ func (s *adminCompanyService) UpdateCompanyStatus(ctx context.Context, companyID
 		s.cache.Delete(cache.GenerateCacheKey("user", "companies", emp.UserID))
 	}
 
-	// TODO: Create audit log entry
+	metadata := audit.Metadata{"status": req.Status, "previously_verified": previousVerified}
+	if req.RejectionReason != nil {
+		metadata["rejection_reason"] = *req.RejectionReason
+	}
+	if req.Notes != nil {
+		metadata["notes"] = *req.Notes
+	}
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		ActorID:    &adminID,
+		ActorType:  audit.ActorAdmin,
+		Action:     companyStatusAuditAction(req.Status),
+		EntityType: audit.EntityCompany,
+		EntityID:   companyID,
+		Metadata:   metadata,
+	})
+
 	// TODO: Send email notification to company based on status
 
 	fmt.Printf("✓ Company %d status updated to %s by admin %d\n", companyID, req.Status, adminID)
 	return nil
 }
 
+// companyStatusAuditAction maps an admin company status change to its audit action
+func companyStatusAuditAction(status string) string {
+	switch status {
+	case company.VerificationStatusVerified:
+		return audit.ActionVerificationApproved
+	case company.VerificationStatusRejected:
+		return audit.ActionVerificationRejected
+	default:
+		return audit.ActionCompanyStatusChanged
+	}
+}
+
 // UpdateCompany updates company details (admin support)
 func (s *adminCompanyService) UpdateCompany(ctx context.Context, companyID int64, req *admin.AdminUpdateCompanyRequest, adminID int64) error {
 	// Get company
@@ -296,19 +252,94 @@ func (s *adminCompanyService) UpdateCompany(ctx context.Context, companyID int64
 	return nil
 }
 
-// DeleteCompany deletes a company with validation
+// DeleteCompany soft deletes a company. Published jobs block the deletion unless forced,
+// in which case they are closed.
 func (s *adminCompanyService) DeleteCompany(ctx context.Context, companyID int64, req *admin.AdminDeleteCompanyRequest, adminID int64) error {
-	// Get company
-	_, err := s.companyRepo.FindByID(ctx, companyID)
+	comp, err := s.companyRepo.FindByID(ctx, companyID)
 	if err != nil {
-		return fmt.Errorf("company not found: %w", err)
+		return fmt.Errorf("failed to get company: %w", err)
+	}
+	if comp == nil {
+		return company.ErrCompanyNotFound
 	}
 
-	// TODO: Check for active jobs unless force flag is set
-	// TODO: Soft delete or hard delete based on business rules
-	// TODO: Create audit log entry
+	_, activeJobs, err := s.jobRepo.ListByCompany(ctx, companyID, job.JobFilter{Status: job.StatusPublished}, 1, 1)
+	if err != nil {
+		return fmt.Errorf("failed to count active jobs: %w", err)
+	}
+	if activeJobs > 0 {
+		if !req.Force {
+			return company.ErrCompanyHasActiveJobs
+		}
+		if err := s.jobRepo.UpdateStatusByCompany(ctx, companyID, job.StatusPublished, job.StatusClosed); err != nil {
+			return fmt.Errorf("failed to close active jobs: %w", err)
+		}
+	}
+
+	employers, _ := s.companyRepo.GetEmployerUsersByCompanyID(ctx, companyID)
+
+	if err := s.companyRepo.Delete(ctx, companyID); err != nil {
+		return fmt.Errorf("failed to delete company: %w", err)
+	}
+
+	invalidateCompanyVisibilityCaches(s.cache, comp, employers)
+
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		ActorID:    &adminID,
+		ActorType:  audit.ActorAdmin,
+		Action:     audit.ActionCompanyDeleted,
+		EntityType: audit.EntityCompany,
+		EntityID:   companyID,
+		Metadata: audit.Metadata{
+			"company_name": comp.CompanyName,
+			"reason":       req.Reason,
+			"force":        req.Force,
+			"closed_jobs":  activeJobs,
+		},
+	})
+	return nil
+}
+
+// RestoreCompany restores a soft-deleted company as long as its slug is still its own
+func (s *adminCompanyService) RestoreCompany(ctx context.Context, companyID int64, adminID int64) error {
+	comp, err := s.companyRepo.FindDeletedByID(ctx, companyID)
+	if err != nil {
+		return fmt.Errorf("failed to get company: %w", err)
+	}
+	if comp == nil {
+		live, err := s.companyRepo.FindByID(ctx, companyID)
+		if err != nil {
+			return fmt.Errorf("failed to get company: %w", err)
+		}
+		if live != nil {
+			return company.ErrCompanyNotDeleted
+		}
+		return company.ErrCompanyNotFound
+	}
+
+	taken, err := s.companyRepo.SlugExists(ctx, comp.Slug, companyID)
+	if err != nil {
+		return fmt.Errorf("failed to check slug: %w", err)
+	}
+	if taken {
+		return company.ErrCompanySlugTaken.WithField("slug", comp.Slug)
+	}
 
-	fmt.Printf("TODO: Delete company %d (force: %v, admin: %d)\n", companyID, req.Force, adminID)
+	if err := s.companyRepo.Restore(ctx, companyID); err != nil {
+		return fmt.Errorf("failed to restore company: %w", err)
+	}
+
+	employers, _ := s.companyRepo.GetEmployerUsersByCompanyID(ctx, companyID)
+	invalidateCompanyVisibilityCaches(s.cache, comp, employers)
+
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		ActorID:    &adminID,
+		ActorType:  audit.ActorAdmin,
+		Action:     audit.ActionCompanyRestored,
+		EntityType: audit.EntityCompany,
+		EntityID:   companyID,
+		Metadata:   audit.Metadata{"company_name": comp.CompanyName},
+	})
 	return nil
 }
 
@@ -362,9 +393,9 @@ func (s *adminCompanyService) BulkUpdateStatus(ctx context.Context, companyIDs [
 
 	// Validate status
 	validStatuses := map[string]bool{
-		"verified":  true,
-		"rejected":  true,
-		"suspended": true,
+		company.VerificationStatusVerified:  true,
+		company.VerificationStatusRejected:  true,
+		company.VerificationStatusSuspended: true,
 	}
 	if !validStatuses[status] {
 		return nil, fmt.Errorf("invalid status for bulk update: %s", status)
@@ -393,10 +424,13 @@ func (s *adminCompanyService) BulkUpdateStatus(ctx context.Context, companyIDs [
 // GetAuditLogs retrieves audit log entries for a company
 func (s *adminCompanyService) GetAuditLogs(ctx context.Context, companyID int64, page, limit int) (*admin.AuditLogListResponse, error) {
 	// Verify company exists
-	_, err := s.companyRepo.FindByID(ctx, companyID)
+	comp, err := s.companyRepo.FindByID(ctx, companyID)
 	if err != nil {
 		return nil, fmt.Errorf("company not found: %w", err)
 	}
+	if comp == nil {
+		return nil, company.ErrCompanyNotFound
+	}
 
 	// Validate pagination
 	if page < 1 {
@@ -406,13 +440,39 @@ func (s *adminCompanyService) GetAuditLogs(ctx context.Context, companyID int64,
 		limit = 20
 	}
 
-	// TODO: Implement actual audit log retrieval
+	filter := audit.AuditLogFilter{EntityType: audit.EntityCompany, EntityID: &companyID}
+	logs, total, err := s.auditService.ListLogs(ctx, filter, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch audit logs: %w", err)
+	}
+
+	entries := make([]admin.AuditLogEntry, 0, len(logs))
+	for _, l := range logs {
+		entry := admin.AuditLogEntry{
+			ID:          l.ID,
+			CompanyID:   companyID,
+			CompanyName: comp.CompanyName,
+			Action:      l.Action,
+			CreatedAt:   l.CreatedAt,
+		}
+		if l.ActorType == audit.ActorAdmin && l.ActorID != nil {
+			entry.AdminID = *l.ActorID
+		}
+		if l.IP != nil {
+			entry.IPAddress = *l.IP
+		}
+		if status, ok := l.Metadata["status"].(string); ok {
+			entry.NewValue = status
+		}
+		entries = append(entries, entry)
+	}
+
 	return &admin.AuditLogListResponse{
-		Logs:       []admin.AuditLogEntry{},
-		Total:      0,
+		Logs:       entries,
+		Total:      total,
 		Page:       page,
 		Limit:      limit,
-		TotalPages: 0,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
 	}, nil
 }
 
@@ -4,94 +4,337 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strings"
 	"time"
 
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/admin"
+	"keerja-backend/internal/domain/audit"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/email"
 	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/notification"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/domain/webhook"
 )
 
 // adminJobService implements admin.AdminJobService interface for job moderation
 type adminJobService struct {
-	jobRepo job.JobRepository
+	jobRepo          job.JobRepository
+	companyRepo      company.CompanyRepository
+	userRepo         user.UserRepository
+	emailService     email.EmailService
+	dispatcher       notification.NotificationDispatcher
+	notifService     notification.NotificationService
+	followerNotifier notification.FollowerNotifier
+	webhooks         webhook.EventPublisher
+	auditService     audit.AuditService
+	expiry           job.ExpiryPolicy
+	cache            cache.Cache          // Featured and trending lists; may be nil
+	quotas           company.QuotaService // Published job limits; nil disables them
 }
 
 // NewAdminJobService creates a new admin job service instance
-func NewAdminJobService(jobRepo job.JobRepository) AdminJobService {
+func NewAdminJobService(
+	jobRepo job.JobRepository,
+	companyRepo company.CompanyRepository,
+	userRepo user.UserRepository,
+	emailService email.EmailService,
+	dispatcher notification.NotificationDispatcher,
+	notifService notification.NotificationService,
+	followerNotifier notification.FollowerNotifier,
+	webhooks webhook.EventPublisher,
+	auditService audit.AuditService,
+	expiry job.ExpiryPolicy,
+	cacheService cache.Cache,
+	quotas company.QuotaService,
+) AdminJobService {
 	return &adminJobService{
-		jobRepo: jobRepo,
+		jobRepo:          jobRepo,
+		companyRepo:      companyRepo,
+		userRepo:         userRepo,
+		emailService:     emailService,
+		dispatcher:       dispatcherOrDefault(dispatcher, userRepo),
+		notifService:     notifService,
+		followerNotifier: followerNotifier,
+		webhooks:         webhooks,
+		auditService:     auditService,
+		expiry:           expiry,
+		cache:            cacheService,
+		quotas:           quotas,
 	}
 }
 
 // AdminJobService interface (implementation of admin.AdminJobService)
 type AdminJobService interface {
 	// Job approval/rejection (moderation)
-	ApproveJob(ctx context.Context, jobID int64) (interface{}, error)
-	RejectJob(ctx context.Context, jobID int64, reason string) (interface{}, error)
+	ApproveJob(ctx context.Context, jobID, adminID int64, notes string) (interface{}, error)
+	RejectJob(ctx context.Context, jobID, adminID int64, reason string) (interface{}, error)
 
 	// Job list for approval
-	GetPendingJobs(ctx context.Context, page, limit int) ([]interface{}, int64, error)
+	GetPendingJobs(ctx context.Context, req *admin.AdminPendingJobsRequest) ([]interface{}, int64, error)
 	GetJobsForReview(ctx context.Context, status string, page, limit int) ([]interface{}, int64, error)
+
+	// Featured jobs curation
+	FeatureJob(ctx context.Context, jobID, adminID int64, req *admin.AdminFeatureJobRequest) (interface{}, error)
+	UnfeatureJob(ctx context.Context, jobID, adminID int64) (interface{}, error)
 }
 
 // ApproveJob approves a pending job posting (admin only)
-// Changes status from pending_review to published
-func (s *adminJobService) ApproveJob(ctx context.Context, jobID int64) (interface{}, error) {
+// Changes status from pending_review to published and stores the review record
+func (s *adminJobService) ApproveJob(ctx context.Context, jobID, adminID int64, notes string) (interface{}, error) {
 	// Get job
 	j, err := s.jobRepo.FindByID(ctx, jobID)
 	if err != nil {
-		return nil, fmt.Errorf("job not found: %w", err)
+		return nil, jobLookupError(err)
 	}
 
 	// Verify job is pending review
-	if j.Status != "pending_review" {
-		return nil, fmt.Errorf("only jobs with pending_review status can be approved (current status: %s)", j.Status)
+	if j.Status != job.StatusPendingReview {
+		return nil, job.ErrJobNotPendingReview.WithField("status", j.Status)
 	}
 
-	// Update status to published
-	if err := s.jobRepo.UpdateStatus(ctx, jobID, "published"); err != nil {
-		return nil, fmt.Errorf("failed to update job status: %w", err)
+	// Approving publishes the job, so it needs one of the company's published job slots
+	if err := checkPublishQuota(ctx, s.quotas, j.CompanyID); err != nil {
+		return nil, err
 	}
 
-	// Set published_at timestamp
+	review := &job.JobReview{
+		JobID:   jobID,
+		AdminID: &adminID,
+		Action:  "approved",
+	}
+	if notes = strings.TrimSpace(notes); notes != "" {
+		review.Reason = &notes
+	}
+
+	// Publish the job and record the review atomically
 	now := time.Now()
-	j.PublishedAt = &now
-	if err := s.jobRepo.Update(ctx, j); err != nil {
-		return nil, fmt.Errorf("failed to update published_at: %w", err)
+	expiredAt := s.expiry.PublishExpiry(j, now)
+	if err := s.jobRepo.ReviewJob(ctx, review, job.StatusPublished, &now, &expiredAt); err != nil {
+		return nil, fmt.Errorf("failed to approve job: %w", err)
 	}
 
+	s.recordReview(ctx, j, review)
+	s.notifyJobOwner(ctx, j, review)
+	notifyFollowersAsync(s.followerNotifier, jobID)
+	publishJobEvent(ctx, s.webhooks, j, webhook.EventJobPublished, job.StatusPublished, &now, &expiredAt)
+
 	// Reload and return updated job
 	return s.jobRepo.FindByID(ctx, jobID)
 }
 
 // RejectJob rejects a pending job posting (admin only)
 // Changes status from pending_review back to draft so employer can fix and resubmit
-func (s *adminJobService) RejectJob(ctx context.Context, jobID int64, reason string) (interface{}, error) {
+func (s *adminJobService) RejectJob(ctx context.Context, jobID, adminID int64, reason string) (interface{}, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, job.ErrRejectionReasonRequired
+	}
+
 	// Get job
 	j, err := s.jobRepo.FindByID(ctx, jobID)
 	if err != nil {
-		return nil, fmt.Errorf("job not found: %w", err)
+		return nil, jobLookupError(err)
 	}
 
 	// Verify job is pending review
-	if j.Status != "pending_review" {
-		return nil, fmt.Errorf("only jobs with pending_review status can be rejected (current status: %s)", j.Status)
+	if j.Status != job.StatusPendingReview {
+		return nil, job.ErrJobNotPendingReview.WithField("status", j.Status)
+	}
+
+	review := &job.JobReview{
+		JobID:   jobID,
+		AdminID: &adminID,
+		Action:  "rejected",
+		Reason:  &reason,
 	}
 
-	// Update status to draft (so employer can fix and resubmit)
-	if err := s.jobRepo.UpdateStatus(ctx, jobID, "draft"); err != nil {
-		return nil, fmt.Errorf("failed to update job status: %w", err)
+	// Move the job back to draft (so employer can fix and resubmit) and record the reason
+	if err := s.jobRepo.ReviewJob(ctx, review, job.StatusDraft, nil, nil); err != nil {
+		return nil, fmt.Errorf("failed to reject job: %w", err)
 	}
 
-	// TODO: Store rejection reason in audit table or notification system
-	// For now, log it for reference
-	fmt.Printf("Job %d rejected with reason: %s\n", jobID, reason)
+	s.recordReview(ctx, j, review)
+	s.notifyJobOwner(ctx, j, review)
 
 	// Reload and return updated job
 	return s.jobRepo.FindByID(ctx, jobID)
 }
 
-// GetPendingJobs retrieves all jobs pending review (status = pending_review)
-func (s *adminJobService) GetPendingJobs(ctx context.Context, page, limit int) ([]interface{}, int64, error) {
+// recordReview records the admin's approval or rejection of a job in the audit log
+func (s *adminJobService) recordReview(ctx context.Context, j *job.Job, review *job.JobReview) {
+	action := audit.ActionJobApproved
+	if review.Action == "rejected" {
+		action = audit.ActionJobRejected
+	}
+
+	metadata := audit.Metadata{"company_id": j.CompanyID, "title": j.Title}
+	if review.Reason != nil {
+		metadata["reason"] = *review.Reason
+	}
+
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		ActorID:    review.AdminID,
+		ActorType:  audit.ActorAdmin,
+		Action:     action,
+		EntityType: audit.EntityJob,
+		EntityID:   j.ID,
+		Metadata:   metadata,
+	})
+}
+
+// FeatureJob features a published job from req.FeaturedFrom (now when unset) until
+// req.FeaturedUntil (until unfeatured when unset). Featuring an already featured job
+// replaces its window.
+func (s *adminJobService) FeatureJob(ctx context.Context, jobID, adminID int64, req *admin.AdminFeatureJobRequest) (interface{}, error) {
+	now := time.Now()
+	if req.FeaturedUntil != nil {
+		if !req.FeaturedUntil.After(now) || (req.FeaturedFrom != nil && !req.FeaturedUntil.After(*req.FeaturedFrom)) {
+			return nil, job.ErrInvalidFeaturedWindow
+		}
+	}
+
+	j, err := s.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, jobLookupError(err)
+	}
+	if j == nil {
+		return nil, job.ErrJobNotFound
+	}
+	if !j.IsPublished() {
+		return nil, job.ErrJobNotFeaturable.WithField("status", j.Status)
+	}
+
+	if err := s.jobRepo.SetFeatured(ctx, jobID, true, req.FeaturedFrom, req.FeaturedUntil); err != nil {
+		return nil, fmt.Errorf("failed to feature job: %w", err)
+	}
+	s.invalidateFeaturedLists()
+
+	metadata := audit.Metadata{"company_id": j.CompanyID, "title": j.Title}
+	if req.FeaturedFrom != nil {
+		metadata["featured_from"] = req.FeaturedFrom.UTC()
+	}
+	if req.FeaturedUntil != nil {
+		metadata["featured_until"] = req.FeaturedUntil.UTC()
+	}
+	s.recordCuration(ctx, j, adminID, audit.ActionJobFeatured, metadata)
+
+	return s.jobRepo.FindByID(ctx, jobID)
+}
+
+// UnfeatureJob removes a job from the curated featured jobs; it may still be featured by views
+func (s *adminJobService) UnfeatureJob(ctx context.Context, jobID, adminID int64) (interface{}, error) {
+	j, err := s.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, jobLookupError(err)
+	}
+	if j == nil {
+		return nil, job.ErrJobNotFound
+	}
+
+	if err := s.jobRepo.SetFeatured(ctx, jobID, false, nil, nil); err != nil {
+		return nil, fmt.Errorf("failed to unfeature job: %w", err)
+	}
+	s.invalidateFeaturedLists()
+	s.recordCuration(ctx, j, adminID, audit.ActionJobUnfeatured, audit.Metadata{"company_id": j.CompanyID, "title": j.Title})
+
+	return s.jobRepo.FindByID(ctx, jobID)
+}
+
+// invalidateFeaturedLists drops the cached featured and trending lists so curation shows right away
+func (s *adminJobService) invalidateFeaturedLists() {
+	if s.cache == nil {
+		return
+	}
+	s.cache.Delete(job.FeaturedJobsCacheKey)
+	s.cache.Delete(job.TrendingJobsCacheKey)
+}
+
+// recordCuration writes the audit entry of a feature or unfeature action
+func (s *adminJobService) recordCuration(ctx context.Context, j *job.Job, adminID int64, action string, metadata audit.Metadata) {
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		ActorID:    &adminID,
+		ActorType:  audit.ActorAdmin,
+		Action:     action,
+		EntityType: audit.EntityJob,
+		EntityID:   j.ID,
+		Metadata:   metadata,
+	})
+}
+
+// notifyJobOwner sends the review result to the employer who created the job via email and an
+// in-app notification, which is also pushed. Without a notification service it pushes directly.
+// Notification failures are logged and never undo the review.
+func (s *adminJobService) notifyJobOwner(ctx context.Context, j *job.Job, review *job.JobReview) {
+	if j.EmployerUserID == nil {
+		return
+	}
+
+	employerUser, err := s.companyRepo.FindEmployerUserByID(ctx, *j.EmployerUserID)
+	if err != nil || employerUser == nil {
+		fmt.Printf("[WARN] job %d review: employer user %d not found: %v\n", j.ID, *j.EmployerUserID, err)
+		return
+	}
+
+	owner, err := s.userRepo.FindByID(ctx, employerUser.UserID)
+	if err != nil || owner == nil {
+		fmt.Printf("[WARN] job %d review: user %d not found: %v\n", j.ID, employerUser.UserID, err)
+		return
+	}
+
+	companyName := ""
+	if comp, err := s.companyRepo.FindByID(ctx, j.CompanyID); err == nil && comp != nil {
+		companyName = comp.CompanyName
+	}
+
+	reason := ""
+	if review.Reason != nil {
+		reason = *review.Reason
+	}
+
+	status := "Disetujui"
+	title := "Lowongan disetujui"
+	body := fmt.Sprintf("Lowongan %s telah disetujui dan sekarang tayang.", j.Title)
+	if review.IsRejected() {
+		status = "Ditolak"
+		title = "Lowongan ditolak"
+		body = fmt.Sprintf("Lowongan %s ditolak: %s", j.Title, reason)
+	}
+
+	if s.emailService != nil {
+		if err := s.emailService.SendJobReviewEmail(ctx, owner.Email, owner.FullName, j.Title, companyName, status, reason); err != nil {
+			fmt.Printf("[WARN] job %d review: failed to send email: %v\n", j.ID, err)
+		}
+	}
+
+	if s.notifService != nil {
+		ownerCtx := userLocaleContext(ctx, s.userRepo, owner.ID)
+		if err := s.notifService.NotifyJobReviewed(ownerCtx, owner.ID, j.ID, j.Title, !review.IsRejected(), reason); err != nil {
+			fmt.Printf("[WARN] job %d review: failed to send notification: %v\n", j.ID, err)
+		}
+	} else {
+		message := &notification.PushMessage{
+			Title:    title,
+			Body:     body,
+			Priority: "high",
+			Data: map[string]string{
+				"type":   "job_review",
+				"job_id": fmt.Sprintf("%d", j.ID),
+				"action": review.Action,
+			},
+		}
+		if _, err := s.dispatcher.Push(ctx, owner.ID, user.NotificationEventTransactional, message); err != nil {
+			fmt.Printf("[WARN] job %d review: failed to send push notification: %v\n", j.ID, err)
+		}
+	}
+}
+
+// GetPendingJobs retrieves jobs pending review (status = pending_review)
+// Optional filters: company and submission date range
+func (s *adminJobService) GetPendingJobs(ctx context.Context, req *admin.AdminPendingJobsRequest) ([]interface{}, int64, error) {
 	// Validate pagination
+	page, limit := req.Page, req.Limit
 	if page < 1 {
 		page = 1
 	}
@@ -99,9 +342,29 @@ func (s *adminJobService) GetPendingJobs(ctx context.Context, page, limit int) (
 		limit = 20
 	}
 
-	// Build filter for pending_review status
+	// Build filter for pending_review status, oldest submissions first
 	filter := job.JobFilter{
-		Status: "pending_review",
+		Status: job.StatusPendingReview,
+		SortBy: "submitted",
+	}
+	if req.CompanyID != nil {
+		filter.CompanyID = *req.CompanyID
+	}
+	if req.SubmittedFrom != "" {
+		from, err := time.Parse("2006-01-02", req.SubmittedFrom)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid submitted_from date: %w", err)
+		}
+		filter.SubmittedAfter = &from
+	}
+	if req.SubmittedTo != "" {
+		to, err := time.Parse("2006-01-02", req.SubmittedTo)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid submitted_to date: %w", err)
+		}
+		// Include the whole end day
+		to = to.AddDate(0, 0, 1)
+		filter.SubmittedBefore = &to
 	}
 
 	jobs, total, err := s.jobRepo.List(ctx, filter, page, limit)
@@ -111,8 +374,8 @@ func (s *adminJobService) GetPendingJobs(ctx context.Context, page, limit int) (
 
 	// Convert to interface{} slice
 	result := make([]interface{}, len(jobs))
-	for i, j := range jobs {
-		result[i] = j
+	for i := range jobs {
+		result[i] = &jobs[i]
 	}
 
 	return result, total, nil
@@ -130,10 +393,10 @@ func (s *adminJobService) GetJobsForReview(ctx context.Context, status string, p
 
 	// Validate status - only allow review-related statuses
 	validStatuses := map[string]bool{
-		"pending_review": true,
-		"published":      true,
-		"draft":          true,
-		"suspended":      true,
+		job.StatusPendingReview: true,
+		job.StatusPublished:     true,
+		job.StatusDraft:         true,
+		job.StatusSuspended:     true,
 	}
 
 	if !validStatuses[status] {
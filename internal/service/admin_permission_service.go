@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/admin"
+	"keerja-backend/internal/domain/audit"
+)
+
+// adminPermissionsTTL is how long an admin's resolved permissions are served from cache.
+// Role assignments drop the entry at once; edits to a role's permission set take effect
+// within this period.
+const adminPermissionsTTL = 5 * time.Minute
+
+// adminPermissionService implements admin.AdminPermissionService
+type adminPermissionService struct {
+	adminUserRepo admin.AdminUserRepository
+	adminRoleRepo admin.AdminRoleRepository
+	auditService  audit.AuditService
+	cache         cache.Cache
+}
+
+// NewAdminPermissionService creates a new admin permission service
+func NewAdminPermissionService(
+	adminUserRepo admin.AdminUserRepository,
+	adminRoleRepo admin.AdminRoleRepository,
+	auditService audit.AuditService,
+	cacheService cache.Cache,
+) admin.AdminPermissionService {
+	return &adminPermissionService{
+		adminUserRepo: adminUserRepo,
+		adminRoleRepo: adminRoleRepo,
+		auditService:  auditService,
+		cache:         cacheService,
+	}
+}
+
+// Permissions returns the permissions of the admin's role. Admins without a role have none.
+func (s *adminPermissionService) Permissions(ctx context.Context, adminID int64) ([]string, error) {
+	cacheKey := adminPermissionsCacheKey(adminID)
+	if s.cache != nil {
+		if perms, ok := cache.GetTyped[[]string](s.cache, cacheKey); ok {
+			return perms, nil
+		}
+	}
+
+	adminUser, err := s.adminUserRepo.FindByID(ctx, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find admin user: %w", err)
+	}
+	if adminUser == nil {
+		return nil, admin.ErrAdminUserNotFound
+	}
+
+	perms := []string{}
+	if adminUser.Role != nil {
+		perms = append(perms, adminUser.Role.Permissions...)
+	}
+
+	if s.cache != nil {
+		s.cache.Set(cacheKey, perms, adminPermissionsTTL)
+	}
+	return perms, nil
+}
+
+// HasPermission checks if the admin's role grants perm
+func (s *adminPermissionService) HasPermission(ctx context.Context, adminID int64, perm string) (bool, error) {
+	perms, err := s.Permissions(ctx, adminID)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range perms {
+		if p == perm {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ListRoles returns every role with its permissions
+func (s *adminPermissionService) ListRoles(ctx context.Context) ([]admin.AdminRole, error) {
+	roles, err := s.adminRoleRepo.ListActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list admin roles: %w", err)
+	}
+	return roles, nil
+}
+
+// AssignRole gives another admin a role. Admins cannot change their own role, so the last
+// superadmin cannot lock everyone out of role management by demoting themselves.
+func (s *adminPermissionService) AssignRole(ctx context.Context, actorID, adminID, roleID int64) (*admin.AdminUser, error) {
+	if actorID == adminID {
+		return nil, admin.ErrCannotChangeOwnRole
+	}
+
+	adminUser, err := s.adminUserRepo.FindByID(ctx, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find admin user: %w", err)
+	}
+	if adminUser == nil {
+		return nil, admin.ErrAdminUserNotFound
+	}
+
+	role, err := s.adminRoleRepo.FindByID(ctx, roleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find admin role: %w", err)
+	}
+	if role == nil {
+		return nil, admin.ErrAdminRoleNotFound
+	}
+
+	if err := s.adminUserRepo.UpdateRole(ctx, adminID, roleID); err != nil {
+		return nil, fmt.Errorf("failed to assign admin role: %w", err)
+	}
+	if s.cache != nil {
+		s.cache.Delete(adminPermissionsCacheKey(adminID))
+	}
+
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		ActorType:  audit.ActorAdmin,
+		ActorID:    &actorID,
+		Action:     audit.ActionAdminRoleAssigned,
+		EntityType: audit.EntityAdminUser,
+		EntityID:   adminID,
+		Metadata:   audit.Metadata{"old_role_id": adminUser.RoleID, "new_role_id": role.ID, "role_name": role.RoleName},
+	})
+
+	adminUser.RoleID = &role.ID
+	adminUser.Role = role
+	return adminUser, nil
+}
+
+// adminPermissionsCacheKey is the cache key of an admin's resolved permissions
+func adminPermissionsCacheKey(adminID int64) string {
+	return fmt.Sprintf("admin:permissions:%d", adminID)
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"keerja-backend/internal/domain/apikey"
+	"keerja-backend/internal/domain/audit"
+)
+
+const (
+	// apiKeyRandomBytes is the entropy of a generated key
+	apiKeyRandomBytes = 32
+	// apiKeyTouchInterval throttles last_used_at writes for busy keys
+	apiKeyTouchInterval = time.Minute
+)
+
+// apiKeyService implements apikey.APIKeyService
+type apiKeyService struct {
+	repo         apikey.APIKeyRepository
+	auditService audit.AuditService
+}
+
+// NewAPIKeyService creates a new company API key service
+func NewAPIKeyService(repo apikey.APIKeyRepository, auditService audit.AuditService) apikey.APIKeyService {
+	return &apiKeyService{
+		repo:         repo,
+		auditService: auditService,
+	}
+}
+
+// CreateKey creates a key for the company and returns it with its plaintext, which is not stored
+func (s *apiKeyService) CreateKey(ctx context.Context, companyID, createdBy int64, name string, scopes []string) (*apikey.CompanyAPIKey, string, error) {
+	if len(scopes) == 0 {
+		return nil, "", apikey.ErrInvalidAPIKeyScope
+	}
+	unique := make([]string, 0, len(scopes))
+	seen := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		if !apikey.IsValidScope(scope) {
+			return nil, "", apikey.ErrInvalidAPIKeyScope
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			unique = append(unique, scope)
+		}
+	}
+
+	random := make([]byte, apiKeyRandomBytes)
+	if _, err := rand.Read(random); err != nil {
+		return nil, "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	rawKey := apikey.KeyPrefix + hex.EncodeToString(random)
+
+	key := &apikey.CompanyAPIKey{
+		CompanyID: companyID,
+		Name:      name,
+		KeyPrefix: rawKey[:apikey.DisplayPrefixLength],
+		KeyHash:   hashAPIKey(rawKey),
+		Scopes:    unique,
+		CreatedBy: &createdBy,
+	}
+	if err := s.repo.Create(ctx, key); err != nil {
+		return nil, "", fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		Action:     audit.ActionAPIKeyCreated,
+		EntityType: audit.EntityAPIKey,
+		EntityID:   key.ID,
+		Metadata:   audit.Metadata{"company_id": companyID, "name": name, "scopes": unique},
+	})
+
+	return key, rawKey, nil
+}
+
+// ListKeys lists a company's keys, newest first
+func (s *apiKeyService) ListKeys(ctx context.Context, companyID int64) ([]apikey.CompanyAPIKey, error) {
+	keys, err := s.repo.ListByCompany(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// RevokeKey revokes one of the company's keys
+func (s *apiKeyService) RevokeKey(ctx context.Context, companyID, keyID int64) error {
+	key, err := s.repo.FindByID(ctx, keyID)
+	if err != nil {
+		return fmt.Errorf("failed to find API key: %w", err)
+	}
+	// Keys of other companies are reported as missing so their IDs are not disclosed
+	if key == nil || key.CompanyID != companyID {
+		return apikey.ErrAPIKeyNotFound
+	}
+	if key.IsRevoked() {
+		return apikey.ErrAPIKeyAlreadyRevoked
+	}
+
+	if err := s.repo.Revoke(ctx, keyID, time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		Action:     audit.ActionAPIKeyRevoked,
+		EntityType: audit.EntityAPIKey,
+		EntityID:   keyID,
+		Metadata:   audit.Metadata{"company_id": companyID, "name": key.Name},
+	})
+	return nil
+}
+
+// Authenticate resolves a plaintext key to its active key record
+func (s *apiKeyService) Authenticate(ctx context.Context, rawKey string) (*apikey.CompanyAPIKey, error) {
+	if !strings.HasPrefix(rawKey, apikey.KeyPrefix) {
+		return nil, apikey.ErrInvalidAPIKey
+	}
+
+	key, err := s.repo.FindByHash(ctx, hashAPIKey(rawKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find API key: %w", err)
+	}
+	if key == nil {
+		return nil, apikey.ErrInvalidAPIKey
+	}
+	if key.IsRevoked() {
+		return nil, apikey.ErrAPIKeyRevoked
+	}
+
+	now := time.Now()
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyTouchInterval {
+		// Usage tracking is best-effort and must not fail the request
+		if err := s.repo.TouchLastUsed(ctx, key.ID, now); err != nil {
+			fmt.Printf("[WARN] failed to record API key %d usage: %v\n", key.ID, err)
+		} else {
+			key.LastUsedAt = &now
+		}
+	}
+
+	return key, nil
+}
+
+// hashAPIKey creates the SHA256 hash a key is stored and looked up by
+func hashAPIKey(rawKey string) string {
+	hash := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"strings"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/user"
+)
+
+// ===== Applicant Profile Projection =====
+
+// projectApplicantProfile builds the profile of the applicant of app as the viewer sees it.
+// Applicants see all of their own details. Companies always see the applicant on their own
+// applications, whatever the profile visibility, but see the phone number and exact address
+// only once the application reaches application.ContactRevealStatuses. Blind screening is
+// applied on top of the projection by redactApplicantDetail.
+func projectApplicantProfile(u *user.User, app *application.JobApplication, employerView bool) application.ApplicantProfile {
+	profile := application.ApplicantProfile{
+		UserID:               u.ID,
+		FullName:             u.FullName,
+		Email:                u.Email,
+		ResumeURL:            app.ResumeURL,
+		ContactRevealed:      !employerView || application.RevealsContactDetails(app.Status),
+		AllowsDirectMessages: u.Preference.AcceptsDirectMessages(),
+	}
+
+	if u.Profile != nil {
+		if u.Profile.AvatarURL != nil {
+			profile.PhotoURL = *u.Profile.AvatarURL
+		}
+		profile.Location = joinNonEmpty(", ", u.Profile.LocationCity, u.Profile.LocationState)
+	}
+
+	if !profile.ContactRevealed {
+		return profile
+	}
+	if u.Phone != nil {
+		profile.Phone = *u.Phone
+	}
+	if u.Profile != nil && u.Profile.Address != nil {
+		profile.Address = strings.TrimSpace(*u.Profile.Address)
+	}
+	return profile
+}
+
+// joinNonEmpty joins the set, non-blank values with sep
+func joinNonEmpty(sep string, values ...*string) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != nil && strings.TrimSpace(*v) != "" {
+			parts = append(parts, strings.TrimSpace(*v))
+		}
+	}
+	return strings.Join(parts, sep)
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"keerja-backend/internal/domain/application"
+)
+
+// ===== Blind Screening =====
+
+// applicantRedaction records the companies whose applicants are anonymous to the employer
+// user viewing them. The zero value redacts nothing, which is what applicants see.
+type applicantRedaction struct {
+	blindCompanies map[int64]bool
+}
+
+// applies reports whether an application of the company in the given status is shown
+// without the applicant's identity
+func (r applicantRedaction) applies(companyID *int64, status string) bool {
+	return companyID != nil && r.blindCompanies[*companyID] && slices.Contains(application.BlindScreeningStatuses, status)
+}
+
+// applicantRedactionFor decides, once per company, whether the employer user sees the
+// company's early-stage applicants anonymized: the company has blind screening enabled and
+// the user is not one of its admins or owners. Users whose role cannot be confirmed are
+// treated as recruiters.
+func (s *applicationService) applicantRedactionFor(ctx context.Context, employerUserID int64, companyIDs []int64) (applicantRedaction, error) {
+	redaction := applicantRedaction{blindCompanies: make(map[int64]bool)}
+	for _, companyID := range uniqueIDs(companyIDs) {
+		settings, err := s.companyRepo.FindSettings(ctx, companyID)
+		if err != nil {
+			return applicantRedaction{}, fmt.Errorf("failed to get company settings: %w", err)
+		}
+		if settings == nil || !settings.BlindScreeningEnabled {
+			continue
+		}
+
+		employerUser, err := s.companyRepo.FindEmployerUserByUserAndCompany(ctx, employerUserID, companyID)
+		if err == nil && employerUser != nil && employerUser.IsAdmin() {
+			continue
+		}
+		redaction.blindCompanies[companyID] = true
+	}
+	return redaction, nil
+}
+
+// blindCandidateLabel names an anonymized applicant after a hash of the application ID, so
+// the label is stable for one application but cannot be traced back to the user
+func blindCandidateLabel(applicationID int64) string {
+	sum := sha256.Sum256([]byte("application:" + strconv.FormatInt(applicationID, 10)))
+	return "Candidate #" + strings.ToUpper(hex.EncodeToString(sum[:4]))
+}
+
+// redactApplicantSummary removes the applicant's identity from a listing summary
+func redactApplicantSummary(summary *application.ApplicationSummary) {
+	summary.UserID = 0
+	summary.UserName = blindCandidateLabel(summary.ID)
+}
+
+// redactApplicantDetail removes the applicant's identity and contact details from an
+// application detail. Qualifications and the resume stay visible for screening.
+func redactApplicantDetail(detail *application.ApplicationDetailResponse) {
+	detail.Application.UserID = 0
+	detail.Applicant.UserID = 0
+	detail.Applicant.FullName = blindCandidateLabel(detail.Application.ID)
+	detail.Applicant.Email = ""
+	detail.Applicant.Phone = ""
+	detail.Applicant.Address = ""
+	detail.Applicant.PhotoURL = ""
+}
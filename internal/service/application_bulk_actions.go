@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/employer"
+
+	"gorm.io/gorm"
+)
+
+// bulkActionsPerRun is the number of unfinished bulk actions ProcessBulkActions works on per run
+const bulkActionsPerRun = 5
+
+// defaultBulkActionNotes is recorded on the stages of bulk actions queued without notes
+const defaultBulkActionNotes = "Bulk update"
+
+// ===== Bulk Actions =====
+
+// CreateBulkAction resolves the selection to the company's application IDs and queues them.
+// Recruiters and above may queue bulk actions; the stage changes are made as ec's employer user.
+func (s *applicationService) CreateBulkAction(ctx context.Context, ec *employer.EmployerContext, req *application.BulkActionRequest) (*application.BulkAction, error) {
+	if ec == nil {
+		return nil, application.ErrEmployerAccessDenied
+	}
+	if !ec.CanManageJobs() {
+		return nil, application.ErrInsufficientPermissions
+	}
+	if !slices.Contains(application.BulkActionStatuses, req.Status) {
+		return nil, application.ErrInvalidBulkAction.WithField("status", "must be one of "+strings.Join(application.BulkActionStatuses, ", "))
+	}
+	if (len(req.ApplicationIDs) == 0) == (req.Filter == nil) {
+		return nil, application.ErrInvalidBulkAction.WithField("application_ids", "send either application_ids or filter")
+	}
+
+	var (
+		ids     []int64
+		skipped int
+		err     error
+	)
+	if req.Filter != nil {
+		if req.Filter.Status != "" && !application.IsValidStatus(req.Filter.Status) {
+			return nil, application.ErrInvalidBulkAction.WithField("filter.status", "is not an application status")
+		}
+		// One more than the cap tells a selection that is too large from one that fits exactly
+		ids, err = s.appRepo.ListCompanyApplicationIDs(ctx, ec.CompanyID, bulkActionApplicationFilter(req.Filter), application.MaxBulkActionItems+1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get applications: %w", err)
+		}
+		if len(ids) > application.MaxBulkActionItems {
+			return nil, application.ErrBulkActionTooLarge
+		}
+	} else {
+		requested := slices.Clone(req.ApplicationIDs)
+		slices.Sort(requested)
+		requested = slices.Compact(requested)
+		if len(requested) > application.MaxBulkActionItems {
+			return nil, application.ErrBulkActionTooLarge
+		}
+
+		ids, err = s.appRepo.FilterCompanyApplicationIDs(ctx, ec.CompanyID, requested)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get applications: %w", err)
+		}
+		skipped = len(requested) - len(ids)
+	}
+	if len(ids) == 0 {
+		return nil, application.ErrNoApplicationsSelected
+	}
+
+	action := &application.BulkAction{
+		CompanyID:      ec.CompanyID,
+		CreatedBy:      ec.UserID,
+		EmployerUserID: &ec.EmployerUserID,
+		EmployerRole:   ec.Role,
+		TargetStatus:   req.Status,
+		Notes:          strings.TrimSpace(req.Notes),
+		Status:         application.BulkActionQueued,
+		TotalCount:     len(ids),
+		SkippedCount:   skipped,
+	}
+	if err := s.appRepo.CreateBulkAction(ctx, action, ids); err != nil {
+		return nil, fmt.Errorf("failed to create bulk action: %w", err)
+	}
+	return action, nil
+}
+
+// GetBulkAction returns one of the company's bulk actions with its failed items
+func (s *applicationService) GetBulkAction(ctx context.Context, ec *employer.EmployerContext, id int64) (*application.BulkAction, error) {
+	if err := checkEmployerContext(ec); err != nil {
+		return nil, err
+	}
+
+	action, err := s.appRepo.FindBulkActionByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && action.CompanyID != ec.CompanyID) {
+		return nil, application.ErrBulkActionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bulk action: %w", err)
+	}
+
+	if action.FailedCount > 0 {
+		action.Failures, err = s.appRepo.ListBulkActionItems(ctx, action.ID, application.BulkItemFailed, application.MaxBulkActionItems)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get bulk action failures: %w", err)
+		}
+	}
+	return action, nil
+}
+
+// ProcessBulkActions works through queued bulk actions, and running ones interrupted by a
+// restart, until each is done. Every item's result is recorded as soon as it is processed, so
+// an interrupted action resumes with the items that are still pending.
+func (s *applicationService) ProcessBulkActions(ctx context.Context) (int, error) {
+	actions, err := s.appRepo.FindUnfinishedBulkActions(ctx, bulkActionsPerRun)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get bulk actions: %w", err)
+	}
+
+	processed := 0
+	for i := range actions {
+		n, err := s.runBulkAction(ctx, &actions[i])
+		processed += n
+		if err != nil {
+			return processed, err
+		}
+	}
+	return processed, nil
+}
+
+// runBulkAction processes the action's pending items in batches and marks it done after the last
+func (s *applicationService) runBulkAction(ctx context.Context, action *application.BulkAction) (int, error) {
+	if action.Status == application.BulkActionQueued {
+		if err := s.appRepo.StartBulkAction(ctx, action.ID, time.Now()); err != nil {
+			return 0, fmt.Errorf("failed to start bulk action %d: %w", action.ID, err)
+		}
+	}
+
+	ec, err := s.bulkActionEmployer(ctx, action)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get employer of bulk action %d: %w", action.ID, err)
+	}
+
+	processed := 0
+	for {
+		items, err := s.appRepo.ListBulkActionItems(ctx, action.ID, application.BulkItemPending, application.BulkActionBatchSize)
+		if err != nil {
+			return processed, fmt.Errorf("failed to get items of bulk action %d: %w", action.ID, err)
+		}
+		if len(items) == 0 {
+			if err := s.appRepo.CompleteBulkAction(ctx, action.ID, time.Now()); err != nil {
+				return processed, fmt.Errorf("failed to complete bulk action %d: %w", action.ID, err)
+			}
+			return processed, nil
+		}
+
+		for i := range items {
+			if err := ctx.Err(); err != nil {
+				return processed, err
+			}
+			s.applyBulkActionItem(ctx, ec, action, &items[i])
+			if err := s.appRepo.RecordBulkActionItem(ctx, &items[i]); err != nil {
+				return processed, fmt.Errorf("failed to record item of bulk action %d: %w", action.ID, err)
+			}
+			processed++
+		}
+	}
+}
+
+// bulkActionEmployer returns the EmployerContext the action's items are moved as, built from
+// the employer user and company stored on the action with the employer user's current role.
+// It is nil once that employer user has been removed or deactivated, which fails the
+// remaining items, as does a demotion below recruiter.
+func (s *applicationService) bulkActionEmployer(ctx context.Context, action *application.BulkAction) (*employer.EmployerContext, error) {
+	if action.EmployerUserID == nil {
+		return nil, nil
+	}
+
+	employerUser, err := s.companyRepo.FindEmployerUserByID(ctx, *action.EmployerUserID)
+	if err != nil {
+		return nil, err
+	}
+	if employerUser == nil || !employerUser.IsActive || employerUser.CompanyID != action.CompanyID {
+		return nil, nil
+	}
+
+	return &employer.EmployerContext{
+		UserID:         action.CreatedBy,
+		EmployerUserID: *action.EmployerUserID,
+		CompanyID:      action.CompanyID,
+		Role:           employerUser.Role,
+	}, nil
+}
+
+// applyBulkActionItem moves the item's application with the same stage method as a single
+// status change and sets the item's result. An application that is already in the target
+// status counts as moved, which covers items interrupted before their result was recorded.
+func (s *applicationService) applyBulkActionItem(ctx context.Context, ec *employer.EmployerContext, action *application.BulkAction, item *application.BulkActionItem) {
+	notes := action.Notes
+	if notes == "" {
+		notes = defaultBulkActionNotes
+	}
+
+	now := time.Now()
+	item.ProcessedAt = &now
+	item.Status = application.BulkItemSucceeded
+
+	// Queueing takes recruiter or above, and so does every move made for the action
+	if ec != nil && !ec.CanManageJobs() {
+		item.Status = application.BulkItemFailed
+		item.Error = application.ErrInsufficientPermissions.Error()
+		return
+	}
+
+	if err := s.moveApplicationToStatus(ctx, ec, item.ApplicationID, action.TargetStatus, notes); err != nil {
+		if app, findErr := s.appRepo.FindByID(ctx, item.ApplicationID); findErr == nil && app.Status == action.TargetStatus {
+			return
+		}
+		item.Status = application.BulkItemFailed
+		item.Error = err.Error()
+	}
+}
+
+// bulkActionApplicationFilter converts a bulk action's filter to the applications list filter
+func bulkActionApplicationFilter(filter *application.BulkActionFilter) application.ApplicationFilter {
+	return application.ApplicationFilter{
+		Status:   filter.Status,
+		JobID:    filter.JobID,
+		Source:   filter.Source,
+		MinScore: filter.MinScore,
+	}
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/i18n"
+)
+
+// ===== Candidate Timeline =====
+
+// GetCandidateTimeline returns the applicant's view of the application's progress. Unlike
+// the employer timeline built by buildTimeline it only carries stage names, dates and
+// interview schedules: stage descriptions and notes, who handled a stage, interviewers,
+// scores and feedback never leave the hiring team.
+func (s *applicationService) GetCandidateTimeline(ctx context.Context, applicationID, userID int64) (*application.CandidateTimeline, error) {
+	app, err := s.appRepo.FindByID(ctx, applicationID)
+	if err != nil {
+		return nil, applicationLookupError(err)
+	}
+	if app.UserID != userID {
+		return nil, application.ErrNotApplicationOwner
+	}
+
+	stages, err := s.appRepo.GetStageHistory(ctx, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stage history: %w", err)
+	}
+	current, err := s.appRepo.GetCurrentStage(ctx, applicationID)
+	switch {
+	case err == nil:
+		stages = append(stages, *current)
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, fmt.Errorf("failed to get current stage: %w", err)
+	}
+
+	interviews, err := s.appRepo.ListInterviewsByApplication(ctx, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get interviews: %w", err)
+	}
+
+	t := i18n.New(i18n.FromContext(ctx))
+	return &application.CandidateTimeline{
+		ApplicationID: app.ID,
+		Status:        app.Status,
+		StatusLabel:   t.T("application.status." + app.Status),
+		NextStep:      s.candidateNextStep(ctx, t, app, interviews),
+		Events:        candidateTimelineEvents(t, app, stages, interviews),
+	}, nil
+}
+
+// candidateTimelineEvents lists the application's steps the applicant may see, oldest
+// first. Events are built field by field so nothing added to stages or interviews for the
+// hiring team reaches the applicant by accident.
+func candidateTimelineEvents(t *i18n.Translator, app *application.JobApplication, stages []application.JobApplicationStage, interviews []application.Interview) []application.CandidateTimelineEvent {
+	events := []application.CandidateTimelineEvent{{
+		Date:      app.AppliedAt,
+		EventType: application.TimelineEventSubmitted,
+		Stage:     application.StatusApplied,
+		Label:     t.T("application.timeline.submitted"),
+	}}
+
+	seen := make(map[int64]bool, len(stages))
+	for _, stage := range stages {
+		// Submitting the application already marks the applied stage
+		if stage.StageName == application.StatusApplied || seen[stage.ID] {
+			continue
+		}
+		seen[stage.ID] = true
+		// Custom stages of the job's pipeline are shown by the name the employer gave them
+		label := stage.StageName
+		if !stage.IsCustom() {
+			label = t.T("application.status." + stage.StageName)
+		}
+		events = append(events, application.CandidateTimelineEvent{
+			Date:      stage.StartedAt,
+			EventType: application.TimelineEventStage,
+			Stage:     stage.StageName,
+			Label:     label,
+		})
+	}
+
+	for _, interview := range interviews {
+		events = append(events, application.CandidateTimelineEvent{
+			Date:            interview.ScheduledAt,
+			EventType:       application.TimelineEventInterview,
+			Label:           t.T("application.timeline.interview." + interview.Status),
+			InterviewType:   interview.InterviewType,
+			InterviewStatus: interview.Status,
+		})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Date.Before(events[j].Date)
+	})
+	return events
+}
+
+// candidateNextStep tells the applicant what to expect next. While the company still has
+// to act the hint gives the number of days the company usually spends in the current
+// stage; an upcoming interview is named instead when there is one.
+func (s *applicationService) candidateNextStep(ctx context.Context, t *i18n.Translator, app *application.JobApplication, interviews []application.Interview) string {
+	if app.IsCompleted() {
+		return t.T("application.next_step." + app.Status)
+	}
+
+	if app.Status == application.StatusInterview {
+		if interview := nextScheduledInterview(interviews, time.Now()); interview != nil {
+			loc, err := time.LoadLocation(interview.Timezone)
+			if err != nil || interview.Timezone == "" {
+				loc = time.UTC
+			}
+			return t.T("application.next_step.interview.scheduled", i18n.FormatLongDate(t.Locale(), interview.ScheduledAt.In(loc)))
+		}
+	}
+
+	if days := s.typicalStageDays(ctx, app); days > 0 {
+		return t.T("application.next_step."+app.Status+".typical", days)
+	}
+	return t.T("application.next_step." + app.Status)
+}
+
+// typicalStageDays returns the whole number of days the application's company usually
+// takes to move applications out of their current stage, or 0 when it is not known
+func (s *applicationService) typicalStageDays(ctx context.Context, app *application.JobApplication) int {
+	if app.CompanyID == nil {
+		return 0
+	}
+	stats, err := s.appRepo.GetAverageTimePerStage(ctx, *app.CompanyID)
+	if err != nil {
+		return 0
+	}
+	for _, stat := range stats {
+		if stat.StageName == app.Status && stat.Count > 0 {
+			return max(int(math.Ceil(stat.AverageDays)), 1)
+		}
+	}
+	return 0
+}
+
+// nextScheduledInterview returns the earliest scheduled or rescheduled interview after now
+func nextScheduledInterview(interviews []application.Interview, now time.Time) *application.Interview {
+	var next *application.Interview
+	for i := range interviews {
+		interview := &interviews[i]
+		if (interview.Status != "scheduled" && interview.Status != "rescheduled") || !interview.ScheduledAt.After(now) {
+			continue
+		}
+		if next == nil || interview.ScheduledAt.Before(next.ScheduledAt) {
+			next = interview
+		}
+	}
+	return next
+}
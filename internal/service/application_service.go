@@ -1,18 +1,32 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
 	"errors"
 	"fmt"
 	"math"
+	"mime/multipart"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
+	"keerja-backend/internal/config"
 	"keerja-backend/internal/domain/application"
 	"keerja-backend/internal/domain/company"
 	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/domain/employer"
 	"keerja-backend/internal/domain/job"
 	"keerja-backend/internal/domain/notification"
 	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/domain/webhook"
+	"keerja-backend/internal/i18n"
+	"keerja-backend/internal/utils"
+
+	"gorm.io/gorm"
 )
 
 // applicationService implements application.ApplicationService interface
@@ -23,9 +37,34 @@ type applicationService struct {
 	companyRepo  company.CompanyRepository
 	emailService email.EmailService
 	notifService notification.NotificationService
+	webhooks     webhook.EventPublisher
+	reapply      application.ReapplyPolicy
+	uploads      UploadService
+	dispatcher   notification.NotificationDispatcher
+
+	// emailTemplates renders companies' own candidate emails; nil sends the platform defaults
+	emailTemplates email.CompanyTemplateRenderer
+
+	// documentURLsAllowed keeps accepting pre-uploaded document file URLs while clients move to uploads
+	documentURLsAllowed bool
+}
+
+// applicationUploadRoot holds the files of applications: uploaded documents and the
+// snapshots of library documents they were submitted with
+const applicationUploadRoot = "applications"
+
+// applicationDocumentDirectory is where uploaded application documents are stored
+const applicationDocumentDirectory = applicationUploadRoot + "/documents"
+
+// applicationSnapshotDirectory is where the library documents an application was submitted
+// with are copied to
+func applicationSnapshotDirectory(applicationID int64) string {
+	return path.Join(applicationUploadRoot, strconv.FormatInt(applicationID, 10))
 }
 
-// NewApplicationService creates a new application service instance
+// NewApplicationService creates a new application service instance. Candidate emails go
+// through dispatcher, which applies their notification settings, and use the company's own
+// template from emailTemplates when it has one.
 func NewApplicationService(
 	appRepo application.ApplicationRepository,
 	jobRepo job.JobRepository,
@@ -33,14 +72,26 @@ func NewApplicationService(
 	companyRepo company.CompanyRepository,
 	emailService email.EmailService,
 	notifService notification.NotificationService,
+	webhooks webhook.EventPublisher,
+	reapply application.ReapplyPolicy,
+	uploads UploadService,
+	documentURLsAllowed bool,
+	dispatcher notification.NotificationDispatcher,
+	emailTemplates email.CompanyTemplateRenderer,
 ) application.ApplicationService {
 	return &applicationService{
-		appRepo:      appRepo,
-		jobRepo:      jobRepo,
-		userRepo:     userRepo,
-		companyRepo:  companyRepo,
-		emailService: emailService,
-		notifService: notifService,
+		appRepo:             appRepo,
+		jobRepo:             jobRepo,
+		userRepo:            userRepo,
+		companyRepo:         companyRepo,
+		emailService:        emailService,
+		notifService:        notifService,
+		webhooks:            webhooks,
+		reapply:             reapply,
+		uploads:             uploads,
+		documentURLsAllowed: documentURLsAllowed,
+		dispatcher:          dispatcherOrDefault(dispatcher, userRepo),
+		emailTemplates:      emailTemplates,
 	}
 }
 
@@ -53,16 +104,16 @@ func (s *applicationService) ApplyForJob(ctx context.Context, req *application.A
 		return nil, err
 	}
 
-	// Check if already applied
-	existingApp, _ := s.appRepo.FindByJobAndUser(ctx, req.JobID, req.UserID)
-	if existingApp != nil {
-		return nil, errors.New("you have already applied for this job")
-	}
-
 	// Get job details
 	j, err := s.jobRepo.FindByID(ctx, req.JobID)
 	if err != nil {
-		return nil, fmt.Errorf("job not found: %w", err)
+		return nil, jobLookupError(err)
+	}
+
+	// Validate screening answers before anything is stored
+	answers, knockedOut, err := s.screenAnswers(ctx, req.JobID, req.Answers)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create application
@@ -70,10 +121,15 @@ func (s *applicationService) ApplyForJob(ctx context.Context, req *application.A
 		JobID:     req.JobID,
 		UserID:    req.UserID,
 		CompanyID: &j.CompanyID,
-		Status:    "applied",
+		Status:    application.StatusApplied,
 		Source:    req.Source,
 		ResumeURL: req.ResumeURL,
 		NotesText: req.CoverLetter,
+
+		UTMSource:   utils.NormalizeUTMParam(req.UTMSource),
+		UTMMedium:   utils.NormalizeUTMParam(req.UTMMedium),
+		UTMCampaign: utils.NormalizeUTMParam(req.UTMCampaign),
+		Referrer:    utils.NormalizeReferrer(req.Referrer),
 	}
 
 	// Set default source if not provided
@@ -90,41 +146,146 @@ func (s *applicationService) ApplyForJob(ctx context.Context, req *application.A
 		return nil, fmt.Errorf("application validation failed: %w", err)
 	}
 
-	// Create application
+	// Create application. The unique (job_id, user_id) index over open applications is
+	// the source of truth for duplicates; concurrent requests that passed CanApplyForJob end here.
 	if err := s.appRepo.Create(ctx, app); err != nil {
+		if errors.Is(err, application.ErrAlreadyApplied) {
+			return nil, application.ErrAlreadyApplied
+		}
 		return nil, fmt.Errorf("failed to create application: %w", err)
 	}
 
+	// The application keeps its own copy of a library resume, so replacing or deleting the
+	// library document does not change what was submitted
+	if _, err := s.snapshotResume(ctx, app); err != nil {
+		warnSnapshotFailure(ctx, err, app.ResumeURL)
+	}
+
+	// Increment application count for job only after a confirmed insert
+	if err := s.jobRepo.IncrementApplications(ctx, req.JobID); err != nil {
+		config.WithContext(ctx).WithError(err).Warn("failed to increment applications count")
+	}
+
 	// Create initial stage
 	stage := &application.JobApplicationStage{
 		ApplicationID: app.ID,
-		StageName:     "applied",
+		StageName:     application.StatusApplied,
 		Description:   "Application submitted",
 	}
 	if err := s.appRepo.CreateStage(ctx, stage); err != nil {
 		return nil, fmt.Errorf("failed to create initial stage: %w", err)
 	}
 
+	// Store screening answers
+	for i := range answers {
+		answers[i].ApplicationID = app.ID
+	}
+	if err := s.appRepo.CreateAnswers(ctx, answers); err != nil {
+		return nil, fmt.Errorf("failed to save screening answers: %w", err)
+	}
+
+	// A knockout answer that does not match rejects the application straight away
+	if knockedOut {
+		if err := s.rejectKnockedOut(ctx, app, stage); err != nil {
+			return nil, err
+		}
+	}
+	s.publishApplicationEvent(ctx, app, webhook.EventApplicationCreated, "")
+
 	// Upload documents if provided
 	for _, docReq := range req.Documents {
 		docReq.ApplicationID = app.ID
 		docReq.UserID = req.UserID
-		if _, err := s.UploadApplicationDocument(ctx, &docReq); err != nil {
+		if _, err := s.UploadApplicationDocument(ctx, &docReq, nil); err != nil {
 			// Log error but don't fail the application
-			fmt.Printf("failed to upload document: %v\n", err)
+			config.WithContext(ctx).WithError(err).Warn("failed to upload document")
 		}
 	}
 
-	// Increment application count for job
-	s.jobRepo.IncrementApplications(ctx, req.JobID)
-
-	// Send notification (async)
-	go s.NotifyApplicationReceived(ctx, app.ID)
+	// Send notification (async). The request context is done once the response is
+	// written, so the notification runs detached from it.
+	go s.NotifyApplicationReceived(context.Background(), app.ID)
 
 	// Reload application with relationships
 	return s.appRepo.FindByID(ctx, app.ID)
 }
 
+// screenAnswers validates answers against the job's screening questions and returns them in
+// canonical form. knockedOut reports whether any knockout question was answered wrongly.
+func (s *applicationService) screenAnswers(ctx context.Context, jobID int64, answers []application.AnswerRequest) ([]application.ApplicationAnswer, bool, error) {
+	questions, err := s.jobRepo.ListQuestionsByJob(ctx, jobID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get screening questions: %w", err)
+	}
+
+	known := make(map[int64]bool, len(questions))
+	for _, question := range questions {
+		known[question.ID] = true
+	}
+
+	given := make(map[int64]string, len(answers))
+	for _, answer := range answers {
+		if !known[answer.QuestionID] {
+			return nil, false, application.ErrUnknownQuestion.WithField(fmt.Sprintf("answers.%d", answer.QuestionID), "is not a question of this job")
+		}
+		given[answer.QuestionID] = answer.Value
+	}
+
+	result := make([]application.ApplicationAnswer, 0, len(questions))
+	knockedOut := false
+	for i := range questions {
+		question := &questions[i]
+		field := fmt.Sprintf("answers.%d", question.ID)
+
+		value, ok := given[question.ID]
+		if !ok || strings.TrimSpace(value) == "" {
+			if question.IsRequired || question.IsKnockout {
+				return nil, false, application.ErrAnswerRequired.WithField(field, "is required")
+			}
+			continue
+		}
+
+		normalized, err := question.NormalizeAnswer(value)
+		if err != nil {
+			return nil, false, application.ErrInvalidAnswer.WithField(field, err.Error())
+		}
+		if !question.PassesKnockout(normalized) {
+			knockedOut = true
+		}
+
+		result = append(result, application.ApplicationAnswer{
+			QuestionID:  question.ID,
+			AnswerValue: normalized,
+		})
+	}
+
+	return result, knockedOut, nil
+}
+
+// rejectKnockedOut rejects a new application that failed a knockout question, completing its applied stage
+func (s *applicationService) rejectKnockedOut(ctx context.Context, app *application.JobApplication, appliedStage *application.JobApplicationStage) error {
+	if err := s.appRepo.CompleteStage(ctx, appliedStage.ID, "Application rejected"); err != nil {
+		return fmt.Errorf("failed to complete applied stage: %w", err)
+	}
+
+	app.Status = application.StatusRejected
+	if err := s.appRepo.Update(ctx, app); err != nil {
+		return fmt.Errorf("failed to reject application: %w", err)
+	}
+
+	stage := &application.JobApplicationStage{
+		ApplicationID: app.ID,
+		StageName:     application.StatusRejected,
+		Description:   "Automatically rejected by a screening question",
+		Notes:         application.RejectionReasonKnockout,
+	}
+	stage.Complete()
+	if err := s.appRepo.CreateStage(ctx, stage); err != nil {
+		return fmt.Errorf("failed to create rejection stage: %w", err)
+	}
+	return nil
+}
+
 // WithdrawApplication withdraws a job application
 func (s *applicationService) WithdrawApplication(ctx context.Context, applicationID, userID int64) error {
 	// Check ownership
@@ -135,24 +296,31 @@ func (s *applicationService) WithdrawApplication(ctx context.Context, applicatio
 	// Get application
 	app, err := s.appRepo.FindByID(ctx, applicationID)
 	if err != nil {
-		return fmt.Errorf("application not found: %w", err)
+		return applicationLookupError(err)
 	}
 
 	// Check if can be withdrawn
+	if app.IsOffered() {
+		return application.ErrWithdrawAfterOffer
+	}
 	if !app.CanWithdraw() {
-		return errors.New("application cannot be withdrawn in current status")
+		return application.ErrCannotWithdraw
 	}
 
 	// Update status
-	app.Status = "withdrawn"
+	now := time.Now()
+	previousStatus := app.Status
+	app.Status = application.StatusWithdrawn
+	app.ClosedAt = &now
 	if err := s.appRepo.Update(ctx, app); err != nil {
 		return fmt.Errorf("failed to withdraw application: %w", err)
 	}
+	s.publishApplicationEvent(ctx, app, webhook.EventApplicationStatusChanged, previousStatus)
 
 	// Create withdrawal stage
 	stage := &application.JobApplicationStage{
 		ApplicationID: applicationID,
-		StageName:     "withdrawn",
+		StageName:     application.StatusWithdrawn,
 		Description:   "Application withdrawn by applicant",
 		Notes:         "Withdrawn by user",
 	}
@@ -174,7 +342,32 @@ func (s *applicationService) GetMyApplications(ctx context.Context, userID int64
 	}
 
 	// Build response
-	return s.buildApplicationListResponse(ctx, apps, total, page, limit)
+	return s.buildApplicationListResponse(ctx, apps, total, page, limit, 0)
+}
+
+// GetCompanyApplicationsByCursor retrieves a company's applications newest first, continuing after the given cursor
+func (s *applicationService) GetCompanyApplicationsByCursor(ctx context.Context, ec *employer.EmployerContext, filter application.ApplicationFilter, cursor string, limit int) ([]application.ApplicationSummary, string, error) {
+	if err := checkEmployerContext(ec); err != nil {
+		return nil, "", err
+	}
+	companyID := ec.CompanyID
+
+	// Verify company exists
+	_, err := s.companyRepo.FindByID(ctx, companyID)
+	if err != nil {
+		return nil, "", fmt.Errorf("company not found: %w", err)
+	}
+
+	apps, next, err := s.appRepo.ListByCompanyCursor(ctx, companyID, filter, cursor, limit)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get applications: %w", err)
+	}
+
+	summaries, err := s.buildApplicationSummaries(ctx, apps, ec.UserID)
+	if err != nil {
+		return nil, "", err
+	}
+	return summaries, next, nil
 }
 
 // GetApplicationDetail retrieves detailed application information
@@ -184,7 +377,7 @@ func (s *applicationService) GetApplicationDetail(ctx context.Context, applicati
 		return nil, err
 	}
 
-	return s.buildApplicationDetailResponse(ctx, applicationID)
+	return s.buildApplicationDetailResponse(ctx, applicationID, 0)
 }
 
 // GetMyApplicationStats retrieves user's application statistics
@@ -194,17 +387,24 @@ func (s *applicationService) GetMyApplicationStats(ctx context.Context, userID i
 
 // ===== Application Review and Management (Employer) =====
 
-// GetJobApplications retrieves applications for a job
-func (s *applicationService) GetJobApplications(ctx context.Context, jobID int64, filter application.ApplicationFilter, page, limit int) (*application.ApplicationListResponse, error) {
-	// Verify job exists
-	_, err := s.jobRepo.FindByID(ctx, jobID)
-	if err != nil {
-		return nil, fmt.Errorf("job not found: %w", err)
+// GetJobApplications retrieves applications for one of the employer's company's jobs
+func (s *applicationService) GetJobApplications(ctx context.Context, ec *employer.EmployerContext, jobID int64, filter application.ApplicationFilter, page, limit int) (*application.ApplicationListResponse, error) {
+	if _, err := s.findEmployerJob(ctx, ec, jobID); err != nil {
+		return nil, err
 	}
 
 	// Set job ID in filter
 	filter.JobID = jobID
 
+	// Answer filters are compared in the same canonical form answers are stored in
+	if len(filter.Answers) > 0 {
+		answers, err := s.normalizeAnswerFilter(ctx, jobID, filter.Answers)
+		if err != nil {
+			return nil, err
+		}
+		filter.Answers = answers
+	}
+
 	// Get applications
 	apps, total, err := s.appRepo.ListByJob(ctx, jobID, filter, page, limit)
 	if err != nil {
@@ -212,11 +412,124 @@ func (s *applicationService) GetJobApplications(ctx context.Context, jobID int64
 	}
 
 	// Build response
-	return s.buildApplicationListResponse(ctx, apps, total, page, limit)
+	return s.buildApplicationListResponse(ctx, apps, total, page, limit, ec.UserID)
+}
+
+// findEmployerJob returns the job when it belongs to the company the employer acts for
+func (s *applicationService) findEmployerJob(ctx context.Context, ec *employer.EmployerContext, jobID int64) (*job.Job, error) {
+	if err := checkEmployerContext(ec); err != nil {
+		return nil, err
+	}
+
+	j, err := s.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, jobLookupError(err)
+	}
+	if !ec.InCompany(j.CompanyID) {
+		return nil, application.ErrEmployerAccessDenied
+	}
+	return j, nil
+}
+
+// normalizeAnswerFilter converts answer filter values to the canonical form of their question type
+func (s *applicationService) normalizeAnswerFilter(ctx context.Context, jobID int64, filter map[int64]string) (map[int64]string, error) {
+	questions, err := s.jobRepo.ListQuestionsByJob(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get screening questions: %w", err)
+	}
+
+	byID := make(map[int64]*job.JobQuestion, len(questions))
+	for i := range questions {
+		byID[questions[i].ID] = &questions[i]
+	}
+
+	normalized := make(map[int64]string, len(filter))
+	for questionID, value := range filter {
+		field := fmt.Sprintf("answer[%d]", questionID)
+		question, ok := byID[questionID]
+		if !ok {
+			return nil, application.ErrUnknownQuestion.WithField(field, "is not a question of this job")
+		}
+		answer, err := question.NormalizeAnswer(value)
+		if err != nil {
+			return nil, application.ErrInvalidAnswer.WithField(field, err.Error())
+		}
+		normalized[questionID] = answer
+	}
+	return normalized, nil
+}
+
+// GetJobApplicationsBoard groups a job's applications by status for the kanban view. Every
+// board status is returned, with count 0 when it has no applications, together with the
+// job's pipeline so custom stages can be shown within their status column.
+func (s *applicationService) GetJobApplicationsBoard(ctx context.Context, ec *employer.EmployerContext, jobID int64, perColumn int, sortBy string) (*application.ApplicationBoard, error) {
+	j, err := s.findEmployerJob(ctx, ec, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if perColumn <= 0 {
+		perColumn = application.DefaultBoardPerColumn
+	}
+	if perColumn > application.MaxBoardPerColumn {
+		perColumn = application.MaxBoardPerColumn
+	}
+	if sortBy != application.BoardSortMatchScore {
+		sortBy = application.BoardSortAppliedAt
+	}
+
+	rows, err := s.appRepo.GetJobBoard(ctx, jobID, application.ApplicationBoardStatuses, perColumn, sortBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application board: %w", err)
+	}
+
+	redaction, err := s.applicantRedactionFor(ctx, ec.UserID, []int64{j.CompanyID})
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline, err := loadJobPipeline(ctx, s.jobRepo, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	board := &application.ApplicationBoard{
+		JobID:     jobID,
+		PerColumn: perColumn,
+		SortBy:    sortBy,
+		Statuses:  application.ApplicationBoardStatuses,
+		Stages:    make([]application.BoardStage, 0, len(pipeline)),
+		Columns:   make(map[string]application.ApplicationBoardColumn, len(application.ApplicationBoardStatuses)),
+	}
+	for _, stage := range pipeline {
+		board.Stages = append(board.Stages, application.BoardStage{Name: stage.Name, Status: stage.MapsToStatus, Custom: stage.IsCustom()})
+	}
+	for _, status := range application.ApplicationBoardStatuses {
+		board.Columns[status] = application.ApplicationBoardColumn{Applications: []application.ApplicationSummary{}}
+	}
+
+	for _, row := range rows {
+		column := board.Columns[row.Status]
+		column.Count = row.StatusCount
+		summary := row.ApplicationSummary
+		summary.DaysSinceApplied = int(time.Since(summary.AppliedAt).Hours() / 24)
+		if redaction.applies(&j.CompanyID, summary.Status) {
+			redactApplicantSummary(&summary)
+		}
+		column.Applications = append(column.Applications, summary)
+		board.Columns[row.Status] = column
+	}
+
+	return board, nil
 }
 
 // GetCompanyApplications retrieves all applications for a company
-func (s *applicationService) GetCompanyApplications(ctx context.Context, companyID int64, filter application.ApplicationFilter, page, limit int) (*application.ApplicationListResponse, error) {
+func (s *applicationService) GetCompanyApplications(ctx context.Context, ec *employer.EmployerContext, filter application.ApplicationFilter, page, limit int) (*application.ApplicationListResponse, error) {
+	if err := checkEmployerContext(ec); err != nil {
+		return nil, err
+	}
+	companyID := ec.CompanyID
+
 	// Verify company exists
 	_, err := s.companyRepo.FindByID(ctx, companyID)
 	if err != nil {
@@ -233,29 +546,27 @@ func (s *applicationService) GetCompanyApplications(ctx context.Context, company
 	}
 
 	// Build response
-	return s.buildApplicationListResponse(ctx, apps, total, page, limit)
+	return s.buildApplicationListResponse(ctx, apps, total, page, limit, ec.UserID)
 }
 
 // GetApplicationForReview retrieves application for employer review
-func (s *applicationService) GetApplicationForReview(ctx context.Context, applicationID, employerUserID int64) (*application.ApplicationDetailResponse, error) {
-	// Check employer access
-	if err := s.CheckEmployerAccess(ctx, applicationID, employerUserID); err != nil {
+func (s *applicationService) GetApplicationForReview(ctx context.Context, ec *employer.EmployerContext, applicationID int64) (*application.ApplicationDetailResponse, error) {
+	app, err := s.findEmployerApplication(ctx, ec, applicationID)
+	if err != nil {
 		return nil, err
 	}
 
 	// Mark as viewed if not already
-	app, _ := s.appRepo.FindByID(ctx, applicationID)
-	if app != nil && !app.ViewedByEmployer {
-		s.MarkAsViewed(ctx, applicationID, employerUserID)
+	if !app.ViewedByEmployer {
+		s.appRepo.MarkAsViewed(ctx, applicationID)
 	}
 
-	return s.buildApplicationDetailResponse(ctx, applicationID)
+	return s.buildApplicationDetailResponse(ctx, applicationID, ec.UserID)
 }
 
 // MarkAsViewed marks application as viewed by employer
-func (s *applicationService) MarkAsViewed(ctx context.Context, applicationID, employerUserID int64) error {
-	// Check employer access
-	if err := s.CheckEmployerAccess(ctx, applicationID, employerUserID); err != nil {
+func (s *applicationService) MarkAsViewed(ctx context.Context, ec *employer.EmployerContext, applicationID int64) error {
+	if _, err := s.findEmployerApplication(ctx, ec, applicationID); err != nil {
 		return err
 	}
 
@@ -263,9 +574,8 @@ func (s *applicationService) MarkAsViewed(ctx context.Context, applicationID, em
 }
 
 // ToggleBookmark toggles application bookmark status
-func (s *applicationService) ToggleBookmark(ctx context.Context, applicationID, employerUserID int64) error {
-	// Check employer access
-	if err := s.CheckEmployerAccess(ctx, applicationID, employerUserID); err != nil {
+func (s *applicationService) ToggleBookmark(ctx context.Context, ec *employer.EmployerContext, applicationID int64) error {
+	if _, err := s.findEmployerApplication(ctx, ec, applicationID); err != nil {
 		return err
 	}
 
@@ -273,53 +583,54 @@ func (s *applicationService) ToggleBookmark(ctx context.Context, applicationID,
 }
 
 // GetBookmarkedApplications retrieves bookmarked applications
-func (s *applicationService) GetBookmarkedApplications(ctx context.Context, companyID int64, page, limit int) (*application.ApplicationListResponse, error) {
-	apps, total, err := s.appRepo.GetBookmarkedApplications(ctx, companyID, page, limit)
+func (s *applicationService) GetBookmarkedApplications(ctx context.Context, ec *employer.EmployerContext, page, limit int) (*application.ApplicationListResponse, error) {
+	if err := checkEmployerContext(ec); err != nil {
+		return nil, err
+	}
+
+	apps, total, err := s.appRepo.GetBookmarkedApplications(ctx, ec.CompanyID, page, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get bookmarked applications: %w", err)
 	}
 
-	return s.buildApplicationListResponse(ctx, apps, total, page, limit)
+	return s.buildApplicationListResponse(ctx, apps, total, page, limit, ec.UserID)
 }
 
 // ===== Application Status Workflow (Employer) =====
 
 // MoveToScreening moves application to screening stage
-func (s *applicationService) MoveToScreening(ctx context.Context, applicationID, handledBy int64, notes string) error {
-	return s.updateApplicationStage(ctx, applicationID, handledBy, "screening", "Moved to screening", notes)
+func (s *applicationService) MoveToScreening(ctx context.Context, ec *employer.EmployerContext, applicationID int64, notes string) error {
+	return s.updateApplicationStage(ctx, ec, applicationID, application.StatusScreening, application.StatusScreening, "Moved to screening", notes)
 }
 
 // MoveToShortlist moves application to shortlist stage
-func (s *applicationService) MoveToShortlist(ctx context.Context, applicationID, handledBy int64, notes string) error {
-	return s.updateApplicationStage(ctx, applicationID, handledBy, "shortlisted", "Shortlisted for interview", notes)
+func (s *applicationService) MoveToShortlist(ctx context.Context, ec *employer.EmployerContext, applicationID int64, notes string) error {
+	return s.updateApplicationStage(ctx, ec, applicationID, application.StatusShortlisted, application.StatusShortlisted, "Shortlisted for interview", notes)
 }
 
 // MoveToInterview moves application to interview stage
-func (s *applicationService) MoveToInterview(ctx context.Context, applicationID, handledBy int64, notes string) error {
-	return s.updateApplicationStage(ctx, applicationID, handledBy, "interview", "Interview scheduled", notes)
+func (s *applicationService) MoveToInterview(ctx context.Context, ec *employer.EmployerContext, applicationID int64, notes string) error {
+	return s.updateApplicationStage(ctx, ec, applicationID, application.StatusInterview, application.StatusInterview, "Interview scheduled", notes)
 }
 
 // MakeOffer makes job offer to applicant
-func (s *applicationService) MakeOffer(ctx context.Context, applicationID, handledBy int64, notes string) error {
-	return s.updateApplicationStage(ctx, applicationID, handledBy, "offered", "Job offer extended", notes)
+func (s *applicationService) MakeOffer(ctx context.Context, ec *employer.EmployerContext, applicationID int64, notes string) error {
+	return s.updateApplicationStage(ctx, ec, applicationID, application.StatusOffered, application.StatusOffered, "Job offer extended", notes)
 }
 
 // MarkAsHired marks applicant as hired
-func (s *applicationService) MarkAsHired(ctx context.Context, applicationID, handledBy int64, notes string) error {
-	return s.updateApplicationStage(ctx, applicationID, handledBy, "hired", "Applicant hired", notes)
+func (s *applicationService) MarkAsHired(ctx context.Context, ec *employer.EmployerContext, applicationID int64, notes string) error {
+	return s.updateApplicationStage(ctx, ec, applicationID, application.StatusHired, application.StatusHired, "Applicant hired", notes)
 }
 
 // RejectApplication rejects an application
-func (s *applicationService) RejectApplication(ctx context.Context, applicationID, handledBy int64, reason string) error {
-	// Check employer access
-	if err := s.CheckEmployerAccess(ctx, applicationID, handledBy); err != nil {
+func (s *applicationService) RejectApplication(ctx context.Context, ec *employer.EmployerContext, applicationID int64, reason string, doNotReapply bool) error {
+	app, err := s.findEmployerApplication(ctx, ec, applicationID)
+	if err != nil {
 		return err
 	}
-
-	// Get application
-	app, err := s.appRepo.FindByID(ctx, applicationID)
-	if err != nil {
-		return fmt.Errorf("application not found: %w", err)
+	if app.IsCompleted() {
+		return application.ErrApplicationCompleted
 	}
 
 	// Complete current stage
@@ -329,17 +640,22 @@ func (s *applicationService) RejectApplication(ctx context.Context, applicationI
 	}
 
 	// Update status
-	app.Status = "rejected"
+	now := time.Now()
+	previousStatus := app.Status
+	app.Status = application.StatusRejected
+	app.ClosedAt = &now
+	app.DoNotReapply = doNotReapply
 	if err := s.appRepo.Update(ctx, app); err != nil {
 		return fmt.Errorf("failed to reject application: %w", err)
 	}
+	s.publishApplicationEvent(ctx, app, webhook.EventApplicationStatusChanged, previousStatus)
 
 	// Create rejection stage
 	stage := &application.JobApplicationStage{
 		ApplicationID: applicationID,
-		StageName:     "rejected",
+		StageName:     application.StatusRejected,
 		Description:   "Application rejected",
-		HandledBy:     &handledBy,
+		HandledBy:     &ec.UserID,
 		Notes:         reason,
 	}
 	stage.Complete()
@@ -348,55 +664,98 @@ func (s *applicationService) RejectApplication(ctx context.Context, applicationI
 	}
 
 	// Send notification
-	go s.NotifyStatusUpdate(ctx, applicationID, "rejected")
+	go s.NotifyStatusUpdate(context.Background(), applicationID, application.StatusRejected)
 
 	return nil
 }
 
-// BulkUpdateStatus updates status for multiple applications
-func (s *applicationService) BulkUpdateStatus(ctx context.Context, applicationIDs []int64, status string, handledBy int64) error {
-	for _, appID := range applicationIDs {
-		// Check access for each application
-		if err := s.CheckEmployerAccess(ctx, appID, handledBy); err != nil {
-			continue // Skip unauthorized applications
-		}
+// BulkUpdateStatus updates status for multiple applications. Applications of other companies
+// and ones that cannot move to the status are skipped.
+func (s *applicationService) BulkUpdateStatus(ctx context.Context, ec *employer.EmployerContext, applicationIDs []int64, status string) error {
+	if err := checkEmployerContext(ec); err != nil {
+		return err
+	}
 
-		// Update status based on the status value
-		switch status {
-		case "screening":
-			s.MoveToScreening(ctx, appID, handledBy, "Bulk update")
-		case "shortlisted":
-			s.MoveToShortlist(ctx, appID, handledBy, "Bulk update")
-		case "interview":
-			s.MoveToInterview(ctx, appID, handledBy, "Bulk update")
-		case "offered":
-			s.MakeOffer(ctx, appID, handledBy, "Bulk update")
-		case "hired":
-			s.MarkAsHired(ctx, appID, handledBy, "Bulk update")
-		case "rejected":
-			s.RejectApplication(ctx, appID, handledBy, "Bulk rejection")
-		}
+	notes := "Bulk update"
+	if status == application.StatusRejected {
+		notes = "Bulk rejection"
+	}
+	for _, appID := range applicationIDs {
+		s.moveApplicationToStatus(ctx, ec, appID, status, notes)
 	}
 
 	return nil
 }
 
-// updateApplicationStage is a helper to update application stage
-func (s *applicationService) updateApplicationStage(ctx context.Context, applicationID, handledBy int64, newStatus, description, notes string) error {
-	// Check employer access
-	if err := s.CheckEmployerAccess(ctx, applicationID, handledBy); err != nil {
+// moveApplicationToStatus moves an application with the stage method of the status, so stage
+// records, webhooks and notifications are the same as for a single status change
+func (s *applicationService) moveApplicationToStatus(ctx context.Context, ec *employer.EmployerContext, applicationID int64, status, notes string) error {
+	switch status {
+	case application.StatusScreening:
+		return s.MoveToScreening(ctx, ec, applicationID, notes)
+	case application.StatusShortlisted:
+		return s.MoveToShortlist(ctx, ec, applicationID, notes)
+	case application.StatusInterview:
+		return s.MoveToInterview(ctx, ec, applicationID, notes)
+	case application.StatusOffered:
+		return s.MakeOffer(ctx, ec, applicationID, notes)
+	case application.StatusHired:
+		return s.MarkAsHired(ctx, ec, applicationID, notes)
+	case application.StatusRejected:
+		return s.RejectApplication(ctx, ec, applicationID, notes, false)
+	}
+	return application.ErrInvalidStatusTransition.WithField("status", status)
+}
+
+// MoveToStage moves an application to a stage of its job's pipeline. Statuses go through their
+// own stage methods; a custom stage puts the application in the status it maps to and is
+// recorded under its own name.
+func (s *applicationService) MoveToStage(ctx context.Context, ec *employer.EmployerContext, applicationID int64, stageName, notes string) error {
+	if application.IsValidStatus(stageName) {
+		return s.moveApplicationToStatus(ctx, ec, applicationID, stageName, notes)
+	}
+
+	app, err := s.findEmployerApplication(ctx, ec, applicationID)
+	if err != nil {
 		return err
 	}
+	pipeline, err := loadJobPipeline(ctx, s.jobRepo, app.JobID)
+	if err != nil {
+		return err
+	}
+	stage := job.FindPipelineStage(pipeline, stageName)
+	if stage == nil {
+		return application.ErrStageNotInPipeline.WithField("stage", stageName)
+	}
+	if !stage.IsCustom() {
+		return s.moveApplicationToStatus(ctx, ec, applicationID, stage.MapsToStatus, notes)
+	}
 
-	// Get application
-	app, err := s.appRepo.FindByID(ctx, applicationID)
+	return s.advanceApplicationStage(ctx, app, ec.UserID, stage.MapsToStatus, stage.Name, "Moved to "+stage.Name, notes)
+}
+
+// updateApplicationStage moves one of the employer's company's applications to a stage as
+// the employer. stageName is newStatus itself or a custom stage of the job's pipeline that
+// maps to it.
+func (s *applicationService) updateApplicationStage(ctx context.Context, ec *employer.EmployerContext, applicationID int64, newStatus, stageName, description, notes string) error {
+	app, err := s.findEmployerApplication(ctx, ec, applicationID)
 	if err != nil {
-		return fmt.Errorf("application not found: %w", err)
+		return err
 	}
+	return s.advanceApplicationStage(ctx, app, ec.UserID, newStatus, stageName, description, notes)
+}
+
+// advanceApplicationStage moves app to a stage on behalf of handledBy, once the caller has
+// checked that they may
+func (s *applicationService) advanceApplicationStage(ctx context.Context, app *application.JobApplication, handledBy int64, newStatus, stageName, description, notes string) error {
+	applicationID := app.ID
 
 	// Check if application is in valid state
 	if app.IsCompleted() {
-		return errors.New("cannot update completed application")
+		return application.ErrApplicationCompleted
+	}
+	if err := s.checkStageMove(ctx, app, newStatus, stageName); err != nil {
+		return err
 	}
 
 	// Complete current stage if exists
@@ -406,15 +765,18 @@ func (s *applicationService) updateApplicationStage(ctx context.Context, applica
 	}
 
 	// Update application status
+	previousStatus := app.Status
 	app.Status = newStatus
 	if err := s.appRepo.Update(ctx, app); err != nil {
 		return fmt.Errorf("failed to update application: %w", err)
 	}
+	s.publishApplicationEvent(ctx, app, webhook.EventApplicationStatusChanged, previousStatus)
 
 	// Create new stage
 	stage := &application.JobApplicationStage{
 		ApplicationID: applicationID,
-		StageName:     newStatus,
+		StageName:     stageName,
+		Status:        newStatus,
 		Description:   description,
 		HandledBy:     &handledBy,
 		Notes:         notes,
@@ -424,8 +786,41 @@ func (s *applicationService) updateApplicationStage(ctx context.Context, applica
 	}
 
 	// Send notification
-	go s.NotifyStatusUpdate(ctx, applicationID, newStatus)
+	go s.NotifyStatusUpdate(context.Background(), applicationID, newStatus)
+
+	return nil
+}
+
+// checkStageMove checks that the application may move to stageName, which puts it in
+// newStatus. Moves to another status follow the status transitions; within its status an
+// application only moves on to a later custom stage of the job's pipeline.
+func (s *applicationService) checkStageMove(ctx context.Context, app *application.JobApplication, newStatus, stageName string) error {
+	if newStatus != app.Status {
+		if !application.IsValidTransition(app.Status, newStatus) {
+			return application.ErrInvalidStatusTransition.WithField("status", app.Status)
+		}
+		return nil
+	}
+	if stageName == newStatus {
+		return application.ErrInvalidStatusTransition.WithField("status", app.Status)
+	}
+
+	pipeline, err := loadJobPipeline(ctx, s.jobRepo, app.JobID)
+	if err != nil {
+		return err
+	}
+	target := job.FindPipelineStage(pipeline, stageName)
+	if target == nil {
+		return application.ErrStageNotInPipeline.WithField("stage", stageName)
+	}
 
+	currentName := app.Status
+	if current, err := s.appRepo.GetCurrentStage(ctx, app.ID); err == nil && current != nil {
+		currentName = current.StageName
+	}
+	if current := job.FindPipelineStage(pipeline, currentName); current != nil && target.StageOrder <= current.StageOrder {
+		return application.ErrInvalidStatusTransition.WithField("stage", currentName)
+	}
 	return nil
 }
 
@@ -446,16 +841,15 @@ func (s *applicationService) GetStageHistory(ctx context.Context, applicationID
 	return s.appRepo.GetStageHistory(ctx, applicationID)
 }
 
-// CompleteStage marks a stage as completed
-func (s *applicationService) CompleteStage(ctx context.Context, stageID, handledBy int64, notes string) error {
+// CompleteStage marks a stage of one of the employer's company's applications as completed
+func (s *applicationService) CompleteStage(ctx context.Context, ec *employer.EmployerContext, stageID int64, notes string) error {
 	// Get stage
 	stage, err := s.appRepo.FindStageByID(ctx, stageID)
 	if err != nil {
 		return fmt.Errorf("stage not found: %w", err)
 	}
 
-	// Check employer access
-	if err := s.CheckEmployerAccess(ctx, stage.ApplicationID, handledBy); err != nil {
+	if _, err := s.findEmployerApplication(ctx, ec, stage.ApplicationID); err != nil {
 		return err
 	}
 
@@ -465,14 +859,15 @@ func (s *applicationService) CompleteStage(ctx context.Context, stageID, handled
 
 // ===== Document Management =====
 
-// UploadApplicationDocument uploads a document for application
-func (s *applicationService) UploadApplicationDocument(ctx context.Context, req *application.UploadDocumentRequest) (*application.ApplicationDocument, error) {
+// UploadApplicationDocument stores a document for an application. The file is validated and
+// uploaded like company documents; without a file the request's pre-uploaded FileURL is
+// used, as long as document URLs are still accepted.
+func (s *applicationService) UploadApplicationDocument(ctx context.Context, req *application.UploadDocumentRequest, file *multipart.FileHeader) (*application.ApplicationDocument, error) {
 	// Check ownership
 	if err := s.CheckApplicationOwnership(ctx, req.ApplicationID, req.UserID); err != nil {
 		return nil, err
 	}
 
-	// Create document
 	doc := &application.ApplicationDocument{
 		ApplicationID: req.ApplicationID,
 		UserID:        req.UserID,
@@ -484,13 +879,63 @@ func (s *applicationService) UploadApplicationDocument(ctx context.Context, req
 		Notes:         req.Notes,
 	}
 
+	if file != nil {
+		maxSize := s.uploads.Limits().DocumentSize
+		if err := s.uploads.ValidateFile(file, DocumentTypes, maxSize); err != nil {
+			return nil, documentFileError(err, maxSize)
+		}
+
+		fileURL, err := s.uploads.UploadFile(ctx, file, applicationDocumentDirectory)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload document: %w", err)
+		}
+
+		doc.FileURL = fileURL
+		doc.FileType = strings.TrimPrefix(strings.ToLower(filepath.Ext(file.Filename)), ".")
+		doc.FileSize = file.Size
+		doc.Uploaded = true
+		if doc.FileName == "" {
+			doc.FileName = file.Filename
+		}
+	} else {
+		if doc.FileURL == "" || !s.documentURLsAllowed {
+			return nil, application.ErrDocumentFileRequired
+		}
+		if _, err := s.snapshotDocument(ctx, doc); err != nil {
+			warnSnapshotFailure(ctx, err, doc.FileURL)
+		}
+	}
+
 	if err := s.appRepo.CreateDocument(ctx, doc); err != nil {
-		return nil, fmt.Errorf("failed to upload document: %w", err)
+		s.deleteDocumentFile(ctx, doc)
+		return nil, fmt.Errorf("failed to save document: %w", err)
 	}
 
 	return doc, nil
 }
 
+// documentFileError converts an upload validation failure into the matching application error
+func documentFileError(err error, maxSize int64) error {
+	switch {
+	case errors.Is(err, ErrFileTooLarge):
+		return application.DocumentTooLarge(maxSize)
+	case errors.Is(err, ErrFileTypeNotAllowed):
+		return application.ErrDocumentTypeNotAllowed
+	}
+	return fmt.Errorf("invalid document file: %w", err)
+}
+
+// deleteDocumentFile removes the stored file of a document uploaded through the upload service.
+// Failures are logged only; an orphaned file must not block deleting the record.
+func (s *applicationService) deleteDocumentFile(ctx context.Context, doc *application.ApplicationDocument) {
+	if !doc.Uploaded || s.uploads == nil {
+		return
+	}
+	if err := s.uploads.DeleteFile(ctx, doc.FileURL); err != nil {
+		config.WithContext(ctx).WithError(err).Warnf("failed to delete application document file %s", doc.FileURL)
+	}
+}
+
 // UpdateDocument updates document information
 func (s *applicationService) UpdateDocument(ctx context.Context, documentID int64, req *application.UpdateDocumentRequest) (*application.ApplicationDocument, error) {
 	// Get document
@@ -503,21 +948,38 @@ func (s *applicationService) UpdateDocument(ctx context.Context, documentID int6
 	if req.FileName != "" {
 		doc.FileName = req.FileName
 	}
-	if req.FileURL != "" {
+	var (
+		replaced    application.ApplicationDocument
+		snapshotted bool
+	)
+	if req.FileURL != "" && req.FileURL != doc.FileURL {
+		if !s.documentURLsAllowed {
+			return nil, application.ErrDocumentFileRequired
+		}
+		replaced = *doc
 		doc.FileURL = req.FileURL
+		doc.Uploaded = false
+		doc.SourceURL = nil
+		if snapshotted, err = s.snapshotDocument(ctx, doc); err != nil {
+			warnSnapshotFailure(ctx, err, doc.FileURL)
+		}
 	}
 	if req.Notes != "" {
 		doc.Notes = req.Notes
 	}
 
 	if err := s.appRepo.UpdateDocument(ctx, doc); err != nil {
+		if snapshotted {
+			s.deleteDocumentFile(ctx, doc)
+		}
 		return nil, fmt.Errorf("failed to update document: %w", err)
 	}
+	s.deleteDocumentFile(ctx, &replaced)
 
 	return doc, nil
 }
 
-// DeleteDocument deletes an application document
+// DeleteDocument deletes an application document and its stored file
 func (s *applicationService) DeleteDocument(ctx context.Context, documentID, userID int64) error {
 	// Get document
 	doc, err := s.appRepo.FindDocumentByID(ctx, documentID)
@@ -530,7 +992,38 @@ func (s *applicationService) DeleteDocument(ctx context.Context, documentID, use
 		return err
 	}
 
-	return s.appRepo.DeleteDocument(ctx, documentID)
+	if err := s.appRepo.DeleteDocument(ctx, documentID); err != nil {
+		return err
+	}
+	s.deleteDocumentFile(ctx, doc)
+	return nil
+}
+
+// DeleteApplication permanently deletes an application together with its documents' stored
+// files and its resume snapshot
+func (s *applicationService) DeleteApplication(ctx context.Context, applicationID int64) error {
+	app, err := s.appRepo.FindByID(ctx, applicationID)
+	if err != nil {
+		return applicationLookupError(err)
+	}
+
+	docs, err := s.appRepo.ListDocumentsByApplication(ctx, applicationID)
+	if err != nil {
+		return fmt.Errorf("failed to list application documents: %w", err)
+	}
+
+	// Documents go with the application through the foreign key cascade
+	if err := s.appRepo.Delete(ctx, applicationID); err != nil {
+		return fmt.Errorf("failed to delete application: %w", err)
+	}
+
+	for i := range docs {
+		s.deleteDocumentFile(ctx, &docs[i])
+	}
+	if app.HasResumeSnapshot() {
+		s.deleteSnapshotFile(ctx, app.ResumeURL)
+	}
+	return nil
 }
 
 // GetApplicationDocuments retrieves all documents for an application
@@ -538,6 +1031,46 @@ func (s *applicationService) GetApplicationDocuments(ctx context.Context, applic
 	return s.appRepo.ListDocumentsByApplication(ctx, applicationID)
 }
 
+// GetDocumentForDownload returns an application document to the applicant or to an employer
+// of the hiring company. Documents of other applications are reported as not found.
+func (s *applicationService) GetDocumentForDownload(ctx context.Context, applicationID, documentID, userID int64) (*application.ApplicationDocument, error) {
+	if _, err := s.findApplicationForDownload(ctx, applicationID, userID); err != nil {
+		return nil, err
+	}
+
+	doc, err := s.appRepo.FindDocumentByID(ctx, documentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, application.ErrDocumentNotFound
+		}
+		return nil, fmt.Errorf("failed to get document: %w", err)
+	}
+	if doc.ApplicationID != applicationID {
+		return nil, application.ErrDocumentNotFound
+	}
+
+	return doc, nil
+}
+
+// findApplicationForDownload returns the application if the user is its applicant or an
+// employer of the hiring company
+func (s *applicationService) findApplicationForDownload(ctx context.Context, applicationID, userID int64) (*application.JobApplication, error) {
+	app, err := s.appRepo.FindByID(ctx, applicationID)
+	if err != nil {
+		return nil, applicationLookupError(err)
+	}
+
+	if app.UserID != userID {
+		if app.CompanyID == nil {
+			return nil, application.ErrEmployerAccessDenied
+		}
+		if err := s.checkCompanyEmployerAccess(ctx, *app.CompanyID, userID); err != nil {
+			return nil, err
+		}
+	}
+	return app, nil
+}
+
 // GetDocumentsByType retrieves documents by type
 func (s *applicationService) GetDocumentsByType(ctx context.Context, applicationID int64, docType string) ([]application.ApplicationDocument, error) {
 	return s.appRepo.GetDocumentsByType(ctx, applicationID, docType)
@@ -552,7 +1085,7 @@ func (s *applicationService) VerifyDocument(ctx context.Context, documentID, ver
 	}
 
 	// Check employer access
-	if err := s.CheckEmployerAccess(ctx, doc.ApplicationID, verifiedBy); err != nil {
+	if err := s.checkApplicationEmployerAccess(ctx, doc.ApplicationID, verifiedBy); err != nil {
 		return err
 	}
 
@@ -569,7 +1102,7 @@ func (s *applicationService) GetUnverifiedDocuments(ctx context.Context, page, l
 // AddNote adds a note to application
 func (s *applicationService) AddNote(ctx context.Context, req *application.AddNoteRequest) (*application.ApplicationNote, error) {
 	// Check employer access
-	if err := s.CheckEmployerAccess(ctx, req.ApplicationID, req.AuthorID); err != nil {
+	if err := s.checkApplicationEmployerAccess(ctx, req.ApplicationID, req.AuthorID); err != nil {
 		return nil, err
 	}
 
@@ -675,7 +1208,7 @@ func (s *applicationService) GetStageNotes(ctx context.Context, stageID int64) (
 }
 
 // PinNote pins a note
-func (s *applicationService) PinNote(ctx context.Context, noteID, employerUserID int64) error {
+func (s *applicationService) PinNote(ctx context.Context, noteID, userID int64) error {
 	// Get note
 	note, err := s.appRepo.FindNoteByID(ctx, noteID)
 	if err != nil {
@@ -683,7 +1216,7 @@ func (s *applicationService) PinNote(ctx context.Context, noteID, employerUserID
 	}
 
 	// Check employer access
-	if err := s.CheckEmployerAccess(ctx, note.ApplicationID, employerUserID); err != nil {
+	if err := s.checkApplicationEmployerAccess(ctx, note.ApplicationID, userID); err != nil {
 		return err
 	}
 
@@ -691,7 +1224,7 @@ func (s *applicationService) PinNote(ctx context.Context, noteID, employerUserID
 }
 
 // UnpinNote unpins a note
-func (s *applicationService) UnpinNote(ctx context.Context, noteID, employerUserID int64) error {
+func (s *applicationService) UnpinNote(ctx context.Context, noteID, userID int64) error {
 	// Get note
 	note, err := s.appRepo.FindNoteByID(ctx, noteID)
 	if err != nil {
@@ -699,7 +1232,7 @@ func (s *applicationService) UnpinNote(ctx context.Context, noteID, employerUser
 	}
 
 	// Check employer access
-	if err := s.CheckEmployerAccess(ctx, note.ApplicationID, employerUserID); err != nil {
+	if err := s.checkApplicationEmployerAccess(ctx, note.ApplicationID, userID); err != nil {
 		return err
 	}
 
@@ -715,16 +1248,30 @@ func (s *applicationService) GetPinnedNotes(ctx context.Context, applicationID i
 
 // ScheduleInterview schedules an interview
 func (s *applicationService) ScheduleInterview(ctx context.Context, req *application.ScheduleInterviewRequest) (*application.Interview, error) {
-	// Check employer access
+	timezone, err := resolveInterviewTimezone(req.Timezone, "UTC")
+	if err != nil {
+		return nil, err
+	}
+	if !req.ScheduledAt.After(time.Now()) {
+		return nil, application.ErrInterviewInPast
+	}
+
+	// Get application
 	app, err := s.appRepo.FindByID(ctx, req.ApplicationID)
 	if err != nil {
-		return nil, fmt.Errorf("application not found: %w", err)
+		return nil, applicationLookupError(err)
 	}
 
 	// Ensure application is in interview stage or later
-	if app.Status != "interview" && app.Status != "offered" {
-		// Auto-move to interview stage if not already
-		if err := s.MoveToInterview(ctx, req.ApplicationID, *req.InterviewerID, "Interview scheduled"); err != nil {
+	if app.Status != application.StatusInterview && app.Status != application.StatusOffered {
+		// Auto-move to interview stage if not already, as the interviewer
+		if app.CompanyID == nil {
+			return nil, application.ErrEmployerAccessDenied
+		}
+		if err := s.checkCompanyEmployerAccess(ctx, *app.CompanyID, *req.InterviewerID); err != nil {
+			return nil, err
+		}
+		if err := s.advanceApplicationStage(ctx, app, *req.InterviewerID, application.StatusInterview, application.StatusInterview, "Interview scheduled", "Interview scheduled"); err != nil {
 			return nil, err
 		}
 	}
@@ -734,10 +1281,11 @@ func (s *applicationService) ScheduleInterview(ctx context.Context, req *applica
 		ApplicationID: req.ApplicationID,
 		StageID:       req.StageID,
 		InterviewerID: req.InterviewerID,
-		ScheduledAt:   req.ScheduledAt,
+		ScheduledAt:   req.ScheduledAt.UTC(),
 		InterviewType: req.InterviewType,
 		MeetingLink:   req.MeetingLink,
 		Location:      req.Location,
+		Timezone:      timezone,
 		Status:        "scheduled",
 	}
 
@@ -749,9 +1297,10 @@ func (s *applicationService) ScheduleInterview(ctx context.Context, req *applica
 	if err := s.appRepo.CreateInterview(ctx, interview); err != nil {
 		return nil, fmt.Errorf("failed to schedule interview: %w", err)
 	}
+	s.publishInterviewScheduled(ctx, app, interview)
 
 	// Send notification
-	go s.NotifyInterviewScheduled(ctx, interview.ID)
+	go s.NotifyInterviewScheduled(context.Background(), interview.ID)
 
 	return interview, nil
 }
@@ -764,8 +1313,18 @@ func (s *applicationService) RescheduleInterview(ctx context.Context, interviewI
 		return nil, fmt.Errorf("interview not found: %w", err)
 	}
 
+	timezone, err := resolveInterviewTimezone(req.Timezone, interview.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	if !req.ScheduledAt.After(time.Now()) {
+		return nil, application.ErrInterviewInPast
+	}
+
 	// Update interview
-	interview.ScheduledAt = req.ScheduledAt
+	interview.ScheduledAt = req.ScheduledAt.UTC()
+	interview.Timezone = timezone
+	interview.CalendarSequence++
 	interview.Status = "rescheduled"
 	if req.MeetingLink != "" {
 		interview.MeetingLink = req.MeetingLink
@@ -792,7 +1351,7 @@ func (s *applicationService) RescheduleInterview(ctx context.Context, interviewI
 	}
 
 	// Send notification
-	go s.NotifyInterviewScheduled(ctx, interviewID)
+	go s.NotifyInterviewScheduled(context.Background(), interviewID)
 
 	return interview, nil
 }
@@ -807,10 +1366,16 @@ func (s *applicationService) CancelInterview(ctx context.Context, interviewID in
 
 	// Update status
 	interview.Status = "cancelled"
+	interview.CalendarSequence++
 	if err := s.appRepo.UpdateInterview(ctx, interview); err != nil {
 		return fmt.Errorf("failed to cancel interview: %w", err)
 	}
 
+	// Reopen the slot the candidate booked so another candidate can take it
+	if err := s.appRepo.ReleaseInterviewSlot(ctx, interviewID); err != nil {
+		return fmt.Errorf("failed to release interview slot: %w", err)
+	}
+
 	// Add note about cancellation
 	if reason != "" {
 		noteReq := &application.AddNoteRequest{
@@ -824,9 +1389,26 @@ func (s *applicationService) CancelInterview(ctx context.Context, interviewID in
 		s.AddNote(ctx, noteReq)
 	}
 
+	// Send cancellation so the candidate's calendar entry is removed
+	go s.notifyInterviewCancelled(context.Background(), interview, reason)
+
 	return nil
 }
 
+// resolveInterviewTimezone validates an IANA timezone name, returning fallback when it is empty
+func resolveInterviewTimezone(name, fallback string) (string, error) {
+	if name == "" {
+		name = fallback
+	}
+	if name == "" {
+		return "UTC", nil
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return "", application.ErrInvalidTimezone
+	}
+	return name, nil
+}
+
 // CompleteInterview marks interview as completed with evaluation
 func (s *applicationService) CompleteInterview(ctx context.Context, interviewID int64, req *application.CompleteInterviewRequest) (*application.Interview, error) {
 	// Get interview
@@ -907,7 +1489,7 @@ func (s *applicationService) GetInterviewDetail(ctx context.Context, interviewID
 }
 
 // GetUpcomingInterviews retrieves upcoming interviews for employer
-func (s *applicationService) GetUpcomingInterviews(ctx context.Context, employerUserID int64, days int) ([]application.Interview, error) {
+func (s *applicationService) GetUpcomingInterviews(ctx context.Context, userID int64, days int) ([]application.Interview, error) {
 	endDate := time.Now().AddDate(0, 0, days)
 	return s.appRepo.GetUpcomingInterviews(ctx, endDate, 100)
 }
@@ -936,14 +1518,79 @@ func (s *applicationService) SendInterviewReminder(ctx context.Context, intervie
 
 // ===== Search and Filtering =====
 
-// SearchApplications performs advanced application search
-func (s *applicationService) SearchApplications(ctx context.Context, filter application.ApplicationSearchFilter, page, limit int) (*application.ApplicationListResponse, error) {
+// SearchApplications performs advanced application search limited to the employer's companies
+func (s *applicationService) SearchApplications(ctx context.Context, ec *employer.EmployerContext, filter application.ApplicationSearchFilter, page, limit int) (*application.ApplicationListResponse, error) {
+	if err := validateSearchFilter(&filter); err != nil {
+		return nil, err
+	}
+
+	companyIDs, err := s.searchableCompanyIDs(ctx, ec, filter.CompanyIDs)
+	if err != nil {
+		return nil, err
+	}
+	filter.CompanyIDs = companyIDs
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
 	apps, total, err := s.appRepo.SearchApplications(ctx, filter, page, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search applications: %w", err)
 	}
 
-	return s.buildApplicationListResponse(ctx, apps, total, page, limit)
+	return s.buildApplicationListResponse(ctx, apps, total, page, limit, ec.UserID)
+}
+
+// validateSearchFilter checks the enumerated search filter values and applies the skill match default
+func validateSearchFilter(filter *application.ApplicationSearchFilter) error {
+	switch filter.SkillMatch {
+	case "":
+		filter.SkillMatch = application.SkillMatchAll
+	case application.SkillMatchAll, application.SkillMatchAny:
+	default:
+		return application.ErrInvalidSearchFilter.WithField("skill_match", "must be one of: all any")
+	}
+
+	if filter.MinDegreeLevel != "" && application.DegreeLevelsFrom(filter.MinDegreeLevel) == nil {
+		return application.ErrInvalidSearchFilter.WithField("min_degree_level", "must be one of: "+strings.Join(application.DegreeLevels, " "))
+	}
+
+	if filter.MinExperienceYears != nil && *filter.MinExperienceYears < 0 {
+		return application.ErrInvalidSearchFilter.WithField("min_experience_years", "must be at least 0")
+	}
+
+	for _, status := range filter.AvailabilityStatuses {
+		switch status {
+		case "open", "looking_actively", "not_looking":
+		default:
+			return application.ErrInvalidSearchFilter.WithField("availability_statuses", "must be one of: open looking_actively not_looking")
+		}
+	}
+
+	return nil
+}
+
+// searchableCompanyIDs returns the companies whose applications the employer may search:
+// the requested ones after checking access, or otherwise the employer's active company
+func (s *applicationService) searchableCompanyIDs(ctx context.Context, ec *employer.EmployerContext, requested []int64) ([]int64, error) {
+	if ec == nil {
+		return nil, application.ErrEmployerAccessDenied
+	}
+
+	if len(requested) > 0 {
+		for _, companyID := range requested {
+			if err := s.checkCompanyEmployerAccess(ctx, companyID, ec.UserID); err != nil {
+				return nil, err
+			}
+		}
+		return requested, nil
+	}
+
+	return []int64{ec.CompanyID}, nil
 }
 
 // GetHighScoreApplications retrieves high-score applications
@@ -986,7 +1633,7 @@ func (s *applicationService) GetApplicationAnalytics(ctx context.Context, applic
 	// Get application
 	app, err := s.appRepo.FindByID(ctx, applicationID)
 	if err != nil {
-		return nil, fmt.Errorf("application not found: %w", err)
+		return nil, applicationLookupError(err)
 	}
 
 	// Get stages
@@ -1019,7 +1666,7 @@ func (s *applicationService) GetJobApplicationAnalytics(ctx context.Context, job
 	// Get job
 	j, err := s.jobRepo.FindByID(ctx, jobID)
 	if err != nil {
-		return nil, fmt.Errorf("job not found: %w", err)
+		return nil, jobLookupError(err)
 	}
 
 	// Get job stats
@@ -1035,13 +1682,13 @@ func (s *applicationService) GetJobApplicationAnalytics(ctx context.Context, job
 		Period:            fmt.Sprintf("%s to %s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02")),
 		TotalApplications: stats.TotalApplications,
 		StatusBreakdown: map[string]int64{
-			"applied":     stats.AppliedCount,
-			"screening":   stats.ScreeningCount,
-			"shortlisted": stats.ShortlistedCount,
-			"interview":   stats.InterviewCount,
-			"offered":     stats.OfferedCount,
-			"hired":       stats.HiredCount,
-			"rejected":    stats.RejectedCount,
+			application.StatusApplied:     stats.AppliedCount,
+			application.StatusScreening:   stats.ScreeningCount,
+			application.StatusShortlisted: stats.ShortlistedCount,
+			application.StatusInterview:   stats.InterviewCount,
+			application.StatusOffered:     stats.OfferedCount,
+			application.StatusHired:       stats.HiredCount,
+			application.StatusRejected:    stats.RejectedCount,
 		},
 		AverageMatchScore: stats.AverageMatchScore,
 		AverageTimeToHire: stats.AverageTimeToHire,
@@ -1106,9 +1753,37 @@ func (s *applicationService) GetTopApplicants(ctx context.Context, jobID int64,
 	return s.appRepo.GetTopApplicants(ctx, jobID, limit)
 }
 
-// GetApplicationSourceAnalytics retrieves application source analytics
-func (s *applicationService) GetApplicationSourceAnalytics(ctx context.Context, companyID int64) ([]application.SourceStats, error) {
-	return s.appRepo.GetApplicationSourceStats(ctx, companyID)
+// GetApplicationSourceAnalytics returns the views, applications and hires per utm_source of a
+// company, or of one of its jobs, with view→application and application→hire rates
+func (s *applicationService) GetApplicationSourceAnalytics(ctx context.Context, filter application.SourceStatsFilter) ([]application.SourceStats, error) {
+	if filter.JobID != nil {
+		j, err := s.jobRepo.FindByID(ctx, *filter.JobID)
+		if err != nil {
+			return nil, jobLookupError(err)
+		}
+		if j.CompanyID != filter.CompanyID {
+			return nil, job.ErrJobNotFound
+		}
+	}
+
+	stats, err := s.appRepo.GetApplicationSourceStats(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application source stats: %w", err)
+	}
+
+	for i := range stats {
+		stats[i].ViewConversionRate = percentOf(stats[i].Count, stats[i].Views)
+		stats[i].ConversionRate = percentOf(stats[i].HiredCount, stats[i].Count)
+	}
+	return stats, nil
+}
+
+// percentOf returns part as a percentage of total rounded to one decimal, or 0 when total is 0
+func percentOf(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(total)*1000) / 10
 }
 
 // ===== Notifications =====
@@ -1118,17 +1793,17 @@ func (s *applicationService) NotifyApplicationReceived(ctx context.Context, appl
 	// Get application details
 	app, err := s.appRepo.FindByID(ctx, applicationID)
 	if err != nil {
-		return fmt.Errorf("application not found: %w", err)
+		return applicationLookupError(err)
 	}
 
 	// Get job details
 	j, err := s.jobRepo.FindByID(ctx, app.JobID)
 	if err != nil {
-		return fmt.Errorf("job not found: %w", err)
+		return jobLookupError(err)
 	}
 
 	// Get user details
-	user, err := s.userRepo.FindByID(ctx, app.UserID)
+	usr, err := s.userRepo.FindByID(ctx, app.UserID)
 	if err != nil {
 		return fmt.Errorf("user not found: %w", err)
 	}
@@ -1146,54 +1821,111 @@ func (s *applicationService) NotifyApplicationReceived(ctx context.Context, appl
 	if s.notifService != nil {
 		if err := s.notifService.NotifyJobApplication(ctx, app.UserID, app.JobID, applicationID); err != nil {
 			// Log error but don't fail the operation
-			fmt.Printf("failed to send notification: %v\n", err)
+			config.WithContext(ctx).WithError(err).Warn("failed to send notification")
+		}
+	}
+
+	// Let the company's employer users know about the new application
+	if s.notifService != nil && app.CompanyID != nil {
+		if employerUserIDs := s.activeEmployerUserIDs(ctx, *app.CompanyID); len(employerUserIDs) > 0 {
+			if err := s.notifService.NotifyApplicationReceived(ctx, employerUserIDs, app.JobID, applicationID, j.Title); err != nil {
+				// Log error but don't fail the operation
+				config.WithContext(ctx).WithError(err).Warn("failed to send employer notification")
+			}
 		}
 	}
 
 	// Send email confirmation to user
 	if s.emailService != nil {
-		if err := s.emailService.SendJobApplicationEmail(ctx, user.Email, j.Title, companyName); err != nil {
+		if _, err := s.dispatcher.Email(ctx, app.UserID, user.NotificationEventApplicationStatus, func(ctx context.Context) error {
+			return s.emailService.SendJobApplicationEmail(ctx, usr.Email, j.Title, companyName)
+		}); err != nil {
 			// Log error but don't fail the operation
-			fmt.Printf("failed to send email: %v\n", err)
+			config.WithContext(ctx).WithError(err).Warn("failed to send email")
 		}
 	}
 
 	return nil
 }
 
+// activeEmployerUserIDs returns the user IDs of a company's active employer users
+func (s *applicationService) activeEmployerUserIDs(ctx context.Context, companyID int64) []int64 {
+	employers, err := s.companyRepo.GetEmployerUsersByCompanyID(ctx, companyID)
+	if err != nil {
+		config.WithContext(ctx).WithError(err).Warnf("failed to load employer users of company %d", companyID)
+		return nil
+	}
+
+	userIDs := make([]int64, 0, len(employers))
+	for _, employer := range employers {
+		if employer.IsActive {
+			userIDs = append(userIDs, employer.UserID)
+		}
+	}
+	return userIDs
+}
+
 // NotifyStatusUpdate sends notification for status change
 func (s *applicationService) NotifyStatusUpdate(ctx context.Context, applicationID int64, newStatus string) error {
 	// Get application details
 	app, err := s.appRepo.FindByID(ctx, applicationID)
 	if err != nil {
-		return fmt.Errorf("application not found: %w", err)
+		return applicationLookupError(err)
 	}
 
 	// Get job details
 	j, err := s.jobRepo.FindByID(ctx, app.JobID)
 	if err != nil {
-		return fmt.Errorf("job not found: %w", err)
+		return jobLookupError(err)
 	}
 
 	// Get user details
-	user, err := s.userRepo.FindByID(ctx, app.UserID)
+	usr, err := s.userRepo.FindByID(ctx, app.UserID)
 	if err != nil {
 		return fmt.Errorf("user not found: %w", err)
 	}
+	ctx = userLocaleContext(ctx, s.userRepo, app.UserID)
 
 	// Send notification to user
 	if s.notifService != nil {
 		if err := s.notifService.NotifyStatusUpdate(ctx, app.UserID, applicationID, app.Status, newStatus); err != nil {
 			// Log error but don't fail the operation
-			fmt.Printf("failed to send notification: %v\n", err)
+			config.WithContext(ctx).WithError(err).Warn("failed to send notification")
+		}
+	}
+
+	// A job offer is also sent by WhatsApp
+	if newStatus == application.StatusOffered {
+		params := map[string]string{
+			notification.WhatsAppParamCandidateName: usr.FullName,
+			notification.WhatsAppParamJobTitle:      j.Title,
+			notification.WhatsAppParamCompanyName:   "",
 		}
+		if s.companyRepo != nil {
+			if comp, err := s.companyRepo.FindByID(ctx, j.CompanyID); err == nil && comp != nil {
+				params[notification.WhatsAppParamCompanyName] = comp.CompanyName
+			}
+		}
+		s.dispatcher.WhatsApp(ctx, app.UserID, user.NotificationEventApplicationStatus, &notification.WhatsAppMessage{
+			Template: notification.WhatsAppTemplateOfferMade,
+			Params:   params,
+		})
 	}
 
-	// Send email notification to user
+	// Send email notification to user, in the company's own words when it wrote a
+	// template for the new status
 	if s.emailService != nil {
-		if err := s.emailService.SendJobStatusUpdateEmail(ctx, user.Email, j.Title, newStatus); err != nil {
+		custom := s.renderCompanyEmail(ctx, j.CompanyID, email.CompanyTemplateEventForStatus(newStatus), func() map[string]string {
+			return s.companyEmailVariables(ctx, usr.FullName, j, newStatus)
+		})
+		if _, err := s.dispatcher.Email(ctx, app.UserID, user.NotificationEventApplicationStatus, func(ctx context.Context) error {
+			if custom != nil {
+				return s.emailService.SendCompanyTemplateEmail(ctx, usr.Email, *custom)
+			}
+			return s.emailService.SendJobStatusUpdateEmail(ctx, usr.Email, j.Title, newStatus)
+		}); err != nil {
 			// Log error but don't fail the operation
-			fmt.Printf("failed to send email: %v\n", err)
+			config.WithContext(ctx).WithError(err).Warn("failed to send email")
 		}
 	}
 
@@ -1208,38 +1940,35 @@ func (s *applicationService) NotifyInterviewScheduled(ctx context.Context, inter
 		return fmt.Errorf("interview not found: %w", err)
 	}
 
-	// Get application details
-	app, err := s.appRepo.FindByID(ctx, interview.ApplicationID)
+	recipient, data, userID, err := s.buildInterviewEmail(ctx, interview)
 	if err != nil {
-		return fmt.Errorf("application not found: %w", err)
-	}
-
-	// Get job details
-	j, err := s.jobRepo.FindByID(ctx, app.JobID)
-	if err != nil {
-		return fmt.Errorf("job not found: %w", err)
-	}
-
-	// Get user details
-	user, err := s.userRepo.FindByID(ctx, app.UserID)
-	if err != nil {
-		return fmt.Errorf("user not found: %w", err)
+		return err
 	}
+	ctx = userLocaleContext(ctx, s.userRepo, userID)
+	s.customizeInterviewEmail(ctx, interview, &data)
 
 	// Send notification to user
 	if s.notifService != nil {
-		if err := s.notifService.NotifyInterviewScheduled(ctx, app.UserID, interviewID, interview.ScheduledAt); err != nil {
+		if err := s.notifService.NotifyInterviewScheduled(ctx, userID, interviewID, interview.ScheduledAt); err != nil {
 			// Log error but don't fail the operation
-			fmt.Printf("failed to send notification: %v\n", err)
+			config.WithContext(ctx).WithError(err).Warn("failed to send notification")
 		}
 	}
 
-	// Send email invitation to user
+	// Send WhatsApp message to user
+	template := notification.WhatsAppTemplateInterviewScheduled
+	if interview.Status == "rescheduled" {
+		template = notification.WhatsAppTemplateInterviewRescheduled
+	}
+	s.notifyInterviewWhatsApp(ctx, userID, template, interview, data)
+
+	// Send email invitation with calendar invite to user
 	if s.emailService != nil {
-		interviewDateStr := interview.ScheduledAt.Format("2006-01-02 15:04 MST")
-		if err := s.emailService.SendInterviewInvitationEmail(ctx, user.Email, j.Title, interviewDateStr); err != nil {
+		if _, err := s.dispatcher.Email(ctx, userID, user.NotificationEventInterview, func(ctx context.Context) error {
+			return s.emailService.SendInterviewInvitationEmail(ctx, recipient, data)
+		}); err != nil {
 			// Log error but don't fail the operation
-			fmt.Printf("failed to send email: %v\n", err)
+			config.WithContext(ctx).WithError(err).Warn("failed to send email")
 		}
 	}
 
@@ -1254,44 +1983,178 @@ func (s *applicationService) NotifyInterviewReminder(ctx context.Context, interv
 		return fmt.Errorf("interview not found: %w", err)
 	}
 
+	recipient, data, userID, err := s.buildInterviewEmail(ctx, interview)
+	if err != nil {
+		return err
+	}
+	ctx = userLocaleContext(ctx, s.userRepo, userID)
+
+	// Send reminder notification to user
+	if s.notifService != nil {
+		// Note: NotificationService doesn't have NotifyInterviewReminder method
+		// We can reuse NotifyInterviewScheduled for reminders
+		if err := s.notifService.NotifyInterviewScheduled(ctx, userID, interviewID, interview.ScheduledAt); err != nil {
+			// Log error but don't fail the operation
+			config.WithContext(ctx).WithError(err).Warn("failed to send reminder notification")
+		}
+	}
+
+	// Send reminder email with the current calendar invite to user
+	if s.emailService != nil {
+		if _, err := s.dispatcher.Email(ctx, userID, user.NotificationEventInterview, func(ctx context.Context) error {
+			return s.emailService.SendInterviewReminderEmail(ctx, recipient, data)
+		}); err != nil {
+			// Log error but don't fail the operation
+			config.WithContext(ctx).WithError(err).Warn("failed to send reminder email")
+		}
+	}
+
+	return nil
+}
+
+// notifyInterviewCancelled emails the candidate a cancellation that removes the calendar entry
+// and lets them know by WhatsApp
+func (s *applicationService) notifyInterviewCancelled(ctx context.Context, interview *application.Interview, reason string) error {
+	recipient, data, userID, err := s.buildInterviewEmail(ctx, interview)
+	if err != nil {
+		return err
+	}
+	ctx = userLocaleContext(ctx, s.userRepo, userID)
+	data.Message = reason
+
+	s.notifyInterviewWhatsApp(ctx, userID, notification.WhatsAppTemplateInterviewCancelled, interview, data)
+
+	if s.emailService == nil {
+		return nil
+	}
+	if _, err := s.dispatcher.Email(ctx, userID, user.NotificationEventInterview, func(ctx context.Context) error {
+		return s.emailService.SendInterviewCancellationEmail(ctx, recipient, data)
+	}); err != nil {
+		// Log error but don't fail the operation
+		config.WithContext(ctx).WithError(err).Warn("failed to send cancellation email")
+	}
+
+	return nil
+}
+
+// notifyInterviewWhatsApp sends the candidate the interview's WhatsApp template message, with
+// the interview time in the interview's timezone
+func (s *applicationService) notifyInterviewWhatsApp(ctx context.Context, userID int64, template string, interview *application.Interview, data email.InterviewEmailData) {
+	localStart := interview.LocalScheduledAt()
+	s.dispatcher.WhatsApp(ctx, userID, user.NotificationEventInterview, &notification.WhatsAppMessage{
+		Template: template,
+		Params: map[string]string{
+			notification.WhatsAppParamCandidateName: data.CandidateName,
+			notification.WhatsAppParamJobTitle:      data.JobTitle,
+			notification.WhatsAppParamCompanyName:   data.CompanyName,
+			notification.WhatsAppParamInterviewDate: i18n.FormatLongDate(i18n.FromContext(ctx), localStart),
+			notification.WhatsAppParamInterviewTime: localStart.Format("15:04 MST"),
+		},
+	})
+}
+
+// buildInterviewEmail loads the candidate, job and company for an interview email and
+// returns the recipient address, email data and candidate user ID
+func (s *applicationService) buildInterviewEmail(ctx context.Context, interview *application.Interview) (string, email.InterviewEmailData, int64, error) {
 	// Get application details
 	app, err := s.appRepo.FindByID(ctx, interview.ApplicationID)
 	if err != nil {
-		return fmt.Errorf("application not found: %w", err)
+		return "", email.InterviewEmailData{}, 0, applicationLookupError(err)
 	}
 
 	// Get job details
 	j, err := s.jobRepo.FindByID(ctx, app.JobID)
 	if err != nil {
-		return fmt.Errorf("job not found: %w", err)
+		return "", email.InterviewEmailData{}, 0, jobLookupError(err)
 	}
 
 	// Get user details
-	user, err := s.userRepo.FindByID(ctx, app.UserID)
+	usr, err := s.userRepo.FindByID(ctx, app.UserID)
 	if err != nil {
-		return fmt.Errorf("user not found: %w", err)
+		return "", email.InterviewEmailData{}, 0, fmt.Errorf("user not found: %w", err)
 	}
 
-	// Send reminder notification to user
-	if s.notifService != nil {
-		// Note: NotificationService doesn't have NotifyInterviewReminder method
-		// We can reuse NotifyInterviewScheduled for reminders
-		if err := s.notifService.NotifyInterviewScheduled(ctx, app.UserID, interviewID, interview.ScheduledAt); err != nil {
-			// Log error but don't fail the operation
-			fmt.Printf("failed to send reminder notification: %v\n", err)
-		}
+	data := email.InterviewEmailData{
+		InterviewID:   interview.ID,
+		CandidateName: usr.FullName,
+		JobTitle:      j.Title,
+		ScheduledAt:   interview.ScheduledAt,
+		Timezone:      interview.Timezone,
+		InterviewType: interview.InterviewType,
+		MeetingLink:   interview.MeetingLink,
+		Location:      interview.Location,
+		Sequence:      interview.CalendarSequence,
 	}
 
-	// Send reminder email to user (using same template as interview invitation)
-	if s.emailService != nil {
-		interviewDateStr := interview.ScheduledAt.Format("2006-01-02 15:04 MST")
-		if err := s.emailService.SendInterviewInvitationEmail(ctx, user.Email, j.Title, interviewDateStr); err != nil {
-			// Log error but don't fail the operation
-			fmt.Printf("failed to send reminder email: %v\n", err)
+	if s.companyRepo != nil {
+		if comp, err := s.companyRepo.FindByID(ctx, j.CompanyID); err == nil && comp != nil {
+			data.CompanyName = comp.CompanyName
 		}
 	}
 
-	return nil
+	return usr.Email, data, app.UserID, nil
+}
+
+// customizeInterviewEmail sets the invitation to the company's own interview template when
+// it has one; the calendar invite is attached either way
+func (s *applicationService) customizeInterviewEmail(ctx context.Context, interview *application.Interview, data *email.InterviewEmailData) {
+	if s.emailTemplates == nil {
+		return
+	}
+	app, err := s.appRepo.FindByID(ctx, interview.ApplicationID)
+	if err != nil {
+		return
+	}
+	j, err := s.jobRepo.FindByID(ctx, app.JobID)
+	if err != nil {
+		return
+	}
+
+	data.Custom = s.renderCompanyEmail(ctx, j.CompanyID, email.CompanyTemplateInterviewScheduled, func() map[string]string {
+		vars := s.companyEmailVariables(ctx, data.CandidateName, j, "interview")
+		localStart := interview.LocalScheduledAt()
+		vars[email.TemplateVarInterviewDate] = i18n.FormatLongDate(i18n.FromContext(ctx), localStart)
+		vars[email.TemplateVarInterviewTime] = fmt.Sprintf("%s (%s)", localStart.Format("15:04 MST"), localStart.Location().String())
+		vars[email.TemplateVarInterviewType] = interview.InterviewType
+		vars[email.TemplateVarInterviewLocation] = interview.Location
+		vars[email.TemplateVarInterviewLink] = interview.MeetingLink
+		return vars
+	})
+}
+
+// renderCompanyEmail renders the company's template for event, or returns nil when the
+// platform default should be sent: the event cannot be customized, the company has no
+// template for it, or the template failed to render
+func (s *applicationService) renderCompanyEmail(ctx context.Context, companyID int64, event string, vars func() map[string]string) *email.RenderedEmail {
+	if s.emailTemplates == nil || event == "" {
+		return nil
+	}
+	rendered, err := s.emailTemplates.RenderCompanyTemplate(ctx, companyID, event, vars())
+	if err != nil {
+		// Log error and fall back to the platform email
+		config.WithContext(ctx).WithError(err).Warn("failed to render company email template")
+		return nil
+	}
+	return rendered
+}
+
+// companyEmailVariables returns the application variables of company email templates, the
+// status labelled in the candidate's language
+func (s *applicationService) companyEmailVariables(ctx context.Context, candidateName string, j *job.Job, status string) map[string]string {
+	vars := map[string]string{
+		email.TemplateVarCandidateName:     candidateName,
+		email.TemplateVarJobTitle:          j.Title,
+		email.TemplateVarApplicationStatus: status,
+	}
+	if t := i18n.New(i18n.FromContext(ctx)); t.Has("application.status." + status) {
+		vars[email.TemplateVarApplicationStatus] = t.T("application.status." + status)
+	}
+	if s.companyRepo != nil {
+		if comp, err := s.companyRepo.FindByID(ctx, j.CompanyID); err == nil && comp != nil {
+			vars[email.TemplateVarCompanyName] = comp.CompanyName
+		}
+	}
+	return vars
 }
 
 // ===== Validation and Permissions =====
@@ -1307,12 +2170,7 @@ func (s *applicationService) ValidateApplication(ctx context.Context, app *appli
 	}
 
 	// Validate status
-	validStatuses := map[string]bool{
-		"applied": true, "screening": true, "shortlisted": true,
-		"interview": true, "offered": true, "hired": true,
-		"rejected": true, "withdrawn": true,
-	}
-	if !validStatuses[app.Status] {
+	if !application.IsValidStatus(app.Status) {
 		return fmt.Errorf("invalid status: %s", app.Status)
 	}
 
@@ -1323,76 +2181,128 @@ func (s *applicationService) ValidateApplication(ctx context.Context, app *appli
 func (s *applicationService) CheckApplicationOwnership(ctx context.Context, applicationID, userID int64) error {
 	app, err := s.appRepo.FindByID(ctx, applicationID)
 	if err != nil {
-		return fmt.Errorf("application not found: %w", err)
+		return applicationLookupError(err)
 	}
 
 	if app.UserID != userID {
-		return errors.New("you do not own this application")
+		return application.ErrNotApplicationOwner
 	}
 
 	return nil
 }
 
-// CheckEmployerAccess verifies employer has access to application
-func (s *applicationService) CheckEmployerAccess(ctx context.Context, applicationID, employerUserID int64) error {
-	// Get application
+// applicationLookupError converts a failed application lookup into application.ErrApplicationNotFound
+// when the application does not exist
+func applicationLookupError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return application.ErrApplicationNotFound
+	}
+	return fmt.Errorf("failed to get application: %w", err)
+}
+
+// findEmployerApplication returns the application when it belongs to the company the
+// employer acts for
+func (s *applicationService) findEmployerApplication(ctx context.Context, ec *employer.EmployerContext, applicationID int64) (*application.JobApplication, error) {
+	if err := checkEmployerContext(ec); err != nil {
+		return nil, err
+	}
+
 	app, err := s.appRepo.FindByID(ctx, applicationID)
 	if err != nil {
-		return fmt.Errorf("application not found: %w", err)
+		return nil, applicationLookupError(err)
+	}
+	if app.CompanyID == nil || !ec.InCompany(*app.CompanyID) {
+		return nil, application.ErrEmployerAccessDenied
 	}
+	return app, nil
+}
 
-	// Check if employer belongs to company
+// checkApplicationEmployerAccess checks that the user is an employer of the application's
+// company, for the note and document methods that take the acting user's ID
+func (s *applicationService) checkApplicationEmployerAccess(ctx context.Context, applicationID, userID int64) error {
+	app, err := s.appRepo.FindByID(ctx, applicationID)
+	if err != nil {
+		return applicationLookupError(err)
+	}
 	if app.CompanyID == nil {
-		return errors.New("application has no company association")
+		return application.ErrEmployerAccessDenied
 	}
 
+	return s.checkCompanyEmployerAccess(ctx, *app.CompanyID, userID)
+}
+
+// checkCompanyEmployerAccess checks that the employer user belongs to the company with a role
+// that can view applications
+func (s *applicationService) checkCompanyEmployerAccess(ctx context.Context, companyID, userID int64) error {
 	// Check employer user permission
-	employerUser, err := s.companyRepo.FindEmployerUserByUserAndCompany(ctx, employerUserID, *app.CompanyID)
+	employerUser, err := s.companyRepo.FindEmployerUserByUserAndCompany(ctx, userID, companyID)
 	if err != nil || employerUser == nil {
-		return errors.New("you do not have access to this application")
+		return application.ErrEmployerAccessDenied
 	}
 
 	// Check if role has permission (viewer and above can view applications)
-	if employerUser.Role != "viewer" && employerUser.Role != "recruiter" && employerUser.Role != "admin" && employerUser.Role != "owner" {
-		return errors.New("insufficient permissions")
+	if !employer.IsValidRole(employerUser.Role) {
+		return application.ErrInsufficientPermissions
 	}
 
 	return nil
 }
 
+// checkEmployerContext checks that the employer's role at the company it acts for can view
+// applications
+func checkEmployerContext(ec *employer.EmployerContext) error {
+	if ec == nil {
+		return application.ErrEmployerAccessDenied
+	}
+	if !ec.CanViewApplications() {
+		return application.ErrInsufficientPermissions
+	}
+	return nil
+}
+
 // CanApplyForJob checks if user can apply for job
 func (s *applicationService) CanApplyForJob(ctx context.Context, jobID, userID int64) error {
 	// Get job
 	j, err := s.jobRepo.FindByID(ctx, jobID)
 	if err != nil {
-		return fmt.Errorf("job not found: %w", err)
+		return jobLookupError(err)
 	}
 
 	// Check if job is active
 	if !j.CanApply() {
-		return errors.New("this job is not accepting applications")
+		return application.ErrJobNotAcceptingApplications
 	}
 
-	// Check if already applied
+	// Check the latest application for this job against the reapply policy
 	existingApp, _ := s.appRepo.FindByJobAndUser(ctx, jobID, userID)
 	if existingApp != nil {
-		return errors.New("you have already applied for this job")
+		if err := existingApp.CheckReapply(s.reapply, time.Now()); err != nil {
+			return err
+		}
 	}
 
 	// Get user
-	user, err := s.userRepo.FindByID(ctx, userID)
+	applicant, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return fmt.Errorf("user not found: %w", err)
 	}
+	if applicant == nil {
+		return ErrUserNotFound
+	}
 
 	// Check if user is active
-	if !user.IsActive() {
-		return errors.New("your account is not active")
+	if !applicant.IsActive() {
+		return application.ErrApplicantInactive
 	}
 
 	// Check if user is jobseeker
-	if !user.IsJobseeker() {
-		return errors.New("only job seekers can apply for jobs")
+	if !applicant.IsJobseeker() {
+		return application.ErrNotJobSeeker
+	}
+
+	// Accounts in their verification grace period can browse but not apply
+	if !applicant.IsEmailVerified() {
+		return user.ErrEmailVerificationNeeded
 	}
 
 	return nil
@@ -1401,75 +2311,198 @@ func (s *applicationService) CanApplyForJob(ctx context.Context, jobID, userID i
 // ===== Bulk Operations =====
 
 // BulkRejectApplications rejects multiple applications
-func (s *applicationService) BulkRejectApplications(ctx context.Context, applicationIDs []int64, rejectedBy int64, reason string) error {
+func (s *applicationService) BulkRejectApplications(ctx context.Context, ec *employer.EmployerContext, applicationIDs []int64, reason string) error {
 	for _, appID := range applicationIDs {
-		if err := s.RejectApplication(ctx, appID, rejectedBy, reason); err != nil {
+		if err := s.RejectApplication(ctx, ec, appID, reason, false); err != nil {
 			// Log error but continue with others
-			fmt.Printf("failed to reject application %d: %v\n", appID, err)
+			config.WithContext(ctx).WithError(err).Warnf("failed to reject application %d", appID)
 		}
 	}
 	return nil
 }
 
-// BulkMoveToStage moves multiple applications to a stage
-func (s *applicationService) BulkMoveToStage(ctx context.Context, applicationIDs []int64, stage string, handledBy int64) error {
-	return s.BulkUpdateStatus(ctx, applicationIDs, stage, handledBy)
+// BulkMoveToStage moves multiple applications to a stage of their job's pipeline
+func (s *applicationService) BulkMoveToStage(ctx context.Context, ec *employer.EmployerContext, applicationIDs []int64, stage string) error {
+	for _, appID := range applicationIDs {
+		if err := s.MoveToStage(ctx, ec, appID, stage, "Bulk update"); err != nil {
+			// Log error but continue with others
+			config.WithContext(ctx).WithError(err).Warnf("failed to move application %d to stage %s", appID, stage)
+		}
+	}
+	return nil
+}
+
+// ExportApplications exports the company's applications matching filter as CSV. Applicants
+// are anonymized by the same blind screening rules as the application lists.
+func (s *applicationService) ExportApplications(ctx context.Context, ec *employer.EmployerContext, filter application.ApplicationFilter) ([]byte, error) {
+	if err := checkEmployerContext(ec); err != nil {
+		return nil, err
+	}
+	companyID := ec.CompanyID
+
+	filter.CompanyID = companyID
+	var apps []application.JobApplication
+	for page := 1; ; page++ {
+		batch, total, err := s.appRepo.ListByCompany(ctx, companyID, filter, page, exportApplicationsPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get applications: %w", err)
+		}
+		apps = append(apps, batch...)
+		if len(batch) < exportApplicationsPageSize || int64(len(apps)) >= total {
+			break
+		}
+	}
+
+	summaries, err := s.buildApplicationSummaries(ctx, apps, ec.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(exportApplicationsColumns); err != nil {
+		return nil, err
+	}
+	for _, summary := range summaries {
+		userID := ""
+		if summary.UserID != 0 {
+			userID = strconv.FormatInt(summary.UserID, 10)
+		}
+		record := []string{
+			strconv.FormatInt(summary.ID, 10),
+			strconv.FormatInt(summary.JobID, 10),
+			csvSafe(summary.JobTitle),
+			userID,
+			csvSafe(summary.UserName),
+			summary.Status,
+			csvSafe(summary.CurrentStage),
+			strconv.FormatFloat(summary.MatchScore, 'f', 2, 64),
+			summary.AppliedAt.UTC().Format(time.RFC3339),
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
 }
 
-// ExportApplications exports applications to CSV/Excel
-func (s *applicationService) ExportApplications(ctx context.Context, companyID int64, filter application.ApplicationFilter) ([]byte, error) {
-	// TODO: Implement export logic
-	// This would generate CSV or Excel file with application data
-	return nil, errors.New("export not yet implemented")
+// csvSafe keeps user-entered text from being evaluated as a formula by spreadsheet apps
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
 }
 
+// exportApplicationsPageSize is the number of applications loaded per query by ExportApplications
+const exportApplicationsPageSize = 500
+
+// exportApplicationsColumns is the header row of ExportApplications
+var exportApplicationsColumns = []string{"application_id", "job_id", "job_title", "user_id", "candidate_name", "status", "current_stage", "match_score", "applied_at"}
+
 // ===== Helper Methods =====
 
-// buildApplicationListResponse builds application list response
-func (s *applicationService) buildApplicationListResponse(ctx context.Context, apps []application.JobApplication, total int64, page, limit int) (*application.ApplicationListResponse, error) {
+// buildApplicationListResponse builds application list response for the employer user viewing
+// it, or for the applicant when userID is 0
+func (s *applicationService) buildApplicationListResponse(ctx context.Context, apps []application.JobApplication, total int64, page, limit int, userID int64) (*application.ApplicationListResponse, error) {
+	summaries, err := s.buildApplicationSummaries(ctx, apps, userID)
+	if err != nil {
+		return nil, err
+	}
+	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+
+	return &application.ApplicationListResponse{
+		Applications: summaries,
+		Total:        total,
+		Page:         page,
+		Limit:        limit,
+		TotalPages:   totalPages,
+	}, nil
+}
+
+// buildApplicationSummaries builds listing summaries with job, company, applicant and stage names.
+// Jobs, companies, applicants and current stages are each loaded in one query for the whole page.
+// When an employer user views them, applicants under blind screening are anonymized.
+func (s *applicationService) buildApplicationSummaries(ctx context.Context, apps []application.JobApplication, userID int64) ([]application.ApplicationSummary, error) {
 	summaries := make([]application.ApplicationSummary, 0, len(apps))
+	if len(apps) == 0 {
+		return summaries, nil
+	}
 
+	jobIDs := make([]int64, 0, len(apps))
+	companyIDs := make([]int64, 0, len(apps))
+	userIDs := make([]int64, 0, len(apps))
+	appIDs := make([]int64, 0, len(apps))
 	for _, app := range apps {
-		// Get job details
-		j, _ := s.jobRepo.FindByID(ctx, app.JobID)
-		jobTitle := ""
-		companyName := ""
-		if j != nil {
-			jobTitle = j.Title
+		jobIDs = append(jobIDs, app.JobID)
+		if app.CompanyID != nil {
+			companyIDs = append(companyIDs, *app.CompanyID)
 		}
+		userIDs = append(userIDs, app.UserID)
+		appIDs = append(appIDs, app.ID)
+	}
 
-		// Get company details
-		if app.CompanyID != nil {
-			comp, _ := s.companyRepo.FindByID(ctx, *app.CompanyID)
-			if comp != nil {
-				companyName = comp.CompanyName
-			}
+	// Lookups are best effort: a failed batch leaves its names empty like a missing row would
+	jobTitles := make(map[int64]string)
+	if jobs, err := s.jobRepo.FindByIDs(ctx, uniqueIDs(jobIDs)); err == nil {
+		for _, j := range jobs {
+			jobTitles[j.ID] = j.Title
+		}
+	}
+	companyNames := make(map[int64]string)
+	if companies, err := s.companyRepo.FindByIDs(ctx, uniqueIDs(companyIDs)); err == nil {
+		for _, comp := range companies {
+			companyNames[comp.ID] = comp.CompanyName
+		}
+	}
+	userNames := make(map[int64]string)
+	if users, err := s.userRepo.FindByIDs(ctx, uniqueIDs(userIDs)); err == nil {
+		for _, u := range users {
+			userNames[u.ID] = u.FullName
+		}
+	}
+	currentStages, _ := s.appRepo.GetCurrentStages(ctx, appIDs)
+
+	// Unlike the names above, the redaction lookup fails the request so identities never leak
+	var redaction applicantRedaction
+	if userID != 0 {
+		var err error
+		if redaction, err = s.applicantRedactionFor(ctx, userID, companyIDs); err != nil {
+			return nil, err
 		}
+	}
 
-		// Get user details
-		user, _ := s.userRepo.FindByID(ctx, app.UserID)
-		userName := ""
-		if user != nil {
-			userName = user.FullName
+	// Stop before building a response nobody is waiting for
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, app := range apps {
+		companyName := ""
+		if app.CompanyID != nil {
+			companyName = companyNames[*app.CompanyID]
 		}
 
-		// Get current stage
-		currentStage, _ := s.appRepo.GetCurrentStage(ctx, app.ID)
 		currentStageName := app.Status
-		if currentStage != nil {
-			currentStageName = currentStage.StageName
+		if stage, ok := currentStages[app.ID]; ok {
+			currentStageName = stage.StageName
 		}
 
 		// Calculate days since applied
 		daysSince := int(time.Since(app.AppliedAt).Hours() / 24)
 
-		summaries = append(summaries, application.ApplicationSummary{
+		summary := application.ApplicationSummary{
 			ID:               app.ID,
 			JobID:            app.JobID,
-			JobTitle:         jobTitle,
+			JobTitle:         jobTitles[app.JobID],
 			CompanyName:      companyName,
 			UserID:           app.UserID,
-			UserName:         userName,
+			UserName:         userNames[app.UserID],
 			Status:           app.Status,
 			MatchScore:       app.MatchScore,
 			AppliedAt:        app.AppliedAt,
@@ -1477,26 +2510,37 @@ func (s *applicationService) buildApplicationListResponse(ctx context.Context, a
 			IsBookmarked:     app.IsBookmarked,
 			CurrentStage:     currentStageName,
 			DaysSinceApplied: daysSince,
-		})
+		}
+		if redaction.applies(app.CompanyID, app.Status) {
+			redactApplicantSummary(&summary)
+		}
+		summaries = append(summaries, summary)
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+	return summaries, nil
+}
 
-	return &application.ApplicationListResponse{
-		Applications: summaries,
-		Total:        total,
-		Page:         page,
-		Limit:        limit,
-		TotalPages:   totalPages,
-	}, nil
+// uniqueIDs returns ids without duplicates, keeping the first occurrence order
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]struct{}, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	return unique
 }
 
-// buildApplicationDetailResponse builds detailed application response
-func (s *applicationService) buildApplicationDetailResponse(ctx context.Context, applicationID int64) (*application.ApplicationDetailResponse, error) {
+// buildApplicationDetailResponse builds detailed application response for the employer user
+// viewing it, or for the applicant when userID is 0
+func (s *applicationService) buildApplicationDetailResponse(ctx context.Context, applicationID, userID int64) (*application.ApplicationDetailResponse, error) {
 	// Get application
 	app, err := s.appRepo.FindByID(ctx, applicationID)
 	if err != nil {
-		return nil, fmt.Errorf("application not found: %w", err)
+		return nil, applicationLookupError(err)
 	}
 
 	// Get job details
@@ -1522,17 +2566,11 @@ func (s *applicationService) buildApplicationDetailResponse(ctx context.Context,
 		}
 	}
 
-	// Get applicant profile
+	// Get applicant profile, with contact details hidden from the company until shortlisted
 	user, _ := s.userRepo.FindByID(ctx, app.UserID)
 	applicantProfile := application.ApplicantProfile{}
 	if user != nil {
-		applicantProfile.UserID = user.ID
-		applicantProfile.FullName = user.FullName
-		applicantProfile.Email = user.Email
-		if user.Phone != nil {
-			applicantProfile.Phone = *user.Phone
-		}
-		applicantProfile.ResumeURL = app.ResumeURL
+		applicantProfile = projectApplicantProfile(user, app, userID != 0)
 	}
 
 	// Get stages
@@ -1547,10 +2585,16 @@ func (s *applicationService) buildApplicationDetailResponse(ctx context.Context,
 	// Get interviews
 	interviews, _ := s.appRepo.ListInterviewsByApplication(ctx, applicationID)
 
+	// Get screening answers
+	answers, _ := s.appRepo.ListAnswersByApplication(ctx, applicationID)
+	if answers == nil {
+		answers = []application.ApplicationAnswer{}
+	}
+
 	// Get stats
 	stats, _ := s.appRepo.GetApplicationStats(ctx, applicationID)
 
-	return &application.ApplicationDetailResponse{
+	detail := &application.ApplicationDetailResponse{
 		Application: *app,
 		Job:         jobDetail,
 		Applicant:   applicantProfile,
@@ -1558,8 +2602,21 @@ func (s *applicationService) buildApplicationDetailResponse(ctx context.Context,
 		Documents:   documents,
 		Notes:       notes,
 		Interviews:  interviews,
+		Answers:     answers,
 		Stats:       stats,
-	}, nil
+	}
+
+	if userID != 0 && app.CompanyID != nil {
+		redaction, err := s.applicantRedactionFor(ctx, userID, []int64{*app.CompanyID})
+		if err != nil {
+			return nil, err
+		}
+		if redaction.applies(app.CompanyID, app.Status) {
+			redactApplicantDetail(detail)
+		}
+	}
+
+	return detail, nil
 }
 
 // buildTimeline builds timeline from application events
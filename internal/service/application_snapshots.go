@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"keerja-backend/internal/config"
+	"keerja-backend/internal/domain/application"
+)
+
+// defaultSnapshotBackfillBatchSize is used by BackfillFileSnapshots for batch sizes below one
+const defaultSnapshotBackfillBatchSize = 100
+
+// ===== Application File Snapshots =====
+
+// snapshotLibraryFile copies fileURL into the application's snapshot directory when it is one
+// of the applicant's library documents and returns the copy's URL. Files the applicant does not
+// own are never copied, so an application cannot pull in another user's private files; for
+// them, and without an upload service, it returns "".
+func (s *applicationService) snapshotLibraryFile(ctx context.Context, userID, applicationID int64, fileURL string) (string, error) {
+	if s.uploads == nil || fileURL == "" {
+		return "", nil
+	}
+
+	docs, err := s.userRepo.GetDocumentsByUserID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get document library: %w", err)
+	}
+
+	resolved := s.uploads.ResolveFileURL(fileURL)
+	for i := range docs {
+		if s.uploads.ResolveFileURL(docs[i].FileURL) == resolved {
+			return s.uploads.CopyFile(ctx, docs[i].FileURL, applicationSnapshotDirectory(applicationID))
+		}
+	}
+	return "", nil
+}
+
+// snapshotResume points the application's resume at a copy of the library document it
+// references. It reports whether a snapshot was made.
+func (s *applicationService) snapshotResume(ctx context.Context, app *application.JobApplication) (bool, error) {
+	snapshotURL, err := s.snapshotLibraryFile(ctx, app.UserID, app.ID, app.ResumeURL)
+	if err != nil || snapshotURL == "" {
+		return false, err
+	}
+
+	updated, err := s.appRepo.SetResumeSnapshot(ctx, app.ID, snapshotURL, app.ResumeURL)
+	if err != nil || !updated {
+		s.deleteSnapshotFile(ctx, snapshotURL)
+		if err != nil {
+			return false, fmt.Errorf("failed to save resume snapshot: %w", err)
+		}
+		return false, nil
+	}
+
+	sourceURL := app.ResumeURL
+	app.ResumeURL = snapshotURL
+	app.ResumeSourceURL = &sourceURL
+	return true, nil
+}
+
+// snapshotDocument points an unsaved or reloaded document at a copy of the library document it
+// references. The copy is stored through the upload service and goes with the document.
+func (s *applicationService) snapshotDocument(ctx context.Context, doc *application.ApplicationDocument) (bool, error) {
+	snapshotURL, err := s.snapshotLibraryFile(ctx, doc.UserID, doc.ApplicationID, doc.FileURL)
+	if err != nil || snapshotURL == "" {
+		return false, err
+	}
+
+	sourceURL := doc.FileURL
+	doc.FileURL = snapshotURL
+	doc.SourceURL = &sourceURL
+	doc.Uploaded = true
+	return true, nil
+}
+
+// warnSnapshotFailure logs a snapshot that could not be made at apply time. The application
+// keeps referencing the original, which the backfill can still copy later.
+func warnSnapshotFailure(ctx context.Context, err error, fileURL string) {
+	config.WithContext(ctx).WithError(err).Warnf("failed to snapshot application file %s", fileURL)
+}
+
+// deleteSnapshotFile removes a snapshot that is no longer referenced. Failures are logged only.
+func (s *applicationService) deleteSnapshotFile(ctx context.Context, snapshotURL string) {
+	if s.uploads == nil || snapshotURL == "" {
+		return
+	}
+	if err := s.uploads.DeleteFile(ctx, snapshotURL); err != nil {
+		config.WithContext(ctx).WithError(err).Warnf("failed to delete application snapshot %s", snapshotURL)
+	}
+}
+
+// GetResumeForDownload returns the application to the applicant or to an employer of the hiring company
+func (s *applicationService) GetResumeForDownload(ctx context.Context, applicationID, userID int64) (*application.JobApplication, error) {
+	app, err := s.findApplicationForDownload(ctx, applicationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if app.ResumeURL == "" {
+		return nil, application.ErrDocumentNotFound
+	}
+	return app, nil
+}
+
+// BackfillFileSnapshots snapshots the resumes and pre-uploaded documents of applications
+// submitted before snapshots were taken. Only files that are still in the applicant's library
+// and in the storage are copied; the others keep their reference and are counted as skipped.
+func (s *applicationService) BackfillFileSnapshots(ctx context.Context, batchSize int) (*application.SnapshotBackfillResult, error) {
+	if s.uploads == nil {
+		return nil, errors.New("snapshots need an upload service")
+	}
+	if batchSize < 1 {
+		batchSize = defaultSnapshotBackfillBatchSize
+	}
+
+	result := &application.SnapshotBackfillResult{}
+	count := func(made bool, err error) {
+		switch {
+		case errors.Is(err, ErrStoredFileNotFound):
+			result.Skipped++
+		case err != nil:
+			result.Failed++
+		case !made:
+			result.Skipped++
+		}
+	}
+
+	for afterID := int64(0); ; {
+		apps, err := s.appRepo.ListUnsnapshottedResumes(ctx, afterID, batchSize)
+		if err != nil {
+			return result, fmt.Errorf("failed to list application resumes: %w", err)
+		}
+		for i := range apps {
+			made, err := s.snapshotResume(ctx, &apps[i])
+			if err != nil && !errors.Is(err, ErrStoredFileNotFound) {
+				warnSnapshotFailure(ctx, err, apps[i].ResumeURL)
+			}
+			if made {
+				result.Resumes++
+			}
+			count(made, err)
+		}
+		if len(apps) < batchSize {
+			break
+		}
+		afterID = apps[len(apps)-1].ID
+	}
+
+	for afterID := int64(0); ; {
+		docs, err := s.appRepo.ListUnsnapshottedDocuments(ctx, afterID, batchSize)
+		if err != nil {
+			return result, fmt.Errorf("failed to list application documents: %w", err)
+		}
+		for i := range docs {
+			doc, sourceURL := &docs[i], docs[i].FileURL
+			made, err := s.snapshotDocument(ctx, doc)
+			if made {
+				if err = s.appRepo.UpdateDocument(ctx, doc); err != nil {
+					s.deleteSnapshotFile(ctx, doc.FileURL)
+					made = false
+				}
+			}
+			if err != nil && !errors.Is(err, ErrStoredFileNotFound) {
+				warnSnapshotFailure(ctx, err, sourceURL)
+			}
+			if made {
+				result.Documents++
+			}
+			count(made, err)
+		}
+		if len(docs) < batchSize {
+			break
+		}
+		afterID = docs[len(docs)-1].ID
+	}
+
+	return result, nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/webhook"
+)
+
+// publishApplicationEvent sends an application event to the webhooks of the application's
+// company. previousStatus is empty for application.created.
+func (s *applicationService) publishApplicationEvent(ctx context.Context, app *application.JobApplication, event, previousStatus string) {
+	if s.webhooks == nil {
+		return
+	}
+	companyID, ok := s.applicationCompanyID(ctx, app)
+	if !ok {
+		return
+	}
+
+	publishWebhookEvent(ctx, s.webhooks, companyID, event, webhook.ApplicationEventData{
+		ApplicationID:  app.ID,
+		JobID:          app.JobID,
+		Status:         app.Status,
+		PreviousStatus: previousStatus,
+		OccurredAt:     time.Now().UTC(),
+	})
+}
+
+// publishInterviewScheduled sends interview.scheduled to the webhooks of the application's company
+func (s *applicationService) publishInterviewScheduled(ctx context.Context, app *application.JobApplication, interview *application.Interview) {
+	if s.webhooks == nil {
+		return
+	}
+	companyID, ok := s.applicationCompanyID(ctx, app)
+	if !ok {
+		return
+	}
+
+	publishWebhookEvent(ctx, s.webhooks, companyID, webhook.EventInterviewScheduled, webhook.InterviewEventData{
+		InterviewID:   interview.ID,
+		ApplicationID: app.ID,
+		JobID:         app.JobID,
+		ScheduledAt:   interview.ScheduledAt,
+		Timezone:      interview.Timezone,
+		InterviewType: interview.InterviewType,
+	})
+}
+
+// applicationCompanyID returns the company an application was made to. Applications made
+// before company_id was stored on them fall back to their job.
+func (s *applicationService) applicationCompanyID(ctx context.Context, app *application.JobApplication) (int64, bool) {
+	if app.CompanyID != nil {
+		return *app.CompanyID, true
+	}
+	j, err := s.jobRepo.FindByID(ctx, app.JobID)
+	if err != nil || j == nil {
+		log.Printf("[WARN] failed to find company of application %d for webhooks: %v", app.ID, err)
+		return 0, false
+	}
+	return j.CompanyID, true
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"keerja-backend/internal/domain/audit"
+)
+
+const (
+	// auditBatchSize caps how many queued entries are written in one insert
+	auditBatchSize = 100
+	// auditWriteTimeout bounds a single batch insert
+	auditWriteTimeout = 5 * time.Second
+)
+
+// auditService implements audit.AuditService. Entries are queued on a buffered channel
+// and written by a single background worker, so recording never blocks or fails the
+// action being audited.
+type auditService struct {
+	repo    audit.AuditLogRepository
+	entries chan audit.AuditLog
+	done    chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewAuditService creates a new audit service that buffers up to bufferSize entries
+// and starts its background writer
+func NewAuditService(repo audit.AuditLogRepository, bufferSize int) audit.AuditService {
+	s := &auditService{
+		repo:    repo,
+		entries: make(chan audit.AuditLog, bufferSize),
+		done:    make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Record queues an entry for writing and returns immediately
+func (s *auditService) Record(ctx context.Context, entry audit.AuditLog) {
+	actor, hasActor := audit.ActorFromContext(ctx)
+	if entry.ActorType == "" {
+		entry.ActorType = audit.ActorSystem
+		if hasActor && actor.Type != "" {
+			entry.ActorType = actor.Type
+			entry.ActorID = &actor.ID
+		}
+	}
+	if entry.IP == nil && hasActor && actor.IP != "" {
+		entry.IP = &actor.IP
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		log.Printf("[WARN] audit service closed, dropping %s on %s %d", entry.Action, entry.EntityType, entry.EntityID)
+		return
+	}
+
+	select {
+	case s.entries <- entry:
+	default:
+		log.Printf("[WARN] audit buffer full, dropping %s on %s %d", entry.Action, entry.EntityType, entry.EntityID)
+	}
+}
+
+// ListLogs lists audit log entries matching filter, newest first
+func (s *auditService) ListLogs(ctx context.Context, filter audit.AuditLogFilter, page, limit int) ([]audit.AuditLog, int64, error) {
+	return s.repo.List(ctx, filter, page, limit)
+}
+
+// Close stops accepting entries and waits for queued entries to be written
+func (s *auditService) Close() {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.entries)
+	}
+	s.mu.Unlock()
+
+	<-s.done
+}
+
+// run writes queued entries, batching whatever has piled up since the last write
+func (s *auditService) run() {
+	defer close(s.done)
+
+	for entry := range s.entries {
+		batch := []audit.AuditLog{entry}
+	drain:
+		for len(batch) < auditBatchSize {
+			select {
+			case next, ok := <-s.entries:
+				if !ok {
+					break drain
+				}
+				batch = append(batch, next)
+			default:
+				break drain
+			}
+		}
+		s.write(batch)
+	}
+}
+
+func (s *auditService) write(batch []audit.AuditLog) {
+	ctx, cancel := context.WithTimeout(context.Background(), auditWriteTimeout)
+	defer cancel()
+
+	if err := s.repo.CreateBatch(ctx, batch); err != nil {
+		log.Printf("[WARN] failed to write %d audit log entries: %v", len(batch), err)
+	}
+}
+
+// recordAudit records entry when an audit service is configured
+func recordAudit(ctx context.Context, svc audit.AuditService, entry audit.AuditLog) {
+	if svc == nil {
+		return
+	}
+	svc.Record(ctx, entry)
+}
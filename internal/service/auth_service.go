@@ -4,23 +4,33 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
-	"errors"
 	"fmt"
 	"sync"
 	"time"
 
+	"keerja-backend/internal/apperror"
+	"keerja-backend/internal/domain/company"
 	"keerja-backend/internal/domain/user"
 	"keerja-backend/internal/utils"
 )
 
 var (
-	ErrInvalidCredentials       = errors.New("invalid email or password")
-	ErrEmailAlreadyExists       = errors.New("email already exists")
-	ErrInvalidVerificationToken = errors.New("invalid verification token")
-	ErrTokenExpired             = errors.New("token has expired")
-	ErrUserNotFound             = errors.New("user not found")
-	ErrInvalidResetToken        = errors.New("invalid reset token")
-	ErrEmailNotVerified         = errors.New("email not verified")
+	ErrInvalidCredentials       = apperror.Unauthorized("INVALID_CREDENTIALS", "invalid email or password")
+	ErrEmailAlreadyExists       = apperror.Conflict("EMAIL_ALREADY_EXISTS", "email already exists")
+	ErrInvalidVerificationToken = apperror.Validation("INVALID_VERIFICATION_TOKEN", "invalid verification token")
+	ErrTokenExpired             = apperror.Validation("TOKEN_EXPIRED", "token has expired")
+	ErrUserNotFound             = apperror.NotFound("USER_NOT_FOUND", "user not found")
+	ErrInvalidResetToken        = apperror.Validation("INVALID_RESET_TOKEN", "invalid reset token")
+	ErrEmailNotVerified         = apperror.Unauthorized("EMAIL_NOT_VERIFIED", "email not verified")
+	ErrEmailAlreadyVerified     = apperror.Conflict("EMAIL_ALREADY_VERIFIED", "email already verified")
+	ErrInvalidCurrentPassword   = apperror.Validation("INVALID_CURRENT_PASSWORD", "invalid current password")
+	ErrAccountSuspended         = apperror.Forbidden("ACCOUNT_SUSPENDED", "account is suspended")
+	ErrAccountDeactivated       = apperror.Forbidden("ACCOUNT_DEACTIVATED", "account is deactivated")
+	ErrAccountNotActive         = apperror.Forbidden("ACCOUNT_NOT_ACTIVE", "account is not active")
+	ErrPasswordNotSet           = apperror.Validation("PASSWORD_NOT_SET", "this account signs in with Google and has no password; set one with forgot password first")
+	ErrWeakPassword             = apperror.Validation("WEAK_PASSWORD", "password is too weak")
+	ErrSamePassword             = apperror.Validation("SAME_PASSWORD", "new password must be different from the current password")
+	ErrSameEmail                = apperror.Validation("SAME_EMAIL", "new email is the same as the current email")
 )
 
 type TokenStore interface {
@@ -124,6 +134,8 @@ type AuthService struct {
 	userRepo     user.UserRepository
 	emailService EmailService
 	tokenStore   TokenStore
+	invitations  invitationSignup
+	verification user.EmailVerificationPolicy
 	jwtSecret    string
 	jwtDuration  time.Duration
 }
@@ -132,20 +144,28 @@ type AuthService struct {
 type AuthServiceConfig struct {
 	JWTSecret   string
 	JWTDuration time.Duration
+
+	// VerificationGracePeriod is how long unverified accounts can sign in; zero means
+	// user.DefaultEmailVerificationGracePeriod
+	VerificationGracePeriod time.Duration
 }
 
-// NewAuthService creates a new auth service instance
-func NewAuthService(userRepo user.UserRepository, emailService EmailService, tokenStore TokenStore, cfg AuthServiceConfig) *AuthService {
+// NewAuthService creates a new auth service instance. companyService accepts the invitations
+// signups carry and may be nil when invitations are not used.
+func NewAuthService(userRepo user.UserRepository, emailService EmailService, tokenStore TokenStore, cfg AuthServiceConfig, companyService company.CompanyService) *AuthService {
 	return &AuthService{
 		userRepo:     userRepo,
 		emailService: emailService,
 		tokenStore:   tokenStore,
+		invitations:  invitationSignup{companies: companyService, users: userRepo},
+		verification: user.NewEmailVerificationPolicy(cfg.VerificationGracePeriod),
 		jwtSecret:    cfg.JWTSecret,
 		jwtDuration:  cfg.JWTDuration,
 	}
 }
 
-// Register registers a new user
+// Register registers a new user. With an invitation token the user signs up as an employer
+// and joins the inviting company with the invited role.
 func (s *AuthService) Register(ctx context.Context, req *user.RegisterRequest) (*user.User, string, error) {
 	// Check if email already exists
 	existingUser, err := s.userRepo.FindByEmail(ctx, req.Email)
@@ -153,6 +173,20 @@ func (s *AuthService) Register(ctx context.Context, req *user.RegisterRequest) (
 		return nil, "", ErrEmailAlreadyExists
 	}
 
+	invitation, err := s.invitations.check(ctx, req.InvitationToken, req.Email)
+	if err != nil {
+		return nil, "", err
+	}
+	userType := req.UserType
+	if invitation != nil {
+		userType = "employer"
+	}
+
+	phone, err := utils.NormalizePhonePtr(req.Phone)
+	if err != nil {
+		return nil, "", user.ErrInvalidPhone
+	}
+
 	// Hash password
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
@@ -169,15 +203,15 @@ func (s *AuthService) Register(ctx context.Context, req *user.RegisterRequest) (
 	newUser := &user.User{
 		FullName:     req.FullName,
 		Email:        req.Email,
-		Phone:        req.Phone,
+		Phone:        phone,
 		PasswordHash: hashedPassword,
-		UserType:     req.UserType,
+		UserType:     userType,
 		IsVerified:   false,
 		Status:       "inactive", // Will be set to 'active' after email verification
 	}
 
-	if err := s.userRepo.Create(ctx, newUser); err != nil {
-		return nil, "", fmt.Errorf("failed to create user: %w", err)
+	if err := s.invitations.create(ctx, invitation, req.InvitationToken, newUser); err != nil {
+		return nil, "", err
 	}
 
 	// Create user profile with slug
@@ -207,7 +241,13 @@ func (s *AuthService) Register(ctx context.Context, req *user.RegisterRequest) (
 	return newUser, verificationToken, nil
 }
 
-// Login authenticates a user and returns JWT token
+// VerificationPolicy returns the email verification policy applied at sign in
+func (s *AuthService) VerificationPolicy() user.EmailVerificationPolicy {
+	return s.verification
+}
+
+// Login authenticates a user and returns JWT token. Accounts with an unverified email get
+// user.ErrVerificationRequired once their grace period has ended.
 func (s *AuthService) Login(ctx context.Context, email, password string) (*user.User, string, error) {
 	// Find user by email
 	usr, err := s.userRepo.FindByEmail(ctx, email)
@@ -220,17 +260,17 @@ func (s *AuthService) Login(ctx context.Context, email, password string) (*user.
 		return nil, "", ErrInvalidCredentials
 	}
 
-	// Check if email is verified
-	if !usr.IsVerified {
-		return nil, "", ErrEmailNotVerified
+	// Unverified accounts can sign in during their grace period only
+	if !s.verification.CanLogin(usr, time.Now()) {
+		return nil, "", user.ErrVerificationRequired
 	}
 
 	// Check account status
 	if usr.Status == "suspended" {
-		return nil, "", errors.New("account is suspended")
+		return nil, "", ErrAccountSuspended
 	}
 	if usr.Status == "deactivated" {
-		return nil, "", errors.New("account is deactivated")
+		return nil, "", ErrAccountDeactivated
 	}
 
 	// Generate JWT token
@@ -265,12 +305,12 @@ func (s *AuthService) VerifyEmail(ctx context.Context, token string) error {
 	}
 
 	// Check if already verified
-	if usr.IsVerified {
+	if usr.IsEmailVerified() {
 		return nil // Already verified, no error
 	}
 
 	// Update user as verified
-	usr.IsVerified = true
+	usr.MarkEmailVerified(time.Now())
 	usr.Status = "active"
 
 	if err := s.userRepo.Update(ctx, usr); err != nil {
@@ -298,8 +338,8 @@ func (s *AuthService) ResendVerificationEmail(ctx context.Context, email string)
 	}
 
 	// Check if already verified
-	if usr.IsVerified {
-		return errors.New("email already verified")
+	if usr.IsEmailVerified() {
+		return ErrEmailAlreadyVerified
 	}
 
 	// Generate new verification token
@@ -382,7 +422,8 @@ func (s *AuthService) ResetPassword(ctx context.Context, token, newPassword stri
 	return nil
 }
 
-// ChangePassword changes user password (requires current password)
+// ChangePassword changes user password (requires current password). Accounts created
+// through OAuth have no password to verify and get ErrPasswordNotSet.
 func (s *AuthService) ChangePassword(ctx context.Context, userID int64, currentPassword, newPassword string) error {
 	// Find user
 	usr, err := s.userRepo.FindByID(ctx, userID)
@@ -390,9 +431,20 @@ func (s *AuthService) ChangePassword(ctx context.Context, userID int64, currentP
 		return ErrUserNotFound
 	}
 
+	if !usr.HasPassword() {
+		return ErrPasswordNotSet
+	}
+
 	// Verify current password
 	if !utils.VerifyPassword(currentPassword, usr.PasswordHash) {
-		return errors.New("invalid current password")
+		return ErrInvalidCurrentPassword
+	}
+
+	if err := utils.IsValidPassword(newPassword); err != nil {
+		return ErrWeakPassword.WithField("new_password", err.Error())
+	}
+	if utils.VerifyPassword(newPassword, usr.PasswordHash) {
+		return ErrSamePassword
 	}
 
 	// Hash new password
@@ -421,7 +473,7 @@ func (s *AuthService) RefreshToken(ctx context.Context, userID int64) (string, e
 
 	// Check account status
 	if usr.Status != "active" {
-		return "", errors.New("account is not active")
+		return "", ErrAccountNotActive
 	}
 
 	// Generate new token
@@ -449,7 +501,7 @@ func (s *AuthService) ValidateToken(ctx context.Context, tokenString string) (*u
 
 	// Check account status
 	if usr.Status != "active" {
-		return nil, errors.New("account is not active")
+		return nil, ErrAccountNotActive
 	}
 
 	return usr, nil
@@ -0,0 +1,459 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/domain/user"
+)
+
+// TXTResolver looks up the TXT records of a domain; *net.Resolver implements it
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// domainLookupTimeout bounds the DNS lookup of a domain verification
+const domainLookupTimeout = 5 * time.Second
+
+// companyDomainService implements company.DomainService
+type companyDomainService struct {
+	companyRepo  company.CompanyRepository
+	domainRepo   company.DomainRepository
+	userRepo     user.UserRepository
+	emailService email.EmailService
+	resolver     TXTResolver
+	cache        cache.Cache
+}
+
+// NewCompanyDomainService creates a new company domain service
+func NewCompanyDomainService(
+	companyRepo company.CompanyRepository,
+	domainRepo company.DomainRepository,
+	userRepo user.UserRepository,
+	emailService email.EmailService,
+	resolver TXTResolver,
+	cache cache.Cache,
+) company.DomainService {
+	return &companyDomainService{
+		companyRepo:  companyRepo,
+		domainRepo:   domainRepo,
+		userRepo:     userRepo,
+		emailService: emailService,
+		resolver:     resolver,
+		cache:        cache,
+	}
+}
+
+// StartDomainVerification claims the company's email domain. The email method sends a code to
+// postmaster@domain; the DNS method returns the TXT record to publish. Starting again replaces
+// the previous claim.
+func (s *companyDomainService) StartDomainVerification(ctx context.Context, companyID int64, method string) (*company.DomainVerificationChallenge, error) {
+	if method != company.DomainVerificationEmail && method != company.DomainVerificationDNS {
+		return nil, company.ErrInvalidDomainVerificationMethod
+	}
+
+	comp, domain, err := s.claimableDomain(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.domainRepo.FindDomainVerification(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domain verification: %w", err)
+	}
+	if current.IsVerifiedFor(domain) {
+		return nil, company.ErrDomainAlreadyVerified
+	}
+
+	verification := &company.DomainVerification{
+		CompanyID: companyID,
+		Domain:    domain,
+		Method:    method,
+	}
+	challenge := &company.DomainVerificationChallenge{Domain: domain, Method: method}
+
+	var code string
+	if method == company.DomainVerificationEmail {
+		if code, err = generateDomainVerificationCode(); err != nil {
+			return nil, err
+		}
+		expiresAt := time.Now().Add(company.DomainVerificationCodeExpiry)
+		verification.Token = hashDomainVerificationCode(companyID, domain, code)
+		verification.ExpiresAt = &expiresAt
+		challenge.SentTo = company.DomainVerificationMailbox + "@" + domain
+		challenge.ExpiresAt = &expiresAt
+	} else {
+		token, err := generateDomainVerificationToken()
+		if err != nil {
+			return nil, err
+		}
+		verification.Token = token
+		challenge.TXTRecordName = domain
+		challenge.TXTRecordValue = verification.TXTRecordValue()
+	}
+
+	if err := s.domainRepo.SaveDomainVerification(ctx, verification); err != nil {
+		return nil, fmt.Errorf("failed to save domain verification: %w", err)
+	}
+
+	if code != "" {
+		if err := s.emailService.SendDomainVerificationEmail(ctx, challenge.SentTo, comp.CompanyName, domain, code, *verification.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("failed to send domain verification email: %w", err)
+		}
+	}
+
+	return challenge, nil
+}
+
+// VerifyDomain completes the company's pending domain claim: the emailed code must match, or
+// the domain must publish the TXT record
+func (s *companyDomainService) VerifyDomain(ctx context.Context, companyID int64, code string) (*company.DomainVerification, error) {
+	_, domain, err := s.claimableDomain(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	verification, err := s.domainRepo.FindDomainVerification(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domain verification: %w", err)
+	}
+	// A claim on the company's previous email domain does not count for the current one
+	if verification == nil || verification.Domain != domain {
+		return nil, company.ErrDomainVerificationNotFound
+	}
+	if verification.IsVerifiedFor(domain) {
+		return verification, nil
+	}
+
+	if verification.Method == company.DomainVerificationEmail {
+		err = s.checkDomainVerificationCode(ctx, verification, code)
+	} else {
+		err = s.checkTXTRecord(ctx, verification)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	verification.VerifiedAt = &now
+	verification.ExpiresAt = nil
+	if err := s.domainRepo.SaveDomainVerification(ctx, verification); err != nil {
+		return nil, fmt.Errorf("failed to save domain verification: %w", err)
+	}
+
+	return verification, nil
+}
+
+// GetDomainVerification returns the company's domain verification, or nil without one
+func (s *companyDomainService) GetDomainVerification(ctx context.Context, companyID int64) (*company.DomainVerification, error) {
+	verification, err := s.domainRepo.FindDomainVerification(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domain verification: %w", err)
+	}
+	return verification, nil
+}
+
+// claimableDomain returns the company and its normalized email domain, which must be set and
+// must not belong to a public email provider
+func (s *companyDomainService) claimableDomain(ctx context.Context, companyID int64) (*company.Company, string, error) {
+	comp, err := s.companyRepo.FindByID(ctx, companyID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get company: %w", err)
+	}
+	if comp == nil {
+		return nil, "", company.ErrCompanyNotFound
+	}
+
+	if comp.EmailDomain == nil || company.NormalizeEmailDomain(*comp.EmailDomain) == "" {
+		return nil, "", company.ErrEmailDomainNotSet
+	}
+	domain := company.NormalizeEmailDomain(*comp.EmailDomain)
+	if company.IsFreeEmailDomain(domain) {
+		return nil, "", company.ErrFreeEmailDomain
+	}
+
+	return comp, domain, nil
+}
+
+// checkDomainVerificationCode compares code with the emailed one, counting wrong attempts
+func (s *companyDomainService) checkDomainVerificationCode(ctx context.Context, verification *company.DomainVerification, code string) error {
+	if verification.ExpiresAt == nil || time.Now().After(*verification.ExpiresAt) ||
+		verification.Attempts >= company.DomainVerificationMaxAttempts {
+		return company.ErrDomainVerificationExpired
+	}
+
+	want := hashDomainVerificationCode(verification.CompanyID, verification.Domain, strings.TrimSpace(code))
+	if subtle.ConstantTimeCompare([]byte(want), []byte(verification.Token)) == 1 {
+		return nil
+	}
+
+	verification.Attempts++
+	if err := s.domainRepo.SaveDomainVerification(ctx, verification); err != nil {
+		return fmt.Errorf("failed to save domain verification: %w", err)
+	}
+	return company.ErrInvalidDomainVerificationCode
+}
+
+// checkTXTRecord looks for the verification TXT record on the domain
+func (s *companyDomainService) checkTXTRecord(ctx context.Context, verification *company.DomainVerification) error {
+	if s.resolver == nil {
+		return company.ErrDomainTXTRecordNotFound
+	}
+
+	lookupCtx, cancel := context.WithTimeout(ctx, domainLookupTimeout)
+	defer cancel()
+
+	records, err := s.resolver.LookupTXT(lookupCtx, verification.Domain)
+	if err != nil {
+		// NXDOMAIN, no TXT records and timeouts all mean the record cannot be seen yet
+		return company.ErrDomainTXTRecordNotFound
+	}
+
+	want := verification.TXTRecordValue()
+	for _, record := range records {
+		if strings.TrimSpace(record) == want {
+			return nil
+		}
+	}
+	return company.ErrDomainTXTRecordNotFound
+}
+
+// GetCompanySuggestions lists the companies the user may ask to join: those that verified the
+// domain of the user's verified email and enabled auto-join. Companies the user already
+// belongs to are left out.
+func (s *companyDomainService) GetCompanySuggestions(ctx context.Context, userID int64) ([]company.CompanySuggestion, error) {
+	usr, domain, err := s.joinableUser(ctx, userID)
+	if err != nil || domain == "" {
+		return []company.CompanySuggestion{}, err
+	}
+
+	companies, err := s.domainRepo.FindAutoJoinCompanies(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find companies by domain: %w", err)
+	}
+
+	suggestions := make([]company.CompanySuggestion, 0, len(companies))
+	for _, comp := range companies {
+		membership, err := s.companyRepo.FindEmployerUserByUserAndCompany(ctx, usr.ID, comp.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get employer user: %w", err)
+		}
+		if membership != nil && !membership.IsPendingJoin() {
+			continue
+		}
+
+		settings, err := s.settings(ctx, comp.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		suggestions = append(suggestions, company.CompanySuggestion{
+			CompanyID:     comp.ID,
+			CompanyName:   comp.CompanyName,
+			Slug:          comp.Slug,
+			LogoURL:       comp.LogoURL,
+			Domain:        domain,
+			Role:          settings.AutoJoinRole,
+			JoinRequested: membership != nil,
+		})
+	}
+
+	return suggestions, nil
+}
+
+// RequestToJoin adds the user to the company with the company's auto-join role. The membership
+// stays inactive until an owner or admin approves it.
+func (s *companyDomainService) RequestToJoin(ctx context.Context, companyID, userID int64) (*company.EmployerUser, error) {
+	usr, domain, err := s.joinableUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if domain == "" {
+		return nil, company.ErrAutoJoinUnavailable
+	}
+
+	comp, err := s.companyRepo.FindByID(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	if comp == nil {
+		return nil, company.ErrCompanyNotFound
+	}
+
+	settings, err := s.settings(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.AutoJoinEnabled || comp.EmailDomain == nil || company.NormalizeEmailDomain(*comp.EmailDomain) != domain {
+		return nil, company.ErrAutoJoinUnavailable
+	}
+	verification, err := s.domainRepo.FindDomainVerification(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domain verification: %w", err)
+	}
+	if !verification.IsVerifiedFor(domain) {
+		return nil, company.ErrAutoJoinUnavailable
+	}
+
+	existing, err := s.companyRepo.FindEmployerUserByUserAndCompany(ctx, usr.ID, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employer user: %w", err)
+	}
+	if existing != nil {
+		if existing.IsPendingJoin() {
+			return nil, company.ErrJoinRequestPending
+		}
+		return nil, company.ErrAlreadyCompanyEmployer
+	}
+
+	now := time.Now()
+	employerUser := &company.EmployerUser{
+		UserID:          usr.ID,
+		CompanyID:       companyID,
+		Role:            settings.AutoJoinRole,
+		EmailCompany:    &usr.Email,
+		IsActive:        false,
+		JoinRequestedAt: &now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := s.companyRepo.CreateEmployerUser(ctx, employerUser); err != nil {
+		return nil, fmt.Errorf("failed to create join request: %w", err)
+	}
+
+	return employerUser, nil
+}
+
+// ListJoinRequests lists the company's pending join requests
+func (s *companyDomainService) ListJoinRequests(ctx context.Context, companyID int64) ([]company.JoinRequest, error) {
+	requests, err := s.domainRepo.ListJoinRequests(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get join requests: %w", err)
+	}
+	if requests == nil {
+		requests = []company.JoinRequest{}
+	}
+	return requests, nil
+}
+
+// ApproveJoinRequest activates the pending membership with the role it was requested with
+func (s *companyDomainService) ApproveJoinRequest(ctx context.Context, companyID, employerUserID, approvedBy int64) (*company.EmployerUser, error) {
+	employerUser, err := s.findJoinRequest(ctx, companyID, employerUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	employerUser.IsActive = true
+	employerUser.IsVerified = true
+	employerUser.VerifiedAt = &now
+	employerUser.VerifiedBy = &approvedBy
+	employerUser.JoinRequestedAt = nil
+	if err := s.companyRepo.UpdateEmployerUser(ctx, employerUser); err != nil {
+		return nil, fmt.Errorf("failed to approve join request: %w", err)
+	}
+
+	s.invalidateMembership(employerUser)
+	return employerUser, nil
+}
+
+// RejectJoinRequest removes the pending membership, so the user may ask again
+func (s *companyDomainService) RejectJoinRequest(ctx context.Context, companyID, employerUserID int64) error {
+	employerUser, err := s.findJoinRequest(ctx, companyID, employerUserID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.companyRepo.DeleteEmployerUser(ctx, employerUser.ID); err != nil {
+		return fmt.Errorf("failed to reject join request: %w", err)
+	}
+	return nil
+}
+
+// findJoinRequest returns the membership when it is a pending join request of the company
+func (s *companyDomainService) findJoinRequest(ctx context.Context, companyID, employerUserID int64) (*company.EmployerUser, error) {
+	employerUser, err := s.companyRepo.FindEmployerUserByID(ctx, employerUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employer user: %w", err)
+	}
+	if employerUser == nil || employerUser.CompanyID != companyID || !employerUser.IsPendingJoin() {
+		return nil, company.ErrJoinRequestNotFound
+	}
+	return employerUser, nil
+}
+
+// joinableUser returns the user and the domain of their email. The domain is empty when it
+// belongs to a public email provider, since nobody can join a company through those.
+func (s *companyDomainService) joinableUser(ctx context.Context, userID int64) (*user.User, string, error) {
+	usr, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to find user: %w", err)
+	}
+	if usr == nil {
+		return nil, "", ErrUserNotFound
+	}
+	if !usr.IsEmailVerified() {
+		return nil, "", company.ErrEmailNotVerified
+	}
+
+	domain := company.EmailDomainOf(usr.Email)
+	if domain == "" || company.IsFreeEmailDomain(domain) {
+		return usr, "", nil
+	}
+	return usr, domain, nil
+}
+
+// settings returns the company's settings, or the defaults when it saved none
+func (s *companyDomainService) settings(ctx context.Context, companyID int64) (*company.CompanySettings, error) {
+	settings, err := s.companyRepo.FindSettings(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company settings: %w", err)
+	}
+	if settings == nil {
+		settings = company.DefaultCompanySettings(companyID)
+	}
+	return settings, nil
+}
+
+// invalidateMembership drops the cached employer lists the membership appears in
+func (s *companyDomainService) invalidateMembership(employerUser *company.EmployerUser) {
+	if s.cache == nil {
+		return
+	}
+	s.cache.Delete(cache.GenerateCacheKey("company", "employers", employerUser.CompanyID))
+	s.cache.Delete(cache.GenerateCacheKey("user", "companies", employerUser.UserID))
+}
+
+// generateDomainVerificationCode returns a random 6-digit code
+func generateDomainVerificationCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate verification code: %w", err)
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// generateDomainVerificationToken returns a random token for the verification TXT record
+func generateDomainVerificationToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate verification token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashDomainVerificationCode binds the code to the company and domain it was sent for
+func hashDomainVerificationCode(companyID int64, domain, code string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d:%s:%s", companyID, domain, code)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/email"
+)
+
+// companyTemplateService implements email.CompanyTemplateService
+type companyTemplateService struct {
+	repo        email.CompanyTemplateRepository
+	companyRepo company.CompanyRepository
+}
+
+// NewCompanyTemplateService creates a new company email template service
+func NewCompanyTemplateService(repo email.CompanyTemplateRepository, companyRepo company.CompanyRepository) email.CompanyTemplateService {
+	return &companyTemplateService{
+		repo:        repo,
+		companyRepo: companyRepo,
+	}
+}
+
+// ListTemplates lists a company's templates ordered by event
+func (s *companyTemplateService) ListTemplates(ctx context.Context, companyID int64) ([]email.CompanyEmailTemplate, error) {
+	templates, err := s.repo.ListByCompany(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list email templates: %w", err)
+	}
+	return templates, nil
+}
+
+// CreateTemplate adds the company's template for an event it has none for yet
+func (s *companyTemplateService) CreateTemplate(ctx context.Context, companyID, userID int64, input email.CompanyTemplateInput) (*email.CompanyEmailTemplate, error) {
+	input.Subject = strings.TrimSpace(input.Subject)
+	if _, err := s.compile(ctx, companyID, input); err != nil {
+		return nil, err
+	}
+
+	t := &email.CompanyEmailTemplate{
+		CompanyID: companyID,
+		EventType: input.EventType,
+		Subject:   input.Subject,
+		Body:      input.Body,
+		UpdatedBy: &userID,
+	}
+	if err := s.repo.Create(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// UpdateTemplate replaces the subject and body of one of the company's templates. The
+// event of a template cannot change.
+func (s *companyTemplateService) UpdateTemplate(ctx context.Context, companyID, templateID, userID int64, input email.CompanyTemplateInput) (*email.CompanyEmailTemplate, error) {
+	t, err := s.findTemplate(ctx, companyID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	input.EventType = t.EventType
+	input.Subject = strings.TrimSpace(input.Subject)
+	if _, err := s.compile(ctx, companyID, input); err != nil {
+		return nil, err
+	}
+
+	t.Subject = input.Subject
+	t.Body = input.Body
+	t.UpdatedBy = &userID
+	if err := s.repo.Update(ctx, t); err != nil {
+		return nil, fmt.Errorf("failed to update email template: %w", err)
+	}
+	return t, nil
+}
+
+// DeleteTemplate deletes one of the company's templates
+func (s *companyTemplateService) DeleteTemplate(ctx context.Context, companyID, templateID int64) error {
+	if _, err := s.findTemplate(ctx, companyID, templateID); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, templateID); err != nil {
+		return fmt.Errorf("failed to delete email template: %w", err)
+	}
+	return nil
+}
+
+// PreviewTemplate renders a template with sample data and the company's name
+func (s *companyTemplateService) PreviewTemplate(ctx context.Context, companyID int64, input email.CompanyTemplateInput) (*email.RenderedEmail, error) {
+	compiled, err := s.compile(ctx, companyID, input)
+	if err != nil {
+		return nil, err
+	}
+	return compiled.Render(s.sampleVariables(ctx, companyID, input.EventType))
+}
+
+// RenderCompanyTemplate renders the company's template for event, or returns nil when it has none
+func (s *companyTemplateService) RenderCompanyTemplate(ctx context.Context, companyID int64, event string, vars map[string]string) (*email.RenderedEmail, error) {
+	t, err := s.repo.FindByCompanyAndEvent(ctx, companyID, event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email template: %w", err)
+	}
+	if t == nil {
+		return nil, nil
+	}
+
+	compiled, err := email.CompileCompanyTemplate(t.EventType, t.Subject, t.Body)
+	if err != nil {
+		return nil, fmt.Errorf("email template %d is invalid: %w", t.ID, err)
+	}
+	return compiled.Render(vars)
+}
+
+// findTemplate returns one of the company's templates
+func (s *companyTemplateService) findTemplate(ctx context.Context, companyID, templateID int64) (*email.CompanyEmailTemplate, error) {
+	t, err := s.repo.FindByID(ctx, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email template: %w", err)
+	}
+	if t == nil || t.CompanyID != companyID {
+		return nil, email.ErrCompanyTemplateNotFound
+	}
+	return t, nil
+}
+
+// compile checks a template's variables and renders it once with sample data, which
+// catches HTML the body escaper cannot handle before the template is saved
+func (s *companyTemplateService) compile(ctx context.Context, companyID int64, input email.CompanyTemplateInput) (*email.CompiledCompanyTemplate, error) {
+	compiled, err := email.CompileCompanyTemplate(input.EventType, input.Subject, input.Body)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := compiled.Render(s.sampleVariables(ctx, companyID, input.EventType)); err != nil {
+		return nil, email.ErrInvalidTemplateSyntax.WithField("body", err.Error())
+	}
+	return compiled, nil
+}
+
+// sampleVariables returns the sample values of event's variables with the company's own name
+func (s *companyTemplateService) sampleVariables(ctx context.Context, companyID int64, event string) map[string]string {
+	vars := email.SampleCompanyTemplateVariables(event)
+	if s.companyRepo != nil {
+		if comp, err := s.companyRepo.FindByID(ctx, companyID); err == nil && comp != nil {
+			vars[email.TemplateVarCompanyName] = comp.CompanyName
+		}
+	}
+	return vars
+}
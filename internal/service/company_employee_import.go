@@ -0,0 +1,373 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/utils"
+)
+
+// employeeImportDateLayouts are the join_date formats an employee import accepts
+var employeeImportDateLayouts = []string{"2006-01-02", "02/01/2006", "2/1/2006", "02-01-2006"}
+
+// employeeImportTypes are the employment types a company_employees row may have
+var employeeImportTypes = []string{"permanent", "contract", "intern", "freelance"}
+
+// employeeImportRecord is one data row read from an import file
+type employeeImportRecord struct {
+	line   int
+	fields map[string]string
+	err    string // Set when the row could not be read
+}
+
+// pendingEmployee is a validated row waiting to be saved, with its index in the report
+type pendingEmployee struct {
+	employee company.CompanyEmployee
+	row      int
+}
+
+// ImportEmployees imports a company's employee roster from a CSV file and reports the
+// outcome of every row. Bad and duplicate rows are reported without stopping the import.
+// With dryRun the rows are only validated. Valid rows are saved in batches of
+// EmployeeImportBatchSize, each in its own transaction.
+func (s *companyService) ImportEmployees(ctx context.Context, companyID, addedBy int64, file *multipart.FileHeader, dryRun bool) (*company.EmployeeImportReport, error) {
+	if file.Size > company.MaxEmployeeImportFileSize {
+		return nil, company.ErrEmployeeImportTooLarge
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer src.Close()
+
+	records, err := readEmployeeImport(src)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.companyRepo.GetEmployeesByCompanyID(ctx, companyID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employees: %w", err)
+	}
+	known := newEmployeeImportIndex(existing)
+
+	report := &company.EmployeeImportReport{
+		DryRun:    dryRun,
+		TotalRows: len(records),
+		Rows:      make([]company.EmployeeImportRow, 0, len(records)),
+	}
+	var pending []pendingEmployee
+
+	for _, record := range records {
+		row := company.EmployeeImportRow{
+			Row:      record.line,
+			FullName: strings.TrimSpace(record.fields["full_name"]),
+			Email:    utils.SanitizeEmail(record.fields["email"]),
+		}
+
+		employee, reason := employeeFromImport(record, companyID, addedBy)
+		if reason != "" {
+			row.Status, row.Reason = company.ImportRowFailed, reason
+		} else if reason := known.duplicate(row.FullName, row.Email); reason != "" {
+			row.Status, row.Reason = company.ImportRowSkipped, reason
+		} else {
+			known.add(row.FullName, row.Email, record.line)
+			if dryRun {
+				row.Status = company.ImportRowValid
+			} else {
+				pending = append(pending, pendingEmployee{employee: *employee, row: len(report.Rows)})
+			}
+		}
+		report.Rows = append(report.Rows, row)
+	}
+
+	if !dryRun {
+		s.saveImportedEmployees(ctx, report, pending)
+	}
+
+	for _, row := range report.Rows {
+		switch row.Status {
+		case company.ImportRowCreated:
+			report.Created++
+		case company.ImportRowValid:
+			report.Valid++
+		case company.ImportRowSkipped:
+			report.Skipped++
+		case company.ImportRowFailed:
+			report.Failed++
+		}
+	}
+
+	return report, nil
+}
+
+// saveImportedEmployees saves the pending employees batch by batch and records the outcome
+// in their report rows. A failed batch marks only its own rows as failed.
+func (s *companyService) saveImportedEmployees(ctx context.Context, report *company.EmployeeImportReport, pending []pendingEmployee) {
+	for batch := range slices.Chunk(pending, company.EmployeeImportBatchSize) {
+		employees := make([]company.CompanyEmployee, len(batch))
+		for i := range batch {
+			employees[i] = batch[i].employee
+		}
+
+		err := s.companyRepo.AddEmployees(ctx, employees)
+		if err != nil {
+			fmt.Printf("[WARN] employee import: failed to save batch of %d rows: %v\n", len(batch), err)
+		}
+
+		for i, p := range batch {
+			row := &report.Rows[p.row]
+			if err != nil {
+				row.Status, row.Reason = company.ImportRowFailed, "could not be saved, please retry"
+				continue
+			}
+			row.Status, row.EmployeeID = company.ImportRowCreated, employees[i].ID
+		}
+	}
+}
+
+// readEmployeeImport reads the data rows of an import CSV. Columns are matched by their
+// header name, so they may come in any order and unknown columns are ignored.
+func readEmployeeImport(r io.Reader) ([]employeeImportRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, company.ErrEmployeeImportInvalidFile
+	}
+
+	columns := make(map[int]string, len(header))
+	hasFullName := false
+	for i, name := range header {
+		// Spreadsheet exports may start the file with a byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if slices.Contains(company.EmployeeImportColumns, name) {
+			columns[i] = name
+			hasFullName = hasFullName || name == "full_name"
+		}
+	}
+	if !hasFullName {
+		return nil, company.ErrEmployeeImportInvalidFile.WithField("file", "header must include a full_name column")
+	}
+
+	var records []employeeImportRecord
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var record employeeImportRecord
+		var parseErr *csv.ParseError
+		switch {
+		case errors.As(err, &parseErr):
+			record = employeeImportRecord{line: parseErr.StartLine, err: "malformed CSV row: " + parseErr.Err.Error()}
+		case err != nil:
+			return nil, fmt.Errorf("failed to read import file: %w", err)
+		default:
+			line, _ := reader.FieldPos(0)
+			record = employeeImportRecord{line: line, fields: make(map[string]string, len(columns))}
+			if len(fields) != len(header) {
+				record.err = fmt.Sprintf("has %d columns, header has %d", len(fields), len(header))
+			}
+			for i, value := range fields {
+				if name, ok := columns[i]; ok {
+					record.fields[name] = strings.TrimSpace(value)
+				}
+			}
+		}
+
+		records = append(records, record)
+		if len(records) > company.MaxEmployeeImportRows {
+			return nil, company.ErrEmployeeImportTooManyRows
+		}
+	}
+
+	return records, nil
+}
+
+// employeeFromImport validates an import row and builds the employee it describes.
+// It returns the reasons the row is invalid instead when it is not.
+func employeeFromImport(record employeeImportRecord, companyID, addedBy int64) (*company.CompanyEmployee, string) {
+	if record.err != "" {
+		return nil, record.err
+	}
+
+	fields := record.fields
+	employee := &company.CompanyEmployee{
+		CompanyID:        companyID,
+		EmploymentType:   "permanent",
+		EmploymentStatus: "active",
+		AddedBy:          &addedBy,
+	}
+
+	var problems []string
+
+	switch fullName := fields["full_name"]; {
+	case fullName == "":
+		problems = append(problems, "full_name is required")
+	case utf8.RuneCountInString(fullName) > 150:
+		problems = append(problems, "full_name must not exceed 150 characters")
+	default:
+		employee.FullName = &fullName
+	}
+
+	if email := utils.SanitizeEmail(fields["email"]); email != "" {
+		if len(email) > 150 || !utils.IsValidEmail(email) {
+			problems = append(problems, "email is invalid")
+		} else {
+			employee.Email = &email
+		}
+	}
+
+	for _, column := range []struct {
+		name   string
+		target **string
+	}{
+		{"job_title", &employee.JobTitle},
+		{"department", &employee.Department},
+	} {
+		value := fields[column.name]
+		if value == "" {
+			continue
+		}
+		if utf8.RuneCountInString(value) > 100 {
+			problems = append(problems, column.name+" must not exceed 100 characters")
+			continue
+		}
+		*column.target = &value
+	}
+
+	if employmentType := strings.ToLower(fields["employment_type"]); employmentType != "" {
+		if !slices.Contains(employeeImportTypes, employmentType) {
+			problems = append(problems, "employment_type must be one of: "+strings.Join(employeeImportTypes, ", "))
+		} else {
+			employee.EmploymentType = employmentType
+		}
+	}
+
+	if value := fields["join_date"]; value != "" {
+		joinDate, ok := parseImportDate(value)
+		switch {
+		case !ok:
+			problems = append(problems, "join_date must be YYYY-MM-DD or DD/MM/YYYY")
+		case joinDate.After(time.Now()):
+			problems = append(problems, "join_date must not be in the future")
+		default:
+			employee.JoinDate = &joinDate
+		}
+	}
+
+	if value := fields["is_visible_public"]; value != "" {
+		visible, ok := parseImportBool(value)
+		if !ok {
+			problems = append(problems, "is_visible_public must be true or false")
+		}
+		employee.IsVisiblePublic = visible
+	}
+
+	if len(problems) > 0 {
+		return nil, strings.Join(problems, "; ")
+	}
+	return employee, ""
+}
+
+// parseImportDate parses a date in any of employeeImportDateLayouts
+func parseImportDate(value string) (time.Time, bool) {
+	for _, layout := range employeeImportDateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseImportBool parses the yes/no spellings spreadsheets commonly export
+func parseImportBool(value string) (bool, bool) {
+	switch strings.ToLower(value) {
+	case "true", "1", "yes", "y", "ya":
+		return true, true
+	case "false", "0", "no", "n", "tidak":
+		return false, true
+	}
+	return false, false
+}
+
+// employeeImportIndex finds rows that duplicate an existing employee or an earlier row.
+// Employees match on email; when either side has no email they match on full name.
+type employeeImportIndex struct {
+	emails       map[string]int // email -> import line, 0 for existing employees
+	names        map[string]int // full name -> import line, 0 for existing employees
+	namesNoEmail map[string]int // full names of employees without an email
+}
+
+func newEmployeeImportIndex(existing []company.CompanyEmployee) *employeeImportIndex {
+	index := &employeeImportIndex{
+		emails:       make(map[string]int),
+		names:        make(map[string]int),
+		namesNoEmail: make(map[string]int),
+	}
+	for _, employee := range existing {
+		index.add(utils.StringValue(employee.FullName), utils.StringValue(employee.Email), 0)
+	}
+	return index
+}
+
+// add records an employee found on the given import line
+func (i *employeeImportIndex) add(fullName, email string, line int) {
+	name := normalizeEmployeeName(fullName)
+	email = utils.SanitizeEmail(email)
+
+	if email != "" {
+		i.emails[email] = line
+	} else if name != "" {
+		i.namesNoEmail[name] = line
+	}
+	if name != "" {
+		i.names[name] = line
+	}
+}
+
+// duplicate returns why an employee with this name and email is a duplicate, or "" when not
+func (i *employeeImportIndex) duplicate(fullName, email string) string {
+	name := normalizeEmployeeName(fullName)
+
+	if email != "" {
+		if line, ok := i.emails[email]; ok {
+			return duplicateReason("email", line)
+		}
+		if line, ok := i.namesNoEmail[name]; ok {
+			return duplicateReason("name", line)
+		}
+		return ""
+	}
+
+	if line, ok := i.names[name]; ok {
+		return duplicateReason("name", line)
+	}
+	return ""
+}
+
+func duplicateReason(field string, line int) string {
+	if line == 0 {
+		return "an employee with this " + field + " already exists"
+	}
+	return fmt.Sprintf("same %s as row %d", field, line)
+}
+
+// normalizeEmployeeName folds case and whitespace so "Budi  Santoso" matches "budi santoso"
+func normalizeEmployeeName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"keerja-backend/internal/domain/audit"
+	"keerja-backend/internal/domain/company"
+)
+
+// companyQuotaService implements company.QuotaService
+type companyQuotaService struct {
+	quotaRepo    company.QuotaRepository
+	companyRepo  company.CompanyRepository
+	limits       company.QuotaLimits
+	auditService audit.AuditService
+}
+
+// NewCompanyQuotaService creates a new company quota service. limits are the configured plan
+// limits; plans missing from them fall back to company.DefaultQuotaLimits.
+func NewCompanyQuotaService(
+	quotaRepo company.QuotaRepository,
+	companyRepo company.CompanyRepository,
+	limits company.QuotaLimits,
+	auditService audit.AuditService,
+) company.QuotaService {
+	return &companyQuotaService{
+		quotaRepo:    quotaRepo,
+		companyRepo:  companyRepo,
+		limits:       limits,
+		auditService: auditService,
+	}
+}
+
+// planLimits returns the limit in effect for each plan: the admin override when there is one,
+// otherwise the configured limit
+func (s *companyQuotaService) planLimits(ctx context.Context) (company.QuotaLimits, error) {
+	limits := make(company.QuotaLimits, len(company.Plans))
+	for _, plan := range company.Plans {
+		limits[plan] = company.DefaultQuotaLimits[plan]
+		if limit, ok := s.limits[plan]; ok && limit > 0 {
+			limits[plan] = limit
+		}
+	}
+
+	overrides, err := s.quotaRepo.ListPlanQuotas(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plan limits: %w", err)
+	}
+	for _, override := range overrides {
+		if company.IsValidPlan(override.Plan) {
+			limits[override.Plan] = override.MaxPublishedJobs
+		}
+	}
+	return limits, nil
+}
+
+// GetJobQuota returns the company's plan, limit and published job count
+func (s *companyQuotaService) GetJobQuota(ctx context.Context, companyID int64) (*company.JobQuota, error) {
+	comp, err := s.companyRepo.FindByID(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	if comp == nil {
+		return nil, company.ErrCompanyNotFound
+	}
+
+	limits, err := s.planLimits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	published, err := s.quotaRepo.CountPublishedJobs(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count published jobs: %w", err)
+	}
+
+	quota := &company.JobQuota{
+		CompanyID: companyID,
+		Plan:      comp.EffectivePlan(),
+		Published: published,
+	}
+	quota.Limit = limits[quota.Plan]
+	quota.Remaining = max(quota.Limit-published, 0)
+	return quota, nil
+}
+
+// CheckCanPublish returns the quota, or ErrJobQuotaExceeded when every slot is taken
+func (s *companyQuotaService) CheckCanPublish(ctx context.Context, companyID int64) (*company.JobQuota, error) {
+	quota, err := s.GetJobQuota(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if quota.Remaining == 0 {
+		return quota, quota.Exceeded()
+	}
+	return quota, nil
+}
+
+// ListPlanLimits returns the limit in effect for each plan with the override's author, if any
+func (s *companyQuotaService) ListPlanLimits(ctx context.Context) ([]company.PlanQuota, error) {
+	overrides, err := s.quotaRepo.ListPlanQuotas(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plan limits: %w", err)
+	}
+	limits, err := s.planLimits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	quotas := make([]company.PlanQuota, 0, len(company.Plans))
+	for _, plan := range company.Plans {
+		quota := company.PlanQuota{Plan: plan, MaxPublishedJobs: limits[plan]}
+		for _, override := range overrides {
+			if override.Plan == plan {
+				quota = override
+			}
+		}
+		quotas = append(quotas, quota)
+	}
+	return quotas, nil
+}
+
+// UpdatePlanLimit overrides a plan's limit. Companies already past the new limit keep their
+// published jobs but cannot publish more until they are under it.
+func (s *companyQuotaService) UpdatePlanLimit(ctx context.Context, plan string, maxPublishedJobs int, adminID int64) (*company.PlanQuota, error) {
+	if !company.IsValidPlan(plan) {
+		return nil, company.ErrInvalidPlan
+	}
+	if maxPublishedJobs < 1 {
+		return nil, company.ErrInvalidPlanLimit
+	}
+
+	quota := &company.PlanQuota{
+		Plan:             plan,
+		MaxPublishedJobs: maxPublishedJobs,
+		UpdatedBy:        &adminID,
+	}
+	if err := s.quotaRepo.SavePlanQuota(ctx, quota); err != nil {
+		return nil, fmt.Errorf("failed to save plan limit: %w", err)
+	}
+	return quota, nil
+}
+
+// SetCompanyPlan moves the company to plan; an empty plan derives it from the verification
+func (s *companyQuotaService) SetCompanyPlan(ctx context.Context, companyID int64, plan string, adminID int64) (*company.JobQuota, error) {
+	var planValue *string
+	if plan != "" {
+		if !company.IsValidPlan(plan) {
+			return nil, company.ErrInvalidPlan
+		}
+		planValue = &plan
+	}
+
+	comp, err := s.companyRepo.FindByID(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	if comp == nil {
+		return nil, company.ErrCompanyNotFound
+	}
+	previous := comp.EffectivePlan()
+
+	if err := s.quotaRepo.SetCompanyPlan(ctx, companyID, planValue); err != nil {
+		return nil, err
+	}
+
+	quota, err := s.GetJobQuota(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		ActorID:    &adminID,
+		ActorType:  audit.ActorAdmin,
+		Action:     audit.ActionCompanyPlanChanged,
+		EntityType: audit.EntityCompany,
+		EntityID:   companyID,
+		Metadata:   audit.Metadata{"plan": quota.Plan, "previous_plan": previous, "derived": planValue == nil},
+	})
+	return quota, nil
+}
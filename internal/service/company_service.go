@@ -2,17 +2,27 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"mime/multipart"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/audit"
 	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/domain/employer"
 	"keerja-backend/internal/domain/job"
 	"keerja-backend/internal/domain/master"
+	"keerja-backend/internal/domain/notification"
 	"keerja-backend/internal/domain/user"
 	"keerja-backend/internal/utils"
 
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/singleflight"
 	"gorm.io/gorm"
 )
 
@@ -26,8 +36,19 @@ const (
 	CompanyRatingTTL   = 10 * time.Minute // Average ratings
 	CompanyVerifiedTTL = 15 * time.Minute // Verified companies
 	CompanyTopRatedTTL = 15 * time.Minute // Top-rated companies
+	CompanyPublicTTL   = 5 * time.Minute  // Anonymous public company profiles
 )
 
+// ReviewReportHideThreshold is the number of open abuse reports that hides a review
+// until a moderator looks at it
+const ReviewReportHideThreshold = 3
+
+// MaxReviewsPerDay is how many reviews a user may submit in any 24 hours
+const MaxReviewsPerDay = 3
+
+// MaxReviewResponseLength is the longest company response to a review, in characters
+const MaxReviewResponseLength = 2000
+
 // companyService implements the CompanyService interface
 type companyService struct {
 	companyRepo        company.CompanyRepository
@@ -38,13 +59,44 @@ type companyService struct {
 	companySizeService master.CompanySizeService
 	districtService    master.DistrictService
 	jobRepo            job.JobRepository
+	applicationRepo    application.ApplicationRepository
 	userService        user.UserService
 	userRepo           user.UserRepository
+	emailService       email.EmailService
+	auditService       audit.AuditService
+	geocoder           Geocoder
+	notifService       notification.NotificationService
+	dispatcher         notification.NotificationDispatcher
+
+	// loads shares one database query among concurrent cache misses on the same hot key
+	loads singleflight.Group
+}
+
+// companyListCacheEntry is a cached page of the company list
+type companyListCacheEntry struct {
+	Companies []company.Company
+	Total     int64
+}
+
+// loadShared runs load once for all concurrent callers with the same key and hands each
+// of them its result, so a cache miss under load costs a single database query
+func loadShared[T any](group *singleflight.Group, key string, load func() (T, error)) (T, error) {
+	v, err, _ := group.Do(key, func() (interface{}, error) {
+		return load()
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return v.(T), nil
 }
 
 // GetJobsByStatus implements CompanyService interface for getting jobs by specific status
-func (s *companyService) GetJobsByStatus(ctx context.Context, userID int64, status string, page, limit int) ([]job.Job, int64, error) {
-	return s.jobRepo.GetJobsByStatus(ctx, userID, status, page, limit)
+func (s *companyService) GetJobsByStatus(ctx context.Context, ec *employer.EmployerContext, status string, page, limit int) ([]job.Job, int64, error) {
+	if ec == nil {
+		return nil, 0, company.ErrNotCompanyEmployer
+	}
+	return s.jobRepo.GetJobsByStatus(ctx, ec.EmployerUserID, status, page, limit)
 }
 
 // NewCompanyService creates a new company service instance
@@ -57,8 +109,14 @@ func NewCompanyService(
 	companySizeService master.CompanySizeService,
 	districtService master.DistrictService,
 	jobRepo job.JobRepository,
+	applicationRepo application.ApplicationRepository,
 	userService user.UserService,
 	userRepo user.UserRepository,
+	emailService email.EmailService,
+	auditService audit.AuditService,
+	geocoder Geocoder,
+	notifService notification.NotificationService,
+	dispatcher notification.NotificationDispatcher,
 ) company.CompanyService {
 	return &companyService{
 		companyRepo:        companyRepo,
@@ -69,8 +127,14 @@ func NewCompanyService(
 		companySizeService: companySizeService,
 		districtService:    districtService,
 		jobRepo:            jobRepo,
+		applicationRepo:    applicationRepo,
 		userService:        userService,
 		userRepo:           userRepo,
+		emailService:       emailService,
+		auditService:       auditService,
+		geocoder:           geocoder,
+		notifService:       notifService,
+		dispatcher:         dispatcherOrDefault(dispatcher, userRepo),
 	}
 }
 
@@ -82,8 +146,20 @@ func NewCompanyService(
 func (s *companyService) RegisterCompany(ctx context.Context, req *company.RegisterCompanyRequest, userID int64) (*company.Company, error) {
 	var comp *company.Company
 
+	// Accounts in their email verification grace period cannot create companies
+	owner, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if owner == nil {
+		return nil, ErrUserNotFound
+	}
+	if !owner.IsEmailVerified() {
+		return nil, user.ErrEmailVerificationNeeded
+	}
+
 	// Validate Master Data IDs
-	err := s.ValidateMasterDataIDs(ctx, req.IndustryID, req.CompanySizeID, req.DistrictID)
+	err = s.ValidateMasterDataIDs(ctx, req.IndustryID, req.CompanySizeID, req.DistrictID, nil)
 	if err != nil {
 		return nil, fmt.Errorf("master data validation failed: %w", err)
 	}
@@ -106,13 +182,11 @@ func (s *companyService) RegisterCompany(ctx context.Context, req *company.Regis
 	// Execute in transaction
 	err = s.db.Transaction(func(tx *gorm.DB) error {
 		// Generate unique slug from company name
-		slug := utils.GenerateSlug(req.CompanyName)
-
-		// Check if slug exists, generate unique one if needed
-		_, err := s.companyRepo.FindBySlug(ctx, slug)
-		if err == nil {
-			// Slug exists, generate unique one
-			slug = utils.GenerateSlugSimple(req.CompanyName)
+		slug, err := utils.GenerateUniqueSlug(ctx, req.CompanyName, func(ctx context.Context, candidate string) (bool, error) {
+			return s.companyRepo.SlugExists(ctx, candidate, 0)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to generate company slug: %w", err)
 		}
 
 		// Create company
@@ -164,7 +238,7 @@ func (s *companyService) RegisterCompany(ctx context.Context, req *company.Regis
 		employerUser := &company.EmployerUser{
 			UserID:     userID,
 			CompanyID:  comp.ID,
-			Role:       "owner",
+			Role:       employer.RoleOwner,
 			IsActive:   true,
 			IsVerified: true, // Auto-verify the owner
 			VerifiedAt: &now,
@@ -223,34 +297,42 @@ func (s *companyService) RegisterCompany(ctx context.Context, req *company.Regis
 func (s *companyService) GetCompany(ctx context.Context, id int64) (*company.Company, error) {
 	// Try cache first
 	cacheKey := cache.GenerateCacheKey("company", "detail", id)
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		return cached.(*company.Company), nil
+	if cached, ok := cache.GetTyped[*company.Company](s.cache, cacheKey); ok && cached != nil {
+		return cached, nil
 	}
 
-	// Cache miss - fetch from database
-	comp, err := s.companyRepo.GetFullCompanyProfile(ctx, id)
-	if err != nil {
-		return nil, fmt.Errorf("company not found: %w", err)
-	}
+	// Cache miss - fetch from database once for all concurrent misses
+	return loadShared(&s.loads, cacheKey, func() (*company.Company, error) {
+		comp, err := s.companyRepo.GetFullCompanyProfile(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get company: %w", err)
+		}
+		if comp == nil {
+			return nil, company.ErrCompanyNotFound
+		}
 
-	// Store in cache
-	s.cache.Set(cacheKey, comp, CompanyDetailTTL)
+		// Store in cache
+		s.cache.Set(cacheKey, comp, CompanyDetailTTL)
 
-	return comp, nil
+		return comp, nil
+	})
 }
 
 // GetCompanyBySlug retrieves company by slug (with caching)
 func (s *companyService) GetCompanyBySlug(ctx context.Context, slug string) (*company.Company, error) {
 	// Try cache first
 	cacheKey := cache.GenerateCacheKey("company", "slug", slug)
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		return cached.(*company.Company), nil
+	if cached, ok := cache.GetTyped[*company.Company](s.cache, cacheKey); ok {
+		return cached, nil
 	}
 
 	// Cache miss - fetch from database
 	comp, err := s.companyRepo.FindBySlug(ctx, slug)
 	if err != nil {
-		return nil, fmt.Errorf("company not found: %w", err)
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	if comp == nil {
+		return nil, company.ErrCompanyNotFound
 	}
 
 	// Get full profile
@@ -267,42 +349,140 @@ func (s *companyService) GetCompanyBySlug(ctx context.Context, slug string) (*co
 	return fullComp, nil
 }
 
+// GetPublicProfile loads the public company page: the company with master data, its latest
+// published jobs, review summary and follower count. The anonymous variant is cached; for a
+// signed-in viewer IsFollowing is added on top of it.
+func (s *companyService) GetPublicProfile(ctx context.Context, slug string, viewerUserID int64) (*company.PublicCompanyProfile, error) {
+	profile, err := s.getAnonymousPublicProfile(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	if viewerUserID == 0 {
+		return profile, nil
+	}
+
+	following, err := s.companyRepo.IsFollowing(ctx, profile.Company.ID, viewerUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check following status: %w", err)
+	}
+
+	personal := *profile
+	personal.IsFollowing = &following
+	return &personal, nil
+}
+
+// getAnonymousPublicProfile builds the viewer-independent part of the public company page
+func (s *companyService) getAnonymousPublicProfile(ctx context.Context, slug string) (*company.PublicCompanyProfile, error) {
+	cacheKey := cache.GenerateCacheKey("company", "public", slug)
+	if cached, ok := cache.GetTyped[*company.PublicCompanyProfile](s.cache, cacheKey); ok && cached != nil {
+		return cached, nil
+	}
+
+	comp, err := s.GetCompanyBySlugWithMasterData(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copy so hiding a draft profile doesn't touch the cached company
+	public := *comp
+	if public.Profile != nil && public.Profile.Status != "published" {
+		public.Profile = nil
+	}
+
+	profile := &company.PublicCompanyProfile{Company: &public}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		jobs, total, err := s.jobRepo.ListByCompany(gctx, comp.ID, job.JobFilter{Status: job.StatusPublished, SortBy: "latest"}, 1, company.PublicProfileJobLimit)
+		if err != nil {
+			return fmt.Errorf("failed to list company jobs: %w", err)
+		}
+		profile.Jobs, profile.JobsTotal = jobs, total
+		return nil
+	})
+	g.Go(func() error {
+		ratings, err := s.companyRepo.CalculateAverageRatings(gctx, comp.ID)
+		if err != nil {
+			return fmt.Errorf("failed to calculate average ratings: %w", err)
+		}
+		profile.Ratings = ratings
+		return nil
+	})
+	g.Go(func() error {
+		approved := company.ReviewStatusApproved
+		reviews, _, err := s.companyRepo.GetReviewsByCompanyID(gctx, comp.ID, &company.ReviewFilter{
+			Status:    &approved,
+			Page:      1,
+			Limit:     company.PublicProfileReviewLimit,
+			SortBy:    "helpful_count",
+			SortOrder: "desc",
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get reviews: %w", err)
+		}
+		profile.TopReviews = reviews
+		return nil
+	})
+	g.Go(func() error {
+		count, err := s.companyRepo.CountFollowers(gctx, comp.ID)
+		if err != nil {
+			return fmt.Errorf("failed to count followers: %w", err)
+		}
+		profile.FollowerCount = count
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	s.cache.Set(cacheKey, profile, CompanyPublicTTL)
+
+	return profile, nil
+}
+
 // UpdateCompany updates company information with banner and logo
 func (s *companyService) UpdateCompany(ctx context.Context, companyID int64, req *company.UpdateCompanyRequest, bannerFile, logoFile *multipart.FileHeader) error {
 	// Get existing company
 	comp, err := s.companyRepo.FindByID(ctx, companyID)
 	if err != nil {
-		return fmt.Errorf("company not found: %w", err)
+		return fmt.Errorf("failed to get company: %w", err)
+	}
+	if comp == nil {
+		return company.ErrCompanyNotFound
+	}
+	if req.Version != nil && *req.Version != comp.Version {
+		// The client may have loaded a cached copy older than the current version
+		s.cache.Delete(cache.GenerateCacheKey("company", "detail", companyID))
+		return company.ErrCompanyVersionConflict.WithCurrentVersion(comp.Version)
 	}
 
 	// Upload banner if provided
 	if bannerFile != nil {
-		// Delete old banner if exists
-		if comp.BannerURL != nil && *comp.BannerURL != "" {
-			_ = s.uploadService.DeleteFile(ctx, *comp.BannerURL)
-		}
-
 		// Upload new banner
-		bannerPath, err := s.uploadService.UploadFile(ctx, bannerFile, fmt.Sprintf("companies/%d/banner", companyID))
+		banner, err := s.uploadService.UploadImage(ctx, bannerFile, fmt.Sprintf("companies/%d/banner", companyID), BannerImageSpec)
 		if err != nil {
 			return fmt.Errorf("failed to upload banner: %w", err)
 		}
-		comp.BannerURL = &bannerPath
+
+		// Delete old banner if exists
+		deleteImageFiles(ctx, s.uploadService, comp.BannerURL, comp.BannerVariants)
+		comp.BannerURL = &banner.URL
+		comp.BannerVariants = banner.Variants
 	}
 
 	// Upload logo if provided
 	if logoFile != nil {
-		// Delete old logo if exists
-		if comp.LogoURL != nil && *comp.LogoURL != "" {
-			_ = s.uploadService.DeleteFile(ctx, *comp.LogoURL)
-		}
-
 		// Upload new logo
-		logoPath, err := s.uploadService.UploadFile(ctx, logoFile, fmt.Sprintf("companies/%d/logo", companyID))
+		logo, err := s.uploadService.UploadImage(ctx, logoFile, fmt.Sprintf("companies/%d/logo", companyID), LogoImageSpec)
 		if err != nil {
 			return fmt.Errorf("failed to upload logo: %w", err)
 		}
-		comp.LogoURL = &logoPath
+
+		// Delete old logo if exists
+		deleteImageFiles(ctx, s.uploadService, comp.LogoURL, comp.LogoVariants)
+		comp.LogoURL = &logo.URL
+		comp.LogoVariants = logo.Variants
 	}
 
 	// NOTE: CompanyName, Country, Province, City, SizeCategory/EmployeeCount, Industry
@@ -356,8 +536,8 @@ func (s *companyService) UpdateCompany(ctx context.Context, companyID int64, req
 	if !wasVerified && newVerified {
 		// Update all jobs for this company from 'in_review' to 'draft'
 		if err := s.db.Model(&job.Job{}).
-			Where("company_id = ? AND status = ?", companyID, "in_review").
-			Update("status", "draft").Error; err != nil {
+			Where("company_id = ? AND status = ?", companyID, job.StatusInReview).
+			Update("status", job.StatusDraft).Error; err != nil {
 			return fmt.Errorf("failed to update jobs to draft: %w", err)
 		}
 		comp.Verified = true
@@ -366,6 +546,9 @@ func (s *companyService) UpdateCompany(ctx context.Context, companyID int64, req
 
 	// Update company in database
 	if err := s.companyRepo.Update(ctx, comp); err != nil {
+		if errors.Is(err, company.ErrCompanyVersionConflict) {
+			s.cache.Delete(cache.GenerateCacheKey("company", "detail", companyID))
+		}
 		return fmt.Errorf("failed to update company: %w", err)
 	}
 
@@ -380,90 +563,118 @@ func (s *companyService) UpdateCompany(ctx context.Context, companyID int64, req
 	return nil
 }
 
-// DeleteCompany deletes a company (soft delete)
+// DeleteCompany deletes a company (soft delete). Logo, banner and documents are kept so an
+// admin can restore the company.
 func (s *companyService) DeleteCompany(ctx context.Context, companyID int64) error {
-	// Get company to clean up files
-	comp, err := s.companyRepo.GetFullCompanyProfile(ctx, companyID)
+	comp, err := s.companyRepo.FindByID(ctx, companyID)
 	if err != nil {
-		return fmt.Errorf("company not found: %w", err)
-	}
-
-	// Clean up logo and banner
-	if comp.LogoURL != nil {
-		_ = s.uploadService.DeleteFile(ctx, *comp.LogoURL)
-	}
-	if comp.BannerURL != nil {
-		_ = s.uploadService.DeleteFile(ctx, *comp.BannerURL)
+		return fmt.Errorf("failed to get company: %w", err)
 	}
-
-	// Clean up documents
-	if len(comp.Documents) > 0 {
-		for _, doc := range comp.Documents {
-			_ = s.uploadService.DeleteFile(ctx, doc.FilePath)
-		}
+	if comp == nil {
+		return company.ErrCompanyNotFound
 	}
 
 	// Get all employers before deletion to invalidate their caches
 	employers, _ := s.companyRepo.GetEmployerUsersByCompanyID(ctx, companyID)
 
-	// Delete company (cascade will handle related records)
 	if err := s.companyRepo.Delete(ctx, companyID); err != nil {
 		return fmt.Errorf("failed to delete company: %w", err)
 	}
 
-	// Invalidate all related caches
-	s.cache.DeletePattern(fmt.Sprintf("company:*:%d", companyID))
-	s.cache.DeletePattern("companies:list:*")
-	s.cache.DeletePattern("companies:verified:*")
-	s.cache.DeletePattern("companies:top-rated:*")
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		Action:     audit.ActionCompanyDeleted,
+		EntityType: audit.EntityCompany,
+		EntityID:   companyID,
+		Metadata:   audit.Metadata{"company_name": comp.CompanyName},
+	})
+
+	invalidateCompanyVisibilityCaches(s.cache, comp, employers)
+	return nil
+}
+
+// invalidateCompanyVisibilityCaches drops every cached entry that shows or lists the company,
+// after it was deleted or restored or its master data changed
+func invalidateCompanyVisibilityCaches(c cache.Cache, comp *company.Company, employers []company.EmployerUser) {
+	c.DeletePattern(fmt.Sprintf("company:*:%d", comp.ID))
+	c.Delete(cache.GenerateCacheKey("company", "slug", comp.Slug))
+	c.Delete(cache.GenerateCacheKey("company", "slug", "masterdata", comp.Slug))
+	c.Delete(cache.GenerateCacheKey("company", "public", comp.Slug))
+	c.DeletePattern("companies:list:*")
+	c.DeletePattern("companies:verified:*")
+	c.DeletePattern("companies:top-rated:*")
 
 	// Invalidate user companies cache for all employers
 	for _, emp := range employers {
-		s.cache.Delete(cache.GenerateCacheKey("user", "companies", emp.UserID))
+		c.Delete(cache.GenerateCacheKey("user", "companies", emp.UserID))
 	}
-
-	return nil
 }
 
 // ListCompanies lists companies with filters (with caching)
 func (s *companyService) ListCompanies(ctx context.Context, filter *company.CompanyFilter) ([]company.Company, int64, error) {
 	// Generate cache key from filter
 	filterMap := map[string]interface{}{
-		"search_query":  filter.SearchQuery,
-		"industry":      filter.Industry,
-		"company_type":  filter.CompanyType,
-		"size_category": filter.SizeCategory,
-		"city":          filter.City,
-		"province":      filter.Province,
-		"verified":      filter.Verified,
-		"is_active":     filter.IsActive,
-		"page":          filter.Page,
-		"limit":         filter.Limit,
-		"sort_by":       filter.SortBy,
-		"sort_order":    filter.SortOrder,
+		"industry_id":     filter.IndustryID,
+		"company_size_id": filter.CompanySizeID,
+		"province_id":     filter.ProvinceID,
+		"city_id":         filter.CityID,
+		"district_id":     filter.DistrictID,
+		"search_query":    filter.SearchQuery,
+		"industry":        filter.Industry,
+		"company_type":    filter.CompanyType,
+		"size_category":   filter.SizeCategory,
+		"city":            filter.City,
+		"province":        filter.Province,
+		"verified":        filter.Verified,
+		"is_active":       filter.IsActive,
+		"page":            filter.Page,
+		"limit":           filter.Limit,
+		"sort_by":         filter.SortBy,
+		"sort_order":      filter.SortOrder,
+	}
+	if !cache.CacheableFilter(filterMap, filter.Page) {
+		companies, total, err := s.companyRepo.List(ctx, filter)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to list companies: %w", err)
+		}
+		return companies, total, nil
 	}
 	filterHash := cache.GenerateFilterHash(filterMap)
 	cacheKey := cache.GenerateCacheKey("companies", "list", filterHash)
 
 	// Try cache first
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		result := cached.(map[string]interface{})
-		return result["companies"].([]company.Company), result["total"].(int64), nil
+	if cached, ok := cache.GetTyped[*companyListCacheEntry](s.cache, cacheKey); ok && cached != nil {
+		return cached.Companies, cached.Total, nil
 	}
 
-	// Cache miss - fetch from database
-	companies, total, err := s.companyRepo.List(ctx, filter)
+	// Cache miss - fetch from database once for all concurrent misses
+	entry, err := loadShared(&s.loads, cacheKey, func() (*companyListCacheEntry, error) {
+		companies, total, err := s.companyRepo.List(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list companies: %w", err)
+		}
+
+		// Store in cache
+		entry := &companyListCacheEntry{Companies: companies, Total: total}
+		s.cache.Set(cacheKey, entry, CompanyListTTL)
+
+		return entry, nil
+	})
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list companies: %w", err)
+		return nil, 0, err
 	}
 
-	// Store in cache
-	s.cache.Set(cacheKey, map[string]interface{}{
-		"companies": companies,
-		"total":     total,
-	}, CompanyListTTL)
+	return entry.Companies, entry.Total, nil
+}
 
-	return companies, total, nil
+// ListCompaniesByCursor lists companies newest first, continuing after the given cursor.
+// Cursor pages are not cached because new companies shift the first page.
+func (s *companyService) ListCompaniesByCursor(ctx context.Context, filter *company.CompanyFilter, cursor string) ([]company.Company, string, error) {
+	companies, next, err := s.companyRepo.ListByCursor(ctx, filter, cursor)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list companies: %w", err)
+	}
+
+	return companies, next, nil
 }
 
 // SearchCompanies searches companies by query
@@ -519,6 +730,12 @@ func (s *companyService) UpdateProfile(ctx context.Context, companyID int64, req
 	if err != nil {
 		return fmt.Errorf("profile not found: %w", err)
 	}
+	if profile == nil {
+		return fmt.Errorf("profile not found")
+	}
+	if req.Version != nil && *req.Version != profile.Version {
+		return company.ErrProfileVersionConflict.WithCurrentVersion(profile.Version)
+	}
 
 	// Update fields if provided
 	if req.Tagline != nil {
@@ -610,89 +827,109 @@ func (s *companyService) UnpublishProfile(ctx context.Context, companyID int64)
 // UploadLogo uploads company logo
 func (s *companyService) UploadLogo(ctx context.Context, companyID int64, file *multipart.FileHeader) (string, error) {
 	// Validate file
-	if err := s.uploadService.ValidateFile(file, ImageTypes, MaxAvatarSize); err != nil {
+	if err := s.uploadService.ValidateFile(file, ImageTypes, s.uploadService.Limits().AvatarSize); err != nil {
 		return "", fmt.Errorf("invalid logo file: %w", err)
 	}
 
 	// Get company
 	comp, err := s.companyRepo.FindByID(ctx, companyID)
 	if err != nil {
-		return "", fmt.Errorf("company not found: %w", err)
+		return "", fmt.Errorf("failed to get company: %w", err)
 	}
-
-	// Delete old logo if exists
-	if comp.LogoURL != nil && *comp.LogoURL != "" {
-		_ = s.uploadService.DeleteFile(ctx, *comp.LogoURL)
+	if comp == nil {
+		return "", company.ErrCompanyNotFound
 	}
 
-	// Upload new logo
-	logoURL, err := s.uploadService.UploadFile(ctx, file, "company/logos")
+	// Upload new logo with its resized variants
+	logo, err := s.uploadService.UploadImage(ctx, file, "company/logos", LogoImageSpec)
 	if err != nil {
 		return "", fmt.Errorf("failed to upload logo: %w", err)
 	}
 
-	// Update company with new logo URL
-	comp.LogoURL = &logoURL
+	// Update company with new logo URL, keeping the old files until it is saved
+	oldLogoURL, oldLogoVariants := comp.LogoURL, comp.LogoVariants
+	comp.LogoURL = &logo.URL
+	comp.LogoVariants = logo.Variants
 	if err := s.companyRepo.Update(ctx, comp); err != nil {
-		// Clean up uploaded file
-		_ = s.uploadService.DeleteFile(ctx, logoURL)
+		// Clean up uploaded files
+		deleteImageFiles(ctx, s.uploadService, &logo.URL, logo.Variants)
 		return "", fmt.Errorf("failed to update company with logo URL: %w", err)
 	}
 
-	return logoURL, nil
+	// Delete old logo if exists
+	deleteImageFiles(ctx, s.uploadService, oldLogoURL, oldLogoVariants)
+
+	// Invalidate cache
+	s.cache.Delete(cache.GenerateCacheKey("company", "detail", companyID))
+	s.cache.Delete(cache.GenerateCacheKey("company", "stats", companyID))
+
+	return logo.URL, nil
 }
 
 // UploadBanner uploads company banner
 func (s *companyService) UploadBanner(ctx context.Context, companyID int64, file *multipart.FileHeader) (string, error) {
 	// Validate file
-	if err := s.uploadService.ValidateFile(file, ImageTypes, MaxCoverSize); err != nil {
+	if err := s.uploadService.ValidateFile(file, ImageTypes, s.uploadService.Limits().CoverSize); err != nil {
 		return "", fmt.Errorf("invalid banner file: %w", err)
 	}
 
 	// Get company
 	comp, err := s.companyRepo.FindByID(ctx, companyID)
 	if err != nil {
-		return "", fmt.Errorf("company not found: %w", err)
+		return "", fmt.Errorf("failed to get company: %w", err)
 	}
-
-	// Delete old banner if exists
-	if comp.BannerURL != nil && *comp.BannerURL != "" {
-		_ = s.uploadService.DeleteFile(ctx, *comp.BannerURL)
+	if comp == nil {
+		return "", company.ErrCompanyNotFound
 	}
 
-	// Upload new banner
-	bannerURL, err := s.uploadService.UploadFile(ctx, file, "company/banners")
+	// Upload new banner with its resized variants
+	banner, err := s.uploadService.UploadImage(ctx, file, "company/banners", BannerImageSpec)
 	if err != nil {
 		return "", fmt.Errorf("failed to upload banner: %w", err)
 	}
 
-	// Update company with new banner URL
-	comp.BannerURL = &bannerURL
+	// Update company with new banner URL, keeping the old files until it is saved
+	oldBannerURL, oldBannerVariants := comp.BannerURL, comp.BannerVariants
+	comp.BannerURL = &banner.URL
+	comp.BannerVariants = banner.Variants
 	if err := s.companyRepo.Update(ctx, comp); err != nil {
-		// Clean up uploaded file
-		_ = s.uploadService.DeleteFile(ctx, bannerURL)
+		// Clean up uploaded files
+		deleteImageFiles(ctx, s.uploadService, &banner.URL, banner.Variants)
 		return "", fmt.Errorf("failed to update company with banner URL: %w", err)
 	}
 
-	return bannerURL, nil
+	// Delete old banner if exists
+	deleteImageFiles(ctx, s.uploadService, oldBannerURL, oldBannerVariants)
+
+	// Invalidate cache
+	s.cache.Delete(cache.GenerateCacheKey("company", "detail", companyID))
+	s.cache.Delete(cache.GenerateCacheKey("company", "stats", companyID))
+
+	return banner.URL, nil
 }
 
 // DeleteLogo deletes company logo
 func (s *companyService) DeleteLogo(ctx context.Context, companyID int64) error {
 	comp, err := s.companyRepo.FindByID(ctx, companyID)
 	if err != nil {
-		return fmt.Errorf("company not found: %w", err)
+		return fmt.Errorf("failed to get company: %w", err)
 	}
-
-	if comp.LogoURL != nil && *comp.LogoURL != "" {
-		_ = s.uploadService.DeleteFile(ctx, *comp.LogoURL)
+	if comp == nil {
+		return company.ErrCompanyNotFound
 	}
 
+	deleteImageFiles(ctx, s.uploadService, comp.LogoURL, comp.LogoVariants)
+
 	comp.LogoURL = nil
+	comp.LogoVariants = nil
 	if err := s.companyRepo.Update(ctx, comp); err != nil {
 		return fmt.Errorf("failed to update company: %w", err)
 	}
 
+	// Invalidate cache
+	s.cache.Delete(cache.GenerateCacheKey("company", "detail", companyID))
+	s.cache.Delete(cache.GenerateCacheKey("company", "stats", companyID))
+
 	return nil
 }
 
@@ -700,18 +937,24 @@ func (s *companyService) DeleteLogo(ctx context.Context, companyID int64) error
 func (s *companyService) DeleteBanner(ctx context.Context, companyID int64) error {
 	comp, err := s.companyRepo.FindByID(ctx, companyID)
 	if err != nil {
-		return fmt.Errorf("company not found: %w", err)
+		return fmt.Errorf("failed to get company: %w", err)
 	}
-
-	if comp.BannerURL != nil && *comp.BannerURL != "" {
-		_ = s.uploadService.DeleteFile(ctx, *comp.BannerURL)
+	if comp == nil {
+		return company.ErrCompanyNotFound
 	}
 
+	deleteImageFiles(ctx, s.uploadService, comp.BannerURL, comp.BannerVariants)
+
 	comp.BannerURL = nil
+	comp.BannerVariants = nil
 	if err := s.companyRepo.Update(ctx, comp); err != nil {
 		return fmt.Errorf("failed to update company: %w", err)
 	}
 
+	// Invalidate cache
+	s.cache.Delete(cache.GenerateCacheKey("company", "detail", companyID))
+	s.cache.Delete(cache.GenerateCacheKey("company", "stats", companyID))
+
 	return nil
 }
 
@@ -728,7 +971,7 @@ func (s *companyService) FollowCompany(ctx context.Context, companyID, userID in
 	}
 
 	if isFollowing {
-		return fmt.Errorf("already following this company")
+		return company.ErrAlreadyFollowing
 	}
 
 	// Follow company
@@ -797,8 +1040,23 @@ func (s *companyService) GetFollowerCount(ctx context.Context, companyID int64)
 // Review Management
 // =============================================================================
 
-// AddReview adds a company review
+// AddReview adds a company review. A user reviews each company once, at most
+// MaxReviewsPerDay times a day, and the review is flagged as verified when the user
+// worked for or was hired by the company.
 func (s *companyService) AddReview(ctx context.Context, req *company.AddReviewRequest) (*company.CompanyReview, error) {
+	recent, err := s.companyRepo.CountReviewsByUserSince(ctx, req.UserID, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recent reviews: %w", err)
+	}
+	if recent >= MaxReviewsPerDay {
+		return nil, company.ErrReviewRateLimited
+	}
+
+	verified, err := s.companyRepo.IsVerifiedReviewer(ctx, req.CompanyID, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check reviewer employment: %w", err)
+	}
+
 	// Create review
 	review := &company.CompanyReview{
 		CompanyID:          req.CompanyID,
@@ -816,18 +1074,18 @@ func (s *companyService) AddReview(ctx context.Context, req *company.AddReviewRe
 		AdviceToManagement: req.AdviceToManagement,
 		IsAnonymous:        req.IsAnonymous,
 		RecommendToFriend:  req.RecommendToFriend,
-		Status:             "pending", // Requires moderation
+		IsVerifiedReviewer: verified,
+		Status:             company.ReviewStatusPending, // Requires moderation
 	}
 
 	if err := s.companyRepo.CreateReview(ctx, review); err != nil {
+		if errors.Is(err, company.ErrReviewAlreadyExists) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to create review: %w", err)
 	}
 
-	// Invalidate caches
-	s.cache.Delete(cache.GenerateCacheKey("company", "reviews", req.CompanyID))
-	s.cache.Delete(cache.GenerateCacheKey("company", "ratings", req.CompanyID))
-	s.cache.Delete(cache.GenerateCacheKey("company", "stats", req.CompanyID))
-	s.cache.DeletePattern("companies:top-rated:*")
+	s.reviewRatingsChanged(ctx, req.CompanyID)
 
 	return review, nil
 }
@@ -835,14 +1093,14 @@ func (s *companyService) AddReview(ctx context.Context, req *company.AddReviewRe
 // UpdateReview updates a company review
 func (s *companyService) UpdateReview(ctx context.Context, reviewID int64, userID int64, req *company.UpdateReviewRequest) error {
 	// Get review to verify ownership
-	review, err := s.companyRepo.FindReviewByID(ctx, reviewID)
+	review, err := s.findReview(ctx, reviewID)
 	if err != nil {
-		return fmt.Errorf("review not found: %w", err)
+		return err
 	}
 
 	// Verify ownership
 	if review.UserID == nil || *review.UserID != userID {
-		return fmt.Errorf("unauthorized to update this review")
+		return company.ErrNotReviewAuthor
 	}
 
 	// Update fields if provided
@@ -884,17 +1142,13 @@ func (s *companyService) UpdateReview(ctx context.Context, reviewID int64, userI
 	}
 
 	// Reset status to pending after update
-	review.Status = "pending"
+	review.Status = company.ReviewStatusPending
 
 	if err := s.companyRepo.UpdateReview(ctx, review); err != nil {
 		return fmt.Errorf("failed to update review: %w", err)
 	}
 
-	// Invalidate caches
-	s.cache.Delete(cache.GenerateCacheKey("company", "reviews", review.CompanyID))
-	s.cache.Delete(cache.GenerateCacheKey("company", "ratings", review.CompanyID))
-	s.cache.Delete(cache.GenerateCacheKey("company", "stats", review.CompanyID))
-	s.cache.DeletePattern("companies:top-rated:*")
+	s.reviewRatingsChanged(ctx, review.CompanyID)
 
 	return nil
 }
@@ -902,14 +1156,14 @@ func (s *companyService) UpdateReview(ctx context.Context, reviewID int64, userI
 // DeleteReview deletes a company review
 func (s *companyService) DeleteReview(ctx context.Context, reviewID, userID int64) error {
 	// Get review to verify ownership
-	review, err := s.companyRepo.FindReviewByID(ctx, reviewID)
+	review, err := s.findReview(ctx, reviewID)
 	if err != nil {
-		return fmt.Errorf("review not found: %w", err)
+		return err
 	}
 
 	// Verify ownership
 	if review.UserID == nil || *review.UserID != userID {
-		return fmt.Errorf("unauthorized to delete this review")
+		return company.ErrNotReviewAuthor
 	}
 
 	companyID := review.CompanyID
@@ -918,23 +1172,14 @@ func (s *companyService) DeleteReview(ctx context.Context, reviewID, userID int6
 		return fmt.Errorf("failed to delete review: %w", err)
 	}
 
-	// Invalidate caches
-	s.cache.Delete(cache.GenerateCacheKey("company", "reviews", companyID))
-	s.cache.Delete(cache.GenerateCacheKey("company", "ratings", companyID))
-	s.cache.Delete(cache.GenerateCacheKey("company", "stats", companyID))
-	s.cache.DeletePattern("companies:top-rated:*")
+	s.reviewRatingsChanged(ctx, companyID)
 
 	return nil
 }
 
 // GetReview retrieves a review by ID
 func (s *companyService) GetReview(ctx context.Context, reviewID int64) (*company.CompanyReview, error) {
-	review, err := s.companyRepo.FindReviewByID(ctx, reviewID)
-	if err != nil {
-		return nil, fmt.Errorf("review not found: %w", err)
-	}
-
-	return review, nil
+	return s.findReview(ctx, reviewID)
 }
 
 // GetCompanyReviews retrieves reviews for a company
@@ -957,78 +1202,674 @@ func (s *companyService) GetUserReviews(ctx context.Context, userID int64) ([]co
 	return reviews, nil
 }
 
-// GetAverageRatings retrieves average ratings for a company
-func (s *companyService) GetAverageRatings(ctx context.Context, companyID int64) (*company.AverageRatings, error) {
-	ratings, err := s.companyRepo.CalculateAverageRatings(ctx, companyID)
+// VoteReview records the user's helpful/not helpful vote on a review, replacing any previous vote
+func (s *companyService) VoteReview(ctx context.Context, reviewID, userID int64, vote string) (*company.CompanyReview, error) {
+	if vote != company.ReviewVoteHelpful && vote != company.ReviewVoteNotHelpful {
+		return nil, company.ErrInvalidReviewVote
+	}
+
+	review, err := s.findVotableReview(ctx, reviewID, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to calculate average ratings: %w", err)
+		return nil, err
 	}
 
-	return ratings, nil
+	if err := s.companyRepo.UpsertReviewVote(ctx, &company.ReviewVote{
+		ReviewID: reviewID,
+		UserID:   userID,
+		Vote:     vote,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to vote on review: %w", err)
+	}
+
+	s.invalidateReviewCaches(review.CompanyID)
+
+	return s.reloadReviewWithVote(ctx, reviewID, &vote)
 }
 
-// =============================================================================
-// Review Moderation (Admin Only)
-// =============================================================================
+// UnvoteReview removes the user's vote on a review
+func (s *companyService) UnvoteReview(ctx context.Context, reviewID, userID int64) (*company.CompanyReview, error) {
+	review, err := s.findVotableReview(ctx, reviewID, userID)
+	if err != nil {
+		return nil, err
+	}
 
-// ApproveReview approves a review
-func (s *companyService) ApproveReview(ctx context.Context, reviewID, moderatedBy int64) error {
-	if err := s.companyRepo.ApproveReview(ctx, reviewID, moderatedBy); err != nil {
-		return fmt.Errorf("failed to approve review: %w", err)
+	if err := s.companyRepo.DeleteReviewVote(ctx, reviewID, userID); err != nil {
+		return nil, fmt.Errorf("failed to remove review vote: %w", err)
 	}
 
-	return nil
+	s.invalidateReviewCaches(review.CompanyID)
+
+	return s.reloadReviewWithVote(ctx, reviewID, nil)
 }
 
-// RejectReview rejects a review
-func (s *companyService) RejectReview(ctx context.Context, reviewID, moderatedBy int64) error {
-	if err := s.companyRepo.RejectReview(ctx, reviewID, moderatedBy); err != nil {
-		return fmt.Errorf("failed to reject review: %w", err)
+// ReportReview files an abuse report and hides the review once it collects enough open reports
+func (s *companyService) ReportReview(ctx context.Context, reviewID, userID int64, reason string) error {
+	review, err := s.findVotableReview(ctx, reviewID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.companyRepo.CreateReviewReport(ctx, &company.ReviewReport{
+		ReviewID: reviewID,
+		UserID:   userID,
+		Reason:   reason,
+		Status:   "open",
+	}); err != nil {
+		if err == company.ErrReviewAlreadyReported {
+			return err
+		}
+		return fmt.Errorf("failed to report review: %w", err)
+	}
+
+	openReports, err := s.companyRepo.CountOpenReviewReports(ctx, reviewID)
+	if err != nil {
+		return fmt.Errorf("failed to count review reports: %w", err)
+	}
+
+	if openReports >= ReviewReportHideThreshold {
+		review.Status = company.ReviewStatusHidden
+		if err := s.companyRepo.UpdateReview(ctx, review); err != nil {
+			return fmt.Errorf("failed to hide review: %w", err)
+		}
+		s.reviewRatingsChanged(ctx, review.CompanyID)
 	}
 
 	return nil
 }
 
-// HideReview hides a review
-func (s *companyService) HideReview(ctx context.Context, reviewID, moderatedBy int64) error {
+// findVotableReview loads a review that the user may vote on or report
+func (s *companyService) findVotableReview(ctx context.Context, reviewID, userID int64) (*company.CompanyReview, error) {
 	review, err := s.companyRepo.FindReviewByID(ctx, reviewID)
 	if err != nil {
-		return fmt.Errorf("review not found: %w", err)
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+	if review == nil {
+		return nil, company.ErrReviewNotFound
+	}
+	if !review.IsApproved() {
+		return nil, company.ErrReviewNotPublic
+	}
+	if review.UserID != nil && *review.UserID == userID {
+		return nil, company.ErrOwnReview
 	}
 
-	review.Status = "hidden"
-	review.ModeratedBy = &moderatedBy
-	now := time.Now()
-	review.ModeratedAt = &now
+	return review, nil
+}
 
-	if err := s.companyRepo.UpdateReview(ctx, review); err != nil {
-		return fmt.Errorf("failed to hide review: %w", err)
+// reloadReviewWithVote returns the review with fresh vote counts and the caller's vote
+func (s *companyService) reloadReviewWithVote(ctx context.Context, reviewID int64, vote *string) (*company.CompanyReview, error) {
+	review, err := s.companyRepo.FindReviewByID(ctx, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+	if review == nil {
+		return nil, company.ErrReviewNotFound
 	}
+	review.MyVote = vote
 
-	return nil
+	return review, nil
 }
 
-// GetPendingReviews retrieves pending reviews for moderation
-func (s *companyService) GetPendingReviews(ctx context.Context, page, limit int) ([]company.CompanyReview, int64, error) {
-	// This is a simplified implementation - ideally would have a dedicated repo method
-	reviews := []company.CompanyReview{}
-	var total int64 = 0
+// invalidateReviewCaches drops cached review listings and rating aggregates for a company
+func (s *companyService) invalidateReviewCaches(companyID int64) {
+	s.cache.Delete(cache.GenerateCacheKey("company", "reviews", companyID))
+	s.cache.Delete(cache.GenerateCacheKey("company", "ratings", companyID))
+	s.cache.Delete(cache.GenerateCacheKey("company", "stats", companyID))
+	s.cache.DeletePattern("companies:top-rated:*")
+}
 
-	return reviews, total, nil
+// reviewRatingsChanged refreshes a company's rating summary after its reviews changed and
+// drops the caches that include its ratings. A failed refresh is left to the rating summary
+// job, which recomputes summaries older than their reviews.
+func (s *companyService) reviewRatingsChanged(ctx context.Context, companyID int64) {
+	_ = s.companyRepo.RefreshRatingSummary(ctx, companyID)
+	s.invalidateReviewCaches(companyID)
 }
 
-// =============================================================================
-// Document Management
-// =============================================================================
+// RefreshStaleRatingSummaries recomputes rating summaries that no longer match their reviews
+func (s *companyService) RefreshStaleRatingSummaries(ctx context.Context) (int64, error) {
+	refreshed, err := s.companyRepo.RefreshStaleRatingSummaries(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to refresh rating summaries: %w", err)
+	}
 
-// UploadDocument uploads a company document
-func (s *companyService) UploadDocument(ctx context.Context, companyID int64, file *multipart.FileHeader, req *company.UploadDocumentRequest) (*company.CompanyDocument, error) {
-	// Validate file
-	if err := s.uploadService.ValidateFile(file, DocumentTypes, MaxDocumentSize); err != nil {
-		return nil, fmt.Errorf("invalid document file: %w", err)
+	if refreshed > 0 {
+		s.cache.DeletePattern("company:ratings:*")
+		s.cache.DeletePattern("company:stats:*")
+		s.cache.DeletePattern("companies:top-rated:*")
 	}
 
-	// Upload file
+	return refreshed, nil
+}
+
+// GetAverageRatings retrieves average ratings for a company
+func (s *companyService) GetAverageRatings(ctx context.Context, companyID int64) (*company.AverageRatings, error) {
+	ratings, err := s.companyRepo.CalculateAverageRatings(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate average ratings: %w", err)
+	}
+
+	return ratings, nil
+}
+
+// =============================================================================
+// Review Responses
+// =============================================================================
+
+// AddReviewResponse posts the company's public response to an approved review and notifies
+// the review author. Each review has at most one response.
+func (s *companyService) AddReviewResponse(ctx context.Context, reviewID, userID int64, body string) (*company.ReviewResponse, error) {
+	body, err := sanitizeReviewResponse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	review, responder, err := s.findRespondableReview(ctx, reviewID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &company.ReviewResponse{
+		ReviewID:                review.ID,
+		CompanyID:               review.CompanyID,
+		ResponderEmployerUserID: &responder.ID,
+		Body:                    body,
+	}
+	if err := s.companyRepo.CreateReviewResponse(ctx, response); err != nil {
+		if errors.Is(err, company.ErrReviewResponseExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create review response: %w", err)
+	}
+
+	s.cache.Delete(cache.GenerateCacheKey("company", "reviews", review.CompanyID))
+	s.notifyReviewAuthor(ctx, review)
+
+	return response, nil
+}
+
+// UpdateReviewResponse edits the company's response to a review and records when it was edited
+func (s *companyService) UpdateReviewResponse(ctx context.Context, reviewID, userID int64, body string) (*company.ReviewResponse, error) {
+	body, err := sanitizeReviewResponse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	review, responder, err := s.findRespondableReview(ctx, reviewID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.findReviewResponse(ctx, review.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	response.Body = body
+	response.ResponderEmployerUserID = &responder.ID
+	response.EditedAt = &now
+	if err := s.companyRepo.UpdateReviewResponse(ctx, response); err != nil {
+		return nil, fmt.Errorf("failed to update review response: %w", err)
+	}
+
+	s.cache.Delete(cache.GenerateCacheKey("company", "reviews", review.CompanyID))
+
+	return response, nil
+}
+
+// DeleteReviewResponse removes the company's response to a review
+func (s *companyService) DeleteReviewResponse(ctx context.Context, reviewID, userID int64) error {
+	review, _, err := s.findRespondableReview(ctx, reviewID, userID)
+	if err != nil {
+		return err
+	}
+
+	response, err := s.findReviewResponse(ctx, review.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.companyRepo.DeleteReviewResponse(ctx, response.ID); err != nil {
+		return fmt.Errorf("failed to delete review response: %w", err)
+	}
+
+	s.cache.Delete(cache.GenerateCacheKey("company", "reviews", review.CompanyID))
+
+	return nil
+}
+
+// findRespondableReview loads an approved review together with the user's employer account,
+// which must be an active owner or admin of the reviewed company
+func (s *companyService) findRespondableReview(ctx context.Context, reviewID, userID int64) (*company.CompanyReview, *company.EmployerUser, error) {
+	review, err := s.findReview(ctx, reviewID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	employerUser, err := s.companyRepo.FindEmployerUserByUserAndCompany(ctx, userID, review.CompanyID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get employer user: %w", err)
+	}
+	if employerUser == nil || !employerUser.IsActive || (employerUser.Role != employer.RoleOwner && !company.IsAdmin(employerUser.Role)) {
+		return nil, nil, company.ErrReviewResponseForbidden
+	}
+
+	if !review.IsApproved() {
+		return nil, nil, company.ErrReviewNotPublic
+	}
+
+	return review, employerUser, nil
+}
+
+// findReviewResponse loads the response to a review, returning company.ErrReviewResponseNotFound
+// when the review has none
+func (s *companyService) findReviewResponse(ctx context.Context, reviewID int64) (*company.ReviewResponse, error) {
+	response, err := s.companyRepo.FindReviewResponseByReviewID(ctx, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review response: %w", err)
+	}
+	if response == nil {
+		return nil, company.ErrReviewResponseNotFound
+	}
+
+	return response, nil
+}
+
+// sanitizeReviewResponse checks the response length and escapes it for display
+func sanitizeReviewResponse(body string) (string, error) {
+	length := utf8.RuneCountInString(strings.TrimSpace(body))
+	if length == 0 || length > MaxReviewResponseLength {
+		return "", company.ErrInvalidReviewResponse
+	}
+
+	return utils.SanitizeString(body), nil
+}
+
+// notifyReviewAuthor tells the review author the company responded. Failures are logged
+// and never fail the response.
+func (s *companyService) notifyReviewAuthor(ctx context.Context, review *company.CompanyReview) {
+	if s.notifService == nil || review.UserID == nil {
+		return
+	}
+
+	companyName := ""
+	if comp, err := s.companyRepo.FindByID(ctx, review.CompanyID); err == nil && comp != nil {
+		companyName = comp.CompanyName
+	}
+
+	ctx = userLocaleContext(ctx, s.userRepo, *review.UserID)
+	if err := s.notifService.NotifyReviewResponse(ctx, *review.UserID, review.CompanyID, review.ID, companyName); err != nil {
+		fmt.Printf("[WARN] review %d: failed to send response notification: %v\n", review.ID, err)
+	}
+}
+
+// =============================================================================
+// Review Moderation (Admin Only)
+// =============================================================================
+
+// ApproveReview approves a review
+func (s *companyService) ApproveReview(ctx context.Context, reviewID, moderatedBy int64) error {
+	review, err := s.findReview(ctx, reviewID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.companyRepo.ApproveReview(ctx, reviewID, moderatedBy); err != nil {
+		return fmt.Errorf("failed to approve review: %w", err)
+	}
+
+	s.reviewRatingsChanged(ctx, review.CompanyID)
+	s.recordReviewModeration(ctx, review, moderatedBy, audit.ActionReviewApproved)
+
+	return nil
+}
+
+// RejectReview rejects a review
+func (s *companyService) RejectReview(ctx context.Context, reviewID, moderatedBy int64) error {
+	review, err := s.findReview(ctx, reviewID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.companyRepo.RejectReview(ctx, reviewID, moderatedBy); err != nil {
+		return fmt.Errorf("failed to reject review: %w", err)
+	}
+
+	s.reviewRatingsChanged(ctx, review.CompanyID)
+	s.recordReviewModeration(ctx, review, moderatedBy, audit.ActionReviewRejected)
+
+	return nil
+}
+
+// HideReview hides a review
+func (s *companyService) HideReview(ctx context.Context, reviewID, moderatedBy int64) error {
+	review, err := s.findReview(ctx, reviewID)
+	if err != nil {
+		return err
+	}
+
+	review.Status = company.ReviewStatusHidden
+	review.ModeratedBy = &moderatedBy
+	now := time.Now()
+	review.ModeratedAt = &now
+
+	if err := s.companyRepo.UpdateReview(ctx, review); err != nil {
+		return fmt.Errorf("failed to hide review: %w", err)
+	}
+
+	s.reviewRatingsChanged(ctx, review.CompanyID)
+	s.recordReviewModeration(ctx, review, moderatedBy, audit.ActionReviewHidden)
+
+	return nil
+}
+
+// recordReviewModeration records a moderator's action on a review in the audit log
+func (s *companyService) recordReviewModeration(ctx context.Context, review *company.CompanyReview, moderatedBy int64, action string) {
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		ActorID:    &moderatedBy,
+		ActorType:  audit.ActorAdmin,
+		Action:     action,
+		EntityType: audit.EntityReview,
+		EntityID:   review.ID,
+		Metadata:   audit.Metadata{"company_id": review.CompanyID},
+	})
+}
+
+// findReview loads a review, returning company.ErrReviewNotFound when it does not exist
+func (s *companyService) findReview(ctx context.Context, reviewID int64) (*company.CompanyReview, error) {
+	review, err := s.companyRepo.FindReviewByID(ctx, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+	if review == nil {
+		return nil, company.ErrReviewNotFound
+	}
+
+	return review, nil
+}
+
+// GetPendingReviews retrieves reviews for moderation, pending ones by default, oldest first.
+// Reviewer names of anonymous reviews are masked.
+func (s *companyService) GetPendingReviews(ctx context.Context, status string, filter *company.ReviewModerationFilter, page, limit int) ([]company.ModerationReview, int64, error) {
+	if status == "" {
+		status = company.ReviewStatusPending
+	}
+	if !company.IsValidReviewStatus(status) {
+		return nil, 0, company.ErrInvalidReviewStatus
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	reviews, total, err := s.companyRepo.GetReviewsByStatus(ctx, status, filter, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get reviews for moderation: %w", err)
+	}
+
+	for i := range reviews {
+		if reviews[i].IsAnonymous {
+			reviews[i].ReviewerName = maskReviewerName(reviews[i].ReviewerName)
+		}
+	}
+
+	return reviews, total, nil
+}
+
+// maskReviewerName keeps the first letter of each word, e.g. "John Doe" becomes "J*** D***"
+func maskReviewerName(name string) string {
+	words := strings.Fields(name)
+	if len(words) == 0 {
+		return "Anonymous"
+	}
+
+	for i, word := range words {
+		first, _ := utf8.DecodeRuneInString(word)
+		words[i] = string(first) + "***"
+	}
+
+	return strings.Join(words, " ")
+}
+
+// =============================================================================
+// Settings Management
+// =============================================================================
+
+// GetSettings retrieves company settings, falling back to defaults when none were saved
+func (s *companyService) GetSettings(ctx context.Context, companyID int64) (*company.CompanySettings, error) {
+	settings, err := s.companyRepo.FindSettings(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company settings: %w", err)
+	}
+	if settings == nil {
+		settings = company.DefaultCompanySettings(companyID)
+	}
+
+	return settings, nil
+}
+
+// UpdateSettings applies the provided fields on top of the current settings
+func (s *companyService) UpdateSettings(ctx context.Context, companyID int64, req *company.UpdateSettingsRequest) (*company.CompanySettings, error) {
+	settings, err := s.GetSettings(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.StageReminderEnabled != nil {
+		settings.StageReminderEnabled = *req.StageReminderEnabled
+	}
+	if req.StageReminderDays != nil {
+		settings.StageReminderDays = *req.StageReminderDays
+	}
+	if req.BlindScreeningEnabled != nil {
+		settings.BlindScreeningEnabled = *req.BlindScreeningEnabled
+	}
+	if req.WeeklyDigestEnabled != nil {
+		settings.WeeklyDigestEnabled = *req.WeeklyDigestEnabled
+	}
+	if req.AutoJoinEnabled != nil {
+		settings.AutoJoinEnabled = *req.AutoJoinEnabled
+	}
+	if req.AutoJoinRole != nil {
+		settings.AutoJoinRole = *req.AutoJoinRole
+	}
+
+	if err := s.companyRepo.UpsertSettings(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to update company settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// =============================================================================
+// Change Requests
+// =============================================================================
+
+// RequestCompanyChange records a request to change the company's industry, size or district.
+// Employers cannot edit these fields directly; an admin applies the request on approval.
+func (s *companyService) RequestCompanyChange(ctx context.Context, companyID, requestedBy int64, req *company.ChangeRequestInput) (*company.CompanyChangeRequest, error) {
+	if req.IndustryID == nil && req.CompanySizeID == nil && req.DistrictID == nil {
+		return nil, company.ErrChangeRequestEmpty
+	}
+
+	comp, err := s.companyRepo.FindByID(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	if comp == nil {
+		return nil, company.ErrCompanyNotFound
+	}
+
+	if err := s.ValidateMasterDataIDs(ctx, req.IndustryID, req.CompanySizeID, req.DistrictID, comp.DistrictID); err != nil {
+		return nil, company.ErrInvalidChangeRequestData.WithMessage(err.Error()).Wrap(err)
+	}
+
+	changeReq := &company.CompanyChangeRequest{
+		CompanyID:     companyID,
+		RequestedBy:   requestedBy,
+		IndustryID:    req.IndustryID,
+		CompanySizeID: req.CompanySizeID,
+		DistrictID:    req.DistrictID,
+		Reason:        req.Reason,
+		Status:        company.ChangeRequestPending,
+	}
+	if err := s.companyRepo.CreateChangeRequest(ctx, changeReq); err != nil {
+		if errors.Is(err, company.ErrChangeRequestPending) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create change request: %w", err)
+	}
+
+	return changeReq, nil
+}
+
+// GetChangeRequests returns the company's most recent change requests, newest first
+func (s *companyService) GetChangeRequests(ctx context.Context, companyID int64) ([]company.CompanyChangeRequest, error) {
+	requests, err := s.companyRepo.ListChangeRequestsByCompany(ctx, companyID, company.ChangeRequestHistoryLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get change requests: %w", err)
+	}
+	return requests, nil
+}
+
+// ListChangeRequests lists change requests for admins, pending ones by default
+func (s *companyService) ListChangeRequests(ctx context.Context, status string, page, limit int) ([]company.CompanyChangeRequest, int64, error) {
+	switch status {
+	case "":
+		status = company.ChangeRequestPending
+	case company.ChangeRequestPending, company.ChangeRequestApproved, company.ChangeRequestRejected:
+	default:
+		return nil, 0, company.ErrInvalidChangeRequestStatus
+	}
+
+	requests, total, err := s.companyRepo.ListChangeRequests(ctx, status, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list change requests: %w", err)
+	}
+	return requests, total, nil
+}
+
+// ApproveChangeRequest applies a pending change request to its company. Master data is
+// validated again because it may have been deactivated since the request was made.
+func (s *companyService) ApproveChangeRequest(ctx context.Context, requestID, decidedBy int64, note *string) error {
+	changeReq, err := s.findPendingChangeRequest(ctx, requestID)
+	if err != nil {
+		return err
+	}
+
+	comp, err := s.companyRepo.FindByID(ctx, changeReq.CompanyID)
+	if err != nil {
+		return fmt.Errorf("failed to get company: %w", err)
+	}
+	if comp == nil {
+		return company.ErrCompanyNotFound
+	}
+
+	if err := s.ValidateMasterDataIDs(ctx, changeReq.IndustryID, changeReq.CompanySizeID, changeReq.DistrictID, comp.DistrictID); err != nil {
+		return company.ErrInvalidChangeRequestData.WithMessage(err.Error()).Wrap(err)
+	}
+
+	change := company.CompanyMasterDataChange{
+		IndustryID:    changeReq.IndustryID,
+		CompanySizeID: changeReq.CompanySizeID,
+		DistrictID:    changeReq.DistrictID,
+	}
+	if changeReq.DistrictID != nil {
+		// ValidateDistrictID has checked that the city and province are present
+		district, err := s.districtService.GetByID(ctx, *changeReq.DistrictID)
+		if err != nil {
+			return fmt.Errorf("failed to get district: %w", err)
+		}
+		change.CityID = &district.City.ID
+		change.ProvinceID = &district.City.Province.ID
+	}
+
+	if err := s.companyRepo.ApproveChangeRequest(ctx, requestID, decidedBy, note, change); err != nil {
+		if errors.Is(err, company.ErrChangeRequestDecided) {
+			return err
+		}
+		return fmt.Errorf("failed to approve change request: %w", err)
+	}
+
+	employers, _ := s.companyRepo.GetEmployerUsersByCompanyID(ctx, comp.ID)
+	invalidateCompanyVisibilityCaches(s.cache, comp, employers)
+	s.recordChangeRequestDecision(ctx, changeReq, decidedBy, audit.ActionCompanyChangeApproved)
+
+	return nil
+}
+
+// RejectChangeRequest rejects a pending change request without touching the company
+func (s *companyService) RejectChangeRequest(ctx context.Context, requestID, decidedBy int64, note *string) error {
+	changeReq, err := s.findPendingChangeRequest(ctx, requestID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.companyRepo.RejectChangeRequest(ctx, requestID, decidedBy, note); err != nil {
+		if errors.Is(err, company.ErrChangeRequestDecided) {
+			return err
+		}
+		return fmt.Errorf("failed to reject change request: %w", err)
+	}
+
+	s.recordChangeRequestDecision(ctx, changeReq, decidedBy, audit.ActionCompanyChangeRejected)
+	return nil
+}
+
+// findPendingChangeRequest loads a change request that still awaits a decision
+func (s *companyService) findPendingChangeRequest(ctx context.Context, requestID int64) (*company.CompanyChangeRequest, error) {
+	changeReq, err := s.companyRepo.FindChangeRequestByID(ctx, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get change request: %w", err)
+	}
+	if changeReq == nil {
+		return nil, company.ErrChangeRequestNotFound
+	}
+	if !changeReq.IsPending() {
+		return nil, company.ErrChangeRequestDecided
+	}
+	return changeReq, nil
+}
+
+// recordChangeRequestDecision writes the audit entry for an admin's decision on a change request
+func (s *companyService) recordChangeRequestDecision(ctx context.Context, changeReq *company.CompanyChangeRequest, decidedBy int64, action string) {
+	metadata := audit.Metadata{"change_request_id": changeReq.ID}
+	if changeReq.IndustryID != nil {
+		metadata["industry_id"] = *changeReq.IndustryID
+	}
+	if changeReq.CompanySizeID != nil {
+		metadata["company_size_id"] = *changeReq.CompanySizeID
+	}
+	if changeReq.DistrictID != nil {
+		metadata["district_id"] = *changeReq.DistrictID
+	}
+
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		ActorID:    &decidedBy,
+		ActorType:  audit.ActorAdmin,
+		Action:     action,
+		EntityType: audit.EntityCompany,
+		EntityID:   changeReq.CompanyID,
+		Metadata:   metadata,
+	})
+}
+
+// =============================================================================
+// Document Management
+// =============================================================================
+
+// UploadDocument uploads a company document
+func (s *companyService) UploadDocument(ctx context.Context, companyID int64, file *multipart.FileHeader, req *company.UploadDocumentRequest) (*company.CompanyDocument, error) {
+	// Validate file
+	if err := s.uploadService.ValidateFile(file, DocumentTypes, s.uploadService.Limits().DocumentSize); err != nil {
+		return nil, fmt.Errorf("invalid document file: %w", err)
+	}
+
+	// Upload file
 	fileURL, err := s.uploadService.UploadFile(ctx, file, "company/documents")
 	if err != nil {
 		return nil, fmt.Errorf("failed to upload document: %w", err)
@@ -1145,6 +1986,30 @@ func (s *companyService) GetDocuments(ctx context.Context, companyID int64) ([]c
 	return docs, nil
 }
 
+// GetDocumentForDownload returns a company document to a platform admin or to an active owner
+// or admin of the company. Documents of other companies are reported as not found.
+func (s *companyService) GetDocumentForDownload(ctx context.Context, companyID, documentID, userID int64, userType string) (*company.CompanyDocument, error) {
+	if userType != "admin" {
+		employerUser, err := s.companyRepo.FindEmployerUserByUserAndCompany(ctx, userID, companyID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get employer user: %w", err)
+		}
+		if employerUser == nil || !employerUser.IsActive || (employerUser.Role != employer.RoleOwner && !company.IsAdmin(employerUser.Role)) {
+			return nil, company.ErrDocumentAccessDenied
+		}
+	}
+
+	doc, err := s.companyRepo.FindDocumentByID(ctx, documentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find document: %w", err)
+	}
+	if doc == nil || doc.CompanyID != companyID {
+		return nil, company.ErrDocumentNotFound
+	}
+
+	return doc, nil
+}
+
 // =============================================================================
 // Document Verification (Admin Only)
 // =============================================================================
@@ -1215,6 +2080,7 @@ func (s *companyService) AddEmployee(ctx context.Context, companyID int64, req *
 		CompanyID:        companyID,
 		UserID:           req.UserID,
 		FullName:         req.FullName,
+		Email:            req.Email,
 		JobTitle:         req.JobTitle,
 		Department:       req.Department,
 		EmploymentType:   req.EmploymentType,
@@ -1356,141 +2222,294 @@ func (s *companyService) GetEmployeeCount(ctx context.Context, companyID int64)
 // =============================================================================
 
 // InviteEmployer invites a user to be an employer with full invitation system
-func (s *companyService) InviteEmployer(ctx context.Context, req *company.InviteEmployerRequest) error {
+func (s *companyService) InviteEmployer(ctx context.Context, req *company.InviteEmployerRequest) (*company.InvitationResult, error) {
+	// Check if there's already a pending invitation for this email
+	pendingInvites, err := s.companyRepo.GetPendingInvitationsByEmail(ctx, req.Email)
+	if err == nil && len(pendingInvites) > 0 {
+		// Check if any pending invitation is for this company
+		for _, inv := range pendingInvites {
+			if inv.CompanyID == req.CompanyID && inv.Status == company.InvitationStatusPending && !inv.IsExpired() {
+				return nil, fmt.Errorf("invitation already sent to this email for this company")
+			}
+		}
+	}
+
+	// Generate invitation token (valid for 7 days)
+	token := utils.GenerateRandomToken(32)
+	expiresAt := time.Now().AddDate(0, 0, 7) // 7 days from now
+
+	fullName := req.FullName
+	if fullName == "" {
+		fullName = req.Email // Will be filled when accepting
+	}
+
+	// Create invitation record
+	invitation := &company.CompanyInvitation{
+		CompanyID: req.CompanyID,
+		Email:     req.Email,
+		FullName:  fullName,
+		Position:  req.PositionTitle,
+		Role:      req.Role,
+		Token:     token,
+		Status:    company.InvitationStatusPending,
+		InvitedBy: req.InvitedBy,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	// Save invitation to database
+	if err := s.companyRepo.CreateInvitation(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	// Invalidate invitation caches
+	s.cache.Delete(cache.GenerateCacheKey("company", "invitations", req.CompanyID))
+	s.cache.Delete(cache.GenerateCacheKey("user", "invitations", req.Email))
+
+	s.notifyInvitedUser(ctx, invitation)
+	return s.sendInvitationEmail(ctx, invitation), nil
+}
+
+// notifyInvitedUser adds the invitation to the invitee's notification feed when the
+// email already belongs to a user. Failures are logged and never fail the invitation.
+func (s *companyService) notifyInvitedUser(ctx context.Context, invitation *company.CompanyInvitation) {
+	if s.notifService == nil || s.userRepo == nil {
+		return
+	}
+
+	invitee, err := s.userRepo.FindByEmail(ctx, invitation.Email)
+	if err != nil || invitee == nil {
+		return
+	}
+
+	companyName := ""
+	if comp, err := s.companyRepo.FindByID(ctx, invitation.CompanyID); err == nil && comp != nil {
+		companyName = comp.CompanyName
+	}
+
+	ctx = userLocaleContext(ctx, s.userRepo, invitee.ID)
+	if err := s.notifService.NotifyInvitationReceived(ctx, invitee.ID, invitation.CompanyID, invitation.ID, companyName); err != nil {
+		fmt.Printf("[WARN] invitation %d: failed to send notification: %v\n", invitation.ID, err)
+	}
+}
+
+// sendInvitationEmail emails the invitation link. Send failures are reported in
+// the result instead of being returned so the saved invitation is kept.
+func (s *companyService) sendInvitationEmail(ctx context.Context, invitation *company.CompanyInvitation) *company.InvitationResult {
+	result := &company.InvitationResult{Invitation: invitation}
+
+	if s.emailService == nil {
+		result.EmailError = "email service is not configured"
+		return result
+	}
+
+	companyName := ""
+	if comp, err := s.companyRepo.FindByID(ctx, invitation.CompanyID); err == nil && comp != nil {
+		companyName = comp.CompanyName
+	}
+
+	inviterName := "Administrator" // Default fallback
+	if inviter, err := s.userRepo.FindByID(ctx, invitation.InvitedBy); err == nil && inviter != nil {
+		inviterName = inviter.FullName
+	}
+
+	position := ""
+	if invitation.Position != nil {
+		position = *invitation.Position
+	}
+
+	// Invitations are transactional, so the dispatcher always sends them
+	var inviteeID int64
+	if invitee, err := s.userRepo.FindByEmail(ctx, invitation.Email); err == nil && invitee != nil {
+		inviteeID = invitee.ID
+	}
+	if _, err := s.dispatcher.Email(ctx, inviteeID, user.NotificationEventTransactional, func(ctx context.Context) error {
+		return s.emailService.SendEmployerInvitationEmail(
+			ctx,
+			invitation.Email,
+			invitation.FullName,
+			companyName,
+			inviterName,
+			position,
+			invitation.Role,
+			invitation.Token,
+			invitation.ExpiresAt,
+		)
+	}); err != nil {
+		result.EmailError = err.Error()
+		return result
+	}
+
+	result.EmailSent = true
+	return result
+}
+
+// AcceptInvitation accepts an employer invitation
+func (s *companyService) AcceptInvitation(ctx context.Context, token string, userID int64) error {
+	invitation, err := s.findAcceptableInvitation(ctx, token)
+	if err != nil {
+		return err
+	}
+
 	// Check if user is already an employer for this company
-	existingEmployer, err := s.companyRepo.FindEmployerUserByUserAndCompany(ctx, 0, req.CompanyID)
+	existingEmployer, err := s.companyRepo.FindEmployerUserByUserAndCompany(ctx, userID, invitation.CompanyID)
 	if err == nil && existingEmployer != nil {
-		// Check by email if userID not found - need to implement email lookup
-		// For now, continue with invitation
+		return company.ErrAlreadyCompanyEmployer
+	}
+
+	employerUser := acceptInvitationAs(invitation, userID)
+
+	// Create the employer user and mark the invitation accepted in one transaction
+	if err := s.companyRepo.AcceptInvitation(ctx, invitation, employerUser); err != nil {
+		return fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	s.invalidateAcceptedInvitationCaches(invitation, userID)
+	return nil
+}
+
+// AcceptInvitationForSignup creates newUser together with its employer user in the
+// invitation's company, and marks the invitation accepted, in one transaction
+func (s *companyService) AcceptInvitationForSignup(ctx context.Context, token string, newUser *user.User) error {
+	invitation, err := s.findAcceptableInvitation(ctx, token)
+	if err != nil {
+		return err
 	}
 
-	// Check if there's already a pending invitation for this email
-	pendingInvites, err := s.companyRepo.GetPendingInvitationsByEmail(ctx, req.Email)
-	if err == nil && len(pendingInvites) > 0 {
-		// Check if any pending invitation is for this company
-		for _, inv := range pendingInvites {
-			if inv.CompanyID == req.CompanyID && inv.Status == "pending" && !inv.IsExpired() {
-				return fmt.Errorf("invitation already sent to this email for this company")
-			}
-		}
+	// The repository sets the user ID once the user is created
+	employerUser := acceptInvitationAs(invitation, 0)
+	if err := s.companyRepo.AcceptInvitationForNewUser(ctx, invitation, newUser, employerUser); err != nil {
+		return fmt.Errorf("failed to accept invitation: %w", err)
 	}
 
-	// Generate invitation token (valid for 7 days)
-	token := utils.GenerateRandomToken(32)
-	expiresAt := time.Now().AddDate(0, 0, 7) // 7 days from now
+	s.invalidateAcceptedInvitationCaches(invitation, newUser.ID)
+	return nil
+}
 
-	// Get authenticated user ID from request
-	invitedBy := req.CompanyID // Placeholder - should come from auth context
+// acceptInvitationAs marks the invitation accepted by userID and returns the employer user
+// it grants
+func acceptInvitationAs(invitation *company.CompanyInvitation, userID int64) *company.EmployerUser {
+	now := time.Now()
+	invitation.Status = company.InvitationStatusAccepted
+	invitation.AcceptedBy = &userID
+	invitation.AcceptedAt = &now
+	invitation.UpdatedAt = now
+
+	return &company.EmployerUser{
+		UserID:        userID,
+		CompanyID:     invitation.CompanyID,
+		Role:          invitation.Role,
+		PositionTitle: invitation.Position,
+		IsActive:      true,
+		IsVerified:    false, // Will be verified by admin/owner
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// invalidateAcceptedInvitationCaches drops the cached invitations and employers of the
+// company and the companies of the user who accepted
+func (s *companyService) invalidateAcceptedInvitationCaches(invitation *company.CompanyInvitation, userID int64) {
+	s.cache.Delete(cache.GenerateCacheKey("company", "invitations", invitation.CompanyID))
+	s.cache.Delete(cache.GenerateCacheKey("company", "employers", invitation.CompanyID))
+	s.cache.Delete(cache.GenerateCacheKey("user", "companies", userID))
+	s.cache.Delete(cache.GenerateCacheKey("user", "invitations", invitation.Email))
+}
 
-	// Create invitation record
-	invitation := &company.CompanyInvitation{
-		CompanyID: req.CompanyID,
-		Email:     req.Email,
-		FullName:  req.Email, // Will be filled when accepting
-		Position:  req.PositionTitle,
-		Role:      req.Role,
-		Token:     token,
-		Status:    "pending",
-		InvitedBy: invitedBy,
-		ExpiresAt: expiresAt,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+// CheckInvitationForSignup returns the invitation if it is pending, has not expired and was
+// sent to email, compared case-insensitively
+func (s *companyService) CheckInvitationForSignup(ctx context.Context, token, email string) (*company.CompanyInvitation, error) {
+	invitation, err := s.findAcceptableInvitation(ctx, token)
+	if err != nil {
+		return nil, err
 	}
 
-	// Save invitation to database
-	if err := s.companyRepo.CreateInvitation(ctx, invitation); err != nil {
-		return fmt.Errorf("failed to create invitation: %w", err)
+	if !strings.EqualFold(strings.TrimSpace(invitation.Email), strings.TrimSpace(email)) {
+		return nil, company.ErrInvitationEmailMismatch
 	}
 
-	// Invalidate invitation caches
-	s.cache.Delete(cache.GenerateCacheKey("company", "invitations", req.CompanyID))
-	s.cache.Delete(cache.GenerateCacheKey("user", "invitations", req.Email))
-
-	return nil
+	return invitation, nil
 }
 
-// AcceptInvitation accepts an employer invitation
-func (s *companyService) AcceptInvitation(ctx context.Context, token string, userID int64) error {
-	// Find invitation by token
+// findAcceptableInvitation finds the invitation by token and checks that it can still be
+// accepted. A pending invitation found past its expiry is marked expired.
+func (s *companyService) findAcceptableInvitation(ctx context.Context, token string) (*company.CompanyInvitation, error) {
 	invitation, err := s.companyRepo.FindInvitationByToken(ctx, token)
 	if err != nil {
-		return fmt.Errorf("invalid invitation token: %w", err)
+		return nil, fmt.Errorf("failed to find invitation: %w", err)
 	}
 
-	// Check if invitation is expired
-	if invitation.IsExpired() {
-		// Update status to expired
-		invitation.Status = "expired"
+	if invitation.IsPending() && invitation.IsExpired() {
+		invitation.Status = company.InvitationStatusExpired
 		_ = s.companyRepo.UpdateInvitation(ctx, invitation)
-		return fmt.Errorf("invitation has expired")
 	}
 
-	// Check if invitation is still pending
-	if invitation.Status != "pending" {
-		return fmt.Errorf("invitation is no longer available (status: %s)", invitation.Status)
+	switch invitation.Status {
+	case company.InvitationStatusPending:
+		return invitation, nil
+	case company.InvitationStatusExpired:
+		return nil, company.ErrInvitationExpired
+	default:
+		return nil, company.ErrInvitationUnavailable
 	}
+}
 
-	// Execute in transaction
-	err = s.db.Transaction(func(tx *gorm.DB) error {
-		// Check if user is already an employer for this company
-		existingEmployer, err := s.companyRepo.FindEmployerUserByUserAndCompany(ctx, userID, invitation.CompanyID)
-		if err == nil && existingEmployer != nil {
-			return fmt.Errorf("you are already an employer for this company")
-		}
+// GetInvitationDetails returns the company, role and inviter of an invitation. Expired and
+// already answered invitations are returned too, with their status, so the link can explain
+// why it no longer works.
+func (s *companyService) GetInvitationDetails(ctx context.Context, token string) (*company.InvitationDetails, error) {
+	invitation, err := s.companyRepo.FindInvitationByToken(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find invitation: %w", err)
+	}
 
-		// Create employer user
-		now := time.Now()
-		employerUser := &company.EmployerUser{
-			UserID:        userID,
-			CompanyID:     invitation.CompanyID,
-			Role:          invitation.Role,
-			PositionTitle: invitation.Position,
-			IsActive:      true,
-			IsVerified:    false, // Will be verified by admin/owner
-			CreatedAt:     now,
-			UpdatedAt:     now,
-		}
+	status := invitation.Status
+	if invitation.IsPending() && invitation.IsExpired() {
+		status = company.InvitationStatusExpired
+	}
 
-		if err := tx.Create(employerUser).Error; err != nil {
-			return fmt.Errorf("failed to create employer user: %w", err)
-		}
+	details := &company.InvitationDetails{
+		Email:       invitation.Email,
+		FullName:    invitation.FullName,
+		CompanyID:   invitation.CompanyID,
+		Role:        invitation.Role,
+		Position:    invitation.Position,
+		InviterName: "Administrator",
+		Status:      status,
+		ExpiresAt:   invitation.ExpiresAt,
+		IsExpired:   status == company.InvitationStatusExpired,
+	}
 
-		// Update invitation status
-		invitation.Status = "accepted"
-		invitation.AcceptedBy = &userID
-		invitation.AcceptedAt = &now
-		invitation.UpdatedAt = now
+	comp := invitation.Company
+	if comp == nil {
+		comp, _ = s.companyRepo.FindByID(ctx, invitation.CompanyID)
+	}
+	if comp != nil {
+		details.CompanyName = comp.CompanyName
+		details.CompanyLogoURL = comp.LogoURL
+	}
 
-		if err := tx.Save(invitation).Error; err != nil {
-			return fmt.Errorf("failed to update invitation: %w", err)
+	if s.userRepo != nil {
+		if inviter, err := s.userRepo.FindByID(ctx, invitation.InvitedBy); err == nil && inviter != nil {
+			details.InviterName = inviter.FullName
 		}
-
-		return nil
-	})
-
-	if err != nil {
-		return err
 	}
 
-	// Invalidate caches
-	s.cache.Delete(cache.GenerateCacheKey("company", "invitations", invitation.CompanyID))
-	s.cache.Delete(cache.GenerateCacheKey("company", "employers", invitation.CompanyID))
-	s.cache.Delete(cache.GenerateCacheKey("user", "companies", userID))
-
-	return nil
+	return details, nil
 }
 
-// ResendInvitation resends an invitation email
-func (s *companyService) ResendInvitation(ctx context.Context, invitationID, requestedBy int64) error {
+// ResendInvitation regenerates the invitation token and resends the invitation email
+func (s *companyService) ResendInvitation(ctx context.Context, invitationID, requestedBy int64) (*company.InvitationResult, error) {
 	// Get invitation
 	invitation, err := s.companyRepo.FindInvitationByID(ctx, invitationID)
 	if err != nil {
-		return fmt.Errorf("invitation not found: %w", err)
+		return nil, fmt.Errorf("invitation not found: %w", err)
 	}
 
 	// Check if invitation is still valid for resend
-	if invitation.Status != "pending" {
-		return fmt.Errorf("can only resend pending invitations")
+	if invitation.Status != company.InvitationStatusPending {
+		return nil, fmt.Errorf("can only resend pending invitations")
 	}
 
 	// Generate new token and extend expiry
@@ -1500,13 +2519,13 @@ func (s *companyService) ResendInvitation(ctx context.Context, invitationID, req
 
 	// Update invitation
 	if err := s.companyRepo.UpdateInvitation(ctx, invitation); err != nil {
-		return fmt.Errorf("failed to update invitation: %w", err)
+		return nil, fmt.Errorf("failed to update invitation: %w", err)
 	}
 
 	// Invalidate cache
 	s.cache.Delete(cache.GenerateCacheKey("company", "invitations", invitation.CompanyID))
 
-	return nil
+	return s.sendInvitationEmail(ctx, invitation), nil
 }
 
 // CancelInvitation cancels a pending invitation
@@ -1538,8 +2557,8 @@ func (s *companyService) CancelInvitation(ctx context.Context, invitationID, can
 func (s *companyService) GetPendingInvitations(ctx context.Context, companyID int64) ([]company.CompanyInvitation, error) {
 	// Try cache first
 	cacheKey := cache.GenerateCacheKey("company", "invitations", companyID)
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		return cached.([]company.CompanyInvitation), nil
+	if cached, ok := cache.GetTyped[[]company.CompanyInvitation](s.cache, cacheKey); ok {
+		return cached, nil
 	}
 
 	// Get from database
@@ -1558,8 +2577,8 @@ func (s *companyService) GetPendingInvitations(ctx context.Context, companyID in
 func (s *companyService) GetUserPendingInvitations(ctx context.Context, email string) ([]company.CompanyInvitation, error) {
 	// Try cache first
 	cacheKey := cache.GenerateCacheKey("user", "invitations", email)
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		return cached.([]company.CompanyInvitation), nil
+	if cached, ok := cache.GetTyped[[]company.CompanyInvitation](s.cache, cacheKey); ok {
+		return cached, nil
 	}
 
 	// Get from database
@@ -1582,15 +2601,16 @@ func (s *companyService) UpdateEmployerRole(ctx context.Context, employerUserID
 	}
 
 	// Don't allow changing owner role
-	if employerUser.Role == "owner" {
+	if employerUser.Role == employer.RoleOwner {
 		return fmt.Errorf("cannot change owner role, use transfer ownership instead")
 	}
 
 	// Don't allow setting owner role
-	if newRole == "owner" {
+	if newRole == employer.RoleOwner {
 		return fmt.Errorf("cannot set owner role, use transfer ownership instead")
 	}
 
+	oldRole := employerUser.Role
 	employerUser.Role = newRole
 	employerUser.UpdatedAt = time.Now()
 
@@ -1598,6 +2618,18 @@ func (s *companyService) UpdateEmployerRole(ctx context.Context, employerUserID
 		return fmt.Errorf("failed to update role: %w", err)
 	}
 
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		Action:     audit.ActionEmployerRoleUpdated,
+		EntityType: audit.EntityEmployerUser,
+		EntityID:   employerUserID,
+		Metadata: audit.Metadata{
+			"company_id": employerUser.CompanyID,
+			"user_id":    employerUser.UserID,
+			"old_role":   oldRole,
+			"new_role":   newRole,
+		},
+	})
+
 	// Invalidate cache
 	s.cache.Delete(cache.GenerateCacheKey("company", "employers", employerUser.CompanyID))
 	s.cache.Delete(cache.GenerateCacheKey("user", "companies", employerUser.UserID))
@@ -1615,6 +2647,9 @@ func (s *companyService) UpdateEmployerUser(ctx context.Context, userID, company
 	if employerUser == nil {
 		return fmt.Errorf("employer user not found for this company")
 	}
+	if req.Version != nil && *req.Version != employerUser.Version {
+		return company.ErrEmployerUserVersionConflict.WithCurrentVersion(employerUser.Version)
+	}
 
 	// Apply updates only for provided fields
 	if req.PositionTitle != nil {
@@ -1661,6 +2696,9 @@ func (s *companyService) UpdateEmployerUserWithProfile(ctx context.Context, user
 
 		// Apply employer_user updates if provided
 		if req != nil {
+			if req.Version != nil && *req.Version != emp.Version {
+				return company.ErrEmployerUserVersionConflict.WithCurrentVersion(emp.Version)
+			}
 			if req.PositionTitle != nil {
 				emp.PositionTitle = req.PositionTitle
 			}
@@ -1751,7 +2789,7 @@ func (s *companyService) RemoveEmployerUser(ctx context.Context, employerUserID,
 	}
 
 	// Don't allow removing owner
-	if employerUser.Role == "owner" {
+	if employerUser.Role == employer.RoleOwner {
 		return fmt.Errorf("cannot remove company owner, transfer ownership first")
 	}
 
@@ -1759,6 +2797,17 @@ func (s *companyService) RemoveEmployerUser(ctx context.Context, employerUserID,
 		return fmt.Errorf("failed to remove employer user: %w", err)
 	}
 
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		Action:     audit.ActionEmployerRemoved,
+		EntityType: audit.EntityEmployerUser,
+		EntityID:   employerUserID,
+		Metadata: audit.Metadata{
+			"company_id": companyID,
+			"user_id":    employerUser.UserID,
+			"role":       employerUser.Role,
+		},
+	})
+
 	// Invalidate caches
 	s.cache.Delete(cache.GenerateCacheKey("company", "employers", companyID))
 	s.cache.Delete(cache.GenerateCacheKey("user", "companies", employerUser.UserID))
@@ -1770,8 +2819,8 @@ func (s *companyService) RemoveEmployerUser(ctx context.Context, employerUserID,
 func (s *companyService) GetEmployerUsers(ctx context.Context, companyID int64) ([]company.EmployerUser, error) {
 	// Try cache first
 	cacheKey := cache.GenerateCacheKey("company", "employers", companyID)
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		return cached.([]company.EmployerUser), nil
+	if cached, ok := cache.GetTyped[[]company.EmployerUser](s.cache, cacheKey); ok {
+		return cached, nil
 	}
 
 	// Get from database
@@ -1790,8 +2839,8 @@ func (s *companyService) GetEmployerUsers(ctx context.Context, companyID int64)
 func (s *companyService) GetUserCompanies(ctx context.Context, userID int64) ([]company.Company, error) {
 	// Try cache first
 	cacheKey := cache.GenerateCacheKey("user", "companies", userID)
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		return cached.([]company.Company), nil
+	if cached, ok := cache.GetTyped[[]company.Company](s.cache, cacheKey); ok {
+		return cached, nil
 	}
 
 	// Get from database
@@ -1810,8 +2859,11 @@ func (s *companyService) GetUserCompanies(ctx context.Context, userID int64) ([]
 func (s *companyService) CreateCompanyAddress(ctx context.Context, companyID int64, req *company.CreateCompanyAddressRequest) (*company.CompanyAddress, error) {
 	// Ensure company exists
 	comp, err := s.companyRepo.FindByID(ctx, companyID)
-	if err != nil || comp == nil {
-		return nil, fmt.Errorf("company not found: %w", err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	if comp == nil {
+		return nil, company.ErrCompanyNotFound
 	}
 
 	addr := &company.CompanyAddress{
@@ -1825,6 +2877,9 @@ func (s *companyService) CreateCompanyAddress(ctx context.Context, companyID int
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
+	if addr.Latitude == nil || addr.Longitude == nil {
+		s.geocodeAddress(ctx, addr)
+	}
 
 	if err := s.companyRepo.CreateCompanyAddress(ctx, addr); err != nil {
 		return nil, fmt.Errorf("failed to create company address: %w", err)
@@ -1833,12 +2888,55 @@ func (s *companyService) CreateCompanyAddress(ctx context.Context, companyID int
 	return addr, nil
 }
 
+// addressGeocodeTimeout bounds the geocoding lookup done while saving an address
+const addressGeocodeTimeout = 5 * time.Second
+
+// geocodeAddress fills the address coordinates from its full address and district.
+// Failures are logged and leave the address without coordinates, never failing the save.
+func (s *companyService) geocodeAddress(ctx context.Context, addr *company.CompanyAddress) {
+	addr.GeocodeProvider = nil
+	addr.GeocodeConfidence = nil
+	if s.geocoder == nil {
+		return
+	}
+
+	query := addr.FullAddress
+	if addr.DistrictID != nil && s.districtService != nil {
+		if district, err := s.districtService.GetByID(ctx, *addr.DistrictID); err == nil && district != nil {
+			location := district.FullLocationPath
+			if location == "" {
+				location = district.Name
+			}
+			query += ", " + location
+		}
+	}
+
+	geoCtx, cancel := context.WithTimeout(ctx, addressGeocodeTimeout)
+	defer cancel()
+
+	result, err := s.geocoder.Geocode(geoCtx, query)
+	if err != nil {
+		if !errors.Is(err, ErrGeocodeNoResult) {
+			fmt.Printf("[WARN] failed to geocode address for company %d: %v\n", addr.CompanyID, err)
+		}
+		return
+	}
+
+	addr.Latitude = &result.Latitude
+	addr.Longitude = &result.Longitude
+	addr.GeocodeProvider = &result.Provider
+	addr.GeocodeConfidence = &result.Confidence
+}
+
 // GetCompanyAddresses returns company addresses; includeDeleted toggles returning soft-deleted rows
 func (s *companyService) GetCompanyAddresses(ctx context.Context, companyID int64, includeDeleted bool) ([]company.CompanyAddress, error) {
 	// Ensure company exists
 	comp, err := s.companyRepo.FindByID(ctx, companyID)
-	if err != nil || comp == nil {
-		return nil, fmt.Errorf("company not found: %w", err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	if comp == nil {
+		return nil, company.ErrCompanyNotFound
 	}
 
 	addrs, err := s.companyRepo.GetCompanyAddressesByCompanyID(ctx, companyID, includeDeleted)
@@ -1895,6 +2993,9 @@ func (s *companyService) UpdateCompanyAddress(ctx context.Context, companyID, ad
 		return nil, fmt.Errorf("unauthorized to update this address")
 	}
 
+	moved := (req.FullAddress != nil && *req.FullAddress != addr.FullAddress) ||
+		(req.DistrictID != nil && (addr.DistrictID == nil || *req.DistrictID != *addr.DistrictID))
+
 	// Apply updates only for provided fields
 	if req.FullAddress != nil {
 		addr.FullAddress = *req.FullAddress
@@ -1915,6 +3016,17 @@ func (s *companyService) UpdateCompanyAddress(ctx context.Context, companyID, ad
 		addr.DistrictID = req.DistrictID
 	}
 
+	// Client-supplied coordinates win; otherwise re-geocode a moved address so its
+	// coordinates never describe the old location
+	if req.Latitude != nil || req.Longitude != nil {
+		addr.GeocodeProvider = nil
+		addr.GeocodeConfidence = nil
+	} else if moved {
+		addr.Latitude = nil
+		addr.Longitude = nil
+		s.geocodeAddress(ctx, addr)
+	}
+
 	addr.UpdatedAt = time.Now()
 
 	if err := s.companyRepo.UpdateCompanyAddress(ctx, addr); err != nil {
@@ -1941,92 +3053,68 @@ func (s *companyService) CheckEmployerPermission(ctx context.Context, userID, co
 	}
 
 	// Role hierarchy: owner > admin > recruiter > viewer
-	roleLevel := map[string]int{
-		"owner":     4,
-		"admin":     3,
-		"recruiter": 2,
-		"viewer":    1,
-	}
-
-	userLevel := roleLevel[employerUser.Role]
-	requiredLevel := roleLevel[requiredRole]
-
-	return userLevel >= requiredLevel, nil
+	return company.HasHigherRole(employerUser.Role, requiredRole), nil
 }
 
 // =============================================================================
 // Verification Management
 // =============================================================================
 
-// RequestVerification requests company verification
-func (s *companyService) RequestVerification(ctx context.Context, companyID, requestedBy int64, npwpNumber string, nibNumber *string, npwpFile *multipart.FileHeader, additionalFiles []*multipart.FileHeader) error {
+// RequestVerification requests company verification. The documents are uploaded first and
+// all database work then runs in one transaction; if anything fails, the files uploaded by
+// this request are deleted again so they are not left orphaned in storage.
+func (s *companyService) RequestVerification(ctx context.Context, companyID, requestedBy int64, npwpNumber string, nibNumber *string, npwpFile, nibFile *multipart.FileHeader, additionalFiles []*multipart.FileHeader) (err error) {
 	// Validate NPWP number is required
 	if npwpNumber == "" {
 		return fmt.Errorf("npwp_number is required")
 	}
-
-	// Upload NPWP document first
-	var npwpDocPath string
-	if npwpFile != nil {
-		filePath, err := s.uploadService.UploadFile(ctx, npwpFile, "documents/npwp")
-		if err != nil {
-			return fmt.Errorf("failed to upload NPWP document: %w", err)
-		}
-		npwpDocPath = filePath
-	} else {
+	if npwpFile == nil {
 		return fmt.Errorf("npwp_file is required")
 	}
 
-	// Start transaction
-	tx := s.db.WithContext(ctx).Begin()
-	if tx.Error != nil {
-		return fmt.Errorf("failed to start transaction: %w", tx.Error)
-	}
+	var uploaded []string
 	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+		if err != nil {
+			s.deleteUploadedFiles(ctx, uploaded)
 		}
 	}()
+	upload := func(file *multipart.FileHeader, directory string) (string, error) {
+		filePath, err := s.uploadService.UploadFile(ctx, file, directory)
+		if err != nil {
+			return "", err
+		}
+		uploaded = append(uploaded, filePath)
+		return filePath, nil
+	}
 
-	// Create or update verification record with NPWP and NIB
-	verification, err := s.companyRepo.FindVerificationByCompanyID(ctx, companyID)
-
+	npwpDocPath, err := upload(npwpFile, "documents/npwp")
 	if err != nil {
-		// Real error occurred
-		tx.Rollback()
-		return fmt.Errorf("failed to find verification: %w", err)
+		return fmt.Errorf("failed to upload NPWP document: %w", err)
 	}
 
-	if verification == nil {
-		// Create new verification record (no record exists)
-		verification = &company.CompanyVerification{
-			CompanyID:   companyID,
-			RequestedBy: &requestedBy,
-			Status:      "pending",
-			NPWPNumber:  npwpNumber,
-			NIBNumber:   nibNumber,
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
+	var nibDocPath string
+	if nibFile != nil {
+		if nibDocPath, err = upload(nibFile, "documents/nib"); err != nil {
+			return fmt.Errorf("failed to upload NIB document: %w", err)
 		}
-		if err := tx.Create(verification).Error; err != nil {
-			tx.Rollback()
-			return fmt.Errorf("failed to create verification: %w", err)
+	}
+
+	var additionalPaths []string
+	for i, file := range additionalFiles {
+		if i >= 5 { // Max 5 files
+			break
 		}
-	} else {
-		// Update existing verification
-		verification.Status = "pending"
-		verification.NPWPNumber = npwpNumber
-		verification.NIBNumber = nibNumber
-		verification.RequestedBy = &requestedBy
-		verification.UpdatedAt = time.Now()
-		if err := tx.Save(verification).Error; err != nil {
-			tx.Rollback()
-			return fmt.Errorf("failed to update verification: %w", err)
+		filePath, uploadErr := upload(file, "documents/additional")
+		if uploadErr != nil {
+			// Continue with other files even if one fails
+			fmt.Printf("[WARN] verification request: failed to upload additional document %q: %v\n", file.Filename, uploadErr)
+			continue
 		}
+		additionalPaths = append(additionalPaths, filePath)
 	}
 
-	// Save NPWP document to company_documents
-	npwpDocument := &company.CompanyDocument{
+	now := time.Now()
+	documents := []company.CompanyDocument{{
 		CompanyID:      companyID,
 		UploadedBy:     &requestedBy,
 		DocumentType:   "NPWP",
@@ -2035,69 +3123,77 @@ func (s *companyService) RequestVerification(ctx context.Context, companyID, req
 		FilePath:       npwpDocPath,
 		Status:         "pending",
 		IsActive:       true,
-		CreatedAt:      time.Now(),
-		UpdatedAt:      time.Now(),
-	}
-	if err := tx.Create(npwpDocument).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to save NPWP document: %w", err)
-	}
-
-	// Save NIB document if NIB number provided
-	if nibNumber != nil && *nibNumber != "" {
-		// Check if NIB file exists in additional documents (optional)
-		// For now, we just save the NIB number without requiring document
-		nibDocument := &company.CompanyDocument{
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}}
+	if nibDocPath != "" {
+		documents = append(documents, company.CompanyDocument{
 			CompanyID:      companyID,
 			UploadedBy:     &requestedBy,
 			DocumentType:   "NIB",
 			DocumentNumber: nibNumber,
 			DocumentName:   utils.StringPtr("Nomor Induk Berusaha"),
-			FilePath:       "", // NIB is optional, may not have file
+			FilePath:       nibDocPath,
 			Status:         "pending",
 			IsActive:       true,
-			CreatedAt:      time.Now(),
-			UpdatedAt:      time.Now(),
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		})
+	}
+	for i, filePath := range additionalPaths {
+		documents = append(documents, company.CompanyDocument{
+			CompanyID:    companyID,
+			UploadedBy:   &requestedBy,
+			DocumentType: "LAINNYA",
+			DocumentName: utils.StringPtr(fmt.Sprintf("Dokumen Tambahan %d", i+1)),
+			FilePath:     filePath,
+			Status:       "pending",
+			IsActive:     true,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		})
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Create or update verification record with NPWP and NIB
+		verification, err := s.companyRepo.FindVerificationByCompanyIDTx(ctx, tx, companyID)
+		if err != nil {
+			return fmt.Errorf("failed to find verification: %w", err)
 		}
-		// Only create if FilePath is provided or we can skip this
-		// For now, skip if no file
-		_ = nibDocument
-	}
-
-	// Save additional documents
-	if len(additionalFiles) > 0 {
-		for i, file := range additionalFiles {
-			if i >= 5 { // Max 5 files
-				break
-			}
 
-			filePath, err := s.uploadService.UploadFile(ctx, file, "documents/additional")
-			if err != nil {
-				// Continue with other files even if one fails
-				continue
+		if verification == nil {
+			verification = &company.CompanyVerification{
+				CompanyID:   companyID,
+				RequestedBy: &requestedBy,
+				Status:      company.VerificationStatusPending,
+				NPWPNumber:  npwpNumber,
+				NIBNumber:   nibNumber,
+				CreatedAt:   now,
+				UpdatedAt:   now,
 			}
-
-			additionalDoc := &company.CompanyDocument{
-				CompanyID:    companyID,
-				UploadedBy:   &requestedBy,
-				DocumentType: "LAINNYA",
-				DocumentName: utils.StringPtr(fmt.Sprintf("Dokumen Tambahan %d", i+1)),
-				FilePath:     filePath,
-				Status:       "pending",
-				IsActive:     true,
-				CreatedAt:    time.Now(),
-				UpdatedAt:    time.Now(),
+			if err := s.companyRepo.CreateVerificationTx(ctx, tx, verification); err != nil {
+				return fmt.Errorf("failed to create verification: %w", err)
 			}
-			if err := tx.Create(additionalDoc).Error; err != nil {
-				// Continue with other files
-				continue
+		} else {
+			verification.Status = company.VerificationStatusPending
+			verification.NPWPNumber = npwpNumber
+			verification.NIBNumber = nibNumber
+			verification.RequestedBy = &requestedBy
+			verification.UpdatedAt = now
+			if err := s.companyRepo.UpdateVerificationTx(ctx, tx, verification); err != nil {
+				return fmt.Errorf("failed to update verification: %w", err)
 			}
 		}
-	}
 
-	// Commit transaction
-	if err := tx.Commit().Error; err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		for i := range documents {
+			if err := s.companyRepo.CreateDocumentTx(ctx, tx, &documents[i]); err != nil {
+				return fmt.Errorf("failed to save %s document: %w", documents[i].DocumentType, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// Invalidate cache
@@ -2119,6 +3215,17 @@ func (s *companyService) RequestVerification(ctx context.Context, companyID, req
 	return nil
 }
 
+// deleteUploadedFiles removes files uploaded by a request that failed. It runs even when
+// ctx was cancelled, and a file that cannot be deleted is only logged.
+func (s *companyService) deleteUploadedFiles(ctx context.Context, filePaths []string) {
+	ctx = context.WithoutCancel(ctx)
+	for _, filePath := range filePaths {
+		if err := s.uploadService.DeleteFile(ctx, filePath); err != nil {
+			fmt.Printf("[WARN] failed to delete orphaned upload %s: %v\n", filePath, err)
+		}
+	}
+}
+
 // GetVerificationStatus retrieves verification status
 func (s *companyService) GetVerificationStatus(ctx context.Context, companyID int64) (*company.CompanyVerification, error) {
 	verification, err := s.companyRepo.FindVerificationByCompanyID(ctx, companyID)
@@ -2138,7 +3245,10 @@ func (s *companyService) ApproveVerification(ctx context.Context, companyID, rev
 	// Update company verified status
 	comp, err := s.companyRepo.FindByID(ctx, companyID)
 	if err != nil {
-		return fmt.Errorf("company not found: %w", err)
+		return fmt.Errorf("failed to get company: %w", err)
+	}
+	if comp == nil {
+		return company.ErrCompanyNotFound
 	}
 
 	comp.Verified = true
@@ -2150,6 +3260,19 @@ func (s *companyService) ApproveVerification(ctx context.Context, companyID, rev
 		return fmt.Errorf("failed to update company: %w", err)
 	}
 
+	// Invalidate cache
+	s.cache.Delete(cache.GenerateCacheKey("company", "detail", companyID))
+	s.cache.Delete(cache.GenerateCacheKey("company", "stats", companyID))
+
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		ActorID:    &reviewedBy,
+		ActorType:  audit.ActorAdmin,
+		Action:     audit.ActionVerificationApproved,
+		EntityType: audit.EntityCompany,
+		EntityID:   companyID,
+		Metadata:   audit.Metadata{"notes": notes},
+	})
+
 	return nil
 }
 
@@ -2159,6 +3282,15 @@ func (s *companyService) RejectVerification(ctx context.Context, companyID, revi
 		return fmt.Errorf("failed to reject verification: %w", err)
 	}
 
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		ActorID:    &reviewedBy,
+		ActorType:  audit.ActorAdmin,
+		Action:     audit.ActionVerificationRejected,
+		EntityType: audit.EntityCompany,
+		EntityID:   companyID,
+		Metadata:   audit.Metadata{"reason": reason},
+	})
+
 	return nil
 }
 
@@ -2189,6 +3321,8 @@ func (s *companyService) RenewVerification(ctx context.Context, companyID int64)
 	}
 
 	verification.AutoExpired = false
+	verification.GraceUntil = nil
+	verification.ExpiryWarningDays = nil
 	now := time.Now()
 	verification.LastChecked = &now
 
@@ -2199,37 +3333,6 @@ func (s *companyService) RenewVerification(ctx context.Context, companyID int64)
 	return nil
 }
 
-// CheckVerificationExpiry checks and updates expired verifications
-func (s *companyService) CheckVerificationExpiry(ctx context.Context) error {
-	// Get companies needing renewal
-	companies, err := s.companyRepo.GetCompaniesNeedingVerificationRenewal(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to get companies: %w", err)
-	}
-
-	now := time.Now()
-	for _, comp := range companies {
-		verification, err := s.companyRepo.FindVerificationByCompanyID(ctx, comp.ID)
-		if err != nil {
-			continue
-		}
-
-		if verification.VerificationExpiry != nil && verification.VerificationExpiry.Before(now) {
-			verification.Status = "expired"
-			verification.AutoExpired = true
-			verification.LastChecked = &now
-
-			_ = s.companyRepo.UpdateVerification(ctx, verification)
-
-			// Update company verified status
-			comp.Verified = false
-			_ = s.companyRepo.Update(ctx, &comp)
-		}
-	}
-
-	return nil
-}
-
 // =============================================================================
 // Industry Management (Admin Only)
 // =============================================================================
@@ -2325,12 +3428,21 @@ func (s *companyService) GetIndustryTree(ctx context.Context) ([]company.Company
 // Analytics and Stats
 // =============================================================================
 
-// GetCompanyStats retrieves comprehensive company statistics
+// GetCompanyStats retrieves comprehensive company statistics (with caching)
 func (s *companyService) GetCompanyStats(ctx context.Context, companyID int64) (*company.CompanyStats, error) {
+	// Try cache first
+	cacheKey := cache.GenerateCacheKey("company", "stats", companyID)
+	if cached, ok := cache.GetTyped[*company.CompanyStats](s.cache, cacheKey); ok {
+		return cached, nil
+	}
+
 	// Get basic company info
 	comp, err := s.companyRepo.GetFullCompanyProfile(ctx, companyID)
 	if err != nil {
-		return nil, fmt.Errorf("company not found: %w", err)
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	if comp == nil {
+		return nil, company.ErrCompanyNotFound
 	}
 
 	// Get follower count
@@ -2342,16 +3454,28 @@ func (s *companyService) GetCompanyStats(ctx context.Context, companyID int64) (
 	// Get average ratings
 	ratings, _ := s.companyRepo.CalculateAverageRatings(ctx, companyID)
 
+	// Get job counts
+	jobStats, err := s.jobRepo.GetCompanyJobStats(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company job stats: %w", err)
+	}
+
+	// Get application counts
+	appStats, err := s.applicationRepo.GetCompanyApplicationStats(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company application stats: %w", err)
+	}
+
 	stats := &company.CompanyStats{
-		TotalJobs:           0, // TODO: Implement job count
-		ActiveJobs:          0, // TODO: Implement active job count
-		TotalApplications:   0, // TODO: Implement application count
+		TotalJobs:           jobStats.TotalJobs,
+		ActiveJobs:          jobStats.ActiveJobs,
+		TotalApplications:   appStats.TotalApplications,
 		TotalFollowers:      followerCount,
 		TotalEmployees:      employeeCount,
 		AverageRating:       0,
 		TotalReviews:        0,
 		VerificationStatus:  "unverified",
-		ProfileCompleteness: 0,
+		ProfileCompleteness: calculateProfileCompleteness(comp, jobStats.ActiveJobs > 0),
 	}
 
 	if ratings != nil {
@@ -2360,20 +3484,72 @@ func (s *companyService) GetCompanyStats(ctx context.Context, companyID int64) (
 	}
 
 	if comp.Verified {
-		stats.VerificationStatus = "verified"
+		stats.VerificationStatus = company.VerificationStatusVerified
 	}
 
+	// Store in cache
+	s.cache.Set(cacheKey, stats, CompanyStatsTTL)
+
 	return stats, nil
 }
 
-// GetTopRatedCompanies retrieves top-rated companies
+// calculateProfileCompleteness returns the percentage (0-100) of profile items a company has filled.
+// Each of the following items carries equal weight:
+//   - logo uploaded
+//   - banner uploaded
+//   - description (or legacy about text) filled in
+//   - industry selected (master data or legacy field)
+//   - company size selected (master data or legacy size category)
+//   - address filled in (full address or legacy address)
+//   - at least one published, non-expired job
+//   - company verified
+func calculateProfileCompleteness(comp *company.Company, hasPublishedJob bool) int {
+	items := []bool{
+		isFilled(comp.LogoURL),
+		isFilled(comp.BannerURL),
+		isFilled(comp.Description) || isFilled(comp.About),
+		comp.IndustryID != nil || isFilled(comp.Industry),
+		comp.CompanySizeID != nil || isFilled(comp.SizeCategory),
+		strings.TrimSpace(comp.FullAddress) != "" || isFilled(comp.Address),
+		hasPublishedJob,
+		comp.Verified,
+	}
+
+	filled := 0
+	for _, ok := range items {
+		if ok {
+			filled++
+		}
+	}
+
+	return filled * 100 / len(items)
+}
+
+// isFilled reports whether an optional string field holds a non-blank value
+func isFilled(value *string) bool {
+	return value != nil && strings.TrimSpace(*value) != ""
+}
+
+// GetTopRatedCompanies retrieves top-rated companies (with caching)
 func (s *companyService) GetTopRatedCompanies(ctx context.Context, limit int) ([]company.Company, error) {
-	companies, err := s.companyRepo.GetTopRatedCompanies(ctx, limit)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get top rated companies: %w", err)
+	// Try cache first
+	cacheKey := cache.GenerateCacheKey("companies", "top-rated", limit)
+	if cached, ok := cache.GetTyped[[]company.Company](s.cache, cacheKey); ok {
+		return cached, nil
 	}
 
-	return companies, nil
+	// Cache miss - fetch from database once for all concurrent misses
+	return loadShared(&s.loads, cacheKey, func() ([]company.Company, error) {
+		companies, err := s.companyRepo.GetTopRatedCompanies(ctx, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get top rated companies: %w", err)
+		}
+
+		// Store in cache
+		s.cache.Set(cacheKey, companies, CompanyTopRatedTTL)
+
+		return companies, nil
+	})
 }
 
 // GetVerifiedCompanies retrieves verified companies
@@ -2431,8 +3607,9 @@ func (s *companyService) GetEmployerUser(ctx context.Context, userID, companyID
 		return nil, fmt.Errorf("failed to get employer user: %w", err)
 	}
 
-	if employerUser == nil {
-		return nil, fmt.Errorf("user is not an employer of this company")
+	// A join request waiting for approval grants nothing yet
+	if employerUser == nil || employerUser.IsPendingJoin() {
+		return nil, company.ErrNotCompanyEmployer
 	}
 
 	return employerUser, nil
@@ -2447,6 +3624,139 @@ func (s *companyService) GetEmployerUserID(ctx context.Context, userID, companyI
 	return employerUser.ID, nil
 }
 
+// ResolveEmployerContext resolves the user's active membership of the company into an
+// EmployerContext. When companyID is 0 the company the user activated last is used, or
+// their only company if they never chose one.
+func (s *companyService) ResolveEmployerContext(ctx context.Context, userID, companyID int64) (*employer.EmployerContext, error) {
+	if companyID == 0 {
+		memberships, err := s.companyRepo.GetEmployerUsersByUserID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get employer companies: %w", err)
+		}
+		active, err := activeMembership(memberships)
+		if err != nil {
+			return nil, err
+		}
+		return employerContextOf(active), nil
+	}
+
+	employerUser, err := s.GetEmployerUser(ctx, userID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if !employerUser.IsActive {
+		return nil, company.ErrNotCompanyEmployer
+	}
+
+	return employerContextOf(employerUser), nil
+}
+
+// GetEmployerCompanies lists the user's companies with their role and marks the active one
+func (s *companyService) GetEmployerCompanies(ctx context.Context, userID, activeCompanyID int64) ([]company.EmployerCompany, error) {
+	memberships, err := s.companyRepo.GetEmployerUsersByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employer companies: %w", err)
+	}
+
+	if activeCompanyID == 0 {
+		// Users with several companies and no choice yet see the list without an active one
+		if active, err := activeMembership(memberships); err == nil {
+			activeCompanyID = active.CompanyID
+		}
+	}
+
+	companies := make([]company.EmployerCompany, 0, len(memberships))
+	found := activeCompanyID == 0
+	for _, m := range memberships {
+		item := company.EmployerCompany{
+			CompanyID:      m.CompanyID,
+			EmployerUserID: m.ID,
+			Role:           m.Role,
+			IsActive:       m.CompanyID == activeCompanyID,
+			ActivatedAt:    m.ActivatedAt,
+		}
+		if m.Company != nil {
+			item.CompanyName = m.Company.CompanyName
+			item.Slug = m.Company.Slug
+			item.LogoURL = m.Company.LogoURL
+			item.Verified = m.Company.Verified
+		}
+		found = found || item.IsActive
+		companies = append(companies, item)
+	}
+	if !found {
+		return nil, company.ErrNotCompanyEmployer
+	}
+
+	return companies, nil
+}
+
+// ActivateCompany stores the company as the user's active company
+func (s *companyService) ActivateCompany(ctx context.Context, userID, companyID int64) (*employer.EmployerContext, error) {
+	ec, err := s.ResolveEmployerContext(ctx, userID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.companyRepo.SetEmployerUserActivatedAt(ctx, ec.EmployerUserID, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to activate company: %w", err)
+	}
+
+	return ec, nil
+}
+
+// activeMembership picks the membership requests without a company act for: the one
+// activated last, or the only one when the user never activated a company
+func activeMembership(memberships []company.EmployerUser) (*company.EmployerUser, error) {
+	var active *company.EmployerUser
+	for i := range memberships {
+		m := &memberships[i]
+		if !m.IsActive {
+			continue
+		}
+		if active == nil || activatedAfter(m, active) {
+			active = m
+		}
+	}
+
+	switch {
+	case active == nil:
+		return nil, company.ErrNoEmployerCompany
+	case active.ActivatedAt == nil && countActive(memberships) > 1:
+		return nil, company.ErrActiveCompanyRequired
+	}
+	return active, nil
+}
+
+// activatedAfter reports whether a was activated more recently than b
+func activatedAfter(a, b *company.EmployerUser) bool {
+	if a.ActivatedAt == nil {
+		return false
+	}
+	return b.ActivatedAt == nil || a.ActivatedAt.After(*b.ActivatedAt)
+}
+
+// countActive counts the memberships that are active
+func countActive(memberships []company.EmployerUser) int {
+	n := 0
+	for _, m := range memberships {
+		if m.IsActive {
+			n++
+		}
+	}
+	return n
+}
+
+// employerContextOf builds the EmployerContext of an employer user
+func employerContextOf(employerUser *company.EmployerUser) *employer.EmployerContext {
+	return &employer.EmployerContext{
+		UserID:         employerUser.UserID,
+		EmployerUserID: employerUser.ID,
+		CompanyID:      employerUser.CompanyID,
+		Role:           employerUser.Role,
+	}
+}
+
 // =============================================================================
 // Master Data Validation Methods (NEW - Phase 8)
 // =============================================================================
@@ -2491,7 +3801,9 @@ func (s *companyService) ValidateCompanySizeID(ctx context.Context, companySizeI
 
 // ValidateDistrictID validates if district ID exists and is active
 // This also validates the full location hierarchy (District -> City -> Province)
-func (s *companyService) ValidateDistrictID(ctx context.Context, districtID int64) error {
+// A company keeps its current district (currentDistrictID) after the district, its city or its
+// province is deactivated; only assigning an inactive district is rejected.
+func (s *companyService) ValidateDistrictID(ctx context.Context, districtID int64, currentDistrictID *int64) error {
 	// Get district from master data service (includes City and Province relations)
 	district, err := s.districtService.GetByID(ctx, districtID)
 	if err != nil {
@@ -2502,7 +3814,9 @@ func (s *companyService) ValidateDistrictID(ctx context.Context, districtID int6
 		return fmt.Errorf("district with ID %d not found", districtID)
 	}
 
-	if !district.IsActive {
+	assigned := currentDistrictID != nil && *currentDistrictID == districtID
+
+	if !district.IsActive && !assigned {
 		return fmt.Errorf("district with ID %d is not active", districtID)
 	}
 
@@ -2511,7 +3825,7 @@ func (s *companyService) ValidateDistrictID(ctx context.Context, districtID int6
 		return fmt.Errorf("district with ID %d has no associated city", districtID)
 	}
 
-	if !district.City.IsActive {
+	if !district.City.IsActive && !assigned {
 		return fmt.Errorf("city associated with district %d is not active", districtID)
 	}
 
@@ -2520,15 +3834,16 @@ func (s *companyService) ValidateDistrictID(ctx context.Context, districtID int6
 		return fmt.Errorf("city associated with district %d has no associated province", districtID)
 	}
 
-	if !district.City.Province.IsActive {
+	if !district.City.Province.IsActive && !assigned {
 		return fmt.Errorf("province associated with district %d is not active", districtID)
 	}
 
 	return nil
 }
 
-// ValidateMasterDataIDs validates all master data IDs in a single call
-func (s *companyService) ValidateMasterDataIDs(ctx context.Context, industryID, companySizeID, districtID *int64) error {
+// ValidateMasterDataIDs validates all master data IDs in a single call. currentDistrictID is the
+// district the company already has, if any (see ValidateDistrictID).
+func (s *companyService) ValidateMasterDataIDs(ctx context.Context, industryID, companySizeID, districtID, currentDistrictID *int64) error {
 	// Validate Industry ID if provided
 	if industryID != nil {
 		if err := s.ValidateIndustryID(ctx, *industryID); err != nil {
@@ -2545,7 +3860,7 @@ func (s *companyService) ValidateMasterDataIDs(ctx context.Context, industryID,
 
 	// Validate District ID (includes City and Province) if provided
 	if districtID != nil {
-		if err := s.ValidateDistrictID(ctx, *districtID); err != nil {
+		if err := s.ValidateDistrictID(ctx, *districtID, currentDistrictID); err != nil {
 			return err
 		}
 	}
@@ -2558,11 +3873,8 @@ func (s *companyService) GetCompanyWithMasterData(ctx context.Context, id int64)
 	// Try cache first
 	cacheKey := fmt.Sprintf("company:masterdata:%d", id)
 
-	found, ok := s.cache.Get(cacheKey)
-	if ok && found != nil {
-		if cachedComp, ok := found.(*company.Company); ok && cachedComp != nil {
-			return cachedComp, nil
-		}
+	if cached, ok := cache.GetTyped[*company.Company](s.cache, cacheKey); ok && cached != nil {
+		return cached, nil
 	}
 
 	// Get from repository with master data preloaded
@@ -2572,7 +3884,7 @@ func (s *companyService) GetCompanyWithMasterData(ctx context.Context, id int64)
 	}
 
 	if comp == nil {
-		return nil, fmt.Errorf("company not found")
+		return nil, company.ErrCompanyNotFound
 	}
 
 	// Cache for 10 minutes
@@ -2586,11 +3898,8 @@ func (s *companyService) GetCompanyBySlugWithMasterData(ctx context.Context, slu
 	// Try cache first
 	cacheKey := fmt.Sprintf("company:slug:masterdata:%s", slug)
 
-	found, ok := s.cache.Get(cacheKey)
-	if ok && found != nil {
-		if cachedComp, ok := found.(*company.Company); ok && cachedComp != nil {
-			return cachedComp, nil
-		}
+	if cached, ok := cache.GetTyped[*company.Company](s.cache, cacheKey); ok && cached != nil {
+		return cached, nil
 	}
 
 	// Get from repository with master data preloaded
@@ -2600,7 +3909,7 @@ func (s *companyService) GetCompanyBySlugWithMasterData(ctx context.Context, slu
 	}
 
 	if comp == nil {
-		return nil, fmt.Errorf("company not found")
+		return nil, company.ErrCompanyNotFound
 	}
 
 	// Cache for 10 minutes
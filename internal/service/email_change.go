@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"keerja-backend/internal/domain/auth"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/utils"
+)
+
+// EmailChangeOTPExpiry is how long an email change code stays valid
+const EmailChangeOTPExpiry = 10 * time.Minute
+
+// RequestEmailChange starts changing a user's email. After checking the current password it
+// sends an OTP to newEmail; the email only changes once ConfirmEmailChange verifies the code.
+// The code is bound to newEmail, so it cannot confirm a different address.
+func (s *RegistrationService) RequestEmailChange(ctx context.Context, userID int64, newEmail, password, clientIP string) error {
+	usr, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if usr == nil {
+		return ErrUserNotFound
+	}
+
+	if !usr.HasPassword() {
+		return ErrPasswordNotSet
+	}
+	if !utils.VerifyPassword(password, usr.PasswordHash) {
+		return ErrInvalidCurrentPassword
+	}
+
+	newEmail = utils.SanitizeEmail(newEmail)
+	if newEmail == utils.SanitizeEmail(usr.Email) {
+		return ErrSameEmail
+	}
+	if err := s.checkEmailAvailable(ctx, newEmail); err != nil {
+		return err
+	}
+
+	if err := s.checkOTPIssueLimits(ctx, usr.ID, otpTypeEmailChange, clientIP); err != nil {
+		return err
+	}
+
+	// Only the latest code is checked, so a new request replaces earlier ones
+	otpCode := s.generateOTPCode()
+	otp := &auth.OTPCode{
+		UserID:    usr.ID,
+		OTPHash:   s.hashOTPCode(newEmail, otpCode),
+		Type:      otpTypeEmailChange,
+		ExpiredAt: time.Now().Add(EmailChangeOTPExpiry),
+		IPAddress: optionalIP(clientIP),
+	}
+	if err := s.otpCodeRepo.Create(ctx, otp); err != nil {
+		return fmt.Errorf("failed to save OTP: %w", err)
+	}
+
+	if err := s.emailService.SendOTPEmail(ctx, newEmail, otpCode, "perubahan email akun"); err != nil {
+		return fmt.Errorf("failed to send OTP email: %w", err)
+	}
+
+	return nil
+}
+
+// ConfirmEmailChange verifies the OTP sent to newEmail and switches the user's email to it.
+// The previous email is kept in the user's email change history and told about the change.
+func (s *RegistrationService) ConfirmEmailChange(ctx context.Context, userID int64, newEmail, otpCode string) (*user.User, error) {
+	usr, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if usr == nil {
+		return nil, ErrUserNotFound
+	}
+
+	latestOTP, err := s.otpCodeRepo.FindByUserIDAndType(ctx, usr.ID, otpTypeEmailChange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find OTP: %w", err)
+	}
+	if latestOTP == nil {
+		return nil, ErrOTPCodeNotFound
+	}
+
+	newEmail = utils.SanitizeEmail(newEmail)
+	if err := s.verifyOTPAttempt(ctx, latestOTP, newEmail, otpCode); err != nil {
+		return nil, err
+	}
+
+	// The address may have been registered since the code was sent
+	if err := s.checkEmailAvailable(ctx, newEmail); err != nil {
+		return nil, err
+	}
+
+	if err := s.otpCodeRepo.MarkAsUsed(ctx, latestOTP.ID); err != nil {
+		return nil, fmt.Errorf("failed to mark OTP as used: %w", err)
+	}
+
+	oldEmail := usr.Email
+	if err := s.userRepo.ChangeEmail(ctx, usr.ID, oldEmail, newEmail); err != nil {
+		if errors.Is(err, user.ErrEmailTaken) {
+			return nil, ErrEmailAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to change email: %w", err)
+	}
+	usr.Email = newEmail
+
+	subject := "Email Changed - Keerja"
+	body := fmt.Sprintf(`
+		<h2>Email Changed</h2>
+		<p>Hello %s,</p>
+		<p>The email address of your Keerja account has been changed to <strong>%s</strong>.</p>
+		<p>If you didn't make this change, please contact support immediately.</p>
+		<hr>
+		<p style="color: #666; font-size: 12px;">Keerja - Job Portal Platform</p>
+	`, usr.FullName, newEmail)
+
+	// Send email asynchronously (ignore error)
+	go s.emailService.SendEmail(context.Background(), oldEmail, subject, body)
+
+	return usr, nil
+}
+
+// checkEmailAvailable returns ErrEmailAlreadyExists when another account uses email
+func (s *RegistrationService) checkEmailAvailable(ctx context.Context, email string) error {
+	existing, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to check existing user: %w", err)
+	}
+	if existing != nil {
+		return ErrEmailAlreadyExists
+	}
+	return nil
+}
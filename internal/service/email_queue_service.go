@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/i18n"
+)
+
+// Email queue defaults, used when the config leaves a value unset
+const (
+	defaultEmailQueueMaxAttempts = 5
+	defaultEmailQueueBaseBackoff = 30 * time.Second
+	defaultEmailQueueMaxBackoff  = time.Hour
+	defaultEmailQueueBatchSize   = 50
+	defaultEmailQueueLease       = 5 * time.Minute
+	emailQueueSendTimeout        = 30 * time.Second
+)
+
+// errUndeliverableEmail marks failures that retrying cannot fix, such as an unknown
+// template or a payload that does not decode; those emails go dead right away
+var errUndeliverableEmail = errors.New("undeliverable email")
+
+// EmailQueueConfig configures retries of the email queue
+type EmailQueueConfig struct {
+	MaxAttempts int           // Attempts before an email goes dead
+	BaseBackoff time.Duration // Delay after the first failed attempt, doubled on each further failure
+	MaxBackoff  time.Duration // Upper bound of the delay between attempts
+	BatchSize   int           // Emails sent per worker run
+	Lease       time.Duration // How long a claimed email is hidden from other workers
+}
+
+// emailQueueService implements email.EmailQueueService
+type emailQueueService struct {
+	queueRepo email.EmailQueueRepository
+	transport email.EmailService
+	cfg       EmailQueueConfig
+}
+
+// NewEmailQueueService creates a new email queue service that sends queued emails through transport
+func NewEmailQueueService(queueRepo email.EmailQueueRepository, transport email.EmailService, cfg EmailQueueConfig) email.EmailQueueService {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultEmailQueueMaxAttempts
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = defaultEmailQueueBaseBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaultEmailQueueMaxBackoff
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultEmailQueueBatchSize
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = defaultEmailQueueLease
+	}
+	return &emailQueueService{
+		queueRepo: queueRepo,
+		transport: transport,
+		cfg:       cfg,
+	}
+}
+
+// Enqueue queues an email built from template and its arguments
+func (s *emailQueueService) Enqueue(ctx context.Context, recipient, template string, payload interface{}) error {
+	if _, ok := queuedEmailSenders[template]; !ok {
+		return fmt.Errorf("unknown email template: %s", template)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode email payload: %w", err)
+	}
+	locale := string(i18n.FromContext(ctx))
+
+	queued := &email.QueuedEmail{
+		Recipient:     recipient,
+		Template:      template,
+		Payload:       string(data),
+		Locale:        locale,
+		DedupeKey:     emailDedupeKey(recipient, template, locale, data),
+		Status:        email.QueueStatusPending,
+		NextAttemptAt: time.Now(),
+	}
+	if _, err := s.queueRepo.Enqueue(ctx, queued); err != nil {
+		return fmt.Errorf("failed to queue email: %w", err)
+	}
+	return nil
+}
+
+// emailDedupeKey identifies an email by its recipient, template, locale and payload
+func emailDedupeKey(recipient, template, locale string, payload []byte) string {
+	h := sha256.New()
+	h.Write([]byte(template))
+	h.Write([]byte{0})
+	h.Write([]byte(recipient))
+	h.Write([]byte{0})
+	h.Write([]byte(locale))
+	h.Write([]byte{0})
+	h.Write(payload)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ProcessDue sends the emails that are due
+func (s *emailQueueService) ProcessDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	emails, err := s.queueRepo.ClaimDue(ctx, now, now.Add(s.cfg.Lease), s.cfg.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim due emails: %w", err)
+	}
+
+	sent := 0
+	var errs []error
+	for i := range emails {
+		sendErr := s.send(ctx, &emails[i])
+		if sendErr == nil {
+			sent++
+		}
+		if err := s.recordAttempt(ctx, &emails[i], sendErr); err != nil {
+			errs = append(errs, fmt.Errorf("email %d: %w", emails[i].ID, err))
+		}
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+// send sends a claimed email through the transport in the locale it was queued with
+func (s *emailQueueService) send(ctx context.Context, queued *email.QueuedEmail) error {
+	sender, ok := queuedEmailSenders[queued.Template]
+	if !ok {
+		return fmt.Errorf("%w: unknown template %s", errUndeliverableEmail, queued.Template)
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, emailQueueSendTimeout)
+	defer cancel()
+	if locale, ok := i18n.ParseLocale(queued.Locale); ok {
+		sendCtx = i18n.WithLocale(sendCtx, locale)
+	}
+	return sender(sendCtx, s.transport, queued.Recipient, []byte(queued.Payload))
+}
+
+// recordAttempt stores the outcome of an attempt; the attempt itself was already
+// counted when the email was claimed
+func (s *emailQueueService) recordAttempt(ctx context.Context, queued *email.QueuedEmail, sendErr error) error {
+	if sendErr == nil {
+		return s.queueRepo.MarkSent(ctx, queued.ID, time.Now())
+	}
+
+	msg := sendErr.Error()
+	if errors.Is(sendErr, errUndeliverableEmail) || queued.Attempts >= s.cfg.MaxAttempts {
+		return s.queueRepo.MarkDead(ctx, queued.ID, msg)
+	}
+	return s.queueRepo.MarkRetry(ctx, queued.ID, time.Now().Add(s.backoff(queued.Attempts)), msg)
+}
+
+// backoff returns the delay before the next attempt after attempts failed attempts
+func (s *emailQueueService) backoff(attempts int) time.Duration {
+	delay := s.cfg.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= s.cfg.MaxBackoff {
+			return s.cfg.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// ListEmails lists queued emails with status
+func (s *emailQueueService) ListEmails(ctx context.Context, status string, page, limit int) ([]email.QueuedEmail, int64, error) {
+	return s.queueRepo.ListByStatus(ctx, status, page, limit)
+}
+
+// RetryDead queues a dead email to be sent again
+func (s *emailQueueService) RetryDead(ctx context.Context, id int64) error {
+	queued, err := s.queueRepo.FindByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find queued email: %w", err)
+	}
+	if queued == nil {
+		return email.ErrQueuedEmailNotFound
+	}
+	if queued.Status != email.QueueStatusDead {
+		return email.ErrQueuedEmailNotDead
+	}
+	return s.queueRepo.Requeue(ctx, id, time.Now())
+}
@@ -5,11 +5,14 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
 	"strconv"
 	"time"
 
 	"keerja-backend/internal/config"
 	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/i18n"
+	"keerja-backend/internal/utils"
 
 	"gopkg.in/gomail.v2"
 )
@@ -78,21 +81,33 @@ func (s *emailService) SendEmail(ctx context.Context, to, subject, body string)
 
 // SendTemplateEmail sends an email using a template
 func (s *emailService) SendTemplateEmail(ctx context.Context, to, templateName string, data map[string]interface{}) error {
+	return s.sendTemplateEmail(ctx, to, templateName, data)
+}
+
+// sendTemplateEmail renders, logs and sends a template email with optional attachments.
+// The email is rendered in the locale carried by ctx.
+func (s *emailService) sendTemplateEmail(ctx context.Context, to, templateName string, data map[string]interface{}, attachments ...email.Attachment) error {
 	// Convert template name to EmailTemplate type
 	templateType := email.EmailTemplate(templateName)
+	locale := i18n.FromContext(ctx)
 
 	// Prepare template data
 	templateData := s.mapToTemplateData(data)
 
 	// Render template
-	body, err := email.RenderTemplate(templateType, templateData)
+	body, err := email.RenderTemplate(templateType, locale, templateData)
 	if err != nil {
 		return fmt.Errorf("failed to render template: %w", err)
 	}
 
 	// Get subject
-	subject := email.GetSubject(templateType)
+	subject := email.GetSubject(templateType, locale)
 
+	return s.sendRenderedEmail(ctx, to, templateName, subject, body, attachments...)
+}
+
+// sendRenderedEmail logs and sends an email whose subject and body are already rendered
+func (s *emailService) sendRenderedEmail(ctx context.Context, to, templateName, subject, body string, attachments ...email.Attachment) error {
 	// Create email log
 	log := &email.EmailLog{
 		Recipient: to,
@@ -113,7 +128,7 @@ func (s *emailService) SendTemplateEmail(ctx context.Context, to, templateName s
 	fmt.Printf("[DEBUG] SMTP Host: %s:%s\n", s.config.SMTPHost, s.config.SMTPPort)
 
 	// Send email
-	if err := s.sendViaSMTP(to, subject, body); err != nil {
+	if err := s.sendViaSMTP(to, subject, body, attachments...); err != nil {
 		fmt.Printf("[ERROR] Failed to send email: %v\n", err)
 		log.MarkAsFailed(err.Error())
 		s.emailRepo.Update(ctx, log)
@@ -187,29 +202,35 @@ func (s *emailService) SendJobApplicationEmail(ctx context.Context, to, jobTitle
 	return s.SendTemplateEmail(ctx, to, string(email.TemplateApplicationUpdate), data)
 }
 
-// SendInterviewInvitationEmail sends interview invitation
-func (s *emailService) SendInterviewInvitationEmail(ctx context.Context, to, jobTitle string, interviewDate string) error {
-	data := map[string]interface{}{
-		"Name":          to,
-		"JobTitle":      jobTitle,
-		"InterviewDate": interviewDate,
-		"InterviewTime": "10:00 AM",
-		"InterviewURL":  fmt.Sprintf("%s/interviews", s.config.DashboardURL),
-		"SupportEmail":  s.config.SupportEmail,
-		"Year":          time.Now().Year(),
-	}
+// SendInterviewInvitationEmail sends interview invitation with an iCalendar REQUEST attached
+func (s *emailService) SendInterviewInvitationEmail(ctx context.Context, to string, interview email.InterviewEmailData) error {
+	return s.sendInterviewEmail(ctx, to, email.TemplateInterviewInvite, interview, utils.CalendarMethodRequest)
+}
 
-	return s.SendTemplateEmail(ctx, to, string(email.TemplateInterviewInvite), data)
+// SendInterviewReminderEmail sends an upcoming interview reminder with the current iCalendar invite attached
+func (s *emailService) SendInterviewReminderEmail(ctx context.Context, to string, interview email.InterviewEmailData) error {
+	return s.sendInterviewEmail(ctx, to, email.TemplateInterviewReminder, interview, utils.CalendarMethodRequest)
+}
+
+// SendInterviewCancellationEmail sends an interview cancellation with an iCalendar CANCEL attached
+func (s *emailService) SendInterviewCancellationEmail(ctx context.Context, to string, interview email.InterviewEmailData) error {
+	return s.sendInterviewEmail(ctx, to, email.TemplateInterviewCancelled, interview, utils.CalendarMethodCancel)
 }
 
 // SendJobStatusUpdateEmail sends job status update notification
 func (s *emailService) SendJobStatusUpdateEmail(ctx context.Context, to, jobTitle, status string) error {
+	t := i18n.New(i18n.FromContext(ctx))
+	statusName := status
+	if t.Has("application.status." + status) {
+		statusName = t.T("application.status." + status)
+	}
+
 	data := map[string]interface{}{
 		"Name":          to,
 		"JobTitle":      jobTitle,
-		"Status":        status,
+		"Status":        statusName,
 		"ApplicationID": "APP-12345",
-		"Message":       "Your application has been updated.",
+		"Message":       t.T("email.application_update.message"),
 		"DashboardURL":  s.config.DashboardURL,
 		"SupportEmail":  s.config.SupportEmail,
 		"Year":          time.Now().Year(),
@@ -218,6 +239,11 @@ func (s *emailService) SendJobStatusUpdateEmail(ctx context.Context, to, jobTitl
 	return s.SendTemplateEmail(ctx, to, string(email.TemplateApplicationUpdate), data)
 }
 
+// SendCompanyTemplateEmail sends a candidate email rendered from a company's own template
+func (s *emailService) SendCompanyTemplateEmail(ctx context.Context, to string, rendered email.RenderedEmail) error {
+	return s.sendRenderedEmail(ctx, to, email.QueueTemplateCompanyTemplate, rendered.Subject, rendered.Body)
+}
+
 // SendBulkEmail sends email to multiple recipients
 func (s *emailService) SendBulkEmail(ctx context.Context, recipients []string, subject, body string) error {
 	var errors []error
@@ -285,7 +311,7 @@ func (s *emailService) GetFailedEmails(ctx context.Context, page, limit int) ([]
 // ===== Helper Methods =====
 
 // sendViaSMTP sends email using SMTP
-func (s *emailService) sendViaSMTP(to, subject, body string) error {
+func (s *emailService) sendViaSMTP(to, subject, body string, attachments ...email.Attachment) error {
 	// Debug: Log connection attempt
 	fmt.Printf("🔌 [DEBUG] Attempting SMTP connection to %s:%s\n", s.config.SMTPHost, s.config.SMTPPort)
 	fmt.Printf("🔌 [DEBUG] SMTP Username: '%s' (empty=%v)\n", s.config.SMTPUsername, s.config.SMTPUsername == "")
@@ -297,6 +323,16 @@ func (s *emailService) sendViaSMTP(to, subject, body string) error {
 	m.SetHeader("To", to)
 	m.SetHeader("Subject", subject)
 	m.SetBody("text/html", body)
+	for _, attachment := range attachments {
+		data := attachment.Data
+		m.Attach(attachment.Filename,
+			gomail.SetCopyFunc(func(w io.Writer) error {
+				_, err := w.Write(data)
+				return err
+			}),
+			gomail.SetHeader(map[string][]string{"Content-Type": {attachment.ContentType}}),
+		)
+	}
 
 	// Send email
 	fmt.Printf("[DEBUG] Calling dialer.DialAndSend()...\n")
@@ -317,6 +353,87 @@ func (s *emailService) sendViaSMTP(to, subject, body string) error {
 	return nil
 }
 
+// defaultInterviewDuration is the calendar event length used since interviews have no planned end time
+const defaultInterviewDuration = time.Hour
+
+// sendInterviewEmail renders an interview template in the interview's timezone and attaches
+// the matching iCalendar invite
+func (s *emailService) sendInterviewEmail(ctx context.Context, to string, templateType email.EmailTemplate, interview email.InterviewEmailData, method string) error {
+	loc, err := time.LoadLocation(interview.Timezone)
+	if err != nil || interview.Timezone == "" {
+		loc = time.UTC
+	}
+	localStart := interview.ScheduledAt.In(loc)
+
+	interviewURL := interview.MeetingLink
+	if interviewURL == "" {
+		interviewURL = fmt.Sprintf("%s/interviews", s.config.DashboardURL)
+	}
+
+	data := map[string]interface{}{
+		"Name":          interview.CandidateName,
+		"JobTitle":      interview.JobTitle,
+		"CompanyName":   interview.CompanyName,
+		"InterviewDate": i18n.FormatLongDate(i18n.FromContext(ctx), localStart),
+		"InterviewTime": fmt.Sprintf("%s (%s)", localStart.Format("15:04 MST"), loc.String()),
+		"InterviewURL":  interviewURL,
+		"Location":      interview.Location,
+		"Message":       interview.Message,
+		"SupportEmail":  s.config.SupportEmail,
+		"Year":          time.Now().Year(),
+	}
+
+	ics := utils.GenerateICS(s.interviewCalendarEvent(to, interview, method))
+	attachment := email.Attachment{
+		Filename:    "interview.ics",
+		ContentType: fmt.Sprintf("text/calendar; charset=utf-8; method=%s", method),
+		Data:        ics,
+	}
+
+	if interview.Custom != nil {
+		return s.sendRenderedEmail(ctx, to, string(templateType), interview.Custom.Subject, interview.Custom.Body, attachment)
+	}
+	return s.sendTemplateEmail(ctx, to, string(templateType), data, attachment)
+}
+
+// interviewCalendarEvent builds the calendar event for an interview. The UID is derived from
+// the interview ID so reschedules and cancellations update the same calendar entry.
+func (s *emailService) interviewCalendarEvent(attendee string, interview email.InterviewEmailData, method string) utils.CalendarEvent {
+	duration := interview.Duration
+	if duration <= 0 {
+		duration = defaultInterviewDuration
+	}
+
+	summary := fmt.Sprintf("Interview: %s", interview.JobTitle)
+	if interview.CompanyName != "" {
+		summary = fmt.Sprintf("%s - %s", summary, interview.CompanyName)
+	}
+
+	location := interview.Location
+	if location == "" {
+		location = interview.MeetingLink
+	}
+
+	return utils.CalendarEvent{
+		UID:         interviewCalendarUID(interview.InterviewID),
+		Sequence:    interview.Sequence,
+		Method:      method,
+		Summary:     summary,
+		Description: interview.Message,
+		Location:    location,
+		URL:         interview.MeetingLink,
+		Start:       interview.ScheduledAt,
+		End:         interview.ScheduledAt.Add(duration),
+		Organizer:   s.config.SMTPFrom,
+		Attendee:    attendee,
+	}
+}
+
+// interviewCalendarUID returns the stable iCalendar UID for an interview
+func interviewCalendarUID(interviewID int64) string {
+	return fmt.Sprintf("interview-%d@keerja.com", interviewID)
+}
+
 // mapToTemplateData converts map to TemplateData
 func (s *emailService) mapToTemplateData(data map[string]interface{}) email.TemplateData {
 	templateData := email.TemplateData{
@@ -357,6 +474,9 @@ func (s *emailService) mapToTemplateData(data map[string]interface{}) email.Temp
 	if v, ok := data["InterviewURL"].(string); ok {
 		templateData.InterviewURL = v
 	}
+	if v, ok := data["Location"].(string); ok {
+		templateData.Location = v
+	}
 	if v, ok := data["ApplicationID"].(string); ok {
 		templateData.ApplicationID = v
 	}
@@ -390,6 +510,30 @@ func (s *emailService) mapToTemplateData(data map[string]interface{}) email.Temp
 	if v, ok := data["ExpiryDays"].(string); ok {
 		templateData.ExpiryDays = v
 	}
+	if v, ok := data["ThresholdDays"].(string); ok {
+		templateData.ThresholdDays = v
+	}
+	if v, ok := data["ReminderGroups"].([]email.StageReminderGroup); ok {
+		templateData.ReminderGroups = v
+	}
+	if v, ok := data["JobURL"].(string); ok {
+		templateData.JobURL = v
+	}
+	if v, ok := data["ExpiryDate"].(string); ok {
+		templateData.ExpiryDate = v
+	}
+	if v, ok := data["AutoExtend"].(bool); ok {
+		templateData.AutoExtend = v
+	}
+	if v, ok := data["BookingURL"].(string); ok {
+		templateData.BookingURL = v
+	}
+	if v, ok := data["Digest"].(*email.WeeklyDigest); ok {
+		templateData.Digest = v
+	}
+	if v, ok := data["Domain"].(string); ok {
+		templateData.Domain = v
+	}
 
 	return templateData
 }
@@ -439,6 +583,18 @@ func (s *emailService) SendCompanyInvitationEmail(ctx context.Context, to, name,
 	return s.SendTemplateEmail(ctx, to, string(email.TemplateCompanyInvitation), data)
 }
 
+// SendEmployerInvitationEmail sends company employee invitation email with an accept link for the token
+func (s *emailService) SendEmployerInvitationEmail(ctx context.Context, to, name, companyName, inviterName, position, role, token string, expiresAt time.Time) error {
+	inviteURL := fmt.Sprintf("%s/accept-invite?token=%s", s.config.FrontendURL, token)
+
+	expiryDays := int(time.Until(expiresAt).Hours()/24 + 0.5)
+	if expiryDays < 1 {
+		expiryDays = 1
+	}
+
+	return s.SendCompanyInvitationEmail(ctx, to, name, companyName, inviterName, position, role, inviteURL, expiryDays)
+}
+
 // SendInvitationAcceptedEmail sends notification when invitation is accepted
 func (s *emailService) SendInvitationAcceptedEmail(ctx context.Context, to, inviterName, memberName, memberEmail, companyName, position, role string) error {
 	data := map[string]interface{}{
@@ -469,3 +625,181 @@ func (s *emailService) SendInvitationExpiredEmail(ctx context.Context, to, name,
 
 	return s.SendTemplateEmail(ctx, to, string(email.TemplateInvitationExpired), data)
 }
+
+// SendJobReviewEmail notifies the job owner about an admin approval or rejection
+func (s *emailService) SendJobReviewEmail(ctx context.Context, to, name, jobTitle, companyName, status, message string) error {
+	data := map[string]interface{}{
+		"Name":         name,
+		"JobTitle":     jobTitle,
+		"CompanyName":  companyName,
+		"Status":       status,
+		"Message":      message,
+		"DashboardURL": s.config.DashboardURL,
+		"SupportEmail": s.config.SupportEmail,
+		"Year":         time.Now().Year(),
+	}
+
+	return s.SendTemplateEmail(ctx, to, string(email.TemplateJobReviewed), data)
+}
+
+// SendStageReminderEmail sends a recruiter the daily summary of applications stuck in a stage
+func (s *emailService) SendStageReminderEmail(ctx context.Context, to, name, companyName string, thresholdDays int, groups []email.StageReminderGroup) error {
+	data := map[string]interface{}{
+		"Name":           name,
+		"CompanyName":    companyName,
+		"ThresholdDays":  strconv.Itoa(thresholdDays),
+		"ReminderGroups": groups,
+		"DashboardURL":   s.config.DashboardURL,
+		"SupportEmail":   s.config.SupportEmail,
+		"Year":           time.Now().Year(),
+	}
+
+	return s.SendTemplateEmail(ctx, to, string(email.TemplateStageReminder), data)
+}
+
+// SendFollowedCompanyJobEmail tells a follower that a company they follow published a new job
+func (s *emailService) SendFollowedCompanyJobEmail(ctx context.Context, to, name, companyName, jobTitle, jobSlug string) error {
+	data := map[string]interface{}{
+		"Name":        name,
+		"CompanyName": companyName,
+		"JobTitle":    jobTitle,
+		"JobURL":      fmt.Sprintf("%s/jobs/%s", s.config.FrontendURL, jobSlug),
+	}
+
+	return s.SendTemplateEmail(ctx, to, string(email.TemplateFollowedCompanyJob), data)
+}
+
+// SendVerificationExpiryEmail warns a company owner or admin that the company verification expires soon
+func (s *emailService) SendVerificationExpiryEmail(ctx context.Context, to, name, companyName string, daysLeft int, expiry time.Time) error {
+	data := map[string]interface{}{
+		"Name":         name,
+		"CompanyName":  companyName,
+		"ExpiryDays":   strconv.Itoa(daysLeft),
+		"ExpiryDate":   i18n.FormatDate(i18n.FromContext(ctx), expiry),
+		"DashboardURL": s.config.DashboardURL,
+		"SupportEmail": s.config.SupportEmail,
+		"Year":         time.Now().Year(),
+	}
+
+	return s.SendTemplateEmail(ctx, to, string(email.TemplateVerificationExpiry), data)
+}
+
+// SendJobExpiryReminderEmail reminds the employer who posted a job that it expires soon
+func (s *emailService) SendJobExpiryReminderEmail(ctx context.Context, to, name, jobTitle, companyName string, daysLeft int, expiry time.Time, autoExtend bool) error {
+	data := map[string]interface{}{
+		"Name":         name,
+		"JobTitle":     jobTitle,
+		"CompanyName":  companyName,
+		"ExpiryDays":   strconv.Itoa(daysLeft),
+		"ExpiryDate":   i18n.FormatDate(i18n.FromContext(ctx), expiry),
+		"AutoExtend":   autoExtend,
+		"DashboardURL": s.config.DashboardURL,
+		"SupportEmail": s.config.SupportEmail,
+		"Year":         time.Now().Year(),
+	}
+
+	return s.SendTemplateEmail(ctx, to, string(email.TemplateJobExpiryReminder), data)
+}
+
+// SendInterviewBookingEmail sends a candidate the link to book an interview slot with the token
+func (s *emailService) SendInterviewBookingEmail(ctx context.Context, to, name, jobTitle, companyName, token string, expiresAt time.Time) error {
+	data := map[string]interface{}{
+		"Name":         name,
+		"JobTitle":     jobTitle,
+		"CompanyName":  companyName,
+		"BookingURL":   fmt.Sprintf("%s/interview-booking/%s", s.config.FrontendURL, token),
+		"ExpiryDate":   i18n.FormatDate(i18n.FromContext(ctx), expiresAt),
+		"SupportEmail": s.config.SupportEmail,
+		"Year":         time.Now().Year(),
+	}
+
+	return s.SendTemplateEmail(ctx, to, string(email.TemplateInterviewBooking), data)
+}
+
+// SendDataExportEmail sends a user the link to download their data export with the token
+func (s *emailService) SendDataExportEmail(ctx context.Context, to, name, token string, expiresAt time.Time) error {
+	data := map[string]interface{}{
+		"Name":         name,
+		"DownloadURL":  fmt.Sprintf("%s/account/data-export/%s", s.config.FrontendURL, token),
+		"ExpiryDate":   i18n.FormatDate(i18n.FromContext(ctx), expiresAt),
+		"SupportEmail": s.config.SupportEmail,
+		"Year":         time.Now().Year(),
+	}
+
+	return s.SendTemplateEmail(ctx, to, string(email.TemplateDataExport), data)
+}
+
+// SendWebhookDisabledEmail tells a company owner or admin that a webhook was disabled after repeated failed deliveries
+func (s *emailService) SendWebhookDisabledEmail(ctx context.Context, to, name, companyName, webhookURL string, failures int) error {
+	data := map[string]interface{}{
+		"Name":         name,
+		"CompanyName":  companyName,
+		"WebhookURL":   webhookURL,
+		"Failures":     strconv.Itoa(failures),
+		"DashboardURL": s.config.DashboardURL,
+		"SupportEmail": s.config.SupportEmail,
+		"Year":         time.Now().Year(),
+	}
+
+	return s.SendTemplateEmail(ctx, to, string(email.TemplateWebhookDisabled), data)
+}
+
+// SendWeeklyDigestEmail sends a recruiter the company's weekly activity digest, linking
+// each section to the dashboard screen it summarizes
+func (s *emailService) SendWeeklyDigestEmail(ctx context.Context, to, name, companyName string, digest *email.WeeklyDigest) error {
+	if digest == nil {
+		digest = &email.WeeklyDigest{}
+	}
+	locale := i18n.FromContext(ctx)
+	dashboard := s.config.DashboardURL
+
+	// Fill the links on a copy so the caller can send the same digest to every recruiter
+	d := *digest
+	d.AwaitingActionURL = fmt.Sprintf("%s/applications?status=applied", dashboard)
+	d.InterviewsURL = fmt.Sprintf("%s/interviews", dashboard)
+	d.FollowersURL = fmt.Sprintf("%s/company/followers", dashboard)
+
+	d.Jobs = make([]email.WeeklyDigestJob, len(digest.Jobs))
+	for i, j := range digest.Jobs {
+		j.URL = fmt.Sprintf("%s/jobs/%d/applications", dashboard, j.JobID)
+		d.Jobs[i] = j
+	}
+	d.Interviews = make([]email.WeeklyDigestInterview, len(digest.Interviews))
+	for i, iv := range digest.Interviews {
+		at := iv.ScheduledAt.UTC()
+		iv.Date = fmt.Sprintf("%s %s", i18n.FormatLongDate(locale, at), at.Format("15:04 MST"))
+		iv.URL = fmt.Sprintf("%s/applications/%d", dashboard, iv.ApplicationID)
+		d.Interviews[i] = iv
+	}
+	d.ExpiringJobs = make([]email.WeeklyDigestExpiringJob, len(digest.ExpiringJobs))
+	for i, j := range digest.ExpiringJobs {
+		j.Date = i18n.FormatDate(locale, j.ExpiredAt)
+		j.URL = fmt.Sprintf("%s/jobs/%d", dashboard, j.JobID)
+		d.ExpiringJobs[i] = j
+	}
+
+	data := map[string]interface{}{
+		"Name":         name,
+		"CompanyName":  companyName,
+		"Digest":       &d,
+		"DashboardURL": dashboard,
+		"SupportEmail": s.config.SupportEmail,
+		"Year":         time.Now().Year(),
+	}
+
+	return s.SendTemplateEmail(ctx, to, string(email.TemplateWeeklyDigest), data)
+}
+
+// SendDomainVerificationEmail sends the code proving the company owns domain to a mailbox on it
+func (s *emailService) SendDomainVerificationEmail(ctx context.Context, to, companyName, domain, code string, expiresAt time.Time) error {
+	data := map[string]interface{}{
+		"CompanyName":  companyName,
+		"Domain":       domain,
+		"OTPCode":      code,
+		"ExpiryDate":   i18n.FormatDate(i18n.FromContext(ctx), expiresAt),
+		"SupportEmail": s.config.SupportEmail,
+		"Year":         time.Now().Year(),
+	}
+
+	return s.SendTemplateEmail(ctx, to, string(email.TemplateDomainVerification), data)
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"time"
 
 	"keerja-backend/internal/config"
 	"keerja-backend/internal/domain/notification"
+	"keerja-backend/internal/metrics"
 
 	"firebase.google.com/go/v4/messaging"
 )
@@ -55,14 +57,14 @@ func (s *FCMPushService) SendToDevice(ctx context.Context, token string, message
 	// Send message
 	messageID, err := fcmClient.Send(ctxTimeout, fcmMessage)
 	if err != nil {
+		metrics.Default().ObservePushSends(0, 1)
 		return s.handleFCMError(ctx, token, err)
 	}
+	metrics.Default().ObservePushSends(1, 0)
 
 	// Update token last used timestamp
-	if deviceToken, err := s.deviceTokenRepo.FindByToken(ctx, token); err == nil {
-		deviceToken.MarkAsUsed()
-		deviceToken.ResetFailures()
-		_ = s.deviceTokenRepo.Update(ctx, deviceToken)
+	if err := s.deviceTokenRepo.MarkDelivered(ctx, []string{token}, time.Now()); err != nil {
+		log.Printf("Failed to mark device token delivered: %v", err)
 	}
 
 	return &notification.PushResult{
@@ -164,12 +166,14 @@ func (s *FCMPushService) SendToTopic(ctx context.Context, topic string, message
 
 	messageID, err := fcmClient.Send(ctxTimeout, fcmMessage)
 	if err != nil {
+		metrics.Default().ObservePushSends(0, 1)
 		return &notification.PushResult{
 			Success:      false,
 			ErrorCode:    "SEND_ERROR",
 			ErrorMessage: err.Error(),
 		}, err
 	}
+	metrics.Default().ObservePushSends(1, 0)
 
 	return &notification.PushResult{
 		Success:   true,
@@ -177,34 +181,92 @@ func (s *FCMPushService) SendToTopic(ctx context.Context, topic string, message
 	}, nil
 }
 
-// RegisterDeviceToken registers a new device token for a user
-func (s *FCMPushService) RegisterDeviceToken(ctx context.Context, userID int64, token string, platform notification.Platform, deviceInfo *notification.DeviceInfo) error {
-	// Check if token already exists
-	existingToken, err := s.deviceTokenRepo.FindByToken(ctx, token)
-	if err == nil {
-		// Token exists, update it
-		existingToken.UserID = userID
-		existingToken.Platform = platform
-		if deviceInfo != nil {
-			existingToken.DeviceInfo = *deviceInfo
-		}
-		existingToken.Activate()
-		return s.deviceTokenRepo.Update(ctx, existingToken)
+// SendToTokens sends push notification to device tokens in multicast batches of at most 500
+func (s *FCMPushService) SendToTokens(ctx context.Context, tokens []string, message *notification.PushMessage) ([]notification.PushResult, error) {
+	if len(tokens) == 0 {
+		return []notification.PushResult{}, nil
 	}
+	return s.sendToMultipleTokens(ctx, tokens, message)
+}
 
-	// Create new token
+// SubscribeToTopic subscribes device tokens to a topic
+func (s *FCMPushService) SubscribeToTopic(ctx context.Context, tokens []string, topic string) error {
+	return s.manageTopic(ctx, tokens, topic, true)
+}
+
+// UnsubscribeFromTopic unsubscribes device tokens from a topic
+func (s *FCMPushService) UnsubscribeFromTopic(ctx context.Context, tokens []string, topic string) error {
+	return s.manageTopic(ctx, tokens, topic, false)
+}
+
+// RegisterDeviceToken registers a device token for a user. A token already registered by
+// another user is reassigned rather than duplicated, and once the user has more active
+// devices than allowed the least recently seen ones are evicted.
+func (s *FCMPushService) RegisterDeviceToken(ctx context.Context, userID int64, token string, platform notification.Platform, deviceInfo *notification.DeviceInfo) (*notification.DeviceToken, error) {
 	deviceToken := &notification.DeviceToken{
 		UserID:   userID,
 		Token:    token,
 		Platform: platform,
-		IsActive: true,
 	}
-
 	if deviceInfo != nil {
 		deviceToken.DeviceInfo = *deviceInfo
 	}
 
-	return s.deviceTokenRepo.Create(ctx, deviceToken)
+	if err := s.deviceTokenRepo.Upsert(ctx, deviceToken); err != nil {
+		return nil, err
+	}
+
+	if err := s.evictExcessDevices(ctx, userID, deviceToken.Token); err != nil {
+		log.Printf("Failed to evict excess device tokens for user %d: %v", userID, err)
+	}
+
+	return deviceToken, nil
+}
+
+// evictExcessDevices deletes the user's least recently seen active tokens beyond the
+// per-user limit, never evicting keep (the token being registered)
+func (s *FCMPushService) evictExcessDevices(ctx context.Context, userID int64, keep string) error {
+	limit := s.cfg.PushMaxDevicesPerUser
+	if limit <= 0 {
+		return nil
+	}
+
+	tokens, err := s.deviceTokenRepo.FindByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(tokens) <= limit {
+		return nil
+	}
+
+	// Most recently seen first, with the token being registered always kept
+	sort.SliceStable(tokens, func(i, j int) bool {
+		if tokens[i].Token == keep || tokens[j].Token == keep {
+			return tokens[i].Token == keep
+		}
+		return tokens[i].LastSeenAt().After(tokens[j].LastSeenAt())
+	})
+
+	var evicted int
+	for _, stale := range tokens[limit:] {
+		// Topic subscriptions live in FCM, so drop them before the token is forgotten
+		topics, err := s.deviceTokenRepo.FindTopics(ctx, stale.ID)
+		if err == nil {
+			for _, topic := range topics {
+				if err := s.UnsubscribeFromTopic(ctx, []string{stale.Token}, topic); err != nil {
+					log.Printf("Failed to unsubscribe evicted device token %d from %s: %v", stale.ID, topic, err)
+				}
+			}
+		}
+
+		if err := s.deviceTokenRepo.Delete(ctx, stale.ID); err != nil {
+			return err
+		}
+		evicted++
+	}
+
+	log.Printf("Evicted %d device tokens for user %d (limit %d)", evicted, userID, limit)
+	return nil
 }
 
 // UnregisterDeviceToken removes a device token
@@ -352,6 +414,7 @@ func (s *FCMPushService) sendToMultipleTokens(ctx context.Context, tokens []stri
 
 		if err != nil {
 			log.Printf("Error sending batch: %v", err)
+			metrics.Default().ObservePushSends(0, len(batch))
 			// Add error results for this batch
 			for range batch {
 				results = append(results, notification.PushResult{
@@ -363,33 +426,136 @@ func (s *FCMPushService) sendToMultipleTokens(ctx context.Context, tokens []stri
 			continue
 		}
 
+		metrics.Default().ObservePushSends(batchResponse.SuccessCount, batchResponse.FailureCount)
+
 		// Process batch results
+		batchResults := make([]notification.PushResult, len(batchResponse.Responses))
+		invalid := invalidTokenIndexes(batchResponse.Responses)
+		var delivered []string
 		for idx, response := range batchResponse.Responses {
 			tokenStr := batch[idx]
 
 			if response.Success {
-				results = append(results, notification.PushResult{
+				batchResults[idx] = notification.PushResult{
 					Success:   true,
 					MessageID: response.MessageID,
-				})
-
-				// Update token success
-				if deviceToken, err := s.deviceTokenRepo.FindByToken(ctx, tokenStr); err == nil {
-					deviceToken.MarkAsUsed()
-					deviceToken.ResetFailures()
-					_ = s.deviceTokenRepo.Update(ctx, deviceToken)
 				}
-			} else {
-				// Handle failure
-				result, _ := s.handleFCMError(ctx, tokenStr, response.Error)
-				results = append(results, *result)
+				delivered = append(delivered, tokenStr)
+				continue
+			}
+
+			errorCode := fcmErrorCode(response.Error)
+			batchResults[idx] = notification.PushResult{
+				Success:      false,
+				ErrorCode:    errorCode,
+				ErrorMessage: response.Error.Error(),
 			}
+			if !invalid[idx] {
+				s.recordFailure(ctx, tokenStr, errorCode, response.Error.Error())
+			}
+		}
+
+		if err := s.deviceTokenRepo.MarkDelivered(ctx, delivered, time.Now()); err != nil {
+			log.Printf("Failed to mark device tokens delivered: %v", err)
 		}
+		s.pruneInvalidTokens(ctx, batch, invalid, batchResults)
+
+		results = append(results, batchResults...)
 	}
 
 	return results, nil
 }
 
+// invalidTokenIndexes marks the responses whose token FCM rejected for good. An invalid
+// argument only condemns the token when other tokens in the batch got past it; if every
+// token failed that way the message itself is malformed and the tokens are kept.
+func invalidTokenIndexes(responses []*messaging.SendResponse) map[int]bool {
+	invalid := make(map[int]bool)
+	allInvalidArgument := len(responses) > 0
+	for idx, response := range responses {
+		if response.Success || !messaging.IsInvalidArgument(response.Error) {
+			allInvalidArgument = false
+		}
+		if !response.Success && isInvalidTokenError(response.Error) {
+			invalid[idx] = true
+		}
+	}
+
+	if allInvalidArgument {
+		return map[int]bool{}
+	}
+	return invalid
+}
+
+// pruneInvalidTokens deletes the marked tokens of a batch and flags their results as pruned
+func (s *FCMPushService) pruneInvalidTokens(ctx context.Context, batch []string, invalid map[int]bool, results []notification.PushResult) {
+	if len(invalid) == 0 {
+		return
+	}
+
+	tokens := make([]string, 0, len(invalid))
+	for idx := range invalid {
+		tokens = append(tokens, batch[idx])
+	}
+
+	pruned, err := s.deviceTokenRepo.DeleteByTokens(ctx, tokens)
+	if err != nil {
+		log.Printf("Failed to prune invalid device tokens: %v", err)
+		return
+	}
+	for idx := range invalid {
+		results[idx].TokenPruned = true
+	}
+	log.Printf("Pruned %d invalid device tokens reported by FCM", pruned)
+}
+
+// maxTopicManagementTokens is the FCM limit of tokens per topic subscription request
+const maxTopicManagementTokens = 1000
+
+// manageTopic subscribes or unsubscribes tokens from a topic in batches
+func (s *FCMPushService) manageTopic(ctx context.Context, tokens []string, topic string, subscribe bool) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	if !config.IsFCMEnabled() {
+		return fmt.Errorf("FCM is not enabled")
+	}
+
+	fcmClient, err := config.GetFCMClient()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(tokens); i += maxTopicManagementTokens {
+		end := i + maxTopicManagementTokens
+		if end > len(tokens) {
+			end = len(tokens)
+		}
+
+		ctxTimeout, cancel := context.WithTimeout(ctx, s.cfg.FCMTimeout)
+		var resp *messaging.TopicManagementResponse
+		if subscribe {
+			resp, err = fcmClient.SubscribeToTopic(ctxTimeout, tokens[i:end], topic)
+		} else {
+			resp, err = fcmClient.UnsubscribeFromTopic(ctxTimeout, tokens[i:end], topic)
+		}
+		cancel()
+
+		if err != nil {
+			return fmt.Errorf("failed to update topic %s: %w", topic, err)
+		}
+		if resp.FailureCount > 0 {
+			reason := "unknown error"
+			if len(resp.Errors) > 0 {
+				reason = resp.Errors[0].Reason
+			}
+			return fmt.Errorf("failed to update topic %s for %d of %d tokens: %s", topic, resp.FailureCount, end-i, reason)
+		}
+	}
+
+	return nil
+}
+
 // buildFCMMessage builds FCM message from PushMessage
 func (s *FCMPushService) buildFCMMessage(token string, message *notification.PushMessage) *messaging.Message {
 	fcmMessage := &messaging.Message{
@@ -500,35 +666,56 @@ func (s *FCMPushService) getTTL(message *notification.PushMessage) *time.Duratio
 	return &ttl
 }
 
-// handleFCMError handles FCM-specific errors and updates token status
+// handleFCMError handles the error of a single-token send: unregistered tokens are deleted,
+// other failures are recorded on the token. An invalid argument is only recorded, since
+// with one token it cannot be told apart from a malformed message.
 func (s *FCMPushService) handleFCMError(ctx context.Context, token string, err error) (*notification.PushResult, error) {
-	errorCode := "UNKNOWN_ERROR"
-	errorMessage := err.Error()
-
-	// Parse FCM error codes
-	// Reference: https://firebase.google.com/docs/cloud-messaging/admin/errors
-	if messaging.IsInvalidArgument(err) {
-		errorCode = "INVALID_ARGUMENT"
-	} else if messaging.IsUnregistered(err) {
-		errorCode = "UNREGISTERED"
-		// Token is invalid, deactivate it
-		_ = s.deviceTokenRepo.Deactivate(ctx, token)
-		log.Printf("Deactivated invalid token: %s", token)
-	} else if messaging.IsInternal(err) || messaging.IsUnavailable(err) {
-		errorCode = "SERVER_ERROR"
-	} else if messaging.IsQuotaExceeded(err) {
-		errorCode = "QUOTA_EXCEEDED"
-	}
-
-	// Record failure
+	result := &notification.PushResult{
+		Success:      false,
+		ErrorCode:    fcmErrorCode(err),
+		ErrorMessage: err.Error(),
+	}
+
+	if messaging.IsUnregistered(err) {
+		if _, delErr := s.deviceTokenRepo.DeleteByTokens(ctx, []string{token}); delErr != nil {
+			log.Printf("Failed to prune invalid device token: %v", delErr)
+		} else {
+			result.TokenPruned = true
+			log.Printf("Pruned invalid device token (%s)", result.ErrorCode)
+		}
+		return result, nil
+	}
+
+	s.recordFailure(ctx, token, result.ErrorCode, result.ErrorMessage)
+	return result, nil
+}
+
+// recordFailure increments the failure count of a token that could not be reached
+func (s *FCMPushService) recordFailure(ctx context.Context, token, errorCode, errorMessage string) {
 	if deviceToken, err := s.deviceTokenRepo.FindByToken(ctx, token); err == nil {
 		deviceToken.RecordFailure(errorCode + ": " + errorMessage)
 		_ = s.deviceTokenRepo.Update(ctx, deviceToken)
 	}
+}
 
-	return &notification.PushResult{
-		Success:      false,
-		ErrorCode:    errorCode,
-		ErrorMessage: errorMessage,
-	}, nil
+// fcmErrorCode maps an FCM send error to the result error code
+// Reference: https://firebase.google.com/docs/cloud-messaging/admin/errors
+func fcmErrorCode(err error) string {
+	switch {
+	case messaging.IsInvalidArgument(err):
+		return "INVALID_ARGUMENT"
+	case messaging.IsUnregistered(err):
+		return "UNREGISTERED"
+	case messaging.IsInternal(err) || messaging.IsUnavailable(err):
+		return "SERVER_ERROR"
+	case messaging.IsQuotaExceeded(err):
+		return "QUOTA_EXCEEDED"
+	default:
+		return "UNKNOWN_ERROR"
+	}
+}
+
+// isInvalidTokenError reports whether FCM rejected the token itself, so sending to it again can never succeed
+func isInvalidTokenError(err error) bool {
+	return messaging.IsUnregistered(err) || messaging.IsInvalidArgument(err)
 }
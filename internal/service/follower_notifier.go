@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/notification"
+	"keerja-backend/internal/domain/user"
+)
+
+// followerNotifyWindow is how long a job's followers are not notified again after a republish
+const followerNotifyWindow = 24 * time.Hour
+
+// followerBatchSize is how many followers are loaded and pushed to per batch
+const followerBatchSize = 500
+
+// followerNotifyTimeout bounds a background fan-out started by a publish
+const followerNotifyTimeout = 10 * time.Minute
+
+// followerNotifier implements notification.FollowerNotifier
+type followerNotifier struct {
+	jobRepo      job.JobRepository
+	companyRepo  company.CompanyRepository
+	userRepo     user.UserRepository
+	emailService email.EmailService
+	dispatcher   notification.NotificationDispatcher
+}
+
+// NewFollowerNotifier creates a new follower notifier instance
+func NewFollowerNotifier(
+	jobRepo job.JobRepository,
+	companyRepo company.CompanyRepository,
+	userRepo user.UserRepository,
+	emailService email.EmailService,
+	dispatcher notification.NotificationDispatcher,
+) notification.FollowerNotifier {
+	return &followerNotifier{
+		jobRepo:      jobRepo,
+		companyRepo:  companyRepo,
+		userRepo:     userRepo,
+		emailService: emailService,
+		dispatcher:   dispatcherOrDefault(dispatcher, userRepo),
+	}
+}
+
+// NotifyJobPublished fans out the new-job notification to the company's followers in batches
+func (n *followerNotifier) NotifyJobPublished(ctx context.Context, jobID int64) (*notification.FollowerNotifyStats, error) {
+	stats := &notification.FollowerNotifyStats{}
+
+	marked, err := n.jobRepo.MarkFollowersNotified(ctx, jobID, followerNotifyWindow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark followers notified: %w", err)
+	}
+	if !marked {
+		stats.Skipped = true
+		return stats, nil
+	}
+
+	j, err := n.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, jobLookupError(err)
+	}
+	if j == nil {
+		return nil, job.ErrJobNotFound
+	}
+
+	comp, err := n.companyRepo.FindByID(ctx, j.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	if comp == nil {
+		return nil, company.ErrCompanyNotFound
+	}
+
+	message := &notification.PushMessage{
+		Title:    "New Job Posted",
+		Body:     fmt.Sprintf("New job at %s: %s", comp.CompanyName, j.Title),
+		Priority: "normal",
+		Data: map[string]string{
+			"type":       "followed_company_job",
+			"job_id":     fmt.Sprintf("%d", j.ID),
+			"company_id": fmt.Sprintf("%d", comp.ID),
+		},
+	}
+
+	for page := 1; ; page++ {
+		followers, _, err := n.companyRepo.GetFollowers(ctx, j.CompanyID, page, followerBatchSize)
+		if err != nil {
+			return stats, fmt.Errorf("failed to get followers: %w", err)
+		}
+		stats.Followers += len(followers)
+
+		n.notifyBatch(ctx, followers, j, comp, message, stats)
+
+		if len(followers) < followerBatchSize {
+			return stats, nil
+		}
+	}
+}
+
+// notifyBatch emails followers individually and dispatches one push for the whole batch.
+// The dispatcher skips followers who muted followed company notifications on a channel.
+// Delivery failures are logged and do not stop the fan-out.
+func (n *followerNotifier) notifyBatch(
+	ctx context.Context,
+	followers []company.CompanyFollower,
+	j *job.Job,
+	comp *company.Company,
+	message *notification.PushMessage,
+	stats *notification.FollowerNotifyStats,
+) {
+	userIDs := make([]int64, 0, len(followers))
+	for _, follower := range followers {
+		userIDs = append(userIDs, follower.UserID)
+		if n.emailService == nil {
+			continue
+		}
+
+		sent, err := n.dispatcher.Email(ctx, follower.UserID, user.NotificationEventCompanyFollows, func(ctx context.Context) error {
+			usr, err := n.userRepo.FindByID(ctx, follower.UserID)
+			if err != nil {
+				return fmt.Errorf("failed to get follower: %w", err)
+			}
+			if usr == nil {
+				return errors.New("follower not found")
+			}
+			return n.emailService.SendFollowedCompanyJobEmail(ctx, usr.Email, usr.FullName, comp.CompanyName, j.Title, j.Slug)
+		})
+		if err != nil {
+			log.Printf("Failed to email follower %d about job %d: %v", follower.UserID, j.ID, err)
+			continue
+		}
+		if sent {
+			stats.EmailsSent++
+		}
+	}
+
+	pushStats, err := n.dispatcher.PushToUsers(ctx, userIDs, user.NotificationEventCompanyFollows, message)
+	if err != nil {
+		log.Printf("Failed to push job %d to followers: %v", j.ID, err)
+	}
+	stats.PushesSent += pushStats.Sent
+}
+
+// notifyFollowersAsync starts the follower fan-out for a newly published job without
+// blocking the caller, logging how many notifications were sent
+func notifyFollowersAsync(notifier notification.FollowerNotifier, jobID int64) {
+	if notifier == nil {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), followerNotifyTimeout)
+		defer cancel()
+
+		stats, err := notifier.NotifyJobPublished(ctx, jobID)
+		if err != nil {
+			log.Printf("Follower notification for job %d failed: %v", jobID, err)
+		}
+		if stats == nil {
+			return
+		}
+		if stats.Skipped {
+			log.Printf("Follower notification for job %d skipped: already sent within %s", jobID, followerNotifyWindow)
+			return
+		}
+		log.Printf("Follower notification for job %d: followers=%d emails_sent=%d pushes_sent=%d",
+			jobID, stats.Followers, stats.EmailsSent, stats.PushesSent)
+	}()
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Geocoding providers
+const (
+	GeocoderNominatim = "nominatim"
+	GeocoderGoogle    = "google"
+)
+
+// ErrGeocodeNoResult is returned when a provider cannot place an address
+var ErrGeocodeNoResult = errors.New("address could not be geocoded")
+
+// GeocodeResult is the position a provider resolved an address to
+type GeocodeResult struct {
+	Latitude   float64
+	Longitude  float64
+	Provider   string
+	Confidence float64 // 0..1, provider-specific quality of the match
+}
+
+// Geocoder resolves a free-text address to coordinates
+type Geocoder interface {
+	Geocode(ctx context.Context, address string) (*GeocodeResult, error)
+}
+
+// GeocoderConfig holds settings for the geocoding provider
+type GeocoderConfig struct {
+	Provider string // "nominatim", "google"; anything else disables geocoding
+	BaseURL  string // Overrides the provider endpoint, e.g. a self-hosted Nominatim
+	APIKey   string // Required for Google
+	Timeout  time.Duration
+}
+
+// NewGeocoder creates the geocoder selected by cfg.Provider, or a no-op geocoder when
+// geocoding is not configured
+func NewGeocoder(cfg GeocoderConfig) (Geocoder, error) {
+	client := &http.Client{Timeout: cfg.Timeout}
+	if cfg.Timeout <= 0 {
+		client.Timeout = 5 * time.Second
+	}
+
+	switch cfg.Provider {
+	case GeocoderNominatim:
+		baseURL := cfg.BaseURL
+		if baseURL == "" {
+			baseURL = "https://nominatim.openstreetmap.org/search"
+		}
+		return &nominatimGeocoder{client: client, baseURL: baseURL}, nil
+	case GeocoderGoogle:
+		if cfg.APIKey == "" {
+			return nil, errors.New("google geocoder requires an API key")
+		}
+		baseURL := cfg.BaseURL
+		if baseURL == "" {
+			baseURL = "https://maps.googleapis.com/maps/api/geocode/json"
+		}
+		return &googleGeocoder{client: client, baseURL: baseURL, apiKey: cfg.APIKey}, nil
+	default:
+		return NewNoopGeocoder(), nil
+	}
+}
+
+// noopGeocoder never resolves addresses
+type noopGeocoder struct{}
+
+// NewNoopGeocoder creates a geocoder that leaves addresses without coordinates
+func NewNoopGeocoder() Geocoder {
+	return noopGeocoder{}
+}
+
+// Geocode always reports that the address could not be placed
+func (noopGeocoder) Geocode(ctx context.Context, address string) (*GeocodeResult, error) {
+	return nil, ErrGeocodeNoResult
+}
+
+// nominatimGeocoder implements Geocoder with the OpenStreetMap Nominatim search API
+type nominatimGeocoder struct {
+	client  *http.Client
+	baseURL string
+}
+
+// Geocode looks up the best Nominatim match for address within Indonesia
+func (g *nominatimGeocoder) Geocode(ctx context.Context, address string) (*GeocodeResult, error) {
+	params := url.Values{}
+	params.Set("q", address)
+	params.Set("format", "jsonv2")
+	params.Set("limit", "1")
+	params.Set("countrycodes", "id")
+
+	var places []struct {
+		Lat        string  `json:"lat"`
+		Lon        string  `json:"lon"`
+		Importance float64 `json:"importance"`
+	}
+	if err := getGeocodeJSON(ctx, g.client, g.baseURL+"?"+params.Encode(), &places); err != nil {
+		return nil, err
+	}
+	if len(places) == 0 {
+		return nil, ErrGeocodeNoResult
+	}
+
+	lat, err := strconv.ParseFloat(places[0].Lat, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nominatim latitude %q: %w", places[0].Lat, err)
+	}
+	lng, err := strconv.ParseFloat(places[0].Lon, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nominatim longitude %q: %w", places[0].Lon, err)
+	}
+
+	return &GeocodeResult{
+		Latitude:   lat,
+		Longitude:  lng,
+		Provider:   GeocoderNominatim,
+		Confidence: clampConfidence(places[0].Importance),
+	}, nil
+}
+
+// googleGeocoder implements Geocoder with the Google Maps Geocoding API
+type googleGeocoder struct {
+	client  *http.Client
+	baseURL string
+	apiKey  string
+}
+
+// googleLocationConfidence rates Google's location_type from exact to approximate
+var googleLocationConfidence = map[string]float64{
+	"ROOFTOP":            1.0,
+	"RANGE_INTERPOLATED": 0.8,
+	"GEOMETRIC_CENTER":   0.6,
+	"APPROXIMATE":        0.4,
+}
+
+// Geocode looks up the best Google match for address, biased to Indonesia
+func (g *googleGeocoder) Geocode(ctx context.Context, address string) (*GeocodeResult, error) {
+	params := url.Values{}
+	params.Set("address", address)
+	params.Set("region", "id")
+	params.Set("key", g.apiKey)
+
+	var body struct {
+		Status  string `json:"status"`
+		Results []struct {
+			PartialMatch bool `json:"partial_match"`
+			Geometry     struct {
+				Location struct {
+					Lat float64 `json:"lat"`
+					Lng float64 `json:"lng"`
+				} `json:"location"`
+				LocationType string `json:"location_type"`
+			} `json:"geometry"`
+		} `json:"results"`
+	}
+	if err := getGeocodeJSON(ctx, g.client, g.baseURL+"?"+params.Encode(), &body); err != nil {
+		return nil, err
+	}
+	if body.Status == "ZERO_RESULTS" || (body.Status == "OK" && len(body.Results) == 0) {
+		return nil, ErrGeocodeNoResult
+	}
+	if body.Status != "OK" {
+		return nil, fmt.Errorf("google geocoding failed with status %s", body.Status)
+	}
+
+	best := body.Results[0]
+	confidence := googleLocationConfidence[best.Geometry.LocationType]
+	if best.PartialMatch {
+		confidence /= 2
+	}
+
+	return &GeocodeResult{
+		Latitude:   best.Geometry.Location.Lat,
+		Longitude:  best.Geometry.Location.Lng,
+		Provider:   GeocoderGoogle,
+		Confidence: confidence,
+	}, nil
+}
+
+// getGeocodeJSON performs a GET request and decodes the JSON response into out
+func getGeocodeJSON(ctx context.Context, client *http.Client, rawURL string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	// Nominatim's usage policy requires an identifying user agent
+	req.Header.Set("User-Agent", "keerja-backend/1.0")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("geocoding request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("geocoding request failed with status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid geocoding response: %w", err)
+	}
+	return nil
+}
+
+func clampConfidence(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
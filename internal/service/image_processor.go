@@ -0,0 +1,385 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // register the GIF decoder
+	"image/jpeg"
+	"image/png"
+	"math"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // register the WebP decoder
+
+	"keerja-backend/internal/apperror"
+)
+
+// Image processing errors
+var (
+	// ErrImageTooSmall is returned for images below the minimum dimensions of their kind
+	ErrImageTooSmall = apperror.Validation("IMAGE_TOO_SMALL", "image is smaller than the minimum allowed dimensions")
+	// ErrImageUnprocessable is returned for files that cannot be decoded or resized; callers
+	// store such files as uploaded
+	ErrImageUnprocessable = errors.New("image cannot be processed")
+)
+
+// maxImagePixels bounds the decoded size of an image so a small file cannot expand into a
+// huge bitmap
+const maxImagePixels = 50_000_000
+
+// imageJPEGQuality is the quality JPEG variants and re-encoded originals are written at
+const imageJPEGQuality = 85
+
+// Image variant formats
+const (
+	ImageFormatWebP = "webp"
+	ImageFormatJPEG = "jpeg"
+)
+
+// ImageVariant is a resized copy of an image, scaled down to fit Width x Height.
+// A zero Height scales to Width keeping the aspect ratio.
+type ImageVariant struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+// ImageSpec holds the minimum dimensions and the variants of one kind of image
+type ImageSpec struct {
+	MinWidth  int
+	MinHeight int
+	Variants  []ImageVariant
+}
+
+// Image specs per kind of upload
+var (
+	LogoImageSpec = ImageSpec{
+		MinWidth:  200,
+		MinHeight: 200,
+		Variants:  []ImageVariant{{Name: "200", Width: 200, Height: 200}, {Name: "400", Width: 400, Height: 400}},
+	}
+	BannerImageSpec = ImageSpec{
+		MinWidth:  1200,
+		MinHeight: 300,
+		Variants:  []ImageVariant{{Name: "1200", Width: 1200}},
+	}
+	AvatarImageSpec = LogoImageSpec
+	CoverImageSpec  = BannerImageSpec
+)
+
+// ImageVariantKey returns the key a variant in the given format is stored under, e.g. 200_webp
+func ImageVariantKey(name, format string) string {
+	return name + "_" + format
+}
+
+// EncodedImage is an image encoded for storage
+type EncodedImage struct {
+	Data        []byte
+	Ext         string
+	ContentType string
+}
+
+// EncodedVariant is one variant of a processed image in one format
+type EncodedVariant struct {
+	Name   string
+	Format string
+	EncodedImage
+}
+
+// ProcessedImage is an uploaded image with its metadata stripped and its variants rendered
+type ProcessedImage struct {
+	Original EncodedImage
+	Variants []EncodedVariant
+}
+
+// ImageProcessor validates uploaded images and renders their variants
+type ImageProcessor interface {
+	// Process checks data against spec and renders each of its variants as WebP and JPEG.
+	// It returns ErrImageTooSmall for images below the minimum dimensions and wraps
+	// ErrImageUnprocessable for data it cannot decode.
+	Process(data []byte, spec ImageSpec) (*ProcessedImage, error)
+}
+
+// imageProcessor implements ImageProcessor with pure Go codecs
+type imageProcessor struct{}
+
+// NewImageProcessor creates a new image processor
+func NewImageProcessor() ImageProcessor {
+	return &imageProcessor{}
+}
+
+// Process checks data against spec and renders its variants
+func (p *imageProcessor) Process(data []byte, spec ImageSpec) (*ProcessedImage, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrImageUnprocessable, err)
+	}
+
+	orientation := 1
+	if format == "jpeg" {
+		orientation = jpegOrientation(data)
+	}
+	width, height := cfg.Width, cfg.Height
+	if orientation >= 5 {
+		width, height = height, width
+	}
+	if width < spec.MinWidth || height < spec.MinHeight {
+		return nil, ErrImageTooSmall.WithField("file", fmt.Sprintf("must be at least %dx%d pixels, got %dx%d", spec.MinWidth, spec.MinHeight, width, height))
+	}
+	if width*height > maxImagePixels {
+		return nil, fmt.Errorf("%w: %dx%d exceeds %d pixels", ErrImageUnprocessable, width, height, maxImagePixels)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrImageUnprocessable, err)
+	}
+	img = applyOrientation(img, orientation)
+
+	original, err := encodeOriginal(data, img, format, orientation)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrImageUnprocessable, err)
+	}
+
+	processed := &ProcessedImage{Original: original}
+	for _, variant := range spec.Variants {
+		resized := resizeToFit(img, variant.Width, variant.Height)
+
+		webpData, err := encodeWebP(resized)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrImageUnprocessable, err)
+		}
+		jpegData, err := encodeJPEG(resized)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrImageUnprocessable, err)
+		}
+
+		processed.Variants = append(processed.Variants,
+			EncodedVariant{Name: variant.Name, Format: ImageFormatWebP, EncodedImage: EncodedImage{Data: webpData, Ext: ".webp", ContentType: "image/webp"}},
+			EncodedVariant{Name: variant.Name, Format: ImageFormatJPEG, EncodedImage: EncodedImage{Data: jpegData, Ext: ".jpg", ContentType: "image/jpeg"}},
+		)
+	}
+
+	return processed, nil
+}
+
+// encodeOriginal returns the uploaded image without its metadata, in its own format. JPEGs
+// keep their compressed data unless they had to be rotated upright; GIFs carry no EXIF and
+// are kept as uploaded so animations survive.
+func encodeOriginal(data []byte, img image.Image, format string, orientation int) (EncodedImage, error) {
+	switch format {
+	case "jpeg":
+		if orientation == 1 {
+			if stripped, ok := stripJPEGMetadata(data); ok {
+				return EncodedImage{Data: stripped, Ext: ".jpg", ContentType: "image/jpeg"}, nil
+			}
+		}
+		encoded, err := encodeJPEG(img)
+		return EncodedImage{Data: encoded, Ext: ".jpg", ContentType: "image/jpeg"}, err
+	case "png":
+		var buf bytes.Buffer
+		err := png.Encode(&buf, img)
+		return EncodedImage{Data: buf.Bytes(), Ext: ".png", ContentType: "image/png"}, err
+	case "gif":
+		return EncodedImage{Data: data, Ext: ".gif", ContentType: "image/gif"}, nil
+	case "webp":
+		encoded, err := encodeWebP(img)
+		return EncodedImage{Data: encoded, Ext: ".webp", ContentType: "image/webp"}, err
+	default:
+		return EncodedImage{}, fmt.Errorf("unsupported image format %q", format)
+	}
+}
+
+// encodeWebP encodes img as lossless WebP
+func encodeWebP(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := nativewebp.Encode(&buf, img, nil); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeJPEG encodes img as JPEG, flattening transparency onto white
+func encodeJPEG(img image.Image) ([]byte, error) {
+	if opaque, ok := img.(interface{ Opaque() bool }); !ok || !opaque.Opaque() {
+		bounds := img.Bounds()
+		flat := image.NewRGBA(bounds)
+		draw.Draw(flat, bounds, image.White, image.Point{}, draw.Src)
+		draw.Draw(flat, bounds, img, bounds.Min, draw.Over)
+		img = flat
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: imageJPEGQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// resizeToFit scales img down to fit maxWidth x maxHeight keeping its aspect ratio; a zero
+// maxHeight only bounds the width. Images that already fit are returned unchanged.
+func resizeToFit(img image.Image, maxWidth, maxHeight int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	scale := float64(maxWidth) / float64(width)
+	if maxHeight > 0 {
+		scale = math.Min(scale, float64(maxHeight)/float64(height))
+	}
+	if scale >= 1 {
+		return img
+	}
+
+	dstWidth := max(1, int(math.Round(float64(width)*scale)))
+	dstHeight := max(1, int(math.Round(float64(height)*scale)))
+	dst := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+// applyOrientation turns img upright according to its EXIF orientation (1-8)
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // mirrored horizontally
+				dx, dy = width-1-x, y
+			case 3: // rotated 180°
+				dx, dy = width-1-x, height-1-y
+			case 4: // mirrored vertically
+				dx, dy = x, height-1-y
+			case 5: // transposed
+				dx, dy = y, x
+			case 6: // rotated 90° clockwise
+				dx, dy = height-1-y, x
+			case 7: // transversed
+				dx, dy = height-1-y, width-1-x
+			case 8: // rotated 90° counter-clockwise
+				dx, dy = y, width-1-x
+			}
+			dst.Set(dx, dy, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return dst
+}
+
+// JPEG markers read while walking the segments before the image data
+const (
+	jpegMarkerSOS   = 0xDA // start of scan, followed by the compressed image data
+	jpegMarkerAPP1  = 0xE1 // EXIF and XMP
+	jpegMarkerAPP13 = 0xED // Photoshop IPTC
+	jpegMarkerCOM   = 0xFE // comment
+)
+
+// jpegSegments calls fn with the marker and bytes of each segment before the image data and
+// returns the offset of the start of scan marker, or false for a malformed file
+func jpegSegments(data []byte, fn func(marker byte, segment []byte)) (int, bool) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 0, false
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 0, false
+		}
+		marker := data[i+1]
+		if marker == jpegMarkerSOS {
+			return i, true
+		}
+		size := int(binary.BigEndian.Uint16(data[i+2:]))
+		if size < 2 || i+2+size > len(data) {
+			return 0, false
+		}
+		fn(marker, data[i:i+2+size])
+		i += 2 + size
+	}
+	return 0, false
+}
+
+// stripJPEGMetadata drops the EXIF, XMP, IPTC and comment segments of a JPEG without
+// re-encoding it. Color profile segments are kept.
+func stripJPEGMetadata(data []byte) ([]byte, bool) {
+	stripped := make([]byte, 2, len(data))
+	copy(stripped, data[:2])
+
+	scan, ok := jpegSegments(data, func(marker byte, segment []byte) {
+		switch marker {
+		case jpegMarkerAPP1, jpegMarkerAPP13, jpegMarkerCOM:
+		default:
+			stripped = append(stripped, segment...)
+		}
+	})
+	if !ok {
+		return nil, false
+	}
+	return append(stripped, data[scan:]...), true
+}
+
+// jpegOrientation returns the EXIF orientation of a JPEG, or 1 when it has none
+func jpegOrientation(data []byte) int {
+	orientation := 1
+	jpegSegments(data, func(marker byte, segment []byte) {
+		if marker == jpegMarkerAPP1 && orientation == 1 {
+			if o := exifOrientation(segment[4:]); o > 0 {
+				orientation = o
+			}
+		}
+	})
+	return orientation
+}
+
+// exifOrientation reads the orientation tag from the first IFD of an APP1 EXIF payload
+func exifOrientation(payload []byte) int {
+	const orientationTag = 0x0112
+
+	if len(payload) < 14 || string(payload[:6]) != "Exif\x00\x00" {
+		return 0
+	}
+	tiff := payload[6:]
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for n := 0; n < entries; n++ {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:]) == orientationTag {
+			if o := int(order.Uint16(tiff[entry+8:])); o >= 1 && o <= 8 {
+				return o
+			}
+			return 0
+		}
+	}
+	return 0
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"keerja-backend/internal/domain/admin"
+	"keerja-backend/internal/domain/audit"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/utils"
+)
+
+// impersonationService implements admin.ImpersonationService
+type impersonationService struct {
+	repo         admin.ImpersonationRepository
+	userRepo     user.UserRepository
+	auditService audit.AuditService
+	jwtSecret    string
+	ttl          time.Duration // How long an impersonation token stays valid
+}
+
+// NewImpersonationService creates a new impersonation service issuing tokens valid for ttl
+func NewImpersonationService(
+	repo admin.ImpersonationRepository,
+	userRepo user.UserRepository,
+	auditService audit.AuditService,
+	jwtSecret string,
+	ttl time.Duration,
+) admin.ImpersonationService {
+	return &impersonationService{
+		repo:         repo,
+		userRepo:     userRepo,
+		auditService: auditService,
+		jwtSecret:    jwtSecret,
+		ttl:          ttl,
+	}
+}
+
+// StartImpersonation opens a session for the admin to act as the user. Admin accounts and
+// deleted users cannot be impersonated.
+func (s *impersonationService) StartImpersonation(ctx context.Context, adminID, userID int64, reason string) (*admin.ImpersonationGrant, error) {
+	target, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if target == nil || target.Status == "deleted" {
+		return nil, ErrUserNotFound
+	}
+	if target.UserType == "admin" {
+		return nil, admin.ErrCannotImpersonateAdmin
+	}
+
+	session := &admin.ImpersonationSession{
+		AdminID:   adminID,
+		UserID:    target.ID,
+		Reason:    reason,
+		ExpiresAt: time.Now().Add(s.ttl),
+	}
+	if err := s.repo.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create impersonation session: %w", err)
+	}
+
+	token, err := utils.GenerateImpersonationToken(target.ID, target.Email, target.UserType, adminID, session.ID, s.jwtSecret, s.ttl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate impersonation token: %w", err)
+	}
+
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		ActorType:  audit.ActorAdmin,
+		ActorID:    &adminID,
+		Action:     audit.ActionImpersonationStarted,
+		EntityType: audit.EntityUser,
+		EntityID:   target.ID,
+		Metadata:   audit.Metadata{"session_id": session.ID, "reason": reason, "expires_at": session.ExpiresAt},
+	})
+
+	return &admin.ImpersonationGrant{Session: session, AccessToken: token}, nil
+}
+
+// EndImpersonation ends a session the admin started. Ending a session that already ended
+// or expired succeeds without another audit entry.
+func (s *impersonationService) EndImpersonation(ctx context.Context, adminID, sessionID int64) error {
+	session, err := s.repo.FindByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to find impersonation session: %w", err)
+	}
+	if session == nil || session.AdminID != adminID {
+		return admin.ErrImpersonationNotFound
+	}
+
+	now := time.Now()
+	if !session.IsActive(now) {
+		return nil
+	}
+	if err := s.repo.End(ctx, sessionID, now); err != nil {
+		return fmt.Errorf("failed to end impersonation session: %w", err)
+	}
+
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		ActorType:  audit.ActorAdmin,
+		ActorID:    &adminID,
+		Action:     audit.ActionImpersonationEnded,
+		EntityType: audit.EntityUser,
+		EntityID:   session.UserID,
+		Metadata:   audit.Metadata{"session_id": session.ID},
+	})
+	return nil
+}
+
+// IsImpersonationActive checks if the session was not ended and has not expired
+func (s *impersonationService) IsImpersonationActive(ctx context.Context, sessionID int64) (bool, error) {
+	session, err := s.repo.FindByID(ctx, sessionID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find impersonation session: %w", err)
+	}
+	return session != nil && session.IsActive(time.Now()), nil
+}
+
+// RecordImpersonatedRequest records the request against the impersonated user, attributed
+// to the admin
+func (s *impersonationService) RecordImpersonatedRequest(ctx context.Context, req admin.ImpersonatedRequest) {
+	adminID := req.AdminID
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		ActorType:  audit.ActorAdmin,
+		ActorID:    &adminID,
+		Action:     audit.ActionImpersonationRequest,
+		EntityType: audit.EntityUser,
+		EntityID:   req.UserID,
+		Metadata: audit.Metadata{
+			"session_id": req.SessionID,
+			"method":     req.Method,
+			"path":       req.Path,
+			"status":     req.Status,
+			"blocked":    req.Blocked,
+		},
+	})
+}
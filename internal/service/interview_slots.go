@@ -0,0 +1,440 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// bookingTokenLength is the number of random bytes in an interview booking link token
+const bookingTokenLength = 32
+
+// ===== Interview Slots =====
+
+// CreateInterviewSlots expands the batch into slots for the interviewer, who defaults to the
+// recruiter creating them. Slots the interviewer already has at the same time are skipped.
+func (s *applicationService) CreateInterviewSlots(ctx context.Context, companyID, createdBy int64, req *application.CreateInterviewSlotsRequest) (*application.InterviewSlotBatchResult, error) {
+	interviewerID := createdBy
+	if req.InterviewerID != nil && *req.InterviewerID != createdBy {
+		if err := s.checkCompanyEmployerAccess(ctx, companyID, *req.InterviewerID); err != nil {
+			return nil, application.ErrInvalidInterviewSlots.WithField("interviewer_id", "must be a member of the company")
+		}
+		interviewerID = *req.InterviewerID
+	}
+
+	slots, err := buildInterviewSlots(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	for i := range slots {
+		slots[i].CompanyID = companyID
+		slots[i].InterviewerID = interviewerID
+		slots[i].CreatedBy = createdBy
+	}
+
+	created, err := s.appRepo.CreateInterviewSlots(ctx, slots)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create interview slots: %w", err)
+	}
+
+	return &application.InterviewSlotBatchResult{Created: created, Skipped: int64(len(slots)) - created}, nil
+}
+
+// ListInterviewSlots lists the company's slots by start time, from now unless filter.From is set
+func (s *applicationService) ListInterviewSlots(ctx context.Context, companyID int64, filter application.InterviewSlotFilter) ([]application.InterviewSlot, error) {
+	if filter.Status != "" && filter.Status != application.InterviewSlotOpen && filter.Status != application.InterviewSlotBooked {
+		return nil, application.ErrInvalidInterviewSlots.WithField("status", "must be open or booked")
+	}
+	if filter.From == nil {
+		now := time.Now()
+		filter.From = &now
+	}
+	if filter.Limit <= 0 || filter.Limit > application.MaxInterviewSlotsListed {
+		filter.Limit = application.MaxInterviewSlotsListed
+	}
+
+	return s.appRepo.ListInterviewSlots(ctx, companyID, filter)
+}
+
+// UpdateInterviewSlot moves an open slot and replaces its meeting details
+func (s *applicationService) UpdateInterviewSlot(ctx context.Context, companyID, slotID int64, req *application.UpdateInterviewSlotRequest) (*application.InterviewSlot, error) {
+	slot, err := s.findCompanyInterviewSlot(ctx, companyID, slotID)
+	if err != nil {
+		return nil, err
+	}
+	if slot.IsBooked() {
+		return nil, application.ErrInterviewSlotBooked
+	}
+
+	timezone, err := resolveInterviewTimezone(req.Timezone, slot.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	if !req.StartsAt.After(time.Now()) {
+		return nil, application.ErrInvalidInterviewSlots.WithField("starts_at", "must be in the future")
+	}
+
+	slot.StartsAt = req.StartsAt.UTC()
+	slot.EndsAt = slot.StartsAt.Add(time.Duration(req.DurationMinutes) * time.Minute)
+	slot.Timezone = timezone
+	if req.InterviewType != "" {
+		slot.InterviewType = req.InterviewType
+	}
+	slot.MeetingLink = req.MeetingLink
+	slot.Location = req.Location
+
+	if err := s.appRepo.UpdateInterviewSlot(ctx, slot); err != nil {
+		if errors.Is(err, application.ErrInterviewSlotBooked) || errors.Is(err, application.ErrInterviewSlotExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update interview slot: %w", err)
+	}
+
+	return slot, nil
+}
+
+// DeleteInterviewSlot deletes an open slot
+func (s *applicationService) DeleteInterviewSlot(ctx context.Context, companyID, slotID int64) error {
+	slot, err := s.findCompanyInterviewSlot(ctx, companyID, slotID)
+	if err != nil {
+		return err
+	}
+	if slot.IsBooked() {
+		return application.ErrInterviewSlotBooked
+	}
+
+	if err := s.appRepo.DeleteInterviewSlot(ctx, slot.ID); err != nil {
+		if errors.Is(err, application.ErrInterviewSlotBooked) {
+			return err
+		}
+		return fmt.Errorf("failed to delete interview slot: %w", err)
+	}
+	return nil
+}
+
+// findCompanyInterviewSlot loads a slot, treating slots of other companies as missing
+func (s *applicationService) findCompanyInterviewSlot(ctx context.Context, companyID, slotID int64) (*application.InterviewSlot, error) {
+	slot, err := s.appRepo.FindInterviewSlotByID(ctx, slotID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, application.ErrInterviewSlotNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get interview slot: %w", err)
+	}
+	if slot.CompanyID != companyID {
+		return nil, application.ErrInterviewSlotNotFound
+	}
+	return slot, nil
+}
+
+// buildInterviewSlots expands a slot batch into its slots that start after now. Days and
+// working hours are read in the batch's timezone; the slots are returned in UTC.
+func buildInterviewSlots(req *application.CreateInterviewSlotsRequest, now time.Time) ([]application.InterviewSlot, error) {
+	timezone, err := resolveInterviewTimezone(req.Timezone, "UTC")
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, application.ErrInvalidTimezone
+	}
+
+	startDate, err := time.ParseInLocation(time.DateOnly, req.StartDate, loc)
+	if err != nil {
+		return nil, application.ErrInvalidInterviewSlots.WithField("start_date", "must be a date in YYYY-MM-DD format")
+	}
+	endDate, err := time.ParseInLocation(time.DateOnly, req.EndDate, loc)
+	if err != nil {
+		return nil, application.ErrInvalidInterviewSlots.WithField("end_date", "must be a date in YYYY-MM-DD format")
+	}
+	if endDate.Before(startDate) {
+		return nil, application.ErrInvalidInterviewSlots.WithField("end_date", "must not be before start_date")
+	}
+	if endDate.After(startDate.AddDate(0, 0, application.MaxInterviewSlotBatchDays-1)) {
+		return nil, application.ErrInvalidInterviewSlots.WithField("end_date", fmt.Sprintf("must be within %d days of start_date", application.MaxInterviewSlotBatchDays))
+	}
+
+	dayStart, err := time.Parse("15:04", req.DayStart)
+	if err != nil {
+		return nil, application.ErrInvalidInterviewSlots.WithField("day_start", "must be a time in HH:MM format")
+	}
+	dayEnd, err := time.Parse("15:04", req.DayEnd)
+	if err != nil {
+		return nil, application.ErrInvalidInterviewSlots.WithField("day_end", "must be a time in HH:MM format")
+	}
+	if !dayEnd.After(dayStart) {
+		return nil, application.ErrInvalidInterviewSlots.WithField("day_end", "must be after day_start")
+	}
+
+	duration := time.Duration(req.DurationMinutes) * time.Minute
+	step := duration + time.Duration(req.BufferMinutes)*time.Minute
+	if duration <= 0 {
+		return nil, application.ErrInvalidInterviewSlots.WithField("duration_minutes", "must be positive")
+	}
+
+	interviewType := req.InterviewType
+	if interviewType == "" {
+		interviewType = "online"
+	}
+
+	var slots []application.InterviewSlot
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		if !req.IncludeWeekends && (day.Weekday() == time.Saturday || day.Weekday() == time.Sunday) {
+			continue
+		}
+
+		opens := time.Date(day.Year(), day.Month(), day.Day(), dayStart.Hour(), dayStart.Minute(), 0, 0, loc)
+		closes := time.Date(day.Year(), day.Month(), day.Day(), dayEnd.Hour(), dayEnd.Minute(), 0, 0, loc)
+		for start := opens; !start.Add(duration).After(closes); start = start.Add(step) {
+			if !start.After(now) {
+				continue
+			}
+			if len(slots) == application.MaxInterviewSlotsPerBatch {
+				return nil, application.ErrInvalidInterviewSlots.WithMessage(fmt.Sprintf("a batch can create at most %d slots", application.MaxInterviewSlotsPerBatch))
+			}
+
+			slots = append(slots, application.InterviewSlot{
+				StartsAt:      start.UTC(),
+				EndsAt:        start.Add(duration).UTC(),
+				Timezone:      timezone,
+				InterviewType: interviewType,
+				MeetingLink:   req.MeetingLink,
+				Location:      req.Location,
+			})
+		}
+	}
+
+	if len(slots) == 0 {
+		return nil, application.ErrInvalidInterviewSlots.WithMessage("the batch has no slots in the future")
+	}
+	return slots, nil
+}
+
+// ===== Candidate Self-Scheduling =====
+
+// SendInterviewBookingLink emails the candidate a link to book one of the company's open
+// slots, moving the application to the interview stage first if needed
+func (s *applicationService) SendInterviewBookingLink(ctx context.Context, ec *employer.EmployerContext, applicationID int64, req *application.SendBookingLinkRequest) (*application.InterviewBookingLinkResult, error) {
+	app, err := s.findEmployerApplication(ctx, ec, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if app.IsCompleted() {
+		return nil, application.ErrApplicationCompleted
+	}
+
+	now := time.Now()
+	open, err := s.appRepo.ListInterviewSlots(ctx, *app.CompanyID, application.InterviewSlotFilter{
+		From:   &now,
+		Status: application.InterviewSlotOpen,
+		Limit:  1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check open interview slots: %w", err)
+	}
+	if len(open) == 0 {
+		return nil, application.ErrNoOpenInterviewSlots
+	}
+
+	if app.Status != application.StatusInterview && app.Status != application.StatusOffered {
+		if err := s.advanceApplicationStage(ctx, app, ec.UserID, application.StatusInterview, application.StatusInterview, "Interview scheduled", "Interview booking link sent"); err != nil {
+			return nil, err
+		}
+	}
+
+	ttl := application.DefaultBookingLinkTTL
+	if req != nil && req.ExpiresInHours > 0 {
+		ttl = min(time.Duration(req.ExpiresInHours)*time.Hour, application.MaxBookingLinkTTL)
+	}
+
+	token, err := utils.GenerateSecureToken(bookingTokenLength)
+	if err != nil {
+		return nil, err
+	}
+
+	link := &application.InterviewBookingLink{
+		ApplicationID: applicationID,
+		CompanyID:     *app.CompanyID,
+		TokenHash:     hashBookingToken(token),
+		ExpiresAt:     now.Add(ttl),
+		CreatedBy:     ec.UserID,
+	}
+	if err := s.appRepo.CreateBookingLink(ctx, link); err != nil {
+		return nil, fmt.Errorf("failed to create booking link: %w", err)
+	}
+
+	go s.notifyInterviewBooking(context.Background(), app, token, link.ExpiresAt)
+
+	return &application.InterviewBookingLinkResult{
+		ID:            link.ID,
+		ApplicationID: applicationID,
+		Token:         token,
+		ExpiresAt:     link.ExpiresAt,
+	}, nil
+}
+
+// GetInterviewBooking returns the job and the open slots a candidate can pick from with the link
+func (s *applicationService) GetInterviewBooking(ctx context.Context, token string) (*application.InterviewBooking, error) {
+	link, app, err := s.findBookingLink(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	j, err := s.jobRepo.FindByID(ctx, app.JobID)
+	if err != nil {
+		return nil, jobLookupError(err)
+	}
+
+	booking := &application.InterviewBooking{
+		JobTitle:  j.Title,
+		ExpiresAt: link.ExpiresAt,
+	}
+	if comp, err := s.companyRepo.FindByID(ctx, link.CompanyID); err == nil && comp != nil {
+		booking.CompanyName = comp.CompanyName
+	}
+
+	now := time.Now()
+	slots, err := s.appRepo.ListInterviewSlots(ctx, link.CompanyID, application.InterviewSlotFilter{
+		From:   &now,
+		Status: application.InterviewSlotOpen,
+		Limit:  application.MaxInterviewSlotsListed,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list interview slots: %w", err)
+	}
+
+	booking.Slots = make([]application.BookableInterviewSlot, 0, len(slots))
+	for _, slot := range slots {
+		booking.Slots = append(booking.Slots, application.BookableInterviewSlot{
+			ID:            slot.ID,
+			StartsAt:      slot.StartsAt,
+			EndsAt:        slot.EndsAt,
+			Timezone:      slot.Timezone,
+			InterviewType: slot.InterviewType,
+		})
+	}
+
+	return booking, nil
+}
+
+// BookInterviewSlot claims the slot for the link's application and schedules the interview
+// through ScheduleInterview. The claim is undone when the interview cannot be scheduled.
+func (s *applicationService) BookInterviewSlot(ctx context.Context, token string, slotID int64) (*application.Interview, error) {
+	link, app, err := s.findBookingLink(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	slot, err := s.findCompanyInterviewSlot(ctx, link.CompanyID, slotID)
+	if err != nil {
+		return nil, err
+	}
+	if slot.IsBooked() || !slot.StartsAt.After(time.Now()) {
+		return nil, application.ErrInterviewSlotTaken
+	}
+
+	if err := s.appRepo.ClaimInterviewSlot(ctx, link, slot.ID); err != nil {
+		if errors.Is(err, application.ErrBookingLinkUsed) || errors.Is(err, application.ErrInterviewSlotTaken) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to book interview slot: %w", err)
+	}
+
+	interviewerID := slot.InterviewerID
+	interview, err := s.ScheduleInterview(ctx, &application.ScheduleInterviewRequest{
+		ApplicationID: app.ID,
+		InterviewerID: &interviewerID,
+		ScheduledAt:   slot.StartsAt,
+		InterviewType: slot.InterviewType,
+		MeetingLink:   slot.MeetingLink,
+		Location:      slot.Location,
+		Timezone:      slot.Timezone,
+	})
+	if err != nil {
+		if unclaimErr := s.appRepo.UnclaimInterviewSlot(ctx, link.ID, slot.ID); unclaimErr != nil {
+			fmt.Printf("failed to reopen interview slot %d: %v\n", slot.ID, unclaimErr)
+		}
+		return nil, err
+	}
+
+	if err := s.appRepo.SetInterviewSlotInterview(ctx, slot.ID, interview.ID); err != nil {
+		return nil, fmt.Errorf("failed to link interview to slot: %w", err)
+	}
+
+	return interview, nil
+}
+
+// findBookingLink returns the booking link with the token and its application, as long as
+// the link can still be used to book a slot
+func (s *applicationService) findBookingLink(ctx context.Context, token string) (*application.InterviewBookingLink, *application.JobApplication, error) {
+	if token == "" {
+		return nil, nil, application.ErrBookingLinkNotFound
+	}
+
+	link, err := s.appRepo.FindBookingLinkByTokenHash(ctx, hashBookingToken(token))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, application.ErrBookingLinkNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get booking link: %w", err)
+	}
+	if link.IsUsed() {
+		return nil, nil, application.ErrBookingLinkUsed
+	}
+	if link.IsExpired(time.Now()) {
+		return nil, nil, application.ErrBookingLinkExpired
+	}
+
+	app, err := s.appRepo.FindByID(ctx, link.ApplicationID)
+	if err != nil {
+		return nil, nil, applicationLookupError(err)
+	}
+	if app.IsCompleted() {
+		return nil, nil, application.ErrApplicationCompleted
+	}
+
+	return link, app, nil
+}
+
+// notifyInterviewBooking emails the candidate their booking link
+func (s *applicationService) notifyInterviewBooking(ctx context.Context, app *application.JobApplication, token string, expiresAt time.Time) {
+	if s.emailService == nil {
+		return
+	}
+
+	j, err := s.jobRepo.FindByID(ctx, app.JobID)
+	if err != nil {
+		fmt.Printf("failed to send interview booking email: %v\n", err)
+		return
+	}
+	usr, err := s.userRepo.FindByID(ctx, app.UserID)
+	if err != nil || usr == nil {
+		fmt.Printf("failed to send interview booking email: user %d not found\n", app.UserID)
+		return
+	}
+	companyName := ""
+	if comp, err := s.companyRepo.FindByID(ctx, *app.CompanyID); err == nil && comp != nil {
+		companyName = comp.CompanyName
+	}
+
+	ctx = userLocaleContext(ctx, s.userRepo, app.UserID)
+	if err := s.emailService.SendInterviewBookingEmail(ctx, usr.Email, usr.FullName, j.Title, companyName, token, expiresAt); err != nil {
+		// Log error but don't fail the operation
+		fmt.Printf("failed to send interview booking email: %v\n", err)
+	}
+}
+
+// hashBookingToken returns the SHA-256 hex digest stored for a booking link token
+func hashBookingToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/user"
+)
+
+// ErrInvitationsUnavailable is returned when a signup carries an invitation token but the
+// service was built without a company service to accept it
+var ErrInvitationsUnavailable = errors.New("company invitations are not available")
+
+// invitationSignup lets a new account join the company that invited it as part of signing up
+type invitationSignup struct {
+	companies company.CompanyService
+	users     user.UserRepository
+}
+
+// check returns the invitation a signup with email is about to accept, or nil when the
+// signup carries no token. It runs before the user is created so a bad token, an expired
+// invitation or another email fails the signup without leaving an account behind.
+func (s invitationSignup) check(ctx context.Context, token, email string) (*company.CompanyInvitation, error) {
+	if token == "" {
+		return nil, nil
+	}
+	if s.companies == nil {
+		return nil, ErrInvitationsUnavailable
+	}
+	return s.companies.CheckInvitationForSignup(ctx, token, email)
+}
+
+// create saves the new user. A signup with an invitation creates the user together with its
+// membership of the invitation's company in one transaction, so the signup either completes
+// with the membership or leaves nothing behind and can be retried with the same email.
+func (s invitationSignup) create(ctx context.Context, invitation *company.CompanyInvitation, token string, usr *user.User) error {
+	if invitation == nil {
+		if err := s.users.Create(ctx, usr); err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+		return nil
+	}
+
+	if err := s.companies.AcceptInvitationForSignup(ctx, token, usr); err != nil {
+		return fmt.Errorf("failed to accept invitation: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/user"
+
+	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
+)
+
+// compareGeocodeTimeout bounds the lookup of the caller's profile city; the distances are
+// left out when it takes longer
+const compareGeocodeTimeout = 3 * time.Second
+
+// jobComparisonService implements job.JobComparisonService on top of the job, company and
+// user services
+type jobComparisonService struct {
+	jobService     job.JobService
+	companyService company.CompanyService
+	userService    user.UserService
+	appRepo        application.ApplicationRepository
+	geocoder       Geocoder // Places the caller's profile city; may be nil
+}
+
+// NewJobComparisonService creates the service comparing jobs side by side
+func NewJobComparisonService(
+	jobService job.JobService,
+	companyService company.CompanyService,
+	userService user.UserService,
+	appRepo application.ApplicationRepository,
+	geocoder Geocoder,
+) job.JobComparisonService {
+	return &jobComparisonService{
+		jobService:     jobService,
+		companyService: companyService,
+		userService:    userService,
+		appRepo:        appRepo,
+		geocoder:       geocoder,
+	}
+}
+
+// comparedColumn is a compared job with the job it was built from
+type comparedColumn struct {
+	job      *job.Job
+	compared job.ComparedJob
+}
+
+// CompareJobs builds the comparison matrix, loading every job and the caller's profile city
+// concurrently
+func (s *jobComparisonService) CompareJobs(ctx context.Context, ids []int64, userID int64) (*job.JobComparison, error) {
+	if len(ids) == 0 || len(ids) > job.MaxCompareJobs {
+		return nil, job.ErrInvalidCompareJobIDs
+	}
+	personalized := userID != 0
+
+	columns := make([]*comparedColumn, len(ids))
+	var origin *GeocodeResult
+
+	g, gctx := errgroup.WithContext(ctx)
+	if personalized {
+		g.Go(func() error {
+			origin = s.profileCityCoordinates(gctx, userID)
+			return nil
+		})
+	}
+	for i, id := range ids {
+		g.Go(func() error {
+			column, err := s.compareJob(gctx, id, userID)
+			if err != nil && !errors.Is(err, job.ErrJobNotFound) {
+				return err
+			}
+			columns[i] = column
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	comparison := &job.JobComparison{
+		Personalized: personalized,
+		Jobs:         make([]job.ComparedJob, 0, len(ids)),
+		NotFound:     []job.ComparisonMissing{},
+	}
+	for i, column := range columns {
+		if column == nil {
+			comparison.NotFound = append(comparison.NotFound, job.ComparisonMissing{
+				JobID:   ids[i],
+				Code:    job.ErrJobNotFound.Code,
+				Message: job.ErrJobNotFound.Message,
+			})
+			continue
+		}
+
+		if origin != nil {
+			if lat, lng, ok := column.job.Coordinates(); ok {
+				distance := job.DistanceKm(origin.Latitude, origin.Longitude, lat, lng)
+				column.compared.Location.DistanceKm = &distance
+			}
+		}
+		comparison.Jobs = append(comparison.Jobs, column.compared)
+	}
+	return comparison, nil
+}
+
+// compareJob builds the comparison column of a job the caller may see, or returns
+// job.ErrJobNotFound. Callers see published jobs and, when signed in, jobs they applied to.
+func (s *jobComparisonService) compareJob(ctx context.Context, jobID, userID int64) (*comparedColumn, error) {
+	j, err := s.jobService.GetJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if j == nil {
+		return nil, job.ErrJobNotFound
+	}
+	if !j.IsPublished() {
+		applied, err := s.hasApplied(ctx, jobID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !applied {
+			return nil, job.ErrJobNotFound
+		}
+	}
+
+	column := &comparedColumn{job: j, compared: job.NewComparedJob(j)}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		// The company name is cosmetic; the column is still useful without it
+		if comp, err := s.companyService.GetCompany(gctx, j.CompanyID); err == nil {
+			column.compared.Company.Name = comp.CompanyName
+		}
+		return nil
+	})
+	g.Go(func() error {
+		ratings, err := s.companyService.GetAverageRatings(gctx, j.CompanyID)
+		if err != nil {
+			return err
+		}
+		column.compared.Company.Rating = ratings.Overall
+		column.compared.Company.TotalReviews = ratings.TotalReviews
+		return nil
+	})
+	if userID != 0 {
+		g.Go(func() error {
+			score, err := s.jobService.CalculateMatchScore(gctx, jobID, userID)
+			if err != nil {
+				return fmt.Errorf("failed to calculate match score for job %d: %w", jobID, err)
+			}
+			column.compared.MatchScore = score
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	// The match score already knows which of the job's skills the caller has
+	if score := column.compared.MatchScore; score != nil {
+		matched := make(map[string]bool, len(score.MatchedSkills))
+		for _, name := range score.MatchedSkills {
+			matched[name] = true
+		}
+		for i := range column.compared.Skills {
+			has := matched[column.compared.Skills[i].Name]
+			column.compared.Skills[i].UserHas = &has
+		}
+	}
+	return column, nil
+}
+
+// hasApplied checks if the user has an application for the job; anonymous callers have none
+func (s *jobComparisonService) hasApplied(ctx context.Context, jobID, userID int64) (bool, error) {
+	if userID == 0 {
+		return false, nil
+	}
+	app, err := s.appRepo.FindByJobAndUser(ctx, jobID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check application: %w", err)
+	}
+	return app != nil, nil
+}
+
+// profileCityCoordinates places the city on the user's profile, or returns nil when the
+// profile has no city or it cannot be placed
+func (s *jobComparisonService) profileCityCoordinates(ctx context.Context, userID int64) *GeocodeResult {
+	if s.geocoder == nil {
+		return nil
+	}
+	usr, err := s.userService.GetProfile(ctx, userID)
+	if err != nil || usr == nil || usr.Profile == nil {
+		return nil
+	}
+	city := usr.Profile.GetCityName()
+	if city == "" {
+		return nil
+	}
+
+	query := city
+	if province := usr.Profile.GetProvinceName(); province != "" && !strings.EqualFold(province, city) {
+		query += ", " + province
+	}
+
+	geoCtx, cancel := context.WithTimeout(ctx, compareGeocodeTimeout)
+	defer cancel()
+
+	result, err := s.geocoder.Geocode(geoCtx, query)
+	if err != nil {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/utils"
+)
+
+const sitemapXMLNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// jobFeedService implements job.JobFeedService
+type jobFeedService struct {
+	jobRepo     job.JobRepository
+	companyRepo company.CompanyRepository
+	cache       cache.Cache // Generated sitemap pages; may be nil
+	frontendURL string      // Job pages are at frontendURL/jobs/:slug
+}
+
+// NewJobFeedService creates the service publishing jobs to search engines; job URLs point
+// at the job pages of the frontend at frontendURL
+func NewJobFeedService(jobRepo job.JobRepository, companyRepo company.CompanyRepository, cacheService cache.Cache, frontendURL string) job.JobFeedService {
+	return &jobFeedService{
+		jobRepo:     jobRepo,
+		companyRepo: companyRepo,
+		cache:       cacheService,
+		frontendURL: strings.TrimSuffix(frontendURL, "/"),
+	}
+}
+
+// jobURL returns the public URL of the job page
+func (s *jobFeedService) jobURL(slug string) string {
+	return s.frontendURL + "/jobs/" + slug
+}
+
+// WriteSitemap writes the sitemap page from the cache, or generates it while streaming the
+// jobs from the database and caches it for job.SitemapCacheTTL
+func (s *jobFeedService) WriteSitemap(ctx context.Context, sitemapURL string, page int, w io.Writer) error {
+	key := fmt.Sprintf("%s%d:%s", job.SitemapCacheKeyPrefix, page, sitemapURL)
+	if s.cache != nil {
+		if cached, ok := cache.GetTyped[[]byte](s.cache, key); ok {
+			_, err := w.Write(cached)
+			return err
+		}
+	}
+
+	now := time.Now()
+	total, err := s.jobRepo.CountSitemapEntries(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to count sitemap jobs: %w", err)
+	}
+	pages := job.SitemapPageCount(total)
+	if page > pages {
+		return job.ErrSitemapPageNotFound
+	}
+
+	var buf bytes.Buffer
+	out := io.MultiWriter(w, &buf)
+	if page == 0 && pages > 1 {
+		err = writeSitemapIndex(out, sitemapURL, pages)
+	} else {
+		err = s.writeSitemapURLs(ctx, out, now, max(page, 1))
+	}
+	if err != nil {
+		return err
+	}
+
+	if s.cache != nil {
+		s.cache.Set(key, buf.Bytes(), job.SitemapCacheTTL)
+	}
+	return nil
+}
+
+// writeSitemapIndex writes a sitemap index linking each of the pages
+func writeSitemapIndex(w io.Writer, sitemapURL string, pages int) error {
+	if _, err := fmt.Fprintf(w, "%s<sitemapindex xmlns=%q>\n", xml.Header, sitemapXMLNS); err != nil {
+		return err
+	}
+	for page := 1; page <= pages; page++ {
+		if _, err := fmt.Fprintf(w, "<sitemap><loc>%s</loc></sitemap>\n", xmlEscape(fmt.Sprintf("%s?page=%d", sitemapURL, page))); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "</sitemapindex>\n")
+	return err
+}
+
+// writeSitemapURLs writes the URLs of the jobs on page, with their last update as lastmod
+func (s *jobFeedService) writeSitemapURLs(ctx context.Context, w io.Writer, now time.Time, page int) error {
+	if _, err := fmt.Fprintf(w, "%s<urlset xmlns=%q>\n", xml.Header, sitemapXMLNS); err != nil {
+		return err
+	}
+	err := s.jobRepo.StreamSitemapEntries(ctx, now, (page-1)*job.MaxSitemapURLs, job.MaxSitemapURLs, func(entry job.SitemapEntry) error {
+		_, err := fmt.Fprintf(w, "<url><loc>%s</loc><lastmod>%s</lastmod></url>\n",
+			xmlEscape(s.jobURL(entry.Slug)), entry.UpdatedAt.UTC().Format(time.RFC3339))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list sitemap jobs: %w", err)
+	}
+	_, err = io.WriteString(w, "</urlset>\n")
+	return err
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// GetStructuredData builds the JobPosting of a job that is published and unexpired; other
+// jobs are reported as not found so search engines drop them
+func (s *jobFeedService) GetStructuredData(ctx context.Context, slug string) (*job.JobPosting, error) {
+	j, err := s.jobRepo.FindBySlug(ctx, slug)
+	if err != nil {
+		return nil, jobLookupError(err)
+	}
+	if j == nil || !j.IsActive() {
+		return nil, job.ErrJobNotFound
+	}
+
+	comp, err := s.companyRepo.FindByID(ctx, j.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	if comp == nil {
+		return nil, job.ErrJobNotFound
+	}
+
+	org := job.PostingOrganization{Name: comp.CompanyName}
+	if comp.WebsiteURL != nil {
+		org.SameAs = *comp.WebsiteURL
+	}
+	if comp.LogoURL != nil {
+		org.Logo = *comp.LogoURL
+	}
+
+	var companyAddress *job.PostingAddress
+	if comp.Address != nil || comp.FullAddress != "" {
+		companyAddress = &job.PostingAddress{
+			StreetAddress:   comp.FullAddress,
+			AddressLocality: utils.StringValue(comp.City),
+			AddressRegion:   utils.StringValue(comp.Province),
+			PostalCode:      utils.StringValue(comp.PostalCode),
+			AddressCountry:  comp.Country,
+		}
+		if comp.Address != nil {
+			companyAddress.StreetAddress = *comp.Address
+		}
+	}
+
+	return job.NewJobPosting(j, s.jobURL(j.Slug), utils.SanitizeBasicHTML(j.Description), org, companyAddress), nil
+}
@@ -32,11 +32,8 @@ func NewJobOptionsService(repo master.JobOptionsRepository, cache cache.Cache) m
 // GetJobOptions retrieves all job options (heavily cached with 3-day TTL)
 func (s *jobOptionsService) GetJobOptions(ctx context.Context) (*master.JobOptionsResponse, error) {
 	// Try to get from cache first
-	cachedData, ok := s.cache.Get(jobOptionsCacheKey)
-	if ok {
-		if response, ok := cachedData.(*master.JobOptionsResponse); ok {
-			return response, nil
-		}
+	if cached, ok := cache.GetTyped[*master.JobOptionsResponse](s.cache, jobOptionsCacheKey); ok && cached != nil {
+		return cached, nil
 	}
 
 	// Cache miss or error - fetch from database
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/internal/domain/job"
+)
+
+// ===== Hiring Pipeline =====
+
+// GetPipeline returns the stages of a job's hiring pipeline in order
+func (s *jobService) GetPipeline(ctx context.Context, jobID int64, ec *employer.EmployerContext) ([]job.JobPipelineStage, error) {
+	if err := s.CheckJobOwnership(ctx, jobID, ec); err != nil {
+		return nil, err
+	}
+	return loadJobPipeline(ctx, s.jobRepo, jobID)
+}
+
+// ConfigurePipeline replaces the custom stages of a job's hiring pipeline. Like screening
+// questions the pipeline is fixed once the job is published, so no application is ever in a
+// stage that no longer exists.
+func (s *jobService) ConfigurePipeline(ctx context.Context, jobID int64, ec *employer.EmployerContext, req *job.ConfigurePipelineRequest) ([]job.JobPipelineStage, error) {
+	if err := s.CheckJobOwnership(ctx, jobID, ec); err != nil {
+		return nil, err
+	}
+
+	j, err := s.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, jobLookupError(err)
+	}
+	if !j.IsDraft() && !j.IsPendingReview() {
+		return nil, job.ErrJobPipelineLocked
+	}
+
+	stages, err := job.BuildPipeline(jobID, req.Stages)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.jobRepo.ReplacePipelineStages(ctx, jobID, stages); err != nil {
+		return nil, fmt.Errorf("failed to save pipeline: %w", err)
+	}
+	return stages, nil
+}
+
+// createDefaultPipeline gives a new job the pipeline of canonical stages
+func (s *jobService) createDefaultPipeline(ctx context.Context, jobID int64) error {
+	if err := s.jobRepo.ReplacePipelineStages(ctx, jobID, job.DefaultPipeline(jobID)); err != nil {
+		return fmt.Errorf("failed to create pipeline: %w", err)
+	}
+	return nil
+}
+
+// loadJobPipeline returns a job's pipeline stages, falling back to the default pipeline for
+// jobs that have none stored
+func loadJobPipeline(ctx context.Context, jobRepo job.JobRepository, jobID int64) ([]job.JobPipelineStage, error) {
+	stages, err := jobRepo.ListPipelineStages(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipeline: %w", err)
+	}
+	if len(stages) == 0 {
+		return job.DefaultPipeline(jobID), nil
+	}
+	return stages, nil
+}
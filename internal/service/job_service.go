@@ -2,28 +2,61 @@ package service
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"math"
 	"strings"
 	"time"
 
+	"keerja-backend/internal/apperror"
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/application"
 	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/domain/employer"
 	"keerja-backend/internal/domain/job"
 	"keerja-backend/internal/domain/master"
+	"keerja-backend/internal/domain/notification"
 	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/domain/webhook"
+	"keerja-backend/internal/i18n"
 	"keerja-backend/internal/utils"
+
+	"gorm.io/gorm"
 )
 
+// jobViewDedupWindow is how long repeat views of a job by the same viewer are ignored
+const jobViewDedupWindow = 24 * time.Hour
+
+// jobExpiryReminderDays are the days-before-expiry thresholds at which employers are
+// reminded. They are processed smallest first, so a job that is already inside both
+// thresholds only gets the most urgent reminder.
+var jobExpiryReminderDays = []int{1, 7}
+
 // jobService implements job.JobService interface
 type jobService struct {
-	jobRepo         job.JobRepository
-	companyRepo     company.CompanyRepository
-	userRepo        user.UserRepository
-	jobOptionsRepo  master.JobOptionsRepository
-	jobTitleRepo    master.JobTitleRepository
-	industryService master.IndustryService
-	districtService master.DistrictService
+	jobRepo          job.JobRepository
+	companyRepo      company.CompanyRepository
+	userRepo         user.UserRepository
+	jobOptionsRepo   master.JobOptionsRepository
+	jobTitleRepo     master.JobTitleRepository
+	skillsMasterRepo master.SkillsMasterRepository
+	industryService  master.IndustryService
+	districtService  master.DistrictService
+	followerNotifier notification.FollowerNotifier
+	emailService     email.EmailService
+	notifService     notification.NotificationService
+	webhooks         webhook.EventPublisher
+	expiry           job.ExpiryPolicy
+	matchScores      job.MatchScoreRepository // Precomputed match scores; may be nil
+	cache            cache.Cache              // Featured and trending lists; may be nil
+	quotas           company.QuotaService     // Published job limits; nil disables them
+	viewHashKey      []byte                   // Keys the HMAC of anonymous viewers' IPs
 }
 
 // NewJobService creates a new job service instance
@@ -33,46 +66,59 @@ func NewJobService(
 	userRepo user.UserRepository,
 	jobOptionsRepo master.JobOptionsRepository,
 	jobTitleRepo master.JobTitleRepository,
+	skillsMasterRepo master.SkillsMasterRepository,
 	industryService master.IndustryService,
 	districtService master.DistrictService,
+	followerNotifier notification.FollowerNotifier,
+	emailService email.EmailService,
+	notifService notification.NotificationService,
+	webhooks webhook.EventPublisher,
+	expiry job.ExpiryPolicy,
+	matchScores job.MatchScoreRepository,
+	cacheService cache.Cache,
+	quotas company.QuotaService,
+	viewHashSecret string,
 ) job.JobService {
 	return &jobService{
-		jobRepo:         jobRepo,
-		companyRepo:     companyRepo,
-		userRepo:        userRepo,
-		jobOptionsRepo:  jobOptionsRepo,
-		jobTitleRepo:    jobTitleRepo,
-		industryService: industryService,
-		districtService: districtService,
+		jobRepo:          jobRepo,
+		companyRepo:      companyRepo,
+		userRepo:         userRepo,
+		jobOptionsRepo:   jobOptionsRepo,
+		jobTitleRepo:     jobTitleRepo,
+		skillsMasterRepo: skillsMasterRepo,
+		industryService:  industryService,
+		districtService:  districtService,
+		followerNotifier: followerNotifier,
+		emailService:     emailService,
+		notifService:     notifService,
+		webhooks:         webhooks,
+		expiry:           expiry,
+		matchScores:      matchScores,
+		cache:            cacheService,
+		quotas:           quotas,
+		viewHashKey:      []byte(viewHashSecret),
 	}
 }
 
 // ===== Job Management (Employer) =====
 
 // CreateJob creates a new job posting
-func (s *jobService) CreateJob(ctx context.Context, req *job.CreateJobRequest) (*job.Job, error) {
+func (s *jobService) CreateJob(ctx context.Context, ec *employer.EmployerContext, req *job.CreateJobRequest) (*job.Job, error) {
+	// The job is posted by the employer user for the company they act for
+	if !ec.InCompany(req.CompanyID) {
+		return nil, company.ErrNotCompanyEmployer
+	}
+	if !ec.CanManageJobs() {
+		return nil, company.ErrInsufficientCompanyRole
+	}
+
 	// Verify company exists
-	_, err := s.companyRepo.FindByID(ctx, req.CompanyID)
+	comp, err := s.companyRepo.FindByID(ctx, req.CompanyID)
 	if err != nil {
-		return nil, fmt.Errorf("company not found: %w", err)
+		return nil, fmt.Errorf("failed to get company: %w", err)
 	}
-
-	// Resolve employer_user ID from user ID and company ID
-	var employerUserID int64
-	if req.EmployerUserID != 0 {
-		// req.EmployerUserID is actually user_id from middleware, need to get employer_user.id
-		employerUser, err := s.companyRepo.FindEmployerUserByUserAndCompany(ctx, req.EmployerUserID, req.CompanyID)
-		if err != nil || employerUser == nil {
-			return nil, fmt.Errorf("user (ID: %d) is not an employer for company (ID: %d)", req.EmployerUserID, req.CompanyID)
-		}
-
-		// Check if role has permission (recruiter and above)
-		if employerUser.Role != "recruiter" && employerUser.Role != "admin" && employerUser.Role != "owner" {
-			return nil, fmt.Errorf("user role '%s' does not have permission to create jobs", employerUser.Role)
-		}
-
-		// Use the actual employer_user ID (not user_id)
-		employerUserID = employerUser.ID
+	if comp == nil {
+		return nil, company.ErrCompanyNotFound
 	}
 
 	// Validate master data IDs (job title is optional now)
@@ -84,7 +130,7 @@ func (s *jobService) CreateJob(ctx context.Context, req *job.CreateJobRequest) (
 	// Validate company exists
 	company, err := s.companyRepo.FindByID(ctx, req.CompanyID)
 	if err != nil || company == nil {
-		return nil, fmt.Errorf("invalid company_id: company with ID %d not found", req.CompanyID)
+		return nil, job.ErrInvalidJobData.WithField("company_id", fmt.Sprintf("company with ID %d not found", req.CompanyID))
 	}
 
 	// Validate company_address_id ownership if provided (address must belong to the company)
@@ -94,10 +140,10 @@ func (s *jobService) CreateJob(ctx context.Context, req *job.CreateJobRequest) (
 			return nil, fmt.Errorf("failed to validate company_address_id: %w", err)
 		}
 		if addr == nil {
-			return nil, fmt.Errorf("invalid company_address_id: %d", *req.CompanyAddressID)
+			return nil, job.ErrInvalidJobData.WithField("company_address_id", "does not exist")
 		}
 		if addr.CompanyID != req.CompanyID {
-			return nil, fmt.Errorf("company_address_id (%d) does not belong to company (%d)", *req.CompanyAddressID, req.CompanyID)
+			return nil, job.ErrInvalidJobData.WithField("company_address_id", "does not belong to the company")
 		}
 	}
 
@@ -106,7 +152,7 @@ func (s *jobService) CreateJob(ctx context.Context, req *job.CreateJobRequest) (
 	if req.JobTitleID != nil && *req.JobTitleID > 0 {
 		jobTitle, err := s.jobTitleRepo.FindByID(ctx, *req.JobTitleID)
 		if err != nil || jobTitle == nil {
-			return nil, fmt.Errorf("invalid job_title_id: %d", *req.JobTitleID)
+			return nil, job.ErrInvalidJobData.WithField("job_title_id", "does not exist")
 		}
 		title = jobTitle.Name
 	} else if req.JobSubcategoryID > 0 {
@@ -116,20 +162,20 @@ func (s *jobService) CreateJob(ctx context.Context, req *job.CreateJobRequest) (
 			return nil, fmt.Errorf("failed to resolve job_subcategory_id for title: %w", err)
 		}
 		if sub == nil {
-			return nil, fmt.Errorf("invalid job_subcategory_id: %d", req.JobSubcategoryID)
+			return nil, job.ErrInvalidJobData.WithField("job_subcategory_id", "does not exist")
 		}
 		title = sub.Name
 	}
 
 	if title == "" {
-		return nil, fmt.Errorf("either job_title_id or job_subcategory_id must be provided to determine job title")
+		return nil, job.ErrInvalidJobData.WithField("job_title_id", "either job_title_id or job_subcategory_id must be provided")
 	}
 
 	// Generate unique slug from determined title
-	slug := utils.GenerateUniqueSlug(title, func(slugToCheck string) bool {
-		existingJob, _ := s.jobRepo.FindBySlug(ctx, slugToCheck)
-		return existingJob != nil
-	})
+	slug, err := s.uniqueJobSlug(ctx, title, 0)
+	if err != nil {
+		return nil, err
+	}
 
 	// Validate and resolve category/subcategory if provided
 	if req.JobSubcategoryID > 0 {
@@ -138,10 +184,10 @@ func (s *jobService) CreateJob(ctx context.Context, req *job.CreateJobRequest) (
 			return nil, fmt.Errorf("failed to validate job_subcategory_id: %w", err)
 		}
 		if sub == nil {
-			return nil, fmt.Errorf("invalid job_subcategory_id: %d", req.JobSubcategoryID)
+			return nil, job.ErrInvalidJobData.WithField("job_subcategory_id", "does not exist")
 		}
 		if !sub.IsActive {
-			return nil, fmt.Errorf("invalid job_subcategory_id: %d is inactive", req.JobSubcategoryID)
+			return nil, job.ErrInvalidJobData.WithField("job_subcategory_id", "is inactive")
 		}
 		// If category provided, ensure it matches subcategory's category
 		if req.JobCategoryID > 0 && sub.CategoryID != req.JobCategoryID {
@@ -154,14 +200,14 @@ func (s *jobService) CreateJob(ctx context.Context, req *job.CreateJobRequest) (
 	}
 
 	// Create job entity - master data only
-	jobStatus := "draft"
+	jobStatus := job.StatusDraft
 	if !company.Verified {
-		jobStatus = "in_review"
+		jobStatus = job.StatusInReview
 	}
 	newJob := &job.Job{
 		CompanyID:      req.CompanyID,
-		EmployerUserID: &employerUserID, // Use resolved employer_user ID (not user_id)
-		Title:          title,           // Use determined title
+		EmployerUserID: utils.Int64Ptr(ec.EmployerUserID),
+		Title:          title, // Use determined title
 		Slug:           slug,
 
 		// Master Data FK fields (All required)
@@ -201,6 +247,9 @@ func (s *jobService) CreateJob(ctx context.Context, req *job.CreateJobRequest) (
 	if err := s.jobRepo.Create(ctx, newJob); err != nil {
 		return nil, fmt.Errorf("failed to create job: %w", err)
 	}
+	if err := s.createDefaultPipeline(ctx, newJob.ID); err != nil {
+		return nil, err
+	}
 
 	// Add skills (required)
 	if len(req.Skills) > 0 {
@@ -214,22 +263,18 @@ func (s *jobService) CreateJob(ctx context.Context, req *job.CreateJobRequest) (
 }
 
 // UpdateJob updates an existing job (master data only)
-func (s *jobService) UpdateJob(ctx context.Context, jobID int64, req *job.UpdateJobRequest) (*job.Job, error) {
+func (s *jobService) UpdateJob(ctx context.Context, jobID int64, ec *employer.EmployerContext, req *job.UpdateJobRequest) (*job.Job, error) {
 	// Find existing job
 	existingJob, err := s.jobRepo.FindByID(ctx, jobID)
 	if err != nil {
-		return nil, fmt.Errorf("job not found: %w", err)
+		return nil, jobLookupError(err)
 	}
 
-	// Verify ownership if EmployerUserID provided (from handler)
-	if req.EmployerUserID > 0 && req.CompanyID > 0 {
-		employerUser, err := s.companyRepo.FindEmployerUserByUserAndCompany(ctx, req.EmployerUserID, req.CompanyID)
-		if err != nil || employerUser == nil {
-			return nil, fmt.Errorf("failed to verify user authorization: %w", err)
-		}
-		if existingJob.EmployerUserID == nil || *existingJob.EmployerUserID != employerUser.ID {
-			return nil, fmt.Errorf("not authorized to update this job")
-		}
+	if err := checkOwnership(existingJob, ec); err != nil {
+		return nil, err
+	}
+	if req.Version != nil && *req.Version != existingJob.Version {
+		return nil, job.ErrJobVersionConflict.WithCurrentVersion(existingJob.Version)
 	}
 
 	// Validate master data IDs if provided
@@ -250,10 +295,9 @@ func (s *jobService) UpdateJob(ctx context.Context, jobID int64, req *job.Update
 		if err == nil && jobTitle != nil {
 			existingJob.Title = jobTitle.Name
 			// Regenerate slug based on new job title
-			newSlug := utils.GenerateSlug(jobTitle.Name)
-			slugJob, _ := s.jobRepo.FindBySlug(ctx, newSlug)
-			if slugJob != nil && slugJob.ID != existingJob.ID {
-				newSlug = utils.GenerateSlugSimple(jobTitle.Name)
+			newSlug, err := s.uniqueJobSlug(ctx, jobTitle.Name, existingJob.ID)
+			if err != nil {
+				return nil, err
 			}
 			existingJob.Slug = newSlug
 		}
@@ -301,7 +345,7 @@ func (s *jobService) UpdateJob(ctx context.Context, jobID int64, req *job.Update
 				return nil, fmt.Errorf("failed to validate company_address_id: %w", err)
 			}
 			if addr == nil {
-				return nil, fmt.Errorf("invalid company_address_id: %d", *req.CompanyAddressID)
+				return nil, job.ErrInvalidJobData.WithField("company_address_id", "does not exist")
 			}
 			if addr.CompanyID != existingJob.CompanyID {
 				return nil, fmt.Errorf("company_address_id (%d) does not belong to job's company (%d)", *req.CompanyAddressID, existingJob.CompanyID)
@@ -309,6 +353,9 @@ func (s *jobService) UpdateJob(ctx context.Context, jobID int64, req *job.Update
 		}
 		existingJob.CompanyAddressID = req.CompanyAddressID
 	}
+	if req.AutoExtend != nil {
+		existingJob.AutoExtend = *req.AutoExtend
+	}
 	// Use dedicated endpoints for status changes
 
 	// Validate updated job
@@ -321,11 +368,14 @@ func (s *jobService) UpdateJob(ctx context.Context, jobID int64, req *job.Update
 		return nil, fmt.Errorf("failed to update job: %w", err)
 	}
 
-	// If skills provided, replace existing skills with new set
-	if req.Skills != nil && len(req.Skills) > 0 {
+	// If skills provided, replace existing skills with new set (an empty list clears them)
+	if req.Skills != nil {
 		if err := s.BulkAddSkills(ctx, jobID, req.Skills); err != nil {
 			return nil, fmt.Errorf("failed to update job skills: %w", err)
 		}
+	} else if req.EducationLevelID != nil {
+		// The education requirement is part of the match score
+		s.invalidateMatchScores(ctx, jobID)
 	}
 
 	// Reload job with relationships
@@ -337,24 +387,27 @@ func (s *jobService) SaveJobDraft(ctx context.Context, companyID int64, req *job
 	// 1. Validate company exists
 	comp, err := s.companyRepo.FindByID(ctx, companyID)
 	if err != nil {
-		return nil, fmt.Errorf("company not found: %w", err)
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	if comp == nil {
+		return nil, company.ErrCompanyNotFound
 	}
 
 	// 2. Validate salary range
 	if req.GajiMaks < req.GajiMin {
-		return nil, errors.New("gaji_maks must be greater than or equal to gaji_min")
+		return nil, job.ErrInvalidJobData.WithField("gaji_maks", "must be greater than or equal to gaji_min")
 	}
 
 	// 3. Validate age range if provided
 	if req.UmurMin != nil && req.UmurMaks != nil {
 		if *req.UmurMaks < *req.UmurMin {
-			return nil, errors.New("umur_maks must be greater than or equal to umur_min")
+			return nil, job.ErrInvalidJobData.WithField("umur_maks", "must be greater than or equal to umur_min")
 		}
 		if *req.UmurMin < 17 || *req.UmurMin > 65 {
-			return nil, errors.New("umur_min must be between 17 and 65")
+			return nil, job.ErrInvalidJobData.WithField("umur_min", "must be between 17 and 65")
 		}
 		if *req.UmurMaks < 17 || *req.UmurMaks > 65 {
-			return nil, errors.New("umur_maks must be between 17 and 65")
+			return nil, job.ErrInvalidJobData.WithField("umur_maks", "must be between 17 and 65")
 		}
 	}
 
@@ -372,17 +425,20 @@ func (s *jobService) SaveJobDraft(ctx context.Context, companyID int64, req *job
 		// Update existing draft
 		existingDraft, err := s.jobRepo.FindByID(ctx, *req.DraftID)
 		if err != nil {
-			return nil, fmt.Errorf("draft not found: %w", err)
+			return nil, jobLookupError(err)
+		}
+		if existingDraft == nil {
+			return nil, job.ErrJobNotFound
 		}
 
 		// Verify draft belongs to this company
 		if existingDraft.CompanyID != companyID {
-			return nil, errors.New("draft does not belong to this company")
+			return nil, job.ErrJobPermissionDenied
 		}
 
 		// Verify it's still a draft
-		if existingDraft.Status != "draft" {
-			return nil, errors.New("job is no longer in draft status")
+		if existingDraft.Status != job.StatusDraft {
+			return nil, job.ErrJobNotDraft
 		}
 
 		jobDraft = existingDraft
@@ -390,27 +446,17 @@ func (s *jobService) SaveJobDraft(ctx context.Context, companyID int64, req *job
 		// Create new draft
 		jobDraft = &job.Job{
 			CompanyID: companyID,
-			Status:    "draft",
+			Status:    job.StatusDraft,
 		}
 	}
 
 	// 7. Map request fields to job entity
-	// Determine title: prefer JobTitle master data, otherwise derive from subcategory, else use placeholder
-	if req.JobTitleID != nil && *req.JobTitleID > 0 {
-		if jt, err := s.jobTitleRepo.FindByID(ctx, *req.JobTitleID); err == nil && jt != nil {
-			jobDraft.Title = jt.Name
-		} else {
-			jobDraft.Title = fmt.Sprintf("Draft Job %d", time.Now().Unix())
-		}
-	} else if req.JobSubcategoryID > 0 {
-		if sub, err := s.jobRepo.FindSubcategoryByID(ctx, req.JobSubcategoryID); err == nil && sub != nil {
-			jobDraft.Title = sub.Name
-		} else {
-			jobDraft.Title = fmt.Sprintf("Draft Job %d", time.Now().Unix())
-		}
-	} else {
-		jobDraft.Title = fmt.Sprintf("Draft Job %d", time.Now().Unix())
+	title, err := s.resolveDraftTitle(ctx, req)
+	if err != nil {
+		return nil, err
 	}
+	titleChanged := title != jobDraft.Title
+	jobDraft.Title = title
 	jobDraft.CategoryID = &req.JobCategoryID
 
 	// Validate/attach subcategory if provided
@@ -420,10 +466,10 @@ func (s *jobService) SaveJobDraft(ctx context.Context, companyID int64, req *job
 			return nil, fmt.Errorf("failed to validate job_subcategory_id: %w", err)
 		}
 		if sub == nil {
-			return nil, fmt.Errorf("invalid job_subcategory_id: %d", req.JobSubcategoryID)
+			return nil, job.ErrInvalidJobData.WithField("job_subcategory_id", "does not exist")
 		}
 		if !sub.IsActive {
-			return nil, fmt.Errorf("invalid job_subcategory_id: %d is inactive", req.JobSubcategoryID)
+			return nil, job.ErrInvalidJobData.WithField("job_subcategory_id", "is inactive")
 		}
 		// Ensure subcategory belongs to provided category
 		if sub.CategoryID != req.JobCategoryID {
@@ -468,12 +514,22 @@ func (s *jobService) SaveJobDraft(ctx context.Context, companyID int64, req *job
 
 	// Set default values
 	jobDraft.TotalHires = 1
-	if jobDraft.Slug == "" {
-		jobDraft.Slug = utils.GenerateSlugSimple(jobDraft.Title)
+	// Drafts are not public yet, so their slug follows the title until they are published
+	if jobDraft.Slug == "" || titleChanged {
+		slugSource := jobDraft.Title
+		if slugSource == "" {
+			slugSource = "draft"
+		}
+		slug, err := s.uniqueJobSlug(ctx, slugSource, jobDraft.ID)
+		if err != nil {
+			return nil, err
+		}
+		jobDraft.Slug = slug
 	}
 
 	// 8. Save job draft
-	if req.DraftID != nil && *req.DraftID > 0 {
+	isUpdate := req.DraftID != nil && *req.DraftID > 0
+	if isUpdate {
 		// Update existing
 		if err := s.jobRepo.Update(ctx, jobDraft); err != nil {
 			return nil, fmt.Errorf("failed to update draft: %w", err)
@@ -483,14 +539,13 @@ func (s *jobService) SaveJobDraft(ctx context.Context, companyID int64, req *job
 		if err := s.jobRepo.Create(ctx, jobDraft); err != nil {
 			return nil, fmt.Errorf("failed to create draft: %w", err)
 		}
+		if err := s.createDefaultPipeline(ctx, jobDraft.ID); err != nil {
+			return nil, err
+		}
 	}
 
-	// 9. Add/Update skills
-	if len(req.SkillIDs) > 0 {
-		// Note: When updating, BulkAddSkills will handle replacing existing skills
-		// We don't need to manually delete them first
-
-		// Add new skills
+	// 9. Replace skills so the draft holds exactly the requested set
+	if len(req.SkillIDs) > 0 || isUpdate {
 		skillRequests := make([]job.AddSkillRequest, 0, len(req.SkillIDs))
 		for _, skillID := range req.SkillIDs {
 			skillRequests = append(skillRequests, job.AddSkillRequest{
@@ -503,29 +558,287 @@ func (s *jobService) SaveJobDraft(ctx context.Context, companyID int64, req *job
 		}
 	}
 
-	// 10. Reload job with all relationships
+	// 10. Snapshot the payload so the draft can be restored later
+	if err := s.recordDraftRevision(ctx, jobDraft.ID, req); err != nil {
+		return nil, err
+	}
+
+	// 11. Reload job with all relationships
 	return s.jobRepo.FindByID(ctx, jobDraft.ID)
 }
 
+// resolveDraftTitle names a draft after its JobTitle master data, falling back to the
+// subcategory name. Drafts with neither stay untitled and are listed as missing a title.
+func (s *jobService) resolveDraftTitle(ctx context.Context, req *job.SaveJobDraftRequest) (string, error) {
+	if req.JobTitleID != nil && *req.JobTitleID > 0 {
+		jt, err := s.jobTitleRepo.FindByID(ctx, *req.JobTitleID)
+		if err != nil {
+			return "", fmt.Errorf("failed to validate job_title_id: %w", err)
+		}
+		if jt == nil {
+			return "", job.ErrInvalidJobData.WithField("job_title_id", "does not exist")
+		}
+		return jt.Name, nil
+	}
+
+	if req.JobSubcategoryID > 0 {
+		if sub, err := s.jobRepo.FindSubcategoryByID(ctx, req.JobSubcategoryID); err == nil && sub != nil {
+			return sub.Name, nil
+		}
+	}
+
+	return "", nil
+}
+
+// recordDraftRevision stores req as the newest revision of a draft, keeping job.MaxDraftRevisions
+func (s *jobService) recordDraftRevision(ctx context.Context, jobID int64, req *job.SaveJobDraftRequest) error {
+	snapshot := *req
+	snapshot.DraftID = &jobID
+
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode draft revision: %w", err)
+	}
+
+	revision := &job.JobDraftRevision{
+		JobID:   jobID,
+		Payload: string(payload),
+	}
+	if err := s.jobRepo.CreateDraftRevision(ctx, revision, job.MaxDraftRevisions); err != nil {
+		return fmt.Errorf("failed to save draft revision: %w", err)
+	}
+	return nil
+}
+
+// ListCompanyDrafts retrieves a company's drafts with the fields each still needs before publishing
+func (s *jobService) ListCompanyDrafts(ctx context.Context, companyID int64, page, limit int) ([]job.JobDraft, int64, error) {
+	jobs, total, err := s.jobRepo.ListByCompany(ctx, companyID, job.JobFilter{Status: job.StatusDraft}, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list drafts: %w", err)
+	}
+
+	drafts := make([]job.JobDraft, len(jobs))
+	for i := range jobs {
+		missing := missingPublishFields(&jobs[i])
+		fields := make([]string, len(missing))
+		for k, m := range missing {
+			fields[k] = m.field
+		}
+		drafts[i] = job.JobDraft{Job: jobs[i], MissingFields: fields}
+	}
+
+	return drafts, total, nil
+}
+
+// ListDraftRevisions retrieves the saved revisions of a draft, newest first
+func (s *jobService) ListDraftRevisions(ctx context.Context, jobID int64, ec *employer.EmployerContext) ([]job.JobDraftRevision, error) {
+	if _, err := s.getOwnedDraft(ctx, jobID, ec); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.jobRepo.ListDraftRevisions(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list draft revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+// RestoreDraftRevision saves a draft again with the payload of one of its revisions.
+// The restored payload becomes the newest revision, so a restore can itself be undone.
+func (s *jobService) RestoreDraftRevision(ctx context.Context, jobID, revisionID int64, ec *employer.EmployerContext) (*job.Job, error) {
+	draft, err := s.getOwnedDraft(ctx, jobID, ec)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := s.jobRepo.FindDraftRevision(ctx, jobID, revisionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get draft revision: %w", err)
+	}
+	if revision == nil {
+		return nil, job.ErrDraftRevisionNotFound
+	}
+
+	var req job.SaveJobDraftRequest
+	if err := json.Unmarshal([]byte(revision.Payload), &req); err != nil {
+		return nil, fmt.Errorf("failed to decode draft revision: %w", err)
+	}
+	req.DraftID = &draft.ID
+
+	return s.SaveJobDraft(ctx, draft.CompanyID, &req)
+}
+
+// getOwnedDraft loads a job the employer user may manage and checks it is still a draft
+func (s *jobService) getOwnedDraft(ctx context.Context, jobID int64, ec *employer.EmployerContext) (*job.Job, error) {
+	if err := s.CheckJobOwnership(ctx, jobID, ec); err != nil {
+		return nil, err
+	}
+
+	j, err := s.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, jobLookupError(err)
+	}
+	if j == nil {
+		return nil, job.ErrJobNotFound
+	}
+	if j.Status != job.StatusDraft {
+		return nil, job.ErrJobNotDraft
+	}
+	return j, nil
+}
+
 // DeleteJob deletes a job (soft delete)
-func (s *jobService) DeleteJob(ctx context.Context, jobID int64, employerUserID int64) error {
+func (s *jobService) DeleteJob(ctx context.Context, jobID int64, ec *employer.EmployerContext) error {
 	// Check ownership
-	if err := s.CheckJobOwnership(ctx, jobID, employerUserID); err != nil {
+	if err := s.CheckJobOwnership(ctx, jobID, ec); err != nil {
+		return err
+	}
+
+	// Soft delete job; its open applications are withdrawn in the same transaction
+	withdrawn, err := s.jobRepo.SoftDelete(ctx, jobID)
+	if err != nil {
+		return jobLookupError(err)
+	}
+	s.notifyJobWithdrawn(ctx, withdrawn)
+
+	// Draft revisions are only useful while the job exists
+	if err := s.jobRepo.DeleteDraftRevisions(ctx, jobID); err != nil {
+		return fmt.Errorf("failed to delete draft revisions: %w", err)
+	}
+	return nil
+}
+
+// RestoreJob brings back a job the employer user deleted within the restore window,
+// together with the applications the deletion withdrew
+func (s *jobService) RestoreJob(ctx context.Context, jobID int64, ec *employer.EmployerContext) error {
+	j, err := s.jobRepo.FindDeletedByID(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to get deleted job: %w", err)
+	}
+	if j == nil {
+		return job.ErrJobNotDeleted
+	}
+
+	if err := checkOwnership(j, ec); err != nil {
 		return err
 	}
+	if !j.CanRestore(time.Now()) {
+		return job.ErrJobRestoreExpired
+	}
 
-	// Soft delete job
-	return s.jobRepo.SoftDelete(ctx, jobID)
+	if err := s.jobRepo.Restore(ctx, jobID); err != nil {
+		return fmt.Errorf("failed to restore job: %w", err)
+	}
+	return nil
+}
+
+// notifyJobWithdrawn tells applicants that their application was closed because the job
+// was deleted. A failed notification is only logged; the deletion has already happened.
+func (s *jobService) notifyJobWithdrawn(ctx context.Context, withdrawn []job.WithdrawnApplication) {
+	if s.notifService == nil {
+		return
+	}
+	for _, app := range withdrawn {
+		if err := s.notifService.NotifyStatusUpdate(ctx, app.UserID, app.ApplicationID, app.PreviousStatus, application.StatusJobWithdrawn); err != nil {
+			log.Printf("Failed to notify user %d about withdrawn application %d: %v", app.UserID, app.ApplicationID, err)
+		}
+	}
+}
+
+// DuplicateJob copies a job the employer user may manage into a new draft, including its
+// locations, benefits, skills, requirements and hiring pipeline
+func (s *jobService) DuplicateJob(ctx context.Context, jobID int64, ec *employer.EmployerContext) (*job.Job, error) {
+	src, err := s.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, jobLookupError(err)
+	}
+	if src == nil {
+		return nil, job.ErrJobNotFound
+	}
+
+	if err := checkOwnership(src, ec); err != nil {
+		return nil, err
+	}
+
+	dup := src.CloneAsDraft()
+
+	// The copy belongs to the employer user who made it
+	dup.EmployerUserID = utils.Int64Ptr(ec.EmployerUserID)
+
+	slug, err := s.uniqueJobSlug(ctx, dup.Title, 0)
+	if err != nil {
+		return nil, err
+	}
+	dup.Slug = slug
+
+	pipeline, err := loadJobPipeline(ctx, s.jobRepo, src.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.jobRepo.CreateWithRelations(ctx, dup); err != nil {
+		return nil, fmt.Errorf("failed to duplicate job: %w", err)
+	}
+	if err := s.jobRepo.ReplacePipelineStages(ctx, dup.ID, pipeline); err != nil {
+		return nil, fmt.Errorf("failed to copy pipeline: %w", err)
+	}
+
+	return s.GetJob(ctx, dup.ID)
 }
 
 // GetJob retrieves a job by ID
 func (s *jobService) GetJob(ctx context.Context, jobID int64) (*job.Job, error) {
-	return s.jobRepo.FindByID(ctx, jobID)
+	j, err := s.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, jobLookupError(err)
+	}
+	return j, nil
+}
+
+// GetJobCompanyID returns the company that owns the job, looking at deleted jobs when no live
+// job has the ID so deleted jobs can still be restored by their company
+func (s *jobService) GetJobCompanyID(ctx context.Context, jobID int64) (int64, error) {
+	j, err := s.jobRepo.FindByID(ctx, jobID)
+	if err == nil {
+		return j.CompanyID, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, jobLookupError(err)
+	}
+
+	deleted, err := s.jobRepo.FindDeletedByID(ctx, jobID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get deleted job: %w", err)
+	}
+	if deleted == nil {
+		return 0, job.ErrJobNotFound
+	}
+	return deleted.CompanyID, nil
+}
+
+// GetLatestReview retrieves the most recent admin review of a job
+func (s *jobService) GetLatestReview(ctx context.Context, jobID int64) (*job.JobReview, error) {
+	return s.jobRepo.FindLatestReview(ctx, jobID)
 }
 
 // GetJobBySlug retrieves a job by slug
 func (s *jobService) GetJobBySlug(ctx context.Context, slug string) (*job.Job, error) {
-	return s.jobRepo.FindBySlug(ctx, slug)
+	j, err := s.jobRepo.FindBySlug(ctx, slug)
+	if err != nil {
+		return nil, jobLookupError(err)
+	}
+	return j, nil
+}
+
+// uniqueJobSlug generates a slug for title that no other job uses; excludeID keeps a job's own slug available to it
+func (s *jobService) uniqueJobSlug(ctx context.Context, title string, excludeID int64) (string, error) {
+	slug, err := utils.GenerateUniqueSlug(ctx, title, func(ctx context.Context, candidate string) (bool, error) {
+		return s.jobRepo.SlugExists(ctx, candidate, excludeID)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to generate job slug: %w", err)
+	}
+	return slug, nil
 }
 
 // GetJobByUUID retrieves a job by UUID
@@ -533,9 +846,12 @@ func (s *jobService) GetJobByUUID(ctx context.Context, uuidStr string) (*job.Job
 	return s.jobRepo.FindByUUID(ctx, uuidStr)
 }
 
-// GetMyJobs retrieves jobs created by employer user
-func (s *jobService) GetMyJobs(ctx context.Context, employerUserID int64, filter job.JobFilter, page, limit int) ([]job.Job, int64, error) {
-	return s.jobRepo.ListByEmployer(ctx, employerUserID, filter, page, limit)
+// GetMyJobs retrieves jobs posted by the employer user
+func (s *jobService) GetMyJobs(ctx context.Context, ec *employer.EmployerContext, filter job.JobFilter, page, limit int) ([]job.Job, int64, error) {
+	if ec == nil {
+		return nil, 0, company.ErrNotCompanyEmployer
+	}
+	return s.jobRepo.ListByEmployer(ctx, ec.EmployerUserID, filter, page, limit)
 }
 
 // GetCompanyJobs retrieves all jobs for a company
@@ -545,28 +861,50 @@ func (s *jobService) GetCompanyJobs(ctx context.Context, companyID int64, filter
 
 // ===== Job Status Management =====
 
+// publishJobEvent sends a job event to the webhooks of the job's company
+func publishJobEvent(ctx context.Context, publisher webhook.EventPublisher, j *job.Job, event, status string, publishedAt, expiredAt *time.Time) {
+	publishWebhookEvent(ctx, publisher, j.CompanyID, event, webhook.JobEventData{
+		JobID:       j.ID,
+		Title:       j.Title,
+		Slug:        j.Slug,
+		Status:      status,
+		PublishedAt: publishedAt,
+		ExpiredAt:   expiredAt,
+	})
+}
+
+// checkPublishQuota returns company.ErrJobQuotaExceeded when the company already has as many
+// published jobs as its plan allows. A nil quota service allows any number.
+func checkPublishQuota(ctx context.Context, quotas company.QuotaService, companyID int64) error {
+	if quotas == nil {
+		return nil
+	}
+	_, err := quotas.CheckCanPublish(ctx, companyID)
+	return err
+}
+
 // PublishJob publishes a job (Phase 7: changes status from draft to pending_review)
-func (s *jobService) PublishJob(ctx context.Context, jobID int64, employerUserID int64, expiredAt *time.Time) error {
+func (s *jobService) PublishJob(ctx context.Context, jobID int64, ec *employer.EmployerContext, expiredAt *time.Time) error {
 	// Check ownership
-	if err := s.CheckJobOwnership(ctx, jobID, employerUserID); err != nil {
+	if err := s.CheckJobOwnership(ctx, jobID, ec); err != nil {
 		return err
 	}
 
 	// Get job to validate
 	j, err := s.jobRepo.FindByID(ctx, jobID)
 	if err != nil {
-		return fmt.Errorf("job not found: %w", err)
+		return jobLookupError(err)
 	}
 
 	// Validate job is in draft status
-	if j.Status != "draft" {
-		if j.Status == "pending_review" {
-			return errors.New("job is already pending review")
+	if j.Status != job.StatusDraft {
+		if j.Status == job.StatusPendingReview {
+			return job.ErrJobAlreadyPendingReview
 		}
-		if j.Status == "published" {
-			return errors.New("job is already published")
+		if j.Status == job.StatusPublished {
+			return job.ErrJobAlreadyPublished
 		}
-		return fmt.Errorf("cannot publish job with status: %s", j.Status)
+		return job.ErrJobNotPublishable.WithField("status", j.Status)
 	}
 
 	// Determine publish flow based on company verification and job status
@@ -577,59 +915,64 @@ func (s *jobService) PublishJob(ctx context.Context, jobID int64, employerUserID
 
 	// Validate job before publishing
 	if err := s.ValidateJob(ctx, j); err != nil {
-		return fmt.Errorf("cannot publish job: %w", err)
+		return job.ErrJobIncomplete.WithField("reason", err.Error())
+	}
+
+	if err := checkPublishQuota(ctx, s.quotas, j.CompanyID); err != nil {
+		return err
 	}
 
 	// If the job is a draft OR the company is verified, publish immediately.
 	// Otherwise, move the job to pending_review so admins can approve.
-	if company.Verified || j.Status == "draft" {
+	if company.Verified || j.Status == job.StatusDraft {
 		now := time.Now()
-		if err := s.jobRepo.UpdateStatusWithExpiry(ctx, jobID, "published", &now, expiredAt); err != nil {
+		if expiredAt == nil {
+			defaultExpiry := s.expiry.PublishExpiry(j, now)
+			expiredAt = &defaultExpiry
+		}
+		if err := s.jobRepo.UpdateStatusWithExpiry(ctx, jobID, job.StatusPublished, &now, expiredAt); err != nil {
 			return fmt.Errorf("failed to publish job: %w", err)
 		}
+		notifyFollowersAsync(s.followerNotifier, jobID)
+		publishJobEvent(ctx, s.webhooks, j, webhook.EventJobPublished, job.StatusPublished, &now, expiredAt)
 		return nil
 	}
 
 	// Company not verified and job not draft -> submit for admin review
-	if err := s.jobRepo.UpdateStatus(ctx, jobID, "pending_review"); err != nil {
+	// Admins pick it up from GET /admin/jobs/pending
+	if err := s.jobRepo.SubmitForReview(ctx, jobID); err != nil {
 		return fmt.Errorf("failed to update job status to pending_review: %w", err)
 	}
-	return nil
-
-	// TODO Phase 7: Trigger admin notification
-	// This should trigger an event/notification to admin system
-	// that there's a new job pending review
-	// For now, we'll just log it (implement notification system separately)
 
 	return nil
 }
 
 // UnpublishJob unpublishes a job (hides from job seekers)
-func (s *jobService) UnpublishJob(ctx context.Context, jobID int64, employerUserID int64) error {
+func (s *jobService) UnpublishJob(ctx context.Context, jobID int64, ec *employer.EmployerContext) error {
 	// Check ownership
-	if err := s.CheckJobOwnership(ctx, jobID, employerUserID); err != nil {
+	if err := s.CheckJobOwnership(ctx, jobID, ec); err != nil {
 		return err
 	}
 
 	// Update status to draft
-	return s.jobRepo.UpdateStatus(ctx, jobID, "draft")
+	return s.jobRepo.UpdateStatus(ctx, jobID, job.StatusDraft)
 }
 
 // InactivateJob marks a job as inactive (hidden from job seekers but not deleted)
-func (s *jobService) InactivateJob(ctx context.Context, jobID int64, employerUserID int64) error {
+func (s *jobService) InactivateJob(ctx context.Context, jobID int64, ec *employer.EmployerContext) error {
 	// Check ownership
-	if err := s.CheckJobOwnership(ctx, jobID, employerUserID); err != nil {
+	if err := s.CheckJobOwnership(ctx, jobID, ec); err != nil {
 		return err
 	}
 
 	// Update status to inactive
-	return s.jobRepo.UpdateStatus(ctx, jobID, "inactive")
+	return s.jobRepo.UpdateStatus(ctx, jobID, job.StatusInactive)
 }
 
 // CloseJob closes a job (no longer accepting applications)
-func (s *jobService) CloseJob(ctx context.Context, jobID int64, employerUserID int64) error {
+func (s *jobService) CloseJob(ctx context.Context, jobID int64, ec *employer.EmployerContext) error {
 	// Check ownership
-	if err := s.CheckJobOwnership(ctx, jobID, employerUserID); err != nil {
+	if err := s.CheckJobOwnership(ctx, jobID, ec); err != nil {
 		return err
 	}
 
@@ -638,39 +981,51 @@ func (s *jobService) CloseJob(ctx context.Context, jobID int64, employerUserID i
 }
 
 // ReopenJob reopens a closed job
-func (s *jobService) ReopenJob(ctx context.Context, jobID int64, employerUserID int64) error {
+func (s *jobService) ReopenJob(ctx context.Context, jobID int64, ec *employer.EmployerContext) error {
 	// Check ownership
-	if err := s.CheckJobOwnership(ctx, jobID, employerUserID); err != nil {
+	if err := s.CheckJobOwnership(ctx, jobID, ec); err != nil {
 		return err
 	}
 
 	// Get job
 	j, err := s.jobRepo.FindByID(ctx, jobID)
 	if err != nil {
-		return fmt.Errorf("job not found: %w", err)
+		return jobLookupError(err)
 	}
 
 	// Check if job is closed
-	if j.Status != "closed" && j.Status != "expired" {
-		return errors.New("only closed or expired jobs can be reopened")
+	if j.Status != job.StatusClosed && j.Status != job.StatusExpired {
+		return job.ErrJobNotReopenable
 	}
 
 	// Validate job before reopening
 	if err := s.ValidateJob(ctx, j); err != nil {
-		return fmt.Errorf("cannot reopen job: %w", err)
+		return job.ErrJobIncomplete.WithField("reason", err.Error())
 	}
 
-	// Reopen job (set to published)
-	return s.jobRepo.PublishJob(ctx, jobID)
+	// A reopened job takes a published job slot again
+	if err := checkPublishQuota(ctx, s.quotas, j.CompanyID); err != nil {
+		return err
+	}
+
+	// Reopen job (set to published) for a new expiry period
+	now := time.Now()
+	expiredAt := s.expiry.PublishExpiry(j, now)
+	if err := s.jobRepo.UpdateStatusWithExpiry(ctx, jobID, job.StatusPublished, &now, &expiredAt); err != nil {
+		return err
+	}
+	notifyFollowersAsync(s.followerNotifier, jobID)
+	publishJobEvent(ctx, s.webhooks, j, webhook.EventJobPublished, job.StatusPublished, &now, &expiredAt)
+	return nil
 }
 
 // SuspendJob suspends a job (admin action)
-func (s *jobService) SuspendJob(ctx context.Context, jobID int64, employerUserID int64, reason string) error {
+func (s *jobService) SuspendJob(ctx context.Context, jobID int64, ec *employer.EmployerContext, reason string) error {
 	// Note: In production, this should check for admin privileges
 	// For now, we'll allow employer to suspend their own jobs
 
 	// Check ownership
-	if err := s.CheckJobOwnership(ctx, jobID, employerUserID); err != nil {
+	if err := s.CheckJobOwnership(ctx, jobID, ec); err != nil {
 		return err
 	}
 
@@ -683,11 +1038,12 @@ func (s *jobService) SetJobExpiry(ctx context.Context, jobID int64, expiryDate t
 	// Get job
 	j, err := s.jobRepo.FindByID(ctx, jobID)
 	if err != nil {
-		return fmt.Errorf("job not found: %w", err)
+		return jobLookupError(err)
 	}
 
-	// Update expiry date
+	// Update expiry date; reminders start over for the new date
 	j.ExpiredAt = &expiryDate
+	j.ExpiryReminderDays = nil
 
 	return s.jobRepo.Update(ctx, j)
 }
@@ -697,7 +1053,7 @@ func (s *jobService) ExtendJobExpiry(ctx context.Context, jobID int64, days int)
 	// Get job
 	j, err := s.jobRepo.FindByID(ctx, jobID)
 	if err != nil {
-		return fmt.Errorf("job not found: %w", err)
+		return jobLookupError(err)
 	}
 
 	// Calculate new expiry date
@@ -708,8 +1064,9 @@ func (s *jobService) ExtendJobExpiry(ctx context.Context, jobID int64, days int)
 		newExpiry = time.Now().AddDate(0, 0, days)
 	}
 
-	// Update expiry date
+	// Update expiry date; reminders start over for the new date
 	j.ExpiredAt = &newExpiry
+	j.ExpiryReminderDays = nil
 
 	return s.jobRepo.Update(ctx, j)
 }
@@ -717,41 +1074,118 @@ func (s *jobService) ExtendJobExpiry(ctx context.Context, jobID int64, days int)
 // UpdateStatus updates a job's status
 // This is called by admin service during approval/rejection
 func (s *jobService) UpdateStatus(ctx context.Context, jobID int64, status string) error {
-	// Validate status value
-	validStatuses := map[string]bool{
-		"draft":          true,
-		"pending_review": true,
-		"published":      true,
-		"closed":         true,
-		"expired":        true,
-		"suspended":      true,
+	if !job.IsValidStatus(status) {
+		return job.ErrInvalidJobStatus.WithField("status", "must be one of "+strings.Join(job.Statuses, ", "))
 	}
 
-	if !validStatuses[status] {
-		return fmt.Errorf("invalid job status: %s", status)
+	j, err := s.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return jobLookupError(err)
+	}
+	if !job.IsValidTransition(j.Status, status) {
+		return job.ErrInvalidStatusTransition.WithField("status", j.Status)
 	}
 
 	// Update job status in database
 	return s.jobRepo.UpdateStatus(ctx, jobID, status)
 }
 
-// AutoExpireJobs automatically expires jobs past their expiry date (cron job)
-func (s *jobService) AutoExpireJobs(ctx context.Context) error {
+// AutoExpireJobs expires published jobs past their expiry date (cron job). Jobs flagged
+// auto_extend get another default expiry period instead, up to MaxAutoExtensions times.
+func (s *jobService) AutoExpireJobs(ctx context.Context) (*job.ExpiryStats, error) {
+	stats := &job.ExpiryStats{}
+
 	// Get expired jobs
 	expiredJobs, err := s.jobRepo.GetExpiredJobs(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get expired jobs: %w", err)
+		return stats, fmt.Errorf("failed to get expired jobs: %w", err)
 	}
 
-	// Expire each job
+	now := time.Now()
 	for _, j := range expiredJobs {
+		if j.CanAutoExtend(s.expiry) {
+			extended, err := s.jobRepo.AutoExtendJob(ctx, j.ID, now.Add(s.expiry.DefaultPeriod), s.expiry.MaxAutoExtensions)
+			if err != nil {
+				fmt.Printf("failed to extend job %d: %v\n", j.ID, err)
+				continue
+			}
+			if extended {
+				stats.Extended++
+				continue
+			}
+		}
+
+		// Expire job; log errors but continue with other jobs
 		if err := s.jobRepo.ExpireJob(ctx, j.ID); err != nil {
-			// Log error but continue with other jobs
 			fmt.Printf("failed to expire job %d: %v\n", j.ID, err)
+			continue
 		}
+		publishJobEvent(ctx, s.webhooks, &j, webhook.EventJobExpired, job.StatusExpired, j.PublishedAt, j.ExpiredAt)
+		stats.Expired++
 	}
 
-	return nil
+	return stats, nil
+}
+
+// SendExpiryReminders emails the employers of published jobs that expire within one of
+// jobExpiryReminderDays. Each threshold is sent once per expiry date; it returns the
+// number of jobs reminded.
+func (s *jobService) SendExpiryReminders(ctx context.Context) (int, error) {
+	sent := 0
+	now := time.Now()
+
+	for _, days := range jobExpiryReminderDays {
+		jobs, err := s.jobRepo.FindJobsExpiringWithin(ctx, days)
+		if err != nil {
+			return sent, fmt.Errorf("failed to get jobs expiring within %d days: %w", days, err)
+		}
+
+		for i := range jobs {
+			j := &jobs[i]
+			marked, err := s.jobRepo.MarkExpiryReminded(ctx, j.ID, days)
+			if err != nil {
+				fmt.Printf("[WARN] failed to mark job %d expiry reminded: %v\n", j.ID, err)
+				continue
+			}
+			if !marked {
+				continue
+			}
+
+			s.sendExpiryReminder(ctx, j, daysUntil(*j.ExpiredAt, now))
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// sendExpiryReminder emails the employer who posted the job about its upcoming expiry
+func (s *jobService) sendExpiryReminder(ctx context.Context, j *job.Job, daysLeft int) {
+	if s.emailService == nil || j.EmployerUserID == nil {
+		return
+	}
+
+	employerUser, err := s.companyRepo.FindEmployerUserByID(ctx, *j.EmployerUserID)
+	if err != nil || employerUser == nil || !employerUser.IsActive {
+		fmt.Printf("[WARN] job %d expiry reminder: no active employer user %d: %v\n", j.ID, *j.EmployerUserID, err)
+		return
+	}
+
+	owner, err := s.userRepo.FindByID(ctx, employerUser.UserID)
+	if err != nil || owner == nil {
+		fmt.Printf("[WARN] job %d expiry reminder: user %d not found: %v\n", j.ID, employerUser.UserID, err)
+		return
+	}
+
+	companyName := ""
+	if comp, err := s.companyRepo.FindByID(ctx, j.CompanyID); err == nil && comp != nil {
+		companyName = comp.CompanyName
+	}
+
+	ownerCtx := userLocaleContext(ctx, s.userRepo, owner.ID)
+	if err := s.emailService.SendJobExpiryReminderEmail(ownerCtx, owner.Email, owner.FullName, j.Title, companyName, daysLeft, *j.ExpiredAt, j.CanAutoExtend(s.expiry)); err != nil {
+		fmt.Printf("[WARN] job %d expiry reminder: failed to send email: %v\n", j.ID, err)
+	}
 }
 
 // ===== Job Search and Discovery (Public) =====
@@ -760,12 +1194,21 @@ func (s *jobService) AutoExpireJobs(ctx context.Context) error {
 func (s *jobService) ListJobs(ctx context.Context, filter job.JobFilter, page, limit int) ([]job.Job, int64, error) {
 	// Set default filter for public listing (only show published jobs)
 	if filter.Status == "" {
-		filter.Status = "published"
+		filter.Status = job.StatusPublished
 	}
 
 	return s.jobRepo.List(ctx, filter, page, limit)
 }
 
+// ListJobsByCursor lists published jobs newest first, continuing after the given cursor
+func (s *jobService) ListJobsByCursor(ctx context.Context, filter job.JobFilter, cursor string, limit int) ([]job.Job, string, error) {
+	if filter.Status == "" {
+		filter.Status = job.StatusPublished
+	}
+
+	return s.jobRepo.ListByCursor(ctx, filter, cursor, limit)
+}
+
 // SearchJobs performs advanced job search
 func (s *jobService) SearchJobs(ctx context.Context, filter job.JobSearchFilter, page, limit int) (*job.JobSearchResponse, error) {
 	// Perform search
@@ -786,7 +1229,13 @@ func (s *jobService) SearchJobs(ctx context.Context, filter job.JobSearchFilter,
 		TotalPages: totalPages,
 	}
 
-	// TODO: Add facets and suggestions (requires additional implementation)
+	// Facets are best-effort; a failure here shouldn't fail the search itself
+	facets, err := s.jobRepo.GetSearchFacets(ctx, filter)
+	if err != nil {
+		fmt.Printf("[WARN] failed to compute search facets: %v\n", err)
+	} else {
+		response.Facets = facets
+	}
 
 	return response, nil
 }
@@ -796,22 +1245,16 @@ func (s *jobService) SearchJobsByLocation(ctx context.Context, latitude, longitu
 	return s.jobRepo.SearchByLocation(ctx, latitude, longitude, radius, filter, page, limit)
 }
 
-// GetFeaturedJobs retrieves featured jobs
+// GetFeaturedJobs retrieves featured jobs: the jobs admins featured for now, then the jobs
+// with the most views over the last week, recent views counting more
 func (s *jobService) GetFeaturedJobs(ctx context.Context, limit int) ([]job.Job, error) {
-	// Featured jobs logic: published jobs from verified companies, sorted by views
-	filter := job.JobFilter{
-		Status: "published",
-		SortBy: "views",
-	}
-
-	jobs, _, err := s.jobRepo.List(ctx, filter, 1, limit)
-	return jobs, err
+	return s.cachedJobList(ctx, job.FeaturedJobsCacheKey, limit, s.computeFeaturedJobs)
 }
 
 // GetLatestJobs retrieves latest published jobs
 func (s *jobService) GetLatestJobs(ctx context.Context, limit int) ([]job.Job, error) {
 	filter := job.JobFilter{
-		Status: "published",
+		Status: job.StatusPublished,
 		SortBy: "latest",
 	}
 
@@ -819,14 +1262,153 @@ func (s *jobService) GetLatestJobs(ctx context.Context, limit int) ([]job.Job, e
 	return jobs, err
 }
 
-// GetTrendingJobs retrieves trending jobs (most viewed recently)
+// GetTrendingJobs retrieves trending jobs: the jobs with the most views within the trending window
 func (s *jobService) GetTrendingJobs(ctx context.Context, limit int) ([]job.Job, error) {
-	return s.jobRepo.GetTrendingJobs(ctx, limit)
+	return s.cachedJobList(ctx, job.TrendingJobsCacheKey, limit, s.computeTrendingJobs)
 }
 
-// GetRecommendedJobs retrieves recommended jobs for a user
+// cachedJobList returns the first limit jobs of the list cached under key, computing and
+// caching the full list of up to job.MaxFeaturedJobs jobs on a miss
+func (s *jobService) cachedJobList(ctx context.Context, key string, limit int, compute func(context.Context, time.Time) ([]job.Job, error)) ([]job.Job, error) {
+	if limit <= 0 || limit > job.MaxFeaturedJobs {
+		limit = job.MaxFeaturedJobs
+	}
+
+	var jobs []job.Job
+	cached := false
+	if s.cache != nil {
+		jobs, cached = cache.GetTyped[[]job.Job](s.cache, key)
+	}
+	if !cached {
+		var err error
+		if jobs, err = compute(ctx, time.Now()); err != nil {
+			return nil, err
+		}
+		if s.cache != nil {
+			s.cache.Set(key, jobs, job.FeaturedJobsCacheTTL)
+		}
+	}
+
+	if len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	return jobs, nil
+}
+
+// computeFeaturedJobs lists the jobs featured at now, filled up with the best jobs by
+// decayed views within job.FeaturedViewWindow
+func (s *jobService) computeFeaturedJobs(ctx context.Context, now time.Time) ([]job.Job, error) {
+	jobs, err := s.jobRepo.ListFeatured(ctx, now, job.MaxFeaturedJobs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list featured jobs: %w", err)
+	}
+	if len(jobs) >= job.MaxFeaturedJobs {
+		return jobs, nil
+	}
+
+	buckets, err := s.jobRepo.ListViewBuckets(ctx, now.Add(-job.FeaturedViewWindow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count job views: %w", err)
+	}
+
+	curated := make(map[int64]bool, len(jobs))
+	for _, j := range jobs {
+		curated[j.ID] = true
+	}
+	var fillIDs []int64
+	for _, id := range job.RankJobsByViews(buckets, now, job.FeaturedViewHalfLife) {
+		if len(jobs)+len(fillIDs) == job.MaxFeaturedJobs {
+			break
+		}
+		if !curated[id] {
+			fillIDs = append(fillIDs, id)
+		}
+	}
+
+	fill, err := s.loadRankedJobs(ctx, fillIDs)
+	if err != nil {
+		return nil, err
+	}
+	return append(jobs, fill...), nil
+}
+
+// computeTrendingJobs lists the jobs with the most views within job.TrendingViewWindow
+func (s *jobService) computeTrendingJobs(ctx context.Context, now time.Time) ([]job.Job, error) {
+	buckets, err := s.jobRepo.ListViewBuckets(ctx, now.Add(-job.TrendingViewWindow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count job views: %w", err)
+	}
+
+	ids := job.RankJobsByViews(buckets, now, 0)
+	if len(ids) > job.MaxFeaturedJobs {
+		ids = ids[:job.MaxFeaturedJobs]
+	}
+	return s.loadRankedJobs(ctx, ids)
+}
+
+// loadRankedJobs loads the published jobs among ids in the order of ids
+func (s *jobService) loadRankedJobs(ctx context.Context, ids []int64) ([]job.Job, error) {
+	if len(ids) == 0 {
+		return []job.Job{}, nil
+	}
+	found, err := s.jobRepo.FindPublishedByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load jobs: %w", err)
+	}
+
+	byID := make(map[int64]job.Job, len(found))
+	for _, j := range found {
+		byID[j.ID] = j
+	}
+	jobs := make([]job.Job, 0, len(found))
+	for _, id := range ids {
+		if j, ok := byID[id]; ok {
+			jobs = append(jobs, j)
+		}
+	}
+	return jobs, nil
+}
+
+// GetRecommendedJobs retrieves recommended jobs for a user: the jobs with the user's best
+// fresh precomputed match scores, topped up with the latest jobs when there are fewer than limit
 func (s *jobService) GetRecommendedJobs(ctx context.Context, userID int64, limit int) ([]job.Job, error) {
-	return s.jobRepo.GetRecommendedJobs(ctx, userID, limit)
+	if s.matchScores == nil {
+		return s.jobRepo.GetRecommendedJobs(ctx, userID, limit)
+	}
+
+	top, err := s.matchScores.TopJobsForUser(ctx, userID, time.Now().Add(-job.MatchScoreFreshness), limit)
+	if err != nil {
+		log.Printf("Failed to load top matching jobs of user %d: %v", userID, err)
+	}
+	if len(top) >= limit {
+		jobs := make([]job.Job, len(top))
+		for i := range top {
+			jobs[i] = top[i].Job
+		}
+		return jobs, nil
+	}
+
+	// Not enough scored jobs (e.g. a new user): fill up with the latest jobs, which may
+	// include some of the scored ones
+	latest, err := s.jobRepo.GetRecommendedJobs(ctx, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]job.Job, 0, limit)
+	seen := make(map[int64]bool, limit)
+	for i := range top {
+		jobs = append(jobs, top[i].Job)
+		seen[top[i].Job.ID] = true
+	}
+	for _, j := range latest {
+		if len(jobs) >= limit {
+			break
+		}
+		if !seen[j.ID] {
+			jobs = append(jobs, j)
+		}
+	}
+	return jobs, nil
 }
 
 // GetSimilarJobs retrieves jobs similar to a given job
@@ -841,7 +1423,7 @@ func (s *jobService) CalculateMatchScore(ctx context.Context, jobID, userID int6
 	// Get job with skills and requirements
 	j, err := s.jobRepo.FindByID(ctx, jobID)
 	if err != nil {
-		return nil, fmt.Errorf("job not found: %w", err)
+		return nil, jobLookupError(err)
 	}
 
 	// Get user profile with skills, education, and experience
@@ -850,14 +1432,25 @@ func (s *jobService) CalculateMatchScore(ctx context.Context, jobID, userID int6
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
 
+	matchScore := s.scoreJob(ctx, j, userProfile)
+
+	// Generate recommendation
+	matchScore.Recommendation = s.generateRecommendation(i18n.FromContext(ctx), matchScore)
+
+	return matchScore, nil
+}
+
+// scoreJob calculates the match score of a job for a loaded user profile, without the
+// recommendation text. On-demand and precomputed scores both go through it.
+func (s *jobService) scoreJob(ctx context.Context, j *job.Job, userProfile *user.User) *job.MatchScore {
 	// Initialize match score
 	matchScore := &job.MatchScore{
-		JobID:  jobID,
-		UserID: userID,
+		JobID:  j.ID,
+		UserID: userProfile.ID,
 	}
 
 	// Calculate skill score
-	skillScore, matchedSkills, missingSkills := s.calculateSkillScore(j, userProfile)
+	skillScore, matchedSkills, missingSkills := s.calculateSkillScore(ctx, j, userProfile)
 	matchScore.SkillScore = skillScore
 	matchScore.MatchedSkills = matchedSkills
 	matchScore.MissingSkills = missingSkills
@@ -875,41 +1468,59 @@ func (s *jobService) CalculateMatchScore(ctx context.Context, jobID, userID int6
 	matchScore.OverallScore = (skillScore*0.4 + matchScore.ExperienceScore*0.3 +
 		matchScore.EducationScore*0.2 + matchScore.LocationScore*0.1)
 
-	// Generate recommendation
-	matchScore.Recommendation = s.generateRecommendation(matchScore)
-
-	return matchScore, nil
+	return matchScore
 }
 
-// calculateSkillScore calculates skill match score
-func (s *jobService) calculateSkillScore(j *job.Job, userProfile *user.User) (float64, []string, []string) {
+// Skill importance multipliers applied on top of each job skill's weight
+const (
+	requiredSkillFactor  = 1.0
+	preferredSkillFactor = 0.6
+	optionalSkillFactor  = 0.3
+)
+
+// calculateSkillScore calculates skill match score.
+// Each job skill contributes Weight x importance factor (required 1.0, preferred 0.6,
+// optional 0.3; a zero weight counts as 1.0). user_skills only stores skill names, so a
+// job skill matches when one of the user's skill names equals the skills_master name,
+// normalized name or one of its aliases after normalizing case and whitespace.
+func (s *jobService) calculateSkillScore(ctx context.Context, j *job.Job, userProfile *user.User) (float64, []string, []string) {
 	if len(j.Skills) == 0 {
 		return 1.0, []string{}, []string{} // No skills required, perfect match
 	}
 
-	// Create map of user skills (by skill name since UserSkill doesn't have SkillID)
-	userSkills := make(map[string]bool)
+	// Index user skills by normalized name
+	userSkills := make(map[string]bool, len(userProfile.Skills))
 	for _, skill := range userProfile.Skills {
-		userSkills[strings.ToLower(skill.SkillName)] = true
+		if name := master.NormalizeSkillName(skill.SkillName); name != "" {
+			userSkills[name] = true
+		}
 	}
 
 	// Calculate matched and missing skills
-	var matchedSkills, missingSkills []string
+	matchedSkills := []string{}
+	missingSkills := []string{}
 	var totalWeight, matchedWeight float64
 
 	for _, jobSkill := range j.Skills {
-		totalWeight += jobSkill.Weight
+		weight := jobSkill.Weight
+		if weight <= 0 {
+			weight = 1.0
+		}
+		weight *= skillImportanceFactor(jobSkill.ImportanceLevel)
+		totalWeight += weight
 
-		// For now, we match by skill ID
-		// TODO: In production, implement proper skill matching logic
-		skillName := fmt.Sprintf("Skill-%d", jobSkill.SkillID)
+		skill := s.resolveJobSkill(ctx, jobSkill)
+		if skill == nil {
+			// Unknown skill: it can't be matched but still counts towards the total
+			missingSkills = append(missingSkills, fmt.Sprintf("Skill #%d", jobSkill.SkillID))
+			continue
+		}
 
-		// Check if user has this skill (simplified matching)
-		if len(userSkills) > 0 {
-			matchedWeight += jobSkill.Weight
-			matchedSkills = append(matchedSkills, skillName)
-		} else if jobSkill.ImportanceLevel == "required" {
-			missingSkills = append(missingSkills, skillName)
+		if userHasSkill(userSkills, skill) {
+			matchedWeight += weight
+			matchedSkills = append(matchedSkills, skill.Name)
+		} else {
+			missingSkills = append(missingSkills, skill.Name)
 		}
 	}
 
@@ -919,7 +1530,48 @@ func (s *jobService) calculateSkillScore(j *job.Job, userProfile *user.User) (fl
 		score = matchedWeight / totalWeight
 	}
 
-	return score, matchedSkills, missingSkills
+	return score, matchedSkills, missingSkills
+}
+
+// resolveJobSkill returns the skills_master entry for a job skill, loading it when it wasn't preloaded
+func (s *jobService) resolveJobSkill(ctx context.Context, jobSkill job.JobSkill) *master.SkillsMaster {
+	if jobSkill.Skill != nil {
+		return jobSkill.Skill
+	}
+	if s.skillsMasterRepo == nil {
+		return nil
+	}
+
+	skill, err := s.skillsMasterRepo.FindByID(ctx, jobSkill.SkillID)
+	if err != nil {
+		return nil
+	}
+	return skill
+}
+
+// skillImportanceFactor maps a job skill importance level to its score multiplier
+func skillImportanceFactor(importanceLevel string) float64 {
+	switch importanceLevel {
+	case "preferred":
+		return preferredSkillFactor
+	case "optional":
+		return optionalSkillFactor
+	default:
+		return requiredSkillFactor
+	}
+}
+
+// userHasSkill checks the user's normalized skill names against a master skill's name, normalized name and aliases
+func userHasSkill(userSkills map[string]bool, skill *master.SkillsMaster) bool {
+	if userSkills[master.NormalizeSkillName(skill.Name)] || userSkills[master.NormalizeSkillName(skill.NormalizedName)] {
+		return true
+	}
+	for _, alias := range skill.Aliases {
+		if userSkills[master.NormalizeSkillName(alias)] {
+			return true
+		}
+	}
+	return false
 }
 
 // calculateExperienceScore calculates experience match score
@@ -1065,20 +1717,21 @@ func (s *jobService) calculateLocationScore(j *job.Job, userProfile *user.User)
 	return 0.3
 }
 
-// generateRecommendation generates recommendation text based on match score
-func (s *jobService) generateRecommendation(score *job.MatchScore) string {
+// generateRecommendation generates recommendation text in locale based on match score
+func (s *jobService) generateRecommendation(locale i18n.Locale, score *job.MatchScore) string {
 	if score.OverallScore >= 0.8 {
-		return "Excellent match! You meet most of the requirements for this position."
+		return i18n.Translate(locale, "job.match.excellent")
 	} else if score.OverallScore >= 0.6 {
-		return "Good match. You have many of the skills and qualifications needed."
+		return i18n.Translate(locale, "job.match.good")
 	} else if score.OverallScore >= 0.4 {
-		return "Fair match. Consider highlighting your relevant experience in your application."
+		return i18n.Translate(locale, "job.match.fair")
 	} else {
-		return "This position may be challenging, but don't let that stop you from applying if you're interested."
+		return i18n.Translate(locale, "job.match.low")
 	}
 }
 
-// GetMatchingJobs retrieves jobs matching user profile
+// GetMatchingJobs retrieves jobs matching user profile. Fresh precomputed scores are used
+// where available; only the jobs without one are scored on demand.
 func (s *jobService) GetMatchingJobs(ctx context.Context, userID int64, filter job.JobFilter, page, limit int) (*job.MatchResponse, error) {
 	// Get matching jobs from repository
 	jobs, total, err := s.jobRepo.GetMatchingJobs(ctx, userID, filter, page, limit)
@@ -1086,19 +1739,20 @@ func (s *jobService) GetMatchingJobs(ctx context.Context, userID int64, filter j
 		return nil, fmt.Errorf("failed to get matching jobs: %w", err)
 	}
 
-	// Calculate match scores for each job
+	scores, err := s.overallMatchScores(ctx, userID, jobs)
+	if err != nil {
+		return nil, err
+	}
+
 	jobsWithScores := make([]job.JobWithScore, 0, len(jobs))
 	for _, j := range jobs {
-		matchScore, err := s.CalculateMatchScore(ctx, j.ID, userID)
-		if err != nil {
-			// Log error but continue with other jobs
-			fmt.Printf("failed to calculate match score for job %d: %v\n", j.ID, err)
+		score, ok := scores[j.ID]
+		if !ok {
 			continue
 		}
-
 		jobsWithScores = append(jobsWithScores, job.JobWithScore{
 			Job:        j,
-			MatchScore: matchScore.OverallScore,
+			MatchScore: score,
 		})
 	}
 
@@ -1114,13 +1768,192 @@ func (s *jobService) GetMatchingJobs(ctx context.Context, userID int64, filter j
 	}, nil
 }
 
+// overallMatchScores returns the user's overall match score of each job by job ID. Fresh
+// stored scores are used as they are; the missing ones are calculated and stored. Jobs
+// that could not be scored are left out.
+func (s *jobService) overallMatchScores(ctx context.Context, userID int64, jobs []job.Job) (map[int64]float64, error) {
+	scores := make(map[int64]float64, len(jobs))
+	if len(jobs) == 0 {
+		return scores, nil
+	}
+
+	if s.matchScores != nil {
+		ids := make([]int64, len(jobs))
+		for i, j := range jobs {
+			ids[i] = j.ID
+		}
+		stored, err := s.matchScores.FindScores(ctx, userID, ids)
+		if err != nil {
+			// Scoring on demand still works, just slower
+			log.Printf("Failed to load match scores of user %d: %v", userID, err)
+		}
+		now := time.Now()
+		for i := range stored {
+			if stored[i].IsFresh(now) {
+				scores[stored[i].JobID] = stored[i].Overall
+			}
+		}
+	}
+
+	computed := make([]job.UserJobMatchScore, 0)
+	for _, j := range jobs {
+		if _, ok := scores[j.ID]; ok {
+			continue
+		}
+
+		// Each score takes several queries; give up once the caller has gone
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		matchScore, err := s.CalculateMatchScore(ctx, j.ID, userID)
+		if err != nil {
+			// Log error but continue with other jobs
+			log.Printf("Failed to calculate match score for job %d: %v", j.ID, err)
+			continue
+		}
+
+		scores[j.ID] = matchScore.OverallScore
+		computed = append(computed, storedMatchScore(matchScore, time.Now()))
+	}
+
+	if s.matchScores != nil && len(computed) > 0 {
+		if err := s.matchScores.UpsertScores(ctx, computed); err != nil {
+			log.Printf("Failed to store match scores of user %d: %v", userID, err)
+		}
+	}
+
+	return scores, nil
+}
+
+// storedMatchScore converts a calculated match score into its stored form
+func storedMatchScore(score *job.MatchScore, computedAt time.Time) job.UserJobMatchScore {
+	return job.UserJobMatchScore{
+		UserID:     score.UserID,
+		JobID:      score.JobID,
+		Overall:    score.OverallScore,
+		Skill:      score.SkillScore,
+		Experience: score.ExperienceScore,
+		Education:  score.EducationScore,
+		Location:   score.LocationScore,
+		ComputedAt: computedAt,
+	}
+}
+
+// Match score refresh settings
+const (
+	// matchScoreActiveUserWindow is how recently a job seeker must have signed in to get scores precomputed
+	matchScoreActiveUserWindow = 30 * 24 * time.Hour
+
+	// matchScoreJobWindow is how recently a job must have been published to be scored
+	matchScoreJobWindow = 30 * 24 * time.Hour
+
+	// matchScoreUserBatch is how many job seekers are scored per batch
+	matchScoreUserBatch = 100
+)
+
+// RefreshMatchScores recomputes the match scores of recently published jobs for job
+// seekers who signed in recently, in batches of users, then deletes scores that are no
+// longer fresh. It returns how many scores were stored.
+func (s *jobService) RefreshMatchScores(ctx context.Context) (int, error) {
+	if s.matchScores == nil {
+		return 0, nil
+	}
+
+	now := time.Now()
+	jobs, err := s.matchScores.ListScoringJobs(ctx, now.Add(-matchScoreJobWindow))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list jobs to score: %w", err)
+	}
+
+	stored := 0
+	if len(jobs) > 0 {
+		var afterID int64
+		for {
+			userIDs, err := s.matchScores.ListActiveJobSeekerIDs(ctx, now.Add(-matchScoreActiveUserWindow), afterID, matchScoreUserBatch)
+			if err != nil {
+				return stored, fmt.Errorf("failed to list active job seekers: %w", err)
+			}
+			if len(userIDs) == 0 {
+				break
+			}
+			afterID = userIDs[len(userIDs)-1]
+
+			batch := make([]job.UserJobMatchScore, 0, len(userIDs)*len(jobs))
+			for _, userID := range userIDs {
+				if err := ctx.Err(); err != nil {
+					return stored, err
+				}
+
+				userProfile, err := s.userRepo.FindByID(ctx, userID)
+				if err != nil || userProfile == nil {
+					log.Printf("Failed to load user %d for match scoring: %v", userID, err)
+					continue
+				}
+
+				computedAt := time.Now()
+				for i := range jobs {
+					batch = append(batch, storedMatchScore(s.scoreJob(ctx, &jobs[i], userProfile), computedAt))
+				}
+			}
+
+			if err := s.matchScores.UpsertScores(ctx, batch); err != nil {
+				return stored, fmt.Errorf("failed to store match scores: %w", err)
+			}
+			stored += len(batch)
+
+			if len(userIDs) < matchScoreUserBatch {
+				break
+			}
+		}
+	}
+
+	// Scores of users and jobs that dropped out of the refresh are no longer kept up to date
+	if _, err := s.matchScores.DeleteComputedBefore(ctx, now.Add(-job.MatchScoreFreshness)); err != nil {
+		return stored, fmt.Errorf("failed to delete stale match scores: %w", err)
+	}
+
+	return stored, nil
+}
+
+// invalidateMatchScores drops a job's precomputed match scores after its requirements change
+func (s *jobService) invalidateMatchScores(ctx context.Context, jobID int64) {
+	if s.matchScores == nil {
+		return
+	}
+	if err := s.matchScores.DeleteByJob(ctx, jobID); err != nil {
+		log.Printf("Failed to invalidate match scores of job %d: %v", jobID, err)
+	}
+}
+
 // ===== Job Views and Interactions =====
 
-// IncrementView increments job view count
+// IncrementView counts a job view. Repeat views by the same user, or for anonymous viewers
+// the same IP (passed via job.WithViewerIP), within jobViewDedupWindow are not counted.
+// Attribution passed via job.WithAttribution is stored on the view event.
 func (s *jobService) IncrementView(ctx context.Context, jobID int64, userID *int64) error {
-	// TODO: Implement view tracking with user ID to prevent duplicate counts
-	// For now, just increment the counter
-	return s.jobRepo.IncrementViews(ctx, jobID)
+	attribution := job.AttributionFromContext(ctx)
+	event := &job.JobViewEvent{
+		JobID:       jobID,
+		UserID:      userID,
+		UTMSource:   utils.NormalizeUTMParam(attribution.Source),
+		UTMMedium:   utils.NormalizeUTMParam(attribution.Medium),
+		UTMCampaign: utils.NormalizeUTMParam(attribution.Campaign),
+		Referrer:    utils.NormalizeReferrer(attribution.Referrer),
+	}
+	if ip := job.ViewerIPFromContext(ctx); ip != "" {
+		// Only a keyed hash is stored so raw IP addresses don't end up in the database, and
+		// the hashes can't be reversed by hashing the small IPv4 space
+		mac := hmac.New(sha256.New, s.viewHashKey)
+		mac.Write([]byte(ip))
+		ipHash := hex.EncodeToString(mac.Sum(nil))
+		event.IPHash = &ipHash
+	}
+
+	if _, err := s.jobRepo.RecordView(ctx, event, jobViewDedupWindow); err != nil {
+		return fmt.Errorf("failed to record job view: %w", err)
+	}
+	return nil
 }
 
 // GetJobStats retrieves job statistics
@@ -1140,7 +1973,7 @@ func (s *jobService) AddLocation(ctx context.Context, jobID int64, req *job.AddL
 	// Verify job exists
 	_, err := s.jobRepo.FindByID(ctx, jobID)
 	if err != nil {
-		return nil, fmt.Errorf("job not found: %w", err)
+		return nil, jobLookupError(err)
 	}
 
 	// Create location
@@ -1241,7 +2074,7 @@ func (s *jobService) AddBenefit(ctx context.Context, jobID int64, req *job.AddBe
 	// Verify job exists
 	_, err := s.jobRepo.FindByID(ctx, jobID)
 	if err != nil {
-		return nil, fmt.Errorf("job not found: %w", err)
+		return nil, jobLookupError(err)
 	}
 
 	// Create benefit
@@ -1291,17 +2124,24 @@ func (s *jobService) DeleteBenefit(ctx context.Context, benefitID int64) error {
 	return s.jobRepo.DeleteBenefit(ctx, benefitID)
 }
 
-// BulkAddBenefits adds multiple benefits to a job
+// BulkAddBenefits replaces the job's benefits with the given set. A benefit name listed
+// more than once is kept once.
 func (s *jobService) BulkAddBenefits(ctx context.Context, jobID int64, benefits []job.AddBenefitRequest) error {
 	// Verify job exists
 	_, err := s.jobRepo.FindByID(ctx, jobID)
 	if err != nil {
-		return fmt.Errorf("job not found: %w", err)
+		return jobLookupError(err)
 	}
 
-	// Create benefits
+	// Build benefits
 	jobBenefits := make([]job.JobBenefit, 0, len(benefits))
+	seen := make(map[string]bool, len(benefits))
 	for _, req := range benefits {
+		if seen[req.BenefitName] {
+			continue
+		}
+		seen[req.BenefitName] = true
+
 		jobBenefits = append(jobBenefits, job.JobBenefit{
 			JobID:       jobID,
 			BenefitID:   req.BenefitID,
@@ -1311,7 +2151,11 @@ func (s *jobService) BulkAddBenefits(ctx context.Context, jobID int64, benefits
 		})
 	}
 
-	return s.jobRepo.BulkCreateBenefits(ctx, jobBenefits)
+	if err := s.jobRepo.ReplaceBenefits(ctx, jobID, jobBenefits); err != nil {
+		return fmt.Errorf("failed to replace job benefits: %w", err)
+	}
+
+	return nil
 }
 
 // AddSkill adds a skill requirement to a job
@@ -1319,13 +2163,18 @@ func (s *jobService) AddSkill(ctx context.Context, jobID int64, req *job.AddSkil
 	// Verify job exists
 	_, err := s.jobRepo.FindByID(ctx, jobID)
 	if err != nil {
-		return nil, fmt.Errorf("job not found: %w", err)
+		return nil, jobLookupError(err)
+	}
+
+	skillID, err := s.canonicalSkillID(ctx, req.SkillID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create job skill
 	jobSkill := &job.JobSkill{
 		JobID:           jobID,
-		SkillID:         req.SkillID,
+		SkillID:         skillID,
 		ImportanceLevel: req.ImportanceLevel,
 		Weight:          1.0, // Default weight
 	}
@@ -1339,6 +2188,8 @@ func (s *jobService) AddSkill(ctx context.Context, jobID int64, req *job.AddSkil
 		return nil, fmt.Errorf("failed to create job skill: %w", err)
 	}
 
+	s.invalidateMatchScores(ctx, jobID)
+
 	return jobSkill, nil
 }
 
@@ -1362,25 +2213,50 @@ func (s *jobService) UpdateSkill(ctx context.Context, jobSkillID int64, req *job
 		return nil, fmt.Errorf("failed to update job skill: %w", err)
 	}
 
+	s.invalidateMatchScores(ctx, jobSkill.JobID)
+
 	return jobSkill, nil
 }
 
 // DeleteSkill deletes a job skill requirement
 func (s *jobService) DeleteSkill(ctx context.Context, jobSkillID int64) error {
-	return s.jobRepo.DeleteSkill(ctx, jobSkillID)
+	jobSkill, err := s.jobRepo.FindSkillByID(ctx, jobSkillID)
+	if err != nil {
+		return fmt.Errorf("failed to get job skill: %w", err)
+	}
+
+	if err := s.jobRepo.DeleteSkill(ctx, jobSkillID); err != nil {
+		return err
+	}
+
+	if jobSkill != nil {
+		s.invalidateMatchScores(ctx, jobSkill.JobID)
+	}
+	return nil
 }
 
-// BulkAddSkills adds multiple skills to a job
+// BulkAddSkills replaces the job's skills with the given set. A skill listed more than
+// once is kept once, so it doesn't count twice in match scores.
 func (s *jobService) BulkAddSkills(ctx context.Context, jobID int64, skills []job.AddSkillRequest) error {
 	// Verify job exists
 	_, err := s.jobRepo.FindByID(ctx, jobID)
 	if err != nil {
-		return fmt.Errorf("job not found: %w", err)
+		return jobLookupError(err)
 	}
 
-	// Create job skills
+	// Build job skills
 	jobSkills := make([]job.JobSkill, 0, len(skills))
+	seen := make(map[int64]bool, len(skills))
 	for _, req := range skills {
+		skillID, err := s.canonicalSkillID(ctx, req.SkillID)
+		if err != nil {
+			return err
+		}
+		if seen[skillID] {
+			continue
+		}
+		seen[skillID] = true
+
 		importanceLevel := req.ImportanceLevel
 		if importanceLevel == "" {
 			importanceLevel = "required"
@@ -1388,26 +2264,180 @@ func (s *jobService) BulkAddSkills(ctx context.Context, jobID int64, skills []jo
 
 		jobSkills = append(jobSkills, job.JobSkill{
 			JobID:           jobID,
-			SkillID:         req.SkillID,
+			SkillID:         skillID,
 			ImportanceLevel: importanceLevel,
 			Weight:          1.0, // Default weight
 		})
 	}
 
-	// Replace existing skills: delete then create
-	if err := s.jobRepo.BulkDeleteSkills(ctx, jobID); err != nil {
-		return fmt.Errorf("failed to delete existing skills: %w", err)
+	if err := s.jobRepo.ReplaceSkills(ctx, jobID, jobSkills); err != nil {
+		return fmt.Errorf("failed to replace job skills: %w", err)
 	}
 
-	if len(jobSkills) == 0 {
-		// nothing to create
-		return nil
+	s.invalidateMatchScores(ctx, jobID)
+
+	return nil
+}
+
+// canonicalSkillID returns the ID a job skill is stored under: a skill merged into another
+// resolves to the skill that replaced it, so old IDs kept by clients still work
+func (s *jobService) canonicalSkillID(ctx context.Context, skillID int64) (int64, error) {
+	if s.skillsMasterRepo == nil {
+		return skillID, nil
+	}
+
+	skill, err := s.skillsMasterRepo.ResolveSkill(ctx, skillID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get skill: %w", err)
+	}
+	if skill == nil {
+		return 0, master.ErrSkillNotFound.WithField("skill_id", fmt.Sprintf("skill_id %d not found", skillID))
+	}
+	return skill.ID, nil
+}
+
+// AddQuestion adds a screening question to a job that is still in draft or pending review
+func (s *jobService) AddQuestion(ctx context.Context, jobID int64, ec *employer.EmployerContext, req *job.AddQuestionRequest) (*job.JobQuestion, error) {
+	if err := s.checkQuestionsEditable(ctx, jobID, ec); err != nil {
+		return nil, err
+	}
+
+	question := &job.JobQuestion{
+		JobID:         jobID,
+		QuestionText:  req.QuestionText,
+		QuestionType:  req.QuestionType,
+		Options:       req.Options,
+		IsRequired:    req.IsRequired,
+		IsKnockout:    req.IsKnockout,
+		KnockoutValue: req.KnockoutValue,
+		SortOrder:     req.SortOrder,
+	}
+	if err := normalizeJobQuestion(question); err != nil {
+		return nil, err
+	}
+
+	if err := s.jobRepo.CreateQuestion(ctx, question); err != nil {
+		return nil, fmt.Errorf("failed to create question: %w", err)
+	}
+
+	return question, nil
+}
+
+// UpdateQuestion updates a screening question of a job that is still in draft or pending review
+func (s *jobService) UpdateQuestion(ctx context.Context, jobID, questionID int64, ec *employer.EmployerContext, req *job.UpdateQuestionRequest) (*job.JobQuestion, error) {
+	if err := s.checkQuestionsEditable(ctx, jobID, ec); err != nil {
+		return nil, err
+	}
+
+	question, err := s.findJobQuestion(ctx, jobID, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields if provided
+	if req.QuestionText != "" {
+		question.QuestionText = req.QuestionText
+	}
+	if req.QuestionType != "" {
+		question.QuestionType = req.QuestionType
+	}
+	if req.Options != nil {
+		question.Options = req.Options
+	}
+	if req.IsRequired != nil {
+		question.IsRequired = *req.IsRequired
+	}
+	if req.IsKnockout != nil {
+		question.IsKnockout = *req.IsKnockout
+	}
+	if req.KnockoutValue != nil {
+		question.KnockoutValue = *req.KnockoutValue
+	}
+	if req.SortOrder != nil {
+		question.SortOrder = *req.SortOrder
+	}
+	if err := normalizeJobQuestion(question); err != nil {
+		return nil, err
+	}
+
+	if err := s.jobRepo.UpdateQuestion(ctx, question); err != nil {
+		return nil, fmt.Errorf("failed to update question: %w", err)
+	}
+
+	return question, nil
+}
+
+// DeleteQuestion removes a screening question from a job that is still in draft or pending review
+func (s *jobService) DeleteQuestion(ctx context.Context, jobID, questionID int64, ec *employer.EmployerContext) error {
+	if err := s.checkQuestionsEditable(ctx, jobID, ec); err != nil {
+		return err
+	}
+
+	if _, err := s.findJobQuestion(ctx, jobID, questionID); err != nil {
+		return err
+	}
+
+	return s.jobRepo.DeleteQuestion(ctx, questionID)
+}
+
+// ListQuestions retrieves a job's screening questions in display order
+func (s *jobService) ListQuestions(ctx context.Context, jobID int64) ([]job.JobQuestion, error) {
+	if _, err := s.jobRepo.FindByID(ctx, jobID); err != nil {
+		return nil, jobLookupError(err)
+	}
+
+	return s.jobRepo.ListQuestionsByJob(ctx, jobID)
+}
+
+// checkQuestionsEditable ensures the employer may modify the job and that its screening
+// questions are not yet visible to applicants
+func (s *jobService) checkQuestionsEditable(ctx context.Context, jobID int64, ec *employer.EmployerContext) error {
+	if err := s.CheckJobOwnership(ctx, jobID, ec); err != nil {
+		return err
+	}
+
+	j, err := s.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return jobLookupError(err)
+	}
+	if !j.IsDraft() && !j.IsPendingReview() {
+		return job.ErrJobQuestionsLocked
+	}
+	return nil
+}
+
+// findJobQuestion retrieves a screening question, making sure it belongs to the job
+func (s *jobService) findJobQuestion(ctx context.Context, jobID, questionID int64) (*job.JobQuestion, error) {
+	question, err := s.jobRepo.FindQuestionByID(ctx, questionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question: %w", err)
 	}
+	if question == nil || question.JobID != jobID {
+		return nil, job.ErrJobQuestionNotFound
+	}
+	return question, nil
+}
 
-	if err := s.jobRepo.BulkCreateSkills(ctx, jobSkills); err != nil {
-		return fmt.Errorf("failed to create job skills: %w", err)
+// normalizeJobQuestion checks that options and the knockout value fit the question type
+// and stores the knockout value in the same canonical form as applicant answers
+func normalizeJobQuestion(question *job.JobQuestion) error {
+	if question.QuestionType == job.QuestionTypeSelect {
+		if len(question.Options) == 0 {
+			return job.ErrInvalidJobQuestion.WithField("options", "select questions need at least one option")
+		}
+	} else {
+		question.Options = nil
 	}
 
+	if !question.IsKnockout {
+		question.KnockoutValue = ""
+		return nil
+	}
+	value, err := question.NormalizeAnswer(question.KnockoutValue)
+	if err != nil {
+		return job.ErrInvalidJobQuestion.WithField("knockout_value", err.Error())
+	}
+	question.KnockoutValue = value
 	return nil
 }
 
@@ -1416,7 +2446,7 @@ func (s *jobService) AddRequirement(ctx context.Context, jobID int64, req *job.A
 	// Verify job exists
 	_, err := s.jobRepo.FindByID(ctx, jobID)
 	if err != nil {
-		return nil, fmt.Errorf("job not found: %w", err)
+		return nil, jobLookupError(err)
 	}
 
 	// Create requirement
@@ -1497,15 +2527,15 @@ func (s *jobService) DeleteRequirement(ctx context.Context, requirementID int64)
 	return s.jobRepo.DeleteRequirement(ctx, requirementID)
 }
 
-// BulkAddRequirements adds multiple requirements to a job
+// BulkAddRequirements replaces the job's requirements with the given set
 func (s *jobService) BulkAddRequirements(ctx context.Context, jobID int64, requirements []job.AddRequirementRequest) error {
 	// Verify job exists
 	_, err := s.jobRepo.FindByID(ctx, jobID)
 	if err != nil {
-		return fmt.Errorf("job not found: %w", err)
+		return jobLookupError(err)
 	}
 
-	// Create requirements
+	// Build requirements
 	jobRequirements := make([]job.JobRequirement, 0, len(requirements))
 	for _, req := range requirements {
 		requirementType := req.RequirementType
@@ -1532,7 +2562,11 @@ func (s *jobService) BulkAddRequirements(ctx context.Context, jobID int64, requi
 		})
 	}
 
-	return s.jobRepo.BulkCreateRequirements(ctx, jobRequirements)
+	if err := s.jobRepo.ReplaceRequirements(ctx, jobID, jobRequirements); err != nil {
+		return fmt.Errorf("failed to replace job requirements: %w", err)
+	}
+
+	return nil
 }
 
 // ===== Category Management (Admin) =====
@@ -1717,15 +2751,37 @@ func (s *jobService) GetJobAnalytics(ctx context.Context, jobID int64, startDate
 		TotalViews:        stats.ViewsCount,
 		TotalApplications: stats.ApplicationsCount,
 		ConversionRate:    stats.ConversionRate,
-		// TODO: Implement time series data, unique viewers, and top sources
-		ViewsData:        []job.TimeSeriesData{},
+		// TODO: Implement applications time series, unique viewers, and top sources
 		ApplicationsData: []job.TimeSeriesData{},
 		TopSources:       []job.SourceStats{},
 	}
 
+	views, err := s.jobRepo.GetDailyViews(ctx, jobID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily views: %w", err)
+	}
+	analytics.ViewsData = fillDailySeries(views, startDate, endDate)
+
 	return analytics, nil
 }
 
+// fillDailySeries returns one point per day from start through end, using zero for days missing from data
+func fillDailySeries(data []job.TimeSeriesData, start, end time.Time) []job.TimeSeriesData {
+	values := make(map[string]int64, len(data))
+	for _, point := range data {
+		values[point.Date.Format("2006-01-02")] = point.Value
+	}
+
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	last := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())
+
+	series := make([]job.TimeSeriesData, 0)
+	for ; !day.After(last); day = day.AddDate(0, 0, 1) {
+		series = append(series, job.TimeSeriesData{Date: day, Value: values[day.Format("2006-01-02")]})
+	}
+	return series
+}
+
 // GetCompanyAnalytics retrieves company job analytics
 func (s *jobService) GetCompanyAnalytics(ctx context.Context, companyID int64, startDate, endDate time.Time) (*job.CompanyAnalytics, error) {
 	// Get company job stats
@@ -1804,47 +2860,52 @@ func (s *jobService) GetPopularCategories(ctx context.Context, limit int) ([]job
 
 // GetTopCompanies retrieves top companies by job performance
 func (s *jobService) GetTopCompanies(ctx context.Context, limit int) ([]job.CompanyStats, error) {
-	// Get all companies
-	companies, _, err := s.companyRepo.List(ctx, &company.CompanyFilter{})
+	stats, err := s.jobRepo.GetTopCompanies(ctx, limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get companies: %w", err)
+		return nil, fmt.Errorf("failed to get top companies: %w", err)
 	}
+	return stats, nil
+}
 
-	// Limit the number of companies to process
-	if len(companies) > limit {
-		companies = companies[:limit]
-	}
+// ===== Bulk Operations =====
 
-	// Build stats for each company
-	companyStats := make([]job.CompanyStats, 0, len(companies))
-	for _, comp := range companies {
-		stats, err := s.jobRepo.GetCompanyJobStats(ctx, comp.ID)
-		if err != nil {
+// BulkPublishJobs publishes multiple jobs. Each job is checked on its own, against the quota
+// left after the jobs before it; jobs that cannot be published are reported in the result
+// without stopping the others, while storage errors stop the whole run.
+func (s *jobService) BulkPublishJobs(ctx context.Context, jobIDs []int64) (*job.BulkPublishResult, error) {
+	result := &job.BulkPublishResult{Published: []int64{}}
+	for _, jobID := range jobIDs {
+		if err := s.bulkPublishJob(ctx, jobID); err != nil {
+			if _, ok := apperror.As(err); !ok {
+				return result, fmt.Errorf("failed to publish job %d: %w", jobID, err)
+			}
+			result.AddFailure(jobID, err)
 			continue
 		}
-
-		companyStats = append(companyStats, job.CompanyStats{
-			CompanyID:         comp.ID,
-			CompanyName:       comp.CompanyName,
-			TotalJobs:         stats.TotalJobs,
-			ActiveJobs:        stats.ActiveJobs,
-			TotalViews:        stats.TotalViews,
-			TotalApplications: stats.TotalApplications,
-		})
+		result.Published = append(result.Published, jobID)
 	}
-
-	return companyStats, nil
+	return result, nil
 }
 
-// ===== Bulk Operations =====
+// bulkPublishJob publishes one job of a bulk publish
+func (s *jobService) bulkPublishJob(ctx context.Context, jobID int64) error {
+	j, err := s.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return jobLookupError(err)
+	}
+	if j.Status == job.StatusPublished {
+		return job.ErrJobAlreadyPublished
+	}
+	if err := checkPublishQuota(ctx, s.quotas, j.CompanyID); err != nil {
+		return err
+	}
 
-// BulkPublishJobs publishes multiple jobs
-func (s *jobService) BulkPublishJobs(ctx context.Context, jobIDs []int64) error {
-	for _, jobID := range jobIDs {
-		if err := s.jobRepo.PublishJob(ctx, jobID); err != nil {
-			return fmt.Errorf("failed to publish job %d: %w", jobID, err)
-		}
+	now := time.Now()
+	expiredAt := s.expiry.PublishExpiry(j, now)
+	if err := s.jobRepo.UpdateStatusWithExpiry(ctx, jobID, job.StatusPublished, &now, &expiredAt); err != nil {
+		return err
 	}
+	publishJobEvent(ctx, s.webhooks, j, webhook.EventJobPublished, job.StatusPublished, &now, &expiredAt)
 	return nil
 }
 
@@ -1861,9 +2922,11 @@ func (s *jobService) BulkCloseJobs(ctx context.Context, jobIDs []int64) error {
 // BulkDeleteJobs deletes multiple jobs
 func (s *jobService) BulkDeleteJobs(ctx context.Context, jobIDs []int64) error {
 	for _, jobID := range jobIDs {
-		if err := s.jobRepo.SoftDelete(ctx, jobID); err != nil {
+		withdrawn, err := s.jobRepo.SoftDelete(ctx, jobID)
+		if err != nil {
 			return fmt.Errorf("failed to delete job %d: %w", jobID, err)
 		}
+		s.notifyJobWithdrawn(ctx, withdrawn)
 	}
 	return nil
 }
@@ -1879,10 +2942,10 @@ func (s *jobService) ValidateJobMasterDataIDs(ctx context.Context, jobTitleID, j
 			return fmt.Errorf("failed to validate job_title_id: %w", err)
 		}
 		if jobTitle == nil {
-			return fmt.Errorf("invalid job_title_id: job title with ID %d not found", *jobTitleID)
+			return job.ErrInvalidJobData.WithField("job_title_id", fmt.Sprintf("job title with ID %d not found", *jobTitleID))
 		}
 		if !jobTitle.IsActive {
-			return fmt.Errorf("invalid job_title_id: job title '%s' is inactive", jobTitle.Name)
+			return job.ErrInvalidJobData.WithField("job_title_id", fmt.Sprintf("job title '%s' is inactive", jobTitle.Name))
 		}
 	}
 
@@ -1893,7 +2956,7 @@ func (s *jobService) ValidateJobMasterDataIDs(ctx context.Context, jobTitleID, j
 			return fmt.Errorf("failed to validate job_type_id: %w", err)
 		}
 		if jobType == nil {
-			return fmt.Errorf("invalid job_type_id: job type with ID %d not found", *jobTypeID)
+			return job.ErrInvalidJobData.WithField("job_type_id", fmt.Sprintf("job type with ID %d not found", *jobTypeID))
 		}
 	}
 
@@ -1904,7 +2967,7 @@ func (s *jobService) ValidateJobMasterDataIDs(ctx context.Context, jobTitleID, j
 			return fmt.Errorf("failed to validate work_policy_id: %w", err)
 		}
 		if workPolicy == nil {
-			return fmt.Errorf("invalid work_policy_id: work policy with ID %d not found", *workPolicyID)
+			return job.ErrInvalidJobData.WithField("work_policy_id", fmt.Sprintf("work policy with ID %d not found", *workPolicyID))
 		}
 	}
 
@@ -1915,7 +2978,7 @@ func (s *jobService) ValidateJobMasterDataIDs(ctx context.Context, jobTitleID, j
 			return fmt.Errorf("failed to validate education_level_id: %w", err)
 		}
 		if educationLevel == nil {
-			return fmt.Errorf("invalid education_level_id: education level with ID %d not found", *educationLevelID)
+			return job.ErrInvalidJobData.WithField("education_level_id", fmt.Sprintf("education level with ID %d not found", *educationLevelID))
 		}
 	}
 
@@ -1926,7 +2989,7 @@ func (s *jobService) ValidateJobMasterDataIDs(ctx context.Context, jobTitleID, j
 			return fmt.Errorf("failed to validate experience_level_id: %w", err)
 		}
 		if experienceLevel == nil {
-			return fmt.Errorf("invalid experience_level_id: experience level with ID %d not found", *experienceLevelID)
+			return job.ErrInvalidJobData.WithField("experience_level_id", fmt.Sprintf("experience level with ID %d not found", *experienceLevelID))
 		}
 	}
 
@@ -1937,7 +3000,7 @@ func (s *jobService) ValidateJobMasterDataIDs(ctx context.Context, jobTitleID, j
 			return fmt.Errorf("failed to validate gender_preference_id: %w", err)
 		}
 		if genderPreference == nil {
-			return fmt.Errorf("invalid gender_preference_id: gender preference with ID %d not found", *genderPreferenceID)
+			return job.ErrInvalidJobData.WithField("gender_preference_id", fmt.Sprintf("gender preference with ID %d not found", *genderPreferenceID))
 		}
 	}
 
@@ -1946,42 +3009,19 @@ func (s *jobService) ValidateJobMasterDataIDs(ctx context.Context, jobTitleID, j
 
 // ValidateJob validates job data
 func (s *jobService) ValidateJob(ctx context.Context, j *job.Job) error {
-	// Required fields
-	if j.Title == "" {
-		return errors.New("job title is required")
-	}
-	if j.Description == "" {
-		return errors.New("job description is required")
-	}
-	if j.CompanyID == 0 {
-		return errors.New("company ID is required")
+	// Required fields, including the salary fields the salary_display mode needs
+	if missing := missingPublishFields(j); len(missing) > 0 {
+		return errors.New(missing[0].message)
 	}
 
 	// Validate salary based on salary_display mode
 	switch j.SalaryDisplay {
 	case "range":
-		// Both salary_min and salary_max must be provided and valid
-		if j.SalaryMin == nil || *j.SalaryMin <= 0 {
-			return errors.New("salary_min is required and must be greater than 0 when salary_display is 'range'")
-		}
-		if j.SalaryMax == nil || *j.SalaryMax <= 0 {
-			return errors.New("salary_max is required and must be greater than 0 when salary_display is 'range'")
-		}
 		if *j.SalaryMin > *j.SalaryMax {
 			return errors.New("salary_min cannot be greater than salary_max when salary_display is 'range'")
 		}
-	case "min_only":
-		// Only salary_min must be provided and valid
-		if j.SalaryMin == nil || *j.SalaryMin <= 0 {
-			return errors.New("salary_min is required and must be greater than 0 when salary_display is 'min_only'")
-		}
-	case "max_only":
-		// Only salary_max must be provided and valid
-		if j.SalaryMax == nil || *j.SalaryMax <= 0 {
-			return errors.New("salary_max is required and must be greater than 0 when salary_display is 'max_only'")
-		}
-	case "negotiable", "competitive", "hidden":
-		// No salary requirements - both can be 0 or nil
+	case "min_only", "max_only", "negotiable", "competitive", "hidden":
+		// Required amounts were checked above; nothing to compare
 	default:
 		// For backwards compatibility, validate if both are provided
 		if j.SalaryMin != nil && j.SalaryMax != nil && *j.SalaryMin > 0 && *j.SalaryMax > 0 {
@@ -1998,11 +3038,6 @@ func (s *jobService) ValidateJob(ctx context.Context, j *job.Job) error {
 		}
 	}
 
-	// Validate total hires
-	if j.TotalHires < 1 {
-		return errors.New("total hires must be at least 1")
-	}
-
 	// Validate expiry date (must be in the future)
 	if j.ExpiredAt != nil && j.ExpiredAt.Before(time.Now()) {
 		return errors.New("expiry date must be in the future")
@@ -2011,63 +3046,95 @@ func (s *jobService) ValidateJob(ctx context.Context, j *job.Job) error {
 	return nil
 }
 
-// CheckJobOwnership verifies if employer user owns the job
-func (s *jobService) CheckJobOwnership(ctx context.Context, jobID, employerUserID int64) error {
+// missingField is a required field a job lacks, with the message ValidateJob reports for it
+type missingField struct {
+	field   string
+	message string
+}
+
+// missingPublishFields lists the required fields j lacks, in the order ValidateJob checks them
+func missingPublishFields(j *job.Job) []missingField {
+	var missing []missingField
+	if j.Title == "" {
+		missing = append(missing, missingField{"title", "job title is required"})
+	}
+	if j.Description == "" {
+		missing = append(missing, missingField{"description", "job description is required"})
+	}
+	if j.CompanyID == 0 {
+		missing = append(missing, missingField{"company_id", "company ID is required"})
+	}
+
+	// salary_display decides which salary amounts must be set
+	needsMin := j.SalaryDisplay == "range" || j.SalaryDisplay == "min_only"
+	needsMax := j.SalaryDisplay == "range" || j.SalaryDisplay == "max_only"
+	if needsMin && (j.SalaryMin == nil || *j.SalaryMin <= 0) {
+		missing = append(missing, missingField{"salary_min", fmt.Sprintf("salary_min is required and must be greater than 0 when salary_display is '%s'", j.SalaryDisplay)})
+	}
+	if needsMax && (j.SalaryMax == nil || *j.SalaryMax <= 0) {
+		missing = append(missing, missingField{"salary_max", fmt.Sprintf("salary_max is required and must be greater than 0 when salary_display is '%s'", j.SalaryDisplay)})
+	}
+
+	if j.TotalHires < 1 {
+		missing = append(missing, missingField{"total_hires", "total hires must be at least 1"})
+	}
+
+	return missing
+}
+
+// CheckJobOwnership verifies that the employer may manage the job
+func (s *jobService) CheckJobOwnership(ctx context.Context, jobID int64, ec *employer.EmployerContext) error {
 	// Get job
 	j, err := s.jobRepo.FindByID(ctx, jobID)
 	if err != nil {
-		return fmt.Errorf("job not found: %w", err)
+		return jobLookupError(err)
 	}
+	return checkOwnership(j, ec)
+}
 
-	// Check if employer user ID matches
-	if j.EmployerUserID != nil && *j.EmployerUserID != employerUserID {
-		// Check if user has permission through company employer users
-		employerUser, err := s.companyRepo.FindEmployerUserByUserAndCompany(ctx, employerUserID, j.CompanyID)
-		if err != nil || employerUser == nil {
-			return errors.New("you do not have permission to modify this job")
-		}
-
-		// Check if role has permission (recruiter and above)
-		if employerUser.Role != "recruiter" && employerUser.Role != "admin" && employerUser.Role != "owner" {
-			return errors.New("you do not have permission to modify this job")
-		}
+// checkOwnership checks that the employer acts for the job's company and either posted the
+// job or is a recruiter or above there
+func checkOwnership(j *job.Job, ec *employer.EmployerContext) error {
+	if !ec.InCompany(j.CompanyID) {
+		return job.ErrJobPermissionDenied
+	}
+	if !ec.IsPoster(j.EmployerUserID) && !ec.CanManageJobs() {
+		return job.ErrJobPermissionDenied
 	}
-
 	return nil
 }
 
+// jobLookupError converts a failed job lookup into job.ErrJobNotFound when the job does not exist
+func jobLookupError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return job.ErrJobNotFound
+	}
+	return fmt.Errorf("failed to get job: %w", err)
+}
+
 // CheckJobStatus retrieves current job status
 func (s *jobService) CheckJobStatus(ctx context.Context, jobID int64) (string, error) {
 	j, err := s.jobRepo.FindByID(ctx, jobID)
 	if err != nil {
-		return "", fmt.Errorf("job not found: %w", err)
+		return "", jobLookupError(err)
 	}
 
 	return j.Status, nil
 }
 
-// GetJobsGroupedByStatus returns jobs grouped by status for a user (for mobile tab UI)
-func (s *jobService) GetJobsGroupedByStatus(ctx context.Context, userID int64) (map[string][]job.Job, error) {
-	jobs, _, err := s.jobRepo.ListByEmployer(ctx, userID, job.JobFilter{}, 1, 1000) // adjust limit as needed
+// GetJobsGroupedByStatus returns the employer user's jobs grouped by status (for mobile tab UI)
+func (s *jobService) GetJobsGroupedByStatus(ctx context.Context, ec *employer.EmployerContext) (map[string][]job.Job, error) {
+	jobs, _, err := s.GetMyJobs(ctx, ec, job.JobFilter{}, 1, 1000) // adjust limit as needed
 	if err != nil {
 		return nil, err
 	}
-	grouped := map[string][]job.Job{
-		"active":    {},
-		"inactive":  {},
-		"draft":     {},
-		"in_review": {},
+	grouped := make(map[string][]job.Job, len(job.TabStatuses))
+	for tab := range job.TabStatuses {
+		grouped[tab] = []job.Job{}
 	}
 	for _, j := range jobs {
-		switch j.Status {
-		case "active":
-			grouped["active"] = append(grouped["active"], j)
-		case "inactive":
-			grouped["inactive"] = append(grouped["inactive"], j)
-		case "draft":
-			grouped["draft"] = append(grouped["draft"], j)
-		case "in_review":
-			grouped["in_review"] = append(grouped["in_review"], j)
+		if tab := job.TabForStatus(j.Status); tab != "" {
+			grouped[tab] = append(grouped[tab], j)
 		}
 	}
 	return grouped, nil
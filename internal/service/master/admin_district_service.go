@@ -115,6 +115,7 @@ func (s *adminDistrictServiceImpl) Update(ctx context.Context, id int64, req mas
 		district.CityID = *req.CityID
 	}
 
+	deactivated := req.IsActive != nil && !*req.IsActive && district.IsActive
 	if req.IsActive != nil {
 		district.IsActive = *req.IsActive
 	}
@@ -135,27 +136,28 @@ func (s *adminDistrictServiceImpl) Update(ctx context.Context, id int64, req mas
 
 	// Map to response
 	response := s.mapToResponseWithFullLocation(districtWithLocation)
-	return response, nil
-}
 
-// Delete deletes a district if not referenced by companies
-func (s *adminDistrictServiceImpl) Delete(ctx context.Context, id int64) error {
-	// Check if district exists
-	_, err := s.repo.GetByID(ctx, id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("district not found")
+	// Existing references keep the district; tell the admin how many there are
+	if deactivated {
+		if response.Usage, err = s.repo.CountUsage(ctx, id); err != nil {
+			return nil, fmt.Errorf("failed to check district references: %w", err)
 		}
-		return fmt.Errorf("failed to get district: %w", err)
 	}
+	return response, nil
+}
 
+// Delete deletes a district if nothing references it
+func (s *adminDistrictServiceImpl) Delete(ctx context.Context, id int64) error {
 	// Check references
-	companies, err := s.CountCompanyReferences(ctx, id)
+	usage, err := s.GetUsage(ctx, id)
 	if err != nil {
-		return fmt.Errorf("failed to check district references: %w", err)
+		return err
 	}
-	if companies > 0 {
-		return fmt.Errorf("cannot delete district: it is still referenced by %d companies", companies)
+	if usage.InUse() {
+		return master.ErrDistrictInUse.WithMessage(fmt.Sprintf(
+			"cannot delete district: it is still referenced by %d companies, %d company addresses, %d user profiles, %d jobs and %d change requests; deactivate or merge it instead",
+			usage.Companies, usage.CompanyAddresses, usage.UserProfiles, usage.Jobs, usage.ChangeRequests,
+		))
 	}
 
 	// Delete district
@@ -169,6 +171,66 @@ func (s *adminDistrictServiceImpl) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// GetUsage reports the records referencing a district
+func (s *adminDistrictServiceImpl) GetUsage(ctx context.Context, id int64) (*master.DistrictUsage, error) {
+	exists, err := s.repo.ExistsByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get district: %w", err)
+	}
+	if !exists {
+		return nil, master.ErrDistrictNotFound
+	}
+
+	usage, err := s.repo.CountUsage(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check district references: %w", err)
+	}
+	return usage, nil
+}
+
+// Merge moves the references of the source district to the target and deactivates the source.
+// Existing records keep working through the target; the source can be deleted afterwards.
+func (s *adminDistrictServiceImpl) Merge(ctx context.Context, sourceID, targetID int64) (*master.DistrictMergeResult, error) {
+	if sourceID == targetID {
+		return nil, master.ErrDistrictMergeIntoSelf
+	}
+
+	if _, err := s.getDistrict(ctx, sourceID, "source_id"); err != nil {
+		return nil, err
+	}
+	target, err := s.getDistrict(ctx, targetID, "target_id")
+	if err != nil {
+		return nil, err
+	}
+	if !target.IsActive {
+		return nil, master.ErrDistrictMergeTargetInactive
+	}
+
+	result, err := s.repo.MergeInto(ctx, sourceID, targetID)
+	if err != nil {
+		if errors.Is(err, master.ErrDistrictNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to merge district: %w", err)
+	}
+
+	s.invalidateCache()
+
+	return result, nil
+}
+
+// getDistrict loads a district, reporting a missing one on field
+func (s *adminDistrictServiceImpl) getDistrict(ctx context.Context, id int64, field string) (*master.District, error) {
+	district, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, master.ErrDistrictNotFound.WithField(field, "district not found")
+		}
+		return nil, fmt.Errorf("failed to get district: %w", err)
+	}
+	return district, nil
+}
+
 // CheckDuplicateNameInCity checks if a district with the given name exists in the city
 func (s *adminDistrictServiceImpl) CheckDuplicateNameInCity(ctx context.Context, name string, cityID int64) (bool, error) {
 	district, err := s.repo.GetByNameAndCityID(ctx, name, cityID)
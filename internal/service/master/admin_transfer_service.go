@@ -0,0 +1,602 @@
+package master
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/master"
+)
+
+// importRowSavepoint wraps each row's write so a failed row does not abort the import transaction
+const importRowSavepoint = "master_data_import_row"
+
+var (
+	postalCodePattern = regexp.MustCompile(`^\d{5}$`)
+	slugPattern       = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+)
+
+// rowError is a reason an import row is invalid, reported back to the admin as is
+type rowError string
+
+func (e rowError) Error() string { return string(e) }
+
+// masterDataTransfer describes how one master data type maps to CSV
+type masterDataTransfer struct {
+	columns   []string // CSV header, in export order
+	required  []string // Columns the import header must include
+	exportSQL string   // Selects the columns as text, in the order of columns
+	// upsert validates a row and writes it, returning the key it upserted on and
+	// whether it inserted a new record
+	upsert func(imp *masterDataImporter, fields map[string]string) (key string, created bool, err error)
+	// cachePatterns are the cache key patterns the services reading this type populate
+	cachePatterns []string
+}
+
+// jobOptionGroups maps the group column of a job options CSV to its table
+var jobOptionGroups = []string{"job_types", "work_policies", "education_levels", "experience_levels", "gender_preferences"}
+
+var masterDataTransfers = map[string]masterDataTransfer{
+	master.MasterDataProvinces: {
+		columns:  []string{"code", "name", "is_active"},
+		required: []string{"code", "name"},
+		exportSQL: `SELECT code, name, is_active::text FROM provinces
+			WHERE deleted_at IS NULL ORDER BY code`,
+		upsert:        upsertProvince,
+		cachePatterns: []string{"provinces:*", "cities:*", "city:id:*", "districts:*", "district:id:*"},
+	},
+	master.MasterDataCities: {
+		columns:  []string{"code", "province_code", "name", "type", "is_active"},
+		required: []string{"code", "province_code", "name", "type"},
+		exportSQL: `SELECT c.code, p.code, c.name, c.type, c.is_active::text FROM cities c
+			JOIN provinces p ON p.id = c.province_id
+			WHERE c.deleted_at IS NULL ORDER BY c.code`,
+		upsert:        upsertCity,
+		cachePatterns: []string{"cities:*", "city:id:*", "districts:*", "district:id:*"},
+	},
+	master.MasterDataDistricts: {
+		columns:  []string{"code", "city_code", "name", "postal_code", "is_active"},
+		required: []string{"code", "city_code", "name"},
+		exportSQL: `SELECT d.code, c.code, d.name, d.postal_code, d.is_active::text FROM districts d
+			JOIN cities c ON c.id = d.city_id
+			WHERE d.deleted_at IS NULL ORDER BY d.code`,
+		upsert:        upsertDistrict,
+		cachePatterns: []string{"districts:*", "district:id:*"},
+	},
+	master.MasterDataIndustries: {
+		columns:  []string{"slug", "name", "description", "icon_url", "display_order", "is_active"},
+		required: []string{"slug", "name"},
+		exportSQL: `SELECT slug, name, description, icon_url, display_order::text, is_active::text FROM industries
+			WHERE deleted_at IS NULL ORDER BY display_order, name`,
+		upsert:        upsertIndustry,
+		cachePatterns: []string{"industries:*"},
+	},
+	master.MasterDataCompanySizes: {
+		columns:  []string{"label", "min_employees", "max_employees", "display_order", "is_active"},
+		required: []string{"label", "min_employees"},
+		exportSQL: `SELECT label, min_employees::text, max_employees::text, display_order::text, is_active::text FROM company_sizes
+			WHERE deleted_at IS NULL ORDER BY display_order, min_employees`,
+		upsert:        upsertCompanySize,
+		cachePatterns: []string{"company_sizes:*"},
+	},
+	master.MasterDataJobTitles: {
+		columns:   []string{"name", "is_active"},
+		required:  []string{"name"},
+		exportSQL: `SELECT name, COALESCE(is_active, true)::text FROM job_titles ORDER BY name`,
+		upsert:    upsertJobTitle,
+	},
+	master.MasterDataJobOptions: {
+		columns:       []string{"group", "code", "name", "order", "min_years", "max_years", "is_active"},
+		required:      []string{"group", "code", "name"},
+		exportSQL:     jobOptionsExportSQL(),
+		upsert:        upsertJobOption,
+		cachePatterns: []string{"master:job_options", "job_options:all"},
+	},
+}
+
+// jobOptionsExportSQL selects every job option table as one list, grouped in the order of jobOptionGroups
+func jobOptionsExportSQL() string {
+	selects := make([]string, len(jobOptionGroups))
+	for i, table := range jobOptionGroups {
+		years := "NULL::text, NULL::text"
+		if table == "experience_levels" {
+			years = "min_years::text, max_years::text"
+		}
+		selects[i] = fmt.Sprintf(`SELECT %d AS position, '%s' AS "group", code, name, "order", %s, COALESCE(is_active, true)::text AS is_active FROM %s`, i, table, years, table)
+	}
+	return `SELECT "group", code, name, "order"::text, min_years, max_years, is_active FROM (` +
+		strings.Join(selects, " UNION ALL ") + `) options ORDER BY options.position, options."order", options.code`
+}
+
+// adminMasterDataTransferServiceImpl implements AdminMasterDataTransferService
+type adminMasterDataTransferServiceImpl struct {
+	db    *gorm.DB
+	cache cache.Cache
+}
+
+// NewAdminMasterDataTransferService creates a new AdminMasterDataTransferService
+func NewAdminMasterDataTransferService(db *gorm.DB, cache cache.Cache) master.AdminMasterDataTransferService {
+	return &adminMasterDataTransferServiceImpl{
+		db:    db,
+		cache: cache,
+	}
+}
+
+// Export writes every record of the type as CSV, streaming rows from the database
+func (s *adminMasterDataTransferServiceImpl) Export(ctx context.Context, dataType string, w io.Writer) error {
+	transfer, ok := masterDataTransfers[dataType]
+	if !ok {
+		return master.ErrUnknownMasterDataType
+	}
+
+	rows, err := s.db.WithContext(ctx).Raw(transfer.exportSQL).Rows()
+	if err != nil {
+		return fmt.Errorf("failed to export %s: %w", dataType, err)
+	}
+	defer rows.Close()
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(transfer.columns); err != nil {
+		return err
+	}
+
+	values := make([]sql.NullString, len(transfer.columns))
+	dest := make([]interface{}, len(values))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	record := make([]string, len(values))
+
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return fmt.Errorf("failed to read %s: %w", dataType, err)
+		}
+		for i, value := range values {
+			record[i] = value.String
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to export %s: %w", dataType, err)
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// Import reads the CSV row by row and upserts each row in a single transaction, so a
+// large file is never held in memory. Invalid rows are reported and skipped; an error
+// that is not about one row rolls the whole import back.
+func (s *adminMasterDataTransferServiceImpl) Import(ctx context.Context, dataType string, r io.Reader) (*master.MasterDataImportReport, error) {
+	transfer, ok := masterDataTransfers[dataType]
+	if !ok {
+		return nil, master.ErrUnknownMasterDataType
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	columns, err := readTransferHeader(reader, transfer)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &master.MasterDataImportReport{Type: dataType, Rows: []master.MasterDataImportRow{}}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		imp := &masterDataImporter{tx: tx, parents: make(map[string]int64)}
+		for {
+			fields, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				report.Rows = append(report.Rows, master.MasterDataImportRow{
+					Row: parseErr.StartLine, Status: master.MasterDataRowFailed, Reason: "malformed CSV row: " + parseErr.Err.Error(),
+				})
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read import file: %w", err)
+			}
+
+			line, _ := reader.FieldPos(0)
+			row, err := imp.importRow(transfer, columns, fields)
+			if err != nil {
+				return err
+			}
+			row.Row = line
+			report.Rows = append(report.Rows, row)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range report.Rows {
+		switch row.Status {
+		case master.MasterDataRowCreated:
+			report.Created++
+		case master.MasterDataRowUpdated:
+			report.Updated++
+		case master.MasterDataRowFailed:
+			report.Failed++
+		}
+	}
+	report.TotalRows = len(report.Rows)
+
+	if report.Created+report.Updated > 0 {
+		for _, pattern := range transfer.cachePatterns {
+			s.cache.DeletePattern(pattern)
+		}
+	}
+
+	return report, nil
+}
+
+// readTransferHeader reads the header row and maps column positions to the column names
+// the type understands. Columns may come in any order and unknown columns are ignored.
+func readTransferHeader(reader *csv.Reader, transfer masterDataTransfer) (map[int]string, error) {
+	header, err := reader.Read()
+	if err != nil {
+		return nil, master.ErrMasterDataImportInvalidFile
+	}
+
+	columns := make(map[int]string, len(header))
+	for i, name := range header {
+		// Spreadsheet exports may start the file with a byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if slices.Contains(transfer.columns, name) {
+			columns[i] = name
+		}
+	}
+
+	for _, name := range transfer.required {
+		found := false
+		for _, column := range columns {
+			found = found || column == name
+		}
+		if !found {
+			return nil, master.ErrMasterDataImportInvalidFile.WithField("file", "header must include the columns: "+strings.Join(transfer.required, ", "))
+		}
+	}
+	return columns, nil
+}
+
+// masterDataImporter holds the state of one import transaction
+type masterDataImporter struct {
+	tx *gorm.DB
+	// parents caches parent IDs looked up by code, keyed by table and code
+	parents map[string]int64
+}
+
+// importRow validates and upserts one row inside a savepoint. Row problems are returned
+// in the report row; only a failure to talk to the database is returned as an error.
+func (imp *masterDataImporter) importRow(transfer masterDataTransfer, columns map[int]string, record []string) (master.MasterDataImportRow, error) {
+	fields := make(map[string]string, len(columns))
+	for i, value := range record {
+		if name, ok := columns[i]; ok {
+			fields[name] = strings.TrimSpace(value)
+		}
+	}
+
+	if err := imp.tx.SavePoint(importRowSavepoint).Error; err != nil {
+		return master.MasterDataImportRow{}, err
+	}
+
+	key, created, err := transfer.upsert(imp, fields)
+	if err != nil {
+		if rollbackErr := imp.tx.RollbackTo(importRowSavepoint).Error; rollbackErr != nil {
+			return master.MasterDataImportRow{}, rollbackErr
+		}
+
+		reason := err.Error()
+		var invalid rowError
+		if !errors.As(err, &invalid) {
+			fmt.Printf("[WARN] master data import: failed to save %q: %v\n", key, err)
+			reason = "could not be saved: " + rowFailureReason(err)
+		}
+		return master.MasterDataImportRow{Key: key, Status: master.MasterDataRowFailed, Reason: reason}, nil
+	}
+
+	if err := imp.tx.Exec("RELEASE SAVEPOINT " + importRowSavepoint).Error; err != nil {
+		return master.MasterDataImportRow{}, err
+	}
+
+	status := master.MasterDataRowUpdated
+	if created {
+		status = master.MasterDataRowCreated
+	}
+	return master.MasterDataImportRow{Key: key, Status: status}, nil
+}
+
+// rowFailureReason explains a database error without leaking the statement
+func rowFailureReason(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return "conflicts with another record"
+	}
+	return "database error"
+}
+
+// parentID looks up the ID of a parent record by its code. A missing parent is a row
+// error; the lookup sees rows imported earlier in the same transaction.
+func (imp *masterDataImporter) parentID(table, code, column string) (int64, error) {
+	cacheKey := table + ":" + code
+	if id, ok := imp.parents[cacheKey]; ok {
+		return id, nil
+	}
+
+	var ids []int64
+	if err := imp.tx.Raw("SELECT id FROM "+table+" WHERE code = ? AND deleted_at IS NULL", code).Scan(&ids).Error; err != nil {
+		return 0, fmt.Errorf("failed to look up %s: %w", column, err)
+	}
+	if len(ids) == 0 {
+		return 0, rowError(fmt.Sprintf("%s %q does not exist", column, code))
+	}
+
+	imp.parents[cacheKey] = ids[0]
+	return ids[0], nil
+}
+
+// upsertReturningCreated runs an INSERT ... ON CONFLICT DO UPDATE and reports whether it
+// inserted a new row rather than updating an existing one
+func (imp *masterDataImporter) upsertReturningCreated(query string, args ...interface{}) (bool, error) {
+	var created bool
+	err := imp.tx.Raw(query+" RETURNING (xmax = 0)", args...).Row().Scan(&created)
+	return created, err
+}
+
+func upsertProvince(imp *masterDataImporter, fields map[string]string) (string, bool, error) {
+	code := fields["code"]
+	var problems []string
+	problems = checkLength(problems, "code", code, 2, 10)
+	problems = checkLength(problems, "name", fields["name"], 2, 100)
+	isActive, problems := parseImportBool(problems, "is_active", fields["is_active"])
+	if len(problems) > 0 {
+		return code, false, rowError(strings.Join(problems, "; "))
+	}
+
+	created, err := imp.upsertReturningCreated(`INSERT INTO provinces (code, name, is_active) VALUES (?, ?, ?)
+		ON CONFLICT (code) DO UPDATE SET name = EXCLUDED.name, is_active = EXCLUDED.is_active,
+			deleted_at = NULL, updated_at = CURRENT_TIMESTAMP`,
+		code, fields["name"], isActive)
+	return code, created, err
+}
+
+func upsertCity(imp *masterDataImporter, fields map[string]string) (string, bool, error) {
+	code := fields["code"]
+	var problems []string
+	problems = checkLength(problems, "code", code, 2, 10)
+	problems = checkLength(problems, "name", fields["name"], 2, 100)
+	if cityType := fields["type"]; cityType != "Kota" && cityType != "Kabupaten" {
+		problems = append(problems, "type must be Kota or Kabupaten")
+	}
+	isActive, problems := parseImportBool(problems, "is_active", fields["is_active"])
+	if fields["province_code"] == "" {
+		problems = append(problems, "province_code is required")
+	}
+	if len(problems) > 0 {
+		return code, false, rowError(strings.Join(problems, "; "))
+	}
+
+	provinceID, err := imp.parentID("provinces", fields["province_code"], "province_code")
+	if err != nil {
+		return code, false, err
+	}
+
+	created, err := imp.upsertReturningCreated(`INSERT INTO cities (code, province_id, name, type, is_active) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (code) DO UPDATE SET province_id = EXCLUDED.province_id, name = EXCLUDED.name, type = EXCLUDED.type,
+			is_active = EXCLUDED.is_active, deleted_at = NULL, updated_at = CURRENT_TIMESTAMP`,
+		code, provinceID, fields["name"], fields["type"], isActive)
+	return code, created, err
+}
+
+func upsertDistrict(imp *masterDataImporter, fields map[string]string) (string, bool, error) {
+	code := fields["code"]
+	var problems []string
+	problems = checkLength(problems, "code", code, 2, 10)
+	problems = checkLength(problems, "name", fields["name"], 2, 100)
+	postalCode := fields["postal_code"]
+	if postalCode != "" && !postalCodePattern.MatchString(postalCode) {
+		problems = append(problems, "postal_code must be 5 digits")
+	}
+	isActive, problems := parseImportBool(problems, "is_active", fields["is_active"])
+	if fields["city_code"] == "" {
+		problems = append(problems, "city_code is required")
+	}
+	if len(problems) > 0 {
+		return code, false, rowError(strings.Join(problems, "; "))
+	}
+
+	cityID, err := imp.parentID("cities", fields["city_code"], "city_code")
+	if err != nil {
+		return code, false, err
+	}
+
+	created, err := imp.upsertReturningCreated(`INSERT INTO districts (code, city_id, name, postal_code, is_active) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (code) DO UPDATE SET city_id = EXCLUDED.city_id, name = EXCLUDED.name, postal_code = EXCLUDED.postal_code,
+			is_active = EXCLUDED.is_active, deleted_at = NULL, updated_at = CURRENT_TIMESTAMP`,
+		code, cityID, fields["name"], nullIfEmpty(postalCode), isActive)
+	return code, created, err
+}
+
+func upsertIndustry(imp *masterDataImporter, fields map[string]string) (string, bool, error) {
+	slug := fields["slug"]
+	var problems []string
+	problems = checkLength(problems, "slug", slug, 2, 100)
+	if slug != "" && !slugPattern.MatchString(slug) {
+		problems = append(problems, "slug must be lowercase letters, digits and hyphens")
+	}
+	problems = checkLength(problems, "name", fields["name"], 2, 100)
+	if utf8.RuneCountInString(fields["icon_url"]) > 500 {
+		problems = append(problems, "icon_url must not exceed 500 characters")
+	}
+	displayOrder, problems := parseImportInt(problems, "display_order", fields["display_order"], 0)
+	isActive, problems := parseImportBool(problems, "is_active", fields["is_active"])
+	if len(problems) > 0 {
+		return slug, false, rowError(strings.Join(problems, "; "))
+	}
+
+	created, err := imp.upsertReturningCreated(`INSERT INTO industries (slug, name, description, icon_url, display_order, is_active) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (slug) DO UPDATE SET name = EXCLUDED.name, description = EXCLUDED.description, icon_url = EXCLUDED.icon_url,
+			display_order = EXCLUDED.display_order, is_active = EXCLUDED.is_active, deleted_at = NULL, updated_at = CURRENT_TIMESTAMP`,
+		slug, fields["name"], nullIfEmpty(fields["description"]), nullIfEmpty(fields["icon_url"]), intOr(displayOrder, 0), isActive)
+	return slug, created, err
+}
+
+func upsertCompanySize(imp *masterDataImporter, fields map[string]string) (string, bool, error) {
+	label := fields["label"]
+	var problems []string
+	problems = checkLength(problems, "label", label, 2, 50)
+	minEmployees, problems := parseImportInt(problems, "min_employees", fields["min_employees"], 1)
+	maxEmployees, problems := parseImportInt(problems, "max_employees", fields["max_employees"], 1)
+	if fields["min_employees"] == "" {
+		problems = append(problems, "min_employees is required")
+	}
+	if minEmployees != nil && maxEmployees != nil && *maxEmployees < *minEmployees {
+		problems = append(problems, "max_employees must not be less than min_employees")
+	}
+	displayOrder, problems := parseImportInt(problems, "display_order", fields["display_order"], 0)
+	isActive, problems := parseImportBool(problems, "is_active", fields["is_active"])
+	if len(problems) > 0 {
+		return label, false, rowError(strings.Join(problems, "; "))
+	}
+
+	created, err := imp.upsertReturningCreated(`INSERT INTO company_sizes (label, min_employees, max_employees, display_order, is_active) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (label) DO UPDATE SET min_employees = EXCLUDED.min_employees, max_employees = EXCLUDED.max_employees,
+			display_order = EXCLUDED.display_order, is_active = EXCLUDED.is_active, deleted_at = NULL, updated_at = CURRENT_TIMESTAMP`,
+		label, *minEmployees, maxEmployees, intOr(displayOrder, 0), isActive)
+	return label, created, err
+}
+
+func upsertJobTitle(imp *masterDataImporter, fields map[string]string) (string, bool, error) {
+	name := fields["name"]
+	var problems []string
+	problems = checkLength(problems, "name", name, 2, 200)
+	isActive, problems := parseImportBool(problems, "is_active", fields["is_active"])
+	if len(problems) > 0 {
+		return name, false, rowError(strings.Join(problems, "; "))
+	}
+
+	// Popularity and search counts are maintained by the app, so existing titles keep theirs
+	created, err := imp.upsertReturningCreated(`INSERT INTO job_titles (name, normalized_name, is_active) VALUES (?, ?, ?)
+		ON CONFLICT (name) DO UPDATE SET normalized_name = EXCLUDED.normalized_name, is_active = EXCLUDED.is_active, updated_at = now()`,
+		name, strings.ToLower(name), isActive)
+	return name, created, err
+}
+
+func upsertJobOption(imp *masterDataImporter, fields map[string]string) (string, bool, error) {
+	group, code := fields["group"], fields["code"]
+	key := group + ":" + code
+
+	var problems []string
+	if !slices.Contains(jobOptionGroups, group) {
+		problems = append(problems, "group must be one of: "+strings.Join(jobOptionGroups, ", "))
+	}
+	problems = checkLength(problems, "code", code, 1, 30)
+	problems = checkLength(problems, "name", fields["name"], 1, 100)
+	order, problems := parseImportInt(problems, "order", fields["order"], 0)
+	isActive, problems := parseImportBool(problems, "is_active", fields["is_active"])
+
+	var minYears, maxYears *int
+	if group == "experience_levels" {
+		minYears, problems = parseImportInt(problems, "min_years", fields["min_years"], 0)
+		maxYears, problems = parseImportInt(problems, "max_years", fields["max_years"], 0)
+		if maxYears != nil && *maxYears < intOr(minYears, 0) {
+			problems = append(problems, "max_years must not be less than min_years")
+		}
+	} else if fields["min_years"] != "" || fields["max_years"] != "" {
+		problems = append(problems, "min_years and max_years only apply to experience_levels")
+	}
+	if len(problems) > 0 {
+		return key, false, rowError(strings.Join(problems, "; "))
+	}
+
+	// group is one of jobOptionGroups, so it is safe to use as the table name
+	if group == "experience_levels" {
+		created, err := imp.upsertReturningCreated(`INSERT INTO experience_levels (code, name, "order", min_years, max_years, is_active) VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT (code) DO UPDATE SET name = EXCLUDED.name, "order" = EXCLUDED."order", min_years = EXCLUDED.min_years,
+				max_years = EXCLUDED.max_years, is_active = EXCLUDED.is_active, updated_at = now()`,
+			code, fields["name"], intOr(order, 0), intOr(minYears, 0), maxYears, isActive)
+		return key, created, err
+	}
+
+	created, err := imp.upsertReturningCreated(`INSERT INTO `+group+` (code, name, "order", is_active) VALUES (?, ?, ?, ?)
+		ON CONFLICT (code) DO UPDATE SET name = EXCLUDED.name, "order" = EXCLUDED."order", is_active = EXCLUDED.is_active, updated_at = now()`,
+		code, fields["name"], intOr(order, 0), isActive)
+	return key, created, err
+}
+
+// checkLength appends a problem when a required value is missing or its length is out of range
+func checkLength(problems []string, column, value string, min, max int) []string {
+	length := utf8.RuneCountInString(value)
+	switch {
+	case length == 0:
+		return append(problems, column+" is required")
+	case length < min:
+		return append(problems, fmt.Sprintf("%s must be at least %d characters", column, min))
+	case length > max:
+		return append(problems, fmt.Sprintf("%s must not exceed %d characters", column, max))
+	}
+	return problems
+}
+
+// parseImportBool parses an optional boolean column; an empty value means true
+func parseImportBool(problems []string, column, value string) (bool, []string) {
+	switch strings.ToLower(value) {
+	case "", "true", "1", "yes", "y":
+		return true, problems
+	case "false", "0", "no", "n":
+		return false, problems
+	}
+	return false, append(problems, column+" must be true or false")
+}
+
+// parseImportInt parses an optional integer column that must be at least min; an empty
+// value returns nil
+func parseImportInt(problems []string, column, value string, min int) (*int, []string) {
+	if value == "" {
+		return nil, problems
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min {
+		return nil, append(problems, fmt.Sprintf("%s must be a whole number of at least %d", column, min))
+	}
+	return &n, problems
+}
+
+// intOr returns the parsed value of an optional integer column, or def when it was empty
+func intOr(value *int, def int) int {
+	if value == nil {
+		return def
+	}
+	return *value
+}
+
+// nullIfEmpty stores an empty optional column as NULL
+func nullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
@@ -59,8 +59,8 @@ func (s *cityServiceImpl) GetByProvinceID(ctx context.Context, provinceID int64,
 	}
 
 	// Check cache
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		return cached.([]master.CityResponse), nil
+	if cached, ok := cache.GetTyped[[]master.CityResponse](s.cache, cacheKey); ok {
+		return cached, nil
 	}
 
 	// Validate province exists
@@ -118,8 +118,8 @@ func (s *cityServiceImpl) GetActiveByProvinceID(ctx context.Context, provinceID
 	}
 
 	// Check cache
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		return cached.([]master.CityResponse), nil
+	if cached, ok := cache.GetTyped[[]master.CityResponse](s.cache, cacheKey); ok {
+		return cached, nil
 	}
 
 	// Validate province exists
@@ -181,8 +181,8 @@ func (s *cityServiceImpl) GetByID(ctx context.Context, id int64) (*master.CityRe
 	cacheKey := fmt.Sprintf("city:id:%d", id)
 
 	// Check cache
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		return cached.(*master.CityResponse), nil
+	if cached, ok := cache.GetTyped[*master.CityResponse](s.cache, cacheKey); ok {
+		return cached, nil
 	}
 
 	// Query repository with province preloaded
@@ -39,8 +39,8 @@ func (s *companySizeServiceImpl) GetAll(ctx context.Context) ([]master.CompanySi
 	cacheKey := "company_sizes:all"
 
 	// Check cache
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		return cached.([]master.CompanySizeResponse), nil
+	if cached, ok := cache.GetTyped[[]master.CompanySizeResponse](s.cache, cacheKey); ok {
+		return cached, nil
 	}
 
 	// Query repository
@@ -64,8 +64,8 @@ func (s *companySizeServiceImpl) GetActive(ctx context.Context) ([]master.Compan
 	cacheKey := "company_sizes:active"
 
 	// Check cache
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		return cached.([]master.CompanySizeResponse), nil
+	if cached, ok := cache.GetTyped[[]master.CompanySizeResponse](s.cache, cacheKey); ok {
+		return cached, nil
 	}
 
 	// Query repository
@@ -94,8 +94,8 @@ func (s *companySizeServiceImpl) GetByID(ctx context.Context, id int64) (*master
 	cacheKey := fmt.Sprintf("company_size:id:%d", id)
 
 	// Check cache
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		return cached.(*master.CompanySizeResponse), nil
+	if cached, ok := cache.GetTyped[*master.CompanySizeResponse](s.cache, cacheKey); ok {
+		return cached, nil
 	}
 
 	// Query repository
@@ -63,8 +63,8 @@ func (s *districtServiceImpl) GetByCityID(ctx context.Context, cityID int64, sea
 	}
 
 	// Check cache
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		return cached.([]master.DistrictResponse), nil
+	if cached, ok := cache.GetTyped[[]master.DistrictResponse](s.cache, cacheKey); ok {
+		return cached, nil
 	}
 
 	// Validate city exists
@@ -122,8 +122,8 @@ func (s *districtServiceImpl) GetActiveByCityID(ctx context.Context, cityID int6
 	}
 
 	// Check cache
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		return cached.([]master.DistrictResponse), nil
+	if cached, ok := cache.GetTyped[[]master.DistrictResponse](s.cache, cacheKey); ok {
+		return cached, nil
 	}
 
 	// Validate city exists
@@ -185,8 +185,8 @@ func (s *districtServiceImpl) GetByID(ctx context.Context, id int64) (*master.Di
 	cacheKey := fmt.Sprintf("district:id:%d", id)
 
 	// Check cache
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		return cached.(*master.DistrictResponse), nil
+	if cached, ok := cache.GetTyped[*master.DistrictResponse](s.cache, cacheKey); ok {
+		return cached, nil
 	}
 
 	// Query repository with full location hierarchy preloaded
@@ -207,8 +207,10 @@ func (s *districtServiceImpl) GetByID(ctx context.Context, id int64) (*master.Di
 	return response, nil
 }
 
-// ValidateDistrictID checks if a district ID exists, is active, and belongs to the given city
-func (s *districtServiceImpl) ValidateDistrictID(ctx context.Context, districtID, cityID int64) error {
+// ValidateDistrictID checks if a district ID exists, is active, and belongs to the given city.
+// An entity that already references the district keeps it after deactivation; only new
+// assignments of an inactive district or city are rejected.
+func (s *districtServiceImpl) ValidateDistrictID(ctx context.Context, districtID, cityID int64, currentDistrictID *int64) error {
 	// Validate ID formats
 	if districtID <= 0 {
 		return ErrInvalidDistrictID
@@ -226,8 +228,10 @@ func (s *districtServiceImpl) ValidateDistrictID(ctx context.Context, districtID
 		return fmt.Errorf("failed to validate district ID: %w", err)
 	}
 
+	assigned := currentDistrictID != nil && *currentDistrictID == districtID
+
 	// Check if active
-	if !district.IsActive {
+	if !district.IsActive && !assigned {
 		return ErrDistrictInactive
 	}
 
@@ -245,7 +249,7 @@ func (s *districtServiceImpl) ValidateDistrictID(ctx context.Context, districtID
 		return fmt.Errorf("failed to validate district's city: %w", err)
 	}
 
-	if !city.IsActive {
+	if !city.IsActive && !assigned {
 		return ErrCityInactive
 	}
 
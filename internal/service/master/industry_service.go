@@ -46,8 +46,8 @@ func (s *industryServiceImpl) GetAll(ctx context.Context, search string) ([]mast
 	}
 
 	// Check cache
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		return cached.([]master.IndustryResponse), nil
+	if cached, ok := cache.GetTyped[[]master.IndustryResponse](s.cache, cacheKey); ok {
+		return cached, nil
 	}
 
 	// Query repository
@@ -93,8 +93,8 @@ func (s *industryServiceImpl) GetActive(ctx context.Context, search string) ([]m
 	}
 
 	// Check cache
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		return cached.([]master.IndustryResponse), nil
+	if cached, ok := cache.GetTyped[[]master.IndustryResponse](s.cache, cacheKey); ok {
+		return cached, nil
 	}
 
 	// Query repository
@@ -149,8 +149,8 @@ func (s *industryServiceImpl) GetByID(ctx context.Context, id int64) (*master.In
 	cacheKey := fmt.Sprintf("industry:id:%d", id)
 
 	// Check cache
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		return cached.(*master.IndustryResponse), nil
+	if cached, ok := cache.GetTyped[*master.IndustryResponse](s.cache, cacheKey); ok {
+		return cached, nil
 	}
 
 	// Query repository
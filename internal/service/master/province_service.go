@@ -46,8 +46,8 @@ func (s *provinceServiceImpl) GetAll(ctx context.Context, search string) ([]mast
 	}
 
 	// Check cache
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		return cached.([]master.ProvinceResponse), nil
+	if cached, ok := cache.GetTyped[[]master.ProvinceResponse](s.cache, cacheKey); ok {
+		return cached, nil
 	}
 
 	// Query repository
@@ -93,8 +93,8 @@ func (s *provinceServiceImpl) GetActive(ctx context.Context, search string) ([]m
 	}
 
 	// Check cache
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		return cached.([]master.ProvinceResponse), nil
+	if cached, ok := cache.GetTyped[[]master.ProvinceResponse](s.cache, cacheKey); ok {
+		return cached, nil
 	}
 
 	// Query repository
@@ -149,8 +149,8 @@ func (s *provinceServiceImpl) GetByID(ctx context.Context, id int64) (*master.Pr
 	cacheKey := fmt.Sprintf("province:id:%d", id)
 
 	// Check cache
-	if cached, ok := s.cache.Get(cacheKey); ok {
-		return cached.(*master.ProvinceResponse), nil
+	if cached, ok := cache.GetTyped[*master.ProvinceResponse](s.cache, cacheKey); ok {
+		return cached, nil
 	}
 
 	// Query repository
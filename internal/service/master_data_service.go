@@ -103,3 +103,8 @@ func NewAdminJobTypeService(
 ) master.AdminJobTypeService {
 	return masterService.NewAdminJobTypeService(jobOptionsService, repo, db, cache)
 }
+
+// NewAdminMasterDataTransferService creates a new AdminMasterDataTransferService
+func NewAdminMasterDataTransferService(db *gorm.DB, cache cache.Cache) master.AdminMasterDataTransferService {
+	return masterService.NewAdminMasterDataTransferService(db, cache)
+}
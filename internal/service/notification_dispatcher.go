@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"keerja-backend/internal/domain/notification"
+	"keerja-backend/internal/domain/user"
+)
+
+// deferredPushBatchSize is how many due deferred pushes are claimed per batch
+const deferredPushBatchSize = 200
+
+// notificationDispatcher implements notification.NotificationDispatcher
+type notificationDispatcher struct {
+	userRepo           user.UserRepository
+	pushService        notification.PushNotificationService
+	deferred           notification.DeferredPushRepository
+	whatsApp           notification.WhatsAppProvider
+	whatsAppDeliveries notification.WhatsAppDeliveryRepository
+}
+
+// NewNotificationDispatcher creates a new notification dispatcher instance. Without a push
+// service pushes are not sent; without a deferred push repository pushes are not held back
+// by quiet hours. Without a user repository everyone gets the default preferences. Without
+// a WhatsApp provider no WhatsApp messages are sent; without a delivery repository they are
+// not recorded.
+func NewNotificationDispatcher(
+	userRepo user.UserRepository,
+	pushService notification.PushNotificationService,
+	deferred notification.DeferredPushRepository,
+	whatsApp notification.WhatsAppProvider,
+	whatsAppDeliveries notification.WhatsAppDeliveryRepository,
+) notification.NotificationDispatcher {
+	return &notificationDispatcher{
+		userRepo:           userRepo,
+		pushService:        pushService,
+		deferred:           deferred,
+		whatsApp:           whatsApp,
+		whatsAppDeliveries: whatsAppDeliveries,
+	}
+}
+
+// dispatcherOrDefault returns dispatcher, or when it is nil one that enforces email
+// preferences and sends no pushes
+func dispatcherOrDefault(dispatcher notification.NotificationDispatcher, userRepo user.UserRepository) notification.NotificationDispatcher {
+	if dispatcher != nil {
+		return dispatcher
+	}
+	return NewNotificationDispatcher(userRepo, nil, nil, nil, nil)
+}
+
+// Email calls send when the user accepts emails about the event
+func (d *notificationDispatcher) Email(ctx context.Context, userID int64, event user.NotificationEvent, send func(ctx context.Context) error) (bool, error) {
+	if userID != 0 && !d.preference(ctx, userID).AllowsNotification(event, user.NotificationChannelEmail) {
+		return false, nil
+	}
+	return true, send(ctx)
+}
+
+// Push sends the push to one user, or defers it until the user's quiet hours end
+func (d *notificationDispatcher) Push(ctx context.Context, userID int64, event user.NotificationEvent, message *notification.PushMessage) (*notification.PushDispatchStats, error) {
+	stats := &notification.PushDispatchStats{}
+	if d.pushService == nil {
+		return stats, nil
+	}
+
+	if len(d.admit(ctx, []int64{userID}, event, message, time.Now(), stats)) == 0 {
+		return stats, nil
+	}
+
+	results, err := d.pushService.SendToUser(ctx, userID, message)
+	if delivered(results) {
+		stats.Sent++
+	}
+	return stats, err
+}
+
+// PushToUsers sends the push in one batch to the users outside quiet hours and defers it
+// for the others
+func (d *notificationDispatcher) PushToUsers(ctx context.Context, userIDs []int64, event user.NotificationEvent, message *notification.PushMessage) (*notification.PushDispatchStats, error) {
+	stats := &notification.PushDispatchStats{}
+	if d.pushService == nil || len(userIDs) == 0 {
+		return stats, nil
+	}
+
+	sendNow := d.admit(ctx, userIDs, event, message, time.Now(), stats)
+	if len(sendNow) == 0 {
+		return stats, nil
+	}
+
+	results, err := d.pushService.SendToMultipleUsers(ctx, sendNow, message)
+	for _, userResults := range results {
+		if delivered(userResults) {
+			stats.Sent++
+		}
+	}
+	return stats, err
+}
+
+// SendDeferredPushes sends the deferred pushes due now in batches. Each push goes through
+// the recipient's current preferences again, so pushes muted since are dropped and pushes
+// whose quiet hours were moved are deferred again.
+func (d *notificationDispatcher) SendDeferredPushes(ctx context.Context) (int, error) {
+	if d.deferred == nil || d.pushService == nil {
+		return 0, nil
+	}
+
+	sent := 0
+	var errs []error
+	for {
+		pushes, err := d.deferred.ClaimDue(ctx, time.Now(), deferredPushBatchSize)
+		if err != nil {
+			return sent, fmt.Errorf("failed to claim deferred pushes: %w", err)
+		}
+
+		for _, push := range pushes {
+			stats, err := d.Push(ctx, push.UserID, user.NotificationEvent(push.Event), push.Message())
+			if err != nil {
+				errs = append(errs, fmt.Errorf("deferred push %d: %w", push.ID, err))
+			}
+			sent += stats.Sent
+		}
+
+		if len(pushes) < deferredPushBatchSize {
+			return sent, errors.Join(errs...)
+		}
+	}
+}
+
+// WhatsApp sends the template message to the user's verified phone. Users without one, and
+// users who turned WhatsApp off for the event, get the event's email and push only.
+func (d *notificationDispatcher) WhatsApp(ctx context.Context, userID int64, event user.NotificationEvent, message *notification.WhatsAppMessage) bool {
+	if d.whatsApp == nil || d.userRepo == nil {
+		return false
+	}
+	if !d.preference(ctx, userID).AllowsNotification(event, user.NotificationChannelWhatsApp) {
+		return false
+	}
+
+	usr, err := d.userRepo.FindByID(ctx, userID)
+	if err != nil || usr == nil || !usr.IsPhoneVerified() {
+		return false
+	}
+
+	delivery := &notification.WhatsAppDelivery{
+		UserID:   userID,
+		Event:    string(event),
+		Template: message.Template,
+		Phone:    *usr.Phone,
+		Status:   notification.WhatsAppDeliverySent,
+	}
+	messageID, err := d.whatsApp.SendTemplate(ctx, *usr.Phone, message)
+	switch {
+	case errors.Is(err, notification.ErrWhatsAppTemplateRejected):
+		delivery.Status = notification.WhatsAppDeliveryTemplateRejected
+	case err != nil:
+		delivery.Status = notification.WhatsAppDeliveryFailed
+	case messageID != "":
+		delivery.ProviderMessageID = &messageID
+	}
+	if err != nil {
+		reason := err.Error()
+		delivery.Error = &reason
+		log.Printf("Failed to send WhatsApp %s to user %d: %v", message.Template, userID, err)
+	}
+
+	if d.whatsAppDeliveries != nil {
+		if err := d.whatsAppDeliveries.Create(ctx, delivery); err != nil {
+			log.Printf("Failed to record WhatsApp %s to user %d: %v", message.Template, userID, err)
+		}
+	}
+	return delivery.Status == notification.WhatsAppDeliverySent
+}
+
+// admit returns the users the push may be sent to at now. Users who turned the event's
+// pushes off are skipped, and the push is deferred for users in quiet hours. A push that
+// cannot be deferred is sent right away rather than lost.
+func (d *notificationDispatcher) admit(
+	ctx context.Context,
+	userIDs []int64,
+	event user.NotificationEvent,
+	message *notification.PushMessage,
+	now time.Time,
+	stats *notification.PushDispatchStats,
+) []int64 {
+	admitted := make([]int64, 0, len(userIDs))
+	for _, userID := range userIDs {
+		pref := d.preference(ctx, userID)
+		if !pref.AllowsNotification(event, user.NotificationChannelPush) {
+			stats.Muted++
+			continue
+		}
+
+		if deliverAt := pref.NextPushTime(now); d.deferred != nil && deliverAt.After(now) {
+			err := d.deferred.Create(ctx, notification.NewDeferredPush(userID, event, message, deliverAt))
+			if err == nil {
+				stats.Deferred++
+				continue
+			}
+			log.Printf("Failed to defer push to user %d until %s: %v", userID, deliverAt.Format(time.RFC3339), err)
+		}
+
+		admitted = append(admitted, userID)
+	}
+	return admitted
+}
+
+// preference returns the user's preferences, nil for the defaults when they cannot be loaded
+func (d *notificationDispatcher) preference(ctx context.Context, userID int64) *user.UserPreference {
+	if d.userRepo == nil {
+		return nil
+	}
+	pref, err := d.userRepo.FindPreferenceByUserID(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to load notification preferences of user %d: %v", userID, err)
+		return nil
+	}
+	return pref
+}
+
+// delivered checks if at least one of a user's devices accepted the push
+func delivered(results []notification.PushResult) bool {
+	for _, result := range results {
+		if result.Success {
+			return true
+		}
+	}
+	return false
+}
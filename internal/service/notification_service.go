@@ -8,26 +8,35 @@ import (
 	"log"
 	"time"
 
+	"keerja-backend/internal/domain/application"
 	"keerja-backend/internal/domain/email"
 	"keerja-backend/internal/domain/notification"
+	"keerja-backend/internal/i18n"
+
+	"gorm.io/gorm"
 )
 
+// notificationDeliveryTimeout bounds the push and email delivery that runs after a
+// notification has been saved to the in-app feed
+const notificationDeliveryTimeout = 30 * time.Second
+
 // notificationService implements notification.NotificationService interface
 type notificationService struct {
 	notifRepo    notification.NotificationRepository
-	pushService  notification.PushNotificationService
+	dispatcher   notification.NotificationDispatcher
 	emailService email.EmailService
 }
 
-// NewNotificationService creates a new notification service instance
+// NewNotificationService creates a new notification service instance. Push and email
+// delivery go through dispatcher, which applies the recipient's notification settings.
 func NewNotificationService(
 	notifRepo notification.NotificationRepository,
-	pushService notification.PushNotificationService,
+	dispatcher notification.NotificationDispatcher,
 	emailService email.EmailService,
 ) notification.NotificationService {
 	return &notificationService{
 		notifRepo:    notifRepo,
-		pushService:  pushService,
+		dispatcher:   dispatcherOrDefault(dispatcher, nil),
 		emailService: emailService,
 	}
 }
@@ -81,8 +90,13 @@ func (s *notificationService) SendNotification(ctx context.Context, req *notific
 		return nil, fmt.Errorf("failed to create notification: %w", err)
 	}
 
-	// Send to appropriate channels
-	go s.sendToChannels(ctx, notif, prefs)
+	// Send to appropriate channels. Delivery outlives the request, so it runs on a
+	// detached context that only keeps the recipient's locale.
+	go func() {
+		deliveryCtx, cancel := context.WithTimeout(i18n.WithLocale(context.Background(), i18n.FromContext(ctx)), notificationDeliveryTimeout)
+		defer cancel()
+		s.sendToChannels(deliveryCtx, notif)
+	}()
 
 	return notif, nil
 }
@@ -158,12 +172,15 @@ func (s *notificationService) GetUnreadNotifications(ctx context.Context, userID
 func (s *notificationService) GetNotificationByID(ctx context.Context, id, userID int64) (*notification.Notification, error) {
 	notif, err := s.notifRepo.FindByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("notification not found: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, notification.ErrNotificationNotFound
+		}
+		return nil, fmt.Errorf("failed to get notification: %w", err)
 	}
 
-	// Check ownership
+	// Check ownership; other users' notifications are reported as missing
 	if notif.UserID != userID {
-		return nil, errors.New("you do not have access to this notification")
+		return nil, notification.ErrNotificationNotFound
 	}
 
 	return notif, nil
@@ -272,29 +289,21 @@ func (s *notificationService) NotifyInterviewScheduled(ctx context.Context, user
 
 // NotifyStatusUpdate sends status update notification
 func (s *notificationService) NotifyStatusUpdate(ctx context.Context, userID, applicationID int64, oldStatus, newStatus string) error {
-	statusMessages := map[string]string{
-		"screening":   "Your application is being reviewed",
-		"shortlisted": "Congratulations! You've been shortlisted",
-		"interview":   "You've been invited for an interview",
-		"offered":     "Congratulations! You've received a job offer",
-		"hired":       "Congratulations! You've been hired",
-		"rejected":    "Your application status has been updated",
-	}
-
-	message := statusMessages[newStatus]
-	if message == "" {
-		message = "Your application status has been updated"
+	t := i18n.New(i18n.FromContext(ctx))
+	message := t.T("application.status_update.default")
+	if key := "application.status_update." + newStatus; t.Has(key) {
+		message = t.T(key)
 	}
 
 	priority := "normal"
-	if newStatus == "offered" || newStatus == "hired" || newStatus == "shortlisted" {
+	if newStatus == application.StatusOffered || newStatus == application.StatusHired || newStatus == application.StatusShortlisted {
 		priority = "high"
 	}
 
 	req := &notification.SendNotificationRequest{
 		UserID:      userID,
 		Type:        "status_update",
-		Title:       "Application Status Update",
+		Title:       t.T("application.status_update.title"),
 		Message:     message,
 		Category:    "application",
 		Priority:    priority,
@@ -356,6 +365,122 @@ func (s *notificationService) NotifyCompanyUpdate(ctx context.Context, userIDs [
 	return s.SendBulkNotification(ctx, userIDs, req)
 }
 
+// NotifyApplicationReceived tells a company's employer users about a new application.
+// Each employer gets their own notification so it is also pushed to their devices.
+func (s *notificationService) NotifyApplicationReceived(ctx context.Context, employerUserIDs []int64, jobID, applicationID int64, jobTitle string) error {
+	t := i18n.New(i18n.FromContext(ctx))
+
+	var errs []error
+	for _, userID := range employerUserIDs {
+		req := &notification.SendNotificationRequest{
+			UserID:      userID,
+			Type:        "application_received",
+			Title:       t.T("notification.application_received.title"),
+			Message:     t.T("notification.application_received.message", jobTitle),
+			Category:    "application",
+			Priority:    "normal",
+			Icon:        "inbox",
+			RelatedID:   &applicationID,
+			RelatedType: "application",
+			ActionURL:   fmt.Sprintf("/applications/%d", applicationID),
+			Data: map[string]interface{}{
+				"job_id":         jobID,
+				"application_id": applicationID,
+			},
+		}
+
+		if _, err := s.SendNotification(ctx, req); err != nil {
+			errs = append(errs, fmt.Errorf("user %d: %w", userID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// NotifyInvitationReceived tells an existing user they were invited to join a company
+func (s *notificationService) NotifyInvitationReceived(ctx context.Context, userID, companyID, invitationID int64, companyName string) error {
+	t := i18n.New(i18n.FromContext(ctx))
+
+	req := &notification.SendNotificationRequest{
+		UserID:      userID,
+		Type:        "company_invitation",
+		Title:       t.T("notification.invitation.title"),
+		Message:     t.T("notification.invitation.message", companyName),
+		Category:    "company",
+		Priority:    "high",
+		Icon:        "user-plus",
+		RelatedID:   &invitationID,
+		RelatedType: "invitation",
+		ActionURL:   fmt.Sprintf("/companies/%d", companyID),
+		Data: map[string]interface{}{
+			"company_id":    companyID,
+			"invitation_id": invitationID,
+		},
+	}
+
+	_, err := s.SendNotification(ctx, req)
+	return err
+}
+
+// NotifyJobReviewed tells the job owner an admin approved or rejected their job
+func (s *notificationService) NotifyJobReviewed(ctx context.Context, userID, jobID int64, jobTitle string, approved bool, reason string) error {
+	t := i18n.New(i18n.FromContext(ctx))
+
+	action := "approved"
+	title := t.T("notification.job_approved.title")
+	message := t.T("notification.job_approved.message", jobTitle)
+	if !approved {
+		action = "rejected"
+		title = t.T("notification.job_rejected.title")
+		message = t.T("notification.job_rejected.message", jobTitle, reason)
+	}
+
+	req := &notification.SendNotificationRequest{
+		UserID:      userID,
+		Type:        "job_review",
+		Title:       title,
+		Message:     message,
+		Category:    "job",
+		Priority:    "high",
+		Icon:        "briefcase",
+		RelatedID:   &jobID,
+		RelatedType: "job",
+		ActionURL:   fmt.Sprintf("/jobs/%d", jobID),
+		Data: map[string]interface{}{
+			"job_id": jobID,
+			"action": action,
+		},
+	}
+
+	_, err := s.SendNotification(ctx, req)
+	return err
+}
+
+// NotifyReviewResponse tells a review author the company responded to their review
+func (s *notificationService) NotifyReviewResponse(ctx context.Context, userID, companyID, reviewID int64, companyName string) error {
+	t := i18n.New(i18n.FromContext(ctx))
+
+	req := &notification.SendNotificationRequest{
+		UserID:      userID,
+		Type:        "review_response",
+		Title:       t.T("notification.review_response.title"),
+		Message:     t.T("notification.review_response.message", companyName),
+		Category:    "company",
+		Priority:    "normal",
+		Icon:        "message-circle",
+		RelatedID:   &reviewID,
+		RelatedType: "review",
+		ActionURL:   fmt.Sprintf("/companies/%d/reviews", companyID),
+		Data: map[string]interface{}{
+			"company_id": companyID,
+			"review_id":  reviewID,
+		},
+	}
+
+	_, err := s.SendNotification(ctx, req)
+	return err
+}
+
 // ===== Notification Preferences =====
 
 // GetNotificationPreferences retrieves user notification preferences
@@ -403,56 +528,31 @@ func (s *notificationService) UpdateNotificationPreferences(ctx context.Context,
 
 // ===== Push and Email Notifications =====
 
-// SendPushNotification sends push notification
+// SendPushNotification pushes the notification to the user's devices through the
+// dispatcher, which skips it when the user muted its event and defers it in quiet hours
 func (s *notificationService) SendPushNotification(ctx context.Context, userID int64, notif *notification.Notification) error {
-	// Check if push enabled
-	prefs, _ := s.GetNotificationPreferences(ctx, userID)
-	if prefs != nil && !prefs.IsPushEnabled() {
-		return nil // Skip if push disabled
-	}
-
-	// Build push message from notification
-	pushMessage := s.buildPushMessage(notif)
-
-	// Send push notification via FCM
-	results, err := s.pushService.SendToUser(ctx, userID, pushMessage)
+	stats, err := s.dispatcher.Push(ctx, userID, notification.EventOf(notif.Type), s.buildPushMessage(notif))
 	if err != nil {
 		log.Printf("Failed to send push notification to user %d: %v", userID, err)
 		return fmt.Errorf("failed to send push notification: %w", err)
 	}
 
-	// Log results
-	successCount := 0
-	for _, result := range results {
-		if result.Success {
-			successCount++
-		} else {
-			log.Printf("Push notification failed: %s - %s", result.ErrorCode, result.ErrorMessage)
-		}
-	}
-
-	log.Printf("Push notification sent to user %d: %d/%d successful", userID, successCount, len(results))
+	log.Printf("Push notification to user %d: sent=%d deferred=%d muted=%d", userID, stats.Sent, stats.Deferred, stats.Muted)
 	return nil
 }
 
-// SendEmailNotification sends email notification
+// SendEmailNotification emails the notification when the user accepts emails about its event
 func (s *notificationService) SendEmailNotification(ctx context.Context, userID int64, notif *notification.Notification) error {
-	// Check if email enabled
-	prefs, _ := s.GetNotificationPreferences(ctx, userID)
-	if prefs != nil && !prefs.IsEmailEnabled() {
-		return nil // Skip if email disabled
-	}
-
-	// TODO: Integrate with email service
-	// Send email notification to user
-
-	fmt.Printf("\n====== EMAIL NOTIFICATION ======\n")
-	fmt.Printf("User ID: %d\n", userID)
-	fmt.Printf("Subject: %s\n", notif.Title)
-	fmt.Printf("Body: %s\n", notif.Message)
-	fmt.Printf("================================\n\n")
-
-	return nil
+	_, err := s.dispatcher.Email(ctx, userID, notification.EventOf(notif.Type), func(ctx context.Context) error {
+		// TODO: Integrate with email service
+		fmt.Printf("\n====== EMAIL NOTIFICATION ======\n")
+		fmt.Printf("User ID: %d\n", userID)
+		fmt.Printf("Subject: %s\n", notif.Title)
+		fmt.Printf("Body: %s\n", notif.Message)
+		fmt.Printf("================================\n\n")
+		return nil
+	})
+	return err
 }
 
 // CleanupExpiredNotifications removes expired notifications
@@ -476,23 +576,19 @@ func (s *notificationService) CleanupExpiredNotifications(ctx context.Context) e
 
 // ===== Helper Methods =====
 
-// sendToChannels sends notification to appropriate channels based on preferences
-func (s *notificationService) sendToChannels(ctx context.Context, notif *notification.Notification, prefs *notification.NotificationPreference) {
+// sendToChannels pushes and emails a saved notification. Whether the user accepts either
+// is decided by the dispatcher.
+func (s *notificationService) sendToChannels(ctx context.Context, notif *notification.Notification) {
 	// Send in-app notification (already saved to database)
 	notif.MarkAsSent()
 	s.notifRepo.Update(ctx, notif)
 
-	if prefs == nil {
-		return
-	}
-
-	// Send push notification if enabled
-	if prefs.PushEnabled && (notif.Channel == "push" || notif.Channel == "in_app") {
+	if notif.Channel == "push" || notif.Channel == "in_app" {
 		s.SendPushNotification(ctx, notif.UserID, notif)
 	}
 
-	// Send email notification if enabled and high priority
-	if prefs.EmailEnabled && notif.IsHighPriority() {
+	// Only high priority notifications are also emailed
+	if notif.IsHighPriority() {
 		s.SendEmailNotification(ctx, notif.UserID, notif)
 	}
 }
@@ -14,8 +14,7 @@ import (
 	"sync"
 	"time"
 
-	"github.com/redis/go-redis/v9"
-
+	"keerja-backend/internal/cache"
 	"keerja-backend/internal/domain/auth"
 	"keerja-backend/internal/domain/user"
 	"keerja-backend/internal/utils"
@@ -214,11 +213,12 @@ func (s *OAuthService) isAllowedMobileRedirect(uri string) bool {
 	return ok
 }
 
-// CreateOneTimeCode stores a single-use code in Redis mapping to the provided jwtToken.
-// It returns the generated code. Requires stateStore to be backed by Redis.
+// CreateOneTimeCode stores a single-use code in the shared key-value store mapping to the
+// provided jwtToken. It returns the generated code. Falls back to process memory when
+// stateStore is not key-value backed.
 func (s *OAuthService) CreateOneTimeCode(ctx context.Context, jwtToken string, ttl time.Duration) (string, error) {
-	// try redis-backed store first
-	if redisStore, ok := s.stateStore.(*RedisOAuthStateStore); ok && redisStore.client != nil {
+	// try the key-value store first
+	if kvStore, ok := s.stateStore.(*KVOAuthStateStore); ok && kvStore.store != nil {
 		// generate a random code (url-safe)
 		b := make([]byte, 32)
 		if _, err := rand.Read(b); err != nil {
@@ -231,8 +231,8 @@ func (s *OAuthService) CreateOneTimeCode(ctx context.Context, jwtToken string, t
 			ttl = oneTimeCodeTTL
 		}
 
-		if err := redisStore.client.Set(ctx, key, jwtToken, ttl).Err(); err != nil {
-			return "", fmt.Errorf("failed to store one-time code in redis: %w", err)
+		if err := kvStore.store.Set(ctx, key, []byte(jwtToken), ttl); err != nil {
+			return "", fmt.Errorf("failed to store one-time code: %w", err)
 		}
 
 		return code, nil
@@ -258,25 +258,25 @@ func (s *OAuthService) CreateOneTimeCode(ctx context.Context, jwtToken string, t
 	return code, nil
 }
 
-// ConsumeOneTimeCode atomically gets and deletes the one-time code from Redis and returns the stored jwtToken.
+// ConsumeOneTimeCode gets and deletes the one-time code and returns the stored jwtToken.
 func (s *OAuthService) ConsumeOneTimeCode(ctx context.Context, code string) (string, error) {
-	// Redis-backed store
-	if redisStore, ok := s.stateStore.(*RedisOAuthStateStore); ok && redisStore.client != nil {
+	// Key-value store
+	if kvStore, ok := s.stateStore.(*KVOAuthStateStore); ok && kvStore.store != nil {
 		key := "oauth:onetime:" + code
-		value, err := redisStore.client.Get(ctx, key).Result()
+		value, err := kvStore.store.Get(ctx, key)
 		if err != nil {
-			if errors.Is(err, redis.Nil) {
+			if errors.Is(err, cache.ErrKeyNotFound) {
 				return "", errors.New("one-time code not found or expired")
 			}
 			return "", fmt.Errorf("failed to get one-time code: %w", err)
 		}
 
 		// delete key after reading
-		if err := redisStore.client.Del(ctx, key).Err(); err != nil {
+		if err := kvStore.store.Delete(ctx, key); err != nil {
 			return "", fmt.Errorf("failed to delete one-time code: %w", err)
 		}
 
-		return value, nil
+		return string(value), nil
 	}
 
 	// fallback in-memory store
@@ -587,14 +587,22 @@ func (s *OAuthService) upsertGoogleUser(ctx context.Context, userInfo *GoogleUse
 			Email:        userInfo.Email,
 			FullName:     userInfo.Name,
 			UserType:     "jobseeker",
-			IsVerified:   userInfo.VerifiedEmail,
 			Status:       "active",
 			PasswordHash: "",
 		}
+		if userInfo.VerifiedEmail {
+			usr.MarkEmailVerified(time.Now())
+		}
 
 		if err := s.userRepo.Create(ctx, usr); err != nil {
 			return nil, fmt.Errorf("failed to create user: %w", err)
 		}
+	} else if userInfo.VerifiedEmail && !usr.IsEmailVerified() {
+		// Google has verified the address the account signed up with
+		usr.MarkEmailVerified(time.Now())
+		if err := s.userRepo.Update(ctx, usr); err != nil {
+			return nil, fmt.Errorf("failed to update user: %w", err)
+		}
 	}
 
 	rawDataJSON, _ := json.Marshal(userInfo)
@@ -8,7 +8,7 @@ import (
 	"sync"
 	"time"
 
-	"github.com/redis/go-redis/v9"
+	"keerja-backend/internal/cache"
 )
 
 const (
@@ -34,20 +34,21 @@ type OAuthStateStore interface {
 	Consume(ctx context.Context, state string) (*OAuthStateData, error)
 }
 
-// RedisOAuthStateStore stores OAuth states in Redis with TTL.
-type RedisOAuthStateStore struct {
-	client *redis.Client
+// KVOAuthStateStore stores OAuth states with TTL in the shared key-value store (Redis,
+// or its in-memory fallback while Redis is unavailable).
+type KVOAuthStateStore struct {
+	store cache.KeyValueStore
 }
 
-// NewRedisOAuthStateStore creates a new Redis-based OAuth state store.
-func NewRedisOAuthStateStore(client *redis.Client) *RedisOAuthStateStore {
-	return &RedisOAuthStateStore{client: client}
+// NewKVOAuthStateStore creates a new key-value OAuth state store.
+func NewKVOAuthStateStore(store cache.KeyValueStore) *KVOAuthStateStore {
+	return &KVOAuthStateStore{store: store}
 }
 
-// Save implements OAuthStateStore.Save for Redis.
-func (s *RedisOAuthStateStore) Save(ctx context.Context, state string, data OAuthStateData, ttl time.Duration) error {
-	if s.client == nil {
-		return errors.New("redis client is nil")
+// Save implements OAuthStateStore.Save.
+func (s *KVOAuthStateStore) Save(ctx context.Context, state string, data OAuthStateData, ttl time.Duration) error {
+	if s.store == nil {
+		return errors.New("oauth state store is nil")
 	}
 
 	payload, err := json.Marshal(data)
@@ -55,29 +56,29 @@ func (s *RedisOAuthStateStore) Save(ctx context.Context, state string, data OAut
 		return fmt.Errorf("failed to marshal oauth state: %w", err)
 	}
 
-	if err := s.client.Set(ctx, oauthStateKeyPrefix+state, payload, ttl).Err(); err != nil {
-		return fmt.Errorf("failed to store oauth state in redis: %w", err)
+	if err := s.store.Set(ctx, oauthStateKeyPrefix+state, payload, ttl); err != nil {
+		return fmt.Errorf("failed to store oauth state: %w", err)
 	}
 
 	return nil
 }
 
-// Consume implements OAuthStateStore.Consume for Redis (get + delete).
-func (s *RedisOAuthStateStore) Consume(ctx context.Context, state string) (*OAuthStateData, error) {
-	if s.client == nil {
-		return nil, errors.New("redis client is nil")
+// Consume implements OAuthStateStore.Consume (get + delete).
+func (s *KVOAuthStateStore) Consume(ctx context.Context, state string) (*OAuthStateData, error) {
+	if s.store == nil {
+		return nil, errors.New("oauth state store is nil")
 	}
 
 	key := oauthStateKeyPrefix + state
-	value, err := s.client.Get(ctx, key).Bytes()
-	if errors.Is(err, redis.Nil) {
+	value, err := s.store.Get(ctx, key)
+	if errors.Is(err, cache.ErrKeyNotFound) {
 		return nil, ErrStateNotFound
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch oauth state from redis: %w", err)
+		return nil, fmt.Errorf("failed to fetch oauth state: %w", err)
 	}
 
-	if err := s.client.Del(ctx, key).Err(); err != nil {
+	if err := s.store.Delete(ctx, key); err != nil {
 		return nil, fmt.Errorf("failed to delete oauth state: %w", err)
 	}
 
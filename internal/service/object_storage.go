@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// ErrStorageTransient marks object storage errors that are safe to retry
+var ErrStorageTransient = errors.New("transient object storage error")
+
+// ObjectStorageClient is the subset of an S3-compatible client used by the upload service
+type ObjectStorageClient interface {
+	PutObject(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	RemoveObject(ctx context.Context, key string) error
+	// GetObject opens an object for reading; it returns ErrStoredFileNotFound for missing keys
+	GetObject(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// S3Config holds connection settings for an S3-compatible bucket (AWS S3, MinIO, GCS interop)
+type S3Config struct {
+	Endpoint  string // e.g. "s3.amazonaws.com", "minio:9000", "storage.googleapis.com"
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+}
+
+// s3ObjectClient implements ObjectStorageClient using the MinIO client
+type s3ObjectClient struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewS3ObjectClient creates an S3-compatible object storage client
+func NewS3ObjectClient(cfg S3Config) (ObjectStorageClient, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("s3 bucket is required")
+	}
+
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = "s3.amazonaws.com"
+	}
+
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 client: %w", err)
+	}
+
+	return &s3ObjectClient{client: client, bucket: cfg.Bucket}, nil
+}
+
+// PutObject uploads an object to the bucket
+func (c *s3ObjectClient) PutObject(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	_, err := c.client.PutObject(ctx, c.bucket, key, body, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	return classifyS3Error(err)
+}
+
+// RemoveObject deletes an object from the bucket
+func (c *s3ObjectClient) RemoveObject(ctx context.Context, key string) error {
+	return classifyS3Error(c.client.RemoveObject(ctx, c.bucket, key, minio.RemoveObjectOptions{}))
+}
+
+// GetObject opens an object in the bucket for reading
+func (c *s3ObjectClient) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	obj, err := c.client.GetObject(ctx, c.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, classifyS3Error(err)
+	}
+	// GetObject is lazy; Stat surfaces a missing key before the caller starts streaming
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		if minio.ToErrorResponse(err).StatusCode == http.StatusNotFound {
+			return nil, ErrStoredFileNotFound
+		}
+		return nil, classifyS3Error(err)
+	}
+	return obj, nil
+}
+
+// classifyS3Error wraps throttling, server and network errors with ErrStorageTransient
+func classifyS3Error(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return fmt.Errorf("%w: %v", ErrStorageTransient, err)
+	}
+
+	resp := minio.ToErrorResponse(err)
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%w: %v", ErrStorageTransient, err)
+	}
+
+	return err
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"keerja-backend/internal/domain/auth"
+	"keerja-backend/internal/domain/user"
+)
+
+// PhoneVerificationOTPExpiry is how long a phone verification code stays valid
+const PhoneVerificationOTPExpiry = OTPCodeExpiryMinutes * time.Minute
+
+// RequestPhoneVerification sends an OTP by SMS to the phone number on the user's profile. The
+// code is bound to that number, so changing the number invalidates codes already sent.
+func (s *RegistrationService) RequestPhoneVerification(ctx context.Context, userID int64, clientIP string) error {
+	usr, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if usr == nil {
+		return ErrUserNotFound
+	}
+
+	if usr.Phone == nil || *usr.Phone == "" {
+		return user.ErrPhoneNotSet
+	}
+	if usr.IsPhoneVerified() {
+		return user.ErrPhoneAlreadyVerified
+	}
+	if err := s.checkPhoneAvailable(ctx, usr.ID, *usr.Phone); err != nil {
+		return err
+	}
+
+	if err := s.checkOTPIssueLimits(ctx, usr.ID, otpTypePhoneVerification, clientIP); err != nil {
+		return err
+	}
+
+	// Only the latest code is checked, so a new request replaces earlier ones
+	otpCode := s.generateOTPCode()
+	otp := &auth.OTPCode{
+		UserID:    usr.ID,
+		OTPHash:   s.hashOTPCode(*usr.Phone, otpCode),
+		Type:      otpTypePhoneVerification,
+		ExpiredAt: time.Now().Add(PhoneVerificationOTPExpiry),
+		IPAddress: optionalIP(clientIP),
+	}
+	if err := s.otpCodeRepo.Create(ctx, otp); err != nil {
+		return fmt.Errorf("failed to save OTP: %w", err)
+	}
+
+	message := fmt.Sprintf("Kode verifikasi Keerja Anda: %s. Berlaku %d menit. Jangan berikan kode ini kepada siapa pun.", otpCode, OTPCodeExpiryMinutes)
+	if err := s.smsProvider.SendSMS(ctx, *usr.Phone, message); err != nil {
+		return fmt.Errorf("failed to send OTP SMS: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyPhoneOTP verifies the OTP sent by RequestPhoneVerification and marks the user's phone
+// number verified. A number can be verified by one account only.
+func (s *RegistrationService) VerifyPhoneOTP(ctx context.Context, userID int64, otpCode string) (*user.User, error) {
+	usr, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if usr == nil {
+		return nil, ErrUserNotFound
+	}
+
+	if usr.Phone == nil || *usr.Phone == "" {
+		return nil, user.ErrPhoneNotSet
+	}
+	if usr.IsPhoneVerified() {
+		return nil, user.ErrPhoneAlreadyVerified
+	}
+
+	latestOTP, err := s.otpCodeRepo.FindByUserIDAndType(ctx, usr.ID, otpTypePhoneVerification)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find OTP: %w", err)
+	}
+	if latestOTP == nil {
+		return nil, ErrOTPCodeNotFound
+	}
+
+	if err := s.verifyOTPAttempt(ctx, latestOTP, *usr.Phone, otpCode); err != nil {
+		return nil, err
+	}
+
+	// Another account may have verified the number since the code was sent
+	if err := s.checkPhoneAvailable(ctx, usr.ID, *usr.Phone); err != nil {
+		return nil, err
+	}
+
+	if err := s.otpCodeRepo.MarkAsUsed(ctx, latestOTP.ID); err != nil {
+		return nil, fmt.Errorf("failed to mark OTP as used: %w", err)
+	}
+
+	verifiedAt := time.Now()
+	if err := s.userRepo.MarkPhoneVerified(ctx, usr.ID, *usr.Phone, verifiedAt); err != nil {
+		if errors.Is(err, user.ErrPhoneTaken) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to mark phone verified: %w", err)
+	}
+	usr.PhoneVerifiedAt = &verifiedAt
+
+	if s.cache != nil {
+		s.cache.Delete(profileCompletenessCacheKey(usr.ID))
+	}
+
+	return usr, nil
+}
+
+// checkPhoneAvailable returns user.ErrPhoneTaken when another account has verified phone
+func (s *RegistrationService) checkPhoneAvailable(ctx context.Context, userID int64, phone string) error {
+	existing, err := s.userRepo.FindByVerifiedPhone(ctx, phone)
+	if err != nil {
+		return fmt.Errorf("failed to check verified phone: %w", err)
+	}
+	if existing != nil && existing.ID != userID {
+		return user.ErrPhoneTaken
+	}
+	return nil
+}
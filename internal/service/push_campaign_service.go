@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"keerja-backend/internal/domain/notification"
+)
+
+// campaignTokenPageSize is how many device tokens are loaded and sent per multicast call
+const campaignTokenPageSize = 500
+
+// dueCampaignBatch bounds how many campaigns a single scheduler run sends
+const dueCampaignBatch = 10
+
+// pushCampaignService implements notification.PushCampaignService
+type pushCampaignService struct {
+	campaignRepo    notification.PushCampaignRepository
+	deviceTokenRepo notification.DeviceTokenRepository
+	pushService     notification.PushNotificationService
+}
+
+// NewPushCampaignService creates a new push campaign service instance
+func NewPushCampaignService(
+	campaignRepo notification.PushCampaignRepository,
+	deviceTokenRepo notification.DeviceTokenRepository,
+	pushService notification.PushNotificationService,
+) notification.PushCampaignService {
+	return &pushCampaignService{
+		campaignRepo:    campaignRepo,
+		deviceTokenRepo: deviceTokenRepo,
+		pushService:     pushService,
+	}
+}
+
+// CreateCampaign validates and schedules a push campaign; the scheduler sends it once it is due
+func (s *pushCampaignService) CreateCampaign(ctx context.Context, req *notification.CreatePushCampaignRequest) (*notification.PushCampaign, error) {
+	hasTopic := req.Topic != ""
+	hasSegment := req.Segment != nil &&
+		(req.Segment.Role != nil || req.Segment.ProvinceID != nil || req.Segment.FollowedCompanyID != nil)
+	if hasTopic == hasSegment {
+		return nil, notification.ErrCampaignTarget
+	}
+	if hasTopic && !notification.IsValidTopic(req.Topic) {
+		return nil, notification.ErrInvalidTopic
+	}
+
+	scheduledAt := time.Now()
+	if req.ScheduledAt != nil {
+		scheduledAt = *req.ScheduledAt
+	}
+
+	campaign := &notification.PushCampaign{
+		Title:       req.Title,
+		Body:        req.Body,
+		Data:        notification.PushData(req.Data),
+		Status:      notification.CampaignStatusScheduled,
+		ScheduledAt: scheduledAt,
+		CreatedBy:   &req.CreatedBy,
+	}
+	if hasTopic {
+		campaign.Topic = &req.Topic
+	} else {
+		campaign.Role = req.Segment.Role
+		campaign.ProvinceID = req.Segment.ProvinceID
+		campaign.FollowedCompanyID = req.Segment.FollowedCompanyID
+	}
+
+	if err := s.campaignRepo.Create(ctx, campaign); err != nil {
+		return nil, fmt.Errorf("failed to create push campaign: %w", err)
+	}
+	return campaign, nil
+}
+
+// GetCampaign retrieves a push campaign with its delivery stats
+func (s *pushCampaignService) GetCampaign(ctx context.Context, id int64) (*notification.PushCampaign, error) {
+	campaign, err := s.campaignRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get push campaign: %w", err)
+	}
+	if campaign == nil {
+		return nil, notification.ErrCampaignNotFound
+	}
+	return campaign, nil
+}
+
+// ListCampaigns lists push campaigns, newest first
+func (s *pushCampaignService) ListCampaigns(ctx context.Context, page, limit int) ([]notification.PushCampaign, int64, error) {
+	return s.campaignRepo.List(ctx, page, limit)
+}
+
+// SendDueCampaigns claims and sends the campaigns whose scheduled time has passed
+func (s *pushCampaignService) SendDueCampaigns(ctx context.Context) (int, error) {
+	campaigns, err := s.campaignRepo.ClaimDue(ctx, time.Now(), dueCampaignBatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim due push campaigns: %w", err)
+	}
+
+	var errs []error
+	for i := range campaigns {
+		if err := s.sendCampaign(ctx, &campaigns[i]); err != nil {
+			errs = append(errs, fmt.Errorf("campaign %d: %w", campaigns[i].ID, err))
+		}
+	}
+
+	return len(campaigns), errors.Join(errs...)
+}
+
+// sendCampaign sends a claimed campaign and stores its outcome and delivery stats
+func (s *pushCampaignService) sendCampaign(ctx context.Context, campaign *notification.PushCampaign) error {
+	var sendErr error
+	if campaign.IsTopicCampaign() {
+		sendErr = s.sendToTopic(ctx, campaign)
+	} else {
+		sendErr = s.sendToSegment(ctx, campaign)
+	}
+
+	now := time.Now()
+	campaign.CompletedAt = &now
+	campaign.Status = notification.CampaignStatusCompleted
+	if sendErr != nil {
+		msg := sendErr.Error()
+		campaign.Status = notification.CampaignStatusFailed
+		campaign.ErrorMessage = &msg
+	}
+
+	if err := s.campaignRepo.Update(ctx, campaign); err != nil {
+		return errors.Join(sendErr, fmt.Errorf("failed to save push campaign: %w", err))
+	}
+	return sendErr
+}
+
+// sendToTopic sends a campaign as a single topic message; FCM fans it out, so the
+// stats only record whether that message was accepted
+func (s *pushCampaignService) sendToTopic(ctx context.Context, campaign *notification.PushCampaign) error {
+	result, err := s.pushService.SendToTopic(ctx, *campaign.Topic, campaign.Message())
+	if err != nil {
+		campaign.FailedCount++
+		return err
+	}
+	if !result.Success {
+		campaign.FailedCount++
+		return fmt.Errorf("%s: %s", result.ErrorCode, result.ErrorMessage)
+	}
+
+	campaign.SentCount++
+	return nil
+}
+
+// sendToSegment pages through the segment's device tokens, sending each page as one
+// multicast call and deleting tokens FCM reports as unregistered that the push service
+// has not already pruned
+func (s *pushCampaignService) sendToSegment(ctx context.Context, campaign *notification.PushCampaign) error {
+	segment := campaign.Segment()
+	message := campaign.Message()
+
+	var afterID int64
+	for {
+		tokens, err := s.deviceTokenRepo.FindBySegment(ctx, segment, afterID, campaignTokenPageSize)
+		if err != nil {
+			return err
+		}
+		if len(tokens) == 0 {
+			return nil
+		}
+		afterID = tokens[len(tokens)-1].ID
+
+		results, err := s.pushService.SendToTokens(ctx, tokenStrings(tokens), message)
+		if err != nil {
+			return fmt.Errorf("failed to send batch: %w", err)
+		}
+
+		var invalid []string
+		for i, result := range results {
+			if result.Success {
+				campaign.SentCount++
+				continue
+			}
+			campaign.FailedCount++
+			if result.TokenPruned {
+				campaign.InvalidTokensPruned++
+				continue
+			}
+			if result.ErrorCode == "UNREGISTERED" {
+				invalid = append(invalid, tokens[i].Token)
+			}
+		}
+
+		pruned, err := s.deviceTokenRepo.DeleteByTokens(ctx, invalid)
+		if err != nil {
+			return err
+		}
+		campaign.InvalidTokensPruned += int(pruned)
+
+		if len(tokens) < campaignTokenPageSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"keerja-backend/internal/domain/notification"
+	"keerja-backend/internal/domain/user"
+)
+
+// pushTopicService implements notification.PushTopicService
+type pushTopicService struct {
+	deviceTokenRepo notification.DeviceTokenRepository
+	userRepo        user.UserRepository
+	pushService     notification.PushNotificationService
+}
+
+// NewPushTopicService creates a new push topic service instance
+func NewPushTopicService(
+	deviceTokenRepo notification.DeviceTokenRepository,
+	userRepo user.UserRepository,
+	pushService notification.PushNotificationService,
+) notification.PushTopicService {
+	return &pushTopicService{
+		deviceTokenRepo: deviceTokenRepo,
+		userRepo:        userRepo,
+		pushService:     pushService,
+	}
+}
+
+// SyncUserTopics reconciles the recorded topic subscriptions of the user's active
+// devices with the topics derived from the user's role and province
+func (s *pushTopicService) SyncUserTopics(ctx context.Context, userID int64) error {
+	usr, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if usr == nil {
+		return fmt.Errorf("user %d not found", userID)
+	}
+
+	profile, err := s.userRepo.FindProfileByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to find profile: %w", err)
+	}
+	var provinceID *int64
+	if profile != nil {
+		provinceID = profile.ProvinceID
+	}
+	desired := notification.UserTopics(usr.UserType, provinceID)
+
+	tokens, err := s.deviceTokenRepo.FindByUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user device tokens: %w", err)
+	}
+
+	// Group tokens per topic so each topic needs a single FCM call
+	subscribe := make(map[string][]notification.DeviceToken)
+	unsubscribe := make(map[string][]notification.DeviceToken)
+	for _, token := range tokens {
+		current, err := s.deviceTokenRepo.FindTopics(ctx, token.ID)
+		if err != nil {
+			return err
+		}
+		for _, topic := range desired {
+			if !slices.Contains(current, topic) {
+				subscribe[topic] = append(subscribe[topic], token)
+			}
+		}
+		for _, topic := range current {
+			if !slices.Contains(desired, topic) {
+				unsubscribe[topic] = append(unsubscribe[topic], token)
+			}
+		}
+	}
+
+	var errs []error
+	for topic, topicTokens := range subscribe {
+		errs = append(errs, s.subscribe(ctx, topicTokens, topic))
+	}
+	for topic, topicTokens := range unsubscribe {
+		errs = append(errs, s.unsubscribe(ctx, topicTokens, topic))
+	}
+	return errors.Join(errs...)
+}
+
+// UnsubscribeToken removes all topic subscriptions of a device token
+func (s *pushTopicService) UnsubscribeToken(ctx context.Context, token *notification.DeviceToken) error {
+	topics, err := s.deviceTokenRepo.FindTopics(ctx, token.ID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, topic := range topics {
+		errs = append(errs, s.unsubscribe(ctx, []notification.DeviceToken{*token}, topic))
+	}
+	return errors.Join(errs...)
+}
+
+// subscribe subscribes tokens to topic with FCM and records the subscriptions
+func (s *pushTopicService) subscribe(ctx context.Context, tokens []notification.DeviceToken, topic string) error {
+	if err := s.pushService.SubscribeToTopic(ctx, tokenStrings(tokens), topic); err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if err := s.deviceTokenRepo.AddTopic(ctx, token.ID, topic); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unsubscribe unsubscribes tokens from topic with FCM and forgets the subscriptions
+func (s *pushTopicService) unsubscribe(ctx context.Context, tokens []notification.DeviceToken, topic string) error {
+	if err := s.pushService.UnsubscribeFromTopic(ctx, tokenStrings(tokens), topic); err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if err := s.deviceTokenRepo.RemoveTopic(ctx, token.ID, topic); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tokenStrings extracts the FCM token strings of device tokens
+func tokenStrings(tokens []notification.DeviceToken) []string {
+	out := make([]string, len(tokens))
+	for i, token := range tokens {
+		out[i] = token.Token
+	}
+	return out
+}
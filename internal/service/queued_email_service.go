@@ -0,0 +1,398 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"keerja-backend/internal/domain/email"
+)
+
+// queuedEmailService implements email.EmailService by queuing notification emails in the
+// email outbox instead of sending them, so transient SMTP failures are retried by the
+// queue worker. Emails a user is actively waiting on (verification, password reset, OTP)
+// and the log methods still go straight to the transport, whose errors their callers report.
+type queuedEmailService struct {
+	email.EmailService // Transport, e.g. the SMTP email service
+	queue              email.EmailQueueService
+}
+
+// NewQueuedEmailService creates an email service that queues notification emails and
+// sends the rest directly through transport
+func NewQueuedEmailService(transport email.EmailService, queue email.EmailQueueService) email.EmailService {
+	return &queuedEmailService{
+		EmailService: transport,
+		queue:        queue,
+	}
+}
+
+// Payloads of queued emails, holding the arguments of the EmailService method each template is sent with
+
+type welcomePayload struct {
+	Name string `json:"name"`
+}
+
+type jobApplicationPayload struct {
+	JobTitle    string `json:"job_title"`
+	CompanyName string `json:"company_name"`
+}
+
+type jobStatusUpdatePayload struct {
+	JobTitle string `json:"job_title"`
+	Status   string `json:"status"`
+}
+
+type companyInvitationPayload struct {
+	Name        string `json:"name"`
+	CompanyName string `json:"company_name"`
+	InviterName string `json:"inviter_name"`
+	Position    string `json:"position"`
+	Role        string `json:"role"`
+	InviteURL   string `json:"invite_url"`
+	ExpiryDays  int    `json:"expiry_days"`
+}
+
+type employerInvitationPayload struct {
+	Name        string    `json:"name"`
+	CompanyName string    `json:"company_name"`
+	InviterName string    `json:"inviter_name"`
+	Position    string    `json:"position"`
+	Role        string    `json:"role"`
+	Token       string    `json:"token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type invitationAcceptedPayload struct {
+	InviterName string `json:"inviter_name"`
+	MemberName  string `json:"member_name"`
+	MemberEmail string `json:"member_email"`
+	CompanyName string `json:"company_name"`
+	Position    string `json:"position"`
+	Role        string `json:"role"`
+}
+
+type invitationExpiredPayload struct {
+	Name        string `json:"name"`
+	CompanyName string `json:"company_name"`
+	InviterName string `json:"inviter_name"`
+	Position    string `json:"position"`
+}
+
+type jobReviewPayload struct {
+	Name        string `json:"name"`
+	JobTitle    string `json:"job_title"`
+	CompanyName string `json:"company_name"`
+	Status      string `json:"status"`
+	Message     string `json:"message"`
+}
+
+type stageReminderPayload struct {
+	Name          string                     `json:"name"`
+	CompanyName   string                     `json:"company_name"`
+	ThresholdDays int                        `json:"threshold_days"`
+	Groups        []email.StageReminderGroup `json:"groups"`
+}
+
+type weeklyDigestPayload struct {
+	Name        string              `json:"name"`
+	CompanyName string              `json:"company_name"`
+	Digest      *email.WeeklyDigest `json:"digest"`
+}
+
+type followedCompanyJobPayload struct {
+	Name        string `json:"name"`
+	CompanyName string `json:"company_name"`
+	JobTitle    string `json:"job_title"`
+	JobSlug     string `json:"job_slug"`
+}
+
+type verificationExpiryPayload struct {
+	Name        string    `json:"name"`
+	CompanyName string    `json:"company_name"`
+	DaysLeft    int       `json:"days_left"`
+	Expiry      time.Time `json:"expiry"`
+}
+
+type jobExpiryReminderPayload struct {
+	Name        string    `json:"name"`
+	JobTitle    string    `json:"job_title"`
+	CompanyName string    `json:"company_name"`
+	DaysLeft    int       `json:"days_left"`
+	Expiry      time.Time `json:"expiry"`
+	AutoExtend  bool      `json:"auto_extend"`
+}
+
+type interviewBookingPayload struct {
+	Name        string    `json:"name"`
+	JobTitle    string    `json:"job_title"`
+	CompanyName string    `json:"company_name"`
+	Token       string    `json:"token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type dataExportPayload struct {
+	Name      string    `json:"name"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type webhookDisabledPayload struct {
+	Name        string `json:"name"`
+	CompanyName string `json:"company_name"`
+	WebhookURL  string `json:"webhook_url"`
+	Failures    int    `json:"failures"`
+}
+
+// queuedEmailSender sends a queued email through the transport
+type queuedEmailSender func(ctx context.Context, transport email.EmailService, to string, payload []byte) error
+
+// decodeQueued wraps a typed send function with payload decoding
+func decodeQueued[P any](send func(ctx context.Context, transport email.EmailService, to string, p P) error) queuedEmailSender {
+	return func(ctx context.Context, transport email.EmailService, to string, payload []byte) error {
+		var p P
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return fmt.Errorf("%w: %v", errUndeliverableEmail, err)
+		}
+		return send(ctx, transport, to, p)
+	}
+}
+
+// queuedEmailSenders maps each queue template to the transport method that sends it
+var queuedEmailSenders = map[string]queuedEmailSender{
+	email.QueueTemplateWelcome: decodeQueued(func(ctx context.Context, t email.EmailService, to string, p welcomePayload) error {
+		return t.SendWelcomeEmail(ctx, to, p.Name)
+	}),
+	email.QueueTemplateJobApplication: decodeQueued(func(ctx context.Context, t email.EmailService, to string, p jobApplicationPayload) error {
+		return t.SendJobApplicationEmail(ctx, to, p.JobTitle, p.CompanyName)
+	}),
+	email.QueueTemplateJobStatusUpdate: decodeQueued(func(ctx context.Context, t email.EmailService, to string, p jobStatusUpdatePayload) error {
+		return t.SendJobStatusUpdateEmail(ctx, to, p.JobTitle, p.Status)
+	}),
+	email.QueueTemplateInterviewInvitation: decodeQueued(func(ctx context.Context, t email.EmailService, to string, p email.InterviewEmailData) error {
+		return t.SendInterviewInvitationEmail(ctx, to, p)
+	}),
+	email.QueueTemplateCompanyTemplate: decodeQueued(func(ctx context.Context, t email.EmailService, to string, p email.RenderedEmail) error {
+		return t.SendCompanyTemplateEmail(ctx, to, p)
+	}),
+	email.QueueTemplateInterviewReminder: decodeQueued(func(ctx context.Context, t email.EmailService, to string, p email.InterviewEmailData) error {
+		return t.SendInterviewReminderEmail(ctx, to, p)
+	}),
+	email.QueueTemplateInterviewCancellation: decodeQueued(func(ctx context.Context, t email.EmailService, to string, p email.InterviewEmailData) error {
+		return t.SendInterviewCancellationEmail(ctx, to, p)
+	}),
+	email.QueueTemplateCompanyInvitation: decodeQueued(func(ctx context.Context, t email.EmailService, to string, p companyInvitationPayload) error {
+		return t.SendCompanyInvitationEmail(ctx, to, p.Name, p.CompanyName, p.InviterName, p.Position, p.Role, p.InviteURL, p.ExpiryDays)
+	}),
+	email.QueueTemplateEmployerInvitation: decodeQueued(func(ctx context.Context, t email.EmailService, to string, p employerInvitationPayload) error {
+		return t.SendEmployerInvitationEmail(ctx, to, p.Name, p.CompanyName, p.InviterName, p.Position, p.Role, p.Token, p.ExpiresAt)
+	}),
+	email.QueueTemplateInvitationAccepted: decodeQueued(func(ctx context.Context, t email.EmailService, to string, p invitationAcceptedPayload) error {
+		return t.SendInvitationAcceptedEmail(ctx, to, p.InviterName, p.MemberName, p.MemberEmail, p.CompanyName, p.Position, p.Role)
+	}),
+	email.QueueTemplateInvitationExpired: decodeQueued(func(ctx context.Context, t email.EmailService, to string, p invitationExpiredPayload) error {
+		return t.SendInvitationExpiredEmail(ctx, to, p.Name, p.CompanyName, p.InviterName, p.Position)
+	}),
+	email.QueueTemplateJobReview: decodeQueued(func(ctx context.Context, t email.EmailService, to string, p jobReviewPayload) error {
+		return t.SendJobReviewEmail(ctx, to, p.Name, p.JobTitle, p.CompanyName, p.Status, p.Message)
+	}),
+	email.QueueTemplateStageReminder: decodeQueued(func(ctx context.Context, t email.EmailService, to string, p stageReminderPayload) error {
+		return t.SendStageReminderEmail(ctx, to, p.Name, p.CompanyName, p.ThresholdDays, p.Groups)
+	}),
+	email.QueueTemplateWeeklyDigest: decodeQueued(func(ctx context.Context, t email.EmailService, to string, p weeklyDigestPayload) error {
+		return t.SendWeeklyDigestEmail(ctx, to, p.Name, p.CompanyName, p.Digest)
+	}),
+	email.QueueTemplateFollowedCompanyJob: decodeQueued(func(ctx context.Context, t email.EmailService, to string, p followedCompanyJobPayload) error {
+		return t.SendFollowedCompanyJobEmail(ctx, to, p.Name, p.CompanyName, p.JobTitle, p.JobSlug)
+	}),
+	email.QueueTemplateVerificationExpiry: decodeQueued(func(ctx context.Context, t email.EmailService, to string, p verificationExpiryPayload) error {
+		return t.SendVerificationExpiryEmail(ctx, to, p.Name, p.CompanyName, p.DaysLeft, p.Expiry)
+	}),
+	email.QueueTemplateJobExpiryReminder: decodeQueued(func(ctx context.Context, t email.EmailService, to string, p jobExpiryReminderPayload) error {
+		return t.SendJobExpiryReminderEmail(ctx, to, p.Name, p.JobTitle, p.CompanyName, p.DaysLeft, p.Expiry, p.AutoExtend)
+	}),
+	email.QueueTemplateInterviewBooking: decodeQueued(func(ctx context.Context, t email.EmailService, to string, p interviewBookingPayload) error {
+		return t.SendInterviewBookingEmail(ctx, to, p.Name, p.JobTitle, p.CompanyName, p.Token, p.ExpiresAt)
+	}),
+	email.QueueTemplateDataExport: decodeQueued(func(ctx context.Context, t email.EmailService, to string, p dataExportPayload) error {
+		return t.SendDataExportEmail(ctx, to, p.Name, p.Token, p.ExpiresAt)
+	}),
+	email.QueueTemplateWebhookDisabled: decodeQueued(func(ctx context.Context, t email.EmailService, to string, p webhookDisabledPayload) error {
+		return t.SendWebhookDisabledEmail(ctx, to, p.Name, p.CompanyName, p.WebhookURL, p.Failures)
+	}),
+}
+
+// SendWelcomeEmail queues a welcome email
+func (s *queuedEmailService) SendWelcomeEmail(ctx context.Context, to, name string) error {
+	return s.queue.Enqueue(ctx, to, email.QueueTemplateWelcome, welcomePayload{Name: name})
+}
+
+// SendJobApplicationEmail queues a job application confirmation
+func (s *queuedEmailService) SendJobApplicationEmail(ctx context.Context, to, jobTitle, companyName string) error {
+	return s.queue.Enqueue(ctx, to, email.QueueTemplateJobApplication, jobApplicationPayload{JobTitle: jobTitle, CompanyName: companyName})
+}
+
+// SendJobStatusUpdateEmail queues an application status update
+func (s *queuedEmailService) SendJobStatusUpdateEmail(ctx context.Context, to, jobTitle, status string) error {
+	return s.queue.Enqueue(ctx, to, email.QueueTemplateJobStatusUpdate, jobStatusUpdatePayload{JobTitle: jobTitle, Status: status})
+}
+
+// SendCompanyTemplateEmail queues a candidate email rendered from a company template
+func (s *queuedEmailService) SendCompanyTemplateEmail(ctx context.Context, to string, rendered email.RenderedEmail) error {
+	return s.queue.Enqueue(ctx, to, email.QueueTemplateCompanyTemplate, rendered)
+}
+
+// SendInterviewInvitationEmail queues an interview invitation
+func (s *queuedEmailService) SendInterviewInvitationEmail(ctx context.Context, to string, interview email.InterviewEmailData) error {
+	return s.queue.Enqueue(ctx, to, email.QueueTemplateInterviewInvitation, interview)
+}
+
+// SendInterviewReminderEmail queues an interview reminder
+func (s *queuedEmailService) SendInterviewReminderEmail(ctx context.Context, to string, interview email.InterviewEmailData) error {
+	return s.queue.Enqueue(ctx, to, email.QueueTemplateInterviewReminder, interview)
+}
+
+// SendInterviewCancellationEmail queues an interview cancellation
+func (s *queuedEmailService) SendInterviewCancellationEmail(ctx context.Context, to string, interview email.InterviewEmailData) error {
+	return s.queue.Enqueue(ctx, to, email.QueueTemplateInterviewCancellation, interview)
+}
+
+// SendCompanyInvitationEmail queues a company employee invitation
+func (s *queuedEmailService) SendCompanyInvitationEmail(ctx context.Context, to, name, companyName, inviterName, position, role, inviteURL string, expiryDays int) error {
+	return s.queue.Enqueue(ctx, to, email.QueueTemplateCompanyInvitation, companyInvitationPayload{
+		Name:        name,
+		CompanyName: companyName,
+		InviterName: inviterName,
+		Position:    position,
+		Role:        role,
+		InviteURL:   inviteURL,
+		ExpiryDays:  expiryDays,
+	})
+}
+
+// SendEmployerInvitationEmail queues an employer invitation
+func (s *queuedEmailService) SendEmployerInvitationEmail(ctx context.Context, to, name, companyName, inviterName, position, role, token string, expiresAt time.Time) error {
+	return s.queue.Enqueue(ctx, to, email.QueueTemplateEmployerInvitation, employerInvitationPayload{
+		Name:        name,
+		CompanyName: companyName,
+		InviterName: inviterName,
+		Position:    position,
+		Role:        role,
+		Token:       token,
+		ExpiresAt:   expiresAt,
+	})
+}
+
+// SendInvitationAcceptedEmail queues an invitation accepted notification
+func (s *queuedEmailService) SendInvitationAcceptedEmail(ctx context.Context, to, inviterName, memberName, memberEmail, companyName, position, role string) error {
+	return s.queue.Enqueue(ctx, to, email.QueueTemplateInvitationAccepted, invitationAcceptedPayload{
+		InviterName: inviterName,
+		MemberName:  memberName,
+		MemberEmail: memberEmail,
+		CompanyName: companyName,
+		Position:    position,
+		Role:        role,
+	})
+}
+
+// SendInvitationExpiredEmail queues an invitation expired notification
+func (s *queuedEmailService) SendInvitationExpiredEmail(ctx context.Context, to, name, companyName, inviterName, position string) error {
+	return s.queue.Enqueue(ctx, to, email.QueueTemplateInvitationExpired, invitationExpiredPayload{
+		Name:        name,
+		CompanyName: companyName,
+		InviterName: inviterName,
+		Position:    position,
+	})
+}
+
+// SendJobReviewEmail queues a job approval or rejection notification
+func (s *queuedEmailService) SendJobReviewEmail(ctx context.Context, to, name, jobTitle, companyName, status, message string) error {
+	return s.queue.Enqueue(ctx, to, email.QueueTemplateJobReview, jobReviewPayload{
+		Name:        name,
+		JobTitle:    jobTitle,
+		CompanyName: companyName,
+		Status:      status,
+		Message:     message,
+	})
+}
+
+// SendStageReminderEmail queues a recruiter's stage reminder summary
+func (s *queuedEmailService) SendStageReminderEmail(ctx context.Context, to, name, companyName string, thresholdDays int, groups []email.StageReminderGroup) error {
+	return s.queue.Enqueue(ctx, to, email.QueueTemplateStageReminder, stageReminderPayload{
+		Name:          name,
+		CompanyName:   companyName,
+		ThresholdDays: thresholdDays,
+		Groups:        groups,
+	})
+}
+
+// SendWeeklyDigestEmail queues a recruiter's weekly company digest
+func (s *queuedEmailService) SendWeeklyDigestEmail(ctx context.Context, to, name, companyName string, digest *email.WeeklyDigest) error {
+	return s.queue.Enqueue(ctx, to, email.QueueTemplateWeeklyDigest, weeklyDigestPayload{
+		Name:        name,
+		CompanyName: companyName,
+		Digest:      digest,
+	})
+}
+
+// SendFollowedCompanyJobEmail queues a new job notification for a company follower
+func (s *queuedEmailService) SendFollowedCompanyJobEmail(ctx context.Context, to, name, companyName, jobTitle, jobSlug string) error {
+	return s.queue.Enqueue(ctx, to, email.QueueTemplateFollowedCompanyJob, followedCompanyJobPayload{
+		Name:        name,
+		CompanyName: companyName,
+		JobTitle:    jobTitle,
+		JobSlug:     jobSlug,
+	})
+}
+
+// SendVerificationExpiryEmail queues a company verification expiry warning
+func (s *queuedEmailService) SendVerificationExpiryEmail(ctx context.Context, to, name, companyName string, daysLeft int, expiry time.Time) error {
+	return s.queue.Enqueue(ctx, to, email.QueueTemplateVerificationExpiry, verificationExpiryPayload{
+		Name:        name,
+		CompanyName: companyName,
+		DaysLeft:    daysLeft,
+		Expiry:      expiry,
+	})
+}
+
+// SendJobExpiryReminderEmail queues a job expiry reminder for the job's employer
+func (s *queuedEmailService) SendJobExpiryReminderEmail(ctx context.Context, to, name, jobTitle, companyName string, daysLeft int, expiry time.Time, autoExtend bool) error {
+	return s.queue.Enqueue(ctx, to, email.QueueTemplateJobExpiryReminder, jobExpiryReminderPayload{
+		Name:        name,
+		JobTitle:    jobTitle,
+		CompanyName: companyName,
+		DaysLeft:    daysLeft,
+		Expiry:      expiry,
+		AutoExtend:  autoExtend,
+	})
+}
+
+// SendInterviewBookingEmail queues a candidate's interview booking link
+func (s *queuedEmailService) SendInterviewBookingEmail(ctx context.Context, to, name, jobTitle, companyName, token string, expiresAt time.Time) error {
+	return s.queue.Enqueue(ctx, to, email.QueueTemplateInterviewBooking, interviewBookingPayload{
+		Name:        name,
+		JobTitle:    jobTitle,
+		CompanyName: companyName,
+		Token:       token,
+		ExpiresAt:   expiresAt,
+	})
+}
+
+// SendDataExportEmail queues a user's data export download link
+func (s *queuedEmailService) SendDataExportEmail(ctx context.Context, to, name, token string, expiresAt time.Time) error {
+	return s.queue.Enqueue(ctx, to, email.QueueTemplateDataExport, dataExportPayload{
+		Name:      name,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	})
+}
+
+// SendWebhookDisabledEmail queues the notice that a company webhook was disabled
+func (s *queuedEmailService) SendWebhookDisabledEmail(ctx context.Context, to, name, companyName, webhookURL string, failures int) error {
+	return s.queue.Enqueue(ctx, to, email.QueueTemplateWebhookDisabled, webhookDisabledPayload{
+		Name:        name,
+		CompanyName: companyName,
+		WebhookURL:  webhookURL,
+		Failures:    failures,
+	})
+}
@@ -12,16 +12,18 @@ import (
 	"time"
 
 	"keerja-backend/internal/domain/auth"
+	"keerja-backend/internal/domain/user"
 	"keerja-backend/internal/utils"
+
+	"github.com/google/uuid"
 )
 
 // Refresh token configuration
 const (
-	RefreshTokenLength     = 64   // 64 bytes = 512 bits
-	RefreshTokenExpiryDays = 30   // Default 30 days
-	RememberMeExpiryDays   = 90   // Remember me: 90 days
-	MaxActiveTokensPerUser = 5    // Max 5 devices
-	RefreshTokenRotation   = true // Enable token rotation on refresh
+	RefreshTokenLength     = 64 // 64 bytes = 512 bits
+	RefreshTokenExpiryDays = 30 // Default 30 days
+	RememberMeExpiryDays   = 90 // Remember me: 90 days
+	MaxActiveTokensPerUser = 5  // Max 5 devices
 )
 
 var (
@@ -30,6 +32,7 @@ var (
 	ErrRefreshTokenRevoked  = errors.New("refresh token has been revoked")
 	ErrMaxDevicesExceeded   = errors.New("maximum number of devices exceeded")
 	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrRefreshTokenReused   = errors.New("refresh token reuse detected; session has been revoked")
 )
 
 // DeviceInfo contains information about the device making the request
@@ -44,6 +47,7 @@ type DeviceInfo struct {
 // RefreshTokenService handles refresh token operations
 type RefreshTokenService struct {
 	refreshTokenRepo auth.RefreshTokenRepository
+	userRepo         user.UserRepository
 	jwtSecret        string
 	jwtDuration      time.Duration
 }
@@ -51,11 +55,13 @@ type RefreshTokenService struct {
 // NewRefreshTokenService creates a new refresh token service
 func NewRefreshTokenService(
 	refreshTokenRepo auth.RefreshTokenRepository,
+	userRepo user.UserRepository,
 	jwtSecret string,
 	jwtDuration time.Duration,
 ) *RefreshTokenService {
 	return &RefreshTokenService{
 		refreshTokenRepo: refreshTokenRepo,
+		userRepo:         userRepo,
 		jwtSecret:        jwtSecret,
 		jwtDuration:      jwtDuration,
 	}
@@ -130,42 +136,65 @@ func (s *RefreshTokenService) parseDeviceName(userAgent string) string {
 	return fmt.Sprintf("%s on %s", browser, os)
 }
 
-// CreateRefreshToken creates a new refresh token for a user
+// CreateRefreshToken creates a new refresh token for a user, starting a new session
 func (s *RefreshTokenService) CreateRefreshToken(
 	ctx context.Context,
 	userID int64,
 	deviceInfo DeviceInfo,
 	rememberMe bool,
 ) (string, error) {
+	token, _, err := s.createRefreshToken(ctx, userID, deviceInfo, rememberMe)
+	return token, err
+}
+
+// CreateSession creates a new refresh token session and an access token bound to it
+func (s *RefreshTokenService) CreateSession(
+	ctx context.Context,
+	usr *user.User,
+	deviceInfo DeviceInfo,
+	rememberMe bool,
+) (accessToken string, refreshToken string, err error) {
+	refreshToken, stored, err := s.createRefreshToken(ctx, usr.ID, deviceInfo, rememberMe)
+	if err != nil {
+		return "", "", err
+	}
+
+	accessToken, err = utils.GenerateSessionAccessToken(usr.ID, usr.Email, usr.UserType, stored.FamilyID, s.jwtSecret, s.jwtDuration)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+// createRefreshToken stores a refresh token that starts a new rotation family
+func (s *RefreshTokenService) createRefreshToken(
+	ctx context.Context,
+	userID int64,
+	deviceInfo DeviceInfo,
+	rememberMe bool,
+) (string, *auth.RefreshToken, error) {
 	// Check active token limit per user
 	activeCount, err := s.refreshTokenRepo.CountActiveByUserID(ctx, userID)
 	if err != nil {
-		return "", fmt.Errorf("failed to count active tokens: %w", err)
+		return "", nil, fmt.Errorf("failed to count active tokens: %w", err)
 	}
 
 	if activeCount >= MaxActiveTokensPerUser {
-		// Revoke oldest token to make room
+		// Revoke least recently used session to make room
 		tokens, err := s.refreshTokenRepo.FindActiveByUserID(ctx, userID)
 		if err != nil {
-			return "", fmt.Errorf("failed to find active tokens: %w", err)
+			return "", nil, fmt.Errorf("failed to find active tokens: %w", err)
 		}
 
 		if len(tokens) > 0 {
 			oldestToken := tokens[len(tokens)-1]
-			if err := s.refreshTokenRepo.Revoke(ctx, oldestToken.ID, "max_devices_exceeded"); err != nil {
-				return "", fmt.Errorf("failed to revoke oldest token: %w", err)
+			if err := s.refreshTokenRepo.RevokeFamily(ctx, oldestToken.FamilyID, "max_devices_exceeded"); err != nil {
+				return "", nil, fmt.Errorf("failed to revoke oldest token: %w", err)
 			}
 		}
 	}
 
-	// Generate refresh token
-	token, err := s.generateRefreshToken()
-	if err != nil {
-		return "", err
-	}
-
-	tokenHash := s.hashRefreshToken(token)
-
 	// Determine expiry based on remember me
 	expiryDays := RefreshTokenExpiryDays
 	if rememberMe {
@@ -173,51 +202,63 @@ func (s *RefreshTokenService) CreateRefreshToken(
 	}
 	expiresAt := time.Now().Add(time.Duration(expiryDays) * 24 * time.Hour)
 
-	// Parse device info
-	deviceType := s.parseDeviceType(deviceInfo.UserAgent)
-	deviceName := s.parseDeviceName(deviceInfo.UserAgent)
-
-	// If device info not provided, use parsed values
+	// If device info not provided, use values parsed from the user agent
 	if deviceInfo.DeviceType == "" {
-		deviceInfo.DeviceType = deviceType
+		deviceInfo.DeviceType = s.parseDeviceType(deviceInfo.UserAgent)
 	}
 	if deviceInfo.DeviceName == "" {
-		deviceInfo.DeviceName = deviceName
+		deviceInfo.DeviceName = s.parseDeviceName(deviceInfo.UserAgent)
 	}
 
-	// Create refresh token record
-	refreshToken := &auth.RefreshToken{
+	token, refreshToken, err := s.newRefreshToken(userID, uuid.New().String(), deviceInfo, expiresAt)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err := s.refreshTokenRepo.Create(ctx, refreshToken); err != nil {
+		return "", nil, fmt.Errorf("failed to save refresh token: %w", err)
+	}
+
+	return token, refreshToken, nil
+}
+
+// newRefreshToken generates a token and builds its (unsaved) record
+func (s *RefreshTokenService) newRefreshToken(
+	userID int64,
+	familyID string,
+	deviceInfo DeviceInfo,
+	expiresAt time.Time,
+) (string, *auth.RefreshToken, error) {
+	token, err := s.generateRefreshToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, &auth.RefreshToken{
 		UserID:     userID,
-		TokenHash:  tokenHash,
+		TokenHash:  s.hashRefreshToken(token),
+		FamilyID:   familyID,
 		DeviceName: &deviceInfo.DeviceName,
 		DeviceType: &deviceInfo.DeviceType,
 		DeviceID:   &deviceInfo.DeviceID,
 		UserAgent:  &deviceInfo.UserAgent,
 		IPAddress:  &deviceInfo.IPAddress,
+		LastUsedAt: time.Now(),
 		ExpiresAt:  expiresAt,
 		Revoked:    false,
-	}
-
-	if err := s.refreshTokenRepo.Create(ctx, refreshToken); err != nil {
-		return "", fmt.Errorf("failed to save refresh token: %w", err)
-	}
-
-	return token, nil
+	}, nil
 }
 
-// RefreshAccessToken validates refresh token and issues new access token
+// RefreshAccessToken exchanges a refresh token for a new access token and a new refresh token.
+// The presented token is revoked; presenting it again is treated as theft and revokes the
+// whole session (token family).
 func (s *RefreshTokenService) RefreshAccessToken(
 	ctx context.Context,
 	refreshToken string,
-	userID int64,
-	email string,
-	userType string,
+	deviceInfo DeviceInfo,
 ) (string, string, error) {
-	// Hash the provided token
-	tokenHash := s.hashRefreshToken(refreshToken)
-
 	// Find refresh token in database
-	storedToken, err := s.refreshTokenRepo.FindByTokenHash(ctx, tokenHash)
+	storedToken, err := s.refreshTokenRepo.FindByTokenHash(ctx, s.hashRefreshToken(refreshToken))
 	if err != nil {
 		return "", "", fmt.Errorf("failed to find refresh token: %w", err)
 	}
@@ -228,6 +269,9 @@ func (s *RefreshTokenService) RefreshAccessToken(
 
 	// Validate token
 	if storedToken.Revoked {
+		if storedToken.IsRotated() {
+			return "", "", s.revokeReusedFamily(ctx, storedToken)
+		}
 		return "", "", ErrRefreshTokenRevoked
 	}
 
@@ -235,39 +279,54 @@ func (s *RefreshTokenService) RefreshAccessToken(
 		return "", "", ErrRefreshTokenExpired
 	}
 
-	if storedToken.UserID != userID {
+	usr, err := s.userRepo.FindByID(ctx, storedToken.UserID)
+	if err != nil || usr == nil || !usr.IsActive() {
 		return "", "", ErrInvalidRefreshToken
 	}
 
-	// Update last used timestamp
-	if err := s.refreshTokenRepo.UpdateLastUsed(ctx, storedToken.ID); err != nil {
-		return "", "", fmt.Errorf("failed to update last used: %w", err)
+	// Keep the device details of the session unless the client sent fresh ones
+	if deviceInfo.DeviceID == "" && storedToken.DeviceID != nil {
+		deviceInfo.DeviceID = *storedToken.DeviceID
+	}
+	if deviceInfo.UserAgent == "" && storedToken.UserAgent != nil {
+		deviceInfo.UserAgent = *storedToken.UserAgent
 	}
+	deviceInfo.DeviceType = s.parseDeviceType(deviceInfo.UserAgent)
+	deviceInfo.DeviceName = s.parseDeviceName(deviceInfo.UserAgent)
 
-	// Generate new access token
-	accessToken, err := utils.GenerateAccessToken(userID, email, userType, s.jwtSecret, s.jwtDuration)
+	// The family keeps its original expiry, so rotation can't extend a session forever
+	newRefreshToken, next, err := s.newRefreshToken(storedToken.UserID, storedToken.FamilyID, deviceInfo, storedToken.ExpiresAt)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to generate access token: %w", err)
+		return "", "", err
 	}
+	// created_at marks when the session started, not when it was last rotated
+	next.CreatedAt = storedToken.CreatedAt
 
-	// Optional: Token rotation - generate new refresh token
-	newRefreshToken := refreshToken
-	if RefreshTokenRotation {
-		newRefreshToken, err = s.generateRefreshToken()
-		if err != nil {
-			return "", "", fmt.Errorf("failed to generate new refresh token: %w", err)
-		}
+	rotated, err := s.refreshTokenRepo.Rotate(ctx, storedToken.ID, next)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	if !rotated {
+		// Another request used this token first
+		return "", "", s.revokeReusedFamily(ctx, storedToken)
+	}
 
-		// Update token hash
-		storedToken.TokenHash = s.hashRefreshToken(newRefreshToken)
-		if err := s.refreshTokenRepo.Update(ctx, storedToken); err != nil {
-			return "", "", fmt.Errorf("failed to rotate refresh token: %w", err)
-		}
+	accessToken, err := utils.GenerateSessionAccessToken(usr.ID, usr.Email, usr.UserType, storedToken.FamilyID, s.jwtSecret, s.jwtDuration)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate access token: %w", err)
 	}
 
 	return accessToken, newRefreshToken, nil
 }
 
+// revokeReusedFamily revokes every token in the family of a reused refresh token
+func (s *RefreshTokenService) revokeReusedFamily(ctx context.Context, token *auth.RefreshToken) error {
+	if err := s.refreshTokenRepo.RevokeFamily(ctx, token.FamilyID, "reuse_detected"); err != nil {
+		return fmt.Errorf("failed to revoke token family: %w", err)
+	}
+	return ErrRefreshTokenReused
+}
+
 // RevokeRefreshToken revokes a specific refresh token
 func (s *RefreshTokenService) RevokeRefreshToken(ctx context.Context, refreshToken string, reason string) error {
 	tokenHash := s.hashRefreshToken(refreshToken)
@@ -289,6 +348,31 @@ func (s *RefreshTokenService) RevokeAllUserTokens(ctx context.Context, userID in
 	return s.refreshTokenRepo.RevokeAllByUserID(ctx, userID, reason)
 }
 
+// RevokeOtherSessions revokes every session of a user except currentSessionID, e.g. after a
+// password change. Without a current session (a token from the legacy login) all are revoked.
+func (s *RefreshTokenService) RevokeOtherSessions(ctx context.Context, userID int64, currentSessionID, reason string) error {
+	if currentSessionID == "" {
+		return s.refreshTokenRepo.RevokeAllByUserID(ctx, userID, reason)
+	}
+
+	tokens, err := s.refreshTokenRepo.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to find sessions: %w", err)
+	}
+
+	revoked := make(map[string]bool)
+	for _, token := range tokens {
+		if token.FamilyID == currentSessionID || revoked[token.FamilyID] {
+			continue
+		}
+		if err := s.refreshTokenRepo.RevokeFamily(ctx, token.FamilyID, reason); err != nil {
+			return fmt.Errorf("failed to revoke session: %w", err)
+		}
+		revoked[token.FamilyID] = true
+	}
+	return nil
+}
+
 // RevokeDeviceToken revokes refresh token for specific device
 func (s *RefreshTokenService) RevokeDeviceToken(ctx context.Context, userID int64, deviceID string, reason string) error {
 	return s.refreshTokenRepo.RevokeByDeviceID(ctx, userID, deviceID, reason)
@@ -299,6 +383,26 @@ func (s *RefreshTokenService) GetUserDevices(ctx context.Context, userID int64)
 	return s.refreshTokenRepo.FindActiveByUserID(ctx, userID)
 }
 
+// RevokeSession revokes the session the given refresh token belongs to
+func (s *RefreshTokenService) RevokeSession(ctx context.Context, userID, tokenID int64) error {
+	token, err := s.refreshTokenRepo.FindByID(ctx, tokenID)
+	if err != nil {
+		return fmt.Errorf("failed to find refresh token: %w", err)
+	}
+
+	if token == nil || token.UserID != userID {
+		return ErrRefreshTokenNotFound
+	}
+
+	return s.refreshTokenRepo.RevokeFamily(ctx, token.FamilyID, "user_revoked")
+}
+
+// IsSessionActive checks if a session (refresh token family) has not been revoked or expired.
+// It is used by the auth middleware to reject access tokens of revoked sessions.
+func (s *RefreshTokenService) IsSessionActive(ctx context.Context, sessionID string) (bool, error) {
+	return s.refreshTokenRepo.IsFamilyActive(ctx, sessionID)
+}
+
 // CleanupExpiredTokens removes expired refresh tokens (should be called periodically)
 func (s *RefreshTokenService) CleanupExpiredTokens(ctx context.Context) error {
 	return s.refreshTokenRepo.DeleteExpired(ctx)
@@ -10,7 +10,9 @@ import (
 	"math/big"
 	"time"
 
+	"keerja-backend/internal/cache"
 	"keerja-backend/internal/domain/auth"
+	"keerja-backend/internal/domain/company"
 	"keerja-backend/internal/domain/email"
 	"keerja-backend/internal/domain/user"
 	"keerja-backend/internal/utils"
@@ -20,11 +22,21 @@ import (
 
 // OTP Configuration constants for registration
 const (
-	OTPCodeLength            = 6
-	OTPCodeExpiryMinutes     = 5
-	OTPMaxVerifyAttempts     = 5
-	OTPResendWindowSeconds   = 60 // user must wait 60s before resend
-	OTPMaxOTPRequestsPerHour = 3  // max 3 OTP requests per hour per user
+	OTPCodeLength          = 6
+	OTPCodeExpiryMinutes   = 5
+	OTPMaxVerifyAttempts   = 5                // a code is invalidated after this many verification attempts
+	OTPRequestWindow       = 15 * time.Minute // window for the issuance limits below
+	OTPMaxRequestsPerEmail = 3                // max codes of one type per email within the window
+	OTPMaxRequestsPerIP    = 10               // max codes of any type per IP within the window
+	OTPResendBaseDelay     = 60 * time.Second // wait after the first code, doubled for each further code in the window
+)
+
+// OTP types stored in otp_codes.type
+const (
+	otpTypeEmailVerification = "email_verification"
+	otpTypePasswordReset     = "password_reset"
+	otpTypeEmailChange       = "email_change"
+	otpTypePhoneVerification = "phone_verification"
 )
 
 var (
@@ -38,27 +50,62 @@ var (
 	ErrUserAlreadyVerified = errors.New("user email already verified")
 )
 
+// OTPRateLimitError is returned when an OTP cannot be issued yet. It wraps
+// ErrTooManyOTPRequests or ErrResendTooSoon and says when to retry.
+type OTPRateLimitError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *OTPRateLimitError) Error() string {
+	return fmt.Sprintf("%s (retry after %ds)", e.Err.Error(), e.RetryAfterSeconds())
+}
+
+func (e *OTPRateLimitError) Unwrap() error {
+	return e.Err
+}
+
+// RetryAfterSeconds returns the wait rounded up to whole seconds, at least 1
+func (e *OTPRateLimitError) RetryAfterSeconds() int {
+	secs := int((e.RetryAfter + time.Second - 1) / time.Second)
+	if secs < 1 {
+		return 1
+	}
+	return secs
+}
+
 // RegistrationService handles user registration with OTP verification
 type RegistrationService struct {
 	userRepo     user.UserRepository
 	otpCodeRepo  auth.OTPCodeRepository
 	emailService email.EmailService
+	smsProvider  SMSProvider
+	cache        cache.Cache
+	invitations  invitationSignup
 	jwtSecret    string
 	jwtDuration  time.Duration
 }
 
-// NewRegistrationService creates a new registration service
+// NewRegistrationService creates a new registration service. companyService accepts the
+// invitations signups carry and may be nil when invitations are not used. smsProvider sends
+// phone verification codes; cacheService may be nil.
 func NewRegistrationService(
 	userRepo user.UserRepository,
 	otpCodeRepo auth.OTPCodeRepository,
 	emailService email.EmailService,
 	jwtSecret string,
 	jwtDuration time.Duration,
+	companyService company.CompanyService,
+	smsProvider SMSProvider,
+	cacheService cache.Cache,
 ) *RegistrationService {
 	return &RegistrationService{
 		userRepo:     userRepo,
 		otpCodeRepo:  otpCodeRepo,
 		emailService: emailService,
+		smsProvider:  smsProvider,
+		cache:        cacheService,
+		invitations:  invitationSignup{companies: companyService, users: userRepo},
 		jwtSecret:    jwtSecret,
 		jwtDuration:  jwtDuration,
 	}
@@ -83,8 +130,28 @@ func (s *RegistrationService) hashOTPCode(email, otpCode string) string {
 	return hex.EncodeToString(hash[:])
 }
 
-// RegisterUser creates a new user with is_verified = false and sends OTP
-func (s *RegistrationService) RegisterUser(ctx context.Context, fullName, email, password, phone, userType string) error {
+// RegisterUser creates a new user with is_verified = false and sends OTP. With an
+// invitationToken the user signs up as an employer and joins the inviting company with the
+// invited role; the email must be the one the invitation was sent to.
+func (s *RegistrationService) RegisterUser(ctx context.Context, fullName, email, password, phone, userType, clientIP, invitationToken string) error {
+	// Check the IP limit first so a throttled client cannot create unverified accounts
+	if err := s.checkIPOTPLimit(ctx, clientIP); err != nil {
+		return err
+	}
+
+	invitation, err := s.invitations.check(ctx, invitationToken, email)
+	if err != nil {
+		return err
+	}
+	if invitation != nil {
+		userType = "employer"
+	}
+
+	normalizedPhone, err := utils.NormalizePhonePtr(&phone)
+	if err != nil {
+		return user.ErrInvalidPhone
+	}
+
 	// Check if email already exists
 	existingUser, err := s.userRepo.FindByEmail(ctx, email)
 	if err != nil {
@@ -104,15 +171,15 @@ func (s *RegistrationService) RegisterUser(ctx context.Context, fullName, email,
 	newUser := &user.User{
 		FullName:     fullName,
 		Email:        email,
-		Phone:        &phone,
+		Phone:        normalizedPhone,
 		PasswordHash: passwordHash,
 		UserType:     userType,
 		IsVerified:   false,
 		Status:       "active",
 	}
 
-	if err := s.userRepo.Create(ctx, newUser); err != nil {
-		return fmt.Errorf("failed to create user: %w", err)
+	if err := s.invitations.create(ctx, invitation, invitationToken, newUser); err != nil {
+		return err
 	}
 
 	// Create empty user profile for the new user
@@ -124,7 +191,7 @@ func (s *RegistrationService) RegisterUser(ctx context.Context, fullName, email,
 	}
 
 	// Generate and send OTP
-	if err := s.sendOTPToUser(ctx, newUser.ID, email); err != nil {
+	if err := s.sendOTPToUser(ctx, newUser.ID, email, clientIP); err != nil {
 		// Rollback user creation if OTP sending fails (optional)
 		// return error but user is already created
 		return fmt.Errorf("user created but failed to send OTP: %w", err)
@@ -134,27 +201,9 @@ func (s *RegistrationService) RegisterUser(ctx context.Context, fullName, email,
 }
 
 // sendOTPToUser generates OTP, saves to DB, and sends via email
-func (s *RegistrationService) sendOTPToUser(ctx context.Context, userID int64, email string) error {
-	// Check rate limiting: max 3 OTP requests per hour
-	oneHourAgo := time.Now().Add(-1 * time.Hour)
-	recentCount, err := s.otpCodeRepo.CountRecentByUserID(ctx, userID, oneHourAgo, "email_verification")
-	if err != nil {
-		return fmt.Errorf("failed to check rate limit: %w", err)
-	}
-	if recentCount >= OTPMaxOTPRequestsPerHour {
-		return ErrTooManyOTPRequests
-	}
-
-	// Check resend window: user must wait 60s before new OTP
-	latestOTP, err := s.otpCodeRepo.FindByUserIDAndType(ctx, userID, "email_verification")
-	if err != nil {
-		return fmt.Errorf("failed to check latest OTP: %w", err)
-	}
-	if latestOTP != nil && !latestOTP.IsUsed {
-		timeSinceCreation := time.Since(latestOTP.CreatedAt)
-		if timeSinceCreation < OTPResendWindowSeconds*time.Second {
-			return ErrResendTooSoon
-		}
+func (s *RegistrationService) sendOTPToUser(ctx context.Context, userID int64, email, clientIP string) error {
+	if err := s.checkOTPIssueLimits(ctx, userID, otpTypeEmailVerification, clientIP); err != nil {
+		return err
 	}
 
 	// Generate OTP code
@@ -165,10 +214,11 @@ func (s *RegistrationService) sendOTPToUser(ctx context.Context, userID int64, e
 	otpRecord := &auth.OTPCode{
 		UserID:    userID,
 		OTPHash:   otpHash,
-		Type:      "email_verification",
+		Type:      otpTypeEmailVerification,
 		ExpiredAt: time.Now().Add(OTPCodeExpiryMinutes * time.Minute),
 		IsUsed:    false,
 		Attempts:  0,
+		IPAddress: optionalIP(clientIP),
 	}
 
 	if err := s.otpCodeRepo.Create(ctx, otpRecord); err != nil {
@@ -183,6 +233,95 @@ func (s *RegistrationService) sendOTPToUser(ctx context.Context, userID int64, e
 	return nil
 }
 
+// checkOTPIssueLimits enforces the per-IP and per-email issuance limits and the resend backoff
+func (s *RegistrationService) checkOTPIssueLimits(ctx context.Context, userID int64, otpType, clientIP string) error {
+	if err := s.checkIPOTPLimit(ctx, clientIP); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	recent, err := s.otpCodeRepo.FindRecentByUserID(ctx, userID, otpType, now.Add(-OTPRequestWindow))
+	if err != nil {
+		return fmt.Errorf("failed to check rate limit: %w", err)
+	}
+	if len(recent) == 0 {
+		return nil
+	}
+
+	if len(recent) >= OTPMaxRequestsPerEmail {
+		oldest := recent[len(recent)-1]
+		return &OTPRateLimitError{Err: ErrTooManyOTPRequests, RetryAfter: oldest.CreatedAt.Add(OTPRequestWindow).Sub(now)}
+	}
+
+	// Each code issued in the window doubles the wait before the next one
+	delay := OTPResendBaseDelay << (len(recent) - 1)
+	if wait := recent[0].CreatedAt.Add(delay).Sub(now); wait > 0 {
+		return &OTPRateLimitError{Err: ErrResendTooSoon, RetryAfter: wait}
+	}
+
+	return nil
+}
+
+// checkIPOTPLimit enforces the per-IP issuance limit across all OTP types
+func (s *RegistrationService) checkIPOTPLimit(ctx context.Context, clientIP string) error {
+	if clientIP == "" {
+		return nil
+	}
+
+	now := time.Now()
+	recent, err := s.otpCodeRepo.FindRecentByIP(ctx, clientIP, now.Add(-OTPRequestWindow))
+	if err != nil {
+		return fmt.Errorf("failed to check rate limit: %w", err)
+	}
+	if len(recent) < OTPMaxRequestsPerIP {
+		return nil
+	}
+
+	// Retry once enough of the window's codes have aged out to drop below the limit
+	unblocking := recent[OTPMaxRequestsPerIP-1]
+	return &OTPRateLimitError{Err: ErrTooManyOTPRequests, RetryAfter: unblocking.CreatedAt.Add(OTPRequestWindow).Sub(now)}
+}
+
+// verifyOTPAttempt consumes one attempt on otp and checks the code against it. The code is
+// invalidated once OTPMaxVerifyAttempts attempts have been made.
+func (s *RegistrationService) verifyOTPAttempt(ctx context.Context, otp *auth.OTPCode, email, otpCode string) error {
+	if otp.IsExpired() {
+		return ErrOTPCodeExpired
+	}
+	if otp.IsUsed {
+		return ErrOTPCodeAlreadyUsed
+	}
+	if !otp.CanAttemptVerification(OTPMaxVerifyAttempts) {
+		return ErrTooManyOTPAttempts
+	}
+
+	consumed, err := s.otpCodeRepo.ConsumeAttempt(ctx, otp.ID, OTPMaxVerifyAttempts)
+	if err != nil {
+		return fmt.Errorf("failed to record OTP attempt: %w", err)
+	}
+	if !consumed {
+		// A concurrent attempt used the last try or the code
+		return ErrTooManyOTPAttempts
+	}
+
+	if s.hashOTPCode(email, otpCode) != otp.OTPHash {
+		if otp.Attempts+1 >= OTPMaxVerifyAttempts {
+			return ErrTooManyOTPAttempts
+		}
+		return ErrInvalidOTPCode
+	}
+
+	return nil
+}
+
+// optionalIP returns nil for an empty IP so the column stays NULL
+func optionalIP(ip string) *string {
+	if ip == "" {
+		return nil
+	}
+	return &ip
+}
+
 // VerifyEmailOTP verifies OTP code and marks user as verified
 func (s *RegistrationService) VerifyEmailOTP(ctx context.Context, email, otpCode string) (string, *user.User, error) {
 	// Find user by email
@@ -195,12 +334,12 @@ func (s *RegistrationService) VerifyEmailOTP(ctx context.Context, email, otpCode
 	}
 
 	// Check if already verified
-	if usr.IsVerified {
+	if usr.IsEmailVerified() {
 		return "", nil, ErrUserAlreadyVerified
 	}
 
 	// Find latest OTP for this user
-	latestOTP, err := s.otpCodeRepo.FindByUserIDAndType(ctx, usr.ID, "email_verification")
+	latestOTP, err := s.otpCodeRepo.FindByUserIDAndType(ctx, usr.ID, otpTypeEmailVerification)
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to find OTP: %w", err)
 	}
@@ -208,29 +347,9 @@ func (s *RegistrationService) VerifyEmailOTP(ctx context.Context, email, otpCode
 		return "", nil, ErrOTPCodeNotFound
 	}
 
-	// Check if OTP is expired
-	if latestOTP.IsExpired() {
-		return "", nil, ErrOTPCodeExpired
-	}
-
-	// Check if OTP is already used
-	if latestOTP.IsUsed {
-		return "", nil, ErrOTPCodeAlreadyUsed
-	}
-
-	// Check max attempts
-	if !latestOTP.CanAttemptVerification(OTPMaxVerifyAttempts) {
-		return "", nil, ErrTooManyOTPAttempts
-	}
-
-	// Verify OTP hash
-	inputHash := s.hashOTPCode(email, otpCode)
-	if inputHash != latestOTP.OTPHash {
-		// Increment attempts on failed verification
-		if err := s.otpCodeRepo.IncrementAttempts(ctx, latestOTP.ID); err != nil {
-			return "", nil, fmt.Errorf("failed to increment attempts: %w", err)
-		}
-		return "", nil, ErrInvalidOTPCode
+	// Check expiry, usage and attempts, then the code itself
+	if err := s.verifyOTPAttempt(ctx, latestOTP, email, otpCode); err != nil {
+		return "", nil, err
 	}
 
 	// Mark OTP as used
@@ -238,8 +357,8 @@ func (s *RegistrationService) VerifyEmailOTP(ctx context.Context, email, otpCode
 		return "", nil, fmt.Errorf("failed to mark OTP as used: %w", err)
 	}
 
-	// Update user: set is_verified = true
-	usr.IsVerified = true
+	// Update user: record the verification
+	usr.MarkEmailVerified(time.Now())
 	if err := s.userRepo.Update(ctx, usr); err != nil {
 		return "", nil, fmt.Errorf("failed to update user verification status: %w", err)
 	}
@@ -254,7 +373,7 @@ func (s *RegistrationService) VerifyEmailOTP(ctx context.Context, email, otpCode
 }
 
 // ResendOTP resends OTP to user email
-func (s *RegistrationService) ResendOTP(ctx context.Context, email string) error {
+func (s *RegistrationService) ResendOTP(ctx context.Context, email, clientIP string) error {
 	// Find user by email
 	usr, err := s.userRepo.FindByEmail(ctx, email)
 	if err != nil {
@@ -265,12 +384,12 @@ func (s *RegistrationService) ResendOTP(ctx context.Context, email string) error
 	}
 
 	// Check if already verified
-	if usr.IsVerified {
+	if usr.IsEmailVerified() {
 		return ErrUserAlreadyVerified
 	}
 
 	// Send OTP (rate limiting is handled inside)
-	if err := s.sendOTPToUser(ctx, usr.ID, email); err != nil {
+	if err := s.sendOTPToUser(ctx, usr.ID, email, clientIP); err != nil {
 		return err
 	}
 
@@ -287,39 +406,30 @@ func (s *RegistrationService) CleanupExpiredOTPs(ctx context.Context) error {
 // ===========================================
 
 // RequestPasswordResetOTP sends OTP for password reset
-func (s *RegistrationService) RequestPasswordResetOTP(ctx context.Context, email string) error {
+func (s *RegistrationService) RequestPasswordResetOTP(ctx context.Context, email, clientIP string) error {
 	// Find user by email
 	usr, err := s.userRepo.FindByEmail(ctx, email)
 	if err != nil {
 		return fmt.Errorf("failed to find user: %w", err)
 	}
 
-	// Don't reveal if user doesn't exist (security best practice)
+	// Don't reveal if user doesn't exist (security best practice), but still count the IP
 	if usr == nil {
-		return nil // Silent success
+		return s.checkIPOTPLimit(ctx, clientIP)
 	}
 
 	// Check if user is verified
-	if !usr.IsVerified {
+	if !usr.IsEmailVerified() {
 		return ErrEmailNotVerified
 	}
 
-	// Check rate limiting - max 3 password reset requests per hour
-	oneHourAgo := time.Now().Add(-1 * time.Hour)
-	recentOTPs, err := s.otpCodeRepo.FindAllByUserIDAndType(ctx, usr.ID, "password_reset")
-	if err != nil {
-		return fmt.Errorf("failed to check recent OTPs: %w", err)
-	}
-
-	count := 0
-	for _, otp := range recentOTPs {
-		if otp.CreatedAt.After(oneHourAgo) {
-			count++
-		}
+	if err := s.checkOTPIssueLimits(ctx, usr.ID, otpTypePasswordReset, clientIP); err != nil {
+		return err
 	}
 
-	if count >= 3 {
-		return ErrTooManyOTPRequests
+	recentOTPs, err := s.otpCodeRepo.FindAllByUserIDAndType(ctx, usr.ID, otpTypePasswordReset)
+	if err != nil {
+		return fmt.Errorf("failed to check recent OTPs: %w", err)
 	}
 
 	// Revoke all existing password reset OTPs for this user
@@ -343,10 +453,11 @@ func (s *RegistrationService) RequestPasswordResetOTP(ctx context.Context, email
 	otp := &auth.OTPCode{
 		UserID:    usr.ID,
 		OTPHash:   otpHash,
-		Type:      "password_reset",
-		ExpiredAt: time.Now().Add(5 * time.Minute), // 5 minutes expiry
+		Type:      otpTypePasswordReset,
+		ExpiredAt: time.Now().Add(OTPCodeExpiryMinutes * time.Minute),
 		IsUsed:    false,
 		Attempts:  0,
+		IPAddress: optionalIP(clientIP),
 	}
 
 	if err := s.otpCodeRepo.Create(ctx, otp); err != nil {
@@ -385,43 +496,24 @@ func (s *RegistrationService) ResetPasswordWithOTP(ctx context.Context, email, o
 		return ErrInvalidCredentials
 	}
 
-	// Hash the provided OTP
-	otpHash := s.hashOTPCode(email, otpCode)
-
-	// Find active password reset OTP
-	otps, err := s.otpCodeRepo.FindAllByUserIDAndType(ctx, usr.ID, "password_reset")
+	// Requesting a new code revokes older ones, so only the latest can be valid
+	latestOTP, err := s.otpCodeRepo.FindByUserIDAndType(ctx, usr.ID, otpTypePasswordReset)
 	if err != nil {
 		return fmt.Errorf("failed to find OTP: %w", err)
 	}
-
-	var validOTP *auth.OTPCode
-	for _, otp := range otps {
-		if !otp.IsUsed && otp.OTPHash == otpHash {
-			validOTP = otp
-			break
-		}
-	}
-
-	if validOTP == nil {
+	if latestOTP == nil {
 		return ErrInvalidOTPCode
 	}
 
-	// Check if OTP is expired
-	if time.Now().After(validOTP.ExpiredAt) {
-		return ErrOTPCodeExpired
-	}
-
-	// Check attempts (max 5)
-	if validOTP.Attempts >= 5 {
-		return ErrTooManyOTPAttempts
+	if err := s.verifyOTPAttempt(ctx, latestOTP, email, otpCode); err != nil {
+		if errors.Is(err, ErrOTPCodeAlreadyUsed) {
+			return ErrInvalidOTPCode
+		}
+		return err
 	}
 
 	// Mark OTP as used
-	validOTP.IsUsed = true
-	now := time.Now()
-	validOTP.UsedAt = &now
-
-	if err := s.otpCodeRepo.Update(ctx, validOTP); err != nil {
+	if err := s.otpCodeRepo.MarkAsUsed(ctx, latestOTP.ID); err != nil {
 		return fmt.Errorf("failed to update OTP: %w", err)
 	}
 
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"keerja-backend/internal/domain/user"
+)
+
+// maxResumeParseSize is the largest resume that is parsed; bigger uploads are stored without suggestions
+const maxResumeParseSize = 5 * 1024 * 1024
+
+// resumeParseTimeout bounds text extraction and skill matching for one resume
+const resumeParseTimeout = 5 * time.Second
+
+// maxParsedSkills caps the number of skill suggestions returned for one resume
+const maxParsedSkills = 30
+
+var (
+	resumeEmailPattern    = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	resumePhonePattern    = regexp.MustCompile(`(?:\+62|62|0)[\s\-.]?8\d{1,3}[\s\-.]?\d{3,4}[\s\-.]?\d{2,5}`)
+	resumeLinkedInPattern = regexp.MustCompile(`(?i)(?:https?://)?(?:[a-z]{2,3}\.)?linkedin\.com/in/[A-Za-z0-9\-_%]+`)
+)
+
+// resumeDegreeKeywords maps lowercase degree keywords to UserEducation degree levels,
+// ordered from the highest level down
+var resumeDegreeKeywords = []struct {
+	level    string
+	keywords []string
+}{
+	{"S3", []string{"s3", "ph.d", "phd", "doctor of", "doktor"}},
+	{"S2", []string{"s2", "master of", "magister", "m.sc", "mba", "m.kom", "m.t."}},
+	{"S1", []string{"s1", "bachelor", "sarjana", "b.sc", "s.kom", "s.t.", "s.e.", "undergraduate"}},
+	{"D3", []string{"d3", "diploma", "ahli madya", "a.md"}},
+	{"D2", []string{"d2"}},
+	{"D1", []string{"d1"}},
+	{"SMA", []string{"sma", "smk", "high school", "senior high"}},
+}
+
+// ParseResume extracts profile suggestions from a PDF or DOCX resume. It is best-effort:
+// callers should treat an error as "no suggestions" rather than a failed upload.
+func (s *userService) ParseResume(ctx context.Context, file *multipart.FileHeader) (*user.ParsedResume, error) {
+	if file.Size > maxResumeParseSize {
+		return nil, fmt.Errorf("resume larger than %d bytes is not parsed", maxResumeParseSize)
+	}
+
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	if ext != ".pdf" && ext != ".docx" {
+		return nil, fmt.Errorf("resume type %s is not supported for parsing", ext)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, resumeParseTimeout)
+	defer cancel()
+
+	text, err := extractResumeText(ctx, file, ext)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := parseResumeText(text)
+
+	if s.skillsMasterRepo != nil {
+		skills, err := s.skillsMasterRepo.ListActive(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load skills: %w", err)
+		}
+		lower := strings.ToLower(text)
+		for _, skill := range skills {
+			if len(parsed.Skills) >= maxParsedSkills {
+				break
+			}
+			names := append([]string{skill.Name}, skill.Aliases...)
+			for _, name := range names {
+				if containsWord(lower, strings.ToLower(name)) {
+					parsed.Skills = append(parsed.Skills, user.ParsedResumeSkill{SkillID: skill.ID, Name: skill.Name})
+					break
+				}
+			}
+		}
+	}
+
+	return parsed, nil
+}
+
+// extractResumeText reads the file and extracts its text, giving up when ctx expires
+func extractResumeText(ctx context.Context, file *multipart.FileHeader, ext string) (string, error) {
+	type result struct {
+		text string
+		err  error
+	}
+	done := make(chan result, 1)
+
+	go func() {
+		src, err := file.Open()
+		if err != nil {
+			done <- result{err: fmt.Errorf("failed to open resume: %w", err)}
+			return
+		}
+		defer src.Close()
+
+		data, err := io.ReadAll(io.LimitReader(src, maxResumeParseSize))
+		if err != nil {
+			done <- result{err: fmt.Errorf("failed to read resume: %w", err)}
+			return
+		}
+
+		var text string
+		if ext == ".pdf" {
+			text, err = extractPDFText(data)
+		} else {
+			text, err = extractDOCXText(data)
+		}
+		done <- result{text: text, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return "", errors.New("resume parsing timed out")
+	case r := <-done:
+		return r.text, r.err
+	}
+}
+
+// parseResumeText applies the contact and degree heuristics to resume plaintext
+func parseResumeText(text string) *user.ParsedResume {
+	parsed := &user.ParsedResume{}
+
+	if email := resumeEmailPattern.FindString(text); email != "" {
+		parsed.Email = &email
+	}
+	if phone := resumePhonePattern.FindString(text); phone != "" {
+		phone = strings.NewReplacer(" ", "", "-", "", ".", "").Replace(phone)
+		parsed.Phone = &phone
+	}
+	if linkedIn := resumeLinkedInPattern.FindString(text); linkedIn != "" {
+		if !strings.HasPrefix(strings.ToLower(linkedIn), "http") {
+			linkedIn = "https://" + linkedIn
+		}
+		parsed.LinkedInURL = &linkedIn
+	}
+
+	lower := strings.ToLower(text)
+	for _, degree := range resumeDegreeKeywords {
+		for _, keyword := range degree.keywords {
+			if containsWord(lower, keyword) {
+				parsed.DegreeLevels = append(parsed.DegreeLevels, degree.level)
+				break
+			}
+		}
+	}
+
+	return parsed
+}
+
+// containsWord reports whether word occurs in text without being part of a longer
+// alphanumeric word. Both arguments are expected in lowercase.
+func containsWord(text, word string) bool {
+	if word == "" {
+		return false
+	}
+	for offset := 0; offset < len(text); {
+		i := strings.Index(text[offset:], word)
+		if i < 0 {
+			return false
+		}
+		start := offset + i
+		end := start + len(word)
+		if (start == 0 || !isWordChar(text[start-1])) && (end == len(text) || !isWordChar(text[end])) {
+			return true
+		}
+		offset = start + 1
+	}
+	return false
+}
+
+// isWordChar reports whether c is an ASCII letter or digit
+func isWordChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
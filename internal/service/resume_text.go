@@ -0,0 +1,262 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxResumeTextSize caps the plaintext kept from a resume; anything past it is ignored
+const maxResumeTextSize = 512 * 1024
+
+// maxResumeStreamSize caps the decompressed size of a single PDF stream or DOCX part
+const maxResumeStreamSize = 8 * 1024 * 1024
+
+// extractDOCXText returns the paragraph text of a DOCX document
+func extractDOCXText(data []byte) (string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("invalid docx archive: %w", err)
+	}
+
+	var part *zip.File
+	for _, f := range zr.File {
+		if f.Name == "word/document.xml" {
+			part = f
+			break
+		}
+	}
+	if part == nil {
+		return "", errors.New("docx has no word/document.xml")
+	}
+
+	rc, err := part.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	var text strings.Builder
+	decoder := xml.NewDecoder(io.LimitReader(rc, maxResumeStreamSize))
+	inText := false
+	for text.Len() < maxResumeTextSize {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("invalid docx xml: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				text.WriteByte('\t')
+			case "br", "cr":
+				text.WriteByte('\n')
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				text.WriteByte('\n')
+			}
+		case xml.CharData:
+			if inText {
+				text.Write(t)
+			}
+		}
+	}
+
+	return text.String(), nil
+}
+
+// extractPDFText returns the text drawn by the content streams of a PDF. It is a
+// best-effort extractor: it understands uncompressed and FlateDecode streams and
+// literal strings shown with Tj/TJ, which covers resumes exported by common editors,
+// but not scanned pages or fonts with custom encodings.
+func extractPDFText(data []byte) (string, error) {
+	if !bytes.HasPrefix(data, []byte("%PDF")) {
+		return "", errors.New("not a pdf document")
+	}
+
+	var text strings.Builder
+	rest := data
+	for text.Len() < maxResumeTextSize {
+		start := bytes.Index(rest, []byte("stream"))
+		if start < 0 {
+			break
+		}
+		dict := rest[:start]
+		if i := bytes.LastIndex(dict, []byte("obj")); i >= 0 {
+			dict = dict[i:]
+		}
+
+		body := rest[start+len("stream"):]
+		body = bytes.TrimLeft(body, "\r\n")
+		end := bytes.Index(body, []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		stream := body[:end]
+		rest = body[end+len("endstream"):]
+
+		// Only content streams carry text; skip images, fonts and other binary data
+		if bytes.Contains(dict, []byte("/Subtype")) || bytes.Contains(dict, []byte("/Length1")) {
+			continue
+		}
+		if bytes.Contains(dict, []byte("/FlateDecode")) {
+			inflated, err := inflate(stream)
+			if err != nil {
+				continue
+			}
+			stream = inflated
+		} else if bytes.Contains(dict, []byte("/Filter")) {
+			continue
+		}
+
+		text.WriteString(pdfContentText(stream))
+	}
+
+	if strings.TrimSpace(text.String()) == "" {
+		return "", errors.New("no extractable text in pdf")
+	}
+	return text.String(), nil
+}
+
+// inflate decompresses a FlateDecode stream, bounded by maxResumeStreamSize
+func inflate(stream []byte) ([]byte, error) {
+	zr, err := zlib.NewReader(bytes.NewReader(stream))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	out, err := io.ReadAll(io.LimitReader(zr, maxResumeStreamSize))
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	return out, nil
+}
+
+// pdfContentText collects the strings shown by text operators in a content stream,
+// starting a new line on text positioning operators
+func pdfContentText(content []byte) string {
+	var out, pending strings.Builder
+	inArray := false
+
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case c == '(':
+			str, next := readPDFString(content, i)
+			pending.WriteString(str)
+			i = next
+		case c == '[':
+			inArray = true
+			i++
+		case c == ']':
+			inArray = false
+			i++
+		case c == '%':
+			for i < len(content) && content[i] != '\n' && content[i] != '\r' {
+				i++
+			}
+		case isPDFSpace(c) || c == '<' || c == '>' || c == '/' || c == '{' || c == '}':
+			i++
+		default:
+			j := i
+			for j < len(content) && !isPDFSpace(content[j]) && !strings.ContainsRune("()[]<>/%{}", rune(content[j])) {
+				j++
+			}
+			token := string(content[i:j])
+			i = j
+
+			switch token {
+			case "Tj", "TJ":
+				out.WriteString(pending.String())
+				pending.Reset()
+			case "'", "\"":
+				out.WriteByte('\n')
+				out.WriteString(pending.String())
+				pending.Reset()
+			case "Td", "TD", "T*", "Tm", "ET":
+				out.WriteByte('\n')
+			default:
+				// A wide negative kerning inside a TJ array is how editors draw a space
+				if inArray && strings.HasPrefix(token, "-") && len(token) > 3 {
+					pending.WriteByte(' ')
+				}
+			}
+		}
+	}
+
+	return out.String()
+}
+
+// readPDFString reads the literal string starting at content[start] == '(' and
+// returns it with the index just past its closing parenthesis
+func readPDFString(content []byte, start int) (string, int) {
+	var str strings.Builder
+	depth := 0
+	i := start
+	for i < len(content) {
+		c := content[i]
+		switch c {
+		case '(':
+			if depth > 0 {
+				str.WriteByte(c)
+			}
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return str.String(), i + 1
+			}
+			str.WriteByte(c)
+		case '\\':
+			i++
+			if i >= len(content) {
+				break
+			}
+			switch e := content[i]; e {
+			case 'n', 'r':
+				str.WriteByte('\n')
+			case 't':
+				str.WriteByte('\t')
+			case '\r', '\n':
+				// Line continuation
+			default:
+				if e >= '0' && e <= '7' {
+					code := 0
+					for k := 0; k < 3 && i < len(content) && content[i] >= '0' && content[i] <= '7'; k++ {
+						code = code*8 + int(content[i]-'0')
+						i++
+					}
+					i--
+					str.WriteByte(byte(code))
+				} else {
+					str.WriteByte(e)
+				}
+			}
+		default:
+			str.WriteByte(c)
+		}
+		i++
+	}
+	return str.String(), i
+}
+
+// isPDFSpace reports whether c is PDF whitespace
+func isPDFSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"keerja-backend/internal/domain/master"
@@ -289,6 +290,67 @@ func (s *skillsMasterService) SearchByAlias(ctx context.Context, alias string) (
 	return nil, fmt.Errorf("not implemented")
 }
 
+// SuggestSkills returns active skills for autocomplete, matching names and aliases by
+// prefix or similarity so a typo still finds the skill
+func (s *skillsMasterService) SuggestSkills(ctx context.Context, query string, limit int) ([]master.SkillResponse, error) {
+	query = master.NormalizeSkillName(query)
+	if query == "" {
+		return []master.SkillResponse{}, nil
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 50 {
+		limit = 50
+	}
+
+	skills, err := s.repo.SuggestSkills(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest skills: %w", err)
+	}
+
+	responses := make([]master.SkillResponse, len(skills))
+	for i := range skills {
+		responses[i] = s.toSkillResponse(&skills[i])
+	}
+	return responses, nil
+}
+
+// MergeSkill merges a duplicate skill into the skill that replaces it. References to the
+// source move to the target and the source's names become aliases of the target.
+func (s *skillsMasterService) MergeSkill(ctx context.Context, sourceID, targetID int64) (*master.SkillMergeResult, error) {
+	if sourceID == targetID {
+		return nil, master.ErrSkillMergeIntoSelf
+	}
+
+	source, err := s.repo.FindByID(ctx, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skill: %w", err)
+	}
+	if source == nil {
+		return nil, master.ErrSkillNotFound.WithField("source_id", "skill not found")
+	}
+	target, err := s.repo.FindByID(ctx, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skill: %w", err)
+	}
+	if target == nil {
+		return nil, master.ErrSkillNotFound.WithField("target_id", "skill not found")
+	}
+	if !target.IsActive {
+		return nil, master.ErrSkillMergeTargetInactive
+	}
+
+	result, err := s.repo.MergeSkill(ctx, sourceID, targetID)
+	if err != nil {
+		if errors.Is(err, master.ErrSkillNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to merge skill: %w", err)
+	}
+	return result, nil
+}
+
 func (s *skillsMasterService) ActivateSkill(ctx context.Context, id int64) error {
 	return fmt.Errorf("not implemented")
 }
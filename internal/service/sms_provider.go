@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"keerja-backend/internal/config"
+)
+
+// SMS providers
+const (
+	SMSProviderLog = "log"
+)
+
+// SMSProvider sends text messages to E.164 phone numbers
+type SMSProvider interface {
+	SendSMS(ctx context.Context, to, message string) error
+}
+
+// SMSConfig holds settings for the SMS provider
+type SMSConfig struct {
+	Provider string // "log"; empty selects the log provider
+}
+
+// NewSMSProvider creates the SMS provider selected by cfg.Provider. Real gateways are added
+// here as further providers.
+func NewSMSProvider(cfg SMSConfig) (SMSProvider, error) {
+	switch cfg.Provider {
+	case "", SMSProviderLog:
+		return NewLogSMSProvider(), nil
+	default:
+		return nil, fmt.Errorf("unsupported SMS provider %q", cfg.Provider)
+	}
+}
+
+// logSMSProvider writes messages to the log instead of sending them
+type logSMSProvider struct{}
+
+// NewLogSMSProvider creates an SMS provider for development that only logs messages,
+// verification codes included
+func NewLogSMSProvider() SMSProvider {
+	return logSMSProvider{}
+}
+
+// SendSMS logs the message
+func (logSMSProvider) SendSMS(ctx context.Context, to, message string) error {
+	config.WithContext(ctx).WithField("to", to).Infof("SMS (not sent): %s", message)
+	return nil
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"keerja-backend/internal/config"
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/master"
+	"keerja-backend/internal/domain/talentpool"
+	"keerja-backend/internal/domain/user"
+)
+
+// talentPoolService implements talentpool.TalentPoolService
+type talentPoolService struct {
+	repo       talentpool.TalentPoolRepository
+	appRepo    application.ApplicationRepository
+	jobRepo    job.JobRepository
+	jobService job.JobService
+	userRepo   user.UserRepository
+}
+
+// NewTalentPoolService creates a new talent pool service. jobService scores pool matches.
+func NewTalentPoolService(
+	repo talentpool.TalentPoolRepository,
+	appRepo application.ApplicationRepository,
+	jobRepo job.JobRepository,
+	jobService job.JobService,
+	userRepo user.UserRepository,
+) talentpool.TalentPoolService {
+	return &talentPoolService{
+		repo:       repo,
+		appRepo:    appRepo,
+		jobRepo:    jobRepo,
+		jobService: jobService,
+		userRepo:   userRepo,
+	}
+}
+
+// CreatePool creates a pool for the company
+func (s *talentPoolService) CreatePool(ctx context.Context, companyID, userID int64, input talentpool.CreatePoolInput) (*talentpool.TalentPool, error) {
+	p := &talentpool.TalentPool{
+		CompanyID:   companyID,
+		Name:        strings.Join(strings.Fields(input.Name), " "),
+		Description: strings.TrimSpace(input.Description),
+		CreatedBy:   &userID,
+	}
+	if err := s.repo.CreatePool(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ListPools lists the company's pools with their member counts
+func (s *talentPoolService) ListPools(ctx context.Context, companyID int64) ([]talentpool.TalentPool, error) {
+	pools, err := s.repo.ListPoolsByCompany(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list talent pools: %w", err)
+	}
+	return pools, nil
+}
+
+// DeletePool deletes one of the company's pools with its members
+func (s *talentPoolService) DeletePool(ctx context.Context, companyID, poolID int64) error {
+	if _, err := s.findPool(ctx, companyID, poolID); err != nil {
+		return err
+	}
+	if err := s.repo.DeletePool(ctx, poolID); err != nil {
+		return fmt.Errorf("failed to delete talent pool: %w", err)
+	}
+	return nil
+}
+
+// AddMember adds the applicant of one of the company's applications to a pool. The candidate
+// must not have opted out of talent pools nor made their profile private.
+func (s *talentPoolService) AddMember(ctx context.Context, companyID, poolID, userID int64, input talentpool.AddMemberInput) (*talentpool.TalentPoolMember, bool, error) {
+	if _, err := s.findPool(ctx, companyID, poolID); err != nil {
+		return nil, false, err
+	}
+
+	app, err := s.appRepo.FindByID(ctx, input.ApplicationID)
+	if err != nil {
+		return nil, false, applicationLookupError(err)
+	}
+	if app.CompanyID == nil || *app.CompanyID != companyID {
+		return nil, false, talentpool.ErrApplicationNotInCompany
+	}
+
+	pref, err := s.userRepo.FindPreferenceByUserID(ctx, app.UserID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get candidate preferences: %w", err)
+	}
+	if !pref.IsDiscoverable() {
+		return nil, false, talentpool.ErrCandidateOptedOut
+	}
+
+	tags, err := normalizeTalentPoolTags(input.Tags)
+	if err != nil {
+		return nil, false, err
+	}
+
+	m := &talentpool.TalentPoolMember{
+		PoolID:              poolID,
+		UserID:              app.UserID,
+		SourceApplicationID: &app.ID,
+		Tags:                tags,
+		Notes:               strings.TrimSpace(input.Notes),
+		AddedBy:             &userID,
+	}
+	added, err := s.repo.AddMember(ctx, m)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to add talent pool member: %w", err)
+	}
+	return m, added, nil
+}
+
+// ListMembers lists the members of one of the company's pools. Tags and skills are matched
+// after normalizing case and whitespace.
+func (s *talentPoolService) ListMembers(ctx context.Context, filter talentpool.MemberFilter, page, limit int) ([]talentpool.TalentPoolMember, int64, error) {
+	if _, err := s.findPool(ctx, filter.CompanyID, filter.PoolID); err != nil {
+		return nil, 0, err
+	}
+
+	filter.Tags = normalizeNames(filter.Tags)
+	filter.Skills = normalizeNames(filter.Skills)
+	filter.Query = strings.TrimSpace(filter.Query)
+
+	members, total, err := s.repo.ListMembers(ctx, filter, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list talent pool members: %w", err)
+	}
+	return members, total, nil
+}
+
+// RemoveMember removes a member from one of the company's pools
+func (s *talentPoolService) RemoveMember(ctx context.Context, companyID, poolID, memberID int64) error {
+	if _, err := s.findPool(ctx, companyID, poolID); err != nil {
+		return err
+	}
+
+	m, err := s.repo.FindMemberByID(ctx, memberID)
+	if err != nil {
+		return fmt.Errorf("failed to get talent pool member: %w", err)
+	}
+	if m == nil || m.PoolID != poolID {
+		return talentpool.ErrMemberNotFound
+	}
+
+	if err := s.repo.RemoveMember(ctx, memberID); err != nil {
+		return fmt.Errorf("failed to remove talent pool member: %w", err)
+	}
+	return nil
+}
+
+// MatchJob scores the pool candidates who have not applied to the job yet against it with
+// CalculateMatchScore and returns the best limit matches. At most
+// talentpool.MaxMembersScored candidates, the most recently added, are scored per request.
+func (s *talentPoolService) MatchJob(ctx context.Context, companyID, jobID, poolID int64, limit int) ([]talentpool.PoolMatch, error) {
+	j, err := s.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, jobLookupError(err)
+	}
+	if j.CompanyID != companyID {
+		return nil, job.ErrJobNotFound
+	}
+	if poolID != 0 {
+		if _, err := s.findPool(ctx, companyID, poolID); err != nil {
+			return nil, err
+		}
+	}
+
+	if limit <= 0 {
+		limit = talentpool.DefaultMatchLimit
+	}
+	limit = min(limit, talentpool.MaxMatchLimit)
+
+	members, err := s.repo.ListMatchCandidates(ctx, companyID, poolID, jobID, talentpool.MaxMembersScored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list talent pool candidates: %w", err)
+	}
+
+	matches := make([]talentpool.PoolMatch, 0, len(members))
+	for _, m := range members {
+		score, err := s.jobService.CalculateMatchScore(ctx, jobID, m.UserID)
+		if err != nil {
+			// One candidate failing to score does not hide the others
+			config.WithContext(ctx).WithError(err).Warnf("failed to score talent pool member %d", m.ID)
+			continue
+		}
+		matches = append(matches, talentpool.PoolMatch{Member: m, Score: *score})
+	}
+
+	sort.SliceStable(matches, func(i, k int) bool {
+		return matches[i].Score.OverallScore > matches[k].Score.OverallScore
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+// findPool returns one of the company's pools
+func (s *talentPoolService) findPool(ctx context.Context, companyID, poolID int64) (*talentpool.TalentPool, error) {
+	p, err := s.repo.FindPoolByID(ctx, poolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get talent pool: %w", err)
+	}
+	if p == nil || p.CompanyID != companyID {
+		return nil, talentpool.ErrPoolNotFound
+	}
+	return p, nil
+}
+
+// normalizeTalentPoolTags normalizes and dedupes a member's tags and checks their limits
+func normalizeTalentPoolTags(tags []string) ([]string, error) {
+	normalized := normalizeNames(tags)
+	if len(normalized) > talentpool.MaxTagsPerMember {
+		return nil, talentpool.ErrInvalidTags
+	}
+	for _, tag := range normalized {
+		if len([]rune(tag)) > talentpool.MaxTagLength {
+			return nil, talentpool.ErrInvalidTags
+		}
+	}
+	return normalized, nil
+}
+
+// normalizeNames lowercases names, collapses their whitespace and drops blanks and duplicates
+func normalizeNames(names []string) []string {
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		if name = master.NormalizeSkillName(name); name != "" && !slices.Contains(normalized, name) {
+			normalized = append(normalized, name)
+		}
+	}
+	return normalized
+}
@@ -1,50 +1,254 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"log"
+	"mime"
 	"mime/multipart"
+	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+
+	"keerja-backend/internal/config"
+	"keerja-backend/internal/domain/upload"
 )
 
 // UploadService defines the interface for file upload operations
 type UploadService interface {
 	UploadFile(ctx context.Context, file *multipart.FileHeader, directory string) (string, error)
+	// UploadImage stores an image along with the resized variants of spec
+	UploadImage(ctx context.Context, file *multipart.FileHeader, directory string, spec ImageSpec) (*StoredImage, error)
 	DeleteFile(ctx context.Context, fileURL string) error
 	GetFileURL(ctx context.Context, path string) string
 	ValidateFile(file *multipart.FileHeader, allowedTypes []string, maxSize int64) error
 	CalculateChecksum(file *multipart.FileHeader) (string, error)
+	// ResolveFileURL returns the public URL of a stored file reference, which may be a
+	// relative path or a URL generated under an earlier base URL
+	ResolveFileURL(stored string) string
+	// Limits returns the configured upload size limits
+	Limits() UploadLimits
+	// HealthCheck writes and deletes a small probe file to verify the storage is writable
+	HealthCheck(ctx context.Context) error
+	// OpenFile opens a stored file for reading, for serving private files through the
+	// signed download route
+	OpenFile(ctx context.Context, stored string) (io.ReadCloser, error)
+	// CopyFile stores a copy of a stored file under directory and returns the copy's URL.
+	// Files missing from the storage, or not kept in it, give ErrStoredFileNotFound.
+	CopyFile(ctx context.Context, stored, directory string) (string, error)
+}
+
+// Errors returned by ValidateFile, wrapped with the limit that was exceeded
+var (
+	ErrFileTooLarge       = errors.New("file size exceeds maximum allowed size")
+	ErrFileTypeNotAllowed = errors.New("file type is not allowed")
+)
+
+// ErrInvalidFilePath is returned for stored file paths that would leave the upload root
+var ErrInvalidFilePath = errors.New("invalid file path")
+
+// ErrStoredFileNotFound is returned by OpenFile for files missing from the storage
+var ErrStoredFileNotFound = errors.New("stored file not found")
+
+// PublicUploadDirectories are the image folders the public /uploads route serves: avatars and
+// cover photos of job seekers, and company logos and banners. A segment may be a path.Match
+// pattern. Every other folder, including any document folder added later, stays private.
+var PublicUploadDirectories = []string{
+	"avatars",
+	"covers",
+	"company/logos",
+	"company/banners",
+	"companies/*/logo",
+	"companies/*/banner",
+}
+
+// IsPublicUpload reports whether a path relative to the upload root lies in one of the
+// PublicUploadDirectories
+func IsPublicUpload(relativePath string) bool {
+	cleaned := strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(relativePath)), "/")
+	segments := strings.Split(cleaned, "/")
+	for _, dir := range PublicUploadDirectories {
+		pattern := strings.Split(dir, "/")
+		if len(segments) <= len(pattern) {
+			continue
+		}
+		if matchSegments(pattern, segments[:len(pattern)]) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments reports whether each path segment matches the pattern segment at its position
+func matchSegments(pattern, segments []string) bool {
+	for i := range pattern {
+		if ok, err := path.Match(pattern[i], segments[i]); err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// PrivateUploadDirectories hold documents that are only handed out through signed download
+// links: company legal and verification documents, job seekers' CVs and ID documents, and the
+// documents and snapshots of applications.
+var PrivateUploadDirectories = []string{
+	"company/documents",
+	"documents", // Job seekers' documents, and company NPWP, NIB and additional documents below it
+	applicationUploadRoot,
+}
+
+// IsPrivateUpload reports whether a path relative to the upload root lies in one of the
+// PrivateUploadDirectories
+func IsPrivateUpload(relativePath string) bool {
+	cleaned := strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(relativePath)), "/")
+	for _, dir := range PrivateUploadDirectories {
+		if cleaned == dir || strings.HasPrefix(cleaned, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// UploadLimits holds the maximum upload sizes in bytes per kind of file
+type UploadLimits struct {
+	AvatarSize   int64
+	CoverSize    int64
+	DocumentSize int64
+}
+
+// DefaultUploadLimits are used for limits left zero in UploadServiceConfig
+var DefaultUploadLimits = UploadLimits{
+	AvatarSize:   MaxAvatarSize,
+	CoverSize:    MaxCoverSize,
+	DocumentSize: MaxDocumentSize,
+}
+
+// StoredImage is an uploaded image and the URLs of its variants
+type StoredImage struct {
+	URL      string
+	Variants upload.ImageVariants
 }
 
+// legacyLocalBaseURL prefixed the URLs of local uploads before the base URL was configurable
+const legacyLocalBaseURL = "http://localhost:8080"
+
+// localUploadRoute is the route prefix locally stored files are served from
+const localUploadRoute = "/uploads/"
+
+// healthCheckDirectory holds the short-lived probe files written by HealthCheck
+const healthCheckDirectory = ".healthcheck"
+
 // uploadService implements file upload functionality
 type uploadService struct {
 	storageProvider string
 	uploadPath      string
 	baseURL         string
+	objectClient    ObjectStorageClient
+	maxRetries      int
+	retryBackoff    time.Duration
+	limits          UploadLimits
+	imageProcessor  ImageProcessor
 }
 
 // UploadServiceConfig holds configuration for upload service
 type UploadServiceConfig struct {
 	StorageProvider string // "local", "s3", "cloudinary"
 	UploadPath      string
-	BaseURL         string // Base URL for serving files
+	BaseURL         string // Base URL for serving files (public bucket URL for s3)
+
+	// Object storage settings (used when StorageProvider is "s3")
+	ObjectClient ObjectStorageClient
+	MaxRetries   int           // attempts on transient errors, defaults to 3
+	RetryBackoff time.Duration // initial backoff, doubled per attempt, defaults to 200ms
+
+	// Limits overrides DefaultUploadLimits; zero fields keep the default
+	Limits UploadLimits
+
+	// ImageProcessor renders image variants, defaults to NewImageProcessor
+	ImageProcessor ImageProcessor
 }
 
 // NewUploadService creates a new upload service instance
 func NewUploadService(config UploadServiceConfig) UploadService {
+	maxRetries := config.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = 3
+	}
+	retryBackoff := config.RetryBackoff
+	if retryBackoff <= 0 {
+		retryBackoff = 200 * time.Millisecond
+	}
+
+	limits := DefaultUploadLimits
+	if config.Limits.AvatarSize > 0 {
+		limits.AvatarSize = config.Limits.AvatarSize
+	}
+	if config.Limits.CoverSize > 0 {
+		limits.CoverSize = config.Limits.CoverSize
+	}
+	if config.Limits.DocumentSize > 0 {
+		limits.DocumentSize = config.Limits.DocumentSize
+	}
+	imageProcessor := config.ImageProcessor
+	if imageProcessor == nil {
+		imageProcessor = NewImageProcessor()
+	}
+
 	return &uploadService{
 		storageProvider: config.StorageProvider,
 		uploadPath:      config.UploadPath,
-		baseURL:         config.BaseURL,
+		baseURL:         strings.TrimSuffix(config.BaseURL, "/"),
+		objectClient:    config.ObjectClient,
+		maxRetries:      maxRetries,
+		retryBackoff:    retryBackoff,
+		limits:          limits,
+		imageProcessor:  imageProcessor,
+	}
+}
+
+// NewUploadServiceFromConfig creates the upload service for the configured storage provider
+func NewUploadServiceFromConfig(cfg *config.Config) (UploadService, error) {
+	uploadConfig := UploadServiceConfig{
+		StorageProvider: cfg.StorageProvider,
+		UploadPath:      cfg.UploadPath,
+		BaseURL:         cfg.GetUploadBaseURL(),
+		Limits: UploadLimits{
+			AvatarSize:   int64(cfg.UploadMaxAvatarMB) * 1024 * 1024,
+			CoverSize:    int64(cfg.UploadMaxCoverMB) * 1024 * 1024,
+			DocumentSize: int64(cfg.UploadMaxDocumentMB) * 1024 * 1024,
+		},
+	}
+	if cfg.StorageProvider == "s3" {
+		objectClient, err := NewS3ObjectClient(S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.AWSRegion,
+			Bucket:    cfg.AWSBucket,
+			AccessKey: cfg.AWSAccessKey,
+			SecretKey: cfg.AWSSecretKey,
+			UseSSL:    cfg.S3UseSSL,
+		})
+		if err != nil {
+			return nil, err
+		}
+		uploadConfig.ObjectClient = objectClient
 	}
+	return NewUploadService(uploadConfig), nil
+}
+
+// Limits returns the configured upload size limits
+func (s *uploadService) Limits() UploadLimits {
+	return s.limits
 }
 
 // UploadFile uploads a file to the configured storage
@@ -55,8 +259,7 @@ func (s *uploadService) UploadFile(ctx context.Context, file *multipart.FileHead
 	case "local":
 		return s.uploadToLocal(ctx, file, directory)
 	case "s3":
-		// TODO: Implement S3 upload
-		return "", fmt.Errorf("S3 storage not yet implemented")
+		return s.uploadToS3(ctx, file, directory)
 	case "cloudinary":
 		// TODO: Implement Cloudinary upload
 		return "", fmt.Errorf("Cloudinary storage not yet implemented")
@@ -68,8 +271,7 @@ func (s *uploadService) UploadFile(ctx context.Context, file *multipart.FileHead
 // uploadToLocal uploads file to local filesystem
 func (s *uploadService) uploadToLocal(ctx context.Context, file *multipart.FileHeader, directory string) (string, error) {
 	// Create unique filename
-	ext := filepath.Ext(file.Filename)
-	filename := fmt.Sprintf("%s_%s%s", uuid.New().String(), time.Now().Format("20060102150405"), ext)
+	filename := uniqueFileBase() + filepath.Ext(file.Filename)
 
 	// Create full path
 	fullPath := filepath.Join(s.uploadPath, directory)
@@ -106,6 +308,114 @@ func (s *uploadService) uploadToLocal(ctx context.Context, file *multipart.FileH
 	return s.GetFileURL(ctx, relativePath), nil
 }
 
+// uniqueFileBase returns a new file name without extension, unique across uploads
+func uniqueFileBase() string {
+	return fmt.Sprintf("%s_%s", uuid.New().String(), time.Now().Format("20060102150405"))
+}
+
+// UploadImage stores an image along with the resized variants of spec, named after the
+// original with the variant as suffix (e.g. <name>_200.webp and <name>_200.jpg). Images
+// below the minimum dimensions of spec are rejected with ErrImageTooSmall; images that
+// cannot be processed are stored as uploaded, without variants.
+func (s *uploadService) UploadImage(ctx context.Context, file *multipart.FileHeader, directory string, spec ImageSpec) (*StoredImage, error) {
+	data, err := readUploadedFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	processed, err := s.imageProcessor.Process(data, spec)
+	if errors.Is(err, ErrImageTooSmall) {
+		return nil, err
+	}
+	if err != nil {
+		log.Printf("[WARN] storing %s without image variants: %v", file.Filename, err)
+		fileURL, err := s.UploadFile(ctx, file, directory)
+		if err != nil {
+			return nil, err
+		}
+		return &StoredImage{URL: fileURL}, nil
+	}
+
+	base := path.Join(filepath.ToSlash(directory), uniqueFileBase())
+	originalURL, err := s.uploadBytes(ctx, base+processed.Original.Ext, processed.Original.Data, processed.Original.ContentType)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := &StoredImage{URL: originalURL, Variants: make(upload.ImageVariants, len(processed.Variants))}
+	for _, variant := range processed.Variants {
+		variantURL, err := s.uploadBytes(ctx, base+"_"+variant.Name+variant.Ext, variant.Data, variant.ContentType)
+		if err != nil {
+			for _, url := range append(stored.Variants.URLs(), originalURL) {
+				_ = s.DeleteFile(ctx, url)
+			}
+			return nil, err
+		}
+		stored.Variants[ImageVariantKey(variant.Name, variant.Format)] = variantURL
+	}
+
+	return stored, nil
+}
+
+// deleteImageFiles deletes an image stored by UploadImage along with its variants. Errors are
+// ignored, as a leftover file does no harm to the record that dropped it.
+func deleteImageFiles(ctx context.Context, uploads UploadService, imageURL *string, variants upload.ImageVariants) {
+	if imageURL != nil && *imageURL != "" {
+		_ = uploads.DeleteFile(ctx, *imageURL)
+	}
+	for _, variantURL := range variants.URLs() {
+		_ = uploads.DeleteFile(ctx, variantURL)
+	}
+}
+
+// readUploadedFile reads the whole content of an uploaded file
+func readUploadedFile(file *multipart.FileHeader) ([]byte, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+	return data, nil
+}
+
+// uploadBytes stores file data held in memory under the given relative path
+func (s *uploadService) uploadBytes(ctx context.Context, relativePath string, data []byte, contentType string) (string, error) {
+	switch s.storageProvider {
+	case "local":
+		filePath, err := LocalUploadPath(s.uploadPath, relativePath)
+		if err != nil {
+			return "", err
+		}
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			return "", fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(filePath, data, 0644); err != nil {
+			return "", fmt.Errorf("failed to save file: %w", err)
+		}
+	case "s3":
+		if s.objectClient == nil {
+			return "", errors.New("s3 storage is not configured")
+		}
+		err := s.withRetry(ctx, func() error {
+			return s.objectClient.PutObject(ctx, relativePath, bytes.NewReader(data), int64(len(data)), contentType)
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to upload file to s3: %w", err)
+		}
+	case "cloudinary":
+		return "", fmt.Errorf("Cloudinary storage not yet implemented")
+	default:
+		return "", fmt.Errorf("unsupported storage provider: %s", s.storageProvider)
+	}
+
+	return s.GetFileURL(ctx, relativePath), nil
+}
+
 // DeleteFile deletes a file from storage
 func (s *uploadService) DeleteFile(ctx context.Context, fileURL string) error {
 	if fileURL == "" {
@@ -116,8 +426,7 @@ func (s *uploadService) DeleteFile(ctx context.Context, fileURL string) error {
 	case "local":
 		return s.deleteFromLocal(ctx, fileURL)
 	case "s3":
-		// TODO: Implement S3 deletion
-		return fmt.Errorf("S3 storage not yet implemented")
+		return s.deleteFromS3(ctx, fileURL)
 	case "cloudinary":
 		// TODO: Implement Cloudinary deletion
 		return fmt.Errorf("Cloudinary storage not yet implemented")
@@ -128,12 +437,17 @@ func (s *uploadService) DeleteFile(ctx context.Context, fileURL string) error {
 
 // deleteFromLocal deletes file from local filesystem
 func (s *uploadService) deleteFromLocal(ctx context.Context, fileURL string) error {
-	// Extract path from URL (remove base URL if present)
-	path := strings.TrimPrefix(fileURL, s.baseURL)
-	path = strings.TrimPrefix(path, "/")
+	relativePath, ok := s.storedPath(fileURL)
+	if !ok {
+		// Not one of our files
+		return nil
+	}
 
 	// Full file path
-	filePath := filepath.Join(s.uploadPath, path)
+	filePath, err := LocalUploadPath(s.uploadPath, relativePath)
+	if err != nil {
+		return err
+	}
 
 	// Check if file exists
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
@@ -149,24 +463,256 @@ func (s *uploadService) deleteFromLocal(ctx context.Context, fileURL string) err
 	return nil
 }
 
+// uploadToS3 streams the file to the bucket under directory/filename, retrying transient errors
+func (s *uploadService) uploadToS3(ctx context.Context, file *multipart.FileHeader, directory string) (string, error) {
+	if s.objectClient == nil {
+		return "", errors.New("s3 storage is not configured")
+	}
+
+	filename := uniqueFileBase() + filepath.Ext(file.Filename)
+	key := path.Join(filepath.ToSlash(directory), filename)
+
+	contentType := file.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	err := s.withRetry(ctx, func() error {
+		// Re-open on every attempt so a failed attempt never leaves a half-read stream
+		src, err := file.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open uploaded file: %w", err)
+		}
+		defer src.Close()
+
+		return s.objectClient.PutObject(ctx, key, src, file.Size, contentType)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload file to s3: %w", err)
+	}
+
+	return s.GetFileURL(ctx, key), nil
+}
+
+// deleteFromS3 removes the object referenced by fileURL from the bucket
+func (s *uploadService) deleteFromS3(ctx context.Context, fileURL string) error {
+	if s.objectClient == nil {
+		return errors.New("s3 storage is not configured")
+	}
+
+	key, ok := s.storedPath(fileURL)
+	if !ok {
+		return nil
+	}
+
+	if err := s.withRetry(ctx, func() error {
+		return s.objectClient.RemoveObject(ctx, key)
+	}); err != nil {
+		return fmt.Errorf("failed to delete file from s3: %w", err)
+	}
+
+	return nil
+}
+
+// withRetry runs fn until it succeeds, fails with a non-transient error, or attempts run out
+func (s *uploadService) withRetry(ctx context.Context, fn func() error) error {
+	backoff := s.retryBackoff
+	var err error
+	for attempt := 1; attempt <= s.maxRetries; attempt++ {
+		if err = fn(); err == nil || !errors.Is(err, ErrStorageTransient) {
+			return err
+		}
+		if attempt == s.maxRetries {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return err
+}
+
+// HealthCheck writes and deletes a small probe file in the configured storage
+func (s *uploadService) HealthCheck(ctx context.Context) error {
+	name := uuid.New().String() + ".probe"
+	probe := []byte("ok")
+
+	switch s.storageProvider {
+	case "local":
+		dir := filepath.Join(s.uploadPath, healthCheckDirectory)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create probe directory: %w", err)
+		}
+		probePath := filepath.Join(dir, name)
+		if err := os.WriteFile(probePath, probe, 0644); err != nil {
+			return fmt.Errorf("failed to write probe file: %w", err)
+		}
+		if err := os.Remove(probePath); err != nil {
+			return fmt.Errorf("failed to delete probe file: %w", err)
+		}
+		return nil
+	case "s3":
+		if s.objectClient == nil {
+			return errors.New("s3 storage is not configured")
+		}
+		key := path.Join(healthCheckDirectory, name)
+		if err := s.objectClient.PutObject(ctx, key, bytes.NewReader(probe), int64(len(probe)), "text/plain"); err != nil {
+			return fmt.Errorf("failed to write probe object: %w", err)
+		}
+		if err := s.objectClient.RemoveObject(ctx, key); err != nil {
+			return fmt.Errorf("failed to delete probe object: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported storage provider: %s", s.storageProvider)
+	}
+}
+
+// OpenFile opens a stored file reference for reading from the configured storage
+func (s *uploadService) OpenFile(ctx context.Context, stored string) (io.ReadCloser, error) {
+	relativePath, ok := s.storedPath(stored)
+	if !ok {
+		return nil, ErrStoredFileNotFound
+	}
+
+	switch s.storageProvider {
+	case "local":
+		filePath, err := LocalUploadPath(s.uploadPath, relativePath)
+		if err != nil {
+			return nil, err
+		}
+		f, err := os.Open(filePath)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, ErrStoredFileNotFound
+			}
+			return nil, fmt.Errorf("failed to open file: %w", err)
+		}
+		if info, err := f.Stat(); err != nil || info.IsDir() {
+			f.Close()
+			return nil, ErrStoredFileNotFound
+		}
+		return f, nil
+	case "s3":
+		if s.objectClient == nil {
+			return nil, errors.New("s3 storage is not configured")
+		}
+		var body io.ReadCloser
+		err := s.withRetry(ctx, func() error {
+			var err error
+			body, err = s.objectClient.GetObject(ctx, relativePath)
+			return err
+		})
+		if err != nil {
+			if errors.Is(err, ErrStoredFileNotFound) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to read file from s3: %w", err)
+		}
+		return body, nil
+	default:
+		return nil, fmt.Errorf("unsupported storage provider: %s", s.storageProvider)
+	}
+}
+
+// CopyFile reads a stored file reference and stores its content under directory with a new
+// unique name that keeps the original extension
+func (s *uploadService) CopyFile(ctx context.Context, stored, directory string) (string, error) {
+	relativePath, ok := s.storedPath(stored)
+	if !ok {
+		return "", ErrStoredFileNotFound
+	}
+
+	src, err := s.OpenFile(ctx, stored)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	ext := strings.ToLower(path.Ext(relativePath))
+	contentType := mime.TypeByExtension(ext)
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	return s.uploadBytes(ctx, path.Join(filepath.ToSlash(directory), uniqueFileBase()+ext), data, contentType)
+}
+
 // GetFileURL generates the full URL for a file path
 func (s *uploadService) GetFileURL(ctx context.Context, path string) string {
-	// For local storage, return URL relative to base URL
-	if s.storageProvider == "local" {
+	// For local storage and s3, return URL relative to base URL
+	if s.storageProvider == "local" || s.storageProvider == "s3" {
 		// Normalize path separators
 		path = filepath.ToSlash(path)
-		return fmt.Sprintf("%s/%s", strings.TrimSuffix(s.baseURL, "/"), strings.TrimPrefix(path, "/"))
+		return fmt.Sprintf("%s/%s", s.baseURL, strings.TrimPrefix(path, "/"))
 	}
 
-	// For S3/Cloudinary, return the full URL (to be implemented)
+	// For Cloudinary, return the full URL (to be implemented)
 	return path
 }
 
+// ResolveFileURL returns the public URL of a stored file reference. Relative paths and URLs
+// generated under the legacy local base URL are rebuilt on the configured base URL; other
+// absolute URLs are returned unchanged.
+func (s *uploadService) ResolveFileURL(stored string) string {
+	if stored == "" {
+		return ""
+	}
+	relativePath, ok := s.storedPath(stored)
+	if !ok {
+		return stored
+	}
+	return s.GetFileURL(context.Background(), relativePath)
+}
+
+// storedPath returns the storage path of a stored file reference: a URL under the configured
+// or legacy base URL, a path under the /uploads route, or a path relative to the upload root.
+// It reports false for URLs pointing elsewhere.
+func (s *uploadService) storedPath(stored string) (string, bool) {
+	switch {
+	case s.baseURL != "" && strings.HasPrefix(stored, s.baseURL+"/"):
+		stored = strings.TrimPrefix(stored, s.baseURL)
+	case s.storageProvider == "local" && strings.HasPrefix(stored, legacyLocalBaseURL+"/"):
+		stored = strings.TrimPrefix(stored, legacyLocalBaseURL)
+	case strings.Contains(stored, "://"):
+		return "", false
+	}
+
+	stored = strings.TrimPrefix(filepath.ToSlash(stored), "/")
+	if s.storageProvider == "local" {
+		stored = strings.TrimPrefix(stored, strings.TrimPrefix(localUploadRoute, "/"))
+	}
+	return stored, stored != ""
+}
+
+// LocalUploadPath joins a stored file path onto the upload root, rejecting paths that would
+// escape it such as ../../etc/passwd
+func LocalUploadPath(root, relativePath string) (string, error) {
+	cleaned := path.Clean("/" + filepath.ToSlash(relativePath))
+	if cleaned == "/" || strings.Contains(relativePath, "\x00") {
+		return "", ErrInvalidFilePath
+	}
+	for _, segment := range strings.Split(filepath.ToSlash(relativePath), "/") {
+		if segment == ".." {
+			return "", ErrInvalidFilePath
+		}
+	}
+	return filepath.Join(root, filepath.FromSlash(cleaned)), nil
+}
+
 // ValidateFile validates file type and size
 func (s *uploadService) ValidateFile(file *multipart.FileHeader, allowedTypes []string, maxSize int64) error {
 	// Check file size
 	if file.Size > maxSize {
-		return fmt.Errorf("file size exceeds maximum allowed size of %d bytes", maxSize)
+		return fmt.Errorf("%w of %d bytes", ErrFileTooLarge, maxSize)
 	}
 
 	// Check file type if allowedTypes is specified
@@ -180,7 +726,7 @@ func (s *uploadService) ValidateFile(file *multipart.FileHeader, allowedTypes []
 			}
 		}
 		if !allowed {
-			return fmt.Errorf("file type %s is not allowed. Allowed types: %v", ext, allowedTypes)
+			return fmt.Errorf("%w: %s. Allowed types: %v", ErrFileTypeNotAllowed, ext, allowedTypes)
 		}
 	}
 
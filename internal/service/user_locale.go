@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/i18n"
+)
+
+// userLocaleContext returns ctx carrying the user's language preference, so emails and
+// notifications sent on their behalf use their language even outside a request. When
+// the user has no supported preference the locale already in ctx is kept.
+func userLocaleContext(ctx context.Context, userRepo user.UserRepository, userID int64) context.Context {
+	if userRepo == nil {
+		return ctx
+	}
+
+	pref, err := userRepo.FindPreferenceByUserID(ctx, userID)
+	if err != nil || pref == nil || pref.LanguagePreference == nil {
+		return ctx
+	}
+
+	locale, ok := i18n.ParseLocale(*pref.LanguagePreference)
+	if !ok {
+		return ctx
+	}
+	return i18n.WithLocale(ctx, locale)
+}
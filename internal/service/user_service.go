@@ -3,10 +3,16 @@ package service
 import (
 	"context"
 	"fmt"
+	"log"
 	"mime/multipart"
+	"sort"
+	"strings"
 	"time"
 
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/job"
 	"keerja-backend/internal/domain/master"
+	"keerja-backend/internal/domain/notification"
 	"keerja-backend/internal/domain/user"
 	"keerja-backend/internal/utils"
 )
@@ -16,6 +22,9 @@ type userService struct {
 	userRepo         user.UserRepository
 	uploadService    UploadService
 	skillsMasterRepo master.SkillsMasterRepository
+	pushTopicService notification.PushTopicService
+	cache            cache.Cache              // Caches profile completeness; may be nil
+	matchScores      job.MatchScoreRepository // Precomputed job match scores; may be nil
 }
 
 // NewUserService creates a new user service instance
@@ -23,11 +32,17 @@ func NewUserService(
 	userRepo user.UserRepository,
 	uploadService UploadService,
 	skillsMasterRepo master.SkillsMasterRepository,
+	pushTopicService notification.PushTopicService,
+	cacheService cache.Cache,
+	matchScores job.MatchScoreRepository,
 ) user.UserService {
 	return &userService{
 		userRepo:         userRepo,
 		uploadService:    uploadService,
 		skillsMasterRepo: skillsMasterRepo,
+		pushTopicService: pushTopicService,
+		cache:            cacheService,
+		matchScores:      matchScores,
 	}
 }
 
@@ -100,7 +115,12 @@ func (s *userService) UpdateProfile(ctx context.Context, userID int64, req *user
 		userUpdated = true
 	}
 	if req.Phone != nil {
-		usr.Phone = req.Phone
+		// A blank number removes it
+		phone, err := utils.NormalizePhonePtr(req.Phone)
+		if err != nil {
+			return user.ErrInvalidPhone
+		}
+		usr.SetPhone(phone)
 		userUpdated = true
 	}
 
@@ -160,7 +180,9 @@ func (s *userService) UpdateProfile(ctx context.Context, userID int64, req *user
 		profile.LocationCountry = req.LocationCountry
 	}
 	// Master-data location IDs (province, city, district)
+	provinceChanged := false
 	if req.ProvinceID != nil {
+		provinceChanged = profile.ProvinceID == nil || *profile.ProvinceID != *req.ProvinceID
 		profile.ProvinceID = req.ProvinceID
 	}
 	if req.CityID != nil {
@@ -205,13 +227,22 @@ func (s *userService) UpdateProfile(ctx context.Context, userID int64, req *user
 		return fmt.Errorf("failed to update profile: %w", err)
 	}
 
+	// Move the user's devices to the new province topic; a failed sync must not fail the update
+	if provinceChanged && s.pushTopicService != nil {
+		if err := s.pushTopicService.SyncUserTopics(ctx, userID); err != nil {
+			log.Printf("Failed to sync push topics for user %d: %v", userID, err)
+		}
+	}
+
+	s.invalidateProfileCompleteness(userID)
+
 	return nil
 }
 
 // UploadAvatar uploads user avatar image
 func (s *userService) UploadAvatar(ctx context.Context, userID int64, file *multipart.FileHeader) (string, error) {
 	// Validate file
-	if err := s.uploadService.ValidateFile(file, ImageTypes, MaxAvatarSize); err != nil {
+	if err := s.uploadService.ValidateFile(file, ImageTypes, s.uploadService.Limits().AvatarSize); err != nil {
 		return "", fmt.Errorf("invalid avatar file: %w", err)
 	}
 
@@ -221,32 +252,34 @@ func (s *userService) UploadAvatar(ctx context.Context, userID int64, file *mult
 		return "", fmt.Errorf("profile not found: %w", err)
 	}
 
-	// Delete old avatar if exists
-	if profile.AvatarURL != nil && *profile.AvatarURL != "" {
-		_ = s.uploadService.DeleteFile(ctx, *profile.AvatarURL)
-	}
-
-	// Upload new avatar
-	avatarURL, err := s.uploadService.UploadFile(ctx, file, "avatars")
+	// Upload new avatar with its resized variants
+	avatar, err := s.uploadService.UploadImage(ctx, file, "avatars", AvatarImageSpec)
 	if err != nil {
 		return "", fmt.Errorf("failed to upload avatar: %w", err)
 	}
 
-	// Update profile with new avatar URL
-	profile.AvatarURL = &avatarURL
+	// Update profile with new avatar URL, keeping the old files until it is saved
+	oldAvatarURL, oldAvatarVariants := profile.AvatarURL, profile.AvatarVariants
+	profile.AvatarURL = &avatar.URL
+	profile.AvatarVariants = avatar.Variants
 	if err := s.userRepo.UpdateProfile(ctx, profile); err != nil {
-		// Clean up uploaded file
-		_ = s.uploadService.DeleteFile(ctx, avatarURL)
+		// Clean up uploaded files
+		deleteImageFiles(ctx, s.uploadService, &avatar.URL, avatar.Variants)
 		return "", fmt.Errorf("failed to update profile with avatar URL: %w", err)
 	}
 
-	return avatarURL, nil
+	// Delete old avatar if exists
+	deleteImageFiles(ctx, s.uploadService, oldAvatarURL, oldAvatarVariants)
+
+	s.invalidateProfileCompleteness(userID)
+
+	return avatar.URL, nil
 }
 
 // UploadCover uploads user cover image
 func (s *userService) UploadCover(ctx context.Context, userID int64, file *multipart.FileHeader) (string, error) {
 	// Validate file
-	if err := s.uploadService.ValidateFile(file, ImageTypes, MaxCoverSize); err != nil {
+	if err := s.uploadService.ValidateFile(file, ImageTypes, s.uploadService.Limits().CoverSize); err != nil {
 		return "", fmt.Errorf("invalid cover file: %w", err)
 	}
 
@@ -256,26 +289,26 @@ func (s *userService) UploadCover(ctx context.Context, userID int64, file *multi
 		return "", fmt.Errorf("profile not found: %w", err)
 	}
 
-	// Delete old cover if exists
-	if profile.CoverURL != nil && *profile.CoverURL != "" {
-		_ = s.uploadService.DeleteFile(ctx, *profile.CoverURL)
-	}
-
-	// Upload new cover
-	coverURL, err := s.uploadService.UploadFile(ctx, file, "covers")
+	// Upload new cover with its resized variants
+	cover, err := s.uploadService.UploadImage(ctx, file, "covers", CoverImageSpec)
 	if err != nil {
 		return "", fmt.Errorf("failed to upload cover: %w", err)
 	}
 
-	// Update profile with new cover URL
-	profile.CoverURL = &coverURL
+	// Update profile with new cover URL, keeping the old files until it is saved
+	oldCoverURL, oldCoverVariants := profile.CoverURL, profile.CoverVariants
+	profile.CoverURL = &cover.URL
+	profile.CoverVariants = cover.Variants
 	if err := s.userRepo.UpdateProfile(ctx, profile); err != nil {
-		// Clean up uploaded file
-		_ = s.uploadService.DeleteFile(ctx, coverURL)
+		// Clean up uploaded files
+		deleteImageFiles(ctx, s.uploadService, &cover.URL, cover.Variants)
 		return "", fmt.Errorf("failed to update profile with cover URL: %w", err)
 	}
 
-	return coverURL, nil
+	// Delete old cover if exists
+	deleteImageFiles(ctx, s.uploadService, oldCoverURL, oldCoverVariants)
+
+	return cover.URL, nil
 }
 
 // DeleteAvatar deletes user avatar
@@ -286,17 +319,18 @@ func (s *userService) DeleteAvatar(ctx context.Context, userID int64) error {
 		return fmt.Errorf("profile not found: %w", err)
 	}
 
-	// Delete avatar file if exists
-	if profile.AvatarURL != nil && *profile.AvatarURL != "" {
-		_ = s.uploadService.DeleteFile(ctx, *profile.AvatarURL)
-	}
+	// Delete avatar files if exists
+	deleteImageFiles(ctx, s.uploadService, profile.AvatarURL, profile.AvatarVariants)
 
 	// Update profile to remove avatar URL
 	profile.AvatarURL = nil
+	profile.AvatarVariants = nil
 	if err := s.userRepo.UpdateProfile(ctx, profile); err != nil {
 		return fmt.Errorf("failed to update profile: %w", err)
 	}
 
+	s.invalidateProfileCompleteness(userID)
+
 	return nil
 }
 
@@ -308,13 +342,12 @@ func (s *userService) DeleteCover(ctx context.Context, userID int64) error {
 		return fmt.Errorf("profile not found: %w", err)
 	}
 
-	// Delete cover file if exists
-	if profile.CoverURL != nil && *profile.CoverURL != "" {
-		_ = s.uploadService.DeleteFile(ctx, *profile.CoverURL)
-	}
+	// Delete cover files if exists
+	deleteImageFiles(ctx, s.uploadService, profile.CoverURL, profile.CoverVariants)
 
 	// Update profile to remove cover URL
 	profile.CoverURL = nil
+	profile.CoverVariants = nil
 	if err := s.userRepo.UpdateProfile(ctx, profile); err != nil {
 		return fmt.Errorf("failed to update profile: %w", err)
 	}
@@ -386,6 +419,11 @@ func (s *userService) UpdatePreferences(ctx context.Context, userID int64, req *
 	}
 	if req.EmailMarketing != nil {
 		pref.EmailMarketing = *req.EmailMarketing
+		// The marketing email consent and the marketing email channel are the same choice
+		if channels, ok := pref.NotificationMatrix[user.NotificationEventMarketing]; ok {
+			channels.Email = *req.EmailMarketing
+			pref.NotificationMatrix[user.NotificationEventMarketing] = channels
+		}
 	}
 	if req.ProfileVisibility != nil {
 		pref.ProfileVisibility = *req.ProfileVisibility
@@ -399,6 +437,9 @@ func (s *userService) UpdatePreferences(ctx context.Context, userID int64, req *
 	if req.DataSharingConsent != nil {
 		pref.DataSharingConsent = *req.DataSharingConsent
 	}
+	if req.AllowTalentPools != nil {
+		pref.AllowTalentPools = *req.AllowTalentPools
+	}
 
 	// Update preferences
 	if err := s.userRepo.UpdatePreference(ctx, pref); err != nil {
@@ -408,6 +449,120 @@ func (s *userService) UpdatePreferences(ctx context.Context, userID int64, req *
 	return nil
 }
 
+// GetNotificationSettings returns the user's notification matrix and quiet hours; users who
+// never saved preferences get the defaults
+func (s *userService) GetNotificationSettings(ctx context.Context, userID int64) (*user.UserPreference, error) {
+	pref, err := s.userRepo.FindPreferenceByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get preferences: %w", err)
+	}
+	if pref == nil {
+		pref = &user.UserPreference{
+			UserID:             userID,
+			EmailNotifications: true,
+			PushNotifications:  true,
+			QuietHoursTimezone: user.DefaultQuietHoursTimezone,
+		}
+	}
+
+	return pref, nil
+}
+
+// UpdateNotificationSettings validates and saves changes to the user's notification matrix
+// and quiet hours. The request is validated as a whole before anything is changed.
+func (s *userService) UpdateNotificationSettings(ctx context.Context, userID int64, req *user.UpdateNotificationSettingsRequest) (*user.UserPreference, error) {
+	for event := range req.Events {
+		if !event.IsValid() {
+			return nil, user.ErrUnknownNotificationEvent
+		}
+	}
+	if req.QuietHoursTimezone != nil {
+		if _, err := time.LoadLocation(*req.QuietHoursTimezone); err != nil || *req.QuietHoursTimezone == "" {
+			return nil, user.ErrInvalidTimezone
+		}
+	}
+
+	pref, err := s.userRepo.FindPreferenceByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve preferences: %w", err)
+	}
+	if pref == nil {
+		// Reload the created row so the saved preference keeps the column defaults
+		if err := s.userRepo.CreatePreference(ctx, &user.UserPreference{UserID: userID, EmailNotifications: true, PushNotifications: true}); err != nil {
+			return nil, fmt.Errorf("failed to create preferences: %w", err)
+		}
+		if pref, err = s.userRepo.FindPreferenceByUserID(ctx, userID); err != nil || pref == nil {
+			return nil, fmt.Errorf("failed to retrieve preferences: %w", err)
+		}
+	}
+
+	start, end := pref.QuietHoursStart, pref.QuietHoursEnd
+	if req.QuietHoursStart != nil {
+		start = quietHoursTime(*req.QuietHoursStart)
+	}
+	if req.QuietHoursEnd != nil {
+		end = quietHoursTime(*req.QuietHoursEnd)
+	}
+	if (start == nil) != (end == nil) {
+		return nil, user.ErrInvalidQuietHours
+	}
+	for _, t := range []*string{start, end} {
+		if t == nil {
+			continue
+		}
+		if _, err := user.ParseQuietHoursTime(*t); err != nil {
+			return nil, user.ErrInvalidQuietHours
+		}
+	}
+	pref.QuietHoursStart, pref.QuietHoursEnd = start, end
+	if req.QuietHoursTimezone != nil {
+		pref.QuietHoursTimezone = *req.QuietHoursTimezone
+	}
+
+	if req.EmailNotifications != nil {
+		pref.EmailNotifications = *req.EmailNotifications
+	}
+	if req.PushNotifications != nil {
+		pref.PushNotifications = *req.PushNotifications
+	}
+
+	matrix := make(user.NotificationMatrix, len(user.NotificationEvents))
+	for _, event := range user.NotificationEvents {
+		matrix[event] = pref.NotificationChannelsFor(event)
+	}
+	for event, update := range req.Events {
+		channels := matrix[event]
+		if update.Email != nil {
+			channels.Email = *update.Email
+		}
+		if update.Push != nil {
+			channels.Push = *update.Push
+		}
+		if update.WhatsApp != nil && event.HasWhatsApp() {
+			channels.WhatsApp = *update.WhatsApp
+		}
+		matrix[event] = channels
+	}
+	pref.NotificationMatrix = matrix
+	// The marketing email channel and the marketing email consent are the same choice
+	pref.EmailMarketing = matrix[user.NotificationEventMarketing].Email
+
+	if err := s.userRepo.UpdatePreference(ctx, pref); err != nil {
+		return nil, fmt.Errorf("failed to update preferences: %w", err)
+	}
+
+	return pref, nil
+}
+
+// quietHoursTime returns the quiet hours time of a request, nil when it was cleared
+func quietHoursTime(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
 // =============================================================================
 // Education Management
 // =============================================================================
@@ -431,6 +586,9 @@ func (s *userService) AddEducation(ctx context.Context, userID int64, req *user.
 		return nil, fmt.Errorf("failed to add education: %w", err)
 	}
 
+	s.invalidateProfileCompleteness(userID)
+	s.invalidateMatchScores(ctx, userID)
+
 	return education, nil
 }
 
@@ -488,6 +646,8 @@ func (s *userService) UpdateEducation(ctx context.Context, userID int64, educati
 		return fmt.Errorf("failed to update education: %w", err)
 	}
 
+	s.invalidateMatchScores(ctx, userID)
+
 	return nil
 }
 
@@ -515,6 +675,9 @@ func (s *userService) DeleteEducation(ctx context.Context, userID int64, educati
 		return fmt.Errorf("failed to delete education: %w", err)
 	}
 
+	s.invalidateProfileCompleteness(userID)
+	s.invalidateMatchScores(ctx, userID)
+
 	return nil
 }
 
@@ -572,6 +735,9 @@ func (s *userService) AddExperience(ctx context.Context, userID int64, req *user
 		return nil, fmt.Errorf("failed to add experience: %w", err)
 	}
 
+	s.invalidateProfileCompleteness(userID)
+	s.invalidateMatchScores(ctx, userID)
+
 	return experience, nil
 }
 
@@ -643,6 +809,8 @@ func (s *userService) UpdateExperience(ctx context.Context, userID int64, experi
 		return fmt.Errorf("failed to update experience: %w", err)
 	}
 
+	s.invalidateMatchScores(ctx, userID)
+
 	return nil
 }
 
@@ -670,6 +838,9 @@ func (s *userService) DeleteExperience(ctx context.Context, userID int64, experi
 		return fmt.Errorf("failed to delete experience: %w", err)
 	}
 
+	s.invalidateProfileCompleteness(userID)
+	s.invalidateMatchScores(ctx, userID)
+
 	return nil
 }
 
@@ -696,17 +867,11 @@ func (s *userService) AddSkill(ctx context.Context, userID int64, req *user.AddU
 	}
 
 	// Determine skill name: either from skills_master or custom input
-	if req.SkillID != nil {
-		// Query skills_master to get skill name by ID
-		skillMaster, err := s.skillsMasterRepo.FindByID(ctx, *req.SkillID)
-		if err != nil {
-			return fmt.Errorf("skill_id %d not found in skills_master: %w", *req.SkillID, err)
-		}
-		skill.SkillName = skillMaster.Name
-	} else {
-		// Use custom skill name provided by user
-		skill.SkillName = req.SkillName
+	name, err := s.canonicalSkillName(ctx, req.SkillID, req.SkillName)
+	if err != nil {
+		return err
 	}
+	skill.SkillName = name
 
 	if req.YearsOfExperience != nil {
 		years := int(*req.YearsOfExperience)
@@ -717,6 +882,9 @@ func (s *userService) AddSkill(ctx context.Context, userID int64, req *user.AddU
 		return fmt.Errorf("failed to add skill: %w", err)
 	}
 
+	s.invalidateProfileCompleteness(userID)
+	s.invalidateMatchScores(ctx, userID)
+
 	return nil
 }
 
@@ -725,6 +893,12 @@ func (s *userService) AddSkill(ctx context.Context, userID int64, req *user.AddU
 func (s *userService) AddSkills(ctx context.Context, userID int64, req *user.AddUserSkillsRequest) ([]user.UserSkill, error) {
 	addedSkills := make([]user.UserSkill, 0, len(req.Skills))
 
+	// Skills added before a failure are kept, so invalidate on every return
+	defer func() {
+		s.invalidateProfileCompleteness(userID)
+		s.invalidateMatchScores(ctx, userID)
+	}()
+
 	for i, skillReq := range req.Skills {
 		skill := &user.UserSkill{
 			UserID:     userID,
@@ -732,17 +906,11 @@ func (s *userService) AddSkills(ctx context.Context, userID int64, req *user.Add
 		}
 
 		// Determine skill name: either from skills_master or custom input
-		if skillReq.SkillID != nil {
-			// Query skills_master to get skill name by ID
-			skillMaster, err := s.skillsMasterRepo.FindByID(ctx, *skillReq.SkillID)
-			if err != nil {
-				return nil, fmt.Errorf("skill #%d: skill_id %d not found in skills_master: %w", i+1, *skillReq.SkillID, err)
-			}
-			skill.SkillName = skillMaster.Name
-		} else {
-			// Use custom skill name provided by user
-			skill.SkillName = skillReq.SkillName
+		name, err := s.canonicalSkillName(ctx, skillReq.SkillID, skillReq.SkillName)
+		if err != nil {
+			return nil, fmt.Errorf("skill #%d: %w", i+1, err)
 		}
+		skill.SkillName = name
 
 		if skillReq.YearsOfExperience != nil {
 			years := int(*skillReq.YearsOfExperience)
@@ -759,6 +927,35 @@ func (s *userService) AddSkills(ctx context.Context, userID int64, req *user.Add
 	return addedSkills, nil
 }
 
+// canonicalSkillName returns the skills_master name to store for a user skill. A skill ID
+// of a merged skill resolves to the skill it was merged into, and a custom name matching a
+// skill's name or alias is stored as that skill's name so synonyms are not counted apart.
+func (s *userService) canonicalSkillName(ctx context.Context, skillID *int64, name string) (string, error) {
+	if s.skillsMasterRepo == nil {
+		return name, nil
+	}
+
+	if skillID != nil {
+		skillMaster, err := s.skillsMasterRepo.ResolveSkill(ctx, *skillID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get skill_id %d from skills_master: %w", *skillID, err)
+		}
+		if skillMaster == nil {
+			return "", master.ErrSkillNotFound.WithField("skill_id", fmt.Sprintf("skill_id %d not found in skills_master", *skillID))
+		}
+		return skillMaster.Name, nil
+	}
+
+	skillMaster, err := s.skillsMasterRepo.FindCanonicalByName(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up skill %q: %w", name, err)
+	}
+	if skillMaster == nil {
+		return name, nil
+	}
+	return skillMaster.Name, nil
+}
+
 // UpdateSkill updates a skill entry
 func (s *userService) UpdateSkill(ctx context.Context, userID int64, skillID int64, req *user.UpdateSkillRequest) error {
 	// Get skills to verify ownership
@@ -799,6 +996,8 @@ func (s *userService) UpdateSkill(ctx context.Context, userID int64, skillID int
 		return fmt.Errorf("failed to update skill: %w", err)
 	}
 
+	s.invalidateMatchScores(ctx, userID)
+
 	return nil
 }
 
@@ -826,6 +1025,9 @@ func (s *userService) DeleteSkill(ctx context.Context, userID int64, skillID int
 		return fmt.Errorf("failed to delete skill: %w", err)
 	}
 
+	s.invalidateProfileCompleteness(userID)
+	s.invalidateMatchScores(ctx, userID)
+
 	return nil
 }
 
@@ -1264,7 +1466,7 @@ func (s *userService) GetProjects(ctx context.Context, userID int64) ([]user.Use
 // UploadDocument uploads a document for the user
 func (s *userService) UploadDocument(ctx context.Context, userID int64, file *multipart.FileHeader, req *user.UploadDocumentRequest) (*user.UserDocument, error) {
 	// Validate file
-	if err := s.uploadService.ValidateFile(file, DocumentTypes, MaxDocumentSize); err != nil {
+	if err := s.uploadService.ValidateFile(file, DocumentTypes, s.uploadService.Limits().DocumentSize); err != nil {
 		return nil, fmt.Errorf("invalid document file: %w", err)
 	}
 
@@ -1299,6 +1501,8 @@ func (s *userService) UploadDocument(ctx context.Context, userID int64, file *mu
 		return nil, fmt.Errorf("failed to save document record: %w", err)
 	}
 
+	s.invalidateProfileCompleteness(userID)
+
 	return doc, nil
 }
 
@@ -1330,6 +1534,8 @@ func (s *userService) DeleteDocument(ctx context.Context, userID int64, document
 		return fmt.Errorf("failed to delete document: %w", err)
 	}
 
+	s.invalidateProfileCompleteness(userID)
+
 	return nil
 }
 
@@ -1377,100 +1583,148 @@ func (s *userService) GetUsersBySkills(ctx context.Context, skillNames []string)
 // Profile Completion and Analytics
 // =============================================================================
 
+// ProfileCompletenessTTL is how long a computed profile completeness is cached
+const ProfileCompletenessTTL = 10 * time.Minute
+
+// profileCompletenessCheck is a weighted profile completeness checklist item
+type profileCompletenessCheck struct {
+	key        string
+	weight     int
+	nextAction string
+	completed  func(usr *user.User) bool
+}
+
+// minCompletenessSkills is how many skills complete the skills item
+const minCompletenessSkills = 3
+
+// profileCompletenessChecks are the checklist items; the weights add up to 100
+var profileCompletenessChecks = []profileCompletenessCheck{
+	{user.CompletenessAvatar, 10, "Upload a profile photo", func(usr *user.User) bool {
+		return usr.Profile != nil && nonEmpty(usr.Profile.AvatarURL)
+	}},
+	{user.CompletenessHeadline, 10, "Add a headline", func(usr *user.User) bool {
+		return usr.Profile != nil && nonEmpty(usr.Profile.Headline)
+	}},
+	{user.CompletenessBio, 10, "Write a short bio", func(usr *user.User) bool {
+		return usr.Profile != nil && nonEmpty(usr.Profile.Bio)
+	}},
+	{user.CompletenessEducation, 15, "Add your education", func(usr *user.User) bool {
+		return len(usr.Educations) > 0
+	}},
+	{user.CompletenessExperience, 15, "Add your work experience", func(usr *user.User) bool {
+		return len(usr.Experiences) > 0
+	}},
+	{user.CompletenessSkills, 10, "Add at least 3 skills", func(usr *user.User) bool {
+		return len(usr.Skills) >= minCompletenessSkills
+	}},
+	{user.CompletenessResume, 15, "Upload your resume", func(usr *user.User) bool {
+		for _, doc := range usr.Documents {
+			if doc.IsActive && doc.DocumentType != nil && *doc.DocumentType == "resume" {
+				return true
+			}
+		}
+		return false
+	}},
+	{user.CompletenessLocation, 5, "Set your location", func(usr *user.User) bool {
+		return usr.Profile != nil && (usr.Profile.CityID != nil || nonEmpty(usr.Profile.LocationCity))
+	}},
+	{user.CompletenessPreferences, 5, "Set your desired position and salary", func(usr *user.User) bool {
+		return usr.Profile != nil && nonEmpty(usr.Profile.DesiredPosition) &&
+			(usr.Profile.DesiredSalaryMin != nil || usr.Profile.DesiredSalaryMax != nil)
+	}},
+	// Only a number verified by SMS OTP completes this item
+	{user.CompletenessPhone, 5, "Verify your phone number", func(usr *user.User) bool {
+		return usr.IsPhoneVerified()
+	}},
+}
+
+// nonEmpty reports whether s is set to a non-blank value
+func nonEmpty(s *string) bool {
+	return s != nil && strings.TrimSpace(*s) != ""
+}
+
 // GetProfileCompletionPercentage calculates profile completion percentage
 func (s *userService) GetProfileCompletionPercentage(ctx context.Context, userID int64) (int, error) {
-	// Get full profile
-	usr, err := s.userRepo.GetFullProfile(ctx, userID)
+	completeness, err := s.GetProfileCompleteness(ctx, userID)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get user profile: %w", err)
+		return 0, err
 	}
+	return completeness.Score, nil
+}
 
-	// Calculate completion percentage
-	totalFields := 0
-	completedFields := 0
-
-	// Basic profile fields (weight: 30%)
-	basicFields := 10
-	basicCompleted := 0
-
-	totalFields += basicFields
+// GetProfileCompleteness computes the weighted profile completeness checklist, cached per user
+func (s *userService) GetProfileCompleteness(ctx context.Context, userID int64) (*user.ProfileCompleteness, error) {
+	cacheKey := profileCompletenessCacheKey(userID)
+	if s.cache != nil {
+		if cached, ok := cache.GetTyped[*user.ProfileCompleteness](s.cache, cacheKey); ok {
+			return cached, nil
+		}
+	}
 
-	if usr.FullName != "" {
-		basicCompleted++
+	usr, err := s.userRepo.GetFullProfile(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user profile: %w", err)
 	}
-	if usr.Email != "" {
-		basicCompleted++
+
+	completeness := computeProfileCompleteness(usr)
+	if s.cache != nil {
+		s.cache.Set(cacheKey, completeness, ProfileCompletenessTTL)
 	}
-	if usr.Phone != nil {
-		basicCompleted++
+	return completeness, nil
+}
+
+// computeProfileCompleteness scores a full profile against the checklist
+func computeProfileCompleteness(usr *user.User) *user.ProfileCompleteness {
+	completeness := &user.ProfileCompleteness{
+		Items:       make([]user.ProfileCompletenessItem, 0, len(profileCompletenessChecks)),
+		NextActions: []string{},
 	}
-	if usr.Profile != nil {
-		if usr.Profile.Headline != nil && *usr.Profile.Headline != "" {
-			basicCompleted++
-		}
-		if usr.Profile.Bio != nil && *usr.Profile.Bio != "" {
-			basicCompleted++
-		}
-		if usr.Profile.LocationCity != nil {
-			basicCompleted++
-		}
-		if usr.Profile.AvatarURL != nil {
-			basicCompleted++
-		}
-		if usr.Profile.DesiredPosition != nil {
-			basicCompleted++
-		}
-		if usr.Profile.ExperienceLevel != nil {
-			basicCompleted++
+
+	var missing []user.ProfileCompletenessItem
+	for _, check := range profileCompletenessChecks {
+		item := user.ProfileCompletenessItem{
+			Key:        check.key,
+			Weight:     check.weight,
+			Completed:  check.completed(usr),
+			NextAction: check.nextAction,
 		}
-		if usr.Profile.IndustryInterest != nil {
-			basicCompleted++
+		if item.Completed {
+			completeness.Score += item.Weight
+		} else {
+			missing = append(missing, item)
 		}
+		completeness.Items = append(completeness.Items, item)
 	}
 
-	completedFields += basicCompleted
-
-	// Education (weight: 15%)
-	educationFields := 2
-	totalFields += educationFields
-	if len(usr.Educations) > 0 {
-		completedFields += educationFields
+	sort.SliceStable(missing, func(i, j int) bool { return missing[i].Weight > missing[j].Weight })
+	for _, item := range missing {
+		completeness.NextActions = append(completeness.NextActions, item.NextAction)
 	}
 
-	// Experience (weight: 20%)
-	experienceFields := 3
-	totalFields += experienceFields
-	if len(usr.Experiences) > 0 {
-		completedFields += experienceFields
-	}
+	return completeness
+}
 
-	// Skills (weight: 20%)
-	skillFields := 3
-	totalFields += skillFields
-	if len(usr.Skills) >= 3 {
-		completedFields += skillFields
-	} else if len(usr.Skills) > 0 {
-		completedFields += len(usr.Skills)
-	}
+// profileCompletenessCacheKey is the cache key of a user's profile completeness
+func profileCompletenessCacheKey(userID int64) string {
+	return cache.GenerateCacheKey("user", "completeness", userID)
+}
 
-	// Certifications (weight: 10%)
-	certFields := 1
-	totalFields += certFields
-	if len(usr.Certifications) > 0 {
-		completedFields += certFields
+// invalidateProfileCompleteness drops the cached completeness after a profile change
+func (s *userService) invalidateProfileCompleteness(userID int64) {
+	if s.cache != nil {
+		s.cache.Delete(profileCompletenessCacheKey(userID))
 	}
+}
 
-	// Languages (weight: 5%)
-	langFields := 1
-	totalFields += langFields
-	if len(usr.Languages) > 0 {
-		completedFields += langFields
+// invalidateMatchScores drops the user's precomputed job match scores after a change to
+// their skills, experience or education; they are recomputed on demand until the next refresh
+func (s *userService) invalidateMatchScores(ctx context.Context, userID int64) {
+	if s.matchScores == nil {
+		return
+	}
+	if err := s.matchScores.DeleteByUser(ctx, userID); err != nil {
+		log.Printf("Failed to invalidate job match scores of user %d: %v", userID, err)
 	}
-
-	// Calculate percentage
-	percentage := (completedFields * 100) / totalFields
-
-	return percentage, nil
 }
 
 // UpdateLastLogin updates the user's last login timestamp
@@ -1556,7 +1810,7 @@ func (s *userService) DeactivateAccount(ctx context.Context, userID int64) error
 
 	// Update status to inactive
 	usr.Status = "inactive"
-	usr.IsVerified = false
+	usr.ClearEmailVerification()
 
 	if err := s.userRepo.Update(ctx, usr); err != nil {
 		return fmt.Errorf("failed to deactivate account: %w", err)
@@ -1578,12 +1832,8 @@ func (s *userService) DeleteAccount(ctx context.Context, userID int64) error {
 
 	// Clean up uploaded files
 	if usr.Profile != nil {
-		if usr.Profile.AvatarURL != nil {
-			_ = s.uploadService.DeleteFile(ctx, *usr.Profile.AvatarURL)
-		}
-		if usr.Profile.CoverURL != nil {
-			_ = s.uploadService.DeleteFile(ctx, *usr.Profile.CoverURL)
-		}
+		deleteImageFiles(ctx, s.uploadService, usr.Profile.AvatarURL, usr.Profile.AvatarVariants)
+		deleteImageFiles(ctx, s.uploadService, usr.Profile.CoverURL, usr.Profile.CoverVariants)
 	}
 
 	// Clean up documents
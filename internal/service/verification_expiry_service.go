@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/domain/notification"
+	"keerja-backend/internal/domain/user"
+)
+
+// verificationWarningDays are the days-before-expiry thresholds at which employers are
+// warned. They are processed smallest first, so a company that is already inside several
+// thresholds only gets the most urgent warning.
+var verificationWarningDays = []int{1, 7, 30}
+
+// verificationExpiryService implements company.VerificationExpiryService
+type verificationExpiryService struct {
+	companyRepo  company.CompanyRepository
+	userRepo     user.UserRepository
+	emailService email.EmailService
+	dispatcher   notification.NotificationDispatcher
+	gracePeriod  time.Duration
+}
+
+// NewVerificationExpiryService creates a new verification expiry service instance.
+// A zero gracePeriod expires verifications as soon as their expiry date has passed.
+func NewVerificationExpiryService(
+	companyRepo company.CompanyRepository,
+	userRepo user.UserRepository,
+	emailService email.EmailService,
+	dispatcher notification.NotificationDispatcher,
+	gracePeriod time.Duration,
+) company.VerificationExpiryService {
+	return &verificationExpiryService{
+		companyRepo:  companyRepo,
+		userRepo:     userRepo,
+		emailService: emailService,
+		dispatcher:   dispatcherOrDefault(dispatcher, userRepo),
+		gracePeriod:  gracePeriod,
+	}
+}
+
+// CheckVerificationExpiry sends due expiry warnings, starts the grace period for verifications
+// whose expiry date has passed and expires those whose grace period has ended
+func (s *verificationExpiryService) CheckVerificationExpiry(ctx context.Context) (*company.VerificationExpiryStats, error) {
+	stats := &company.VerificationExpiryStats{}
+	now := time.Now()
+
+	for _, days := range verificationWarningDays {
+		verifications, err := s.companyRepo.FindVerificationsExpiringWithin(ctx, days)
+		if err != nil {
+			return stats, fmt.Errorf("failed to get verifications expiring within %d days: %w", days, err)
+		}
+
+		for i := range verifications {
+			verification := &verifications[i]
+			marked, err := s.companyRepo.MarkVerificationExpiryWarned(ctx, verification.ID, days)
+			if err != nil {
+				log.Printf("Failed to mark verification %d warned: %v", verification.ID, err)
+				continue
+			}
+			if !marked {
+				continue
+			}
+
+			s.sendExpiryWarning(ctx, verification, daysUntil(*verification.VerificationExpiry, now))
+			stats.WarningsSent++
+		}
+	}
+
+	lapsed, err := s.companyRepo.FindLapsedVerifications(ctx)
+	if err != nil {
+		return stats, fmt.Errorf("failed to get lapsed verifications: %w", err)
+	}
+
+	for i := range lapsed {
+		verification := &lapsed[i]
+		comp := verification.Company
+		// Only the verification row is updated here; never write the preloaded company back through it
+		verification.Company = nil
+
+		if verification.GraceUntil == nil && s.gracePeriod > 0 {
+			graceUntil := verification.VerificationExpiry.Add(s.gracePeriod)
+			if graceUntil.After(now) {
+				verification.GraceUntil = &graceUntil
+				verification.LastChecked = &now
+				if err := s.companyRepo.UpdateVerification(ctx, verification); err != nil {
+					log.Printf("Failed to start grace period for verification %d: %v", verification.ID, err)
+					continue
+				}
+				stats.EnteredGrace++
+				continue
+			}
+		}
+		if verification.GraceUntil != nil && verification.GraceUntil.After(now) {
+			continue
+		}
+
+		verification.Status = company.VerificationStatusExpired
+		verification.AutoExpired = true
+		verification.LastChecked = &now
+		if err := s.companyRepo.UpdateVerification(ctx, verification); err != nil {
+			log.Printf("Failed to expire verification %d: %v", verification.ID, err)
+			continue
+		}
+
+		companyName := ""
+		if comp != nil {
+			companyName = comp.CompanyName
+			comp.Verified = false
+			if err := s.companyRepo.Update(ctx, comp); err != nil {
+				log.Printf("Failed to unverify company %d: %v", comp.ID, err)
+			}
+		}
+
+		s.notifyExpired(ctx, verification.CompanyID, companyName)
+		stats.Expired++
+	}
+
+	return stats, nil
+}
+
+// sendExpiryWarning emails the company's owners and admins about the upcoming expiry
+func (s *verificationExpiryService) sendExpiryWarning(ctx context.Context, verification *company.CompanyVerification, daysLeft int) {
+	if s.emailService == nil || verification.Company == nil {
+		return
+	}
+
+	for _, usr := range s.companyManagers(ctx, verification.CompanyID) {
+		if err := s.emailService.SendVerificationExpiryEmail(ctx, usr.Email, usr.FullName, verification.Company.CompanyName, daysLeft, *verification.VerificationExpiry); err != nil {
+			log.Printf("Failed to send verification expiry warning to user %d: %v", usr.ID, err)
+		}
+	}
+}
+
+// notifyExpired pushes the expiry to the company's owners and admins
+func (s *verificationExpiryService) notifyExpired(ctx context.Context, companyID int64, companyName string) {
+	managers := s.companyManagers(ctx, companyID)
+	if len(managers) == 0 {
+		return
+	}
+	userIDs := make([]int64, len(managers))
+	for i, usr := range managers {
+		userIDs[i] = usr.ID
+	}
+
+	message := &notification.PushMessage{
+		Title:    "Company Verification Expired",
+		Body:     fmt.Sprintf("The verification for %s has expired. Renew it to keep publishing jobs.", companyName),
+		Priority: "high",
+		Data: map[string]string{
+			"type":       "company_verification_expired",
+			"company_id": fmt.Sprintf("%d", companyID),
+		},
+	}
+	if _, err := s.dispatcher.PushToUsers(ctx, userIDs, user.NotificationEventTransactional, message); err != nil {
+		log.Printf("Failed to push verification expiry for company %d: %v", companyID, err)
+	}
+}
+
+// companyManagers returns the active owner and admin users of a company
+func (s *verificationExpiryService) companyManagers(ctx context.Context, companyID int64) []*user.User {
+	return findCompanyManagers(ctx, s.companyRepo, s.userRepo, companyID)
+}
+
+// findCompanyManagers returns the active owner and admin users of a company
+func findCompanyManagers(ctx context.Context, companyRepo company.CompanyRepository, userRepo user.UserRepository, companyID int64) []*user.User {
+	employers, err := companyRepo.GetEmployerUsersByCompanyID(ctx, companyID)
+	if err != nil {
+		log.Printf("Failed to get employer users of company %d: %v", companyID, err)
+		return nil
+	}
+
+	var managers []*user.User
+	for _, employer := range employers {
+		if !employer.IsActive || !employer.IsAdmin() {
+			continue
+		}
+		usr, err := userRepo.FindByID(ctx, employer.UserID)
+		if err != nil || usr == nil {
+			continue
+		}
+		managers = append(managers, usr)
+	}
+	return managers
+}
+
+// daysUntil counts the calendar days from now until the expiry date, at least 1 so a
+// verification expiring today is reported as expiring within a day
+func daysUntil(expiry, now time.Time) int {
+	year, month, day := now.Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, expiry.Location())
+	return max(int(expiry.Sub(today).Hours()/24), 1)
+}
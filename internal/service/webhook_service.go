@@ -0,0 +1,562 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"keerja-backend/internal/domain/audit"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/domain/webhook"
+
+	"github.com/google/uuid"
+)
+
+// Webhook delivery defaults, used when the config leaves a value unset
+const (
+	defaultWebhookMaxAttempts = 8
+	defaultWebhookBaseBackoff = time.Minute
+	defaultWebhookMaxBackoff  = 2 * time.Hour
+	defaultWebhookBatchSize   = 50
+	defaultWebhookLease       = 5 * time.Minute
+	defaultWebhookTimeout     = 10 * time.Second
+
+	// webhookSecretBytes is the entropy of a generated signing secret
+	webhookSecretBytes = 24
+	// webhookResponseSnippet is how much of a failed response body is kept for debugging
+	webhookResponseSnippet = 512
+)
+
+// errBlockedWebhookAddress is returned when a webhook host resolves to a loopback or private address
+var errBlockedWebhookAddress = errors.New("webhook host resolves to a non-public address")
+
+// WebhookConfig configures delivery of company webhooks
+type WebhookConfig struct {
+	MaxAttempts      int           // Attempts before a delivery fails
+	BaseBackoff      time.Duration // Delay after the first failed attempt, doubled on each further failure
+	MaxBackoff       time.Duration // Upper bound of the delay between attempts
+	BatchSize        int           // Deliveries sent per worker run
+	Lease            time.Duration // How long a claimed delivery is hidden from other workers
+	Timeout          time.Duration // How long an endpoint has to respond
+	AllowPrivateURLs bool          // Allow http and loopback/private hosts, for local development
+}
+
+// webhookService implements webhook.WebhookService
+type webhookService struct {
+	repo         webhook.WebhookRepository
+	companyRepo  company.CompanyRepository
+	userRepo     user.UserRepository
+	emailService email.EmailService
+	auditService audit.AuditService
+	client       *http.Client
+	cfg          WebhookConfig
+}
+
+// NewWebhookService creates a new company webhook service
+func NewWebhookService(
+	repo webhook.WebhookRepository,
+	companyRepo company.CompanyRepository,
+	userRepo user.UserRepository,
+	emailService email.EmailService,
+	auditService audit.AuditService,
+	cfg WebhookConfig,
+) webhook.WebhookService {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultWebhookMaxAttempts
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = defaultWebhookBaseBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaultWebhookMaxBackoff
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultWebhookBatchSize
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = defaultWebhookLease
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultWebhookTimeout
+	}
+	return &webhookService{
+		repo:         repo,
+		companyRepo:  companyRepo,
+		userRepo:     userRepo,
+		emailService: emailService,
+		auditService: auditService,
+		client:       newWebhookHTTPClient(cfg),
+		cfg:          cfg,
+	}
+}
+
+// newWebhookHTTPClient creates the client deliveries are sent with. Redirects are not
+// followed and, unless private URLs are allowed, connections to loopback and private
+// addresses are refused at dial time so a public hostname cannot point inside the network.
+func newWebhookHTTPClient(cfg WebhookConfig) *http.Client {
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	if !cfg.AllowPrivateURLs {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return fmt.Errorf("%w: %s", errBlockedWebhookAddress, host)
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   cfg.Timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Publish queues the event for the company's subscribed webhooks
+func (s *webhookService) Publish(ctx context.Context, companyID int64, event string, data interface{}) {
+	now := time.Now()
+	eventID := uuid.New().String()
+	payload, err := json.Marshal(webhook.Envelope{
+		ID:        eventID,
+		Event:     event,
+		CompanyID: companyID,
+		CreatedAt: now.UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		log.Printf("[WARN] failed to encode webhook event %s of company %d: %v", event, companyID, err)
+		return
+	}
+
+	// The request that caused the event may be finishing; the event is queued regardless
+	if _, err := s.repo.EnqueueEvent(context.WithoutCancel(ctx), companyID, event, eventID, string(payload), now); err != nil {
+		log.Printf("[WARN] failed to queue webhook event %s of company %d: %v", event, companyID, err)
+	}
+}
+
+// publishWebhookEvent publishes event when a webhook publisher is configured
+func publishWebhookEvent(ctx context.Context, publisher webhook.EventPublisher, companyID int64, event string, data interface{}) {
+	if publisher == nil {
+		return
+	}
+	publisher.Publish(ctx, companyID, event, data)
+}
+
+// CreateWebhook registers an endpoint for the company with a generated signing secret
+func (s *webhookService) CreateWebhook(ctx context.Context, companyID, createdBy int64, rawURL string, events []string) (*webhook.CompanyWebhook, error) {
+	endpoint, err := s.validateURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	unique, err := normalizeWebhookEvents(events)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.CountByCompany(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count webhooks: %w", err)
+	}
+	if count >= webhook.MaxWebhooksPerCompany {
+		return nil, webhook.ErrTooManyWebhooks
+	}
+
+	random := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(random); err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	w := &webhook.CompanyWebhook{
+		CompanyID: companyID,
+		URL:       endpoint,
+		Secret:    webhook.SecretPrefix + hex.EncodeToString(random),
+		Events:    unique,
+		Active:    true,
+		CreatedBy: &createdBy,
+	}
+	if err := s.repo.Create(ctx, w); err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		Action:     audit.ActionWebhookCreated,
+		EntityType: audit.EntityWebhook,
+		EntityID:   w.ID,
+		Metadata:   audit.Metadata{"company_id": companyID, "url": endpoint, "events": unique},
+	})
+
+	return w, nil
+}
+
+// ListWebhooks lists a company's webhooks, newest first
+func (s *webhookService) ListWebhooks(ctx context.Context, companyID int64) ([]webhook.CompanyWebhook, error) {
+	webhooks, err := s.repo.ListByCompany(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// UpdateWebhook changes one of the company's webhooks. Enabling a disabled webhook gives
+// it a fresh start with the circuit breaker.
+func (s *webhookService) UpdateWebhook(ctx context.Context, companyID, webhookID int64, input webhook.UpdateWebhookInput) (*webhook.CompanyWebhook, error) {
+	w, err := s.findCompanyWebhook(ctx, companyID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.URL != nil {
+		endpoint, err := s.validateURL(*input.URL)
+		if err != nil {
+			return nil, err
+		}
+		w.URL = endpoint
+	}
+	if input.Events != nil {
+		unique, err := normalizeWebhookEvents(input.Events)
+		if err != nil {
+			return nil, err
+		}
+		w.Events = unique
+	}
+	if input.Active != nil {
+		if *input.Active && !w.Active {
+			w.FailureCount = 0
+			w.FailingSince = nil
+			w.DisabledAt = nil
+		}
+		w.Active = *input.Active
+	}
+
+	if err := s.repo.Update(ctx, w); err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		Action:     audit.ActionWebhookUpdated,
+		EntityType: audit.EntityWebhook,
+		EntityID:   w.ID,
+		Metadata:   audit.Metadata{"company_id": companyID, "url": w.URL, "events": w.Events, "active": w.Active},
+	})
+
+	return w, nil
+}
+
+// DeleteWebhook deletes one of the company's webhooks with its deliveries
+func (s *webhookService) DeleteWebhook(ctx context.Context, companyID, webhookID int64) error {
+	w, err := s.findCompanyWebhook(ctx, companyID, webhookID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, webhookID); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		Action:     audit.ActionWebhookDeleted,
+		EntityType: audit.EntityWebhook,
+		EntityID:   webhookID,
+		Metadata:   audit.Metadata{"company_id": companyID, "url": w.URL},
+	})
+	return nil
+}
+
+// findCompanyWebhook finds a webhook of the company. Webhooks of other companies are
+// reported as missing so their IDs are not disclosed.
+func (s *webhookService) findCompanyWebhook(ctx context.Context, companyID, webhookID int64) (*webhook.CompanyWebhook, error) {
+	w, err := s.repo.FindByID(ctx, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook: %w", err)
+	}
+	if w == nil || w.CompanyID != companyID {
+		return nil, webhook.ErrWebhookNotFound
+	}
+	return w, nil
+}
+
+// ProcessDue sends the deliveries that are due
+func (s *webhookService) ProcessDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	deliveries, err := s.repo.ClaimDue(ctx, now, now.Add(s.cfg.Lease), s.cfg.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim due webhook deliveries: %w", err)
+	}
+
+	delivered := 0
+	var errs []error
+	webhooks := make(map[int64]*webhook.CompanyWebhook)
+	for i := range deliveries {
+		d := &deliveries[i]
+		w, ok := webhooks[d.WebhookID]
+		if !ok {
+			// A delivery whose webhook cannot be loaded is retried once its lease runs out
+			if w, err = s.repo.FindByID(ctx, d.WebhookID); err != nil {
+				errs = append(errs, fmt.Errorf("webhook %d: %w", d.WebhookID, err))
+				continue
+			}
+			webhooks[d.WebhookID] = w
+		}
+		// Deleted webhooks take their deliveries with them; disabled ones had theirs failed
+		if w == nil || !w.Active {
+			continue
+		}
+
+		responseCode, sendErr := s.send(ctx, w, d)
+		if sendErr == nil {
+			delivered++
+		}
+		if err := s.recordAttempt(ctx, w, d, responseCode, sendErr); err != nil {
+			errs = append(errs, fmt.Errorf("webhook delivery %d: %w", d.ID, err))
+		}
+	}
+
+	return delivered, errors.Join(errs...)
+}
+
+// send posts a delivery's payload to the webhook, signed with its secret. It returns the
+// response code, if a response arrived, and an error unless the endpoint answered 2xx.
+func (s *webhookService) send(ctx context.Context, w *webhook.CompanyWebhook, d *webhook.WebhookDelivery) (*int, error) {
+	sendCtx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	body := []byte(d.Payload)
+	req, err := http.NewRequestWithContext(sendCtx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Keerja-Webhooks/1.0")
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(w.Secret, body))
+	req.Header.Set(webhook.EventHeader, d.Event)
+	req.Header.Set(webhook.EventIDHeader, d.EventID)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	code := resp.StatusCode
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseSnippet))
+	if code >= 200 && code < 300 {
+		return &code, nil
+	}
+	return &code, fmt.Errorf("endpoint responded with status %d: %s", code, strings.TrimSpace(string(snippet)))
+}
+
+// recordAttempt stores the outcome of an attempt and feeds the webhook's circuit breaker;
+// the attempt itself was already counted when the delivery was claimed
+func (s *webhookService) recordAttempt(ctx context.Context, w *webhook.CompanyWebhook, d *webhook.WebhookDelivery, responseCode *int, sendErr error) error {
+	if sendErr == nil {
+		if err := s.repo.MarkDelivered(ctx, d.ID, *responseCode, time.Now()); err != nil {
+			return err
+		}
+		if w.FailureCount == 0 {
+			return nil
+		}
+		w.FailureCount = 0
+		w.FailingSince = nil
+		return s.repo.RecordSuccess(ctx, w.ID)
+	}
+
+	msg := sendErr.Error()
+	var err error
+	if d.Attempts >= s.cfg.MaxAttempts {
+		err = s.repo.MarkFailed(ctx, d.ID, responseCode, msg)
+	} else {
+		err = s.repo.MarkRetry(ctx, d.ID, time.Now().Add(s.backoff(d.Attempts)), responseCode, msg)
+	}
+	if err != nil {
+		return err
+	}
+	return s.recordFailure(ctx, w)
+}
+
+// recordFailure counts a failed attempt on the webhook and disables it once the failures
+// are sustained, telling the company's owners and admins
+func (s *webhookService) recordFailure(ctx context.Context, w *webhook.CompanyWebhook) error {
+	now := time.Now()
+	updated, err := s.repo.RecordFailure(ctx, w.ID, now)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook failure: %w", err)
+	}
+	if updated == nil {
+		return nil
+	}
+	*w = *updated
+	if !w.ShouldTrip(now) {
+		return nil
+	}
+
+	disabled, err := s.repo.Disable(ctx, w.ID, now)
+	if err != nil {
+		return fmt.Errorf("failed to disable webhook: %w", err)
+	}
+	w.Active = false
+	w.DisabledAt = &now
+	if !disabled {
+		return nil
+	}
+
+	recordAudit(ctx, s.auditService, audit.AuditLog{
+		Action:     audit.ActionWebhookDisabled,
+		EntityType: audit.EntityWebhook,
+		EntityID:   w.ID,
+		Metadata:   audit.Metadata{"company_id": w.CompanyID, "url": w.URL, "failure_count": w.FailureCount},
+	})
+	s.notifyDisabled(ctx, w)
+	return nil
+}
+
+// notifyDisabled emails the company's owners and admins that the webhook was disabled
+func (s *webhookService) notifyDisabled(ctx context.Context, w *webhook.CompanyWebhook) {
+	if s.emailService == nil {
+		return
+	}
+
+	companyName := ""
+	if comp, err := s.companyRepo.FindByID(ctx, w.CompanyID); err == nil && comp != nil {
+		companyName = comp.CompanyName
+	}
+	for _, usr := range findCompanyManagers(ctx, s.companyRepo, s.userRepo, w.CompanyID) {
+		if err := s.emailService.SendWebhookDisabledEmail(ctx, usr.Email, usr.FullName, companyName, w.URL, w.FailureCount); err != nil {
+			log.Printf("Failed to send webhook disabled notice to user %d: %v", usr.ID, err)
+		}
+	}
+}
+
+// backoff returns the delay before the next attempt after attempts failed attempts
+func (s *webhookService) backoff(attempts int) time.Duration {
+	delay := s.cfg.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= s.cfg.MaxBackoff {
+			return s.cfg.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// ListDeliveries lists deliveries matching filter, newest first
+func (s *webhookService) ListDeliveries(ctx context.Context, filter webhook.DeliveryFilter, page, limit int) ([]webhook.WebhookDelivery, int64, error) {
+	deliveries, total, err := s.repo.ListDeliveries(ctx, filter, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return deliveries, total, nil
+}
+
+// Redeliver queues the delivery's event again as a new delivery, due now
+func (s *webhookService) Redeliver(ctx context.Context, deliveryID int64, scope webhook.DeliveryFilter) (*webhook.WebhookDelivery, error) {
+	d, err := s.repo.FindDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook delivery: %w", err)
+	}
+	if d == nil || !scope.Matches(d) {
+		return nil, webhook.ErrDeliveryNotFound
+	}
+
+	w, err := s.repo.FindByID(ctx, d.WebhookID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook: %w", err)
+	}
+	if w == nil {
+		return nil, webhook.ErrWebhookNotFound
+	}
+	if !w.Active {
+		return nil, webhook.ErrWebhookInactive
+	}
+
+	redelivery := &webhook.WebhookDelivery{
+		WebhookID:     d.WebhookID,
+		CompanyID:     d.CompanyID,
+		EventID:       d.EventID,
+		Event:         d.Event,
+		Payload:       d.Payload,
+		Status:        webhook.DeliveryStatusPending,
+		NextAttemptAt: time.Now(),
+	}
+	if err := s.repo.CreateDelivery(ctx, redelivery); err != nil {
+		return nil, fmt.Errorf("failed to queue webhook redelivery: %w", err)
+	}
+	return redelivery, nil
+}
+
+// validateURL checks a webhook URL and returns it in canonical form. Hostnames are checked
+// again when connecting, since they may resolve to a private address.
+func (s *webhookService) validateURL(rawURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Host == "" || u.User != nil {
+		return "", webhook.ErrInvalidWebhookURL
+	}
+	if u.Scheme != "https" && !(s.cfg.AllowPrivateURLs && u.Scheme == "http") {
+		return "", webhook.ErrInvalidWebhookURL
+	}
+	if !s.cfg.AllowPrivateURLs && !isPublicWebhookHost(u.Hostname()) {
+		return "", webhook.ErrInvalidWebhookURL
+	}
+	u.Fragment = ""
+	return u.String(), nil
+}
+
+// normalizeWebhookEvents checks the events a webhook subscribes to and drops duplicates
+func normalizeWebhookEvents(events []string) ([]string, error) {
+	if len(events) == 0 {
+		return nil, webhook.ErrInvalidWebhookEvent
+	}
+	unique := make([]string, 0, len(events))
+	seen := make(map[string]bool, len(events))
+	for _, event := range events {
+		if !webhook.IsValidEvent(event) {
+			return nil, webhook.ErrInvalidWebhookEvent
+		}
+		if !seen[event] {
+			seen[event] = true
+			unique = append(unique, event)
+		}
+	}
+	return unique, nil
+}
+
+// isPublicWebhookHost rejects localhost and IP literals of non-public addresses
+func isPublicWebhookHost(host string) bool {
+	host = strings.ToLower(host)
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return isPublicIP(ip)
+	}
+	return true
+}
+
+// isPublicIP checks if ip is reachable on the public internet
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast())
+}
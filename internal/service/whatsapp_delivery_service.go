@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"keerja-backend/internal/domain/notification"
+)
+
+// whatsAppDeliveryService implements notification.WhatsAppDeliveryService
+type whatsAppDeliveryService struct {
+	deliveryRepo notification.WhatsAppDeliveryRepository
+}
+
+// NewWhatsAppDeliveryService creates a new WhatsApp delivery service instance
+func NewWhatsAppDeliveryService(deliveryRepo notification.WhatsAppDeliveryRepository) notification.WhatsAppDeliveryService {
+	return &whatsAppDeliveryService{deliveryRepo: deliveryRepo}
+}
+
+// ListDeliveries lists deliveries matching filter, newest first
+func (s *whatsAppDeliveryService) ListDeliveries(ctx context.Context, filter notification.WhatsAppDeliveryFilter, page, limit int) ([]notification.WhatsAppDelivery, int64, error) {
+	deliveries, total, err := s.deliveryRepo.List(ctx, filter, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list WhatsApp deliveries: %w", err)
+	}
+	return deliveries, total, nil
+}
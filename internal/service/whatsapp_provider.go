@@ -0,0 +1,214 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"keerja-backend/internal/config"
+	"keerja-backend/internal/domain/notification"
+)
+
+// WhatsApp providers
+const (
+	WhatsAppProviderLog   = "log"
+	WhatsAppProviderCloud = "cloud"
+)
+
+// WhatsAppConfig holds settings for the WhatsApp provider
+type WhatsAppConfig struct {
+	Provider      string            // "cloud", "log"; empty disables WhatsApp
+	BaseURL       string            // Overrides the Graph API endpoint, including its version
+	Token         string            // Access token of the WhatsApp Business account
+	PhoneNumberID string            // Business phone number messages are sent from
+	Language      string            // Language code of the templates; "id" when empty
+	Templates     map[string]string // Approved template name per template key; the key itself when missing
+	Timeout       time.Duration
+}
+
+// NewWhatsAppProvider creates the WhatsApp provider selected by cfg.Provider. It returns nil
+// when WhatsApp is not configured, so notifications go out by email and push only.
+func NewWhatsAppProvider(cfg WhatsAppConfig) (notification.WhatsAppProvider, error) {
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case WhatsAppProviderLog:
+		return NewLogWhatsAppProvider(), nil
+	case WhatsAppProviderCloud:
+		if cfg.Token == "" || cfg.PhoneNumberID == "" {
+			return nil, errors.New("whatsapp cloud provider requires a token and a phone number ID")
+		}
+		client := &http.Client{Timeout: cfg.Timeout}
+		if cfg.Timeout <= 0 {
+			client.Timeout = 10 * time.Second
+		}
+		baseURL := strings.TrimSuffix(cfg.BaseURL, "/")
+		if baseURL == "" {
+			baseURL = "https://graph.facebook.com/v21.0"
+		}
+		language := cfg.Language
+		if language == "" {
+			language = "id"
+		}
+		return &cloudWhatsAppProvider{
+			client:    client,
+			endpoint:  baseURL + "/" + cfg.PhoneNumberID + "/messages",
+			token:     cfg.Token,
+			language:  language,
+			templates: cfg.Templates,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported WhatsApp provider %q", cfg.Provider)
+	}
+}
+
+// logWhatsAppProvider writes template messages to the log instead of sending them
+type logWhatsAppProvider struct{}
+
+// NewLogWhatsAppProvider creates a WhatsApp provider for development that only logs messages
+func NewLogWhatsAppProvider() notification.WhatsAppProvider {
+	return logWhatsAppProvider{}
+}
+
+// SendTemplate logs the message
+func (logWhatsAppProvider) SendTemplate(ctx context.Context, to string, message *notification.WhatsAppMessage) (string, error) {
+	config.WithContext(ctx).WithField("to", to).Infof("WhatsApp %s (not sent): %v", message.Template, message.Params)
+	return "", nil
+}
+
+// cloudWhatsAppProvider implements notification.WhatsAppProvider with the WhatsApp Business
+// Cloud API
+type cloudWhatsAppProvider struct {
+	client    *http.Client
+	endpoint  string
+	token     string
+	language  string
+	templates map[string]string
+}
+
+// cloudTemplateRequest is the Cloud API payload of a template message
+type cloudTemplateRequest struct {
+	MessagingProduct string        `json:"messaging_product"`
+	RecipientType    string        `json:"recipient_type"`
+	To               string        `json:"to"`
+	Type             string        `json:"type"`
+	Template         cloudTemplate `json:"template"`
+}
+
+type cloudTemplate struct {
+	Name       string                   `json:"name"`
+	Language   cloudTemplateLanguage    `json:"language"`
+	Components []cloudTemplateComponent `json:"components,omitempty"`
+}
+
+type cloudTemplateLanguage struct {
+	Code string `json:"code"`
+}
+
+type cloudTemplateComponent struct {
+	Type       string                   `json:"type"`
+	Parameters []cloudTemplateParameter `json:"parameters"`
+}
+
+type cloudTemplateParameter struct {
+	Type          string `json:"type"`
+	ParameterName string `json:"parameter_name"`
+	Text          string `json:"text"`
+}
+
+// cloudResponse is the Cloud API response to a message, either its ID or an error
+type cloudResponse struct {
+	Messages []struct {
+		ID string `json:"id"`
+	} `json:"messages"`
+	Error *struct {
+		Message string `json:"message"`
+		Type    string `json:"type"`
+		Code    int    `json:"code"`
+	} `json:"error"`
+}
+
+// isCloudTemplateError checks if a Cloud API error code is one of the 132xxx template errors,
+// such as a missing, paused or unapproved template or mismatched parameters
+func isCloudTemplateError(code int) bool {
+	return code >= 132000 && code < 133000
+}
+
+// SendTemplate sends the message as a template message with named body parameters
+func (p *cloudWhatsAppProvider) SendTemplate(ctx context.Context, to string, message *notification.WhatsAppMessage) (string, error) {
+	name := p.templates[message.Template]
+	if name == "" {
+		name = message.Template
+	}
+
+	payload := cloudTemplateRequest{
+		MessagingProduct: "whatsapp",
+		RecipientType:    "individual",
+		To:               strings.TrimPrefix(to, "+"),
+		Type:             "template",
+		Template: cloudTemplate{
+			Name:     name,
+			Language: cloudTemplateLanguage{Code: p.language},
+		},
+	}
+	if len(message.Params) > 0 {
+		keys := make([]string, 0, len(message.Params))
+		for key := range message.Params {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		body := cloudTemplateComponent{Type: "body", Parameters: make([]cloudTemplateParameter, 0, len(keys))}
+		for _, key := range keys {
+			body.Parameters = append(body.Parameters, cloudTemplateParameter{Type: "text", ParameterName: key, Text: message.Params[key]})
+		}
+		payload.Template.Components = []cloudTemplateComponent{body}
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("whatsapp request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result cloudResponse
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read whatsapp response: %w", err)
+	}
+	if err := json.Unmarshal(raw, &result); err != nil && resp.StatusCode < 300 {
+		return "", fmt.Errorf("invalid whatsapp response: %w", err)
+	}
+
+	if result.Error != nil {
+		if isCloudTemplateError(result.Error.Code) {
+			return "", fmt.Errorf("%w: %s (code %d)", notification.ErrWhatsAppTemplateRejected, result.Error.Message, result.Error.Code)
+		}
+		return "", fmt.Errorf("whatsapp returned status %d: %s (code %d)", resp.StatusCode, result.Error.Message, result.Error.Code)
+	}
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("whatsapp returned status %d", resp.StatusCode)
+	}
+	if len(result.Messages) == 0 {
+		return "", errors.New("whatsapp response has no message ID")
+	}
+	return result.Messages[0].ID, nil
+}
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Longest accepted utm_* value and referrer URL
+const (
+	MaxUTMParamLength = 100
+	MaxReferrerLength = 500
+)
+
+// utmParamPattern matches the plain tokens accepted as utm_* values, e.g. "linkedin" or "spring-2025"
+var utmParamPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._+-]*$`)
+
+// NormalizeUTMParam lowercases a utm_* value. Values that are not plain tokens or are longer
+// than MaxUTMParamLength are dropped, so they group with traffic without attribution.
+func NormalizeUTMParam(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	value = strings.ReplaceAll(value, " ", "_")
+	if len(value) > MaxUTMParamLength || !utmParamPattern.MatchString(value) {
+		return ""
+	}
+	return value
+}
+
+// NormalizeReferrer keeps an absolute http(s) URL of at most MaxReferrerLength characters,
+// dropping its query string and fragment, and returns "" for anything else
+func NormalizeReferrer(value string) string {
+	u, err := url.Parse(strings.TrimSpace(value))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+	u.RawQuery, u.Fragment, u.User = "", "", nil
+
+	referrer := u.String()
+	if len(referrer) > MaxReferrerLength {
+		return ""
+	}
+	return referrer
+}
@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// iCalendar (RFC 5545) methods used for meeting invites
+const (
+	CalendarMethodRequest = "REQUEST"
+	CalendarMethodCancel  = "CANCEL"
+)
+
+// icsTimeFormat is the UTC DATE-TIME form, e.g. 20240102T030405Z
+const icsTimeFormat = "20060102T150405Z"
+
+// icsMaxLineOctets is the line length limit before content lines must be folded
+const icsMaxLineOctets = 75
+
+// CalendarEvent describes a single VEVENT to send as a meeting invite
+type CalendarEvent struct {
+	UID         string // Stable across updates so calendar clients replace the earlier event
+	Sequence    int    // Must increase on every update or cancellation of the same UID
+	Method      string // CalendarMethodRequest or CalendarMethodCancel
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Start       time.Time
+	End         time.Time
+	Organizer   string // Organizer email address
+	Attendee    string // Attendee email address
+	Stamp       time.Time
+}
+
+// GenerateICS renders event as an iCalendar object. All times are written in UTC
+// so clients convert them to the viewer's own timezone.
+func GenerateICS(event CalendarEvent) []byte {
+	method := event.Method
+	if method == "" {
+		method = CalendarMethodRequest
+	}
+	stamp := event.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+
+	var b strings.Builder
+	writeLine := func(name, value string) {
+		b.WriteString(foldICSLine(name + ":" + value))
+		b.WriteString("\r\n")
+	}
+
+	writeLine("BEGIN", "VCALENDAR")
+	writeLine("VERSION", "2.0")
+	writeLine("PRODID", "-//Keerja//Interview Scheduler//EN")
+	writeLine("CALSCALE", "GREGORIAN")
+	writeLine("METHOD", method)
+	writeLine("BEGIN", "VEVENT")
+	writeLine("UID", event.UID)
+	writeLine("SEQUENCE", fmt.Sprintf("%d", event.Sequence))
+	writeLine("DTSTAMP", stamp.UTC().Format(icsTimeFormat))
+	writeLine("DTSTART", event.Start.UTC().Format(icsTimeFormat))
+	if !event.End.IsZero() {
+		writeLine("DTEND", event.End.UTC().Format(icsTimeFormat))
+	}
+	writeLine("SUMMARY", escapeICSText(event.Summary))
+	if event.Description != "" {
+		writeLine("DESCRIPTION", escapeICSText(event.Description))
+	}
+	if event.Location != "" {
+		writeLine("LOCATION", escapeICSText(event.Location))
+	}
+	if event.URL != "" {
+		writeLine("URL", event.URL)
+	}
+	if event.Organizer != "" {
+		writeLine("ORGANIZER", "mailto:"+event.Organizer)
+	}
+	if event.Attendee != "" {
+		writeLine("ATTENDEE;ROLE=REQ-PARTICIPANT;RSVP=TRUE", "mailto:"+event.Attendee)
+	}
+	if method == CalendarMethodCancel {
+		writeLine("STATUS", "CANCELLED")
+	} else {
+		writeLine("STATUS", "CONFIRMED")
+	}
+	writeLine("END", "VEVENT")
+	writeLine("END", "VCALENDAR")
+
+	return []byte(b.String())
+}
+
+// ParseICSTime parses a UTC DATE-TIME value as written by GenerateICS
+func ParseICSTime(value string) (time.Time, error) {
+	return time.Parse(icsTimeFormat, value)
+}
+
+// escapeICSText escapes a TEXT property value
+func escapeICSText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+	)
+	return replacer.Replace(s)
+}
+
+// foldICSLine splits a content line longer than 75 octets into continuation lines,
+// never breaking inside a multi-byte character
+func foldICSLine(line string) string {
+	if len(line) <= icsMaxLineOctets {
+		return line
+	}
+
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > icsMaxLineOctets {
+			// The leading space of a continuation line counts toward its length
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
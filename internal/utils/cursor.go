@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"keerja-backend/internal/apperror"
+)
+
+// Cursor errors
+var (
+	ErrInvalidCursor         = apperror.Validation("INVALID_CURSOR", "Invalid pagination cursor")
+	ErrCursorSortUnsupported = apperror.Validation("CURSOR_SORT_UNSUPPORTED", "Cursor pagination only supports the default sort order")
+)
+
+// Cursor marks the position of the last row of a page ordered by created_at DESC, id DESC
+type Cursor struct {
+	CreatedAt time.Time
+	ID        int64
+}
+
+// EncodeCursor encodes a row position into an opaque cursor string
+func EncodeCursor(createdAt time.Time, id int64) string {
+	raw := strconv.FormatInt(createdAt.UnixMicro(), 10) + ":" + strconv.FormatInt(id, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor decodes a cursor string. An empty string means the first page and returns nil.
+func DecodeCursor(s string) (*Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	micros, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	unixMicro, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	rowID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || rowID < 1 {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{CreatedAt: time.UnixMicro(unixMicro), ID: rowID}, nil
+}
+
+// UsesCursor reports whether the request opted into cursor pagination. An empty
+// ?cursor= requests the first page.
+func UsesCursor(c *fiber.Ctx) bool {
+	return c.Context().QueryArgs().Has("cursor")
+}
@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"errors"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -11,6 +13,29 @@ func ParseIDParam(c *fiber.Ctx, name string) (int64, error) {
 	return strconv.ParseInt(c.Params(name), 10, 64)
 }
 
+// ErrInvalidIfMatch is returned when the If-Match header is not a resource version
+var ErrInvalidIfMatch = errors.New("If-Match must be the version of the resource, e.g. \"3\"")
+
+// RequestVersion returns the version of the resource the client loaded, for updates that
+// must not overwrite changes made since: bodyVersion when the body set it, otherwise the
+// If-Match header ("3", "\"3\"" or W/"3"). It returns nil when the client sent neither.
+func RequestVersion(c *fiber.Ctx, bodyVersion *int64) (*int64, error) {
+	if bodyVersion != nil {
+		return bodyVersion, nil
+	}
+
+	header := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if header == "" {
+		return nil, nil
+	}
+	header = strings.Trim(strings.TrimPrefix(header, "W/"), `"`)
+	version, err := strconv.ParseInt(header, 10, 64)
+	if err != nil || version < 1 {
+		return nil, ErrInvalidIfMatch
+	}
+	return &version, nil
+}
+
 // SanitizePtr sanitizes a *string using utils.SanitizeString, preserving nil
 func SanitizePtr(s *string) *string {
 	if s == nil {
@@ -16,12 +16,22 @@ var (
 
 // Claims represents the JWT claims
 type Claims struct {
-	UserID   int64  `json:"user_id"`
-	Email    string `json:"email"`
-	UserType string `json:"user_type"`
+	UserID    int64  `json:"user_id"`
+	Email     string `json:"email"`
+	UserType  string `json:"user_type"`
+	SessionID string `json:"sid,omitempty"` // refresh token family, set for tokens issued through a refresh session
+
+	// Set for tokens an admin uses to impersonate the user
+	ImpersonatorID  int64 `json:"imp_by,omitempty"`  // admin acting as the user
+	ImpersonationID int64 `json:"imp_sid,omitempty"` // impersonation session the token was issued for
 	jwt.RegisteredClaims
 }
 
+// IsImpersonation checks if the token was issued to an admin impersonating the user
+func (c *Claims) IsImpersonation() bool {
+	return c.ImpersonatorID != 0
+}
+
 // AdminClaims represents the JWT claims for admin users
 type AdminClaims struct {
 	AdminID     int64  `json:"admin_id"`
@@ -39,11 +49,18 @@ type JWTConfig struct {
 }
 
 func GenerateAccessToken(userID int64, email, userType, secretKey string, duration time.Duration) (string, error) {
+	return GenerateSessionAccessToken(userID, email, userType, "", secretKey, duration)
+}
+
+// GenerateSessionAccessToken generates an access token bound to a refresh token session,
+// so the auth middleware can reject it once the session is revoked
+func GenerateSessionAccessToken(userID int64, email, userType, sessionID, secretKey string, duration time.Duration) (string, error) {
 	now := time.Now()
 	claims := Claims{
-		UserID:   userID,
-		Email:    email,
-		UserType: userType,
+		UserID:    userID,
+		Email:     email,
+		UserType:  userType,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(duration)),
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -62,6 +79,34 @@ func GenerateAccessToken(userID int64, email, userType, secretKey string, durati
 	return signedToken, nil
 }
 
+// GenerateImpersonationToken generates an access token that lets the admin adminID act as the
+// user, bound to the impersonation session so it stops working once the session ends
+func GenerateImpersonationToken(userID int64, email, userType string, adminID, impersonationID int64, secretKey string, duration time.Duration) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		UserID:          userID,
+		Email:           email,
+		UserType:        userType,
+		ImpersonatorID:  adminID,
+		ImpersonationID: impersonationID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(duration)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "keerja-api",
+			Subject:   fmt.Sprintf("%d", userID),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signedToken, err := token.SignedString([]byte(secretKey))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign impersonation token: %w", err)
+	}
+
+	return signedToken, nil
+}
+
 func GenerateRefreshToken(userID int64, email, userType, secretKey string, duration time.Duration) (string, error) {
 	return GenerateAccessToken(userID, email, userType, secretKey, duration)
 }
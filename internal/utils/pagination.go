@@ -1,9 +1,91 @@
 package utils
 
 import (
+	"errors"
 	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"keerja-backend/internal/apperror"
+)
+
+const (
+	// DefaultPageLimit is the page size of list endpoints that do not set their own default
+	DefaultPageLimit = 10
+
+	// MaxPageLimit is the largest page size list endpoints serve unless they set a lower maximum
+	MaxPageLimit = 100
 )
 
+// ErrInvalidPagination is returned when the page or limit query parameter is not a whole number
+var ErrInvalidPagination = apperror.Validation("INVALID_PAGINATION", "page and limit must be whole numbers")
+
+// PageQuery describes how a list endpoint reads its page and limit query parameters
+type PageQuery struct {
+	PageParam    string // Query parameter holding the page; "page" when empty
+	LimitParam   string // Query parameter holding the page size; "limit" when empty
+	DefaultLimit int    // Page size when none is given; DefaultPageLimit when zero
+	MaxLimit     int    // Largest page size served; MaxPageLimit when zero
+}
+
+// Parse reads the page and page size of the request. A missing or non-positive page is 1,
+// a missing or non-positive limit the default, and a limit above the maximum is clamped to
+// it. Values that are not whole numbers are rejected with ErrInvalidPagination.
+func (q PageQuery) Parse(c *fiber.Ctx) (page, limit int, err error) {
+	pageParam, limitParam := q.PageParam, q.LimitParam
+	if pageParam == "" {
+		pageParam = "page"
+	}
+	if limitParam == "" {
+		limitParam = "limit"
+	}
+	defaultLimit, maxLimit := q.DefaultLimit, q.MaxLimit
+	if defaultLimit <= 0 {
+		defaultLimit = DefaultPageLimit
+	}
+	if maxLimit <= 0 {
+		maxLimit = MaxPageLimit
+	}
+
+	if page, err = queryWholeNumber(c, pageParam); err != nil {
+		return 0, 0, ErrInvalidPagination.WithField(pageParam, "must be a whole number")
+	}
+	if limit, err = queryWholeNumber(c, limitParam); err != nil {
+		return 0, 0, ErrInvalidPagination.WithField(limitParam, "must be a whole number")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = defaultLimit
+	}
+	return page, min(limit, maxLimit), nil
+}
+
+// ParsePagination reads the page and limit query parameters with the given default and
+// maximum page size (MaxPageLimit when zero); see PageQuery.Parse
+func ParsePagination(c *fiber.Ctx, defaultLimit, maxLimit int) (int, int, error) {
+	return PageQuery{DefaultLimit: defaultLimit, MaxLimit: maxLimit}.Parse(c)
+}
+
+// queryWholeNumber parses the query parameter as an integer; a missing parameter is 0.
+// Numbers too large for an int saturate instead of failing, so they are clamped like any
+// other oversized value.
+func queryWholeNumber(c *fiber.Ctx, key string) (int, error) {
+	value := strings.TrimSpace(c.Query(key))
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if errors.Is(err, strconv.ErrRange) {
+		return n, nil
+	}
+	return n, err
+}
+
 type Pagination struct {
 	Page       int   `json:"page"`
 	Limit      int   `json:"limit"`
@@ -62,6 +144,7 @@ func GetPaginationMeta(page, limit int, totalRows int64) map[string]interface{}
 	return map[string]interface{}{
 		"page":        pagination.Page,
 		"limit":       pagination.Limit,
+		"total":       pagination.TotalRows,
 		"total_rows":  pagination.TotalRows,
 		"total_pages": pagination.TotalPages,
 		"has_next":    pagination.Page < pagination.TotalPages,
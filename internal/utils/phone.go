@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+)
+
+// DefaultPhoneCountryCode is the calling code assumed for numbers written without one
+const DefaultPhoneCountryCode = "62"
+
+// ErrInvalidPhone is returned for strings that are not a phone number
+var ErrInvalidPhone = errors.New("invalid phone number")
+
+// phoneSeparators are the characters people group phone number digits with
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "", "/", "", "\u00a0", "")
+
+// NormalizePhone parses a phone number the way it is commonly written in Indonesia and returns
+// it in E.164 form, e.g. "+6281234567890". Numbers without a country code are taken to be
+// Indonesian, so "0812-3456-7890", "62 812 3456 7890", "812 3456 7890" and "+62 812 3456 7890"
+// all normalize to the same number. Numbers of other countries need a "+" or "00" prefix.
+func NormalizePhone(raw string) (string, error) {
+	phone := phoneSeparators.Replace(strings.TrimSpace(raw))
+
+	var digits string
+	switch {
+	case strings.HasPrefix(phone, "+"):
+		digits = phone[1:]
+	case strings.HasPrefix(phone, "00"):
+		digits = phone[2:]
+	case strings.HasPrefix(phone, "0"):
+		// National format: the trunk prefix 0 takes the place of the country code
+		digits = DefaultPhoneCountryCode + phone[1:]
+	case strings.HasPrefix(phone, DefaultPhoneCountryCode):
+		digits = phone
+	default:
+		digits = DefaultPhoneCountryCode + phone
+	}
+
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return "", ErrInvalidPhone
+	}
+
+	if national, ok := strings.CutPrefix(digits, DefaultPhoneCountryCode); ok {
+		// "+62 0812..." keeps the trunk prefix by mistake
+		national = strings.TrimPrefix(national, "0")
+		if !isIndonesianNationalNumber(national) {
+			return "", ErrInvalidPhone
+		}
+		return "+" + DefaultPhoneCountryCode + national, nil
+	}
+
+	// E.164 allows at most 15 digits; country codes never start with 0
+	if len(digits) < 8 || len(digits) > 15 || digits[0] == '0' {
+		return "", ErrInvalidPhone
+	}
+	return "+" + digits, nil
+}
+
+// isIndonesianNationalNumber reports whether n has the shape of an Indonesian number after
+// the country code: a mobile number (8xx) of 9 to 12 digits or a landline of 8 to 11 digits
+func isIndonesianNationalNumber(n string) bool {
+	if n == "" || n[0] < '2' {
+		return false
+	}
+	if n[0] == '8' {
+		return len(n) >= 9 && len(n) <= 12
+	}
+	return len(n) >= 8 && len(n) <= 11
+}
+
+// NormalizePhonePtr normalizes an optional phone number. Blank numbers become nil.
+func NormalizePhonePtr(raw *string) (*string, error) {
+	if raw == nil || strings.TrimSpace(*raw) == "" {
+		return nil, nil
+	}
+	phone, err := NormalizePhone(*raw)
+	if err != nil {
+		return nil, err
+	}
+	return &phone, nil
+}
@@ -13,6 +13,14 @@ type Response struct {
 	Errors  any    `json:"errors,omitempty"`
 }
 
+// CodedErrorResponseBody is the error envelope for responses carrying a machine-readable code
+type CodedErrorResponseBody struct {
+	Success bool   `json:"success"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details any    `json:"details,omitempty"`
+}
+
 func SuccessResponse(c *fiber.Ctx, message string, data any) error {
 	return c.Status(fiber.StatusOK).JSON(Response{
 		Success: true,
@@ -52,6 +60,15 @@ func ErrorResponse(c *fiber.Ctx, statusCode int, message string, details ...stri
 	return c.Status(statusCode).JSON(resp)
 }
 
+func CodedErrorResponse(c *fiber.Ctx, statusCode int, code, message string, details any) error {
+	return c.Status(statusCode).JSON(CodedErrorResponseBody{
+		Success: false,
+		Code:    code,
+		Message: message,
+		Details: details,
+	})
+}
+
 func ErrorResponseWithErrors(c *fiber.Ctx, statusCode int, message string, errors any) error {
 	return c.Status(statusCode).JSON(Response{
 		Success: false,
@@ -4,6 +4,9 @@ import (
 	"html"
 	"regexp"
 	"strings"
+
+	xhtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 )
 
 // SanitizeString removes leading/trailing whitespace and escapes HTML characters
@@ -165,3 +168,58 @@ func ValidateNoXSS(s string) bool {
 	}
 	return true
 }
+
+// basicHTMLTags are the formatting tags SanitizeBasicHTML keeps
+var basicHTMLTags = map[atom.Atom]bool{
+	atom.P: true, atom.Br: true, atom.Ul: true, atom.Ol: true, atom.Li: true,
+	atom.Strong: true, atom.B: true, atom.Em: true, atom.I: true, atom.U: true,
+	atom.H2: true, atom.H3: true, atom.H4: true,
+}
+
+// SanitizeBasicHTML reduces s to plain HTML: paragraphs, line breaks, lists, headings and
+// emphasis without attributes. Other tags are dropped with their attributes, and script
+// and style elements with their content. Text without any tags has its blank-line
+// separated paragraphs wrapped in <p> and its remaining line breaks turned into <br>.
+func SanitizeBasicHTML(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, "<") {
+		var b strings.Builder
+		for _, para := range regexp.MustCompile(`\n\s*\n`).Split(strings.ReplaceAll(s, "\r\n", "\n"), -1) {
+			if para = strings.TrimSpace(para); para != "" {
+				b.WriteString("<p>" + strings.ReplaceAll(html.EscapeString(para), "\n", "<br>") + "</p>")
+			}
+		}
+		return b.String()
+	}
+
+	var b strings.Builder
+	skipping := 0 // Depth inside script and style elements
+	tokenizer := xhtml.NewTokenizer(strings.NewReader(s))
+	for {
+		tt := tokenizer.Next()
+		if tt == xhtml.ErrorToken {
+			return strings.TrimSpace(b.String())
+		}
+		token := tokenizer.Token()
+		switch tt {
+		case xhtml.StartTagToken, xhtml.SelfClosingTagToken:
+			if token.DataAtom == atom.Script || token.DataAtom == atom.Style {
+				if tt == xhtml.StartTagToken {
+					skipping++
+				}
+			} else if skipping == 0 && basicHTMLTags[token.DataAtom] {
+				b.WriteString("<" + token.Data + ">")
+			}
+		case xhtml.EndTagToken:
+			if token.DataAtom == atom.Script || token.DataAtom == atom.Style {
+				skipping = max(skipping-1, 0)
+			} else if skipping == 0 && basicHTMLTags[token.DataAtom] && token.DataAtom != atom.Br {
+				b.WriteString("</" + token.Data + ">")
+			}
+		case xhtml.TextToken:
+			if skipping == 0 {
+				b.WriteString(html.EscapeString(token.Data))
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strings"
@@ -12,36 +13,34 @@ func GenerateSlug(text string) string {
 	return slug.Make(text)
 }
 
-// GenerateSlugSimple generates a slug without uniqueness checking
-func GenerateSlugSimple(text string) string {
-	return GenerateSlug(text)
-}
+// maxSlugAttempts bounds how many numbered candidates are tried before giving up
+const maxSlugAttempts = 1000
 
-// GenerateUniqueSlug generates a unique slug by checking against existing slugs
-// checkFunc should return true if the slug already exists
-func GenerateUniqueSlug(text string, checkFunc func(string) bool) string {
-	baseSlug := GenerateSlug(text)
+// SlugExistsFunc reports whether a slug is already taken. Implementations should
+// include soft-deleted rows, since their slug still occupies the unique index.
+type SlugExistsFunc func(ctx context.Context, slug string) (bool, error)
 
-	// If base slug is unique, return it
-	if !checkFunc(baseSlug) {
-		return baseSlug
-	}
+// GenerateUniqueSlug generates a slug for text that exists does not report as taken,
+// trying the base slug first and then appending -2, -3, ... until a free one is found
+func GenerateUniqueSlug(ctx context.Context, text string, exists SlugExistsFunc) (string, error) {
+	baseSlug := GenerateSlug(text)
 
-	// Try appending numbers until find a unique slug
-	counter := 1
-	for {
-		newSlug := fmt.Sprintf("%s-%d", baseSlug, counter)
-		if !checkFunc(newSlug) {
-			return newSlug
+	candidate := baseSlug
+	for attempt := 1; attempt <= maxSlugAttempts; attempt++ {
+		if attempt > 1 {
+			candidate = fmt.Sprintf("%s-%d", baseSlug, attempt)
 		}
-		counter++
 
-		// Prevent infinite loop
-		if counter > 1000 {
-			// Fallback: append timestamp
-			return fmt.Sprintf("%s-%d", baseSlug, getCurrentTimestamp())
+		taken, err := exists(ctx, candidate)
+		if err != nil {
+			return "", fmt.Errorf("failed to check slug %q: %w", candidate, err)
+		}
+		if !taken {
+			return candidate, nil
 		}
 	}
+
+	return "", fmt.Errorf("no free slug for %q after %d attempts", baseSlug, maxSlugAttempts)
 }
 
 // SanitizeSlug sanitizes a slug by removing invalid characters
@@ -78,7 +77,3 @@ func ValidateSlug(slug string) bool {
 	match := regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`).MatchString(slug)
 	return match
 }
-
-func getCurrentTimestamp() int64 {
-	return 0 // Will be implemented when needed with time.Now().Unix()
-}
@@ -2,11 +2,13 @@ package utils
 
 import (
 	stdErrors "errors"
-	"fmt"
 	"regexp"
 	"strings"
 
+	"keerja-backend/internal/i18n"
+
 	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
 )
 
 var validate *validator.Validate
@@ -32,7 +34,13 @@ func ValidateStruct(s interface{}) error {
 	return v.Struct(s)
 }
 
-func FormatValidationErrors(err error) map[string]string {
+// FormatValidationErrors maps each invalid field to a message in the request locale
+func FormatValidationErrors(c *fiber.Ctx, err error) map[string]string {
+	return FormatValidationErrorsLocale(i18n.FromContext(c.UserContext()), err)
+}
+
+// FormatValidationErrorsLocale maps each invalid field to a message in locale
+func FormatValidationErrorsLocale(locale i18n.Locale, err error) map[string]string {
 	errors := make(map[string]string)
 
 	var validationErrors validator.ValidationErrors
@@ -42,36 +50,22 @@ func FormatValidationErrors(err error) map[string]string {
 
 	for _, err := range validationErrors {
 		field := strings.ToLower(err.Field())
-		errors[field] = getErrorMessage(err)
+		errors[field] = getErrorMessage(locale, err)
 	}
 
 	return errors
 }
 
-func getErrorMessage(err validator.FieldError) string {
+func getErrorMessage(locale i18n.Locale, err validator.FieldError) string {
 	field := err.Field()
 
 	switch err.Tag() {
-	case "required":
-		return fmt.Sprintf("%s is required", field)
-	case "email":
-		return fmt.Sprintf("%s must be a valid email address", field)
-	case "min":
-		return fmt.Sprintf("%s must be at least %s characters", field, err.Param())
-	case "max":
-		return fmt.Sprintf("%s must not exceed %s characters", field, err.Param())
-	case "gte":
-		return fmt.Sprintf("%s must be greater than or equal to %s", field, err.Param())
-	case "lte":
-		return fmt.Sprintf("%s must be less than or equal to %s", field, err.Param())
-	case "oneof":
-		return fmt.Sprintf("%s must be one of: %s", field, err.Param())
-	case "url":
-		return fmt.Sprintf("%s must be a valid URL", field)
-	case "uuid":
-		return fmt.Sprintf("%s must be a valid UUID", field)
+	case "required", "email", "url", "uuid":
+		return i18n.Translate(locale, "validation."+err.Tag(), field)
+	case "min", "max", "gte", "lte", "oneof", "required_without":
+		return i18n.Translate(locale, "validation."+err.Tag(), field, err.Param())
 	default:
-		return fmt.Sprintf("%s is invalid", field)
+		return i18n.Translate(locale, "validation.invalid", field)
 	}
 }
 
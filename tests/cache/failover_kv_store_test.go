@@ -0,0 +1,100 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+)
+
+// flakyStore is an in-memory primary that fails every call while down
+type flakyStore struct {
+	*cache.InMemoryKVStore
+	down atomic.Bool
+}
+
+var errConnRefused = errors.New("dial tcp: connection refused")
+
+func (s *flakyStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if s.down.Load() {
+		return nil, errConnRefused
+	}
+	return s.InMemoryKVStore.Get(ctx, key)
+}
+
+func (s *flakyStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if s.down.Load() {
+		return errConnRefused
+	}
+	return s.InMemoryKVStore.Set(ctx, key, value, ttl)
+}
+
+func (s *flakyStore) Ping(ctx context.Context) error {
+	if s.down.Load() {
+		return errConnRefused
+	}
+	return nil
+}
+
+func newFlakyFailover(t *testing.T, primaryUp bool) (*cache.FailoverKVStore, *flakyStore, *cache.InMemoryKVStore) {
+	t.Helper()
+
+	primary := &flakyStore{InMemoryKVStore: cache.NewInMemoryKVStore(time.Minute)}
+	primary.down.Store(!primaryUp)
+	fallback := cache.NewInMemoryKVStore(time.Minute)
+	t.Cleanup(primary.Stop)
+	t.Cleanup(fallback.Stop)
+	return cache.NewFailoverKVStore(primary, fallback, primaryUp), primary, fallback
+}
+
+func TestFailoverKVStore_FallsBackWhenPrimaryFails(t *testing.T) {
+	store, primary, fallback := newFlakyFailover(t, true)
+	ctx := context.Background()
+
+	require.NoError(t, store.Set(ctx, "a", []byte("redis"), time.Minute))
+	_, err := fallback.Get(ctx, "a")
+	assert.ErrorIs(t, err, cache.ErrKeyNotFound)
+
+	primary.down.Store(true)
+	require.NoError(t, store.Set(ctx, "b", []byte("memory"), time.Minute))
+	assert.True(t, store.Degraded())
+
+	value, err := store.Get(ctx, "b")
+	require.NoError(t, err)
+	assert.Equal(t, "memory", string(value))
+
+	stored, err := store.SetNX(ctx, "b", []byte("again"), time.Minute)
+	require.NoError(t, err)
+	assert.False(t, stored)
+}
+
+func TestFailoverKVStore_RunPromotesPrimaryWhenItRecovers(t *testing.T) {
+	store, primary, _ := newFlakyFailover(t, false)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.True(t, store.Degraded())
+	require.NoError(t, store.Set(ctx, "state", []byte("pending"), time.Minute))
+
+	go store.Run(ctx, 10*time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+	assert.True(t, store.Degraded(), "stays on the fallback while the primary is down")
+
+	primary.down.Store(false)
+	assert.Eventually(t, func() bool { return !store.Degraded() }, time.Second, 5*time.Millisecond)
+
+	// Values written during the outage are still readable after the switch back
+	value, err := store.Get(ctx, "state")
+	require.NoError(t, err)
+	assert.Equal(t, "pending", string(value))
+
+	require.NoError(t, store.Delete(ctx, "state"))
+	_, err = store.Get(ctx, "state")
+	assert.ErrorIs(t, err, cache.ErrKeyNotFound)
+}
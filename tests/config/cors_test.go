@@ -0,0 +1,66 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfgpkg "keerja-backend/internal/config"
+)
+
+func TestCORSOrigin_WildcardMatchesSubdomainsOnly(t *testing.T) {
+	origin, err := cfgpkg.ParseCORSOrigin("https://*.keerja.com")
+	require.NoError(t, err)
+
+	assert.True(t, origin.Matches("https://staging.keerja.com"))
+	assert.True(t, origin.Matches("https://app.demo.keerja.com"))
+	assert.True(t, origin.Matches("https://Staging.Keerja.com"), "hosts are case-insensitive")
+
+	assert.False(t, origin.Matches("https://keerja.com"), "the bare domain is not a subdomain")
+	assert.False(t, origin.Matches("https://evilkeerja.com"), "the match stops at a dot")
+	assert.False(t, origin.Matches("https://keerja.com.evil.io"))
+	assert.False(t, origin.Matches("http://staging.keerja.com"), "the scheme must match")
+	assert.False(t, origin.Matches("https://staging.keerja.com:8443"), "the port must match")
+	assert.False(t, origin.Matches("https://user@staging.keerja.com"))
+}
+
+func TestCORSOrigin_ExactMatch(t *testing.T) {
+	origin, err := cfgpkg.ParseCORSOrigin("http://localhost:3000")
+	require.NoError(t, err)
+
+	assert.True(t, origin.Matches("http://localhost:3000"))
+	assert.False(t, origin.Matches("http://localhost:3001"))
+	assert.False(t, origin.Matches("http://localhost"))
+	assert.False(t, origin.Matches("null"))
+}
+
+func TestCORSOrigin_RejectsMalformedPatterns(t *testing.T) {
+	for _, pattern := range []string{
+		"keerja.com",
+		"ftp://keerja.com",
+		"https://keerja.com/app",
+		"https://*.com",
+		"https://app.*.keerja.com",
+		"https://keerja.com:http",
+	} {
+		_, err := cfgpkg.ParseCORSOrigin(pattern)
+		assert.Error(t, err, pattern)
+	}
+}
+
+func TestValidateCORS_RejectsCredentialsWithAnyOrigin(t *testing.T) {
+	c := &cfgpkg.Config{
+		CORSAllowedOrigins:   []string{"https://keerja.com", "*"},
+		CORSAllowedMethods:   []string{"GET"},
+		CORSAllowCredentials: true,
+	}
+	assert.ErrorContains(t, c.ValidateCORS(), "CORS_ALLOW_CREDENTIALS")
+
+	c.CORSAllowCredentials = false
+	assert.NoError(t, c.ValidateCORS(), "* is fine without credentials")
+
+	c.CORSAllowCredentials = true
+	c.CORSAllowedOrigins = []string{"https://*.keerja.com"}
+	assert.NoError(t, c.ValidateCORS(), "subdomain wildcards may be used with credentials")
+}
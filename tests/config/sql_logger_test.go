@@ -0,0 +1,116 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	cfgpkg "keerja-backend/internal/config"
+	"keerja-backend/internal/metrics"
+)
+
+// requestContext returns a context of the request with the given ID
+func requestContext(id string) context.Context {
+	return cfgpkg.WithRequestInfo(context.Background(), cfgpkg.RequestInfo{ID: id, Route: "GET /api/v1/jobs/12"})
+}
+
+func query(sql string, rows int64) func() (string, int64) {
+	return func() (string, int64) { return sql, rows }
+}
+
+func TestSQLLogger_LogsSlowQueriesWithRequestID(t *testing.T) {
+	m := metrics.New()
+	metrics.SetDefault(m)
+	t.Cleanup(func() { metrics.SetDefault(nil) })
+
+	logger, hook := logtest.NewNullLogger()
+	logger.SetLevel(logrus.DebugLevel)
+	sqlLogger := cfgpkg.NewSQLLogger(logger, gormlogger.Warn, 200*time.Millisecond)
+
+	sqlLogger.Trace(requestContext("req-1"), time.Now().Add(-300*time.Millisecond), query("SELECT * FROM jobs WHERE id = 12", 1), nil)
+
+	require.Len(t, hook.AllEntries(), 1)
+	entry := hook.LastEntry()
+	assert.Equal(t, logrus.WarnLevel, entry.Level)
+	assert.Equal(t, "Slow SQL query", entry.Message)
+	assert.Equal(t, "req-1", entry.Data["request_id"])
+	assert.Equal(t, "GET /api/v1/jobs/12", entry.Data["route"])
+	assert.Equal(t, int64(1), entry.Data["rows"])
+	assert.Equal(t, "SELECT * FROM jobs WHERE id = 12", entry.Data["sql"])
+	assert.GreaterOrEqual(t, entry.Data["duration_ms"].(float64), 300.0)
+	assert.Equal(t, int64(200), entry.Data["threshold_ms"])
+
+	sqlLogger.Trace(context.Background(), time.Now(), query("SELECT 1", 1), nil)
+
+	app := fiber.New()
+	app.Get(metrics.Path, m.Handler())
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, metrics.Path, nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "keerja_db_slow_queries_total 1")
+}
+
+func TestSQLLogger_RespectsThresholdAndLevel(t *testing.T) {
+	logger, hook := logtest.NewNullLogger()
+	logger.SetLevel(logrus.DebugLevel)
+	ctx := requestContext("req-2")
+
+	warn := cfgpkg.NewSQLLogger(logger, gormlogger.Warn, 200*time.Millisecond)
+	warn.Trace(ctx, time.Now().Add(-50*time.Millisecond), query("SELECT 1", 1), nil)
+	warn.Trace(ctx, time.Now(), query("SELECT * FROM jobs WHERE id = 99", 0), gorm.ErrRecordNotFound)
+	assert.Empty(t, hook.AllEntries(), "fast queries and missing records are not logged at warn")
+
+	warn.Trace(ctx, time.Now(), query("INSERT INTO jobs", 0), errors.New("duplicate key"))
+	require.Len(t, hook.AllEntries(), 1)
+	assert.Equal(t, logrus.ErrorLevel, hook.LastEntry().Level)
+	assert.Equal(t, "req-2", hook.LastEntry().Data["request_id"])
+	hook.Reset()
+
+	disabled := cfgpkg.NewSQLLogger(logger, gormlogger.Warn, 0)
+	disabled.Trace(ctx, time.Now().Add(-time.Minute), query("SELECT 1", 1), nil)
+	assert.Empty(t, hook.AllEntries(), "a zero threshold disables slow query logging")
+
+	silent := warn.LogMode(gormlogger.Silent)
+	silent.Trace(ctx, time.Now().Add(-time.Second), query("SELECT 1", 1), errors.New("boom"))
+	assert.Empty(t, hook.AllEntries())
+
+	info := warn.LogMode(gormlogger.Info)
+	info.Trace(context.Background(), time.Now(), query("SELECT 1", 1), nil)
+	require.Len(t, hook.AllEntries(), 1)
+	assert.Equal(t, logrus.DebugLevel, hook.LastEntry().Level)
+	assert.NotContains(t, hook.LastEntry().Data, "request_id", "queries outside a request carry no request ID")
+}
+
+func TestSQLLogger_TruncatesLongStatements(t *testing.T) {
+	logger, hook := logtest.NewNullLogger()
+	sqlLogger := cfgpkg.NewSQLLogger(logger, gormlogger.Warn, time.Millisecond)
+
+	long := "INSERT INTO skills VALUES " + strings.Repeat("('go'),", 1000)
+	sqlLogger.Trace(requestContext("req-3"), time.Now().Add(-time.Second), query(long, 1000), nil)
+
+	require.Len(t, hook.AllEntries(), 1)
+	sql := hook.LastEntry().Data["sql"].(string)
+	assert.Less(t, len(sql), len(long))
+	assert.True(t, strings.HasPrefix(long, strings.SplitN(sql, "...", 2)[0]))
+}
+
+func TestParseSQLLogLevel(t *testing.T) {
+	assert.Equal(t, gormlogger.Silent, cfgpkg.ParseSQLLogLevel("silent", gormlogger.Warn))
+	assert.Equal(t, gormlogger.Info, cfgpkg.ParseSQLLogLevel(" INFO ", gormlogger.Warn))
+	assert.Equal(t, gormlogger.Warn, cfgpkg.ParseSQLLogLevel("", gormlogger.Warn))
+	assert.Equal(t, gormlogger.Error, cfgpkg.ParseSQLLogLevel("verbose", gormlogger.Error))
+}
@@ -0,0 +1,89 @@
+package migrator_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/database/migrator"
+)
+
+func writeFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("SELECT 1;"), 0o644))
+	}
+}
+
+func TestLoad_PairsAndSortsMigrations(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir,
+		"000002_add_index.up.sql", "000002_add_index.down.sql",
+		"000001_initial_schema.up.sql", "000001_initial_schema.down.sql",
+		"README.md",
+	)
+
+	migrations, err := migrator.Load(dir)
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "initial_schema", migrations[0].Name)
+	assert.Equal(t, int64(2), migrations[1].Version)
+	assert.NotEmpty(t, migrations[1].DownPath)
+}
+
+func TestLoad_RejectsMissingUpFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, "000001_orphan.down.sql")
+
+	_, err := migrator.Load(dir)
+	assert.Error(t, err)
+}
+
+func TestPlanUp_SkipsAppliedAndHonoursSteps(t *testing.T) {
+	all := []migrator.Migration{{Version: 1}, {Version: 2}, {Version: 3}, {Version: 4}}
+	applied := map[int64]time.Time{1: time.Now(), 3: time.Now()}
+
+	pending := migrator.PlanUp(all, applied, 0)
+	require.Len(t, pending, 2)
+	assert.Equal(t, int64(2), pending[0].Version)
+	assert.Equal(t, int64(4), pending[1].Version)
+
+	limited := migrator.PlanUp(all, applied, 1)
+	require.Len(t, limited, 1)
+	assert.Equal(t, int64(2), limited[0].Version)
+}
+
+func TestPlanDown_RollsBackNewestFirst(t *testing.T) {
+	all := []migrator.Migration{{Version: 1}, {Version: 2}, {Version: 3}}
+	applied := map[int64]time.Time{1: time.Now(), 2: time.Now()}
+
+	rollback := migrator.PlanDown(all, applied, 1)
+	require.Len(t, rollback, 1)
+	assert.Equal(t, int64(2), rollback[0].Version)
+
+	everything := migrator.PlanDown(all, applied, 0)
+	require.Len(t, everything, 2)
+	assert.Equal(t, int64(1), everything[1].Version)
+}
+
+func TestPlanTo_RollsBackNewerAndAppliesOlderPending(t *testing.T) {
+	all := []migrator.Migration{{Version: 1}, {Version: 2}, {Version: 3}, {Version: 4}}
+	applied := map[int64]time.Time{1: time.Now(), 3: time.Now(), 4: time.Now()}
+
+	down, up := migrator.PlanTo(all, applied, 2)
+	require.Len(t, down, 2)
+	assert.Equal(t, int64(4), down[0].Version)
+	assert.Equal(t, int64(3), down[1].Version)
+	require.Len(t, up, 1)
+	assert.Equal(t, int64(2), up[0].Version)
+
+	down, up = migrator.PlanTo(all, applied, 0)
+	assert.Len(t, down, 3)
+	assert.Empty(t, up)
+}
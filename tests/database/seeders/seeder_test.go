@@ -0,0 +1,149 @@
+package seeders_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"keerja-backend/database/seeders"
+)
+
+func seederNames(list []seeders.Seeder) []string {
+	names := make([]string, len(list))
+	for i, s := range list {
+		names[i] = s.Name
+	}
+	return names
+}
+
+func TestSelect_FiltersByNamePatternAndEnv(t *testing.T) {
+	registered := []seeders.Seeder{
+		{Name: "provinces", Production: true},
+		{Name: "skills", Production: true},
+		{Name: "industries", Production: true},
+		{Name: "admin_user"},
+		{Name: "demo_companies"},
+		{Name: "demo_users"},
+	}
+
+	tests := []struct {
+		name string
+		opts seeders.Options
+		want []string
+	}{
+		{"all", seeders.Options{}, []string{"provinces", "skills", "industries", "admin_user", "demo_companies", "demo_users"}},
+		{"only keeps run order", seeders.Options{Only: []string{"industries", "skills"}}, []string{"skills", "industries"}},
+		{"exclude glob", seeders.Options{Exclude: []string{"demo_*"}}, []string{"provinces", "skills", "industries", "admin_user"}},
+		{"only and exclude", seeders.Options{Only: []string{"demo_*"}, Exclude: []string{"demo_users"}}, []string{"demo_companies"}},
+		{"production", seeders.Options{Env: seeders.EnvProduction}, []string{"provinces", "skills", "industries"}},
+		{"production drops unsafe only", seeders.Options{Env: seeders.EnvProduction, Only: []string{"skills", "demo_*"}}, []string{"skills"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := seeders.Select(registered, tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, seederNames(selected))
+		})
+	}
+}
+
+func TestSelect_RejectsUnknownAndInvalidPatterns(t *testing.T) {
+	registered := []seeders.Seeder{{Name: "skills", Production: true}}
+
+	_, err := seeders.Select(registered, seeders.Options{Only: []string{"skils"}})
+	assert.ErrorContains(t, err, `no seeder matches "skils"`)
+
+	_, err = seeders.Select(registered, seeders.Options{Only: []string{"[skills"}})
+	assert.ErrorContains(t, err, "invalid seeder pattern")
+}
+
+func TestRegistered_ProductionSeedersExcludeDemoData(t *testing.T) {
+	for _, s := range seeders.Registered() {
+		if s.Name == "admin_user" || strings.HasPrefix(s.Name, "demo_") {
+			assert.False(t, s.Production, s.Name)
+		}
+		assert.Positive(t, s.Version, s.Name)
+	}
+}
+
+func setupSeedDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	url := os.Getenv("TEST_DB_URL")
+	if url == "" {
+		t.Skip("TEST_DB_URL not set; skipping postgres seeder tests")
+	}
+
+	db, err := gorm.Open(postgres.Open(url), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	tx := db.Begin()
+	require.NoError(t, tx.Error)
+	t.Cleanup(func() { tx.Rollback() })
+	return tx
+}
+
+func countRows(t *testing.T, db *gorm.DB, tables []string) map[string]int64 {
+	t.Helper()
+
+	counts := make(map[string]int64, len(tables))
+	for _, table := range tables {
+		var count int64
+		require.NoError(t, db.Table(table).Count(&count).Error)
+		counts[table] = count
+	}
+	return counts
+}
+
+func TestRun_MasterDataSeedersAreIdempotent(t *testing.T) {
+	db := setupSeedDB(t)
+	opts := seeders.Options{Env: seeders.EnvProduction, Force: true}
+	tables := []string{"provinces", "cities", "districts", "industries", "company_sizes", "education_levels", "job_titles", "skills_master", "job_subcategories"}
+
+	_, err := seeders.Run(db, opts)
+	require.NoError(t, err)
+	before := countRows(t, db, tables)
+
+	results, err := seeders.Run(db, opts)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	for _, r := range results {
+		assert.Zero(t, r.Rows, "%s changed rows on a second run", r.Name)
+	}
+	assert.Equal(t, before, countRows(t, db, tables))
+
+	// Without Force every seeder that ran is skipped
+	results, err = seeders.Run(db, seeders.Options{Env: seeders.EnvProduction})
+	require.NoError(t, err)
+	for _, r := range results {
+		assert.True(t, r.Skipped, r.Name)
+	}
+}
+
+func TestRun_DryRunChangesNothing(t *testing.T) {
+	db := setupSeedDB(t)
+	_, err := seeders.Run(db, seeders.Options{Only: []string{"industries"}, Force: true})
+	require.NoError(t, err)
+	require.NoError(t, db.Exec("DELETE FROM seed_runs WHERE seeder_name = ?", "industries").Error)
+	require.NoError(t, db.Exec("UPDATE industries SET description = 'changed' WHERE slug = 'technology'").Error)
+
+	results, err := seeders.Run(db, seeders.Options{Only: []string{"industries"}, DryRun: true})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Positive(t, results[0].Rows)
+
+	var description string
+	require.NoError(t, db.Raw("SELECT description FROM industries WHERE slug = 'technology'").Scan(&description).Error)
+	assert.Equal(t, "changed", description)
+
+	var runs int64
+	require.NoError(t, db.Table("seed_runs").Where("seeder_name = ?", "industries").Count(&runs).Error)
+	assert.Zero(t, runs)
+}
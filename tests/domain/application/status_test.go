@@ -0,0 +1,51 @@
+package application_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"keerja-backend/internal/domain/application"
+)
+
+// allowedApplicationTransitions is every status change an open application may make
+var allowedApplicationTransitions = map[string][]string{
+	application.StatusApplied:     {application.StatusScreening, application.StatusShortlisted, application.StatusInterview, application.StatusOffered, application.StatusRejected, application.StatusWithdrawn, application.StatusJobWithdrawn},
+	application.StatusScreening:   {application.StatusShortlisted, application.StatusInterview, application.StatusOffered, application.StatusRejected, application.StatusWithdrawn, application.StatusJobWithdrawn},
+	application.StatusShortlisted: {application.StatusInterview, application.StatusOffered, application.StatusRejected, application.StatusWithdrawn, application.StatusJobWithdrawn},
+	application.StatusInterview:   {application.StatusOffered, application.StatusHired, application.StatusRejected, application.StatusWithdrawn, application.StatusJobWithdrawn},
+	application.StatusOffered:     {application.StatusHired, application.StatusRejected, application.StatusJobWithdrawn},
+}
+
+func TestIsValidTransition_EveryStatusPair(t *testing.T) {
+	for _, from := range application.Statuses {
+		for _, to := range application.Statuses {
+			want := slices.Contains(allowedApplicationTransitions[from], to)
+			assert.Equal(t, want, application.IsValidTransition(from, to), "%s -> %s", from, to)
+		}
+	}
+}
+
+func TestIsValidTransition_FinalStatusesHaveNoExits(t *testing.T) {
+	for _, from := range []string{application.StatusHired, application.StatusRejected, application.StatusWithdrawn, application.StatusJobWithdrawn} {
+		for _, to := range application.Statuses {
+			assert.False(t, application.IsValidTransition(from, to), "%s -> %s", from, to)
+		}
+	}
+}
+
+func TestIsValidStatus(t *testing.T) {
+	for _, status := range application.Statuses {
+		assert.True(t, application.IsValidStatus(status), status)
+	}
+	assert.False(t, application.IsValidStatus("pending"))
+	assert.False(t, application.IsValidStatus(""))
+}
+
+func TestRevealsContactDetails(t *testing.T) {
+	revealed := []string{application.StatusShortlisted, application.StatusInterview, application.StatusOffered, application.StatusHired}
+	for _, status := range application.Statuses {
+		assert.Equal(t, slices.Contains(revealed, status), application.RevealsContactDetails(status), status)
+	}
+}
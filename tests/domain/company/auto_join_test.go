@@ -0,0 +1,40 @@
+package company_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"keerja-backend/internal/domain/company"
+)
+
+func TestEmailDomainOf(t *testing.T) {
+	assert.Equal(t, "acme.co.id", company.EmailDomainOf("Budi@ACME.co.id"))
+	assert.Equal(t, "acme.com", company.EmailDomainOf("odd@name@acme.com"))
+	assert.Equal(t, "", company.EmailDomainOf("no-at-sign"))
+	assert.Equal(t, "acme.com", company.NormalizeEmailDomain(" @Acme.COM. "))
+}
+
+func TestIsFreeEmailDomain(t *testing.T) {
+	for _, domain := range []string{"gmail.com", "yahoo.com", "yahoo.co.id", "outlook.com", "icloud.com", "@GMAIL.com"} {
+		assert.True(t, company.IsFreeEmailDomain(domain), domain)
+	}
+	for _, domain := range []string{"acme.co.id", "mail.acme.com", "gmail.com.evil.io", ""} {
+		assert.False(t, company.IsFreeEmailDomain(domain), domain)
+	}
+}
+
+func TestDomainVerification_IsVerifiedFor(t *testing.T) {
+	var none *company.DomainVerification
+	assert.False(t, none.IsVerifiedFor("acme.com"))
+
+	now := time.Now()
+	v := &company.DomainVerification{Domain: "acme.com", Token: "abc"}
+	assert.False(t, v.IsVerifiedFor("acme.com"))
+	assert.Equal(t, "keerja-domain-verification=abc", v.TXTRecordValue())
+
+	v.VerifiedAt = &now
+	assert.True(t, v.IsVerifiedFor("ACME.com"))
+	assert.False(t, v.IsVerifiedFor("acme.co.id"), "a new email domain needs a new verification")
+}
@@ -0,0 +1,56 @@
+package company_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"keerja-backend/internal/domain/company"
+)
+
+func assertTransitions(t *testing.T, statuses []string, allowed map[string][]string, isValid func(from, to string) bool) {
+	t.Helper()
+	for _, from := range statuses {
+		for _, to := range statuses {
+			want := slices.Contains(allowed[from], to)
+			assert.Equal(t, want, isValid(from, to), "%s -> %s", from, to)
+		}
+	}
+}
+
+func TestIsValidReviewTransition(t *testing.T) {
+	assertTransitions(t, company.ReviewStatuses, map[string][]string{
+		company.ReviewStatusPending:  {company.ReviewStatusApproved, company.ReviewStatusRejected, company.ReviewStatusHidden},
+		company.ReviewStatusApproved: {company.ReviewStatusRejected, company.ReviewStatusHidden},
+		company.ReviewStatusRejected: {company.ReviewStatusApproved},
+		company.ReviewStatusHidden:   {company.ReviewStatusApproved, company.ReviewStatusRejected},
+	}, company.IsValidReviewTransition)
+}
+
+func TestIsValidVerificationTransition(t *testing.T) {
+	assertTransitions(t, company.VerificationStatuses, map[string][]string{
+		company.VerificationStatusPending:     {company.VerificationStatusUnderReview, company.VerificationStatusVerified, company.VerificationStatusRejected, company.VerificationStatusSuspended, company.VerificationStatusBlacklisted},
+		company.VerificationStatusUnderReview: {company.VerificationStatusVerified, company.VerificationStatusRejected, company.VerificationStatusSuspended, company.VerificationStatusBlacklisted},
+		company.VerificationStatusVerified:    {company.VerificationStatusPending, company.VerificationStatusRejected, company.VerificationStatusSuspended, company.VerificationStatusBlacklisted, company.VerificationStatusExpired},
+		company.VerificationStatusRejected:    {company.VerificationStatusPending, company.VerificationStatusUnderReview, company.VerificationStatusVerified, company.VerificationStatusBlacklisted},
+		company.VerificationStatusSuspended:   {company.VerificationStatusPending, company.VerificationStatusVerified, company.VerificationStatusRejected, company.VerificationStatusBlacklisted},
+		company.VerificationStatusExpired:     {company.VerificationStatusPending, company.VerificationStatusVerified, company.VerificationStatusRejected, company.VerificationStatusBlacklisted},
+		company.VerificationStatusBlacklisted: {company.VerificationStatusVerified, company.VerificationStatusRejected},
+	}, company.IsValidVerificationTransition)
+}
+
+func TestIsValidInvitationTransition(t *testing.T) {
+	assertTransitions(t, company.InvitationStatuses, map[string][]string{
+		company.InvitationStatusPending: {company.InvitationStatusAccepted, company.InvitationStatusRejected, company.InvitationStatusExpired},
+	}, company.IsValidInvitationTransition)
+}
+
+func TestIsValidStatuses(t *testing.T) {
+	assert.True(t, company.IsValidReviewStatus(company.ReviewStatusHidden))
+	assert.False(t, company.IsValidReviewStatus("published"))
+	assert.True(t, company.IsValidVerificationStatus(company.VerificationStatusSuspended))
+	assert.False(t, company.IsValidVerificationStatus("approved"))
+	assert.True(t, company.IsValidInvitationStatus(company.InvitationStatusExpired))
+	assert.False(t, company.IsValidInvitationStatus("cancelled"))
+}
@@ -0,0 +1,77 @@
+package email_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/i18n"
+)
+
+func TestRenderTemplate_BothLocales(t *testing.T) {
+	data := email.TemplateData{Name: "Sari", OTPCode: "123456", ExpiryMinutes: "5"}
+
+	id, err := email.RenderTemplate(email.TemplateOTPRegistration, i18n.Indonesian, data)
+	require.NoError(t, err)
+	assert.Contains(t, id, "Kode OTP ini akan kadaluarsa dalam 5 menit")
+	assert.Contains(t, id, "123456")
+
+	en, err := email.RenderTemplate(email.TemplateOTPRegistration, i18n.English, data)
+	require.NoError(t, err)
+	assert.Contains(t, en, "This OTP code expires in 5 minutes")
+	assert.Contains(t, en, "123456")
+
+	assert.Equal(t, "Verifikasi Email Registrasi - Keerja", email.GetSubject(email.TemplateOTPRegistration, i18n.Indonesian))
+	assert.Equal(t, "Verify Your Registration Email - Keerja", email.GetSubject(email.TemplateOTPRegistration, i18n.English))
+}
+
+func TestRenderTemplate_UntranslatedTemplateFallsBackToIndonesian(t *testing.T) {
+	body, err := email.RenderTemplate(email.TemplateJobReviewed, i18n.English, email.TemplateData{Name: "Sari", JobTitle: "Backend Engineer"})
+	require.NoError(t, err)
+	assert.Contains(t, body, "Backend Engineer")
+
+	assert.Equal(t, "Hasil Review Lowongan - Keerja", email.GetSubject(email.TemplateJobReviewed, i18n.English))
+	assert.Equal(t, "Notification from Keerja", email.GetSubject(email.EmailTemplate("unknown"), i18n.English))
+}
+
+func TestRenderTemplate_UnknownTemplateErrors(t *testing.T) {
+	_, err := email.RenderTemplate(email.EmailTemplate("unknown"), i18n.English, email.TemplateData{})
+	assert.Error(t, err)
+}
+
+func TestRenderTemplate_JobExpiryReminderAutoExtend(t *testing.T) {
+	data := email.TemplateData{Name: "Sari", JobTitle: "Backend Engineer", ExpiryDays: "3"}
+
+	body, err := email.RenderTemplate(email.TemplateJobExpiryReminder, i18n.Indonesian, data)
+	require.NoError(t, err)
+	assert.Contains(t, body, "Perpanjang lowongan jika Anda masih mencari kandidat")
+
+	data.AutoExtend = true
+	body, err = email.RenderTemplate(email.TemplateJobExpiryReminder, i18n.Indonesian, data)
+	require.NoError(t, err)
+	assert.Contains(t, body, "Perpanjangan otomatis aktif")
+}
+
+func TestRenderTemplate_WeeklyDigestLinksEachSection(t *testing.T) {
+	data := email.TemplateData{Name: "Sari", CompanyName: "Acme", Digest: &email.WeeklyDigest{
+		Jobs:              []email.WeeklyDigestJob{{Title: "Backend Engineer", NewApplications: 4, AwaitingAction: 2, URL: "https://app.keerja.com/jobs/10/applications"}},
+		AwaitingAction:    2,
+		AwaitingActionURL: "https://app.keerja.com/applications?status=applied",
+		Interviews:        []email.WeeklyDigestInterview{{CandidateName: "Ana", JobTitle: "Backend Engineer", Date: "Senin, 19 Oktober 2026 09:00 UTC", URL: "https://app.keerja.com/applications/70"}},
+		TotalFollowers:    40,
+		FollowersURL:      "https://app.keerja.com/company/followers",
+	}}
+
+	body, err := email.RenderTemplate(email.TemplateWeeklyDigest, i18n.Indonesian, data)
+	require.NoError(t, err)
+	assert.Contains(t, body, `href="https://app.keerja.com/jobs/10/applications"`)
+	assert.Contains(t, body, "4 lamaran baru, 2 menunggu tindakan")
+	assert.Contains(t, body, `href="https://app.keerja.com/applications?status=applied"`)
+	assert.Contains(t, body, "Senin, 19 Oktober 2026 09:00 UTC")
+	assert.Contains(t, body, "tidak ada yang baru")
+	assert.NotContains(t, body, "Lowongan Segera Berakhir")
+
+	assert.Equal(t, "Your Weekly Hiring Digest - Keerja", email.GetSubject(email.TemplateWeeklyDigest, i18n.English))
+}
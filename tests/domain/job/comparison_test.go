@@ -0,0 +1,40 @@
+package job_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/job"
+)
+
+func TestParseCompareJobIDs(t *testing.T) {
+	ids, err := job.ParseCompareJobIDs(" 3, 1,3,,2 ")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3, 1, 2}, ids)
+
+	for _, raw := range []string{"", ",", "1,2,3,4", "1,abc", "0", "-5"} {
+		_, err := job.ParseCompareJobIDs(raw)
+		assert.ErrorIs(t, err, job.ErrInvalidCompareJobIDs, raw)
+	}
+}
+
+func TestPublicSalary_FollowsSalaryDisplay(t *testing.T) {
+	minSalary, maxSalary := 5000000.0, 9000000.0
+	j := &job.Job{SalaryMin: &minSalary, SalaryMax: &maxSalary}
+
+	for display, want := range map[string][2]*float64{
+		"range":       {&minSalary, &maxSalary},
+		"min_only":    {&minSalary, nil},
+		"max_only":    {nil, &maxSalary},
+		"negotiable":  {nil, nil},
+		"competitive": {nil, nil},
+		"hidden":      {nil, nil},
+	} {
+		j.SalaryDisplay = display
+		gotMin, gotMax := j.PublicSalary()
+		assert.Equal(t, want[0], gotMin, display)
+		assert.Equal(t, want[1], gotMax, display)
+	}
+}
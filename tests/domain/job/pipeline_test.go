@@ -0,0 +1,63 @@
+package job_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/job"
+)
+
+func pipelineNames(stages []job.JobPipelineStage) []string {
+	names := make([]string, len(stages))
+	for i, stage := range stages {
+		names[i] = stage.Name
+	}
+	return names
+}
+
+func TestBuildPipeline_PlacesCustomStagesAfterTheirStatus(t *testing.T) {
+	stages, err := job.BuildPipeline(10, []job.CustomPipelineStage{
+		{Name: "Reference Check", MapsToStatus: "offered"},
+		{Name: " Technical Test ", MapsToStatus: "shortlisted"},
+		{Name: "Portfolio Review", MapsToStatus: "shortlisted"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"applied", "screening", "shortlisted", "Technical Test", "Portfolio Review",
+		"interview", "offered", "Reference Check", "hired",
+	}, pipelineNames(stages))
+	for i, stage := range stages {
+		assert.Equal(t, int64(10), stage.JobID)
+		assert.Equal(t, i+1, stage.StageOrder)
+	}
+	assert.Equal(t, "shortlisted", stages[3].MapsToStatus)
+	assert.True(t, stages[3].IsCustom())
+	assert.False(t, stages[2].IsCustom())
+
+	assert.Equal(t, "Technical Test", job.FindPipelineStage(stages, "technical test").Name)
+	assert.Nil(t, job.FindPipelineStage(stages, "Culture Fit"))
+}
+
+func TestBuildPipeline_RejectsInvalidCustomStages(t *testing.T) {
+	cases := map[string][]job.CustomPipelineStage{
+		"blank name":       {{Name: "  ", MapsToStatus: "screening"}},
+		"status name":      {{Name: "Interview", MapsToStatus: "screening"}},
+		"duplicate name":   {{Name: "Technical Test", MapsToStatus: "screening"}, {Name: "technical test", MapsToStatus: "interview"}},
+		"maps to hired":    {{Name: "Onboarding", MapsToStatus: "hired"}},
+		"maps to rejected": {{Name: "Archive", MapsToStatus: "rejected"}},
+	}
+	for name, custom := range cases {
+		_, err := job.BuildPipeline(10, custom)
+		assert.ErrorIs(t, err, job.ErrInvalidPipeline, name)
+	}
+
+	tooMany := make([]job.CustomPipelineStage, job.MaxCustomPipelineStages+1)
+	for i := range tooMany {
+		tooMany[i] = job.CustomPipelineStage{Name: string(rune('A' + i)), MapsToStatus: "screening"}
+	}
+	_, err := job.BuildPipeline(10, tooMany)
+	assert.ErrorIs(t, err, job.ErrInvalidPipeline)
+}
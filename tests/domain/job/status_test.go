@@ -0,0 +1,65 @@
+package job_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"keerja-backend/internal/domain/job"
+)
+
+// allowedJobTransitions is every job status change the platform makes
+var allowedJobTransitions = map[string][]string{
+	job.StatusInReview:      {job.StatusDraft, job.StatusRejected},
+	job.StatusDraft:         {job.StatusPendingReview, job.StatusPublished, job.StatusInactive, job.StatusClosed},
+	job.StatusPendingReview: {job.StatusPublished, job.StatusDraft, job.StatusRejected, job.StatusSuspended},
+	job.StatusPublished:     {job.StatusDraft, job.StatusInactive, job.StatusClosed, job.StatusExpired, job.StatusSuspended},
+	job.StatusInactive:      {job.StatusDraft, job.StatusPublished, job.StatusClosed},
+	job.StatusClosed:        {job.StatusDraft, job.StatusPublished},
+	job.StatusExpired:       {job.StatusDraft, job.StatusPublished, job.StatusClosed},
+	job.StatusSuspended:     {job.StatusDraft, job.StatusPublished, job.StatusClosed},
+	job.StatusRejected:      {job.StatusDraft},
+}
+
+func TestIsValidTransition_EveryStatusPair(t *testing.T) {
+	for _, from := range job.Statuses {
+		for _, to := range job.Statuses {
+			want := slices.Contains(allowedJobTransitions[from], to)
+			assert.Equal(t, want, job.IsValidTransition(from, to), "%s -> %s", from, to)
+		}
+	}
+}
+
+func TestIsValidTransition_UnknownStatuses(t *testing.T) {
+	assert.False(t, job.IsValidTransition("active", job.StatusPublished))
+	assert.False(t, job.IsValidTransition(job.StatusDraft, "publised"))
+	assert.False(t, job.IsValidTransition("", job.StatusDraft))
+}
+
+func TestIsValidStatus(t *testing.T) {
+	for _, status := range job.Statuses {
+		assert.True(t, job.IsValidStatus(status), status)
+	}
+	assert.False(t, job.IsValidStatus("active"))
+	assert.False(t, job.IsValidStatus(""))
+}
+
+func TestTabStatuses_CoverListedStatusesOnce(t *testing.T) {
+	assert.Equal(t, job.TabActive, job.TabForStatus(job.StatusPublished))
+	assert.Equal(t, job.TabInactive, job.TabForStatus(job.StatusClosed))
+	assert.Equal(t, job.TabInactive, job.TabForStatus(job.StatusInactive))
+	assert.Equal(t, job.TabDraft, job.TabForStatus(job.StatusDraft))
+	assert.Equal(t, job.TabInReview, job.TabForStatus(job.StatusPendingReview))
+	assert.Equal(t, job.TabInReview, job.TabForStatus(job.StatusInReview))
+	assert.Empty(t, job.TabForStatus(job.StatusSuspended))
+
+	seen := map[string]string{}
+	for tab, statuses := range job.TabStatuses {
+		for _, status := range statuses {
+			assert.True(t, job.IsValidStatus(status), status)
+			assert.NotContains(t, seen, status, "%s is shown in %s and %s", status, seen[status], tab)
+			seen[status] = tab
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package user_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"keerja-backend/internal/domain/user"
+)
+
+func TestUserPreference_VisibilityPolicy(t *testing.T) {
+	var none *user.UserPreference
+	assert.False(t, none.IsProfilePrivate())
+	assert.True(t, none.IsDiscoverable())
+	assert.True(t, none.AcceptsDirectMessages())
+
+	for _, tc := range []struct {
+		pref         user.UserPreference
+		private      bool
+		discoverable bool
+	}{
+		{user.UserPreference{ProfileVisibility: user.ProfileVisibilityPublic, AllowTalentPools: true}, false, true},
+		{user.UserPreference{ProfileVisibility: user.ProfileVisibilityRecruiterOnly, AllowTalentPools: true}, false, true},
+		{user.UserPreference{ProfileVisibility: user.ProfileVisibilityPrivate, AllowTalentPools: true}, true, false},
+		{user.UserPreference{ProfileVisibility: user.ProfileVisibilityPublic, AllowTalentPools: false}, false, false},
+	} {
+		assert.Equal(t, tc.private, tc.pref.IsProfilePrivate(), tc.pref.ProfileVisibility)
+		assert.Equal(t, tc.discoverable, tc.pref.IsDiscoverable(), "%s/%v", tc.pref.ProfileVisibility, tc.pref.AllowTalentPools)
+	}
+	assert.False(t, (&user.UserPreference{AllowDirectMessages: false}).AcceptsDirectMessages())
+}
@@ -0,0 +1,36 @@
+package request_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"keerja-backend/internal/dto/request"
+	"keerja-backend/internal/i18n"
+	"keerja-backend/internal/utils"
+)
+
+func reviewErrors(req request.AddReviewRequest) map[string]string {
+	return utils.FormatValidationErrorsLocale(i18n.English, utils.ValidateStruct(&req))
+}
+
+func TestAddReviewRequest_ContentRules(t *testing.T) {
+	long := strings.Repeat("great team ", 2)
+	short := "nice"
+
+	assert.Empty(t, reviewErrors(request.AddReviewRequest{RatingOverall: 4, Pros: &long}))
+	assert.Empty(t, reviewErrors(request.AddReviewRequest{RatingOverall: 1, Cons: &long}))
+
+	errs := reviewErrors(request.AddReviewRequest{RatingOverall: 4})
+	assert.Equal(t, "Pros is required when Cons is empty", errs["pros"])
+	assert.Equal(t, "Cons is required when Pros is empty", errs["cons"])
+
+	errs = reviewErrors(request.AddReviewRequest{RatingOverall: 4, Pros: &short})
+	assert.Equal(t, "Pros must be at least 20 characters", errs["pros"])
+
+	errs = reviewErrors(request.AddReviewRequest{RatingOverall: 6, Pros: &long})
+	assert.Contains(t, errs, "ratingoverall")
+	errs = reviewErrors(request.AddReviewRequest{RatingOverall: 0.5, Pros: &long})
+	assert.Contains(t, errs, "ratingoverall")
+}
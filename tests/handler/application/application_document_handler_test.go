@@ -0,0 +1,126 @@
+package application_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	apphandler "keerja-backend/internal/handler/http/application"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+)
+
+type documentApplicationRepo struct {
+	application.ApplicationRepository
+
+	docs []application.ApplicationDocument
+}
+
+func (r *documentApplicationRepo) FindByID(ctx context.Context, id int64) (*application.JobApplication, error) {
+	return &application.JobApplication{ID: id, UserID: 7}, nil
+}
+
+func (r *documentApplicationRepo) CreateDocument(ctx context.Context, doc *application.ApplicationDocument) error {
+	doc.ID = int64(len(r.docs) + 1)
+	r.docs = append(r.docs, *doc)
+	return nil
+}
+
+func newDocumentApp(t *testing.T) (*fiber.App, *documentApplicationRepo) {
+	t.Helper()
+
+	repo := &documentApplicationRepo{}
+	uploads := service.NewUploadService(service.UploadServiceConfig{StorageProvider: "local", UploadPath: t.TempDir()})
+	svc := service.NewApplicationService(repo, nil, nil, nil, nil, nil, nil, application.DefaultReapplyPolicy, uploads, false, nil, nil)
+	handler := apphandler.NewApplicationHandler(svc, nil)
+
+	app := fiber.New(fiber.Config{BodyLimit: 32 * 1024 * 1024})
+	app.Use(middleware.ErrorHandler(false))
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(middleware.ContextKeyUserID, int64(7))
+		return c.Next()
+	})
+	app.Post("/applications/:id/documents", handler.UploadDocument)
+	return app, repo
+}
+
+func postDocument(t *testing.T, app *fiber.App, fileName string, size int) (int, utils.CodedErrorResponseBody) {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	require.NoError(t, writer.WriteField("document_type", "portfolio"))
+	if fileName != "" {
+		part, err := writer.CreateFormFile("file", fileName)
+		require.NoError(t, err)
+		_, err = part.Write(bytes.Repeat([]byte("a"), size))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(fiber.MethodPost, "/applications/1/documents", body)
+	req.Header.Set(fiber.HeaderContentType, writer.FormDataContentType())
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var payload utils.CodedErrorResponseBody
+	require.NoError(t, json.Unmarshal(raw, &payload), string(raw))
+	return resp.StatusCode, payload
+}
+
+func TestUploadDocument_RejectsOversizedFile(t *testing.T) {
+	app, repo := newDocumentApp(t)
+
+	status, payload := postDocument(t, app, "portfolio.pdf", int(service.MaxDocumentSize)+1)
+
+	assert.Equal(t, fiber.StatusBadRequest, status)
+	assert.Equal(t, "DOCUMENT_TOO_LARGE", payload.Code)
+	assert.Empty(t, repo.docs)
+}
+
+func TestUploadDocument_RejectsDisallowedType(t *testing.T) {
+	app, repo := newDocumentApp(t)
+
+	status, payload := postDocument(t, app, "portfolio.exe", 128)
+
+	assert.Equal(t, fiber.StatusBadRequest, status)
+	assert.Equal(t, "DOCUMENT_TYPE_NOT_ALLOWED", payload.Code)
+	assert.Empty(t, repo.docs)
+}
+
+func TestUploadDocument_RequiresFileWhenURLsDisabled(t *testing.T) {
+	app, repo := newDocumentApp(t)
+
+	status, payload := postDocument(t, app, "", 0)
+
+	assert.Equal(t, fiber.StatusBadRequest, status)
+	assert.Equal(t, "DOCUMENT_FILE_REQUIRED", payload.Code)
+	assert.Empty(t, repo.docs)
+}
+
+func TestUploadDocument_StoresUploadedFile(t *testing.T) {
+	app, repo := newDocumentApp(t)
+
+	status, _ := postDocument(t, app, "portfolio.pdf", 1024)
+
+	assert.Equal(t, fiber.StatusCreated, status)
+	require.Len(t, repo.docs, 1)
+	assert.True(t, repo.docs[0].Uploaded)
+	assert.Equal(t, "portfolio.pdf", repo.docs[0].FileName)
+	assert.Equal(t, "pdf", repo.docs[0].FileType)
+	assert.EqualValues(t, 1024, repo.docs[0].FileSize)
+	assert.NotEmpty(t, repo.docs[0].FileURL)
+}
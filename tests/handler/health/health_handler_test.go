@@ -0,0 +1,88 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/handler/http/health"
+	"keerja-backend/internal/jobs"
+)
+
+type failingStorage struct{}
+
+func (failingStorage) HealthCheck(ctx context.Context) error {
+	return errors.New("read-only file system")
+}
+
+type stoppedScheduler struct{}
+
+func (stoppedScheduler) Status() jobs.SchedulerStatus {
+	return jobs.SchedulerStatus{}
+}
+
+func TestReadiness_ReportsComponentsAndTimesOutHungChecks(t *testing.T) {
+	handler := health.NewHealthHandler(nil, nil, "1.2.3", "abc123")
+	handler.SetCheckTimeout(50 * time.Millisecond)
+	handler.AddCheck("upload_storage", health.StorageCheck(failingStorage{}))
+	handler.AddCheck("scheduler", health.SchedulerCheck(stoppedScheduler{}, time.Minute))
+	handler.AddCheck("hung", func(ctx context.Context) health.ComponentHealth {
+		time.Sleep(2 * time.Second)
+		return health.ComponentHealth{Status: health.StatusOK}
+	})
+
+	app := fiber.New()
+	app.Get("/health/ready", handler.Readiness)
+
+	start := time.Now()
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/health/ready", nil), -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Less(t, time.Since(start), time.Second)
+
+	var body health.ReadinessResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+	// The database and Redis are not configured, so the service is not ready
+	assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+	assert.False(t, body.Ready)
+	assert.Equal(t, health.StatusDown, body.Status)
+	assert.Equal(t, "1.2.3", body.Version)
+	assert.Equal(t, "abc123", body.GitSHA)
+
+	assert.Equal(t, health.StatusDown, body.Components["database"].Status)
+	assert.True(t, body.Components["database"].Critical)
+	assert.Equal(t, health.StatusDegraded, body.Components["upload_storage"].Status)
+	assert.Equal(t, health.StatusDown, body.Components["scheduler"].Status)
+	assert.False(t, body.Components["scheduler"].Critical)
+	assert.Equal(t, health.StatusDown, body.Components["hung"].Status)
+	assert.Contains(t, body.Components["hung"].Message, "timed out")
+	assert.NotEmpty(t, body.Components["hung"].Latency)
+}
+
+func TestReadiness_OptionalRedisOnFallbackIsDegraded(t *testing.T) {
+	fallback := cache.NewInMemoryKVStore(time.Minute)
+	t.Cleanup(fallback.Stop)
+	handler := health.NewHealthHandler(nil, cache.NewFailoverKVStore(nil, fallback, false), "1.2.3", "abc123")
+
+	app := fiber.New()
+	app.Get("/health/ready", handler.Readiness)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/health/ready", nil), -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var body health.ReadinessResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+	assert.Equal(t, health.StatusDegraded, body.Components["redis"].Status)
+	assert.False(t, body.Components["redis"].Critical)
+}
@@ -0,0 +1,87 @@
+package upload_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/config"
+	"keerja-backend/internal/handler/http/upload"
+	"keerja-backend/internal/routes"
+	"keerja-backend/internal/service"
+)
+
+func newDownloadApp(t *testing.T) (*fiber.App, *service.DownloadSigner) {
+	t.Helper()
+
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "documents", "npwp"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "documents", "npwp", "a.pdf"), []byte("npwp-bytes"), 0644))
+
+	uploads := service.NewUploadService(service.UploadServiceConfig{StorageProvider: "local", UploadPath: root})
+	signer := service.NewDownloadSigner("secret", time.Minute)
+
+	app := fiber.New()
+	routes.SetupDownloadRoutes(app, &routes.Dependencies{DownloadHandler: upload.NewDownloadHandler(signer, uploads)})
+	return app, signer
+}
+
+func getDownload(t *testing.T, app *fiber.App, token string) (int, string, map[string]string) {
+	t.Helper()
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, config.DownloadRoutePrefix+"/"+token, nil), -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	headers := map[string]string{}
+	for _, h := range []string{fiber.HeaderContentDisposition, fiber.HeaderCacheControl, fiber.HeaderContentType} {
+		headers[h] = resp.Header.Get(h)
+	}
+	return resp.StatusCode, string(body), headers
+}
+
+func TestDownload_StreamsSignedFileAsAttachment(t *testing.T) {
+	app, signer := newDownloadApp(t)
+	token, _ := signer.Sign("documents/npwp/a.pdf", "NPWP-10.pdf")
+
+	status, body, headers := getDownload(t, app, token)
+
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "npwp-bytes", body)
+	assert.Equal(t, `attachment; filename=NPWP-10.pdf`, headers[fiber.HeaderContentDisposition])
+	assert.Equal(t, "private, no-store", headers[fiber.HeaderCacheControl])
+	assert.Equal(t, "application/pdf", headers[fiber.HeaderContentType])
+}
+
+func TestDownload_RejectsExpiredTamperedAndMissing(t *testing.T) {
+	app, signer := newDownloadApp(t)
+
+	expired := signer.SignUntil("documents/npwp/a.pdf", "a.pdf", time.Now().Add(-time.Second))
+	status, body, _ := getDownload(t, app, expired)
+	assert.Equal(t, fiber.StatusForbidden, status)
+	assert.Contains(t, body, "expired")
+
+	valid, _ := signer.Sign("documents/npwp/a.pdf", "a.pdf")
+	forged := service.NewDownloadSigner("other", time.Minute).SignUntil("documents/npwp/a.pdf", "a.pdf", time.Now().Add(time.Minute))
+	payload, _, _ := strings.Cut(valid, ".")
+	for _, token := range []string{forged, payload + ".AAAA", "garbage"} {
+		status, _, _ := getDownload(t, app, token)
+		assert.Equal(t, fiber.StatusForbidden, status, token)
+	}
+
+	for _, filePath := range []string{"documents/npwp/missing.pdf", "../../etc/passwd"} {
+		token, _ := signer.Sign(filePath, "x.pdf")
+		status, _, _ := getDownload(t, app, token)
+		assert.Equal(t, fiber.StatusNotFound, status, filePath)
+	}
+}
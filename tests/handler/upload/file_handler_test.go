@@ -0,0 +1,94 @@
+package upload_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/handler/http/upload"
+	"keerja-backend/internal/routes"
+)
+
+func newFileApp(t *testing.T) *fiber.App {
+	t.Helper()
+
+	// Keep a secret next to the upload root that traversal attempts aim for
+	base := t.TempDir()
+	root := filepath.Join(base, "uploads")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "avatars"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".healthcheck"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "documents", "npwp"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "companies", "7", "logo"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "exports"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "avatars", "a.png"), []byte("png-bytes"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "companies", "7", "logo", "a.png"), []byte("logo-bytes"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "exports", "a.csv"), []byte("csv"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".healthcheck", "probe"), []byte("ok"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "documents", "npwp", "a.pdf"), []byte("npwp"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(base, "secret.txt"), []byte("secret"), 0644))
+
+	app := fiber.New()
+	routes.SetupUploadRoutes(app, upload.NewFileHandler(root, time.Hour))
+	return app
+}
+
+func TestServe_SendsFileWithCacheHeaders(t *testing.T) {
+	app := newFileApp(t)
+
+	// Escaped paths are decoded before the lookup
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/uploads/avatars/%61.png", nil), -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "png-bytes", string(body))
+	assert.Equal(t, "public, max-age=3600, immutable", resp.Header.Get(fiber.HeaderCacheControl))
+	assert.Equal(t, "nosniff", resp.Header.Get(fiber.HeaderXContentTypeOptions))
+}
+
+func TestServe_SendsCompanyImages(t *testing.T) {
+	app := newFileApp(t)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/uploads/companies/7/logo/a.png", nil), -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "logo-bytes", string(body))
+}
+
+func TestServe_RejectsTraversalAndHiddenFiles(t *testing.T) {
+	app := newFileApp(t)
+
+	for _, target := range []string{
+		"/uploads/../secret.txt",
+		"/uploads/../../etc/passwd",
+		"/uploads/avatars/../../secret.txt",
+		"/uploads/%2e%2e/secret.txt",
+		"/uploads/%2e%2e%2f%2e%2e%2fetc%2fpasswd",
+		"/uploads/avatars/..%5c..%5csecret.txt",
+		"/uploads/.healthcheck/probe",
+		"/uploads/documents/npwp/a.pdf",
+		"/uploads/avatars/../documents/npwp/a.pdf",
+		"/uploads/exports/a.csv", // Folders outside the image allow-list stay private
+		"/uploads/avatars",
+		"/uploads/avatars/missing.png",
+	} {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, target, nil), -1)
+		require.NoError(t, err, target)
+		resp.Body.Close()
+
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode, target)
+	}
+}
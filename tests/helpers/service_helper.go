@@ -0,0 +1,109 @@
+package helpers
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// NewApplicationService creates an application service on the given repositories with the
+// default reapply policy and no email, notification, upload or template dependencies
+func NewApplicationService(apps application.ApplicationRepository, jobs job.JobRepository, users user.UserRepository, companies company.CompanyRepository) application.ApplicationService {
+	return service.NewApplicationService(apps, jobs, users, companies, nil, nil, nil, application.DefaultReapplyPolicy, nil, true, nil, nil)
+}
+
+// NewCompanyMemberRepository holds company 3, where user 5 is the active employer user 50
+// with the given role
+func NewCompanyMemberRepository(role string) *fakes.CompanyRepository {
+	return &fakes.CompanyRepository{EmployerUsers: []company.EmployerUser{
+		{ID: 50, UserID: 5, CompanyID: 3, Role: role, IsActive: true},
+	}}
+}
+
+// NewCachedCompanyService creates a company service on repo backed by an in-memory cache
+// that is stopped when the test ends
+func NewCachedCompanyService(t *testing.T, repo company.CompanyRepository) (company.CompanyService, *cache.InMemoryCache) {
+	t.Helper()
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	t.Cleanup(memCache.Stop)
+	svc := service.NewCompanyService(repo, nil, memCache, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	return svc, memCache
+}
+
+// NewS3UploadService creates an upload service that stores files in store and retries
+// transient failures quickly
+func NewS3UploadService(store *fakes.ObjectStore) service.UploadService {
+	return service.NewUploadService(service.UploadServiceConfig{
+		StorageProvider: "s3",
+		BaseURL:         "https://cdn.example.com/keerja/",
+		ObjectClient:    store,
+		MaxRetries:      3,
+		RetryBackoff:    time.Millisecond,
+	})
+}
+
+// NewFileHeader builds the multipart file header of an upload with the given content
+func NewFileHeader(t *testing.T, filename, contentType string, content []byte) *multipart.FileHeader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", `form-data; name="file"; filename="`+filename+`"`)
+	h.Set("Content-Type", contentType)
+	part, err := w.CreatePart(h)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	form, err := multipart.NewReader(&buf, w.Boundary()).ReadForm(1 << 20)
+	require.NoError(t, err)
+	return form.File["file"][0]
+}
+
+// VerifiedJobseeker returns user 7, an active jobseeker with a verified email
+func VerifiedJobseeker() *user.User {
+	verifiedAt := time.Now().Add(-time.Hour)
+	return &user.User{ID: 7, Email: "seeker@example.com", UserType: "jobseeker", Status: "active", IsVerified: true, EmailVerifiedAt: &verifiedAt}
+}
+
+// NewEmployerJobRepository holds jobs 1 and 2 of company 3, posted by employer users 40 and
+// 7, and job 3 of company 4, posted by employer user 40
+func NewEmployerJobRepository() *fakes.JobRepository {
+	return &fakes.JobRepository{Jobs: map[int64]*job.Job{
+		1: {ID: 1, CompanyID: 3, EmployerUserID: utils.Int64Ptr(40), Title: "Posted by user 7"},
+		2: {ID: 2, CompanyID: 3, EmployerUserID: utils.Int64Ptr(7), Title: "Posted by user 9"},
+		3: {ID: 3, CompanyID: 4, EmployerUserID: utils.Int64Ptr(40), Title: "Other company"},
+	}}
+}
+
+// JobIDs returns the IDs of the jobs in order
+func JobIDs(jobs []job.Job) []int64 {
+	ids := make([]int64, len(jobs))
+	for i := range jobs {
+		ids[i] = jobs[i].ID
+	}
+	return ids
+}
+
+// WrongCode returns a six digit code that differs from code
+func WrongCode(code string) string {
+	if code == "000000" {
+		return "111111"
+	}
+	return "000000"
+}
@@ -0,0 +1,72 @@
+package i18n_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"keerja-backend/internal/i18n"
+)
+
+func TestTranslate_RendersBothLocales(t *testing.T) {
+	assert.Equal(t, "Email wajib diisi", i18n.Translate(i18n.Indonesian, "validation.required", "Email"))
+	assert.Equal(t, "Email is required", i18n.Translate(i18n.English, "validation.required", "Email"))
+
+	assert.Equal(t, "Ditolak", i18n.New(i18n.Indonesian).T("application.status.rejected"))
+	assert.Equal(t, "Rejected", i18n.New(i18n.English).T("application.status.rejected"))
+}
+
+func TestTranslate_MissingKeyFallsBackToDefaultLocale(t *testing.T) {
+	// The English catalog has no subject for job review emails
+	en := i18n.New(i18n.English)
+	assert.True(t, en.Has("email.subject.job_reviewed"))
+	assert.Equal(t, "Hasil Review Lowongan - Keerja", en.T("email.subject.job_reviewed"))
+
+	// Unknown locales use the default catalog
+	assert.Equal(t, "Ditolak", i18n.Translate(i18n.Locale("fr"), "application.status.rejected"))
+
+	// Keys missing everywhere come back unchanged instead of failing
+	assert.False(t, en.Has("does.not.exist"))
+	assert.Equal(t, "does.not.exist", en.T("does.not.exist"))
+}
+
+func TestFromAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   i18n.Locale
+	}{
+		{"", i18n.Indonesian},
+		{"*", i18n.Indonesian},
+		{"en-US,en;q=0.9", i18n.English},
+		{"id-ID", i18n.Indonesian},
+		{"fr-FR, en;q=0.5, id;q=0.8", i18n.Indonesian},
+		{"en;q=0, id", i18n.Indonesian},
+		{"fr-FR, de", i18n.English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, i18n.FromAcceptLanguage(tt.header))
+		})
+	}
+}
+
+func TestFromPreference(t *testing.T) {
+	en, unsupported := "en", "jv"
+	assert.Equal(t, i18n.English, i18n.FromPreference(&en))
+	assert.Equal(t, i18n.Indonesian, i18n.FromPreference(&unsupported))
+	assert.Equal(t, i18n.Indonesian, i18n.FromPreference(nil))
+}
+
+func TestFromContext_DefaultsToIndonesian(t *testing.T) {
+	assert.Equal(t, i18n.Indonesian, i18n.FromContext(context.Background()))
+	assert.Equal(t, i18n.English, i18n.FromContext(i18n.WithLocale(context.Background(), i18n.English)))
+}
+
+func TestFormatLongDate(t *testing.T) {
+	date := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, "Senin, 02 Maret 2026", i18n.FormatLongDate(i18n.Indonesian, date))
+	assert.Equal(t, "Monday, 02 March 2026", i18n.FormatLongDate(i18n.English, date))
+}
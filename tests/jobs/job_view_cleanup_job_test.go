@@ -0,0 +1,49 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/jobs"
+)
+
+type viewCleanupRepo struct {
+	job.JobRepository
+
+	before  time.Time
+	deleted int64
+	err     error
+}
+
+func (r *viewCleanupRepo) DeleteViewEventsBefore(ctx context.Context, before time.Time) (int64, error) {
+	r.before = before
+	return r.deleted, r.err
+}
+
+func TestJobViewCleanupJob_DeletesEventsOlderThanRetention(t *testing.T) {
+	repo := &viewCleanupRepo{deleted: 42}
+	cleanup := jobs.NewJobViewCleanupJob(repo, logrus.New(), 30)
+
+	processed, err := cleanup.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 42, processed)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -30), repo.before, time.Minute)
+}
+
+func TestJobViewCleanupJob_DefaultsRetention(t *testing.T) {
+	repo := &viewCleanupRepo{}
+	_, err := jobs.NewJobViewCleanupJob(repo, logrus.New(), 0).Run(context.Background())
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -jobs.DefaultJobViewRetentionDays), repo.before, time.Minute)
+
+	repo.err = errors.New("db down")
+	_, err = jobs.NewJobViewCleanupJob(repo, logrus.New(), 0).Run(context.Background())
+	assert.ErrorIs(t, err, repo.err)
+}
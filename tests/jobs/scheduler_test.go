@@ -0,0 +1,180 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/jobs"
+)
+
+// memoryRunRepo keeps job runs in memory
+type memoryRunRepo struct {
+	mu   sync.Mutex
+	runs []jobs.JobRun
+}
+
+func (r *memoryRunRepo) Create(ctx context.Context, run *jobs.JobRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	run.ID = int64(len(r.runs) + 1)
+	r.runs = append(r.runs, *run)
+	return nil
+}
+
+func (r *memoryRunRepo) Finish(ctx context.Context, run *jobs.JobRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runs[run.ID-1] = *run
+	return nil
+}
+
+func (r *memoryRunRepo) LatestByJob(ctx context.Context) (map[string]jobs.JobRun, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	latest := make(map[string]jobs.JobRun)
+	for _, run := range r.runs {
+		latest[run.JobName] = run
+	}
+	return latest, nil
+}
+
+func (r *memoryRunRepo) DeleteStartedBefore(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (r *memoryRunRepo) all() []jobs.JobRun {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]jobs.JobRun(nil), r.runs...)
+}
+
+// funcJob runs fn; it is scheduled yearly so only manual runs happen during a test
+type funcJob struct {
+	name    string
+	timeout time.Duration
+	fn      func(ctx context.Context) (int, error)
+}
+
+func (j *funcJob) Name() string                         { return j.name }
+func (j *funcJob) Schedule() string                     { return "0 0 0 1 1 *" }
+func (j *funcJob) Timeout() time.Duration               { return j.timeout }
+func (j *funcJob) Run(ctx context.Context) (int, error) { return j.fn(ctx) }
+
+func newTestScheduler(t *testing.T, registered ...jobs.Job) (*jobs.Scheduler, *memoryRunRepo) {
+	t.Helper()
+	repo := &memoryRunRepo{}
+	scheduler := jobs.NewScheduler(repo)
+	for _, job := range registered {
+		require.NoError(t, scheduler.Register(job))
+	}
+	return scheduler, repo
+}
+
+func TestScheduler_PreventsOverlappingRuns(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	scheduler, repo := newTestScheduler(t, &funcJob{name: "slow", fn: func(ctx context.Context) (int, error) {
+		started <- struct{}{}
+		<-release
+		return 7, nil
+	}})
+
+	run, err := scheduler.Trigger("slow")
+	require.NoError(t, err)
+	assert.Equal(t, jobs.RunTriggerManual, run.TriggeredBy)
+	<-started
+
+	_, err = scheduler.Trigger("slow")
+	assert.ErrorIs(t, err, jobs.ErrJobAlreadyRunning)
+	_, err = scheduler.RunNow("slow")
+	assert.ErrorIs(t, err, jobs.ErrJobAlreadyRunning)
+
+	schedules, err := scheduler.Schedules(context.Background())
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+	assert.True(t, schedules[0].Running)
+
+	close(release)
+	require.Eventually(t, func() bool {
+		schedules, err := scheduler.Schedules(context.Background())
+		return err == nil && !schedules[0].Running
+	}, time.Second, 5*time.Millisecond)
+
+	// Once the run has finished the job can run again
+	run, err = scheduler.RunNow("slow")
+	require.NoError(t, err)
+	<-started
+	assert.True(t, run.Success)
+
+	runs := repo.all()
+	require.Len(t, runs, 2)
+	for _, run := range runs {
+		assert.True(t, run.Success)
+		assert.Equal(t, 7, run.ItemsProcessed)
+		assert.NotNil(t, run.FinishedAt)
+	}
+
+	_, err = scheduler.Trigger("missing")
+	assert.ErrorIs(t, err, jobs.ErrJobNotFound)
+}
+
+func TestScheduler_IsolatesPanics(t *testing.T) {
+	var healthyRuns int
+	scheduler, repo := newTestScheduler(t,
+		&funcJob{name: "broken", fn: func(ctx context.Context) (int, error) {
+			var counts map[string]int
+			counts["boom"]++
+			return 1, nil
+		}},
+		&funcJob{name: "healthy", fn: func(ctx context.Context) (int, error) {
+			healthyRuns++
+			return 3, nil
+		}},
+	)
+
+	for i := 0; i < 2; i++ {
+		run, err := scheduler.RunNow("broken")
+		require.NoError(t, err, "a panicking job is recorded as failed and can run again")
+		assert.False(t, run.Success)
+		require.NotNil(t, run.Error)
+		assert.Contains(t, *run.Error, "panicked")
+	}
+
+	run, err := scheduler.RunNow("healthy")
+	require.NoError(t, err)
+	assert.True(t, run.Success)
+	assert.Equal(t, 1, healthyRuns)
+
+	schedules, err := scheduler.Schedules(context.Background())
+	require.NoError(t, err)
+	require.Len(t, schedules, 2)
+	assert.Equal(t, "broken", schedules[0].Name)
+	assert.False(t, schedules[0].LastRun.Success)
+	assert.Equal(t, "healthy", schedules[1].Name)
+	assert.Equal(t, 3, schedules[1].LastRun.ItemsProcessed)
+	assert.Len(t, repo.all(), 3)
+}
+
+func TestScheduler_RunsWithDeadline(t *testing.T) {
+	scheduler, _ := newTestScheduler(t, &funcJob{name: "stuck", timeout: 20 * time.Millisecond, fn: func(ctx context.Context) (int, error) {
+		if _, ok := ctx.Deadline(); !ok {
+			return 0, errors.New("no deadline")
+		}
+		<-ctx.Done()
+		return 2, ctx.Err()
+	}})
+
+	run, err := scheduler.RunNow("stuck")
+	require.NoError(t, err)
+	assert.False(t, run.Success)
+	assert.Equal(t, 2, run.ItemsProcessed)
+	require.NotNil(t, run.Error)
+	assert.Equal(t, context.DeadlineExceeded.Error(), *run.Error)
+	assert.Less(t, run.Duration(), time.Second)
+}
@@ -0,0 +1,120 @@
+package jobs_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/jobs"
+)
+
+type staleStageRepo struct {
+	application.ApplicationRepository
+
+	stages         []application.StaleStage
+	defaultDays    int
+	remindedBefore time.Time
+	marked         []int64
+}
+
+func (r *staleStageRepo) FindStaleStages(ctx context.Context, defaultThresholdDays int, remindedBefore time.Time) ([]application.StaleStage, error) {
+	r.defaultDays = defaultThresholdDays
+	r.remindedBefore = remindedBefore
+	return r.stages, nil
+}
+
+func (r *staleStageRepo) MarkStagesReminded(ctx context.Context, stageIDs []int64, at time.Time) error {
+	r.marked = append(r.marked, stageIDs...)
+	return nil
+}
+
+type reminderCompanyRepo struct {
+	company.CompanyRepository
+	employers []company.EmployerUser
+}
+
+func (r *reminderCompanyRepo) FindByID(ctx context.Context, id int64) (*company.Company, error) {
+	return &company.Company{ID: id, CompanyName: "Acme"}, nil
+}
+
+func (r *reminderCompanyRepo) GetEmployerUsersByCompanyID(ctx context.Context, companyID int64) ([]company.EmployerUser, error) {
+	return r.employers, nil
+}
+
+type reminderUserRepo struct {
+	user.UserRepository
+}
+
+func (r *reminderUserRepo) FindByID(ctx context.Context, id int64) (*user.User, error) {
+	return &user.User{ID: id, FullName: "Recruiter", Email: "recruiter@example.com"}, nil
+}
+
+type sentReminder struct {
+	to     string
+	groups []email.StageReminderGroup
+}
+
+type reminderEmailService struct {
+	email.EmailService
+	sent []sentReminder
+}
+
+func (s *reminderEmailService) SendStageReminderEmail(ctx context.Context, to, name, companyName string, thresholdDays int, groups []email.StageReminderGroup) error {
+	s.sent = append(s.sent, sentReminder{to: to, groups: groups})
+	return nil
+}
+
+func TestStageReminderJob_GroupsByJobAndNotifiesRecruiters(t *testing.T) {
+	started := time.Now().AddDate(0, 0, -10)
+	appRepo := &staleStageRepo{stages: []application.StaleStage{
+		{StageID: 1, JobID: 100, JobTitle: "Backend Engineer", CompanyID: 5, ApplicantName: "Ana", StageName: "applied", StartedAt: started, ThresholdDays: 7},
+		{StageID: 2, JobID: 200, JobTitle: "Designer", CompanyID: 5, ApplicantName: "Budi", StageName: "screening", StartedAt: started, ThresholdDays: 7},
+		{StageID: 3, JobID: 100, JobTitle: "Backend Engineer", CompanyID: 5, ApplicantName: "Citra", StageName: "screening", StartedAt: started, ThresholdDays: 7},
+	}}
+	companyRepo := &reminderCompanyRepo{employers: []company.EmployerUser{
+		{UserID: 1, Role: "owner"},
+		{UserID: 2, Role: "recruiter"},
+		{UserID: 3, Role: "viewer"},
+	}}
+	emailSvc := &reminderEmailService{}
+
+	job := jobs.NewStageReminderJob(appRepo, companyRepo, &reminderUserRepo{}, emailSvc, logrus.New(), jobs.StageReminderConfig{})
+	handled, err := job.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, handled)
+
+	assert.Equal(t, company.DefaultStageReminderDays, appRepo.defaultDays)
+	assert.WithinDuration(t, time.Now().Add(-24*time.Hour), appRepo.remindedBefore, time.Hour)
+
+	require.Len(t, emailSvc.sent, 2, "owner and recruiter are notified, viewer is not")
+	groups := emailSvc.sent[0].groups
+	require.Len(t, groups, 2)
+	assert.Equal(t, "Backend Engineer", groups[0].JobTitle)
+	assert.Len(t, groups[0].Applications, 2)
+	assert.Equal(t, 10, groups[0].Applications[0].DaysInStage)
+	assert.Equal(t, "Designer", groups[1].JobTitle)
+
+	assert.ElementsMatch(t, []int64{1, 2, 3}, appRepo.marked)
+}
+
+func TestStageReminderJob_NothingStale(t *testing.T) {
+	appRepo := &staleStageRepo{}
+	emailSvc := &reminderEmailService{}
+
+	job := jobs.NewStageReminderJob(appRepo, &reminderCompanyRepo{}, &reminderUserRepo{}, emailSvc, logrus.New(), jobs.StageReminderConfig{DefaultThresholdDays: 3})
+	handled, err := job.Run(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, handled)
+
+	assert.Equal(t, 3, appRepo.defaultDays)
+	assert.Empty(t, emailSvc.sent)
+	assert.Empty(t, appRepo.marked)
+}
@@ -0,0 +1,159 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/jobs"
+)
+
+// digestRepo serves fixed activity per company and records the companies marked sent
+type digestRepo struct {
+	companies  []company.DigestCompany
+	activity   map[int64][]company.DigestJobActivity
+	interviews map[int64][]company.DigestInterview
+	followers  map[int64]company.FollowerGrowth
+
+	sentBefore time.Time
+	marked     []int64
+}
+
+func (r *digestRepo) FindWeeklyDigestCompanies(ctx context.Context, sentBefore time.Time) ([]company.DigestCompany, error) {
+	r.sentBefore = sentBefore
+	return r.companies, nil
+}
+
+func (r *digestRepo) GetDigestJobActivity(ctx context.Context, companyID int64, since, awaitingBefore time.Time) ([]company.DigestJobActivity, error) {
+	return r.activity[companyID], nil
+}
+
+func (r *digestRepo) GetDigestInterviews(ctx context.Context, companyID int64, from, to time.Time) ([]company.DigestInterview, error) {
+	return r.interviews[companyID], nil
+}
+
+func (r *digestRepo) GetDigestExpiringJobs(ctx context.Context, companyID int64, from, to time.Time) ([]company.DigestExpiringJob, error) {
+	return nil, nil
+}
+
+func (r *digestRepo) GetFollowerGrowth(ctx context.Context, companyID int64, since time.Time) (*company.FollowerGrowth, error) {
+	growth := r.followers[companyID]
+	return &growth, nil
+}
+
+func (r *digestRepo) MarkWeeklyDigestSent(ctx context.Context, companyID int64, at time.Time) error {
+	r.marked = append(r.marked, companyID)
+	return nil
+}
+
+type digestUserRepo struct {
+	reminderUserRepo
+	emailOff map[int64]bool
+}
+
+func (r *digestUserRepo) FindPreferenceByUserID(ctx context.Context, userID int64) (*user.UserPreference, error) {
+	if off, ok := r.emailOff[userID]; ok {
+		return &user.UserPreference{UserID: userID, EmailNotifications: !off}, nil
+	}
+	return nil, nil
+}
+
+type sentDigest struct {
+	userName    string
+	companyName string
+	digest      *email.WeeklyDigest
+}
+
+type digestEmailService struct {
+	email.EmailService
+	sent []sentDigest
+	fail bool
+}
+
+func (s *digestEmailService) SendWeeklyDigestEmail(ctx context.Context, to, name, companyName string, digest *email.WeeklyDigest) error {
+	if s.fail {
+		return errors.New("smtp unavailable")
+	}
+	s.sent = append(s.sent, sentDigest{userName: name, companyName: companyName, digest: digest})
+	return nil
+}
+
+func TestWeeklyDigestJob_SkipsIdleCompaniesAndRespectsEmailNotifications(t *testing.T) {
+	repo := &digestRepo{
+		companies: []company.DigestCompany{
+			{CompanyID: 1, CompanyName: "Active", PublishedJobs: 2},
+			{CompanyID: 2, CompanyName: "Idle"},                    // No activity, no published jobs
+			{CompanyID: 3, CompanyName: "Quiet", PublishedJobs: 1}, // No activity but still hiring
+			{CompanyID: 4, CompanyName: "Followed"},                // Only new followers
+		},
+		activity: map[int64][]company.DigestJobActivity{
+			1: {
+				{JobID: 10, JobTitle: "Backend Engineer", NewApplications: 4, AwaitingAction: 2},
+				{JobID: 11, JobTitle: "Designer", NewApplications: 0, AwaitingAction: 1},
+			},
+		},
+		interviews: map[int64][]company.DigestInterview{
+			1: {{InterviewID: 7, ApplicationID: 70, JobTitle: "Backend Engineer", CandidateName: "Ana", ScheduledAt: time.Now().Add(48 * time.Hour)}},
+		},
+		followers: map[int64]company.FollowerGrowth{
+			1: {NewFollowers: 3, TotalFollowers: 40},
+			4: {NewFollowers: 1, TotalFollowers: 1},
+		},
+	}
+	companyRepo := &reminderCompanyRepo{employers: []company.EmployerUser{
+		{UserID: 1, Role: "owner"},
+		{UserID: 2, Role: "recruiter"},
+		{UserID: 3, Role: "viewer"},
+	}}
+	userRepo := &digestUserRepo{emailOff: map[int64]bool{1: true, 2: false}}
+	emailSvc := &digestEmailService{}
+
+	job := jobs.NewWeeklyDigestJob(repo, companyRepo, userRepo, emailSvc, logrus.New())
+	sent, err := job.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, sent)
+
+	assert.WithinDuration(t, time.Now().Add(-company.WeeklyDigestResendAfter), repo.sentBefore, time.Hour)
+	assert.Equal(t, []int64{1, 3, 4}, repo.marked, "the idle company is skipped and not marked")
+
+	// The owner turned email notifications off and viewers never get the digest
+	require.Len(t, emailSvc.sent, 3)
+	assert.Equal(t, []string{"Active", "Quiet", "Followed"}, []string{emailSvc.sent[0].companyName, emailSvc.sent[1].companyName, emailSvc.sent[2].companyName})
+
+	digest := emailSvc.sent[0].digest
+	require.Len(t, digest.Jobs, 2)
+	assert.Equal(t, int64(4), digest.Jobs[0].NewApplications)
+	assert.Equal(t, int64(3), digest.AwaitingAction)
+	require.Len(t, digest.Interviews, 1)
+	assert.Equal(t, int64(70), digest.Interviews[0].ApplicationID)
+	assert.Equal(t, int64(3), digest.NewFollowers)
+	assert.Equal(t, int64(40), digest.TotalFollowers)
+}
+
+func TestWeeklyDigestJob_MarksSentOnlyAfterDelivery(t *testing.T) {
+	repo := &digestRepo{companies: []company.DigestCompany{{CompanyID: 1, CompanyName: "Acme", PublishedJobs: 1}}}
+	companyRepo := &reminderCompanyRepo{employers: []company.EmployerUser{{UserID: 2, Role: "recruiter"}}}
+
+	// A failed delivery leaves the company unmarked so the next run retries it
+	job := jobs.NewWeeklyDigestJob(repo, companyRepo, &digestUserRepo{}, &digestEmailService{fail: true}, logrus.New())
+	sent, err := job.Run(context.Background())
+	assert.Error(t, err)
+	assert.Zero(t, sent)
+	assert.Empty(t, repo.marked)
+
+	// Nobody wants the digest: the company is marked so it is not rebuilt on every rerun
+	emailSvc := &digestEmailService{}
+	job = jobs.NewWeeklyDigestJob(repo, companyRepo, &digestUserRepo{emailOff: map[int64]bool{2: true}}, emailSvc, logrus.New())
+	_, err = job.Run(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, emailSvc.sent)
+	assert.Equal(t, []int64{1}, repo.marked)
+}
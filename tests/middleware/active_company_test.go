@@ -0,0 +1,123 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/service"
+)
+
+// membershipRepo serves the employer memberships RequireActiveCompany resolves
+type membershipRepo struct {
+	company.CompanyRepository
+	members []company.EmployerUser
+}
+
+func (r *membershipRepo) FindEmployerUserByUserAndCompany(ctx context.Context, userID, companyID int64) (*company.EmployerUser, error) {
+	for i := range r.members {
+		if r.members[i].UserID == userID && r.members[i].CompanyID == companyID {
+			return &r.members[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *membershipRepo) GetEmployerUsersByUserID(ctx context.Context, userID int64) ([]company.EmployerUser, error) {
+	var memberships []company.EmployerUser
+	for _, m := range r.members {
+		if m.UserID == userID {
+			memberships = append(memberships, m)
+		}
+	}
+	return memberships, nil
+}
+
+// activeCompanyBody is the error body, or the employer user the route acted as
+type activeCompanyBody struct {
+	errorBody
+	EmployerUserID int64 `json:"employer_user_id"`
+}
+
+// serveActiveCompany calls a route guarded by RequireActiveCompany as userID with the
+// X-Company-ID header set to header when it is not empty. The route returns the company
+// the request acts for.
+func serveActiveCompany(t *testing.T, userID int64, header string) (int, string, activeCompanyBody) {
+	t.Helper()
+
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	t.Cleanup(memCache.Stop)
+	repo := &membershipRepo{members: []company.EmployerUser{
+		{ID: 40, UserID: 7, CompanyID: 3, Role: "recruiter", IsActive: true},
+		{ID: 60, UserID: 7, CompanyID: 4, Role: "admin", IsActive: true},
+		{ID: 61, UserID: 9, CompanyID: 4, Role: "viewer", IsActive: true},
+	}}
+	pm := middleware.NewPermissionMiddleware(service.NewCompanyService(repo, nil, memCache, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil))
+
+	app := fiber.New()
+	app.Use(middleware.ErrorHandler(false))
+	app.Get("/jobs/status/active",
+		func(c *fiber.Ctx) error {
+			c.Locals(middleware.ContextKeyUserID, userID)
+			return c.Next()
+		},
+		pm.RequireActiveCompany(),
+		func(c *fiber.Ctx) error {
+			ec := middleware.GetEmployerContext(c)
+			return c.JSON(fiber.Map{"success": true, "employer_user_id": ec.EmployerUserID})
+		},
+	)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/jobs/status/active", nil)
+	if header != "" {
+		req.Header.Set(middleware.CompanyIDHeader, header)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var body activeCompanyBody
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return resp.StatusCode, resp.Header.Get(middleware.CompanyIDHeader), body
+}
+
+func TestRequireActiveCompany_ResolvesHeaderOrOnlyCompany(t *testing.T) {
+	// The header picks the membership, and the resolved company is echoed back
+	status, echoed, body := serveActiveCompany(t, 7, "4")
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "4", echoed)
+	assert.Equal(t, int64(60), body.EmployerUserID)
+
+	// A user with one company needs no header
+	status, echoed, body = serveActiveCompany(t, 9, "")
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "4", echoed)
+	assert.Equal(t, int64(61), body.EmployerUserID)
+}
+
+func TestRequireActiveCompany_RejectsUnresolvableCompany(t *testing.T) {
+	for name, tc := range map[string]struct {
+		userID int64
+		header string
+		status int
+		code   string
+	}{
+		"several companies without a choice": {7, "", fiber.StatusBadRequest, company.ErrActiveCompanyRequired.Code},
+		"no company":                         {8, "", fiber.StatusBadRequest, company.ErrNoEmployerCompany.Code},
+		"malformed header":                   {7, "acme", fiber.StatusBadRequest, middleware.ErrInvalidCompanyHeader.Code},
+		"another user's company":             {9, "3", fiber.StatusForbidden, company.ErrNotCompanyEmployer.Code},
+	} {
+		status, _, body := serveActiveCompany(t, tc.userID, tc.header)
+		assert.Equal(t, tc.status, status, name)
+		assert.Equal(t, tc.code, body.Code, name)
+	}
+}
@@ -0,0 +1,118 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/config"
+	"keerja-backend/internal/domain/admin"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/service"
+)
+
+type memoryAdminUserRepo struct {
+	admin.AdminUserRepository
+	users   map[int64]*admin.AdminUser
+	lookups int
+}
+
+func (r *memoryAdminUserRepo) FindByID(ctx context.Context, id int64) (*admin.AdminUser, error) {
+	r.lookups++
+	u, ok := r.users[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *u
+	return &copied, nil
+}
+
+// newAdminPermissionApp guards a route with RequirePermission. AdminAuthRequired is replaced
+// by a stub that takes the admin ID from a header, since it only checks the JWT.
+func newAdminPermissionApp(perms admin.AdminPermissionService, repo admin.AdminUserRepository) *fiber.App {
+	app := fiber.New()
+	app.Use(middleware.ErrorHandler(false))
+
+	adminAuthMw := middleware.NewAdminAuthMiddleware(&config.Config{}, repo, perms)
+	authenticated := app.Group("/admin", func(c *fiber.Ctx) error {
+		if id, err := strconv.ParseInt(c.Get("X-Admin-ID"), 10, 64); err == nil {
+			c.Locals("admin_id", id)
+		}
+		return c.Next()
+	})
+	authenticated.Post("/companies/:id/status",
+		adminAuthMw.RequirePermission(admin.PermCompaniesVerify),
+		func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) },
+	)
+	return app
+}
+
+func callAdmin(t *testing.T, app *fiber.App, adminID int64) (int, errorBody) {
+	t.Helper()
+
+	req := httptest.NewRequest(fiber.MethodPost, "/admin/companies/1/status", nil)
+	if adminID != 0 {
+		req.Header.Set("X-Admin-ID", strconv.FormatInt(adminID, 10))
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var body errorBody
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	return resp.StatusCode, body
+}
+
+func TestRequirePermission_AllowsAndDeniesByRole(t *testing.T) {
+	verifier := &admin.AdminRole{ID: 1, RoleName: "Content Manager", Permissions: pq.StringArray{admin.PermCompaniesVerify}}
+	analyst := &admin.AdminRole{ID: 2, RoleName: "Analyst", Permissions: pq.StringArray{admin.PermAnalyticsRead}}
+	repo := &memoryAdminUserRepo{users: map[int64]*admin.AdminUser{
+		10: {ID: 10, Status: "active", RoleID: &verifier.ID, Role: verifier},
+		11: {ID: 11, Status: "active", RoleID: &analyst.ID, Role: analyst},
+		12: {ID: 12, Status: "active"},
+	}}
+	permCache := cache.NewInMemoryCache(100, time.Minute)
+	defer permCache.Stop()
+	app := newAdminPermissionApp(service.NewAdminPermissionService(repo, nil, nil, permCache), repo)
+
+	status, _ := callAdmin(t, app, 10)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	status, body := callAdmin(t, app, 11)
+	assert.Equal(t, fiber.StatusForbidden, status)
+	assert.Equal(t, admin.ErrPermissionDenied.Code, body.Code)
+	assert.Equal(t, admin.PermCompaniesVerify, body.Details["permission"])
+	assert.Contains(t, body.Message, admin.PermCompaniesVerify)
+
+	status, body = callAdmin(t, app, 12)
+	assert.Equal(t, fiber.StatusForbidden, status, "admins without a role have no permissions")
+	assert.Equal(t, admin.ErrPermissionDenied.Code, body.Code)
+
+	status, _ = callAdmin(t, app, 0)
+	assert.Equal(t, fiber.StatusUnauthorized, status)
+}
+
+func TestRequirePermission_CachesPermissionsPerAdmin(t *testing.T) {
+	verifier := &admin.AdminRole{ID: 1, Permissions: pq.StringArray{admin.PermCompaniesVerify}}
+	repo := &memoryAdminUserRepo{users: map[int64]*admin.AdminUser{
+		10: {ID: 10, Status: "active", RoleID: &verifier.ID, Role: verifier},
+	}}
+	permCache := cache.NewInMemoryCache(100, time.Minute)
+	defer permCache.Stop()
+	app := newAdminPermissionApp(service.NewAdminPermissionService(repo, nil, nil, permCache), repo)
+
+	for i := 0; i < 3; i++ {
+		status, _ := callAdmin(t, app, 10)
+		require.Equal(t, fiber.StatusOK, status)
+	}
+	assert.Equal(t, 1, repo.lookups)
+}
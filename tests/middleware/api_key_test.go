@@ -0,0 +1,200 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/apikey"
+	"keerja-backend/internal/domain/application"
+	apphandler "keerja-backend/internal/handler/http/application"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+type memoryAPIKeyRepo struct {
+	apikey.APIKeyRepository
+	keys []*apikey.CompanyAPIKey
+}
+
+func (r *memoryAPIKeyRepo) Create(ctx context.Context, key *apikey.CompanyAPIKey) error {
+	key.ID = int64(len(r.keys) + 1)
+	r.keys = append(r.keys, key)
+	return nil
+}
+
+func (r *memoryAPIKeyRepo) FindByID(ctx context.Context, id int64) (*apikey.CompanyAPIKey, error) {
+	for _, k := range r.keys {
+		if k.ID == id {
+			copied := *k
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryAPIKeyRepo) FindByHash(ctx context.Context, keyHash string) (*apikey.CompanyAPIKey, error) {
+	for _, k := range r.keys {
+		if k.KeyHash == keyHash {
+			copied := *k
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryAPIKeyRepo) Revoke(ctx context.Context, id int64, revokedAt time.Time) error {
+	for _, k := range r.keys {
+		if k.ID == id {
+			k.RevokedAt = &revokedAt
+		}
+	}
+	return nil
+}
+
+func (r *memoryAPIKeyRepo) TouchLastUsed(ctx context.Context, id int64, usedAt time.Time) error {
+	for _, k := range r.keys {
+		if k.ID == id {
+			k.LastUsedAt = &usedAt
+		}
+	}
+	return nil
+}
+
+// newIntegrationApp mounts the integration middleware in front of handlers that echo the
+// principal's company
+func newIntegrationApp(apiKeys apikey.APIKeyService) *fiber.App {
+	app := fiber.New()
+	app.Use(middleware.ErrorHandler(false))
+
+	echo := func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"company_id": middleware.GetAPIKeyPrincipal(c).CompanyID})
+	}
+	integrations := app.Group("/integrations", middleware.APIKeyAuth(apiKeys))
+	integrations.Get("/companies/:id/jobs", middleware.RequireAPIKeyScope(apikey.ScopeJobsRead), echo)
+	integrations.Get("/companies/:id/applications", middleware.RequireAPIKeyScope(apikey.ScopeApplicationsRead), echo)
+	integrations.Post("/companies/:id/jobs", middleware.RequireAPIKeyScope(apikey.ScopeJobsRead), echo)
+	return app
+}
+
+func callIntegration(t *testing.T, app *fiber.App, method, path, key string) (int, errorBody) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, nil)
+	if key != "" {
+		req.Header.Set(middleware.HeaderAPIKey, key)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var body errorBody
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	return resp.StatusCode, body
+}
+
+func TestAPIKeyAuth_EnforcesScopesCompanyAndReadOnly(t *testing.T) {
+	repo := &memoryAPIKeyRepo{}
+	apiKeys := service.NewAPIKeyService(repo, nil)
+	app := newIntegrationApp(apiKeys)
+
+	key, rawKey, err := apiKeys.CreateKey(context.Background(), 7, 1, "Greenhouse", []string{apikey.ScopeJobsRead, apikey.ScopeJobsRead})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(rawKey, apikey.KeyPrefix))
+	assert.Equal(t, rawKey[:apikey.DisplayPrefixLength], key.KeyPrefix)
+	assert.NotContains(t, key.KeyHash, rawKey)
+	assert.Equal(t, []string{apikey.ScopeJobsRead}, []string(key.Scopes))
+
+	status, _ := callIntegration(t, app, fiber.MethodGet, "/integrations/companies/7/jobs", rawKey)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.NotNil(t, repo.keys[0].LastUsedAt)
+
+	status, body := callIntegration(t, app, fiber.MethodGet, "/integrations/companies/7/applications", rawKey)
+	assert.Equal(t, fiber.StatusForbidden, status, "key lacks applications:read")
+	assert.Equal(t, apikey.ErrAPIKeyScopeDenied.Code, body.Code)
+
+	status, body = callIntegration(t, app, fiber.MethodGet, "/integrations/companies/8/jobs", rawKey)
+	assert.Equal(t, fiber.StatusForbidden, status, "key belongs to another company")
+	assert.Equal(t, apikey.ErrAPIKeyScopeDenied.Code, body.Code)
+
+	status, body = callIntegration(t, app, fiber.MethodPost, "/integrations/companies/7/jobs", rawKey)
+	assert.Equal(t, fiber.StatusForbidden, status, "keys are read-only")
+	assert.Equal(t, apikey.ErrAPIKeyScopeDenied.Code, body.Code)
+
+	status, _ = callIntegration(t, app, fiber.MethodGet, "/integrations/companies/7/jobs", "")
+	assert.Equal(t, fiber.StatusUnauthorized, status)
+
+	status, body = callIntegration(t, app, fiber.MethodGet, "/integrations/companies/7/jobs", apikey.KeyPrefix+"guessed")
+	assert.Equal(t, fiber.StatusUnauthorized, status)
+	assert.Equal(t, apikey.ErrInvalidAPIKey.Code, body.Code)
+
+	_, _, err = apiKeys.CreateKey(context.Background(), 7, 1, "Bad", []string{"jobs:write"})
+	assert.ErrorIs(t, err, apikey.ErrInvalidAPIKeyScope)
+}
+
+func TestAPIKeyAuth_RejectsRevokedKeys(t *testing.T) {
+	repo := &memoryAPIKeyRepo{}
+	apiKeys := service.NewAPIKeyService(repo, nil)
+	app := newIntegrationApp(apiKeys)
+
+	key, rawKey, err := apiKeys.CreateKey(context.Background(), 7, 1, "Lever", []string{apikey.ScopeApplicationsRead})
+	require.NoError(t, err)
+
+	status, _ := callIntegration(t, app, fiber.MethodGet, "/integrations/companies/7/applications", rawKey)
+	require.Equal(t, fiber.StatusOK, status)
+
+	// Another company cannot revoke the key
+	assert.ErrorIs(t, apiKeys.RevokeKey(context.Background(), 8, key.ID), apikey.ErrAPIKeyNotFound)
+
+	require.NoError(t, apiKeys.RevokeKey(context.Background(), 7, key.ID))
+	assert.ErrorIs(t, apiKeys.RevokeKey(context.Background(), 7, key.ID), apikey.ErrAPIKeyAlreadyRevoked)
+
+	status, body := callIntegration(t, app, fiber.MethodGet, "/integrations/companies/7/applications", rawKey)
+	assert.Equal(t, fiber.StatusUnauthorized, status)
+	assert.Equal(t, apikey.ErrAPIKeyRevoked.Code, body.Code)
+}
+
+func TestAPIKeyAuth_ListsApplicationsThroughTheEmployerHandler(t *testing.T) {
+	apiKeys := service.NewAPIKeyService(&memoryAPIKeyRepo{}, nil)
+	_, rawKey, err := apiKeys.CreateKey(context.Background(), 7, 1, "Greenhouse", []string{apikey.ScopeApplicationsRead})
+	require.NoError(t, err)
+
+	apps := fakes.NewApplicationRepository(
+		application.JobApplication{ID: 1, JobID: 10, UserID: 100, CompanyID: utils.Int64Ptr(7), Status: application.StatusApplied},
+		application.JobApplication{ID: 2, JobID: 11, UserID: 101, CompanyID: utils.Int64Ptr(8), Status: application.StatusApplied},
+	)
+	svc := helpers.NewApplicationService(apps, &fakes.JobRepository{}, &fakes.UserRepository{}, &fakes.CompanyRepository{})
+	handler := apphandler.NewApplicationHandler(svc, nil)
+
+	app := fiber.New()
+	app.Use(middleware.ErrorHandler(false))
+	app.Get("/integrations/companies/:id/applications",
+		middleware.APIKeyAuth(apiKeys),
+		middleware.RequireAPIKeyScope(apikey.ScopeApplicationsRead),
+		handler.ListByCompany,
+	)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/integrations/companies/7/applications", nil)
+	req.Header.Set(middleware.HeaderAPIKey, rawKey)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Data application.ApplicationListResponse `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(t, body.Data.Applications, 1, "only the key's company is listed")
+	assert.Equal(t, int64(1), body.Data.Applications[0].ID)
+}
@@ -0,0 +1,124 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/config"
+	"keerja-backend/internal/middleware"
+)
+
+// newCORSApp registers CORS followed by a middleware standing in for rate limiting and
+// auth, which counts the requests that get past CORS and rejects them
+func newCORSApp(cfg *config.Config) (*fiber.App, *int) {
+	reached := 0
+	app := fiber.New()
+	app.Use(middleware.CORSConfig(cfg))
+	app.Use(func(c *fiber.Ctx) error {
+		reached++
+		return c.SendStatus(fiber.StatusUnauthorized)
+	})
+	return app, &reached
+}
+
+func corsConfig(origins ...string) *config.Config {
+	return &config.Config{
+		CORSAllowedOrigins:   origins,
+		CORSAllowedMethods:   []string{fiber.MethodGet, fiber.MethodPost},
+		CORSAllowedHeaders:   []string{fiber.HeaderAuthorization, fiber.HeaderContentType},
+		CORSMaxAge:           10 * time.Minute,
+		CORSAllowCredentials: true,
+	}
+}
+
+func TestCORS_PreflightShortCircuits(t *testing.T) {
+	app, reached := newCORSApp(corsConfig("https://keerja.com", "https://*.keerja.com"))
+
+	req := httptest.NewRequest(fiber.MethodOptions, "/api/v1/users/me", nil)
+	req.Header.Set(fiber.HeaderOrigin, "https://staging.keerja.com")
+	req.Header.Set(fiber.HeaderAccessControlRequestMethod, fiber.MethodPost)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	assert.Zero(t, *reached, "preflight requests never reach auth or rate limiting")
+	assert.Equal(t, "https://staging.keerja.com", resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+	assert.Equal(t, "true", resp.Header.Get(fiber.HeaderAccessControlAllowCredentials))
+	assert.Equal(t, "GET, POST", resp.Header.Get(fiber.HeaderAccessControlAllowMethods))
+	assert.Equal(t, "Authorization, Content-Type", resp.Header.Get(fiber.HeaderAccessControlAllowHeaders))
+	assert.Equal(t, "600", resp.Header.Get(fiber.HeaderAccessControlMaxAge))
+}
+
+func TestCORS_RejectedPreflightGetsNoHeaders(t *testing.T) {
+	app, reached := newCORSApp(corsConfig("https://*.keerja.com"))
+
+	req := httptest.NewRequest(fiber.MethodOptions, "/api/v1/users/me", nil)
+	req.Header.Set(fiber.HeaderOrigin, "https://evilkeerja.com")
+	req.Header.Set(fiber.HeaderAccessControlRequestMethod, fiber.MethodPost)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	assert.Zero(t, *reached)
+	assert.Empty(t, resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+	assert.Empty(t, resp.Header.Get(fiber.HeaderAccessControlAllowMethods))
+}
+
+func TestCORS_EchoesOnlyTheMatchingOrigin(t *testing.T) {
+	app, reached := newCORSApp(corsConfig("https://keerja.com", "http://localhost:3000"))
+
+	req := httptest.NewRequest(fiber.MethodGet, "/api/v1/jobs", nil)
+	req.Header.Set(fiber.HeaderOrigin, "http://localhost:3000")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, *reached, "simple requests continue down the chain")
+	assert.Equal(t, "http://localhost:3000", resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+	assert.Contains(t, resp.Header.Get(fiber.HeaderVary), fiber.HeaderOrigin)
+	assert.Contains(t, resp.Header.Get(fiber.HeaderAccessControlExposeHeaders), middleware.HeaderImpersonatedBy,
+		"the frontend reads the impersonation header to show its banner")
+
+	req = httptest.NewRequest(fiber.MethodGet, "/api/v1/jobs", nil)
+	req.Header.Set(fiber.HeaderOrigin, "https://other.example")
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Empty(t, resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+	assert.Empty(t, resp.Header.Get(fiber.HeaderAccessControlAllowCredentials))
+}
+
+func TestCORS_ExposesMiddlewareResponseHeaders(t *testing.T) {
+	app, _ := newCORSApp(corsConfig("https://keerja.com"))
+
+	req := httptest.NewRequest(fiber.MethodGet, "/api/v1/jobs", nil)
+	req.Header.Set(fiber.HeaderOrigin, "https://keerja.com")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	exposed := resp.Header.Get(fiber.HeaderAccessControlExposeHeaders)
+	for _, header := range []string{
+		middleware.RequestIDHeader,
+		middleware.CompanyIDHeader,
+		middleware.HeaderRateLimitLimit,
+		middleware.HeaderRateLimitRemaining,
+		middleware.HeaderRateLimitReset,
+		fiber.HeaderRetryAfter,
+		middleware.HeaderIdempotentReplayed,
+		middleware.HeaderImpersonatedBy,
+	} {
+		assert.Contains(t, exposed, header)
+	}
+}
+
+func TestCORS_OptionsWithoutOriginIsNotAPreflight(t *testing.T) {
+	app, reached := newCORSApp(corsConfig("https://keerja.com"))
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodOptions, "/api/v1/jobs", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, 1, *reached)
+}
@@ -0,0 +1,84 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/service"
+)
+
+type errorBody struct {
+	Success bool              `json:"success"`
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Details map[string]string `json:"details"`
+}
+
+// serveError runs err through the error middleware and decodes the response
+func serveError(t *testing.T, err error) (int, errorBody) {
+	t.Helper()
+
+	app := fiber.New()
+	app.Use(middleware.ErrorHandler(false))
+	app.Get("/", func(c *fiber.Ctx) error { return err })
+
+	resp, reqErr := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	require.NoError(t, reqErr)
+	defer resp.Body.Close()
+
+	var body errorBody
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return resp.StatusCode, body
+}
+
+func TestErrorHandler_MapsAppErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"not found", job.ErrJobNotFound, fiber.StatusNotFound, "JOB_NOT_FOUND"},
+		{"conflict", application.ErrAlreadyApplied, fiber.StatusConflict, application.ErrAlreadyApplied.Code},
+		{"forbidden when wrapped", fmt.Errorf("update job: %w", job.ErrJobPermissionDenied), fiber.StatusForbidden, job.ErrJobPermissionDenied.Code},
+		{"unauthorized", service.ErrInvalidCredentials, fiber.StatusUnauthorized, "INVALID_CREDENTIALS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := serveError(t, tt.err)
+
+			assert.Equal(t, tt.status, status)
+			assert.False(t, body.Success)
+			assert.Equal(t, tt.code, body.Code)
+			assert.NotEmpty(t, body.Message)
+		})
+	}
+}
+
+func TestErrorHandler_IncludesValidationDetails(t *testing.T) {
+	status, body := serveError(t, job.ErrRejectionReasonRequired)
+
+	assert.Equal(t, fiber.StatusBadRequest, status)
+	assert.Equal(t, job.ErrRejectionReasonRequired.Code, body.Code)
+	assert.Contains(t, body.Details, "reason")
+}
+
+func TestErrorHandler_HidesUnknownErrors(t *testing.T) {
+	status, body := serveError(t, errors.New("pq: connection refused"))
+
+	assert.Equal(t, fiber.StatusInternalServerError, status)
+	assert.Equal(t, "INTERNAL_ERROR", body.Code)
+	assert.NotContains(t, body.Message, "connection refused")
+	assert.Nil(t, body.Details)
+}
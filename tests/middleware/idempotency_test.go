@@ -0,0 +1,94 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/middleware"
+)
+
+// newIdempotentApp serves POST /apply behind the idempotency middleware and counts handler runs
+func newIdempotentApp(ttl time.Duration) (*fiber.App, *int) {
+	calls := 0
+	app := fiber.New()
+	app.Use(middleware.ErrorHandler(false))
+	app.Post("/apply", middleware.Idempotency(middleware.NewInMemoryIdempotencyStore(), ttl), func(c *fiber.Ctx) error {
+		calls++
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"success": true, "call": calls})
+	})
+	return app, &calls
+}
+
+type idempotentResult struct {
+	status   int
+	replayed string
+	body     []byte
+}
+
+func postIdempotent(t *testing.T, app *fiber.App, key, body string) idempotentResult {
+	t.Helper()
+
+	req := httptest.NewRequest(fiber.MethodPost, "/apply", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	if key != "" {
+		req.Header.Set(middleware.HeaderIdempotencyKey, key)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return idempotentResult{status: resp.StatusCode, replayed: resp.Header.Get(middleware.HeaderIdempotentReplayed), body: raw}
+}
+
+func TestIdempotency_ReplaysSameKeyAndBody(t *testing.T) {
+	app, calls := newIdempotentApp(time.Hour)
+
+	first := postIdempotent(t, app, "key-1", `{"job_id":7}`)
+	second := postIdempotent(t, app, "key-1", `{"job_id":7}`)
+
+	assert.Equal(t, 1, *calls)
+	assert.Equal(t, fiber.StatusCreated, first.status)
+	assert.Empty(t, first.replayed)
+	assert.Equal(t, fiber.StatusCreated, second.status)
+	assert.Equal(t, "true", second.replayed)
+	assert.JSONEq(t, string(first.body), string(second.body))
+
+	// Requests without a key are never deduplicated
+	postIdempotent(t, app, "", `{"job_id":7}`)
+	assert.Equal(t, 2, *calls)
+}
+
+func TestIdempotency_RejectsReusedKeyWithDifferentBody(t *testing.T) {
+	app, calls := newIdempotentApp(time.Hour)
+
+	postIdempotent(t, app, "key-1", `{"job_id":7}`)
+	conflict := postIdempotent(t, app, "key-1", `{"job_id":8}`)
+
+	assert.Equal(t, 1, *calls)
+	assert.Equal(t, fiber.StatusConflict, conflict.status)
+
+	var body errorBody
+	require.NoError(t, json.Unmarshal(conflict.body, &body))
+	assert.Equal(t, "IDEMPOTENCY_KEY_REUSED", body.Code)
+}
+
+func TestIdempotency_KeyExpiresAfterTTL(t *testing.T) {
+	app, calls := newIdempotentApp(50 * time.Millisecond)
+
+	postIdempotent(t, app, "key-1", `{"job_id":7}`)
+	time.Sleep(100 * time.Millisecond)
+	again := postIdempotent(t, app, "key-1", `{"job_id":7}`)
+
+	assert.Equal(t, 2, *calls)
+	assert.Empty(t, again.replayed)
+}
@@ -0,0 +1,168 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/config"
+	"keerja-backend/internal/domain/admin"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/utils"
+)
+
+const impersonationJWTSecret = "impersonation-test-secret"
+
+// recordingImpersonationTracker treats every session but ended as active and keeps the
+// requests it is asked to audit
+type recordingImpersonationTracker struct {
+	ended    map[int64]bool
+	requests []admin.ImpersonatedRequest
+}
+
+func (t *recordingImpersonationTracker) IsImpersonationActive(ctx context.Context, sessionID int64) (bool, error) {
+	return !t.ended[sessionID], nil
+}
+
+func (t *recordingImpersonationTracker) RecordImpersonatedRequest(ctx context.Context, req admin.ImpersonatedRequest) {
+	t.requests = append(t.requests, req)
+}
+
+// newImpersonationApp serves a read route and two mutating routes behind AuthRequired;
+// allowed lists the mutations impersonated requests may make. Handlers echo the
+// impersonating admin they see.
+func newImpersonationApp(tracker *recordingImpersonationTracker, allowed ...string) *fiber.App {
+	cfg := &config.Config{JWTSecret: impersonationJWTSecret, ImpersonationAllowedMutations: allowed}
+	authMw := middleware.NewAuthMiddleware(cfg, nil, tracker)
+
+	app := fiber.New()
+	app.Use(middleware.ErrorHandler(false))
+
+	echo := func(c *fiber.Ctx) error {
+		var adminID int64
+		if imp := middleware.GetImpersonation(c); imp != nil {
+			adminID = imp.AdminID
+		}
+		return c.JSON(fiber.Map{"user_id": middleware.GetUserID(c), "impersonator_id": adminID})
+	}
+	users := app.Group("/api/v1/users", authMw.AuthRequired())
+	users.Get("/me", echo)
+	users.Put("/me", echo)
+	users.Post("/notifications/:id/read", echo)
+	return app
+}
+
+type impersonationEcho struct {
+	UserID         int64 `json:"user_id"`
+	ImpersonatorID int64 `json:"impersonator_id"`
+}
+
+func callAs(t *testing.T, app *fiber.App, token, method, path string) (int, string, []byte) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var body json.RawMessage
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	return resp.StatusCode, resp.Header.Get(middleware.HeaderImpersonatedBy), body
+}
+
+func impersonationToken(t *testing.T, sessionID int64) string {
+	t.Helper()
+	token, err := utils.GenerateImpersonationToken(1, "seeker@example.com", "jobseeker", 7, sessionID, impersonationJWTSecret, time.Minute)
+	require.NoError(t, err)
+	return token
+}
+
+func TestImpersonation_ReadsActAsTheUser(t *testing.T) {
+	tracker := &recordingImpersonationTracker{}
+	app := newImpersonationApp(tracker)
+
+	status, header, raw := callAs(t, app, impersonationToken(t, 3), fiber.MethodGet, "/api/v1/users/me")
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "7", header)
+
+	var echo impersonationEcho
+	require.NoError(t, json.Unmarshal(raw, &echo))
+	assert.Equal(t, impersonationEcho{UserID: 1, ImpersonatorID: 7}, echo)
+
+	require.Len(t, tracker.requests, 1)
+	assert.Equal(t, admin.ImpersonatedRequest{
+		SessionID: 3, AdminID: 7, UserID: 1, Method: fiber.MethodGet, Path: "/api/v1/users/me", Status: fiber.StatusOK,
+	}, tracker.requests[0])
+}
+
+func TestImpersonation_BlocksMutations(t *testing.T) {
+	tracker := &recordingImpersonationTracker{}
+	app := newImpersonationApp(tracker)
+
+	status, header, raw := callAs(t, app, impersonationToken(t, 3), fiber.MethodPut, "/api/v1/users/me")
+	assert.Equal(t, fiber.StatusForbidden, status)
+	assert.Equal(t, "7", header, "blocked responses still carry the banner header")
+
+	var body errorBody
+	require.NoError(t, json.Unmarshal(raw, &body))
+	assert.Equal(t, admin.ErrImpersonationReadOnly.Code, body.Code)
+
+	require.Len(t, tracker.requests, 1)
+	assert.True(t, tracker.requests[0].Blocked)
+	assert.Equal(t, fiber.StatusForbidden, tracker.requests[0].Status)
+}
+
+func TestImpersonation_AllowListedMutations(t *testing.T) {
+	tracker := &recordingImpersonationTracker{}
+	app := newImpersonationApp(tracker, "POST /api/v1/users/notifications/:id/read")
+	token := impersonationToken(t, 3)
+
+	status, _, _ := callAs(t, app, token, fiber.MethodPost, "/api/v1/users/notifications/42/read")
+	assert.Equal(t, fiber.StatusOK, status)
+
+	status, _, _ = callAs(t, app, token, fiber.MethodPut, "/api/v1/users/me")
+	assert.Equal(t, fiber.StatusForbidden, status, "only listed routes are allowed")
+
+	require.Len(t, tracker.requests, 2)
+	assert.False(t, tracker.requests[0].Blocked)
+	assert.True(t, tracker.requests[1].Blocked)
+}
+
+func TestImpersonation_EndedSessionsAreRejected(t *testing.T) {
+	tracker := &recordingImpersonationTracker{ended: map[int64]bool{3: true}}
+	app := newImpersonationApp(tracker)
+
+	status, header, _ := callAs(t, app, impersonationToken(t, 3), fiber.MethodGet, "/api/v1/users/me")
+	assert.Equal(t, fiber.StatusUnauthorized, status)
+	assert.Empty(t, header)
+	assert.Empty(t, tracker.requests)
+}
+
+func TestImpersonation_UserTokensAreUnaffected(t *testing.T) {
+	tracker := &recordingImpersonationTracker{}
+	app := newImpersonationApp(tracker)
+	token, err := utils.GenerateAccessToken(1, "seeker@example.com", "jobseeker", impersonationJWTSecret, time.Minute)
+	require.NoError(t, err)
+
+	status, header, _ := callAs(t, app, token, fiber.MethodPut, "/api/v1/users/me")
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Empty(t, header)
+	assert.Empty(t, tracker.requests)
+}
+
+func TestImpersonation_RejectedWithoutTracker(t *testing.T) {
+	cfg := &config.Config{JWTSecret: impersonationJWTSecret}
+	authMw := middleware.NewAuthMiddleware(cfg, nil, nil)
+	app := fiber.New()
+	app.Get("/me", authMw.AuthRequired(), func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	status, _, _ := callAs(t, app, impersonationToken(t, 3), fiber.MethodGet, "/me")
+	assert.Equal(t, fiber.StatusUnauthorized, status)
+}
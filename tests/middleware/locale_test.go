@@ -0,0 +1,80 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/i18n"
+	"keerja-backend/internal/middleware"
+)
+
+type localeTestRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type validationBody struct {
+	Message string            `json:"message"`
+	Errors  map[string]string `json:"errors"`
+}
+
+// postInvalid sends an empty body through the localization and validation middleware
+func postInvalid(t *testing.T, acceptLanguage string) (*validationBody, string) {
+	t.Helper()
+
+	app := fiber.New()
+	app.Use(middleware.Localization())
+	app.Post("/", middleware.ValidateRequest(&localeTestRequest{}), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	req := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(`{}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	if acceptLanguage != "" {
+		req.Header.Set(fiber.HeaderAcceptLanguage, acceptLanguage)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var body validationBody
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return &body, resp.Header.Get(fiber.HeaderContentLanguage)
+}
+
+func TestLocalization_ValidationErrorsFollowAcceptLanguage(t *testing.T) {
+	body, lang := postInvalid(t, "")
+	assert.Equal(t, "id", lang)
+	assert.Equal(t, "Validasi gagal", body.Message)
+	assert.Equal(t, "Email wajib diisi", body.Errors["email"])
+
+	body, lang = postInvalid(t, "en-GB,en;q=0.8")
+	assert.Equal(t, "en", lang)
+	assert.Equal(t, "Validation failed", body.Message)
+	assert.Equal(t, "Email is required", body.Errors["email"])
+}
+
+func TestLocalization_LocaleReachesRequestContext(t *testing.T) {
+	app := fiber.New()
+	app.Use(middleware.Localization())
+	app.Get("/", func(c *fiber.Ctx) error {
+		// Handlers pass c.Context() to services, which read the locale from it
+		return c.SendString(string(i18n.FromContext(c.Context())) + "," + string(i18n.FromContext(c.UserContext())) + "," + middleware.GetTranslator(c).T("application.status.hired"))
+	})
+
+	req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+	req.Header.Set(fiber.HeaderAcceptLanguage, "en")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	got, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "en,en,Hired", string(got))
+}
@@ -0,0 +1,105 @@
+package middleware_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/apperror"
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/metrics"
+	"keerja-backend/internal/middleware"
+)
+
+// scrape returns the metrics of m in the Prometheus text format
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+
+	app := fiber.New()
+	app.Get(metrics.Path, m.Handler())
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, metrics.Path, nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestMetrics_RecordsRoutedRequests(t *testing.T) {
+	m := metrics.New()
+
+	app := fiber.New()
+	app.Use(middleware.Metrics(m))
+	app.Get(metrics.Path, m.Handler())
+	app.Use(middleware.ErrorHandler(false))
+	app.Get("/api/v1/jobs/:id", func(c *fiber.Ctx) error {
+		if c.Params("id") == "404" {
+			return apperror.NotFound("JOB_NOT_FOUND", "job not found")
+		}
+		return c.JSON(fiber.Map{"success": true})
+	})
+	app.Use(middleware.NotFoundHandler())
+
+	for _, target := range []string{"/api/v1/jobs/1", "/api/v1/jobs/2", "/api/v1/jobs/404", "/wp-login.php", metrics.Path} {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, target, nil))
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	body := scrape(t, m)
+
+	// Requests are labeled by route pattern, so both job IDs share a series
+	assert.Contains(t, body, `keerja_http_requests_total{method="GET",route="/api/v1/jobs/:id",status="200"} 2`)
+	assert.Contains(t, body, `keerja_http_requests_total{method="GET",route="/api/v1/jobs/:id",status="404"} 1`)
+	assert.Contains(t, body, `keerja_http_request_duration_seconds_count{method="GET",route="/api/v1/jobs/:id",status="200"} 2`)
+	assert.Contains(t, body, `keerja_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+
+	// Raw paths and scrapes of the endpoint itself are not recorded
+	assert.NotContains(t, body, "wp-login")
+	assert.NotContains(t, body, `route="/metrics"`)
+}
+
+func TestMetrics_RecordsCacheEventsOfDefault(t *testing.T) {
+	m := metrics.New()
+	metrics.SetDefault(m)
+	t.Cleanup(func() { metrics.SetDefault(nil) })
+
+	memCache := cache.NewInMemoryCache(1, time.Minute)
+	t.Cleanup(memCache.Stop)
+
+	memCache.Set("a", 1, time.Minute)
+	memCache.Get("a")
+	memCache.Get("missing")
+	memCache.Set("b", 2, time.Minute) // Evicts a, as the cache holds one entry
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `keerja_cache_events_total{cache="memory",event="hit"} 1`)
+	assert.Contains(t, body, `keerja_cache_events_total{cache="memory",event="miss"} 1`)
+	assert.Contains(t, body, `keerja_cache_events_total{cache="memory",event="eviction"} 1`)
+}
+
+func TestMetrics_NilRecordsNothing(t *testing.T) {
+	var m *metrics.Metrics
+
+	app := fiber.New()
+	app.Use(middleware.Metrics(m))
+	app.Get("/ping", func(c *fiber.Ctx) error { return c.SendString("pong") })
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/ping", nil))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	// The no-op mode accepts every call
+	m.CacheEvent("memory", metrics.CacheHit)
+	m.ObserveJobRun("job", time.Second, nil)
+	m.ObservePushSends(1, 1)
+	assert.NoError(t, m.RegisterDB(nil, "keerja"))
+}
@@ -0,0 +1,83 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/middleware"
+)
+
+// serveQuery sends a GET to target through ValidateQueryParams with the master data rules
+func serveQuery(t *testing.T, target string) (int, errorBody) {
+	t.Helper()
+
+	app := fiber.New()
+	app.Use(middleware.ErrorHandler(false))
+	app.Use(middleware.ValidateQueryParams(middleware.MasterDataQueryRules))
+	app.Get("/master/cities", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"success": true})
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, target, nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var body errorBody
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return resp.StatusCode, body
+}
+
+func TestValidateQueryParams_AcceptsWellFormedQueries(t *testing.T) {
+	for _, target := range []string{
+		"/master/cities",
+		"/master/cities?province_id=31&active=true&search=jakarta",
+		"/master/cities?page=&province_id=%2031%20&unknown=anything",
+		"/master/cities?q=" + strings.Repeat("a", middleware.MaxSearchLength),
+	} {
+		status, body := serveQuery(t, target)
+		assert.Equal(t, fiber.StatusOK, status, target)
+		assert.True(t, body.Success, target)
+	}
+}
+
+func TestValidateQueryParams_RejectsMalformedQueries(t *testing.T) {
+	status, body := serveQuery(t, "/master/cities?province_id=abc&active=maybe&limit=2.5&q="+
+		strings.Repeat("é", middleware.MaxSearchLength+1))
+
+	assert.Equal(t, fiber.StatusBadRequest, status)
+	assert.Equal(t, middleware.ErrInvalidQuery.Code, body.Code)
+	assert.Equal(t, map[string]string{
+		"province_id": "must be a whole number",
+		"limit":       "must be a whole number",
+		"active":      "must be true or false",
+		"q":           "must be at most 100 characters",
+	}, body.Details)
+}
+
+func TestQueryRulesCacheKey_IgnoresUnknownParamsAndOrder(t *testing.T) {
+	app := fiber.New()
+	app.Get("/master/cities", func(c *fiber.Ctx) error {
+		return c.SendString(middleware.MasterDataQueryRules.CacheKey(c))
+	})
+	key := func(target string) string {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, target, nil))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	base := key("/master/cities?province_id=31&search=bandung")
+	assert.Equal(t, "/master/cities?province_id=31&search=bandung", base)
+	assert.Equal(t, base, key("/master/cities?search=bandung&province_id=31"))
+	assert.Equal(t, base, key("/master/cities?province_id=31&search=%20bandung%20&utm_source=x&_=1700000000"))
+	assert.NotEqual(t, base, key("/master/cities?province_id=32&search=bandung"))
+}
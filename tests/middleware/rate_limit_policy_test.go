@@ -0,0 +1,169 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/config"
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/utils"
+)
+
+const rateLimitSecret = "rate-limit-test-secret"
+
+// newRateLimitedApp serves every route behind the global rate limiter with small limits
+func newRateLimitedApp(t *testing.T, window time.Duration) *fiber.App {
+	t.Helper()
+
+	store := cache.NewInMemoryKVStore(time.Minute)
+	t.Cleanup(store.Stop)
+
+	cfg := &config.Config{
+		JWTSecret:            rateLimitSecret,
+		RateLimitEnabled:     true,
+		RateLimitMax:         2,
+		RateLimitWindow:      window,
+		RateLimitAuthMax:     1,
+		RateLimitAuthWindow:  window,
+		RateLimitAuthIPMax:   3,
+		RateLimitEmployerMax: 4,
+		RateLimitAdminBypass: true,
+	}
+
+	app := fiber.New()
+	app.Use(middleware.RateLimiter(cfg, store))
+	app.All("/*", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	return app
+}
+
+func sendRateLimited(t *testing.T, app *fiber.App, method, path, token, body string) (int, string, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	if token != "" {
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	return resp.StatusCode, resp.Header.Get(middleware.HeaderRateLimitRemaining), resp.Header.Get(fiber.HeaderRetryAfter)
+}
+
+func TestRateLimiter_AnonymousRequestsLimitedByIP(t *testing.T) {
+	app := newRateLimitedApp(t, time.Minute)
+
+	status, remaining, _ := sendRateLimited(t, app, fiber.MethodGet, "/api/v1/jobs", "", "")
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "1", remaining)
+
+	status, remaining, _ = sendRateLimited(t, app, fiber.MethodGet, "/api/v1/companies", "", "")
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "0", remaining)
+
+	status, _, retryAfter := sendRateLimited(t, app, fiber.MethodGet, "/api/v1/jobs", "", "")
+	assert.Equal(t, fiber.StatusTooManyRequests, status)
+	assert.NotEmpty(t, retryAfter)
+}
+
+func TestRateLimiter_EmployersLimitedByUserID(t *testing.T) {
+	app := newRateLimitedApp(t, time.Minute)
+	first, err := utils.GenerateAccessToken(11, "a@example.com", "employer", rateLimitSecret, time.Hour)
+	require.NoError(t, err)
+	second, err := utils.GenerateAccessToken(12, "b@example.com", "employer", rateLimitSecret, time.Hour)
+	require.NoError(t, err)
+
+	// Both employers share an IP but not a bucket, and get the employer limit
+	for i := 0; i < 4; i++ {
+		status, _, _ := sendRateLimited(t, app, fiber.MethodPost, "/api/v1/applications/bulk-status", first, "")
+		require.Equal(t, fiber.StatusOK, status)
+	}
+	status, _, _ := sendRateLimited(t, app, fiber.MethodPost, "/api/v1/applications/bulk-status", first, "")
+	assert.Equal(t, fiber.StatusTooManyRequests, status)
+
+	status, remaining, _ := sendRateLimited(t, app, fiber.MethodPost, "/api/v1/applications/bulk-status", second, "")
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "3", remaining)
+
+	// Job seekers and forged tokens fall back to the per-IP default
+	seeker, err := utils.GenerateAccessToken(13, "c@example.com", "job_seeker", rateLimitSecret, time.Hour)
+	require.NoError(t, err)
+	_, remaining, _ = sendRateLimited(t, app, fiber.MethodGet, "/api/v1/jobs", seeker, "")
+	assert.Equal(t, "1", remaining)
+	forged, err := utils.GenerateAccessToken(14, "d@example.com", "employer", "other-secret", time.Hour)
+	require.NoError(t, err)
+	_, remaining, _ = sendRateLimited(t, app, fiber.MethodGet, "/api/v1/jobs", forged, "")
+	assert.Equal(t, "0", remaining)
+}
+
+func TestRateLimiter_AuthEndpointsLimitedByIPAndEmail(t *testing.T) {
+	app := newRateLimitedApp(t, time.Minute)
+
+	status, _, _ := sendRateLimited(t, app, fiber.MethodPost, "/api/v1/auth/login", "", `{"email":"sari@example.com","password":"x"}`)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	status, _, _ = sendRateLimited(t, app, fiber.MethodPost, "/api/v1/auth/login", "", `{"email":" Sari@Example.com ","password":"y"}`)
+	assert.Equal(t, fiber.StatusTooManyRequests, status)
+
+	status, _, _ = sendRateLimited(t, app, fiber.MethodPost, "/api/v1/auth/login", "", `{"email":"budi@example.com","password":"x"}`)
+	assert.Equal(t, fiber.StatusOK, status)
+}
+
+func TestRateLimiter_AuthEndpointsCappedPerIPAcrossEmails(t *testing.T) {
+	app := newRateLimitedApp(t, time.Minute)
+
+	// Every email gets its own bucket, but the IP runs out after three attempts
+	for _, email := range []string{"a", "b", "c"} {
+		status, _, _ := sendRateLimited(t, app, fiber.MethodPost, "/api/v1/auth/login", "", `{"email":"`+email+`@example.com"}`)
+		require.Equal(t, fiber.StatusOK, status, email)
+	}
+	status, remaining, retryAfter := sendRateLimited(t, app, fiber.MethodPost, "/api/v1/auth/login", "", `{"email":"d@example.com"}`)
+	assert.Equal(t, fiber.StatusTooManyRequests, status)
+	assert.Equal(t, "0", remaining)
+	assert.NotEmpty(t, retryAfter)
+
+	// Other endpoints keep the default per-IP limit
+	status, _, _ = sendRateLimited(t, app, fiber.MethodGet, "/api/v1/jobs", "", "")
+	assert.Equal(t, fiber.StatusOK, status)
+}
+
+func TestRateLimiter_AdminTokensBypass(t *testing.T) {
+	app := newRateLimitedApp(t, time.Minute)
+	token, err := utils.GenerateAdminToken(1, "admin@example.com", nil, 9, rateLimitSecret, time.Hour)
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		status, remaining, _ := sendRateLimited(t, app, fiber.MethodGet, "/api/v1/admin/companies", token, "")
+		require.Equal(t, fiber.StatusOK, status)
+		assert.Empty(t, remaining)
+	}
+}
+
+func TestRateLimiter_WindowResets(t *testing.T) {
+	window := 200 * time.Millisecond
+	app := newRateLimitedApp(t, window)
+
+	// Start at the beginning of a window so all three requests land in it
+	time.Sleep(window - time.Duration(time.Now().UnixNano()%int64(window)))
+	for i := 0; i < 2; i++ {
+		status, _, _ := sendRateLimited(t, app, fiber.MethodGet, "/api/v1/jobs", "", "")
+		require.Equal(t, fiber.StatusOK, status)
+	}
+	status, _, _ := sendRateLimited(t, app, fiber.MethodGet, "/api/v1/jobs", "", "")
+	require.Equal(t, fiber.StatusTooManyRequests, status)
+
+	// Once the previous window no longer overlaps, the caller starts over
+	time.Sleep(2 * window)
+	status, remaining, _ := sendRateLimited(t, app, fiber.MethodGet, "/api/v1/jobs", "", "")
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "1", remaining)
+}
@@ -0,0 +1,60 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/config"
+	"keerja-backend/internal/middleware"
+)
+
+func TestRequestID_HonorsOrGeneratesID(t *testing.T) {
+	var seen config.RequestInfo
+	app := fiber.New()
+	app.Use(middleware.RequestID())
+	app.Use(middleware.RequestTimeout(0))
+	app.Get("/api/v1/jobs/:id", func(c *fiber.Ctx) error {
+		seen = config.RequestInfoFromContext(c.UserContext())
+		assert.Equal(t, seen.ID, middleware.GetRequestID(c))
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	tests := []struct {
+		name   string
+		header string
+		keep   bool
+	}{
+		{"client ID", "3f2a-req_1.retry:2", true},
+		{"no ID", "", false},
+		{"unsafe characters", "abc\" level=error msg=forged", false},
+		{"too long", strings.Repeat("a", 200), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, "/api/v1/jobs/12", nil)
+			if tt.header != "" {
+				req.Header.Set(middleware.RequestIDHeader, tt.header)
+			}
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			id := resp.Header.Get(middleware.RequestIDHeader)
+			require.NotEmpty(t, id)
+			if tt.keep {
+				assert.Equal(t, tt.header, id)
+			} else {
+				assert.NotEqual(t, tt.header, id)
+				assert.Len(t, id, 36, "a UUID is generated")
+			}
+			assert.Equal(t, id, seen.ID, "services see the ID through the user context")
+			assert.Equal(t, "GET /api/v1/jobs/12", seen.Route)
+		})
+	}
+}
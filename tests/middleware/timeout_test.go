@@ -0,0 +1,56 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/middleware"
+)
+
+// newTimeoutApp serves a handler that waits for its context like a slow query would
+func newTimeoutApp(timeout time.Duration) *fiber.App {
+	app := fiber.New()
+	app.Use(middleware.RequestTimeout(timeout))
+	app.Use(middleware.ErrorHandler(false))
+	app.Get("/", func(c *fiber.Ctx) error {
+		select {
+		case <-c.UserContext().Done():
+			return c.UserContext().Err()
+		case <-time.After(time.Second):
+			return c.SendString("done")
+		}
+	})
+	return app
+}
+
+func TestRequestTimeout_CancelsSlowRequests(t *testing.T) {
+	app := newTimeoutApp(20 * time.Millisecond)
+
+	start := time.Now()
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil), 2000)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+
+	var body errorBody
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "REQUEST_TIMEOUT", body.Code)
+}
+
+func TestRequestTimeout_ZeroDisablesDeadline(t *testing.T) {
+	app := newTimeoutApp(0)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil), 2000)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,181 @@
+// Package fakes provides in-memory fakes of the domain repositories and services that the
+// service tests share. Each fake embeds the interface it stands in for, so calling a method
+// the fake does not implement panics and points at the missing behaviour.
+package fakes
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"keerja-backend/internal/domain/application"
+)
+
+// ApplicationRepository stores applications in memory and enforces the unique
+// (job_id, user_id) index over open applications the way the database does. Fakes that
+// embed it hold its lock while touching Apps, Stages or Answers.
+type ApplicationRepository struct {
+	application.ApplicationRepository
+
+	sync.Mutex
+	nextID  int64
+	Apps    map[int64]*application.JobApplication
+	Stages  []application.JobApplicationStage
+	Answers []application.ApplicationAnswer
+}
+
+// NewApplicationRepository creates an application repository holding the given applications
+func NewApplicationRepository(apps ...application.JobApplication) *ApplicationRepository {
+	r := &ApplicationRepository{Apps: make(map[int64]*application.JobApplication, len(apps))}
+	for i := range apps {
+		app := apps[i]
+		r.Apps[app.ID] = &app
+		if app.ID > r.nextID {
+			r.nextID = app.ID
+		}
+	}
+	return r
+}
+
+func (r *ApplicationRepository) Create(ctx context.Context, app *application.JobApplication) error {
+	r.Lock()
+	defer r.Unlock()
+	for _, existing := range r.Apps {
+		if existing.JobID == app.JobID && existing.UserID == app.UserID && !existing.IsWithdrawn() && !existing.IsRejected() {
+			return application.ErrAlreadyApplied
+		}
+	}
+	r.nextID++
+	app.ID = r.nextID
+	stored := *app
+	r.Apps[app.ID] = &stored
+	return nil
+}
+
+func (r *ApplicationRepository) FindByJobAndUser(ctx context.Context, jobID, userID int64) (*application.JobApplication, error) {
+	r.Lock()
+	defer r.Unlock()
+	var latest *application.JobApplication
+	for _, app := range r.Apps {
+		if app.JobID == jobID && app.UserID == userID && (latest == nil || app.ID > latest.ID) {
+			latest = app
+		}
+	}
+	if latest == nil {
+		return nil, errors.New("not found")
+	}
+	return latest, nil
+}
+
+func (r *ApplicationRepository) FindByID(ctx context.Context, id int64) (*application.JobApplication, error) {
+	r.Lock()
+	defer r.Unlock()
+	if app, ok := r.Apps[id]; ok {
+		return app, nil
+	}
+	return nil, errors.New("not found")
+}
+
+// ListByCompany lists the applications of the company in ID order, ignoring the filter
+func (r *ApplicationRepository) ListByCompany(ctx context.Context, companyID int64, filter application.ApplicationFilter, page, limit int) ([]application.JobApplication, int64, error) {
+	r.Lock()
+	defer r.Unlock()
+	var apps []application.JobApplication
+	for _, app := range r.Apps {
+		if app.CompanyID != nil && *app.CompanyID == companyID {
+			apps = append(apps, *app)
+		}
+	}
+	sort.Slice(apps, func(i, j int) bool { return apps[i].ID < apps[j].ID })
+	return apps, int64(len(apps)), nil
+}
+
+func (r *ApplicationRepository) Update(ctx context.Context, app *application.JobApplication) error {
+	r.Lock()
+	defer r.Unlock()
+	stored := *app
+	r.Apps[app.ID] = &stored
+	return nil
+}
+
+func (r *ApplicationRepository) CreateStage(ctx context.Context, stage *application.JobApplicationStage) error {
+	r.Lock()
+	defer r.Unlock()
+	r.Stages = append(r.Stages, *stage)
+	return nil
+}
+
+func (r *ApplicationRepository) CompleteStage(ctx context.Context, id int64, notes string) error {
+	return nil
+}
+
+func (r *ApplicationRepository) GetCurrentStages(ctx context.Context, applicationIDs []int64) (map[int64]application.JobApplicationStage, error) {
+	return nil, nil
+}
+
+func (r *ApplicationRepository) ListStagesByApplication(ctx context.Context, applicationID int64) ([]application.JobApplicationStage, error) {
+	r.Lock()
+	defer r.Unlock()
+	var stages []application.JobApplicationStage
+	for _, stage := range r.Stages {
+		if stage.ApplicationID == applicationID {
+			stages = append(stages, stage)
+		}
+	}
+	return stages, nil
+}
+
+func (r *ApplicationRepository) CreateAnswers(ctx context.Context, answers []application.ApplicationAnswer) error {
+	r.Lock()
+	defer r.Unlock()
+	r.Answers = append(r.Answers, answers...)
+	return nil
+}
+
+func (r *ApplicationRepository) ListAnswersByApplication(ctx context.Context, applicationID int64) ([]application.ApplicationAnswer, error) {
+	r.Lock()
+	defer r.Unlock()
+	var answers []application.ApplicationAnswer
+	for _, answer := range r.Answers {
+		if answer.ApplicationID == applicationID {
+			answers = append(answers, answer)
+		}
+	}
+	return answers, nil
+}
+
+func (r *ApplicationRepository) ListDocumentsByApplication(ctx context.Context, applicationID int64) ([]application.ApplicationDocument, error) {
+	return nil, nil
+}
+
+func (r *ApplicationRepository) ListNotesByApplication(ctx context.Context, applicationID int64) ([]application.ApplicationNote, error) {
+	return nil, nil
+}
+
+func (r *ApplicationRepository) ListInterviewsByApplication(ctx context.Context, applicationID int64) ([]application.Interview, error) {
+	return nil, nil
+}
+
+func (r *ApplicationRepository) GetApplicationStats(ctx context.Context, applicationID int64) (*application.ApplicationStats, error) {
+	return nil, nil
+}
+
+// Count returns how many applications are stored
+func (r *ApplicationRepository) Count() int {
+	r.Lock()
+	defer r.Unlock()
+	return len(r.Apps)
+}
+
+// SearchApplicationRepository finds nothing and remembers the last search filter
+type SearchApplicationRepository struct {
+	application.ApplicationRepository
+
+	Filter *application.ApplicationSearchFilter
+}
+
+func (r *SearchApplicationRepository) SearchApplications(ctx context.Context, filter application.ApplicationSearchFilter, page, limit int) ([]application.JobApplication, int64, error) {
+	r.Filter = &filter
+	return nil, 0, nil
+}
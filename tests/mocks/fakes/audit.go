@@ -0,0 +1,38 @@
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"keerja-backend/internal/domain/audit"
+)
+
+// AuditLogRepository records the audit logs written to it. When Started is set every batch
+// write signals it and then waits on Release, so tests can hold the writer mid-batch.
+type AuditLogRepository struct {
+	audit.AuditLogRepository
+
+	mu      sync.Mutex
+	logs    []audit.AuditLog
+	Started chan struct{}
+	Release chan struct{}
+	Err     error
+}
+
+func (r *AuditLogRepository) CreateBatch(ctx context.Context, logs []audit.AuditLog) error {
+	if r.Started != nil {
+		r.Started <- struct{}{}
+		<-r.Release
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logs = append(r.logs, logs...)
+	return r.Err
+}
+
+// Stored returns the audit logs written so far
+func (r *AuditLogRepository) Stored() []audit.AuditLog {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]audit.AuditLog(nil), r.logs...)
+}
@@ -0,0 +1,211 @@
+package fakes
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"keerja-backend/internal/domain/auth"
+)
+
+// OTPRepository keeps OTP codes in memory, newest last
+type OTPRepository struct {
+	auth.OTPCodeRepository
+
+	mu    sync.Mutex
+	codes []*auth.OTPCode
+}
+
+func (r *OTPRepository) Create(ctx context.Context, otp *auth.OTPCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	otp.ID = int64(len(r.codes) + 1)
+	if otp.CreatedAt.IsZero() {
+		otp.CreatedAt = time.Now()
+	}
+	r.codes = append(r.codes, otp)
+	return nil
+}
+
+// newest returns copies of the matching codes, newest first
+func (r *OTPRepository) newest(match func(*auth.OTPCode) bool) []*auth.OTPCode {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*auth.OTPCode
+	for i := len(r.codes) - 1; i >= 0; i-- {
+		if match(r.codes[i]) {
+			copied := *r.codes[i]
+			out = append(out, &copied)
+		}
+	}
+	return out
+}
+
+func (r *OTPRepository) FindByUserIDAndType(ctx context.Context, userID int64, otpType string) (*auth.OTPCode, error) {
+	codes := r.newest(func(o *auth.OTPCode) bool { return o.UserID == userID && o.Type == otpType })
+	if len(codes) == 0 {
+		return nil, nil
+	}
+	return codes[0], nil
+}
+
+func (r *OTPRepository) FindAllByUserIDAndType(ctx context.Context, userID int64, otpType string) ([]*auth.OTPCode, error) {
+	return r.newest(func(o *auth.OTPCode) bool { return o.UserID == userID && o.Type == otpType }), nil
+}
+
+func (r *OTPRepository) FindRecentByUserID(ctx context.Context, userID int64, otpType string, since time.Time) ([]*auth.OTPCode, error) {
+	return r.newest(func(o *auth.OTPCode) bool {
+		return o.UserID == userID && o.Type == otpType && !o.CreatedAt.Before(since)
+	}), nil
+}
+
+func (r *OTPRepository) FindRecentByIP(ctx context.Context, ipAddress string, since time.Time) ([]*auth.OTPCode, error) {
+	return r.newest(func(o *auth.OTPCode) bool {
+		return o.IPAddress != nil && *o.IPAddress == ipAddress && !o.CreatedAt.Before(since)
+	}), nil
+}
+
+func (r *OTPRepository) ConsumeAttempt(ctx context.Context, id int64, maxAttempts int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	otp := r.codes[id-1]
+	if otp.IsUsed || otp.Attempts >= maxAttempts {
+		return false, nil
+	}
+	otp.Attempts++
+	return true, nil
+}
+
+func (r *OTPRepository) MarkAsUsed(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	r.codes[id-1].IsUsed = true
+	r.codes[id-1].UsedAt = &now
+	return nil
+}
+
+func (r *OTPRepository) Update(ctx context.Context, otp *auth.OTPCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *otp
+	r.codes[otp.ID-1] = &copied
+	return nil
+}
+
+// Age moves every stored code back in time, as if d had passed
+func (r *OTPRepository) Age(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, otp := range r.codes {
+		otp.CreatedAt = otp.CreatedAt.Add(-d)
+		otp.ExpiredAt = otp.ExpiredAt.Add(-d)
+	}
+}
+
+// RefreshTokenRepository keeps refresh tokens in memory
+type RefreshTokenRepository struct {
+	auth.RefreshTokenRepository
+
+	mu     sync.Mutex
+	nextID int64
+	Tokens map[int64]*auth.RefreshToken
+}
+
+// NewRefreshTokenRepository creates an empty refresh token repository
+func NewRefreshTokenRepository() *RefreshTokenRepository {
+	return &RefreshTokenRepository{Tokens: make(map[int64]*auth.RefreshToken)}
+}
+
+func (r *RefreshTokenRepository) Create(ctx context.Context, token *auth.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	token.ID = r.nextID
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now()
+	}
+	stored := *token
+	r.Tokens[token.ID] = &stored
+	return nil
+}
+
+func (r *RefreshTokenRepository) FindByID(ctx context.Context, id int64) (*auth.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if token, ok := r.Tokens[id]; ok {
+		copied := *token
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (r *RefreshTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*auth.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, token := range r.Tokens {
+		if token.TokenHash == tokenHash {
+			copied := *token
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *RefreshTokenRepository) FindActiveByUserID(ctx context.Context, userID int64) ([]auth.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var tokens []auth.RefreshToken
+	for _, token := range r.Tokens {
+		if token.UserID == userID && token.IsValid() {
+			tokens = append(tokens, *token)
+		}
+	}
+	return tokens, nil
+}
+
+func (r *RefreshTokenRepository) CountActiveByUserID(ctx context.Context, userID int64) (int64, error) {
+	return 0, nil
+}
+
+func (r *RefreshTokenRepository) Rotate(ctx context.Context, oldID int64, next *auth.RefreshToken) (bool, error) {
+	r.mu.Lock()
+	old := r.Tokens[oldID]
+	if old == nil || old.Revoked {
+		r.mu.Unlock()
+		return false, nil
+	}
+	r.mu.Unlock()
+
+	if err := r.Create(ctx, next); err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	old.Revoke("rotated")
+	old.ReplacedBy = &next.ID
+	return true, nil
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string, reason string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, token := range r.Tokens {
+		if token.FamilyID == familyID && !token.Revoked {
+			token.Revoke(reason)
+		}
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) IsFamilyActive(ctx context.Context, familyID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, token := range r.Tokens {
+		if token.FamilyID == familyID && token.IsValid() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,177 @@
+package fakes
+
+import (
+	"context"
+	"time"
+
+	"keerja-backend/internal/domain/company"
+)
+
+// CompanyRepository keeps companies, their settings, employer users, followers and change
+// requests in memory. When Companies is nil every company ID resolves to a company named Acme.
+type CompanyRepository struct {
+	company.CompanyRepository
+
+	Companies      map[int64]*company.Company
+	Settings       map[int64]*company.CompanySettings
+	EmployerUsers  []company.EmployerUser
+	Followers      []company.CompanyFollower
+	ChangeRequests map[int64]*company.CompanyChangeRequest
+	AppliedChange  *company.CompanyMasterDataChange
+}
+
+func (r *CompanyRepository) FindByID(ctx context.Context, id int64) (*company.Company, error) {
+	if r.Companies == nil {
+		return &company.Company{ID: id, CompanyName: "Acme"}, nil
+	}
+	c, ok := r.Companies[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *c
+	return &copied, nil
+}
+
+func (r *CompanyRepository) FindByIDs(ctx context.Context, ids []int64) ([]company.Company, error) {
+	companies := make([]company.Company, 0, len(ids))
+	for _, id := range ids {
+		if c, _ := r.FindByID(ctx, id); c != nil {
+			companies = append(companies, *c)
+		}
+	}
+	return companies, nil
+}
+
+func (r *CompanyRepository) FindSettings(ctx context.Context, companyID int64) (*company.CompanySettings, error) {
+	return r.Settings[companyID], nil
+}
+
+// CreateEmployerUser stores the employer user under the ID after the highest stored one
+func (r *CompanyRepository) CreateEmployerUser(ctx context.Context, employerUser *company.EmployerUser) error {
+	employerUser.ID = 1
+	for _, eu := range r.EmployerUsers {
+		employerUser.ID = max(employerUser.ID, eu.ID+1)
+	}
+	r.EmployerUsers = append(r.EmployerUsers, *employerUser)
+	return nil
+}
+
+func (r *CompanyRepository) FindEmployerUserByID(ctx context.Context, id int64) (*company.EmployerUser, error) {
+	for i := range r.EmployerUsers {
+		if r.EmployerUsers[i].ID == id {
+			eu := r.EmployerUsers[i]
+			return &eu, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *CompanyRepository) FindEmployerUserByUserAndCompany(ctx context.Context, userID, companyID int64) (*company.EmployerUser, error) {
+	for i := range r.EmployerUsers {
+		if r.EmployerUsers[i].UserID == userID && r.EmployerUsers[i].CompanyID == companyID {
+			return &r.EmployerUsers[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *CompanyRepository) GetEmployerUsersByUserID(ctx context.Context, userID int64) ([]company.EmployerUser, error) {
+	var memberships []company.EmployerUser
+	for _, eu := range r.EmployerUsers {
+		if eu.UserID == userID && eu.IsActive {
+			memberships = append(memberships, eu)
+		}
+	}
+	return memberships, nil
+}
+
+func (r *CompanyRepository) GetEmployerUsersByCompanyID(ctx context.Context, companyID int64) ([]company.EmployerUser, error) {
+	var employerUsers []company.EmployerUser
+	for _, eu := range r.EmployerUsers {
+		if eu.CompanyID == companyID {
+			employerUsers = append(employerUsers, eu)
+		}
+	}
+	return employerUsers, nil
+}
+
+func (r *CompanyRepository) GetCompaniesByUserID(ctx context.Context, userID int64) ([]company.Company, error) {
+	var companies []company.Company
+	for _, eu := range r.EmployerUsers {
+		if eu.UserID == userID {
+			companies = append(companies, company.Company{ID: eu.CompanyID})
+		}
+	}
+	return companies, nil
+}
+
+func (r *CompanyRepository) UpdateEmployerUser(ctx context.Context, employerUser *company.EmployerUser) error {
+	for i := range r.EmployerUsers {
+		if r.EmployerUsers[i].ID == employerUser.ID {
+			r.EmployerUsers[i] = *employerUser
+		}
+	}
+	return nil
+}
+
+func (r *CompanyRepository) SetEmployerUserActivatedAt(ctx context.Context, employerUserID int64, activatedAt time.Time) error {
+	for i := range r.EmployerUsers {
+		if r.EmployerUsers[i].ID == employerUserID {
+			r.EmployerUsers[i].ActivatedAt = &activatedAt
+		}
+	}
+	return nil
+}
+
+func (r *CompanyRepository) DeleteEmployerUser(ctx context.Context, id int64) error {
+	for i := range r.EmployerUsers {
+		if r.EmployerUsers[i].ID == id {
+			r.EmployerUsers = append(r.EmployerUsers[:i], r.EmployerUsers[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *CompanyRepository) GetFollowers(ctx context.Context, companyID int64, page, limit int) ([]company.CompanyFollower, int64, error) {
+	start := (page - 1) * limit
+	if start >= len(r.Followers) {
+		return nil, int64(len(r.Followers)), nil
+	}
+	end := min(start+limit, len(r.Followers))
+	return r.Followers[start:end], int64(len(r.Followers)), nil
+}
+
+func (r *CompanyRepository) CreateChangeRequest(ctx context.Context, req *company.CompanyChangeRequest) error {
+	for _, existing := range r.ChangeRequests {
+		if existing.CompanyID == req.CompanyID && existing.IsPending() {
+			return company.ErrChangeRequestPending
+		}
+	}
+	if r.ChangeRequests == nil {
+		r.ChangeRequests = make(map[int64]*company.CompanyChangeRequest)
+	}
+	req.ID = int64(len(r.ChangeRequests) + 1)
+	r.ChangeRequests[req.ID] = req
+	return nil
+}
+
+func (r *CompanyRepository) FindChangeRequestByID(ctx context.Context, id int64) (*company.CompanyChangeRequest, error) {
+	req, ok := r.ChangeRequests[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *req
+	return &copied, nil
+}
+
+func (r *CompanyRepository) ApproveChangeRequest(ctx context.Context, id, decidedBy int64, note *string, change company.CompanyMasterDataChange) error {
+	r.ChangeRequests[id].Status = company.ChangeRequestApproved
+	r.AppliedChange = &change
+	return nil
+}
+
+func (r *CompanyRepository) RejectChangeRequest(ctx context.Context, id, decidedBy int64, note *string) error {
+	r.ChangeRequests[id].Status = company.ChangeRequestRejected
+	return nil
+}
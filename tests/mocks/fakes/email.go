@@ -0,0 +1,166 @@
+package fakes
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"keerja-backend/internal/domain/email"
+)
+
+// SentEmail is one email the EmailService fake was asked to send. Code holds the OTP,
+// verification code or download token of the emails that carry one.
+type SentEmail struct {
+	Method     string
+	To         string
+	Code       string
+	JobTitle   string
+	Status     string
+	Message    string
+	DaysLeft   int
+	AutoExtend bool
+}
+
+// EmailService sends nothing and records every email it was asked to send
+type EmailService struct {
+	email.EmailService
+
+	mu   sync.Mutex
+	Sent []SentEmail
+}
+
+func (s *EmailService) record(sent SentEmail) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Sent = append(s.Sent, sent)
+	return nil
+}
+
+// Last returns the most recent email, or the zero SentEmail when nothing was sent
+func (s *EmailService) Last() SentEmail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.Sent) == 0 {
+		return SentEmail{}
+	}
+	return s.Sent[len(s.Sent)-1]
+}
+
+// LastCode returns the code of the most recent email that carried one
+func (s *EmailService) LastCode() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.Sent) - 1; i >= 0; i-- {
+		if s.Sent[i].Code != "" {
+			return s.Sent[i].Code
+		}
+	}
+	return ""
+}
+
+// Recipients returns who every email was sent to, in order
+func (s *EmailService) Recipients() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	recipients := make([]string, len(s.Sent))
+	for i, sent := range s.Sent {
+		recipients[i] = sent.To
+	}
+	return recipients
+}
+
+func (s *EmailService) SendEmail(ctx context.Context, to, subject, body string) error {
+	return s.record(SentEmail{Method: "SendEmail", To: to})
+}
+
+func (s *EmailService) SendOTPEmail(ctx context.Context, to, code, purpose string) error {
+	return s.record(SentEmail{Method: "SendOTPEmail", To: to, Code: code})
+}
+
+func (s *EmailService) SendOTPRegistrationEmail(ctx context.Context, to, name, code string) error {
+	return s.record(SentEmail{Method: "SendOTPRegistrationEmail", To: to, Code: code})
+}
+
+func (s *EmailService) SendJobReviewEmail(ctx context.Context, to, name, jobTitle, companyName, status, message string) error {
+	return s.record(SentEmail{Method: "SendJobReviewEmail", To: to, JobTitle: jobTitle, Status: status, Message: message})
+}
+
+func (s *EmailService) SendFollowedCompanyJobEmail(ctx context.Context, to, name, companyName, jobTitle, jobSlug string) error {
+	return s.record(SentEmail{Method: "SendFollowedCompanyJobEmail", To: to, JobTitle: jobTitle})
+}
+
+func (s *EmailService) SendVerificationExpiryEmail(ctx context.Context, to, name, companyName string, daysLeft int, expiry time.Time) error {
+	return s.record(SentEmail{Method: "SendVerificationExpiryEmail", To: to, DaysLeft: daysLeft})
+}
+
+func (s *EmailService) SendJobExpiryReminderEmail(ctx context.Context, to, name, jobTitle, companyName string, daysLeft int, expiry time.Time, autoExtend bool) error {
+	return s.record(SentEmail{Method: "SendJobExpiryReminderEmail", To: to, JobTitle: jobTitle, DaysLeft: daysLeft, AutoExtend: autoExtend})
+}
+
+func (s *EmailService) SendDataExportEmail(ctx context.Context, to, name, token string, expiresAt time.Time) error {
+	return s.record(SentEmail{Method: "SendDataExportEmail", To: to, Code: token})
+}
+
+func (s *EmailService) SendDomainVerificationEmail(ctx context.Context, to, companyName, domain, code string, expiresAt time.Time) error {
+	return s.record(SentEmail{Method: "SendDomainVerificationEmail", To: to, Code: code})
+}
+
+// EmailQueueRepository keeps the queue in memory, honouring the pending dedupe key and
+// claiming due emails the way the postgres repository does
+type EmailQueueRepository struct {
+	email.EmailQueueRepository
+
+	Emails []*email.QueuedEmail
+}
+
+func (r *EmailQueueRepository) Enqueue(ctx context.Context, queued *email.QueuedEmail) (bool, error) {
+	for _, e := range r.Emails {
+		if e.Status == email.QueueStatusPending && e.DedupeKey == queued.DedupeKey {
+			return false, nil
+		}
+	}
+	queued.ID = int64(len(r.Emails) + 1)
+	copied := *queued
+	r.Emails = append(r.Emails, &copied)
+	return true, nil
+}
+
+func (r *EmailQueueRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]email.QueuedEmail, error) {
+	var due []email.QueuedEmail
+	for _, e := range r.Emails {
+		if e.Status == email.QueueStatusPending && !e.NextAttemptAt.After(now) && len(due) < limit {
+			e.Attempts++
+			e.NextAttemptAt = leaseUntil
+			due = append(due, *e)
+		}
+	}
+	return due, nil
+}
+
+func (r *EmailQueueRepository) MarkSent(ctx context.Context, id int64, sentAt time.Time) error {
+	e := r.Emails[id-1]
+	e.Status = email.QueueStatusSent
+	e.SentAt = &sentAt
+	return nil
+}
+
+func (r *EmailQueueRepository) MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error {
+	e := r.Emails[id-1]
+	e.NextAttemptAt = nextAttemptAt
+	e.LastError = &lastError
+	return nil
+}
+
+func (r *EmailQueueRepository) MarkDead(ctx context.Context, id int64, lastError string) error {
+	e := r.Emails[id-1]
+	e.Status = email.QueueStatusDead
+	e.LastError = &lastError
+	return nil
+}
+
+// DueAll makes every pending email due again
+func (r *EmailQueueRepository) DueAll() {
+	for _, e := range r.Emails {
+		e.NextAttemptAt = time.Time{}
+	}
+}
@@ -0,0 +1,19 @@
+package fakes
+
+import (
+	"context"
+
+	"keerja-backend/internal/service"
+)
+
+// Geocoder returns Result and Err for every address and records the addresses asked for
+type Geocoder struct {
+	Result *service.GeocodeResult
+	Err    error
+	Calls  []string
+}
+
+func (g *Geocoder) Geocode(ctx context.Context, address string) (*service.GeocodeResult, error) {
+	g.Calls = append(g.Calls, address)
+	return g.Result, g.Err
+}
@@ -0,0 +1,139 @@
+package fakes
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"keerja-backend/internal/domain/job"
+)
+
+// JobRepository keeps jobs and their skills, questions, pipeline and draft revisions in
+// memory. Jobs are looked up in Jobs first; when Job is set every other ID resolves to it.
+type JobRepository struct {
+	job.JobRepository
+
+	Job        *job.Job
+	Jobs       map[int64]*job.Job
+	Skills     map[int64][]job.JobSkill
+	Questions  []job.JobQuestion
+	Pipeline   []job.JobPipelineStage
+	Reviews    []job.JobReview
+	Revisions  []job.JobDraftRevision
+	Keep       int
+	Increments int64
+}
+
+// stored returns the job kept for the ID, or nil when there is none
+func (r *JobRepository) stored(id int64) *job.Job {
+	if j, ok := r.Jobs[id]; ok {
+		return j
+	}
+	return r.Job
+}
+
+func (r *JobRepository) FindByID(ctx context.Context, id int64) (*job.Job, error) {
+	j := r.stored(id)
+	if j == nil {
+		return nil, nil
+	}
+	copied := *j
+	return &copied, nil
+}
+
+func (r *JobRepository) FindByIDs(ctx context.Context, ids []int64) ([]job.Job, error) {
+	jobs := make([]job.Job, 0, len(ids))
+	for _, id := range ids {
+		if j := r.stored(id); j != nil {
+			jobs = append(jobs, *j)
+		}
+	}
+	return jobs, nil
+}
+
+// ListByCompany lists the company's jobs in the filter's status, in ID order
+func (r *JobRepository) ListByCompany(ctx context.Context, companyID int64, filter job.JobFilter, page, limit int) ([]job.Job, int64, error) {
+	jobs := r.list(func(j *job.Job) bool { return j.CompanyID == companyID && j.Status == filter.Status })
+	return jobs, int64(len(jobs)), nil
+}
+
+// ListByEmployer lists the jobs posted by the employer user, in ID order
+func (r *JobRepository) ListByEmployer(ctx context.Context, employerUserID int64, filter job.JobFilter, page, limit int) ([]job.Job, int64, error) {
+	jobs := r.list(func(j *job.Job) bool { return j.EmployerUserID != nil && *j.EmployerUserID == employerUserID })
+	return jobs, int64(len(jobs)), nil
+}
+
+func (r *JobRepository) list(match func(*job.Job) bool) []job.Job {
+	var jobs []job.Job
+	for _, j := range r.Jobs {
+		if match(j) {
+			jobs = append(jobs, *j)
+		}
+	}
+	sort.Slice(jobs, func(i, k int) bool { return jobs[i].ID < jobs[k].ID })
+	return jobs
+}
+
+func (r *JobRepository) SlugExists(ctx context.Context, slug string, excludeID int64) (bool, error) {
+	return false, nil
+}
+
+func (r *JobRepository) Update(ctx context.Context, j *job.Job) error {
+	if r.Jobs == nil {
+		r.Jobs = make(map[int64]*job.Job)
+	}
+	copied := *j
+	r.Jobs[j.ID] = &copied
+	return nil
+}
+
+func (r *JobRepository) ReviewJob(ctx context.Context, review *job.JobReview, status string, publishedAt, expiredAt *time.Time) error {
+	j := r.stored(review.JobID)
+	if j.Status != "pending_review" {
+		return job.ErrJobNotPendingReview
+	}
+	j.Status = status
+	j.PublishedAt = publishedAt
+	if expiredAt != nil {
+		j.ExpiredAt = expiredAt
+	}
+	r.Reviews = append(r.Reviews, *review)
+	return nil
+}
+
+func (r *JobRepository) ReplaceSkills(ctx context.Context, jobID int64, skills []job.JobSkill) error {
+	if r.Skills == nil {
+		r.Skills = make(map[int64][]job.JobSkill)
+	}
+	r.Skills[jobID] = skills
+	return nil
+}
+
+func (r *JobRepository) ListQuestionsByJob(ctx context.Context, jobID int64) ([]job.JobQuestion, error) {
+	return r.Questions, nil
+}
+
+func (r *JobRepository) ListPipelineStages(ctx context.Context, jobID int64) ([]job.JobPipelineStage, error) {
+	return r.Pipeline, nil
+}
+
+func (r *JobRepository) IncrementApplications(ctx context.Context, id int64) error {
+	atomic.AddInt64(&r.Increments, 1)
+	return nil
+}
+
+func (r *JobRepository) CreateDraftRevision(ctx context.Context, revision *job.JobDraftRevision, keep int) error {
+	r.Revisions = append(r.Revisions, *revision)
+	r.Keep = keep
+	return nil
+}
+
+func (r *JobRepository) FindDraftRevision(ctx context.Context, jobID, revisionID int64) (*job.JobDraftRevision, error) {
+	for i := range r.Revisions {
+		if r.Revisions[i].ID == revisionID && r.Revisions[i].JobID == jobID {
+			return &r.Revisions[i], nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,56 @@
+package fakes
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"keerja-backend/internal/domain/master"
+)
+
+// SkillsMasterRepository keeps skills in memory by ID
+type SkillsMasterRepository struct {
+	master.SkillsMasterRepository
+
+	Skills map[int64]*master.SkillsMaster
+}
+
+// NewSkillsMasterRepository creates a skills repository holding Go, PostgreSQL, Docker and
+// Kubernetes as skills 1-4
+func NewSkillsMasterRepository() *SkillsMasterRepository {
+	return &SkillsMasterRepository{Skills: map[int64]*master.SkillsMaster{
+		1: {ID: 1, Name: "Go", NormalizedName: "go", Aliases: []string{"Golang"}},
+		2: {ID: 2, Name: "PostgreSQL", NormalizedName: "postgresql", Aliases: []string{"Postgres"}},
+		3: {ID: 3, Name: "Docker", NormalizedName: "docker"},
+		4: {ID: 4, Name: "Kubernetes", NormalizedName: "kubernetes", Aliases: []string{"k8s"}},
+	}}
+}
+
+func (r *SkillsMasterRepository) FindByID(ctx context.Context, id int64) (*master.SkillsMaster, error) {
+	if skill, ok := r.Skills[id]; ok {
+		return skill, nil
+	}
+	return nil, errors.New("not found")
+}
+
+// ListActive lists every skill in ID order
+func (r *SkillsMasterRepository) ListActive(ctx context.Context) ([]master.SkillsMaster, error) {
+	skills := make([]master.SkillsMaster, 0, len(r.Skills))
+	for _, skill := range r.Skills {
+		skills = append(skills, *skill)
+	}
+	sort.Slice(skills, func(i, j int) bool { return skills[i].ID < skills[j].ID })
+	return skills, nil
+}
+
+// IndustryService serves industries 1-9; industry 5 is inactive
+type IndustryService struct {
+	master.IndustryService
+}
+
+func (s IndustryService) GetByID(ctx context.Context, id int64) (*master.IndustryResponse, error) {
+	if id >= 10 {
+		return nil, nil
+	}
+	return &master.IndustryResponse{ID: id, IsActive: id != 5}, nil
+}
@@ -0,0 +1,37 @@
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"keerja-backend/internal/domain/notification"
+)
+
+// PushService delivers every push and records who it was sent to
+type PushService struct {
+	notification.PushNotificationService
+
+	mu      sync.Mutex
+	UserIDs []int64
+	Message *notification.PushMessage
+}
+
+func (s *PushService) SendToUser(ctx context.Context, userID int64, message *notification.PushMessage) ([]notification.PushResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.UserIDs = append(s.UserIDs, userID)
+	s.Message = message
+	return []notification.PushResult{{Success: true}}, nil
+}
+
+func (s *PushService) SendToMultipleUsers(ctx context.Context, userIDs []int64, message *notification.PushMessage) (map[int64][]notification.PushResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.UserIDs = append(s.UserIDs, userIDs...)
+	s.Message = message
+	results := make(map[int64][]notification.PushResult, len(userIDs))
+	for _, id := range userIDs {
+		results[id] = []notification.PushResult{{Success: true}}
+	}
+	return results, nil
+}
@@ -0,0 +1,81 @@
+package fakes
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"sync"
+
+	"keerja-backend/internal/service"
+)
+
+// UploadService stores nothing and remembers which files were uploaded and deleted
+type UploadService struct {
+	service.UploadService
+
+	Uploaded []string
+	Deleted  []string
+}
+
+func (s *UploadService) UploadFile(ctx context.Context, file *multipart.FileHeader, directory string) (string, error) {
+	path := directory + "/" + file.Filename
+	s.Uploaded = append(s.Uploaded, path)
+	return path, nil
+}
+
+func (s *UploadService) DeleteFile(ctx context.Context, fileURL string) error {
+	s.Deleted = append(s.Deleted, fileURL)
+	return nil
+}
+
+// ObjectStore is an in-memory ObjectStorageClient. The next FailPuts puts fail with a
+// transient error.
+type ObjectStore struct {
+	mu             sync.Mutex
+	Objects        map[string][]byte
+	ContentTypes   map[string]string
+	FailPuts       int
+	PutAttempts    int
+	RemoveAttempts int
+}
+
+// NewObjectStore creates an empty object store
+func NewObjectStore() *ObjectStore {
+	return &ObjectStore{Objects: map[string][]byte{}, ContentTypes: map[string]string{}}
+}
+
+func (m *ObjectStore) PutObject(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.PutAttempts++
+	if m.FailPuts > 0 {
+		m.FailPuts--
+		return service.ErrStorageTransient
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	m.Objects[key] = data
+	m.ContentTypes[key] = contentType
+	return nil
+}
+
+func (m *ObjectStore) RemoveObject(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.RemoveAttempts++
+	delete(m.Objects, key)
+	return nil
+}
+
+func (m *ObjectStore) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.Objects[key]
+	if !ok {
+		return nil, service.ErrStoredFileNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
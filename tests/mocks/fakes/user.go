@@ -0,0 +1,104 @@
+package fakes
+
+import (
+	"context"
+	"fmt"
+
+	"keerja-backend/internal/domain/user"
+)
+
+// UserRepository keeps users and their preferences in memory by user ID. Users are looked
+// up in Users first; when User is set every other ID resolves to it.
+type UserRepository struct {
+	user.UserRepository
+
+	User         *user.User
+	Users        map[int64]*user.User
+	Preferences  map[int64]*user.UserPreference
+	EmailChanges []user.UserEmailChange
+}
+
+// NumberedUsers returns users with the given IDs, each reachable at user<id>@test.id
+func NumberedUsers(ids ...int64) map[int64]*user.User {
+	users := make(map[int64]*user.User, len(ids))
+	for _, id := range ids {
+		users[id] = &user.User{ID: id, Email: fmt.Sprintf("user%d@test.id", id), FullName: fmt.Sprintf("User %d", id)}
+	}
+	return users
+}
+
+func (r *UserRepository) FindByID(ctx context.Context, id int64) (*user.User, error) {
+	if u, ok := r.Users[id]; ok {
+		return u, nil
+	}
+	return r.User, nil
+}
+
+func (r *UserRepository) FindByIDs(ctx context.Context, ids []int64) ([]user.User, error) {
+	users := make([]user.User, 0, len(ids))
+	for _, id := range ids {
+		if u, _ := r.FindByID(ctx, id); u != nil {
+			users = append(users, *u)
+		}
+	}
+	return users, nil
+}
+
+func (r *UserRepository) GetFullProfile(ctx context.Context, userID int64) (*user.User, error) {
+	return r.FindByID(ctx, userID)
+}
+
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*user.User, error) {
+	return r.WithEmail(email), nil
+}
+
+// WithEmail returns the stored user with the email, or nil when there is none
+func (r *UserRepository) WithEmail(email string) *user.User {
+	for _, u := range r.Users {
+		if u.Email == email {
+			return u
+		}
+	}
+	return nil
+}
+
+// Create stores the user under the lowest free ID above the number of stored users
+func (r *UserRepository) Create(ctx context.Context, u *user.User) error {
+	if r.Users == nil {
+		r.Users = make(map[int64]*user.User)
+	}
+	u.ID = int64(len(r.Users) + 1)
+	for r.Users[u.ID] != nil {
+		u.ID++
+	}
+	r.Users[u.ID] = u
+	return nil
+}
+
+func (r *UserRepository) CreateProfile(ctx context.Context, profile *user.UserProfile) error {
+	return nil
+}
+
+func (r *UserRepository) Update(ctx context.Context, u *user.User) error {
+	r.Users[u.ID] = u
+	return nil
+}
+
+func (r *UserRepository) ChangeEmail(ctx context.Context, userID int64, oldEmail, newEmail string) error {
+	r.Users[userID].Email = newEmail
+	r.EmailChanges = append(r.EmailChanges, user.UserEmailChange{UserID: userID, OldEmail: oldEmail, NewEmail: newEmail})
+	return nil
+}
+
+func (r *UserRepository) Delete(ctx context.Context, id int64) error {
+	delete(r.Users, id)
+	return nil
+}
+
+func (r *UserRepository) FindProfileBySlug(ctx context.Context, slug string) (*user.UserProfile, error) {
+	return nil, nil
+}
+
+func (r *UserRepository) FindPreferenceByUserID(ctx context.Context, userID int64) (*user.UserPreference, error) {
+	return r.Preferences[userID], nil
+}
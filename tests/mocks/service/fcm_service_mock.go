@@ -51,9 +51,12 @@ func (m *MockFCMService) SendToTopic(ctx context.Context, topic string, message
 }
 
 // RegisterDeviceToken mocks registering a device token
-func (m *MockFCMService) RegisterDeviceToken(ctx context.Context, userID int64, token string, platform notification.Platform, deviceInfo *notification.DeviceInfo) error {
+func (m *MockFCMService) RegisterDeviceToken(ctx context.Context, userID int64, token string, platform notification.Platform, deviceInfo *notification.DeviceInfo) (*notification.DeviceToken, error) {
 	args := m.Called(ctx, userID, token, platform, deviceInfo)
-	return args.Error(0)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*notification.DeviceToken), args.Error(1)
 }
 
 // UnregisterDeviceToken mocks unregistering a device token
@@ -0,0 +1,141 @@
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/user"
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+// retainedUserTables keep rows of deleted users on purpose: applications stay for employers'
+// statistics and the deletion request records that the deletion was carried out
+var retainedUserTables = map[string]bool{
+	"job_applications":          true,
+	"account_deletion_requests": true,
+}
+
+func TestAnonymizeUser_LeavesNoPersonalData(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+
+	userID := testutil.CreateUser(t, db).ID
+	companyID := testutil.CreateCompany(t, db).ID
+	openApp := testutil.CreateApplication(t, db, testutil.CreateJob(t, db, companyID), userID).ID
+	testutil.CreateApplication(t, db, testutil.CreateJob(t, db, companyID), userID, testutil.WithApplicationStatus(application.StatusRejected))
+
+	require.NoError(t, db.Exec("UPDATE job_applications SET resume_url = 'https://cdn.example.com/cv.pdf', notes = 'Hire me' WHERE user_id = ?", userID).Error)
+	require.NoError(t, db.Exec(
+		"UPDATE job_applications SET resume_url = 'https://cdn.example.com/applications/1/cv.pdf', resume_source_url = 'https://cdn.example.com/doc.pdf' WHERE id = ?",
+		openApp,
+	).Error)
+	require.NoError(t, db.Exec("UPDATE users SET phone = '081234567890' WHERE id = ?", userID).Error)
+	require.NoError(t, db.Exec(
+		"INSERT INTO user_profiles (user_id, headline, avatar_url) VALUES (?, 'Engineer', 'https://cdn.example.com/avatar.jpg')",
+		userID,
+	).Error)
+	require.NoError(t, db.Exec(
+		"INSERT INTO user_documents (user_id, document_name, file_url) VALUES (?, 'CV', 'https://cdn.example.com/doc.pdf')",
+		userID,
+	).Error)
+	require.NoError(t, db.Exec(
+		"INSERT INTO application_documents (application_id, user_id, document_type, file_url, uploaded) VALUES (?, ?, 'cv', 'https://cdn.example.com/app-cv.pdf', true)",
+		openApp, userID,
+	).Error)
+	require.NoError(t, db.Exec(
+		"INSERT INTO device_tokens (user_id, token, platform) VALUES (?, ?, 'android')",
+		userID, fmt.Sprintf("token-%d", time.Now().UnixNano()),
+	).Error)
+	require.NoError(t, db.Exec(
+		"INSERT INTO refresh_tokens (user_id, token_hash, expires_at) VALUES (?, ?, now() + interval '1 day')",
+		userID, fmt.Sprintf("hash-%d", time.Now().UnixNano()),
+	).Error)
+	require.NoError(t, db.Exec("INSERT INTO company_followers (company_id, user_id, is_active) VALUES (?, ?, true)", companyID, userID).Error)
+	require.NoError(t, db.Exec(
+		"INSERT INTO company_reviews (company_id, user_id, rating_overall, status) VALUES (?, ?, 4, 'approved')",
+		companyID, userID,
+	).Error)
+	require.NoError(t, db.Exec(
+		"INSERT INTO account_deletion_requests (user_id, status, scheduled_for) VALUES (?, 'pending', now())",
+		userID,
+	).Error)
+
+	privacyRepo := repo.NewAccountPrivacyRepository(db)
+	files, err := privacyRepo.AnonymizeUser(ctx, userID, "deleted-test@deleted.keerja.invalid")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"https://cdn.example.com/avatar.jpg",
+		"https://cdn.example.com/doc.pdf",
+		"https://cdn.example.com/app-cv.pdf",
+		"https://cdn.example.com/applications/1/cv.pdf",
+	}, files, "the snapshot of the resume goes with the account; the pre-uploaded resume was never ours")
+
+	// Every table linked to users must be emptied, unlinked or explicitly retained
+	var linkedTables []string
+	require.NoError(t, db.Raw(`
+		SELECT DISTINCT tc.table_name
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu ON kcu.constraint_name = tc.constraint_name AND kcu.table_schema = tc.table_schema
+		JOIN information_schema.constraint_column_usage ccu ON ccu.constraint_name = tc.constraint_name AND ccu.table_schema = tc.table_schema
+		WHERE tc.constraint_type = 'FOREIGN KEY' AND ccu.table_name = 'users' AND kcu.column_name = 'user_id'`,
+	).Scan(&linkedTables).Error)
+	require.NotEmpty(t, linkedTables)
+	for _, table := range linkedTables {
+		if retainedUserTables[table] {
+			continue
+		}
+		var remaining int64
+		require.NoError(t, db.Raw("SELECT COUNT(*) FROM "+table+" WHERE user_id = ?", userID).Scan(&remaining).Error)
+		assert.Zero(t, remaining, "rows of the deleted user left in %s", table)
+	}
+
+	var account struct {
+		FullName     string
+		Email        string
+		Phone        *string
+		PasswordHash string
+		Status       string
+	}
+	require.NoError(t, db.Raw("SELECT full_name, email, phone, password_hash, status FROM users WHERE id = ?", userID).Scan(&account).Error)
+	assert.Empty(t, account.FullName)
+	assert.Equal(t, "deleted-test@deleted.keerja.invalid", account.Email)
+	assert.Nil(t, account.Phone)
+	assert.Empty(t, account.PasswordHash)
+	assert.Equal(t, user.StatusDeleted, account.Status)
+
+	var apps []struct {
+		ID              int64
+		Status          string
+		ResumeURL       *string
+		ResumeSourceURL *string
+		Notes           *string
+	}
+	require.NoError(t, db.Raw("SELECT id, status, resume_url, resume_source_url, notes FROM job_applications WHERE user_id = ? ORDER BY id", userID).Scan(&apps).Error)
+	require.Len(t, apps, 2)
+	for _, app := range apps {
+		assert.Nil(t, app.ResumeURL)
+		assert.Nil(t, app.ResumeSourceURL)
+		assert.Nil(t, app.Notes)
+	}
+	assert.Equal(t, "withdrawn", apps[0].Status)
+	assert.Equal(t, "rejected", apps[1].Status, "closed applications keep their outcome")
+
+	var withdrawnStages int64
+	require.NoError(t, db.Raw(
+		"SELECT COUNT(*) FROM job_application_stages WHERE application_id = ? AND stage_name = 'withdrawn'", openApp,
+	).Scan(&withdrawnStages).Error)
+	assert.Equal(t, int64(1), withdrawnStages)
+
+	var anonymousReviews int64
+	require.NoError(t, db.Raw(
+		"SELECT COUNT(*) FROM company_reviews WHERE company_id = ? AND user_id IS NULL AND is_anonymous", companyID,
+	).Scan(&anonymousReviews).Error)
+	assert.Equal(t, int64(1), anonymousReviews, "reviews keep counting in the company rating")
+}
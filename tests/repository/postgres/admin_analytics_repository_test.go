@@ -0,0 +1,66 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/admin"
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+func analyticsCounts(counts []admin.AnalyticsBucketCount, metric string, previous bool) map[string]int64 {
+	byDay := make(map[string]int64)
+	for _, c := range counts {
+		if c.Metric == metric && c.Previous == previous {
+			byDay[c.Bucket.Format("2006-01-02")] += c.Count
+		}
+	}
+	return byDay
+}
+
+func TestAdminAnalyticsCountByBucket_GroupsEventsByDayAndPeriod(t *testing.T) {
+	db := testutil.PerTest(t)
+	day := func(d, h int) time.Time { return time.Date(2001, time.March, d, h, 0, 0, 0, time.UTC) }
+	registeredAt := func(at time.Time) int64 { return testutil.CreateUser(t, db, testutil.WithUserCreatedAt(at)).ID }
+
+	// Requested period 5-7 March, previous period 2-4 March
+	previousFrom, from, to := day(2, 0), day(5, 0), day(8, 0)
+
+	applicant := registeredAt(day(5, 9))
+	registeredAt(day(5, 15))
+	registeredAt(day(7, 23))
+	registeredAt(day(3, 10))
+	registeredAt(day(8, 0)) // Outside both periods
+
+	companyID := testutil.CreateCompany(t, db, testutil.WithCompanyCreatedAt(day(6, 8))).ID
+	j := testutil.CreateJob(t, db, companyID, testutil.WithJobPublishedAt(day(6, 12)))
+
+	appID := testutil.CreateApplication(t, db, j, applicant, testutil.WithAppliedAt(day(6, 13))).ID
+	testutil.CreateApplication(t, db, j, registeredAt(day(1, 0)), testutil.WithAppliedAt(day(4, 13)))
+	require.NoError(t, db.Exec(
+		"INSERT INTO job_application_stages (application_id, stage_name, status, started_at) VALUES (?, 'offered', 'offered', ?), (?, 'hired', 'hired', ?)",
+		appID, day(7, 9), appID, day(7, 10),
+	).Error)
+
+	r := repo.NewAdminAnalyticsRepository(db)
+	counts, err := r.CountByBucket(context.Background(), previousFrom, from, to, admin.AnalyticsIntervalDay)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int64{"2001-03-05": 2, "2001-03-07": 1}, analyticsCounts(counts, admin.MetricNewUsers, false))
+	assert.Equal(t, map[string]int64{"2001-03-03": 1}, analyticsCounts(counts, admin.MetricNewUsers, true))
+	assert.Equal(t, map[string]int64{"2001-03-06": 1}, analyticsCounts(counts, admin.MetricNewCompanies, false))
+	assert.Equal(t, map[string]int64{"2001-03-06": 1}, analyticsCounts(counts, admin.MetricJobsPublished, false))
+	assert.Equal(t, map[string]int64{"2001-03-06": 1}, analyticsCounts(counts, admin.MetricApplicationsSubmitted, false))
+	assert.Equal(t, map[string]int64{"2001-03-04": 1}, analyticsCounts(counts, admin.MetricApplicationsSubmitted, true))
+	assert.Equal(t, map[string]int64{"2001-03-07": 1}, analyticsCounts(counts, admin.MetricHires, false))
+
+	counts, err = r.CountByBucket(context.Background(), previousFrom, from, to, admin.AnalyticsIntervalWeek)
+	require.NoError(t, err)
+	// 5 March 2001 is a Monday, so the whole requested period falls in one week
+	assert.Equal(t, map[string]int64{"2001-03-05": 3}, analyticsCounts(counts, admin.MetricNewUsers, false))
+}
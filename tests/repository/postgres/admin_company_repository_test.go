@@ -0,0 +1,66 @@
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"keerja-backend/internal/domain/admin"
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+func createAdminListDocument(t *testing.T, db *gorm.DB, companyID int64, docType, status string) {
+	t.Helper()
+
+	require.NoError(t, db.Exec(
+		"INSERT INTO company_documents (company_id, document_type, file_path, status) VALUES (?, ?, 'docs/test.pdf', ?)",
+		companyID, docType, status,
+	).Error)
+}
+
+func TestAdminListCompanies_FiltersByDocumentsAndAggregatesCounts(t *testing.T) {
+	db := testutil.PerTest(t)
+	prefix := fmt.Sprintf("AdminList%d", time.Now().UnixNano())
+
+	pendingNPWP := testutil.CreateCompany(t, db, testutil.WithCompanyName(prefix+" Pending NPWP")).ID
+	createAdminListDocument(t, db, pendingNPWP, "NPWP", "pending")
+	createAdminListDocument(t, db, pendingNPWP, "SIUP", "pending")
+	testutil.CreateJob(t, db, pendingNPWP)
+	testutil.CreateJob(t, db, pendingNPWP)
+
+	approvedNPWP := testutil.CreateCompany(t, db, testutil.WithCompanyName(prefix+" Approved NPWP")).ID
+	createAdminListDocument(t, db, approvedNPWP, "NPWP", "approved")
+	testutil.CreateJob(t, db, approvedNPWP)
+
+	r := repo.NewAdminCompanyRepository(db)
+	base := admin.AdminCompanyFilter{Page: 1, Limit: 10, Search: prefix}
+
+	filter := base
+	filter.DocumentStatus = "pending"
+	filter.DocumentType = "NPWP"
+	items, total, err := r.ListCompanies(context.Background(), &filter)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Len(t, items, 1)
+	assert.Equal(t, pendingNPWP, items[0].ID)
+	assert.Equal(t, int64(2), items[0].TotalJobs)
+	assert.Equal(t, int64(2), items[0].ActiveJobs)
+	assert.Equal(t, int64(2), items[0].PendingDocuments)
+	assert.Equal(t, "pending", items[0].VerificationStatus)
+
+	filter = base
+	filter.SortBy = admin.AdminCompanySortJobCount
+	filter.SortOrder = "asc"
+	items, total, err = r.ListCompanies(context.Background(), &filter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, items, 2)
+	assert.Equal(t, approvedNPWP, items[0].ID)
+	assert.Equal(t, pendingNPWP, items[1].ID)
+}
@@ -0,0 +1,70 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+func TestBulkAction_RecordsEachItemOnce(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	companyID := testutil.CreateCompany(t, db).ID
+	j := testutil.CreateJob(t, db, companyID)
+	otherJob := testutil.CreateJob(t, db, testutil.CreateCompany(t, db).ID)
+
+	var ids []int64
+	for i := 0; i < 3; i++ {
+		ids = append(ids, testutil.CreateApplication(t, db, j, testutil.CreateUser(t, db).ID).ID)
+	}
+	foreign := testutil.CreateApplication(t, db, otherJob, testutil.CreateUser(t, db).ID).ID
+
+	r := repo.NewApplicationRepository(db)
+
+	found, err := r.FilterCompanyApplicationIDs(ctx, companyID, append([]int64{foreign}, ids...))
+	require.NoError(t, err)
+	assert.Equal(t, ids, found)
+
+	action := &application.BulkAction{CompanyID: companyID, CreatedBy: testutil.CreateUser(t, db).ID, TargetStatus: application.StatusScreening, Status: application.BulkActionQueued, TotalCount: len(ids)}
+	require.NoError(t, r.CreateBulkAction(ctx, action, ids))
+
+	pending, err := r.ListBulkActionItems(ctx, action.ID, application.BulkItemPending, 2)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+
+	pending[0].Status, pending[0].ProcessedAt = application.BulkItemSucceeded, &now
+	pending[1].Status, pending[1].Error, pending[1].ProcessedAt = application.BulkItemFailed, "invalid transition", &now
+	require.NoError(t, r.RecordBulkActionItem(ctx, &pending[0]))
+	require.NoError(t, r.RecordBulkActionItem(ctx, &pending[1]))
+	require.NoError(t, r.RecordBulkActionItem(ctx, &pending[0]), "recording an item again is a no-op")
+
+	stored, err := r.FindBulkActionByID(ctx, action.ID)
+	require.NoError(t, err)
+	assert.Equal(t, [3]int{2, 1, 1}, [3]int{stored.ProcessedCount, stored.SucceededCount, stored.FailedCount})
+
+	unfinished, err := r.FindUnfinishedBulkActions(ctx, 10)
+	require.NoError(t, err)
+	assert.Contains(t, bulkActionIDs(unfinished), action.ID)
+
+	require.NoError(t, r.CompleteBulkAction(ctx, action.ID, now))
+	unfinished, err = r.FindUnfinishedBulkActions(ctx, 10)
+	require.NoError(t, err)
+	assert.NotContains(t, bulkActionIDs(unfinished), action.ID)
+}
+
+func bulkActionIDs(actions []application.BulkAction) []int64 {
+	ids := make([]int64, len(actions))
+	for i, action := range actions {
+		ids[i] = action.ID
+	}
+	return ids
+}
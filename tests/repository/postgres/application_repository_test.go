@@ -0,0 +1,72 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+func TestApplicationListByCompany_Paginates(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	appRepo := repo.NewApplicationRepository(db)
+
+	comp := testutil.CreateCompany(t, db)
+	backend := testutil.CreateJob(t, db, comp.ID)
+	frontend := testutil.CreateJob(t, db, comp.ID, testutil.WithJobTitle("Frontend Engineer"))
+
+	// Five applications across the company's jobs, newest first in want
+	now := time.Now()
+	var want []int64
+	for i := 0; i < 5; i++ {
+		j := backend
+		if i%2 == 1 {
+			j = frontend
+		}
+		applicant := testutil.CreateUser(t, db)
+		app := testutil.CreateApplication(t, db, j, applicant.ID, testutil.WithAppliedAt(now.Add(-time.Duration(i)*time.Hour)))
+		want = append(want, app.ID)
+	}
+
+	other := testutil.CreateJob(t, db, testutil.CreateCompany(t, db).ID)
+	testutil.CreateApplication(t, db, other, testutil.CreateUser(t, db).ID)
+
+	var got []int64
+	for page := 1; page <= 3; page++ {
+		apps, total, err := appRepo.ListByCompany(ctx, comp.ID, application.ApplicationFilter{}, page, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), total, "page %d", page)
+		assert.LessOrEqual(t, len(apps), 2)
+		got = append(got, applicationIDs(apps)...)
+	}
+	assert.Equal(t, want, got, "pages follow each other without gaps or repeats")
+
+	apps, total, err := appRepo.ListByCompany(ctx, comp.ID, application.ApplicationFilter{}, 4, 2)
+	require.NoError(t, err)
+	assert.Empty(t, apps)
+	assert.Equal(t, int64(5), total)
+}
+
+func TestApplicationListByCompany_FiltersByStatus(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	appRepo := repo.NewApplicationRepository(db)
+
+	comp := testutil.CreateCompany(t, db)
+	j := testutil.CreateJob(t, db, comp.ID)
+	shortlisted := testutil.CreateApplication(t, db, j, testutil.CreateUser(t, db).ID,
+		testutil.WithApplicationStatus(application.StatusShortlisted))
+	testutil.CreateApplication(t, db, j, testutil.CreateUser(t, db).ID)
+
+	apps, total, err := appRepo.ListByCompany(ctx, comp.ID, application.ApplicationFilter{Status: application.StatusShortlisted}, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, []int64{shortlisted.ID}, applicationIDs(apps))
+}
@@ -0,0 +1,167 @@
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"keerja-backend/internal/domain/application"
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+// candidateProfile describes the profile rows created for a search test applicant
+type candidateProfile struct {
+	skills       []string
+	degree       string
+	experience   []time.Time // Start dates of positions lasting until today
+	cityID       *int64
+	availability string
+}
+
+func createCandidate(t *testing.T, db *gorm.DB, profile candidateProfile) int64 {
+	t.Helper()
+
+	userID := testutil.CreateUser(t, db).ID
+	availability := profile.availability
+	if availability == "" {
+		availability = "open"
+	}
+	require.NoError(t, db.Exec(
+		"INSERT INTO user_profiles (user_id, city_id, availability_status) VALUES (?, ?, ?)",
+		userID, profile.cityID, availability,
+	).Error)
+	for _, skill := range profile.skills {
+		require.NoError(t, db.Exec("INSERT INTO user_skills (user_id, skill_name) VALUES (?, ?)", userID, skill).Error)
+	}
+	if profile.degree != "" {
+		require.NoError(t, db.Exec(
+			"INSERT INTO user_educations (user_id, institution_name, degree_level) VALUES (?, 'Universitas Test', ?)",
+			userID, profile.degree,
+		).Error)
+	}
+	for _, start := range profile.experience {
+		require.NoError(t, db.Exec(
+			"INSERT INTO user_experiences (user_id, company_name, position_title, start_date, is_current) VALUES (?, 'Previous Co', 'Engineer', ?, true)",
+			userID, start,
+		).Error)
+	}
+	return userID
+}
+
+func applicationIDs(apps []application.JobApplication) []int64 {
+	ids := make([]int64, 0, len(apps))
+	for _, app := range apps {
+		ids = append(ids, app.ID)
+	}
+	return ids
+}
+
+func TestSearchApplications_FiltersByApplicantProfile(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	suffix := time.Now().UnixNano()
+
+	golang := testutil.CreateSkill(t, db, fmt.Sprintf("Golang %d", suffix), fmt.Sprintf("go lang %d", suffix)).ID
+	postgres := testutil.CreateSkill(t, db, fmt.Sprintf("PostgreSQL %d", suffix)).ID
+
+	var cityID int64
+	require.NoError(t, db.Raw("SELECT id FROM cities ORDER BY id LIMIT 1").Scan(&cityID).Error)
+	if cityID == 0 {
+		t.Skip("no cities seeded")
+	}
+
+	companyID := testutil.CreateCompany(t, db).ID
+	j := testutil.CreateJob(t, db, companyID)
+	yearsAgo := func(years int) time.Time { return time.Now().AddDate(-years, 0, -1) }
+
+	// Senior: both skills (one through an alias with odd spacing), S2, 4 years, in the city
+	senior := testutil.CreateApplication(t, db, j, createCandidate(t, db, candidateProfile{
+		skills:     []string{fmt.Sprintf("  Go   Lang %d", suffix), fmt.Sprintf("postgresql %d", suffix)},
+		degree:     "S2",
+		experience: []time.Time{yearsAgo(4)},
+		cityID:     &cityID,
+	})).ID
+	// Junior: Go only, S1, two positions adding up to 2 years, not looking
+	junior := testutil.CreateApplication(t, db, j, createCandidate(t, db, candidateProfile{
+		skills:       []string{fmt.Sprintf("Golang %d", suffix)},
+		degree:       "S1",
+		experience:   []time.Time{yearsAgo(1), yearsAgo(1)},
+		availability: "not_looking",
+	})).ID
+	// Diploma: Postgres only, D3, 5 years
+	diploma := testutil.CreateApplication(t, db, j, createCandidate(t, db, candidateProfile{
+		skills:     []string{fmt.Sprintf("PostgreSQL %d", suffix)},
+		degree:     "D3",
+		experience: []time.Time{yearsAgo(5)},
+	})).ID
+
+	years := func(n int) *int { return &n }
+	base := application.ApplicationSearchFilter{CompanyIDs: []int64{companyID}, SkillMatch: application.SkillMatchAll}
+
+	tests := []struct {
+		name   string
+		filter func(f *application.ApplicationSearchFilter)
+		want   []int64
+	}{
+		{"all skills", func(f *application.ApplicationSearchFilter) { f.SkillIDs = []int64{golang, postgres} }, []int64{senior}},
+		{"any skill", func(f *application.ApplicationSearchFilter) {
+			f.SkillIDs = []int64{golang}
+			f.SkillMatch = application.SkillMatchAny
+		}, []int64{senior, junior}},
+		{"S1 or higher", func(f *application.ApplicationSearchFilter) { f.MinDegreeLevel = "S1" }, []int64{senior, junior}},
+		{"S1 with 3 years", func(f *application.ApplicationSearchFilter) {
+			f.MinDegreeLevel = "S1"
+			f.MinExperienceYears = years(3)
+		}, []int64{senior}},
+		{"postgres with 3 years", func(f *application.ApplicationSearchFilter) {
+			f.SkillIDs = []int64{postgres}
+			f.MinExperienceYears = years(3)
+		}, []int64{senior, diploma}},
+		{"city", func(f *application.ApplicationSearchFilter) { f.CityIDs = []int64{cityID} }, []int64{senior}},
+		{"availability", func(f *application.ApplicationSearchFilter) { f.AvailabilityStatuses = []string{"not_looking"} }, []int64{junior}},
+		{"no match", func(f *application.ApplicationSearchFilter) {
+			f.SkillIDs = []int64{golang}
+			f.MinDegreeLevel = "S3"
+		}, []int64{}},
+	}
+
+	appRepo := repo.NewApplicationRepository(db)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := base
+			tt.filter(&filter)
+
+			apps, total, err := appRepo.SearchApplications(ctx, filter, 1, 20)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, applicationIDs(apps))
+			assert.EqualValues(t, len(tt.want), total)
+		})
+	}
+}
+
+func TestSearchApplications_LimitedToCompanies(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+
+	ownCompany := testutil.CreateCompany(t, db).ID
+	ownJob := testutil.CreateJob(t, db, ownCompany)
+	otherJob := testutil.CreateJob(t, db, testutil.CreateCompany(t, db).ID)
+
+	applicant := createCandidate(t, db, candidateProfile{degree: "S1"})
+	own := testutil.CreateApplication(t, db, ownJob, applicant).ID
+	testutil.CreateApplication(t, db, otherJob, applicant)
+
+	apps, total, err := repo.NewApplicationRepository(db).SearchApplications(ctx, application.ApplicationSearchFilter{
+		CompanyIDs:     []int64{ownCompany},
+		MinDegreeLevel: "S1",
+	}, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{own}, applicationIDs(apps))
+	assert.EqualValues(t, 1, total)
+}
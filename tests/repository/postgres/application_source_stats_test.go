@@ -0,0 +1,94 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/job"
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+// seedSourcedApplication applies to the job with the given utm_source and status; a nil
+// source is stored as NULL
+func seedSourcedApplication(t *testing.T, db *gorm.DB, j *job.Job, source *string, status string, appliedAt time.Time) {
+	t.Helper()
+
+	app := testutil.CreateApplication(t, db, j, testutil.CreateUser(t, db).ID, testutil.WithApplicationStatus(status), testutil.WithAppliedAt(appliedAt))
+	require.NoError(t, db.Model(app).UpdateColumn("utm_source", source).Error)
+}
+
+// seedSourcedViews records count views of the job with the given utm_source
+func seedSourcedViews(t *testing.T, db *gorm.DB, jobID int64, source *string, count int, viewedAt time.Time) {
+	t.Helper()
+
+	for i := 0; i < count; i++ {
+		require.NoError(t, db.Exec(
+			"INSERT INTO job_view_events (job_id, utm_source, viewed_at) VALUES (?, ?, ?)",
+			jobID, source, viewedAt,
+		).Error)
+	}
+}
+
+func sourceStatsBySource(stats []application.SourceStats) map[string]application.SourceStats {
+	bySource := make(map[string]application.SourceStats, len(stats))
+	for _, s := range stats {
+		bySource[s.Source] = s
+	}
+	return bySource
+}
+
+func TestGetApplicationSourceStats_GroupsViewsApplicationsAndHiresBySource(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	day := func(d int) time.Time { return time.Date(2001, time.May, d, 10, 0, 0, 0, time.UTC) }
+	linkedin, empty := "linkedin", ""
+
+	companyID := testutil.CreateCompany(t, db).ID
+	backendJob := testutil.CreateJob(t, db, companyID)
+	analystJob := testutil.CreateJob(t, db, companyID)
+	backend, analyst := backendJob.ID, analystJob.ID
+
+	seedSourcedViews(t, db, backend, &linkedin, 8, day(3))
+	seedSourcedViews(t, db, analyst, &linkedin, 2, day(3))
+	seedSourcedViews(t, db, backend, nil, 5, day(4))
+	seedSourcedViews(t, db, backend, &empty, 5, day(4))
+	seedSourcedViews(t, db, backend, &linkedin, 7, day(20)) // Outside the range
+
+	seedSourcedApplication(t, db, backendJob, &linkedin, application.StatusHired, day(5))
+	seedSourcedApplication(t, db, backendJob, &linkedin, application.StatusApplied, day(5))
+	seedSourcedApplication(t, db, analystJob, &linkedin, application.StatusRejected, day(6))
+	seedSourcedApplication(t, db, backendJob, nil, application.StatusHired, day(6))
+	seedSourcedApplication(t, db, backendJob, &empty, application.StatusApplied, day(6))
+	referral := "referral"
+	seedSourcedApplication(t, db, analystJob, &referral, application.StatusApplied, day(7)) // No views
+
+	r := repo.NewApplicationRepository(db)
+	filter := application.SourceStatsFilter{CompanyID: companyID, From: day(1), To: day(10)}
+
+	stats, err := r.GetApplicationSourceStats(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, stats, 3)
+	assert.Equal(t, "linkedin", stats[0].Source, "sources with the most applications come first")
+
+	bySource := sourceStatsBySource(stats)
+	assert.Equal(t, [3]int64{10, 3, 1}, [3]int64{bySource["linkedin"].Views, bySource["linkedin"].Count, bySource["linkedin"].HiredCount})
+	assert.Equal(t, [3]int64{10, 2, 1}, [3]int64{bySource["direct"].Views, bySource["direct"].Count, bySource["direct"].HiredCount}, "missing sources count as direct")
+	assert.Equal(t, [3]int64{0, 1, 0}, [3]int64{bySource["referral"].Views, bySource["referral"].Count, bySource["referral"].HiredCount})
+
+	// Scoped to one job
+	filter.JobID = &analyst
+	stats, err = r.GetApplicationSourceStats(ctx, filter)
+	require.NoError(t, err)
+
+	bySource = sourceStatsBySource(stats)
+	require.Len(t, bySource, 2)
+	assert.Equal(t, [3]int64{2, 1, 0}, [3]int64{bySource["linkedin"].Views, bySource["linkedin"].Count, bySource["linkedin"].HiredCount})
+	assert.Equal(t, [3]int64{0, 1, 0}, [3]int64{bySource["referral"].Views, bySource["referral"].Count, bySource["referral"].HiredCount})
+}
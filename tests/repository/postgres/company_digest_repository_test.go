@@ -0,0 +1,125 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/job"
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+func TestCompanyDigestRepository_FindsCompaniesDueADigest(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	r := repo.NewCompanyDigestRepository(db)
+	now := time.Now()
+
+	neverSent := testutil.CreateCompany(t, db)
+	testutil.CreateJob(t, db, neverSent.ID)
+	testutil.CreateJob(t, db, neverSent.ID, testutil.WithJobStatus(job.StatusDraft))
+	sentLastWeek := testutil.CreateCompany(t, db)
+	sentYesterday := testutil.CreateCompany(t, db)
+	optedOut := testutil.CreateCompany(t, db)
+	testutil.CreateCompany(t, db, testutil.WithCompanyDeleted())
+
+	require.NoError(t, r.MarkWeeklyDigestSent(ctx, sentLastWeek.ID, now.AddDate(0, 0, -7)))
+	require.NoError(t, r.MarkWeeklyDigestSent(ctx, sentYesterday.ID, now.AddDate(0, 0, -1)))
+	require.NoError(t, db.Exec("INSERT INTO company_settings (company_id, stage_reminder_enabled, stage_reminder_days, weekly_digest_enabled) VALUES (?, TRUE, 7, FALSE)", optedOut.ID).Error)
+
+	companies, err := r.FindWeeklyDigestCompanies(ctx, now.Add(-company.WeeklyDigestResendAfter))
+	require.NoError(t, err)
+
+	byID := make(map[int64]company.DigestCompany)
+	for _, c := range companies {
+		byID[c.CompanyID] = c
+	}
+	require.Len(t, byID, 2)
+	assert.Equal(t, int64(1), byID[neverSent.ID].PublishedJobs)
+	assert.Equal(t, neverSent.CompanyName, byID[neverSent.ID].CompanyName)
+	assert.Contains(t, byID, sentLastWeek.ID)
+
+	// Marking a company that has no settings row keeps the default settings
+	settings, err := repo.NewCompanyRepository(db).FindSettings(ctx, sentLastWeek.ID)
+	require.NoError(t, err)
+	require.NotNil(t, settings)
+	assert.True(t, settings.WeeklyDigestEnabled)
+	assert.True(t, settings.StageReminderEnabled)
+}
+
+func TestCompanyDigestRepository_AggregatesActivity(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	r := repo.NewCompanyDigestRepository(db)
+	now := time.Now()
+	since := now.Add(-company.WeeklyDigestPeriod)
+	awaitingBefore := now.Add(-company.DigestAwaitingActionAfter)
+
+	c := testutil.CreateCompany(t, db)
+	other := testutil.CreateCompany(t, db)
+	backend := testutil.CreateJob(t, db, c.ID, testutil.WithJobTitle("Backend Engineer"))
+	designer := testutil.CreateJob(t, db, c.ID, testutil.WithJobTitle("Designer"), testutil.WithJobExpiredAt(now.AddDate(0, 0, 3)))
+	testutil.CreateJob(t, db, c.ID, testutil.WithJobTitle("Idle"))
+	otherJob := testutil.CreateJob(t, db, other.ID)
+
+	candidate := testutil.CreateUser(t, db)
+	// Backend: two new applications, one of them waiting for 4 days
+	testutil.CreateApplication(t, db, backend, testutil.CreateUser(t, db).ID)
+	testutil.CreateApplication(t, db, backend, testutil.CreateUser(t, db).ID, testutil.WithAppliedAt(now.AddDate(0, 0, -4)))
+	// Designer: an old application still waiting, and an old one already screened
+	testutil.CreateApplication(t, db, designer, testutil.CreateUser(t, db).ID, testutil.WithAppliedAt(now.AddDate(0, 0, -20)))
+	testutil.CreateApplication(t, db, designer, testutil.CreateUser(t, db).ID, testutil.WithAppliedAt(now.AddDate(0, 0, -20)), testutil.WithApplicationStatus(application.StatusScreening))
+	interviewed := testutil.CreateApplication(t, db, backend, candidate.ID, testutil.WithApplicationStatus(application.StatusInterview), testutil.WithAppliedAt(now.AddDate(0, 0, -10)))
+	testutil.CreateApplication(t, db, otherJob, testutil.CreateUser(t, db).ID)
+
+	activity, err := r.GetDigestJobActivity(ctx, c.ID, since, awaitingBefore)
+	require.NoError(t, err)
+	require.Len(t, activity, 2)
+	assert.Equal(t, company.DigestJobActivity{JobID: backend.ID, JobTitle: "Backend Engineer", NewApplications: 2, AwaitingAction: 1}, activity[0])
+	assert.Equal(t, company.DigestJobActivity{JobID: designer.ID, JobTitle: "Designer", NewApplications: 0, AwaitingAction: 1}, activity[1])
+
+	// Interviews: upcoming scheduled ones only
+	for _, iv := range []struct {
+		at     time.Time
+		status string
+	}{
+		{now.Add(48 * time.Hour), "scheduled"},
+		{now.Add(24 * time.Hour), "cancelled"},
+		{now.AddDate(0, 0, 10), "scheduled"},
+		{now.Add(-24 * time.Hour), "scheduled"},
+	} {
+		require.NoError(t, db.Exec("INSERT INTO interviews (application_id, scheduled_at, status) VALUES (?, ?, ?)", interviewed.ID, iv.at, iv.status).Error)
+	}
+	interviews, err := r.GetDigestInterviews(ctx, c.ID, now, now.Add(company.DigestLookahead))
+	require.NoError(t, err)
+	require.Len(t, interviews, 1)
+	assert.Equal(t, interviewed.ID, interviews[0].ApplicationID)
+	assert.Equal(t, candidate.FullName, interviews[0].CandidateName)
+	assert.Equal(t, "Backend Engineer", interviews[0].JobTitle)
+
+	expiring, err := r.GetDigestExpiringJobs(ctx, c.ID, now, now.Add(company.DigestLookahead))
+	require.NoError(t, err)
+	require.Len(t, expiring, 1)
+	assert.Equal(t, designer.ID, expiring[0].JobID)
+
+	// Followers: one new, one older, one who unfollowed
+	for _, f := range []struct {
+		at     time.Time
+		active bool
+	}{
+		{now.AddDate(0, 0, -2), true},
+		{now.AddDate(0, 0, -30), true},
+		{now.AddDate(0, 0, -1), false},
+	} {
+		require.NoError(t, db.Exec("INSERT INTO company_followers (company_id, user_id, followed_at, is_active) VALUES (?, ?, ?, ?)", c.ID, testutil.CreateUser(t, db).ID, f.at, f.active).Error)
+	}
+	growth, err := r.GetFollowerGrowth(ctx, c.ID, since)
+	require.NoError(t, err)
+	assert.Equal(t, company.FollowerGrowth{NewFollowers: 1, TotalFollowers: 2}, *growth)
+}
@@ -0,0 +1,74 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/company"
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+func TestCompanyDomainRepository_FindsAutoJoinCompanies(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	r := repo.NewCompanyDomainRepository(db)
+	now := time.Now()
+
+	joinable := testutil.CreateCompany(t, db, testutil.WithCompanyEmailDomain("@Acme.co.id"))
+	unverified := testutil.CreateCompany(t, db, testutil.WithCompanyEmailDomain("acme.co.id"))
+	optedOut := testutil.CreateCompany(t, db, testutil.WithCompanyEmailDomain("acme.co.id"))
+	deleted := testutil.CreateCompany(t, db, testutil.WithCompanyEmailDomain("acme.co.id"), testutil.WithCompanyDeleted())
+
+	for _, c := range []*company.Company{joinable, unverified, optedOut, deleted} {
+		v := &company.DomainVerification{CompanyID: c.ID, Domain: "acme.co.id", Method: company.DomainVerificationDNS, Token: "t"}
+		if c != unverified {
+			v.VerifiedAt = &now
+		}
+		require.NoError(t, r.SaveDomainVerification(ctx, v))
+		require.NoError(t, db.Exec("INSERT INTO company_settings (company_id, auto_join_enabled) VALUES (?, ?)", c.ID, c != optedOut).Error)
+	}
+
+	companies, err := r.FindAutoJoinCompanies(ctx, "acme.co.id")
+	require.NoError(t, err)
+	require.Len(t, companies, 1)
+	assert.Equal(t, joinable.ID, companies[0].ID)
+
+	// Saving again replaces the verification
+	require.NoError(t, r.SaveDomainVerification(ctx, &company.DomainVerification{CompanyID: joinable.ID, Domain: "acme.co.id", Method: company.DomainVerificationEmail, Token: "hash"}))
+	v, err := r.FindDomainVerification(ctx, joinable.ID)
+	require.NoError(t, err)
+	assert.Nil(t, v.VerifiedAt)
+	assert.Equal(t, company.DomainVerificationEmail, v.Method)
+
+	companies, err = r.FindAutoJoinCompanies(ctx, "acme.co.id")
+	require.NoError(t, err)
+	assert.Empty(t, companies)
+}
+
+func TestCompanyDomainRepository_ListsJoinRequests(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	r := repo.NewCompanyDomainRepository(db)
+	now := time.Now()
+
+	c := testutil.CreateCompany(t, db)
+	testutil.CreateEmployerUser(t, db, testutil.CreateUser(t, db).ID, c.ID, testutil.WithRole("owner"))
+	testutil.CreateEmployerUser(t, db, testutil.CreateUser(t, db).ID, c.ID, testutil.WithEmployerUserInactive())
+	later := testutil.CreateUser(t, db)
+	earlier := testutil.CreateUser(t, db)
+	testutil.CreateEmployerUser(t, db, later.ID, c.ID, testutil.WithRole("viewer"), testutil.WithJoinRequested(now))
+	first := testutil.CreateEmployerUser(t, db, earlier.ID, c.ID, testutil.WithRole("viewer"), testutil.WithJoinRequested(now.Add(-time.Hour)))
+
+	requests, err := r.ListJoinRequests(ctx, c.ID)
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+	assert.Equal(t, first.ID, requests[0].EmployerUserID)
+	assert.Equal(t, earlier.Email, requests[0].Email)
+	assert.Equal(t, "viewer", requests[0].Role)
+	assert.Equal(t, later.ID, requests[1].UserID)
+}
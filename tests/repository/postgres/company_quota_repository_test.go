@@ -0,0 +1,61 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/job"
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+func TestCompanyQuotaRepository_CountsPublishedUnexpiredJobs(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	r := repo.NewCompanyQuotaRepository(db)
+
+	c := testutil.CreateCompany(t, db)
+	testutil.CreateJob(t, db, c.ID)
+	testutil.CreateJob(t, db, c.ID)
+	testutil.CreateJob(t, db, c.ID, testutil.WithJobStatus(job.StatusClosed))
+	testutil.CreateJob(t, db, c.ID, testutil.WithJobStatus(job.StatusDraft))
+	testutil.CreateJob(t, db, c.ID, testutil.WithJobExpiredAt(time.Now().Add(-time.Hour)))
+	testutil.CreateJob(t, db, c.ID, testutil.WithJobDeleted())
+	testutil.CreateJob(t, db, testutil.CreateCompany(t, db).ID)
+
+	count, err := r.CountPublishedJobs(ctx, c.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestCompanyQuotaRepository_PlansAndOverrides(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	r := repo.NewCompanyQuotaRepository(db)
+
+	c := testutil.CreateCompany(t, db)
+	plan := company.PlanPremium
+	require.NoError(t, r.SetCompanyPlan(ctx, c.ID, &plan))
+	var stored *string
+	require.NoError(t, db.Raw("SELECT plan FROM companies WHERE id = ?", c.ID).Scan(&stored).Error)
+	require.NotNil(t, stored)
+	assert.Equal(t, company.PlanPremium, *stored)
+
+	require.NoError(t, r.SetCompanyPlan(ctx, c.ID, nil))
+	require.NoError(t, db.Raw("SELECT plan FROM companies WHERE id = ?", c.ID).Scan(&stored).Error)
+	assert.Nil(t, stored)
+	assert.ErrorIs(t, r.SetCompanyPlan(ctx, -1, &plan), company.ErrCompanyNotFound)
+
+	// Saving a plan's override twice replaces it
+	require.NoError(t, r.SavePlanQuota(ctx, &company.PlanQuota{Plan: company.PlanFree, MaxPublishedJobs: 5}))
+	require.NoError(t, r.SavePlanQuota(ctx, &company.PlanQuota{Plan: company.PlanFree, MaxPublishedJobs: 7}))
+	quotas, err := r.ListPlanQuotas(ctx)
+	require.NoError(t, err)
+	require.Len(t, quotas, 1)
+	assert.Equal(t, 7, quotas[0].MaxPublishedJobs)
+}
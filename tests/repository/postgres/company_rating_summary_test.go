@@ -0,0 +1,98 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+func createRatedReview(t *testing.T, db *gorm.DB, companyID int64, overall float64, status string) int64 {
+	t.Helper()
+
+	var id int64
+	userID := testutil.CreateUser(t, db).ID
+	require.NoError(t, db.Raw(
+		"INSERT INTO company_reviews (company_id, user_id, rating_overall, rating_culture, status) VALUES (?, ?, ?, ?, ?) RETURNING id",
+		companyID, userID, overall, overall, status,
+	).Scan(&id).Error)
+	return id
+}
+
+func TestRatingSummary_MatchesApprovedReviewsAfterModeration(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	companyID := testutil.CreateCompany(t, db).ID
+	companyRepo := repo.NewCompanyRepository(db)
+
+	createRatedReview(t, db, companyID, 4, "approved")
+	pending := createRatedReview(t, db, companyID, 2, "pending")
+	deleted := createRatedReview(t, db, companyID, 5, "approved")
+
+	// The backfill picks up a company that has reviews but no summary
+	refreshed, err := companyRepo.RefreshStaleRatingSummaries(ctx)
+	require.NoError(t, err)
+	assert.Positive(t, refreshed)
+
+	ratings, err := companyRepo.CalculateAverageRatings(ctx, companyID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), ratings.TotalReviews)
+	assert.InDelta(t, 4.5, ratings.Overall, 0.001)
+	assert.InDelta(t, 4.5, ratings.Culture, 0.001)
+
+	require.NoError(t, companyRepo.ApproveReview(ctx, pending, 1))
+	require.NoError(t, companyRepo.RefreshRatingSummary(ctx, companyID))
+	ratings, err = companyRepo.CalculateAverageRatings(ctx, companyID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), ratings.TotalReviews)
+	assert.InDelta(t, 11.0/3, ratings.Overall, 0.01)
+
+	// A deletion without a refresh leaves the count stale until the job runs
+	require.NoError(t, companyRepo.DeleteReview(ctx, deleted))
+	_, err = companyRepo.RefreshStaleRatingSummaries(ctx)
+	require.NoError(t, err)
+	ratings, err = companyRepo.CalculateAverageRatings(ctx, companyID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), ratings.TotalReviews)
+	assert.InDelta(t, 3, ratings.Overall, 0.001)
+
+	require.NoError(t, companyRepo.RejectReview(ctx, pending, 1))
+	require.NoError(t, companyRepo.RefreshRatingSummary(ctx, companyID))
+	ratings, err = companyRepo.CalculateAverageRatings(ctx, companyID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), ratings.TotalReviews)
+	assert.InDelta(t, 4, ratings.Overall, 0.001)
+}
+
+func TestGetTopRatedCompanies_ReadsSummaries(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	companyRepo := repo.NewCompanyRepository(db)
+
+	best := testutil.CreateCompany(t, db).ID
+	good := testutil.CreateCompany(t, db).ID
+	few := testutil.CreateCompany(t, db).ID
+	for i := 0; i < 5; i++ {
+		createRatedReview(t, db, best, 5, "approved")
+		createRatedReview(t, db, good, 4, "approved")
+	}
+	createRatedReview(t, db, few, 5, "approved")
+	for _, id := range []int64{best, good, few} {
+		require.NoError(t, companyRepo.RefreshRatingSummary(ctx, id))
+	}
+
+	companies, err := companyRepo.GetTopRatedCompanies(ctx, 100)
+	require.NoError(t, err)
+	var ours []int64
+	for _, c := range companies {
+		if c.ID == best || c.ID == good || c.ID == few {
+			ours = append(ours, c.ID)
+		}
+	}
+	assert.Equal(t, []int64{best, good}, ours, "companies with under 5 reviews are left out")
+}
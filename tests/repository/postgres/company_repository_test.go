@@ -0,0 +1,143 @@
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/user"
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+func companyIDs(companies []company.Company) []int64 {
+	ids := make([]int64, 0, len(companies))
+	for _, c := range companies {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}
+
+func TestCompanyList_Filters(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	companyRepo := repo.NewCompanyRepository(db)
+
+	// A city of its own keeps companies of other tests out of the results
+	city := fmt.Sprintf("Filter City %d", time.Now().UnixNano())
+	verified := testutil.CreateCompany(t, db, testutil.WithCompanyCity(city), testutil.WithCompanyVerified(),
+		testutil.WithCompanyType("private"), testutil.WithCompanyName("Nusantara Logistics"))
+	startup := testutil.CreateCompany(t, db, testutil.WithCompanyCity(city), testutil.WithCompanyType("startup"),
+		testutil.WithCompanyIndustry("Fintech"))
+	inactive := testutil.CreateCompany(t, db, testutil.WithCompanyCity(city), testutil.WithCompanyInactive())
+	testutil.CreateCompany(t, db, testutil.WithCompanyCity(city), testutil.WithCompanyDeleted())
+	testutil.CreateCompany(t, db, testutil.WithCompanyType("startup"))
+
+	list := func(filter company.CompanyFilter) ([]int64, int64) {
+		t.Helper()
+		filter.City = &city
+		companies, total, err := companyRepo.List(ctx, &filter)
+		require.NoError(t, err)
+		return companyIDs(companies), total
+	}
+
+	ids, total := list(company.CompanyFilter{})
+	assert.ElementsMatch(t, []int64{verified.ID, startup.ID, inactive.ID}, ids, "deleted companies are not listed")
+	assert.Equal(t, int64(3), total)
+
+	isVerified, isActive := true, false
+	ids, _ = list(company.CompanyFilter{Verified: &isVerified})
+	assert.Equal(t, []int64{verified.ID}, ids)
+
+	ids, _ = list(company.CompanyFilter{IsActive: &isActive})
+	assert.Equal(t, []int64{inactive.ID}, ids)
+
+	companyType, industry := "startup", "Fintech"
+	ids, _ = list(company.CompanyFilter{CompanyType: &companyType})
+	assert.Equal(t, []int64{startup.ID}, ids)
+
+	ids, _ = list(company.CompanyFilter{Industry: &industry})
+	assert.Equal(t, []int64{startup.ID}, ids)
+
+	search := "nusantara"
+	ids, _ = list(company.CompanyFilter{SearchQuery: &search})
+	assert.Equal(t, []int64{verified.ID}, ids, "search is case insensitive")
+}
+
+func TestCompanyList_Paginates(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	companyRepo := repo.NewCompanyRepository(db)
+
+	city := fmt.Sprintf("Page City %d", time.Now().UnixNano())
+	var created []int64
+	for i := 0; i < 3; i++ {
+		created = append(created, testutil.CreateCompany(t, db, testutil.WithCompanyCity(city)).ID)
+	}
+
+	filter := &company.CompanyFilter{City: &city, Limit: 2, Page: 1, SortBy: "id", SortOrder: "asc"}
+	companies, total, err := companyRepo.List(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Equal(t, created[:2], companyIDs(companies))
+
+	filter.Page = 2
+	companies, total, err = companyRepo.List(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total, "the total counts every page")
+	assert.Equal(t, created[2:], companyIDs(companies))
+}
+
+func TestAcceptInvitationForNewUser_CreatesUserOnlyWithTheMembership(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	companyRepo := repo.NewCompanyRepository(db)
+
+	owner := testutil.CreateUser(t, db, testutil.WithUserType("employer"))
+	comp := testutil.CreateCompany(t, db)
+	invitation := &company.CompanyInvitation{
+		CompanyID: comp.ID,
+		Email:     fmt.Sprintf("invitee-%d@example.com", time.Now().UnixNano()),
+		FullName:  "Invitee",
+		Role:      "recruiter",
+		Token:     fmt.Sprintf("token-%d", time.Now().UnixNano()),
+		Status:    company.InvitationStatusPending,
+		InvitedBy: owner.ID,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	require.NoError(t, companyRepo.CreateInvitation(ctx, invitation))
+
+	accept := func() (*user.User, error) {
+		now := time.Now()
+		newUser := &user.User{FullName: "Invitee", Email: invitation.Email, PasswordHash: "not-a-password-hash", UserType: "employer", Status: "active"}
+		invitation.AcceptedAt, invitation.UpdatedAt = &now, now
+		employerUser := &company.EmployerUser{CompanyID: comp.ID, Role: invitation.Role, IsActive: true, CreatedAt: now, UpdatedAt: now}
+		return newUser, companyRepo.AcceptInvitationForNewUser(ctx, invitation, newUser, employerUser)
+	}
+
+	newUser, err := accept()
+	require.NoError(t, err)
+	membership, err := companyRepo.FindEmployerUserByUserAndCompany(ctx, newUser.ID, comp.ID)
+	require.NoError(t, err)
+	require.NotNil(t, membership)
+	assert.Equal(t, "recruiter", membership.Role)
+
+	stored, err := companyRepo.FindInvitationByToken(ctx, invitation.Token)
+	require.NoError(t, err)
+	assert.Equal(t, company.InvitationStatusAccepted, stored.Status)
+	assert.Equal(t, newUser.ID, *stored.AcceptedBy)
+
+	// A second signup with the used token is rolled back together with its user
+	invitation.Email = fmt.Sprintf("second-%d@example.com", time.Now().UnixNano())
+	_, err = accept()
+	assert.ErrorIs(t, err, company.ErrInvitationUnavailable)
+
+	var count int64
+	require.NoError(t, db.Model(&user.User{}).Where("email = ?", invitation.Email).Count(&count).Error)
+	assert.Zero(t, count)
+}
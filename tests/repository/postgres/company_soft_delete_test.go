@@ -0,0 +1,94 @@
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/admin"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/employer"
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+func TestCompanySoftDelete_HidesAndRestoresCompanyData(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	name := fmt.Sprintf("SoftDelete%d", time.Now().UnixNano())
+
+	companyID := testutil.CreateCompany(t, db, testutil.WithCompanyName(name)).ID
+	owner := testutil.CreateUser(t, db).ID
+	follower := testutil.CreateUser(t, db).ID
+	testutil.CreateEmployerUser(t, db, owner, companyID, testutil.WithRole(employer.RoleOwner))
+	testutil.CreateEmployerUser(t, db, testutil.CreateUser(t, db).ID, companyID, testutil.WithEmployerUserInactive())
+	require.NoError(t, db.Exec("INSERT INTO company_followers (company_id, user_id, is_active) VALUES (?, ?, true)", companyID, follower).Error)
+	require.NoError(t, db.Exec(
+		"INSERT INTO company_reviews (company_id, user_id, rating_overall, status) VALUES (?, ?, 4, 'approved')",
+		companyID, follower,
+	).Error)
+
+	companyRepo := repo.NewCompanyRepository(db)
+	adminRepo := repo.NewAdminCompanyRepository(db)
+	listed := func() (int64, int64) {
+		_, total, err := companyRepo.List(ctx, &company.CompanyFilter{SearchQuery: &name, Page: 1, Limit: 10})
+		require.NoError(t, err)
+		_, adminTotal, err := adminRepo.ListCompanies(ctx, &admin.AdminCompanyFilter{Page: 1, Limit: 10, Search: name})
+		require.NoError(t, err)
+		return total, adminTotal
+	}
+	activeEmployers := func() []int64 {
+		var ids []int64
+		require.NoError(t, db.Raw("SELECT user_id FROM employer_users WHERE company_id = ? AND is_active ORDER BY user_id", companyID).Scan(&ids).Error)
+		return ids
+	}
+
+	require.NoError(t, companyRepo.Delete(ctx, companyID))
+
+	total, adminTotal := listed()
+	assert.Zero(t, total)
+	assert.Zero(t, adminTotal)
+	_, deletedTotal, err := adminRepo.ListCompanies(ctx, &admin.AdminCompanyFilter{Page: 1, Limit: 10, Search: name, Deleted: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deletedTotal)
+
+	found, err := companyRepo.FindByID(ctx, companyID)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+	followers, err := companyRepo.CountFollowers(ctx, companyID)
+	require.NoError(t, err)
+	assert.Zero(t, followers)
+	_, reviews, err := companyRepo.GetReviewsByCompanyID(ctx, companyID, nil)
+	require.NoError(t, err)
+	assert.Zero(t, reviews)
+	assert.Empty(t, activeEmployers())
+
+	deleted, err := companyRepo.FindDeletedByID(ctx, companyID)
+	require.NoError(t, err)
+	require.NotNil(t, deleted)
+	taken, err := companyRepo.SlugExists(ctx, deleted.Slug, 0)
+	require.NoError(t, err)
+	assert.True(t, taken, "deleted companies keep their slug reserved")
+
+	require.NoError(t, companyRepo.Restore(ctx, companyID))
+
+	total, adminTotal = listed()
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, int64(1), adminTotal)
+	followers, err = companyRepo.CountFollowers(ctx, companyID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), followers)
+	_, reviews, err = companyRepo.GetReviewsByCompanyID(ctx, companyID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), reviews)
+	// Only the membership the deletion deactivated comes back
+	assert.Equal(t, []int64{owner}, activeEmployers())
+
+	deleted, err = companyRepo.FindDeletedByID(ctx, companyID)
+	require.NoError(t, err)
+	assert.Nil(t, deleted)
+}
@@ -0,0 +1,56 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/job"
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+func TestListByCursor_StableWhileRowsAreInserted(t *testing.T) {
+	db := testutil.PerTest(t)
+	companyID := testutil.CreateCompany(t, db).ID
+
+	// Rows inserted in one transaction share created_at, so paging relies on the id tiebreak
+	var original []int64
+	for i := 0; i < 5; i++ {
+		original = append(original, testutil.CreateJob(t, db, companyID).ID)
+	}
+
+	r := repo.NewJobRepository(db)
+	filter := job.JobFilter{CompanyID: companyID, Status: "published"}
+
+	var seen []int64
+	cursor := ""
+	for page := 0; page < 10; page++ {
+		jobs, next, err := r.ListByCursor(context.Background(), filter, cursor, 2)
+		require.NoError(t, err)
+		seen = append(seen, jobIDs(jobs)...)
+
+		// Newer rows land before the cursor and must not shift later pages
+		testutil.CreateJob(t, db, companyID)
+
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	expected := make([]int64, 0, len(original))
+	for i := len(original) - 1; i >= 0; i-- {
+		expected = append(expected, original[i])
+	}
+	assert.Equal(t, expected, seen)
+}
+
+func TestListByCursor_RejectsInvalidCursor(t *testing.T) {
+	db := testutil.PerTest(t)
+
+	_, _, err := repo.NewJobRepository(db).ListByCursor(context.Background(), job.JobFilter{}, "not-a-cursor", 10)
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"keerja-backend/internal/domain/master"
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+// createLocation inserts a province, city and district and returns their IDs
+func createLocation(t *testing.T, db *gorm.DB, name string) (provinceID, cityID, districtID int64) {
+	t.Helper()
+
+	code := func() string { return fmt.Sprintf("T%d", time.Now().UnixNano()%1e9) }
+	require.NoError(t, db.Raw("INSERT INTO provinces (name, code) VALUES (?, ?) RETURNING id", "Province "+name, code()).Scan(&provinceID).Error)
+	require.NoError(t, db.Raw("INSERT INTO cities (province_id, name, type, code) VALUES (?, ?, 'Kota', ?) RETURNING id", provinceID, "City "+name, code()).Scan(&cityID).Error)
+	require.NoError(t, db.Raw("INSERT INTO districts (city_id, name, code) VALUES (?, ?, ?) RETURNING id", cityID, name, code()).Scan(&districtID).Error)
+	return provinceID, cityID, districtID
+}
+
+func TestDistrictMergeInto_RemapsReferencesAndDeactivatesSource(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+
+	sourceProvince, sourceCity, sourceID := createLocation(t, db, "Old Kecamatan")
+	targetProvince, targetCity, targetID := createLocation(t, db, "New Kecamatan")
+	_, _, otherID := createLocation(t, db, "Untouched Kecamatan")
+
+	companyID := testutil.CreateCompany(t, db).ID
+	require.NoError(t, db.Exec("UPDATE companies SET district_id = ?, city_id = ?, province_id = ? WHERE id = ?", sourceID, sourceCity, sourceProvince, companyID).Error)
+
+	var addressID int64
+	require.NoError(t, db.Raw(
+		"INSERT INTO company_addresses (company_id, full_address, district_id, city_id, province_id) VALUES (?, 'Jl. Merdeka 1', ?, ?, ?) RETURNING id",
+		companyID, sourceID, sourceCity, sourceProvince,
+	).Scan(&addressID).Error)
+	jobID := testutil.CreateJob(t, db, companyID).ID
+	require.NoError(t, db.Exec("UPDATE jobs SET company_address_id = ? WHERE id = ?", addressID, jobID).Error)
+
+	userID := testutil.CreateUser(t, db).ID
+	otherUserID := testutil.CreateUser(t, db).ID
+	require.NoError(t, db.Exec("INSERT INTO user_profiles (user_id, district_id, city_id, province_id) VALUES (?, ?, ?, ?)", userID, sourceID, sourceCity, sourceProvince).Error)
+	require.NoError(t, db.Exec("INSERT INTO user_profiles (user_id, district_id) VALUES (?, ?)", otherUserID, otherID).Error)
+
+	r := repo.NewDistrictRepository(db)
+
+	usage, err := r.CountUsage(ctx, sourceID)
+	require.NoError(t, err)
+	assert.Equal(t, master.DistrictUsage{DistrictID: sourceID, Companies: 1, CompanyAddresses: 1, UserProfiles: 1, Jobs: 1}, *usage)
+
+	result, err := r.MergeInto(ctx, sourceID, targetID)
+	require.NoError(t, err)
+	assert.Equal(t, master.DistrictMergeResult{SourceID: sourceID, TargetID: targetID, CompaniesMoved: 1, CompanyAddressesMoved: 1, UserProfilesMoved: 1}, *result)
+
+	var location struct{ DistrictID, CityID, ProvinceID int64 }
+	require.NoError(t, db.Raw("SELECT district_id, city_id, province_id FROM companies WHERE id = ?", companyID).Scan(&location).Error)
+	assert.Equal(t, []int64{targetID, targetCity, targetProvince}, []int64{location.DistrictID, location.CityID, location.ProvinceID})
+	require.NoError(t, db.Raw("SELECT district_id, city_id, province_id FROM company_addresses WHERE id = ?", addressID).Scan(&location).Error)
+	assert.Equal(t, []int64{targetID, targetCity, targetProvince}, []int64{location.DistrictID, location.CityID, location.ProvinceID})
+	require.NoError(t, db.Raw("SELECT district_id, city_id, province_id FROM user_profiles WHERE user_id = ?", userID).Scan(&location).Error)
+	assert.Equal(t, []int64{targetID, targetCity, targetProvince}, []int64{location.DistrictID, location.CityID, location.ProvinceID})
+
+	usage, err = r.CountUsage(ctx, sourceID)
+	require.NoError(t, err)
+	assert.False(t, usage.InUse(), "the merged district can be deleted")
+	usage, err = r.CountUsage(ctx, targetID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), usage.Jobs, "jobs follow their address")
+	usage, err = r.CountUsage(ctx, otherID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), usage.UserProfiles)
+
+	source, err := r.GetByID(ctx, sourceID)
+	require.NoError(t, err)
+	assert.False(t, source.IsActive)
+
+	_, err = r.MergeInto(ctx, sourceID, 0)
+	assert.ErrorIs(t, err, master.ErrDistrictNotFound)
+}
@@ -0,0 +1,62 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/job"
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+func TestJobPipelineStages_ReplaceAndList(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	jobRepo := repo.NewJobRepository(db)
+
+	j := testutil.CreateJob(t, db, testutil.CreateCompany(t, db).ID)
+	require.NoError(t, jobRepo.ReplacePipelineStages(ctx, j.ID, job.DefaultPipeline(j.ID)))
+
+	pipeline, err := job.BuildPipeline(j.ID, []job.CustomPipelineStage{{Name: "Technical Test", MapsToStatus: application.StatusShortlisted}})
+	require.NoError(t, err)
+	require.NoError(t, jobRepo.ReplacePipelineStages(ctx, j.ID, pipeline))
+
+	stages, err := jobRepo.ListPipelineStages(ctx, j.ID)
+	require.NoError(t, err)
+	require.Len(t, stages, len(job.PipelineStatuses)+1)
+	assert.Equal(t, "Technical Test", stages[3].Name)
+	assert.Equal(t, 4, stages[3].StageOrder)
+	assert.Equal(t, application.StatusShortlisted, stages[3].MapsToStatus)
+}
+
+func TestConversionFunnel_CountsCustomStagesUnderTheirStatus(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	appRepo := repo.NewApplicationRepository(db)
+
+	j := testutil.CreateJob(t, db, testutil.CreateCompany(t, db).ID)
+	inTest := testutil.CreateApplication(t, db, j, testutil.CreateUser(t, db).ID, testutil.WithApplicationStatus(application.StatusShortlisted))
+	testutil.CreateApplication(t, db, j, testutil.CreateUser(t, db).ID, testutil.WithApplicationStatus(application.StatusShortlisted))
+	testutil.CreateApplication(t, db, j, testutil.CreateUser(t, db).ID)
+
+	// The application in the custom stage keeps its canonical status
+	require.NoError(t, appRepo.CreateStage(ctx, &application.JobApplicationStage{
+		ApplicationID: inTest.ID,
+		StageName:     "Technical Test",
+		Status:        application.StatusShortlisted,
+	}))
+
+	funnel, err := appRepo.GetConversionFunnel(ctx, j.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), funnel.AppliedCount)
+	assert.Equal(t, int64(2), funnel.ShortlistedCount)
+
+	stage, err := appRepo.GetCurrentStage(ctx, inTest.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Technical Test", stage.StageName)
+	assert.Equal(t, application.StatusShortlisted, stage.Status)
+}
@@ -0,0 +1,109 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/job"
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+func TestSearchJobs_OnlyListsLivePublishedJobs(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	jobRepo := repo.NewJobRepository(db)
+
+	comp := testutil.CreateCompany(t, db)
+	live := testutil.CreateJob(t, db, comp.ID)
+	noExpiry := testutil.CreateJob(t, db, comp.ID, func(j *job.Job) { j.ExpiredAt = nil })
+	testutil.CreateJob(t, db, comp.ID, testutil.WithJobStatus(job.StatusDraft))
+	testutil.CreateJob(t, db, comp.ID, testutil.WithJobStatus(job.StatusPendingReview))
+	testutil.CreateJob(t, db, comp.ID, testutil.WithJobStatus(job.StatusClosed))
+	testutil.CreateJob(t, db, comp.ID, testutil.WithJobStatus(job.StatusSuspended))
+	testutil.CreateJob(t, db, comp.ID, testutil.WithJobExpiredAt(time.Now().Add(-time.Hour)))
+	testutil.CreateJob(t, db, comp.ID, testutil.WithJobDeleted())
+
+	jobs, total, err := jobRepo.SearchJobs(ctx, job.JobSearchFilter{CompanyIDs: []int64{comp.ID}}, 1, 20)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{live.ID, noExpiry.ID}, jobIDs(jobs))
+	assert.Equal(t, int64(2), total, "the total follows the same scoping")
+}
+
+func TestSearchJobs_NewestPublishedFirst(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	jobRepo := repo.NewJobRepository(db)
+
+	comp := testutil.CreateCompany(t, db)
+	now := time.Now()
+	older := testutil.CreateJob(t, db, comp.ID, testutil.WithJobPublishedAt(now.Add(-48*time.Hour)))
+	newest := testutil.CreateJob(t, db, comp.ID, testutil.WithJobPublishedAt(now.Add(-time.Hour)))
+	oldest := testutil.CreateJob(t, db, comp.ID, testutil.WithJobPublishedAt(now.Add(-96*time.Hour)))
+
+	jobs, total, err := jobRepo.SearchJobs(ctx, job.JobSearchFilter{CompanyIDs: []int64{comp.ID}}, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Equal(t, []int64{newest.ID, older.ID}, jobIDs(jobs))
+
+	jobs, _, err = jobRepo.SearchJobs(ctx, job.JobSearchFilter{CompanyIDs: []int64{comp.ID}}, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{oldest.ID}, jobIDs(jobs))
+}
+
+func TestRecordView_CountsEachViewerOncePerWindow(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	jobRepo := repo.NewJobRepository(db)
+
+	comp := testutil.CreateCompany(t, db)
+	j := testutil.CreateJob(t, db, comp.ID)
+	ipHash := "viewer-hash"
+
+	ok, err := jobRepo.RecordView(ctx, &job.JobViewEvent{JobID: j.ID, IPHash: &ipHash}, time.Hour)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = jobRepo.RecordView(ctx, &job.JobViewEvent{JobID: j.ID, IPHash: &ipHash}, time.Hour)
+	require.NoError(t, err)
+	assert.False(t, ok, "a repeat view within the window is not counted")
+
+	other := "other-hash"
+	ok, err = jobRepo.RecordView(ctx, &job.JobViewEvent{JobID: j.ID, IPHash: &other}, time.Hour)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	var views int64
+	require.NoError(t, db.Model(&job.Job{}).Select("views_count").Where("id = ?", j.ID).Scan(&views).Error)
+	assert.Equal(t, int64(2), views)
+}
+
+func TestDeleteViewEventsBefore_KeepsRecentEventsAndCounts(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	jobRepo := repo.NewJobRepository(db)
+
+	comp := testutil.CreateCompany(t, db)
+	j := testutil.CreateJob(t, db, comp.ID)
+	now := time.Now()
+	for _, viewedAt := range []time.Time{now.AddDate(-2, 0, 0), now.AddDate(-1, 0, -1), now.Add(-time.Hour)} {
+		_, err := jobRepo.RecordView(ctx, &job.JobViewEvent{JobID: j.ID, ViewedAt: viewedAt}, time.Hour)
+		require.NoError(t, err)
+	}
+
+	deleted, err := jobRepo.DeleteViewEventsBefore(ctx, now.AddDate(-1, 0, 0))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	var remaining int64
+	require.NoError(t, db.Model(&job.JobViewEvent{}).Where("job_id = ?", j.ID).Count(&remaining).Error)
+	assert.Equal(t, int64(1), remaining)
+
+	var views int64
+	require.NoError(t, db.Model(&job.Job{}).Select("views_count").Where("id = ?", j.ID).Scan(&views).Error)
+	assert.Equal(t, int64(3), views, "deleting history keeps the view counter")
+}
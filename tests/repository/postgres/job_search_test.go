@@ -0,0 +1,72 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/job"
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+func jobIDs(jobs []job.Job) []int64 {
+	ids := make([]int64, 0, len(jobs))
+	for _, j := range jobs {
+		ids = append(ids, j.ID)
+	}
+	return ids
+}
+
+func TestSearchJobs_MatchesRegardlessOfWordForm(t *testing.T) {
+	db := testutil.PerTest(t)
+	companyID := testutil.CreateCompany(t, db).ID
+	match := testutil.CreateJob(t, db, companyID, testutil.WithJobTitle("Backend Engineer"), testutil.WithJobDescription("You will be developing services in Golang"), testutil.WithJobCity("Jakarta")).ID
+	testutil.CreateJob(t, db, companyID, testutil.WithJobTitle("Graphic Designer"), testutil.WithJobDescription("Design marketing assets"), testutil.WithJobCity("Bandung"))
+
+	r := repo.NewJobRepository(db)
+	jobs, total, err := r.SearchJobs(context.Background(), job.JobSearchFilter{
+		Keyword:    "engineers develop golang",
+		CompanyIDs: []int64{companyID},
+	}, 1, 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, []int64{match}, jobIDs(jobs))
+}
+
+func TestSearchJobs_RanksTitleMatchesFirst(t *testing.T) {
+	db := testutil.PerTest(t)
+	companyID := testutil.CreateCompany(t, db).ID
+	descriptionOnly := testutil.CreateJob(t, db, companyID, testutil.WithJobTitle("Data Analyst"), testutil.WithJobDescription("Work closely with our golang team"), testutil.WithJobCity("Jakarta")).ID
+	titleMatch := testutil.CreateJob(t, db, companyID, testutil.WithJobTitle("Golang Developer"), testutil.WithJobDescription("Build and maintain APIs"), testutil.WithJobCity("Jakarta")).ID
+
+	r := repo.NewJobRepository(db)
+	jobs, _, err := r.SearchJobs(context.Background(), job.JobSearchFilter{
+		Keyword:    "golang",
+		CompanyIDs: []int64{companyID},
+	}, 1, 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, []int64{titleMatch, descriptionOnly}, jobIDs(jobs))
+}
+
+func TestGetSearchFacets_CountsMatchingJobsByCity(t *testing.T) {
+	db := testutil.PerTest(t)
+	companyID := testutil.CreateCompany(t, db).ID
+	testutil.CreateJob(t, db, companyID, testutil.WithJobTitle("Backend Engineer"), testutil.WithJobDescription("Golang services"), testutil.WithJobCity("Jakarta"))
+	testutil.CreateJob(t, db, companyID, testutil.WithJobTitle("Platform Engineer"), testutil.WithJobDescription("Kubernetes and Golang"), testutil.WithJobCity("Jakarta"))
+	testutil.CreateJob(t, db, companyID, testutil.WithJobTitle("Mobile Engineer"), testutil.WithJobDescription("Flutter apps"), testutil.WithJobCity("Surabaya"))
+	testutil.CreateJob(t, db, companyID, testutil.WithJobTitle("Accountant"), testutil.WithJobDescription("Golang is not required"), testutil.WithJobCity("Bandung"))
+
+	r := repo.NewJobRepository(db)
+	facets, err := r.GetSearchFacets(context.Background(), job.JobSearchFilter{
+		Keyword:    "engineer",
+		CompanyIDs: []int64{companyID},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []job.FacetItem{{Value: "Jakarta", Count: 2}, {Value: "Surabaya", Count: 1}}, facets.Cities)
+}
@@ -0,0 +1,42 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/job"
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+func TestSitemapEntries_OnlyPublishedUnexpiredJobs(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	r := repo.NewJobRepository(db)
+	now := time.Now()
+
+	before, err := r.CountSitemapEntries(ctx, now)
+	require.NoError(t, err)
+
+	companyID := testutil.CreateCompany(t, db).ID
+	listed := testutil.CreateJob(t, db, companyID, testutil.WithJobExpiredAt(now.Add(24*time.Hour))).Slug
+	testutil.CreateJob(t, db, companyID, testutil.WithJobExpiredAt(now.Add(-time.Hour)))
+	testutil.CreateJob(t, db, companyID, testutil.WithJobStatus(job.StatusDraft))
+
+	count, err := r.CountSitemapEntries(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, before+1, count)
+
+	var slugs []string
+	require.NoError(t, r.StreamSitemapEntries(ctx, now, 0, int(count), func(entry job.SitemapEntry) error {
+		assert.False(t, entry.UpdatedAt.IsZero())
+		slugs = append(slugs, entry.Slug)
+		return nil
+	}))
+	assert.Len(t, slugs, int(count))
+	assert.Equal(t, listed, slugs[len(slugs)-1], "entries are in ID order")
+}
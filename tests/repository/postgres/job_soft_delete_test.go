@@ -0,0 +1,90 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/job"
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+func TestJobSoftDelete_WithdrawsOpenApplicationsAndRestoresThem(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+
+	companyID := testutil.CreateCompany(t, db).ID
+	j := testutil.CreateJob(t, db, companyID)
+	deletedJob := j.ID
+	testutil.CreateJob(t, db, companyID)
+
+	applicant := testutil.CreateUser(t, db).ID
+	interviewing := testutil.CreateUser(t, db).ID
+	openApp := testutil.CreateApplication(t, db, j, applicant).ID
+	interviewApp := testutil.CreateApplication(t, db, j, interviewing, testutil.WithApplicationStatus(application.StatusInterview)).ID
+	rejectedApp := testutil.CreateApplication(t, db, j, testutil.CreateUser(t, db).ID, testutil.WithApplicationStatus(application.StatusRejected)).ID
+	require.NoError(t, db.Exec("INSERT INTO job_benefits (job_id, benefit_name) VALUES (?, 'Health insurance')", deletedJob).Error)
+
+	jobRepo := repo.NewJobRepository(db)
+	appRepo := repo.NewApplicationRepository(db)
+
+	withdrawn, err := jobRepo.SoftDelete(ctx, deletedJob)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []job.WithdrawnApplication{
+		{ApplicationID: openApp, UserID: applicant, PreviousStatus: "applied"},
+		{ApplicationID: interviewApp, UserID: interviewing, PreviousStatus: "interview"},
+	}, withdrawn)
+
+	statusOf := func(appID int64) string {
+		var status string
+		require.NoError(t, db.Raw("SELECT status FROM job_applications WHERE id = ?", appID).Scan(&status).Error)
+		return status
+	}
+	assert.Equal(t, application.StatusJobWithdrawn, statusOf(openApp))
+	assert.Equal(t, application.StatusJobWithdrawn, statusOf(interviewApp))
+	assert.Equal(t, "rejected", statusOf(rejectedApp), "closed applications keep their outcome")
+
+	var stages int64
+	require.NoError(t, db.Raw(
+		"SELECT COUNT(*) FROM job_application_stages WHERE application_id IN (?, ?) AND stage_name = 'job_withdrawn'", openApp, interviewApp,
+	).Scan(&stages).Error)
+	assert.Equal(t, int64(2), stages)
+
+	var benefits int64
+	require.NoError(t, db.Raw("SELECT COUNT(*) FROM job_benefits WHERE job_id = ?", deletedJob).Scan(&benefits).Error)
+	assert.Equal(t, int64(1), benefits, "benefits stay so a restore brings the job back unchanged")
+
+	// The deleted job drops out of lookups and company statistics
+	_, err = jobRepo.FindByID(ctx, deletedJob)
+	assert.Error(t, err)
+	companyStats, err := jobRepo.GetCompanyJobStats(ctx, companyID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), companyStats.TotalJobs)
+	assert.Equal(t, int64(1), companyStats.ActiveJobs)
+
+	jobStats, err := appRepo.GetJobApplicationStats(ctx, deletedJob)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), jobStats.JobWithdrawnCount)
+
+	deleted, err := jobRepo.FindDeletedByID(ctx, deletedJob)
+	require.NoError(t, err)
+	require.NotNil(t, deleted)
+	assert.True(t, deleted.CanRestore(time.Now()))
+
+	require.NoError(t, jobRepo.Restore(ctx, deletedJob))
+	assert.Equal(t, "applied", statusOf(openApp))
+	assert.Equal(t, "interview", statusOf(interviewApp))
+	assert.Equal(t, "rejected", statusOf(rejectedApp))
+
+	restored, err := jobRepo.FindByID(ctx, deletedJob)
+	require.NoError(t, err)
+	assert.Equal(t, deletedJob, restored.ID)
+	missing, err := jobRepo.FindDeletedByID(ctx, deletedJob)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
@@ -0,0 +1,115 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/apperror"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/internal/domain/job"
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+func requireVersionConflict(t *testing.T, err, conflict error, current string) {
+	t.Helper()
+	require.ErrorIs(t, err, conflict)
+	appErr, ok := apperror.As(err)
+	require.True(t, ok)
+	assert.Equal(t, current, appErr.Details["current_version"])
+}
+
+func TestCompanyUpdate_DetectsConcurrentEdit(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	companyID := testutil.CreateCompany(t, db).ID
+	r := repo.NewCompanyRepository(db)
+
+	// Two editors load the same version
+	first, err := r.FindByID(ctx, companyID)
+	require.NoError(t, err)
+	second, err := r.FindByID(ctx, companyID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), first.Version)
+
+	first.FullAddress = "Jl. Thamrin 2"
+	require.NoError(t, r.Update(ctx, first))
+	assert.Equal(t, int64(2), first.Version)
+
+	second.FullAddress = "Jl. Gatot Subroto 3"
+	requireVersionConflict(t, r.Update(ctx, second), company.ErrCompanyVersionConflict, "2")
+	assert.Equal(t, int64(1), second.Version, "a rejected update keeps the loaded version")
+
+	stored, err := r.FindByID(ctx, companyID)
+	require.NoError(t, err)
+	assert.Equal(t, "Jl. Thamrin 2", stored.FullAddress)
+	assert.Equal(t, int64(2), stored.Version)
+
+	// After reloading, the second editor's change goes through
+	stored.FullAddress = "Jl. Gatot Subroto 3"
+	require.NoError(t, r.Update(ctx, stored))
+	assert.Equal(t, int64(3), stored.Version)
+}
+
+func TestCompanyProfileAndEmployerUserUpdate_DetectConcurrentEdits(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	companyID := testutil.CreateCompany(t, db).ID
+	userID := testutil.CreateUser(t, db).ID
+	require.NoError(t, db.Exec("INSERT INTO company_profiles (company_id, mission) VALUES (?, 'Old')", companyID).Error)
+	testutil.CreateEmployerUser(t, db, userID, companyID, testutil.WithRole(employer.RoleAdmin))
+	r := repo.NewCompanyRepository(db)
+
+	firstProfile, err := r.FindProfileByCompanyID(ctx, companyID)
+	require.NoError(t, err)
+	secondProfile, err := r.FindProfileByCompanyID(ctx, companyID)
+	require.NoError(t, err)
+
+	firstProfile.Mission = &[]string{"First"}[0]
+	require.NoError(t, r.UpdateProfile(ctx, firstProfile))
+	secondProfile.Mission = &[]string{"Second"}[0]
+	requireVersionConflict(t, r.UpdateProfile(ctx, secondProfile), company.ErrProfileVersionConflict, "2")
+
+	firstMember, err := r.FindEmployerUserByUserAndCompany(ctx, userID, companyID)
+	require.NoError(t, err)
+	secondMember, err := r.FindEmployerUserByUserAndCompany(ctx, userID, companyID)
+	require.NoError(t, err)
+
+	firstMember.PositionTitle = &[]string{"HR Lead"}[0]
+	require.NoError(t, r.UpdateEmployerUser(ctx, firstMember))
+	secondMember.PositionTitle = &[]string{"Recruiter"}[0]
+	requireVersionConflict(t, r.UpdateEmployerUserTx(ctx, db, secondMember), company.ErrEmployerUserVersionConflict, "2")
+
+	stored, err := r.FindEmployerUserByUserAndCompany(ctx, userID, companyID)
+	require.NoError(t, err)
+	assert.Equal(t, "HR Lead", *stored.PositionTitle)
+}
+
+func TestJobUpdate_DetectsStatusChangeSinceLoad(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	jobID := testutil.CreateJob(t, db, testutil.CreateCompany(t, db).ID, testutil.WithJobDescription("Go services")).ID
+	r := repo.NewJobRepository(db)
+
+	// A recruiter loads the job, then an admin suspends it
+	loaded, err := r.FindByID(ctx, jobID)
+	require.NoError(t, err)
+	require.NoError(t, r.SuspendJob(ctx, jobID))
+
+	// Saving the stale copy would publish the job again
+	loaded.Description = "Go and PostgreSQL services"
+	requireVersionConflict(t, r.Update(ctx, loaded), job.ErrJobVersionConflict, "2")
+
+	stored, err := r.FindByID(ctx, jobID)
+	require.NoError(t, err)
+	assert.Equal(t, "suspended", stored.Status)
+	assert.Equal(t, "Go services", stored.Description)
+
+	stored.Description = "Go and PostgreSQL services"
+	require.NoError(t, r.Update(ctx, stored))
+	assert.Equal(t, int64(3), stored.Version)
+}
@@ -0,0 +1,139 @@
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+func countRows(t *testing.T, db *gorm.DB, query string, args ...interface{}) int64 {
+	t.Helper()
+
+	var count int64
+	require.NoError(t, db.Raw(query, args...).Scan(&count).Error)
+	return count
+}
+
+func TestMergeSkill_MovesReferencesWithoutOrphans(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	suffix := time.Now().UnixNano()
+	target := testutil.CreateSkill(t, db, fmt.Sprintf("Go %d", suffix)).ID
+	source := testutil.CreateSkill(t, db, fmt.Sprintf("Golang %d", suffix), fmt.Sprintf("go-lang %d", suffix)).ID
+	child := testutil.CreateSkill(t, db, fmt.Sprintf("Gin %d", suffix)).ID
+	require.NoError(t, db.Exec("UPDATE skills_master SET parent_id = ? WHERE id = ?", source, child).Error)
+
+	companyID := testutil.CreateCompany(t, db).ID
+	bothJob := testutil.CreateJob(t, db, companyID).ID
+	sourceJob := testutil.CreateJob(t, db, companyID).ID
+	for _, js := range [][2]int64{{bothJob, target}, {bothJob, source}, {sourceJob, source}} {
+		require.NoError(t, db.Exec("INSERT INTO job_skills (job_id, skill_id) VALUES (?, ?)", js[0], js[1]).Error)
+	}
+	require.NoError(t, db.Exec("INSERT INTO job_requirements (job_id, requirement_text, skill_id) VALUES (?, 'Golang', ?)", sourceJob, source).Error)
+
+	bothUser := testutil.CreateUser(t, db).ID
+	sourceUser := testutil.CreateUser(t, db).ID
+	for _, us := range []struct {
+		userID int64
+		name   string
+	}{
+		{bothUser, fmt.Sprintf("Go %d", suffix)},
+		{bothUser, fmt.Sprintf("golang %d", suffix)},
+		{sourceUser, fmt.Sprintf("GO-LANG %d", suffix)},
+		{sourceUser, fmt.Sprintf(" Golang  %d", suffix)},
+	} {
+		require.NoError(t, db.Exec("INSERT INTO user_skills (user_id, skill_name) VALUES (?, ?)", us.userID, us.name).Error)
+	}
+
+	jobsWithSkill := countRows(t, db, "SELECT count(DISTINCT job_id) FROM job_skills WHERE skill_id IN (?, ?)", source, target)
+	usersWithSkill := countRows(t, db, "SELECT count(DISTINCT user_id) FROM user_skills WHERE user_id IN (?, ?)", bothUser, sourceUser)
+
+	r := repo.NewSkillsMasterRepository(db)
+	result, err := r.MergeSkill(ctx, source, target)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.JobSkillsMerged)
+	assert.Equal(t, int64(1), result.JobSkillsMoved)
+	assert.Equal(t, int64(1), result.RequirementsMoved)
+	assert.Equal(t, int64(2), result.UserSkillsMerged)
+	assert.Equal(t, int64(1), result.UserSkillsRenamed)
+
+	// No reference to the source is left behind
+	assert.Zero(t, countRows(t, db, "SELECT count(*) FROM skills_master WHERE id = ?", source))
+	assert.Zero(t, countRows(t, db, "SELECT count(*) FROM job_skills WHERE skill_id = ?", source))
+	assert.Zero(t, countRows(t, db, "SELECT count(*) FROM job_requirements WHERE skill_id = ?", source))
+	assert.Zero(t, countRows(t, db, "SELECT count(*) FROM skills_master WHERE parent_id = ?", source))
+	assert.Zero(t, countRows(t, db, "SELECT count(*) FROM user_skills WHERE user_id IN (?, ?) AND skill_name <> ?", bothUser, sourceUser, fmt.Sprintf("Go %d", suffix)))
+
+	// Every job and user that had either skill still has it, once
+	assert.Equal(t, jobsWithSkill, countRows(t, db, "SELECT count(*) FROM job_skills WHERE skill_id = ?", target))
+	assert.Equal(t, usersWithSkill, countRows(t, db, "SELECT count(*) FROM user_skills WHERE user_id IN (?, ?)", bothUser, sourceUser))
+	assert.Equal(t, int64(1), countRows(t, db, "SELECT count(*) FROM job_requirements WHERE job_id = ? AND skill_id = ?", sourceJob, target))
+	assert.Equal(t, int64(1), countRows(t, db, "SELECT count(*) FROM skills_master WHERE id = ? AND parent_id = ?", child, target))
+
+	// The source's names now lead to the target
+	old, err := r.ResolveSkill(ctx, source)
+	require.NoError(t, err)
+	require.NotNil(t, old)
+	assert.Equal(t, target, old.ID)
+
+	for _, name := range []string{fmt.Sprintf("GOLANG %d", suffix), fmt.Sprintf("go-lang %d", suffix)} {
+		skill, err := r.FindCanonicalByName(ctx, name)
+		require.NoError(t, err)
+		require.NotNil(t, skill, name)
+		assert.Equal(t, target, skill.ID, name)
+	}
+
+	merged, err := r.FindByID(ctx, target)
+	require.NoError(t, err)
+	assert.Contains(t, merged.Aliases, fmt.Sprintf("golang %d", suffix))
+}
+
+func TestSuggestSkills_MatchesPrefixesAliasesAndTypos(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	suffix := time.Now().UnixNano()
+	kubernetes := testutil.CreateSkill(t, db, fmt.Sprintf("Kubernetesx%d", suffix)).ID
+	require.NoError(t, db.Exec(
+		"INSERT INTO skill_aliases (skill_id, alias, normalized_alias) VALUES (?, ?, lower(?))",
+		kubernetes, fmt.Sprintf("K8sx%d", suffix), fmt.Sprintf("K8sx%d", suffix),
+	).Error)
+	inactive := testutil.CreateSkill(t, db, fmt.Sprintf("Kubernetesx%d Legacy", suffix)).ID
+	require.NoError(t, db.Exec("UPDATE skills_master SET is_active = false WHERE id = ?", inactive).Error)
+
+	r := repo.NewSkillsMasterRepository(db)
+	for _, query := range []string{
+		"kubern",
+		fmt.Sprintf("k8sx%d", suffix),
+		fmt.Sprintf("kubernetsx%d", suffix),
+	} {
+		skills, err := r.SuggestSkills(ctx, query, 50)
+		require.NoError(t, err)
+		ids := make([]int64, len(skills))
+		for i, skill := range skills {
+			ids[i] = skill.ID
+		}
+		assert.Contains(t, ids, kubernetes, query)
+		assert.NotContains(t, ids, inactive, query)
+	}
+
+	skills, err := r.SuggestSkills(ctx, "%", 50)
+	require.NoError(t, err)
+	for _, skill := range skills {
+		assert.NotEqual(t, kubernetes, skill.ID, "wildcards in the query are matched literally")
+	}
+}
+
+func TestFindCanonicalByName_UnknownName(t *testing.T) {
+	db := testutil.PerTest(t)
+	skill, err := repo.NewSkillsMasterRepository(db).FindCanonicalByName(context.Background(), fmt.Sprintf("no such skill %d", time.Now().UnixNano()))
+	require.NoError(t, err)
+	assert.Nil(t, skill)
+}
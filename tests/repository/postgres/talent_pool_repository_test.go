@@ -0,0 +1,85 @@
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/talentpool"
+	repo "keerja-backend/internal/repository/postgres"
+	"keerja-backend/internal/repository/postgres/testutil"
+)
+
+func memberUserIDs(members []talentpool.TalentPoolMember) []int64 {
+	ids := make([]int64, len(members))
+	for i, m := range members {
+		ids[i] = m.UserID
+	}
+	return ids
+}
+
+func TestTalentPoolRepository_MembersAndFilters(t *testing.T) {
+	db := testutil.PerTest(t)
+	ctx := context.Background()
+	r := repo.NewTalentPoolRepository(db)
+
+	companyID := testutil.CreateCompany(t, db).ID
+	pool := &talentpool.TalentPool{CompanyID: companyID, Name: fmt.Sprintf("Backend %d", time.Now().UnixNano())}
+	require.NoError(t, r.CreatePool(ctx, pool))
+	assert.ErrorIs(t, r.CreatePool(ctx, &talentpool.TalentPool{CompanyID: companyID, Name: pool.Name}), talentpool.ErrPoolNameExists)
+
+	gopher := testutil.CreateUser(t, db).ID
+	rustacean := testutil.CreateUser(t, db).ID
+	optedOut := testutil.CreateUser(t, db).ID
+	require.NoError(t, db.Exec("INSERT INTO user_skills (user_id, skill_name) VALUES (?, 'Go'), (?, ' postgresql '), (?, 'Rust')", gopher, gopher, rustacean).Error)
+	require.NoError(t, db.Exec("INSERT INTO user_preferences (user_id, allow_talent_pools) VALUES (?, false)", optedOut).Error)
+	private := testutil.CreateUser(t, db).ID
+	require.NoError(t, db.Exec("INSERT INTO user_preferences (user_id, profile_visibility) VALUES (?, 'private')", private).Error)
+
+	for _, m := range []talentpool.TalentPoolMember{
+		{PoolID: pool.ID, UserID: gopher, Tags: pq.StringArray{"senior", "remote"}, Notes: "Filled by internal hire"},
+		{PoolID: pool.ID, UserID: rustacean, Tags: pq.StringArray{"senior"}},
+		{PoolID: pool.ID, UserID: optedOut, Tags: pq.StringArray{"senior"}},
+		{PoolID: pool.ID, UserID: private, Tags: pq.StringArray{"senior"}},
+	} {
+		added, err := r.AddMember(ctx, &m)
+		require.NoError(t, err)
+		assert.True(t, added)
+	}
+
+	again := talentpool.TalentPoolMember{PoolID: pool.ID, UserID: gopher, Tags: pq.StringArray{"junior"}}
+	added, err := r.AddMember(ctx, &again)
+	require.NoError(t, err)
+	assert.False(t, added, "a candidate is in a pool once")
+	assert.Equal(t, []string{"senior", "remote"}, []string(again.Tags), "the existing member is kept")
+
+	list := func(filter talentpool.MemberFilter) []int64 {
+		filter.CompanyID, filter.PoolID = companyID, pool.ID
+		members, total, err := r.ListMembers(ctx, filter, 1, 20)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(members)), total)
+		return memberUserIDs(members)
+	}
+
+	assert.ElementsMatch(t, []int64{gopher, rustacean}, list(talentpool.MemberFilter{}), "opted out and private candidates are hidden")
+	assert.Equal(t, []int64{gopher}, list(talentpool.MemberFilter{Tags: []string{"senior", "remote"}}))
+	assert.Equal(t, []int64{gopher}, list(talentpool.MemberFilter{Skills: []string{"go", "postgresql"}}))
+	assert.Empty(t, list(talentpool.MemberFilter{Skills: []string{"go", "rust"}}))
+	assert.Equal(t, []int64{gopher}, list(talentpool.MemberFilter{Query: "internal"}))
+
+	pools, err := r.ListPoolsByCompany(ctx, companyID)
+	require.NoError(t, err)
+	require.Len(t, pools, 1)
+	assert.Equal(t, int64(2), pools[0].MemberCount)
+
+	j := testutil.CreateJob(t, db, companyID)
+	testutil.CreateApplication(t, db, j, rustacean)
+	candidates, err := r.ListMatchCandidates(ctx, companyID, 0, j.ID, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{gopher}, memberUserIDs(candidates), "candidates who already applied are not matched")
+}
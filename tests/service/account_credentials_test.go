@@ -0,0 +1,140 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+const credentialPassword = "Secret123!"
+
+func newCredentialUserRepo(t *testing.T) *fakes.UserRepository {
+	t.Helper()
+
+	hash, err := utils.HashPassword(credentialPassword)
+	require.NoError(t, err)
+	return &fakes.UserRepository{Users: map[int64]*user.User{
+		1: {ID: 1, Email: "jane@example.com", FullName: "Jane", PasswordHash: hash},
+		2: {ID: 2, Email: "taken@example.com", FullName: "Taken", PasswordHash: hash},
+		3: {ID: 3, Email: "google@example.com", FullName: "Google User"},
+	}}
+}
+
+func TestChangePassword_ValidatesCurrentAndNewPassword(t *testing.T) {
+	userRepo := newCredentialUserRepo(t)
+	svc := service.NewAuthService(userRepo, nil, nil, service.AuthServiceConfig{}, nil)
+	ctx := context.Background()
+
+	err := svc.ChangePassword(ctx, 1, "Wrong123!", "NewSecret123!")
+	assert.ErrorIs(t, err, service.ErrInvalidCurrentPassword)
+
+	err = svc.ChangePassword(ctx, 1, credentialPassword, "weakpass")
+	assert.ErrorIs(t, err, service.ErrWeakPassword)
+
+	err = svc.ChangePassword(ctx, 1, credentialPassword, credentialPassword)
+	assert.ErrorIs(t, err, service.ErrSamePassword)
+
+	err = svc.ChangePassword(ctx, 3, "", "NewSecret123!")
+	assert.ErrorIs(t, err, service.ErrPasswordNotSet)
+
+	require.NoError(t, svc.ChangePassword(ctx, 1, credentialPassword, "NewSecret123!"))
+	assert.True(t, utils.VerifyPassword("NewSecret123!", userRepo.Users[1].PasswordHash))
+}
+
+func TestRevokeOtherSessions_KeepsCurrentSession(t *testing.T) {
+	repo := fakes.NewRefreshTokenRepository()
+	usr := &user.User{ID: 7, Email: "seeker@example.com", UserType: "jobseeker", Status: "active"}
+	svc := service.NewRefreshTokenService(repo, &fakes.UserRepository{User: usr}, testJWTSecret, time.Hour)
+	ctx := context.Background()
+
+	currentAccess, currentRefresh, err := svc.CreateSession(ctx, usr, service.DeviceInfo{DeviceID: "laptop"}, false)
+	require.NoError(t, err)
+	_, otherRefresh, err := svc.CreateSession(ctx, usr, service.DeviceInfo{DeviceID: "phone"}, false)
+	require.NoError(t, err)
+
+	claims, err := utils.ValidateToken(currentAccess, testJWTSecret)
+	require.NoError(t, err)
+	require.NoError(t, svc.RevokeOtherSessions(ctx, usr.ID, claims.SessionID, "password_changed"))
+
+	_, _, err = svc.RefreshAccessToken(ctx, currentRefresh, service.DeviceInfo{})
+	assert.NoError(t, err)
+	_, _, err = svc.RefreshAccessToken(ctx, otherRefresh, service.DeviceInfo{})
+	assert.Error(t, err)
+}
+
+func newEmailChangeFixture(t *testing.T) (*service.RegistrationService, *fakes.UserRepository, *fakes.OTPRepository, *fakes.EmailService) {
+	t.Helper()
+
+	userRepo := newCredentialUserRepo(t)
+	otpRepo := &fakes.OTPRepository{}
+	emailSvc := &fakes.EmailService{}
+	svc := service.NewRegistrationService(userRepo, otpRepo, emailSvc, "secret", time.Hour, nil, nil, nil)
+	return svc, userRepo, otpRepo, emailSvc
+}
+
+func TestRequestEmailChange_RejectsInvalidRequests(t *testing.T) {
+	svc, _, _, emailSvc := newEmailChangeFixture(t)
+	ctx := context.Background()
+
+	err := svc.RequestEmailChange(ctx, 1, "new@example.com", "Wrong123!", "")
+	assert.ErrorIs(t, err, service.ErrInvalidCurrentPassword)
+
+	err = svc.RequestEmailChange(ctx, 1, "Taken@Example.com", credentialPassword, "")
+	assert.ErrorIs(t, err, service.ErrEmailAlreadyExists)
+
+	err = svc.RequestEmailChange(ctx, 1, "jane@example.com", credentialPassword, "")
+	assert.ErrorIs(t, err, service.ErrSameEmail)
+
+	err = svc.RequestEmailChange(ctx, 3, "new@example.com", "", "")
+	assert.ErrorIs(t, err, service.ErrPasswordNotSet)
+
+	assert.Empty(t, emailSvc.LastCode())
+}
+
+func TestConfirmEmailChange_ChangesEmailAndKeepsHistory(t *testing.T) {
+	svc, userRepo, _, emailSvc := newEmailChangeFixture(t)
+	ctx := context.Background()
+
+	require.NoError(t, svc.RequestEmailChange(ctx, 1, "new@example.com", credentialPassword, "198.51.100.1"))
+	assert.Equal(t, "new@example.com", emailSvc.Last().To)
+	assert.Equal(t, "jane@example.com", userRepo.Users[1].Email)
+
+	// The code only confirms the address it was sent to
+	_, err := svc.ConfirmEmailChange(ctx, 1, "other@example.com", emailSvc.LastCode())
+	assert.ErrorIs(t, err, service.ErrInvalidOTPCode)
+
+	usr, err := svc.ConfirmEmailChange(ctx, 1, "new@example.com", emailSvc.LastCode())
+	require.NoError(t, err)
+	assert.Equal(t, "new@example.com", usr.Email)
+	assert.Equal(t, []user.UserEmailChange{{UserID: 1, OldEmail: "jane@example.com", NewEmail: "new@example.com"}}, userRepo.EmailChanges)
+
+	_, err = svc.ConfirmEmailChange(ctx, 1, "new@example.com", emailSvc.LastCode())
+	assert.ErrorIs(t, err, service.ErrOTPCodeAlreadyUsed)
+}
+
+func TestConfirmEmailChange_RejectsExpiredCodeAndTakenEmail(t *testing.T) {
+	svc, userRepo, otpRepo, emailSvc := newEmailChangeFixture(t)
+	ctx := context.Background()
+
+	require.NoError(t, svc.RequestEmailChange(ctx, 1, "new@example.com", credentialPassword, ""))
+	otpRepo.Age(service.EmailChangeOTPExpiry + time.Second)
+	_, err := svc.ConfirmEmailChange(ctx, 1, "new@example.com", emailSvc.LastCode())
+	assert.ErrorIs(t, err, service.ErrOTPCodeExpired)
+
+	// Another account registers the address while the code is pending
+	require.NoError(t, svc.RequestEmailChange(ctx, 1, "race@example.com", credentialPassword, ""))
+	userRepo.Users[4] = &user.User{ID: 4, Email: "race@example.com"}
+	_, err = svc.ConfirmEmailChange(ctx, 1, "race@example.com", emailSvc.LastCode())
+	assert.ErrorIs(t, err, service.ErrEmailAlreadyExists)
+
+	assert.Empty(t, userRepo.EmailChanges)
+	assert.Equal(t, "jane@example.com", userRepo.Users[1].Email)
+}
@@ -0,0 +1,363 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/audit"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// privacyRepo keeps deletion requests and exports in memory. AnonymizeUser marks the user
+// deleted and returns the files configured for them.
+type privacyRepo struct {
+	users      map[int64]*user.User
+	files      map[int64][]string
+	requests   []*user.AccountDeletionRequest
+	exports    []*user.DataExport
+	anonymized map[int64]string
+}
+
+func newPrivacyRepo(users ...*user.User) *privacyRepo {
+	repo := &privacyRepo{
+		users:      make(map[int64]*user.User),
+		files:      make(map[int64][]string),
+		anonymized: make(map[int64]string),
+	}
+	for _, u := range users {
+		repo.users[u.ID] = u
+	}
+	return repo
+}
+
+func (r *privacyRepo) CreateDeletionRequest(ctx context.Context, req *user.AccountDeletionRequest) error {
+	req.ID = int64(len(r.requests) + 1)
+	copied := *req
+	r.requests = append(r.requests, &copied)
+	return nil
+}
+
+func (r *privacyRepo) FindPendingDeletionRequest(ctx context.Context, userID int64) (*user.AccountDeletionRequest, error) {
+	for _, req := range r.requests {
+		if req.UserID == userID && req.IsPending() {
+			copied := *req
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *privacyRepo) UpdateDeletionRequest(ctx context.Context, req *user.AccountDeletionRequest) error {
+	for i, existing := range r.requests {
+		if existing.ID == req.ID {
+			copied := *req
+			r.requests[i] = &copied
+		}
+	}
+	return nil
+}
+
+func (r *privacyRepo) FindDueDeletionRequests(ctx context.Context, now time.Time, limit int) ([]user.AccountDeletionRequest, error) {
+	var due []user.AccountDeletionRequest
+	for _, req := range r.requests {
+		if req.IsDue(now) {
+			due = append(due, *req)
+		}
+	}
+	return due, nil
+}
+
+func (r *privacyRepo) AnonymizeUser(ctx context.Context, userID int64, placeholderEmail string) ([]string, error) {
+	u := r.users[userID]
+	u.Email = placeholderEmail
+	u.FullName = ""
+	u.Status = user.StatusDeleted
+	r.anonymized[userID] = placeholderEmail
+	return r.files[userID], nil
+}
+
+func (r *privacyRepo) CreateDataExport(ctx context.Context, export *user.DataExport) error {
+	export.ID = int64(len(r.exports) + 1)
+	copied := *export
+	r.exports = append(r.exports, &copied)
+	return nil
+}
+
+func (r *privacyRepo) FindOpenDataExport(ctx context.Context, userID int64, now time.Time) (*user.DataExport, error) {
+	for _, export := range r.exports {
+		if export.UserID == userID && (export.IsPending() || export.IsDownloadable(now)) {
+			copied := *export
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *privacyRepo) FindDataExportByTokenHash(ctx context.Context, tokenHash string) (*user.DataExport, error) {
+	for _, export := range r.exports {
+		if export.TokenHash != nil && *export.TokenHash == tokenHash {
+			copied := *export
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *privacyRepo) FindPendingDataExports(ctx context.Context, limit int) ([]user.DataExport, error) {
+	var pending []user.DataExport
+	for _, export := range r.exports {
+		if export.IsPending() {
+			pending = append(pending, *export)
+		}
+	}
+	return pending, nil
+}
+
+func (r *privacyRepo) UpdateDataExport(ctx context.Context, export *user.DataExport) error {
+	for i, existing := range r.exports {
+		if existing.ID == export.ID {
+			copied := *export
+			r.exports[i] = &copied
+		}
+	}
+	return nil
+}
+
+func (r *privacyRepo) ExpireDataExports(ctx context.Context, now time.Time) (int64, error) {
+	var expired int64
+	for _, export := range r.exports {
+		if export.Status == user.DataExportReady && !now.Before(*export.ExpiresAt) {
+			export.Status = user.DataExportExpired
+			export.Data = nil
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+type privacyUserRepo struct {
+	user.UserRepository
+	repo *privacyRepo
+}
+
+func (r *privacyUserRepo) FindByID(ctx context.Context, id int64) (*user.User, error) {
+	return r.repo.users[id], nil
+}
+
+func (r *privacyUserRepo) GetFullProfile(ctx context.Context, userID int64) (*user.User, error) {
+	return r.repo.users[userID], nil
+}
+
+type privacyAppRepo struct {
+	application.ApplicationRepository
+	apps []application.JobApplication
+	docs []application.ApplicationDocument
+}
+
+func (r *privacyAppRepo) ListByUser(ctx context.Context, userID int64, filter application.ApplicationFilter, page, limit int) ([]application.JobApplication, int64, error) {
+	if page > 1 {
+		return nil, int64(len(r.apps)), nil
+	}
+	return r.apps, int64(len(r.apps)), nil
+}
+
+func (r *privacyAppRepo) ListDocumentsByUser(ctx context.Context, userID int64, docType string) ([]application.ApplicationDocument, error) {
+	return r.docs, nil
+}
+
+type privacyCompanyRepo struct {
+	company.CompanyRepository
+}
+
+func (r *privacyCompanyRepo) GetReviewsByUserID(ctx context.Context, userID int64) ([]company.CompanyReview, error) {
+	return nil, nil
+}
+
+func (r *privacyCompanyRepo) GetFollowedCompanies(ctx context.Context, userID int64, page, limit int) ([]company.Company, int64, error) {
+	if page > 1 {
+		return nil, 1, nil
+	}
+	return []company.Company{{ID: 3, CompanyName: "Acme", Slug: "acme"}}, 1, nil
+}
+
+type privacyFixture struct {
+	repo         *privacyRepo
+	uploads      *fakes.UploadService
+	emails       *fakes.EmailService
+	audits       *fakes.AuditLogRepository
+	auditService audit.AuditService
+	svc          user.AccountPrivacyService
+}
+
+func newPrivacyFixture(t *testing.T, cfg service.AccountPrivacyConfig) *privacyFixture {
+	t.Helper()
+
+	repo := newPrivacyRepo(&user.User{ID: 7, UUID: uuid.New(), Email: "rina@example.com", FullName: "Rina", Status: "active"})
+	f := &privacyFixture{
+		repo:    repo,
+		uploads: &fakes.UploadService{},
+		emails:  &fakes.EmailService{},
+		audits:  &fakes.AuditLogRepository{},
+	}
+	appRepo := &privacyAppRepo{
+		apps: []application.JobApplication{{ID: 11, JobID: 2, UserID: 7, Status: "applied", NotesText: "Hello", AppliedAt: time.Now()}},
+		docs: []application.ApplicationDocument{{ID: 21, ApplicationID: 11, UserID: 7, FileURL: "https://cdn.example.com/cv.pdf"}},
+	}
+	f.auditService = service.NewAuditService(f.audits, 10)
+	f.svc = service.NewAccountPrivacyService(repo, &privacyUserRepo{repo: repo}, appRepo, &privacyCompanyRepo{}, f.uploads, f.emails, f.auditService, cfg)
+	t.Cleanup(f.auditService.Close)
+	return f
+}
+
+func TestRequestDeletion_SchedulesAfterGracePeriodOnce(t *testing.T) {
+	f := newPrivacyFixture(t, service.AccountPrivacyConfig{})
+
+	req, err := f.svc.RequestDeletion(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, user.DeletionRequestPending, req.Status)
+	assert.WithinDuration(t, time.Now().Add(user.DefaultAccountDeletionGracePeriod), req.ScheduledFor, time.Minute)
+
+	_, err = f.svc.RequestDeletion(context.Background(), 7)
+	assert.ErrorIs(t, err, user.ErrDeletionAlreadyRequested)
+
+	// Nothing is due during the grace period
+	deleted, err := f.svc.ExecuteDueDeletions(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+	assert.Equal(t, "rina@example.com", f.repo.users[7].Email)
+}
+
+func TestCancelDeletion_StopsTheDeletion(t *testing.T) {
+	f := newPrivacyFixture(t, service.AccountPrivacyConfig{DeletionGracePeriod: time.Nanosecond})
+
+	_, err := f.svc.RequestDeletion(context.Background(), 7)
+	require.NoError(t, err)
+	require.NoError(t, f.svc.CancelDeletion(context.Background(), 7))
+	assert.ErrorIs(t, f.svc.CancelDeletion(context.Background(), 7), user.ErrNoPendingDeletion)
+
+	deleted, err := f.svc.ExecuteDueDeletions(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+	assert.Empty(t, f.repo.anonymized)
+}
+
+func TestExecuteDueDeletions_AnonymizesAndDeletesFiles(t *testing.T) {
+	f := newPrivacyFixture(t, service.AccountPrivacyConfig{DeletionGracePeriod: time.Nanosecond})
+	f.repo.files[7] = []string{"https://cdn.example.com/avatar.webp", "https://cdn.example.com/cv.pdf"}
+
+	_, err := f.svc.RequestDeletion(context.Background(), 7)
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+
+	deleted, err := f.svc.ExecuteDueDeletions(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	// The placeholder email reveals nothing of the old address
+	email := f.repo.anonymized[7]
+	assert.True(t, strings.HasPrefix(email, "deleted-"))
+	assert.NotContains(t, email, "rina")
+	assert.ElementsMatch(t, f.repo.files[7], f.uploads.Deleted)
+	assert.Equal(t, user.DeletionRequestCompleted, f.repo.requests[0].Status)
+	assert.NotNil(t, f.repo.requests[0].CompletedAt)
+
+	// Completed requests are not run again
+	deleted, err = f.svc.ExecuteDueDeletions(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	_, err = f.svc.RequestDeletion(context.Background(), 7)
+	assert.Error(t, err, "a deleted account cannot request deletion again")
+}
+
+func TestDeletionAudit_RecordsEachStep(t *testing.T) {
+	f := newPrivacyFixture(t, service.AccountPrivacyConfig{DeletionGracePeriod: time.Nanosecond})
+
+	_, err := f.svc.RequestDeletion(context.Background(), 7)
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	_, err = f.svc.ExecuteDueDeletions(context.Background())
+	require.NoError(t, err)
+	f.auditService.Close()
+
+	logs := f.audits.Stored()
+	require.Len(t, logs, 2)
+	assert.Equal(t, audit.ActionDeletionRequested, logs[0].Action)
+	assert.Equal(t, audit.ActorUser, logs[0].ActorType)
+	assert.Equal(t, int64(7), *logs[0].ActorID)
+	assert.Equal(t, audit.ActionAccountDeleted, logs[1].Action)
+	assert.Equal(t, audit.ActorSystem, logs[1].ActorType)
+	assert.Equal(t, int64(7), logs[1].EntityID)
+}
+
+func TestDataExport_GeneratesEmailsAndExpires(t *testing.T) {
+	f := newPrivacyFixture(t, service.AccountPrivacyConfig{ExportTTL: time.Hour})
+
+	export, err := f.svc.RequestDataExport(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, user.DataExportPending, export.Status)
+
+	// A second request returns the open export instead of queueing another
+	again, err := f.svc.RequestDataExport(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, export.ID, again.ID)
+
+	processed, err := f.svc.ProcessDataExports(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	require.Len(t, f.emails.Sent, 1)
+	assert.Equal(t, []string{"rina@example.com"}, f.emails.Recipients())
+
+	// Only the token hash is stored
+	token := f.emails.Sent[0].Code
+	assert.NotEqual(t, token, *f.repo.exports[0].TokenHash)
+
+	downloaded, err := f.svc.DownloadDataExport(context.Background(), token)
+	require.NoError(t, err)
+
+	var bundle struct {
+		Account struct {
+			Email string `json:"email"`
+		} `json:"account"`
+		Applications []struct {
+			ID        int64 `json:"id"`
+			Documents []struct {
+				FileURL string `json:"file_url"`
+			} `json:"documents"`
+		} `json:"applications"`
+		FollowedCompanies []struct {
+			Slug string `json:"slug"`
+		} `json:"followed_companies"`
+	}
+	require.NoError(t, json.Unmarshal(downloaded.Data, &bundle))
+	assert.Equal(t, "rina@example.com", bundle.Account.Email)
+	require.Len(t, bundle.Applications, 1)
+	require.Len(t, bundle.Applications[0].Documents, 1)
+	assert.Equal(t, "https://cdn.example.com/cv.pdf", bundle.Applications[0].Documents[0].FileURL)
+	require.Len(t, bundle.FollowedCompanies, 1)
+	assert.Equal(t, "acme", bundle.FollowedCompanies[0].Slug)
+
+	_, err = f.svc.DownloadDataExport(context.Background(), "not-a-token")
+	assert.ErrorIs(t, err, user.ErrDataExportNotFound)
+
+	// Once the link lapses the export is expired and can no longer be downloaded
+	expiredAt := time.Now().Add(-time.Minute)
+	f.repo.exports[0].ExpiresAt = &expiredAt
+	_, err = f.svc.ProcessDataExports(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, user.DataExportExpired, f.repo.exports[0].Status)
+
+	_, err = f.svc.DownloadDataExport(context.Background(), token)
+	assert.ErrorIs(t, err, user.ErrDataExportExpired)
+}
@@ -0,0 +1,177 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// statusJobRepo lists published jobs by the employer user that posted them
+type statusJobRepo struct {
+	job.JobRepository
+
+	jobs []job.Job
+}
+
+func (r *statusJobRepo) GetJobsByStatus(ctx context.Context, employerUserID int64, status string, page, limit int) ([]job.Job, int64, error) {
+	var jobs []job.Job
+	for _, j := range r.jobs {
+		if j.EmployerUserID != nil && *j.EmployerUserID == employerUserID && j.Status == "published" {
+			jobs = append(jobs, j)
+		}
+	}
+	return jobs, int64(len(jobs)), nil
+}
+
+// newTwoCompanyFixture has user 7 as a recruiter at company 3 (employer user 40) and an admin
+// at company 4 (employer user 60), with one published job at each
+func newTwoCompanyFixture(t *testing.T) (company.CompanyService, *fakes.CompanyRepository) {
+	t.Helper()
+
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	t.Cleanup(memCache.Stop)
+
+	companies := &fakes.CompanyRepository{EmployerUsers: []company.EmployerUser{
+		{ID: 40, UserID: 7, CompanyID: 3, Role: "recruiter", IsActive: true, Company: &company.Company{ID: 3, CompanyName: "Acme", Slug: "acme"}},
+		{ID: 60, UserID: 7, CompanyID: 4, Role: "admin", IsActive: true, Company: &company.Company{ID: 4, CompanyName: "Globex", Slug: "globex"}},
+		{ID: 61, UserID: 9, CompanyID: 4, Role: "viewer", IsActive: true},
+	}}
+	jobs := &statusJobRepo{jobs: []job.Job{
+		{ID: 1, CompanyID: 3, EmployerUserID: utils.Int64Ptr(40), Title: "Acme engineer", Status: "published"},
+		{ID: 2, CompanyID: 4, EmployerUserID: utils.Int64Ptr(60), Title: "Globex engineer", Status: "published"},
+	}}
+	svc := service.NewCompanyService(companies, nil, memCache, nil, nil, nil, nil, jobs, nil, nil, nil, nil, nil, nil, nil, nil)
+	return svc, companies
+}
+
+func TestActiveCompany_RequiredWithSeveralCompanies(t *testing.T) {
+	svc, _ := newTwoCompanyFixture(t)
+	ctx := context.Background()
+
+	// Neither company was chosen, so requests without a company cannot be resolved
+	_, err := svc.ResolveEmployerContext(ctx, 7, 0)
+	assert.ErrorIs(t, err, company.ErrActiveCompanyRequired)
+
+	// Naming a company still works and the choice is stored on activation
+	ec, err := svc.ResolveEmployerContext(ctx, 7, 3)
+	require.NoError(t, err)
+	assert.Equal(t, employer.EmployerContext{UserID: 7, EmployerUserID: 40, CompanyID: 3, Role: "recruiter"}, *ec)
+
+	ec, err = svc.ActivateCompany(ctx, 7, 4)
+	require.NoError(t, err)
+	assert.Equal(t, int64(60), ec.EmployerUserID)
+
+	ec, err = svc.ResolveEmployerContext(ctx, 7, 0)
+	require.NoError(t, err)
+	assert.Equal(t, employer.EmployerContext{UserID: 7, EmployerUserID: 60, CompanyID: 4, Role: "admin"}, *ec)
+
+	// Switching back makes the more recent choice win
+	_, err = svc.ActivateCompany(ctx, 7, 3)
+	require.NoError(t, err)
+	ec, err = svc.ResolveEmployerContext(ctx, 7, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), ec.CompanyID)
+
+	// Companies the user does not belong to cannot be activated
+	_, err = svc.ActivateCompany(ctx, 7, 9)
+	assert.ErrorIs(t, err, company.ErrNotCompanyEmployer)
+}
+
+func TestActiveCompany_IsolatesJobs(t *testing.T) {
+	svc, _ := newTwoCompanyFixture(t)
+	ctx := context.Background()
+
+	for companyID, title := range map[int64]string{3: "Acme engineer", 4: "Globex engineer"} {
+		ec, err := svc.ResolveEmployerContext(ctx, 7, companyID)
+		require.NoError(t, err)
+
+		jobs, total, err := svc.GetJobsByStatus(ctx, ec, "active", 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total, companyID)
+		require.Len(t, jobs, 1)
+		assert.Equal(t, title, jobs[0].Title)
+	}
+
+	_, _, err := svc.GetJobsByStatus(ctx, nil, "active", 1, 10)
+	assert.ErrorIs(t, err, company.ErrNotCompanyEmployer)
+}
+
+func TestActiveCompany_IsolatesApplications(t *testing.T) {
+	companySvc, companies := newTwoCompanyFixture(t)
+	appRepo := &fakes.SearchApplicationRepository{}
+	svc := helpers.NewApplicationService(appRepo, &fakes.JobRepository{}, &fakes.UserRepository{}, companies)
+	ctx := context.Background()
+
+	for _, companyID := range []int64{3, 4} {
+		ec, err := companySvc.ResolveEmployerContext(ctx, 7, companyID)
+		require.NoError(t, err)
+
+		_, err = svc.SearchApplications(ctx, ec, application.ApplicationSearchFilter{}, 1, 20)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{companyID}, appRepo.Filter.CompanyIDs)
+	}
+
+	// Both companies are searched together only when asked for
+	ec, err := companySvc.ResolveEmployerContext(ctx, 7, 3)
+	require.NoError(t, err)
+	_, err = svc.SearchApplications(ctx, ec, application.ApplicationSearchFilter{CompanyIDs: []int64{3, 4}}, 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3, 4}, appRepo.Filter.CompanyIDs)
+}
+
+func TestGetEmployerCompanies_MarksActiveCompany(t *testing.T) {
+	svc, _ := newTwoCompanyFixture(t)
+	ctx := context.Background()
+
+	activeOf := func(companies []company.EmployerCompany) []int64 {
+		var active []int64
+		for _, c := range companies {
+			if c.IsActive {
+				active = append(active, c.CompanyID)
+			}
+		}
+		return active
+	}
+
+	// Before a choice both companies are listed with their roles and none is active
+	companies, err := svc.GetEmployerCompanies(ctx, 7, 0)
+	require.NoError(t, err)
+	require.Len(t, companies, 2)
+	assert.Equal(t, "Acme", companies[0].CompanyName)
+	assert.Equal(t, "recruiter", companies[0].Role)
+	assert.Equal(t, "admin", companies[1].Role)
+	assert.Empty(t, activeOf(companies))
+
+	_, err = svc.ActivateCompany(ctx, 7, 4)
+	require.NoError(t, err)
+	companies, err = svc.GetEmployerCompanies(ctx, 7, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{4}, activeOf(companies))
+	assert.NotNil(t, companies[1].ActivatedAt)
+
+	// The header's company takes precedence over the stored one
+	companies, err = svc.GetEmployerCompanies(ctx, 7, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3}, activeOf(companies))
+
+	_, err = svc.GetEmployerCompanies(ctx, 7, 9)
+	assert.ErrorIs(t, err, company.ErrNotCompanyEmployer)
+
+	// A user with one company always has it active
+	companies, err = svc.GetEmployerCompanies(ctx, 9, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{4}, activeOf(companies))
+}
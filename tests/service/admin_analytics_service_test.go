@@ -0,0 +1,84 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/admin"
+	"keerja-backend/internal/service"
+)
+
+type fakeAnalyticsRepo struct {
+	counts []admin.AnalyticsBucketCount
+	calls  int
+
+	previousFrom time.Time
+}
+
+func (r *fakeAnalyticsRepo) CountByBucket(ctx context.Context, previousFrom, from, to time.Time, interval string) ([]admin.AnalyticsBucketCount, error) {
+	r.calls++
+	r.previousFrom = previousFrom
+	return r.counts, nil
+}
+
+func TestGetPlatformSummary_ZeroFillsSeriesAndComputesDeltas(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, time.May, d, 0, 0, 0, 0, time.UTC) }
+	repo := &fakeAnalyticsRepo{counts: []admin.AnalyticsBucketCount{
+		{Metric: admin.MetricNewUsers, Bucket: day(10), Count: 3},
+		{Metric: admin.MetricNewUsers, Bucket: day(12), Count: 2},
+		{Metric: admin.MetricNewUsers, Previous: true, Bucket: day(8), Count: 4},
+		{Metric: admin.MetricHires, Bucket: day(11), Count: 1},
+	}}
+	svc := service.NewAdminAnalyticsService(repo, cache.NewInMemoryCache(100, time.Minute))
+
+	summary, err := svc.GetPlatformSummary(context.Background(), day(10), day(13), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, day(7), repo.previousFrom)
+	assert.Equal(t, day(7), summary.PreviousFrom)
+	assert.Equal(t, day(10), summary.PreviousTo)
+	assert.Equal(t, admin.AnalyticsIntervalDay, summary.Interval)
+
+	users := summary.NewUsers
+	assert.Equal(t, int64(5), users.Total)
+	assert.Equal(t, int64(4), users.PreviousTotal)
+	assert.Equal(t, int64(1), users.Change)
+	require.NotNil(t, users.ChangePercent)
+	assert.Equal(t, 25.0, *users.ChangePercent)
+	assert.Equal(t, []admin.AnalyticsPoint{
+		{Bucket: day(10), Count: 3},
+		{Bucket: day(11), Count: 0},
+		{Bucket: day(12), Count: 2},
+	}, users.Series)
+
+	// No events in the previous period leaves the percentage undefined
+	assert.Equal(t, int64(1), summary.Hires.Total)
+	assert.Nil(t, summary.Hires.ChangePercent)
+	assert.Len(t, summary.NewCompanies.Series, 3)
+
+	_, err = svc.GetPlatformSummary(context.Background(), day(10), day(13), admin.AnalyticsIntervalDay)
+	require.NoError(t, err)
+	assert.Equal(t, 1, repo.calls, "second summary should be served from cache")
+}
+
+func TestGetPlatformSummary_RejectsInvalidRangeAndInterval(t *testing.T) {
+	repo := &fakeAnalyticsRepo{}
+	svc := service.NewAdminAnalyticsService(repo, nil)
+	now := time.Now()
+
+	_, err := svc.GetPlatformSummary(context.Background(), now, now.AddDate(0, 0, -1), admin.AnalyticsIntervalDay)
+	assert.ErrorIs(t, err, admin.ErrInvalidAnalyticsRange)
+
+	_, err = svc.GetPlatformSummary(context.Background(), now.AddDate(-5, 0, 0), now, admin.AnalyticsIntervalDay)
+	assert.ErrorIs(t, err, admin.ErrInvalidAnalyticsRange)
+
+	_, err = svc.GetPlatformSummary(context.Background(), now.AddDate(0, 0, -7), now, "hour")
+	assert.ErrorIs(t, err, admin.ErrInvalidAnalyticsInterval)
+
+	assert.Zero(t, repo.calls)
+}
@@ -0,0 +1,92 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/notification"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+type recordingPushService struct {
+	notification.PushNotificationService
+	userID  int64
+	message *notification.PushMessage
+}
+
+func (s *recordingPushService) SendToUser(ctx context.Context, userID int64, message *notification.PushMessage) ([]notification.PushResult, error) {
+	s.userID, s.message = userID, message
+	return nil, errors.New("no registered devices")
+}
+
+func newReviewFixture(status string) (*fakes.JobRepository, *fakes.EmailService, *recordingPushService, service.AdminJobService) {
+	employerUserID := int64(11)
+	jobRepo := &fakes.JobRepository{Job: &job.Job{ID: 5, CompanyID: 3, EmployerUserID: &employerUserID, Title: "Backend Engineer", Status: status}}
+	companyRepo := &fakes.CompanyRepository{EmployerUsers: []company.EmployerUser{{ID: employerUserID, UserID: 7, CompanyID: 3}}}
+	userRepo := &fakes.UserRepository{User: &user.User{ID: 7, Email: "owner@acme.test", FullName: "Owner"}}
+	emailSvc := &fakes.EmailService{}
+	pushSvc := &recordingPushService{}
+	svc := service.NewAdminJobService(jobRepo, companyRepo, userRepo, emailSvc, service.NewNotificationDispatcher(userRepo, pushSvc, nil, nil, nil), nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil)
+	return jobRepo, emailSvc, pushSvc, svc
+}
+
+func TestAdminJobService_RejectRecordsReasonAndNotifiesOwner(t *testing.T) {
+	jobRepo, emailSvc, pushSvc, svc := newReviewFixture("pending_review")
+
+	_, err := svc.RejectJob(context.Background(), 5, 1, "  Salary range is missing  ")
+	require.NoError(t, err)
+
+	assert.Equal(t, "draft", jobRepo.Job.Status)
+	require.Len(t, jobRepo.Reviews, 1)
+	assert.Equal(t, "rejected", jobRepo.Reviews[0].Action)
+	assert.Equal(t, int64(1), *jobRepo.Reviews[0].AdminID)
+	assert.Equal(t, "Salary range is missing", *jobRepo.Reviews[0].Reason)
+
+	// Push failures don't fail the review
+	assert.Equal(t, "owner@acme.test", emailSvc.Last().To)
+	assert.Equal(t, "Salary range is missing", emailSvc.Last().Message)
+	assert.Equal(t, int64(7), pushSvc.userID)
+	assert.Equal(t, "rejected", pushSvc.message.Data["action"])
+}
+
+func TestAdminJobService_RejectRequiresReason(t *testing.T) {
+	jobRepo, _, _, svc := newReviewFixture("pending_review")
+
+	_, err := svc.RejectJob(context.Background(), 5, 1, "   ")
+	assert.ErrorIs(t, err, job.ErrRejectionReasonRequired)
+	assert.Equal(t, "pending_review", jobRepo.Job.Status)
+	assert.Empty(t, jobRepo.Reviews)
+}
+
+func TestAdminJobService_ApprovePublishesPendingJob(t *testing.T) {
+	jobRepo, emailSvc, _, svc := newReviewFixture("pending_review")
+
+	_, err := svc.ApproveJob(context.Background(), 5, 1, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "published", jobRepo.Job.Status)
+	assert.NotNil(t, jobRepo.Job.PublishedAt)
+	require.NotNil(t, jobRepo.Job.ExpiredAt)
+	assert.WithinDuration(t, time.Now().Add(job.DefaultExpiryPolicy.DefaultPeriod), *jobRepo.Job.ExpiredAt, time.Minute)
+	require.Len(t, jobRepo.Reviews, 1)
+	assert.Equal(t, "approved", jobRepo.Reviews[0].Action)
+	assert.Nil(t, jobRepo.Reviews[0].Reason)
+	assert.Equal(t, "Disetujui", emailSvc.Last().Status)
+}
+
+func TestAdminJobService_ApproveRejectsNonPendingJob(t *testing.T) {
+	jobRepo, _, _, svc := newReviewFixture("draft")
+
+	_, err := svc.ApproveJob(context.Background(), 5, 1, "")
+	assert.ErrorIs(t, err, job.ErrJobNotPendingReview)
+	assert.Empty(t, jobRepo.Reviews)
+}
@@ -0,0 +1,127 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/admin"
+	"keerja-backend/internal/domain/audit"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+type permissionAdminUserRepo struct {
+	admin.AdminUserRepository
+	users map[int64]*admin.AdminUser
+	roles map[int64]*admin.AdminRole
+}
+
+func (r *permissionAdminUserRepo) FindByID(ctx context.Context, id int64) (*admin.AdminUser, error) {
+	u, ok := r.users[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *u
+	if u.RoleID != nil {
+		copied.Role = r.roles[*u.RoleID]
+	}
+	return &copied, nil
+}
+
+func (r *permissionAdminUserRepo) UpdateRole(ctx context.Context, userID, roleID int64) error {
+	r.users[userID].RoleID = &roleID
+	return nil
+}
+
+type permissionAdminRoleRepo struct {
+	admin.AdminRoleRepository
+	roles map[int64]*admin.AdminRole
+}
+
+func (r *permissionAdminRoleRepo) FindByID(ctx context.Context, id int64) (*admin.AdminRole, error) {
+	return r.roles[id], nil
+}
+
+type adminPermissionFixture struct {
+	svc          admin.AdminPermissionService
+	users        *permissionAdminUserRepo
+	auditService audit.AuditService
+	auditRepo    *fakes.AuditLogRepository
+}
+
+func newAdminPermissionFixture(t *testing.T) *adminPermissionFixture {
+	t.Helper()
+
+	roles := map[int64]*admin.AdminRole{
+		1: {ID: 1, RoleName: "Super Admin", Permissions: pq.StringArray(admin.AllPermissions)},
+		2: {ID: 2, RoleName: "Moderator", Permissions: pq.StringArray{admin.PermReviewsModerate, admin.PermJobsReview}},
+	}
+	superRole, moderatorRole := int64(1), int64(2)
+	users := &permissionAdminUserRepo{
+		roles: roles,
+		users: map[int64]*admin.AdminUser{
+			10: {ID: 10, RoleID: &superRole},
+			20: {ID: 20, RoleID: &moderatorRole},
+		},
+	}
+
+	permCache := cache.NewInMemoryCache(100, time.Minute)
+	t.Cleanup(permCache.Stop)
+	auditRepo := &fakes.AuditLogRepository{}
+	auditService := service.NewAuditService(auditRepo, 10)
+	t.Cleanup(auditService.Close)
+
+	return &adminPermissionFixture{
+		svc:          service.NewAdminPermissionService(users, &permissionAdminRoleRepo{roles: roles}, auditService, permCache),
+		users:        users,
+		auditService: auditService,
+		auditRepo:    auditRepo,
+	}
+}
+
+func TestAdminPermissionService_AssignRoleRefreshesCachedPermissions(t *testing.T) {
+	f := newAdminPermissionFixture(t)
+	ctx := context.Background()
+
+	allowed, err := f.svc.HasPermission(ctx, 20, admin.PermCompaniesVerify)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	updated, err := f.svc.AssignRole(ctx, 10, 20, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), *updated.RoleID)
+	assert.Equal(t, "Super Admin", updated.Role.RoleName)
+
+	allowed, err = f.svc.HasPermission(ctx, 20, admin.PermCompaniesVerify)
+	require.NoError(t, err)
+	assert.True(t, allowed, "the promoted admin's cached permissions are dropped")
+
+	f.auditService.Close()
+	logs := f.auditRepo.Stored()
+	require.Len(t, logs, 1)
+	assert.Equal(t, audit.ActionAdminRoleAssigned, logs[0].Action)
+	assert.Equal(t, int64(20), logs[0].EntityID)
+	assert.Equal(t, int64(10), *logs[0].ActorID)
+}
+
+func TestAdminPermissionService_AssignRoleRejections(t *testing.T) {
+	f := newAdminPermissionFixture(t)
+	ctx := context.Background()
+
+	_, err := f.svc.AssignRole(ctx, 10, 10, 2)
+	assert.ErrorIs(t, err, admin.ErrCannotChangeOwnRole)
+
+	_, err = f.svc.AssignRole(ctx, 10, 99, 2)
+	assert.ErrorIs(t, err, admin.ErrAdminUserNotFound)
+
+	_, err = f.svc.AssignRole(ctx, 10, 20, 99)
+	assert.ErrorIs(t, err, admin.ErrAdminRoleNotFound)
+
+	assert.Equal(t, int64(2), *f.users.users[20].RoleID, "failed assignments leave the role unchanged")
+}
@@ -0,0 +1,117 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// visibilityUserRepo serves applicant 100 with a full profile and the given preferences
+type visibilityUserRepo struct {
+	user.UserRepository
+
+	pref *user.UserPreference
+}
+
+func (r *visibilityUserRepo) applicant(id int64) user.User {
+	phone := "+628123456789"
+	address := "Jl. Melati No. 7, RT 02/RW 05"
+	city, state := "Bandung", "Jawa Barat"
+	avatar := "https://cdn.example.com/avatar.jpg"
+	return user.User{
+		ID:         id,
+		FullName:   "Sari Wulandari",
+		Email:      "sari@example.com",
+		Phone:      &phone,
+		Profile:    &user.UserProfile{UserID: id, Address: &address, LocationCity: &city, LocationState: &state, AvatarURL: &avatar},
+		Preference: r.pref,
+	}
+}
+
+func (r *visibilityUserRepo) FindByID(ctx context.Context, id int64) (*user.User, error) {
+	u := r.applicant(id)
+	return &u, nil
+}
+
+func (r *visibilityUserRepo) FindByIDs(ctx context.Context, ids []int64) ([]user.User, error) {
+	users := make([]user.User, len(ids))
+	for i, id := range ids {
+		users[i] = r.applicant(id)
+	}
+	return users, nil
+}
+
+// newVisibilityService serves one application of applicant 100 to company 3 in the given
+// status, viewed by recruiter 5; blind screening is off
+func newVisibilityService(status string, pref *user.UserPreference) application.ApplicationService {
+	companyID := int64(3)
+	appRepo := fakes.NewApplicationRepository(application.JobApplication{
+		ID: 42, JobID: 10, UserID: 100, CompanyID: &companyID, Status: status, ViewedByEmployer: true,
+	})
+	companyRepo := helpers.NewCompanyMemberRepository("recruiter")
+	companyRepo.Settings = map[int64]*company.CompanySettings{companyID: {CompanyID: companyID}}
+	jobRepo := &fakes.JobRepository{Job: &job.Job{ID: 10, Title: "Backend Engineer"}}
+	return helpers.NewApplicationService(appRepo, jobRepo, &visibilityUserRepo{pref: pref}, companyRepo)
+}
+
+func TestApplicantVisibility_StageAndVisibilityMatrix(t *testing.T) {
+	preferences := map[string]*user.UserPreference{
+		"none":           nil,
+		"public":         {ProfileVisibility: user.ProfileVisibilityPublic, AllowDirectMessages: true},
+		"recruiter-only": {ProfileVisibility: user.ProfileVisibilityRecruiterOnly, AllowDirectMessages: true},
+		"private":        {ProfileVisibility: user.ProfileVisibilityPrivate, AllowDirectMessages: true},
+		"no-messages":    {ProfileVisibility: user.ProfileVisibilityPublic, AllowDirectMessages: false},
+	}
+	revealed := map[string]bool{"shortlisted": true, "interview": true, "offered": true, "hired": true}
+
+	for name, pref := range preferences {
+		for _, status := range application.Statuses {
+			t.Run(name+"/"+status, func(t *testing.T) {
+				svc := newVisibilityService(status, pref)
+				ctx := context.Background()
+
+				// Companies see the applicant on their own applications whatever the visibility
+				ec := &employer.EmployerContext{UserID: 5, EmployerUserID: 50, CompanyID: 3, Role: "recruiter"}
+				list, err := svc.GetCompanyApplications(ctx, ec, application.ApplicationFilter{}, 1, 20)
+				require.NoError(t, err)
+				require.Len(t, list.Applications, 1)
+				assert.Equal(t, "Sari Wulandari", list.Applications[0].UserName)
+
+				detail, err := svc.GetApplicationForReview(ctx, ec, 42)
+				require.NoError(t, err)
+				applicant := detail.Applicant
+				assert.Equal(t, int64(100), applicant.UserID)
+				assert.Equal(t, "Sari Wulandari", applicant.FullName)
+				assert.Equal(t, "sari@example.com", applicant.Email)
+				assert.Equal(t, "Bandung, Jawa Barat", applicant.Location)
+				assert.Equal(t, pref == nil || pref.AllowDirectMessages, applicant.AllowsDirectMessages)
+
+				assert.Equal(t, revealed[status], applicant.ContactRevealed)
+				if revealed[status] {
+					assert.Equal(t, "+628123456789", applicant.Phone)
+					assert.Equal(t, "Jl. Melati No. 7, RT 02/RW 05", applicant.Address)
+				} else {
+					assert.Empty(t, applicant.Phone)
+					assert.Empty(t, applicant.Address)
+				}
+
+				// Applicants always see their own details
+				own, err := svc.GetApplicationDetail(ctx, 42, 100)
+				require.NoError(t, err)
+				assert.True(t, own.Applicant.ContactRevealed)
+				assert.Equal(t, "+628123456789", own.Applicant.Phone)
+				assert.Equal(t, "Jl. Melati No. 7, RT 02/RW 05", own.Applicant.Address)
+			})
+		}
+	}
+}
@@ -0,0 +1,352 @@
+package service_test
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/internal/utils"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// bulkActionApplicationRepo keeps bulk actions and their items in memory on top of the
+// applications of fakes.ApplicationRepository, and records the size of every batch of pending items
+type bulkActionApplicationRepo struct {
+	*fakes.ApplicationRepository
+
+	bulkMu  sync.Mutex
+	actions map[int64]*application.BulkAction
+	items   []application.BulkActionItem
+	batches []int
+}
+
+func newBulkActionRepo() *bulkActionApplicationRepo {
+	return &bulkActionApplicationRepo{ApplicationRepository: fakes.NewApplicationRepository(), actions: map[int64]*application.BulkAction{}}
+}
+
+func (r *bulkActionApplicationRepo) addApplications(companyID int64, status string, ids ...int64) {
+	for _, id := range ids {
+		r.Apps[id] = &application.JobApplication{ID: id, JobID: 10, UserID: 100 + id, CompanyID: utils.Int64Ptr(companyID), Status: status}
+	}
+}
+
+func (r *bulkActionApplicationRepo) GetCurrentStage(ctx context.Context, applicationID int64) (*application.JobApplicationStage, error) {
+	return nil, nil
+}
+
+func (r *bulkActionApplicationRepo) FilterCompanyApplicationIDs(ctx context.Context, companyID int64, ids []int64) ([]int64, error) {
+	r.Lock()
+	defer r.Unlock()
+	var found []int64
+	for _, id := range ids {
+		if app, ok := r.Apps[id]; ok && *app.CompanyID == companyID {
+			found = append(found, id)
+		}
+	}
+	return found, nil
+}
+
+func (r *bulkActionApplicationRepo) ListCompanyApplicationIDs(ctx context.Context, companyID int64, filter application.ApplicationFilter, limit int) ([]int64, error) {
+	r.Lock()
+	defer r.Unlock()
+	var ids []int64
+	for id, app := range r.Apps {
+		if *app.CompanyID == companyID && (filter.Status == "" || app.Status == filter.Status) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids, nil
+}
+
+func (r *bulkActionApplicationRepo) CreateBulkAction(ctx context.Context, action *application.BulkAction, applicationIDs []int64) error {
+	r.bulkMu.Lock()
+	defer r.bulkMu.Unlock()
+	action.ID = int64(len(r.actions) + 1)
+	stored := *action
+	r.actions[action.ID] = &stored
+	for _, id := range applicationIDs {
+		r.items = append(r.items, application.BulkActionItem{ID: int64(len(r.items) + 1), BulkActionID: action.ID, ApplicationID: id, Status: application.BulkItemPending})
+	}
+	return nil
+}
+
+func (r *bulkActionApplicationRepo) FindBulkActionByID(ctx context.Context, id int64) (*application.BulkAction, error) {
+	r.bulkMu.Lock()
+	defer r.bulkMu.Unlock()
+	action, ok := r.actions[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *action
+	return &copied, nil
+}
+
+func (r *bulkActionApplicationRepo) FindUnfinishedBulkActions(ctx context.Context, limit int) ([]application.BulkAction, error) {
+	r.bulkMu.Lock()
+	defer r.bulkMu.Unlock()
+	var actions []application.BulkAction
+	for id := int64(1); id <= int64(len(r.actions)) && len(actions) < limit; id++ {
+		if action := r.actions[id]; !action.IsDone() {
+			actions = append(actions, *action)
+		}
+	}
+	return actions, nil
+}
+
+func (r *bulkActionApplicationRepo) StartBulkAction(ctx context.Context, id int64, at time.Time) error {
+	r.bulkMu.Lock()
+	defer r.bulkMu.Unlock()
+	r.actions[id].Status = application.BulkActionRunning
+	r.actions[id].StartedAt = &at
+	return nil
+}
+
+func (r *bulkActionApplicationRepo) CompleteBulkAction(ctx context.Context, id int64, at time.Time) error {
+	r.bulkMu.Lock()
+	defer r.bulkMu.Unlock()
+	r.actions[id].Status = application.BulkActionDone
+	r.actions[id].CompletedAt = &at
+	return nil
+}
+
+func (r *bulkActionApplicationRepo) ListBulkActionItems(ctx context.Context, actionID int64, status string, limit int) ([]application.BulkActionItem, error) {
+	r.bulkMu.Lock()
+	defer r.bulkMu.Unlock()
+	var items []application.BulkActionItem
+	for _, item := range r.items {
+		if item.BulkActionID == actionID && item.Status == status && len(items) < limit {
+			items = append(items, item)
+		}
+	}
+	if status == application.BulkItemPending {
+		r.batches = append(r.batches, len(items))
+	}
+	return items, nil
+}
+
+func (r *bulkActionApplicationRepo) RecordBulkActionItem(ctx context.Context, item *application.BulkActionItem) error {
+	r.bulkMu.Lock()
+	defer r.bulkMu.Unlock()
+	stored := &r.items[item.ID-1]
+	if stored.Status != application.BulkItemPending {
+		return nil
+	}
+	stored.Status, stored.Error, stored.ProcessedAt = item.Status, item.Error, item.ProcessedAt
+
+	action := r.actions[item.BulkActionID]
+	action.ProcessedCount++
+	if item.Status == application.BulkItemSucceeded {
+		action.SucceededCount++
+	} else {
+		action.FailedCount++
+	}
+	return nil
+}
+
+// newBulkActionService serves company 3, where user 5 is a recruiter (employer user 50) and
+// user 6 a viewer (employer user 60)
+func newBulkActionService(repo *bulkActionApplicationRepo) (application.ApplicationService, *fakes.CompanyRepository) {
+	companyRepo := &fakes.CompanyRepository{EmployerUsers: []company.EmployerUser{
+		{ID: 50, UserID: 5, CompanyID: 3, Role: employer.RoleRecruiter, IsActive: true},
+		{ID: 60, UserID: 6, CompanyID: 3, Role: employer.RoleViewer, IsActive: true},
+	}}
+	return helpers.NewApplicationService(repo, &fakes.JobRepository{}, &fakes.UserRepository{}, companyRepo), companyRepo
+}
+
+var (
+	bulkRecruiter = &employer.EmployerContext{UserID: 5, EmployerUserID: 50, CompanyID: 3, Role: employer.RoleRecruiter}
+	bulkViewer    = &employer.EmployerContext{UserID: 6, EmployerUserID: 60, CompanyID: 3, Role: employer.RoleViewer}
+)
+
+func TestBulkAction_KeepsOnlyTheCompanysApplications(t *testing.T) {
+	repo := newBulkActionRepo()
+	repo.addApplications(3, application.StatusApplied, 1, 2)
+	repo.addApplications(4, application.StatusApplied, 3)
+	svc, _ := newBulkActionService(repo)
+	ctx := context.Background()
+
+	action, err := svc.CreateBulkAction(ctx, bulkRecruiter, &application.BulkActionRequest{
+		Status:         application.StatusScreening,
+		ApplicationIDs: []int64{2, 1, 2, 3, 99},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, application.BulkActionQueued, action.Status)
+	assert.Equal(t, int64(5), action.CreatedBy)
+	assert.Equal(t, int64(50), *action.EmployerUserID)
+	assert.Equal(t, employer.RoleRecruiter, action.EmployerRole)
+	assert.Equal(t, 2, action.TotalCount)
+	assert.Equal(t, 2, action.SkippedCount, "another company's application and an unknown ID are skipped")
+
+	queued := make([]int64, 0, len(repo.items))
+	for _, item := range repo.items {
+		queued = append(queued, item.ApplicationID)
+	}
+	assert.Equal(t, []int64{1, 2}, queued)
+
+	_, err = svc.CreateBulkAction(ctx, bulkRecruiter, &application.BulkActionRequest{Status: application.StatusScreening, ApplicationIDs: []int64{3}})
+	assert.ErrorIs(t, err, application.ErrNoApplicationsSelected)
+
+	_, err = svc.CreateBulkAction(ctx, bulkViewer, &application.BulkActionRequest{Status: application.StatusScreening, ApplicationIDs: []int64{1}})
+	assert.ErrorIs(t, err, application.ErrInsufficientPermissions)
+	assert.Len(t, repo.actions, 1)
+}
+
+func TestBulkAction_ValidatesSelection(t *testing.T) {
+	repo := newBulkActionRepo()
+	repo.addApplications(3, application.StatusApplied, 1)
+	svc, _ := newBulkActionService(repo)
+	ctx := context.Background()
+
+	_, err := svc.CreateBulkAction(ctx, bulkRecruiter, &application.BulkActionRequest{Status: application.StatusWithdrawn, ApplicationIDs: []int64{1}})
+	assert.ErrorIs(t, err, application.ErrInvalidBulkAction, "employers cannot withdraw applications")
+
+	_, err = svc.CreateBulkAction(ctx, bulkRecruiter, &application.BulkActionRequest{Status: application.StatusScreening})
+	assert.ErrorIs(t, err, application.ErrInvalidBulkAction)
+
+	_, err = svc.CreateBulkAction(ctx, bulkRecruiter, &application.BulkActionRequest{
+		Status:         application.StatusScreening,
+		ApplicationIDs: []int64{1},
+		Filter:         &application.BulkActionFilter{Status: application.StatusApplied},
+	})
+	assert.ErrorIs(t, err, application.ErrInvalidBulkAction, "IDs and a filter cannot be combined")
+
+	tooMany := make([]int64, application.MaxBulkActionItems+1)
+	for i := range tooMany {
+		tooMany[i] = int64(i + 1)
+	}
+	_, err = svc.CreateBulkAction(ctx, bulkRecruiter, &application.BulkActionRequest{Status: application.StatusScreening, ApplicationIDs: tooMany})
+	assert.ErrorIs(t, err, application.ErrBulkActionTooLarge)
+	assert.Empty(t, repo.actions)
+}
+
+func TestBulkAction_ProcessesInBatchesAndCountsResults(t *testing.T) {
+	repo := newBulkActionRepo()
+	ids := make([]int64, 0, 120)
+	for id := int64(1); id <= 120; id++ {
+		ids = append(ids, id)
+	}
+	repo.addApplications(3, application.StatusApplied, ids...)
+	repo.addApplications(3, application.StatusOffered, 7, 8) // Cannot move back to screening
+	svc, _ := newBulkActionService(repo)
+	ctx := context.Background()
+
+	action, err := svc.CreateBulkAction(ctx, bulkRecruiter, &application.BulkActionRequest{
+		Status: application.StatusScreening,
+		Filter: &application.BulkActionFilter{},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 120, action.TotalCount)
+
+	processed, err := svc.ProcessBulkActions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 120, processed)
+	assert.Equal(t, []int{50, 50, 20, 0}, repo.batches)
+
+	progress, err := svc.GetBulkAction(ctx, bulkViewer, action.ID)
+	require.NoError(t, err)
+	assert.Equal(t, application.BulkActionDone, progress.Status)
+	assert.Equal(t, 120, progress.ProcessedCount)
+	assert.Equal(t, 118, progress.SucceededCount)
+	assert.Equal(t, 2, progress.FailedCount)
+	require.Len(t, progress.Failures, 2)
+	assert.Equal(t, []int64{7, 8}, []int64{progress.Failures[0].ApplicationID, progress.Failures[1].ApplicationID})
+	assert.NotEmpty(t, progress.Failures[0].Error)
+	assert.Equal(t, application.StatusScreening, repo.Apps[120].Status)
+	require.NotEmpty(t, repo.Stages)
+	assert.Equal(t, int64(5), *repo.Stages[0].HandledBy, "stages are handled by the employer who queued the action")
+
+	processed, err = svc.ProcessBulkActions(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, processed, "done actions are not picked up again")
+
+	_, err = svc.GetBulkAction(ctx, &employer.EmployerContext{UserID: 5, CompanyID: 4, Role: employer.RoleOwner}, action.ID)
+	assert.ErrorIs(t, err, application.ErrBulkActionNotFound)
+}
+
+func TestBulkAction_ResumesInterruptedAction(t *testing.T) {
+	repo := newBulkActionRepo()
+	repo.addApplications(3, application.StatusApplied, 1, 2, 3)
+	svc, _ := newBulkActionService(repo)
+	ctx := context.Background()
+
+	action, err := svc.CreateBulkAction(ctx, bulkRecruiter, &application.BulkActionRequest{Status: application.StatusShortlisted, ApplicationIDs: []int64{1, 2, 3}})
+	require.NoError(t, err)
+
+	// A restart interrupted the action after item 1 was recorded and application 2 was moved
+	// but before its item was recorded
+	repo.actions[action.ID].Status = application.BulkActionRunning
+	require.NoError(t, repo.RecordBulkActionItem(ctx, &application.BulkActionItem{ID: 1, BulkActionID: action.ID, Status: application.BulkItemSucceeded}))
+	repo.Apps[1].Status = application.StatusShortlisted
+	repo.Apps[2].Status = application.StatusShortlisted
+
+	processed, err := svc.ProcessBulkActions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, processed, "only pending items are processed again")
+
+	done := repo.actions[action.ID]
+	assert.Equal(t, application.BulkActionDone, done.Status)
+	assert.Equal(t, 3, done.ProcessedCount)
+	assert.Equal(t, 3, done.SucceededCount, "an application already moved counts as moved")
+	assert.Zero(t, done.FailedCount)
+	assert.Equal(t, application.StatusShortlisted, repo.Apps[3].Status)
+}
+
+func TestBulkAction_FailsItemsOnceTheEmployerUserIsDeactivated(t *testing.T) {
+	repo := newBulkActionRepo()
+	repo.addApplications(3, application.StatusApplied, 1, 2)
+	svc, companyRepo := newBulkActionService(repo)
+	ctx := context.Background()
+
+	action, err := svc.CreateBulkAction(ctx, bulkRecruiter, &application.BulkActionRequest{Status: application.StatusScreening, ApplicationIDs: []int64{1, 2}})
+	require.NoError(t, err)
+
+	companyRepo.EmployerUsers[0].IsActive = false
+
+	processed, err := svc.ProcessBulkActions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, processed)
+
+	done := repo.actions[action.ID]
+	assert.Equal(t, application.BulkActionDone, done.Status)
+	assert.Equal(t, 2, done.FailedCount)
+	assert.Equal(t, application.StatusApplied, repo.Apps[1].Status)
+	assert.Equal(t, application.StatusApplied, repo.Apps[2].Status)
+}
+
+func TestBulkAction_FailsItemsOnceTheEmployerUserIsDemoted(t *testing.T) {
+	repo := newBulkActionRepo()
+	repo.addApplications(3, application.StatusApplied, 1, 2)
+	svc, companyRepo := newBulkActionService(repo)
+	ctx := context.Background()
+
+	action, err := svc.CreateBulkAction(ctx, bulkRecruiter, &application.BulkActionRequest{Status: application.StatusScreening, ApplicationIDs: []int64{1, 2}})
+	require.NoError(t, err)
+
+	companyRepo.EmployerUsers[0].Role = employer.RoleViewer
+
+	processed, err := svc.ProcessBulkActions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, processed)
+
+	done := repo.actions[action.ID]
+	assert.Equal(t, 2, done.FailedCount, "the role at processing time applies, not the one at queueing")
+	for _, item := range repo.items {
+		assert.Equal(t, application.ErrInsufficientPermissions.Error(), item.Error)
+	}
+	assert.Equal(t, application.StatusApplied, repo.Apps[1].Status)
+	assert.Equal(t, application.StatusApplied, repo.Apps[2].Status)
+}
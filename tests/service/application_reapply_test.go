@@ -0,0 +1,110 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/apperror"
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+func newReapplyService(existing *application.JobApplication) (application.ApplicationService, *fakes.ApplicationRepository) {
+	expiry := time.Now().Add(24 * time.Hour)
+	appRepo := fakes.NewApplicationRepository()
+	if existing != nil {
+		appRepo = fakes.NewApplicationRepository(*existing)
+	}
+	jobRepo := &fakes.JobRepository{Job: &job.Job{ID: 10, CompanyID: 3, Title: "Backend Engineer", Status: "published", ExpiredAt: &expiry}}
+	userRepo := &fakes.UserRepository{User: helpers.VerifiedJobseeker()}
+	return helpers.NewApplicationService(appRepo, jobRepo, userRepo, &fakes.CompanyRepository{}), appRepo
+}
+
+func closedApplication(status string, closedAgo time.Duration) *application.JobApplication {
+	closedAt := time.Now().Add(-closedAgo)
+	return &application.JobApplication{ID: 1, JobID: 10, UserID: 7, Status: status, ClosedAt: &closedAt}
+}
+
+func TestCanApplyForJob_ReapplyRules(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		name     string
+		existing *application.JobApplication
+		want     error
+	}{
+		{"open application", &application.JobApplication{ID: 1, JobID: 10, UserID: 7, Status: "interview"}, application.ErrAlreadyApplied},
+		{"withdrawn inside cooldown", closedApplication("withdrawn", 30*day-time.Minute), application.ErrReapplyNotYetAvailable},
+		{"withdrawn after cooldown", closedApplication("withdrawn", 30*day+time.Minute), nil},
+		{"rejected inside cooldown", closedApplication("rejected", 90*day-time.Minute), application.ErrReapplyNotYetAvailable},
+		{"rejected after cooldown", closedApplication("rejected", 90*day+time.Minute), nil},
+		{"hired", closedApplication("hired", 365*day), application.ErrNotEligibleToReapply},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newReapplyService(tt.existing)
+			err := svc.CanApplyForJob(context.Background(), 10, 7)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestCanApplyForJob_DoNotReapplyFlagBlocksForGood(t *testing.T) {
+	rejected := closedApplication("rejected", 365*24*time.Hour)
+	rejected.DoNotReapply = true
+	svc, _ := newReapplyService(rejected)
+
+	err := svc.CanApplyForJob(context.Background(), 10, 7)
+	assert.ErrorIs(t, err, application.ErrNotEligibleToReapply)
+}
+
+func TestCanApplyForJob_CooldownErrorCarriesDate(t *testing.T) {
+	withdrawn := closedApplication("withdrawn", 10*24*time.Hour)
+	svc, _ := newReapplyService(withdrawn)
+
+	err := svc.CanApplyForJob(context.Background(), 10, 7)
+	appErr, ok := apperror.As(err)
+	require.True(t, ok)
+
+	availableAt := withdrawn.ClosedAt.Add(application.DefaultReapplyPolicy.WithdrawnCooldown)
+	assert.Equal(t, "reapply available on "+availableAt.Format("2006-01-02"), appErr.Message)
+	assert.Equal(t, availableAt.UTC().Format(time.RFC3339), appErr.Details["reapply_available_at"])
+}
+
+func TestApplyForJob_ReapplyAfterCooldownCreatesNewApplication(t *testing.T) {
+	svc, appRepo := newReapplyService(closedApplication("withdrawn", 31*24*time.Hour))
+
+	app, err := svc.ApplyForJob(context.Background(), &application.ApplyJobRequest{JobID: 10, UserID: 7})
+	require.NoError(t, err)
+	assert.NotEqual(t, int64(1), app.ID)
+	assert.Equal(t, 2, appRepo.Count())
+}
+
+func TestWithdrawApplication_BlockedOnceOffered(t *testing.T) {
+	svc, _ := newReapplyService(&application.JobApplication{ID: 1, JobID: 10, UserID: 7, Status: "offered"})
+
+	err := svc.WithdrawApplication(context.Background(), 1, 7)
+	assert.ErrorIs(t, err, application.ErrWithdrawAfterOffer)
+}
+
+func TestWithdrawApplication_RecordsClosedAt(t *testing.T) {
+	svc, appRepo := newReapplyService(&application.JobApplication{ID: 1, JobID: 10, UserID: 7, Status: "interview"})
+
+	require.NoError(t, svc.WithdrawApplication(context.Background(), 1, 7))
+
+	stored, err := appRepo.FindByID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "withdrawn", stored.Status)
+	require.NotNil(t, stored.ClosedAt)
+	assert.WithinDuration(t, time.Now(), *stored.ClosedAt, time.Minute)
+}
@@ -0,0 +1,118 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/apperror"
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// newScreeningService returns an application service for a published job with a driving
+// license knockout question, a required salary question and an optional select question
+func newScreeningService() (application.ApplicationService, *fakes.ApplicationRepository) {
+	expiry := time.Now().Add(24 * time.Hour)
+	appRepo := fakes.NewApplicationRepository()
+	jobRepo := &fakes.JobRepository{
+		Job: &job.Job{ID: 10, CompanyID: 3, Title: "Driver", Status: "published", ExpiredAt: &expiry},
+		Questions: []job.JobQuestion{
+			{ID: 1, JobID: 10, QuestionText: "Do you have a driving license?", QuestionType: job.QuestionTypeBoolean, IsKnockout: true, KnockoutValue: "true"},
+			{ID: 2, JobID: 10, QuestionText: "Expected salary?", QuestionType: job.QuestionTypeNumber, IsRequired: true},
+			{ID: 3, JobID: 10, QuestionText: "Preferred shift?", QuestionType: job.QuestionTypeSelect, Options: []string{"Morning", "Night"}},
+		},
+	}
+	userRepo := &fakes.UserRepository{User: helpers.VerifiedJobseeker()}
+
+	return helpers.NewApplicationService(appRepo, jobRepo, userRepo, &fakes.CompanyRepository{}), appRepo
+}
+
+func apply(svc application.ApplicationService, answers ...application.AnswerRequest) (*application.JobApplication, error) {
+	return svc.ApplyForJob(context.Background(), &application.ApplyJobRequest{JobID: 10, UserID: 7, Answers: answers})
+}
+
+func TestApplyForJob_StoresNormalizedAnswers(t *testing.T) {
+	svc, appRepo := newScreeningService()
+
+	app, err := apply(svc,
+		application.AnswerRequest{QuestionID: 1, Value: "TRUE"},
+		application.AnswerRequest{QuestionID: 2, Value: "7500000.00"},
+		application.AnswerRequest{QuestionID: 3, Value: "night"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "applied", app.Status)
+
+	require.Len(t, appRepo.Answers, 3)
+	assert.Equal(t, "true", appRepo.Answers[0].AnswerValue)
+	assert.Equal(t, "7500000", appRepo.Answers[1].AnswerValue)
+	assert.Equal(t, "Night", appRepo.Answers[2].AnswerValue)
+	assert.Equal(t, app.ID, appRepo.Answers[0].ApplicationID)
+}
+
+func TestApplyForJob_KnockoutAnswerRejectsApplication(t *testing.T) {
+	svc, appRepo := newScreeningService()
+
+	app, err := apply(svc,
+		application.AnswerRequest{QuestionID: 1, Value: "false"},
+		application.AnswerRequest{QuestionID: 2, Value: "5000000"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "rejected", app.Status)
+
+	require.NotEmpty(t, appRepo.Stages)
+	last := appRepo.Stages[len(appRepo.Stages)-1]
+	assert.Equal(t, "rejected", last.StageName)
+	assert.Equal(t, application.RejectionReasonKnockout, last.Notes)
+	assert.Len(t, appRepo.Answers, 2)
+}
+
+func TestApplyForJob_MissingRequiredAnswer(t *testing.T) {
+	svc, appRepo := newScreeningService()
+
+	_, err := apply(svc, application.AnswerRequest{QuestionID: 1, Value: "true"})
+
+	require.ErrorIs(t, err, application.ErrAnswerRequired)
+	var appErr *apperror.Error
+	require.ErrorAs(t, err, &appErr)
+	assert.Contains(t, appErr.Details, "answers.2")
+	assert.Zero(t, appRepo.Count())
+}
+
+func TestApplyForJob_RejectsAnswersOfWrongType(t *testing.T) {
+	cases := map[string]application.AnswerRequest{
+		"boolean":          {QuestionID: 1, Value: "maybe"},
+		"number":           {QuestionID: 2, Value: "a lot"},
+		"select":           {QuestionID: 3, Value: "Weekend"},
+		"unknown question": {QuestionID: 99, Value: "true"},
+	}
+
+	for name, bad := range cases {
+		t.Run(name, func(t *testing.T) {
+			svc, appRepo := newScreeningService()
+			answers := map[int64]application.AnswerRequest{
+				1: {QuestionID: 1, Value: "true"},
+				2: {QuestionID: 2, Value: "5000000"},
+			}
+			answers[bad.QuestionID] = bad
+
+			var req []application.AnswerRequest
+			for _, a := range answers {
+				req = append(req, a)
+			}
+			_, err := apply(svc, req...)
+
+			if bad.QuestionID == 99 {
+				assert.ErrorIs(t, err, application.ErrUnknownQuestion)
+			} else {
+				assert.ErrorIs(t, err, application.ErrInvalidAnswer)
+			}
+			assert.Zero(t, appRepo.Count())
+		})
+	}
+}
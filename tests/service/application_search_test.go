@@ -0,0 +1,65 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+func newSearchService() (application.ApplicationService, *fakes.SearchApplicationRepository) {
+	appRepo := &fakes.SearchApplicationRepository{}
+	companyRepo := helpers.NewCompanyMemberRepository("recruiter")
+	companyRepo.EmployerUsers = append(companyRepo.EmployerUsers, company.EmployerUser{ID: 51, UserID: 5, CompanyID: 4, Role: "recruiter", IsActive: true})
+	return helpers.NewApplicationService(appRepo, &fakes.JobRepository{}, &fakes.UserRepository{}, companyRepo), appRepo
+}
+
+// searchEmployer is user 5 acting for company 3
+var searchEmployer = &employer.EmployerContext{UserID: 5, EmployerUserID: 50, CompanyID: 3, Role: "recruiter"}
+
+func TestSearchApplications_DefaultsToActiveCompany(t *testing.T) {
+	svc, appRepo := newSearchService()
+
+	_, err := svc.SearchApplications(context.Background(), searchEmployer, application.ApplicationSearchFilter{MinDegreeLevel: "S1"}, 1, 20)
+	require.NoError(t, err)
+
+	// User 5 also belongs to company 4, whose applications are only searched when asked for
+	require.NotNil(t, appRepo.Filter)
+	assert.Equal(t, []int64{3}, appRepo.Filter.CompanyIDs)
+	assert.Equal(t, application.SkillMatchAll, appRepo.Filter.SkillMatch)
+}
+
+func TestSearchApplications_RejectsOtherCompanies(t *testing.T) {
+	svc, appRepo := newSearchService()
+
+	_, err := svc.SearchApplications(context.Background(), searchEmployer, application.ApplicationSearchFilter{CompanyIDs: []int64{3, 9}}, 1, 20)
+	assert.ErrorIs(t, err, application.ErrEmployerAccessDenied)
+	assert.Nil(t, appRepo.Filter)
+
+	_, err = svc.SearchApplications(context.Background(), nil, application.ApplicationSearchFilter{}, 1, 20)
+	assert.ErrorIs(t, err, application.ErrEmployerAccessDenied)
+	assert.Nil(t, appRepo.Filter)
+}
+
+func TestSearchApplications_ValidatesProfileFilters(t *testing.T) {
+	svc, appRepo := newSearchService()
+	negative := -1
+
+	for _, filter := range []application.ApplicationSearchFilter{
+		{SkillMatch: "most"},
+		{MinDegreeLevel: "PhD"},
+		{MinExperienceYears: &negative},
+		{AvailabilityStatuses: []string{"busy"}},
+	} {
+		_, err := svc.SearchApplications(context.Background(), searchEmployer, filter, 1, 20)
+		assert.ErrorIs(t, err, application.ErrInvalidSearchFilter)
+	}
+	assert.Nil(t, appRepo.Filter)
+}
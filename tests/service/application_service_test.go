@@ -0,0 +1,133 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+func TestApplyForJob_ConcurrentRequestsCreateSingleApplication(t *testing.T) {
+	expiry := time.Now().Add(24 * time.Hour)
+	appRepo := fakes.NewApplicationRepository()
+	jobRepo := &fakes.JobRepository{Job: &job.Job{ID: 10, CompanyID: 3, Title: "Backend Engineer", Status: "published", ExpiredAt: &expiry}}
+	userRepo := &fakes.UserRepository{User: helpers.VerifiedJobseeker()}
+
+	svc := helpers.NewApplicationService(appRepo, jobRepo, userRepo, &fakes.CompanyRepository{})
+
+	const workers = 20
+	var wg sync.WaitGroup
+	var succeeded, duplicates int64
+	start := make(chan struct{})
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, err := svc.ApplyForJob(context.Background(), &application.ApplyJobRequest{JobID: 10, UserID: 7})
+			switch {
+			case err == nil:
+				atomic.AddInt64(&succeeded, 1)
+			case errors.Is(err, application.ErrAlreadyApplied):
+				atomic.AddInt64(&duplicates, 1)
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	require.Equal(t, 1, appRepo.Count())
+	assert.Equal(t, int64(1), succeeded)
+	assert.Equal(t, int64(workers-1), duplicates)
+	assert.Equal(t, int64(1), atomic.LoadInt64(&jobRepo.Increments))
+}
+
+func TestScheduleInterview_RejectsPastTimeAndUnknownTimezone(t *testing.T) {
+	appRepo := fakes.NewApplicationRepository()
+	svc := helpers.NewApplicationService(appRepo, &fakes.JobRepository{}, &fakes.UserRepository{}, &fakes.CompanyRepository{})
+	interviewerID := int64(5)
+
+	_, err := svc.ScheduleInterview(context.Background(), &application.ScheduleInterviewRequest{
+		ApplicationID: 1,
+		InterviewerID: &interviewerID,
+		ScheduledAt:   time.Now().Add(-time.Hour),
+	})
+	assert.ErrorIs(t, err, application.ErrInterviewInPast)
+
+	_, err = svc.ScheduleInterview(context.Background(), &application.ScheduleInterviewRequest{
+		ApplicationID: 1,
+		InterviewerID: &interviewerID,
+		ScheduledAt:   time.Now().Add(24 * time.Hour),
+		Timezone:      "Mars/Olympus_Mons",
+	})
+	assert.ErrorIs(t, err, application.ErrInvalidTimezone)
+}
+
+type boardApplicationRepo struct {
+	application.ApplicationRepository
+
+	rows      []application.ApplicationBoardRow
+	perColumn int
+	sortBy    string
+}
+
+func (r *boardApplicationRepo) GetJobBoard(ctx context.Context, jobID int64, statuses []string, perColumn int, sortBy string) ([]application.ApplicationBoardRow, error) {
+	r.perColumn = perColumn
+	r.sortBy = sortBy
+	return r.rows, nil
+}
+
+var boardViewer = &employer.EmployerContext{UserID: 5, EmployerUserID: 50, CompanyID: 3, Role: "viewer"}
+
+func TestGetJobApplicationsBoard_ReturnsEveryColumnWithCounts(t *testing.T) {
+	appRepo := &boardApplicationRepo{rows: []application.ApplicationBoardRow{
+		{ApplicationSummary: application.ApplicationSummary{ID: 1, Status: "applied", AppliedAt: time.Now().Add(-72 * time.Hour)}, StatusCount: 12},
+		{ApplicationSummary: application.ApplicationSummary{ID: 2, Status: "applied"}, StatusCount: 12},
+		{ApplicationSummary: application.ApplicationSummary{ID: 3, Status: "interview"}, StatusCount: 1},
+	}}
+	jobRepo := &fakes.JobRepository{Job: &job.Job{ID: 10, CompanyID: 3}}
+	companyRepo := helpers.NewCompanyMemberRepository("viewer")
+	svc := helpers.NewApplicationService(appRepo, jobRepo, &fakes.UserRepository{}, companyRepo)
+
+	board, err := svc.GetJobApplicationsBoard(context.Background(), boardViewer, 10, 500, "match_score")
+	require.NoError(t, err)
+
+	assert.Equal(t, application.MaxBoardPerColumn, appRepo.perColumn)
+	assert.Equal(t, application.BoardSortMatchScore, appRepo.sortBy)
+	assert.Equal(t, application.ApplicationBoardStatuses, board.Statuses)
+	require.Len(t, board.Columns, len(application.ApplicationBoardStatuses))
+
+	assert.Equal(t, int64(12), board.Columns["applied"].Count)
+	require.Len(t, board.Columns["applied"].Applications, 2)
+	assert.Equal(t, 3, board.Columns["applied"].Applications[0].DaysSinceApplied)
+	assert.Equal(t, int64(1), board.Columns["interview"].Count)
+	assert.Equal(t, int64(0), board.Columns["hired"].Count)
+	assert.NotNil(t, board.Columns["hired"].Applications)
+	assert.Empty(t, board.Columns["hired"].Applications)
+}
+
+func TestGetJobApplicationsBoard_DeniesOtherCompanies(t *testing.T) {
+	jobRepo := &fakes.JobRepository{Job: &job.Job{ID: 10, CompanyID: 3}}
+	svc := helpers.NewApplicationService(&boardApplicationRepo{}, jobRepo, &fakes.UserRepository{}, &fakes.CompanyRepository{})
+
+	otherCompany := &employer.EmployerContext{UserID: 5, EmployerUserID: 51, CompanyID: 4, Role: "owner"}
+	_, err := svc.GetJobApplicationsBoard(context.Background(), otherCompany, 10, 10, "")
+	assert.ErrorIs(t, err, application.ErrEmployerAccessDenied)
+
+	_, err = svc.GetJobApplicationsBoard(context.Background(), nil, 10, 10, "")
+	assert.ErrorIs(t, err, application.ErrEmployerAccessDenied)
+}
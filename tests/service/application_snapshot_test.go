@@ -0,0 +1,313 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+const snapshotBaseURL = "https://files.keerja.test"
+
+// snapshotApplicationRepo adds application documents and resume snapshots to the fake applications
+type snapshotApplicationRepo struct {
+	*fakes.ApplicationRepository
+
+	docs      map[int64]*application.ApplicationDocument
+	nextDocID int64
+}
+
+func newSnapshotApplicationRepo() *snapshotApplicationRepo {
+	return &snapshotApplicationRepo{ApplicationRepository: fakes.NewApplicationRepository(), docs: make(map[int64]*application.ApplicationDocument)}
+}
+
+func (r *snapshotApplicationRepo) SetResumeSnapshot(ctx context.Context, applicationID int64, snapshotURL, sourceURL string) (bool, error) {
+	r.Lock()
+	defer r.Unlock()
+	app, ok := r.Apps[applicationID]
+	if !ok || app.ResumeSourceURL != nil {
+		return false, nil
+	}
+	app.ResumeURL = snapshotURL
+	app.ResumeSourceURL = &sourceURL
+	return true, nil
+}
+
+func (r *snapshotApplicationRepo) ListUnsnapshottedResumes(ctx context.Context, afterID int64, limit int) ([]application.JobApplication, error) {
+	r.Lock()
+	defer r.Unlock()
+	var apps []application.JobApplication
+	for _, app := range r.Apps {
+		if app.ID > afterID && app.ResumeURL != "" && app.ResumeSourceURL == nil {
+			apps = append(apps, *app)
+		}
+	}
+	sort.Slice(apps, func(i, j int) bool { return apps[i].ID < apps[j].ID })
+	if len(apps) > limit {
+		apps = apps[:limit]
+	}
+	return apps, nil
+}
+
+func (r *snapshotApplicationRepo) Delete(ctx context.Context, id int64) error {
+	r.Lock()
+	defer r.Unlock()
+	delete(r.Apps, id)
+	for docID, doc := range r.docs {
+		if doc.ApplicationID == id {
+			delete(r.docs, docID)
+		}
+	}
+	return nil
+}
+
+func (r *snapshotApplicationRepo) CreateDocument(ctx context.Context, doc *application.ApplicationDocument) error {
+	r.Lock()
+	defer r.Unlock()
+	r.nextDocID++
+	doc.ID = r.nextDocID
+	stored := *doc
+	r.docs[doc.ID] = &stored
+	return nil
+}
+
+func (r *snapshotApplicationRepo) UpdateDocument(ctx context.Context, doc *application.ApplicationDocument) error {
+	r.Lock()
+	defer r.Unlock()
+	stored := *doc
+	r.docs[doc.ID] = &stored
+	return nil
+}
+
+func (r *snapshotApplicationRepo) ListDocumentsByApplication(ctx context.Context, applicationID int64) ([]application.ApplicationDocument, error) {
+	r.Lock()
+	defer r.Unlock()
+	var docs []application.ApplicationDocument
+	for _, doc := range r.docs {
+		if doc.ApplicationID == applicationID {
+			docs = append(docs, *doc)
+		}
+	}
+	return docs, nil
+}
+
+func (r *snapshotApplicationRepo) ListUnsnapshottedDocuments(ctx context.Context, afterID int64, limit int) ([]application.ApplicationDocument, error) {
+	r.Lock()
+	defer r.Unlock()
+	var docs []application.ApplicationDocument
+	for _, doc := range r.docs {
+		if doc.ID > afterID && !doc.Uploaded && doc.SourceURL == nil {
+			docs = append(docs, *doc)
+		}
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].ID < docs[j].ID })
+	if len(docs) > limit {
+		docs = docs[:limit]
+	}
+	return docs, nil
+}
+
+// libraryUserRepo serves the applicants' document libraries
+type libraryUserRepo struct {
+	*fakes.UserRepository
+
+	library []user.UserDocument
+}
+
+func (r *libraryUserRepo) GetDocumentsByUserID(ctx context.Context, userID int64) ([]user.UserDocument, error) {
+	var docs []user.UserDocument
+	for _, doc := range r.library {
+		if doc.UserID == userID {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+type snapshotFixture struct {
+	svc     application.ApplicationService
+	appRepo *snapshotApplicationRepo
+	users   *libraryUserRepo
+	uploads service.UploadService
+	root    string
+}
+
+func newSnapshotFixture(t *testing.T) *snapshotFixture {
+	t.Helper()
+
+	root := t.TempDir()
+	uploads := service.NewUploadService(service.UploadServiceConfig{StorageProvider: "local", UploadPath: root, BaseURL: snapshotBaseURL})
+	expiry := time.Now().Add(24 * time.Hour)
+	appRepo := newSnapshotApplicationRepo()
+	users := &libraryUserRepo{UserRepository: &fakes.UserRepository{User: helpers.VerifiedJobseeker()}}
+	jobRepo := &fakes.JobRepository{Job: &job.Job{ID: 10, CompanyID: 3, Title: "Backend Engineer", Status: "published", ExpiredAt: &expiry}}
+
+	return &snapshotFixture{
+		svc:     service.NewApplicationService(appRepo, jobRepo, users, &fakes.CompanyRepository{}, nil, nil, nil, application.DefaultReapplyPolicy, uploads, true, nil, nil),
+		appRepo: appRepo,
+		users:   users,
+		uploads: uploads,
+		root:    root,
+	}
+}
+
+// libraryDocument stores a file in the user's document library and returns its URL
+func (f *snapshotFixture) libraryDocument(t *testing.T, userID int64, name, content string) string {
+	t.Helper()
+	f.users.library = append(f.users.library, user.UserDocument{UserID: userID, DocumentName: name, FileURL: f.storeFile(t, "documents/"+name, content)})
+	return f.users.library[len(f.users.library)-1].FileURL
+}
+
+// storeFile writes a file into the storage and returns its URL
+func (f *snapshotFixture) storeFile(t *testing.T, relativePath, content string) string {
+	t.Helper()
+	filePath := filepath.Join(f.root, filepath.FromSlash(relativePath))
+	require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0o755))
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0o644))
+	return snapshotBaseURL + "/" + relativePath
+}
+
+func (f *snapshotFixture) read(t *testing.T, fileURL string) (string, error) {
+	t.Helper()
+	file, err := f.uploads.OpenFile(context.Background(), fileURL)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	require.NoError(t, err)
+	return string(data), nil
+}
+
+func TestApplyForJob_SnapshotSurvivesDeletingTheLibraryDocument(t *testing.T) {
+	f := newSnapshotFixture(t)
+	ctx := context.Background()
+	resumeURL := f.libraryDocument(t, 7, "cv.pdf", "resume as submitted")
+	portfolioURL := f.libraryDocument(t, 7, "portfolio.pdf", "portfolio as submitted")
+
+	app, err := f.svc.ApplyForJob(ctx, &application.ApplyJobRequest{
+		JobID:     10,
+		UserID:    7,
+		ResumeURL: resumeURL,
+		Documents: []application.UploadDocumentRequest{{DocumentType: "portfolio", FileName: "Portfolio", FileURL: portfolioURL}},
+	})
+	require.NoError(t, err)
+
+	snapshotDir := snapshotBaseURL + "/applications/1/"
+	assert.True(t, strings.HasPrefix(app.ResumeURL, snapshotDir), "resume snapshot %s", app.ResumeURL)
+	require.NotNil(t, app.ResumeSourceURL)
+	assert.Equal(t, resumeURL, *app.ResumeSourceURL)
+
+	docs, err := f.appRepo.ListDocumentsByApplication(ctx, app.ID)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.True(t, strings.HasPrefix(docs[0].FileURL, snapshotDir), "document snapshot %s", docs[0].FileURL)
+	assert.True(t, docs[0].Uploaded, "the snapshot is stored by us and goes with the document")
+	require.NotNil(t, docs[0].SourceURL)
+	assert.Equal(t, portfolioURL, *docs[0].SourceURL)
+	assert.True(t, service.IsPrivateUpload(strings.TrimPrefix(docs[0].FileURL, snapshotBaseURL+"/")))
+
+	// The applicant deletes the library documents
+	require.NoError(t, f.uploads.DeleteFile(ctx, resumeURL))
+	require.NoError(t, f.uploads.DeleteFile(ctx, portfolioURL))
+
+	content, err := f.read(t, app.ResumeURL)
+	require.NoError(t, err)
+	assert.Equal(t, "resume as submitted", content)
+	content, err = f.read(t, docs[0].FileURL)
+	require.NoError(t, err)
+	assert.Equal(t, "portfolio as submitted", content)
+
+	// Deleting the application takes its snapshots along
+	require.NoError(t, f.svc.DeleteApplication(ctx, app.ID))
+	_, err = f.read(t, app.ResumeURL)
+	assert.ErrorIs(t, err, service.ErrStoredFileNotFound)
+	_, err = f.read(t, docs[0].FileURL)
+	assert.ErrorIs(t, err, service.ErrStoredFileNotFound)
+}
+
+func TestApplyForJob_OnlySnapshotsTheApplicantsLibrary(t *testing.T) {
+	f := newSnapshotFixture(t)
+	ctx := context.Background()
+	othersURL := f.libraryDocument(t, 8, "cv.pdf", "someone else's resume")
+	privateURL := f.storeFile(t, "company/documents/nib.pdf", "company legal document")
+
+	app, err := f.svc.ApplyForJob(ctx, &application.ApplyJobRequest{
+		JobID:     10,
+		UserID:    7,
+		ResumeURL: othersURL,
+		Documents: []application.UploadDocumentRequest{{DocumentType: "other", FileURL: privateURL}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, othersURL, app.ResumeURL, "files outside the applicant's library stay referenced")
+	assert.Nil(t, app.ResumeSourceURL)
+
+	docs, err := f.appRepo.ListDocumentsByApplication(ctx, app.ID)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, privateURL, docs[0].FileURL)
+	assert.False(t, docs[0].Uploaded)
+	assert.Nil(t, docs[0].SourceURL)
+
+	_, err = os.Stat(filepath.Join(f.root, "applications"))
+	assert.True(t, errors.Is(err, os.ErrNotExist), "nothing was copied")
+}
+
+func TestBackfillFileSnapshots_CopiesFilesThatStillExist(t *testing.T) {
+	f := newSnapshotFixture(t)
+	ctx := context.Background()
+	resumeURL := f.libraryDocument(t, 7, "cv.pdf", "older resume")
+	goneURL := f.libraryDocument(t, 7, "gone.pdf", "deleted from storage")
+	certificateURL := f.libraryDocument(t, 7, "certificate.pdf", "older certificate")
+	require.NoError(t, f.uploads.DeleteFile(ctx, goneURL))
+
+	// Applications submitted before snapshots reference the library directly
+	for jobID, resume := range map[int64]string{10: resumeURL, 11: goneURL, 12: "https://elsewhere.example.com/cv.pdf"} {
+		require.NoError(t, f.appRepo.Create(ctx, &application.JobApplication{JobID: jobID, UserID: 7, Status: application.StatusApplied, ResumeURL: resume}))
+	}
+	require.NoError(t, f.appRepo.CreateDocument(ctx, &application.ApplicationDocument{ApplicationID: 1, UserID: 7, DocumentType: "certificate", FileURL: certificateURL}))
+
+	result, err := f.svc.BackfillFileSnapshots(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, application.SnapshotBackfillResult{Resumes: 1, Documents: 1, Skipped: 2}, *result)
+
+	var snapshotted *application.JobApplication
+	for _, app := range f.appRepo.Apps {
+		if app.HasResumeSnapshot() {
+			snapshotted = app
+		} else {
+			assert.NotContains(t, app.ResumeURL, "/applications/")
+		}
+	}
+	require.NotNil(t, snapshotted)
+	assert.Equal(t, resumeURL, *snapshotted.ResumeSourceURL)
+
+	require.NoError(t, f.uploads.DeleteFile(ctx, resumeURL))
+	require.NoError(t, f.uploads.DeleteFile(ctx, certificateURL))
+	content, err := f.read(t, snapshotted.ResumeURL)
+	require.NoError(t, err)
+	assert.Equal(t, "older resume", content)
+	content, err = f.read(t, f.appRepo.docs[1].FileURL)
+	require.NoError(t, err)
+	assert.Equal(t, "older certificate", content)
+
+	result, err = f.svc.BackfillFileSnapshots(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, application.SnapshotBackfillResult{Skipped: 2}, *result, "snapshots are made once")
+}
@@ -0,0 +1,95 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// sourceStatsApplicationRepo serves source counts and keeps the filter it was asked for
+type sourceStatsApplicationRepo struct {
+	*fakes.ApplicationRepository
+
+	stats  []application.SourceStats
+	filter application.SourceStatsFilter
+}
+
+func (r *sourceStatsApplicationRepo) GetApplicationSourceStats(ctx context.Context, filter application.SourceStatsFilter) ([]application.SourceStats, error) {
+	r.filter = filter
+	return r.stats, nil
+}
+
+func newSourceAnalyticsService(stats []application.SourceStats) (application.ApplicationService, *sourceStatsApplicationRepo) {
+	expiry := time.Now().Add(24 * time.Hour)
+	appRepo := &sourceStatsApplicationRepo{ApplicationRepository: fakes.NewApplicationRepository(), stats: stats}
+	jobRepo := &fakes.JobRepository{Job: &job.Job{ID: 10, CompanyID: 3, Title: "Backend Engineer", Status: "published", ExpiredAt: &expiry}}
+	userRepo := &fakes.UserRepository{User: helpers.VerifiedJobseeker()}
+	return helpers.NewApplicationService(appRepo, jobRepo, userRepo, &fakes.CompanyRepository{}), appRepo
+}
+
+func TestSourceAnalytics_ComputesConversionRates(t *testing.T) {
+	svc, _ := newSourceAnalyticsService([]application.SourceStats{
+		{Source: "linkedin", Views: 200, Count: 30, HiredCount: 3},
+		{Source: application.SourceDirect, Views: 90, Count: 9, HiredCount: 0},
+		{Source: "newsletter", Views: 3, Count: 1, HiredCount: 1},
+		{Source: "referral", Views: 0, Count: 2, HiredCount: 1}, // Applied through a shared link without viewing
+		{Source: "jobstreet", Views: 40, Count: 0, HiredCount: 0},
+	})
+
+	stats, err := svc.GetApplicationSourceAnalytics(context.Background(), application.SourceStatsFilter{CompanyID: 3})
+	require.NoError(t, err)
+
+	rates := make(map[string][2]float64, len(stats))
+	for _, s := range stats {
+		rates[s.Source] = [2]float64{s.ViewConversionRate, s.ConversionRate}
+	}
+	assert.Equal(t, map[string][2]float64{
+		"linkedin":               {15, 10},
+		application.SourceDirect: {10, 0},
+		"newsletter":             {33.3, 100},
+		"referral":               {0, 50},
+		"jobstreet":              {0, 0},
+	}, rates)
+}
+
+func TestSourceAnalytics_ScopesToTheCompanysJob(t *testing.T) {
+	svc, repo := newSourceAnalyticsService(nil)
+	ctx := context.Background()
+	from, to := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	jobID := int64(10)
+	_, err := svc.GetApplicationSourceAnalytics(ctx, application.SourceStatsFilter{CompanyID: 3, JobID: &jobID, From: from, To: to})
+	require.NoError(t, err)
+	assert.Equal(t, application.SourceStatsFilter{CompanyID: 3, JobID: &jobID, From: from, To: to}, repo.filter)
+
+	_, err = svc.GetApplicationSourceAnalytics(ctx, application.SourceStatsFilter{CompanyID: 4, JobID: &jobID, From: from, To: to})
+	assert.ErrorIs(t, err, job.ErrJobNotFound, "jobs of other companies are not found")
+}
+
+func TestApplyForJob_StoresNormalizedAttribution(t *testing.T) {
+	svc, repo := newSourceAnalyticsService(nil)
+
+	app, err := svc.ApplyForJob(context.Background(), &application.ApplyJobRequest{
+		JobID:       10,
+		UserID:      7,
+		UTMSource:   " LinkedIn ",
+		UTMMedium:   "social",
+		UTMCampaign: "<script>",
+		Referrer:    "https://www.linkedin.com/feed/?trk=abc#top",
+	})
+	require.NoError(t, err)
+
+	stored := repo.Apps[app.ID]
+	assert.Equal(t, "linkedin", stored.UTMSource)
+	assert.Equal(t, "social", stored.UTMMedium)
+	assert.Empty(t, stored.UTMCampaign, "values that are not plain tokens are dropped")
+	assert.Equal(t, "https://www.linkedin.com/feed/", stored.Referrer)
+}
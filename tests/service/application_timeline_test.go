@@ -0,0 +1,154 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/i18n"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// timelineAppRepo holds one application with its stages, interviews and the company's
+// stage durations
+type timelineAppRepo struct {
+	application.ApplicationRepository
+
+	app        application.JobApplication
+	history    []application.JobApplicationStage
+	current    *application.JobApplicationStage
+	interviews []application.Interview
+	stageTimes []application.StageTimeStats
+}
+
+func (r *timelineAppRepo) FindByID(ctx context.Context, id int64) (*application.JobApplication, error) {
+	if id != r.app.ID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	app := r.app
+	return &app, nil
+}
+
+func (r *timelineAppRepo) GetStageHistory(ctx context.Context, applicationID int64) ([]application.JobApplicationStage, error) {
+	return r.history, nil
+}
+
+func (r *timelineAppRepo) GetCurrentStage(ctx context.Context, applicationID int64) (*application.JobApplicationStage, error) {
+	if r.current == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.current, nil
+}
+
+func (r *timelineAppRepo) ListInterviewsByApplication(ctx context.Context, applicationID int64) ([]application.Interview, error) {
+	return r.interviews, nil
+}
+
+func (r *timelineAppRepo) GetAverageTimePerStage(ctx context.Context, companyID int64) ([]application.StageTimeStats, error) {
+	return r.stageTimes, nil
+}
+
+func newTimelineService(repo *timelineAppRepo) application.ApplicationService {
+	return helpers.NewApplicationService(repo, &fakes.JobRepository{}, &fakes.UserRepository{}, &fakes.CompanyRepository{})
+}
+
+func newTimelineApp(status string) *timelineAppRepo {
+	companyID := int64(3)
+	appliedAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	return &timelineAppRepo{app: application.JobApplication{ID: 10, UserID: 7, CompanyID: &companyID, Status: status, AppliedAt: appliedAt}}
+}
+
+func TestGetCandidateTimeline_NeverLeaksHiringTeamData(t *testing.T) {
+	repo := newTimelineApp("interview")
+	handler := int64(55)
+	interviewer := int64(56)
+	score := 42.5
+	completedAt := repo.app.AppliedAt.Add(48 * time.Hour)
+	repo.history = []application.JobApplicationStage{
+		{ID: 1, StageName: "applied", StartedAt: repo.app.AppliedAt, CompletedAt: &completedAt, HandledBy: &handler, Description: "Auto-screened"},
+		{ID: 2, StageName: "screening", StartedAt: completedAt, CompletedAt: &completedAt, HandledBy: &handler,
+			Description: "Weak culture fit, keep as backup", Notes: "Asked for too much salary"},
+	}
+	repo.current = &application.JobApplicationStage{ID: 3, StageName: "interview", StartedAt: completedAt.Add(time.Hour), HandledBy: &handler, Notes: "Panel is skeptical"}
+	repo.interviews = []application.Interview{{
+		ID: 4, ScheduledAt: completedAt.Add(72 * time.Hour), InterviewType: "online", Status: "completed", InterviewerID: &interviewer,
+		OverallScore: &score, TechnicalScore: &score, Remarks: "Struggled with SQL", FeedbackSummary: "Not senior enough",
+	}}
+
+	timeline, err := newTimelineService(repo).GetCandidateTimeline(context.Background(), 10, 7)
+	require.NoError(t, err)
+
+	body, err := json.Marshal(timeline)
+	require.NoError(t, err)
+	for _, leak := range []string{
+		"Auto-screened", "culture fit", "salary", "skeptical", "Struggled", "senior enough", "42.5",
+		"handled_by", "interviewer", "notes", "remarks", "feedback", "score", "sentiment", "description",
+	} {
+		assert.NotContains(t, string(body), leak)
+	}
+
+	require.Len(t, timeline.Events, 4)
+	assert.Equal(t, application.TimelineEventSubmitted, timeline.Events[0].EventType)
+	assert.Equal(t, "Lamaran dikirim", timeline.Events[0].Label)
+	assert.Equal(t, "screening", timeline.Events[1].Stage)
+	assert.Equal(t, "Sedang Ditinjau", timeline.Events[1].Label)
+	assert.Equal(t, "interview", timeline.Events[2].Stage)
+	assert.Equal(t, application.TimelineEventInterview, timeline.Events[3].EventType)
+	assert.Equal(t, "Interview selesai", timeline.Events[3].Label)
+	assert.Equal(t, "Interview", timeline.StatusLabel)
+}
+
+func TestGetCandidateTimeline_NextStepUsesCompanyStageTimes(t *testing.T) {
+	repo := newTimelineApp("applied")
+	repo.stageTimes = []application.StageTimeStats{{StageName: "applied", AverageDays: 2.3, Count: 12}}
+	svc := newTimelineService(repo)
+
+	timeline, err := svc.GetCandidateTimeline(context.Background(), 10, 7)
+	require.NoError(t, err)
+	assert.Equal(t, "Perusahaan ini biasanya merespons dalam 3 hari", timeline.NextStep)
+	assert.Equal(t, "Dikirim", timeline.StatusLabel)
+
+	english := i18n.WithLocale(context.Background(), i18n.English)
+	timeline, err = svc.GetCandidateTimeline(english, 10, 7)
+	require.NoError(t, err)
+	assert.Equal(t, "This employer typically responds within 3 days", timeline.NextStep)
+	assert.Equal(t, "Applied", timeline.StatusLabel)
+
+	// Without history for the stage the hint is generic
+	repo.stageTimes = nil
+	timeline, err = svc.GetCandidateTimeline(english, 10, 7)
+	require.NoError(t, err)
+	assert.Equal(t, "The employer will review your application", timeline.NextStep)
+}
+
+func TestGetCandidateTimeline_NextStepNamesUpcomingInterview(t *testing.T) {
+	repo := newTimelineApp("interview")
+	upcoming := time.Now().Add(72 * time.Hour)
+	repo.interviews = []application.Interview{
+		{ID: 1, ScheduledAt: time.Now().Add(-24 * time.Hour), Status: "completed"},
+		{ID: 2, ScheduledAt: time.Now().Add(24 * time.Hour), Status: "cancelled"},
+		{ID: 3, ScheduledAt: upcoming, Status: "scheduled", Timezone: "UTC"},
+	}
+
+	english := i18n.WithLocale(context.Background(), i18n.English)
+	timeline, err := newTimelineService(repo).GetCandidateTimeline(english, 10, 7)
+	require.NoError(t, err)
+	assert.Equal(t, "Your interview is scheduled for "+i18n.FormatLongDate(i18n.English, upcoming.UTC()), timeline.NextStep)
+}
+
+func TestGetCandidateTimeline_OnlyForTheApplicant(t *testing.T) {
+	svc := newTimelineService(newTimelineApp("rejected"))
+
+	_, err := svc.GetCandidateTimeline(context.Background(), 10, 8)
+	assert.ErrorIs(t, err, application.ErrNotApplicationOwner)
+
+	_, err = svc.GetCandidateTimeline(context.Background(), 11, 7)
+	assert.ErrorIs(t, err, application.ErrApplicationNotFound)
+}
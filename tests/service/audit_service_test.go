@@ -0,0 +1,80 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/audit"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+func TestAuditRecord_AttributesActorFromContext(t *testing.T) {
+	repo := &fakes.AuditLogRepository{}
+	svc := service.NewAuditService(repo, 10)
+
+	ctx := audit.WithActor(context.Background(), audit.Actor{Type: audit.ActorEmployer, ID: 42, IP: "10.0.0.1"})
+	svc.Record(ctx, audit.AuditLog{Action: audit.ActionEmployerRemoved, EntityType: audit.EntityEmployerUser, EntityID: 7})
+
+	adminID := int64(3)
+	svc.Record(ctx, audit.AuditLog{ActorID: &adminID, ActorType: audit.ActorAdmin, Action: audit.ActionJobApproved, EntityType: audit.EntityJob, EntityID: 9})
+	svc.Record(context.Background(), audit.AuditLog{Action: audit.ActionCompanyDeleted, EntityType: audit.EntityCompany, EntityID: 1})
+	svc.Close()
+
+	logs := repo.Stored()
+	require.Len(t, logs, 3)
+
+	assert.Equal(t, audit.ActorEmployer, logs[0].ActorType)
+	assert.Equal(t, int64(42), *logs[0].ActorID)
+	assert.Equal(t, "10.0.0.1", *logs[0].IP)
+	assert.False(t, logs[0].CreatedAt.IsZero())
+
+	// An explicit actor wins over the context one, but the request IP is still kept
+	assert.Equal(t, audit.ActorAdmin, logs[1].ActorType)
+	assert.Equal(t, int64(3), *logs[1].ActorID)
+	assert.Equal(t, "10.0.0.1", *logs[1].IP)
+
+	assert.Equal(t, audit.ActorSystem, logs[2].ActorType)
+	assert.Nil(t, logs[2].ActorID)
+	assert.Nil(t, logs[2].IP)
+}
+
+func TestAuditRecord_NeverBlocksWhenWriterIsStuck(t *testing.T) {
+	repo := &fakes.AuditLogRepository{
+		Started: make(chan struct{}, 10),
+		Release: make(chan struct{}),
+		Err:     errors.New("database unavailable"),
+	}
+	svc := service.NewAuditService(repo, 2)
+
+	// The first entry occupies the writer, which then hangs
+	svc.Record(context.Background(), audit.AuditLog{Action: audit.ActionReviewHidden, EntityID: 1})
+	<-repo.Started
+
+	done := make(chan struct{})
+	go func() {
+		for i := int64(2); i <= 6; i++ {
+			svc.Record(context.Background(), audit.AuditLog{Action: audit.ActionReviewHidden, EntityID: i})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Record blocked while the audit writer was stuck")
+	}
+
+	// Two entries fit in the buffer, the rest were dropped
+	close(repo.Release)
+	svc.Close()
+	assert.Len(t, repo.Stored(), 3)
+
+	// Recording after shutdown is a no-op
+	svc.Record(context.Background(), audit.AuditLog{Action: audit.ActionReviewHidden, EntityID: 7})
+}
@@ -0,0 +1,134 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+type blindUserRepo struct {
+	user.UserRepository
+}
+
+func blindApplicant(id int64) user.User {
+	phone := "+628123456789"
+	return user.User{ID: id, FullName: "Sari Wulandari", Email: "sari@example.com", Phone: &phone}
+}
+
+func (r *blindUserRepo) FindByID(ctx context.Context, id int64) (*user.User, error) {
+	u := blindApplicant(id)
+	return &u, nil
+}
+
+func (r *blindUserRepo) FindByIDs(ctx context.Context, ids []int64) ([]user.User, error) {
+	users := make([]user.User, len(ids))
+	for i, id := range ids {
+		users[i] = blindApplicant(id)
+	}
+	return users, nil
+}
+
+// newBlindScreeningService serves one application of company 3 in the given status, viewed
+// by employer user 5 with the given role
+func newBlindScreeningService(enabled bool, role, status string) application.ApplicationService {
+	companyID := int64(3)
+	appRepo := fakes.NewApplicationRepository(application.JobApplication{
+		ID: 42, JobID: 10, UserID: 100, CompanyID: &companyID, Status: status, ViewedByEmployer: true, ResumeURL: "https://cdn.example.com/resume.pdf",
+	})
+	companyRepo := helpers.NewCompanyMemberRepository(role)
+	companyRepo.Settings = map[int64]*company.CompanySettings{companyID: {CompanyID: companyID, BlindScreeningEnabled: enabled}}
+	jobRepo := &fakes.JobRepository{Job: &job.Job{ID: 10, Title: "Backend Engineer"}}
+	return helpers.NewApplicationService(appRepo, jobRepo, &blindUserRepo{}, companyRepo)
+}
+
+func TestBlindScreening_RoleAndStageMatrix(t *testing.T) {
+	statuses := []string{"applied", "screening", "shortlisted", "interview", "offered", "hired", "rejected"}
+	roles := []string{"viewer", "recruiter", "admin", "owner"}
+
+	for _, role := range roles {
+		for _, status := range statuses {
+			redacted := (role == "viewer" || role == "recruiter") && (status == "applied" || status == "screening")
+
+			t.Run(role+"/"+status, func(t *testing.T) {
+				svc := newBlindScreeningService(true, role, status)
+
+				ec := &employer.EmployerContext{UserID: 5, EmployerUserID: 50, CompanyID: 3, Role: role}
+				list, err := svc.GetCompanyApplications(context.Background(), ec, application.ApplicationFilter{}, 1, 20)
+				require.NoError(t, err)
+				require.Len(t, list.Applications, 1)
+				summary := list.Applications[0]
+
+				detail, err := svc.GetApplicationForReview(context.Background(), ec, 42)
+				require.NoError(t, err)
+
+				export, err := svc.ExportApplications(context.Background(), ec, application.ApplicationFilter{})
+				require.NoError(t, err)
+				records, err := csv.NewReader(bytes.NewReader(export)).ReadAll()
+				require.NoError(t, err)
+				require.Len(t, records, 2)
+
+				if redacted {
+					label := summary.UserName
+					assert.Regexp(t, `^Candidate #[0-9A-F]{8}$`, label)
+					assert.Zero(t, summary.UserID)
+
+					assert.Equal(t, label, detail.Applicant.FullName)
+					assert.Zero(t, detail.Applicant.UserID)
+					assert.Zero(t, detail.Application.UserID)
+					assert.Empty(t, detail.Applicant.Email)
+					assert.Empty(t, detail.Applicant.Phone)
+					assert.Empty(t, detail.Applicant.PhotoURL)
+					assert.Equal(t, "https://cdn.example.com/resume.pdf", detail.Applicant.ResumeURL)
+
+					assert.Equal(t, []string{"", label}, records[1][3:5])
+					return
+				}
+
+				assert.Equal(t, "Sari Wulandari", summary.UserName)
+				assert.Equal(t, int64(100), summary.UserID)
+				assert.Equal(t, "Sari Wulandari", detail.Applicant.FullName)
+				assert.Equal(t, "sari@example.com", detail.Applicant.Email)
+				// The phone number is revealed from shortlisting on, whatever the role
+				if status == "shortlisted" || status == "interview" || status == "offered" || status == "hired" {
+					assert.Equal(t, "+628123456789", detail.Applicant.Phone)
+				} else {
+					assert.Empty(t, detail.Applicant.Phone)
+				}
+				assert.Equal(t, []string{"100", "Sari Wulandari"}, records[1][3:5])
+			})
+		}
+	}
+}
+
+func TestBlindScreening_DisabledShowsApplicants(t *testing.T) {
+	svc := newBlindScreeningService(false, "recruiter", "applied")
+
+	ec := &employer.EmployerContext{UserID: 5, EmployerUserID: 50, CompanyID: 3, Role: "recruiter"}
+	list, err := svc.GetCompanyApplications(context.Background(), ec, application.ApplicationFilter{}, 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, "Sari Wulandari", list.Applications[0].UserName)
+
+	detail, err := svc.GetApplicationForReview(context.Background(), ec, 42)
+	require.NoError(t, err)
+	assert.Equal(t, "sari@example.com", detail.Applicant.Email)
+}
+
+func TestBlindScreening_ApplicantsSeeTheirOwnApplications(t *testing.T) {
+	svc := newBlindScreeningService(true, "recruiter", "applied")
+
+	detail, err := svc.GetApplicationDetail(context.Background(), 42, 100)
+	require.NoError(t, err)
+	assert.Equal(t, "Sari Wulandari", detail.Applicant.FullName)
+}
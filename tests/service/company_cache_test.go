@@ -0,0 +1,81 @@
+package service_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/tests/helpers"
+)
+
+type countingCompanyRepo struct {
+	company.CompanyRepository
+
+	detailLoads   atomic.Int32
+	topRatedLoads atomic.Int32
+}
+
+func (r *countingCompanyRepo) GetFullCompanyProfile(ctx context.Context, companyID int64) (*company.Company, error) {
+	r.detailLoads.Add(1)
+	time.Sleep(20 * time.Millisecond)
+	return &company.Company{ID: companyID, CompanyName: "Acme"}, nil
+}
+
+func (r *countingCompanyRepo) GetTopRatedCompanies(ctx context.Context, limit int) ([]company.Company, error) {
+	r.topRatedLoads.Add(1)
+	return []company.Company{{ID: 1, CompanyName: "Acme"}}, nil
+}
+
+func TestGetCompany_PoisonedCacheFallsBackToDatabase(t *testing.T) {
+	repo := &countingCompanyRepo{}
+	svc, memCache := helpers.NewCachedCompanyService(t, repo)
+
+	memCache.Set("company:detail:1", "not a company", time.Minute)
+
+	comp, err := svc.GetCompany(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), comp.ID)
+	assert.Equal(t, int32(1), repo.detailLoads.Load())
+
+	// The bad entry was replaced, so the next read is a hit
+	_, err = svc.GetCompany(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), repo.detailLoads.Load())
+}
+
+func TestGetTopRatedCompanies_PoisonedCacheFallsBackToDatabase(t *testing.T) {
+	repo := &countingCompanyRepo{}
+	svc, memCache := helpers.NewCachedCompanyService(t, repo)
+
+	memCache.Set("companies:top-rated:5", map[string]interface{}{"id": 1}, time.Minute)
+
+	companies, err := svc.GetTopRatedCompanies(context.Background(), 5)
+	require.NoError(t, err)
+	assert.Len(t, companies, 1)
+	assert.Equal(t, int32(1), repo.topRatedLoads.Load())
+}
+
+func TestGetCompany_ConcurrentMissesHitDatabaseOnce(t *testing.T) {
+	repo := &countingCompanyRepo{}
+	svc, _ := helpers.NewCachedCompanyService(t, repo)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			comp, err := svc.GetCompany(context.Background(), 7)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(7), comp.ID)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), repo.detailLoads.Load())
+}
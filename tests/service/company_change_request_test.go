@@ -0,0 +1,107 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/master"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// changeRequestDistricts serves district 20 in city 30, province 40
+type changeRequestDistricts struct {
+	master.DistrictService
+}
+
+func (s changeRequestDistricts) GetByID(ctx context.Context, id int64) (*master.DistrictResponse, error) {
+	if id != 20 {
+		return nil, nil
+	}
+	province := &master.ProvinceResponse{ID: 40, IsActive: true}
+	city := &master.CityResponse{ID: 30, ProvinceID: 40, Province: province, IsActive: true}
+	return &master.DistrictResponse{ID: 20, CityID: 30, City: city, IsActive: true}, nil
+}
+
+func newChangeRequestService(t *testing.T) (company.CompanyService, *fakes.CompanyRepository, cache.Cache) {
+	t.Helper()
+
+	repo := &fakes.CompanyRepository{
+		Companies:      map[int64]*company.Company{7: {ID: 7, Slug: "acme"}},
+		ChangeRequests: map[int64]*company.CompanyChangeRequest{},
+	}
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	t.Cleanup(memCache.Stop)
+	svc := service.NewCompanyService(repo, nil, memCache, nil, fakes.IndustryService{}, nil, changeRequestDistricts{}, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	return svc, repo, memCache
+}
+
+func TestRequestCompanyChange_ValidatesAndAllowsOnePending(t *testing.T) {
+	svc, _, _ := newChangeRequestService(t)
+	ctx := context.Background()
+
+	_, err := svc.RequestCompanyChange(ctx, 7, 3, &company.ChangeRequestInput{Reason: "We moved offices"})
+	assert.ErrorIs(t, err, company.ErrChangeRequestEmpty)
+
+	_, err = svc.RequestCompanyChange(ctx, 7, 3, &company.ChangeRequestInput{IndustryID: utils.Int64Ptr(5), Reason: "Re-classified"})
+	assert.ErrorIs(t, err, company.ErrInvalidChangeRequestData)
+
+	_, err = svc.RequestCompanyChange(ctx, 8, 3, &company.ChangeRequestInput{IndustryID: utils.Int64Ptr(2), Reason: "Re-classified"})
+	assert.ErrorIs(t, err, company.ErrCompanyNotFound)
+
+	req, err := svc.RequestCompanyChange(ctx, 7, 3, &company.ChangeRequestInput{IndustryID: utils.Int64Ptr(2), Reason: "Re-classified"})
+	require.NoError(t, err)
+	assert.Equal(t, company.ChangeRequestPending, req.Status)
+
+	_, err = svc.RequestCompanyChange(ctx, 7, 3, &company.ChangeRequestInput{DistrictID: utils.Int64Ptr(20), Reason: "We moved offices"})
+	assert.ErrorIs(t, err, company.ErrChangeRequestPending)
+}
+
+func TestApproveChangeRequest_AppliesDistrictLocationAndClearsCaches(t *testing.T) {
+	svc, repo, memCache := newChangeRequestService(t)
+	ctx := context.Background()
+
+	req, err := svc.RequestCompanyChange(ctx, 7, 3, &company.ChangeRequestInput{IndustryID: utils.Int64Ptr(2), DistrictID: utils.Int64Ptr(20), Reason: "We moved offices"})
+	require.NoError(t, err)
+
+	memCache.Set(cache.GenerateCacheKey("company", "slug", "acme"), "stale", time.Minute)
+	memCache.Set(cache.GenerateCacheKey("company", "public", "acme"), "stale", time.Minute)
+
+	require.NoError(t, svc.ApproveChangeRequest(ctx, req.ID, 1, nil))
+
+	require.NotNil(t, repo.AppliedChange)
+	assert.Equal(t, int64(2), *repo.AppliedChange.IndustryID)
+	assert.Nil(t, repo.AppliedChange.CompanySizeID)
+	assert.Equal(t, int64(20), *repo.AppliedChange.DistrictID)
+	assert.Equal(t, int64(30), *repo.AppliedChange.CityID)
+	assert.Equal(t, int64(40), *repo.AppliedChange.ProvinceID)
+
+	_, found := memCache.Get(cache.GenerateCacheKey("company", "slug", "acme"))
+	assert.False(t, found)
+	_, found = memCache.Get(cache.GenerateCacheKey("company", "public", "acme"))
+	assert.False(t, found)
+
+	// A decided request cannot be decided again
+	assert.ErrorIs(t, svc.RejectChangeRequest(ctx, req.ID, 1, nil), company.ErrChangeRequestDecided)
+	assert.ErrorIs(t, svc.ApproveChangeRequest(ctx, 99, 1, nil), company.ErrChangeRequestNotFound)
+}
+
+func TestApproveChangeRequest_RevalidatesMasterData(t *testing.T) {
+	svc, repo, _ := newChangeRequestService(t)
+	ctx := context.Background()
+
+	// The industry was deactivated after the request was made
+	repo.ChangeRequests[1] = &company.CompanyChangeRequest{ID: 1, CompanyID: 7, IndustryID: utils.Int64Ptr(5), Status: company.ChangeRequestPending}
+
+	err := svc.ApproveChangeRequest(ctx, 1, 1, nil)
+	assert.ErrorIs(t, err, company.ErrInvalidChangeRequestData)
+	assert.Nil(t, repo.AppliedChange)
+	assert.True(t, repo.ChangeRequests[1].IsPending())
+}
@@ -0,0 +1,331 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// domainVerificationRepo stores domain verifications and finds auto-join companies like the
+// postgres repository does
+type domainVerificationRepo struct {
+	companies     *fakes.CompanyRepository
+	verifications map[int64]*company.DomainVerification
+}
+
+func (r *domainVerificationRepo) FindDomainVerification(ctx context.Context, companyID int64) (*company.DomainVerification, error) {
+	if v, ok := r.verifications[companyID]; ok {
+		copied := *v
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (r *domainVerificationRepo) SaveDomainVerification(ctx context.Context, verification *company.DomainVerification) error {
+	copied := *verification
+	r.verifications[verification.CompanyID] = &copied
+	return nil
+}
+
+func (r *domainVerificationRepo) FindAutoJoinCompanies(ctx context.Context, domain string) ([]company.Company, error) {
+	var companies []company.Company
+	for id := int64(1); id <= int64(len(r.companies.Companies)); id++ {
+		c := r.companies.Companies[id]
+		settings := r.companies.Settings[id]
+		if c != nil && settings != nil && settings.AutoJoinEnabled && r.verifications[id].IsVerifiedFor(domain) &&
+			c.EmailDomain != nil && company.NormalizeEmailDomain(*c.EmailDomain) == domain {
+			companies = append(companies, *c)
+		}
+	}
+	return companies, nil
+}
+
+func (r *domainVerificationRepo) ListJoinRequests(ctx context.Context, companyID int64) ([]company.JoinRequest, error) {
+	var requests []company.JoinRequest
+	for _, m := range r.companies.EmployerUsers {
+		if m.CompanyID == companyID && m.IsPendingJoin() {
+			requests = append(requests, company.JoinRequest{EmployerUserID: m.ID, UserID: m.UserID, Role: m.Role, RequestedAt: *m.JoinRequestedAt})
+		}
+	}
+	return requests, nil
+}
+
+type stubTXTResolver map[string][]string
+
+func (r stubTXTResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	records, ok := r[name]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return records, nil
+}
+
+type domainFixture struct {
+	svc       company.DomainService
+	companies *fakes.CompanyRepository
+	domains   *domainVerificationRepo
+	emails    *fakes.EmailService
+	resolver  stubTXTResolver
+}
+
+// newDomainFixture has Acme (company 1, acme.co.id) with admin user 1, Globex (company 2)
+// on gmail.com, and users 1 (admin@acme.co.id, verified), 2 (budi@acme.co.id, verified), 3 (unverified, acme.co.id) and
+// 4 (gmail.com, verified)
+func newDomainFixture(t *testing.T) *domainFixture {
+	t.Helper()
+
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	t.Cleanup(memCache.Stop)
+
+	now := time.Now()
+	companies := &fakes.CompanyRepository{
+		EmployerUsers: []company.EmployerUser{
+			{ID: 1, UserID: 1, CompanyID: 1, Role: "admin", IsActive: true},
+		},
+		Companies: map[int64]*company.Company{
+			1: {ID: 1, CompanyName: "Acme", Slug: "acme", EmailDomain: utils.StringPtr("@Acme.co.id")},
+			2: {ID: 2, CompanyName: "Globex", Slug: "globex", EmailDomain: utils.StringPtr("gmail.com")},
+		},
+		Settings: map[int64]*company.CompanySettings{},
+	}
+	users := &fakes.UserRepository{Users: map[int64]*user.User{
+		1: {ID: 1, Email: "admin@acme.co.id", FullName: "Admin", EmailVerifiedAt: &now},
+		2: {ID: 2, Email: "budi@acme.co.id", FullName: "Budi", EmailVerifiedAt: &now},
+		3: {ID: 3, Email: "sari@acme.co.id", FullName: "Sari"},
+		4: {ID: 4, Email: "rina@gmail.com", FullName: "Rina", EmailVerifiedAt: &now},
+	}}
+	f := &domainFixture{
+		companies: companies,
+		domains:   &domainVerificationRepo{companies: companies, verifications: map[int64]*company.DomainVerification{}},
+		emails:    &fakes.EmailService{},
+		resolver:  stubTXTResolver{},
+	}
+	f.svc = service.NewCompanyDomainService(companies, f.domains, users, f.emails, f.resolver, memCache)
+	return f
+}
+
+func TestDomainVerification_EmailCode(t *testing.T) {
+	f := newDomainFixture(t)
+	ctx := context.Background()
+
+	challenge, err := f.svc.StartDomainVerification(ctx, 1, company.DomainVerificationEmail)
+	require.NoError(t, err)
+	assert.Equal(t, "acme.co.id", challenge.Domain)
+	assert.Equal(t, "postmaster@acme.co.id", challenge.SentTo)
+	assert.Equal(t, "postmaster@acme.co.id", f.emails.Last().To)
+	require.Len(t, f.emails.LastCode(), 6)
+	assert.NotContains(t, f.domains.verifications[1].Token, f.emails.LastCode(), "only a hash of the code is stored")
+
+	// Wrong codes count towards the attempt limit
+	_, err = f.svc.VerifyDomain(ctx, 1, "000000x")
+	assert.ErrorIs(t, err, company.ErrInvalidDomainVerificationCode)
+	assert.Equal(t, 1, f.domains.verifications[1].Attempts)
+
+	v, err := f.svc.VerifyDomain(ctx, 1, f.emails.LastCode())
+	require.NoError(t, err)
+	assert.True(t, v.IsVerifiedFor("acme.co.id"))
+
+	_, err = f.svc.StartDomainVerification(ctx, 1, company.DomainVerificationEmail)
+	assert.ErrorIs(t, err, company.ErrDomainAlreadyVerified)
+
+	// A new email domain needs a new claim
+	f.companies.Companies[1].EmailDomain = utils.StringPtr("acme.com")
+	_, err = f.svc.VerifyDomain(ctx, 1, f.emails.LastCode())
+	assert.ErrorIs(t, err, company.ErrDomainVerificationNotFound)
+}
+
+func TestDomainVerification_EmailCodeAttemptsAndExpiry(t *testing.T) {
+	f := newDomainFixture(t)
+	ctx := context.Background()
+
+	_, err := f.svc.StartDomainVerification(ctx, 1, company.DomainVerificationEmail)
+	require.NoError(t, err)
+	for i := 0; i < company.DomainVerificationMaxAttempts; i++ {
+		_, err = f.svc.VerifyDomain(ctx, 1, "999999x")
+		assert.ErrorIs(t, err, company.ErrInvalidDomainVerificationCode)
+	}
+	// Even the right code is refused once the attempts are used up
+	_, err = f.svc.VerifyDomain(ctx, 1, f.emails.LastCode())
+	assert.ErrorIs(t, err, company.ErrDomainVerificationExpired)
+
+	// Starting again sends a fresh code
+	_, err = f.svc.StartDomainVerification(ctx, 1, company.DomainVerificationEmail)
+	require.NoError(t, err)
+	past := time.Now().Add(-time.Minute)
+	f.domains.verifications[1].ExpiresAt = &past
+	_, err = f.svc.VerifyDomain(ctx, 1, f.emails.LastCode())
+	assert.ErrorIs(t, err, company.ErrDomainVerificationExpired)
+}
+
+func TestDomainVerification_DNSRecord(t *testing.T) {
+	f := newDomainFixture(t)
+	ctx := context.Background()
+
+	challenge, err := f.svc.StartDomainVerification(ctx, 1, company.DomainVerificationDNS)
+	require.NoError(t, err)
+	assert.Equal(t, "acme.co.id", challenge.TXTRecordName)
+	assert.Contains(t, challenge.TXTRecordValue, company.DomainVerificationTXTPrefix)
+	assert.Empty(t, f.emails.Sent, "nothing is emailed for DNS verification")
+
+	// Not published yet, then published next to other records
+	_, err = f.svc.VerifyDomain(ctx, 1, "")
+	assert.ErrorIs(t, err, company.ErrDomainTXTRecordNotFound)
+	f.resolver["acme.co.id"] = []string{"v=spf1 -all", company.DomainVerificationTXTPrefix + "someone-else"}
+	_, err = f.svc.VerifyDomain(ctx, 1, "")
+	assert.ErrorIs(t, err, company.ErrDomainTXTRecordNotFound)
+
+	f.resolver["acme.co.id"] = append(f.resolver["acme.co.id"], challenge.TXTRecordValue)
+	v, err := f.svc.VerifyDomain(ctx, 1, "")
+	require.NoError(t, err)
+	assert.NotNil(t, v.VerifiedAt)
+}
+
+func TestDomainVerification_RejectsFreeEmailProviders(t *testing.T) {
+	f := newDomainFixture(t)
+	ctx := context.Background()
+
+	_, err := f.svc.StartDomainVerification(ctx, 2, company.DomainVerificationDNS)
+	assert.ErrorIs(t, err, company.ErrFreeEmailDomain)
+	_, err = f.svc.StartDomainVerification(ctx, 2, company.DomainVerificationEmail)
+	assert.ErrorIs(t, err, company.ErrFreeEmailDomain)
+	assert.Empty(t, f.emails.Sent)
+
+	f.companies.Companies[2].EmailDomain = nil
+	_, err = f.svc.StartDomainVerification(ctx, 2, company.DomainVerificationEmail)
+	assert.ErrorIs(t, err, company.ErrEmailDomainNotSet)
+
+	_, err = f.svc.StartDomainVerification(ctx, 1, "sms")
+	assert.ErrorIs(t, err, company.ErrInvalidDomainVerificationMethod)
+
+	// Users on a public provider never get suggestions nor join by domain
+	suggestions, err := f.svc.GetCompanySuggestions(ctx, 4)
+	require.NoError(t, err)
+	assert.Empty(t, suggestions)
+	_, err = f.svc.RequestToJoin(ctx, 2, 4)
+	assert.ErrorIs(t, err, company.ErrAutoJoinUnavailable)
+}
+
+// enableAutoJoin verifies Acme's domain and turns auto-join on
+func enableAutoJoin(t *testing.T, f *domainFixture) {
+	t.Helper()
+	now := time.Now()
+	f.domains.verifications[1] = &company.DomainVerification{CompanyID: 1, Domain: "acme.co.id", Method: company.DomainVerificationDNS, VerifiedAt: &now}
+	settings := company.DefaultCompanySettings(1)
+	settings.AutoJoinEnabled = true
+	f.companies.Settings[1] = settings
+}
+
+func TestJoinRequest_NeedsVerifiedDomainAndAutoJoin(t *testing.T) {
+	f := newDomainFixture(t)
+	ctx := context.Background()
+
+	// Without a verified domain and auto-join, Acme is neither suggested nor joinable
+	suggestions, err := f.svc.GetCompanySuggestions(ctx, 2)
+	require.NoError(t, err)
+	assert.Empty(t, suggestions)
+	_, err = f.svc.RequestToJoin(ctx, 1, 2)
+	assert.ErrorIs(t, err, company.ErrAutoJoinUnavailable)
+
+	enableAutoJoin(t, f)
+
+	// The user's own email must be verified
+	_, err = f.svc.GetCompanySuggestions(ctx, 3)
+	assert.ErrorIs(t, err, company.ErrEmailNotVerified)
+	_, err = f.svc.RequestToJoin(ctx, 1, 3)
+	assert.ErrorIs(t, err, company.ErrEmailNotVerified)
+
+	suggestions, err = f.svc.GetCompanySuggestions(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, company.CompanySuggestion{CompanyID: 1, CompanyName: "Acme", Slug: "acme", Domain: "acme.co.id", Role: company.DefaultAutoJoinRole}, suggestions[0])
+
+	// Existing members are not offered their own company
+	suggestions, err = f.svc.GetCompanySuggestions(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, suggestions)
+}
+
+func TestJoinRequest_PendingUntilApproved(t *testing.T) {
+	f := newDomainFixture(t)
+	ctx := context.Background()
+	enableAutoJoin(t, f)
+	companySvc := service.NewCompanyService(f.companies, nil, cache.NewInMemoryCache(100, time.Minute), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	eu, err := f.svc.RequestToJoin(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "viewer", eu.Role)
+	assert.False(t, eu.IsActive)
+	assert.True(t, eu.IsPendingJoin())
+
+	_, err = f.svc.RequestToJoin(ctx, 1, 2)
+	assert.ErrorIs(t, err, company.ErrJoinRequestPending)
+
+	// The pending request grants no access yet, and shows up for the admins
+	_, err = companySvc.GetEmployerUser(ctx, 2, 1)
+	assert.ErrorIs(t, err, company.ErrNotCompanyEmployer)
+	requests, err := f.svc.ListJoinRequests(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	assert.Equal(t, eu.ID, requests[0].EmployerUserID)
+
+	suggestions, err := f.svc.GetCompanySuggestions(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, suggestions, 1)
+	assert.True(t, suggestions[0].JoinRequested)
+
+	// Requests of another company cannot be approved through this one
+	_, err = f.svc.ApproveJoinRequest(ctx, 2, eu.ID, 1)
+	assert.ErrorIs(t, err, company.ErrJoinRequestNotFound)
+
+	approved, err := f.svc.ApproveJoinRequest(ctx, 1, eu.ID, 1)
+	require.NoError(t, err)
+	assert.True(t, approved.IsActive)
+	assert.False(t, approved.IsPendingJoin())
+	assert.Equal(t, int64(1), *approved.VerifiedBy)
+
+	member, err := companySvc.GetEmployerUser(ctx, 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "viewer", member.Role)
+
+	// Approved members are neither pending nor suggested any more
+	_, err = f.svc.ApproveJoinRequest(ctx, 1, eu.ID, 1)
+	assert.ErrorIs(t, err, company.ErrJoinRequestNotFound)
+	_, err = f.svc.RequestToJoin(ctx, 1, 2)
+	assert.ErrorIs(t, err, company.ErrAlreadyCompanyEmployer)
+	suggestions, err = f.svc.GetCompanySuggestions(ctx, 2)
+	require.NoError(t, err)
+	assert.Empty(t, suggestions)
+}
+
+func TestJoinRequest_RejectLetsTheUserAskAgain(t *testing.T) {
+	f := newDomainFixture(t)
+	ctx := context.Background()
+	enableAutoJoin(t, f)
+	f.companies.Settings[1].AutoJoinRole = "recruiter"
+
+	eu, err := f.svc.RequestToJoin(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "recruiter", eu.Role, "the company's auto-join role is used")
+
+	// Active members cannot be removed through the join request endpoints
+	assert.ErrorIs(t, f.svc.RejectJoinRequest(ctx, 1, 1), company.ErrJoinRequestNotFound)
+
+	require.NoError(t, f.svc.RejectJoinRequest(ctx, 1, eu.ID))
+	requests, err := f.svc.ListJoinRequests(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, requests)
+
+	_, err = f.svc.RequestToJoin(ctx, 1, 2)
+	require.NoError(t, err)
+}
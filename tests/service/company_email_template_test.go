@@ -0,0 +1,286 @@
+package service_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/i18n"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// memoryCompanyTemplateRepo keeps company email templates in memory, one per company and event
+type memoryCompanyTemplateRepo struct {
+	email.CompanyTemplateRepository
+	templates map[int64]*email.CompanyEmailTemplate
+	nextID    int64
+}
+
+func newMemoryCompanyTemplateRepo() *memoryCompanyTemplateRepo {
+	return &memoryCompanyTemplateRepo{templates: map[int64]*email.CompanyEmailTemplate{}}
+}
+
+func (r *memoryCompanyTemplateRepo) Create(ctx context.Context, t *email.CompanyEmailTemplate) error {
+	if existing, _ := r.FindByCompanyAndEvent(ctx, t.CompanyID, t.EventType); existing != nil {
+		return email.ErrCompanyTemplateExists
+	}
+	r.nextID++
+	t.ID = r.nextID
+	r.templates[t.ID] = t
+	return nil
+}
+
+func (r *memoryCompanyTemplateRepo) FindByID(ctx context.Context, id int64) (*email.CompanyEmailTemplate, error) {
+	return r.templates[id], nil
+}
+
+func (r *memoryCompanyTemplateRepo) FindByCompanyAndEvent(ctx context.Context, companyID int64, event string) (*email.CompanyEmailTemplate, error) {
+	for _, t := range r.templates {
+		if t.CompanyID == companyID && t.EventType == event {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryCompanyTemplateRepo) ListByCompany(ctx context.Context, companyID int64) ([]email.CompanyEmailTemplate, error) {
+	var templates []email.CompanyEmailTemplate
+	for _, t := range r.templates {
+		if t.CompanyID == companyID {
+			templates = append(templates, *t)
+		}
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].EventType < templates[j].EventType })
+	return templates, nil
+}
+
+func (r *memoryCompanyTemplateRepo) Update(ctx context.Context, t *email.CompanyEmailTemplate) error {
+	r.templates[t.ID] = t
+	return nil
+}
+
+func (r *memoryCompanyTemplateRepo) Delete(ctx context.Context, id int64) error {
+	delete(r.templates, id)
+	return nil
+}
+
+// templateRecordingTransport records which candidate emails are sent
+type templateRecordingTransport struct {
+	email.EmailService
+	statusUpdates []string
+	custom        []email.RenderedEmail
+	invitations   []email.InterviewEmailData
+}
+
+func (t *templateRecordingTransport) SendJobStatusUpdateEmail(ctx context.Context, to, jobTitle, status string) error {
+	t.statusUpdates = append(t.statusUpdates, status)
+	return nil
+}
+
+func (t *templateRecordingTransport) SendCompanyTemplateEmail(ctx context.Context, to string, rendered email.RenderedEmail) error {
+	t.custom = append(t.custom, rendered)
+	return nil
+}
+
+func (t *templateRecordingTransport) SendInterviewInvitationEmail(ctx context.Context, to string, interview email.InterviewEmailData) error {
+	t.invitations = append(t.invitations, interview)
+	return nil
+}
+
+// interviewApplicationRepo adds a single interview to the fake application repository
+type interviewApplicationRepo struct {
+	*fakes.ApplicationRepository
+	interview *application.Interview
+}
+
+func (r *interviewApplicationRepo) FindInterviewByID(ctx context.Context, id int64) (*application.Interview, error) {
+	return r.interview, nil
+}
+
+func TestCompileCompanyTemplate_SubstitutesAndEscapesVariables(t *testing.T) {
+	compiled, err := email.CompileCompanyTemplate(email.CompanyTemplateApplicationRejected,
+		"Your application for {{ job_title }}",
+		"<p>Dear {{candidate_name}},</p><p>{{company_name}} has decided: {{application_status}}.</p>")
+	require.NoError(t, err)
+
+	rendered, err := compiled.Render(map[string]string{
+		email.TemplateVarCandidateName:     "<script>alert(1)</script>",
+		email.TemplateVarJobTitle:          "Backend & Data\nEngineer",
+		email.TemplateVarCompanyName:       "Acme",
+		email.TemplateVarApplicationStatus: "Rejected",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Your application for Backend & Data Engineer", rendered.Subject, "the subject is plain text on one line")
+	assert.Contains(t, rendered.Body, "<p>Dear &lt;script&gt;alert(1)&lt;/script&gt;,</p>")
+	assert.Contains(t, rendered.Body, "<p>Acme has decided: Rejected.</p>")
+	assert.NotContains(t, rendered.Body, "<script>")
+}
+
+func TestCompileCompanyTemplate_RejectsAnythingButKnownVariables(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   string
+		subject string
+		body    string
+		want    error
+	}{
+		{"unknown event", "hired", "Hi", "Hi", email.ErrInvalidTemplateEvent},
+		{"unknown variable", email.CompanyTemplateOfferMade, "Hi", "Salary: {{salary}}", email.ErrUnknownTemplateVariable},
+		{"interview variable outside interviews", email.CompanyTemplateApplicationRejected, "Hi", "{{interview_date}}", email.ErrUnknownTemplateVariable},
+		{"template function", email.CompanyTemplateOfferMade, `{{printf "%s" .}}`, "Hi", email.ErrUnknownTemplateVariable},
+		{"field lookup", email.CompanyTemplateOfferMade, "Hi", "{{.candidate_name}}", email.ErrUnknownTemplateVariable},
+		{"unclosed placeholder", email.CompanyTemplateOfferMade, "Hi", "Dear {{candidate_name", email.ErrInvalidTemplateSyntax},
+		{"nested braces", email.CompanyTemplateOfferMade, "Hi", "{{ {{candidate_name}} }}", email.ErrInvalidTemplateSyntax},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := email.CompileCompanyTemplate(tt.event, tt.subject, tt.body)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+
+	_, err := email.CompileCompanyTemplate(email.CompanyTemplateInterviewScheduled, "Interview on {{interview_date}}", "{{interview_link}}")
+	assert.NoError(t, err)
+}
+
+func TestCompanyTemplateService_ManagesTemplatesPerCompany(t *testing.T) {
+	repo := newMemoryCompanyTemplateRepo()
+	svc := service.NewCompanyTemplateService(repo, &fakes.CompanyRepository{})
+	ctx := context.Background()
+
+	created, err := svc.CreateTemplate(ctx, 3, 9, email.CompanyTemplateInput{
+		EventType: email.CompanyTemplateApplicationShortlisted,
+		Subject:   "  Good news from {{company_name}}  ",
+		Body:      "<p>Hi {{candidate_name}}</p>",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Good news from {{company_name}}", created.Subject)
+	assert.Equal(t, int64(9), *created.UpdatedBy)
+
+	_, err = svc.CreateTemplate(ctx, 3, 9, email.CompanyTemplateInput{EventType: email.CompanyTemplateApplicationShortlisted, Subject: "Again", Body: "Again"})
+	assert.ErrorIs(t, err, email.ErrCompanyTemplateExists)
+
+	_, err = svc.CreateTemplate(ctx, 3, 9, email.CompanyTemplateInput{EventType: email.CompanyTemplateApplicationRejected, Subject: "Hi", Body: "{{interview_link}}"})
+	assert.ErrorIs(t, err, email.ErrUnknownTemplateVariable)
+
+	_, err = svc.UpdateTemplate(ctx, 4, created.ID, 9, email.CompanyTemplateInput{Subject: "Hi", Body: "Hi"})
+	assert.ErrorIs(t, err, email.ErrCompanyTemplateNotFound, "another company's template is not found")
+	assert.ErrorIs(t, svc.DeleteTemplate(ctx, 4, created.ID), email.ErrCompanyTemplateNotFound)
+
+	updated, err := svc.UpdateTemplate(ctx, 3, created.ID, 11, email.CompanyTemplateInput{EventType: email.CompanyTemplateOfferMade, Subject: "Update for {{job_title}}", Body: "<p>{{application_status}}</p>"})
+	require.NoError(t, err)
+	assert.Equal(t, email.CompanyTemplateApplicationShortlisted, updated.EventType, "the event of a template does not change")
+	assert.Equal(t, int64(11), *updated.UpdatedBy)
+
+	preview, err := svc.PreviewTemplate(ctx, 3, email.CompanyTemplateInput{EventType: email.CompanyTemplateApplicationShortlisted, Subject: "News from {{company_name}}", Body: "<p>{{candidate_name}}</p>"})
+	require.NoError(t, err)
+	assert.Equal(t, "News from Acme", preview.Subject, "previews use the company's own name")
+	assert.Contains(t, preview.Body, "<p>Budi Santoso</p>")
+
+	require.NoError(t, svc.DeleteTemplate(ctx, 3, created.ID))
+	templates, err := svc.ListTemplates(ctx, 3)
+	require.NoError(t, err)
+	assert.Empty(t, templates)
+}
+
+func TestNotifyStatusUpdate_UsesCompanyTemplateOrFallsBack(t *testing.T) {
+	setup := func() (*fakes.ApplicationRepository, *fakes.JobRepository, *fakes.UserRepository) {
+		appRepo := fakes.NewApplicationRepository()
+		appRepo.Apps[1] = &application.JobApplication{ID: 1, JobID: 10, UserID: 7, Status: "screening"}
+		jobRepo := &fakes.JobRepository{Job: &job.Job{ID: 10, CompanyID: 3, Title: "Backend Engineer", Status: "published", ExpiredAt: utils.TimePtr(time.Now().Add(time.Hour))}}
+		userRepo := &fakes.UserRepository{User: &user.User{ID: 7, FullName: "Siti", Email: "seeker@example.com"}}
+		return appRepo, jobRepo, userRepo
+	}
+
+	templates := newMemoryCompanyTemplateRepo()
+	renderer := service.NewCompanyTemplateService(templates, &fakes.CompanyRepository{})
+	_, err := renderer.CreateTemplate(context.Background(), 3, 9, email.CompanyTemplateInput{
+		EventType: email.CompanyTemplateApplicationRejected,
+		Subject:   "{{job_title}} at {{company_name}}",
+		Body:      "<p>Dear {{candidate_name}}, your application is {{application_status}}.</p>",
+	})
+	require.NoError(t, err)
+
+	t.Run("company template", func(t *testing.T) {
+		appRepo, jobRepo, userRepo := setup()
+		transport := &templateRecordingTransport{}
+		svc := service.NewApplicationService(appRepo, jobRepo, userRepo, &fakes.CompanyRepository{}, transport, nil, nil, application.DefaultReapplyPolicy, nil, true, nil, renderer)
+
+		ctx := i18n.WithLocale(context.Background(), i18n.English)
+		require.NoError(t, svc.NotifyStatusUpdate(ctx, 1, "rejected"))
+		require.Len(t, transport.custom, 1)
+		assert.Empty(t, transport.statusUpdates)
+		assert.Equal(t, "Backend Engineer at Acme", transport.custom[0].Subject)
+		assert.Contains(t, transport.custom[0].Body, "Dear Siti, your application is Rejected.")
+	})
+
+	t.Run("no template for the status", func(t *testing.T) {
+		appRepo, jobRepo, userRepo := setup()
+		transport := &templateRecordingTransport{}
+		svc := service.NewApplicationService(appRepo, jobRepo, userRepo, &fakes.CompanyRepository{}, transport, nil, nil, application.DefaultReapplyPolicy, nil, true, nil, renderer)
+
+		require.NoError(t, svc.NotifyStatusUpdate(context.Background(), 1, "shortlisted"))
+		require.NoError(t, svc.NotifyStatusUpdate(context.Background(), 1, "hired"))
+		assert.Empty(t, transport.custom)
+		assert.Equal(t, []string{"shortlisted", "hired"}, transport.statusUpdates)
+	})
+
+	t.Run("no template renderer", func(t *testing.T) {
+		appRepo, jobRepo, userRepo := setup()
+		transport := &templateRecordingTransport{}
+		svc := service.NewApplicationService(appRepo, jobRepo, userRepo, &fakes.CompanyRepository{}, transport, nil, nil, application.DefaultReapplyPolicy, nil, true, nil, nil)
+
+		require.NoError(t, svc.NotifyStatusUpdate(context.Background(), 1, "rejected"))
+		assert.Empty(t, transport.custom)
+		assert.Equal(t, []string{"rejected"}, transport.statusUpdates)
+	})
+}
+
+func TestNotifyInterviewScheduled_UsesCompanyTemplate(t *testing.T) {
+	appRepo := &interviewApplicationRepo{
+		ApplicationRepository: fakes.NewApplicationRepository(),
+		interview: &application.Interview{
+			ID:            5,
+			ApplicationID: 1,
+			ScheduledAt:   time.Date(2026, 3, 2, 3, 0, 0, 0, time.UTC),
+			Timezone:      "Asia/Jakarta",
+			InterviewType: "online",
+			MeetingLink:   "https://meet.example.com/abc",
+			Status:        "scheduled",
+		},
+	}
+	appRepo.Apps[1] = &application.JobApplication{ID: 1, JobID: 10, UserID: 7, Status: "interview"}
+	jobRepo := &fakes.JobRepository{Job: &job.Job{ID: 10, CompanyID: 3, Title: "Backend Engineer", Status: "published", ExpiredAt: utils.TimePtr(time.Now().Add(time.Hour))}}
+	userRepo := &fakes.UserRepository{User: &user.User{ID: 7, FullName: "Siti", Email: "seeker@example.com"}}
+
+	templates := newMemoryCompanyTemplateRepo()
+	renderer := service.NewCompanyTemplateService(templates, &fakes.CompanyRepository{})
+	_, err := renderer.CreateTemplate(context.Background(), 3, 9, email.CompanyTemplateInput{
+		EventType: email.CompanyTemplateInterviewScheduled,
+		Subject:   "Interview for {{job_title}}",
+		Body:      `<p>{{interview_time}} via <a href="{{interview_link}}">{{interview_type}}</a></p>`,
+	})
+	require.NoError(t, err)
+
+	transport := &templateRecordingTransport{}
+	svc := service.NewApplicationService(appRepo, jobRepo, userRepo, &fakes.CompanyRepository{}, transport, nil, nil, application.DefaultReapplyPolicy, nil, true, nil, renderer)
+
+	require.NoError(t, svc.NotifyInterviewScheduled(context.Background(), 5))
+	require.Len(t, transport.invitations, 1)
+	custom := transport.invitations[0].Custom
+	require.NotNil(t, custom, "the invitation carries the company's version, the calendar invite is still attached")
+	assert.Equal(t, "Interview for Backend Engineer", custom.Subject)
+	assert.Contains(t, custom.Body, `<p>10:00 WIB (Asia/Jakarta) via <a href="https://meet.example.com/abc">online</a></p>`)
+}
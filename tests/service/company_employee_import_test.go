@@ -0,0 +1,150 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/helpers"
+)
+
+// importCompanyRepo holds a company's employees and records the batches an import saves
+type importCompanyRepo struct {
+	company.CompanyRepository
+
+	existing []company.CompanyEmployee
+	batches  [][]company.CompanyEmployee
+	nextID   int64
+}
+
+func (r *importCompanyRepo) GetEmployeesByCompanyID(ctx context.Context, companyID int64, includeInactive bool) ([]company.CompanyEmployee, error) {
+	return r.existing, nil
+}
+
+func (r *importCompanyRepo) AddEmployees(ctx context.Context, employees []company.CompanyEmployee) error {
+	for i := range employees {
+		r.nextID++
+		employees[i].ID = r.nextID
+	}
+	r.batches = append(r.batches, employees)
+	return nil
+}
+
+func newEmployeeImportService(t *testing.T) (company.CompanyService, *importCompanyRepo) {
+	t.Helper()
+
+	existingName, legacyName := "Existing Person", "legacy  name"
+	existingEmail := "existing@acme.test"
+	repo := &importCompanyRepo{
+		existing: []company.CompanyEmployee{
+			{ID: 1, CompanyID: 3, FullName: &existingName, Email: &existingEmail},
+			{ID: 2, CompanyID: 3, FullName: &legacyName},
+		},
+		nextID: 100,
+	}
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	t.Cleanup(memCache.Stop)
+
+	return service.NewCompanyService(repo, nil, memCache, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil), repo
+}
+
+func employeeImportFixture(t *testing.T) []byte {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("..", "testdata", "employees_import.csv"))
+	require.NoError(t, err)
+	return content
+}
+
+func importRowsByStatus(report *company.EmployeeImportReport) map[string][]int {
+	rows := map[string][]int{}
+	for _, row := range report.Rows {
+		rows[row.Status] = append(rows[row.Status], row.Row)
+	}
+	return rows
+}
+
+func TestImportEmployees_ReportsEachRowAndSavesValidOnes(t *testing.T) {
+	svc, repo := newEmployeeImportService(t)
+	file := helpers.NewFileHeader(t, "employees.csv", "text/csv", employeeImportFixture(t))
+
+	report, err := svc.ImportEmployees(context.Background(), 3, 9, file, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, 11, report.TotalRows)
+	assert.Equal(t, 3, report.Created)
+	assert.Equal(t, 3, report.Skipped)
+	assert.Equal(t, 5, report.Failed)
+	assert.Equal(t, map[string][]int{
+		company.ImportRowCreated: {2, 3, 11},
+		company.ImportRowSkipped: {4, 8, 10},
+		company.ImportRowFailed:  {5, 6, 7, 9, 12},
+	}, importRowsByStatus(report))
+
+	reasons := map[int]string{}
+	for _, row := range report.Rows {
+		reasons[row.Row] = row.Reason
+	}
+	assert.Equal(t, "an employee with this email already exists", reasons[4])
+	assert.Equal(t, "full_name is required", reasons[5])
+	assert.Contains(t, reasons[6], "employment_type must be one of")
+	assert.Contains(t, reasons[7], "join_date")
+	assert.Equal(t, "same email as row 2", reasons[8])
+	assert.Equal(t, "email is invalid; is_visible_public must be true or false", reasons[9])
+	assert.Equal(t, "an employee with this name already exists", reasons[10])
+	assert.Equal(t, "has 2 columns, header has 7", reasons[12])
+
+	require.Len(t, repo.batches, 1)
+	saved := repo.batches[0]
+	require.Len(t, saved, 3)
+	assert.Equal(t, "siti@acme.test", *saved[1].Email)
+	assert.Equal(t, "contract", saved[1].EmploymentType)
+	assert.Equal(t, time.Date(2022, 2, 15, 0, 0, 0, 0, time.UTC), *saved[1].JoinDate)
+	assert.True(t, saved[1].IsVisiblePublic)
+	assert.Equal(t, int64(9), *saved[0].AddedBy)
+	assert.Nil(t, saved[2].Email)
+	assert.Equal(t, "freelance", saved[2].EmploymentType)
+	assert.Equal(t, saved[0].ID, report.Rows[0].EmployeeID)
+}
+
+func TestImportEmployees_DryRunOnlyValidates(t *testing.T) {
+	svc, repo := newEmployeeImportService(t)
+	file := helpers.NewFileHeader(t, "employees.csv", "text/csv", employeeImportFixture(t))
+
+	report, err := svc.ImportEmployees(context.Background(), 3, 9, file, true)
+	require.NoError(t, err)
+
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 0, report.Created)
+	assert.Equal(t, 3, report.Valid)
+	assert.Equal(t, []int{2, 3, 11}, importRowsByStatus(report)[company.ImportRowValid])
+	assert.Empty(t, repo.batches)
+}
+
+func TestImportEmployees_RejectsOversizedImports(t *testing.T) {
+	svc, repo := newEmployeeImportService(t)
+
+	var csv strings.Builder
+	csv.WriteString("full_name,email\n")
+	for i := 0; i <= company.MaxEmployeeImportRows; i++ {
+		fmt.Fprintf(&csv, "Employee %d,employee%d@acme.test\n", i, i)
+	}
+	file := helpers.NewFileHeader(t, "employees.csv", "text/csv", []byte(csv.String()))
+
+	_, err := svc.ImportEmployees(context.Background(), 3, 9, file, false)
+	assert.ErrorIs(t, err, company.ErrEmployeeImportTooManyRows)
+
+	file = helpers.NewFileHeader(t, "employees.csv", "text/csv", []byte("name,email\nBudi,budi@acme.test\n"))
+	_, err = svc.ImportEmployees(context.Background(), 3, 9, file, false)
+	assert.ErrorIs(t, err, company.ErrEmployeeImportInvalidFile)
+	assert.Empty(t, repo.batches)
+}
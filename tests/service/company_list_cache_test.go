@@ -0,0 +1,64 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+)
+
+// countingCompanyListRepo counts List calls
+type countingCompanyListRepo struct {
+	company.CompanyRepository
+	lists int
+}
+
+func (r *countingCompanyListRepo) List(ctx context.Context, filter *company.CompanyFilter) ([]company.Company, int64, error) {
+	r.lists++
+	return []company.Company{{ID: 1, CompanyName: "Acme"}}, 1, nil
+}
+
+func TestListCompanies_CachesOnlyBoundedQueries(t *testing.T) {
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	t.Cleanup(memCache.Stop)
+	repo := &countingCompanyListRepo{}
+	svc := service.NewCompanyService(repo, nil, memCache, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	list := func(filter company.CompanyFilter) {
+		t.Helper()
+		companies, total, err := svc.ListCompanies(ctx, &filter)
+		require.NoError(t, err)
+		assert.Len(t, companies, 1)
+		assert.Equal(t, int64(1), total)
+	}
+
+	// Shallow pages are read once and then served from the cache
+	list(company.CompanyFilter{Page: 1, Limit: 10, SearchQuery: utils.StringPtr("acme")})
+	list(company.CompanyFilter{Page: 1, Limit: 10, SearchQuery: utils.StringPtr("acme")})
+	assert.Equal(t, 1, repo.lists)
+
+	// Filters are keyed by value, so another province is a separate entry
+	jakarta, bandung := int64(31), int64(32)
+	list(company.CompanyFilter{Page: 1, Limit: 10, ProvinceID: &jakarta})
+	list(company.CompanyFilter{Page: 1, Limit: 10, ProvinceID: &bandung})
+	list(company.CompanyFilter{Page: 1, Limit: 10, ProvinceID: &bandung})
+	assert.Equal(t, 3, repo.lists)
+
+	// Deep pages and long search terms always go to the repository
+	deep := company.CompanyFilter{Page: cache.MaxCacheablePage + 1, Limit: 10}
+	long := company.CompanyFilter{Page: 1, Limit: 10, SearchQuery: utils.StringPtr(strings.Repeat("x", cache.MaxCacheableFilterValue+1))}
+	for range 2 {
+		list(deep)
+		list(long)
+	}
+	assert.Equal(t, 7, repo.lists)
+}
@@ -0,0 +1,212 @@
+package service_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/service"
+)
+
+// publicProfileRepo serves one company and counts the queries made for it. Every query
+// sleeps for latency to stand in for a database round trip.
+type publicProfileRepo struct {
+	company.CompanyRepository
+
+	company   *company.Company
+	latency   time.Duration
+	followers map[int64]bool
+	queries   atomic.Int64
+}
+
+func (r *publicProfileRepo) wait() {
+	r.queries.Add(1)
+	time.Sleep(r.latency)
+}
+
+func (r *publicProfileRepo) FindBySlug(ctx context.Context, slug string) (*company.Company, error) {
+	r.wait()
+	if slug != r.company.Slug {
+		return nil, nil
+	}
+	return r.company, nil
+}
+
+func (r *publicProfileRepo) FindBySlugWithMasterData(ctx context.Context, slug string) (*company.Company, error) {
+	return r.FindBySlug(ctx, slug)
+}
+
+func (r *publicProfileRepo) GetFullCompanyProfile(ctx context.Context, id int64) (*company.Company, error) {
+	r.wait()
+	return r.company, nil
+}
+
+func (r *publicProfileRepo) CalculateAverageRatings(ctx context.Context, companyID int64) (*company.AverageRatings, error) {
+	r.wait()
+	return &company.AverageRatings{Overall: 4.5, Culture: 4, TotalReviews: 12}, nil
+}
+
+func (r *publicProfileRepo) GetReviewsByCompanyID(ctx context.Context, companyID int64, filter *company.ReviewFilter) ([]company.CompanyReview, int64, error) {
+	r.wait()
+	reviews := []company.CompanyReview{{ID: 1, HelpfulCount: 9}, {ID: 2, HelpfulCount: 5}, {ID: 3, HelpfulCount: 2}}
+	return reviews[:filter.Limit], 12, nil
+}
+
+func (r *publicProfileRepo) CountFollowers(ctx context.Context, companyID int64) (int64, error) {
+	r.wait()
+	return 42, nil
+}
+
+func (r *publicProfileRepo) IsFollowing(ctx context.Context, companyID, userID int64) (bool, error) {
+	r.wait()
+	return r.followers[userID], nil
+}
+
+// publicProfileJobRepo returns the company's published jobs
+type publicProfileJobRepo struct {
+	job.JobRepository
+
+	latency time.Duration
+	filter  job.JobFilter
+}
+
+func (r *publicProfileJobRepo) ListByCompany(ctx context.Context, companyID int64, filter job.JobFilter, page, limit int) ([]job.Job, int64, error) {
+	time.Sleep(r.latency)
+	r.filter = filter
+	jobs := make([]job.Job, limit)
+	for i := range jobs {
+		jobs[i] = job.Job{ID: int64(i + 1), CompanyID: companyID, Status: "published"}
+	}
+	return jobs, 8, nil
+}
+
+func newPublicProfileService(tb testing.TB, latency time.Duration) (company.CompanyService, *publicProfileRepo, *publicProfileJobRepo, cache.Cache) {
+	tb.Helper()
+
+	repo := &publicProfileRepo{
+		company: &company.Company{
+			ID:      7,
+			Slug:    "acme",
+			Profile: &company.CompanyProfile{CompanyID: 7, Status: "draft"},
+		},
+		latency:   latency,
+		followers: map[int64]bool{5: true},
+	}
+	jobRepo := &publicProfileJobRepo{latency: latency}
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	tb.Cleanup(memCache.Stop)
+	svc := service.NewCompanyService(repo, nil, memCache, nil, nil, nil, nil, jobRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+	return svc, repo, jobRepo, memCache
+}
+
+func TestGetPublicProfile_ComposesPublicContent(t *testing.T) {
+	svc, repo, jobRepo, _ := newPublicProfileService(t, 0)
+	ctx := context.Background()
+
+	profile, err := svc.GetPublicProfile(ctx, "acme", 0)
+	require.NoError(t, err)
+
+	assert.Len(t, profile.Jobs, company.PublicProfileJobLimit)
+	assert.Equal(t, int64(8), profile.JobsTotal)
+	assert.Equal(t, "published", jobRepo.filter.Status)
+	assert.Len(t, profile.TopReviews, company.PublicProfileReviewLimit)
+	assert.Equal(t, 4.5, profile.Ratings.Overall)
+	assert.Equal(t, int64(42), profile.FollowerCount)
+	assert.Nil(t, profile.IsFollowing)
+
+	// The draft profile is hidden without touching the cached company
+	assert.Nil(t, profile.Company.Profile)
+	assert.NotNil(t, repo.company.Profile)
+
+	_, err = svc.GetPublicProfile(ctx, "missing", 0)
+	assert.ErrorIs(t, err, company.ErrCompanyNotFound)
+}
+
+func TestGetPublicProfile_CachesAnonymousVariant(t *testing.T) {
+	svc, repo, _, _ := newPublicProfileService(t, 0)
+	ctx := context.Background()
+
+	_, err := svc.GetPublicProfile(ctx, "acme", 0)
+	require.NoError(t, err)
+	queries := repo.queries.Load()
+
+	anonymous, err := svc.GetPublicProfile(ctx, "acme", 0)
+	require.NoError(t, err)
+	assert.Equal(t, queries, repo.queries.Load())
+
+	// Signed-in viewers reuse the cached profile and only check following
+	following, err := svc.GetPublicProfile(ctx, "acme", 5)
+	require.NoError(t, err)
+	assert.Equal(t, queries+1, repo.queries.Load())
+	require.NotNil(t, following.IsFollowing)
+	assert.True(t, *following.IsFollowing)
+
+	notFollowing, err := svc.GetPublicProfile(ctx, "acme", 6)
+	require.NoError(t, err)
+	require.NotNil(t, notFollowing.IsFollowing)
+	assert.False(t, *notFollowing.IsFollowing)
+	assert.Nil(t, anonymous.IsFollowing)
+}
+
+// The benchmarks use 2ms per query to compare one composed call with the five calls the
+// company page made before.
+const publicProfileBenchLatency = 2 * time.Millisecond
+
+func BenchmarkPublicProfile_Composed(b *testing.B) {
+	svc, _, _, memCache := newPublicProfileService(b, publicProfileBenchLatency)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		memCache.Clear()
+		if _, err := svc.GetPublicProfile(ctx, "acme", 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPublicProfile_ComposedCached(b *testing.B) {
+	svc, _, _, _ := newPublicProfileService(b, publicProfileBenchLatency)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := svc.GetPublicProfile(ctx, "acme", 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPublicProfile_SeparateCalls(b *testing.B) {
+	svc, _, jobRepo, memCache := newPublicProfileService(b, publicProfileBenchLatency)
+	ctx := context.Background()
+	approved := "approved"
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		memCache.Clear()
+		comp, err := svc.GetCompanyBySlug(ctx, "acme")
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, _, err := jobRepo.ListByCompany(ctx, comp.ID, job.JobFilter{Status: "published"}, 1, company.PublicProfileJobLimit); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := svc.GetAverageRatings(ctx, comp.ID); err != nil {
+			b.Fatal(err)
+		}
+		if _, _, err := svc.GetCompanyReviews(ctx, comp.ID, &company.ReviewFilter{Status: &approved, Page: 1, Limit: company.PublicProfileReviewLimit}); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := svc.GetFollowerCount(ctx, comp.ID); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"keerja-backend/internal/apperror"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// quotaJobRepo stores jobs in memory and applies status changes to them
+type quotaJobRepo struct {
+	job.JobRepository
+
+	jobs map[int64]*job.Job
+}
+
+func (r *quotaJobRepo) FindByID(ctx context.Context, id int64) (*job.Job, error) {
+	j, ok := r.jobs[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *j
+	return &copied, nil
+}
+
+func (r *quotaJobRepo) UpdateStatusWithExpiry(ctx context.Context, id int64, status string, publishedAt, expiredAt *time.Time) error {
+	r.jobs[id].Status = status
+	r.jobs[id].PublishedAt = publishedAt
+	r.jobs[id].ExpiredAt = expiredAt
+	return nil
+}
+
+func (r *quotaJobRepo) CloseJob(ctx context.Context, id int64) error {
+	r.jobs[id].Status = job.StatusClosed
+	return nil
+}
+
+// fakeQuotaRepo counts the published, unexpired jobs of quotaJobRepo
+type fakeQuotaRepo struct {
+	jobs      *quotaJobRepo
+	companies *fakes.CompanyRepository
+	overrides []company.PlanQuota
+}
+
+func (r *fakeQuotaRepo) ListPlanQuotas(ctx context.Context) ([]company.PlanQuota, error) {
+	return r.overrides, nil
+}
+
+func (r *fakeQuotaRepo) SavePlanQuota(ctx context.Context, quota *company.PlanQuota) error {
+	for i := range r.overrides {
+		if r.overrides[i].Plan == quota.Plan {
+			r.overrides[i] = *quota
+			return nil
+		}
+	}
+	r.overrides = append(r.overrides, *quota)
+	return nil
+}
+
+func (r *fakeQuotaRepo) SetCompanyPlan(ctx context.Context, companyID int64, plan *string) error {
+	c, ok := r.companies.Companies[companyID]
+	if !ok {
+		return company.ErrCompanyNotFound
+	}
+	c.Plan = plan
+	return nil
+}
+
+func (r *fakeQuotaRepo) CountPublishedJobs(ctx context.Context, companyID int64) (int, error) {
+	count := 0
+	for _, j := range r.jobs.jobs {
+		if j.CompanyID == companyID && j.Status == job.StatusPublished && (j.ExpiredAt == nil || j.ExpiredAt.After(time.Now())) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+type quotaFixture struct {
+	jobs   *quotaJobRepo
+	quotas company.QuotaService
+	svc    job.JobService
+	ec     *employer.EmployerContext
+}
+
+// newQuotaFixture sets up company 3, unverified and so on the free plan, with draft jobs 1
+// to drafts and the given number of published jobs after them
+func newQuotaFixture(freeLimit, drafts, published int) *quotaFixture {
+	jobRepo := &quotaJobRepo{jobs: map[int64]*job.Job{}}
+	for i := 1; i <= drafts+published; i++ {
+		status := job.StatusDraft
+		if i > drafts {
+			status = job.StatusPublished
+		}
+		jobRepo.jobs[int64(i)] = &job.Job{ID: int64(i), CompanyID: 3, Title: "Backend Engineer", Description: "Build APIs", TotalHires: 1, Status: status}
+	}
+	companyRepo := &fakes.CompanyRepository{Companies: map[int64]*company.Company{3: {ID: 3, CompanyName: "Acme"}}}
+	quotaRepo := &fakeQuotaRepo{jobs: jobRepo, companies: companyRepo}
+
+	quotas := service.NewCompanyQuotaService(quotaRepo, companyRepo, company.QuotaLimits{company.PlanFree: freeLimit}, nil)
+	return &quotaFixture{
+		jobs:   jobRepo,
+		quotas: quotas,
+		svc:    service.NewJobService(jobRepo, companyRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil, quotas, ""),
+		ec:     &employer.EmployerContext{UserID: 7, EmployerUserID: 40, CompanyID: 3, Role: "recruiter"},
+	}
+}
+
+func TestPublishJob_EnforcesPlanQuotaAtTheLimit(t *testing.T) {
+	f := newQuotaFixture(3, 2, 2)
+	ctx := context.Background()
+
+	// The third published job fills the free plan's three slots
+	require.NoError(t, f.svc.PublishJob(ctx, 1, f.ec, nil))
+	assert.Equal(t, job.StatusPublished, f.jobs.jobs[1].Status)
+
+	// The fourth is refused with the limit and the current count
+	err := f.svc.PublishJob(ctx, 2, f.ec, nil)
+	require.ErrorIs(t, err, company.ErrJobQuotaExceeded)
+	appErr, ok := apperror.As(err)
+	require.True(t, ok)
+	assert.Equal(t, "QUOTA_EXCEEDED", appErr.Code)
+	assert.Equal(t, map[string]string{"plan": "free", "limit": "3", "published": "3"}, appErr.Details)
+	assert.Equal(t, job.StatusDraft, f.jobs.jobs[2].Status)
+
+	// Closing a published job frees its slot
+	require.NoError(t, f.svc.CloseJob(ctx, 3, f.ec))
+	require.NoError(t, f.svc.PublishJob(ctx, 2, f.ec, nil))
+	assert.Equal(t, job.StatusPublished, f.jobs.jobs[2].Status)
+
+	quota, err := f.quotas.GetJobQuota(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, company.JobQuota{CompanyID: 3, Plan: "free", Limit: 3, Published: 3, Remaining: 0}, *quota)
+}
+
+func TestPublishJob_ExpiredJobsDoNotCountTowardsQuota(t *testing.T) {
+	f := newQuotaFixture(1, 1, 1)
+	expired := time.Now().Add(-time.Hour)
+	f.jobs.jobs[2].ExpiredAt = &expired
+
+	require.NoError(t, f.svc.PublishJob(context.Background(), 1, f.ec, nil))
+}
+
+func TestReopenJob_NeedsFreeQuotaSlot(t *testing.T) {
+	f := newQuotaFixture(1, 0, 2)
+	ctx := context.Background()
+	require.NoError(t, f.svc.CloseJob(ctx, 1, f.ec))
+
+	// Job 2 still takes the only slot
+	assert.ErrorIs(t, f.svc.ReopenJob(ctx, 1, f.ec), company.ErrJobQuotaExceeded)
+	assert.Equal(t, job.StatusClosed, f.jobs.jobs[1].Status)
+}
+
+func TestPlanQuota_AdminChangesApplyToTheNextPublish(t *testing.T) {
+	f := newQuotaFixture(1, 2, 1)
+	ctx := context.Background()
+	require.ErrorIs(t, f.svc.PublishJob(ctx, 1, f.ec, nil), company.ErrJobQuotaExceeded)
+
+	// Raising the free plan's limit takes effect without a restart
+	_, err := f.quotas.UpdatePlanLimit(ctx, company.PlanFree, 2, 1)
+	require.NoError(t, err)
+	require.NoError(t, f.svc.PublishJob(ctx, 1, f.ec, nil))
+	require.ErrorIs(t, f.svc.PublishJob(ctx, 2, f.ec, nil), company.ErrJobQuotaExceeded)
+
+	// Moving the company to the premium plan gives it the premium limit
+	quota, err := f.quotas.SetCompanyPlan(ctx, 3, company.PlanPremium, 1)
+	require.NoError(t, err)
+	assert.Equal(t, company.PlanPremium, quota.Plan)
+	assert.Equal(t, company.DefaultQuotaLimits[company.PlanPremium], quota.Limit)
+	require.NoError(t, f.svc.PublishJob(ctx, 2, f.ec, nil))
+
+	limits, err := f.quotas.ListPlanLimits(ctx)
+	require.NoError(t, err)
+	require.Len(t, limits, 3)
+	assert.Equal(t, 2, limits[0].MaxPublishedJobs)
+	assert.Equal(t, int64(1), *limits[0].UpdatedBy)
+
+	_, err = f.quotas.UpdatePlanLimit(ctx, "enterprise", 5, 1)
+	assert.ErrorIs(t, err, company.ErrInvalidPlan)
+	_, err = f.quotas.UpdatePlanLimit(ctx, company.PlanFree, 0, 1)
+	assert.ErrorIs(t, err, company.ErrInvalidPlanLimit)
+}
+
+func TestBulkPublishJobs_ReportsQuotaFailuresPerJob(t *testing.T) {
+	f := newQuotaFixture(2, 3, 1)
+
+	result, err := f.svc.BulkPublishJobs(context.Background(), []int64{1, 4, 2, 3, 99})
+	require.NoError(t, err)
+
+	assert.Equal(t, []int64{1}, result.Published)
+	require.Len(t, result.Failed, 4)
+	assert.Equal(t, job.BulkPublishError{JobID: 4, Code: "JOB_ALREADY_PUBLISHED", Error: job.ErrJobAlreadyPublished.Message}, result.Failed[0])
+	assert.Equal(t, int64(2), result.Failed[1].JobID)
+	assert.Equal(t, "QUOTA_EXCEEDED", result.Failed[1].Code)
+	assert.Equal(t, map[string]string{"plan": "free", "limit": "2", "published": "2"}, result.Failed[1].Details)
+	assert.Equal(t, "QUOTA_EXCEEDED", result.Failed[2].Code)
+	assert.Equal(t, "JOB_NOT_FOUND", result.Failed[3].Code)
+	assert.Equal(t, job.StatusDraft, f.jobs.jobs[2].Status)
+}
+
+// fullQuotaService reports every company's quota as used up
+type fullQuotaService struct {
+	company.QuotaService
+}
+
+func (s *fullQuotaService) CheckCanPublish(ctx context.Context, companyID int64) (*company.JobQuota, error) {
+	quota := &company.JobQuota{CompanyID: companyID, Plan: company.PlanFree, Limit: 3, Published: 3}
+	return quota, quota.Exceeded()
+}
+
+func TestAdminJobService_ApproveRespectsQuota(t *testing.T) {
+	jobRepo := &fakes.JobRepository{Job: &job.Job{ID: 5, CompanyID: 3, Title: "Backend Engineer", Status: "pending_review"}}
+	userRepo := &fakes.UserRepository{User: &user.User{ID: 7}}
+	svc := service.NewAdminJobService(jobRepo, &fakes.CompanyRepository{}, userRepo, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, &fullQuotaService{})
+
+	_, err := svc.ApproveJob(context.Background(), 5, 1, "")
+	assert.ErrorIs(t, err, company.ErrJobQuotaExceeded)
+	assert.Equal(t, "pending_review", jobRepo.Job.Status)
+	assert.Empty(t, jobRepo.Reviews)
+}
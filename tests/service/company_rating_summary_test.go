@@ -0,0 +1,161 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/service"
+)
+
+// ratingCompanyRepo keeps reviews in memory and summarizes them only when asked to, like
+// the company_rating_summaries table
+type ratingCompanyRepo struct {
+	company.CompanyRepository
+
+	reviews      map[int64]*company.CompanyReview
+	summaries    map[int64]company.AverageRatings
+	topRatedHits int
+}
+
+func (r *ratingCompanyRepo) FindReviewByID(ctx context.Context, id int64) (*company.CompanyReview, error) {
+	if review, ok := r.reviews[id]; ok {
+		copied := *review
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (r *ratingCompanyRepo) UpdateReview(ctx context.Context, review *company.CompanyReview) error {
+	copied := *review
+	r.reviews[review.ID] = &copied
+	return nil
+}
+
+func (r *ratingCompanyRepo) DeleteReview(ctx context.Context, id int64) error {
+	delete(r.reviews, id)
+	return nil
+}
+
+func (r *ratingCompanyRepo) ApproveReview(ctx context.Context, id, moderatedBy int64) error {
+	r.reviews[id].Status = "approved"
+	return nil
+}
+
+func (r *ratingCompanyRepo) RejectReview(ctx context.Context, id, moderatedBy int64) error {
+	r.reviews[id].Status = "rejected"
+	return nil
+}
+
+func (r *ratingCompanyRepo) RefreshRatingSummary(ctx context.Context, companyID int64) error {
+	r.summaries[companyID] = liveRatings(r.reviews, companyID)
+	return nil
+}
+
+func (r *ratingCompanyRepo) RefreshStaleRatingSummaries(ctx context.Context) (int64, error) {
+	var refreshed int64
+	for _, review := range r.reviews {
+		if _, ok := r.summaries[review.CompanyID]; !ok {
+			r.summaries[review.CompanyID] = liveRatings(r.reviews, review.CompanyID)
+			refreshed++
+		}
+	}
+	return refreshed, nil
+}
+
+func (r *ratingCompanyRepo) CalculateAverageRatings(ctx context.Context, companyID int64) (*company.AverageRatings, error) {
+	summary := r.summaries[companyID]
+	return &summary, nil
+}
+
+func (r *ratingCompanyRepo) GetTopRatedCompanies(ctx context.Context, limit int) ([]company.Company, error) {
+	r.topRatedHits++
+	return []company.Company{{ID: 5}}, nil
+}
+
+// liveRatings aggregates a company's approved reviews the way the summary refresh does
+func liveRatings(reviews map[int64]*company.CompanyReview, companyID int64) company.AverageRatings {
+	var ratings company.AverageRatings
+	var sum float64
+	for _, review := range reviews {
+		if review.CompanyID == companyID && review.IsApproved() {
+			sum += *review.RatingOverall
+			ratings.TotalReviews++
+		}
+	}
+	if ratings.TotalReviews > 0 {
+		ratings.Overall = sum / float64(ratings.TotalReviews)
+	}
+	return ratings
+}
+
+func newRatingSummaryService(t *testing.T) (company.CompanyService, *ratingCompanyRepo) {
+	t.Helper()
+
+	rating := func(v float64) *float64 { return &v }
+	authorID := int64(9)
+	repo := &ratingCompanyRepo{
+		reviews: map[int64]*company.CompanyReview{
+			1: {ID: 1, CompanyID: 5, RatingOverall: rating(4), Status: "approved"},
+			2: {ID: 2, CompanyID: 5, RatingOverall: rating(2), Status: "pending"},
+			3: {ID: 3, CompanyID: 5, UserID: &authorID, RatingOverall: rating(5), Status: "approved"},
+		},
+		summaries: map[int64]company.AverageRatings{},
+	}
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	t.Cleanup(memCache.Stop)
+
+	return service.NewCompanyService(repo, nil, memCache, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil), repo
+}
+
+func TestRatingSummary_StaysConsistentThroughModeration(t *testing.T) {
+	svc, repo := newRatingSummaryService(t)
+	ctx := context.Background()
+
+	assertConsistent := func(step string, total int64) {
+		t.Helper()
+		ratings, err := svc.GetAverageRatings(ctx, 5)
+		require.NoError(t, err)
+		assert.Equal(t, liveRatings(repo.reviews, 5), *ratings, step)
+		assert.Equal(t, total, ratings.TotalReviews, step)
+	}
+
+	// Existing reviews are backfilled by the refresh job
+	refreshed, err := svc.RefreshStaleRatingSummaries(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), refreshed)
+	assertConsistent("backfill", 2)
+
+	require.NoError(t, svc.ApproveReview(ctx, 2, 1))
+	assertConsistent("approve", 3)
+
+	require.NoError(t, svc.HideReview(ctx, 1, 1))
+	assertConsistent("hide", 2)
+
+	require.NoError(t, svc.RejectReview(ctx, 2, 1))
+	assertConsistent("reject", 1)
+
+	require.NoError(t, svc.DeleteReview(ctx, 3, 9))
+	assertConsistent("delete", 0)
+}
+
+func TestGetTopRatedCompanies_CachedUntilReviewsChange(t *testing.T) {
+	svc, repo := newRatingSummaryService(t)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := svc.GetTopRatedCompanies(ctx, 10)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, repo.topRatedHits)
+
+	require.NoError(t, svc.ApproveReview(ctx, 2, 1))
+	_, err := svc.GetTopRatedCompanies(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, repo.topRatedHits)
+}
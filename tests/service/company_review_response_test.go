@@ -0,0 +1,158 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/internal/service"
+)
+
+// reviewResponseCompanyRepo serves an approved review (1) and a pending review (2) of company 5,
+// whose owner is user 10, admin user 11 and recruiter user 12. Responses are kept one per review.
+type reviewResponseCompanyRepo struct {
+	company.CompanyRepository
+
+	responses map[int64]*company.ReviewResponse
+}
+
+func (r *reviewResponseCompanyRepo) FindReviewByID(ctx context.Context, id int64) (*company.CompanyReview, error) {
+	author := int64(7)
+	switch id {
+	case 1:
+		return &company.CompanyReview{ID: 1, CompanyID: 5, UserID: &author, Status: company.ReviewStatusApproved}, nil
+	case 2:
+		return &company.CompanyReview{ID: 2, CompanyID: 5, UserID: &author, Status: company.ReviewStatusPending}, nil
+	}
+	return nil, nil
+}
+
+func (r *reviewResponseCompanyRepo) FindEmployerUserByUserAndCompany(ctx context.Context, userID, companyID int64) (*company.EmployerUser, error) {
+	roles := map[int64]string{10: employer.RoleOwner, 11: employer.RoleAdmin, 12: employer.RoleRecruiter}
+	role, ok := roles[userID]
+	if !ok || companyID != 5 {
+		return nil, nil
+	}
+	return &company.EmployerUser{ID: userID + 100, UserID: userID, CompanyID: companyID, Role: role, IsActive: true}, nil
+}
+
+func (r *reviewResponseCompanyRepo) CreateReviewResponse(ctx context.Context, response *company.ReviewResponse) error {
+	if _, ok := r.responses[response.ReviewID]; ok {
+		return company.ErrReviewResponseExists
+	}
+	response.ID = int64(len(r.responses) + 1)
+	copied := *response
+	r.responses[response.ReviewID] = &copied
+	return nil
+}
+
+func (r *reviewResponseCompanyRepo) UpdateReviewResponse(ctx context.Context, response *company.ReviewResponse) error {
+	copied := *response
+	r.responses[response.ReviewID] = &copied
+	return nil
+}
+
+func (r *reviewResponseCompanyRepo) DeleteReviewResponse(ctx context.Context, id int64) error {
+	for reviewID, response := range r.responses {
+		if response.ID == id {
+			delete(r.responses, reviewID)
+		}
+	}
+	return nil
+}
+
+func (r *reviewResponseCompanyRepo) FindReviewResponseByReviewID(ctx context.Context, reviewID int64) (*company.ReviewResponse, error) {
+	response, ok := r.responses[reviewID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *response
+	return &copied, nil
+}
+
+func newReviewResponseService(t *testing.T) (company.CompanyService, *reviewResponseCompanyRepo) {
+	t.Helper()
+
+	repo := &reviewResponseCompanyRepo{responses: map[int64]*company.ReviewResponse{}}
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	t.Cleanup(memCache.Stop)
+
+	return service.NewCompanyService(repo, nil, memCache, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil), repo
+}
+
+func TestReviewResponse_OnePerReview(t *testing.T) {
+	svc, repo := newReviewResponseService(t)
+	ctx := context.Background()
+
+	response, err := svc.AddReviewResponse(ctx, 1, 10, "  Thanks for the <b>feedback</b>  ")
+	require.NoError(t, err)
+	assert.Equal(t, "Thanks for the &lt;b&gt;feedback&lt;/b&gt;", response.Body)
+	assert.Equal(t, int64(110), *response.ResponderEmployerUserID)
+	assert.Nil(t, response.EditedAt)
+
+	_, err = svc.AddReviewResponse(ctx, 1, 11, "A second response")
+	assert.ErrorIs(t, err, company.ErrReviewResponseExists)
+
+	updated, err := svc.UpdateReviewResponse(ctx, 1, 11, "We have since improved onboarding")
+	require.NoError(t, err)
+	assert.Equal(t, response.ID, updated.ID)
+	assert.Equal(t, int64(111), *updated.ResponderEmployerUserID)
+	require.NotNil(t, updated.EditedAt, "edits are timestamped")
+
+	require.NoError(t, svc.DeleteReviewResponse(ctx, 1, 10))
+	assert.Empty(t, repo.responses)
+	assert.ErrorIs(t, svc.DeleteReviewResponse(ctx, 1, 10), company.ErrReviewResponseNotFound)
+
+	_, err = svc.AddReviewResponse(ctx, 1, 10, "Responding again after deleting")
+	assert.NoError(t, err)
+}
+
+func TestReviewResponse_OnlyOwnersAndAdminsOfTheCompany(t *testing.T) {
+	svc, repo := newReviewResponseService(t)
+	ctx := context.Background()
+
+	_, err := svc.AddReviewResponse(ctx, 1, 12, "Recruiters cannot respond")
+	assert.ErrorIs(t, err, company.ErrReviewResponseForbidden)
+
+	_, err = svc.AddReviewResponse(ctx, 1, 99, "Outsiders cannot respond")
+	assert.ErrorIs(t, err, company.ErrReviewResponseForbidden)
+
+	_, err = svc.AddReviewResponse(ctx, 1, 7, "The review author is not the company")
+	assert.ErrorIs(t, err, company.ErrReviewResponseForbidden)
+
+	_, err = svc.AddReviewResponse(ctx, 1, 10, "Company response")
+	require.NoError(t, err)
+
+	_, err = svc.UpdateReviewResponse(ctx, 1, 12, "Recruiters cannot edit")
+	assert.ErrorIs(t, err, company.ErrReviewResponseForbidden)
+	assert.ErrorIs(t, svc.DeleteReviewResponse(ctx, 1, 99), company.ErrReviewResponseForbidden)
+	assert.Equal(t, "Company response", repo.responses[1].Body)
+}
+
+func TestReviewResponse_RejectsUnapprovedReviewsAndInvalidBodies(t *testing.T) {
+	svc, repo := newReviewResponseService(t)
+	ctx := context.Background()
+
+	_, err := svc.AddReviewResponse(ctx, 2, 10, "Pending reviews are not public yet")
+	assert.ErrorIs(t, err, company.ErrReviewNotPublic)
+
+	_, err = svc.AddReviewResponse(ctx, 3, 10, "Unknown review")
+	assert.ErrorIs(t, err, company.ErrReviewNotFound)
+
+	_, err = svc.AddReviewResponse(ctx, 1, 10, "   ")
+	assert.ErrorIs(t, err, company.ErrInvalidReviewResponse)
+
+	_, err = svc.AddReviewResponse(ctx, 1, 10, strings.Repeat("a", service.MaxReviewResponseLength+1))
+	assert.ErrorIs(t, err, company.ErrInvalidReviewResponse)
+
+	_, err = svc.AddReviewResponse(ctx, 1, 10, strings.Repeat("a", service.MaxReviewResponseLength))
+	assert.NoError(t, err)
+	assert.Len(t, repo.responses, 1)
+}
@@ -0,0 +1,102 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/service"
+)
+
+// reviewRulesCompanyRepo stores created reviews and enforces one review per user per company
+type reviewRulesCompanyRepo struct {
+	company.CompanyRepository
+
+	reviews  []company.CompanyReview
+	verified map[int64]bool // company IDs the user worked for
+}
+
+func (r *reviewRulesCompanyRepo) CountReviewsByUserSince(ctx context.Context, userID int64, since time.Time) (int64, error) {
+	var count int64
+	for _, review := range r.reviews {
+		if *review.UserID == userID && !review.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *reviewRulesCompanyRepo) IsVerifiedReviewer(ctx context.Context, companyID, userID int64) (bool, error) {
+	return r.verified[companyID], nil
+}
+
+func (r *reviewRulesCompanyRepo) CreateReview(ctx context.Context, review *company.CompanyReview) error {
+	for _, existing := range r.reviews {
+		if existing.CompanyID == review.CompanyID && *existing.UserID == *review.UserID {
+			return company.ErrReviewAlreadyExists
+		}
+	}
+	review.ID = int64(len(r.reviews) + 1)
+	review.CreatedAt = time.Now()
+	r.reviews = append(r.reviews, *review)
+	return nil
+}
+
+func (r *reviewRulesCompanyRepo) RefreshRatingSummary(ctx context.Context, companyID int64) error {
+	return nil
+}
+
+func newReviewRulesService(t *testing.T) (company.CompanyService, *reviewRulesCompanyRepo) {
+	t.Helper()
+
+	repo := &reviewRulesCompanyRepo{verified: map[int64]bool{2: true}}
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	t.Cleanup(memCache.Stop)
+
+	return service.NewCompanyService(repo, nil, memCache, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil), repo
+}
+
+func addReviewRequest(companyID int64) *company.AddReviewRequest {
+	pros := "friendly team and good mentoring"
+	return &company.AddReviewRequest{CompanyID: companyID, UserID: 7, RatingOverall: 4, Pros: &pros}
+}
+
+func TestAddReview_OncePerCompanyAndFlagsVerifiedReviewers(t *testing.T) {
+	svc, _ := newReviewRulesService(t)
+	ctx := context.Background()
+
+	review, err := svc.AddReview(ctx, addReviewRequest(1))
+	require.NoError(t, err)
+	assert.False(t, review.IsVerifiedReviewer)
+	assert.Equal(t, "pending", review.Status)
+
+	_, err = svc.AddReview(ctx, addReviewRequest(1))
+	assert.ErrorIs(t, err, company.ErrReviewAlreadyExists)
+
+	review, err = svc.AddReview(ctx, addReviewRequest(2))
+	require.NoError(t, err)
+	assert.True(t, review.IsVerifiedReviewer)
+}
+
+func TestAddReview_LimitsReviewsPerDay(t *testing.T) {
+	svc, repo := newReviewRulesService(t)
+	ctx := context.Background()
+
+	for companyID := int64(1); companyID <= service.MaxReviewsPerDay; companyID++ {
+		_, err := svc.AddReview(ctx, addReviewRequest(companyID))
+		require.NoError(t, err)
+	}
+
+	_, err := svc.AddReview(ctx, addReviewRequest(10))
+	assert.ErrorIs(t, err, company.ErrReviewRateLimited)
+
+	// Reviews older than a day no longer count
+	repo.reviews[0].CreatedAt = time.Now().Add(-25 * time.Hour)
+	_, err = svc.AddReview(ctx, addReviewRequest(10))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,333 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+type statsCompanyRepo struct {
+	company.CompanyRepository
+
+	company      *company.Company
+	profileLoads int
+}
+
+func (r *statsCompanyRepo) GetFullCompanyProfile(ctx context.Context, companyID int64) (*company.Company, error) {
+	r.profileLoads++
+	return r.company, nil
+}
+
+func (r *statsCompanyRepo) CountFollowers(ctx context.Context, companyID int64) (int64, error) {
+	return 12, nil
+}
+
+func (r *statsCompanyRepo) CountEmployees(ctx context.Context, companyID int64, activeOnly bool) (int64, error) {
+	return 4, nil
+}
+
+func (r *statsCompanyRepo) CalculateAverageRatings(ctx context.Context, companyID int64) (*company.AverageRatings, error) {
+	return &company.AverageRatings{Overall: 4.5, TotalReviews: 8}, nil
+}
+
+type statsJobRepo struct {
+	job.JobRepository
+	stats *job.CompanyJobStats
+}
+
+func (r *statsJobRepo) GetCompanyJobStats(ctx context.Context, companyID int64) (*job.CompanyJobStats, error) {
+	return r.stats, nil
+}
+
+type statsApplicationRepo struct {
+	application.ApplicationRepository
+	stats *application.CompanyApplicationStats
+}
+
+func (r *statsApplicationRepo) GetCompanyApplicationStats(ctx context.Context, companyID int64) (*application.CompanyApplicationStats, error) {
+	return r.stats, nil
+}
+
+func TestGetCompanyStats_ComputesCountsAndCompleteness(t *testing.T) {
+	logo := "https://cdn.example.com/logo.png"
+	description := "We build things"
+	industryID := int64(3)
+	companyRepo := &statsCompanyRepo{company: &company.Company{
+		ID:          5,
+		LogoURL:     &logo,
+		Description: &description,
+		IndustryID:  &industryID,
+		FullAddress: "Jl. Sudirman 1, Jakarta",
+		Verified:    true,
+	}}
+	jobRepo := &statsJobRepo{stats: &job.CompanyJobStats{TotalJobs: 7, ActiveJobs: 2}}
+	appRepo := &statsApplicationRepo{stats: &application.CompanyApplicationStats{TotalApplications: 31}}
+
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	defer memCache.Stop()
+	svc := service.NewCompanyService(companyRepo, nil, memCache, nil, nil, nil, nil, jobRepo, appRepo, nil, nil, nil, nil, nil, nil, nil)
+
+	stats, err := svc.GetCompanyStats(context.Background(), 5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), stats.TotalJobs)
+	assert.Equal(t, int64(2), stats.ActiveJobs)
+	assert.Equal(t, int64(31), stats.TotalApplications)
+	assert.Equal(t, int64(12), stats.TotalFollowers)
+	assert.Equal(t, "verified", stats.VerificationStatus)
+	// 6 of 8 items filled: banner and company size are missing
+	assert.Equal(t, 75, stats.ProfileCompleteness)
+
+	// Second call is served from cache
+	_, err = svc.GetCompanyStats(context.Background(), 5)
+	require.NoError(t, err)
+	assert.Equal(t, 1, companyRepo.profileLoads)
+}
+
+type reviewVoteCompanyRepo struct {
+	company.CompanyRepository
+
+	review  *company.CompanyReview
+	votes   map[int64]string
+	reports []company.ReviewReport
+	updated []company.CompanyReview
+	// refreshed lists the companies whose rating summary was refreshed
+	refreshed []int64
+}
+
+func (r *reviewVoteCompanyRepo) FindReviewByID(ctx context.Context, id int64) (*company.CompanyReview, error) {
+	if r.review == nil || r.review.ID != id {
+		return nil, nil
+	}
+	review := *r.review
+	return &review, nil
+}
+
+func (r *reviewVoteCompanyRepo) UpsertReviewVote(ctx context.Context, vote *company.ReviewVote) error {
+	r.votes[vote.UserID] = vote.Vote
+	r.recount()
+	return nil
+}
+
+func (r *reviewVoteCompanyRepo) DeleteReviewVote(ctx context.Context, reviewID, userID int64) error {
+	delete(r.votes, userID)
+	r.recount()
+	return nil
+}
+
+func (r *reviewVoteCompanyRepo) recount() {
+	r.review.HelpfulCount, r.review.NotHelpfulCount = 0, 0
+	for _, vote := range r.votes {
+		if vote == company.ReviewVoteHelpful {
+			r.review.HelpfulCount++
+		} else {
+			r.review.NotHelpfulCount++
+		}
+	}
+}
+
+func (r *reviewVoteCompanyRepo) CreateReviewReport(ctx context.Context, report *company.ReviewReport) error {
+	for _, existing := range r.reports {
+		if existing.UserID == report.UserID {
+			return company.ErrReviewAlreadyReported
+		}
+	}
+	r.reports = append(r.reports, *report)
+	return nil
+}
+
+func (r *reviewVoteCompanyRepo) CountOpenReviewReports(ctx context.Context, reviewID int64) (int64, error) {
+	return int64(len(r.reports)), nil
+}
+
+func (r *reviewVoteCompanyRepo) UpdateReview(ctx context.Context, review *company.CompanyReview) error {
+	r.updated = append(r.updated, *review)
+	*r.review = *review
+	return nil
+}
+
+func (r *reviewVoteCompanyRepo) RefreshRatingSummary(ctx context.Context, companyID int64) error {
+	r.refreshed = append(r.refreshed, companyID)
+	return nil
+}
+
+func newReviewVoteService(t *testing.T) (company.CompanyService, *reviewVoteCompanyRepo) {
+	t.Helper()
+
+	authorID := int64(1)
+	repo := &reviewVoteCompanyRepo{
+		review: &company.CompanyReview{ID: 10, CompanyID: 5, UserID: &authorID, Status: "approved"},
+		votes:  map[int64]string{},
+	}
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	t.Cleanup(memCache.Stop)
+
+	return service.NewCompanyService(repo, nil, memCache, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil), repo
+}
+
+func TestVoteReview_ChangesAndRemovesVote(t *testing.T) {
+	svc, _ := newReviewVoteService(t)
+	ctx := context.Background()
+
+	review, err := svc.VoteReview(ctx, 10, 2, company.ReviewVoteHelpful)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), review.HelpfulCount)
+	require.NotNil(t, review.MyVote)
+	assert.Equal(t, company.ReviewVoteHelpful, *review.MyVote)
+
+	review, err = svc.VoteReview(ctx, 10, 2, company.ReviewVoteNotHelpful)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), review.HelpfulCount)
+	assert.Equal(t, int32(1), review.NotHelpfulCount)
+
+	review, err = svc.UnvoteReview(ctx, 10, 2)
+	require.NoError(t, err)
+	assert.Zero(t, review.NotHelpfulCount)
+	assert.Nil(t, review.MyVote)
+}
+
+func TestVoteReview_RejectsOwnAndInvalidVotes(t *testing.T) {
+	svc, _ := newReviewVoteService(t)
+	ctx := context.Background()
+
+	_, err := svc.VoteReview(ctx, 10, 1, company.ReviewVoteHelpful)
+	assert.ErrorIs(t, err, company.ErrOwnReview)
+
+	_, err = svc.VoteReview(ctx, 10, 2, "love")
+	assert.ErrorIs(t, err, company.ErrInvalidReviewVote)
+
+	_, err = svc.VoteReview(ctx, 99, 2, company.ReviewVoteHelpful)
+	assert.ErrorIs(t, err, company.ErrReviewNotFound)
+}
+
+func TestReportReview_HidesAfterThreshold(t *testing.T) {
+	svc, repo := newReviewVoteService(t)
+	ctx := context.Background()
+
+	for userID := int64(2); userID < 2+service.ReviewReportHideThreshold-1; userID++ {
+		require.NoError(t, svc.ReportReview(ctx, 10, userID, "spam content"))
+	}
+	assert.Empty(t, repo.updated)
+	assert.ErrorIs(t, svc.ReportReview(ctx, 10, 2, "spam content"), company.ErrReviewAlreadyReported)
+
+	require.NoError(t, svc.ReportReview(ctx, 10, 50, "harassment"))
+	require.Len(t, repo.updated, 1)
+	assert.Equal(t, "hidden", repo.updated[0].Status)
+	assert.Equal(t, []int64{5}, repo.refreshed, "hiding refreshes the rating summary")
+
+	// Hidden reviews can no longer be voted on or reported
+	_, err := svc.VoteReview(ctx, 10, 3, company.ReviewVoteHelpful)
+	assert.ErrorIs(t, err, company.ErrReviewNotPublic)
+}
+
+type moderationCompanyRepo struct {
+	reviewVoteCompanyRepo
+
+	queue      []company.ModerationReview
+	lastStatus string
+	approved   []int64
+}
+
+func (r *moderationCompanyRepo) GetReviewsByStatus(ctx context.Context, status string, filter *company.ReviewModerationFilter, page, limit int) ([]company.ModerationReview, int64, error) {
+	r.lastStatus = status
+	return r.queue, int64(len(r.queue)), nil
+}
+
+func (r *moderationCompanyRepo) ApproveReview(ctx context.Context, id, moderatedBy int64) error {
+	r.approved = append(r.approved, id)
+	return nil
+}
+
+func TestGetPendingReviews_MasksAnonymousReviewers(t *testing.T) {
+	repo := &moderationCompanyRepo{queue: []company.ModerationReview{
+		{CompanyReview: company.CompanyReview{ID: 1, IsAnonymous: true}, CompanyName: "Acme", ReviewerName: "John Doe"},
+		{CompanyReview: company.CompanyReview{ID: 2}, CompanyName: "Acme", ReviewerName: "Jane Roe"},
+	}}
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	defer memCache.Stop()
+	svc := service.NewCompanyService(repo, nil, memCache, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	reviews, total, err := svc.GetPendingReviews(context.Background(), "", nil, 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, "pending", repo.lastStatus)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, "J*** D***", reviews[0].ReviewerName)
+	assert.Equal(t, "Jane Roe", reviews[1].ReviewerName)
+
+	_, _, err = svc.GetPendingReviews(context.Background(), "deleted", nil, 1, 20)
+	assert.ErrorIs(t, err, company.ErrInvalidReviewStatus)
+}
+
+func TestApproveReview_InvalidatesReviewCaches(t *testing.T) {
+	repo := &moderationCompanyRepo{reviewVoteCompanyRepo: reviewVoteCompanyRepo{
+		review: &company.CompanyReview{ID: 10, CompanyID: 5, Status: "pending"},
+	}}
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	defer memCache.Stop()
+	svc := service.NewCompanyService(repo, nil, memCache, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	ratingsKey := cache.GenerateCacheKey("company", "ratings", int64(5))
+	memCache.Set(ratingsKey, &company.AverageRatings{Overall: 4}, time.Minute)
+
+	require.NoError(t, svc.ApproveReview(context.Background(), 10, 1))
+	assert.Equal(t, []int64{10}, repo.approved)
+	assert.Equal(t, []int64{5}, repo.refreshed)
+	_, cached := memCache.Get(ratingsKey)
+	assert.False(t, cached)
+
+	assert.ErrorIs(t, svc.ApproveReview(context.Background(), 99, 1), company.ErrReviewNotFound)
+}
+
+type addressCompanyRepo struct {
+	company.CompanyRepository
+	created []company.CompanyAddress
+}
+
+func (r *addressCompanyRepo) FindByID(ctx context.Context, id int64) (*company.Company, error) {
+	return &company.Company{ID: id}, nil
+}
+
+func (r *addressCompanyRepo) CreateCompanyAddress(ctx context.Context, address *company.CompanyAddress) error {
+	r.created = append(r.created, *address)
+	return nil
+}
+
+func TestCreateCompanyAddress_GeocodesMissingCoordinates(t *testing.T) {
+	repo := &addressCompanyRepo{}
+	geocoder := &fakes.Geocoder{Result: &service.GeocodeResult{Latitude: -6.2, Longitude: 106.8, Provider: service.GeocoderNominatim, Confidence: 0.7}}
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	defer memCache.Stop()
+	svc := service.NewCompanyService(repo, nil, memCache, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, geocoder, nil, nil)
+
+	addr, err := svc.CreateCompanyAddress(context.Background(), 5, &company.CreateCompanyAddressRequest{FullAddress: "Jl. Sudirman 1, Jakarta"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Jl. Sudirman 1, Jakarta"}, geocoder.Calls)
+	require.NotNil(t, addr.Latitude)
+	assert.Equal(t, -6.2, *addr.Latitude)
+	assert.Equal(t, service.GeocoderNominatim, *addr.GeocodeProvider)
+	assert.Equal(t, 0.7, *addr.GeocodeConfidence)
+
+	// Client coordinates are kept as sent
+	lat, lng := -7.25, 112.75
+	addr, err = svc.CreateCompanyAddress(context.Background(), 5, &company.CreateCompanyAddressRequest{FullAddress: "Surabaya", Latitude: &lat, Longitude: &lng})
+	require.NoError(t, err)
+	assert.Len(t, geocoder.Calls, 1)
+	assert.Nil(t, addr.GeocodeProvider)
+
+	// A failing provider does not block the address
+	geocoder.Result, geocoder.Err = nil, errors.New("provider unavailable")
+	addr, err = svc.CreateCompanyAddress(context.Background(), 5, &company.CreateCompanyAddressRequest{FullAddress: "Bandung"})
+	require.NoError(t, err)
+	assert.Nil(t, addr.Latitude)
+	assert.Len(t, repo.created, 3)
+}
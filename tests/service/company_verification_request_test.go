@@ -0,0 +1,176 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"mime/multipart"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// txInjectionPool is a gorm connection pool whose transactions commit or fail on demand.
+// It runs no SQL; the repository fake below does all the work.
+type txInjectionPool struct {
+	commitErr  error
+	committed  bool
+	rolledBack bool
+}
+
+func (p *txInjectionPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	return &injectedTx{p}, nil
+}
+
+func (p *txInjectionPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, errors.New("no database")
+}
+
+func (p *txInjectionPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, errors.New("no database")
+}
+
+func (p *txInjectionPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, errors.New("no database")
+}
+
+func (p *txInjectionPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return nil
+}
+
+// injectedTx is a transaction of txInjectionPool
+type injectedTx struct {
+	*txInjectionPool
+}
+
+func (tx *injectedTx) Commit() error {
+	if tx.commitErr != nil {
+		return tx.commitErr
+	}
+	tx.committed = true
+	return nil
+}
+
+func (tx *injectedTx) Rollback() error {
+	tx.rolledBack = true
+	return nil
+}
+
+func newTxInjectionDB(t *testing.T, pool *txInjectionPool) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: pool}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	return db
+}
+
+// verificationRequestRepo records the verification and documents written inside the transaction
+type verificationRequestRepo struct {
+	company.CompanyRepository
+
+	failDocumentType string
+	verification     *company.CompanyVerification
+	documents        []company.CompanyDocument
+}
+
+func (r *verificationRequestRepo) FindVerificationByCompanyIDTx(ctx context.Context, tx *gorm.DB, companyID int64) (*company.CompanyVerification, error) {
+	return r.verification, nil
+}
+
+func (r *verificationRequestRepo) CreateVerificationTx(ctx context.Context, tx *gorm.DB, verification *company.CompanyVerification) error {
+	r.verification = verification
+	return nil
+}
+
+func (r *verificationRequestRepo) CreateDocumentTx(ctx context.Context, tx *gorm.DB, doc *company.CompanyDocument) error {
+	if doc.DocumentType == r.failDocumentType {
+		return errors.New("insert failed")
+	}
+	r.documents = append(r.documents, *doc)
+	return nil
+}
+
+func (r *verificationRequestRepo) FindByID(ctx context.Context, id int64) (*company.Company, error) {
+	return nil, nil
+}
+
+func (r *verificationRequestRepo) GetEmployerUsersByCompanyID(ctx context.Context, companyID int64) ([]company.EmployerUser, error) {
+	return nil, nil
+}
+
+func requestVerification(t *testing.T, repo *verificationRequestRepo, pool *txInjectionPool) (*fakes.UploadService, error) {
+	t.Helper()
+
+	uploads := &fakes.UploadService{}
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	t.Cleanup(memCache.Stop)
+	svc := service.NewCompanyService(repo, uploads, memCache, newTxInjectionDB(t, pool), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	nib := "1234567890123"
+	err := svc.RequestVerification(context.Background(), 3, 9, "01.234.567.8-901.000", &nib,
+		helpers.NewFileHeader(t, "npwp.pdf", "application/pdf", []byte("%PDF-npwp")),
+		helpers.NewFileHeader(t, "nib.pdf", "application/pdf", []byte("%PDF-nib")),
+		[]*multipart.FileHeader{helpers.NewFileHeader(t, "deed.pdf", "application/pdf", []byte("%PDF-deed"))},
+	)
+	return uploads, err
+}
+
+func TestRequestVerification_SavesAllDocumentsIncludingNIB(t *testing.T) {
+	repo := &verificationRequestRepo{}
+	pool := &txInjectionPool{}
+
+	uploads, err := requestVerification(t, repo, pool)
+	require.NoError(t, err)
+
+	assert.True(t, pool.committed)
+	assert.Empty(t, uploads.Deleted)
+	require.NotNil(t, repo.verification)
+	assert.Equal(t, "pending", repo.verification.Status)
+
+	paths := map[string]string{}
+	for _, doc := range repo.documents {
+		paths[doc.DocumentType] = doc.FilePath
+	}
+	assert.Equal(t, map[string]string{
+		"NPWP":    "documents/npwp/npwp.pdf",
+		"NIB":     "documents/nib/nib.pdf",
+		"LAINNYA": "documents/additional/deed.pdf",
+	}, paths)
+}
+
+func TestRequestVerification_DeletesUploadsWhenTransactionFails(t *testing.T) {
+	t.Run("repository write fails", func(t *testing.T) {
+		repo := &verificationRequestRepo{failDocumentType: "NIB"}
+		pool := &txInjectionPool{}
+
+		uploads, err := requestVerification(t, repo, pool)
+		require.Error(t, err)
+
+		assert.True(t, pool.rolledBack)
+		assert.False(t, pool.committed)
+		assert.Len(t, uploads.Uploaded, 3)
+		assert.ElementsMatch(t, uploads.Uploaded, uploads.Deleted)
+	})
+
+	t.Run("commit fails", func(t *testing.T) {
+		repo := &verificationRequestRepo{}
+		pool := &txInjectionPool{commitErr: errors.New("connection reset")}
+
+		uploads, err := requestVerification(t, repo, pool)
+		require.Error(t, err)
+
+		assert.Len(t, uploads.Uploaded, 3)
+		assert.ElementsMatch(t, uploads.Uploaded, uploads.Deleted)
+	})
+}
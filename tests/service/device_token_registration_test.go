@@ -0,0 +1,115 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/config"
+	"keerja-backend/internal/domain/notification"
+	"keerja-backend/internal/service"
+)
+
+// memoryDeviceTokenRepo keeps one row per token string, like the unique index on token
+type memoryDeviceTokenRepo struct {
+	notification.DeviceTokenRepository
+
+	nextID  int64
+	tokens  map[string]*notification.DeviceToken
+	deleted []int64
+}
+
+func newMemoryDeviceTokenRepo() *memoryDeviceTokenRepo {
+	return &memoryDeviceTokenRepo{tokens: make(map[string]*notification.DeviceToken)}
+}
+
+func (r *memoryDeviceTokenRepo) Upsert(ctx context.Context, token *notification.DeviceToken) error {
+	now := time.Now()
+	if existing, ok := r.tokens[token.Token]; ok {
+		existing.UserID = token.UserID
+		existing.Platform = token.Platform
+		existing.IsActive = true
+		existing.LastUsedAt = &now
+		existing.FailureCount = 0
+		*token = *existing
+		return nil
+	}
+	r.nextID++
+	token.ID = r.nextID
+	token.IsActive = true
+	token.LastUsedAt = &now
+	token.CreatedAt = now
+	stored := *token
+	r.tokens[token.Token] = &stored
+	return nil
+}
+
+func (r *memoryDeviceTokenRepo) FindByUser(ctx context.Context, userID int64) ([]notification.DeviceToken, error) {
+	var tokens []notification.DeviceToken
+	for _, token := range r.tokens {
+		if token.UserID == userID && token.IsActive {
+			tokens = append(tokens, *token)
+		}
+	}
+	return tokens, nil
+}
+
+func (r *memoryDeviceTokenRepo) FindTopics(ctx context.Context, deviceTokenID int64) ([]string, error) {
+	return nil, nil
+}
+
+func (r *memoryDeviceTokenRepo) Delete(ctx context.Context, id int64) error {
+	for key, token := range r.tokens {
+		if token.ID == id {
+			delete(r.tokens, key)
+			r.deleted = append(r.deleted, id)
+		}
+	}
+	return nil
+}
+
+func (r *memoryDeviceTokenRepo) seed(userID int64, token string, lastSeen time.Time) {
+	r.nextID++
+	r.tokens[token] = &notification.DeviceToken{
+		ID: r.nextID, UserID: userID, Token: token, Platform: notification.PlatformAndroid,
+		IsActive: true, LastUsedAt: &lastSeen, CreatedAt: lastSeen,
+	}
+}
+
+func TestRegisterDeviceToken_EvictsLeastRecentlySeenBeyondLimit(t *testing.T) {
+	repo := newMemoryDeviceTokenRepo()
+	now := time.Now()
+	for i, token := range []string{"tok-a", "tok-b", "tok-c", "tok-d", "tok-e"} {
+		// tok-a is the most recently seen, tok-e the least
+		repo.seed(7, token, now.Add(-time.Duration(i+1)*time.Hour))
+	}
+	svc := service.NewFCMPushService(repo, &config.Config{PushMaxDevicesPerUser: 5})
+
+	registered, err := svc.RegisterDeviceToken(context.Background(), 7, "tok-f", notification.PlatformIOS, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "tok-f", registered.Token)
+
+	active, err := repo.FindByUser(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Len(t, active, 5)
+	assert.NotContains(t, repo.tokens, "tok-e")
+	assert.Contains(t, repo.tokens, "tok-f")
+}
+
+func TestRegisterDeviceToken_ReassignsTokenFromAnotherUser(t *testing.T) {
+	repo := newMemoryDeviceTokenRepo()
+	repo.seed(7, "shared-device", time.Now().Add(-time.Hour))
+	svc := service.NewFCMPushService(repo, &config.Config{PushMaxDevicesPerUser: 5})
+
+	registered, err := svc.RegisterDeviceToken(context.Background(), 9, "shared-device", notification.PlatformAndroid, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), registered.ID, "the existing row is reused")
+	assert.Equal(t, int64(9), registered.UserID)
+	require.Len(t, repo.tokens, 1)
+	assert.Equal(t, int64(9), repo.tokens["shared-device"].UserID)
+	assert.Empty(t, repo.deleted)
+}
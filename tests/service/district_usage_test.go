@@ -0,0 +1,154 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/master"
+	"keerja-backend/internal/service"
+	masterService "keerja-backend/internal/service/master"
+	"keerja-backend/internal/utils"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// usageDistrictRepo serves active district 20 and inactive district 21, both in city 30, and
+// reports the usage set per district
+type usageDistrictRepo struct {
+	master.DistrictRepository
+
+	usage  map[int64]*master.DistrictUsage
+	merged [][2]int64
+}
+
+func (r *usageDistrictRepo) GetByID(ctx context.Context, id int64) (*master.District, error) {
+	switch id {
+	case 20, 21:
+		return &master.District{ID: id, CityID: 30, IsActive: id == 20}, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *usageDistrictRepo) ExistsByID(ctx context.Context, id int64) (bool, error) {
+	return id == 20 || id == 21, nil
+}
+
+func (r *usageDistrictRepo) CountUsage(ctx context.Context, id int64) (*master.DistrictUsage, error) {
+	if usage, ok := r.usage[id]; ok {
+		return usage, nil
+	}
+	return &master.DistrictUsage{DistrictID: id}, nil
+}
+
+func (r *usageDistrictRepo) MergeInto(ctx context.Context, sourceID, targetID int64) (*master.DistrictMergeResult, error) {
+	r.merged = append(r.merged, [2]int64{sourceID, targetID})
+	return &master.DistrictMergeResult{SourceID: sourceID, TargetID: targetID}, nil
+}
+
+func (r *usageDistrictRepo) Delete(ctx context.Context, id int64) error {
+	return nil
+}
+
+// usageCityRepo serves active city 30 and inactive city 31
+type usageCityRepo struct {
+	master.CityRepository
+}
+
+func (r usageCityRepo) GetByID(ctx context.Context, id int64) (*master.City, error) {
+	return &master.City{ID: id, ProvinceID: 40, IsActive: id == 30}, nil
+}
+
+func newAdminDistrictService(t *testing.T, repo *usageDistrictRepo) master.AdminDistrictService {
+	t.Helper()
+
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	t.Cleanup(memCache.Stop)
+	base := masterService.NewDistrictService(repo, usageCityRepo{}, nil, memCache)
+	return masterService.NewAdminDistrictService(base, repo, nil, memCache)
+}
+
+func TestValidateDistrictID_OnlyBlocksNewAssignmentsOfInactiveDistricts(t *testing.T) {
+	svc := newAdminDistrictService(t, &usageDistrictRepo{})
+	ctx := context.Background()
+
+	assert.NoError(t, svc.ValidateDistrictID(ctx, 20, 30, nil))
+	assert.ErrorIs(t, svc.ValidateDistrictID(ctx, 21, 30, nil), masterService.ErrDistrictInactive)
+	assert.ErrorIs(t, svc.ValidateDistrictID(ctx, 21, 30, utils.Int64Ptr(20)), masterService.ErrDistrictInactive, "moving to an inactive district is a new assignment")
+	assert.NoError(t, svc.ValidateDistrictID(ctx, 21, 30, utils.Int64Ptr(21)), "entities keep a district deactivated after it was assigned")
+	assert.ErrorIs(t, svc.ValidateDistrictID(ctx, 21, 31, utils.Int64Ptr(21)), masterService.ErrDistrictCityIDMismatch)
+	assert.ErrorIs(t, svc.ValidateDistrictID(ctx, 22, 30, utils.Int64Ptr(22)), masterService.ErrDistrictNotFound)
+}
+
+func TestAdminDistrict_DeleteBlockedWhileReferenced(t *testing.T) {
+	repo := &usageDistrictRepo{usage: map[int64]*master.DistrictUsage{
+		21: {DistrictID: 21, CompanyAddresses: 2, Jobs: 3},
+	}}
+	svc := newAdminDistrictService(t, repo)
+	ctx := context.Background()
+
+	usage, err := svc.GetUsage(ctx, 21)
+	require.NoError(t, err)
+	assert.True(t, usage.InUse())
+
+	err = svc.Delete(ctx, 21)
+	assert.ErrorIs(t, err, master.ErrDistrictInUse)
+	assert.Contains(t, err.Error(), "2 company addresses")
+
+	assert.NoError(t, svc.Delete(ctx, 20))
+	assert.ErrorIs(t, svc.Delete(ctx, 22), master.ErrDistrictNotFound)
+}
+
+func TestAdminDistrict_MergeIntoAnActiveDistrict(t *testing.T) {
+	repo := &usageDistrictRepo{}
+	svc := newAdminDistrictService(t, repo)
+	ctx := context.Background()
+
+	_, err := svc.Merge(ctx, 20, 20)
+	assert.ErrorIs(t, err, master.ErrDistrictMergeIntoSelf)
+	_, err = svc.Merge(ctx, 20, 21)
+	assert.ErrorIs(t, err, master.ErrDistrictMergeTargetInactive)
+	_, err = svc.Merge(ctx, 22, 20)
+	assert.ErrorIs(t, err, master.ErrDistrictNotFound)
+	assert.Empty(t, repo.merged)
+
+	result, err := svc.Merge(ctx, 21, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20), result.TargetID)
+	assert.Equal(t, [][2]int64{{21, 20}}, repo.merged)
+}
+
+// grandfatheredDistricts serves district 20 and districts 21 and 22, which were deactivated
+type grandfatheredDistricts struct {
+	master.DistrictService
+}
+
+func (s grandfatheredDistricts) GetByID(ctx context.Context, id int64) (*master.DistrictResponse, error) {
+	province := &master.ProvinceResponse{ID: 40, IsActive: true}
+	city := &master.CityResponse{ID: 30, ProvinceID: 40, Province: province, IsActive: true}
+	return &master.DistrictResponse{ID: id, CityID: 30, City: city, IsActive: id == 20}, nil
+}
+
+func TestRequestCompanyChange_KeepsTheCompanysDeactivatedDistrict(t *testing.T) {
+	repo := &fakes.CompanyRepository{
+		Companies:      map[int64]*company.Company{7: {ID: 7, Slug: "acme", DistrictID: utils.Int64Ptr(21)}},
+		ChangeRequests: map[int64]*company.CompanyChangeRequest{},
+	}
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	t.Cleanup(memCache.Stop)
+	svc := service.NewCompanyService(repo, nil, memCache, nil, fakes.IndustryService{}, nil, grandfatheredDistricts{}, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	_, err := svc.RequestCompanyChange(ctx, 7, 3, &company.ChangeRequestInput{IndustryID: utils.Int64Ptr(2), DistrictID: utils.Int64Ptr(22), Reason: "We moved offices"})
+	assert.ErrorIs(t, err, company.ErrInvalidChangeRequestData, "inactive districts cannot be newly assigned")
+
+	req, err := svc.RequestCompanyChange(ctx, 7, 3, &company.ChangeRequestInput{IndustryID: utils.Int64Ptr(2), DistrictID: utils.Int64Ptr(21), Reason: "Re-classified"})
+	require.NoError(t, err)
+	require.NoError(t, svc.ApproveChangeRequest(ctx, req.ID, 1, nil))
+	assert.Equal(t, company.ChangeRequestApproved, repo.ChangeRequests[req.ID].Status)
+}
@@ -0,0 +1,199 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/helpers"
+)
+
+func TestDownloadSigner_SignAndVerify(t *testing.T) {
+	signer := service.NewDownloadSigner("secret", time.Minute)
+
+	token, expiresAt := signer.Sign("documents/npwp/a.pdf", "NPWP-1.pdf")
+	assert.WithinDuration(t, time.Now().Add(time.Minute), expiresAt, 2*time.Second)
+
+	claims, err := signer.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, "documents/npwp/a.pdf", claims.FilePath)
+	assert.Equal(t, "NPWP-1.pdf", claims.FileName)
+	assert.Equal(t, expiresAt.Unix(), claims.ExpiresAt)
+
+	url, _ := signer.URL("https://api.keerja.com/", "documents/npwp/a.pdf", "NPWP-1.pdf")
+	assert.True(t, strings.HasPrefix(url, "https://api.keerja.com/api/v1/downloads/"), url)
+}
+
+func TestDownloadSigner_RejectsExpiredTokens(t *testing.T) {
+	signer := service.NewDownloadSigner("secret", time.Minute)
+
+	token := signer.SignUntil("documents/npwp/a.pdf", "a.pdf", time.Now().Add(-time.Second))
+
+	_, err := signer.Verify(token)
+	assert.ErrorIs(t, err, service.ErrDownloadLinkExpired)
+}
+
+func TestDownloadSigner_RejectsTamperedTokens(t *testing.T) {
+	signer := service.NewDownloadSigner("secret", time.Minute)
+	token, _ := signer.Sign("documents/npwp/a.pdf", "a.pdf")
+	payload, sig, _ := strings.Cut(token, ".")
+
+	// A payload granting another file, or a later expiry, under the original signature
+	forged := service.NewDownloadSigner("secret", time.Hour).SignUntil("documents/nib/b.pdf", "b.pdf", time.Now().Add(time.Hour))
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+
+	flipped := []byte(sig)
+	if flipped[0] == 'A' {
+		flipped[0] = 'B'
+	} else {
+		flipped[0] = 'A'
+	}
+
+	for name, tampered := range map[string]string{
+		"swapped payload":   forgedPayload + "." + sig,
+		"changed signature": payload + "." + string(flipped),
+		"other secret":      service.NewDownloadSigner("other", time.Minute).SignUntil("documents/npwp/a.pdf", "a.pdf", time.Now().Add(time.Minute)),
+		"missing signature": payload,
+		"empty":             "",
+		"garbage":           "not-a-token.%%%",
+	} {
+		_, err := signer.Verify(tampered)
+		assert.ErrorIs(t, err, service.ErrDownloadLinkInvalid, name)
+	}
+}
+
+// documentCompanyRepo serves one company's employers and documents
+type documentCompanyRepo struct {
+	company.CompanyRepository
+
+	employers map[int64]*company.EmployerUser
+	docs      map[int64]*company.CompanyDocument
+}
+
+func (r *documentCompanyRepo) FindEmployerUserByUserAndCompany(ctx context.Context, userID, companyID int64) (*company.EmployerUser, error) {
+	if eu, ok := r.employers[userID]; ok && eu.CompanyID == companyID {
+		return eu, nil
+	}
+	return nil, nil
+}
+
+func (r *documentCompanyRepo) FindDocumentByID(ctx context.Context, id int64) (*company.CompanyDocument, error) {
+	return r.docs[id], nil
+}
+
+func newDocumentCompanyRepo() *documentCompanyRepo {
+	return &documentCompanyRepo{
+		employers: map[int64]*company.EmployerUser{
+			1: {UserID: 1, CompanyID: 5, Role: "owner", IsActive: true},
+			2: {UserID: 2, CompanyID: 5, Role: "admin", IsActive: true},
+			3: {UserID: 3, CompanyID: 5, Role: "recruiter", IsActive: true},
+			4: {UserID: 4, CompanyID: 5, Role: "viewer", IsActive: true},
+			5: {UserID: 5, CompanyID: 5, Role: "admin", IsActive: false},
+			6: {UserID: 6, CompanyID: 8, Role: "owner", IsActive: true},
+		},
+		docs: map[int64]*company.CompanyDocument{
+			10: {ID: 10, CompanyID: 5, DocumentType: "NPWP", FilePath: "documents/npwp/a.pdf"},
+			11: {ID: 11, CompanyID: 8, DocumentType: "NIB", FilePath: "documents/nib/b.pdf"},
+		},
+	}
+}
+
+func TestCompanyGetDocumentForDownload_AllowsOwnersAdminsAndPlatformAdmins(t *testing.T) {
+	svc := service.NewCompanyService(newDocumentCompanyRepo(), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	for _, caller := range []struct {
+		userID   int64
+		userType string
+	}{{1, "employer"}, {2, "employer"}, {99, "admin"}} {
+		doc, err := svc.GetDocumentForDownload(ctx, 5, 10, caller.userID, caller.userType)
+		require.NoError(t, err, "user %d", caller.userID)
+		assert.Equal(t, "documents/npwp/a.pdf", doc.FilePath)
+	}
+}
+
+func TestCompanyGetDocumentForDownload_RejectsOtherRoles(t *testing.T) {
+	svc := service.NewCompanyService(newDocumentCompanyRepo(), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	// Recruiters, viewers, inactive admins, other companies' owners and job seekers
+	for _, userID := range []int64{3, 4, 5, 6, 42} {
+		_, err := svc.GetDocumentForDownload(ctx, 5, 10, userID, "employer")
+		assert.ErrorIs(t, err, company.ErrDocumentAccessDenied, "user %d", userID)
+	}
+	_, err := svc.GetDocumentForDownload(ctx, 5, 10, 42, "jobseeker")
+	assert.ErrorIs(t, err, company.ErrDocumentAccessDenied)
+
+	// Another company's document is not disclosed through this company
+	_, err = svc.GetDocumentForDownload(ctx, 5, 11, 1, "employer")
+	assert.ErrorIs(t, err, company.ErrDocumentNotFound)
+	_, err = svc.GetDocumentForDownload(ctx, 5, 12, 99, "admin")
+	assert.ErrorIs(t, err, company.ErrDocumentNotFound)
+}
+
+// documentApplicationsRepo serves applications and their documents
+type documentApplicationsRepo struct {
+	application.ApplicationRepository
+
+	apps map[int64]*application.JobApplication
+	docs map[int64]*application.ApplicationDocument
+}
+
+func (r *documentApplicationsRepo) FindByID(ctx context.Context, id int64) (*application.JobApplication, error) {
+	if app, ok := r.apps[id]; ok {
+		return app, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *documentApplicationsRepo) FindDocumentByID(ctx context.Context, id int64) (*application.ApplicationDocument, error) {
+	if doc, ok := r.docs[id]; ok {
+		return doc, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func TestApplicationGetDocumentForDownload_AccessControl(t *testing.T) {
+	hiringCompany := int64(5)
+	appRepo := &documentApplicationsRepo{
+		apps: map[int64]*application.JobApplication{
+			1: {ID: 1, UserID: 7, CompanyID: &hiringCompany},
+			2: {ID: 2, UserID: 8, CompanyID: &hiringCompany},
+		},
+		docs: map[int64]*application.ApplicationDocument{
+			20: {ID: 20, ApplicationID: 1, FileURL: "applications/documents/cv.pdf", Uploaded: true},
+			21: {ID: 21, ApplicationID: 2, FileURL: "applications/documents/other.pdf", Uploaded: true},
+		},
+	}
+	svc := helpers.NewApplicationService(appRepo, nil, nil, newDocumentCompanyRepo())
+	ctx := context.Background()
+
+	// The applicant and any employer of the hiring company
+	for _, userID := range []int64{7, 1, 3, 4} {
+		doc, err := svc.GetDocumentForDownload(ctx, 1, 20, userID)
+		require.NoError(t, err, "user %d", userID)
+		assert.Equal(t, int64(20), doc.ID)
+	}
+
+	// Another applicant and an employer of another company
+	_, err := svc.GetDocumentForDownload(ctx, 1, 20, 8)
+	assert.ErrorIs(t, err, application.ErrEmployerAccessDenied)
+	_, err = svc.GetDocumentForDownload(ctx, 1, 20, 6)
+	assert.ErrorIs(t, err, application.ErrEmployerAccessDenied)
+
+	// Documents of another application, missing documents and missing applications
+	_, err = svc.GetDocumentForDownload(ctx, 1, 21, 7)
+	assert.ErrorIs(t, err, application.ErrDocumentNotFound)
+	_, err = svc.GetDocumentForDownload(ctx, 1, 99, 7)
+	assert.ErrorIs(t, err, application.ErrDocumentNotFound)
+	_, err = svc.GetDocumentForDownload(ctx, 3, 20, 7)
+	assert.ErrorIs(t, err, application.ErrApplicationNotFound)
+}
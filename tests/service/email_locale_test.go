@@ -0,0 +1,79 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/i18n"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// localeRecordingTransport records the locale each status update email is sent in
+type localeRecordingTransport struct {
+	email.EmailService
+	locales []i18n.Locale
+}
+
+func (t *localeRecordingTransport) SendJobStatusUpdateEmail(ctx context.Context, to, jobTitle, status string) error {
+	t.locales = append(t.locales, i18n.FromContext(ctx))
+	return nil
+}
+
+func TestEmailQueue_SendsInQueuedLocale(t *testing.T) {
+	repo := &fakes.EmailQueueRepository{}
+	transport := &localeRecordingTransport{}
+	queue := service.NewEmailQueueService(repo, transport, service.EmailQueueConfig{})
+	emails := service.NewQueuedEmailService(transport, queue)
+
+	ctx := i18n.WithLocale(context.Background(), i18n.English)
+	require.NoError(t, emails.SendJobStatusUpdateEmail(ctx, "seeker@example.com", "Backend Engineer", "hired"))
+	require.NoError(t, emails.SendJobStatusUpdateEmail(context.Background(), "seeker@example.com", "Backend Engineer", "hired"))
+
+	require.Len(t, repo.Emails, 2, "the same email in another language is not a duplicate")
+	assert.Equal(t, "en", repo.Emails[0].Locale)
+	assert.Equal(t, "id", repo.Emails[1].Locale)
+
+	sent, err := queue.ProcessDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	assert.Equal(t, []i18n.Locale{i18n.English, i18n.Indonesian}, transport.locales)
+}
+
+func TestNotifyStatusUpdate_UsesRecipientLanguagePreference(t *testing.T) {
+	english := "en"
+	tests := []struct {
+		name     string
+		language *string
+		want     i18n.Locale
+	}{
+		{"preference set", &english, i18n.English},
+		{"no preference", nil, i18n.Indonesian},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appRepo := fakes.NewApplicationRepository()
+			appRepo.Apps[1] = &application.JobApplication{ID: 1, JobID: 10, UserID: 7, Status: "interview"}
+			jobRepo := &fakes.JobRepository{Job: &job.Job{ID: 10, CompanyID: 3, Title: "Backend Engineer", Status: "published", ExpiredAt: utils.TimePtr(time.Now().Add(time.Hour))}}
+			userRepo := &fakes.UserRepository{User: &user.User{ID: 7, Email: "seeker@example.com"}}
+			if tt.language != nil {
+				userRepo.Preferences = map[int64]*user.UserPreference{7: {UserID: 7, LanguagePreference: tt.language, EmailNotifications: true}}
+			}
+			transport := &localeRecordingTransport{}
+			svc := service.NewApplicationService(appRepo, jobRepo, userRepo, &fakes.CompanyRepository{}, transport, nil, nil, application.DefaultReapplyPolicy, nil, true, nil, nil)
+
+			require.NoError(t, svc.NotifyStatusUpdate(context.Background(), 1, "hired"))
+			assert.Equal(t, []i18n.Locale{tt.want}, transport.locales)
+		})
+	}
+}
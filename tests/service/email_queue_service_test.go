@@ -0,0 +1,85 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/email"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// failingTransport fails every status update email and counts the attempts
+type failingTransport struct {
+	email.EmailService
+	attempts int
+}
+
+func (t *failingTransport) SendJobStatusUpdateEmail(ctx context.Context, to, jobTitle, status string) error {
+	t.attempts++
+	return errors.New("smtp: connection refused")
+}
+
+func TestEmailQueue_EnqueueIsIdempotentWhilePending(t *testing.T) {
+	repo := &fakes.EmailQueueRepository{}
+	queue := service.NewEmailQueueService(repo, &failingTransport{}, service.EmailQueueConfig{})
+	emails := service.NewQueuedEmailService(&failingTransport{}, queue)
+	ctx := context.Background()
+
+	require.NoError(t, emails.SendJobStatusUpdateEmail(ctx, "candidate@example.com", "Backend Engineer", "shortlisted"))
+	require.NoError(t, emails.SendJobStatusUpdateEmail(ctx, "candidate@example.com", "Backend Engineer", "shortlisted"))
+	require.NoError(t, emails.SendJobStatusUpdateEmail(ctx, "candidate@example.com", "Backend Engineer", "rejected"))
+
+	require.Len(t, repo.Emails, 2)
+	assert.Equal(t, email.QueueTemplateJobStatusUpdate, repo.Emails[0].Template)
+	assert.JSONEq(t, `{"job_title":"Backend Engineer","status":"shortlisted"}`, repo.Emails[0].Payload)
+	assert.Equal(t, email.QueueStatusPending, repo.Emails[0].Status)
+}
+
+func TestEmailQueue_BacksOffExponentiallyThenGoesDead(t *testing.T) {
+	repo := &fakes.EmailQueueRepository{}
+	transport := &failingTransport{}
+	queue := service.NewEmailQueueService(repo, transport, service.EmailQueueConfig{
+		MaxAttempts: 4,
+		BaseBackoff: time.Minute,
+		MaxBackoff:  3 * time.Minute,
+	})
+	ctx := context.Background()
+
+	require.NoError(t, queue.Enqueue(ctx, "candidate@example.com", email.QueueTemplateJobStatusUpdate, map[string]string{
+		"job_title": "Backend Engineer",
+		"status":    "shortlisted",
+	}))
+
+	// 1m after the first failure, 2m after the second, then capped at 3m
+	for _, want := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute} {
+		before := time.Now()
+		sent, err := queue.ProcessDue(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+
+		queued := repo.Emails[0]
+		require.Equal(t, email.QueueStatusPending, queued.Status)
+		assert.WithinDuration(t, before.Add(want), queued.NextAttemptAt, 5*time.Second)
+		require.NotNil(t, queued.LastError)
+		assert.Contains(t, *queued.LastError, "connection refused")
+		repo.DueAll()
+	}
+
+	_, err := queue.ProcessDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, email.QueueStatusDead, repo.Emails[0].Status)
+	assert.Equal(t, 4, repo.Emails[0].Attempts)
+	assert.Equal(t, 4, transport.attempts)
+
+	// Dead emails are no longer picked up
+	repo.DueAll()
+	_, err = queue.ProcessDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, transport.attempts)
+}
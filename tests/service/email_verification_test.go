@@ -0,0 +1,120 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+func TestEmailVerificationPolicy_GraceBoundary(t *testing.T) {
+	policy := user.NewEmailVerificationPolicy(0)
+	require.Equal(t, user.DefaultEmailVerificationGracePeriod, policy.GracePeriod, "a zero grace period falls back to the default")
+
+	signedUp := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	u := &user.User{ID: 1, CreatedAt: signedUp}
+	endsAt := signedUp.Add(72 * time.Hour)
+
+	assert.True(t, policy.CanLogin(u, endsAt.Add(-time.Second)))
+	assert.False(t, policy.CanLogin(u, endsAt), "the grace period ends exactly 3 days after signing up")
+
+	state := policy.State(u, signedUp.Add(time.Hour))
+	assert.Equal(t, 3, state.DaysRemaining, "partial days count as a day")
+	assert.False(t, state.Required)
+	assert.Equal(t, endsAt, *state.GraceEndsAt)
+
+	state = policy.State(u, endsAt.Add(-time.Minute))
+	assert.Equal(t, 1, state.DaysRemaining)
+
+	state = policy.State(u, endsAt)
+	assert.Equal(t, 0, state.DaysRemaining)
+	assert.True(t, state.Required)
+
+	verifiedAt := endsAt.Add(24 * time.Hour)
+	u.MarkEmailVerified(verifiedAt)
+	assert.True(t, policy.CanLogin(u, endsAt.Add(48*time.Hour)), "verified accounts are never blocked")
+	assert.Equal(t, user.EmailVerificationState{Verified: true, VerifiedAt: &verifiedAt}, policy.State(u, verifiedAt))
+}
+
+func TestLogin_BlocksUnverifiedAccountsAfterTheGracePeriod(t *testing.T) {
+	hash, err := utils.HashPassword(credentialPassword)
+	require.NoError(t, err)
+
+	now := time.Now()
+	verifiedAt := now.Add(-30 * 24 * time.Hour)
+	userRepo := &fakes.UserRepository{Users: map[int64]*user.User{
+		1: {ID: 1, Email: "new@example.com", PasswordHash: hash, Status: "active", CreatedAt: now.Add(-47 * time.Hour)},
+		2: {ID: 2, Email: "late@example.com", PasswordHash: hash, Status: "active", CreatedAt: now.Add(-49 * time.Hour)},
+		3: {ID: 3, Email: "verified@example.com", PasswordHash: hash, Status: "active", CreatedAt: verifiedAt, EmailVerifiedAt: &verifiedAt},
+	}}
+	svc := service.NewAuthService(userRepo, nil, nil, service.AuthServiceConfig{
+		JWTSecret:               "test-secret",
+		JWTDuration:             time.Hour,
+		VerificationGracePeriod: 48 * time.Hour,
+	}, nil)
+	ctx := context.Background()
+
+	_, token, err := svc.Login(ctx, "new@example.com", credentialPassword)
+	require.NoError(t, err, "unverified accounts can sign in during the grace period")
+	assert.NotEmpty(t, token)
+
+	_, _, err = svc.Login(ctx, "late@example.com", credentialPassword)
+	assert.ErrorIs(t, err, user.ErrVerificationRequired)
+
+	_, _, err = svc.Login(ctx, "late@example.com", "Wrong123!")
+	assert.ErrorIs(t, err, service.ErrInvalidCredentials, "the password is checked before verification")
+
+	_, _, err = svc.Login(ctx, "verified@example.com", credentialPassword)
+	assert.NoError(t, err)
+}
+
+func TestApplyForJob_NeedsVerifiedEmail(t *testing.T) {
+	expiry := time.Now().Add(24 * time.Hour)
+	appRepo := fakes.NewApplicationRepository()
+	jobRepo := &fakes.JobRepository{Job: &job.Job{ID: 10, CompanyID: 3, Title: "Backend Engineer", Status: "published", ExpiredAt: &expiry}}
+	userRepo := &fakes.UserRepository{User: &user.User{ID: 7, UserType: "jobseeker", Status: "active", CreatedAt: time.Now()}}
+	svc := helpers.NewApplicationService(appRepo, jobRepo, userRepo, &fakes.CompanyRepository{})
+
+	_, err := svc.ApplyForJob(context.Background(), &application.ApplyJobRequest{JobID: 10, UserID: 7})
+	assert.ErrorIs(t, err, user.ErrEmailVerificationNeeded, "the grace period allows browsing, not applying")
+	assert.Empty(t, appRepo.Apps)
+
+	userRepo.User = helpers.VerifiedJobseeker()
+	_, err = svc.ApplyForJob(context.Background(), &application.ApplyJobRequest{JobID: 10, UserID: 7})
+	assert.NoError(t, err)
+}
+
+// unverifiedOwnerCompanyRepo fails the test when a company would be created
+type unverifiedOwnerCompanyRepo struct {
+	company.CompanyRepository
+
+	t *testing.T
+}
+
+func (r *unverifiedOwnerCompanyRepo) Create(ctx context.Context, c *company.Company) error {
+	r.t.Fatal("company created for an unverified owner")
+	return nil
+}
+
+func TestRegisterCompany_NeedsVerifiedEmail(t *testing.T) {
+	userRepo := &fakes.UserRepository{User: &user.User{ID: 7, UserType: "employer", Status: "active", CreatedAt: time.Now()}}
+	svc := service.NewCompanyService(&unverifiedOwnerCompanyRepo{t: t}, nil, nil, nil, nil, nil, nil, nil, nil, nil, userRepo, nil, nil, nil, nil, nil)
+
+	_, err := svc.RegisterCompany(context.Background(), &company.RegisterCompanyRequest{CompanyName: "Acme"}, 7)
+	assert.ErrorIs(t, err, user.ErrEmailVerificationNeeded)
+
+	userRepo.User = nil
+	_, err = svc.RegisterCompany(context.Background(), &company.RegisterCompanyRequest{CompanyName: "Acme"}, 7)
+	assert.ErrorIs(t, err, service.ErrUserNotFound)
+}
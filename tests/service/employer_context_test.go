@@ -0,0 +1,91 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// In these fixtures user IDs and employer_users IDs overlap on purpose: user 7 is employer
+// user 40 at company 3, while employer user 7 belongs to user 9.
+
+func TestResolveEmployerContext_UsesEmployerUserID(t *testing.T) {
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	t.Cleanup(memCache.Stop)
+	repo := &fakes.CompanyRepository{EmployerUsers: []company.EmployerUser{
+		{ID: 40, UserID: 7, CompanyID: 3, Role: "recruiter", IsActive: true},
+		{ID: 7, UserID: 9, CompanyID: 3, Role: "viewer", IsActive: true},
+		{ID: 41, UserID: 8, CompanyID: 3, Role: "admin", IsActive: false},
+	}}
+	svc := service.NewCompanyService(repo, nil, memCache, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	ec, err := svc.ResolveEmployerContext(ctx, 7, 3)
+	require.NoError(t, err)
+	assert.Equal(t, employer.EmployerContext{UserID: 7, EmployerUserID: 40, CompanyID: 3, Role: "recruiter"}, *ec)
+
+	// Without a company the user's only company is used
+	ec, err = svc.ResolveEmployerContext(ctx, 9, 0)
+	require.NoError(t, err)
+	assert.Equal(t, employer.EmployerContext{UserID: 9, EmployerUserID: 7, CompanyID: 3, Role: "viewer"}, *ec)
+
+	// Inactive members, other companies and users without a company have no context
+	_, err = svc.ResolveEmployerContext(ctx, 8, 3)
+	assert.ErrorIs(t, err, company.ErrNotCompanyEmployer)
+	_, err = svc.ResolveEmployerContext(ctx, 7, 4)
+	assert.ErrorIs(t, err, company.ErrNotCompanyEmployer)
+	_, err = svc.ResolveEmployerContext(ctx, 8, 0)
+	assert.ErrorIs(t, err, company.ErrNoEmployerCompany)
+	_, err = svc.ResolveEmployerContext(ctx, 42, 0)
+	assert.ErrorIs(t, err, company.ErrNoEmployerCompany)
+}
+
+func TestGetMyJobs_ListsByEmployerUserID(t *testing.T) {
+	svc := service.NewJobService(helpers.NewEmployerJobRepository(), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil, nil, "")
+	ec := &employer.EmployerContext{UserID: 7, EmployerUserID: 40, CompanyID: 3, Role: "recruiter"}
+
+	jobs, total, err := svc.GetMyJobs(context.Background(), ec, job.JobFilter{}, 1, 10)
+	require.NoError(t, err)
+
+	// Job 2 was posted by employer user 7, which is not user 7
+	assert.Equal(t, int64(2), total)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, int64(1), jobs[0].ID)
+	assert.Equal(t, int64(3), jobs[1].ID)
+}
+
+func TestCheckJobOwnership_ComparesEmployerUserIDs(t *testing.T) {
+	svc := service.NewJobService(helpers.NewEmployerJobRepository(), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil, nil, "")
+	ctx := context.Background()
+
+	for name, tc := range map[string]struct {
+		jobID int64
+		ec    *employer.EmployerContext
+		err   error
+	}{
+		// User 7's ID equals job 2's poster, but user 7 is employer user 40 and a viewer there
+		"viewer whose user ID matches the poster": {2, &employer.EmployerContext{UserID: 7, EmployerUserID: 40, CompanyID: 3, Role: "viewer"}, job.ErrJobPermissionDenied},
+		"poster with a viewer role":               {2, &employer.EmployerContext{UserID: 9, EmployerUserID: 7, CompanyID: 3, Role: "viewer"}, nil},
+		"recruiter of the company":                {2, &employer.EmployerContext{UserID: 7, EmployerUserID: 40, CompanyID: 3, Role: "recruiter"}, nil},
+		"recruiter of another company":            {1, &employer.EmployerContext{UserID: 7, EmployerUserID: 40, CompanyID: 4, Role: "recruiter"}, job.ErrJobPermissionDenied},
+		"no employer context":                     {1, nil, job.ErrJobPermissionDenied},
+	} {
+		err := svc.CheckJobOwnership(ctx, tc.jobID, tc.ec)
+		if tc.err == nil {
+			assert.NoError(t, err, name)
+		} else {
+			assert.ErrorIs(t, err, tc.err, name)
+		}
+	}
+}
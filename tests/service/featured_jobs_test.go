@@ -0,0 +1,240 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/admin"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/helpers"
+)
+
+// featuredJobRepo keeps jobs and hourly view counts in memory
+type featuredJobRepo struct {
+	job.JobRepository
+
+	jobs    map[int64]*job.Job
+	buckets []job.JobViewBucket
+	since   []time.Time // Window starts the view counts were read from
+}
+
+func (r *featuredJobRepo) FindByID(ctx context.Context, id int64) (*job.Job, error) {
+	if j, ok := r.jobs[id]; ok {
+		copied := *j
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (r *featuredJobRepo) ListFeatured(ctx context.Context, now time.Time, limit int) ([]job.Job, error) {
+	var jobs []job.Job
+	for id := int64(1); id <= 20; id++ {
+		if j, ok := r.jobs[id]; ok && j.IsPublished() && j.IsFeaturedAt(now) {
+			jobs = append(jobs, *j)
+		}
+	}
+	return jobs, nil
+}
+
+func (r *featuredJobRepo) ListViewBuckets(ctx context.Context, since time.Time) ([]job.JobViewBucket, error) {
+	r.since = append(r.since, since)
+	var buckets []job.JobViewBucket
+	for _, b := range r.buckets {
+		if !b.Hour.Before(since.Truncate(time.Hour)) {
+			buckets = append(buckets, b)
+		}
+	}
+	return buckets, nil
+}
+
+func (r *featuredJobRepo) FindPublishedByIDs(ctx context.Context, ids []int64) ([]job.Job, error) {
+	var jobs []job.Job
+	for _, id := range ids {
+		if j, ok := r.jobs[id]; ok && j.IsPublished() {
+			jobs = append(jobs, *j)
+		}
+	}
+	return jobs, nil
+}
+
+func (r *featuredJobRepo) SetFeatured(ctx context.Context, jobID int64, featured bool, from, until *time.Time) error {
+	j := r.jobs[jobID]
+	j.IsFeatured, j.FeaturedFrom, j.FeaturedUntil = featured, from, until
+	return nil
+}
+
+func newFeaturedJobRepo(now time.Time) *featuredJobRepo {
+	hour := now.Truncate(time.Hour)
+	from, until := now.Add(-time.Hour), now.Add(time.Hour)
+	later := now.Add(24 * time.Hour)
+	return &featuredJobRepo{
+		jobs: map[int64]*job.Job{
+			1: {ID: 1, Title: "Viral last week", Status: "published"},
+			2: {ID: 2, Title: "Viewed today", Status: "published"},
+			3: {ID: 3, Title: "Closed", Status: "closed"},
+			4: {ID: 4, Title: "Not viewed", Status: "published"},
+			5: {ID: 5, Title: "Campaign", Status: "published", IsFeatured: true, FeaturedFrom: &from, FeaturedUntil: &until},
+			6: {ID: 6, Title: "Campaign next week", Status: "published", IsFeatured: true, FeaturedFrom: &later},
+			7: {ID: 7, Title: "Campaign ended", Status: "published", IsFeatured: true, FeaturedUntil: &from},
+		},
+		buckets: []job.JobViewBucket{
+			{JobID: 1, Hour: hour.Add(-6 * 24 * time.Hour), Views: 10},
+			{JobID: 2, Hour: hour, Views: 4},
+			{JobID: 3, Hour: hour, Views: 50},
+			{JobID: 5, Hour: hour, Views: 1},
+			{JobID: 6, Hour: hour.Add(-time.Hour), Views: 1},
+		},
+	}
+}
+
+func TestJobIsFeaturedAt_WindowBoundaries(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	before, after := now.Add(-time.Second), now.Add(time.Second)
+
+	for name, tc := range map[string]struct {
+		job  job.Job
+		want bool
+	}{
+		"not featured":              {job.Job{FeaturedFrom: &before, FeaturedUntil: &after}, false},
+		"open window":               {job.Job{IsFeatured: true}, true},
+		"starts now":                {job.Job{IsFeatured: true, FeaturedFrom: &now}, true},
+		"starts in a second":        {job.Job{IsFeatured: true, FeaturedFrom: &after}, false},
+		"ends now":                  {job.Job{IsFeatured: true, FeaturedUntil: &now}, false},
+		"ends in a second":          {job.Job{IsFeatured: true, FeaturedUntil: &after}, true},
+		"ended a second ago":        {job.Job{IsFeatured: true, FeaturedFrom: &before, FeaturedUntil: &before}, false},
+		"within a bounded schedule": {job.Job{IsFeatured: true, FeaturedFrom: &before, FeaturedUntil: &after}, true},
+	} {
+		assert.Equal(t, tc.want, tc.job.IsFeaturedAt(now), name)
+	}
+}
+
+func TestRankJobsByViews_DecaysOlderViews(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	buckets := []job.JobViewBucket{
+		{JobID: 1, Hour: now.Add(-6 * 24 * time.Hour), Views: 10},
+		{JobID: 2, Hour: now, Views: 4},
+		{JobID: 3, Hour: now.Add(-time.Hour), Views: 2},
+		{JobID: 3, Hour: now.Add(-2 * time.Hour), Views: 2},
+	}
+
+	// 10 views six days ago weigh 10 * 0.5^3 = 1.25, less than 4 views now
+	assert.Equal(t, []int64{2, 3, 1}, job.RankJobsByViews(buckets, now, job.FeaturedViewHalfLife))
+
+	// Without decay every view counts once; the 4-view tie goes to the newer job
+	assert.Equal(t, []int64{1, 3, 2}, job.RankJobsByViews(buckets, now, 0))
+	assert.Empty(t, job.RankJobsByViews(nil, now, job.FeaturedViewHalfLife))
+}
+
+func TestGetFeaturedJobs_CuratedFirstThenDecayedViews(t *testing.T) {
+	repo := newFeaturedJobRepo(time.Now())
+	svc := service.NewJobService(repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil, nil, "")
+
+	before := time.Now()
+	jobs, err := svc.GetFeaturedJobs(context.Background(), 10)
+	require.NoError(t, err)
+
+	// Campaign 5 is in its window; 6 has not started and 7 has ended, so they only rank
+	// by views. Closed job 3 is left out despite its views, and 5 is not listed twice.
+	assert.Equal(t, []int64{5, 2, 1, 6}, helpers.JobIDs(jobs))
+
+	// Views are counted over the last seven days
+	require.Len(t, repo.since, 1)
+	assert.WithinDuration(t, before.Add(-job.FeaturedViewWindow), repo.since[0], time.Second)
+
+	jobs, err = svc.GetFeaturedJobs(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{5, 2}, helpers.JobIDs(jobs))
+}
+
+func TestGetTrendingJobs_RanksViewsWithinWindow(t *testing.T) {
+	repo := newFeaturedJobRepo(time.Now())
+	svc := service.NewJobService(repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil, nil, "")
+
+	before := time.Now()
+	jobs, err := svc.GetTrendingJobs(context.Background(), 10)
+	require.NoError(t, err)
+
+	// Job 1's views from six days ago are outside the window; curation plays no part
+	assert.Equal(t, []int64{2, 6, 5}, helpers.JobIDs(jobs))
+	require.Len(t, repo.since, 1)
+	assert.WithinDuration(t, before.Add(-job.TrendingViewWindow), repo.since[0], time.Second)
+}
+
+func TestFeaturedJobs_CachedUntilFeatureChanges(t *testing.T) {
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	t.Cleanup(memCache.Stop)
+	repo := newFeaturedJobRepo(time.Now())
+	jobSvc := service.NewJobService(repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, memCache, nil, "")
+	adminSvc := service.NewAdminJobService(repo, nil, nil, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, memCache, nil)
+	ctx := context.Background()
+
+	featured, err := jobSvc.GetFeaturedJobs(ctx, 10)
+	require.NoError(t, err)
+	trending, err := jobSvc.GetTrendingJobs(ctx, 10)
+	require.NoError(t, err)
+
+	// Later reads come from the cache, even for a different limit
+	repo.buckets = nil
+	again, err := jobSvc.GetFeaturedJobs(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, helpers.JobIDs(featured)[:3], helpers.JobIDs(again))
+	again, err = jobSvc.GetTrendingJobs(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, helpers.JobIDs(trending), helpers.JobIDs(again))
+	assert.Len(t, repo.since, 2)
+
+	// Featuring a job drops both cached lists
+	_, err = adminSvc.FeatureJob(ctx, 4, 99, &admin.AdminFeatureJobRequest{})
+	require.NoError(t, err)
+	featured, err = jobSvc.GetFeaturedJobs(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{4, 5}, helpers.JobIDs(featured))
+	trending, err = jobSvc.GetTrendingJobs(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, trending)
+
+	_, err = adminSvc.UnfeatureJob(ctx, 5, 99)
+	require.NoError(t, err)
+	featured, err = jobSvc.GetFeaturedJobs(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{4}, helpers.JobIDs(featured))
+	assert.False(t, repo.jobs[5].IsFeatured)
+	assert.Nil(t, repo.jobs[5].FeaturedFrom)
+}
+
+func TestFeatureJob_ValidatesWindowAndStatus(t *testing.T) {
+	repo := newFeaturedJobRepo(time.Now())
+	svc := service.NewAdminJobService(repo, nil, nil, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil)
+	ctx := context.Background()
+	now := time.Now()
+	past, soon, later := now.Add(-time.Hour), now.Add(time.Hour), now.Add(48*time.Hour)
+
+	for name, req := range map[string]*admin.AdminFeatureJobRequest{
+		"ends before it starts": {FeaturedFrom: &later, FeaturedUntil: &soon},
+		"ends when it starts":   {FeaturedFrom: &soon, FeaturedUntil: &soon},
+		"already ended":         {FeaturedUntil: &past},
+	} {
+		_, err := svc.FeatureJob(ctx, 4, 99, req)
+		assert.ErrorIs(t, err, job.ErrInvalidFeaturedWindow, name)
+	}
+
+	_, err := svc.FeatureJob(ctx, 3, 99, &admin.AdminFeatureJobRequest{})
+	assert.ErrorIs(t, err, job.ErrJobNotFeaturable)
+	_, err = svc.FeatureJob(ctx, 42, 99, &admin.AdminFeatureJobRequest{})
+	assert.ErrorIs(t, err, job.ErrJobNotFound)
+
+	// A schedule starting next week is stored as requested
+	updated, err := svc.FeatureJob(ctx, 4, 99, &admin.AdminFeatureJobRequest{FeaturedFrom: &later})
+	require.NoError(t, err)
+	j := updated.(*job.Job)
+	assert.True(t, j.IsFeatured)
+	assert.Equal(t, &later, j.FeaturedFrom)
+	assert.Nil(t, j.FeaturedUntil)
+	assert.False(t, j.IsFeaturedAt(now))
+}
@@ -0,0 +1,68 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+type followedJobRepo struct {
+	job.JobRepository
+	job        *job.Job
+	notifiedAt *time.Time
+}
+
+func (r *followedJobRepo) FindByID(ctx context.Context, id int64) (*job.Job, error) {
+	return r.job, nil
+}
+
+func (r *followedJobRepo) MarkFollowersNotified(ctx context.Context, jobID int64, window time.Duration) (bool, error) {
+	now := time.Now()
+	if r.notifiedAt != nil && r.notifiedAt.After(now.Add(-window)) {
+		return false, nil
+	}
+	r.notifiedAt = &now
+	return true, nil
+}
+
+func TestNotifyJobPublished_RespectsPreferencesAndDedupsRepublish(t *testing.T) {
+	jobRepo := &followedJobRepo{job: &job.Job{ID: 9, CompanyID: 3, Title: "Backend Engineer", Slug: "backend-engineer"}}
+	companyRepo := &fakes.CompanyRepository{Followers: []company.CompanyFollower{
+		{CompanyID: 3, UserID: 1},
+		{CompanyID: 3, UserID: 2},
+		{CompanyID: 3, UserID: 3},
+	}}
+	userRepo := &fakes.UserRepository{Users: fakes.NumberedUsers(1, 2, 3), Preferences: map[int64]*user.UserPreference{
+		2: {UserID: 2, EmailNotifications: false, PushNotifications: true},
+		3: {UserID: 3, EmailNotifications: true, PushNotifications: false},
+	}}
+	emailSvc := &fakes.EmailService{}
+	pushSvc := &fakes.PushService{}
+	notifier := service.NewFollowerNotifier(jobRepo, companyRepo, userRepo, emailSvc, service.NewNotificationDispatcher(userRepo, pushSvc, nil, nil, nil))
+
+	stats, err := notifier.NotifyJobPublished(context.Background(), 9)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, stats.Followers)
+	assert.Equal(t, 2, stats.EmailsSent)
+	assert.Equal(t, 2, stats.PushesSent)
+	assert.ElementsMatch(t, []string{"user1@test.id", "user3@test.id"}, emailSvc.Recipients())
+	assert.ElementsMatch(t, []int64{1, 2}, pushSvc.UserIDs)
+	assert.Equal(t, "New job at Acme: Backend Engineer", pushSvc.Message.Body)
+
+	// Republishing within the window must not notify anyone again
+	stats, err = notifier.NotifyJobPublished(context.Background(), 9)
+	require.NoError(t, err)
+	assert.True(t, stats.Skipped)
+	assert.Len(t, emailSvc.Sent, 2)
+	assert.Len(t, pushSvc.UserIDs, 2)
+}
@@ -0,0 +1,57 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/service"
+)
+
+func TestNominatimGeocoder_ParsesBestMatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Jl. Sudirman 1, Jakarta", r.URL.Query().Get("q"))
+		assert.Equal(t, "id", r.URL.Query().Get("countrycodes"))
+		assert.NotEmpty(t, r.Header.Get("User-Agent"))
+		w.Write([]byte(`[{"lat":"-6.2088","lon":"106.8456","importance":0.72}]`))
+	}))
+	defer srv.Close()
+
+	geocoder, err := service.NewGeocoder(service.GeocoderConfig{Provider: service.GeocoderNominatim, BaseURL: srv.URL})
+	require.NoError(t, err)
+
+	result, err := geocoder.Geocode(context.Background(), "Jl. Sudirman 1, Jakarta")
+	require.NoError(t, err)
+	assert.InDelta(t, -6.2088, result.Latitude, 1e-9)
+	assert.InDelta(t, 106.8456, result.Longitude, 1e-9)
+	assert.Equal(t, service.GeocoderNominatim, result.Provider)
+	assert.InDelta(t, 0.72, result.Confidence, 1e-9)
+}
+
+func TestGoogleGeocoder_RatesConfidenceAndReportsNoResult(t *testing.T) {
+	body := `{"status":"OK","results":[{"partial_match":true,"geometry":{"location":{"lat":-6.9,"lng":107.6},"location_type":"ROOFTOP"}}]}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.URL.Query().Get("key"))
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	_, err := service.NewGeocoder(service.GeocoderConfig{Provider: service.GeocoderGoogle})
+	assert.Error(t, err, "google requires an API key")
+
+	geocoder, err := service.NewGeocoder(service.GeocoderConfig{Provider: service.GeocoderGoogle, BaseURL: srv.URL, APIKey: "secret"})
+	require.NoError(t, err)
+
+	result, err := geocoder.Geocode(context.Background(), "Jl. Asia Afrika, Bandung")
+	require.NoError(t, err)
+	assert.Equal(t, service.GeocoderGoogle, result.Provider)
+	assert.InDelta(t, 0.5, result.Confidence, 1e-9, "partial matches are halved")
+
+	body = `{"status":"ZERO_RESULTS","results":[]}`
+	_, err = geocoder.Geocode(context.Background(), "nowhere")
+	assert.ErrorIs(t, err, service.ErrGeocodeNoResult)
+}
@@ -0,0 +1,189 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "golang.org/x/image/webp"
+
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// fixtureImage returns a width x height gradient, so resized copies are not trivially uniform
+func fixtureImage(width, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+func fixturePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, fixtureImage(width, height)))
+	return buf.Bytes()
+}
+
+func fixtureJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, fixtureImage(width, height), nil))
+	return buf.Bytes()
+}
+
+// withEXIFOrientation inserts an EXIF segment with the given orientation into a JPEG
+func withEXIFOrientation(data []byte, orientation uint16) []byte {
+	// Little endian TIFF header, one IFD entry: orientation (0x0112), SHORT, count 1
+	tiff := []byte{'I', 'I', 0x2A, 0x00, 0x08, 0x00, 0x00, 0x00, 0x01, 0x00}
+	entry := make([]byte, 12)
+	binary.LittleEndian.PutUint16(entry[0:], 0x0112)
+	binary.LittleEndian.PutUint16(entry[2:], 3)
+	binary.LittleEndian.PutUint32(entry[4:], 1)
+	binary.LittleEndian.PutUint16(entry[8:], orientation)
+	tiff = append(append(tiff, entry...), 0, 0, 0, 0)
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	return append(append(append([]byte{}, data[:2]...), segment...), data[2:]...)
+}
+
+func decodedSize(t *testing.T, data []byte) (int, int, string) {
+	t.Helper()
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	require.NoError(t, err)
+	return cfg.Width, cfg.Height, format
+}
+
+func variantSizes(t *testing.T, processed *service.ProcessedImage) map[string][2]int {
+	t.Helper()
+	sizes := map[string][2]int{}
+	for _, variant := range processed.Variants {
+		width, height, format := decodedSize(t, variant.Data)
+		assert.Equal(t, variant.Format, format)
+		sizes[service.ImageVariantKey(variant.Name, variant.Format)] = [2]int{width, height}
+	}
+	return sizes
+}
+
+func TestImageProcessor_RejectsImagesBelowMinimumDimensions(t *testing.T) {
+	processor := service.NewImageProcessor()
+	tests := []struct {
+		name          string
+		width, height int
+		spec          service.ImageSpec
+	}{
+		{"logo too narrow", 199, 400, service.LogoImageSpec},
+		{"logo too short", 400, 199, service.LogoImageSpec},
+		{"banner too narrow", 1199, 400, service.BannerImageSpec},
+		{"banner too short", 1600, 299, service.BannerImageSpec},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := processor.Process(fixturePNG(t, tt.width, tt.height), tt.spec)
+			assert.ErrorIs(t, err, service.ErrImageTooSmall)
+		})
+	}
+}
+
+func TestImageProcessor_RendersLogoVariants(t *testing.T) {
+	processed, err := service.NewImageProcessor().Process(fixturePNG(t, 1000, 500), service.LogoImageSpec)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][2]int{
+		"200_webp": {200, 100},
+		"200_jpeg": {200, 100},
+		"400_webp": {400, 200},
+		"400_jpeg": {400, 200},
+	}, variantSizes(t, processed))
+
+	width, height, format := decodedSize(t, processed.Original.Data)
+	assert.Equal(t, [2]int{1000, 500}, [2]int{width, height})
+	assert.Equal(t, "png", format)
+}
+
+func TestImageProcessor_RendersBannerVariantWithoutUpscaling(t *testing.T) {
+	processed, err := service.NewImageProcessor().Process(fixturePNG(t, 2400, 600), service.BannerImageSpec)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][2]int{"1200_webp": {1200, 300}, "1200_jpeg": {1200, 300}}, variantSizes(t, processed))
+
+	// A logo at the minimum size is not scaled up for the larger variant
+	processed, err = service.NewImageProcessor().Process(fixturePNG(t, 250, 250), service.LogoImageSpec)
+	require.NoError(t, err)
+	assert.Equal(t, [2]int{250, 250}, variantSizes(t, processed)["400_webp"])
+}
+
+func TestImageProcessor_StripsEXIFAndAppliesOrientation(t *testing.T) {
+	// Orientation 6 means the stored 300x240 pixels display rotated to 240x300
+	data := withEXIFOrientation(fixtureJPEG(t, 300, 240), 6)
+
+	processed, err := service.NewImageProcessor().Process(data, service.LogoImageSpec)
+	require.NoError(t, err)
+	assert.NotContains(t, string(processed.Original.Data), "Exif")
+	width, height, _ := decodedSize(t, processed.Original.Data)
+	assert.Equal(t, [2]int{240, 300}, [2]int{width, height})
+	assert.Equal(t, [2]int{160, 200}, variantSizes(t, processed)["200_jpeg"])
+
+	// Upright JPEGs only lose their metadata segments
+	plain := fixtureJPEG(t, 300, 240)
+	processed, err = service.NewImageProcessor().Process(withEXIFOrientation(plain, 1), service.LogoImageSpec)
+	require.NoError(t, err)
+	assert.Equal(t, plain, processed.Original.Data)
+}
+
+func TestImageProcessor_RejectsUndecodableData(t *testing.T) {
+	_, err := service.NewImageProcessor().Process([]byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`), service.LogoImageSpec)
+	assert.ErrorIs(t, err, service.ErrImageUnprocessable)
+}
+
+func TestUploadService_UploadImageStoresVariants(t *testing.T) {
+	store := fakes.NewObjectStore()
+	svc := helpers.NewS3UploadService(store)
+	file := helpers.NewFileHeader(t, "logo.png", "image/png", fixturePNG(t, 400, 400))
+
+	stored, err := svc.UploadImage(context.Background(), file, "company/logos", service.LogoImageSpec)
+	require.NoError(t, err)
+
+	base := strings.TrimSuffix(stored.URL, ".png")
+	assert.Equal(t, map[string]string{
+		"200_webp": base + "_200.webp",
+		"200_jpeg": base + "_200.jpg",
+		"400_webp": base + "_400.webp",
+		"400_jpeg": base + "_400.jpg",
+	}, map[string]string(stored.Variants))
+	assert.Len(t, store.Objects, 5)
+	assert.Equal(t, "image/webp", store.ContentTypes[strings.TrimPrefix(base, "https://cdn.example.com/keerja/")+"_200.webp"])
+}
+
+func TestUploadService_UploadImageFallsBackToOriginal(t *testing.T) {
+	store := fakes.NewObjectStore()
+	svc := helpers.NewS3UploadService(store)
+	svg := []byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`)
+
+	stored, err := svc.UploadImage(context.Background(), helpers.NewFileHeader(t, "logo.svg", "image/svg+xml", svg), "company/logos", service.LogoImageSpec)
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(stored.URL, ".svg"))
+	assert.Empty(t, stored.Variants)
+	assert.Equal(t, svg, store.Objects[strings.TrimPrefix(stored.URL, "https://cdn.example.com/keerja/")])
+
+	// Too small images are rejected rather than stored
+	_, err = svc.UploadImage(context.Background(), helpers.NewFileHeader(t, "logo.png", "image/png", fixturePNG(t, 100, 100)), "company/logos", service.LogoImageSpec)
+	assert.ErrorIs(t, err, service.ErrImageTooSmall)
+	assert.Len(t, store.Objects, 1)
+}
@@ -0,0 +1,147 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/admin"
+	"keerja-backend/internal/domain/audit"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+const impersonationSecret = "impersonation-test-secret"
+
+// memoryImpersonationRepo keeps impersonation sessions in a map
+type memoryImpersonationRepo struct {
+	sessions map[int64]*admin.ImpersonationSession
+}
+
+func (r *memoryImpersonationRepo) Create(ctx context.Context, session *admin.ImpersonationSession) error {
+	session.ID = int64(len(r.sessions) + 1)
+	copied := *session
+	r.sessions[session.ID] = &copied
+	return nil
+}
+
+func (r *memoryImpersonationRepo) FindByID(ctx context.Context, id int64) (*admin.ImpersonationSession, error) {
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (r *memoryImpersonationRepo) End(ctx context.Context, id int64, endedAt time.Time) error {
+	if session, ok := r.sessions[id]; ok && session.EndedAt == nil {
+		session.EndedAt = &endedAt
+	}
+	return nil
+}
+
+type impersonationFixture struct {
+	svc          admin.ImpersonationService
+	auditService audit.AuditService
+	auditRepo    *fakes.AuditLogRepository
+}
+
+func newImpersonationFixture(t *testing.T) *impersonationFixture {
+	t.Helper()
+
+	auditRepo := &fakes.AuditLogRepository{}
+	auditService := service.NewAuditService(auditRepo, 10)
+	t.Cleanup(auditService.Close)
+
+	// User 1 is a job seeker and user 2 has an admin account
+	users := &fakes.UserRepository{Users: map[int64]*user.User{
+		1: {ID: 1, Email: "seeker@example.com", UserType: "jobseeker", Status: "active"},
+		2: {ID: 2, Email: "ops@example.com", UserType: "admin", Status: "active"},
+	}}
+	repo := &memoryImpersonationRepo{sessions: map[int64]*admin.ImpersonationSession{}}
+	return &impersonationFixture{
+		svc:          service.NewImpersonationService(repo, users, auditService, impersonationSecret, 15*time.Minute),
+		auditService: auditService,
+		auditRepo:    auditRepo,
+	}
+}
+
+func TestImpersonation_TokenCarriesUserAndAdmin(t *testing.T) {
+	f := newImpersonationFixture(t)
+
+	grant, err := f.svc.StartImpersonation(context.Background(), 7, 1, "ticket #123: applications missing")
+	require.NoError(t, err)
+
+	claims, err := utils.ValidateToken(grant.AccessToken, impersonationSecret)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), claims.UserID)
+	assert.Equal(t, "jobseeker", claims.UserType)
+	assert.Equal(t, int64(7), claims.ImpersonatorID)
+	assert.Equal(t, grant.Session.ID, claims.ImpersonationID)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), claims.ExpiresAt.Time, 5*time.Second)
+
+	_, err = utils.ValidateAdminToken(grant.AccessToken, impersonationSecret)
+	assert.Error(t, err, "impersonation tokens are not admin tokens")
+}
+
+func TestImpersonation_AuditsStartRequestsAndEnd(t *testing.T) {
+	f := newImpersonationFixture(t)
+	ctx := context.Background()
+
+	grant, err := f.svc.StartImpersonation(ctx, 7, 1, "ticket #123")
+	require.NoError(t, err)
+	sessionID := grant.Session.ID
+
+	active, err := f.svc.IsImpersonationActive(ctx, sessionID)
+	require.NoError(t, err)
+	assert.True(t, active)
+
+	f.svc.RecordImpersonatedRequest(ctx, admin.ImpersonatedRequest{
+		SessionID: sessionID, AdminID: 7, UserID: 1, Method: "POST", Path: "/api/v1/users/me", Status: 403, Blocked: true,
+	})
+
+	assert.ErrorIs(t, f.svc.EndImpersonation(ctx, 8, sessionID), admin.ErrImpersonationNotFound, "only the admin who started it may end it")
+	require.NoError(t, f.svc.EndImpersonation(ctx, 7, sessionID))
+	require.NoError(t, f.svc.EndImpersonation(ctx, 7, sessionID), "ending twice is not an error")
+
+	active, err = f.svc.IsImpersonationActive(ctx, sessionID)
+	require.NoError(t, err)
+	assert.False(t, active)
+
+	f.auditService.Close()
+	logs := f.auditRepo.Stored()
+	require.Len(t, logs, 3)
+
+	actions := []string{logs[0].Action, logs[1].Action, logs[2].Action}
+	assert.Equal(t, []string{audit.ActionImpersonationStarted, audit.ActionImpersonationRequest, audit.ActionImpersonationEnded}, actions)
+	for _, entry := range logs {
+		assert.Equal(t, audit.ActorAdmin, entry.ActorType)
+		assert.Equal(t, int64(7), *entry.ActorID)
+		assert.Equal(t, audit.EntityUser, entry.EntityType)
+		assert.Equal(t, int64(1), entry.EntityID)
+		assert.EqualValues(t, sessionID, entry.Metadata["session_id"])
+	}
+	assert.Equal(t, "ticket #123", logs[0].Metadata["reason"])
+	assert.Equal(t, "/api/v1/users/me", logs[1].Metadata["path"])
+	assert.Equal(t, true, logs[1].Metadata["blocked"])
+}
+
+func TestImpersonation_RejectsAdminsAndUnknownUsers(t *testing.T) {
+	f := newImpersonationFixture(t)
+	ctx := context.Background()
+
+	_, err := f.svc.StartImpersonation(ctx, 7, 2, "checking")
+	assert.ErrorIs(t, err, admin.ErrCannotImpersonateAdmin)
+
+	_, err = f.svc.StartImpersonation(ctx, 7, 99, "checking")
+	assert.ErrorIs(t, err, service.ErrUserNotFound)
+
+	f.auditService.Close()
+	assert.Empty(t, f.auditRepo.Stored())
+}
@@ -0,0 +1,298 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// interviewSlotRepo adds interview slots, booking links and interviews to the fake
+// application repository, claiming slots conditionally the way the database does.
+type interviewSlotRepo struct {
+	*fakes.ApplicationRepository
+
+	slots           map[int64]*application.InterviewSlot
+	links           map[string]*application.InterviewBookingLink
+	interviews      map[int64]*application.Interview
+	createdSlots    []application.InterviewSlot
+	failInterviews  bool
+	nextInterviewID int64
+}
+
+func newInterviewSlotRepo() *interviewSlotRepo {
+	return &interviewSlotRepo{
+		ApplicationRepository: fakes.NewApplicationRepository(),
+		slots:                 make(map[int64]*application.InterviewSlot),
+		links:                 make(map[string]*application.InterviewBookingLink),
+		interviews:            make(map[int64]*application.Interview),
+	}
+}
+
+func (r *interviewSlotRepo) CreateInterviewSlots(ctx context.Context, slots []application.InterviewSlot) (int64, error) {
+	r.Lock()
+	defer r.Unlock()
+	r.createdSlots = append(r.createdSlots, slots...)
+	return int64(len(slots)), nil
+}
+
+func (r *interviewSlotRepo) FindInterviewSlotByID(ctx context.Context, id int64) (*application.InterviewSlot, error) {
+	r.Lock()
+	defer r.Unlock()
+	if slot, ok := r.slots[id]; ok {
+		stored := *slot
+		return &stored, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *interviewSlotRepo) FindBookingLinkByTokenHash(ctx context.Context, tokenHash string) (*application.InterviewBookingLink, error) {
+	r.Lock()
+	defer r.Unlock()
+	if link, ok := r.links[tokenHash]; ok {
+		stored := *link
+		return &stored, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *interviewSlotRepo) ClaimInterviewSlot(ctx context.Context, link *application.InterviewBookingLink, slotID int64) error {
+	r.Lock()
+	defer r.Unlock()
+	stored := r.links[link.TokenHash]
+	if stored.UsedAt != nil || !stored.ExpiresAt.After(time.Now()) {
+		return application.ErrBookingLinkUsed
+	}
+	slot := r.slots[slotID]
+	if slot == nil || slot.CompanyID != link.CompanyID || slot.ApplicationID != nil {
+		return application.ErrInterviewSlotTaken
+	}
+	now := time.Now()
+	stored.UsedAt, stored.SlotID = &now, &slotID
+	slot.ApplicationID, slot.BookedAt = &link.ApplicationID, &now
+	return nil
+}
+
+func (r *interviewSlotRepo) UnclaimInterviewSlot(ctx context.Context, linkID, slotID int64) error {
+	r.Lock()
+	defer r.Unlock()
+	for _, link := range r.links {
+		if link.ID == linkID {
+			link.UsedAt, link.SlotID = nil, nil
+		}
+	}
+	slot := r.slots[slotID]
+	slot.ApplicationID, slot.BookedAt = nil, nil
+	return nil
+}
+
+func (r *interviewSlotRepo) SetInterviewSlotInterview(ctx context.Context, slotID, interviewID int64) error {
+	r.Lock()
+	defer r.Unlock()
+	r.slots[slotID].InterviewID = &interviewID
+	return nil
+}
+
+func (r *interviewSlotRepo) ReleaseInterviewSlot(ctx context.Context, interviewID int64) error {
+	r.Lock()
+	defer r.Unlock()
+	for _, slot := range r.slots {
+		if slot.InterviewID != nil && *slot.InterviewID == interviewID {
+			slot.ApplicationID, slot.InterviewID, slot.BookedAt = nil, nil, nil
+		}
+	}
+	return nil
+}
+
+func (r *interviewSlotRepo) CreateInterview(ctx context.Context, interview *application.Interview) error {
+	r.Lock()
+	defer r.Unlock()
+	if r.failInterviews {
+		return errors.New("database unavailable")
+	}
+	r.nextInterviewID++
+	interview.ID = r.nextInterviewID
+	stored := *interview
+	r.interviews[interview.ID] = &stored
+	return nil
+}
+
+func (r *interviewSlotRepo) FindInterviewByID(ctx context.Context, id int64) (*application.Interview, error) {
+	r.Lock()
+	defer r.Unlock()
+	if interview, ok := r.interviews[id]; ok {
+		stored := *interview
+		return &stored, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *interviewSlotRepo) UpdateInterview(ctx context.Context, interview *application.Interview) error {
+	r.Lock()
+	defer r.Unlock()
+	stored := *interview
+	r.interviews[interview.ID] = &stored
+	return nil
+}
+
+// slot returns a copy of the stored slot
+func (r *interviewSlotRepo) slot(id int64) application.InterviewSlot {
+	r.Lock()
+	defer r.Unlock()
+	return *r.slots[id]
+}
+
+// addBookingLink stores a link for the application and returns its token
+func (r *interviewSlotRepo) addBookingLink(id, applicationID int64, expiresAt time.Time) string {
+	r.Lock()
+	defer r.Unlock()
+	token := fmt.Sprintf("booking-token-%d", id)
+	link := &application.InterviewBookingLink{
+		ID:            id,
+		ApplicationID: applicationID,
+		CompanyID:     3,
+		TokenHash:     hashToken(token),
+		ExpiresAt:     expiresAt,
+	}
+	r.links[link.TokenHash] = link
+	return token
+}
+
+// hashToken hashes a booking token the way the service stores it
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func newInterviewSlotService(repo *interviewSlotRepo) application.ApplicationService {
+	jobRepo := &fakes.JobRepository{Job: &job.Job{ID: 10, CompanyID: 3, Title: "Backend Engineer"}}
+	userRepo := &fakes.UserRepository{User: &user.User{ID: 7, Email: "seeker@example.com", FullName: "Sari"}}
+	return helpers.NewApplicationService(repo, jobRepo, userRepo, &fakes.CompanyRepository{})
+}
+
+func TestCreateInterviewSlots_ExpandsWorkingHoursOnWeekdays(t *testing.T) {
+	repo := newInterviewSlotRepo()
+	svc := newInterviewSlotService(repo)
+
+	// 2030-01-07 is a Monday; the batch runs through Sunday
+	result, err := svc.CreateInterviewSlots(context.Background(), 3, 5, &application.CreateInterviewSlotsRequest{
+		StartDate:       "2030-01-07",
+		EndDate:         "2030-01-13",
+		DayStart:        "09:00",
+		DayEnd:          "11:00",
+		DurationMinutes: 45,
+		BufferMinutes:   15,
+		Timezone:        "Asia/Jakarta",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), result.Created)
+	assert.Equal(t, int64(0), result.Skipped)
+
+	require.Len(t, repo.createdSlots, 10)
+	first, second := repo.createdSlots[0], repo.createdSlots[1]
+	assert.Equal(t, time.Date(2030, 1, 7, 2, 0, 0, 0, time.UTC), first.StartsAt)
+	assert.Equal(t, time.Date(2030, 1, 7, 2, 45, 0, 0, time.UTC), first.EndsAt)
+	assert.Equal(t, time.Date(2030, 1, 7, 3, 0, 0, 0, time.UTC), second.StartsAt)
+	assert.Equal(t, int64(5), first.InterviewerID)
+	assert.Equal(t, "online", first.InterviewType)
+	assert.Equal(t, time.Date(2030, 1, 11, 3, 0, 0, 0, time.UTC), repo.createdSlots[9].StartsAt)
+}
+
+func TestCreateInterviewSlots_RejectsInvalidBatches(t *testing.T) {
+	svc := newInterviewSlotService(newInterviewSlotRepo())
+	pastDay := time.Now().AddDate(0, 0, -2).Format(time.DateOnly)
+
+	tests := map[string]*application.CreateInterviewSlotsRequest{
+		"end before start": {StartDate: "2030-01-08", EndDate: "2030-01-07", DayStart: "09:00", DayEnd: "17:00", DurationMinutes: 30},
+		"range too long":   {StartDate: "2030-01-01", EndDate: "2030-02-01", DayStart: "09:00", DayEnd: "17:00", DurationMinutes: 30},
+		"day end first":    {StartDate: "2030-01-07", EndDate: "2030-01-07", DayStart: "17:00", DayEnd: "09:00", DurationMinutes: 30},
+		"too many slots":   {StartDate: "2030-01-01", EndDate: "2030-01-31", DayStart: "00:00", DayEnd: "23:50", DurationMinutes: 10, IncludeWeekends: true},
+		"only past slots":  {StartDate: pastDay, EndDate: pastDay, DayStart: "00:00", DayEnd: "23:50", DurationMinutes: 60, IncludeWeekends: true},
+	}
+	for name, req := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := svc.CreateInterviewSlots(context.Background(), 3, 5, req)
+			assert.ErrorIs(t, err, application.ErrInvalidInterviewSlots)
+		})
+	}
+}
+
+func TestBookInterviewSlot_SchedulesInterviewOnceAndCancelReleasesSlot(t *testing.T) {
+	repo := newInterviewSlotRepo()
+	companyID := int64(3)
+	repo.Apps[1] = &application.JobApplication{ID: 1, JobID: 10, UserID: 7, CompanyID: &companyID, Status: "interview"}
+	repo.Apps[2] = &application.JobApplication{ID: 2, JobID: 10, UserID: 8, CompanyID: &companyID, Status: "interview"}
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Minute).UTC()
+	repo.slots[1] = &application.InterviewSlot{ID: 1, CompanyID: 3, InterviewerID: 5, StartsAt: startsAt, EndsAt: startsAt.Add(time.Hour), Timezone: "Asia/Jakarta", InterviewType: "online"}
+	first := repo.addBookingLink(1, 1, time.Now().Add(time.Hour))
+	second := repo.addBookingLink(2, 2, time.Now().Add(time.Hour))
+	svc := newInterviewSlotService(repo)
+
+	interview, err := svc.BookInterviewSlot(context.Background(), first, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), interview.ApplicationID)
+	assert.Equal(t, startsAt, interview.ScheduledAt)
+	assert.Equal(t, "Asia/Jakarta", interview.Timezone)
+	booked := repo.slot(1)
+	require.NotNil(t, booked.InterviewID)
+	assert.Equal(t, interview.ID, *booked.InterviewID)
+
+	_, err = svc.BookInterviewSlot(context.Background(), first, 1)
+	assert.ErrorIs(t, err, application.ErrBookingLinkUsed)
+
+	_, err = svc.BookInterviewSlot(context.Background(), second, 1)
+	assert.ErrorIs(t, err, application.ErrInterviewSlotTaken)
+
+	require.NoError(t, svc.CancelInterview(context.Background(), interview.ID, 5, ""))
+	released := repo.slot(1)
+	assert.False(t, released.IsBooked())
+
+	_, err = svc.BookInterviewSlot(context.Background(), second, 1)
+	assert.NoError(t, err)
+}
+
+func TestBookInterviewSlot_ReopensSlotWhenSchedulingFails(t *testing.T) {
+	repo := newInterviewSlotRepo()
+	companyID := int64(3)
+	repo.Apps[1] = &application.JobApplication{ID: 1, JobID: 10, UserID: 7, CompanyID: &companyID, Status: "interview"}
+	startsAt := time.Now().Add(48 * time.Hour).UTC()
+	repo.slots[1] = &application.InterviewSlot{ID: 1, CompanyID: 3, InterviewerID: 5, StartsAt: startsAt, EndsAt: startsAt.Add(time.Hour), Timezone: "UTC"}
+	token := repo.addBookingLink(1, 1, time.Now().Add(time.Hour))
+	repo.failInterviews = true
+	svc := newInterviewSlotService(repo)
+
+	_, err := svc.BookInterviewSlot(context.Background(), token, 1)
+	require.Error(t, err)
+	reopened := repo.slot(1)
+	assert.False(t, reopened.IsBooked())
+	link, err := repo.FindBookingLinkByTokenHash(context.Background(), hashToken(token))
+	require.NoError(t, err)
+	assert.False(t, link.IsUsed())
+}
+
+func TestBookInterviewSlot_RejectsExpiredAndUnknownLinks(t *testing.T) {
+	repo := newInterviewSlotRepo()
+	companyID := int64(3)
+	repo.Apps[1] = &application.JobApplication{ID: 1, JobID: 10, UserID: 7, CompanyID: &companyID, Status: "interview"}
+	expired := repo.addBookingLink(1, 1, time.Now().Add(-time.Minute))
+	svc := newInterviewSlotService(repo)
+
+	_, err := svc.BookInterviewSlot(context.Background(), expired, 1)
+	assert.ErrorIs(t, err, application.ErrBookingLinkExpired)
+
+	_, err = svc.GetInterviewBooking(context.Background(), "unknown")
+	assert.ErrorIs(t, err, application.ErrBookingLinkNotFound)
+}
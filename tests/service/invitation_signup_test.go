@@ -0,0 +1,241 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// inviteCompanyRepo keeps invitations and employer users in memory, and creates the users
+// of invited signups in users
+type inviteCompanyRepo struct {
+	company.CompanyRepository
+
+	users       *fakes.UserRepository
+	invitations map[string]*company.CompanyInvitation
+	employers   []company.EmployerUser
+	acceptErr   error // Returned by the accept methods instead of accepting when set
+}
+
+func (r *inviteCompanyRepo) FindByID(ctx context.Context, id int64) (*company.Company, error) {
+	return &company.Company{ID: id, CompanyName: "Acme"}, nil
+}
+
+func (r *inviteCompanyRepo) FindInvitationByToken(ctx context.Context, token string) (*company.CompanyInvitation, error) {
+	inv, ok := r.invitations[token]
+	if !ok {
+		return nil, company.ErrInvitationNotFound
+	}
+	copied := *inv
+	return &copied, nil
+}
+
+func (r *inviteCompanyRepo) UpdateInvitation(ctx context.Context, invitation *company.CompanyInvitation) error {
+	copied := *invitation
+	r.invitations[invitation.Token] = &copied
+	return nil
+}
+
+func (r *inviteCompanyRepo) FindEmployerUserByUserAndCompany(ctx context.Context, userID, companyID int64) (*company.EmployerUser, error) {
+	for i := range r.employers {
+		if r.employers[i].UserID == userID && r.employers[i].CompanyID == companyID {
+			return &r.employers[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *inviteCompanyRepo) AcceptInvitation(ctx context.Context, invitation *company.CompanyInvitation, employerUser *company.EmployerUser) error {
+	if r.acceptErr != nil {
+		return r.acceptErr
+	}
+	if r.invitations[invitation.Token].Status != "pending" {
+		return company.ErrInvitationUnavailable
+	}
+	employerUser.ID = int64(len(r.employers) + 1)
+	r.employers = append(r.employers, *employerUser)
+	return r.UpdateInvitation(ctx, invitation)
+}
+
+// AcceptInvitationForNewUser creates the user only when the invitation is accepted, as the
+// repository's transaction does
+func (r *inviteCompanyRepo) AcceptInvitationForNewUser(ctx context.Context, invitation *company.CompanyInvitation, newUser *user.User, employerUser *company.EmployerUser) error {
+	if r.acceptErr != nil {
+		return r.acceptErr
+	}
+	if r.invitations[invitation.Token].Status != "pending" {
+		return company.ErrInvitationUnavailable
+	}
+	if err := r.users.Create(ctx, newUser); err != nil {
+		return err
+	}
+	employerUser.UserID = newUser.ID
+	invitation.AcceptedBy = &newUser.ID
+	return r.AcceptInvitation(ctx, invitation, employerUser)
+}
+
+// verificationEmails accepts the traditional signup's verification email
+type verificationEmails struct{ service.EmailService }
+
+func (verificationEmails) SendVerificationEmail(ctx context.Context, email, token string) error {
+	return nil
+}
+
+type inviteFixture struct {
+	companies    *inviteCompanyRepo
+	users        *fakes.UserRepository
+	emails       *fakes.EmailService
+	registration *service.RegistrationService
+	auth         *service.AuthService
+	companySvc   company.CompanyService
+}
+
+func newInviteFixture(t *testing.T) *inviteFixture {
+	t.Helper()
+
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	t.Cleanup(memCache.Stop)
+
+	position := "HR Lead"
+	f := &inviteFixture{
+		companies: &inviteCompanyRepo{invitations: map[string]*company.CompanyInvitation{
+			"valid": {ID: 1, CompanyID: 7, Email: "Rina@Example.com", FullName: "Rina", Role: "recruiter", Position: &position,
+				Token: "valid", Status: "pending", InvitedBy: 100, ExpiresAt: time.Now().Add(24 * time.Hour)},
+			"expired": {ID: 2, CompanyID: 7, Email: "budi@example.com", Role: "viewer",
+				Token: "expired", Status: "pending", InvitedBy: 100, ExpiresAt: time.Now().Add(-time.Minute)},
+			"accepted": {ID: 3, CompanyID: 7, Email: "sari@example.com", Role: "admin",
+				Token: "accepted", Status: "accepted", InvitedBy: 100, ExpiresAt: time.Now().Add(24 * time.Hour)},
+		}},
+		users:  &fakes.UserRepository{Users: map[int64]*user.User{100: {ID: 100, FullName: "Owner"}}},
+		emails: &fakes.EmailService{},
+	}
+	f.companies.users = f.users
+	f.companySvc = service.NewCompanyService(f.companies, nil, memCache, nil, nil, nil, nil, nil, nil, nil, f.users, nil, nil, nil, nil, nil)
+	f.registration = service.NewRegistrationService(f.users, &fakes.OTPRepository{}, f.emails, "secret", time.Hour, f.companySvc, nil, nil)
+	f.auth = service.NewAuthService(f.users, verificationEmails{}, service.NewInMemoryTokenStore(), service.AuthServiceConfig{}, f.companySvc)
+	return f
+}
+
+func TestGetInvitationDetails_WithoutAccount(t *testing.T) {
+	f := newInviteFixture(t)
+	ctx := context.Background()
+
+	details, err := f.companySvc.GetInvitationDetails(ctx, "valid")
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", details.CompanyName)
+	assert.Equal(t, "recruiter", details.Role)
+	assert.Equal(t, "HR Lead", *details.Position)
+	assert.Equal(t, "Owner", details.InviterName)
+	assert.Equal(t, "pending", details.Status)
+	assert.False(t, details.IsExpired)
+
+	// Expired invitations are shown with their status so the page can say why
+	details, err = f.companySvc.GetInvitationDetails(ctx, "expired")
+	require.NoError(t, err)
+	assert.Equal(t, "expired", details.Status)
+	assert.True(t, details.IsExpired)
+
+	_, err = f.companySvc.GetInvitationDetails(ctx, "unknown")
+	assert.ErrorIs(t, err, company.ErrInvitationNotFound)
+}
+
+func TestRegisterUser_WithInvitationJoinsCompany(t *testing.T) {
+	f := newInviteFixture(t)
+	ctx := context.Background()
+
+	// Emails are compared case-insensitively; the invitation decides the user type
+	require.NoError(t, f.registration.RegisterUser(ctx, "Rina", "rina@example.com", "Secret123!", "", "jobseeker", "", "valid"))
+
+	rina := f.users.WithEmail("rina@example.com")
+	require.NotNil(t, rina)
+	assert.Equal(t, "employer", rina.UserType)
+	assert.False(t, rina.IsVerified)
+
+	require.Len(t, f.companies.employers, 1)
+	employer := f.companies.employers[0]
+	assert.Equal(t, rina.ID, employer.UserID)
+	assert.Equal(t, int64(7), employer.CompanyID)
+	assert.Equal(t, "recruiter", employer.Role)
+	assert.Equal(t, "HR Lead", *employer.PositionTitle)
+
+	inv := f.companies.invitations["valid"]
+	assert.Equal(t, "accepted", inv.Status)
+	assert.Equal(t, rina.ID, *inv.AcceptedBy)
+
+	// The OTP is still sent and verifying it logs the new employer in
+	require.NotEmpty(t, f.emails.LastCode())
+	token, verified, err := f.registration.VerifyEmailOTP(ctx, "rina@example.com", f.emails.LastCode())
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.True(t, verified.IsVerified)
+
+	// The token cannot be used for a second signup
+	err = f.registration.RegisterUser(ctx, "Rina", "rina2@example.com", "Secret123!", "", "employer", "", "valid")
+	assert.ErrorIs(t, err, company.ErrInvitationUnavailable)
+}
+
+func TestRegister_WithInvitationJoinsCompany(t *testing.T) {
+	f := newInviteFixture(t)
+
+	usr, _, err := f.auth.Register(context.Background(), &user.RegisterRequest{
+		FullName: "Rina", Email: "rina@example.com", Password: "Secret123!", UserType: "jobseeker", InvitationToken: "valid",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "employer", usr.UserType)
+	require.Len(t, f.companies.employers, 1)
+	assert.Equal(t, usr.ID, f.companies.employers[0].UserID)
+	assert.Equal(t, "accepted", f.companies.invitations["valid"].Status)
+}
+
+func TestRegisterUser_RejectsUnusableInvitations(t *testing.T) {
+	f := newInviteFixture(t)
+	ctx := context.Background()
+
+	for name, tc := range map[string]struct {
+		email, token string
+		want         error
+	}{
+		"expired":          {"budi@example.com", "expired", company.ErrInvitationExpired},
+		"already accepted": {"sari@example.com", "accepted", company.ErrInvitationUnavailable},
+		"unknown token":    {"rina@example.com", "unknown", company.ErrInvitationNotFound},
+		"another email":    {"someone@example.com", "valid", company.ErrInvitationEmailMismatch},
+	} {
+		err := f.registration.RegisterUser(ctx, "Someone", tc.email, "Secret123!", "", "employer", "", tc.token)
+		assert.ErrorIs(t, err, tc.want, name)
+		assert.Nil(t, f.users.WithEmail(tc.email), name)
+	}
+
+	assert.Empty(t, f.companies.employers)
+	assert.Empty(t, f.emails.LastCode())
+	assert.Equal(t, "expired", f.companies.invitations["expired"].Status)
+	assert.Equal(t, "pending", f.companies.invitations["valid"].Status)
+
+	// The expired invitation is not accepted by an existing account either
+	assert.ErrorIs(t, f.companySvc.AcceptInvitation(ctx, "expired", 100), company.ErrInvitationExpired)
+}
+
+func TestRegisterUser_CreatesNoUserWhenAcceptFails(t *testing.T) {
+	f := newInviteFixture(t)
+	ctx := context.Background()
+
+	// Another request accepted the invitation between the check and the accept
+	f.companies.acceptErr = company.ErrInvitationUnavailable
+	err := f.registration.RegisterUser(ctx, "Rina", "rina@example.com", "Secret123!", "", "employer", "", "valid")
+	assert.ErrorIs(t, err, company.ErrInvitationUnavailable)
+	assert.Nil(t, f.users.WithEmail("rina@example.com"))
+	assert.Empty(t, f.emails.LastCode())
+
+	// With the conflict gone the same email can sign up again
+	f.companies.acceptErr = nil
+	require.NoError(t, f.registration.RegisterUser(ctx, "Rina", "rina@example.com", "Secret123!", "", "employer", "", "valid"))
+	assert.Len(t, f.companies.employers, 1)
+}
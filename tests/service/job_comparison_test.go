@@ -0,0 +1,163 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/master"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// compareJobService serves fixed jobs and scores every job with the same matched skills
+type compareJobService struct {
+	job.JobService
+	jobs    map[int64]*job.Job
+	matched []string
+}
+
+func (s *compareJobService) GetJob(ctx context.Context, jobID int64) (*job.Job, error) {
+	j, ok := s.jobs[jobID]
+	if !ok {
+		return nil, job.ErrJobNotFound
+	}
+	return j, nil
+}
+
+func (s *compareJobService) CalculateMatchScore(ctx context.Context, jobID, userID int64) (*job.MatchScore, error) {
+	return &job.MatchScore{JobID: jobID, UserID: userID, OverallScore: 0.75, MatchedSkills: s.matched}, nil
+}
+
+type compareCompanyService struct {
+	company.CompanyService
+}
+
+func (compareCompanyService) GetCompany(ctx context.Context, id int64) (*company.Company, error) {
+	return &company.Company{ID: id, CompanyName: "Keerja Labs"}, nil
+}
+
+func (compareCompanyService) GetAverageRatings(ctx context.Context, companyID int64) (*company.AverageRatings, error) {
+	return &company.AverageRatings{Overall: 4.2, TotalReviews: 18}, nil
+}
+
+type compareUserService struct {
+	user.UserService
+}
+
+func (compareUserService) GetProfile(ctx context.Context, userID int64) (*user.User, error) {
+	city := "Bandung"
+	return &user.User{ID: userID, Profile: &user.UserProfile{LocationCity: &city}}, nil
+}
+
+// compareApplicationRepo knows which jobs user 2 applied to
+type compareApplicationRepo struct {
+	application.ApplicationRepository
+	appliedJobs map[int64]bool
+}
+
+func (r *compareApplicationRepo) FindByJobAndUser(ctx context.Context, jobID, userID int64) (*application.JobApplication, error) {
+	if userID != 2 || !r.appliedJobs[jobID] {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &application.JobApplication{JobID: jobID, UserID: userID}, nil
+}
+
+func newJobComparisonFixture(geocoder service.Geocoder) job.JobComparisonService {
+	minSalary, maxSalary := 8000000.0, 12000000.0
+	lat, lng := -6.2, 106.8
+	goSkill := &master.SkillsMaster{ID: 1, Name: "Go"}
+	sqlSkill := &master.SkillsMaster{ID: 2, Name: "PostgreSQL"}
+
+	jobService := &compareJobService{
+		jobs: map[int64]*job.Job{
+			10: {
+				ID: 10, CompanyID: 3, Title: "Backend Engineer", Status: job.StatusPublished,
+				SalaryMin: &minSalary, SalaryMax: &maxSalary, SalaryDisplay: "range", Currency: "IDR",
+				Skills: []job.JobSkill{
+					{SkillID: 1, ImportanceLevel: "required", Skill: goSkill},
+					{SkillID: 2, ImportanceLevel: "preferred", Skill: sqlSkill},
+				},
+				Locations: []job.JobLocation{{City: "Jakarta Selatan", Province: "DKI Jakarta", Latitude: &lat, Longitude: &lng, IsPrimary: true}},
+			},
+			11: {ID: 11, CompanyID: 3, Title: "Platform Engineer", Status: job.StatusPublished, SalaryMin: &minSalary, SalaryDisplay: "hidden"},
+			12: {ID: 12, CompanyID: 3, Title: "Data Engineer", Status: job.StatusClosed, SalaryDisplay: "negotiable"},
+			13: {ID: 13, CompanyID: 3, Title: "Site Reliability Engineer", Status: job.StatusClosed},
+		},
+		matched: []string{"Go"},
+	}
+	appRepo := &compareApplicationRepo{appliedJobs: map[int64]bool{12: true}}
+	return service.NewJobComparisonService(jobService, compareCompanyService{}, compareUserService{}, appRepo, geocoder)
+}
+
+func TestCompareJobs_ReportsJobsTheCallerCannotSee(t *testing.T) {
+	svc := newJobComparisonFixture(nil)
+
+	// User 2 applied to closed job 12 but not to closed job 13
+	comparison, err := svc.CompareJobs(context.Background(), []int64{13, 10, 12}, 2)
+	require.NoError(t, err)
+	require.Len(t, comparison.Jobs, 2)
+	assert.Equal(t, int64(10), comparison.Jobs[0].JobID)
+	assert.Equal(t, int64(12), comparison.Jobs[1].JobID)
+	require.Len(t, comparison.NotFound, 1)
+	assert.Equal(t, int64(13), comparison.NotFound[0].JobID)
+	assert.Equal(t, "JOB_NOT_FOUND", comparison.NotFound[0].Code)
+
+	// Job 99 does not exist and anonymous callers only see published jobs
+	comparison, err = svc.CompareJobs(context.Background(), []int64{99, 12}, 0)
+	require.NoError(t, err)
+	assert.Empty(t, comparison.Jobs)
+	assert.Equal(t, []int64{99, 12}, []int64{comparison.NotFound[0].JobID, comparison.NotFound[1].JobID})
+
+	_, err = svc.CompareJobs(context.Background(), []int64{10, 11, 12, 13}, 2)
+	assert.ErrorIs(t, err, job.ErrInvalidCompareJobIDs)
+}
+
+func TestCompareJobs_PersonalizesSignedInCallers(t *testing.T) {
+	geocoder := &fakes.Geocoder{Result: &service.GeocodeResult{Latitude: -6.9, Longitude: 107.6}}
+	svc := newJobComparisonFixture(geocoder)
+	ctx := context.Background()
+
+	comparison, err := svc.CompareJobs(ctx, []int64{10, 11}, 2)
+	require.NoError(t, err)
+	assert.True(t, comparison.Personalized)
+	require.Len(t, comparison.Jobs, 2)
+	assert.Equal(t, []string{"Bandung"}, geocoder.Calls)
+
+	backend := comparison.Jobs[0]
+	assert.Equal(t, "Keerja Labs", backend.Company.Name)
+	assert.Equal(t, 4.2, backend.Company.Rating)
+	assert.Equal(t, 8000000.0, *backend.Salary.Min)
+	assert.Equal(t, 12000000.0, *backend.Salary.Max)
+	require.NotNil(t, backend.MatchScore)
+	assert.Equal(t, 0.75, backend.MatchScore.OverallScore)
+	require.Len(t, backend.Skills, 2)
+	assert.True(t, *backend.Skills[0].UserHas)
+	assert.False(t, *backend.Skills[1].UserHas)
+	require.NotNil(t, backend.Location.DistanceKm)
+	assert.InDelta(t, 121, *backend.Location.DistanceKm, 5)
+
+	// Hidden salaries stay hidden and jobs without coordinates have no distance
+	platform := comparison.Jobs[1]
+	assert.Nil(t, platform.Salary.Min)
+	assert.Nil(t, platform.Location.DistanceKm)
+
+	// Anonymous callers get the same matrix without the personalized columns
+	comparison, err = svc.CompareJobs(ctx, []int64{10, 11}, 0)
+	require.NoError(t, err)
+	assert.False(t, comparison.Personalized)
+	require.Len(t, comparison.Jobs, 2)
+	backend = comparison.Jobs[0]
+	assert.Nil(t, backend.MatchScore)
+	assert.Nil(t, backend.Skills[0].UserHas)
+	assert.Nil(t, backend.Location.DistanceKm)
+	assert.Equal(t, 4.2, backend.Company.Rating)
+	assert.Len(t, geocoder.Calls, 1)
+}
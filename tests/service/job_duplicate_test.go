@@ -0,0 +1,147 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/service"
+)
+
+type duplicateJobRepo struct {
+	job.JobRepository
+
+	nextID    int64
+	jobs      map[int64]*job.Job
+	created   []*job.Job
+	pipelines map[int64][]job.JobPipelineStage
+}
+
+func (r *duplicateJobRepo) FindByID(ctx context.Context, id int64) (*job.Job, error) {
+	return r.jobs[id], nil
+}
+
+func (r *duplicateJobRepo) SlugExists(ctx context.Context, slug string, excludeID int64) (bool, error) {
+	for _, j := range r.jobs {
+		if j.Slug == slug && j.ID != excludeID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *duplicateJobRepo) CreateWithRelations(ctx context.Context, j *job.Job) error {
+	r.nextID++
+	j.ID = r.nextID
+	r.jobs[j.ID] = j
+	r.created = append(r.created, j)
+	return nil
+}
+
+func (r *duplicateJobRepo) ListPipelineStages(ctx context.Context, jobID int64) ([]job.JobPipelineStage, error) {
+	return r.pipelines[jobID], nil
+}
+
+func (r *duplicateJobRepo) ReplacePipelineStages(ctx context.Context, jobID int64, stages []job.JobPipelineStage) error {
+	r.pipelines[jobID] = stages
+	return nil
+}
+
+// A recruiter and a viewer of company 3; neither posted the job
+var (
+	duplicateRecruiter = &employer.EmployerContext{UserID: 7, EmployerUserID: 40, CompanyID: 3, Role: "recruiter"}
+	duplicateViewer    = &employer.EmployerContext{UserID: 8, EmployerUserID: 41, CompanyID: 3, Role: "viewer"}
+)
+
+func newDuplicateFixture() (job.JobService, *duplicateJobRepo) {
+	ownerID := int64(30)
+	published := time.Now().Add(-90 * 24 * time.Hour)
+	expiry := time.Now().Add(-24 * time.Hour)
+	salaryMin, salaryMax := 8000000.0, 12000000.0
+	minAge, maxAge := 21, 35
+	titleID := int64(4)
+
+	src := &job.Job{
+		ID:                1,
+		UUID:              uuid.New(),
+		CompanyID:         3,
+		EmployerUserID:    &ownerID,
+		Title:             "Backend Engineer",
+		Slug:              "backend-engineer",
+		Description:       "Build APIs",
+		JobTitleID:        &titleID,
+		SalaryMin:         &salaryMin,
+		SalaryMax:         &salaryMax,
+		MinAge:            &minAge,
+		MaxAge:            &maxAge,
+		Status:            "published",
+		ViewsCount:        420,
+		ApplicationsCount: 37,
+		PublishedAt:       &published,
+		ExpiredAt:         &expiry,
+		Locations:         []job.JobLocation{{ID: 11, JobID: 1, City: "Jakarta", IsPrimary: true}},
+		Benefits:          []job.JobBenefit{{ID: 12, JobID: 1, BenefitName: "Health insurance"}},
+		Skills:            []job.JobSkill{{ID: 13, JobID: 1, SkillID: 2, ImportanceLevel: "required", Weight: 1}},
+		JobRequirements:   []job.JobRequirement{{ID: 14, JobID: 1, RequirementText: "3+ years of Go"}},
+	}
+
+	jobRepo := &duplicateJobRepo{nextID: 1, jobs: map[int64]*job.Job{1: src}, pipelines: map[int64][]job.JobPipelineStage{}}
+	return service.NewJobService(jobRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil, nil, ""), jobRepo
+}
+
+func TestDuplicateJob_CopiesContentIntoFreshDraft(t *testing.T) {
+	svc, jobRepo := newDuplicateFixture()
+	src := jobRepo.jobs[1]
+
+	dup, err := svc.DuplicateJob(context.Background(), 1, duplicateRecruiter)
+	require.NoError(t, err)
+	require.Len(t, jobRepo.created, 1)
+
+	assert.Equal(t, int64(2), dup.ID)
+	assert.Equal(t, "draft", dup.Status)
+	assert.Equal(t, uuid.Nil, dup.UUID, "the UUID is generated on insert")
+	assert.Equal(t, "backend-engineer-2", dup.Slug)
+	assert.Zero(t, dup.ViewsCount)
+	assert.Zero(t, dup.ApplicationsCount)
+	assert.Nil(t, dup.PublishedAt)
+	assert.Nil(t, dup.ExpiredAt)
+	require.NotNil(t, dup.EmployerUserID)
+	assert.Equal(t, int64(40), *dup.EmployerUserID)
+
+	assert.Equal(t, src.Description, dup.Description)
+	assert.Equal(t, src.JobTitleID, dup.JobTitleID)
+	assert.Equal(t, src.SalaryMin, dup.SalaryMin)
+	assert.Equal(t, src.MaxAge, dup.MaxAge)
+
+	require.Len(t, dup.Locations, 1)
+	assert.Zero(t, dup.Locations[0].ID)
+	assert.Equal(t, "Jakarta", dup.Locations[0].City)
+	require.Len(t, dup.Benefits, 1)
+	assert.Zero(t, dup.Benefits[0].ID)
+	require.Len(t, dup.Skills, 1)
+	assert.Equal(t, int64(2), dup.Skills[0].SkillID)
+	require.Len(t, dup.JobRequirements, 1)
+	assert.Equal(t, "3+ years of Go", dup.JobRequirements[0].RequirementText)
+
+	// The source job is left untouched
+	assert.Equal(t, "published", src.Status)
+	assert.Equal(t, int64(11), src.Locations[0].ID)
+}
+
+func TestDuplicateJob_RequiresManagePermission(t *testing.T) {
+	svc, jobRepo := newDuplicateFixture()
+
+	_, err := svc.DuplicateJob(context.Background(), 1, duplicateViewer)
+	assert.ErrorIs(t, err, job.ErrJobPermissionDenied)
+
+	_, err = svc.DuplicateJob(context.Background(), 99, duplicateRecruiter)
+	assert.ErrorIs(t, err, job.ErrJobNotFound)
+
+	assert.Empty(t, jobRepo.created)
+}
@@ -0,0 +1,130 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// expiryJobRepo applies expiry updates to an in-memory set of published jobs
+type expiryJobRepo struct {
+	job.JobRepository
+
+	jobs    map[int64]*job.Job
+	expired []int64
+}
+
+func (r *expiryJobRepo) GetExpiredJobs(ctx context.Context) ([]job.Job, error) {
+	var jobs []job.Job
+	for _, j := range r.jobs {
+		if j.Status == "published" && j.ExpiredAt != nil && !j.ExpiredAt.After(time.Now()) {
+			jobs = append(jobs, *j)
+		}
+	}
+	return jobs, nil
+}
+
+func (r *expiryJobRepo) AutoExtendJob(ctx context.Context, jobID int64, expiredAt time.Time, maxExtensions int) (bool, error) {
+	j := r.jobs[jobID]
+	if !j.AutoExtend || j.AutoExtendCount >= maxExtensions {
+		return false, nil
+	}
+	j.ExpiredAt, j.ExpiryReminderDays = &expiredAt, nil
+	j.AutoExtendCount++
+	return true, nil
+}
+
+func (r *expiryJobRepo) ExpireJob(ctx context.Context, id int64) error {
+	r.jobs[id].Status = "expired"
+	r.expired = append(r.expired, id)
+	return nil
+}
+
+func (r *expiryJobRepo) FindJobsExpiringWithin(ctx context.Context, days int) ([]job.Job, error) {
+	var jobs []job.Job
+	limit := time.Now().AddDate(0, 0, days)
+	for _, j := range r.jobs {
+		reminded := j.ExpiryReminderDays != nil && *j.ExpiryReminderDays <= days
+		if j.Status == "published" && j.ExpiredAt.After(time.Now()) && !j.ExpiredAt.After(limit) && !reminded {
+			jobs = append(jobs, *j)
+		}
+	}
+	return jobs, nil
+}
+
+func (r *expiryJobRepo) MarkExpiryReminded(ctx context.Context, jobID int64, days int) (bool, error) {
+	j := r.jobs[jobID]
+	if j.ExpiryReminderDays != nil && *j.ExpiryReminderDays <= days {
+		return false, nil
+	}
+	j.ExpiryReminderDays = &days
+	return true, nil
+}
+
+func publishedJob(id int64, title string, expiresIn time.Duration) *job.Job {
+	employerUserID := int64(11)
+	expiry := time.Now().Add(expiresIn)
+	return &job.Job{ID: id, CompanyID: 3, EmployerUserID: &employerUserID, Title: title, Status: "published", ExpiredAt: &expiry}
+}
+
+func TestAutoExpireJobs_ExtendsAutoExtendJobsUpToTheLimit(t *testing.T) {
+	plain := publishedJob(1, "Plain", -time.Hour)
+	extending := publishedJob(2, "Extending", -time.Hour)
+	extending.AutoExtend = true
+	exhausted := publishedJob(3, "Exhausted", -time.Hour)
+	exhausted.AutoExtend, exhausted.AutoExtendCount = true, job.DefaultExpiryPolicy.MaxAutoExtensions
+	repo := &expiryJobRepo{jobs: map[int64]*job.Job{1: plain, 2: extending, 3: exhausted}}
+	svc := service.NewJobService(repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil, nil, "")
+
+	stats, err := svc.AutoExpireJobs(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, stats.Extended)
+	assert.Equal(t, 2, stats.Expired)
+	assert.ElementsMatch(t, []int64{1, 3}, repo.expired)
+	assert.Equal(t, "published", extending.Status)
+	assert.Equal(t, 1, extending.AutoExtendCount)
+	assert.WithinDuration(t, time.Now().Add(job.DefaultExpiryPolicy.DefaultPeriod), *extending.ExpiredAt, time.Minute)
+}
+
+func TestSendExpiryReminders_SendsEachThresholdOnce(t *testing.T) {
+	soon := publishedJob(1, "Soon", 12*time.Hour)
+	later := publishedJob(2, "Later", 5*24*time.Hour)
+	later.AutoExtend = true
+	distant := publishedJob(3, "Distant", 20*24*time.Hour)
+	repo := &expiryJobRepo{jobs: map[int64]*job.Job{1: soon, 2: later, 3: distant}}
+	companyRepo := &fakes.CompanyRepository{EmployerUsers: []company.EmployerUser{{ID: 11, UserID: 7, CompanyID: 3, IsActive: true}}}
+	userRepo := &fakes.UserRepository{User: &user.User{ID: 7, Email: "owner@acme.test", FullName: "Owner"}}
+	emails := &fakes.EmailService{}
+	svc := service.NewJobService(repo, companyRepo, userRepo, nil, nil, nil, nil, nil, nil, emails, nil, nil, job.DefaultExpiryPolicy, nil, nil, nil, "")
+
+	sent, err := svc.SendExpiryReminders(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	assert.ElementsMatch(t, []fakes.SentEmail{
+		{Method: "SendJobExpiryReminderEmail", To: "owner@acme.test", JobTitle: "Soon", DaysLeft: 1},
+		{Method: "SendJobExpiryReminderEmail", To: "owner@acme.test", JobTitle: "Later", DaysLeft: 5, AutoExtend: true},
+	}, emails.Sent)
+
+	// A second run sends nothing new
+	sent, err = svc.SendExpiryReminders(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+
+	// Once the job is inside one day it gets the 1-day reminder as well
+	expiry := time.Now().Add(20 * time.Hour)
+	later.ExpiredAt = &expiry
+	sent, err = svc.SendExpiryReminders(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Len(t, emails.Sent, 3)
+}
@@ -0,0 +1,259 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/master"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+)
+
+// feedJobRepo serves jobs by slug and a sitemap of `published` generated entries
+type feedJobRepo struct {
+	job.JobRepository
+
+	jobs      map[string]*job.Job
+	published int
+	streamed  int // Sitemap pages read from the repository
+}
+
+func (r *feedJobRepo) FindBySlug(ctx context.Context, slug string) (*job.Job, error) {
+	return r.jobs[slug], nil
+}
+
+func (r *feedJobRepo) CountSitemapEntries(ctx context.Context, now time.Time) (int64, error) {
+	return int64(r.published), nil
+}
+
+func (r *feedJobRepo) StreamSitemapEntries(ctx context.Context, now time.Time, offset, limit int, fn func(job.SitemapEntry) error) error {
+	r.streamed++
+	updated := time.Date(2026, 10, 1, 8, 30, 0, 0, time.UTC)
+	for i := offset; i < min(offset+limit, r.published); i++ {
+		if err := fn(job.SitemapEntry{Slug: fmt.Sprintf("job-%d", i+1), UpdatedAt: updated}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// feedCompanyRepo returns one company with a website, logo and address
+type feedCompanyRepo struct {
+	company.CompanyRepository
+}
+
+func (r *feedCompanyRepo) FindByID(ctx context.Context, id int64) (*company.Company, error) {
+	return &company.Company{
+		ID:          id,
+		CompanyName: "Acme Indonesia",
+		WebsiteURL:  utils.StringPtr("https://acme.example"),
+		LogoURL:     utils.StringPtr("https://cdn.example/acme.png"),
+		Address:     utils.StringPtr("Jl. Sudirman 1"),
+		City:        utils.StringPtr("Jakarta Selatan"),
+		Province:    utils.StringPtr("DKI Jakarta"),
+		Country:     "Indonesia",
+	}, nil
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name `xml:"urlset"`
+	URLs    []struct {
+		Loc     string `xml:"loc"`
+		LastMod string `xml:"lastmod"`
+	} `xml:"url"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name `xml:"sitemapindex"`
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+}
+
+func newFeedService(t *testing.T, repo *feedJobRepo) job.JobFeedService {
+	t.Helper()
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	t.Cleanup(memCache.Stop)
+	return service.NewJobFeedService(repo, &feedCompanyRepo{}, memCache, "https://keerja.example/")
+}
+
+func TestWriteSitemap_SingleFile(t *testing.T) {
+	repo := &feedJobRepo{published: 2}
+	svc := newFeedService(t, repo)
+
+	var out bytes.Buffer
+	require.NoError(t, svc.WriteSitemap(context.Background(), "https://api.keerja.example/sitemap-jobs.xml", 0, &out))
+
+	var urlset sitemapURLSet
+	require.NoError(t, xml.Unmarshal(out.Bytes(), &urlset))
+	require.Len(t, urlset.URLs, 2)
+	assert.Equal(t, "https://keerja.example/jobs/job-1", urlset.URLs[0].Loc)
+	assert.Equal(t, "2026-10-01T08:30:00Z", urlset.URLs[0].LastMod)
+}
+
+func TestWriteSitemap_PaginatesPastFiftyThousandURLs(t *testing.T) {
+	repo := &feedJobRepo{published: 2*job.MaxSitemapURLs + 1}
+	svc := newFeedService(t, repo)
+	ctx := context.Background()
+	sitemapURL := "https://api.keerja.example/sitemap-jobs.xml"
+
+	var out bytes.Buffer
+	require.NoError(t, svc.WriteSitemap(ctx, sitemapURL, 0, &out))
+	var index sitemapIndex
+	require.NoError(t, xml.Unmarshal(out.Bytes(), &index))
+	require.Len(t, index.Sitemaps, 3)
+	assert.Equal(t, sitemapURL+"?page=1", index.Sitemaps[0].Loc)
+	assert.Equal(t, sitemapURL+"?page=3", index.Sitemaps[2].Loc)
+	assert.Zero(t, repo.streamed, "the index only needs the count")
+
+	pageSizes := map[int]int{1: job.MaxSitemapURLs, 2: job.MaxSitemapURLs, 3: 1}
+	for page, size := range pageSizes {
+		out.Reset()
+		require.NoError(t, svc.WriteSitemap(ctx, sitemapURL, page, &out))
+		var urlset sitemapURLSet
+		require.NoError(t, xml.Unmarshal(out.Bytes(), &urlset))
+		assert.Len(t, urlset.URLs, size, "page %d", page)
+	}
+
+	out.Reset()
+	require.NoError(t, svc.WriteSitemap(ctx, sitemapURL, 3, &out))
+	assert.Contains(t, out.String(), "https://keerja.example/jobs/job-100001")
+	assert.Equal(t, 3, repo.streamed, "pages are served from the cache once generated")
+
+	assert.ErrorIs(t, svc.WriteSitemap(ctx, sitemapURL, 4, &out), job.ErrSitemapPageNotFound)
+}
+
+func publishedFeedJob(slug string) *job.Job {
+	published := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	expires := time.Now().Add(30 * 24 * time.Hour)
+	return &job.Job{
+		ID:            1,
+		CompanyID:     3,
+		Title:         "Backend Engineer",
+		Slug:          slug,
+		Description:   `<p onclick="steal()">Build <b>APIs</b></p><script>alert(1)</script><ul><li>Go</li></ul>`,
+		Status:        job.StatusPublished,
+		PublishedAt:   &published,
+		ExpiredAt:     &expires,
+		SalaryMin:     utils.Float64Ptr(10000000),
+		SalaryMax:     utils.Float64Ptr(15000000),
+		SalaryDisplay: "range",
+		Currency:      "IDR",
+		JobType:       &master.JobType{Code: "full_time"},
+		Locations: []job.JobLocation{
+			{City: "Bandung", Country: "Indonesia"},
+			{Address: "Jl. Asia Afrika 8", City: "Bandung", Province: "Jawa Barat", PostalCode: "40111", Country: "Indonesia", IsPrimary: true},
+		},
+	}
+}
+
+// structuredData returns the JobPosting of the job as the JSON-LD object clients receive
+func structuredData(t *testing.T, j *job.Job) map[string]any {
+	t.Helper()
+	svc := newFeedService(t, &feedJobRepo{jobs: map[string]*job.Job{j.Slug: j}})
+	posting, err := svc.GetStructuredData(context.Background(), j.Slug)
+	require.NoError(t, err)
+
+	raw, err := json.Marshal(posting)
+	require.NoError(t, err)
+	var ld map[string]any
+	require.NoError(t, json.Unmarshal(raw, &ld))
+	return ld
+}
+
+func TestGetStructuredData_HasGoogleRequiredFields(t *testing.T) {
+	ld := structuredData(t, publishedFeedJob("backend-engineer"))
+
+	assert.Equal(t, "https://schema.org/", ld["@context"])
+	assert.Equal(t, "JobPosting", ld["@type"])
+	assert.Equal(t, "Backend Engineer", ld["title"])
+	assert.Equal(t, "<p>Build <b>APIs</b></p><ul><li>Go</li></ul>", ld["description"])
+	assert.Equal(t, "2026-10-01T09:00:00Z", ld["datePosted"])
+	assert.NotEmpty(t, ld["validThrough"])
+	assert.Equal(t, "FULL_TIME", ld["employmentType"])
+	assert.Equal(t, "https://keerja.example/jobs/backend-engineer", ld["url"])
+
+	assert.Equal(t, map[string]any{
+		"@type":  "Organization",
+		"name":   "Acme Indonesia",
+		"sameAs": "https://acme.example",
+		"logo":   "https://cdn.example/acme.png",
+	}, ld["hiringOrganization"])
+
+	assert.Equal(t, map[string]any{
+		"@type": "Place",
+		"address": map[string]any{
+			"@type":           "PostalAddress",
+			"streetAddress":   "Jl. Asia Afrika 8",
+			"addressLocality": "Bandung",
+			"addressRegion":   "Jawa Barat",
+			"postalCode":      "40111",
+			"addressCountry":  "ID",
+		},
+	}, ld["jobLocation"], "the primary location is used")
+	assert.NotContains(t, ld, "jobLocationType")
+
+	assert.Equal(t, map[string]any{
+		"@type":    "MonetaryAmount",
+		"currency": "IDR",
+		"value": map[string]any{
+			"@type":    "QuantitativeValue",
+			"minValue": 10000000.0,
+			"maxValue": 15000000.0,
+			"unitText": "MONTH",
+		},
+	}, ld["baseSalary"])
+}
+
+func TestGetStructuredData_HonorsSalaryDisplay(t *testing.T) {
+	j := publishedFeedJob("min-only")
+	j.SalaryDisplay = "min_only"
+	salary := structuredData(t, j)["baseSalary"].(map[string]any)["value"].(map[string]any)
+	assert.Equal(t, 10000000.0, salary["minValue"])
+	assert.NotContains(t, salary, "maxValue")
+
+	for _, display := range []string{"hidden", "negotiable", "competitive"} {
+		j := publishedFeedJob(display)
+		j.SalaryDisplay = display
+		assert.NotContains(t, structuredData(t, j), "baseSalary", display)
+	}
+}
+
+func TestGetStructuredData_FallsBackToCompanyAddress(t *testing.T) {
+	j := publishedFeedJob("remote")
+	j.Locations = nil
+	j.RemoteOption = true
+	ld := structuredData(t, j)
+
+	address := ld["jobLocation"].(map[string]any)["address"].(map[string]any)
+	assert.Equal(t, "Jl. Sudirman 1", address["streetAddress"])
+	assert.Equal(t, "Jakarta Selatan", address["addressLocality"])
+	assert.Equal(t, "ID", address["addressCountry"])
+	assert.Equal(t, "TELECOMMUTE", ld["jobLocationType"])
+	assert.Equal(t, map[string]any{"@type": "Country", "name": "Indonesia"}, ld["applicantLocationRequirements"])
+}
+
+func TestGetStructuredData_OnlyPublishedUnexpiredJobs(t *testing.T) {
+	draft := publishedFeedJob("draft")
+	draft.Status = job.StatusDraft
+	expired := publishedFeedJob("expired")
+	past := time.Now().Add(-time.Hour)
+	expired.ExpiredAt = &past
+	svc := newFeedService(t, &feedJobRepo{jobs: map[string]*job.Job{"draft": draft, "expired": expired}})
+
+	for _, slug := range []string{"draft", "expired", "missing"} {
+		_, err := svc.GetStructuredData(context.Background(), slug)
+		assert.ErrorIs(t, err, job.ErrJobNotFound, slug)
+	}
+}
@@ -0,0 +1,198 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/master"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+type scoreKey struct{ userID, jobID int64 }
+
+// memoryMatchScoreRepo keeps match scores in memory
+type memoryMatchScoreRepo struct {
+	job.MatchScoreRepository
+
+	scores  map[scoreKey]job.UserJobMatchScore
+	jobs    []job.Job
+	seekers []int64
+}
+
+func newMemoryMatchScoreRepo(jobs []job.Job, seekers ...int64) *memoryMatchScoreRepo {
+	return &memoryMatchScoreRepo{scores: map[scoreKey]job.UserJobMatchScore{}, jobs: jobs, seekers: seekers}
+}
+
+func (r *memoryMatchScoreRepo) UpsertScores(ctx context.Context, scores []job.UserJobMatchScore) error {
+	for _, s := range scores {
+		r.scores[scoreKey{s.UserID, s.JobID}] = s
+	}
+	return nil
+}
+
+func (r *memoryMatchScoreRepo) FindScores(ctx context.Context, userID int64, jobIDs []int64) ([]job.UserJobMatchScore, error) {
+	var found []job.UserJobMatchScore
+	for _, id := range jobIDs {
+		if s, ok := r.scores[scoreKey{userID, id}]; ok {
+			found = append(found, s)
+		}
+	}
+	return found, nil
+}
+
+func (r *memoryMatchScoreRepo) DeleteComputedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	var deleted int64
+	for k, s := range r.scores {
+		if s.ComputedAt.Before(cutoff) {
+			delete(r.scores, k)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (r *memoryMatchScoreRepo) ListActiveJobSeekerIDs(ctx context.Context, activeSince time.Time, afterID int64, limit int) ([]int64, error) {
+	var ids []int64
+	for _, id := range r.seekers {
+		if id > afterID && len(ids) < limit {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (r *memoryMatchScoreRepo) ListScoringJobs(ctx context.Context, publishedSince time.Time) ([]job.Job, error) {
+	return r.jobs, nil
+}
+
+// scoringJobRepo serves jobs by ID and counts the lookups made to score them
+type scoringJobRepo struct {
+	job.JobRepository
+
+	jobs    map[int64]*job.Job
+	lookups int
+}
+
+func (r *scoringJobRepo) FindByID(ctx context.Context, id int64) (*job.Job, error) {
+	r.lookups++
+	return r.jobs[id], nil
+}
+
+func (r *scoringJobRepo) GetMatchingJobs(ctx context.Context, userID int64, filter job.JobFilter, page, limit int) ([]job.Job, int64, error) {
+	jobs := make([]job.Job, 0, len(r.jobs))
+	for id := int64(1); id <= int64(len(r.jobs)); id++ {
+		jobs = append(jobs, *r.jobs[id])
+	}
+	return jobs, int64(len(jobs)), nil
+}
+
+func newMatchScoreFixture() (job.JobService, *scoringJobRepo, *memoryMatchScoreRepo) {
+	bachelor := &master.EducationLevel{ID: 3, Name: "S1"}
+	jobs := []job.Job{
+		{ID: 1, City: "Bandung", Skills: []job.JobSkill{{SkillID: 1, ImportanceLevel: "required", Weight: 1}, {SkillID: 3, ImportanceLevel: "preferred", Weight: 1}}},
+		{ID: 2, RemoteOption: true, ExperienceMin: utils.Int16Ptr(3), EducationLevelID: utils.Int64Ptr(3), EducationLevelM: bachelor},
+		{ID: 3, Location: "Jakarta", Skills: []job.JobSkill{{SkillID: 2, ImportanceLevel: "required", Weight: 1}}},
+	}
+	byID := make(map[int64]*job.Job, len(jobs))
+	for i := range jobs {
+		byID[jobs[i].ID] = &jobs[i]
+	}
+
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	users := map[int64]*user.User{
+		7: {
+			ID:          7,
+			Profile:     &user.UserProfile{LocationCity: utils.StringPtr("Jakarta Selatan")},
+			Skills:      []user.UserSkill{{SkillName: "golang"}, {SkillName: "Docker"}},
+			Experiences: []user.UserExperience{{StartDate: start, EndDate: &end}},
+			Educations:  []user.UserEducation{{DegreeLevel: utils.StringPtr("D3")}},
+		},
+		8: {ID: 8, Skills: []user.UserSkill{{SkillName: "Postgres"}}},
+	}
+
+	jobRepo := &scoringJobRepo{jobs: byID}
+	scores := newMemoryMatchScoreRepo(jobs, 7, 8)
+	svc := service.NewJobService(jobRepo, nil, &fakes.UserRepository{Users: users}, nil, nil, fakes.NewSkillsMasterRepository(), nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, scores, nil, nil, "")
+	return svc, jobRepo, scores
+}
+
+func TestRefreshMatchScores_MatchesOnDemandScores(t *testing.T) {
+	svc, _, scores := newMatchScoreFixture()
+	ctx := context.Background()
+
+	stored, err := svc.RefreshMatchScores(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 6, stored)
+
+	for _, userID := range []int64{7, 8} {
+		for jobID := int64(1); jobID <= 3; jobID++ {
+			onDemand, err := svc.CalculateMatchScore(ctx, jobID, userID)
+			require.NoError(t, err)
+
+			precomputed, ok := scores.scores[scoreKey{userID, jobID}]
+			require.True(t, ok, "user %d job %d was not scored", userID, jobID)
+			assert.Equal(t, onDemand.OverallScore, precomputed.Overall, "user %d job %d", userID, jobID)
+			assert.Equal(t, onDemand.SkillScore, precomputed.Skill)
+			assert.Equal(t, onDemand.ExperienceScore, precomputed.Experience)
+			assert.Equal(t, onDemand.EducationScore, precomputed.Education)
+			assert.Equal(t, onDemand.LocationScore, precomputed.Location)
+		}
+	}
+}
+
+func TestGetMatchingJobs_ScoresOnlyMissingPairsOnDemand(t *testing.T) {
+	svc, jobRepo, scores := newMatchScoreFixture()
+	ctx := context.Background()
+
+	// Only job 2 has a fresh score; job 3's score is stale
+	fresh, err := svc.CalculateMatchScore(ctx, 2, 7)
+	require.NoError(t, err)
+	require.NoError(t, scores.UpsertScores(ctx, []job.UserJobMatchScore{
+		{UserID: 7, JobID: 2, Overall: fresh.OverallScore, ComputedAt: time.Now()},
+		{UserID: 7, JobID: 3, Overall: 0.01, ComputedAt: time.Now().Add(-job.MatchScoreFreshness - time.Hour)},
+	}))
+	jobRepo.lookups = 0
+
+	resp, err := svc.GetMatchingJobs(ctx, 7, job.JobFilter{}, 1, 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, jobRepo.lookups, "jobs 1 and 3 are scored on demand")
+	require.Len(t, resp.Jobs, 3)
+	for _, j := range resp.Jobs {
+		onDemand, err := svc.CalculateMatchScore(ctx, j.Job.ID, 7)
+		require.NoError(t, err)
+		assert.Equal(t, onDemand.OverallScore, j.MatchScore, "job %d", j.Job.ID)
+	}
+
+	// The on-demand scores were stored, so the next request reads them all
+	jobRepo.lookups = 0
+	_, err = svc.GetMatchingJobs(ctx, 7, job.JobFilter{}, 1, 10)
+	require.NoError(t, err)
+	assert.Zero(t, jobRepo.lookups)
+}
+
+func TestRefreshMatchScores_DeletesStaleScores(t *testing.T) {
+	svc, _, scores := newMatchScoreFixture()
+	scores.seekers = nil
+	ctx := context.Background()
+
+	require.NoError(t, scores.UpsertScores(ctx, []job.UserJobMatchScore{
+		{UserID: 9, JobID: 1, Overall: 0.5, ComputedAt: time.Now().Add(-job.MatchScoreFreshness - time.Hour)},
+		{UserID: 9, JobID: 2, Overall: 0.5, ComputedAt: time.Now()},
+	}))
+
+	_, err := svc.RefreshMatchScores(ctx)
+	require.NoError(t, err)
+
+	assert.Len(t, scores.scores, 1)
+	assert.Contains(t, scores.scores, scoreKey{9, 2})
+}
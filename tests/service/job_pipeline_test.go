@@ -0,0 +1,140 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// pipelineJobRepo holds one job and its stored pipeline
+type pipelineJobRepo struct {
+	job.JobRepository
+
+	job      *job.Job
+	pipeline []job.JobPipelineStage
+}
+
+func (r *pipelineJobRepo) FindByID(ctx context.Context, id int64) (*job.Job, error) {
+	return r.job, nil
+}
+
+func (r *pipelineJobRepo) ListPipelineStages(ctx context.Context, jobID int64) ([]job.JobPipelineStage, error) {
+	return r.pipeline, nil
+}
+
+func (r *pipelineJobRepo) ReplacePipelineStages(ctx context.Context, jobID int64, stages []job.JobPipelineStage) error {
+	r.pipeline = stages
+	return nil
+}
+
+var pipelineRecruiter = &employer.EmployerContext{UserID: 7, EmployerUserID: 40, CompanyID: 3, Role: "recruiter"}
+
+func TestConfigurePipeline_OnlyBeforePublishing(t *testing.T) {
+	jobRepo := &pipelineJobRepo{job: &job.Job{ID: 10, CompanyID: 3, Status: job.StatusDraft}}
+	svc := service.NewJobService(jobRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil, nil, "")
+	ctx := context.Background()
+	req := &job.ConfigurePipelineRequest{Stages: []job.CustomPipelineStage{{Name: "Technical Test", MapsToStatus: "shortlisted"}}}
+
+	// Jobs without stored stages have the default pipeline
+	stages, err := svc.GetPipeline(ctx, 10, pipelineRecruiter)
+	require.NoError(t, err)
+	assert.Len(t, stages, len(job.PipelineStatuses))
+
+	stages, err = svc.ConfigurePipeline(ctx, 10, pipelineRecruiter, req)
+	require.NoError(t, err)
+	assert.Len(t, stages, len(job.PipelineStatuses)+1)
+	assert.Equal(t, stages, jobRepo.pipeline)
+
+	// Once published, applications may be in the stages, so they are fixed
+	jobRepo.job.Status = job.StatusPublished
+	_, err = svc.ConfigurePipeline(ctx, 10, pipelineRecruiter, &job.ConfigurePipelineRequest{})
+	assert.ErrorIs(t, err, job.ErrJobPipelineLocked)
+	assert.Len(t, jobRepo.pipeline, len(job.PipelineStatuses)+1)
+}
+
+// stageApplicationRepo reports the last recorded stage as the current one
+type stageApplicationRepo struct {
+	*fakes.ApplicationRepository
+}
+
+func (r *stageApplicationRepo) GetCurrentStage(ctx context.Context, applicationID int64) (*application.JobApplicationStage, error) {
+	r.Lock()
+	defer r.Unlock()
+	for i := len(r.Stages) - 1; i >= 0; i-- {
+		if r.Stages[i].ApplicationID == applicationID {
+			stage := r.Stages[i]
+			return &stage, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *stageApplicationRepo) status(id int64) string {
+	r.Lock()
+	defer r.Unlock()
+	return r.Apps[id].Status
+}
+
+func newPipelineApplicationFixture(t *testing.T) (application.ApplicationService, *stageApplicationRepo) {
+	pipeline, err := job.BuildPipeline(10, []job.CustomPipelineStage{
+		{Name: "Technical Test", MapsToStatus: application.StatusShortlisted},
+		{Name: "Portfolio Review", MapsToStatus: application.StatusShortlisted},
+	})
+	require.NoError(t, err)
+
+	appRepo := &stageApplicationRepo{fakes.NewApplicationRepository()}
+	appRepo.Apps[1] = &application.JobApplication{ID: 1, JobID: 10, UserID: 2, CompanyID: utils.Int64Ptr(3), Status: application.StatusScreening}
+	jobRepo := &fakes.JobRepository{Job: &job.Job{ID: 10, CompanyID: 3, Title: "Backend Engineer"}, Pipeline: pipeline}
+	companyRepo := helpers.NewCompanyMemberRepository("recruiter")
+	svc := helpers.NewApplicationService(appRepo, jobRepo, &fakes.UserRepository{}, companyRepo)
+	return svc, appRepo
+}
+
+func TestMoveToStage_CustomStagesKeepTheCanonicalStatus(t *testing.T) {
+	svc, appRepo := newPipelineApplicationFixture(t)
+	ctx := context.Background()
+
+	require.NoError(t, svc.MoveToStage(ctx, pipelineRecruiter, 1, application.StatusShortlisted, ""))
+	require.NoError(t, svc.MoveToStage(ctx, pipelineRecruiter, 1, "technical test", "Take-home sent"))
+	assert.Equal(t, application.StatusShortlisted, appRepo.status(1))
+
+	stage, err := appRepo.GetCurrentStage(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Technical Test", stage.StageName)
+	assert.Equal(t, application.StatusShortlisted, stage.Status)
+	assert.Equal(t, "Take-home sent", stage.Notes)
+	assert.True(t, stage.IsCustom())
+
+	// Stages of the same status are passed through in pipeline order
+	err = svc.MoveToStage(ctx, pipelineRecruiter, 1, application.StatusShortlisted, "")
+	assert.ErrorIs(t, err, application.ErrInvalidStatusTransition)
+	err = svc.MoveToStage(ctx, pipelineRecruiter, 1, "Technical Test", "")
+	assert.ErrorIs(t, err, application.ErrInvalidStatusTransition)
+
+	require.NoError(t, svc.MoveToStage(ctx, pipelineRecruiter, 1, "Portfolio Review", ""))
+	err = svc.MoveToStage(ctx, pipelineRecruiter, 1, "Technical Test", "")
+	assert.ErrorIs(t, err, application.ErrInvalidStatusTransition)
+
+	// Moving on from a custom stage follows the status transitions
+	require.NoError(t, svc.MoveToStage(ctx, pipelineRecruiter, 1, application.StatusInterview, ""))
+	assert.Equal(t, application.StatusInterview, appRepo.status(1))
+}
+
+func TestMoveToStage_RejectsStagesOutsideThePipeline(t *testing.T) {
+	svc, appRepo := newPipelineApplicationFixture(t)
+
+	err := svc.MoveToStage(context.Background(), pipelineRecruiter, 1, "Culture Fit", "")
+	assert.ErrorIs(t, err, application.ErrStageNotInPipeline)
+	assert.Equal(t, application.StatusScreening, appRepo.status(1))
+	assert.Empty(t, appRepo.Stages)
+}
@@ -0,0 +1,212 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+func matchScoreFor(t *testing.T, jobSkills []job.JobSkill, userSkills ...string) *job.MatchScore {
+	t.Helper()
+
+	skills := make([]user.UserSkill, 0, len(userSkills))
+	for _, name := range userSkills {
+		skills = append(skills, user.UserSkill{SkillName: name})
+	}
+
+	jobRepo := &fakes.JobRepository{Job: &job.Job{ID: 1, Skills: jobSkills}}
+	userRepo := &fakes.UserRepository{User: &user.User{ID: 2, Skills: skills}}
+	svc := service.NewJobService(jobRepo, nil, userRepo, nil, nil, fakes.NewSkillsMasterRepository(), nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil, nil, "")
+
+	score, err := svc.CalculateMatchScore(context.Background(), 1, 2)
+	require.NoError(t, err)
+	return score
+}
+
+func TestCalculateMatchScore_ExactSkillMatch(t *testing.T) {
+	score := matchScoreFor(t, []job.JobSkill{
+		{SkillID: 1, ImportanceLevel: "required", Weight: 1},
+		{SkillID: 2, ImportanceLevel: "required", Weight: 1},
+	}, "  golang ", "POSTGRESQL")
+
+	assert.InDelta(t, 1.0, score.SkillScore, 1e-9)
+	assert.ElementsMatch(t, []string{"Go", "PostgreSQL"}, score.MatchedSkills)
+	assert.Empty(t, score.MissingSkills)
+}
+
+func TestCalculateMatchScore_PartialMatchWeightsImportance(t *testing.T) {
+	score := matchScoreFor(t, []job.JobSkill{
+		{SkillID: 1, ImportanceLevel: "required", Weight: 1},
+		{SkillID: 3, ImportanceLevel: "preferred", Weight: 1},
+		{SkillID: 4, ImportanceLevel: "optional", Weight: 0.5},
+	}, "Go", "k8s")
+
+	// matched: 1.0 (Go) + 0.15 (k8s) out of 1.0 + 0.6 + 0.15
+	assert.InDelta(t, 1.15/1.75, score.SkillScore, 1e-9)
+	assert.Equal(t, []string{"Go", "Kubernetes"}, score.MatchedSkills)
+	assert.Equal(t, []string{"Docker"}, score.MissingSkills)
+}
+
+func TestCalculateMatchScore_MissingRequiredSkills(t *testing.T) {
+	score := matchScoreFor(t, []job.JobSkill{
+		{SkillID: 1, ImportanceLevel: "required", Weight: 1},
+		{SkillID: 2, ImportanceLevel: "required", Weight: 1},
+	}, "Photoshop")
+
+	assert.Zero(t, score.SkillScore)
+	assert.Empty(t, score.MatchedSkills)
+	assert.Equal(t, []string{"Go", "PostgreSQL"}, score.MissingSkills)
+}
+
+func TestCalculateMatchScore_EmptyJobSkills(t *testing.T) {
+	score := matchScoreFor(t, nil, "Go")
+
+	assert.Equal(t, 1.0, score.SkillScore)
+	assert.Empty(t, score.MatchedSkills)
+	assert.Empty(t, score.MissingSkills)
+}
+
+type viewJobRepo struct {
+	job.JobRepository
+
+	events []job.JobViewEvent
+	window time.Duration
+	daily  []job.TimeSeriesData
+}
+
+func (r *viewJobRepo) RecordView(ctx context.Context, event *job.JobViewEvent, window time.Duration) (bool, error) {
+	r.events = append(r.events, *event)
+	r.window = window
+	return true, nil
+}
+
+func (r *viewJobRepo) GetJobStats(ctx context.Context, jobID int64) (*job.JobStats, error) {
+	return &job.JobStats{JobID: jobID, ViewsCount: 12}, nil
+}
+
+func (r *viewJobRepo) GetDailyViews(ctx context.Context, jobID int64, from, to time.Time) ([]job.TimeSeriesData, error) {
+	return r.daily, nil
+}
+
+func TestIncrementView_PassesViewerIdentityForDedup(t *testing.T) {
+	repo := &viewJobRepo{}
+	svc := service.NewJobService(repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil, nil, "view-secret")
+
+	userID := int64(9)
+	require.NoError(t, svc.IncrementView(context.Background(), 1, &userID))
+	require.NoError(t, svc.IncrementView(job.WithViewerIP(context.Background(), "203.0.113.7"), 1, nil))
+
+	require.Len(t, repo.events, 2)
+	assert.Equal(t, 24*time.Hour, repo.window)
+	assert.Equal(t, int64(9), *repo.events[0].UserID)
+	assert.Nil(t, repo.events[0].IPHash)
+
+	assert.Nil(t, repo.events[1].UserID)
+	require.NotNil(t, repo.events[1].IPHash)
+	assert.Len(t, *repo.events[1].IPHash, 64)
+	assert.NotContains(t, *repo.events[1].IPHash, "203.0.113.7")
+
+	// The hash is keyed, so it can't be recomputed from the IP alone
+	plain := sha256.Sum256([]byte("203.0.113.7"))
+	assert.NotEqual(t, hex.EncodeToString(plain[:]), *repo.events[1].IPHash)
+
+	other := service.NewJobService(repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil, nil, "other-secret")
+	require.NoError(t, other.IncrementView(job.WithViewerIP(context.Background(), "203.0.113.7"), 1, nil))
+	require.Len(t, repo.events, 3)
+	assert.NotEqual(t, *repo.events[1].IPHash, *repo.events[2].IPHash)
+}
+
+func TestGetJobAnalytics_FillsMissingDays(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, time.March, d, 0, 0, 0, 0, time.UTC) }
+	repo := &viewJobRepo{daily: []job.TimeSeriesData{{Date: day(2), Value: 5}, {Date: day(4), Value: 7}}}
+	svc := service.NewJobService(repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil, nil, "")
+
+	analytics, err := svc.GetJobAnalytics(context.Background(), 1, day(1), day(4).Add(15*time.Hour))
+	require.NoError(t, err)
+
+	assert.Equal(t, []job.TimeSeriesData{
+		{Date: day(1), Value: 0},
+		{Date: day(2), Value: 5},
+		{Date: day(3), Value: 0},
+		{Date: day(4), Value: 7},
+	}, analytics.ViewsData)
+	assert.Equal(t, int64(12), analytics.TotalViews)
+}
+
+func TestListCompanyDrafts_ReportsMissingPublishFields(t *testing.T) {
+	salary := 5000000.0
+	repo := &fakes.JobRepository{Jobs: map[int64]*job.Job{
+		1: {ID: 1, CompanyID: 7, Status: "draft", Description: "Build things", SalaryDisplay: "range", SalaryMin: &salary, TotalHires: 1},
+		2: {ID: 2, CompanyID: 7, Status: "published", Title: "Live job"},
+	}}
+	svc := service.NewJobService(repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil, nil, "")
+
+	drafts, total, err := svc.ListCompanyDrafts(context.Background(), 7, 1, 10)
+	require.NoError(t, err)
+
+	require.Equal(t, int64(1), total)
+	require.Len(t, drafts, 1)
+	assert.Equal(t, int64(1), drafts[0].Job.ID)
+	assert.Equal(t, []string{"title", "salary_max"}, drafts[0].MissingFields)
+}
+
+func TestRestoreDraftRevision_ResavesPayloadAsNewestRevision(t *testing.T) {
+	repo := &fakes.JobRepository{
+		Jobs: map[int64]*job.Job{
+			3: {ID: 3, CompanyID: 7, Status: "draft", Description: "Current description", Slug: "draft"},
+		},
+		Revisions: []job.JobDraftRevision{
+			{ID: 10, JobID: 3, Payload: `{"draft_id":3,"job_category_id":2,"gaji_min":100,"gaji_maks":200,"deskripsi":"Earlier description"}`},
+		},
+	}
+	svc := service.NewJobService(repo, &fakes.CompanyRepository{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil, nil, "")
+
+	recruiter := &employer.EmployerContext{UserID: 99, EmployerUserID: 12, CompanyID: 7, Role: "recruiter"}
+	restored, err := svc.RestoreDraftRevision(context.Background(), 3, 10, recruiter)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Earlier description", restored.Description)
+	require.Len(t, repo.Revisions, 2)
+	assert.Equal(t, job.MaxDraftRevisions, repo.Keep)
+	assert.Contains(t, repo.Revisions[1].Payload, `"draft_id":3`)
+
+	_, err = svc.RestoreDraftRevision(context.Background(), 3, 11, recruiter)
+	assert.ErrorIs(t, err, job.ErrDraftRevisionNotFound)
+}
+
+func TestSaveJobDraft_ReplacesSkillsWithLatestSet(t *testing.T) {
+	repo := &fakes.JobRepository{Jobs: map[int64]*job.Job{
+		3: {ID: 3, CompanyID: 7, Status: "draft", Slug: "draft"},
+	}}
+	svc := service.NewJobService(repo, &fakes.CompanyRepository{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil, nil, "")
+	draftID := int64(3)
+
+	save := func(skillIDs ...int64) []int64 {
+		t.Helper()
+		_, err := svc.SaveJobDraft(context.Background(), 7, &job.SaveJobDraftRequest{
+			DraftID: &draftID, JobCategoryID: 2, GajiMin: 100, GajiMaks: 200, SkillIDs: skillIDs,
+		})
+		require.NoError(t, err)
+
+		var ids []int64
+		for _, s := range repo.Skills[draftID] {
+			ids = append(ids, s.SkillID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []int64{1, 2, 3}, save(1, 2, 3))
+	assert.Equal(t, []int64{2, 4}, save(2, 4, 4))
+	assert.Empty(t, save())
+}
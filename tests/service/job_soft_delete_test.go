@@ -0,0 +1,159 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/notification"
+	"keerja-backend/internal/service"
+)
+
+type softDeleteJobRepo struct {
+	job.JobRepository
+
+	jobs      map[int64]*job.Job
+	withdrawn map[int64][]job.WithdrawnApplication
+	restored  []int64
+}
+
+func (r *softDeleteJobRepo) FindByID(ctx context.Context, id int64) (*job.Job, error) {
+	j, ok := r.jobs[id]
+	if !ok || j.DeletedAt.Valid {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return j, nil
+}
+
+func (r *softDeleteJobRepo) SoftDelete(ctx context.Context, id int64) ([]job.WithdrawnApplication, error) {
+	r.jobs[id].DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	return r.withdrawn[id], nil
+}
+
+func (r *softDeleteJobRepo) DeleteDraftRevisions(ctx context.Context, jobID int64) error {
+	return nil
+}
+
+func (r *softDeleteJobRepo) FindDeletedByID(ctx context.Context, id int64) (*job.Job, error) {
+	j, ok := r.jobs[id]
+	if !ok || !j.DeletedAt.Valid {
+		return nil, nil
+	}
+	return j, nil
+}
+
+func (r *softDeleteJobRepo) Restore(ctx context.Context, id int64) error {
+	r.jobs[id].DeletedAt = gorm.DeletedAt{}
+	r.restored = append(r.restored, id)
+	return nil
+}
+
+type statusUpdateCall struct {
+	userID, applicationID int64
+	oldStatus, newStatus  string
+}
+
+type statusUpdateNotifier struct {
+	notification.NotificationService
+
+	calls []statusUpdateCall
+	fail  bool
+}
+
+func (n *statusUpdateNotifier) NotifyStatusUpdate(ctx context.Context, userID, applicationID int64, oldStatus, newStatus string) error {
+	n.calls = append(n.calls, statusUpdateCall{userID, applicationID, oldStatus, newStatus})
+	if n.fail {
+		return errors.New("push unavailable")
+	}
+	return nil
+}
+
+// softDeletePoster is the employer user who posted the fixture's jobs
+var softDeletePoster = &employer.EmployerContext{UserID: 5, EmployerUserID: 30, CompanyID: 3, Role: "recruiter"}
+
+func newSoftDeleteFixture() (job.JobService, *softDeleteJobRepo, *statusUpdateNotifier) {
+	ownerID := int64(30)
+	jobRepo := &softDeleteJobRepo{
+		jobs: map[int64]*job.Job{
+			1: {ID: 1, CompanyID: 3, EmployerUserID: &ownerID, Status: "published"},
+			2: {ID: 2, CompanyID: 3, EmployerUserID: &ownerID, Status: "published"},
+		},
+		withdrawn: map[int64][]job.WithdrawnApplication{
+			1: {
+				{ApplicationID: 100, UserID: 50, PreviousStatus: "applied"},
+				{ApplicationID: 101, UserID: 51, PreviousStatus: "interview"},
+			},
+		},
+	}
+	notifier := &statusUpdateNotifier{}
+	svc := service.NewJobService(jobRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, notifier, nil, job.DefaultExpiryPolicy, nil, nil, nil, "")
+	return svc, jobRepo, notifier
+}
+
+func TestDeleteJob_NotifiesWithdrawnApplicants(t *testing.T) {
+	svc, jobRepo, notifier := newSoftDeleteFixture()
+
+	require.NoError(t, svc.DeleteJob(context.Background(), 1, softDeletePoster))
+
+	assert.True(t, jobRepo.jobs[1].DeletedAt.Valid)
+	assert.Equal(t, []statusUpdateCall{
+		{userID: 50, applicationID: 100, oldStatus: "applied", newStatus: application.StatusJobWithdrawn},
+		{userID: 51, applicationID: 101, oldStatus: "interview", newStatus: application.StatusJobWithdrawn},
+	}, notifier.calls)
+}
+
+func TestDeleteJob_FailedNotificationDoesNotFailDeletion(t *testing.T) {
+	svc, jobRepo, notifier := newSoftDeleteFixture()
+	notifier.fail = true
+
+	require.NoError(t, svc.DeleteJob(context.Background(), 1, softDeletePoster))
+
+	assert.True(t, jobRepo.jobs[1].DeletedAt.Valid)
+	assert.Len(t, notifier.calls, 2, "every applicant is still attempted")
+}
+
+func TestRestoreJob(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("within the restore window", func(t *testing.T) {
+		svc, jobRepo, _ := newSoftDeleteFixture()
+		require.NoError(t, svc.DeleteJob(ctx, 1, softDeletePoster))
+
+		require.NoError(t, svc.RestoreJob(ctx, 1, softDeletePoster))
+		assert.Equal(t, []int64{1}, jobRepo.restored)
+		assert.False(t, jobRepo.jobs[1].DeletedAt.Valid)
+	})
+
+	t.Run("after the restore window", func(t *testing.T) {
+		svc, jobRepo, _ := newSoftDeleteFixture()
+		jobRepo.jobs[1].DeletedAt = gorm.DeletedAt{Time: time.Now().Add(-job.RestoreWindow - time.Hour), Valid: true}
+
+		err := svc.RestoreJob(ctx, 1, softDeletePoster)
+		assert.ErrorIs(t, err, job.ErrJobRestoreExpired)
+		assert.Empty(t, jobRepo.restored)
+	})
+
+	t.Run("job that is not deleted", func(t *testing.T) {
+		svc, _, _ := newSoftDeleteFixture()
+
+		err := svc.RestoreJob(ctx, 2, softDeletePoster)
+		assert.ErrorIs(t, err, job.ErrJobNotDeleted)
+	})
+
+	t.Run("employer user without permission", func(t *testing.T) {
+		svc, jobRepo, _ := newSoftDeleteFixture()
+		require.NoError(t, svc.DeleteJob(ctx, 1, softDeletePoster))
+
+		err := svc.RestoreJob(ctx, 1, duplicateViewer)
+		assert.ErrorIs(t, err, job.ErrJobPermissionDenied)
+		assert.Empty(t, jobRepo.restored)
+	})
+}
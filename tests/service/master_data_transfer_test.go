@@ -0,0 +1,102 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/master"
+	"keerja-backend/internal/service"
+)
+
+func TestMasterDataTransfer_RejectsUnknownTypeAndMissingColumns(t *testing.T) {
+	svc := service.NewAdminMasterDataTransferService(nil, nil)
+	ctx := context.Background()
+
+	_, err := svc.Import(ctx, "skills", strings.NewReader("code,name\n"))
+	assert.ErrorIs(t, err, master.ErrUnknownMasterDataType)
+
+	err = svc.Export(ctx, "skills", &bytes.Buffer{})
+	assert.ErrorIs(t, err, master.ErrUnknownMasterDataType)
+
+	_, err = svc.Import(ctx, master.MasterDataCities, strings.NewReader("code,name,type\n3273,Bandung,Kota\n"))
+	assert.ErrorIs(t, err, master.ErrMasterDataImportInvalidFile)
+
+	_, err = svc.Import(ctx, master.MasterDataProvinces, strings.NewReader(""))
+	assert.ErrorIs(t, err, master.ErrMasterDataImportInvalidFile)
+}
+
+// setupTransferDB opens a transaction on a migrated test database that is rolled back after the test
+func setupTransferDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	url := os.Getenv("TEST_DB_URL")
+	if url == "" {
+		t.Skip("TEST_DB_URL not set; skipping postgres master data import tests")
+	}
+
+	db, err := gorm.Open(postgres.Open(url), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	tx := db.Begin()
+	require.NoError(t, tx.Error)
+	t.Cleanup(func() { tx.Rollback() })
+	return tx
+}
+
+func TestMasterDataTransfer_ImportsLocationsAndReportsRows(t *testing.T) {
+	db := setupTransferDB(t)
+	memCache := cache.NewInMemoryCache(100, time.Minute)
+	t.Cleanup(memCache.Stop)
+	svc := service.NewAdminMasterDataTransferService(db, memCache)
+	ctx := context.Background()
+
+	suffix := time.Now().UnixNano() % 100000
+	province := fmt.Sprintf("T%d", suffix)
+	city := fmt.Sprintf("C%d", suffix)
+
+	report, err := svc.Import(ctx, master.MasterDataProvinces, strings.NewReader(
+		"code,name\n"+province+",Test Province\n"+province+"x,\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.TotalRows)
+	assert.Equal(t, 1, report.Created)
+	require.Len(t, report.Rows, 2)
+	assert.Equal(t, master.MasterDataRowFailed, report.Rows[1].Status)
+	assert.Equal(t, 3, report.Rows[1].Row)
+	assert.Contains(t, report.Rows[1].Reason, "name is required")
+
+	memCache.Set("cities:province:1", "stale", time.Minute)
+	memCache.Set("industries:all", "kept", time.Minute)
+
+	report, err = svc.Import(ctx, master.MasterDataCities, strings.NewReader(
+		"name,type,province_code,code\n"+
+			"Test City,Kota,"+province+","+city+"\n"+
+			"Orphan City,Kota,NOPE,"+city+"x\n"+
+			"Renamed City,Kabupaten,"+province+","+city+"\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Updated)
+	assert.Equal(t, 1, report.Failed)
+	assert.Contains(t, report.Rows[1].Reason, `province_code "NOPE" does not exist`)
+
+	_, stale := memCache.Get("cities:province:1")
+	assert.False(t, stale)
+	_, kept := memCache.Get("industries:all")
+	assert.True(t, kept)
+
+	var out bytes.Buffer
+	require.NoError(t, svc.Export(ctx, master.MasterDataCities, &out))
+	assert.True(t, strings.HasPrefix(out.String(), "code,province_code,name,type,is_active\n"))
+	assert.Contains(t, out.String(), city+","+province+",Renamed City,Kabupaten,true\n")
+}
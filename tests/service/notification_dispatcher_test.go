@@ -0,0 +1,178 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/notification"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+type memoryDeferredPushRepo struct {
+	notification.DeferredPushRepository
+	pushes []notification.DeferredPush
+}
+
+func (r *memoryDeferredPushRepo) Create(ctx context.Context, push *notification.DeferredPush) error {
+	push.ID = int64(len(r.pushes) + 1)
+	r.pushes = append(r.pushes, *push)
+	return nil
+}
+
+func (r *memoryDeferredPushRepo) ClaimDue(ctx context.Context, now time.Time, limit int) ([]notification.DeferredPush, error) {
+	var due, kept []notification.DeferredPush
+	for _, push := range r.pushes {
+		if !push.DeliverAt.After(now) && len(due) < limit {
+			due = append(due, push)
+		} else {
+			kept = append(kept, push)
+		}
+	}
+	r.pushes = kept
+	return due, nil
+}
+
+// quietNow returns quiet hours around the current time in the default timezone
+func quietNow() (start, end *string) {
+	loc, _ := time.LoadLocation(user.DefaultQuietHoursTimezone)
+	now := time.Now().In(loc)
+	return utils.StringPtr(now.Add(-time.Hour).Format("15:04")), utils.StringPtr(now.Add(time.Hour).Format("15:04"))
+}
+
+func TestUserPreference_NextPushTime(t *testing.T) {
+	loc, err := time.LoadLocation(user.DefaultQuietHoursTimezone)
+	require.NoError(t, err)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.March, day, hour, minute, 0, 0, loc)
+	}
+	overnight := &user.UserPreference{QuietHoursStart: utils.StringPtr("22:00"), QuietHoursEnd: utils.StringPtr("07:00")}
+	daytime := &user.UserPreference{QuietHoursStart: utils.StringPtr("13:00"), QuietHoursEnd: utils.StringPtr("15:00")}
+
+	tests := []struct {
+		name string
+		pref *user.UserPreference
+		now  time.Time
+		want time.Time
+	}{
+		{"no preference", nil, at(10, 23, 30), at(10, 23, 30)},
+		{"equal start and end", &user.UserPreference{QuietHoursStart: utils.StringPtr("07:00"), QuietHoursEnd: utils.StringPtr("07:00")}, at(10, 7, 0), at(10, 7, 0)},
+		{"before midnight", overnight, at(10, 23, 30), at(11, 7, 0)},
+		{"after midnight", overnight, at(11, 3, 0), at(11, 7, 0)},
+		{"outside overnight", overnight, at(10, 12, 0), at(10, 12, 0)},
+		{"end is not quiet", overnight, at(11, 7, 0), at(11, 7, 0)},
+		{"within daytime", daytime, at(10, 14, 0), at(10, 15, 0)},
+		{"outside daytime", daytime, at(10, 16, 0), at(10, 16, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.want.Equal(tt.pref.NextPushTime(tt.now)), "got %s", tt.pref.NextPushTime(tt.now))
+		})
+	}
+
+	t.Run("read in the user's timezone", func(t *testing.T) {
+		pref := &user.UserPreference{QuietHoursStart: utils.StringPtr("22:00"), QuietHoursEnd: utils.StringPtr("07:00"), QuietHoursTimezone: "UTC"}
+		// 23:30 in Jakarta is 16:30 UTC, outside the user's quiet hours
+		now := at(10, 23, 30)
+		assert.True(t, now.Equal(pref.NextPushTime(now)))
+	})
+}
+
+func TestNotificationDispatcher_PushToUsersEnforcesMatrixAndQuietHours(t *testing.T) {
+	start, end := quietNow()
+	userRepo := &fakes.UserRepository{Preferences: map[int64]*user.UserPreference{
+		2: {UserID: 2, EmailNotifications: true, PushNotifications: true, NotificationMatrix: user.NotificationMatrix{
+			user.NotificationEventJobAlerts: {Email: true, Push: false},
+		}},
+		3: {UserID: 3, EmailNotifications: true, PushNotifications: true, QuietHoursStart: start, QuietHoursEnd: end},
+		4: {UserID: 4, EmailNotifications: true, PushNotifications: false},
+	}}
+	pushSvc := &fakes.PushService{}
+	deferred := &memoryDeferredPushRepo{}
+	dispatcher := service.NewNotificationDispatcher(userRepo, pushSvc, deferred, nil, nil)
+
+	message := &notification.PushMessage{Title: "New job", Body: "Backend Engineer", Data: map[string]string{"job_id": "9"}}
+	stats, err := dispatcher.PushToUsers(context.Background(), []int64{1, 2, 3, 4}, user.NotificationEventJobAlerts, message)
+	require.NoError(t, err)
+
+	assert.Equal(t, []int64{1}, pushSvc.UserIDs, "users without preferences get every push")
+	assert.Equal(t, 1, stats.Sent)
+	assert.Equal(t, 1, stats.Deferred)
+	assert.Equal(t, 2, stats.Muted)
+
+	require.Len(t, deferred.pushes, 1)
+	push := deferred.pushes[0]
+	assert.Equal(t, int64(3), push.UserID)
+	assert.Equal(t, string(user.NotificationEventJobAlerts), push.Event)
+	assert.Equal(t, "9", push.Message().Data["job_id"])
+	assert.WithinDuration(t, time.Now().Add(time.Hour), push.DeliverAt, time.Minute, "deferred until quiet hours end")
+
+	// Transactional pushes ignore the matrix and master switch but not quiet hours
+	pushSvc.UserIDs = nil
+	stats, err = dispatcher.PushToUsers(context.Background(), []int64{2, 3, 4}, user.NotificationEventTransactional, message)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{2, 4}, pushSvc.UserIDs)
+	assert.Equal(t, 1, stats.Deferred)
+	assert.Zero(t, stats.Muted)
+}
+
+func TestNotificationDispatcher_EmailFollowsMatrix(t *testing.T) {
+	start, end := quietNow()
+	userRepo := &fakes.UserRepository{Preferences: map[int64]*user.UserPreference{
+		2: {UserID: 2, EmailNotifications: true, PushNotifications: true, QuietHoursStart: start, QuietHoursEnd: end, NotificationMatrix: user.NotificationMatrix{
+			user.NotificationEventApplicationStatus: {Email: false, Push: true},
+		}},
+	}}
+	dispatcher := service.NewNotificationDispatcher(userRepo, nil, &memoryDeferredPushRepo{}, nil, nil)
+
+	calls := 0
+	send := func(ctx context.Context) error {
+		calls++
+		return nil
+	}
+
+	sent, err := dispatcher.Email(context.Background(), 2, user.NotificationEventApplicationStatus, send)
+	require.NoError(t, err)
+	assert.False(t, sent)
+
+	sent, err = dispatcher.Email(context.Background(), 2, user.NotificationEventInterview, send)
+	require.NoError(t, err)
+	assert.True(t, sent, "emails are not held back by quiet hours")
+
+	sent, err = dispatcher.Email(context.Background(), 1, user.NotificationEventMarketing, send)
+	require.NoError(t, err)
+	assert.False(t, sent, "marketing needs consent")
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestNotificationDispatcher_SendDeferredPushesRechecksPreferences(t *testing.T) {
+	userRepo := &fakes.UserRepository{Preferences: map[int64]*user.UserPreference{
+		2: {UserID: 2, EmailNotifications: true, PushNotifications: false},
+	}}
+	pushSvc := &fakes.PushService{}
+	deferred := &memoryDeferredPushRepo{}
+	dispatcher := service.NewNotificationDispatcher(userRepo, pushSvc, deferred, nil, nil)
+
+	message := &notification.PushMessage{Title: "Interview", Body: "Tomorrow at 10:00"}
+	past, future := time.Now().Add(-time.Minute), time.Now().Add(time.Hour)
+	require.NoError(t, deferred.Create(context.Background(), notification.NewDeferredPush(1, user.NotificationEventInterview, message, past)))
+	require.NoError(t, deferred.Create(context.Background(), notification.NewDeferredPush(2, user.NotificationEventInterview, message, past)))
+	require.NoError(t, deferred.Create(context.Background(), notification.NewDeferredPush(3, user.NotificationEventInterview, message, future)))
+
+	sent, err := dispatcher.SendDeferredPushes(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, []int64{1}, pushSvc.UserIDs, "pushes muted since they were deferred are dropped")
+	assert.Equal(t, "Interview", pushSvc.Message.Title)
+	require.Len(t, deferred.pushes, 1, "pushes not yet due are kept")
+	assert.Equal(t, int64(3), deferred.pushes[0].UserID)
+}
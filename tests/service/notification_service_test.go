@@ -0,0 +1,115 @@
+package service_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"keerja-backend/internal/domain/notification"
+	"keerja-backend/internal/i18n"
+	"keerja-backend/internal/service"
+)
+
+// memoryNotificationRepo keeps notifications in memory; delivery runs in a goroutine so access is locked
+type memoryNotificationRepo struct {
+	notification.NotificationRepository
+
+	mu            sync.Mutex
+	notifications map[int64]*notification.Notification
+	nextID        int64
+}
+
+func newMemoryNotificationRepo() *memoryNotificationRepo {
+	return &memoryNotificationRepo{notifications: map[int64]*notification.Notification{}}
+}
+
+func (r *memoryNotificationRepo) Create(ctx context.Context, notif *notification.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	notif.ID = r.nextID
+	copied := *notif
+	r.notifications[notif.ID] = &copied
+	return nil
+}
+
+func (r *memoryNotificationRepo) Update(ctx context.Context, notif *notification.Notification) error {
+	return nil
+}
+
+func (r *memoryNotificationRepo) FindByID(ctx context.Context, id int64) (*notification.Notification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	notif, ok := r.notifications[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *notif
+	return &copied, nil
+}
+
+func (r *memoryNotificationRepo) MarkAsRead(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notifications[id].IsRead = true
+	return nil
+}
+
+func (r *memoryNotificationRepo) FindPreferenceByUser(ctx context.Context, userID int64) (*notification.NotificationPreference, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryNotificationRepo) CreatePreference(ctx context.Context, pref *notification.NotificationPreference) error {
+	return nil
+}
+
+func (r *memoryNotificationRepo) byUser(userID int64) []notification.Notification {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var notifs []notification.Notification
+	for _, notif := range r.notifications {
+		if notif.UserID == userID {
+			notifs = append(notifs, *notif)
+		}
+	}
+	return notifs
+}
+
+func TestNotificationService_ApplicationReceivedNotifiesEachEmployer(t *testing.T) {
+	repo := newMemoryNotificationRepo()
+	svc := service.NewNotificationService(repo, nil, nil)
+	ctx := i18n.WithLocale(context.Background(), i18n.English)
+
+	require.NoError(t, svc.NotifyApplicationReceived(ctx, []int64{7, 8}, 5, 42, "Backend Engineer"))
+
+	for _, userID := range []int64{7, 8} {
+		notifs := repo.byUser(userID)
+		require.Len(t, notifs, 1)
+		assert.Equal(t, "application_received", notifs[0].Type)
+		assert.Equal(t, "You received a new application for Backend Engineer", notifs[0].Message)
+		assert.Equal(t, int64(42), *notifs[0].RelatedID)
+		assert.JSONEq(t, `{"job_id":5,"application_id":42}`, notifs[0].Data)
+	}
+}
+
+func TestNotificationService_MarkAsReadOnlyForRecipient(t *testing.T) {
+	repo := newMemoryNotificationRepo()
+	svc := service.NewNotificationService(repo, nil, nil)
+
+	require.NoError(t, svc.NotifyJobReviewed(context.Background(), 7, 5, "Backend Engineer", false, "Salary range is missing"))
+	notifs := repo.byUser(7)
+	require.Len(t, notifs, 1)
+	assert.Equal(t, "Lowongan Backend Engineer ditolak: Salary range is missing", notifs[0].Message)
+	id := notifs[0].ID
+
+	assert.ErrorIs(t, svc.MarkAsRead(context.Background(), id, 8), notification.ErrNotificationNotFound)
+	assert.ErrorIs(t, svc.MarkAsRead(context.Background(), id+1, 7), notification.ErrNotificationNotFound)
+	assert.False(t, repo.byUser(7)[0].IsRead)
+
+	require.NoError(t, svc.MarkAsRead(context.Background(), id, 7))
+	assert.True(t, repo.byUser(7)[0].IsRead)
+}
@@ -0,0 +1,164 @@
+package service_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/apperror"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// versionedCompanyRepo stores one company and checks versions on update the way the
+// postgres repository does
+type versionedCompanyRepo struct {
+	company.CompanyRepository
+
+	mu      sync.Mutex
+	stored  company.Company
+	profile company.CompanyProfile
+	loads   *sync.WaitGroup // When set, loads wait for each other so editors share a version
+}
+
+func (r *versionedCompanyRepo) FindByID(ctx context.Context, id int64) (*company.Company, error) {
+	r.mu.Lock()
+	comp := r.stored
+	r.mu.Unlock()
+
+	if r.loads != nil {
+		r.loads.Done()
+		r.loads.Wait()
+	}
+	return &comp, nil
+}
+
+func (r *versionedCompanyRepo) Update(ctx context.Context, c *company.Company) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c.Version != r.stored.Version {
+		return company.ErrCompanyVersionConflict.WithCurrentVersion(r.stored.Version)
+	}
+	c.Version++
+	r.stored = *c
+	return nil
+}
+
+func (r *versionedCompanyRepo) FindProfileByCompanyID(ctx context.Context, companyID int64) (*company.CompanyProfile, error) {
+	profile := r.profile
+	return &profile, nil
+}
+
+func (r *versionedCompanyRepo) UpdateProfile(ctx context.Context, profile *company.CompanyProfile) error {
+	if profile.Version != r.profile.Version {
+		return company.ErrProfileVersionConflict.WithCurrentVersion(r.profile.Version)
+	}
+	profile.Version++
+	r.profile = *profile
+	return nil
+}
+
+func assertVersionConflict(t *testing.T, err, conflict error, current string) {
+	t.Helper()
+	require.ErrorIs(t, err, conflict)
+	appErr, ok := apperror.As(err)
+	require.True(t, ok)
+	assert.Equal(t, apperror.KindConflict, appErr.Kind)
+	assert.Equal(t, current, appErr.Details["current_version"])
+}
+
+func TestUpdateCompany_RejectsStaleVersion(t *testing.T) {
+	repo := &versionedCompanyRepo{stored: company.Company{ID: 1, Slug: "acme", FullAddress: "Jl. Sudirman 1", Version: 3}}
+	svc, _ := helpers.NewCachedCompanyService(t, repo)
+
+	err := svc.UpdateCompany(context.Background(), 1, &company.UpdateCompanyRequest{
+		FullAddress: utils.StringPtr("Jl. Thamrin 2"),
+		Version:     utils.Int64Ptr(2),
+	}, nil, nil)
+	assertVersionConflict(t, err, company.ErrCompanyVersionConflict, "3")
+	assert.Equal(t, "Jl. Sudirman 1", repo.stored.FullAddress)
+
+	// The current version goes through and is incremented
+	require.NoError(t, svc.UpdateCompany(context.Background(), 1, &company.UpdateCompanyRequest{
+		FullAddress: utils.StringPtr("Jl. Thamrin 2"),
+		Version:     utils.Int64Ptr(3),
+	}, nil, nil))
+	assert.Equal(t, "Jl. Thamrin 2", repo.stored.FullAddress)
+	assert.Equal(t, int64(4), repo.stored.Version)
+}
+
+func TestUpdateCompany_ConcurrentEditsAreDetected(t *testing.T) {
+	loads := &sync.WaitGroup{}
+	loads.Add(2)
+	repo := &versionedCompanyRepo{stored: company.Company{ID: 1, Slug: "acme", FullAddress: "Jl. Sudirman 1", Version: 1}, loads: loads}
+	svc, _ := helpers.NewCachedCompanyService(t, repo)
+
+	// Both admins loaded version 1; neither sends it, so only the repository can catch the race
+	addresses := []string{"Jl. Thamrin 2", "Jl. Gatot Subroto 3"}
+	errs := make([]error, len(addresses))
+	var wg sync.WaitGroup
+	for i, address := range addresses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = svc.UpdateCompany(context.Background(), 1, &company.UpdateCompanyRequest{FullAddress: &address}, nil, nil)
+		}()
+	}
+	wg.Wait()
+
+	winner, loser := 0, 1
+	if errs[0] != nil {
+		winner, loser = 1, 0
+	}
+	require.NoError(t, errs[winner])
+	assertVersionConflict(t, errs[loser], company.ErrCompanyVersionConflict, "2")
+	assert.Equal(t, addresses[winner], repo.stored.FullAddress)
+	assert.Equal(t, int64(2), repo.stored.Version)
+}
+
+func TestUpdateProfile_RejectsStaleVersion(t *testing.T) {
+	repo := &versionedCompanyRepo{profile: company.CompanyProfile{ID: 5, CompanyID: 1, Mission: utils.StringPtr("Old"), Version: 2}}
+	svc, _ := helpers.NewCachedCompanyService(t, repo)
+
+	err := svc.UpdateProfile(context.Background(), 1, &company.UpdateProfileRequest{Mission: utils.StringPtr("New"), Version: utils.Int64Ptr(1)})
+	assertVersionConflict(t, err, company.ErrProfileVersionConflict, "2")
+	assert.Equal(t, "Old", *repo.profile.Mission)
+
+	require.NoError(t, svc.UpdateProfile(context.Background(), 1, &company.UpdateProfileRequest{Mission: utils.StringPtr("New"), Version: utils.Int64Ptr(2)}))
+	assert.Equal(t, "New", *repo.profile.Mission)
+	assert.Equal(t, int64(3), repo.profile.Version)
+}
+
+func TestUpdateEmployerUser_RejectsStaleVersion(t *testing.T) {
+	repo := &fakes.CompanyRepository{EmployerUsers: []company.EmployerUser{{ID: 40, UserID: 7, CompanyID: 3, Role: "recruiter", Version: 4}}}
+	svc := service.NewCompanyService(repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	err := svc.UpdateEmployerUser(context.Background(), 7, 3, &company.UpdateEmployerUserRequest{
+		PositionTitle: utils.StringPtr("Lead Recruiter"),
+		Version:       utils.Int64Ptr(3),
+	})
+	assertVersionConflict(t, err, company.ErrEmployerUserVersionConflict, "4")
+	assert.Nil(t, repo.EmployerUsers[0].PositionTitle)
+}
+
+func TestUpdateJob_RejectsStaleVersion(t *testing.T) {
+	repo := helpers.NewEmployerJobRepository()
+	repo.Jobs[1].Version = 5
+	svc := service.NewJobService(repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil, nil, "")
+	ec := &employer.EmployerContext{UserID: 7, EmployerUserID: 40, CompanyID: 3, Role: "recruiter"}
+
+	_, err := svc.UpdateJob(context.Background(), 1, ec, &job.UpdateJobRequest{
+		Description: "A description long enough to be accepted by job validation rules",
+		Version:     utils.Int64Ptr(4),
+	})
+	assertVersionConflict(t, err, job.ErrJobVersionConflict, "5")
+	assert.Empty(t, repo.Jobs[1].Description)
+}
@@ -0,0 +1,144 @@
+package service_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// phoneUserRepo keeps users by ID and enforces that a phone number is verified only once
+type phoneUserRepo struct {
+	user.UserRepository
+
+	users map[int64]*user.User
+}
+
+func (r *phoneUserRepo) FindByID(ctx context.Context, id int64) (*user.User, error) {
+	u, ok := r.users[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *u
+	return &copied, nil
+}
+
+func (r *phoneUserRepo) FindByVerifiedPhone(ctx context.Context, phone string) (*user.User, error) {
+	for _, u := range r.users {
+		if u.IsPhoneVerified() && *u.Phone == phone {
+			copied := *u
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *phoneUserRepo) MarkPhoneVerified(ctx context.Context, userID int64, phone string, verifiedAt time.Time) error {
+	if existing, _ := r.FindByVerifiedPhone(ctx, phone); existing != nil && existing.ID != userID {
+		return user.ErrPhoneTaken
+	}
+	r.users[userID].PhoneVerifiedAt = &verifiedAt
+	return nil
+}
+
+// recordingSMSProvider remembers the recipients and messages sent
+type recordingSMSProvider struct {
+	to   []string
+	sent []string
+}
+
+func (p *recordingSMSProvider) SendSMS(ctx context.Context, to, message string) error {
+	p.to = append(p.to, to)
+	p.sent = append(p.sent, message)
+	return nil
+}
+
+var smsCodePattern = regexp.MustCompile(`\b\d{6}\b`)
+
+// lastCode returns the code in the last message sent
+func (p *recordingSMSProvider) lastCode() string {
+	if len(p.sent) == 0 {
+		return ""
+	}
+	return smsCodePattern.FindString(p.sent[len(p.sent)-1])
+}
+
+func newPhoneVerificationFixture() (*service.RegistrationService, *phoneUserRepo, *recordingSMSProvider) {
+	phone := "+6281234567890"
+	users := &phoneUserRepo{users: map[int64]*user.User{
+		1: {ID: 1, Email: "ani@example.com", Phone: &phone},
+		2: {ID: 2, Email: "budi@example.com", Phone: &phone},
+	}}
+	sms := &recordingSMSProvider{}
+	svc := service.NewRegistrationService(users, &fakes.OTPRepository{}, &fakes.EmailService{}, "secret", time.Hour, nil, sms, nil)
+	return svc, users, sms
+}
+
+func TestVerifyPhoneOTP_MarksPhoneVerified(t *testing.T) {
+	svc, users, sms := newPhoneVerificationFixture()
+	ctx := context.Background()
+
+	require.NoError(t, svc.RequestPhoneVerification(ctx, 1, "10.0.0.1"))
+	require.Len(t, sms.sent, 1)
+	assert.Equal(t, []string{"+6281234567890"}, sms.to)
+
+	_, err := svc.VerifyPhoneOTP(ctx, 1, helpers.WrongCode(sms.lastCode()))
+	assert.ErrorIs(t, err, service.ErrInvalidOTPCode)
+
+	usr, err := svc.VerifyPhoneOTP(ctx, 1, sms.lastCode())
+	require.NoError(t, err)
+	assert.True(t, usr.IsPhoneVerified())
+	assert.True(t, users.users[1].IsPhoneVerified())
+
+	assert.ErrorIs(t, svc.RequestPhoneVerification(ctx, 1, "10.0.0.1"), user.ErrPhoneAlreadyVerified)
+}
+
+func TestPhoneVerification_RejectsNumberVerifiedByAnotherAccount(t *testing.T) {
+	svc, users, sms := newPhoneVerificationFixture()
+	ctx := context.Background()
+
+	// Both accounts request a code before either verifies
+	require.NoError(t, svc.RequestPhoneVerification(ctx, 1, "10.0.0.1"))
+	firstCode := sms.lastCode()
+	require.NoError(t, svc.RequestPhoneVerification(ctx, 2, "10.0.0.2"))
+	secondCode := sms.lastCode()
+
+	_, err := svc.VerifyPhoneOTP(ctx, 1, firstCode)
+	require.NoError(t, err)
+
+	// The second account can neither verify its pending code nor request a new one
+	_, err = svc.VerifyPhoneOTP(ctx, 2, secondCode)
+	assert.ErrorIs(t, err, user.ErrPhoneTaken)
+	assert.False(t, users.users[2].IsPhoneVerified())
+	assert.ErrorIs(t, svc.RequestPhoneVerification(ctx, 2, "10.0.0.2"), user.ErrPhoneTaken)
+}
+
+func TestRequestPhoneVerification_NeedsPhone(t *testing.T) {
+	svc, users, sms := newPhoneVerificationFixture()
+	users.users[1].Phone = nil
+
+	assert.ErrorIs(t, svc.RequestPhoneVerification(context.Background(), 1, "10.0.0.1"), user.ErrPhoneNotSet)
+	assert.Empty(t, sms.sent)
+}
+
+func TestUserSetPhone_ClearsVerificationOnChange(t *testing.T) {
+	phone := "+6281234567890"
+	verifiedAt := time.Now()
+	usr := &user.User{Phone: &phone, PhoneVerifiedAt: &verifiedAt}
+
+	same := "+6281234567890"
+	usr.SetPhone(&same)
+	assert.True(t, usr.IsPhoneVerified())
+
+	other := "+6281298765432"
+	usr.SetPhone(&other)
+	assert.False(t, usr.IsPhoneVerified())
+}
@@ -0,0 +1,102 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/cache"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+)
+
+// completenessUserRepo serves a full profile and counts how often it is loaded
+type completenessUserRepo struct {
+	user.UserRepository
+	usr   *user.User
+	loads int
+}
+
+func (r *completenessUserRepo) GetFullProfile(ctx context.Context, userID int64) (*user.User, error) {
+	r.loads++
+	copied := *r.usr
+	return &copied, nil
+}
+
+func (r *completenessUserRepo) AddEducation(ctx context.Context, education *user.UserEducation) error {
+	r.usr.Educations = append(r.usr.Educations, *education)
+	return nil
+}
+
+func TestGetProfileCompleteness_WeightsItems(t *testing.T) {
+	salary := 10000000.0
+	phoneVerifiedAt := time.Now()
+	repo := &completenessUserRepo{usr: &user.User{
+		ID:              1,
+		Phone:           utils.StringPtr("+6281234567890"),
+		PhoneVerifiedAt: &phoneVerifiedAt,
+		Profile: &user.UserProfile{
+			Headline:         utils.StringPtr("Backend Engineer"),
+			Bio:              utils.StringPtr("   "), // Blank does not count
+			LocationCity:     utils.StringPtr("Bandung"),
+			DesiredPosition:  utils.StringPtr("Senior Backend Engineer"),
+			DesiredSalaryMin: &salary,
+		},
+		Skills: []user.UserSkill{{SkillName: "Go"}, {SkillName: "SQL"}},
+		Documents: []user.UserDocument{
+			{DocumentType: utils.StringPtr("resume"), IsActive: true},
+		},
+	}}
+	svc := service.NewUserService(repo, nil, nil, nil, nil, nil)
+
+	completeness, err := svc.GetProfileCompleteness(context.Background(), 1)
+	require.NoError(t, err)
+
+	// headline 10 + resume 15 + location 5 + job preferences 5 + verified phone 5
+	assert.Equal(t, 40, completeness.Score)
+
+	completed := map[string]bool{}
+	total := 0
+	for _, item := range completeness.Items {
+		completed[item.Key] = item.Completed
+		total += item.Weight
+	}
+	assert.Equal(t, 100, total)
+	assert.False(t, completed[user.CompletenessBio])
+	assert.False(t, completed[user.CompletenessSkills], "two skills are not enough")
+	assert.True(t, completed[user.CompletenessResume])
+
+	// Highest weight missing items come first
+	require.NotEmpty(t, completeness.NextActions)
+	assert.Equal(t, []string{"Add your education", "Add your work experience"}, completeness.NextActions[:2])
+
+	percentage, err := svc.GetProfileCompletionPercentage(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, completeness.Score, percentage)
+}
+
+func TestGetProfileCompleteness_CachedUntilProfileChanges(t *testing.T) {
+	repo := &completenessUserRepo{usr: &user.User{ID: 1}}
+	svc := service.NewUserService(repo, nil, nil, nil, cache.NewInMemoryCache(100, time.Minute), nil)
+	ctx := context.Background()
+
+	first, err := svc.GetProfileCompleteness(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, first.Score)
+
+	_, err = svc.GetProfileCompleteness(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, repo.loads, "second read is served from cache")
+
+	_, err = svc.AddEducation(ctx, 1, &user.AddEducationRequest{InstitutionName: "ITB"})
+	require.NoError(t, err)
+
+	updated, err := svc.GetProfileCompleteness(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, repo.loads)
+	assert.Equal(t, 15, updated.Score)
+}
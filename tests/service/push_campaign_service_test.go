@@ -0,0 +1,129 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/notification"
+	"keerja-backend/internal/service"
+)
+
+type fakeCampaignRepo struct {
+	notification.PushCampaignRepository
+	campaigns []notification.PushCampaign
+	updated   []notification.PushCampaign
+}
+
+func (r *fakeCampaignRepo) Create(ctx context.Context, c *notification.PushCampaign) error {
+	c.ID = int64(len(r.campaigns) + 1)
+	r.campaigns = append(r.campaigns, *c)
+	return nil
+}
+
+func (r *fakeCampaignRepo) ClaimDue(ctx context.Context, now time.Time, limit int) ([]notification.PushCampaign, error) {
+	due := r.campaigns
+	r.campaigns = nil
+	return due, nil
+}
+
+func (r *fakeCampaignRepo) Update(ctx context.Context, c *notification.PushCampaign) error {
+	r.updated = append(r.updated, *c)
+	return nil
+}
+
+type segmentTokenRepo struct {
+	notification.DeviceTokenRepository
+	tokens  []notification.DeviceToken
+	deleted []string
+}
+
+func (r *segmentTokenRepo) FindBySegment(ctx context.Context, segment notification.PushSegment, afterID int64, limit int) ([]notification.DeviceToken, error) {
+	var page []notification.DeviceToken
+	for _, token := range r.tokens {
+		if token.ID > afterID && len(page) < limit {
+			page = append(page, token)
+		}
+	}
+	return page, nil
+}
+
+func (r *segmentTokenRepo) DeleteByTokens(ctx context.Context, tokens []string) (int64, error) {
+	r.deleted = append(r.deleted, tokens...)
+	return int64(len(tokens)), nil
+}
+
+// batchPushService fails tokens listed in failures with the mapped FCM error code
+type batchPushService struct {
+	notification.PushNotificationService
+	batches  []int
+	failures map[string]string
+}
+
+func (s *batchPushService) SendToTokens(ctx context.Context, tokens []string, message *notification.PushMessage) ([]notification.PushResult, error) {
+	s.batches = append(s.batches, len(tokens))
+	results := make([]notification.PushResult, len(tokens))
+	for i, token := range tokens {
+		if code, ok := s.failures[token]; ok {
+			results[i] = notification.PushResult{ErrorCode: code}
+			continue
+		}
+		results[i] = notification.PushResult{Success: true}
+	}
+	return results, nil
+}
+
+func TestCreateCampaign_RequiresExactlyOneTarget(t *testing.T) {
+	svc := service.NewPushCampaignService(&fakeCampaignRepo{}, &segmentTokenRepo{}, &batchPushService{})
+	role := "jobseeker"
+
+	_, err := svc.CreateCampaign(context.Background(), &notification.CreatePushCampaignRequest{Title: "t", Body: "b"})
+	assert.ErrorIs(t, err, notification.ErrCampaignTarget)
+
+	_, err = svc.CreateCampaign(context.Background(), &notification.CreatePushCampaignRequest{
+		Title: "t", Body: "b", Topic: "role_jobseeker", Segment: &notification.PushSegment{Role: &role},
+	})
+	assert.ErrorIs(t, err, notification.ErrCampaignTarget)
+
+	_, err = svc.CreateCampaign(context.Background(), &notification.CreatePushCampaignRequest{Title: "t", Body: "b", Topic: "bad topic!"})
+	assert.ErrorIs(t, err, notification.ErrInvalidTopic)
+}
+
+func TestSendDueCampaigns_BatchesSegmentAndPrunesUnregisteredTokens(t *testing.T) {
+	tokenRepo := &segmentTokenRepo{}
+	for i := 1; i <= 1200; i++ {
+		tokenRepo.tokens = append(tokenRepo.tokens, notification.DeviceToken{ID: int64(i), Token: fmt.Sprintf("tok-%d", i)})
+	}
+	push := &batchPushService{failures: map[string]string{
+		"tok-3":    "UNREGISTERED",
+		"tok-700":  "UNREGISTERED",
+		"tok-1100": "UNAVAILABLE",
+	}}
+	campaignRepo := &fakeCampaignRepo{}
+	svc := service.NewPushCampaignService(campaignRepo, tokenRepo, push)
+
+	role := "jobseeker"
+	_, err := svc.CreateCampaign(context.Background(), &notification.CreatePushCampaignRequest{
+		Title: "Hiring now", Body: "New jobs near you", Segment: &notification.PushSegment{Role: &role}, CreatedBy: 1,
+	})
+	require.NoError(t, err)
+
+	sent, err := svc.SendDueCampaigns(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	assert.Equal(t, []int{500, 500, 200}, push.batches)
+	assert.ElementsMatch(t, []string{"tok-3", "tok-700"}, tokenRepo.deleted)
+
+	require.Len(t, campaignRepo.updated, 1)
+	result := campaignRepo.updated[0]
+	assert.Equal(t, notification.CampaignStatusCompleted, result.Status)
+	assert.Equal(t, 1197, result.SentCount)
+	assert.Equal(t, 3, result.FailedCount)
+	assert.Equal(t, 2, result.InvalidTokensPruned)
+	assert.NotNil(t, result.CompletedAt)
+}
@@ -0,0 +1,88 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+const testJWTSecret = "test-secret-key-for-refresh-token-rotation"
+
+func newRefreshTokenFixture(t *testing.T) (*fakes.RefreshTokenRepository, *service.RefreshTokenService, string, string) {
+	t.Helper()
+
+	repo := fakes.NewRefreshTokenRepository()
+	userRepo := &fakes.UserRepository{User: &user.User{ID: 7, Email: "seeker@example.com", UserType: "jobseeker", Status: "active"}}
+	svc := service.NewRefreshTokenService(repo, userRepo, testJWTSecret, time.Hour)
+
+	device := service.DeviceInfo{DeviceID: "device-1", UserAgent: "Mozilla/5.0 (Windows NT 10.0) Chrome/120.0", IPAddress: "203.0.113.7"}
+	accessToken, refreshToken, err := svc.CreateSession(context.Background(), userRepo.User, device, false)
+	require.NoError(t, err)
+	return repo, svc, accessToken, refreshToken
+}
+
+func TestRefreshAccessToken_RotatesToken(t *testing.T) {
+	repo, svc, accessToken, firstToken := newRefreshTokenFixture(t)
+
+	newAccess, secondToken, err := svc.RefreshAccessToken(context.Background(), firstToken, service.DeviceInfo{})
+	require.NoError(t, err)
+	assert.NotEqual(t, firstToken, secondToken)
+
+	// Both access tokens belong to the same session
+	before, err := utils.ValidateToken(accessToken, testJWTSecret)
+	require.NoError(t, err)
+	after, err := utils.ValidateToken(newAccess, testJWTSecret)
+	require.NoError(t, err)
+	assert.NotEmpty(t, after.SessionID)
+	assert.Equal(t, before.SessionID, after.SessionID)
+	assert.Equal(t, int64(7), after.UserID)
+
+	old, _ := repo.FindByID(context.Background(), 1)
+	assert.True(t, old.Revoked)
+	require.NotNil(t, old.ReplacedBy)
+	assert.Equal(t, int64(2), *old.ReplacedBy)
+
+	// The rotated token keeps working until it is rotated itself
+	_, _, err = svc.RefreshAccessToken(context.Background(), secondToken, service.DeviceInfo{})
+	require.NoError(t, err)
+}
+
+func TestRefreshAccessToken_ReuseRevokesWholeFamily(t *testing.T) {
+	_, svc, accessToken, firstToken := newRefreshTokenFixture(t)
+
+	_, secondToken, err := svc.RefreshAccessToken(context.Background(), firstToken, service.DeviceInfo{})
+	require.NoError(t, err)
+
+	// Replaying the already rotated token is treated as theft
+	_, _, err = svc.RefreshAccessToken(context.Background(), firstToken, service.DeviceInfo{})
+	assert.ErrorIs(t, err, service.ErrRefreshTokenReused)
+
+	// The legitimate successor is revoked too
+	_, _, err = svc.RefreshAccessToken(context.Background(), secondToken, service.DeviceInfo{})
+	assert.ErrorIs(t, err, service.ErrRefreshTokenRevoked)
+
+	claims, err := utils.ValidateToken(accessToken, testJWTSecret)
+	require.NoError(t, err)
+	active, err := svc.IsSessionActive(context.Background(), claims.SessionID)
+	require.NoError(t, err)
+	assert.False(t, active)
+}
+
+func TestRevokeSession_OnlyOwnSessions(t *testing.T) {
+	_, svc, _, refreshToken := newRefreshTokenFixture(t)
+
+	err := svc.RevokeSession(context.Background(), 99, 1)
+	assert.ErrorIs(t, err, service.ErrRefreshTokenNotFound)
+
+	require.NoError(t, svc.RevokeSession(context.Background(), 7, 1))
+	_, _, err = svc.RefreshAccessToken(context.Background(), refreshToken, service.DeviceInfo{})
+	assert.ErrorIs(t, err, service.ErrRefreshTokenRevoked)
+}
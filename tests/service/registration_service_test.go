@@ -0,0 +1,118 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/auth"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+func newRegistrationFixture() (*service.RegistrationService, *fakes.OTPRepository, *fakes.UserRepository, *fakes.EmailService) {
+	otpRepo := &fakes.OTPRepository{}
+	userRepo := &fakes.UserRepository{Users: make(map[int64]*user.User)}
+	emailSvc := &fakes.EmailService{}
+	svc := service.NewRegistrationService(userRepo, otpRepo, emailSvc, "secret", time.Hour, nil, nil, nil)
+	return svc, otpRepo, userRepo, emailSvc
+}
+
+func TestVerifyEmailOTP_LocksCodeAfterMaxAttempts(t *testing.T) {
+	svc, _, _, emailSvc := newRegistrationFixture()
+	ctx := context.Background()
+	require.NoError(t, svc.RegisterUser(ctx, "Jane Doe", "jane@example.com", "Secret123!", "", "jobseeker", "198.51.100.1", ""))
+	code := emailSvc.LastCode()
+
+	for i := 1; i < service.OTPMaxVerifyAttempts; i++ {
+		_, _, err := svc.VerifyEmailOTP(ctx, "jane@example.com", helpers.WrongCode(code))
+		assert.ErrorIs(t, err, service.ErrInvalidOTPCode, "attempt %d", i)
+	}
+
+	_, _, err := svc.VerifyEmailOTP(ctx, "jane@example.com", helpers.WrongCode(code))
+	assert.ErrorIs(t, err, service.ErrTooManyOTPAttempts)
+
+	// The correct code no longer works once the code is locked
+	_, _, err = svc.VerifyEmailOTP(ctx, "jane@example.com", code)
+	assert.ErrorIs(t, err, service.ErrTooManyOTPAttempts)
+}
+
+func TestVerifyEmailOTP_RejectsExpiredCode(t *testing.T) {
+	svc, otpRepo, _, emailSvc := newRegistrationFixture()
+	ctx := context.Background()
+	require.NoError(t, svc.RegisterUser(ctx, "Jane Doe", "jane@example.com", "Secret123!", "", "jobseeker", "", ""))
+
+	otpRepo.Age(service.OTPCodeExpiryMinutes*time.Minute + time.Second)
+
+	_, _, err := svc.VerifyEmailOTP(ctx, "jane@example.com", emailSvc.LastCode())
+	assert.ErrorIs(t, err, service.ErrOTPCodeExpired)
+}
+
+func TestResendOTP_BacksOffExponentiallyThenLimitsWindow(t *testing.T) {
+	svc, otpRepo, _, _ := newRegistrationFixture()
+	ctx := context.Background()
+	require.NoError(t, svc.RegisterUser(ctx, "Jane Doe", "jane@example.com", "Secret123!", "", "jobseeker", "", ""))
+
+	var limitErr *service.OTPRateLimitError
+	err := svc.ResendOTP(ctx, "jane@example.com", "")
+	require.True(t, errors.As(err, &limitErr))
+	assert.ErrorIs(t, err, service.ErrResendTooSoon)
+	assert.Equal(t, 60, limitErr.RetryAfterSeconds())
+
+	otpRepo.Age(service.OTPResendBaseDelay)
+	require.NoError(t, svc.ResendOTP(ctx, "jane@example.com", ""))
+
+	// The second code doubles the wait
+	otpRepo.Age(service.OTPResendBaseDelay)
+	err = svc.ResendOTP(ctx, "jane@example.com", "")
+	require.True(t, errors.As(err, &limitErr))
+	assert.ErrorIs(t, err, service.ErrResendTooSoon)
+	assert.Equal(t, 60, limitErr.RetryAfterSeconds())
+
+	otpRepo.Age(service.OTPResendBaseDelay)
+	require.NoError(t, svc.ResendOTP(ctx, "jane@example.com", ""))
+
+	// Three codes in the window: blocked until the first one ages out
+	otpRepo.Age(5 * time.Minute)
+	err = svc.ResendOTP(ctx, "jane@example.com", "")
+	require.True(t, errors.As(err, &limitErr))
+	assert.ErrorIs(t, err, service.ErrTooManyOTPRequests)
+	assert.InDelta(t, (15*time.Minute - 3*service.OTPResendBaseDelay - 5*time.Minute).Seconds(), float64(limitErr.RetryAfterSeconds()), 1)
+}
+
+func TestRegisterUser_PerIPLimitBlocksBeforeCreatingUser(t *testing.T) {
+	svc, otpRepo, userRepo, _ := newRegistrationFixture()
+	ctx := context.Background()
+	ip := "203.0.113.9"
+	for i := 0; i < service.OTPMaxRequestsPerIP; i++ {
+		require.NoError(t, otpRepo.Create(ctx, &auth.OTPCode{UserID: 99, Type: "email_verification", IPAddress: &ip, ExpiredAt: time.Now().Add(time.Minute)}))
+	}
+
+	err := svc.RegisterUser(ctx, "Jane Doe", "jane@example.com", "Secret123!", "", "jobseeker", ip, "")
+
+	var limitErr *service.OTPRateLimitError
+	require.True(t, errors.As(err, &limitErr))
+	assert.ErrorIs(t, err, service.ErrTooManyOTPRequests)
+	assert.Greater(t, limitErr.RetryAfterSeconds(), 0)
+	assert.Empty(t, userRepo.Users)
+}
+
+func TestResetPasswordWithOTP_WrongGuessesCountAgainstCode(t *testing.T) {
+	svc, _, userRepo, _ := newRegistrationFixture()
+	ctx := context.Background()
+	userRepo.Users[1] = &user.User{ID: 1, Email: "jane@example.com", IsVerified: true}
+	require.NoError(t, svc.RequestPasswordResetOTP(ctx, "jane@example.com", ""))
+
+	for i := 1; i < service.OTPMaxVerifyAttempts; i++ {
+		err := svc.ResetPasswordWithOTP(ctx, "jane@example.com", "999999", "NewSecret123!")
+		assert.ErrorIs(t, err, service.ErrInvalidOTPCode, "attempt %d", i)
+	}
+	err := svc.ResetPasswordWithOTP(ctx, "jane@example.com", "999999", "NewSecret123!")
+	assert.ErrorIs(t, err, service.ErrTooManyOTPAttempts)
+}
@@ -0,0 +1,244 @@
+package service_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// queryCounter counts repository calls across the fakes below. Every call sleeps for
+// latency to stand in for a database round trip.
+type queryCounter struct {
+	latency time.Duration
+	queries atomic.Int64
+}
+
+func (q *queryCounter) wait() {
+	q.queries.Add(1)
+	time.Sleep(q.latency)
+}
+
+type summaryAppRepo struct {
+	application.ApplicationRepository
+	*queryCounter
+
+	apps []application.JobApplication
+}
+
+func (r *summaryAppRepo) ListByCompanyCursor(ctx context.Context, companyID int64, filter application.ApplicationFilter, cursor string, limit int) ([]application.JobApplication, string, error) {
+	r.wait()
+	return r.apps, "", nil
+}
+
+func (r *summaryAppRepo) GetCurrentStage(ctx context.Context, applicationID int64) (*application.JobApplicationStage, error) {
+	r.wait()
+	return &application.JobApplicationStage{ApplicationID: applicationID, StageName: "screening"}, nil
+}
+
+func (r *summaryAppRepo) GetCurrentStages(ctx context.Context, applicationIDs []int64) (map[int64]application.JobApplicationStage, error) {
+	r.wait()
+	stages := make(map[int64]application.JobApplicationStage, len(applicationIDs))
+	for _, id := range applicationIDs {
+		stages[id] = application.JobApplicationStage{ApplicationID: id, StageName: "screening"}
+	}
+	return stages, nil
+}
+
+type summaryJobRepo struct {
+	job.JobRepository
+	*queryCounter
+}
+
+func (r *summaryJobRepo) FindByID(ctx context.Context, id int64) (*job.Job, error) {
+	r.wait()
+	return &job.Job{ID: id, Title: "Backend Engineer"}, nil
+}
+
+func (r *summaryJobRepo) FindByIDs(ctx context.Context, ids []int64) ([]job.Job, error) {
+	r.wait()
+	jobs := make([]job.Job, len(ids))
+	for i, id := range ids {
+		jobs[i] = job.Job{ID: id, Title: "Backend Engineer"}
+	}
+	return jobs, nil
+}
+
+type summaryCompanyRepo struct {
+	company.CompanyRepository
+	*queryCounter
+}
+
+func (r *summaryCompanyRepo) FindByID(ctx context.Context, id int64) (*company.Company, error) {
+	r.wait()
+	return &company.Company{ID: id, CompanyName: "Acme"}, nil
+}
+
+func (r *summaryCompanyRepo) FindByIDs(ctx context.Context, ids []int64) ([]company.Company, error) {
+	r.wait()
+	companies := make([]company.Company, len(ids))
+	for i, id := range ids {
+		companies[i] = company.Company{ID: id, CompanyName: "Acme"}
+	}
+	return companies, nil
+}
+
+// summaryUserRepo cancels the request while loading applicants when cancel is set
+type summaryUserRepo struct {
+	user.UserRepository
+	*queryCounter
+
+	cancel context.CancelFunc
+}
+
+func (r *summaryUserRepo) FindByID(ctx context.Context, id int64) (*user.User, error) {
+	r.wait()
+	return &user.User{ID: id, FullName: "Applicant"}, nil
+}
+
+func (r *summaryUserRepo) FindByIDs(ctx context.Context, ids []int64) ([]user.User, error) {
+	r.wait()
+	if r.cancel != nil {
+		r.cancel()
+		return nil, ctx.Err()
+	}
+	users := make([]user.User, len(ids))
+	for i, id := range ids {
+		users[i] = user.User{ID: id, FullName: "Applicant"}
+	}
+	return users, nil
+}
+
+func newSummaryService(latency time.Duration, count int) (application.ApplicationService, *summaryAppRepo, *summaryUserRepo) {
+	counter := &queryCounter{latency: latency}
+	companyID := int64(3)
+	apps := make([]application.JobApplication, count)
+	for i := range apps {
+		apps[i] = application.JobApplication{ID: int64(i + 1), JobID: int64(i%5 + 1), CompanyID: &companyID, UserID: int64(i + 100), Status: "applied"}
+	}
+
+	appRepo := &summaryAppRepo{queryCounter: counter, apps: apps}
+	userRepo := &summaryUserRepo{queryCounter: counter}
+	svc := helpers.NewApplicationService(appRepo, &summaryJobRepo{queryCounter: counter}, userRepo, &summaryCompanyRepo{queryCounter: counter})
+	return svc, appRepo, userRepo
+}
+
+// summaryEmployer lists company 3's applications without a user, so no blind screening
+// settings are looked up
+var summaryEmployer = &employer.EmployerContext{CompanyID: 3, Role: "viewer"}
+
+func TestGetCompanyApplicationsByCursor_BatchesLookups(t *testing.T) {
+	svc, appRepo, _ := newSummaryService(0, 20)
+
+	summaries, _, err := svc.GetCompanyApplicationsByCursor(context.Background(), summaryEmployer, application.ApplicationFilter{}, "", 20)
+	require.NoError(t, err)
+	require.Len(t, summaries, 20)
+
+	// Company check and listing, then one query each for jobs, companies, users and stages
+	assert.Equal(t, int64(6), appRepo.queries.Load())
+	assert.Equal(t, "Backend Engineer", summaries[0].JobTitle)
+	assert.Equal(t, "Acme", summaries[0].CompanyName)
+	assert.Equal(t, "Applicant", summaries[0].UserName)
+	assert.Equal(t, "screening", summaries[0].CurrentStage)
+}
+
+func TestGetCompanyApplicationsByCursor_StopsWhenContextIsCancelled(t *testing.T) {
+	svc, appRepo, userRepo := newSummaryService(time.Millisecond, 50)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	userRepo.cancel = cancel
+
+	_, _, err := svc.GetCompanyApplicationsByCursor(ctx, summaryEmployer, application.ApplicationFilter{}, "", 50)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.LessOrEqual(t, appRepo.queries.Load(), int64(6))
+}
+
+// matchingJobRepo returns a page of matching jobs and cancels the request once
+// cancelAfter jobs have been scored
+type matchingJobRepo struct {
+	job.JobRepository
+
+	cancel      context.CancelFunc
+	cancelAfter int64
+	lookups     atomic.Int64
+}
+
+func (r *matchingJobRepo) GetMatchingJobs(ctx context.Context, userID int64, filter job.JobFilter, page, limit int) ([]job.Job, int64, error) {
+	jobs := make([]job.Job, limit)
+	for i := range jobs {
+		jobs[i] = job.Job{ID: int64(i + 1), Title: "Backend Engineer"}
+	}
+	return jobs, int64(limit), nil
+}
+
+func (r *matchingJobRepo) FindByID(ctx context.Context, id int64) (*job.Job, error) {
+	if r.lookups.Add(1) == r.cancelAfter {
+		r.cancel()
+	}
+	return &job.Job{ID: id, Title: "Backend Engineer"}, nil
+}
+
+func TestGetMatchingJobs_StopsWhenContextIsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	jobRepo := &matchingJobRepo{cancel: cancel, cancelAfter: 3}
+	svc := service.NewJobService(jobRepo, nil, &fakes.UserRepository{User: &user.User{ID: 7}}, nil, nil, nil, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil, nil, "")
+
+	_, err := svc.GetMatchingJobs(ctx, 7, job.JobFilter{}, 1, 50)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int64(3), jobRepo.lookups.Load(), "no job is scored after the request is cancelled")
+}
+
+// The benchmarks use 1ms per query to compare the batched listing with the four
+// lookups per application it replaced.
+const summaryBenchLatency = time.Millisecond
+
+func BenchmarkApplicationSummaries_Batched(b *testing.B) {
+	svc, appRepo, _ := newSummaryService(summaryBenchLatency, 20)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := svc.GetCompanyApplicationsByCursor(ctx, summaryEmployer, application.ApplicationFilter{}, "", 20); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(appRepo.queries.Load())/float64(b.N), "queries/op")
+}
+
+func BenchmarkApplicationSummaries_PerApplication(b *testing.B) {
+	_, appRepo, userRepo := newSummaryService(summaryBenchLatency, 20)
+	jobRepo := &summaryJobRepo{queryCounter: appRepo.queryCounter}
+	companyRepo := &summaryCompanyRepo{queryCounter: appRepo.queryCounter}
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := companyRepo.FindByID(ctx, 3); err != nil {
+			b.Fatal(err)
+		}
+		apps, _, err := appRepo.ListByCompanyCursor(ctx, 3, application.ApplicationFilter{}, "", 20)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, app := range apps {
+			_, _ = jobRepo.FindByID(ctx, app.JobID)
+			_, _ = companyRepo.FindByID(ctx, *app.CompanyID)
+			_, _ = userRepo.FindByID(ctx, app.UserID)
+			_, _ = appRepo.GetCurrentStage(ctx, app.ID)
+		}
+	}
+	b.ReportMetric(float64(appRepo.queries.Load())/float64(b.N), "queries/op")
+}
@@ -0,0 +1,80 @@
+package service_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/master"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+func parseResumeFixture(t *testing.T, name, contentType string) (*user.ParsedResume, error) {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join("..", "testdata", "resumes", name))
+	require.NoError(t, err)
+
+	skills := fakes.NewSkillsMasterRepository()
+	skills.Skills[5] = &master.SkillsMaster{ID: 5, Name: "Java", NormalizedName: "java"}
+	svc := service.NewUserService(nil, nil, skills, nil, nil, nil)
+	return svc.ParseResume(context.Background(), helpers.NewFileHeader(t, name, contentType, content))
+}
+
+func skillNames(skills []user.ParsedResumeSkill) []string {
+	names := make([]string, len(skills))
+	for i, skill := range skills {
+		names[i] = skill.Name
+	}
+	return names
+}
+
+func TestParseResume_PDF(t *testing.T) {
+	parsed, err := parseResumeFixture(t, "resume.pdf", "application/pdf")
+	require.NoError(t, err)
+
+	require.NotNil(t, parsed.Email)
+	assert.Equal(t, "budi.santoso@example.com", *parsed.Email)
+	require.NotNil(t, parsed.Phone)
+	assert.Equal(t, "+6281234567890", *parsed.Phone)
+	require.NotNil(t, parsed.LinkedInURL)
+	assert.Equal(t, "https://linkedin.com/in/budi-santoso", *parsed.LinkedInURL)
+	assert.ElementsMatch(t, []string{"Go", "PostgreSQL", "Docker"}, skillNames(parsed.Skills))
+	assert.Equal(t, []string{"S1"}, parsed.DegreeLevels)
+}
+
+func TestParseResume_DOCX(t *testing.T) {
+	parsed, err := parseResumeFixture(t, "resume.docx", "application/vnd.openxmlformats-officedocument.wordprocessingml.document")
+	require.NoError(t, err)
+
+	require.NotNil(t, parsed.Email)
+	assert.Equal(t, "siti.rahma@example.co.id", *parsed.Email)
+	require.NotNil(t, parsed.Phone)
+	assert.Equal(t, "085712345678", *parsed.Phone)
+	require.NotNil(t, parsed.LinkedInURL)
+	assert.Equal(t, "https://www.linkedin.com/in/siti-rahma", *parsed.LinkedInURL)
+	assert.ElementsMatch(t, []string{"PostgreSQL", "Kubernetes"}, skillNames(parsed.Skills))
+	assert.Equal(t, []string{"S2", "S1"}, parsed.DegreeLevels)
+}
+
+func TestParseResume_RejectsUnsupportedAndCorruptFiles(t *testing.T) {
+	skills := fakes.NewSkillsMasterRepository()
+	skills.Skills[5] = &master.SkillsMaster{ID: 5, Name: "Java", NormalizedName: "java"}
+	svc := service.NewUserService(nil, nil, skills, nil, nil, nil)
+
+	_, err := svc.ParseResume(context.Background(), helpers.NewFileHeader(t, "resume.txt", "text/plain", []byte("Go developer")))
+	assert.Error(t, err)
+
+	_, err = svc.ParseResume(context.Background(), helpers.NewFileHeader(t, "resume.pdf", "application/pdf", []byte("not really a pdf")))
+	assert.Error(t, err)
+
+	_, err = svc.ParseResume(context.Background(), helpers.NewFileHeader(t, "resume.docx", "application/octet-stream", []byte("PK broken")))
+	assert.Error(t, err)
+}
@@ -0,0 +1,168 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/master"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// taxonomySkillsRepo resolves merged skill IDs and aliases the way the postgres repository does
+type taxonomySkillsRepo struct {
+	master.SkillsMasterRepository
+
+	skills  map[int64]*master.SkillsMaster
+	merged  map[int64]int64  // Merged skill ID to the skill it was merged into
+	aliases map[string]int64 // Normalized alias to skill ID
+	merges  int
+	limit   int
+}
+
+func newTaxonomySkillsRepo() *taxonomySkillsRepo {
+	return &taxonomySkillsRepo{
+		skills: map[int64]*master.SkillsMaster{
+			1: {ID: 1, Name: "Go", IsActive: true},
+			2: {ID: 2, Name: "PostgreSQL", IsActive: true},
+			3: {ID: 3, Name: "Flash", IsActive: false},
+		},
+		merged:  map[int64]int64{10: 1},
+		aliases: map[string]int64{"golang": 1, "go-lang": 1, "postgres": 2},
+	}
+}
+
+func (r *taxonomySkillsRepo) FindByID(ctx context.Context, id int64) (*master.SkillsMaster, error) {
+	return r.skills[id], nil
+}
+
+func (r *taxonomySkillsRepo) ResolveSkill(ctx context.Context, id int64) (*master.SkillsMaster, error) {
+	if target, ok := r.merged[id]; ok {
+		id = target
+	}
+	return r.skills[id], nil
+}
+
+func (r *taxonomySkillsRepo) FindCanonicalByName(ctx context.Context, name string) (*master.SkillsMaster, error) {
+	normalized := master.NormalizeSkillName(name)
+	for _, skill := range r.skills {
+		if master.NormalizeSkillName(skill.Name) == normalized {
+			return skill, nil
+		}
+	}
+	return r.skills[r.aliases[normalized]], nil
+}
+
+func (r *taxonomySkillsRepo) SuggestSkills(ctx context.Context, query string, limit int) ([]master.SkillsMaster, error) {
+	r.limit = limit
+	return []master.SkillsMaster{*r.skills[1]}, nil
+}
+
+func (r *taxonomySkillsRepo) MergeSkill(ctx context.Context, sourceID, targetID int64) (*master.SkillMergeResult, error) {
+	r.merges++
+	return &master.SkillMergeResult{SourceID: sourceID, TargetID: targetID}, nil
+}
+
+// skillUserRepo records the skills added to a user
+type skillUserRepo struct {
+	user.UserRepository
+	added []user.UserSkill
+}
+
+func (r *skillUserRepo) AddSkill(ctx context.Context, skill *user.UserSkill) error {
+	r.added = append(r.added, *skill)
+	return nil
+}
+
+func TestMergeSkill_Validation(t *testing.T) {
+	repo := newTaxonomySkillsRepo()
+	svc := service.NewSkillsMasterService(repo)
+
+	_, err := svc.MergeSkill(context.Background(), 1, 1)
+	assert.ErrorIs(t, err, master.ErrSkillMergeIntoSelf)
+
+	_, err = svc.MergeSkill(context.Background(), 99, 1)
+	assert.ErrorIs(t, err, master.ErrSkillNotFound)
+
+	_, err = svc.MergeSkill(context.Background(), 2, 99)
+	assert.ErrorIs(t, err, master.ErrSkillNotFound)
+
+	_, err = svc.MergeSkill(context.Background(), 2, 3)
+	assert.ErrorIs(t, err, master.ErrSkillMergeTargetInactive)
+	assert.Zero(t, repo.merges)
+
+	result, err := svc.MergeSkill(context.Background(), 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.SourceID)
+	assert.Equal(t, int64(1), result.TargetID)
+	assert.Equal(t, 1, repo.merges)
+}
+
+func TestSuggestSkills_NormalizesQueryAndClampsLimit(t *testing.T) {
+	repo := newTaxonomySkillsRepo()
+	svc := service.NewSkillsMasterService(repo)
+
+	skills, err := svc.SuggestSkills(context.Background(), "   ", 10)
+	require.NoError(t, err)
+	assert.Empty(t, skills)
+	assert.Zero(t, repo.limit, "a blank query is not searched")
+
+	skills, err = svc.SuggestSkills(context.Background(), "  GoLa ", 500)
+	require.NoError(t, err)
+	require.Len(t, skills, 1)
+	assert.Equal(t, "Go", skills[0].Name)
+	assert.Equal(t, 50, repo.limit)
+
+	_, err = svc.SuggestSkills(context.Background(), "go", 0)
+	require.NoError(t, err)
+	assert.Equal(t, 10, repo.limit)
+}
+
+func TestUserAddSkills_ResolveAliasesAndMergedSkills(t *testing.T) {
+	userRepo := &skillUserRepo{}
+	svc := service.NewUserService(userRepo, nil, newTaxonomySkillsRepo(), nil, nil, nil)
+
+	added, err := svc.AddSkills(context.Background(), 7, &user.AddUserSkillsRequest{Skills: []user.AddUserSkillRequest{
+		{SkillName: "GoLang", ProficiencyLevel: "advanced"},
+		{SkillID: utils.Int64Ptr(10), ProficiencyLevel: "advanced"},
+		{SkillName: " postgres ", ProficiencyLevel: "intermediate"},
+		{SkillName: "Rust", ProficiencyLevel: "beginner"},
+	}})
+	require.NoError(t, err)
+
+	names := make([]string, len(added))
+	for i, skill := range added {
+		names[i] = skill.SkillName
+	}
+	assert.Equal(t, []string{"Go", "Go", "PostgreSQL", "Rust"}, names, "unknown names are kept as typed")
+
+	err = svc.AddSkill(context.Background(), 7, &user.AddUserSkillRequest{SkillID: utils.Int64Ptr(99), ProficiencyLevel: "advanced"})
+	assert.ErrorIs(t, err, master.ErrSkillNotFound)
+	assert.Len(t, userRepo.added, 4)
+}
+
+func TestBulkAddSkills_StoresCanonicalSkillIDsOnce(t *testing.T) {
+	jobRepo := &fakes.JobRepository{Jobs: map[int64]*job.Job{1: {ID: 1}}}
+	svc := service.NewJobService(jobRepo, nil, nil, nil, nil, newTaxonomySkillsRepo(), nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil, nil, "")
+
+	err := svc.BulkAddSkills(context.Background(), 1, []job.AddSkillRequest{
+		{SkillID: 10, ImportanceLevel: "required"},
+		{SkillID: 1, ImportanceLevel: "preferred"},
+		{SkillID: 2},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, jobRepo.Skills[1], 2, "a merged skill and the skill it was merged into count once")
+	assert.Equal(t, int64(1), jobRepo.Skills[1][0].SkillID)
+	assert.Equal(t, "required", jobRepo.Skills[1][0].ImportanceLevel)
+	assert.Equal(t, int64(2), jobRepo.Skills[1][1].SkillID)
+
+	err = svc.BulkAddSkills(context.Background(), 1, []job.AddSkillRequest{{SkillID: 99}})
+	assert.ErrorIs(t, err, master.ErrSkillNotFound)
+}
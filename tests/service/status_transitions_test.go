@@ -0,0 +1,97 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/employer"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// transitionJobRepo holds one job and records status updates
+type transitionJobRepo struct {
+	job.JobRepository
+
+	job     *job.Job
+	updated []string
+}
+
+func (r *transitionJobRepo) FindByID(ctx context.Context, id int64) (*job.Job, error) {
+	return r.job, nil
+}
+
+func (r *transitionJobRepo) UpdateStatus(ctx context.Context, id int64, status string) error {
+	r.updated = append(r.updated, status)
+	return nil
+}
+
+func TestJobUpdateStatus_RejectsUnknownStatus(t *testing.T) {
+	repo := &transitionJobRepo{job: &job.Job{ID: 1, Status: job.StatusDraft}}
+	svc := service.NewJobService(repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil, nil, "")
+
+	err := svc.UpdateStatus(context.Background(), 1, "active")
+	assert.ErrorIs(t, err, job.ErrInvalidJobStatus)
+	assert.Empty(t, repo.updated)
+}
+
+func TestJobUpdateStatus_EnforcesTransitions(t *testing.T) {
+	repo := &transitionJobRepo{job: &job.Job{ID: 1, Status: job.StatusRejected}}
+	svc := service.NewJobService(repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, job.DefaultExpiryPolicy, nil, nil, nil, "")
+	ctx := context.Background()
+
+	// A rejected job goes back to draft before it can be published again
+	err := svc.UpdateStatus(ctx, 1, job.StatusPublished)
+	assert.ErrorIs(t, err, job.ErrInvalidStatusTransition)
+	assert.Empty(t, repo.updated)
+
+	require.NoError(t, svc.UpdateStatus(ctx, 1, job.StatusDraft))
+	assert.Equal(t, []string{job.StatusDraft}, repo.updated)
+}
+
+var transitionRecruiter = &employer.EmployerContext{UserID: 5, EmployerUserID: 50, CompanyID: 3, Role: "recruiter"}
+
+func TestApplicationStage_CannotMoveBackwards(t *testing.T) {
+	appRepo := fakes.NewApplicationRepository()
+	appRepo.Apps[1] = &application.JobApplication{ID: 1, JobID: 10, UserID: 2, CompanyID: utils.Int64Ptr(3), Status: application.StatusOffered}
+	companyRepo := helpers.NewCompanyMemberRepository("recruiter")
+	svc := helpers.NewApplicationService(appRepo, &fakes.JobRepository{}, &fakes.UserRepository{}, companyRepo)
+
+	err := svc.MoveToScreening(context.Background(), transitionRecruiter, 1, "")
+	assert.ErrorIs(t, err, application.ErrInvalidStatusTransition)
+	assert.Equal(t, application.StatusOffered, appRepo.Apps[1].Status)
+}
+
+func TestRejectApplication_FinalStatusIsKept(t *testing.T) {
+	appRepo := fakes.NewApplicationRepository()
+	appRepo.Apps[1] = &application.JobApplication{ID: 1, JobID: 10, UserID: 2, CompanyID: utils.Int64Ptr(3), Status: application.StatusHired}
+	companyRepo := helpers.NewCompanyMemberRepository("recruiter")
+	svc := helpers.NewApplicationService(appRepo, &fakes.JobRepository{}, &fakes.UserRepository{}, companyRepo)
+
+	err := svc.RejectApplication(context.Background(), transitionRecruiter, 1, "Position filled", false)
+	assert.ErrorIs(t, err, application.ErrApplicationCompleted)
+	assert.Equal(t, application.StatusHired, appRepo.Apps[1].Status)
+}
+
+func TestApplicationStage_DeniesOtherCompanies(t *testing.T) {
+	appRepo := fakes.NewApplicationRepository()
+	appRepo.Apps[1] = &application.JobApplication{ID: 1, JobID: 10, UserID: 2, CompanyID: utils.Int64Ptr(3), Status: application.StatusApplied}
+	svc := helpers.NewApplicationService(appRepo, &fakes.JobRepository{}, &fakes.UserRepository{}, &fakes.CompanyRepository{})
+	ctx := context.Background()
+
+	otherCompany := &employer.EmployerContext{UserID: 5, EmployerUserID: 51, CompanyID: 4, Role: "owner"}
+	assert.ErrorIs(t, svc.MoveToScreening(ctx, otherCompany, 1, ""), application.ErrEmployerAccessDenied)
+	assert.ErrorIs(t, svc.RejectApplication(ctx, otherCompany, 1, "", false), application.ErrEmployerAccessDenied)
+	assert.ErrorIs(t, svc.ToggleBookmark(ctx, otherCompany, 1), application.ErrEmployerAccessDenied)
+
+	// Bulk updates skip the applications the employer cannot reach
+	require.NoError(t, svc.BulkUpdateStatus(ctx, otherCompany, []int64{1}, application.StatusScreening))
+	assert.Equal(t, application.StatusApplied, appRepo.Apps[1].Status)
+}
@@ -0,0 +1,229 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/application"
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/domain/talentpool"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+// memoryTalentPoolRepo keeps pools and members in memory, one membership per pool and user
+type memoryTalentPoolRepo struct {
+	talentpool.TalentPoolRepository
+	pools      map[int64]*talentpool.TalentPool
+	members    map[int64]*talentpool.TalentPoolMember
+	nextID     int64
+	lastFilter talentpool.MemberFilter
+}
+
+func newMemoryTalentPoolRepo() *memoryTalentPoolRepo {
+	return &memoryTalentPoolRepo{
+		pools: map[int64]*talentpool.TalentPool{
+			1: {ID: 1, CompanyID: 3, Name: "Backend"},
+			2: {ID: 2, CompanyID: 4, Name: "Other company"},
+		},
+		members: map[int64]*talentpool.TalentPoolMember{},
+		nextID:  100,
+	}
+}
+
+func (r *memoryTalentPoolRepo) FindPoolByID(ctx context.Context, id int64) (*talentpool.TalentPool, error) {
+	return r.pools[id], nil
+}
+
+func (r *memoryTalentPoolRepo) AddMember(ctx context.Context, m *talentpool.TalentPoolMember) (bool, error) {
+	for _, existing := range r.members {
+		if existing.PoolID == m.PoolID && existing.UserID == m.UserID {
+			*m = *existing
+			return false, nil
+		}
+	}
+	r.nextID++
+	m.ID = r.nextID
+	stored := *m
+	r.members[m.ID] = &stored
+	return true, nil
+}
+
+func (r *memoryTalentPoolRepo) FindMemberByID(ctx context.Context, id int64) (*talentpool.TalentPoolMember, error) {
+	return r.members[id], nil
+}
+
+func (r *memoryTalentPoolRepo) ListMembers(ctx context.Context, filter talentpool.MemberFilter, page, limit int) ([]talentpool.TalentPoolMember, int64, error) {
+	r.lastFilter = filter
+	return nil, 0, nil
+}
+
+func (r *memoryTalentPoolRepo) ListMatchCandidates(ctx context.Context, companyID, poolID, jobID int64, limit int) ([]talentpool.TalentPoolMember, error) {
+	var members []talentpool.TalentPoolMember
+	for _, m := range r.members {
+		if r.pools[m.PoolID].CompanyID == companyID && (poolID == 0 || m.PoolID == poolID) {
+			members = append(members, *m)
+		}
+	}
+	return members, nil
+}
+
+func (r *memoryTalentPoolRepo) RemoveMember(ctx context.Context, id int64) error {
+	delete(r.members, id)
+	return nil
+}
+
+// scoringJobService scores candidates with fixed overall scores
+type scoringJobService struct {
+	job.JobService
+	scores map[int64]float64
+}
+
+func (s *scoringJobService) CalculateMatchScore(ctx context.Context, jobID, userID int64) (*job.MatchScore, error) {
+	return &job.MatchScore{JobID: jobID, UserID: userID, OverallScore: s.scores[userID]}, nil
+}
+
+func newTalentPoolFixture(optOut bool) (talentpool.TalentPoolService, *memoryTalentPoolRepo) {
+	return newTalentPoolFixtureWithPreference(user.UserPreference{AllowTalentPools: !optOut, ProfileVisibility: user.ProfileVisibilityPublic})
+}
+
+// newTalentPoolFixtureWithPreference is newTalentPoolFixture with the candidates' preferences
+func newTalentPoolFixtureWithPreference(pref user.UserPreference) (talentpool.TalentPoolService, *memoryTalentPoolRepo) {
+	companyID, otherCompanyID := int64(3), int64(4)
+	appRepo := fakes.NewApplicationRepository()
+	appRepo.Apps[10] = &application.JobApplication{ID: 10, JobID: 20, UserID: 7, CompanyID: &companyID, Status: "rejected"}
+	appRepo.Apps[11] = &application.JobApplication{ID: 11, JobID: 21, UserID: 8, CompanyID: &otherCompanyID, Status: "rejected"}
+
+	userRepo := &optOutUserRepo{pref: pref}
+	repo := newMemoryTalentPoolRepo()
+	svc := service.NewTalentPoolService(
+		repo,
+		appRepo,
+		&fakes.JobRepository{Job: &job.Job{ID: 30, CompanyID: 3, Title: "Go Engineer", Status: "published", ExpiredAt: utils.TimePtr(time.Now().Add(time.Hour))}},
+		&scoringJobService{scores: map[int64]float64{7: 0.4, 8: 0.9, 9: 0.7}},
+		userRepo,
+	)
+	return svc, repo
+}
+
+// optOutUserRepo returns the same preferences for every candidate
+type optOutUserRepo struct {
+	user.UserRepository
+	pref user.UserPreference
+}
+
+func (r *optOutUserRepo) FindPreferenceByUserID(ctx context.Context, userID int64) (*user.UserPreference, error) {
+	pref := r.pref
+	pref.UserID = userID
+	return &pref, nil
+}
+
+func TestTalentPoolAddMember_IsIdempotent(t *testing.T) {
+	svc, repo := newTalentPoolFixture(false)
+	ctx := context.Background()
+
+	m, added, err := svc.AddMember(ctx, 3, 1, 50, talentpool.AddMemberInput{
+		ApplicationID: 10,
+		Tags:          []string{" Go ", "go", "Strong  Communicator", ""},
+		Notes:         "  Role was filled  ",
+	})
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.Equal(t, int64(7), m.UserID)
+	assert.Equal(t, int64(10), *m.SourceApplicationID)
+	assert.Equal(t, []string{"go", "strong communicator"}, []string(m.Tags))
+	assert.Equal(t, "Role was filled", m.Notes)
+
+	again, added, err := svc.AddMember(ctx, 3, 1, 51, talentpool.AddMemberInput{ApplicationID: 10, Tags: []string{"other"}})
+	require.NoError(t, err)
+	assert.False(t, added)
+	assert.Equal(t, m.ID, again.ID, "adding the same candidate twice returns the existing member")
+	assert.Len(t, repo.members, 1)
+}
+
+func TestTalentPoolAddMember_Validation(t *testing.T) {
+	svc, _ := newTalentPoolFixture(false)
+	ctx := context.Background()
+
+	_, _, err := svc.AddMember(ctx, 3, 2, 50, talentpool.AddMemberInput{ApplicationID: 10})
+	assert.ErrorIs(t, err, talentpool.ErrPoolNotFound, "another company's pool is not found")
+
+	_, _, err = svc.AddMember(ctx, 3, 1, 50, talentpool.AddMemberInput{ApplicationID: 12})
+	assert.Error(t, err, "an unknown application cannot be added")
+
+	_, _, err = svc.AddMember(ctx, 3, 1, 50, talentpool.AddMemberInput{ApplicationID: 11})
+	assert.ErrorIs(t, err, talentpool.ErrApplicationNotInCompany)
+
+	tags := make([]string, talentpool.MaxTagsPerMember+1)
+	for i := range tags {
+		tags[i] = string(rune('a' + i))
+	}
+	_, _, err = svc.AddMember(ctx, 3, 1, 50, talentpool.AddMemberInput{ApplicationID: 10, Tags: tags})
+	assert.ErrorIs(t, err, talentpool.ErrInvalidTags)
+
+	optedOut, repo := newTalentPoolFixture(true)
+	_, _, err = optedOut.AddMember(ctx, 3, 1, 50, talentpool.AddMemberInput{ApplicationID: 10})
+	assert.ErrorIs(t, err, talentpool.ErrCandidateOptedOut)
+	assert.Empty(t, repo.members)
+
+	// Private profiles stay out of talent pools even when pools are allowed
+	private, repo := newTalentPoolFixtureWithPreference(user.UserPreference{AllowTalentPools: true, ProfileVisibility: user.ProfileVisibilityPrivate})
+	_, _, err = private.AddMember(ctx, 3, 1, 50, talentpool.AddMemberInput{ApplicationID: 10})
+	assert.ErrorIs(t, err, talentpool.ErrCandidateOptedOut)
+	assert.Empty(t, repo.members)
+}
+
+func TestTalentPoolListMembers_NormalizesFilters(t *testing.T) {
+	svc, repo := newTalentPoolFixture(false)
+
+	_, _, err := svc.ListMembers(context.Background(), talentpool.MemberFilter{CompanyID: 4, PoolID: 1}, 1, 20)
+	assert.ErrorIs(t, err, talentpool.ErrPoolNotFound)
+
+	_, _, err = svc.ListMembers(context.Background(), talentpool.MemberFilter{
+		CompanyID: 3,
+		PoolID:    1,
+		Tags:      []string{"Senior", " senior "},
+		Skills:    []string{"Go", "PostgreSQL "},
+		Query:     " budi ",
+	}, 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"senior"}, repo.lastFilter.Tags)
+	assert.Equal(t, []string{"go", "postgresql"}, repo.lastFilter.Skills)
+	assert.Equal(t, "budi", repo.lastFilter.Query)
+}
+
+func TestTalentPoolRemoveMember_ChecksPool(t *testing.T) {
+	svc, repo := newTalentPoolFixture(false)
+	repo.members[5] = &talentpool.TalentPoolMember{ID: 5, PoolID: 1, UserID: 7}
+
+	assert.ErrorIs(t, svc.RemoveMember(context.Background(), 4, 2, 5), talentpool.ErrMemberNotFound)
+	assert.ErrorIs(t, svc.RemoveMember(context.Background(), 4, 1, 5), talentpool.ErrPoolNotFound)
+	require.NoError(t, svc.RemoveMember(context.Background(), 3, 1, 5))
+	assert.Empty(t, repo.members)
+}
+
+func TestTalentPoolMatchJob_ReturnsBestMatches(t *testing.T) {
+	svc, repo := newTalentPoolFixture(false)
+	for id, userID := range map[int64]int64{5: 7, 6: 8, 7: 9} {
+		repo.members[id] = &talentpool.TalentPoolMember{ID: id, PoolID: 1, UserID: userID}
+	}
+
+	matches, err := svc.MatchJob(context.Background(), 3, 30, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+	assert.Equal(t, int64(8), matches[0].Member.UserID)
+	assert.Equal(t, int64(9), matches[1].Member.UserID)
+	assert.InDelta(t, 0.9, matches[0].Score.OverallScore, 1e-9)
+
+	_, err = svc.MatchJob(context.Background(), 4, 30, 0, 2)
+	assert.ErrorIs(t, err, job.ErrJobNotFound, "another company's job cannot be matched")
+
+	_, err = svc.MatchJob(context.Background(), 3, 30, 2, 2)
+	assert.ErrorIs(t, err, talentpool.ErrPoolNotFound)
+}
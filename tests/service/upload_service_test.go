@@ -0,0 +1,199 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/helpers"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+func TestUploadService_S3UploadAndDelete(t *testing.T) {
+	store := fakes.NewObjectStore()
+	svc := helpers.NewS3UploadService(store)
+	file := helpers.NewFileHeader(t, "logo.png", "image/png", []byte("png-bytes"))
+
+	url, err := svc.UploadFile(context.Background(), file, "companies/42/logo")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(url, "https://cdn.example.com/keerja/companies/42/logo/"))
+	assert.True(t, strings.HasSuffix(url, ".png"))
+
+	key := strings.TrimPrefix(url, "https://cdn.example.com/keerja/")
+	assert.Equal(t, []byte("png-bytes"), store.Objects[key])
+	assert.Equal(t, "image/png", store.ContentTypes[key])
+
+	require.NoError(t, svc.DeleteFile(context.Background(), url))
+	assert.Empty(t, store.Objects)
+}
+
+func TestUploadService_S3CopyFile(t *testing.T) {
+	store := fakes.NewObjectStore()
+	svc := helpers.NewS3UploadService(store)
+	ctx := context.Background()
+	store.Objects["documents/cv.pdf"] = []byte("resume")
+
+	url, err := svc.CopyFile(ctx, "https://cdn.example.com/keerja/documents/cv.pdf", "applications/9")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(url, "https://cdn.example.com/keerja/applications/9/"))
+	assert.True(t, strings.HasSuffix(url, ".pdf"))
+
+	key := strings.TrimPrefix(url, "https://cdn.example.com/keerja/")
+	assert.Equal(t, []byte("resume"), store.Objects[key])
+	assert.Equal(t, "application/pdf", store.ContentTypes[key])
+	assert.Equal(t, []byte("resume"), store.Objects["documents/cv.pdf"], "the original is left in place")
+
+	_, err = svc.CopyFile(ctx, "documents/missing.pdf", "applications/9")
+	assert.ErrorIs(t, err, service.ErrStoredFileNotFound)
+	_, err = svc.CopyFile(ctx, "https://elsewhere.example.com/cv.pdf", "applications/9")
+	assert.ErrorIs(t, err, service.ErrStoredFileNotFound)
+}
+
+func TestUploadService_S3RetriesTransientErrors(t *testing.T) {
+	store := fakes.NewObjectStore()
+	store.FailPuts = 2
+	svc := helpers.NewS3UploadService(store)
+	file := helpers.NewFileHeader(t, "cv.pdf", "application/pdf", []byte("resume"))
+
+	_, err := svc.UploadFile(context.Background(), file, "avatars")
+	require.NoError(t, err)
+	assert.Equal(t, 3, store.PutAttempts)
+	assert.Len(t, store.Objects, 1)
+}
+
+func TestUploadService_S3GivesUpAfterMaxRetries(t *testing.T) {
+	store := fakes.NewObjectStore()
+	store.FailPuts = 5
+	svc := helpers.NewS3UploadService(store)
+	file := helpers.NewFileHeader(t, "doc.pdf", "application/pdf", []byte("doc"))
+
+	_, err := svc.UploadFile(context.Background(), file, "company/documents")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, service.ErrStorageTransient))
+	assert.Equal(t, 3, store.PutAttempts)
+	assert.Empty(t, store.Objects)
+}
+
+func TestUploadService_LocalURLsUseConfiguredBaseURL(t *testing.T) {
+	root := t.TempDir()
+	svc := service.NewUploadService(service.UploadServiceConfig{
+		StorageProvider: "local",
+		UploadPath:      root,
+		BaseURL:         "https://api.staging.keerja.com/uploads/",
+	})
+	file := helpers.NewFileHeader(t, "avatar.png", "image/png", []byte("png-bytes"))
+
+	url, err := svc.UploadFile(context.Background(), file, "avatars")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(url, "https://api.staging.keerja.com/uploads/avatars/"))
+
+	for stored, want := range map[string]string{
+		"avatars/a.png":                                "https://api.staging.keerja.com/uploads/avatars/a.png",
+		"/uploads/avatars/a.png":                       "https://api.staging.keerja.com/uploads/avatars/a.png",
+		"http://localhost:8080/company/logos/b.png":    "https://api.staging.keerja.com/uploads/company/logos/b.png",
+		"https://api.staging.keerja.com/uploads/c.pdf": "https://api.staging.keerja.com/uploads/c.pdf",
+		"https://lh3.googleusercontent.com/photo.jpg":  "https://lh3.googleusercontent.com/photo.jpg",
+		"": "",
+	} {
+		assert.Equal(t, want, svc.ResolveFileURL(stored), stored)
+	}
+
+	require.NoError(t, svc.DeleteFile(context.Background(), url))
+	_, err = os.Stat(filepath.Join(root, strings.TrimPrefix(url, "https://api.staging.keerja.com/uploads/")))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestLocalUploadPath_RejectsTraversal(t *testing.T) {
+	for _, relativePath := range []string{"../../etc/passwd", "avatars/../../etc/passwd", "..", "", "/"} {
+		_, err := service.LocalUploadPath("/srv/uploads", relativePath)
+		assert.ErrorIs(t, err, service.ErrInvalidFilePath, relativePath)
+	}
+
+	path, err := service.LocalUploadPath("/srv/uploads", "avatars/a.png")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/srv/uploads", "avatars", "a.png"), path)
+}
+
+func TestUploadService_OpenFile(t *testing.T) {
+	ctx := context.Background()
+
+	store := fakes.NewObjectStore()
+	s3 := helpers.NewS3UploadService(store)
+	url, err := s3.UploadFile(ctx, helpers.NewFileHeader(t, "npwp.pdf", "application/pdf", []byte("npwp")), "documents/npwp")
+	require.NoError(t, err)
+
+	body, err := s3.OpenFile(ctx, url)
+	require.NoError(t, err)
+	data, err := io.ReadAll(body)
+	require.NoError(t, body.Close())
+	require.NoError(t, err)
+	assert.Equal(t, "npwp", string(data))
+
+	_, err = s3.OpenFile(ctx, "https://cdn.example.com/keerja/documents/npwp/missing.pdf")
+	assert.ErrorIs(t, err, service.ErrStoredFileNotFound)
+	_, err = s3.OpenFile(ctx, "https://elsewhere.example.com/npwp.pdf")
+	assert.ErrorIs(t, err, service.ErrStoredFileNotFound)
+
+	local := service.NewUploadService(service.UploadServiceConfig{
+		StorageProvider: "local",
+		UploadPath:      t.TempDir(),
+		BaseURL:         "https://api.keerja.com/uploads",
+	})
+	url, err = local.UploadFile(ctx, helpers.NewFileHeader(t, "cv.pdf", "application/pdf", []byte("cv")), "applications/documents")
+	require.NoError(t, err)
+
+	body, err = local.OpenFile(ctx, url)
+	require.NoError(t, err)
+	data, err = io.ReadAll(body)
+	require.NoError(t, body.Close())
+	require.NoError(t, err)
+	assert.Equal(t, "cv", string(data))
+
+	_, err = local.OpenFile(ctx, "applications/documents/missing.pdf")
+	assert.ErrorIs(t, err, service.ErrStoredFileNotFound)
+	_, err = local.OpenFile(ctx, "../../etc/passwd")
+	assert.ErrorIs(t, err, service.ErrInvalidFilePath)
+}
+
+func TestIsPublicUpload(t *testing.T) {
+	for relativePath, want := range map[string]bool{
+		"avatars/a.png":                 true,
+		"/covers/a_md.webp":             true,
+		"company/logos/a.png":           true,
+		"company/banners/a.png":         true,
+		"companies/7/logo/a.png":        true,
+		"companies/7/banner/a_sm.webp":  true,
+		"avatars":                       false,
+		"avatars/../documents/a.pdf":    false,
+		"company/documents/a.pdf":       false,
+		"companies/7/documents/a.pdf":   false,
+		"documents/cv.pdf":              false,
+		"applications/documents/cv.pdf": false,
+		"exports/a.csv":                 false,
+	} {
+		assert.Equal(t, want, service.IsPublicUpload(relativePath), relativePath)
+	}
+}
+
+func TestIsPrivateUpload(t *testing.T) {
+	for relativePath, want := range map[string]bool{
+		"company/documents/a.pdf":        true,
+		"documents/npwp/a.pdf":           true,
+		"/documents/nib/a.pdf":           true,
+		"applications/documents/cv.pdf":  true,
+		"avatars/../documents/nib/a.pdf": true,
+		"documents/cv.pdf":               true,
+		"company/logos/a.png":            false,
+		"documents-old/a.pdf":            false,
+	} {
+		assert.Equal(t, want, service.IsPrivateUpload(relativePath), relativePath)
+	}
+}
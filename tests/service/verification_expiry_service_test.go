@@ -0,0 +1,151 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/company"
+	"keerja-backend/internal/service"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+type expiryCompanyRepo struct {
+	company.CompanyRepository
+	verifications []*company.CompanyVerification
+	companies     map[int64]*company.Company
+	employers     []company.EmployerUser
+}
+
+func (r *expiryCompanyRepo) FindVerificationsExpiringWithin(ctx context.Context, days int) ([]company.CompanyVerification, error) {
+	limit := time.Now().AddDate(0, 0, days)
+	var result []company.CompanyVerification
+	for _, v := range r.verifications {
+		if v.Status != "verified" || v.VerificationExpiry.Before(time.Now()) || v.VerificationExpiry.After(limit) {
+			continue
+		}
+		if v.ExpiryWarningDays != nil && *v.ExpiryWarningDays <= days {
+			continue
+		}
+		copied := *v
+		copied.Company = r.companies[v.CompanyID]
+		result = append(result, copied)
+	}
+	return result, nil
+}
+
+func (r *expiryCompanyRepo) MarkVerificationExpiryWarned(ctx context.Context, verificationID int64, days int) (bool, error) {
+	for _, v := range r.verifications {
+		if v.ID == verificationID {
+			if v.ExpiryWarningDays != nil && *v.ExpiryWarningDays <= days {
+				return false, nil
+			}
+			v.ExpiryWarningDays = &days
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *expiryCompanyRepo) FindLapsedVerifications(ctx context.Context) ([]company.CompanyVerification, error) {
+	var result []company.CompanyVerification
+	for _, v := range r.verifications {
+		if v.Status == "verified" && v.VerificationExpiry.Before(time.Now()) {
+			copied := *v
+			copied.Company = r.companies[v.CompanyID]
+			result = append(result, copied)
+		}
+	}
+	return result, nil
+}
+
+func (r *expiryCompanyRepo) UpdateVerification(ctx context.Context, verification *company.CompanyVerification) error {
+	for i, v := range r.verifications {
+		if v.ID == verification.ID {
+			updated := *verification
+			r.verifications[i] = &updated
+		}
+	}
+	return nil
+}
+
+func (r *expiryCompanyRepo) Update(ctx context.Context, c *company.Company) error {
+	r.companies[c.ID] = c
+	return nil
+}
+
+func (r *expiryCompanyRepo) GetEmployerUsersByCompanyID(ctx context.Context, companyID int64) ([]company.EmployerUser, error) {
+	var result []company.EmployerUser
+	for _, e := range r.employers {
+		if e.CompanyID == companyID {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func daysFromNow(days int) *time.Time {
+	t := time.Now().AddDate(0, 0, days)
+	return &t
+}
+
+func newExpiryRepo(verifications ...*company.CompanyVerification) *expiryCompanyRepo {
+	return &expiryCompanyRepo{
+		verifications: verifications,
+		companies: map[int64]*company.Company{
+			1: {ID: 1, CompanyName: "Acme", Verified: true},
+		},
+		employers: []company.EmployerUser{
+			{CompanyID: 1, UserID: 10, Role: "owner", IsActive: true},
+			{CompanyID: 1, UserID: 11, Role: "admin", IsActive: true},
+			{CompanyID: 1, UserID: 12, Role: "recruiter", IsActive: true},
+		},
+	}
+}
+
+func TestCheckVerificationExpiry_WarnsOncePerThreshold(t *testing.T) {
+	repo := newExpiryRepo(&company.CompanyVerification{ID: 5, CompanyID: 1, Status: "verified", VerificationExpiry: daysFromNow(5)})
+	userRepo := &fakes.UserRepository{Users: fakes.NumberedUsers(10, 11, 12)}
+	emailSvc := &fakes.EmailService{}
+	svc := service.NewVerificationExpiryService(repo, userRepo, emailSvc, service.NewNotificationDispatcher(userRepo, &fakes.PushService{}, nil, nil, nil), 14*24*time.Hour)
+
+	stats, err := svc.CheckVerificationExpiry(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.WarningsSent)
+	assert.ElementsMatch(t, []string{"user10@test.id", "user11@test.id"}, emailSvc.Recipients())
+	assert.Equal(t, 7, *repo.verifications[0].ExpiryWarningDays)
+
+	// The 30-day threshold is already covered by the 7-day warning, and the 7-day one was sent
+	stats, err = svc.CheckVerificationExpiry(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, stats.WarningsSent)
+	assert.Len(t, emailSvc.Sent, 2)
+}
+
+func TestCheckVerificationExpiry_GracePeriodThenExpire(t *testing.T) {
+	repo := newExpiryRepo(&company.CompanyVerification{ID: 5, CompanyID: 1, Status: "verified", VerificationExpiry: daysFromNow(-2)})
+	pushSvc := &fakes.PushService{}
+	userRepo := &fakes.UserRepository{Users: fakes.NumberedUsers(10, 11, 12)}
+	svc := service.NewVerificationExpiryService(repo, userRepo, &fakes.EmailService{}, service.NewNotificationDispatcher(userRepo, pushSvc, nil, nil, nil), 14*24*time.Hour)
+
+	stats, err := svc.CheckVerificationExpiry(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.EnteredGrace)
+	assert.Zero(t, stats.Expired)
+	assert.True(t, repo.verifications[0].InGracePeriod())
+	assert.True(t, repo.companies[1].Verified)
+	assert.Empty(t, pushSvc.UserIDs)
+
+	// Once the grace period has ended the company loses its verification
+	repo.verifications[0].GraceUntil = daysFromNow(-1)
+	stats, err = svc.CheckVerificationExpiry(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Expired)
+	assert.Equal(t, "expired", repo.verifications[0].Status)
+	assert.True(t, repo.verifications[0].AutoExpired)
+	assert.False(t, repo.companies[1].Verified)
+	assert.ElementsMatch(t, []int64{10, 11}, pushSvc.UserIDs)
+}
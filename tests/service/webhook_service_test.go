@@ -0,0 +1,292 @@
+package service_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/webhook"
+	"keerja-backend/internal/service"
+)
+
+// fakeWebhookRepo keeps webhooks and deliveries in memory, queueing events and claiming due
+// deliveries the way the postgres repository does
+type fakeWebhookRepo struct {
+	webhook.WebhookRepository
+	webhooks   []*webhook.CompanyWebhook
+	deliveries []*webhook.WebhookDelivery
+}
+
+func (r *fakeWebhookRepo) Create(ctx context.Context, w *webhook.CompanyWebhook) error {
+	w.ID = int64(len(r.webhooks) + 1)
+	copied := *w
+	r.webhooks = append(r.webhooks, &copied)
+	return nil
+}
+
+func (r *fakeWebhookRepo) FindByID(ctx context.Context, id int64) (*webhook.CompanyWebhook, error) {
+	if id < 1 || int(id) > len(r.webhooks) {
+		return nil, nil
+	}
+	copied := *r.webhooks[id-1]
+	return &copied, nil
+}
+
+func (r *fakeWebhookRepo) CountByCompany(ctx context.Context, companyID int64) (int64, error) {
+	var count int64
+	for _, w := range r.webhooks {
+		if w.CompanyID == companyID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeWebhookRepo) EnqueueEvent(ctx context.Context, companyID int64, event, eventID, payload string, now time.Time) (int64, error) {
+	var queued int64
+	for _, w := range r.webhooks {
+		if w.CompanyID == companyID && w.Active && w.Subscribes(event) {
+			r.deliveries = append(r.deliveries, &webhook.WebhookDelivery{
+				ID:            int64(len(r.deliveries) + 1),
+				WebhookID:     w.ID,
+				CompanyID:     companyID,
+				EventID:       eventID,
+				Event:         event,
+				Payload:       payload,
+				Status:        webhook.DeliveryStatusPending,
+				NextAttemptAt: now,
+			})
+			queued++
+		}
+	}
+	return queued, nil
+}
+
+func (r *fakeWebhookRepo) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]webhook.WebhookDelivery, error) {
+	var due []webhook.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.Status == webhook.DeliveryStatusPending && !d.NextAttemptAt.After(now) &&
+			r.webhooks[d.WebhookID-1].Active && len(due) < limit {
+			d.Attempts++
+			d.NextAttemptAt = leaseUntil
+			due = append(due, *d)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeWebhookRepo) MarkDelivered(ctx context.Context, id int64, responseCode int, deliveredAt time.Time) error {
+	d := r.deliveries[id-1]
+	d.Status = webhook.DeliveryStatusDelivered
+	d.ResponseCode = &responseCode
+	d.DeliveredAt = &deliveredAt
+	return nil
+}
+
+func (r *fakeWebhookRepo) MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, responseCode *int, lastError string) error {
+	d := r.deliveries[id-1]
+	d.NextAttemptAt = nextAttemptAt
+	d.ResponseCode = responseCode
+	d.LastError = &lastError
+	return nil
+}
+
+func (r *fakeWebhookRepo) MarkFailed(ctx context.Context, id int64, responseCode *int, lastError string) error {
+	d := r.deliveries[id-1]
+	d.Status = webhook.DeliveryStatusFailed
+	d.ResponseCode = responseCode
+	d.LastError = &lastError
+	return nil
+}
+
+func (r *fakeWebhookRepo) RecordSuccess(ctx context.Context, webhookID int64) error {
+	w := r.webhooks[webhookID-1]
+	w.FailureCount = 0
+	w.FailingSince = nil
+	return nil
+}
+
+func (r *fakeWebhookRepo) RecordFailure(ctx context.Context, webhookID int64, now time.Time) (*webhook.CompanyWebhook, error) {
+	w := r.webhooks[webhookID-1]
+	w.FailureCount++
+	if w.FailingSince == nil {
+		w.FailingSince = &now
+	}
+	copied := *w
+	return &copied, nil
+}
+
+func (r *fakeWebhookRepo) Disable(ctx context.Context, webhookID int64, now time.Time) (bool, error) {
+	w := r.webhooks[webhookID-1]
+	if !w.Active {
+		return false, nil
+	}
+	w.Active = false
+	w.DisabledAt = &now
+	for _, d := range r.deliveries {
+		if d.WebhookID == webhookID && d.Status == webhook.DeliveryStatusPending {
+			d.Status = webhook.DeliveryStatusFailed
+		}
+	}
+	return true, nil
+}
+
+// dueAll makes every pending delivery due again
+func (r *fakeWebhookRepo) dueAll() {
+	for _, d := range r.deliveries {
+		d.NextAttemptAt = time.Time{}
+	}
+}
+
+// receivedWebhook is a request captured by the test endpoint
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+// newWebhookEndpoint starts an endpoint that answers every request with status
+func newWebhookEndpoint(t *testing.T, status int) (*httptest.Server, *[]receivedWebhook) {
+	var received []receivedWebhook
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, receivedWebhook{header: r.Header.Clone(), body: body})
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &received
+}
+
+func TestWebhook_DeliversSignedEvent(t *testing.T) {
+	server, received := newWebhookEndpoint(t, http.StatusNoContent)
+	repo := &fakeWebhookRepo{}
+	svc := service.NewWebhookService(repo, nil, nil, nil, nil, service.WebhookConfig{AllowPrivateURLs: true})
+	ctx := context.Background()
+
+	w, err := svc.CreateWebhook(ctx, 7, 1, server.URL, []string{webhook.EventApplicationCreated})
+	require.NoError(t, err)
+
+	svc.Publish(ctx, 7, webhook.EventApplicationCreated, webhook.ApplicationEventData{ApplicationID: 42, JobID: 3, Status: "applied"})
+	svc.Publish(ctx, 7, webhook.EventJobPublished, webhook.JobEventData{JobID: 3})
+	require.Len(t, repo.deliveries, 1, "only subscribed events are queued")
+
+	delivered, err := svc.ProcessDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, webhook.DeliveryStatusDelivered, repo.deliveries[0].Status)
+
+	require.Len(t, *received, 1)
+	req := (*received)[0]
+	mac := hmac.New(sha256.New, []byte(w.Secret))
+	mac.Write(req.body)
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), req.header.Get(webhook.SignatureHeader))
+	assert.True(t, webhook.VerifySignature(w.Secret, req.body, req.header.Get(webhook.SignatureHeader)))
+	assert.Equal(t, webhook.EventApplicationCreated, req.header.Get(webhook.EventHeader))
+	assert.Equal(t, repo.deliveries[0].EventID, req.header.Get(webhook.EventIDHeader))
+
+	var envelope struct {
+		ID    string                       `json:"id"`
+		Event string                       `json:"event"`
+		Data  webhook.ApplicationEventData `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(req.body, &envelope))
+	assert.Equal(t, repo.deliveries[0].EventID, envelope.ID)
+	assert.Equal(t, int64(42), envelope.Data.ApplicationID)
+}
+
+func TestWebhook_BacksOffExponentiallyThenFails(t *testing.T) {
+	server, received := newWebhookEndpoint(t, http.StatusInternalServerError)
+	repo := &fakeWebhookRepo{}
+	svc := service.NewWebhookService(repo, nil, nil, nil, nil, service.WebhookConfig{
+		MaxAttempts:      4,
+		BaseBackoff:      time.Minute,
+		MaxBackoff:       3 * time.Minute,
+		AllowPrivateURLs: true,
+	})
+	ctx := context.Background()
+
+	_, err := svc.CreateWebhook(ctx, 7, 1, server.URL, []string{webhook.EventJobExpired})
+	require.NoError(t, err)
+	svc.Publish(ctx, 7, webhook.EventJobExpired, webhook.JobEventData{JobID: 3})
+
+	// 1m after the first failure, 2m after the second, then capped at 3m
+	for _, want := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute} {
+		before := time.Now()
+		delivered, err := svc.ProcessDue(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, delivered)
+
+		d := repo.deliveries[0]
+		require.Equal(t, webhook.DeliveryStatusPending, d.Status)
+		assert.WithinDuration(t, before.Add(want), d.NextAttemptAt, 5*time.Second)
+		require.NotNil(t, d.ResponseCode)
+		assert.Equal(t, http.StatusInternalServerError, *d.ResponseCode)
+		repo.dueAll()
+	}
+
+	_, err = svc.ProcessDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, webhook.DeliveryStatusFailed, repo.deliveries[0].Status)
+	assert.Equal(t, 4, repo.deliveries[0].Attempts)
+	assert.Len(t, *received, 4)
+
+	// Failed deliveries are no longer picked up
+	repo.dueAll()
+	_, err = svc.ProcessDue(ctx)
+	require.NoError(t, err)
+	assert.Len(t, *received, 4)
+}
+
+func TestWebhook_DisablesEndpointAfterSustainedFailures(t *testing.T) {
+	server, _ := newWebhookEndpoint(t, http.StatusBadGateway)
+	repo := &fakeWebhookRepo{}
+	svc := service.NewWebhookService(repo, nil, nil, nil, nil, service.WebhookConfig{AllowPrivateURLs: true})
+	ctx := context.Background()
+
+	_, err := svc.CreateWebhook(ctx, 7, 1, server.URL, []string{webhook.EventJobPublished})
+	require.NoError(t, err)
+	svc.Publish(ctx, 7, webhook.EventJobPublished, webhook.JobEventData{JobID: 3})
+	svc.Publish(ctx, 7, webhook.EventJobPublished, webhook.JobEventData{JobID: 4})
+
+	// One failure short of the threshold, failing for longer than the breaker period
+	since := time.Now().Add(-webhook.BreakerFailingPeriod - time.Hour)
+	repo.webhooks[0].FailureCount = webhook.BreakerFailureThreshold - 2
+	repo.webhooks[0].FailingSince = &since
+
+	_, err = svc.ProcessDue(ctx)
+	require.NoError(t, err)
+
+	assert.False(t, repo.webhooks[0].Active)
+	assert.NotNil(t, repo.webhooks[0].DisabledAt)
+	for _, d := range repo.deliveries {
+		assert.Equal(t, webhook.DeliveryStatusFailed, d.Status)
+	}
+
+	// Disabled endpoints are no longer called
+	svc.Publish(ctx, 7, webhook.EventJobPublished, webhook.JobEventData{JobID: 5})
+	assert.Len(t, repo.deliveries, 2)
+}
+
+func TestWebhook_RejectsPrivateURLs(t *testing.T) {
+	svc := service.NewWebhookService(&fakeWebhookRepo{}, nil, nil, nil, nil, service.WebhookConfig{})
+
+	for _, url := range []string{
+		"http://hooks.example.com/keerja",
+		"https://127.0.0.1/hook",
+		"https://localhost/hook",
+		"https://10.0.0.8/hook",
+		"https://[::1]/hook",
+	} {
+		_, err := svc.CreateWebhook(context.Background(), 7, 1, url, []string{webhook.EventJobPublished})
+		assert.ErrorIs(t, err, webhook.ErrInvalidWebhookURL, url)
+	}
+}
@@ -0,0 +1,214 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/notification"
+	"keerja-backend/internal/domain/user"
+	"keerja-backend/internal/service"
+	"keerja-backend/internal/utils"
+	"keerja-backend/tests/mocks/fakes"
+)
+
+func newCloudWhatsApp(t *testing.T, handler http.HandlerFunc) notification.WhatsAppProvider {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	provider, err := service.NewWhatsAppProvider(service.WhatsAppConfig{
+		Provider:      service.WhatsAppProviderCloud,
+		BaseURL:       srv.URL + "/v21.0",
+		Token:         "secret-token",
+		PhoneNumberID: "1098765",
+		Templates:     map[string]string{notification.WhatsAppTemplateInterviewScheduled: "keerja_interview_v2"},
+	})
+	require.NoError(t, err)
+	return provider
+}
+
+func TestCloudWhatsApp_SendsTemplateWithNamedParameters(t *testing.T) {
+	provider := newCloudWhatsApp(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v21.0/1098765/messages", r.URL.Path)
+		assert.Equal(t, "Bearer secret-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"messaging_product": "whatsapp",
+			"recipient_type": "individual",
+			"to": "6281234567890",
+			"type": "template",
+			"template": {
+				"name": "keerja_interview_v2",
+				"language": {"code": "id"},
+				"components": [{
+					"type": "body",
+					"parameters": [
+						{"type": "text", "parameter_name": "candidate_name", "text": "Budi"},
+						{"type": "text", "parameter_name": "interview_date", "text": "12 Maret 2026"},
+						{"type": "text", "parameter_name": "job_title", "text": "Backend Engineer"}
+					]
+				}]
+			}
+		}`, string(body))
+
+		w.Write([]byte(`{"messaging_product":"whatsapp","contacts":[{"input":"6281234567890","wa_id":"6281234567890"}],"messages":[{"id":"wamid.HBgN"}]}`))
+	})
+
+	id, err := provider.SendTemplate(context.Background(), "+6281234567890", &notification.WhatsAppMessage{
+		Template: notification.WhatsAppTemplateInterviewScheduled,
+		Params: map[string]string{
+			notification.WhatsAppParamJobTitle:      "Backend Engineer",
+			notification.WhatsAppParamCandidateName: "Budi",
+			notification.WhatsAppParamInterviewDate: "12 Maret 2026",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "wamid.HBgN", id)
+}
+
+func TestCloudWhatsApp_ReportsRejectedTemplates(t *testing.T) {
+	var status, body string
+	provider := newCloudWhatsApp(t, func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Template struct {
+				Name string `json:"name"`
+			} `json:"template"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, notification.WhatsAppTemplateOfferMade, payload.Template.Name, "unmapped templates are sent under their key")
+
+		w.WriteHeader(map[string]int{"bad": http.StatusBadRequest, "auth": http.StatusUnauthorized}[status])
+		w.Write([]byte(body))
+	})
+	message := &notification.WhatsAppMessage{Template: notification.WhatsAppTemplateOfferMade}
+
+	status, body = "bad", `{"error":{"message":"(#132001) Template name does not exist in the translation","type":"OAuthException","code":132001,"fbtrace_id":"A1"}}`
+	_, err := provider.SendTemplate(context.Background(), "+6281234567890", message)
+	assert.ErrorIs(t, err, notification.ErrWhatsAppTemplateRejected)
+
+	status, body = "auth", `{"error":{"message":"Error validating access token","type":"OAuthException","code":190,"fbtrace_id":"A2"}}`
+	_, err = provider.SendTemplate(context.Background(), "+6281234567890", message)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, notification.ErrWhatsAppTemplateRejected)
+	assert.Contains(t, err.Error(), "Error validating access token")
+}
+
+func TestNewWhatsAppProvider_SelectsProvider(t *testing.T) {
+	provider, err := service.NewWhatsAppProvider(service.WhatsAppConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, provider, "WhatsApp is off unless configured")
+
+	_, err = service.NewWhatsAppProvider(service.WhatsAppConfig{Provider: service.WhatsAppProviderCloud, Token: "secret-token"})
+	assert.Error(t, err, "the cloud provider needs a phone number ID")
+
+	_, err = service.NewWhatsAppProvider(service.WhatsAppConfig{Provider: "twilio"})
+	assert.Error(t, err)
+
+	provider, err = service.NewWhatsAppProvider(service.WhatsAppConfig{Provider: service.WhatsAppProviderLog})
+	require.NoError(t, err)
+	_, err = provider.SendTemplate(context.Background(), "+6281234567890", &notification.WhatsAppMessage{Template: notification.WhatsAppTemplateOfferMade})
+	assert.NoError(t, err)
+}
+
+// fakeWhatsAppProvider records the messages sent and fails with err when it is set
+type fakeWhatsAppProvider struct {
+	sent []string
+	err  error
+}
+
+func (p *fakeWhatsAppProvider) SendTemplate(ctx context.Context, to string, message *notification.WhatsAppMessage) (string, error) {
+	if p.err != nil {
+		return "", p.err
+	}
+	p.sent = append(p.sent, to+" "+message.Template)
+	return "wamid.1", nil
+}
+
+type memoryWhatsAppDeliveryRepo struct {
+	notification.WhatsAppDeliveryRepository
+	deliveries []notification.WhatsAppDelivery
+}
+
+func (r *memoryWhatsAppDeliveryRepo) Create(ctx context.Context, delivery *notification.WhatsAppDelivery) error {
+	r.deliveries = append(r.deliveries, *delivery)
+	return nil
+}
+
+// newWhatsAppUserRepo gives users 2 and 3 a verified phone, user 4 an unverified one and
+// user 5 none
+func newWhatsAppUserRepo() *fakes.UserRepository {
+	verifiedAt := time.Now()
+	users := fakes.NumberedUsers(2, 3, 4, 5)
+	users[2].Phone, users[2].PhoneVerifiedAt = utils.StringPtr("+6281200000002"), &verifiedAt
+	users[3].Phone, users[3].PhoneVerifiedAt = utils.StringPtr("+6281200000003"), &verifiedAt
+	users[4].Phone = utils.StringPtr("+6281200000004")
+	return &fakes.UserRepository{Users: users}
+}
+
+func TestNotificationDispatcher_WhatsAppNeedsVerifiedPhoneAndConsent(t *testing.T) {
+	userRepo := newWhatsAppUserRepo()
+	userRepo.Preferences = map[int64]*user.UserPreference{
+		3: {UserID: 3, EmailNotifications: true, PushNotifications: true, NotificationMatrix: user.NotificationMatrix{
+			user.NotificationEventInterview: {Email: true, Push: true, WhatsApp: false},
+		}},
+	}
+	provider := &fakeWhatsAppProvider{}
+	deliveries := &memoryWhatsAppDeliveryRepo{}
+	dispatcher := service.NewNotificationDispatcher(userRepo, nil, nil, provider, deliveries)
+	ctx := context.Background()
+	message := &notification.WhatsAppMessage{Template: notification.WhatsAppTemplateInterviewScheduled}
+
+	// User 2 has a verified phone and the default preferences
+	assert.True(t, dispatcher.WhatsApp(ctx, 2, user.NotificationEventInterview, message))
+	// User 3 turned WhatsApp off for interviews, user 4 has not verified their phone and
+	// user 5 has no phone
+	assert.False(t, dispatcher.WhatsApp(ctx, 3, user.NotificationEventInterview, message))
+	assert.False(t, dispatcher.WhatsApp(ctx, 4, user.NotificationEventInterview, message))
+	assert.False(t, dispatcher.WhatsApp(ctx, 5, user.NotificationEventInterview, message))
+	// Only the high-value events go out by WhatsApp
+	assert.False(t, dispatcher.WhatsApp(ctx, 2, user.NotificationEventJobAlerts, message))
+
+	assert.Equal(t, []string{"+6281200000002 interview_scheduled"}, provider.sent)
+	require.Len(t, deliveries.deliveries, 1)
+	delivered := deliveries.deliveries[0]
+	assert.Equal(t, int64(2), delivered.UserID)
+	assert.Equal(t, string(user.NotificationEventInterview), delivered.Event)
+	assert.Equal(t, notification.WhatsAppDeliverySent, delivered.Status)
+	assert.Equal(t, "wamid.1", *delivered.ProviderMessageID)
+
+	// Without a provider WhatsApp is skipped silently
+	assert.False(t, service.NewNotificationDispatcher(userRepo, nil, nil, nil, nil).WhatsApp(ctx, 2, user.NotificationEventInterview, message))
+}
+
+func TestNotificationDispatcher_RecordsWhatsAppFailures(t *testing.T) {
+	userRepo := newWhatsAppUserRepo()
+	provider := &fakeWhatsAppProvider{}
+	deliveries := &memoryWhatsAppDeliveryRepo{}
+	dispatcher := service.NewNotificationDispatcher(userRepo, nil, nil, provider, deliveries)
+	ctx := context.Background()
+	message := &notification.WhatsAppMessage{Template: notification.WhatsAppTemplateOfferMade}
+
+	provider.err = errors.Join(notification.ErrWhatsAppTemplateRejected, errors.New("template paused"))
+	assert.False(t, dispatcher.WhatsApp(ctx, 2, user.NotificationEventApplicationStatus, message))
+	provider.err = errors.New("connection reset")
+	assert.False(t, dispatcher.WhatsApp(ctx, 2, user.NotificationEventApplicationStatus, message))
+
+	require.Len(t, deliveries.deliveries, 2)
+	assert.Equal(t, notification.WhatsAppDeliveryTemplateRejected, deliveries.deliveries[0].Status)
+	assert.Equal(t, notification.WhatsAppDeliveryFailed, deliveries.deliveries[1].Status)
+	assert.Equal(t, "connection reset", *deliveries.deliveries[1].Error)
+	assert.Nil(t, deliveries.deliveries[1].ProviderMessageID)
+}
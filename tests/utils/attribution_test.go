@@ -0,0 +1,25 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"keerja-backend/internal/utils"
+)
+
+func TestNormalizeUTMParam(t *testing.T) {
+	assert.Equal(t, "linkedin", utils.NormalizeUTMParam("  LinkedIn "))
+	assert.Equal(t, "spring_hiring-2025", utils.NormalizeUTMParam("Spring Hiring-2025"))
+	assert.Equal(t, "", utils.NormalizeUTMParam(""))
+	assert.Equal(t, "", utils.NormalizeUTMParam("<script>alert(1)</script>"))
+	assert.Equal(t, "", utils.NormalizeUTMParam(strings.Repeat("a", utils.MaxUTMParamLength+1)))
+}
+
+func TestNormalizeReferrer(t *testing.T) {
+	assert.Equal(t, "https://www.google.com/search", utils.NormalizeReferrer("https://user:pw@www.google.com/search?q=keerja#results"))
+	assert.Equal(t, "", utils.NormalizeReferrer("javascript:alert(1)"))
+	assert.Equal(t, "", utils.NormalizeReferrer("/jobs/10"))
+	assert.Equal(t, "", utils.NormalizeReferrer("https://example.com/"+strings.Repeat("a", utils.MaxReferrerLength)))
+}
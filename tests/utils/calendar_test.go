@@ -0,0 +1,86 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/utils"
+)
+
+// parseICS unfolds continuation lines and returns the property values keyed by name
+// (parameters stripped), failing if any line breaks the CRLF or 75-octet rules
+func parseICS(t *testing.T, data []byte) map[string][]string {
+	t.Helper()
+
+	raw := string(data)
+	require.True(t, strings.HasSuffix(raw, "\r\n"))
+	for _, line := range strings.Split(strings.TrimSuffix(raw, "\r\n"), "\r\n") {
+		require.LessOrEqual(t, len(line), 75, "line too long: %q", line)
+	}
+
+	unfolded := strings.ReplaceAll(raw, "\r\n ", "")
+	props := make(map[string][]string)
+	for _, line := range strings.Split(strings.TrimSuffix(unfolded, "\r\n"), "\r\n") {
+		name, value, ok := strings.Cut(line, ":")
+		require.True(t, ok, "malformed line: %q", line)
+		name, _, _ = strings.Cut(name, ";")
+		props[name] = append(props[name], value)
+	}
+	return props
+}
+
+func TestGenerateICS_RoundTripsStartTimeInUTC(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+	start := time.Date(2030, time.March, 5, 14, 30, 0, 0, jakarta)
+
+	ics := utils.GenerateICS(utils.CalendarEvent{
+		UID:         "interview-42@keerja.com",
+		Sequence:    2,
+		Method:      utils.CalendarMethodRequest,
+		Summary:     "Interview: Backend Engineer, Platform; Acme",
+		Description: strings.Repeat("Bring your portfolio. ", 10),
+		Location:    "https://meet.example.com/abc",
+		URL:         "https://meet.example.com/abc",
+		Start:       start,
+		End:         start.Add(time.Hour),
+		Attendee:    "seeker@example.com",
+	})
+
+	props := parseICS(t, ics)
+	assert.Equal(t, []string{"VCALENDAR", "VEVENT"}, props["BEGIN"])
+	assert.Equal(t, []string{"VEVENT", "VCALENDAR"}, props["END"])
+	assert.Equal(t, []string{"REQUEST"}, props["METHOD"])
+	assert.Equal(t, []string{"interview-42@keerja.com"}, props["UID"])
+	assert.Equal(t, []string{"2"}, props["SEQUENCE"])
+	assert.Equal(t, []string{"CONFIRMED"}, props["STATUS"])
+	assert.Equal(t, []string{`Interview: Backend Engineer\, Platform\; Acme`}, props["SUMMARY"])
+	assert.Equal(t, []string{"mailto:seeker@example.com"}, props["ATTENDEE"])
+
+	require.Len(t, props["DTSTART"], 1)
+	assert.Equal(t, "20300305T073000Z", props["DTSTART"][0])
+	parsed, err := utils.ParseICSTime(props["DTSTART"][0])
+	require.NoError(t, err)
+	assert.True(t, parsed.Equal(start), "expected %s, got %s", start, parsed)
+
+	parsedEnd, err := utils.ParseICSTime(props["DTEND"][0])
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, parsedEnd.Sub(parsed))
+}
+
+func TestGenerateICS_CancelMarksEventCancelled(t *testing.T) {
+	ics := utils.GenerateICS(utils.CalendarEvent{
+		UID:    "interview-42@keerja.com",
+		Method: utils.CalendarMethodCancel,
+		Start:  time.Date(2030, time.March, 5, 7, 30, 0, 0, time.UTC),
+	})
+
+	props := parseICS(t, ics)
+	assert.Equal(t, []string{"CANCEL"}, props["METHOD"])
+	assert.Equal(t, []string{"CANCELLED"}, props["STATUS"])
+	assert.Equal(t, []string{"interview-42@keerja.com"}, props["UID"])
+}
@@ -0,0 +1,35 @@
+package utils_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/utils"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	createdAt := time.Date(2026, 3, 14, 9, 26, 53, 589793000, time.UTC)
+
+	cursor, err := utils.DecodeCursor(utils.EncodeCursor(createdAt, 42))
+	require.NoError(t, err)
+	require.NotNil(t, cursor)
+	assert.True(t, createdAt.Equal(cursor.CreatedAt))
+	assert.Equal(t, int64(42), cursor.ID)
+}
+
+func TestDecodeCursor_EmptyMeansFirstPage(t *testing.T) {
+	cursor, err := utils.DecodeCursor("")
+	require.NoError(t, err)
+	assert.Nil(t, cursor)
+}
+
+func TestDecodeCursor_RejectsGarbage(t *testing.T) {
+	for _, input := range []string{"%%%", "bm90LWEtY3Vyc29y", "MTIzOmFiYw", "MTIzOjA"} {
+		_, err := utils.DecodeCursor(input)
+		assert.True(t, errors.Is(err, utils.ErrInvalidCursor), input)
+	}
+}
@@ -0,0 +1,108 @@
+package utils_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/middleware"
+	"keerja-backend/internal/utils"
+)
+
+type pageBody struct {
+	Page    int               `json:"page"`
+	Limit   int               `json:"limit"`
+	Code    string            `json:"code"`
+	Details map[string]string `json:"details"`
+}
+
+// servePage parses the pagination of a request to target with q and returns the status
+// and either the parsed values or the error body
+func servePage(t *testing.T, q utils.PageQuery, target string) (int, pageBody) {
+	t.Helper()
+
+	app := fiber.New()
+	app.Use(middleware.ErrorHandler(false))
+	app.Get("/", func(c *fiber.Ctx) error {
+		page, limit, err := q.Parse(c)
+		if err != nil {
+			return err
+		}
+		return c.JSON(fiber.Map{"page": page, "limit": limit})
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, target, nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var body pageBody
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return resp.StatusCode, body
+}
+
+func TestPageQuery_DefaultsAndClamps(t *testing.T) {
+	q := utils.PageQuery{DefaultLimit: 20}
+
+	for target, want := range map[string][2]int{
+		"/":                            {1, 20},
+		"/?page=3&limit=15":            {3, 15},
+		"/?page=0&limit=0":             {1, 20},
+		"/?page=-4&limit=-1":           {1, 20},
+		"/?page=+2&limit=%2025%20":     {2, 25},
+		"/?limit=5000":                 {1, utils.MaxPageLimit},
+		"/?page=99999999999999999999":  {int(^uint(0) >> 1), 20},
+		"/?page=-99999999999999999999": {1, 20},
+		"/?limit=99999999999999999999": {1, utils.MaxPageLimit},
+		"/?page=&limit=":               {1, 20},
+	} {
+		status, body := servePage(t, q, target)
+		require.Equal(t, fiber.StatusOK, status, target)
+		assert.Equal(t, want, [2]int{body.Page, body.Limit}, target)
+	}
+}
+
+func TestPageQuery_PerEndpointBounds(t *testing.T) {
+	_, body := servePage(t, utils.PageQuery{MaxLimit: 50}, "/?limit=80")
+	assert.Equal(t, 50, body.Limit)
+
+	_, body = servePage(t, utils.PageQuery{}, "/")
+	assert.Equal(t, utils.DefaultPageLimit, body.Limit)
+
+	// Endpoints with their own parameter names ignore page and limit
+	q := utils.PageQuery{PageParam: "skills_page", LimitParam: "skills_limit", DefaultLimit: 50}
+	_, body = servePage(t, q, "/?page=7&limit=3&skills_page=2&skills_limit=500")
+	assert.Equal(t, [2]int{2, utils.MaxPageLimit}, [2]int{body.Page, body.Limit})
+}
+
+func TestPageQuery_RejectsNonNumericValues(t *testing.T) {
+	for target, param := range map[string]string{
+		"/?page=two":          "page",
+		"/?limit=10.5":        "limit",
+		"/?page=1&limit=1e3":  "limit",
+		"/?page=0x10":         "page",
+		"/?skills_page=first": "skills_page",
+	} {
+		q := utils.PageQuery{}
+		if param == "skills_page" {
+			q.PageParam = param
+		}
+
+		status, body := servePage(t, q, target)
+		assert.Equal(t, fiber.StatusBadRequest, status, target)
+		assert.Equal(t, utils.ErrInvalidPagination.Code, body.Code, target)
+		assert.Equal(t, "must be a whole number", body.Details[param], target)
+	}
+}
+
+func TestGetPaginationMeta_EchoesBounds(t *testing.T) {
+	meta := utils.GetPaginationMeta(2, 25, 51)
+
+	assert.Equal(t, 2, meta["page"])
+	assert.Equal(t, 25, meta["limit"])
+	assert.Equal(t, int64(51), meta["total"])
+	assert.Equal(t, 3, meta["total_pages"])
+}
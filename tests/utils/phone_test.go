@@ -0,0 +1,70 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"keerja-backend/internal/utils"
+)
+
+func TestNormalizePhone_IndonesianFormats(t *testing.T) {
+	for _, raw := range []string{
+		"081234567890",
+		"0812-3456-7890",
+		"0812 3456 7890",
+		"(0812) 3456.7890",
+		"+6281234567890",
+		"+62 812-3456-7890",
+		"+62 0812 3456 7890",
+		"6281234567890",
+		"62 812-3456-7890",
+		"006281234567890",
+		"81234567890",
+		" 0812 3456 7890 ",
+	} {
+		phone, err := utils.NormalizePhone(raw)
+		if assert.NoError(t, err, raw) {
+			assert.Equal(t, "+6281234567890", phone, raw)
+		}
+	}
+
+	// Landlines keep their area code
+	phone, err := utils.NormalizePhone("(021) 555-1234")
+	assert.NoError(t, err)
+	assert.Equal(t, "+62215551234", phone)
+
+	// Other countries need an international prefix
+	phone, err = utils.NormalizePhone("+65 6123 4567")
+	assert.NoError(t, err)
+	assert.Equal(t, "+6561234567", phone)
+}
+
+func TestNormalizePhone_RejectsInvalidNumbers(t *testing.T) {
+	for _, raw := range []string{
+		"",
+		"   ",
+		"0812",
+		"08123456789012345",
+		"0812-3456-789O",
+		"phone: 0812 3456 7890",
+		"+62 0 1234 5678",
+		"+0 123 4567 890",
+		"+1 23",
+	} {
+		_, err := utils.NormalizePhone(raw)
+		assert.ErrorIs(t, err, utils.ErrInvalidPhone, raw)
+	}
+}
+
+func TestNormalizePhonePtr_BlankIsNil(t *testing.T) {
+	blank := "  "
+	phone, err := utils.NormalizePhonePtr(&blank)
+	assert.NoError(t, err)
+	assert.Nil(t, phone)
+
+	raw := "0812 3456 7890"
+	phone, err = utils.NormalizePhonePtr(&raw)
+	assert.NoError(t, err)
+	assert.Equal(t, "+6281234567890", *phone)
+}
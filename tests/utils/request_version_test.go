@@ -0,0 +1,65 @@
+package utils_test
+
+import (
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/utils"
+)
+
+// requestVersion returns the version RequestVersion reads from a request with the
+// If-Match header and body version, -1 when there is none and 0 when it is invalid
+func requestVersion(t *testing.T, ifMatch string, body *int64) int64 {
+	t.Helper()
+
+	app := fiber.New()
+	app.Put("/", func(c *fiber.Ctx) error {
+		version, err := utils.RequestVersion(c, body)
+		switch {
+		case err != nil:
+			assert.ErrorIs(t, err, utils.ErrInvalidIfMatch)
+			return c.SendString("0")
+		case version == nil:
+			return c.SendString("-1")
+		}
+		return c.SendString(strconv.FormatInt(*version, 10))
+	})
+
+	req := httptest.NewRequest(fiber.MethodPut, "/", nil)
+	if ifMatch != "" {
+		req.Header.Set(fiber.HeaderIfMatch, ifMatch)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var out [20]byte
+	n, _ := resp.Body.Read(out[:])
+	version, err := strconv.ParseInt(string(out[:n]), 10, 64)
+	require.NoError(t, err)
+	return version
+}
+
+func TestRequestVersion(t *testing.T) {
+	for ifMatch, want := range map[string]int64{
+		"":       -1,
+		"3":      3,
+		`"3"`:    3,
+		`W/"12"`: 12,
+		" 4 ":    4,
+		"0":      0,
+		"-2":     0,
+		`"abc"`:  0,
+		"*":      0,
+	} {
+		assert.Equal(t, want, requestVersion(t, ifMatch, nil), "If-Match %q", ifMatch)
+	}
+
+	// The body version wins over the header
+	assert.Equal(t, int64(7), requestVersion(t, "3", utils.Int64Ptr(7)))
+}
@@ -0,0 +1,24 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"keerja-backend/internal/utils"
+)
+
+func TestSanitizeBasicHTML_KeepsFormattingWithoutAttributes(t *testing.T) {
+	in := `<div class="x"><h2 style="color:red">Role</h2><p onclick="x()">Build &amp; ship</p><img src=x onerror=alert(1)><br/><a href="javascript:x">link</a></div>`
+	assert.Equal(t, "<h2>Role</h2><p>Build &amp; ship</p><br>link", utils.SanitizeBasicHTML(in))
+}
+
+func TestSanitizeBasicHTML_DropsScriptAndStyleContent(t *testing.T) {
+	in := "<p>Hi</p><script>alert('<p>x</p>')</script><style>p{}</style><ul><li>Go</li></ul>"
+	assert.Equal(t, "<p>Hi</p><ul><li>Go</li></ul>", utils.SanitizeBasicHTML(in))
+}
+
+func TestSanitizeBasicHTML_WrapsPlainTextParagraphs(t *testing.T) {
+	in := "We are hiring.\r\n\r\nYou will:\n- write Go\n- ship & operate"
+	assert.Equal(t, "<p>We are hiring.</p><p>You will:<br>- write Go<br>- ship &amp; operate</p>", utils.SanitizeBasicHTML(in))
+}
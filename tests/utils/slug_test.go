@@ -0,0 +1,58 @@
+package utils_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"keerja-backend/internal/domain/job"
+	"keerja-backend/internal/utils"
+)
+
+// collidingSlugRepo reports the first `collisions` candidates as taken, as if they
+// belonged to live or soft-deleted jobs
+type collidingSlugRepo struct {
+	job.JobRepository
+
+	collisions int
+	checked    []string
+}
+
+func (r *collidingSlugRepo) SlugExists(ctx context.Context, slug string, excludeID int64) (bool, error) {
+	r.checked = append(r.checked, slug)
+	return len(r.checked) <= r.collisions, nil
+}
+
+func TestGenerateUniqueSlug_SkipsCollidingCandidates(t *testing.T) {
+	repo := &collidingSlugRepo{collisions: 3}
+
+	slug, err := utils.GenerateUniqueSlug(context.Background(), "Backend Engineer", func(ctx context.Context, candidate string) (bool, error) {
+		return repo.SlugExists(ctx, candidate, 0)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "backend-engineer-4", slug)
+	assert.Equal(t, []string{"backend-engineer", "backend-engineer-2", "backend-engineer-3", "backend-engineer-4"}, repo.checked)
+}
+
+func TestGenerateUniqueSlug_FreeBaseSlug(t *testing.T) {
+	repo := &collidingSlugRepo{}
+
+	slug, err := utils.GenerateUniqueSlug(context.Background(), "PT Maju Jaya", func(ctx context.Context, candidate string) (bool, error) {
+		return repo.SlugExists(ctx, candidate, 0)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pt-maju-jaya", slug)
+}
+
+func TestGenerateUniqueSlug_PropagatesLookupError(t *testing.T) {
+	lookupErr := errors.New("connection reset")
+
+	_, err := utils.GenerateUniqueSlug(context.Background(), "Designer", func(ctx context.Context, candidate string) (bool, error) {
+		return false, lookupErr
+	})
+	assert.ErrorIs(t, err, lookupErr)
+}